@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/server"
+)
+
+// 終了コード
+const (
+	exitOK       = 0
+	exitFailure  = 1
+	exitUsage    = 2 // 引数・フラグの誤り
+	exitConflict = 3 // 作成しようとしたものがすでにある
+)
+
+// サブコマンドが実行する処理（テストでは差し替える）
+type operations struct {
+	serve       func(ctx context.Context) error
+	migrate     func(ctx context.Context, command string, steps int) (*server.MigrateResult, error)
+	seed        func(ctx context.Context, r io.Reader, opts server.SeedOptions) (*server.SeedResult, error)
+	createAdmin func(ctx context.Context, name, password string) (*entity.AdminUser, error)
+}
+
+// 引数・フラグの誤り
+type usageError struct {
+	error
+}
+
+func (e usageError) Unwrap() error { return e.error }
+
+func usageArgs(args cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, a []string) error {
+		if err := args(cmd, a); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
+}
+
+// 結果の出力先と形式（text / json）
+type printer struct {
+	out, err io.Writer
+	format   string
+}
+
+// json なら v を1つの JSON として、text なら text の内容を出力する
+func (p *printer) print(v interface{}, text func(w io.Writer)) {
+	if p.format == "json" {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(v)
+		return
+	}
+	text(p.out)
+}
+
+// 終了コードを決めてエラーを出力する
+func (p *printer) fail(err error) int {
+	code := exitFailure
+	var usageErr usageError
+	switch {
+	case errors.As(err, &usageErr):
+		code = exitUsage
+	case domainErrors.IsConflictError(err):
+		code = exitConflict
+	}
+	if p.format == "json" {
+		encoder := json.NewEncoder(p.err)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(struct {
+			Error    string `json:"error"`
+			ExitCode int    `json:"exit_code"`
+		}{Error: err.Error(), ExitCode: code})
+	} else {
+		fmt.Fprintf(p.err, "❌ %v\n", err)
+	}
+	return code
+}
+
+// コマンドを実行して終了コードを返す
+func execute(ctx context.Context, root *cobra.Command) int {
+	cmd, err := root.ExecuteContextC(ctx)
+	if err == nil {
+		return exitOK
+	}
+	format, _ := root.PersistentFlags().GetString("output")
+	p := &printer{out: root.OutOrStdout(), err: root.ErrOrStderr(), format: format}
+	if code := p.fail(err); code != exitUsage {
+		return code
+	}
+	if p.format != "json" {
+		fmt.Fprintf(p.err, "Run '%s --help' for usage.\n", cmd.CommandPath())
+	}
+	return exitUsage
+}
+
+func newRootCommand(ops operations) *cobra.Command {
+	p := &printer{}
+	root := &cobra.Command{
+		Use:   "main",
+		Short: "アイテム管理APIのサーバーと運用コマンド",
+		Long:  "サブコマンドを指定しない場合は serve と同じくサーバーを起動します。",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ops.serve(cmd.Context())
+		},
+		// エラーは execute で1回だけ表示する
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			p.out, p.err = cmd.OutOrStdout(), cmd.ErrOrStderr()
+			if p.format != "text" && p.format != "json" {
+				return usageError{fmt.Errorf("invalid output format: %s (use text or json)", p.format)}
+			}
+			return nil
+		},
+	}
+	root.PersistentFlags().StringVarP(&p.format, "output", "o", "text", "結果の形式（text / json）")
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err}
+	})
+
+	root.AddCommand(
+		&cobra.Command{
+			Use:   "serve",
+			Short: "APIサーバーを起動する",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return ops.serve(cmd.Context())
+			},
+		},
+		newMigrateCommand(ops, p),
+		newSeedCommand(ops, p),
+		newCreateAdminCommand(ops, p),
+	)
+	return root
+}
+
+func newMigrateCommand(ops operations, p *printer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "データベースのマイグレーションを実行する",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usageError{errors.New("migrate requires a subcommand: up, down or version")}
+		},
+	}
+
+	run := func(command string, steps *int) func(cmd *cobra.Command, args []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			n := 0
+			if steps != nil {
+				n = *steps
+				if n <= 0 {
+					return usageError{fmt.Errorf("steps must be positive: %d", n)}
+				}
+			}
+			result, err := ops.migrate(cmd.Context(), command, n)
+			if err != nil {
+				return err
+			}
+			p.print(result, func(w io.Writer) {
+				switch command {
+				case "up":
+					fmt.Fprintf(w, "✅ Applied %d migration(s)\n", result.Applied)
+				case "down":
+					fmt.Fprintf(w, "✅ Rolled back %d migration(s)\n", result.RolledBack)
+				}
+				fmt.Fprintf(w, "Schema version: %d\n", result.Version)
+			})
+			return nil
+		}
+	}
+
+	var steps int
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "最新のマイグレーションを戻す",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE:  run("down", &steps),
+	}
+	down.Flags().IntVar(&steps, "steps", 1, "戻すマイグレーションの件数")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "未適用のマイグレーションをすべて適用する",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE:  run("up", nil),
+		},
+		down,
+		&cobra.Command{
+			Use:   "version",
+			Short: "現在のスキーマバージョンを表示する",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE:  run("version", nil),
+		},
+	)
+	return cmd
+}
+
+func newSeedCommand(ops operations, p *printer) *cobra.Command {
+	var opts server.SeedOptions
+	cmd := &cobra.Command{
+		Use:   "seed [file.json]",
+		Short: "アイテムを登録する（ファイルを省略するとサンプルデータ、- で標準入力）",
+		Long: "JSON の配列（POST /items のボディと同じ形）のアイテムを登録します。ファイルを省略するとサンプルデータを登録します。\n" +
+			"既存のアイテムとほぼ同じものは登録せず skipped に数えるため、何度実行しても重複しません。\n" +
+			"検証に失敗したアイテムがあれば残りを登録したうえで終了コード1で終了します。",
+		Args: usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader
+			if len(args) == 1 {
+				if args[0] == "-" {
+					r = cmd.InOrStdin()
+				} else {
+					f, err := os.Open(args[0])
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					r = f
+				}
+			}
+
+			result, err := ops.seed(cmd.Context(), r, opts)
+			if result != nil {
+				p.print(result, func(w io.Writer) {
+					fmt.Fprintf(w, "✅ Seeded %d item(s), %d skipped, %d failed\n", result.Created, result.Skipped, len(result.Failed))
+					for _, failure := range result.Failed {
+						fmt.Fprintf(p.err, "item %d (%s): %s\n", failure.Index, failure.Name, failure.Error)
+					}
+				})
+			}
+			if err != nil {
+				return err
+			}
+			if len(result.Failed) > 0 {
+				return fmt.Errorf("%d item(s) could not be seeded", len(result.Failed))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.OwnerID, "owner", "", "登録するアイテムの所有者（X-User-ID）")
+	cmd.Flags().StringVar(&opts.TenantID, "tenant", "", "カスタム属性を検証するテナント（X-Tenant-ID）")
+	return cmd
+}
+
+// 生成するパスワードのバイト数（base64 で32文字）
+const generatedPasswordBytes = 24
+
+func newCreateAdminCommand(ops operations, p *printer) *cobra.Command {
+	var (
+		name          string
+		passwordStdin bool
+	)
+	cmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "管理用サーバーのアカウントを作成する",
+		Long: "管理用サーバー（ADMIN_ENABLED）のアカウントを作成します。アカウントが1つでもあると、管理用サーバーは Basic 認証を求めます。\n" +
+			"--password-stdin を指定しない場合はパスワードを生成し、1回だけ表示します。",
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(name) == "" {
+				return usageError{errors.New(`required flag "name" not set`)}
+			}
+			var password string
+			generated := !passwordStdin
+			if passwordStdin {
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("failed to read password: %w", err)
+				}
+				password = strings.TrimRight(line, "\r\n")
+			} else {
+				b := make([]byte, generatedPasswordBytes)
+				if _, err := rand.Read(b); err != nil {
+					return fmt.Errorf("failed to generate password: %w", err)
+				}
+				password = base64.RawURLEncoding.EncodeToString(b)
+			}
+
+			user, err := ops.createAdmin(cmd.Context(), name, password)
+			if err != nil {
+				return err
+			}
+
+			result := struct {
+				ID        int64     `json:"id"`
+				Name      string    `json:"name"`
+				CreatedAt time.Time `json:"created_at"`
+				Password  string    `json:"password,omitempty"` // 生成した場合のみ
+			}{ID: user.ID, Name: user.Name, CreatedAt: user.CreatedAt}
+			if generated {
+				result.Password = password
+			}
+			p.print(result, func(w io.Writer) {
+				fmt.Fprintf(w, "✅ Created admin user %s (id %d)\n", user.Name, user.ID)
+				if generated {
+					fmt.Fprintf(w, "Password: %s (shown only once)\n", password)
+				}
+			})
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "ログイン名")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "パスワードを標準入力の1行目から読む")
+	return cmd
+}
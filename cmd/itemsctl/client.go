@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// APIのクライアント
+type client struct {
+	server string
+	user   string
+	tenant string
+	http   *http.Client
+}
+
+func newClient(server, user, tenant string, timeout time.Duration) *client {
+	return &client{
+		server: strings.TrimRight(server, "/"),
+		user:   user,
+		tenant: tenant,
+		http:   &http.Client{Timeout: timeout},
+	}
+}
+
+// APIのエラーレスポンス（ステータスと error・details）
+type apiError struct {
+	Status   int
+	Response itemController.ErrorResponse
+}
+
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Response.Error)
+	if len(e.Response.Details) > 0 {
+		msg += ": " + strings.Join(e.Response.Details, ", ")
+	}
+	return msg
+}
+
+// リクエストを送り、2xx 以外は apiError にする。呼び出し側がボディを閉じる
+func (c *client) send(ctx context.Context, method, path string, query url.Values, body interface{}, header http.Header) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	u := c.server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if c.user != "" {
+		req.Header.Set(itemController.HeaderUserID, c.user)
+	}
+	if c.tenant != "" {
+		req.Header.Set(itemController.HeaderTenantID, c.tenant)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		apiErr := &apiError{Status: res.StatusCode}
+		if err := json.NewDecoder(res.Body).Decode(&apiErr.Response); err != nil || apiErr.Response.Error == "" {
+			apiErr.Response.Error = http.StatusText(res.StatusCode)
+		}
+		return nil, apiErr
+	}
+	return res, nil
+}
+
+// リクエストを送り、JSONのレスポンスを out に読み込む（out が nil なら読み捨てる）
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) (*http.Response, error) {
+	res, err := c.send(ctx, method, path, query, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return res, nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return res, nil
+}
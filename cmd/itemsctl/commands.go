@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+func newRootCommand() *cobra.Command {
+	var (
+		server, user, tenant string
+		timeout              time.Duration
+	)
+	root := &cobra.Command{
+		Use:   "itemsctl",
+		Short: "アイテム管理APIのクライアント",
+		// エラーは main で1回だけ表示する
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	flags := root.PersistentFlags()
+	flags.StringVar(&server, "server", envOr("ITEMSCTL_SERVER", "http://localhost:8080"), "APIのURL")
+	flags.StringVar(&user, "user", os.Getenv("ITEMSCTL_USER"), "ユーザーID（X-User-ID）")
+	flags.StringVar(&tenant, "tenant", os.Getenv("ITEMSCTL_TENANT"), "テナントID（X-Tenant-ID）")
+	flags.DurationVar(&timeout, "timeout", 30*time.Second, "1リクエストの期限（エクスポートは読み終えるまで）")
+
+	api := func() *client { return newClient(server, user, tenant, timeout) }
+	root.AddCommand(
+		newListCommand(api),
+		newGetCommand(api),
+		newCreateCommand(api),
+		newPatchCommand(api),
+		newDeleteCommand(api),
+		newImportCommand(api),
+		newExportCommand(api),
+		newSummaryCommand(api),
+	)
+	return root
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func newListCommand(api func() *client) *cobra.Command {
+	var (
+		category, brand, sort, order string
+		page, pageSize               int
+		asJSON                       bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "アイテムの一覧を表で表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			setQuery(query, "category", category)
+			setQuery(query, "brand", brand)
+			setQuery(query, "sort", sort)
+			setQuery(query, "order", order)
+			if page > 0 {
+				query.Set("page", strconv.Itoa(page))
+			}
+			if pageSize > 0 {
+				query.Set("page_size", strconv.Itoa(pageSize))
+			}
+
+			var items []*entity.Item
+			res, err := api().do(cmd.Context(), http.MethodGet, "/items", query, nil, &items)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				return printJSON(cmd.OutOrStdout(), items)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tCATEGORY\tBRAND\tPURCHASE_PRICE\tPURCHASE_DATE\tVERSION")
+			for _, item := range items {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%d\n", item.ID, item.Name, item.Category, item.Brand, item.PurchasePrice, item.PurchaseDate, item.Version)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			// 件数は表の外（標準エラー）に出し、表だけをパイプで渡せるようにする
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d of %s item(s)\n", len(items), res.Header.Get(itemController.HeaderTotalCount))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&category, "category", "", "カテゴリーで絞り込む")
+	cmd.Flags().StringVar(&brand, "brand", "", "ブランドで絞り込む")
+	cmd.Flags().StringVar(&sort, "sort", "", "並べ替える項目（purchase_price など）")
+	cmd.Flags().StringVar(&order, "order", "", "asc / desc")
+	cmd.Flags().IntVar(&page, "page", 0, "ページ（1から）")
+	cmd.Flags().IntVar(&pageSize, "page-size", 0, "1ページの件数")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "表ではなくJSONで表示する")
+	return cmd
+}
+
+func newGetCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "アイテムをJSONで表示する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			var item entity.Item
+			if _, err := api().do(cmd.Context(), http.MethodGet, itemPath(id), nil, nil, &item); err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), &item)
+		},
+	}
+}
+
+func newCreateCommand(api func() *client) *cobra.Command {
+	var (
+		input          usecase.CreateItemInput
+		price          string
+		allowDuplicate bool
+	)
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "アイテムを登録し、登録したアイテムをJSONで表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := entity.ParseAmount(price)
+			if err != nil {
+				return fmt.Errorf("--price %w", err)
+			}
+			input.PurchasePrice = amount
+			var item entity.Item
+			if _, err := api().do(cmd.Context(), http.MethodPost, "/items", createQuery(allowDuplicate), &input, &item); err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), &item)
+		},
+	}
+	cmd.Flags().StringVar(&input.Name, "name", "", "名前")
+	cmd.Flags().StringVar(&input.Category, "category", "", "カテゴリー")
+	cmd.Flags().StringVar(&input.Brand, "brand", "", "ブランド")
+	cmd.Flags().StringVar(&price, "price", "0", "購入価格（主単位の10進数。例: 12.34）")
+	cmd.Flags().StringVar(&input.Currency, "currency", "", "購入価格の通貨（ISO 4217、省略時は JPY）")
+	cmd.Flags().StringVar(&input.PurchaseDate, "date", "", "購入日（YYYY-MM-DD）")
+	cmd.Flags().StringToStringVar(&input.Attributes, "attr", nil, "カスタム属性（key=value、複数指定できる）")
+	cmd.Flags().BoolVar(&allowDuplicate, "allow-duplicate", false, "既存のアイテムとほぼ同じでも登録する")
+	for _, name := range []string{"name", "category", "brand", "date"} {
+		_ = cmd.MarkFlagRequired(name)
+	}
+	return cmd
+}
+
+func newPatchCommand(api func() *client) *cobra.Command {
+	var (
+		name, brand string
+		price       string
+		attributes  map[string]string
+		removed     []string
+		version     int64
+	)
+	cmd := &cobra.Command{
+		Use:   "patch <id>",
+		Short: "指定した項目だけを更新し、更新したアイテムをJSONで表示する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			// 指定されたフラグだけを送る
+			req := usecase.UpdateItemRequest{}
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				req.Name = &name
+			}
+			if flags.Changed("brand") {
+				req.Brand = &brand
+			}
+			if flags.Changed("price") {
+				amount, err := entity.ParseAmount(price)
+				if err != nil {
+					return fmt.Errorf("--price %w", err)
+				}
+				req.PurchasePrice = &amount
+			}
+			if flags.Changed("version") {
+				req.Version = &version
+			}
+			if len(attributes) > 0 || len(removed) > 0 {
+				req.Attributes = make(map[string]*string)
+				for key, value := range attributes {
+					req.Attributes[key] = &value
+				}
+				for _, key := range removed {
+					req.Attributes[key] = nil
+				}
+			}
+			if req.Name == nil && req.Brand == nil && req.PurchasePrice == nil && req.Attributes == nil {
+				return fmt.Errorf("nothing to update: specify at least one of --name, --brand, --price, --attr, --remove-attr")
+			}
+
+			var item entity.Item
+			if _, err := api().do(cmd.Context(), http.MethodPatch, itemPath(id), nil, &req, &item); err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), &item)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "名前")
+	cmd.Flags().StringVar(&brand, "brand", "", "ブランド")
+	cmd.Flags().StringVar(&price, "price", "", "購入価格（主単位の10進数、アイテムの通貨）")
+	cmd.Flags().StringToStringVar(&attributes, "attr", nil, "設定するカスタム属性（key=value、複数指定できる）")
+	cmd.Flags().StringSliceVar(&removed, "remove-attr", nil, "削除するカスタム属性のキー")
+	cmd.Flags().Int64Var(&version, "version", 0, "最後に読んだバージョン（変わっていたら 409）")
+	return cmd
+}
+
+func newDeleteCommand(api func() *client) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <id>",
+		Short: "アイテムを削除する（ゴミ箱に移る）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+			res, err := api().do(cmd.Context(), http.MethodDelete, itemPath(id), nil, nil, nil)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "deleted item %d\n", id)
+			if token := res.Header.Get(itemController.HeaderUndoToken); token != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "undo token: %s (until %s)\n", token, res.Header.Get(itemController.HeaderUndoExpiresAt))
+			}
+			return nil
+		},
+	}
+}
+
+func newSummaryCommand(api func() *client) *cobra.Command {
+	var from, to, currency string
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "カテゴリー別の集計を表で表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			setQuery(query, "from", from)
+			setQuery(query, "to", to)
+			setQuery(query, "currency", currency)
+
+			var summary usecase.CategorySummary
+			if _, err := api().do(cmd.Context(), http.MethodGet, "/summary", query, nil, &summary); err != nil {
+				return err
+			}
+			return printSummary(cmd.OutOrStdout(), &summary)
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "購入日の範囲の開始（YYYY-MM-DD）")
+	cmd.Flags().StringVar(&to, "to", "", "購入日の範囲の終了（YYYY-MM-DD）")
+	cmd.Flags().StringVar(&currency, "currency", "", "合計を換算する通貨（USD など）")
+	return cmd
+}
+
+// カテゴリーは定義順に並べ、通貨の指定があれば換算した合計の列を加える
+func printSummary(out io.Writer, summary *usecase.CategorySummary) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := "CATEGORY\tCOUNT\tMIN\tMAX\tAVG\tTOTAL\t"
+	if summary.Value != nil {
+		header += "VALUE (" + summary.Value.Currency + ")\t"
+	}
+	fmt.Fprintln(w, header)
+
+	var sum int
+	for _, category := range entity.GetValidCategories() {
+		stats := summary.Stats[category]
+		sum += stats.Sum
+		row := fmt.Sprintf("%s\t%d\t%d\t%d\t%.0f\t%d\t", category, summary.Categories[category], stats.Min, stats.Max, stats.Avg, stats.Sum)
+		if summary.Value != nil {
+			row += fmt.Sprintf("%.2f\t", summary.Value.Categories[category])
+		}
+		fmt.Fprintln(w, row)
+	}
+	total := fmt.Sprintf("TOTAL\t%d\t\t\t\t%d\t", summary.Total, sum)
+	if summary.Value != nil {
+		total += fmt.Sprintf("%.2f\t", summary.Value.Total)
+	}
+	fmt.Fprintln(w, total)
+	return w.Flush()
+}
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid item ID: %s", s)
+	}
+	return id, nil
+}
+
+func itemPath(id int64) string {
+	return "/items/" + strconv.FormatInt(id, 10)
+}
+
+func createQuery(allowDuplicate bool) url.Values {
+	if !allowDuplicate {
+		return nil
+	}
+	return url.Values{"allow_duplicate": {"true"}}
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
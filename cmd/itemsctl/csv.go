@@ -0,0 +1,260 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// インポートに必要な列。GET /exports/items の CSV はそのままインポートできる（ID・バージョン・日時などの列は無視する）
+var importColumns = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+func newExportCommand(api func() *client) *cobra.Command {
+	var format, category, brand, output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "アイテムを CSV（または JSON）で出力する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			setQuery(query, "format", format)
+			setQuery(query, "category", category)
+			setQuery(query, "brand", brand)
+
+			c := api()
+			// 件数が多いと1リクエストの期限では読み終わらないため、期限はコンテキストに任せる
+			c.http.Timeout = 0
+			res, err := c.send(cmd.Context(), http.MethodGet, "/exports/items", query, nil, http.Header{"Accept": {"*/*"}})
+			if err != nil {
+				return err
+			}
+			defer res.Body.Close()
+
+			out := cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			if _, err := io.Copy(out, res.Body); err != nil {
+				return fmt.Errorf("failed to read export: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "csv", "csv / json")
+	cmd.Flags().StringVar(&category, "category", "", "カテゴリーで絞り込む")
+	cmd.Flags().StringVar(&brand, "brand", "", "ブランドで絞り込む")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "出力先のファイル（既定は標準出力）")
+	return cmd
+}
+
+func newImportCommand(api func() *client) *cobra.Command {
+	var allowDuplicate, dryRun bool
+	var onDuplicate string
+	cmd := &cobra.Command{
+		Use:   "import <file.csv>",
+		Short: "CSV の各行をアイテムとして登録する（- で標準入力）",
+		Long: "CSV の各行をアイテムとして登録します。1行目は列名で、name, category, brand, purchase_price, purchase_date が必要です。\n" +
+			"attributes 列があれば JSON のオブジェクトとしてカスタム属性に使います。\n" +
+			"既存のアイテムと同じ行（シリアル番号の属性 serial が同じ、または名前・ブランド・購入日が近い）は一致したアイテムとともに出力し、\n" +
+			"--on-duplicate（または行ごとの on_duplicate 列）に従って skip（既定）・merge（merge_into 列のアイテムに価格と属性をまとめる）・create します。\n" +
+			"登録に失敗した行は標準エラーに出力して次の行に進み、1行でも失敗すると終了コードは1になります。",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+			if allowDuplicate && onDuplicate == "" {
+				onDuplicate = usecase.ImportCreate
+			}
+
+			// 不正な行は送らずに失敗として数え、残りの行をまとめて送る
+			var rows []usecase.ImportRow
+			var lines []int
+			failed := 0
+			err := readImportRows(in, func(line int, row *usecase.ImportRow, err error) error {
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "line %d: %v\n", line, err)
+					failed++
+					return nil
+				}
+				rows = append(rows, *row)
+				lines = append(lines, line)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			c := api()
+			// 1回のインポートの行数には上限があるため分けて送る。前に送った行と同じ行は登録済みのアイテムとして見つかる
+			c.http.Timeout = 0
+			var total usecase.ImportReport
+			for start := 0; start < len(rows); start += importBatchSize {
+				end := min(start+importBatchSize, len(rows))
+				input := usecase.ImportInput{Rows: rows[start:end], OnDuplicate: onDuplicate, DryRun: dryRun}
+				var report usecase.ImportReport
+				if _, err = c.do(cmd.Context(), http.MethodPost, "/items/import", nil, input, &report); err != nil {
+					// 送れなかった行以降は登録されていない
+					err = fmt.Errorf("line %d: %w", lines[start], err)
+					break
+				}
+				printImportReport(cmd, report, lines[start:end])
+				total.Created += report.Created
+				total.Merged += report.Merged
+				total.Skipped += report.Skipped
+				total.Failed += report.Failed
+			}
+
+			failed += total.Failed
+			prefix := ""
+			if dryRun {
+				prefix = "dry run: "
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%simported %d item(s), %d merged, %d skipped, %d failed\n", prefix, total.Created, total.Merged, total.Skipped, failed)
+			if err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d row(s) could not be imported", failed)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&onDuplicate, "on-duplicate", "", "既存のアイテムと同じ行の扱い: skip（既定）/ merge / create")
+	cmd.Flags().BoolVar(&allowDuplicate, "allow-duplicate", false, "既存のアイテムとほぼ同じ行も登録する（--on-duplicate create と同じ）")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "登録せずに、各行がどうなるかと重複の候補だけを出力する")
+	return cmd
+}
+
+// importBatchSize は1回の POST /items/import で送る行数（サーバーの上限）
+const importBatchSize = 500
+
+// printImportReport は重複の候補のある行を標準出力に、失敗した行を標準エラーに CSV の行番号で出力する
+func printImportReport(cmd *cobra.Command, report usecase.ImportReport, lines []int) {
+	for _, row := range report.Rows {
+		line := lines[row.Row-1]
+		if row.Status == usecase.ImportFailed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "line %d: %s\n", line, row.Error)
+			continue
+		}
+		if len(row.Matches) == 0 {
+			continue
+		}
+		matches := make([]string, len(row.Matches))
+		for i, match := range row.Matches {
+			target := fmt.Sprintf("item %d", match.ID)
+			if match.Row > 0 {
+				target = fmt.Sprintf("line %d", lines[match.Row-1])
+			}
+			if match.Reason == "serial" {
+				matches[i] = fmt.Sprintf("%s (serial)", target)
+			} else {
+				matches[i] = fmt.Sprintf("%s (similar %.2f)", target, match.Similarity)
+			}
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "line %d: %s, matches %s\n", line, row.Status, strings.Join(matches, ", "))
+	}
+}
+
+// readImportRows は CSV の各行をインポートの行にして fn に渡す。行の内容が不正なら row は nil で err に理由を渡す。
+// fn がエラーを返すと読み込みをやめる
+func readImportRows(r io.Reader, fn func(line int, row *usecase.ImportRow, err error) error) error {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[name] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := index[name]; !ok {
+			return fmt.Errorf("CSV header has no %s column", name)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// 列数が違う行などは飛ばして続ける
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			if err := fn(parseErr.Line, nil, err); err != nil {
+				return err
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		row, err := importRow(index, record)
+		if err != nil {
+			row = nil
+		}
+		if err := fn(line, row, err); err != nil {
+			return err
+		}
+	}
+}
+
+func importRow(index map[string]int, record []string) (*usecase.ImportRow, error) {
+	// 購入価格は主単位の10進数（12.34）。小数点以下の桁数は通貨に応じてサーバーが確かめる
+	price, err := entity.ParseAmount(record[index["purchase_price"]])
+	if err != nil {
+		return nil, fmt.Errorf("purchase_price %w", err)
+	}
+	row := &usecase.ImportRow{CreateItemInput: usecase.CreateItemInput{
+		Name:          record[index["name"]],
+		Category:      record[index["category"]],
+		Brand:         record[index["brand"]],
+		PurchasePrice: price,
+		PurchaseDate:  record[index["purchase_date"]],
+	}}
+	if i, ok := index["currency"]; ok {
+		row.Currency = strings.TrimSpace(record[i])
+	}
+	if i, ok := index["attributes"]; ok && record[i] != "" {
+		if err := json.Unmarshal([]byte(record[i]), &row.Attributes); err != nil {
+			return nil, fmt.Errorf("attributes must be a JSON object of strings: %w", err)
+		}
+	}
+	// 重複の扱いは --dry-run の結果を見て行ごとに決められる
+	if i, ok := index["on_duplicate"]; ok {
+		row.OnDuplicate = strings.TrimSpace(record[i])
+	}
+	if i, ok := index["merge_into"]; ok && strings.TrimSpace(record[i]) != "" {
+		id, err := strconv.ParseInt(strings.TrimSpace(record[i]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("merge_into must be an item ID: %q", record[i])
+		}
+		row.MergeInto = id
+	}
+	return row, nil
+}
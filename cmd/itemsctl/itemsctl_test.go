@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// request はテスト用サーバーが受け取ったリクエスト
+type request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// newTestServer は受け取ったリクエストを記録し、handler の応答を返すサーバーを起動する
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, func() []request) {
+	var mu sync.Mutex
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Header: r.Header}
+		if len(body) > 0 {
+			require.NoError(t, json.Unmarshal(body, &req.Body))
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []request {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+// run はコマンドを実行し、標準出力・標準エラーとエラーを返す
+func run(server *httptest.Server, stdin string, args ...string) (string, string, error) {
+	root := newRootCommand()
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(append([]string{"--server", server.URL, "--user", "alice"}, args...))
+	err := root.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestList(t *testing.T) {
+	server, requests := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "12")
+		_, _ = io.WriteString(w, `[{"id":1,"name":"ロレックス","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15","version":2}]`)
+	})
+
+	stdout, stderr, err := run(server, "", "list", "--category", "時計", "--page-size", "1")
+
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "ID  NAME")
+	assert.Regexp(t, `1\s+ロレックス\s+時計\s+ROLEX\s+1500000\s+2023-01-15\s+2`, stdout)
+	assert.Equal(t, "1 of 12 item(s)\n", stderr)
+	req := requests()[0]
+	assert.Equal(t, "/items", req.Path)
+	assert.Equal(t, "category=%E6%99%82%E8%A8%88&page_size=1", req.Query)
+	assert.Equal(t, "alice", req.Header.Get("X-User-ID"))
+}
+
+func TestCreateAndPatch(t *testing.T) {
+	server, requests := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"id":7,"name":"ロレックス","version":1}`)
+	})
+
+	t.Run("正常系: 登録", func(t *testing.T) {
+		stdout, _, err := run(server, "", "create", "--name", "ロレックス", "--category", "時計", "--brand", "ROLEX", "--price", "1500000", "--date", "2023-01-15", "--attr", "serial=A1")
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout, `"id": 7`)
+		req := requests()[0]
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, map[string]interface{}{
+			"name": "ロレックス", "category": "時計", "brand": "ROLEX", "purchase_price": "1500000",
+			"purchase_date": "2023-01-15", "attributes": map[string]interface{}{"serial": "A1"},
+		}, req.Body)
+	})
+
+	t.Run("正常系: 指定した項目だけを更新する", func(t *testing.T) {
+		_, _, err := run(server, "", "patch", "7", "--price", "0", "--remove-attr", "serial")
+
+		require.NoError(t, err)
+		req := requests()[1]
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "/items/7", req.Path)
+		assert.Equal(t, map[string]interface{}{"purchase_price": "0", "attributes": map[string]interface{}{"serial": nil}}, req.Body)
+	})
+
+	t.Run("異常系: 更新する項目がない", func(t *testing.T) {
+		_, _, err := run(server, "", "patch", "7")
+
+		assert.ErrorContains(t, err, "nothing to update")
+		assert.Len(t, requests(), 2)
+	})
+
+	t.Run("異常系: 必須のフラグがない", func(t *testing.T) {
+		_, _, err := run(server, "", "create", "--name", "ロレックス")
+
+		assert.Error(t, err)
+		assert.Len(t, requests(), 2)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Undo-Token", "token")
+		w.Header().Set("X-Undo-Expires-At", "2024-03-01T00:00:30Z")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	stdout, _, err := run(server, "", "delete", "7")
+
+	require.NoError(t, err)
+	assert.Equal(t, "deleted item 7\nundo token: token (until 2024-03-01T00:00:30Z)\n", stdout)
+}
+
+func TestAPIError(t *testing.T) {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"error":"item not found"}`)
+	})
+
+	_, _, err := run(server, "", "get", "99")
+
+	assert.EqualError(t, err, "404 item not found")
+}
+
+func TestImport(t *testing.T) {
+	server, requests := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// 1行目は既存のアイテム7とシリアル番号が同じ、2行目はカテゴリーが不正、3行目は1行目と同じ
+		_, _ = io.WriteString(w, `{"dry_run":false,"created":1,"merged":0,"skipped":1,"failed":1,"rows":[`+
+			`{"row":1,"status":"created","item_id":10,"matches":[{"id":7,"name":"デイトナ","brand":"ROLEX","purchase_date":"2023-01-15","reason":"serial"}],"options":["skip","merge","create"]},`+
+			`{"row":2,"status":"failed","error":"invalid input: category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},`+
+			`{"row":3,"status":"skipped","matches":[{"id":10,"row":1,"name":"ロレックス","brand":"ROLEX","purchase_date":"2023-01-15","reason":"similar","similarity":1}],"options":["skip","merge","create"]}]}`)
+	})
+	// GET /exports/items の CSV と同じ列に、行ごとの重複の扱い
+	csv := "id,name,category,brand,purchase_price,purchase_date,owner_id,attributes,version,created_at,updated_at,on_duplicate\n" +
+		`1,ロレックス,時計,ROLEX,1500000,2023-01-15,alice,"{""serial"":""A1""}",3,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,create` + "\n" +
+		"2,イス,家具,IKEA,5000,2023-02-01,,,1,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,\n" +
+		"3,時計,時計,SEIKO,abc,2023-03-01,,,1,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,\n" +
+		"4,ロレックス,時計,ROLEX,1500000,2023-01-15,,,1,2024-01-01T00:00:00Z,2024-01-01T00:00:00Z,\n"
+
+	stdout, stderr, err := run(server, csv, "import", "-", "--on-duplicate", "skip")
+
+	assert.EqualError(t, err, "2 row(s) could not be imported")
+	assert.Equal(t, "line 2: created, matches item 7 (serial)\n"+
+		"line 5: skipped, matches line 2 (similar 1.00)\n"+
+		"imported 1 item(s), 0 merged, 1 skipped, 2 failed\n", stdout)
+	assert.Contains(t, stderr, "line 3: invalid input: category must be one of")
+	assert.Contains(t, stderr, `line 4: purchase_price must be a decimal number such as 12.34: "abc"`)
+	require.Len(t, requests(), 1)
+	req := requests()[0]
+	assert.Equal(t, "/items/import", req.Path)
+	assert.Equal(t, "skip", req.Body["on_duplicate"])
+	assert.Equal(t, false, req.Body["dry_run"])
+	rows := req.Body["rows"].([]interface{})
+	require.Len(t, rows, 3)
+	assert.Equal(t, map[string]interface{}{
+		"name": "ロレックス", "category": "時計", "brand": "ROLEX", "purchase_price": "1500000",
+		"purchase_date": "2023-01-15", "attributes": map[string]interface{}{"serial": "A1"}, "on_duplicate": "create",
+	}, rows[0])
+}
+
+func TestSummary(t *testing.T) {
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"categories":{"時計":2,"バッグ":1},"total":3,"stats":{"時計":{"count":2,"min_purchase_price":1000,"max_purchase_price":3000,"avg_purchase_price":2000,"total_purchase_price":4000},"バッグ":{"count":1,"min_purchase_price":500,"max_purchase_price":500,"avg_purchase_price":500,"total_purchase_price":500}}}`)
+	})
+
+	stdout, _, err := run(server, "", "summary")
+
+	require.NoError(t, err)
+	assert.Regexp(t, `時計\s+2\s+1000\s+3000\s+2000\s+4000`, stdout)
+	assert.Regexp(t, `靴\s+0\s+0\s+0\s+0\s+0`, stdout)
+	assert.Regexp(t, `TOTAL\s+3\s+4500`, stdout)
+}
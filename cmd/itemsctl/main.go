@@ -0,0 +1,21 @@
+// itemsctl はアイテム管理APIのコマンドラインクライアント。
+//
+//	go run ./cmd/itemsctl list --category 時計
+//	go run ./cmd/itemsctl create --name "ロレックス" --category 時計 --brand ROLEX --price 1500000 --date 2023-01-15
+//	go run ./cmd/itemsctl export > items.csv
+//
+// 接続先は --server（既定 ITEMSCTL_SERVER、なければ http://localhost:8080）、
+// ユーザーとテナントは --user / --tenant（既定 ITEMSCTL_USER / ITEMSCTL_TENANT）で指定する。
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,18 +1,54 @@
+// サーバーのバイナリ。API サーバーの起動と運用作業（マイグレーション・シード・管理者の作成）を同じバイナリで行う。
+//
+//	go run ./cmd                          # serve と同じ
+//	go run ./cmd migrate up
+//	go run ./cmd seed items.json --output json
+//	go run ./cmd create-admin --name ops
+//
+// 終了コードは 0（成功）、1（失敗）、2（引数・フラグの誤り）、3（作成しようとしたものがすでにある）。
 package main
 
 import (
 	"context"
-	"log"
+	"io"
+	"os"
 
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/infrastructure/server"
 )
 
 func main() {
-	ctx := context.Background()
-
-	server := server.NewServer()
-
-	if err := server.Run(ctx); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
-	}
+	// 設定はサブコマンドを実行するときに読み込む（--help は設定がなくても表示できる）
+	root := newRootCommand(operations{
+		serve: func(ctx context.Context) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			return server.NewServer(cfg).Run(ctx)
+		},
+		migrate: func(ctx context.Context, command string, steps int) (*server.MigrateResult, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			return server.Migrate(ctx, cfg, command, steps)
+		},
+		seed: func(ctx context.Context, r io.Reader, opts server.SeedOptions) (*server.SeedResult, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			return server.Seed(ctx, cfg, r, opts)
+		},
+		createAdmin: func(ctx context.Context, name, password string) (*entity.AdminUser, error) {
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, err
+			}
+			return server.CreateAdmin(ctx, cfg, name, password)
+		},
+	})
+	os.Exit(execute(context.Background(), root))
 }
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/server"
+)
+
+// fakeOperations は呼び出しを記録する
+type fakeOperations struct {
+	calls    []string
+	seedBody string
+	password string
+	seedErr  error
+}
+
+func (f *fakeOperations) operations() operations {
+	return operations{
+		serve: func(ctx context.Context) error {
+			f.calls = append(f.calls, "serve")
+			return nil
+		},
+		migrate: func(ctx context.Context, command string, steps int) (*server.MigrateResult, error) {
+			f.calls = append(f.calls, fmt.Sprintf("migrate %s %d", command, steps))
+			return &server.MigrateResult{Command: command, Applied: 2, RolledBack: steps, Version: 22}, nil
+		},
+		seed: func(ctx context.Context, r io.Reader, opts server.SeedOptions) (*server.SeedResult, error) {
+			f.calls = append(f.calls, "seed "+opts.OwnerID)
+			if r != nil {
+				b, _ := io.ReadAll(r)
+				f.seedBody = string(b)
+			}
+			return &server.SeedResult{Created: 4, Skipped: 1, Failed: []*server.SeedFailure{}}, f.seedErr
+		},
+		createAdmin: func(ctx context.Context, name, password string) (*entity.AdminUser, error) {
+			f.calls = append(f.calls, "create-admin "+name)
+			f.password = password
+			if name == "taken" {
+				return nil, fmt.Errorf("%w: admin user %q already exists", domainErrors.ErrConflict, name)
+			}
+			return &entity.AdminUser{ID: 1, Name: name, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, nil
+		},
+	}
+}
+
+// run はコマンドを実行し、終了コードと標準出力・標準エラーを返す
+func run(f *fakeOperations, stdin string, args ...string) (int, string, string) {
+	root := newRootCommand(f.operations())
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(args)
+	code := execute(context.Background(), root)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestServe(t *testing.T) {
+	for _, args := range [][]string{{}, {"serve"}} {
+		f := &fakeOperations{}
+
+		code, _, _ := run(f, "", args...)
+
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, []string{"serve"}, f.calls)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("正常系: up", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, stdout, _ := run(f, "", "migrate", "up")
+
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, "✅ Applied 2 migration(s)\nSchema version: 22\n", stdout)
+		assert.Equal(t, []string{"migrate up 0"}, f.calls)
+	})
+
+	t.Run("正常系: down を JSON で出力する", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, stdout, _ := run(f, "", "migrate", "down", "--steps", "3", "-o", "json")
+
+		assert.Equal(t, exitOK, code)
+		assert.JSONEq(t, `{"command":"down","applied":2,"rolled_back":3,"version":22}`, stdout)
+		assert.Equal(t, []string{"migrate down 3"}, f.calls)
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "異常系: サブコマンドがない", args: []string{"migrate"}, expected: "migrate requires a subcommand"},
+		{name: "異常系: 不明なサブコマンド", args: []string{"migrate", "sideways"}, expected: `unknown command "sideways"`},
+		{name: "異常系: 戻す件数が0", args: []string{"migrate", "down", "--steps", "0"}, expected: "steps must be positive"},
+		{name: "異常系: 不明なフラグ", args: []string{"migrate", "up", "--force"}, expected: "unknown flag: --force"},
+		{name: "異常系: 不明な出力形式", args: []string{"migrate", "up", "-o", "yaml"}, expected: "invalid output format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeOperations{}
+
+			code, _, stderr := run(f, "", tt.args...)
+
+			assert.Equal(t, exitUsage, code)
+			assert.Contains(t, stderr, tt.expected)
+			assert.Contains(t, stderr, "--help")
+			assert.Empty(t, f.calls)
+		})
+	}
+}
+
+func TestSeed(t *testing.T) {
+	t.Run("正常系: 標準入力から読む", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, stdout, _ := run(f, `[{"name":"ロレックス"}]`, "seed", "-", "--owner", "alice")
+
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, "✅ Seeded 4 item(s), 1 skipped, 0 failed\n", stdout)
+		assert.Equal(t, []string{"seed alice"}, f.calls)
+		assert.Equal(t, `[{"name":"ロレックス"}]`, f.seedBody)
+	})
+
+	t.Run("異常系: 途中で失敗したら結果とエラーを JSON で出力する", func(t *testing.T) {
+		f := &fakeOperations{seedErr: errors.New("database is down")}
+
+		code, stdout, stderr := run(f, "", "seed", "--output", "json")
+
+		assert.Equal(t, exitFailure, code)
+		assert.JSONEq(t, `{"created":4,"skipped":1,"failed":[]}`, stdout)
+		assert.JSONEq(t, `{"error":"database is down","exit_code":1}`, stderr)
+	})
+}
+
+func TestCreateAdmin(t *testing.T) {
+	t.Run("正常系: パスワードを生成して1回だけ表示する", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, stdout, _ := run(f, "", "create-admin", "--name", "ops")
+
+		assert.Equal(t, exitOK, code)
+		assert.Len(t, f.password, 32)
+		assert.Contains(t, stdout, "✅ Created admin user ops (id 1)\n")
+		assert.Contains(t, stdout, "Password: "+f.password)
+	})
+
+	t.Run("正常系: 標準入力のパスワードは表示しない", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, stdout, _ := run(f, "correct horse battery\n", "create-admin", "--name", "ops", "--password-stdin", "-o", "json")
+
+		assert.Equal(t, exitOK, code)
+		assert.Equal(t, "correct horse battery", f.password)
+		assert.JSONEq(t, `{"id":1,"name":"ops","created_at":"2024-03-01T00:00:00Z"}`, stdout)
+	})
+
+	t.Run("異常系: 同じ名前のアカウントがある", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, _, stderr := run(f, "", "create-admin", "--name", "taken")
+
+		assert.Equal(t, exitConflict, code)
+		assert.Contains(t, stderr, "already exists")
+	})
+
+	t.Run("異常系: 名前がない", func(t *testing.T) {
+		f := &fakeOperations{}
+
+		code, _, stderr := run(f, "", "create-admin")
+
+		require.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, `required flag "name" not set`)
+		assert.Empty(t, f.calls)
+	})
+}
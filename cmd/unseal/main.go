@@ -0,0 +1,44 @@
+// unseal は暗号化されたエクスポートファイル（*.sealed）を復号する。
+//
+//	UNSEAL_PASSPHRASE=... go run ./cmd/unseal estate-xxxx.pdf.sealed > inventory.pdf
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"Aicon-assignment/internal/infrastructure/seal"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: unseal <file.sealed> > output")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	passphrase := os.Getenv("UNSEAL_PASSPHRASE")
+	if passphrase == "" {
+		fmt.Fprint(os.Stderr, "passphrase: ")
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		passphrase = strings.TrimRight(line, "\r\n")
+	}
+
+	plaintext, err := seal.Decrypt(data, passphrase)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stdout.Write(plaintext); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// 管理用サーバーのアカウント（create-admin コマンドで作成する）
+type AdminUser struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"-"` // bcrypt のハッシュ
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// バックアップ（全データのスナップショットを暗号化したもの）
+type Backup struct {
+	ID        string    `json:"id"`
+	Size      int64     `json:"size,omitempty"` // 暗号化後のバイト数（作成時のみ）
+	CreatedAt time.Time `json:"created_at"`
+}
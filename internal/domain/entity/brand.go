@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// ブランド名・別名の最大の長さ（アイテムの brand と同じ）
+	maxBrandNameLength = 100
+	// 1つのブランドに登録できる別名の数
+	maxBrandAliases = 20
+)
+
+// ブランドの正式名と別名（"Rolex" や "ロレックス" など）。
+// アイテムの登録・更新では、別名や大文字・小文字の違う名前を正式名に置き換える
+type Brand struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewBrand(name string, aliases []string, now time.Time) (*Brand, error) {
+	brand := &Brand{
+		Name:      SanitizeString(name),
+		Aliases:   []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	// 正式名と同じ別名、重複した別名は除く
+	for _, alias := range aliases {
+		alias = SanitizeString(alias)
+		if alias != brand.Name && !slices.Contains(brand.Aliases, alias) {
+			brand.Aliases = append(brand.Aliases, alias)
+		}
+	}
+
+	if err := brand.Validate(); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+// ブランドのバリデーション
+func (b *Brand) Validate() error {
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(b.Name) > maxBrandNameLength {
+		return fmt.Errorf("name must be %d characters or less", maxBrandNameLength)
+	}
+	if len(b.Aliases) > maxBrandAliases {
+		return fmt.Errorf("aliases must be %d or fewer", maxBrandAliases)
+	}
+	for _, alias := range b.Aliases {
+		if alias == "" {
+			return errors.New("aliases must not be empty")
+		}
+		if len(alias) > maxBrandNameLength {
+			return fmt.Errorf("aliases must be %d characters or less", maxBrandNameLength)
+		}
+	}
+	return nil
+}
+
+// 正式名と別名（アイテムのブランドと照合する名前）
+func (b *Brand) Names() []string {
+	return append([]string{b.Name}, b.Aliases...)
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// カテゴリー名の最大の長さ（文字数。items.category 列の長さ）
+const maxCategoryNameLength = 50
+
+// テナントが追加したカテゴリー（組み込みのカテゴリーと同じく、アイテムのカテゴリーに使える）
+type Category struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewCategory(name string, now time.Time) (*Category, error) {
+	category := &Category{
+		Name:      SanitizeString(name),
+		CreatedAt: now,
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// カテゴリーのバリデーション
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if !IsValidCategoryName(c.Name) {
+		return errors.New("name must be 50 characters or less")
+	}
+	if isValidCategory(c.Name) {
+		return errors.New("name must not be a built-in category")
+	}
+	return nil
+}
+
+// カテゴリー名として使える長さかどうか（リクエストの検証で使う。有効なカテゴリーかどうかはテナントごとに決まる）
+func IsValidCategoryName(name string) bool {
+	return utf8.RuneCountInString(name) <= maxCategoryNameLength
+}
+
+// カテゴリー名の一覧（nil なら空）
+func CategoryNames(categories []*Category) []string {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return names
+}
+
+// 集計のカテゴリーを表示する順に返す。組み込みのカテゴリー（集計になくても含める）の順に、
+// 続いてそれ以外（テナントが追加したカテゴリー）を名前順に並べる
+func SortedCategories[V any](byCategory map[string]V) []string {
+	categories := GetValidCategories()
+	var custom []string
+	for category := range byCategory {
+		if !isValidCategory(category) {
+			custom = append(custom, category)
+		}
+	}
+	sort.Strings(custom)
+	return slices.Concat(categories, custom)
+}
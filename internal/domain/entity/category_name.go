@@ -0,0 +1,32 @@
+package entity
+
+// 表示名を用意している言語（先頭が既定。カテゴリーそのものが日本語の表示名）
+var CategoryLanguages = []string{"ja", "en"}
+
+// 言語ごとのカテゴリーの表示名（カテゴリー → 表示名）。API ではカテゴリー（日本語）を変えずに使い、表示名は表示のためだけに使う
+var categoryDisplayNames = map[string]map[string]string{
+	"en": {
+		"時計":    "Watches",
+		"バッグ":   "Bags",
+		"ジュエリー": "Jewelry",
+		"靴":     "Shoes",
+		"その他":   "Other",
+	},
+}
+
+// カテゴリーの表示名。その言語の表示名がなければカテゴリーのまま（テナントが追加したカテゴリーは常にそのまま）
+func CategoryDisplayName(category, lang string) string {
+	if name, ok := categoryDisplayNames[lang][category]; ok {
+		return name
+	}
+	return category
+}
+
+// カテゴリーの表示名（カテゴリー → 表示名）
+func CategoryDisplayNames(lang string, categories []string) map[string]string {
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category] = CategoryDisplayName(category, lang)
+	}
+	return names
+}
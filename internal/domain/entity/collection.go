@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ユーザーが作るアイテムのまとまり（「祖父の時計」「2024年の購入品」など）
+type Collection struct {
+	ID          int64     `json:"id"`
+	OwnerID     string    `json:"owner_id,omitempty"` // 作成したユーザーのID
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	ItemCount   int       `json:"item_count"` // コレクションのアイテムの数
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewCollection(ownerID, name, description string, now time.Time) (*Collection, error) {
+	c := &Collection{
+		OwnerID:   ownerID,
+		CreatedAt: now,
+	}
+	if err := c.Change(name, description, now); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// 名前と説明を置き換える
+func (c *Collection) Change(name, description string, now time.Time) error {
+	c.Name = SanitizeString(name)
+	c.Description = SanitizeString(description)
+	c.UpdatedAt = now
+
+	return c.Validate()
+}
+
+// コレクションのバリデーション
+func (c *Collection) Validate() error {
+	var errs []string
+
+	if c.Name == "" {
+		errs = append(errs, "name is required")
+	} else if len(c.Name) > 100 {
+		errs = append(errs, "name must be 100 characters or less")
+	}
+
+	if len(c.Description) > 500 {
+		errs = append(errs, "description must be 500 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
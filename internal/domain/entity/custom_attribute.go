@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// カスタム属性の型
+const (
+	AttributeTypeEnum = "enum"
+	AttributeTypeDate = "date" // YYYY-MM-DD（例: 保証期限・保険の満期日）
+)
+
+const maxAttributeOptions = 100
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// テナントが定義するカスタム属性（例: 保管ボックス）
+type CustomAttribute struct {
+	Key       string    `json:"key"`
+	Label     string    `json:"label"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options"`
+	Required  bool      `json:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewCustomAttribute(key, label, attrType string, options []string, required bool) (*CustomAttribute, error) {
+	attr := &CustomAttribute{
+		Key:       strings.TrimSpace(key),
+		Label:     SanitizeString(label),
+		Type:      strings.TrimSpace(attrType),
+		Required:  required,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if attr.Type == "" {
+		attr.Type = AttributeTypeEnum
+	}
+	for _, opt := range options {
+		attr.Options = append(attr.Options, SanitizeString(opt))
+	}
+
+	if err := attr.Validate(); err != nil {
+		return nil, err
+	}
+
+	return attr, nil
+}
+
+// カスタム属性定義のバリデーション
+func (a *CustomAttribute) Validate() error {
+	var errs []string
+
+	if !attributeKeyPattern.MatchString(a.Key) {
+		errs = append(errs, "key must start with a lowercase letter and contain only a-z, 0-9, _ (max 50 characters)")
+	}
+
+	if a.Label == "" {
+		errs = append(errs, "label is required")
+	} else if len(a.Label) > 100 {
+		errs = append(errs, "label must be 100 characters or less")
+	}
+
+	switch a.Type {
+	case AttributeTypeEnum:
+		if len(a.Options) == 0 {
+			errs = append(errs, "options is required")
+		}
+	case AttributeTypeDate:
+		if len(a.Options) > 0 {
+			errs = append(errs, "options must be empty for date attributes")
+		}
+	default:
+		errs = append(errs, "type must be one of: enum, date")
+	}
+
+	if len(a.Options) > maxAttributeOptions {
+		errs = append(errs, fmt.Sprintf("options must contain %d values or less", maxAttributeOptions))
+	}
+	seen := make(map[string]bool, len(a.Options))
+	for _, opt := range a.Options {
+		if opt == "" {
+			errs = append(errs, "options must not contain empty values")
+			break
+		}
+		if seen[opt] {
+			errs = append(errs, fmt.Sprintf("options must be unique: %s", opt))
+			break
+		}
+		seen[opt] = true
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+// 値が選択肢に含まれるかどうか（日付型は YYYY-MM-DD 形式かどうか）
+func (a *CustomAttribute) Allows(value string) bool {
+	if a.Type == AttributeTypeDate {
+		return isValidDateFormat(value)
+	}
+	for _, opt := range a.Options {
+		if opt == value {
+			return true
+		}
+	}
+	return false
+}
+
+// アイテムの属性値を定義に照らして検証する
+// 定義にないキーや選択肢にない値はエラー。requireAll が true の場合は必須属性の欠落もエラーにする。
+func ValidateAttributes(defs []*CustomAttribute, values map[string]string, requireAll bool) []string {
+	var errs []string
+
+	byKey := make(map[string]*CustomAttribute, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := values[key]
+		def, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("attributes.%s is not defined", key))
+			continue
+		}
+		if !def.Allows(value) {
+			if def.Type == AttributeTypeDate {
+				errs = append(errs, fmt.Sprintf("attributes.%s must be in YYYY-MM-DD format", key))
+				continue
+			}
+			errs = append(errs, fmt.Sprintf("attributes.%s must be one of: %s", key, strings.Join(def.Options, ", ")))
+		}
+	}
+
+	if requireAll {
+		for _, def := range defs {
+			if _, ok := values[def.Key]; def.Required && !ok {
+				errs = append(errs, fmt.Sprintf("attributes.%s is required", def.Key))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// ユーザーのデータの削除依頼（DELETE /me）
+// 猶予期間の間（EraseAfter まで）は取り消せ、過ぎると保持期間のジョブがユーザーのデータを削除・匿名化する
+type ErasureRequest struct {
+	UserID      string    `json:"user_id"`
+	RequestedAt time.Time `json:"requested_at"`
+	EraseAfter  time.Time `json:"erase_after"` // この時刻を過ぎると削除する
+}
+
+func NewErasureRequest(userID string, now time.Time, gracePeriod time.Duration) *ErasureRequest {
+	// データベースには秒単位で保存されるため、返す値と保存する値をそろえる
+	now = now.UTC().Truncate(time.Second)
+	return &ErasureRequest{
+		UserID:      userID,
+		RequestedAt: now,
+		EraseAfter:  now.Add(gracePeriod),
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// エクスポートジョブのステータス
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// 非同期で生成するエクスポートファイル
+type ExportJob struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	Size        int        `json:"size,omitempty"` // 生成されたファイルのバイト数
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Data        []byte     `json:"-"`
+}
+
+func (j *ExportJob) IsDone() bool {
+	return j.Status == ExportStatusCompleted || j.Status == ExportStatusFailed
+}
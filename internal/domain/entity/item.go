@@ -1,45 +1,67 @@
 package entity
 
 import (
-	"errors"
+	"slices"
 	"strings"
 	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID              int64             `json:"id"`
+	Name            string            `json:"name"`
+	Category        string            `json:"category"`
+	Brand           string            `json:"brand"`
+	PurchasePrice   int               `json:"purchase_price"`             // 通貨の最小単位（円なら1円、米ドルなら1セント）の金額
+	Currency        string            `json:"currency,omitempty"`         // 購入価格の通貨（ISO 4217。空は DefaultCurrency）
+	PurchaseDate    string            `json:"purchase_date"`              // YYYY-MM-DD 形式
+	Attributes      map[string]string `json:"attributes,omitempty"`       // カスタム属性値（キー → 値）
+	OwnerID         string            `json:"owner_id,omitempty"`         // 所有者のユーザーID
+	MaintenanceCost int               `json:"maintenance_cost,omitempty"` // 整備記録の費用の合計
+	ImageIDs        []int64           `json:"image_ids,omitempty"`        // 画像のID（登録順）
+	Tags            []string          `json:"tags,omitempty"`             // タグの名前（名前順）
+	Version         int64             `json:"version"`                    // 楽観ロック用のバージョン（更新のたびに1増える）
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// ゴミ箱のアイテム（削除した日時つき）。完全に削除されるまで復元できる
+type TrashedItem struct {
+	*Item
+	DeletedAt time.Time `json:"deleted_at"`
 }
 
-// カテゴリー定義
+// 組み込みのカテゴリー定義（テナントはこのほかに独自のカテゴリーを追加できる）
 var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+// customCategories はテナントが追加したカテゴリー。組み込みのカテゴリーとともに有効なカテゴリーとして検証する。
+// 購入価格は DefaultCurrency の金額（ほかの通貨は登録後に Currency を設定する）
+func NewItem(name, category, brand string, purchasePrice int, purchaseDate string, customCategories ...string) (*Item, error) {
 	item := &Item{
-		Name:          strings.TrimSpace(name),
+		Name:          SanitizeString(name),
 		Category:      strings.TrimSpace(category),
-		Brand:         strings.TrimSpace(brand),
+		Brand:         SanitizeString(brand),
 		PurchasePrice: purchasePrice,
+		Currency:      DefaultCurrency,
 		PurchaseDate:  strings.TrimSpace(purchaseDate),
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
 
-	if err := item.Validate(); err != nil {
+	if err := item.validate(customCategories); err != nil {
 		return nil, err
 	}
 
 	return item, nil
 }
 
-// アイテムフィールドのバリデーション
+// アイテムフィールドのバリデーション（カテゴリーは組み込みのカテゴリーのみ有効）
 func (i *Item) Validate() error {
+	return i.validate(nil)
+}
+
+func (i *Item) validate(customCategories []string) error {
 	var errs []string
 
 	if i.Name == "" {
@@ -50,13 +72,12 @@ func (i *Item) Validate() error {
 
 	if i.Category == "" {
 		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+	} else if !IsValidCategory(i.Category, customCategories...) {
+		errs = append(errs, "category must be one of: "+strings.Join(append(GetValidCategories(), customCategories...), ", "))
 	}
 
-	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
+	// ブランドが必須かどうかはデプロイの設定（usecase の検証ルール）で決める
+	if len(i.Brand) > 100 {
 		errs = append(errs, "brand must be 100 characters or less")
 	}
 
@@ -64,6 +85,11 @@ func (i *Item) Validate() error {
 		errs = append(errs, "purchase_price must be 0 or greater")
 	}
 
+	// 空の通貨は DefaultCurrency とみなす（通貨を持つ前に保存されたアイテム）
+	if i.Currency != "" && !IsValidCurrency(i.Currency) {
+		errs = append(errs, "currency must be in ISO 4217 format (e.g. USD)")
+	}
+
 	if i.PurchaseDate == "" {
 		errs = append(errs, "purchase_date is required")
 	} else if !isValidDateFormat(i.PurchaseDate) {
@@ -71,22 +97,31 @@ func (i *Item) Validate() error {
 	}
 
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+		return domainErrors.NewValidationError(errs...)
 	}
 
 	return nil
 }
 
-// アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
-	i.Name = strings.TrimSpace(name)
+// 購入価格を通貨つきの金額で返す
+func (i *Item) Price() Money {
+	currency := i.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Amount: int64(i.PurchasePrice), Currency: currency}
+}
+
+// アイテムフィールドのアップデート（customCategories は NewItem と同じ）
+func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string, customCategories ...string) error {
+	i.Name = SanitizeString(name)
 	i.Category = strings.TrimSpace(category)
-	i.Brand = strings.TrimSpace(brand)
+	i.Brand = SanitizeString(brand)
 	i.PurchasePrice = purchasePrice
 	i.PurchaseDate = strings.TrimSpace(purchaseDate)
 	i.UpdatedAt = time.Now()
 
-	return i.Validate()
+	return i.validate(customCategories)
 }
 
 // カテゴリーのバリデーション
@@ -99,13 +134,23 @@ func isValidCategory(category string) bool {
 	return false
 }
 
+// 有効なカテゴリーかどうか（一覧の絞り込みなどで使う）。customCategories はテナントが追加したカテゴリー
+func IsValidCategory(category string, customCategories ...string) bool {
+	return isValidCategory(category) || slices.Contains(customCategories, category)
+}
+
 // デート形式のバリデーション
 func isValidDateFormat(dateStr string) bool {
 	_, err := time.Parse("2006-01-02", dateStr)
 	return err == nil
 }
 
-// カテゴリーの取得
+// 日付が YYYY-MM-DD 形式かどうか（リクエストの検証で使う）
+func IsValidDateFormat(dateStr string) bool {
+	return isValidDateFormat(dateStr)
+}
+
+// 組み込みのカテゴリーの取得（呼び出し側が変更しても定義は変わらないよう、コピーを返す）
 func GetValidCategories() []string {
-	return ValidCategories
+	return slices.Clone(ValidCategories)
 }
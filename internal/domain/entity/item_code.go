@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 読み取ってアイテムを探すためのコードの種類
+const (
+	ItemCodeSerial = "serial" // メーカーのシリアル番号（大文字・小文字を区別しない）
+	ItemCodeEAN    = "ean"    // 商品の JAN・EAN・UPC のバーコード
+	ItemCodeQR     = "qr"     // 保証書などに印刷された QR コードの内容（ラベルの QR コードは登録しなくても探せる）
+)
+
+// ItemCodeTypes はコードの種類（表示・エラーメッセージの順）
+var ItemCodeTypes = []string{ItemCodeSerial, ItemCodeEAN, ItemCodeQR}
+
+const maxItemCodeLength = 255
+
+// アイテムのシリアル番号・バーコード・QR コード。Code は種類ごとに正規化した値
+type ItemCode struct {
+	Type      string    `json:"type"`
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewItemCode(codeType, code string, now time.Time) (*ItemCode, error) {
+	codeType = strings.ToLower(strings.TrimSpace(codeType))
+	c := &ItemCode{Type: codeType, CreatedAt: now}
+
+	switch codeType {
+	case ItemCodeSerial:
+		c.Code = NormalizeSerialNumber(code)
+	case ItemCodeEAN:
+		normalized, ok := NormalizeEAN(code)
+		if !ok {
+			return nil, fmt.Errorf("ean %q must be an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit", strings.TrimSpace(code))
+		}
+		c.Code = normalized
+	case ItemCodeQR:
+		c.Code = SanitizeString(code)
+	default:
+		return nil, fmt.Errorf("type must be one of: %s", strings.Join(ItemCodeTypes, ", "))
+	}
+
+	if c.Code == "" {
+		return nil, errors.New("code is required")
+	}
+	if len(c.Code) > maxItemCodeLength {
+		return nil, fmt.Errorf("code must be %d characters or less", maxItemCodeLength)
+	}
+	return c, nil
+}
+
+// シリアル番号は前後の空白を除き、大文字にそろえる
+func NormalizeSerialNumber(code string) string {
+	return strings.ToUpper(SanitizeString(code))
+}
+
+// バーコードの数字を検証し、同じ商品が同じ値になるようそろえる。
+// UPC-A（12桁）と先頭が0の GTIN-14 は EAN-13 に、EAN-8 と先頭が0でない GTIN-14 はそのまま。
+// 数字以外を含むものや、チェックディジットが合わないものは false
+func NormalizeEAN(code string) (string, bool) {
+	code = strings.TrimSpace(code)
+	switch len(code) {
+	case 8, 12, 13, 14:
+	default:
+		return "", false
+	}
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		d := code[i]
+		if d < '0' || d > '9' {
+			return "", false
+		}
+		// 右端（チェックディジットの隣）から 3, 1, 3, ... の重み
+		weight := 1
+		if (len(code)-2-i)%2 == 0 {
+			weight = 3
+		}
+		sum += int(d-'0') * weight
+	}
+	if check := code[len(code)-1]; check < '0' || check > '9' || int(check-'0') != (10-sum%10)%10 {
+		return "", false
+	}
+
+	switch {
+	case len(code) == 12:
+		return "0" + code, true
+	case len(code) == 14 && code[0] == '0':
+		return code[1:], true
+	}
+	return code, true
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeEAN(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+		ok       bool
+	}{
+		{name: "正常系: JAN（EAN-13）", code: "4901234567894", expected: "4901234567894", ok: true},
+		{name: "正常系: EAN-8", code: "96385074", expected: "96385074", ok: true},
+		{name: "正常系: UPC-A は EAN-13 にそろえる", code: "036000291452", expected: "0036000291452", ok: true},
+		{name: "正常系: 先頭が0の GTIN-14 は EAN-13 にそろえる", code: " 04901234567894 ", expected: "4901234567894", ok: true},
+		{name: "正常系: 先頭が0でない GTIN-14 はそのまま", code: "14901234567891", expected: "14901234567891", ok: true},
+		{name: "異常系: チェックディジットが合わない", code: "4901234567895"},
+		{name: "異常系: 数字以外を含む", code: "49012345678A4"},
+		{name: "異常系: 桁数が違う", code: "490123456789"[:10]},
+		{name: "異常系: 空", code: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeEAN(tt.code)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNewItemCode(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		codeType    string
+		code        string
+		expected    *ItemCode
+		expectedErr string
+	}{
+		{name: "正常系: シリアル番号は大文字にそろえる", codeType: "serial", code: " z123456 ", expected: &ItemCode{Type: ItemCodeSerial, Code: "Z123456", CreatedAt: now}},
+		{name: "正常系: 種類は大文字・小文字を区別しない", codeType: "EAN", code: "036000291452", expected: &ItemCode{Type: ItemCodeEAN, Code: "0036000291452", CreatedAt: now}},
+		{name: "正常系: QR コードはそのまま", codeType: "qr", code: "https://example.com/warranty?id=AbC", expected: &ItemCode{Type: ItemCodeQR, Code: "https://example.com/warranty?id=AbC", CreatedAt: now}},
+		{name: "異常系: 不正な種類", codeType: "isbn", code: "4901234567894", expectedErr: "type must be one of: serial, ean, qr"},
+		{name: "異常系: 不正なバーコード", codeType: "ean", code: "4901234567895", expectedErr: `ean "4901234567895" must be an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit`},
+		{name: "異常系: 空のシリアル番号", codeType: "serial", code: "  ", expectedErr: "code is required"},
+		{name: "異常系: 長すぎる", codeType: "qr", code: strings.Repeat("a", 256), expectedErr: "code must be 255 characters or less"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewItemCode(tt.codeType, tt.code, now)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
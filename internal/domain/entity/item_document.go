@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの書類の種類
+const (
+	DocumentTypeReceipt     = "receipt"     // 購入時の領収書・レシート
+	DocumentTypeCertificate = "certificate" // 鑑定書・保証書などの真贋の証明書
+	DocumentTypeAppraisal   = "appraisal"   // 査定書・評価書
+)
+
+var documentTypes = map[string]bool{
+	DocumentTypeReceipt:     true,
+	DocumentTypeCertificate: true,
+	DocumentTypeAppraisal:   true,
+}
+
+// アイテムの書類（保険の請求などの証拠として残す PDF・画像。ファイルはデータベースではなくストレージに保存する）
+type ItemDocument struct {
+	ID          int64     `json:"id"`
+	ItemID      int64     `json:"item_id"`
+	Type        string    `json:"type"`
+	FileName    string    `json:"file_name"`        // アップロード時のファイル名
+	ContentType string    `json:"content_type"`     // ファイルの内容から判定した MIME タイプ
+	Size        int64     `json:"size"`             // バイト数
+	SHA256      string    `json:"sha256,omitempty"` // ファイルの内容の SHA-256（16進数）。破損・改ざんの確認用（導入前の書類は空）
+	StorageKey  string    `json:"-"`                // ストレージ上の名前（内容が同じファイルは同じ名前で1つだけ保存する）
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewItemDocument(itemID int64, docType, fileName, contentType string, size int64, sha256, storageKey string, now time.Time) (*ItemDocument, error) {
+	document := &ItemDocument{
+		ItemID:      itemID,
+		Type:        strings.ToLower(strings.TrimSpace(docType)),
+		FileName:    SanitizeString(filepath.Base(strings.ReplaceAll(fileName, `\`, "/"))),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      sha256,
+		StorageKey:  storageKey,
+		CreatedAt:   now,
+	}
+
+	if err := document.Validate(); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// 書類の種類として使える値か
+func IsDocumentType(docType string) bool {
+	return documentTypes[docType]
+}
+
+// 書類のバリデーション
+func (d *ItemDocument) Validate() error {
+	var errs []string
+
+	if d.Type == "" {
+		errs = append(errs, "type is required")
+	} else if !IsDocumentType(d.Type) {
+		errs = append(errs, "type must be one of: receipt, certificate, appraisal")
+	}
+
+	if d.FileName == "" || d.FileName == "." || d.FileName == "/" {
+		errs = append(errs, "file_name is required")
+	} else if len(d.FileName) > 255 {
+		errs = append(errs, "file_name must be 255 characters or less")
+	}
+
+	if d.Size <= 0 {
+		errs = append(errs, "file must not be empty")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
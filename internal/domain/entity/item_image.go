@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの画像（ファイルはデータベースではなく画像ストレージに保存する）
+type ItemImage struct {
+	ID          int64     `json:"id"`
+	ItemID      int64     `json:"item_id"`
+	FileName    string    `json:"file_name"`        // アップロード時のファイル名
+	ContentType string    `json:"content_type"`     // ファイルの内容から判定した MIME タイプ
+	Size        int64     `json:"size"`             // バイト数
+	SHA256      string    `json:"sha256,omitempty"` // ファイルの内容の SHA-256（16進数）。破損・改ざんの確認用（導入前の画像は空）
+	StorageKey  string    `json:"-"`                // 画像ストレージ上の名前（内容が同じファイルは同じ名前で1つだけ保存する）
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewItemImage(itemID int64, fileName, contentType string, size int64, sha256, storageKey string, now time.Time) (*ItemImage, error) {
+	image := &ItemImage{
+		ItemID:      itemID,
+		FileName:    SanitizeString(filepath.Base(strings.ReplaceAll(fileName, `\`, "/"))),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      sha256,
+		StorageKey:  storageKey,
+		CreatedAt:   now,
+	}
+
+	if err := image.Validate(); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// 画像のバリデーション
+func (i *ItemImage) Validate() error {
+	var errs []string
+
+	if i.FileName == "" || i.FileName == "." || i.FileName == "/" {
+		errs = append(errs, "file_name is required")
+	} else if len(i.FileName) > 255 {
+		errs = append(errs, "file_name must be 255 characters or less")
+	}
+
+	if i.Size <= 0 {
+		errs = append(errs, "file must not be empty")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// アイテムのリビジョン（登録・更新のたびに記録される、その時点のアイテムの内容）
+type ItemRevision struct {
+	ID        int64         `json:"id"`
+	ItemID    int64         `json:"item_id"`
+	Revision  int64         `json:"revision"` // 記録した時点のアイテムのバージョン
+	Snapshot  ItemSnapshot  `json:"snapshot"`
+	Changes   []FieldChange `json:"changes"`              // 1つ前のリビジョンからの変更（最初のリビジョンは登録時の値）
+	ChangedBy string        `json:"changed_by,omitempty"` // 登録・更新したユーザー
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// リビジョンに記録するアイテムの内容
+type ItemSnapshot struct {
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchasePrice int               `json:"purchase_price"`
+	PurchaseDate  string            `json:"purchase_date"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// フィールドの変更（属性は attributes.<キー>。値のないほうは null）
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+func NewItemSnapshot(item *Item) ItemSnapshot {
+	snapshot := ItemSnapshot{
+		Name:          item.Name,
+		Category:      item.Category,
+		Brand:         item.Brand,
+		PurchasePrice: item.PurchasePrice,
+		PurchaseDate:  item.PurchaseDate,
+	}
+	if len(item.Attributes) > 0 {
+		snapshot.Attributes = make(map[string]string, len(item.Attributes))
+		for key, value := range item.Attributes {
+			snapshot.Attributes[key] = value
+		}
+	}
+	return snapshot
+}
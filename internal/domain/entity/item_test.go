@@ -1,11 +1,14 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 func TestNewItem(t *testing.T) {
@@ -69,14 +72,14 @@ func TestNewItem(t *testing.T) {
 			expectedErr:   "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
 		},
 		{
-			name:          "異常系: ブランドが空",
+			// ブランドが必須かどうかは usecase の検証ルールで決める
+			name:          "正常系: ブランドが空",
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "",
 			purchasePrice: 1500000,
 			purchaseDate:  "2023-01-15",
-			wantErr:       true,
-			expectedErr:   "brand is required",
+			wantErr:       false,
 		},
 		{
 			name:          "異常系: ブランドが100文字超過",
@@ -259,7 +262,20 @@ func TestItem_Validate(t *testing.T) {
 				PurchaseDate:  "",
 			},
 			wantErr:     true,
-			expectedErr: "name is required, category is required, brand is required, purchase_price must be 0 or greater, purchase_date is required",
+			expectedErr: "name is required, category is required, purchase_price must be 0 or greater, purchase_date is required",
+		},
+		{
+			name: "異常系: ISO 4217 の形式でない通貨",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1234,
+				Currency:      "usd",
+				PurchaseDate:  "2023-01-15",
+			},
+			wantErr:     true,
+			expectedErr: "currency must be in ISO 4217 format (e.g. USD)",
 		},
 	}
 
@@ -268,8 +284,8 @@ func TestItem_Validate(t *testing.T) {
 			err := tt.item.Validate()
 
 			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Equal(t, tt.expectedErr, err.Error())
+				assert.True(t, domainErrors.IsValidationError(err))
+				assert.Equal(t, "invalid input: "+tt.expectedErr, err.Error())
 				return
 			}
 
@@ -335,3 +351,182 @@ func TestGetValidCategories(t *testing.T) {
 	assert.Equal(t, expected, categories)
 	assert.Len(t, categories, 5)
 }
+
+func TestCategoryDisplayName(t *testing.T) {
+	assert.Equal(t, "Watches", CategoryDisplayName("時計", "en"))
+	assert.Equal(t, "時計", CategoryDisplayName("時計", "ja"))
+	// 表示名のない言語・カテゴリーはそのまま
+	assert.Equal(t, "時計", CategoryDisplayName("時計", "fr"))
+	assert.Equal(t, "家具", CategoryDisplayName("家具", "en"))
+
+	names := CategoryDisplayNames("en", []string{"その他", "家具"})
+	assert.Equal(t, map[string]string{"その他": "Other", "家具": "家具"}, names)
+}
+
+func TestNewItem_CustomCategories(t *testing.T) {
+	t.Run("正常系: テナントが追加したカテゴリー", func(t *testing.T) {
+		item, err := NewItem("イス", "家具", "IKEA", 5000, "2023-02-01", "家具", "家電")
+
+		require.NoError(t, err)
+		assert.Equal(t, "家具", item.Category)
+	})
+
+	t.Run("異常系: 追加されていないカテゴリーは有効なカテゴリーを示す", func(t *testing.T) {
+		_, err := NewItem("イス", "車", "IKEA", 5000, "2023-02-01", "家具")
+
+		assert.EqualError(t, err, "invalid input: category must be one of: 時計, バッグ, ジュエリー, 靴, その他, 家具")
+	})
+}
+
+func TestNewCategory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		{name: "正常系: 前後の空白を除く", input: "  家具 ", expected: "家具"},
+		{name: "正常系: 50文字ちょうど（マルチバイト）", input: strings.Repeat("家", 50), expected: strings.Repeat("家", 50)},
+		{name: "異常系: 空", input: " ", expectedErr: "name is required"},
+		{name: "異常系: 51文字", input: strings.Repeat("家", 51), expectedErr: "name must be 50 characters or less"},
+		{name: "異常系: 組み込みのカテゴリー", input: "時計", expectedErr: "name must not be a built-in category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory(tt.input, now)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &Category{Name: tt.expected, CreatedAt: now}, category)
+		})
+	}
+}
+
+func TestSortedCategories(t *testing.T) {
+	counts := map[string]int{"家電": 1, "時計": 2, "家具": 3}
+
+	assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴", "その他", "家具", "家電"}, SortedCategories(counts))
+}
+
+func TestSanitizeString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"正常系: 通常の文字列", "ロレックス デイトナ", "ロレックス デイトナ"},
+		{"正常系: 前後の空白を除去", "  ROLEX  ", "ROLEX"},
+		{"正常系: 制御文字を除去", "ROL\x00EX\x1b[31m\n", "ROLEX[31m"},
+		{"正常系: 双方向制御文字を除去", "abc\u202edef", "abcdef"},
+		{"正常系: 不正なUTF-8を除去", "RO\xffLEX", "ROLEX"},
+		{"正常系: HTMLはエスケープせずに保存する", "<b>Tiffany & Co.</b>", "<b>Tiffany & Co.</b>"},
+		{"正常系: 何度サニタイズしても変わらない", "Tiffany &amp; Co.", "Tiffany &amp; Co."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SanitizeString(tt.input))
+			assert.Equal(t, tt.want, SanitizeString(SanitizeString(tt.input)))
+		})
+	}
+}
+
+func TestNewItem_Sanitize(t *testing.T) {
+	item, err := NewItem("<script>alert(1)</script>\x07", "時計", "ROLEX\u202e", 1000, "2023-01-15")
+	require.NoError(t, err)
+
+	assert.Equal(t, "<script>alert(1)</script>", item.Name)
+	assert.Equal(t, "ROLEX", item.Brand)
+
+	t.Run("正常系: 長さはエスケープ前の値で検証する", func(t *testing.T) {
+		// 100バイトちょうどの名前は、エスケープすると100バイトを超えるが登録できる
+		name := strings.Repeat("&", 100)
+		item, err := NewItem(name, "時計", "ROLEX", 1000, "2023-01-15")
+		require.NoError(t, err)
+		assert.Equal(t, name, item.Name)
+
+		_, err = NewItem(name+"&", "時計", "ROLEX", 1000, "2023-01-15")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewCustomAttribute(t *testing.T) {
+	t.Run("正常系: 型を省略すると選択肢型", func(t *testing.T) {
+		attr, err := NewCustomAttribute("storage_box", "保管ボックス", "", []string{"A-1"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, AttributeTypeEnum, attr.Type)
+	})
+
+	t.Run("正常系: 日付型は選択肢なしで定義する", func(t *testing.T) {
+		attr, err := NewCustomAttribute("warranty_expires", "保証期限", AttributeTypeDate, nil, false)
+		require.NoError(t, err)
+		assert.True(t, attr.Allows("2026-03-31"))
+		assert.False(t, attr.Allows("来年"))
+	})
+
+	t.Run("異常系: 日付型に選択肢", func(t *testing.T) {
+		_, err := NewCustomAttribute("warranty_expires", "保証期限", AttributeTypeDate, []string{"2026-03-31"}, false)
+		assert.EqualError(t, err, "invalid input: options must be empty for date attributes")
+	})
+
+	t.Run("異常系: 選択肢型に選択肢がない", func(t *testing.T) {
+		_, err := NewCustomAttribute("storage_box", "保管ボックス", AttributeTypeEnum, nil, false)
+		assert.EqualError(t, err, "invalid input: options is required")
+	})
+}
+
+func TestValidateAttributes(t *testing.T) {
+	defs := []*CustomAttribute{
+		{Key: "storage_box", Label: "保管ボックス", Type: AttributeTypeEnum, Options: []string{"A-1", "A-2"}, Required: true},
+		{Key: "condition", Label: "状態", Type: AttributeTypeEnum, Options: []string{"新品", "中古"}},
+		{Key: "warranty_expires", Label: "保証期限", Type: AttributeTypeDate},
+	}
+
+	tests := []struct {
+		name       string
+		values     map[string]string
+		requireAll bool
+		expected   []string
+	}{
+		{
+			name:       "正常系: 定義どおりの値",
+			values:     map[string]string{"storage_box": "A-1", "condition": "中古"},
+			requireAll: true,
+		},
+		{
+			name:     "正常系: 部分更新では必須チェックをしない",
+			values:   map[string]string{"condition": "新品"},
+			expected: nil,
+		},
+		{
+			name:   "正常系: 日付型の値",
+			values: map[string]string{"warranty_expires": "2026-03-31"},
+		},
+		{
+			name:     "異常系: 日付型に日付以外の値",
+			values:   map[string]string{"warranty_expires": "2026/03/31"},
+			expected: []string{"attributes.warranty_expires must be in YYYY-MM-DD format"},
+		},
+		{
+			name:       "異常系: 選択肢外・未定義・必須欠落",
+			values:     map[string]string{"condition": "不明", "color": "red"},
+			requireAll: true,
+			expected: []string{
+				"attributes.color is not defined",
+				"attributes.condition must be one of: 新品, 中古",
+				"attributes.storage_box is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidateAttributes(defs, tt.values, tt.requireAll))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+
+	// 1ページあたりの最大件数（0は全件）
+	MaxPageSize = 100
+)
+
+// 一覧取得のデフォルト設定（テナント単位）
+// アイテムにはアーカイブの状態がないため、「アーカイブ済みを含めるか」の設定は持たない
+type ListSettings struct {
+	SortBy    string    `json:"sort_by"`
+	SortOrder string    `json:"sort_order"`
+	PageSize  int       `json:"page_size"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ソート可能なフィールド。current_value は最新の時価の評価額（評価のないアイテムは昇順・降順とも最後）
+var SortableFields = []string{"created_at", "updated_at", "purchase_date", "purchase_price", "name", "current_value"}
+
+// 設定が保存されていない場合のデフォルト（作成日時の降順、全件）
+func DefaultListSettings() *ListSettings {
+	return &ListSettings{
+		SortBy:    "created_at",
+		SortOrder: SortDesc,
+		PageSize:  0,
+	}
+}
+
+func NewListSettings(sortBy, sortOrder string, pageSize int) (*ListSettings, error) {
+	defaults := DefaultListSettings()
+
+	s := &ListSettings{
+		SortBy:    strings.TrimSpace(sortBy),
+		SortOrder: strings.ToLower(strings.TrimSpace(sortOrder)),
+		PageSize:  pageSize,
+		UpdatedAt: time.Now(),
+	}
+	if s.SortBy == "" {
+		s.SortBy = defaults.SortBy
+	}
+	if s.SortOrder == "" {
+		s.SortOrder = defaults.SortOrder
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// 一覧設定のバリデーション
+func (s *ListSettings) Validate() error {
+	var errs []string
+
+	if !IsSortableField(s.SortBy) {
+		errs = append(errs, fmt.Sprintf("sort_by must be one of: %s", strings.Join(SortableFields, ", ")))
+	}
+
+	if s.SortOrder != SortAsc && s.SortOrder != SortDesc {
+		errs = append(errs, "sort_order must be asc or desc")
+	}
+
+	if s.PageSize < 0 || s.PageSize > MaxPageSize {
+		errs = append(errs, fmt.Sprintf("page_size must be between 0 and %d", MaxPageSize))
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+// ソート可能なフィールドかどうか
+func IsSortableField(field string) bool {
+	for _, f := range SortableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 貸出ステータス
+const (
+	LoanStatusActive   = "active"
+	LoanStatusReturned = "returned"
+)
+
+// アイテムの貸出（友人やギャラリーへの貸し出し。返却されるまで削除できない）
+type Loan struct {
+	ID         int64      `json:"id"`
+	ItemID     int64      `json:"item_id"`
+	Lender     string     `json:"lender,omitempty"` // 貸し出したユーザー
+	Borrower   string     `json:"borrower"`         // 借り手（ユーザーではない相手も記録できる）
+	DueDate    string     `json:"due_date"`         // 返却期限（YYYY-MM-DD）
+	Note       string     `json:"note,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+}
+
+func NewLoan(itemID int64, lender, borrower, dueDate, note string, now time.Time) (*Loan, error) {
+	l := &Loan{
+		ItemID:    itemID,
+		Lender:    strings.TrimSpace(lender),
+		Borrower:  SanitizeString(borrower),
+		DueDate:   strings.TrimSpace(dueDate),
+		Note:      SanitizeString(note),
+		Status:    LoanStatusActive,
+		CreatedAt: now,
+	}
+
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	// 過去の日付を期限にした貸出は作らない（登録済みの貸出は期限が過ぎても有効）
+	if l.DueDate < now.Format("2006-01-02") {
+		return nil, errors.New("due_date must not be in the past")
+	}
+
+	return l, nil
+}
+
+// 貸出のバリデーション
+func (l *Loan) Validate() error {
+	var errs []string
+
+	if l.Borrower == "" {
+		errs = append(errs, "borrower is required")
+	} else if len(l.Borrower) > 100 {
+		errs = append(errs, "borrower must be 100 characters or less")
+	}
+
+	if l.DueDate == "" {
+		errs = append(errs, "due_date is required")
+	} else if !isValidDateFormat(l.DueDate) {
+		errs = append(errs, "due_date must be in YYYY-MM-DD format")
+	}
+
+	if len(l.Note) > 500 {
+		errs = append(errs, "note must be 500 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+func (l *Loan) IsActive() bool {
+	return l.Status == LoanStatusActive
+}
+
+// 返却期限を過ぎても返却されていないか（期限日の当日中は延滞としない）
+func (l *Loan) IsOverdue(now time.Time) bool {
+	return l.IsActive() && l.DueDate < now.Format("2006-01-02")
+}
+
+// 返却済みにする
+func (l *Loan) Return(at time.Time) {
+	l.Status = LoanStatusReturned
+	l.ReturnedAt = &at
+}
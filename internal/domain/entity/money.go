@@ -0,0 +1,192 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 通貨を指定せずに登録したアイテムの通貨（通貨を持つ前に登録されたアイテムもこの通貨）
+const DefaultCurrency = "JPY"
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// 最小単位が1でない通貨の小数点以下の桁数（ほかの通貨は2桁。円などの補助単位のない通貨は0桁）
+var currencyDigits = map[string]int{
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "PYG": 0, "UGX": 0, "VND": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// 通貨の金額の小数点以下の桁数（最小単位が主単位の 1/10^桁数）
+func CurrencyDigits(currency string) int {
+	if digits, ok := currencyDigits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// 通貨コードが ISO 4217 の形式（大文字3文字）かどうか
+func IsValidCurrency(currency string) bool {
+	return currencyCode.MatchString(currency)
+}
+
+// 通貨の最小単位（円なら1円、米ドルなら1セント）の整数で持つ金額
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// 10進数の表記（"12.34"）の金額を、通貨の最小単位の Money にする。小数点以下は通貨の桁数まで
+func ParseMoney(text, currency string) (Money, error) {
+	digits := CurrencyDigits(currency)
+	text = strings.TrimSpace(text)
+	negative, whole, fraction, ok := splitDecimal(text)
+	if !ok {
+		return Money{}, fmt.Errorf("must be a decimal number such as 12.34: %q", text)
+	}
+	if len(strings.TrimRight(fraction, "0")) > digits {
+		if digits == 0 {
+			return Money{}, fmt.Errorf("must be a whole number in %s: %q", currency, text)
+		}
+		return Money{}, fmt.Errorf("must have at most %d decimal places in %s: %q", digits, currency, text)
+	}
+
+	fraction = (fraction + strings.Repeat("0", digits))[:digits]
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("is too large: %q", text)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// 10進数の表記（USD の 1234 なら "12.34"、JPY の 1500000 なら "1500000"）
+func (m Money) String() string {
+	digits := CurrencyDigits(m.Currency)
+	text := strconv.FormatInt(m.Amount, 10)
+	if digits == 0 {
+		return text
+	}
+	sign := ""
+	if m.Amount < 0 {
+		sign, text = "-", text[1:]
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
+
+// API で受け取る金額。JSON の整数は通貨の最小単位（1234 は USD なら12.34ドル）、
+// 文字列は主単位の10進数（"12.34"）の金額で、通貨が決まってから Money にする
+type Amount struct {
+	minor   int64
+	decimal string
+	// 整数でも10進数の文字列でもなかった値（Validate と Money でエラーにする）
+	invalid string
+}
+
+// 通貨の最小単位の金額
+func MinorUnits(amount int64) Amount {
+	return Amount{minor: amount}
+}
+
+// 主単位の10進数の表記（"12.34"）の金額
+func DecimalAmount(text string) Amount {
+	return Amount{decimal: text}
+}
+
+// 10進数の表記の金額を読む（小数点以下の桁数は、通貨が決まってから Money で確かめる）
+func ParseAmount(text string) (Amount, error) {
+	text = strings.TrimSpace(text)
+	if _, _, _, ok := splitDecimal(text); !ok {
+		return Amount{}, fmt.Errorf("must be a decimal number such as 12.34: %q", text)
+	}
+	return DecimalAmount(text), nil
+}
+
+// 通貨によらない検証（表記と符号）。エラーはフィールド名に続くメッセージ（"must be ..."）
+func (a Amount) Validate() error {
+	if a.invalid != "" {
+		return fmt.Errorf("must be an integer (minor units) or a decimal string such as \"12.34\": %s", a.invalid)
+	}
+	if a.decimal == "" && a.minor < 0 || strings.HasPrefix(strings.TrimSpace(a.decimal), "-") {
+		return errors.New("must be 0 or greater")
+	}
+	return nil
+}
+
+// 通貨の金額にする。表記が不正か、小数点以下が通貨の桁数より多ければエラー
+func (a Amount) Money(currency string) (Money, error) {
+	if a.invalid != "" {
+		return Money{}, a.Validate()
+	}
+	if a.decimal == "" {
+		return Money{Amount: a.minor, Currency: currency}, nil
+	}
+	return ParseMoney(a.decimal, currency)
+}
+
+// 整数は最小単位、文字列は10進数として読む。小数の数値や不正な文字列はエラーにせず、Validate で報告する
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if amount, err := ParseAmount(text); err == nil {
+			*a = amount
+		} else {
+			*a = Amount{invalid: strconv.Quote(text)}
+		}
+		return nil
+	}
+	if minor, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+		*a = MinorUnits(minor)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(Amount{})}
+	}
+	*a = Amount{invalid: number.String()}
+	return nil
+}
+
+// 受け取ったときの形（整数または10進数の文字列）で書き出す
+func (a Amount) MarshalJSON() ([]byte, error) {
+	switch {
+	case a.invalid != "":
+		return []byte(a.invalid), nil
+	case a.decimal != "":
+		return json.Marshal(a.decimal)
+	}
+	return strconv.AppendInt(nil, a.minor, 10), nil
+}
+
+// 10進数の表記を符号・整数部・小数部に分ける。ok は表記が正しいかどうか
+func splitDecimal(text string) (negative bool, whole, fraction string, ok bool) {
+	negative = strings.HasPrefix(text, "-")
+	whole, fraction, hasPoint := strings.Cut(strings.TrimPrefix(text, "-"), ".")
+	ok = whole != "" && isDigits(whole) && (!hasPoint || (fraction != "" && isDigits(fraction)))
+	return negative, whole, fraction, ok
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		currency string
+		expected int64
+		err      string
+	}{
+		{name: "正常系: 米ドルはセント", text: "12.34", currency: "USD", expected: 1234},
+		{name: "正常系: 小数点以下を省略", text: "12", currency: "USD", expected: 1200},
+		{name: "正常系: 桁数より短い小数", text: "0.5", currency: "EUR", expected: 50},
+		{name: "正常系: 末尾の0は桁数に数えない", text: "1500000.00", currency: "JPY", expected: 1500000},
+		{name: "正常系: 3桁の通貨", text: "1.234", currency: "KWD", expected: 1234},
+		{name: "正常系: 負の金額", text: "-1.05", currency: "USD", expected: -105},
+		{name: "異常系: 桁数より多い小数", text: "12.345", currency: "USD", err: `must have at most 2 decimal places in USD: "12.345"`},
+		{name: "異常系: 円の小数", text: "0.5", currency: "JPY", err: `must be a whole number in JPY: "0.5"`},
+		{name: "異常系: 数ではない", text: "1,000", currency: "JPY", err: `must be a decimal number such as 12.34: "1,000"`},
+		{name: "異常系: 小数点だけ", text: "12.", currency: "USD", err: `must be a decimal number such as 12.34: "12."`},
+		{name: "異常系: 大きすぎる", text: "99999999999999999999", currency: "JPY", err: `is too large: "99999999999999999999"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			money, err := ParseMoney(tt.text, tt.currency)
+
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, Money{Amount: tt.expected, Currency: tt.currency}, money)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "12.34", Money{Amount: 1234, Currency: "USD"}.String())
+	assert.Equal(t, "0.05", Money{Amount: 5, Currency: "USD"}.String())
+	assert.Equal(t, "-0.05", Money{Amount: -5, Currency: "USD"}.String())
+	assert.Equal(t, "1.250", Money{Amount: 1250, Currency: "KWD"}.String())
+	assert.Equal(t, "1500000", Money{Amount: 1500000, Currency: "JPY"}.String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		currency string
+		expected int64
+		err      string
+	}{
+		{name: "正常系: 整数は最小単位", json: `1234`, currency: "USD", expected: 1234},
+		{name: "正常系: 文字列は10進数", json: `"12.34"`, currency: "USD", expected: 1234},
+		{name: "正常系: 円の整数の文字列", json: `"1500000"`, currency: "JPY", expected: 1500000},
+		{name: "異常系: 整数でない数値", json: `12.34`, currency: "USD", err: `must be an integer (minor units) or a decimal string such as "12.34": 12.34`},
+		{name: "異常系: 数ではない文字列", json: `"abc"`, currency: "USD", err: `must be an integer (minor units) or a decimal string such as "12.34": "abc"`},
+		{name: "異常系: 通貨の桁数より多い小数", json: `"12.345"`, currency: "USD", err: `must have at most 2 decimal places in USD: "12.345"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var amount Amount
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &amount))
+
+			// 受け取った形のまま書き出す
+			encoded, err := json.Marshal(amount)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(encoded))
+
+			money, err := amount.Money(tt.currency)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, amount.Validate())
+			assert.Equal(t, Money{Amount: tt.expected, Currency: tt.currency}, money)
+		})
+	}
+
+	t.Run("異常系: 数でも文字列でもない", func(t *testing.T) {
+		var amount Amount
+		var typeErr *json.UnmarshalTypeError
+		assert.ErrorAs(t, json.Unmarshal([]byte(`true`), &amount), &typeErr)
+	})
+}
+
+func TestAmount_Validate(t *testing.T) {
+	assert.NoError(t, MinorUnits(0).Validate())
+	assert.NoError(t, DecimalAmount("0.01").Validate())
+	assert.EqualError(t, MinorUnits(-1).Validate(), "must be 0 or greater")
+	assert.EqualError(t, DecimalAmount("-0.01").Validate(), "must be 0 or greater")
+}
+
+func TestItem_Price(t *testing.T) {
+	// 通貨を持つ前に保存されたアイテムは DefaultCurrency
+	assert.Equal(t, Money{Amount: 1500000, Currency: DefaultCurrency}, (&Item{PurchasePrice: 1500000}).Price())
+	assert.Equal(t, Money{Amount: 1234, Currency: "USD"}, (&Item{PurchasePrice: 1234, Currency: "USD"}).Price())
+}
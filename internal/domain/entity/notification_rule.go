@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メール通知の対象にできるイベント（usecase のアイテムイベントの種類と同じ）
+var NotificationEventTypes = []string{"item.created", "item.deleted", "item.expiring"}
+
+// 1つのルールの宛先の上限
+const maxNotificationRecipients = 10
+
+// 高額アイテムの登録・削除・期限の接近をメールで知らせるルール
+type NotificationRule struct {
+	ID         int64     `json:"id"`
+	MinPrice   int       `json:"min_price"`  // この購入価格以上のアイテムを通知する
+	Events     []string  `json:"events"`     // 通知するイベント（空ならすべて）
+	Recipients []string  `json:"recipients"` // 宛先のメールアドレス
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewNotificationRule(minPrice int, events []string, recipients []string) (*NotificationRule, error) {
+	r := &NotificationRule{
+		MinPrice:   minPrice,
+		Events:     dedupe(events),
+		Recipients: dedupe(recipients),
+		CreatedAt:  time.Now(),
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// 通知ルールのバリデーション
+func (r *NotificationRule) Validate() error {
+	var errs []string
+
+	if r.MinPrice < 0 {
+		errs = append(errs, "min_price must be 0 or greater")
+	}
+
+	for _, event := range r.Events {
+		if !isNotificationEventType(event) {
+			errs = append(errs, "events must be one of "+strings.Join(NotificationEventTypes, ", "))
+			break
+		}
+	}
+
+	if len(r.Recipients) == 0 {
+		errs = append(errs, "recipients is required")
+	} else if len(r.Recipients) > maxNotificationRecipients {
+		errs = append(errs, "recipients must contain "+strconv.Itoa(maxNotificationRecipients)+" addresses or less")
+	} else {
+		for _, recipient := range r.Recipients {
+			// 表示名付き（"名前 <addr>"）ではなくアドレスだけを受け付ける
+			if addr, err := mail.ParseAddress(recipient); err != nil || addr.Address != recipient {
+				errs = append(errs, "recipients must be email addresses")
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+// イベントの種類と購入価格がルールに当てはまるか
+func (r *NotificationRule) Matches(eventType string, purchasePrice int) bool {
+	if purchasePrice < r.MinPrice {
+		return false
+	}
+	if len(r.Events) == 0 {
+		return isNotificationEventType(eventType)
+	}
+	for _, event := range r.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotificationEventType(eventType string) bool {
+	for _, t := range NotificationEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// 前後の空白を除き、重複を1つにまとめる
+func dedupe(values []string) []string {
+	result := []string{}
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationRule_Matches(t *testing.T) {
+	tests := []struct {
+		name          string
+		rule          NotificationRule
+		eventType     string
+		purchasePrice int
+		expected      bool
+	}{
+		{name: "正常系: しきい値ちょうどの登録", rule: NotificationRule{MinPrice: 1000000}, eventType: "item.created", purchasePrice: 1000000, expected: true},
+		{name: "正常系: しきい値未満は通知しない", rule: NotificationRule{MinPrice: 1000000}, eventType: "item.deleted", purchasePrice: 999999, expected: false},
+		{name: "正常系: 更新は通知しない", rule: NotificationRule{MinPrice: 0}, eventType: "item.updated", purchasePrice: 2000000, expected: false},
+		{name: "正常系: 指定したイベントのみ通知", rule: NotificationRule{MinPrice: 0, Events: []string{"item.deleted"}}, eventType: "item.created", purchasePrice: 2000000, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rule.Matches(tt.eventType, tt.purchasePrice))
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// ある日のカテゴリーごとのアイテムの評価額の記録（毎晩記録する）
+type PortfolioSnapshot struct {
+	TakenOn       string    `json:"taken_on"` // 記録した日（YYYY-MM-DD）
+	Category      string    `json:"category"`
+	ItemCount     int       `json:"item_count"`
+	Value         int       `json:"value"`          // 時価（最新の評価額）の合計。評価のないアイテムは購入価格で数える
+	PurchaseTotal int       `json:"purchase_total"` // 購入価格の合計
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// 期限の通知（保証期限・保険の満期日など、日付型のカスタム属性の日付が近づいたアイテム）
+type ExpiryReminder struct {
+	ItemID     int64     `json:"item_id"`
+	Attribute  string    `json:"attribute"`  // 日付型のカスタム属性のキー
+	ExpiresOn  string    `json:"expires_on"` // 期限（YYYY-MM-DD）
+	DaysLeft   int       `json:"days_left"`  // 通知した日から期限までの日数
+	RemindedAt time.Time `json:"reminded_at"`
+}
+
+// 期限の通知の一時停止（アイテム単位）
+type ReminderSnooze struct {
+	ItemID    int64     `json:"item_id"`
+	Until     string    `json:"until"` // この日から通知を再開する（YYYY-MM-DD）
+	SnoozedBy string    `json:"snoozed_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewReminderSnooze(itemID int64, actor, until string, now time.Time) (*ReminderSnooze, error) {
+	s := &ReminderSnooze{
+		ItemID:    itemID,
+		Until:     strings.TrimSpace(until),
+		SnoozedBy: strings.TrimSpace(actor),
+		CreatedAt: now,
+	}
+
+	if s.Until == "" {
+		return nil, errors.New("until is required")
+	}
+	if !isValidDateFormat(s.Until) {
+		return nil, errors.New("until must be in YYYY-MM-DD format")
+	}
+	// 今日までの停止は何も止めないので受け付けない
+	if s.Until <= now.Format("2006-01-02") {
+		return nil, errors.New("until must be after today")
+	}
+
+	return s, nil
+}
+
+// 指定の日に通知を止めているか
+func (s *ReminderSnooze) Active(today string) bool {
+	return s != nil && today < s.Until
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReminderSnooze(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 翌日以降まで止める", func(t *testing.T) {
+		snooze, err := NewReminderSnooze(1, " alice ", "2024-04-01", now)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", snooze.SnoozedBy)
+		assert.True(t, snooze.Active("2024-03-31"))
+		assert.False(t, snooze.Active("2024-04-01"))
+	})
+
+	tests := []struct {
+		name     string
+		until    string
+		expected string
+	}{
+		{name: "異常系: 日付がない", until: "", expected: "until is required"},
+		{name: "異常系: 不正な日付", until: "2024/04/01", expected: "until must be in YYYY-MM-DD format"},
+		{name: "異常系: 今日", until: "2024-03-10", expected: "until must be after today"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReminderSnooze(1, "alice", tt.until, now)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ユーザー入力文字列のサニタイズ
+// 前後の空白を除去し、制御文字・不正なUTF-8を取り除く。
+// HTMLエスケープは保存時には行わない（保存する値を長さの検証の対象にし、PATCH のたびに二重にエスケープしないため）。
+// SANITIZE_HTML によるエスケープはレスポンスの出力時に serializer が行う。
+func SanitizeString(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "")
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || isBidiControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// 表示順序を書き換える双方向制御文字の判定
+func isBidiControl(r rune) bool {
+	return (r >= 0x202A && r <= 0x202E) || (r >= 0x2066 && r <= 0x2069)
+}
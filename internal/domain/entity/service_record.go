@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの整備・修理の記録（時計のオーバーホールなど）
+type ServiceRecord struct {
+	ID          int64     `json:"id"`
+	ItemID      int64     `json:"item_id"`
+	ServiceDate string    `json:"service_date"` // 整備した日（YYYY-MM-DD）
+	Vendor      string    `json:"vendor"`       // 整備した業者
+	Cost        int       `json:"cost"`
+	Notes       string    `json:"notes,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewServiceRecord(itemID int64, serviceDate, vendor string, cost int, notes string, now time.Time) (*ServiceRecord, error) {
+	s := &ServiceRecord{
+		ItemID:    itemID,
+		CreatedAt: now,
+	}
+	if err := s.Change(serviceDate, vendor, cost, notes, now); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// 記録の内容を置き換える（整備履歴なので未来の日付は受け付けない）
+func (s *ServiceRecord) Change(serviceDate, vendor string, cost int, notes string, now time.Time) error {
+	s.ServiceDate = strings.TrimSpace(serviceDate)
+	s.Vendor = SanitizeString(vendor)
+	s.Cost = cost
+	s.Notes = SanitizeString(notes)
+	s.UpdatedAt = now
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if s.ServiceDate > now.Format("2006-01-02") {
+		return errors.New("service_date must not be in the future")
+	}
+
+	return nil
+}
+
+// 整備記録のバリデーション
+func (s *ServiceRecord) Validate() error {
+	var errs []string
+
+	if s.ServiceDate == "" {
+		errs = append(errs, "service_date is required")
+	} else if !isValidDateFormat(s.ServiceDate) {
+		errs = append(errs, "service_date must be in YYYY-MM-DD format")
+	}
+
+	if s.Vendor == "" {
+		errs = append(errs, "vendor is required")
+	} else if len(s.Vendor) > 100 {
+		errs = append(errs, "vendor must be 100 characters or less")
+	}
+
+	if s.Cost < 0 {
+		errs = append(errs, "cost must be 0 or greater")
+	}
+
+	if len(s.Notes) > 1000 {
+		errs = append(errs, "notes must be 1000 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
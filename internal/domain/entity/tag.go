@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const maxTagNameLength = 50
+
+// アイテムに付けるタグ（名前は大文字・小文字を区別せず一意）
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ItemCount int       `json:"item_count"` // タグが付いているアイテムの数
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewTag(name string, now time.Time) (*Tag, error) {
+	tag := &Tag{
+		Name:      NormalizeTagName(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// 前後の空白を除き、連続する空白を1つにまとめる
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(SanitizeString(name)), " ")
+}
+
+// タグのバリデーション
+func (t *Tag) Validate() error {
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(t.Name) > maxTagNameLength {
+		return errors.New("name must be 50 characters or less")
+	}
+	return nil
+}
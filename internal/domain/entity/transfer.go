@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 譲渡ステータス
+const (
+	TransferStatusPending   = "pending"
+	TransferStatusAccepted  = "accepted"
+	TransferStatusRejected  = "rejected"
+	TransferStatusCancelled = "cancelled"
+)
+
+// アイテムの所有者間の譲渡（受取人が承諾した時点で所有者が移る）
+type Transfer struct {
+	ID         int64      `json:"id"`
+	ItemID     int64      `json:"item_id"`
+	FromUser   string     `json:"from_user"`
+	ToUser     string     `json:"to_user"`
+	Status     string     `json:"status"`
+	Note       string     `json:"note,omitempty"`
+	ResolvedBy string     `json:"resolved_by,omitempty"` // 承諾・拒否・取消を行ったユーザー
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+func NewTransfer(itemID int64, fromUser, toUser, note string) (*Transfer, error) {
+	t := &Transfer{
+		ItemID:    itemID,
+		FromUser:  strings.TrimSpace(fromUser),
+		ToUser:    strings.TrimSpace(toUser),
+		Status:    TransferStatusPending,
+		Note:      SanitizeString(note),
+		CreatedAt: time.Now(),
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// 譲渡のバリデーション
+func (t *Transfer) Validate() error {
+	var errs []string
+
+	if t.FromUser == "" {
+		errs = append(errs, "from_user is required")
+	}
+
+	if t.ToUser == "" {
+		errs = append(errs, "to_user is required")
+	} else if len(t.ToUser) > 64 {
+		errs = append(errs, "to_user must be 64 characters or less")
+	} else if t.ToUser == t.FromUser {
+		errs = append(errs, "to_user must differ from the current owner")
+	}
+
+	if len(t.Note) > 500 {
+		errs = append(errs, "note must be 500 characters or less")
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+func (t *Transfer) IsPending() bool {
+	return t.Status == TransferStatusPending
+}
+
+// 譲渡を確定させる（承諾・拒否・取消）
+func (t *Transfer) Resolve(status, actor string) {
+	now := time.Now()
+	t.Status = status
+	t.ResolvedBy = actor
+	t.ResolvedAt = &now
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// アイテムの時価の評価（外部の相場の提供元から取得した価格の記録）
+type Valuation struct {
+	ID         int64     `json:"id"`
+	ItemID     int64     `json:"item_id"`
+	Value      int       `json:"value"`                 // 評価額
+	Currency   string    `json:"currency"`              // 通貨（ISO 4217）
+	Source     string    `json:"source"`                // 相場の提供元
+	SampleSize int       `json:"sample_size,omitempty"` // 評価額の算出に使われた出品・取引の件数
+	QuotedAt   time.Time `json:"quoted_at"`             // 提供元が価格を算出した日時
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Webhook で購読できるイベント（usecase のアイテムイベントの種類と同じ）
+var WebhookEventTypes = []string{"item.created", "item.updated", "item.deleted", "item.expiring"}
+
+// Webhook 配信のステータス
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// 配信ログに残すエラーメッセージの最大長
+const maxDeliveryErrorLength = 500
+
+// 内部ネットワーク（ループバック・プライベート・リンクローカル）の宛先を許可するか（開発用）
+var allowPrivateWebhookHosts bool
+
+// 内部ネットワークの宛先の Webhook を許可する。サーバー内部のサービスやクラウドのメタデータに
+// リクエストを送らせないため、本番では無効にする（配信時の接続先も dispatcher が検査する）
+func SetAllowPrivateWebhookHosts(allowed bool) {
+	allowPrivateWebhookHosts = allowed
+}
+
+// 100.64.0.0/10（キャリアグレードNAT）は IsPrivate に含まれない
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// インターネット上の宛先か（ループバック・プライベート・リンクローカル・未指定・マルチキャストでない）
+func IsPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() && !addr.IsLoopback() && !addr.IsPrivate() && !addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() && !addr.IsInterfaceLocalMulticast() && !addr.IsMulticast() &&
+		!addr.IsUnspecified() && !sharedAddressSpace.Contains(addr)
+}
+
+// 内部ネットワークを指すホスト名・IPアドレスか。名前解決が必要なホスト名は配信時に検査する
+func isPrivateWebhookHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return true
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return !IsPublicWebhookAddr(addr)
+	}
+	return false
+}
+
+// アイテムイベントの通知先
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`           // 通知するイベント（空ならすべて）
+	Secret    string    `json:"secret,omitempty"` // 署名の鍵（登録時のレスポンスにだけ含める）
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewWebhook(rawURL string, events []string, secret string) (*Webhook, error) {
+	w := &Webhook{
+		URL:       strings.TrimSpace(rawURL),
+		Events:    []string{},
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+
+	// 重複したイベントは1つにまとめる
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !seen[event] {
+			seen[event] = true
+			w.Events = append(w.Events, event)
+		}
+	}
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Webhook のバリデーション
+func (w *Webhook) Validate() error {
+	var errs []string
+
+	if w.URL == "" {
+		errs = append(errs, "url is required")
+	} else if len(w.URL) > 2048 {
+		errs = append(errs, "url must be 2048 characters or less")
+	} else if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, "url must start with http:// or https://")
+	} else if !allowPrivateWebhookHosts && isPrivateWebhookHost(u.Hostname()) {
+		errs = append(errs, "url must not point to a private network")
+	}
+
+	for _, event := range w.Events {
+		if !isWebhookEventType(event) {
+			errs = append(errs, "events must be one of "+strings.Join(WebhookEventTypes, ", "))
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+
+	return nil
+}
+
+// イベントを購読しているか（イベントの指定がなければすべて購読する）
+func (w *Webhook) Subscribes(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func isWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook への1件のイベントの配信（試行の結果を記録する配信ログ）
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	WebhookID      int64      `json:"webhook_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	ItemID         int64      `json:"item_id"`
+	Payload        string     `json:"payload"` // 送信するJSON（再試行でも同じ内容を送る）
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"` // 最後の試行のHTTPステータス
+	Error          string     `json:"error,omitempty"`           // 最後の試行の失敗理由
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"` // pending の間の次の試行時刻
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewWebhookDelivery(webhookID int64, eventID, eventType string, itemID int64, payload string, at time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       eventID,
+		EventType:     eventType,
+		ItemID:        itemID,
+		Payload:       payload,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: &at,
+		CreatedAt:     at,
+	}
+}
+
+// 試行の成功を記録する
+func (d *WebhookDelivery) Succeed(responseStatus int, at time.Time) {
+	d.Attempts++
+	d.Status = DeliveryStatusSucceeded
+	d.ResponseStatus = responseStatus
+	d.Error = ""
+	d.NextAttemptAt = nil
+	d.CompletedAt = &at
+}
+
+// 試行の失敗を記録する。next が nil なら再試行せずに failed にする
+func (d *WebhookDelivery) Fail(responseStatus int, reason string, at time.Time, next *time.Time) {
+	if len(reason) > maxDeliveryErrorLength {
+		reason = reason[:maxDeliveryErrorLength]
+	}
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.Error = reason
+	d.NextAttemptAt = next
+	if next == nil {
+		d.Status = DeliveryStatusFailed
+		d.CompletedAt = &at
+	}
+}
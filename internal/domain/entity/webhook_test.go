@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicWebhookAddr(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsPublicWebhookAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestNewWebhook_PrivateHost(t *testing.T) {
+	_, err := NewWebhook("http://metadata.google.internal/computeMetadata/v1/", nil, "whsec")
+	assert.ErrorContains(t, err, "private network")
+
+	SetAllowPrivateWebhookHosts(true)
+	defer SetAllowPrivateWebhookHosts(false)
+	_, err = NewWebhook("http://localhost:9000/hooks", nil, "whsec")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,275 @@
+package errors
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Code はクライアントが分岐に使う安定したエラーコード
+// メッセージは変わることがあるが、コードは互換性のため変更しない
+type Code string
+
+// リクエスト全体のエラーコード
+const (
+	CodeBadRequest                Code = "BAD_REQUEST"
+	CodeInvalidRequestFormat      Code = "INVALID_REQUEST_FORMAT"
+	CodeUnknownFields             Code = "UNKNOWN_FIELDS"
+	CodeInvalidPatch              Code = "INVALID_PATCH"
+	CodePatchNotApplicable        Code = "PATCH_NOT_APPLICABLE"
+	CodePatchTestFailed           Code = "PATCH_TEST_FAILED"
+	CodeInvalidItemID             Code = "INVALID_ITEM_ID"
+	CodeInvalidTransferID         Code = "INVALID_TRANSFER_ID"
+	CodeInvalidWebhookID          Code = "INVALID_WEBHOOK_ID"
+	CodeInvalidNotificationRuleID Code = "INVALID_NOTIFICATION_RULE_ID"
+	CodeInvalidLoanID             Code = "INVALID_LOAN_ID"
+	CodeInvalidServiceRecordID    Code = "INVALID_SERVICE_RECORD_ID"
+	CodeInvalidImageID            Code = "INVALID_IMAGE_ID"
+	CodeInvalidDocumentID         Code = "INVALID_DOCUMENT_ID"
+	CodeInvalidTagID              Code = "INVALID_TAG_ID"
+	CodeInvalidCollectionID       Code = "INVALID_COLLECTION_ID"
+	CodeInvalidRevision           Code = "INVALID_REVISION"
+	CodeInvalidIfMatch            Code = "INVALID_IF_MATCH"
+	CodeIfMatchMismatch           Code = "IF_MATCH_MISMATCH"
+	CodeInvalidIdempotencyKey     Code = "INVALID_IDEMPOTENCY_KEY"
+	CodeIdempotencyKeyInUse       Code = "IDEMPOTENCY_KEY_IN_USE"
+	CodeIdempotencyKeyReused      Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeValidationFailed          Code = "VALIDATION_FAILED"
+	CodeUnauthorized              Code = "UNAUTHORIZED"
+	CodeSandboxKeyRequired        Code = "SANDBOX_API_KEY_REQUIRED"
+	CodeSandboxNotSupported       Code = "SANDBOX_NOT_SUPPORTED"
+	CodeForbidden                 Code = "FORBIDDEN"
+	CodeNotFound                  Code = "NOT_FOUND"
+	CodeItemNotFound              Code = "ITEM_NOT_FOUND"
+	CodeAttributeNotFound         Code = "ATTRIBUTE_NOT_FOUND"
+	CodeTransferNotFound          Code = "TRANSFER_NOT_FOUND"
+	CodeExportNotFound            Code = "EXPORT_NOT_FOUND"
+	CodeWebhookNotFound           Code = "WEBHOOK_NOT_FOUND"
+	CodeNotificationRuleNotFound  Code = "NOTIFICATION_RULE_NOT_FOUND"
+	CodeLoanNotFound              Code = "LOAN_NOT_FOUND"
+	CodeServiceRecordNotFound     Code = "SERVICE_RECORD_NOT_FOUND"
+	CodeMarketPriceNotFound       Code = "MARKET_PRICE_NOT_FOUND"
+	CodeImageNotFound             Code = "IMAGE_NOT_FOUND"
+	CodeDocumentNotFound          Code = "DOCUMENT_NOT_FOUND"
+	CodeTagNotFound               Code = "TAG_NOT_FOUND"
+	CodeCategoryNotFound          Code = "CATEGORY_NOT_FOUND"
+	CodeBrandNotFound             Code = "BRAND_NOT_FOUND"
+	CodeCollectionNotFound        Code = "COLLECTION_NOT_FOUND"
+	CodeShareLinkNotFound         Code = "SHARE_LINK_NOT_FOUND"
+	CodeMediaLinkNotFound         Code = "MEDIA_LINK_NOT_FOUND"
+	CodeReminderSnoozeNotFound    Code = "REMINDER_SNOOZE_NOT_FOUND"
+	CodeItemRevisionNotFound      Code = "ITEM_REVISION_NOT_FOUND"
+	CodeErasureRequestNotFound    Code = "ERASURE_REQUEST_NOT_FOUND"
+	CodeItemCodeNotFound          Code = "ITEM_CODE_NOT_FOUND"
+	CodeLabelTemplateNotFound     Code = "LABEL_TEMPLATE_NOT_FOUND"
+	CodeMethodNotAllowed          Code = "METHOD_NOT_ALLOWED"
+	CodeUnsupportedAPIVersion     Code = "UNSUPPORTED_API_VERSION"
+	CodeConflict                  Code = "CONFLICT"
+	CodeItemModified              Code = "ITEM_MODIFIED"
+	CodeDuplicateItem             Code = "DUPLICATE_ITEM"
+	CodeExportNotReady            Code = "EXPORT_NOT_READY"
+	CodePreconditionFailed        Code = "PRECONDITION_FAILED"
+	CodePayloadTooLarge           Code = "PAYLOAD_TOO_LARGE"
+	CodeImageTooLarge             Code = "IMAGE_TOO_LARGE"
+	CodeUnsupportedImageType      Code = "UNSUPPORTED_IMAGE_TYPE"
+	CodeDocumentTooLarge          Code = "DOCUMENT_TOO_LARGE"
+	CodeUnsupportedDocumentType   Code = "UNSUPPORTED_DOCUMENT_TYPE"
+	CodeQuotaExceeded             Code = "QUOTA_EXCEEDED"
+	CodeTooManyRequests           Code = "TOO_MANY_REQUESTS"
+	CodeInternal                  Code = "INTERNAL_ERROR"
+	CodeRequestTimeout            Code = "REQUEST_TIMEOUT"
+	CodeClientClosedRequest       Code = "CLIENT_CLOSED_REQUEST"
+	CodeServiceUnavailable        Code = "SERVICE_UNAVAILABLE"
+	CodeDatabaseUnavailable       Code = "DATABASE_UNAVAILABLE"
+	CodeServerBusy                Code = "SERVER_BUSY"
+	CodePriceProviderUnavailable  Code = "PRICE_PROVIDER_UNAVAILABLE"
+	CodePriceProviderTimeout      Code = "PRICE_PROVIDER_TIMEOUT"
+	CodeExchangeRateUnavailable   Code = "EXCHANGE_RATE_UNAVAILABLE"
+	CodeOCRUnavailable            Code = "OCR_PROVIDER_UNAVAILABLE"
+	CodeOCRTimeout                Code = "OCR_PROVIDER_TIMEOUT"
+)
+
+// 個々の検証エラーのコードは VALIDATION_<フィールド>_<種類>（例: VALIDATION_NAME_TOO_LONG）
+// フィールドを特定できないものは VALIDATION_INVALID
+const CodeValidationInvalid Code = "VALIDATION_INVALID"
+
+// 検証エラーの種類
+const (
+	ValidationRequired      = "REQUIRED"
+	ValidationImmutable     = "IMMUTABLE"
+	ValidationUndefined     = "UNDEFINED"
+	ValidationUnknown       = "UNKNOWN"
+	ValidationTooLong       = "TOO_LONG"
+	ValidationTooMany       = "TOO_MANY"
+	ValidationOutOfRange    = "OUT_OF_RANGE"
+	ValidationInvalidChoice = "INVALID_CHOICE"
+	ValidationInvalidFormat = "INVALID_FORMAT"
+	ValidationDuplicate     = "DUPLICATE"
+	ValidationInvalid       = "INVALID"
+)
+
+// エラーメッセージとコードの対応表
+var messageCodes = map[string]Code{
+	"validation failed":                                            CodeValidationFailed,
+	"invalid request format":                                       CodeInvalidRequestFormat,
+	"unknown fields in request body":                               CodeUnknownFields,
+	"invalid patch document":                                       CodeInvalidPatch,
+	"patch cannot be applied":                                      CodePatchNotApplicable,
+	"patch test failed":                                            CodePatchTestFailed,
+	"invalid item ID":                                              CodeInvalidItemID,
+	"invalid transfer ID":                                          CodeInvalidTransferID,
+	"invalid webhook ID":                                           CodeInvalidWebhookID,
+	"invalid notification rule ID":                                 CodeInvalidNotificationRuleID,
+	"invalid loan ID":                                              CodeInvalidLoanID,
+	"invalid service record ID":                                    CodeInvalidServiceRecordID,
+	"invalid image ID":                                             CodeInvalidImageID,
+	"invalid document ID":                                          CodeInvalidDocumentID,
+	"invalid tag ID":                                               CodeInvalidTagID,
+	"invalid collection ID":                                        CodeInvalidCollectionID,
+	"invalid revision":                                             CodeInvalidRevision,
+	"invalid If-Match header":                                      CodeInvalidIfMatch,
+	"version does not match If-Match header":                       CodeIfMatchMismatch,
+	"invalid Idempotency-Key header":                               CodeInvalidIdempotencyKey,
+	"a request with this Idempotency-Key is in progress":           CodeIdempotencyKeyInUse,
+	"Idempotency-Key was used for a different request":             CodeIdempotencyKeyReused,
+	"a valid integration API key is required for sandbox requests": CodeSandboxKeyRequired,
+	"sandbox is not supported for this endpoint":                   CodeSandboxNotSupported,
+	ErrItemNotFound.Error():                                        CodeItemNotFound,
+	ErrAttributeNotFound.Error():                                   CodeAttributeNotFound,
+	ErrTransferNotFound.Error():                                    CodeTransferNotFound,
+	ErrExportNotFound.Error():                                      CodeExportNotFound,
+	ErrWebhookNotFound.Error():                                     CodeWebhookNotFound,
+	ErrNotificationRuleNotFound.Error():                            CodeNotificationRuleNotFound,
+	ErrLoanNotFound.Error():                                        CodeLoanNotFound,
+	ErrServiceRecordNotFound.Error():                               CodeServiceRecordNotFound,
+	ErrMarketPriceNotFound.Error():                                 CodeMarketPriceNotFound,
+	ErrImageNotFound.Error():                                       CodeImageNotFound,
+	ErrDocumentNotFound.Error():                                    CodeDocumentNotFound,
+	ErrTagNotFound.Error():                                         CodeTagNotFound,
+	ErrCategoryNotFound.Error():                                    CodeCategoryNotFound,
+	ErrBrandNotFound.Error():                                       CodeBrandNotFound,
+	ErrCollectionNotFound.Error():                                  CodeCollectionNotFound,
+	ErrShareLinkNotFound.Error():                                   CodeShareLinkNotFound,
+	ErrMediaLinkNotFound.Error():                                   CodeMediaLinkNotFound,
+	ErrReminderSnoozeNotFound.Error():                              CodeReminderSnoozeNotFound,
+	ErrItemRevisionNotFound.Error():                                CodeItemRevisionNotFound,
+	ErrErasureRequestNotFound.Error():                              CodeErasureRequestNotFound,
+	ErrItemCodeNotFound.Error():                                    CodeItemCodeNotFound,
+	"label template not found":                                     CodeLabelTemplateNotFound,
+	"item has been modified":                                       CodeItemModified,
+	ErrDuplicateItem.Error():                                       CodeDuplicateItem,
+	ErrPreconditionFailed.Error():                                  CodePreconditionFailed,
+	ErrPriceProviderUnavailable.Error():                            CodePriceProviderUnavailable,
+	ErrPriceProviderTimeout.Error():                                CodePriceProviderTimeout,
+	ErrExchangeRateUnavailable.Error():                             CodeExchangeRateUnavailable,
+	ErrOCRUnavailable.Error():                                      CodeOCRUnavailable,
+	ErrOCRTimeout.Error():                                          CodeOCRTimeout,
+	ErrImageTooLarge.Error():                                       CodeImageTooLarge,
+	ErrUnsupportedImageType.Error():                                CodeUnsupportedImageType,
+	ErrDocumentTooLarge.Error():                                    CodeDocumentTooLarge,
+	ErrUnsupportedDocumentType.Error():                             CodeUnsupportedDocumentType,
+	ErrQuotaExceeded.Error():                                       CodeQuotaExceeded,
+	"request timed out":                                            CodeRequestTimeout,
+	"client closed request":                                        CodeClientClosedRequest,
+	"database unavailable":                                         CodeDatabaseUnavailable,
+	"server busy":                                                  CodeServerBusy,
+}
+
+// 値を含むメッセージは前方一致でコードを決める
+var messagePrefixCodes = []struct {
+	prefix string
+	code   Code
+}{
+	{"export is not ready", CodeExportNotReady},
+	{"unsupported API version", CodeUnsupportedAPIVersion},
+}
+
+// 対応表にないメッセージはステータスコードでコードを決める
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeServiceUnavailable,
+}
+
+// エラーレスポンスのコードを返す
+func CodeFor(status int, message string) Code {
+	if code, ok := messageCodes[message]; ok {
+		return code
+	}
+	for _, p := range messagePrefixCodes {
+		if strings.HasPrefix(message, p.prefix) {
+			return p.code
+		}
+	}
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// "name is required" のような「フィールド 規則」形式の検証メッセージ
+var validationMessage = regexp.MustCompile(`^([a-z][a-z0-9_]*(?:\.[a-z0-9_]+)*) ((?:is|are|must) .*)$`)
+
+// 検証メッセージの規則部分と種類の対応（上から順に判定する）
+var validationKinds = []struct {
+	rule *regexp.Regexp
+	kind string
+}{
+	{regexp.MustCompile(`^is required$`), ValidationRequired},
+	{regexp.MustCompile(`^is immutable$`), ValidationImmutable},
+	{regexp.MustCompile(`^is not defined$`), ValidationUndefined},
+	{regexp.MustCompile(`^is not a known field$`), ValidationUnknown},
+	{regexp.MustCompile(`^must be \d+ characters or less$`), ValidationTooLong},
+	{regexp.MustCompile(`^must contain \d+ \w+ or less$`), ValidationTooMany},
+	{regexp.MustCompile(`^(?:must be (?:\d+ or greater|\d+ or less|>= |between |positive|on or (?:after|before) )|is too large)`), ValidationOutOfRange},
+	{regexp.MustCompile(`^must be (?:one of|a comma-separated list of|\w+ or \w+$)`), ValidationInvalidChoice},
+	{regexp.MustCompile(`^must (?:be in .* format|be an? (?:integer|number|string|boolean|array|object)$|start with|be an integer .*or a decimal string|be a (?:decimal|whole) number|have at most \d+ decimal places)`), ValidationInvalidFormat},
+	{regexp.MustCompile(`^must be unique`), ValidationDuplicate},
+}
+
+// 検証メッセージが指すフィールドを返す（"name is required" → "name"）。フィールドを特定できなければ空文字
+func ValidationField(message string) string {
+	if m := validationMessage.FindStringSubmatch(message); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// 検証メッセージのコードを返す（例: "name must be 100 characters or less" → VALIDATION_NAME_TOO_LONG）
+// カスタム属性（attributes.<キー>）はキーによらず VALIDATION_ATTRIBUTE_<種類> になる
+// ErrInvalidInput をラップしたエラーのメッセージ（"invalid input: ..."）も受け付ける
+func ValidationCode(message string) Code {
+	field, kind := parseValidationMessage(strings.TrimPrefix(message, ErrInvalidInput.Error()+": "))
+	if field == "" {
+		return CodeValidationInvalid
+	}
+	if strings.HasPrefix(field, "attributes.") {
+		field = "attribute"
+	}
+	return Code("VALIDATION_" + strings.ToUpper(field) + "_" + kind)
+}
+
+// 検証メッセージのフィールドと種類を返す。「フィールド 規則」形式でなければフィールドは空文字で種類は ValidationInvalid
+func parseValidationMessage(message string) (field, kind string) {
+	m := validationMessage.FindStringSubmatch(message)
+	if m == nil {
+		return "", ValidationInvalid
+	}
+	for _, k := range validationKinds {
+		if k.rule.MatchString(m[2]) {
+			return m[1], k.kind
+		}
+	}
+	return m[1], ValidationInvalid
+}
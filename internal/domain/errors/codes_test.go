@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		message  string
+		expected Code
+	}{
+		{name: "正常系: ドメインエラーのメッセージ", status: http.StatusNotFound, message: ErrItemNotFound.Error(), expected: CodeItemNotFound},
+		{name: "正常系: 対応表のメッセージ", status: http.StatusBadRequest, message: "invalid request format", expected: CodeInvalidRequestFormat},
+		{name: "正常系: Webhookが見つからない", status: http.StatusNotFound, message: ErrWebhookNotFound.Error(), expected: CodeWebhookNotFound},
+		{name: "正常系: 通知ルールが見つからない", status: http.StatusNotFound, message: ErrNotificationRuleNotFound.Error(), expected: CodeNotificationRuleNotFound},
+		{name: "正常系: 整備記録が見つからない", status: http.StatusNotFound, message: ErrServiceRecordNotFound.Error(), expected: CodeServiceRecordNotFound},
+		{name: "正常系: 整備記録のIDが不正", status: http.StatusBadRequest, message: "invalid service record ID", expected: CodeInvalidServiceRecordID},
+		{name: "正常系: 画像が見つからない", status: http.StatusNotFound, message: ErrImageNotFound.Error(), expected: CodeImageNotFound},
+		{name: "正常系: 画像が大きすぎる", status: http.StatusRequestEntityTooLarge, message: ErrImageTooLarge.Error(), expected: CodeImageTooLarge},
+		{name: "正常系: 対応していない画像形式", status: http.StatusUnsupportedMediaType, message: ErrUnsupportedImageType.Error(), expected: CodeUnsupportedImageType},
+		{name: "正常系: 書類が見つからない", status: http.StatusNotFound, message: ErrDocumentNotFound.Error(), expected: CodeDocumentNotFound},
+		{name: "正常系: 書類が大きすぎる", status: http.StatusRequestEntityTooLarge, message: ErrDocumentTooLarge.Error(), expected: CodeDocumentTooLarge},
+		{name: "正常系: 対応していない書類の形式", status: http.StatusUnsupportedMediaType, message: ErrUnsupportedDocumentType.Error(), expected: CodeUnsupportedDocumentType},
+		{name: "正常系: タグが見つからない", status: http.StatusNotFound, message: ErrTagNotFound.Error(), expected: CodeTagNotFound},
+		{name: "正常系: カテゴリーが見つからない", status: http.StatusNotFound, message: ErrCategoryNotFound.Error(), expected: CodeCategoryNotFound},
+		{name: "正常系: ブランドが見つからない", status: http.StatusNotFound, message: ErrBrandNotFound.Error(), expected: CodeBrandNotFound},
+		{name: "正常系: 重複の疑いがあるアイテム", status: http.StatusConflict, message: ErrDuplicateItem.Error(), expected: CodeDuplicateItem},
+		{name: "正常系: コレクションが見つからない", status: http.StatusNotFound, message: ErrCollectionNotFound.Error(), expected: CodeCollectionNotFound},
+		{name: "正常系: 共有リンクが見つからない", status: http.StatusNotFound, message: ErrShareLinkNotFound.Error(), expected: CodeShareLinkNotFound},
+		{name: "正常系: メディアのURLが見つからない", status: http.StatusNotFound, message: ErrMediaLinkNotFound.Error(), expected: CodeMediaLinkNotFound},
+		{name: "正常系: 読み取ったコードのアイテムが見つからない", status: http.StatusNotFound, message: ErrItemCodeNotFound.Error(), expected: CodeItemCodeNotFound},
+		{name: "正常系: 期限の通知の停止が見つからない", status: http.StatusNotFound, message: ErrReminderSnoozeNotFound.Error(), expected: CodeReminderSnoozeNotFound},
+		{name: "正常系: アイテムのリビジョンが見つからない", status: http.StatusNotFound, message: ErrItemRevisionNotFound.Error(), expected: CodeItemRevisionNotFound},
+		{name: "正常系: 相場が見つからない", status: http.StatusNotFound, message: ErrMarketPriceNotFound.Error(), expected: CodeMarketPriceNotFound},
+		{name: "正常系: 相場の提供元の障害", status: http.StatusBadGateway, message: ErrPriceProviderUnavailable.Error(), expected: CodePriceProviderUnavailable},
+		{name: "正常系: 相場の提供元のタイムアウト", status: http.StatusGatewayTimeout, message: ErrPriceProviderTimeout.Error(), expected: CodePriceProviderTimeout},
+		{name: "正常系: 為替レートの提供元の障害", status: http.StatusBadGateway, message: ErrExchangeRateUnavailable.Error(), expected: CodeExchangeRateUnavailable},
+		{name: "正常系: OCR の障害", status: http.StatusBadGateway, message: ErrOCRUnavailable.Error(), expected: CodeOCRUnavailable},
+		{name: "正常系: OCR のタイムアウト", status: http.StatusGatewayTimeout, message: ErrOCRTimeout.Error(), expected: CodeOCRTimeout},
+		{name: "正常系: パッチのテスト失敗", status: http.StatusConflict, message: "patch test failed", expected: CodePatchTestFailed},
+		{name: "正常系: 前方一致", status: http.StatusConflict, message: "export is not ready: running", expected: CodeExportNotReady},
+		{name: "正常系: 未対応のAPIバージョン", status: http.StatusBadRequest, message: "unsupported API version: v3 (supported: v1, v2)", expected: CodeUnsupportedAPIVersion},
+		{name: "正常系: 対応表にないメッセージはステータスで決める", status: http.StatusForbidden, message: "forbidden: item 1 is not owned by bob", expected: CodeForbidden},
+		{name: "正常系: 500番台", status: http.StatusBadGateway, message: "failed to retrieve items", expected: CodeInternal},
+		{name: "正常系: 400番台", status: http.StatusTeapot, message: "short and stout", expected: CodeBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CodeFor(tt.status, tt.message))
+		})
+	}
+}
+
+func TestValidationCode(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected Code
+	}{
+		{message: "name is required", expected: "VALIDATION_NAME_REQUIRED"},
+		{message: "name must be 100 characters or less", expected: "VALIDATION_NAME_TOO_LONG"},
+		{message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他", expected: "VALIDATION_CATEGORY_INVALID_CHOICE"},
+		{message: "purchase_price must be 0 or greater", expected: "VALIDATION_PURCHASE_PRICE_OUT_OF_RANGE"},
+		{message: "purchase_price must be >= 0", expected: "VALIDATION_PURCHASE_PRICE_OUT_OF_RANGE"},
+		{message: "purchase_price must be 10000000 or less", expected: "VALIDATION_PURCHASE_PRICE_OUT_OF_RANGE"},
+		{message: "purchase_date must be on or before 2024-12-31", expected: "VALIDATION_PURCHASE_DATE_OUT_OF_RANGE"},
+		{message: "purchase_date must be in YYYY-MM-DD format", expected: "VALIDATION_PURCHASE_DATE_INVALID_FORMAT"},
+		{message: "page_size must be an integer", expected: "VALIDATION_PAGE_SIZE_INVALID_FORMAT"},
+		{message: `purchase_price must have at most 2 decimal places in USD: "12.345"`, expected: "VALIDATION_PURCHASE_PRICE_INVALID_FORMAT"},
+		{message: `purchase_price must be an integer (minor units) or a decimal string such as "12.34": 12.5`, expected: "VALIDATION_PURCHASE_PRICE_INVALID_FORMAT"},
+		{message: `purchase_price is too large: "99999999999999999999"`, expected: "VALIDATION_PURCHASE_PRICE_OUT_OF_RANGE"},
+		{message: "currency must be in ISO 4217 format (e.g. USD)", expected: "VALIDATION_CURRENCY_INVALID_FORMAT"},
+		{message: "tags must be an array", expected: "VALIDATION_TAGS_INVALID_FORMAT"},
+		{message: "page_size must be between 0 and 100", expected: "VALIDATION_PAGE_SIZE_OUT_OF_RANGE"},
+		{message: "sort_order must be asc or desc", expected: "VALIDATION_SORT_ORDER_INVALID_CHOICE"},
+		{message: "created_at is immutable", expected: "VALIDATION_CREATED_AT_IMMUTABLE"},
+		{message: "options must contain 20 values or less", expected: "VALIDATION_OPTIONS_TOO_MANY"},
+		{message: "options must be unique: 新品", expected: "VALIDATION_OPTIONS_DUPLICATE"},
+		{message: "attributes.color is not defined", expected: "VALIDATION_ATTRIBUTE_UNDEFINED"},
+		{message: "purchase_prise is not a known field", expected: "VALIDATION_PURCHASE_PRISE_UNKNOWN"},
+		{message: "attributes.storage_box is required", expected: "VALIDATION_ATTRIBUTE_REQUIRED"},
+		{message: "to_user must differ from the current owner", expected: "VALIDATION_TO_USER_INVALID"},
+		{message: fmt.Errorf("%w: item_ids is required", ErrInvalidInput).Error(), expected: "VALIDATION_ITEM_IDS_REQUIRED"},
+		{message: "unknown label template: a3", expected: CodeValidationInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run("正常系: "+tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidationCode(tt.message))
+		})
+	}
+}
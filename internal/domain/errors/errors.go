@@ -1,16 +1,67 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrItemNotFound   = errors.New("item not found")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrDatabaseError  = errors.New("database error")
-	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrNotFound                 = errors.New("not found")
+	ErrItemNotFound             = fmt.Errorf("item %w", ErrNotFound)
+	ErrAttributeNotFound        = fmt.Errorf("custom attribute %w", ErrNotFound)
+	ErrTransferNotFound         = fmt.Errorf("transfer %w", ErrNotFound)
+	ErrExportNotFound           = fmt.Errorf("export %w", ErrNotFound)
+	ErrWebhookNotFound          = fmt.Errorf("webhook %w", ErrNotFound)
+	ErrNotificationRuleNotFound = fmt.Errorf("notification rule %w", ErrNotFound)
+	ErrLoanNotFound             = fmt.Errorf("loan %w", ErrNotFound)
+	ErrServiceRecordNotFound    = fmt.Errorf("service record %w", ErrNotFound)
+	ErrMarketPriceNotFound      = fmt.Errorf("market price %w", ErrNotFound)
+	ErrImageNotFound            = fmt.Errorf("image %w", ErrNotFound)
+	ErrDocumentNotFound         = fmt.Errorf("document %w", ErrNotFound)
+	ErrTagNotFound              = fmt.Errorf("tag %w", ErrNotFound)
+	ErrCollectionNotFound       = fmt.Errorf("collection %w", ErrNotFound)
+	ErrShareLinkNotFound        = fmt.Errorf("share link %w", ErrNotFound)
+	ErrMediaLinkNotFound        = fmt.Errorf("media link %w", ErrNotFound)
+	ErrReminderSnoozeNotFound   = fmt.Errorf("reminder snooze %w", ErrNotFound)
+	ErrItemRevisionNotFound     = fmt.Errorf("item revision %w", ErrNotFound)
+	ErrUndoTokenNotFound        = fmt.Errorf("undo token %w", ErrNotFound)
+	ErrBackupNotFound           = fmt.Errorf("backup %w", ErrNotFound)
+	ErrAdminUserNotFound        = fmt.Errorf("admin user %w", ErrNotFound)
+	ErrCategoryNotFound         = fmt.Errorf("category %w", ErrNotFound)
+	ErrBrandNotFound            = fmt.Errorf("brand %w", ErrNotFound)
+	ErrErasureRequestNotFound   = fmt.Errorf("erasure request %w", ErrNotFound)
+	ErrItemCodeNotFound         = fmt.Errorf("item code %w", ErrNotFound)
+	ErrInvalidInput             = errors.New("invalid input")
+	ErrDatabaseError            = errors.New("database error")
+	ErrDuplicateEntry           = errors.New("duplicate entry")
+	ErrForbidden                = errors.New("forbidden")
+	ErrConflict                 = errors.New("conflict")
+	// ErrDuplicateItem は登録しようとしたアイテムが既存のアイテムとほぼ同じであることを示す（ErrConflict の一種）
+	ErrDuplicateItem = errors.New("possible duplicate item")
+	// ErrPreconditionFailed はクライアントが指定した事前条件(If-Match)が満たされないことを示す
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrPriceProviderUnavailable は外部の相場の提供元から価格を取得できないことを示す
+	ErrPriceProviderUnavailable = errors.New("price provider unavailable")
+	ErrPriceProviderTimeout     = fmt.Errorf("%w: timed out", ErrPriceProviderUnavailable)
+	// ErrExchangeRateUnavailable は外部の為替レートの提供元からレートを取得できないことを示す
+	ErrExchangeRateUnavailable = errors.New("exchange rate provider unavailable")
+	// ErrOCRUnavailable は外部の OCR サービスで画像の文字を読み取れないことを示す
+	ErrOCRUnavailable = errors.New("OCR provider unavailable")
+	ErrOCRTimeout     = fmt.Errorf("%w: timed out", ErrOCRUnavailable)
+	// ErrImageTooLarge はアップロードされた画像が上限のサイズを超えていることを示す
+	ErrImageTooLarge = errors.New("image too large")
+	// ErrUnsupportedImageType はアップロードされたファイルが受け付ける形式の画像でないことを示す
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	// ErrDocumentTooLarge はアップロードされた書類が上限のサイズを超えていることを示す
+	ErrDocumentTooLarge = errors.New("document too large")
+	// ErrUnsupportedDocumentType はアップロードされたファイルが受け付ける形式の書類（PDF・画像）でないことを示す
+	ErrUnsupportedDocumentType = errors.New("unsupported document type")
+	// ErrQuotaExceeded はユーザーのアイテム数、またはアイテムの添付ファイル数が上限に達していることを示す
+	ErrQuotaExceeded = errors.New("quota exceeded")
 )
 
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -20,3 +71,47 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsForbiddenError(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+func IsPreconditionFailedError(err error) bool {
+	return errors.Is(err, ErrPreconditionFailed)
+}
+
+func IsPriceProviderError(err error) bool {
+	return errors.Is(err, ErrPriceProviderUnavailable)
+}
+
+func IsExchangeRateError(err error) bool {
+	return errors.Is(err, ErrExchangeRateUnavailable)
+}
+
+func IsOCRError(err error) bool {
+	return errors.Is(err, ErrOCRUnavailable)
+}
+
+func IsImageTooLargeError(err error) bool {
+	return errors.Is(err, ErrImageTooLarge)
+}
+
+func IsUnsupportedImageTypeError(err error) bool {
+	return errors.Is(err, ErrUnsupportedImageType)
+}
+
+func IsDocumentTooLargeError(err error) bool {
+	return errors.Is(err, ErrDocumentTooLarge)
+}
+
+func IsUnsupportedDocumentTypeError(err error) bool {
+	return errors.Is(err, ErrUnsupportedDocumentType)
+}
+
+func IsQuotaExceededError(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTransient は時間をおいて再試行すれば成功しうる一時的な失敗を示す
+var ErrTransient = errors.New("temporarily unavailable")
+
+// 種類ごとのエラーの型
+// いずれも対応する番兵エラー（ErrNotFound など）をラップするため、errors.Is と Is*Error でも判定できる
+// 型は errors.As で取り出して、フィールドや詳細を使うときに用いる
+
+// FieldError は1つのフィールドの検証エラー
+type FieldError struct {
+	// 対象のフィールド（例: "name"、"attributes.color"）。特定できなければ空文字
+	Field string
+	// 違反した規則の種類（ValidationRequired など）
+	Rule string
+	// クライアントに返すメッセージ（例: "name must be 100 characters or less"）
+	Message string
+}
+
+// NewFieldError は「フィールド 規則」形式のメッセージ（"name is required" など）からフィールドと規則を求める
+func NewFieldError(message string) FieldError {
+	field, rule := parseValidationMessage(message)
+	return FieldError{Field: field, Rule: rule, Message: message}
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationError は入力の検証エラー。違反したフィールドごとの FieldError を持つ
+// メッセージは "invalid input: <メッセージ>, <メッセージ>" で、ErrInvalidInput をラップする
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewValidationError は検証メッセージごとの FieldError を持つ検証エラーを返す
+func NewValidationError(messages ...string) error {
+	fields := make([]FieldError, len(messages))
+	for i, message := range messages {
+		fields[i] = NewFieldError(message)
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// Validation は FieldError から検証エラーを返す
+func Validation(fields ...FieldError) error {
+	return &ValidationError{Fields: fields}
+}
+
+// Invalid は err を検証エラーにする。既に ValidationError を含むならそのまま返し、
+// それ以外はメッセージ全体を1つの FieldError にする（エンティティの単一の検証エラーなど）
+func Invalid(err error) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return err
+	}
+	return NewValidationError(strings.TrimPrefix(err.Error(), ErrInvalidInput.Error()+": "))
+}
+
+func (e *ValidationError) Error() string {
+	return ErrInvalidInput.Error() + ": " + strings.Join(e.Details(), ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// Details はフィールドごとのメッセージを返す
+func (e *ValidationError) Details() []string {
+	details := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		details[i] = field.Message
+	}
+	return details
+}
+
+// AsValidationError は err が含む ValidationError を返す
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	ok := errors.As(err, &validationErr)
+	return validationErr, ok
+}
+
+// NotFoundError は対象が見つからないことを示す。Kind は ErrItemNotFound などの種類
+// メッセージは "<種類>: <詳細>"（詳細がなければ種類だけ）
+type NotFoundError struct {
+	Kind   error
+	Detail string
+}
+
+// NotFound は kind の NotFoundError を返す。format を指定すると詳細にする
+func NotFound(kind error, format string, args ...interface{}) error {
+	return &NotFoundError{Kind: kind, Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Detail == "" {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Detail
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Kind
+}
+
+// ConflictError は現在の状態と矛盾する操作であることを示す。ErrConflict をラップする
+type ConflictError struct {
+	Detail string
+}
+
+// Conflict は詳細を format で組み立てた ConflictError を返す
+func Conflict(format string, args ...interface{}) error {
+	return &ConflictError{Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *ConflictError) Error() string {
+	return ErrConflict.Error() + ": " + e.Detail
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// PermissionDeniedError は操作する権限がないことを示す。ErrForbidden をラップする
+type PermissionDeniedError struct {
+	Detail string
+}
+
+// PermissionDenied は詳細を format で組み立てた PermissionDeniedError を返す
+func PermissionDenied(format string, args ...interface{}) error {
+	return &PermissionDeniedError{Detail: fmt.Sprintf(format, args...)}
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return ErrForbidden.Error() + ": " + e.Detail
+}
+
+func (e *PermissionDeniedError) Unwrap() error {
+	return ErrForbidden
+}
+
+// TransientError は一時的な失敗を示す。原因の Err と ErrTransient の両方をラップする
+type TransientError struct {
+	Err error
+}
+
+// Transient は err を一時的な失敗にする。nil ならそのまま nil を返す
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TransientError{Err: err}
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() []error {
+	return []error{e.Err, ErrTransient}
+}
+
+// IsPermissionDeniedError は IsForbiddenError と同じ
+func IsPermissionDeniedError(err error) bool {
+	return IsForbiddenError(err)
+}
+
+// IsTransientError は再試行すれば成功しうる失敗かを返す
+// 外部の提供元（相場・為替レート・OCR）を利用できない失敗も一時的とみなす
+func IsTransientError(err error) bool {
+	return errors.Is(err, ErrTransient) || IsPriceProviderError(err) || IsExchangeRateError(err) || IsOCRError(err)
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("正常系: メッセージからフィールドと規則を求める", func(t *testing.T) {
+		err := NewValidationError("name is required", "category must be one of: 時計, バッグ", "price looks wrong")
+
+		validationErr, ok := AsValidationError(fmt.Errorf("failed to create item: %w", err))
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{
+			{Field: "name", Rule: ValidationRequired, Message: "name is required"},
+			{Field: "category", Rule: ValidationInvalidChoice, Message: "category must be one of: 時計, バッグ"},
+			{Field: "", Rule: ValidationInvalid, Message: "price looks wrong"},
+		}, validationErr.Fields)
+		assert.Equal(t, []string{"name is required", "category must be one of: 時計, バッグ", "price looks wrong"}, validationErr.Details())
+		assert.EqualError(t, err, "invalid input: name is required, category must be one of: 時計, バッグ, price looks wrong")
+		assert.True(t, IsValidationError(err))
+	})
+
+	t.Run("正常系: 検証エラーでないエラーは1つのフィールドにする", func(t *testing.T) {
+		err := Invalid(errors.New("name must be 100 characters or less"))
+
+		validationErr, ok := AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{{Field: "name", Rule: ValidationTooLong, Message: "name must be 100 characters or less"}}, validationErr.Fields)
+	})
+
+	t.Run("正常系: 検証エラーはそのまま返す", func(t *testing.T) {
+		err := NewValidationError("name is required", "purchase_date is required")
+		assert.Same(t, err, Invalid(err))
+	})
+
+	t.Run("正常系: ErrInvalidInput をラップしたメッセージは接頭辞を重ねない", func(t *testing.T) {
+		err := Invalid(fmt.Errorf("%w: name is required", ErrInvalidInput))
+		assert.EqualError(t, err, "invalid input: name is required")
+	})
+
+	t.Run("異常系: 検証エラーを含まない", func(t *testing.T) {
+		_, ok := AsValidationError(fmt.Errorf("%w: name is required", ErrInvalidInput))
+		assert.False(t, ok)
+	})
+}
+
+func TestTypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		message  string
+		sentinel error
+	}{
+		{name: "正常系: 詳細のある NotFound", err: NotFound(ErrItemNotFound, "item %d", 1), message: "item not found: item 1", sentinel: ErrItemNotFound},
+		{name: "正常系: 詳細のない NotFound", err: NotFound(ErrTagNotFound, ""), message: "tag not found", sentinel: ErrNotFound},
+		{name: "正常系: Conflict", err: Conflict("loan %d is already %s", 2, "returned"), message: "conflict: loan 2 is already returned", sentinel: ErrConflict},
+		{name: "正常系: PermissionDenied", err: PermissionDenied("item %d is not owned by %s", 3, "bob"), message: "forbidden: item 3 is not owned by bob", sentinel: ErrForbidden},
+		{name: "正常系: Transient", err: Transient(ErrDatabaseError), message: "database error", sentinel: ErrTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.err, tt.message)
+			assert.ErrorIs(t, fmt.Errorf("wrapped: %w", tt.err), tt.sentinel)
+		})
+	}
+
+	t.Run("正常系: 型を取り出せる", func(t *testing.T) {
+		var notFound *NotFoundError
+		require.ErrorAs(t, fmt.Errorf("wrapped: %w", NotFound(ErrLoanNotFound, "loan %d", 4)), &notFound)
+		assert.Equal(t, ErrLoanNotFound, notFound.Kind)
+		assert.Equal(t, "loan 4", notFound.Detail)
+	})
+
+	t.Run("正常系: 判定のヘルパー", func(t *testing.T) {
+		assert.True(t, IsNotFoundError(NotFound(ErrItemNotFound, "")))
+		assert.True(t, IsConflictError(Conflict("x")))
+		assert.True(t, IsPermissionDeniedError(PermissionDenied("x")))
+		assert.True(t, IsForbiddenError(PermissionDenied("x")))
+		assert.True(t, IsTransientError(Transient(errors.New("connection reset"))))
+		assert.True(t, IsDatabaseError(Transient(ErrDatabaseError)), "原因のエラーも判定できる")
+		assert.True(t, IsTransientError(ErrPriceProviderTimeout), "外部の提供元を利用できない失敗は一時的")
+		assert.False(t, IsTransientError(ErrInvalidInput))
+		assert.NoError(t, Transient(nil))
+	})
+}
@@ -0,0 +1,196 @@
+// Package accesslog はアクセスログを1行1JSON（NDJSON）で出力するミドルウェアを提供する。
+package accesslog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const redacted = "[REDACTED]"
+
+// デフォルトで値を伏せるヘッダー・クエリパラメーター
+var DefaultRedactFields = []string{"Authorization", "Cookie", "Set-Cookie", "X-API-Key", "X-Sentry-Auth", "passphrase", "token"}
+
+type Config struct {
+	// 出力先
+	Writer io.Writer
+	// ステータスクラス（2, 3, 4, 5）ごとの記録する割合（0〜1）。未指定のクラスは全件記録する
+	SampleRates map[int]float64
+	// 値を伏せるヘッダー・クエリパラメーター名（大文字小文字を区別しない）
+	RedactFields []string
+
+	random func() float64
+}
+
+// 1リクエスト分のログ
+type Entry struct {
+	Time       string            `json:"time"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	Route      string            `json:"route"`
+	Query      string            `json:"query,omitempty"`
+	Status     int               `json:"status"`
+	LatencyMS  float64           `json:"latency_ms"`
+	BytesIn    int64             `json:"bytes_in"`
+	BytesOut   int64             `json:"bytes_out"`
+	RemoteIP   string            `json:"remote_ip"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	SampleRate float64           `json:"sample_rate"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// "2xx:0.1,5xx:1" 形式のサンプリング設定を読み込む
+func ParseSampleRates(s string) (map[int]float64, error) {
+	rates := make(map[int]float64)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		class, rate, ok := strings.Cut(part, ":")
+		class = strings.ToLower(strings.TrimSpace(class))
+		if !ok || len(class) != 3 || !strings.HasSuffix(class, "xx") || class[0] < '1' || class[0] > '5' {
+			return nil, fmt.Errorf("invalid sample rate %q: expected e.g. 2xx:0.1", part)
+		}
+		r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || r < 0 || r > 1 {
+			return nil, fmt.Errorf("invalid sample rate %q: rate must be between 0 and 1", part)
+		}
+		rates[int(class[0]-'0')] = r
+	}
+	return rates, nil
+}
+
+func Middleware(cfg Config) echo.MiddlewareFunc {
+	if cfg.random == nil {
+		cfg.random = rand.Float64
+	}
+	redact := make(map[string]bool, len(cfg.RedactFields))
+	for _, f := range cfg.RedactFields {
+		redact[strings.ToLower(f)] = true
+	}
+	var mu sync.Mutex
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// ステータスを確定させるため、ここでエラーレスポンスを書き出す
+				c.Error(err)
+			}
+
+			req, res := c.Request(), c.Response()
+			rate, ok := cfg.SampleRates[res.Status/100]
+			if !ok {
+				rate = 1
+			}
+			if rate < 1 && cfg.random() >= rate {
+				return nil
+			}
+
+			entry := Entry{
+				Time:       start.UTC().Format(time.RFC3339Nano),
+				Method:     req.Method,
+				Path:       redactPath(c, redact),
+				Route:      c.Path(),
+				Query:      redactQuery(req.URL.Query(), redact),
+				Status:     res.Status,
+				LatencyMS:  float64(time.Since(start).Microseconds()) / 1000,
+				BytesIn:    req.ContentLength,
+				BytesOut:   res.Size,
+				RemoteIP:   c.RealIP(),
+				UserAgent:  req.UserAgent(),
+				RequestID:  req.Header.Get(echo.HeaderXRequestID),
+				Headers:    redactHeaders(req.Header, redact),
+				SampleRate: rate,
+			}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+
+			line, mErr := json.Marshal(entry)
+			if mErr != nil {
+				return nil
+			}
+			mu.Lock()
+			_, _ = cfg.Writer.Write(append(line, '\n'))
+			mu.Unlock()
+
+			return nil
+		}
+	}
+}
+
+// 値を伏せるパスパラメーター（共有リンクの /shared/:token など）を含むパスは、ルートのパターンに
+// パラメーターの値を当てはめて組み立て直す
+func redactPath(c echo.Context, redact map[string]bool) string {
+	path := c.Request().URL.Path
+	values := make(map[string]string, len(c.ParamNames()))
+	sensitive := false
+	for i, name := range c.ParamNames() {
+		if redact[strings.ToLower(name)] {
+			sensitive = true
+		}
+		if i < len(c.ParamValues()) {
+			values[name] = c.ParamValues()[i]
+		}
+	}
+	if !sensitive {
+		return path
+	}
+
+	segments := strings.Split(c.Path(), "/")
+	for i, segment := range segments {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+		if redact[strings.ToLower(name)] {
+			segments[i] = redacted
+		} else {
+			segments[i] = values[name]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func redactHeaders(header http.Header, redact map[string]bool) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(header))
+	for name, values := range header {
+		if redact[strings.ToLower(name)] {
+			out[name] = redacted
+			continue
+		}
+		out[name] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+func redactQuery(query url.Values, redact map[string]bool) string {
+	if len(query) == 0 {
+		return ""
+	}
+	for name := range query {
+		if redact[strings.ToLower(name)] {
+			query[name] = []string{redacted}
+		}
+	}
+	return query.Encode()
+}
@@ -0,0 +1,122 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSampleRates(t *testing.T) {
+	rates, err := ParseSampleRates("2xx:0.1, 5XX:1,4xx:0.5")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]float64{2: 0.1, 4: 0.5, 5: 1}, rates)
+
+	for _, invalid := range []string{"200:0.1", "2xx", "2xx:1.5", "9xx:1", "2xx:abc"} {
+		_, err := ParseSampleRates(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func newTestServer(buf *bytes.Buffer, rates map[int]float64, random float64) *echo.Echo {
+	e := echo.New()
+	e.Use(Middleware(Config{
+		Writer:       buf,
+		SampleRates:  rates,
+		RedactFields: DefaultRedactFields,
+		random:       func() float64 { return random },
+	}))
+	e.GET("/items/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+	return e
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		rates    map[int]float64
+		random   float64
+		expected int // 出力される行数
+	}{
+		{name: "正常系: 設定なしは全件記録", path: "/items/1", expected: 1},
+		{name: "正常系: サンプリング対象外の2xx", path: "/items/1", rates: map[int]float64{2: 0.1}, random: 0.5, expected: 0},
+		{name: "正常系: サンプリング対象の2xx", path: "/items/1", rates: map[int]float64{2: 0.1}, random: 0.05, expected: 1},
+		{name: "正常系: エラーは別の割合", path: "/items/0", rates: map[int]float64{2: 0, 4: 1}, random: 0.99, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			e := newTestServer(&buf, tt.rates, tt.random)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if tt.expected == 0 {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.Len(t, lines, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Entry(t *testing.T) {
+	var buf bytes.Buffer
+	e := newTestServer(&buf, nil, 0)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/0?token=abc&page=2", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-API-Key", "partner-key")
+	req.Header.Set("User-Agent", "curl/8")
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, http.MethodGet, entry.Method)
+	assert.Equal(t, "/items/0", entry.Path)
+	assert.Equal(t, "/items/:id", entry.Route)
+	assert.Equal(t, http.StatusBadRequest, entry.Status)
+	assert.Equal(t, "curl/8", entry.UserAgent)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, "page=2&token=%5BREDACTED%5D", entry.Query)
+	assert.Equal(t, redacted, entry.Headers["Authorization"])
+	assert.Equal(t, redacted, entry.Headers["X-Api-Key"])
+	assert.Contains(t, entry.Error, "invalid item ID")
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), "partner-key")
+}
+
+func TestMiddleware_RedactPathParam(t *testing.T) {
+	var buf bytes.Buffer
+	e := echo.New()
+	e.Use(Middleware(Config{Writer: &buf, RedactFields: DefaultRedactFields}))
+	e.GET("/v1/shared/:token", func(c echo.Context) error { return c.String(http.StatusOK, "ok") })
+	e.GET("/items/:id/images/:token", func(c echo.Context) error { return c.String(http.StatusOK, "ok") })
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/shared/signed-secret", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/7/images/signed-secret", nil))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var shared, image Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &shared))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &image))
+	assert.Equal(t, "/v1/shared/[REDACTED]", shared.Path)
+	assert.Equal(t, "/v1/shared/:token", shared.Route)
+	assert.Equal(t, "/items/7/images/[REDACTED]", image.Path)
+	assert.NotContains(t, buf.String(), "signed-secret")
+}
@@ -0,0 +1,147 @@
+package broker
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Avro のメッセージは単一オブジェクトエンコーディング（先頭にスキーマのフィンガープリントを付ける）で送る
+// https://avro.apache.org/docs/1.11.1/specification/#single-object-encoding
+const AvroContentType = "application/vnd.apache.avro+binary"
+
+// アイテムイベントの Avro スキーマ（消費側はフィンガープリントでこのスキーマを特定する）
+const AvroSchema = `{
+  "type": "record",
+  "name": "ItemEvent",
+  "namespace": "aicon.inventory",
+  "fields": [
+    {"name": "type", "type": "string"},
+    {"name": "item_id", "type": "long"},
+    {"name": "item", "type": ["null", {
+      "type": "record",
+      "name": "Item",
+      "fields": [
+        {"name": "id", "type": "long"},
+        {"name": "name", "type": "string"},
+        {"name": "category", "type": "string"},
+        {"name": "brand", "type": "string"},
+        {"name": "purchase_price", "type": "long"},
+        {"name": "purchase_date", "type": "string"},
+        {"name": "attributes", "type": {"type": "map", "values": "string"}},
+        {"name": "owner_id", "type": "string"},
+        {"name": "version", "type": "long"},
+        {"name": "created_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+        {"name": "updated_at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+        {"name": "currency", "type": "string", "default": "JPY"}
+      ]
+    }], "default": null},
+    {"name": "occurred_at", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+  ]
+}`
+
+// AvroSchema の正規形（Parsing Canonical Form）。フィンガープリントはこの文字列から計算する
+const avroCanonicalSchema = `{"name":"aicon.inventory.ItemEvent","type":"record","fields":[` +
+	`{"name":"type","type":"string"},{"name":"item_id","type":"long"},` +
+	`{"name":"item","type":["null",{"name":"aicon.inventory.Item","type":"record","fields":[` +
+	`{"name":"id","type":"long"},{"name":"name","type":"string"},{"name":"category","type":"string"},` +
+	`{"name":"brand","type":"string"},{"name":"purchase_price","type":"long"},{"name":"purchase_date","type":"string"},` +
+	`{"name":"attributes","type":{"type":"map","values":"string"}},{"name":"owner_id","type":"string"},` +
+	`{"name":"version","type":"long"},{"name":"created_at","type":"long"},{"name":"updated_at","type":"long"},` +
+	`{"name":"currency","type":"string"}]}]},` +
+	`{"name":"occurred_at","type":"long"}]}`
+
+// AvroFingerprint は AvroSchema の CRC-64-AVRO フィンガープリント
+var AvroFingerprint = avroFingerprint([]byte(avroCanonicalSchema))
+
+// イベントを単一オブジェクトエンコーディングの Avro にする
+func EncodeAvro(event usecase.ItemEvent) ([]byte, error) {
+	buf := make([]byte, 0, 256)
+	buf = append(buf, 0xC3, 0x01)
+	buf = binary.LittleEndian.AppendUint64(buf, AvroFingerprint)
+
+	buf = appendAvroString(buf, event.Type)
+	buf = appendAvroLong(buf, event.ItemID)
+	if event.Item == nil {
+		buf = appendAvroLong(buf, 0) // union の null
+	} else {
+		buf = appendAvroLong(buf, 1)
+		buf = appendAvroItem(buf, event.Item)
+	}
+	buf = appendAvroLong(buf, avroTimestamp(event.OccurredAt))
+
+	return buf, nil
+}
+
+func appendAvroItem(buf []byte, item *entity.Item) []byte {
+	buf = appendAvroLong(buf, item.ID)
+	buf = appendAvroString(buf, item.Name)
+	buf = appendAvroString(buf, item.Category)
+	buf = appendAvroString(buf, item.Brand)
+	buf = appendAvroLong(buf, int64(item.PurchasePrice))
+	buf = appendAvroString(buf, item.PurchaseDate)
+
+	// map は要素数のブロックと終端の0。キーの順に並べて同じ内容を同じバイト列にする
+	if len(item.Attributes) > 0 {
+		keys := make([]string, 0, len(item.Attributes))
+		for k := range item.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf = appendAvroLong(buf, int64(len(keys)))
+		for _, k := range keys {
+			buf = appendAvroString(buf, k)
+			buf = appendAvroString(buf, item.Attributes[k])
+		}
+	}
+	buf = appendAvroLong(buf, 0)
+
+	buf = appendAvroString(buf, item.OwnerID)
+	buf = appendAvroLong(buf, item.Version)
+	buf = appendAvroLong(buf, avroTimestamp(item.CreatedAt))
+	buf = appendAvroLong(buf, avroTimestamp(item.UpdatedAt))
+	// 通貨は後から追加したので、既定値（JPY）つきで末尾に置く
+	buf = appendAvroString(buf, item.Price().Currency)
+	return buf
+}
+
+// long は zigzag 符号化した可変長整数
+func appendAvroLong(buf []byte, v int64) []byte {
+	return binary.AppendUvarint(buf, uint64((v<<1)^(v>>63)))
+}
+
+// string は長さ（long）とUTF-8のバイト列
+func appendAvroString(buf []byte, s string) []byte {
+	buf = appendAvroLong(buf, int64(len(s)))
+	return append(buf, s...)
+}
+
+func avroTimestamp(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// CRC-64-AVRO（Rabin）フィンガープリント
+const avroFingerprintEmpty uint64 = 0xc15d213aa4d7a795
+
+var avroFingerprintTable = func() [256]uint64 {
+	var table [256]uint64
+	for i := range table {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (avroFingerprintEmpty & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+func avroFingerprint(data []byte) uint64 {
+	fp := avroFingerprintEmpty
+	for _, b := range data {
+		fp = (fp >> 8) ^ avroFingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
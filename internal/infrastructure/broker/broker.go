@@ -0,0 +1,274 @@
+// Package broker はアイテムイベントをメッセージブローカー（Kafka / NATS）に送信する。
+//
+// トピック（NATS ではサブジェクト）は <接頭辞>.<イベントの種類>（例: inventory.item.created）、
+// キーはアイテムIDで、同じアイテムのイベントは Kafka の同じパーティションに入る。
+// ドライバーはビルドタグを付けた場合のみ組み込まれる:
+//
+//	go build -tags kafka -o main ./cmd
+//	go build -tags nats -o main ./cmd
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// ドライバー
+const (
+	DriverKafka = "kafka"
+	DriverNATS  = "nats"
+)
+
+// メッセージの形式
+const (
+	FormatJSON = "json"
+	FormatAvro = "avro"
+)
+
+// メッセージのヘッダー
+const (
+	HeaderContentType = "content-type"
+	HeaderEventType   = "event-type"
+)
+
+const (
+	DefaultTopicPrefix = "inventory"
+	DefaultBufferSize  = 1024
+	// 1件の送信の期限
+	sendTimeout = 10 * time.Second
+	// 停止時に送信待ちのイベントを送り切るまで待つ時間
+	defaultShutdownTimeout = 10 * time.Second
+)
+
+var errClosed = errors.New("broker publisher is closed")
+
+var (
+	droppedCount   = expvar.NewInt("broker_events_dropped")
+	sendErrorCount = expvar.NewInt("broker_send_errors")
+)
+
+// ブローカーに送るメッセージ
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// ドライバーごとの送信の実装
+type producer interface {
+	Send(ctx context.Context, msg Message) error
+	// 送信中のメッセージを送り切って接続を閉じる
+	Close() error
+}
+
+type Config struct {
+	Driver          string   // kafka / nats
+	URLs            []string // Kafka のブローカーのアドレス、または NATS サーバーのURL
+	TopicPrefix     string   // トピック名の接頭辞
+	Format          string   // json / avro
+	BufferSize      int
+	ShutdownTimeout time.Duration
+}
+
+// イベントの種類のトピック名（<接頭辞>.item.created）
+func Topic(prefix, eventType string) string {
+	if prefix == "" {
+		return eventType
+	}
+	return prefix + "." + eventType
+}
+
+// Publisher はアイテムイベントをブローカーに送信する（usecase.ItemEventPublisher の実装）。
+// 送信はバックグラウンドで行い、送信待ちがバッファを超えたイベントは破棄する（破棄数は /debug/vars の broker_events_dropped）
+type Publisher struct {
+	producer    producer
+	topicPrefix string
+	encode      func(usecase.ItemEvent) ([]byte, error)
+	contentType string
+	shutdown    time.Duration
+	logf        func(format string, args ...interface{})
+
+	mu     sync.RWMutex
+	events chan usecase.ItemEvent
+	closed bool
+	done   chan struct{}
+}
+
+// 設定のドライバーでブローカーに接続する
+func New(cfg Config) (*Publisher, error) {
+	var p producer
+	var err error
+	switch cfg.Driver {
+	case DriverKafka:
+		p, err = newKafkaProducer(cfg.URLs)
+	case DriverNATS:
+		p, err = newNATSProducer(cfg.URLs)
+	default:
+		return nil, fmt.Errorf("unsupported BROKER_DRIVER: %s (supported: kafka, nats)", cfg.Driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := newPublisher(p, cfg)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return publisher, nil
+}
+
+func newPublisher(p producer, cfg Config) (*Publisher, error) {
+	publisher := &Publisher{
+		producer:    p,
+		topicPrefix: cfg.TopicPrefix,
+		shutdown:    cfg.ShutdownTimeout,
+		logf:        log.Printf,
+		done:        make(chan struct{}),
+	}
+
+	switch cfg.Format {
+	case "", FormatJSON:
+		publisher.encode = func(event usecase.ItemEvent) ([]byte, error) { return json.Marshal(event) }
+		publisher.contentType = "application/json"
+	case FormatAvro:
+		publisher.encode = EncodeAvro
+		publisher.contentType = AvroContentType
+	default:
+		return nil, fmt.Errorf("unsupported BROKER_FORMAT: %s (supported: json, avro)", cfg.Format)
+	}
+	if publisher.shutdown <= 0 {
+		publisher.shutdown = defaultShutdownTimeout
+	}
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	publisher.events = make(chan usecase.ItemEvent, bufferSize)
+
+	go publisher.run()
+	return publisher, nil
+}
+
+func (p *Publisher) Publish(_ context.Context, event usecase.ItemEvent) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		droppedCount.Add(1)
+		return
+	}
+
+	select {
+	case p.events <- event:
+	default:
+		droppedCount.Add(1)
+	}
+}
+
+// 再送するイベントを送信する（usecase.TopicSink の実装）。送信待ちのイベントとは別に、送信し終えるまで待つ
+func (p *Publisher) Replay(ctx context.Context, event usecase.ItemEvent) error {
+	return p.replay(ctx, p.topicPrefix, event)
+}
+
+// 再送するイベントを prefix のトピック（<prefix>.item.created など）に送信する
+func (p *Publisher) WithTopicPrefix(prefix string) usecase.ItemEventSink {
+	return &topicSink{publisher: p, topicPrefix: prefix}
+}
+
+func (p *Publisher) replay(ctx context.Context, topicPrefix string, event usecase.ItemEvent) error {
+	msg, err := p.message(topicPrefix, event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event for item %d: %w", event.Type, event.ItemID, err)
+	}
+
+	// 送信中に接続を閉じないよう、送信し終えるまで Close を待たせる
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return errClosed
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if err := p.producer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s event for item %d to %s: %w", event.Type, event.ItemID, msg.Topic, err)
+	}
+	return nil
+}
+
+// 別のトピックに再送する
+type topicSink struct {
+	publisher   *Publisher
+	topicPrefix string
+}
+
+func (s *topicSink) Replay(ctx context.Context, event usecase.ItemEvent) error {
+	return s.publisher.replay(ctx, s.topicPrefix, event)
+}
+
+// 送信待ちのイベントを送り切ってから接続を閉じる。ShutdownTimeout を過ぎたら残りを破棄する
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.done
+		return nil
+	}
+	p.closed = true
+	close(p.events)
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+	case <-time.After(p.shutdown):
+		p.logf("⚠️  broker: gave up sending %d item event(s) on shutdown", len(p.events))
+	}
+	return p.producer.Close()
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+
+	for event := range p.events {
+		msg, err := p.message(p.topicPrefix, event)
+		if err != nil {
+			sendErrorCount.Add(1)
+			p.logf("⚠️  broker: failed to encode %s event for item %d: %v", event.Type, event.ItemID, err)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err = p.producer.Send(ctx, msg)
+		cancel()
+		if err != nil {
+			sendErrorCount.Add(1)
+			p.logf("⚠️  broker: failed to send %s event for item %d to %s: %v", event.Type, event.ItemID, msg.Topic, err)
+		}
+	}
+}
+
+func (p *Publisher) message(topicPrefix string, event usecase.ItemEvent) (Message, error) {
+	value, err := p.encode(event)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{
+		Topic: Topic(topicPrefix, event.Type),
+		Key:   []byte(strconv.FormatInt(event.ItemID, 10)),
+		Value: value,
+		Headers: map[string]string{
+			HeaderContentType: p.contentType,
+			HeaderEventType:   event.Type,
+		},
+	}, nil
+}
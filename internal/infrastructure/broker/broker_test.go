@@ -0,0 +1,256 @@
+package broker
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeProducer は送信されたメッセージを記録する
+type fakeProducer struct {
+	mu       sync.Mutex
+	messages []Message
+	sendErr  error
+	block    chan struct{} // 閉じるまで Send を止める
+	closed   bool
+}
+
+func (p *fakeProducer) Send(ctx context.Context, msg Message) error {
+	if p.block != nil {
+		<-p.block
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.sendErr != nil {
+		return p.sendErr
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fakeProducer) sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+func newTestPublisher(t *testing.T, p producer, cfg Config) *Publisher {
+	t.Helper()
+	publisher, err := newPublisher(p, cfg)
+	require.NoError(t, err)
+	publisher.logf = t.Logf
+	return publisher
+}
+
+func testItem() *entity.Item {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &entity.Item{
+		ID:            42,
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+		Attributes:    map[string]string{"color": "black", "size": "40mm"},
+		Version:       3,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	}
+}
+
+func TestTopic(t *testing.T) {
+	assert.Equal(t, "inventory.item.created", Topic("inventory", usecase.ItemCreated))
+	assert.Equal(t, "item.created", Topic("", usecase.ItemCreated))
+}
+
+func TestPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: JSONのイベントをトピックとキーを付けて送信する", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{TopicPrefix: DefaultTopicPrefix})
+
+		event := usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 42, Item: testItem(), OccurredAt: time.Now().UTC()}
+		publisher.Publish(ctx, event)
+		require.NoError(t, publisher.Close())
+
+		messages := producer.sent()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "inventory.item.updated", messages[0].Topic)
+		assert.Equal(t, []byte("42"), messages[0].Key)
+		assert.Equal(t, map[string]string{
+			HeaderContentType: "application/json",
+			HeaderEventType:   usecase.ItemUpdated,
+		}, messages[0].Headers)
+
+		want, err := json.Marshal(event)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(messages[0].Value))
+		assert.True(t, producer.closed)
+	})
+
+	t.Run("正常系: Avroのイベントを単一オブジェクトエンコーディングで送信する", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{TopicPrefix: DefaultTopicPrefix, Format: FormatAvro})
+
+		publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 42, OccurredAt: time.UnixMilli(1)})
+		require.NoError(t, publisher.Close())
+
+		messages := producer.sent()
+		require.Len(t, messages, 1)
+		assert.Equal(t, AvroContentType, messages[0].Headers[HeaderContentType])
+		assert.Equal(t, "inventory.item.deleted", messages[0].Topic)
+
+		value := messages[0].Value
+		require.Greater(t, len(value), 10)
+		assert.Equal(t, []byte{0xC3, 0x01}, value[:2])
+		assert.Equal(t, AvroFingerprint, binary.LittleEndian.Uint64(value[2:10]))
+		// "item.deleted"（長さ12）、item_id 42、null、occurred_at 1
+		payload := append([]byte{24}, "item.deleted"...)
+		payload = append(payload, 84, 0, 2)
+		assert.Equal(t, payload, value[10:])
+	})
+
+	t.Run("正常系: 停止時に送信待ちのイベントを送り切る", func(t *testing.T) {
+		producer := &fakeProducer{block: make(chan struct{})}
+		publisher := newTestPublisher(t, producer, Config{})
+
+		for i := int64(1); i <= 3; i++ {
+			publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: i})
+		}
+		close(producer.block)
+		require.NoError(t, publisher.Close())
+
+		assert.Len(t, producer.sent(), 3)
+	})
+
+	t.Run("正常系: 停止の待ち時間を過ぎたら残りを破棄して接続を閉じる", func(t *testing.T) {
+		producer := &fakeProducer{block: make(chan struct{})}
+		defer close(producer.block)
+		publisher := newTestPublisher(t, producer, Config{ShutdownTimeout: 10 * time.Millisecond})
+
+		publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		require.NoError(t, publisher.Close())
+
+		assert.True(t, producer.closed)
+	})
+
+	t.Run("正常系: 停止後のイベントは破棄する", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{})
+		require.NoError(t, publisher.Close())
+
+		dropped := droppedCount.Value()
+		publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+
+		assert.Empty(t, producer.sent())
+		assert.Equal(t, dropped+1, droppedCount.Value())
+		assert.NoError(t, publisher.Close(), "2回目の停止は何もしない")
+	})
+
+	t.Run("正常系: 送信に失敗しても後続のイベントを送信する", func(t *testing.T) {
+		producer := &fakeProducer{sendErr: errors.New("broker unavailable")}
+		publisher := newTestPublisher(t, producer, Config{})
+
+		errorsBefore := sendErrorCount.Value()
+		publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		publisher.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2})
+		require.NoError(t, publisher.Close())
+
+		assert.Equal(t, errorsBefore+2, sendErrorCount.Value())
+	})
+}
+
+func TestPublisher_Replay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 送信し終えてから戻る", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{TopicPrefix: DefaultTopicPrefix})
+		defer publisher.Close()
+
+		require.NoError(t, publisher.Replay(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 42}))
+
+		messages := producer.sent()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "inventory.item.created", messages[0].Topic)
+		assert.Equal(t, []byte("42"), messages[0].Key)
+	})
+
+	t.Run("正常系: 別のトピックに送信する", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{TopicPrefix: DefaultTopicPrefix})
+		defer publisher.Close()
+
+		require.NoError(t, publisher.WithTopicPrefix("replay").Replay(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 42}))
+
+		messages := producer.sent()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "replay.item.deleted", messages[0].Topic)
+	})
+
+	t.Run("異常系: 送信の失敗を返す", func(t *testing.T) {
+		producer := &fakeProducer{sendErr: errors.New("broker unavailable")}
+		publisher := newTestPublisher(t, producer, Config{TopicPrefix: DefaultTopicPrefix})
+		defer publisher.Close()
+
+		err := publisher.Replay(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 42})
+		assert.EqualError(t, err, "failed to send item.created event for item 42 to inventory.item.created: broker unavailable")
+	})
+
+	t.Run("異常系: 停止後は送信しない", func(t *testing.T) {
+		producer := &fakeProducer{}
+		publisher := newTestPublisher(t, producer, Config{})
+		require.NoError(t, publisher.Close())
+
+		assert.ErrorIs(t, publisher.Replay(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 42}), errClosed)
+		assert.Empty(t, producer.sent())
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("異常系: 未対応のドライバー", func(t *testing.T) {
+		_, err := New(Config{Driver: "rabbitmq"})
+		assert.ErrorContains(t, err, "unsupported BROKER_DRIVER")
+	})
+
+	t.Run("異常系: 未対応の形式", func(t *testing.T) {
+		_, err := newPublisher(&fakeProducer{}, Config{Format: "protobuf"})
+		assert.ErrorContains(t, err, "unsupported BROKER_FORMAT")
+	})
+}
+
+func TestAvroFingerprint(t *testing.T) {
+	// Avro の仕様のテストデータ（"null" のフィンガープリント）
+	assert.Equal(t, uint64(7195948357588979594), avroFingerprint([]byte(`"null"`)))
+}
+
+func TestEncodeAvro(t *testing.T) {
+	t.Run("正常系: 属性の順序によらず同じバイト列になる", func(t *testing.T) {
+		event := usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 42, Item: testItem(), OccurredAt: time.Now()}
+		first, err := EncodeAvro(event)
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			again, err := EncodeAvro(event)
+			require.NoError(t, err)
+			assert.Equal(t, first, again)
+		}
+	})
+}
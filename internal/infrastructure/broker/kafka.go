@@ -0,0 +1,47 @@
+//go:build kafka
+
+package broker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaProducer struct {
+	writer *kafka.Writer
+}
+
+// Kafka のブローカー（host:port）に接続する。トピックはメッセージごとに指定する
+func newKafkaProducer(urls []string) (producer, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("BROKER_URLS is required for kafka")
+	}
+	return &kafkaProducer{
+		writer: &kafka.Writer{
+			Addr: kafka.TCP(urls...),
+			// キー（アイテムID）でパーティションを決め、同じアイテムのイベントの順序を保つ
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}, nil
+}
+
+func (p *kafkaProducer) Send(ctx context.Context, msg Message) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   msg.Topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+}
+
+func (p *kafkaProducer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,66 @@
+//go:build kafka
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKafkaProducer(t *testing.T) {
+	t.Run("異常系: ブローカーの指定がない", func(t *testing.T) {
+		_, err := newKafkaProducer(nil)
+		assert.ErrorContains(t, err, "BROKER_URLS")
+	})
+
+	t.Run("正常系: キーでパーティションを決めるライターを作る", func(t *testing.T) {
+		p, err := newKafkaProducer([]string{"localhost:9092"})
+		require.NoError(t, err)
+		defer p.Close()
+
+		writer := p.(*kafkaProducer).writer
+		assert.Equal(t, "localhost:9092", writer.Addr.String())
+		assert.IsType(t, &kafka.Hash{}, writer.Balancer)
+		assert.Equal(t, kafka.RequireAll, writer.RequiredAcks)
+	})
+}
+
+// KAFKA_TEST_BROKERS（例: localhost:9092）が設定されている場合のみ実際のブローカーに送信する
+func TestKafkaProducer_Send(t *testing.T) {
+	brokers := os.Getenv("KAFKA_TEST_BROKERS")
+	if brokers == "" {
+		t.Skip("KAFKA_TEST_BROKERS is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	topic := fmt.Sprintf("inventory-test-%d", time.Now().UnixNano())
+	p, err := newKafkaProducer(strings.Split(brokers, ","))
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.NoError(t, p.Send(ctx, Message{
+		Topic:   topic,
+		Key:     []byte("1"),
+		Value:   []byte(`{"type":"item.created"}`),
+		Headers: map[string]string{HeaderEventType: "item.created"},
+	}))
+
+	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: strings.Split(brokers, ","), Topic: topic})
+	defer reader.Close()
+	msg, err := reader.ReadMessage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", string(msg.Key))
+	assert.JSONEq(t, `{"type":"item.created"}`, string(msg.Value))
+	require.Len(t, msg.Headers, 1)
+	assert.Equal(t, HeaderEventType, msg.Headers[0].Key)
+	assert.Equal(t, "item.created", string(msg.Headers[0].Value))
+}
@@ -0,0 +1,41 @@
+//go:build nats
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+type natsProducer struct {
+	conn *nats.Conn
+}
+
+// NATS サーバー（nats://host:4222）に接続する。トピック名をサブジェクトにする
+func newNATSProducer(urls []string) (producer, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("BROKER_URLS is required for nats")
+	}
+	conn, err := nats.Connect(strings.Join(urls, ","), nats.Name("Aicon-assignment"))
+	if err != nil {
+		return nil, err
+	}
+	return &natsProducer{conn: conn}, nil
+}
+
+func (p *natsProducer) Send(ctx context.Context, msg Message) error {
+	m := nats.NewMsg(msg.Topic)
+	m.Data = msg.Value
+	for k, v := range msg.Headers {
+		m.Header.Set(k, v)
+	}
+	return p.conn.PublishMsg(m)
+}
+
+// 送信バッファのメッセージをサーバーに送り切ってから接続を閉じる
+func (p *natsProducer) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,56 @@
+//go:build nats
+
+package broker
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNATSProducer(t *testing.T) {
+	t.Run("異常系: サーバーの指定がない", func(t *testing.T) {
+		_, err := newNATSProducer(nil)
+		assert.ErrorContains(t, err, "BROKER_URLS")
+	})
+
+	t.Run("異常系: 接続できないサーバー", func(t *testing.T) {
+		_, err := newNATSProducer([]string{"nats://127.0.0.1:1"})
+		assert.Error(t, err)
+	})
+}
+
+// NATS_TEST_URL（例: nats://localhost:4222）が設定されている場合のみ実際のサーバーに送信する
+func TestNATSProducer_Send(t *testing.T) {
+	url := os.Getenv("NATS_TEST_URL")
+	if url == "" {
+		t.Skip("NATS_TEST_URL is not set")
+	}
+
+	sub, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer sub.Close()
+	received, err := sub.SubscribeSync("inventory.item.created")
+	require.NoError(t, err)
+	require.NoError(t, sub.Flush())
+
+	p, err := newNATSProducer([]string{url})
+	require.NoError(t, err)
+	require.NoError(t, p.Send(context.Background(), Message{
+		Topic:   "inventory.item.created",
+		Key:     []byte("1"),
+		Value:   []byte(`{"type":"item.created"}`),
+		Headers: map[string]string{HeaderEventType: "item.created"},
+	}))
+	require.NoError(t, p.Close())
+
+	msg, err := received.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"item.created"}`, string(msg.Data))
+	assert.Equal(t, "item.created", msg.Header.Get(HeaderEventType))
+}
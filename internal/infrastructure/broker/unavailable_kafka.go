@@ -0,0 +1,10 @@
+//go:build !kafka
+
+package broker
+
+import "errors"
+
+// Kafka のドライバーは kafka ビルドタグを付けた場合のみ組み込まれる
+func newKafkaProducer(urls []string) (producer, error) {
+	return nil, errors.New("kafka support is not compiled in: build with -tags kafka")
+}
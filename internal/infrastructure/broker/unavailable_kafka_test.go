@@ -0,0 +1,15 @@
+//go:build !kafka
+
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithoutKafkaDriver(t *testing.T) {
+	// kafka ビルドタグなしではドライバーが組み込まれていない
+	_, err := New(Config{Driver: DriverKafka, URLs: []string{"localhost:9092"}})
+	assert.ErrorContains(t, err, "-tags kafka")
+}
@@ -0,0 +1,10 @@
+//go:build !nats
+
+package broker
+
+import "errors"
+
+// NATS のドライバーは nats ビルドタグを付けた場合のみ組み込まれる
+func newNATSProducer(urls []string) (producer, error) {
+	return nil, errors.New("nats support is not compiled in: build with -tags nats")
+}
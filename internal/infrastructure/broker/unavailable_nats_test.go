@@ -0,0 +1,15 @@
+//go:build !nats
+
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithoutNATSDriver(t *testing.T) {
+	// nats ビルドタグなしではドライバーが組み込まれていない
+	_, err := New(Config{Driver: DriverNATS, URLs: []string{"nats://localhost:4222"}})
+	assert.ErrorContains(t, err, "-tags nats")
+}
@@ -0,0 +1,169 @@
+// Package circuitbreaker は失敗が続いた依存先（データベースなど）への呼び出しを一定時間止めるサーキットブレーカーを提供する。
+//
+// 閉（closed）では呼び出しを通し、失敗が FailureThreshold 回続くと開（open）になる。
+// 開の間は呼び出さずに ErrOpen を返し、OpenTimeout が過ぎると半開（half-open）になる。
+// 半開では HalfOpenRequests 件までの試しの呼び出しを通し、すべて成功すれば閉に、1件でも失敗すれば開に戻る。
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 開いている（または半開で試しの呼び出しの枠がない）ために呼び出さなかったことを示す。
+// 時間をおけば呼び出せるため、一時的な失敗（domainErrors.ErrTransient）として扱う
+var ErrOpen = domainErrors.Transient(errors.New("circuit breaker is open"))
+
+// ブレーカーの状態
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// ブレーカーの設定
+type Settings struct {
+	// 開くまでに続いた失敗の回数（1未満は1）
+	FailureThreshold int
+	// 開いてから半開になるまでの時間
+	OpenTimeout time.Duration
+	// 半開で通す試しの呼び出しの件数（1未満は1）
+	HalfOpenRequests int
+	// 状態が変わったときに呼ばれる（ロックを持ったまま呼ぶため、ブレーカーのメソッドは呼ばないこと）
+	OnStateChange func(from, to State)
+}
+
+type Breaker struct {
+	settings Settings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	// 開いた時刻（開の間）
+	openedAt time.Time
+	// 半開で通した試しの呼び出しの件数と、そのうち成功した件数
+	probes    int
+	successes int
+	// 状態が変わるたびに増やし、変わる前に始まった呼び出しの結果を見分ける
+	generation int
+}
+
+func New(settings Settings) *Breaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 1
+	}
+	if settings.HalfOpenRequests < 1 {
+		settings.HalfOpenRequests = 1
+	}
+	return &Breaker{settings: settings, now: time.Now}
+}
+
+// 呼び出してよいかを判定する。よければ結果を報告する done を返し、呼び出し側は結果に応じて done(成功したか) を1回呼ぶ
+// 開いている間は ErrOpen を返す
+func (b *Breaker) Allow() (done func(success bool), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	switch b.state {
+	case StateOpen:
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.probes >= b.settings.HalfOpenRequests {
+			return nil, ErrOpen
+		}
+		b.probes++
+	}
+
+	// 結果が届く前に状態が変わった場合（開いて半開になったなど）、古い呼び出しの結果は使わない
+	generation := b.generation
+	var once sync.Once
+	return func(success bool) {
+		once.Do(func() { b.report(generation, success) })
+	}, nil
+}
+
+// 現在の状態
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// 開いている場合に半開になるまでの残り時間（開いていなければ0）
+func (b *Breaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	if b.state != StateOpen {
+		return 0
+	}
+	return b.openedAt.Add(b.settings.OpenTimeout).Sub(b.now())
+}
+
+func (b *Breaker) report(generation int, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	if b.generation != generation {
+		return
+	}
+	switch b.state {
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		if !success {
+			b.setState(StateOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.settings.HalfOpenRequests {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+// 開いてから OpenTimeout が過ぎていれば半開にする
+func (b *Breaker) advance() {
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		b.setState(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(state State) {
+	from := b.state
+	b.state = state
+	b.failures, b.probes, b.successes = 0, 0, 0
+	b.generation++
+	if state == StateOpen {
+		b.openedAt = b.now()
+	}
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, state)
+	}
+}
@@ -0,0 +1,123 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 時刻を進められるブレーカー
+func newTestBreaker(settings Settings) (*Breaker, *time.Time) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	b := New(settings)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+// 呼び出しを1回通して結果を報告する
+func call(t *testing.T, b *Breaker, success bool) {
+	t.Helper()
+	done, err := b.Allow()
+	require.NoError(t, err)
+	done(success)
+}
+
+func TestBreaker(t *testing.T) {
+	settings := Settings{FailureThreshold: 3, OpenTimeout: 30 * time.Second, HalfOpenRequests: 2}
+
+	t.Run("正常系: 失敗が続くと開いて呼び出さない", func(t *testing.T) {
+		b, _ := newTestBreaker(settings)
+
+		call(t, b, false)
+		call(t, b, false)
+		assert.Equal(t, StateClosed, b.State())
+		call(t, b, false)
+
+		assert.Equal(t, StateOpen, b.State())
+		_, err := b.Allow()
+		assert.ErrorIs(t, err, ErrOpen)
+		assert.Equal(t, 30*time.Second, b.RetryAfter())
+	})
+
+	t.Run("正常系: 成功すると失敗の回数を数え直す", func(t *testing.T) {
+		b, _ := newTestBreaker(settings)
+
+		call(t, b, false)
+		call(t, b, false)
+		call(t, b, true)
+		call(t, b, false)
+		call(t, b, false)
+
+		assert.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("正常系: 時間が過ぎると半開で試しの呼び出しだけを通す", func(t *testing.T) {
+		b, now := newTestBreaker(settings)
+		for range 3 {
+			call(t, b, false)
+		}
+
+		*now = now.Add(10 * time.Second)
+		assert.Equal(t, 20*time.Second, b.RetryAfter())
+		*now = now.Add(20 * time.Second)
+		assert.Equal(t, StateHalfOpen, b.State())
+		assert.Zero(t, b.RetryAfter())
+
+		first, err := b.Allow()
+		require.NoError(t, err)
+		second, err := b.Allow()
+		require.NoError(t, err)
+		_, err = b.Allow()
+		assert.ErrorIs(t, err, ErrOpen, "試しの呼び出しの枠を超えた")
+
+		first(true)
+		assert.Equal(t, StateHalfOpen, b.State())
+		second(true)
+		assert.Equal(t, StateClosed, b.State())
+	})
+
+	t.Run("異常系: 半開で失敗するとまた開く", func(t *testing.T) {
+		b, now := newTestBreaker(settings)
+		for range 3 {
+			call(t, b, false)
+		}
+		*now = now.Add(30 * time.Second)
+
+		call(t, b, false)
+
+		assert.Equal(t, StateOpen, b.State())
+		assert.Equal(t, 30*time.Second, b.RetryAfter())
+	})
+
+	t.Run("正常系: 開く前に始まった呼び出しの結果は使わない", func(t *testing.T) {
+		b, now := newTestBreaker(settings)
+		slow, err := b.Allow()
+		require.NoError(t, err)
+		for range 3 {
+			call(t, b, false)
+		}
+		*now = now.Add(30 * time.Second)
+
+		slow(true)
+		slow(true)
+
+		assert.Equal(t, StateHalfOpen, b.State())
+	})
+
+	t.Run("正常系: 状態の変化を通知する", func(t *testing.T) {
+		var changes []string
+		b, now := newTestBreaker(Settings{
+			FailureThreshold: 1,
+			OpenTimeout:      time.Second,
+			OnStateChange:    func(from, to State) { changes = append(changes, from.String()+"->"+to.String()) },
+		})
+
+		call(t, b, false)
+		*now = now.Add(time.Second)
+		call(t, b, true)
+
+		assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, changes)
+	})
+}
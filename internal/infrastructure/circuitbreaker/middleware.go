@@ -0,0 +1,40 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// ブレーカーが閉じていない間、依存先のエラーで失敗したリクエストを 503 と Retry-After（半開になるまでの秒数）にするミドルウェア
+// リポジトリはエラーを文字列にして ErrDatabaseError で包むため、ErrOpen かどうかではなくブレーカーの状態で判断する
+// （ブレーカーを開いた障害のリクエストも503になる）
+func Middleware(b *Breaker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err == nil || c.Response().Committed {
+				return err
+			}
+			if !domainErrors.IsDatabaseError(err) && !errors.Is(err, ErrOpen) {
+				return err
+			}
+			if b.State() == StateClosed {
+				return err
+			}
+
+			c.Set(itemController.ContextKeyError, err)
+			retryAfter := max(1, int(math.Ceil(b.RetryAfter().Seconds())))
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return itemController.RespondError(c, http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Error: itemController.DatabaseUnavailableMessage,
+			})
+		}
+	}
+}
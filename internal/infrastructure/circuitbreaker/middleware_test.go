@@ -0,0 +1,80 @@
+package circuitbreaker
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+func TestMiddleware(t *testing.T) {
+	serve := func(b *Breaker, handlerErr error) *httptest.ResponseRecorder {
+		e := echo.New()
+		e.HTTPErrorHandler = itemController.HTTPErrorHandler
+		e.Use(Middleware(b))
+		e.GET("/items", func(c echo.Context) error { return handlerErr })
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		return rec
+	}
+	open := func() (*Breaker, *time.Time) {
+		b, now := newTestBreaker(Settings{FailureThreshold: 1, OpenTimeout: 30 * time.Second})
+		done, _ := b.Allow()
+		done(false)
+		return b, now
+	}
+	dbErr := fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, fmt.Errorf("%w: database queries are suspended", ErrOpen))
+
+	t.Run("正常系: 開いている間のDBのエラーは503とRetry-After", func(t *testing.T) {
+		b, now := open()
+		*now = now.Add(500 * time.Millisecond)
+
+		rec := serve(b, dbErr)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+		assert.JSONEq(t, `{"error":"database unavailable","code":"DATABASE_UNAVAILABLE"}`, rec.Body.String())
+	})
+
+	t.Run("正常系: 半開の間は1秒後に再試行させる", func(t *testing.T) {
+		b, now := open()
+		*now = now.Add(30 * time.Second)
+
+		rec := serve(b, dbErr)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+	})
+
+	t.Run("正常系: 閉じている間のDBのエラーはそのまま", func(t *testing.T) {
+		b, _ := newTestBreaker(Settings{FailureThreshold: 5, OpenTimeout: time.Second})
+
+		rec := serve(b, fmt.Errorf("%w: deadlock", domainErrors.ErrDatabaseError))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderRetryAfter))
+	})
+
+	t.Run("正常系: DB以外のエラーは開いていてもそのまま", func(t *testing.T) {
+		b, _ := open()
+
+		rec := serve(b, domainErrors.ErrItemNotFound)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("正常系: 成功したリクエストはそのまま", func(t *testing.T) {
+		b, _ := open()
+
+		rec := serve(b, nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -0,0 +1,153 @@
+// Package concurrency は同時に処理するリクエストの数を制限するミドルウェアを提供する。
+//
+// サーバー全体と、エクスポートのような重いルートごとに上限を設け、上限に達している間のリクエストは待たせずに
+// 503 と Retry-After を返す。アクセスが急増しても、処理中のリクエストがDBの接続を奪い合い続けないようにする。
+// 上限は実行中に変更できる（設定ファイルの MAX_CONCURRENT_REQUESTS / ROUTE_CONCURRENCY_LIMITS の変更を再起動せずに反映する）。
+package concurrency
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// 上限に達したときのエラーメッセージ
+const Message = "server busy"
+
+// 上限に達したときに返す Retry-After（秒）。処理中のリクエストはすぐに終わることが多いため短くする
+const retryAfterSeconds = 1
+
+// 処理中のリクエストと上限に達して断ったリクエストの件数（/debug/vars で参照できる）
+// in_flight はサーバー全体の上限に数えている処理中のリクエスト、rejected はルート別の断った件数
+var (
+	metrics         = expvar.NewMap("http_concurrency")
+	inFlightMetric  = new(expvar.Int)
+	rejectedMetrics = new(expvar.Map).Init()
+)
+
+func init() {
+	metrics.Set("in_flight", inFlightMetric)
+	metrics.Set("rejected", rejectedMetrics)
+}
+
+// 同時に処理するリクエストの上限
+// Routes のキーは "GET /exports/items" のようなメソッドとルートのパターンで、ルートはバージョンの接頭辞（/v1 など）を除いたパス
+// ルートの値が0の場合は、そのルートを上限に数えない（WebSocket のように接続が長く続くルート用）
+type Policy struct {
+	// サーバー全体の上限（0で制限しない）
+	Global int
+	Routes map[string]int
+}
+
+// "GET /exports/items=4,POST /labels/batch=2" 形式のルートごとの上限を読み込む
+func ParseRoutes(s string) (map[string]int, error) {
+	routes := make(map[string]int)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(part, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		path = strings.TrimSpace(path)
+		if !ok || !hasPath || method == "" || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid route concurrency limit %q: expected e.g. GET /exports/items=4", part)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid route concurrency limit %q: limit must be 0 or greater", part)
+		}
+		routes[strings.ToUpper(method)+" "+path] = limit
+	}
+	return routes, nil
+}
+
+// 処理中のリクエストを数え、上限を超えるリクエストを断る
+type Limiter struct {
+	mu       sync.Mutex
+	policy   Policy
+	inFlight int
+	routes   map[string]int
+}
+
+func New(policy Policy) *Limiter {
+	return &Limiter{policy: policy, routes: make(map[string]int)}
+}
+
+// 上限を変更する。処理中のリクエストはそのまま続け、新しいリクエストから適用する
+func (l *Limiter) SetPolicy(policy Policy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.policy = policy
+}
+
+// route（"GET /exports/items"）のリクエストを処理してよいかを判定する
+// よければ処理の終了時に呼ぶ release を返し、上限に達していれば ok に false を返す
+func (l *Limiter) Acquire(route string) (release func(), ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit, limited := l.policy.Routes[route]
+	if limited && limit == 0 {
+		return func() {}, true
+	}
+	if l.policy.Global > 0 && l.inFlight >= l.policy.Global {
+		return nil, false
+	}
+	if limited && l.routes[route] >= limit {
+		return nil, false
+	}
+
+	l.inFlight++
+	inFlightMetric.Add(1)
+	if limited {
+		l.routes[route]++
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.release(route, limited) })
+	}, true
+}
+
+func (l *Limiter) release(route string, limited bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	inFlightMetric.Add(-1)
+	// 処理中に上限が変わっても、始めたときに数えた分を減らす
+	if limited {
+		if l.routes[route]--; l.routes[route] <= 0 {
+			delete(l.routes, route)
+		}
+	}
+}
+
+// 同時に処理するリクエストの数を制限するミドルウェア。ヘルスチェックは制限しない
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Path() == "/health" {
+				return next(c)
+			}
+			route := c.Request().Method + " " + apiversion.Route(c.Path())
+			release, ok := l.Acquire(route)
+			if !ok {
+				rejectedMetrics.Add(route, 1)
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+				return itemController.RespondError(c, http.StatusServiceUnavailable, itemController.ErrorResponse{
+					Error: Message,
+				})
+			}
+			defer release()
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package concurrency
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Acquire(t *testing.T) {
+	t.Run("正常系: サーバー全体の上限まで許可し、終わったら再び許可する", func(t *testing.T) {
+		l := New(Policy{Global: 2})
+
+		first, ok := l.Acquire("GET /items")
+		require.True(t, ok)
+		_, ok = l.Acquire("GET /items/:id")
+		require.True(t, ok)
+		_, ok = l.Acquire("GET /items")
+		assert.False(t, ok)
+
+		first()
+		// release は何度呼んでも1回分だけ減らす
+		first()
+		_, ok = l.Acquire("GET /items")
+		assert.True(t, ok)
+		_, ok = l.Acquire("GET /items")
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: ルートの上限はそのルートだけに適用する", func(t *testing.T) {
+		l := New(Policy{Global: 10, Routes: map[string]int{"GET /exports/items": 1}})
+
+		release, ok := l.Acquire("GET /exports/items")
+		require.True(t, ok)
+		_, ok = l.Acquire("GET /exports/items")
+		assert.False(t, ok)
+		_, ok = l.Acquire("GET /items")
+		assert.True(t, ok)
+
+		release()
+		_, ok = l.Acquire("GET /exports/items")
+		assert.True(t, ok)
+	})
+
+	t.Run("正常系: 上限が0のルートは数えない", func(t *testing.T) {
+		l := New(Policy{Global: 1, Routes: map[string]int{"GET /ws": 0}})
+
+		for i := 0; i < 3; i++ {
+			_, ok := l.Acquire("GET /ws")
+			require.True(t, ok)
+		}
+		_, ok := l.Acquire("GET /items")
+		assert.True(t, ok)
+	})
+
+	t.Run("正常系: 0は制限しない", func(t *testing.T) {
+		l := New(Policy{})
+
+		for i := 0; i < 100; i++ {
+			_, ok := l.Acquire("GET /items")
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("正常系: 上限の変更は新しいリクエストから適用する", func(t *testing.T) {
+		l := New(Policy{Global: 1, Routes: map[string]int{"GET /exports/items": 1}})
+		release, ok := l.Acquire("GET /exports/items")
+		require.True(t, ok)
+
+		l.SetPolicy(Policy{Global: 2})
+		_, ok = l.Acquire("GET /exports/items")
+		assert.True(t, ok)
+		_, ok = l.Acquire("GET /items")
+		assert.False(t, ok)
+
+		// 変更前に始めたリクエストの分もルートの数から減らす
+		release()
+		assert.Empty(t, l.routes)
+	})
+}
+
+func TestParseRoutes(t *testing.T) {
+	t.Run("正常系: ルートごとの上限を読み込む", func(t *testing.T) {
+		routes, err := ParseRoutes(" get /exports/items=4, POST /labels/batch = 2 ,")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"GET /exports/items": 4, "POST /labels/batch": 2}, routes)
+	})
+
+	t.Run("異常系: 不正な指定", func(t *testing.T) {
+		for _, s := range []string{"/exports/items=4", "GET exports=4", "GET /exports/items", "GET /exports/items=-1", "GET /exports/items=many"} {
+			_, err := ParseRoutes(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	l := New(Policy{Global: 1})
+	e := echo.New()
+	e.Use(l.Middleware())
+
+	started, finish := make(chan struct{}), make(chan struct{})
+	e.GET("/items", func(c echo.Context) error {
+		close(started)
+		<-finish
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/items/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	do := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	done := make(chan int)
+	go func() { done <- do("/items").Code }()
+	<-started
+
+	rec := do("/items/1")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, Message, body["error"])
+	assert.Equal(t, "SERVER_BUSY", body["code"])
+
+	// ヘルスチェックは制限しない
+	assert.Equal(t, http.StatusOK, do("/health").Code)
+
+	close(finish)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, do("/items/1").Code)
+}
@@ -1,38 +1,929 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-var (
+// 設定。Load で環境変数（と .env ファイル、CONFIG_FILE の YAML ファイル）から読み込み、起動時に検証する
+type Config struct {
+	// 設定ファイル（YAML、環境変数でだけ指定できる）のパスと、ファイルの変更を確認する間隔（0で監視しない）
+	ConfigFile           string
+	ConfigReloadInterval time.Duration
+
+	// ログレベル（info / warn / error）
+	LogLevel string
+
+	// クライアント（IPアドレス）ごとの1秒あたりのリクエスト数の上限（0で制限しない）と、一時的に超えてよい回数
+	RateLimitRPS   int
+	RateLimitBurst int
+
+	// 同時に処理するリクエストの上限（0で制限しない）と、ルートごとの上限（例: "GET /exports/items=4"、0で数えない）
+	MaxConcurrentRequests  int
+	RouteConcurrencyLimits string
+
+	// JSONのリクエストボディのサイズ（バイト）と、オブジェクト・配列の入れ子の深さの上限
+	JSONMaxBodySize int
+	JSONMaxDepth    int
+
+	// データベースの種類（mysql / sqlite）と、SQLite使用時のファイルパス
+	DBDriver   string
+	SQLitePath string
+
+	// アイテムの保存先（sql / mongodb）と、MongoDB使用時の接続先
+	ItemStore     string
+	MongoURI      string
+	MongoDatabase string
+
+	// 検索（/items/search）で見つかるのに必要な関連度（0〜1）。1 は入力どおりの語を含むアイテムだけ、小さいほど綴りの誤りを許す
+	SearchMinScore float64
+	// 検索に使う Elasticsearch / OpenSearch の接続先（空なら使わず、アイテムを読んで探す）とインデックス名、Basic 認証、1回のリクエストの期限
+	SearchIndexURL      string
+	SearchIndexName     string
+	SearchIndexUsername string
+	SearchIndexPassword string
+	SearchIndexTimeout  time.Duration
+
+	// 起動時に未適用のマイグレーションを自動で適用するかどうか
+	MigrateOnStart bool
+
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBName     string
 	DBPort     string
-)
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
+	// 読み取り専用の処理に使うリードレプリカ（未設定ならプライマリのみ）。認証情報とDB名はプライマリと共通
+	DBReadHost string
+	DBReadPort string
+
+	// コネクションプール（同時接続数・アイドル接続数・接続の寿命）と接続タイムアウト
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+	DBConnectTimeout  time.Duration
+
+	// DBのサーキットブレーカー（障害が続いたら開いてクエリを止める回数、試しのクエリを通すまでの時間と件数）。回数が0なら使わない
+	DBCircuitBreakerFailures         int
+	DBCircuitBreakerOpenTimeout      time.Duration
+	DBCircuitBreakerHalfOpenRequests int
+
+	// レスポンスのアイテムの name / brand をHTMLエスケープするかどうか
+	SanitizeHTML bool
+
+	// Accept で指定がない場合のアイテムAPIのレスポンス形式（json / jsonapi / msgpack / protobuf / xml）
+	ResponseFormat string
+
+	// アイテムのJSONをリフレクションなしのエンコーダーで出力するかどうか
+	FastJSON bool
+
+	// JSONのエラーレスポンスの形式（default / problem）
+	ErrorFormat string
+
+	// 外部から見たAPIのベースURL（ラベルのQRコードに埋め込む）
+	PublicBaseURL string
+
+	// ラベル印刷のデフォルトテンプレートと追加テンプレートのJSONファイル
+	LabelTemplate      string
+	LabelTemplatesFile string
+
+	// アイテムの共有リンクのトークンに署名するシークレット（未設定なら起動ごとに生成し、再起動で発行済みのリンクが無効になる）
+	ShareLinkSecret string
+
+	// 生成したエクスポートファイルを保持する期間
+	ExportRetention time.Duration
+
+	// この時間を超えたクエリをログに出す（0で無効）
+	SlowQueryThreshold time.Duration
+	// 1つのSQL文の期限。結果を返し始めるまでにこの時間を過ぎたクエリは取り消す（0で無効）
+	DBStatementTimeout time.Duration
+
+	// 5xxエラーの通知先（未設定の場合はログのみ）と、通知に付ける環境名・リリース
+	SentryDSN  string
+	AppEnv     string
+	AppRelease string
+
+	// アクセスログ（NDJSON）の有効化、出力先ファイル（空なら標準出力）、
+	// ステータスクラスごとのサンプリング割合（例: 2xx:0.1,5xx:1）と追加で伏せるフィールド
+	AccessLogEnabled     bool
+	AccessLogFile        string
+	AccessLogSampleRates string
+	AccessLogRedact      []string
+
+	// リクエストのレイテンシーの SLO（管理用サーバーの /metrics の SLI・バーンレート）。
+	// 閾値以内に 5xx 以外で応答したリクエストの割合の目標
+	SLOLatencyThreshold time.Duration
+	SLOObjective        float64
+
+	// サンドボックス（X-Sandbox）を利用できる連携用APIキーと、データセットの保持期間
+	SandboxAPIKeys []string
+	SandboxTTL     time.Duration
+
+	// Idempotency-Key 付きのアイテム登録のレスポンスを保持する期間
+	IdempotencyTTL time.Duration
+
+	// API 自身以外に /ws への接続を許可するページのオリジン（* ですべて許可）
+	WebSocketAllowedOrigins []string
+
+	// ブラウザーから API を呼び出せるページのオリジン（空なら CORS のヘッダーを返さない、* ですべて許可）と、
+	// 許可するメソッド・リクエストヘッダー、Cookie・Authorization を送れるか（credentials）、プリフライトの結果をキャッシュさせる期間
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool
+	CORSMaxAge           time.Duration
+
+	// Webhook 配信の1回の試行の期限、試行回数の上限、再試行の間隔（初回、以降は倍々）とその上限
+	WebhookTimeout     time.Duration
+	WebhookMaxAttempts int
+	WebhookRetryBase   time.Duration
+	WebhookRetryMax    time.Duration
+	// 内部ネットワーク（localhost・プライベートアドレスなど）への Webhook を許可するか（開発用）
+	WebhookAllowPrivateNetworks bool
+
+	// アウトボックスの未配信のイベントを探す間隔と、配信済みのイベントを残す期間
+	OutboxPollInterval time.Duration
+	OutboxRetention    time.Duration
+
+	// アイテムイベントを送信するメッセージブローカー（kafka / nats、空なら送信しない）と接続先、トピック名の接頭辞、メッセージの形式（json / avro）
+	BrokerDriver      string
+	BrokerURLs        []string
+	BrokerTopicPrefix string
+	BrokerFormat      string
+
+	// メール通知の送信に使う SMTP サーバー（ホストが空なら送信しない）と差出人
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	// 通知ルールの min_price を省略したときのしきい値と、メールのテンプレートを差し替えるディレクトリ
+	NotificationPriceThreshold int
+	NotificationTemplateDir    string
+
+	// 期限を通知する日付型のカスタム属性のキーと、期限の何日前に通知するか、期限の近いアイテムを調べる間隔（0で無効）
+	ReminderAttributes []string
+	ReminderDays       []int
+	ReminderInterval   time.Duration
+
+	// 削除したアイテムをゴミ箱に残す日数と、アイテムのリビジョンを残す日数（過ぎたら完全に削除する、0なら削除しない）、
+	// 保持期間を過ぎたデータを削除する間隔（0で無効）
+	TrashRetentionDays    int
+	RevisionRetentionDays int
+	RetentionInterval     time.Duration
+	// ユーザーのデータの削除依頼（DELETE /me）から削除するまでの猶予日数。この間は依頼を取り消せる（0なら次の削除の実行で削除する）
+	ErasureGraceDays int
+	// 自分のデータのエクスポートと削除（/me）を利用できる、ユーザーを認証した連携先のAPIキー（空なら /me は使えない）と、
+	// 削除の確認の署名に使うシークレット（空なら起動ごとに生成する）
+	PrivacyAPIKeys     []string
+	ErasureTokenSecret string
+	// 削除を取り消せる期間（0で無効）と、取り消しのトークンの署名に使うシークレット（空なら起動ごとに生成する）
+	UndoDeleteWindow time.Duration
+	UndoTokenSecret  string
+
+	// ポートフォリオの評価額を記録する毎日の時刻（HH:MM、ローカル時刻。off で記録しない）
+	PortfolioSnapshotTime string
+
+	// 時価の取得に使う相場 API（URL が空なら取得しない）と API キー、評価の提供元として記録する名前
+	MarketPriceURL    string
+	MarketPriceAPIKey string
+	MarketPriceSource string
+	// 相場 API の1回の問い合わせの期限と、取得した相場をキャッシュする期間
+	MarketPriceTimeout  time.Duration
+	MarketPriceCacheTTL time.Duration
+
+	// 為替レートの提供元（ecb / openexchangerates / none）と接続先（空なら提供元の既定）、Open Exchange Rates の App ID
+	ExchangeRateProvider string
+	ExchangeRateURL      string
+	ExchangeRateAPIKey   string
+	// 提供元への1回の問い合わせの期限と、取得したレートをキャッシュする期間
+	ExchangeRateTimeout  time.Duration
+	ExchangeRateCacheTTL time.Duration
+
+	// レシートの読み取りに使う OCR サービス（http / google-vision / none）と接続先、API キー、1回の問い合わせの期限
+	OCRProvider string
+	OCRURL      string
+	OCRAPIKey   string
+	OCRTimeout  time.Duration
+
+	// アイテム画像のファイルを保存するディレクトリと、1枚あたりのサイズの上限（バイト）
+	ImageDir     string
+	ImageMaxSize int
+
+	// 領収書・鑑定書などの書類のファイルを保存するディレクトリと、1件あたりのサイズの上限（バイト）
+	DocumentDir     string
+	DocumentMaxSize int
+
+	// ユーザーが所有できるアイテムの数と、アイテムごとの添付ファイル（画像と書類の合計）の数の上限（0で制限しない）
+	QuotaItemsPerUser       int
+	QuotaAttachmentsPerItem int
+
+	// 管理用サーバーで作成するバックアップを保存するディレクトリ
+	BackupDir string
+
+	// 画像などのファイルの保存先（local / s3 / gcs）と、S3 / GCS のバケット・画像と書類とバックアップのオブジェクト名の接頭辞・リージョン・エンドポイント
+	MediaStorage        string
+	MediaBucket         string
+	MediaPrefix         string
+	MediaDocumentPrefix string
+	MediaBackupPrefix   string
+	MediaRegion         string
+	MediaEndpoint       string
+	// どの画像からも参照されないファイルを削除する間隔（0で無効）
+	MediaCleanupInterval time.Duration
+	// 画像・書類のファイルの署名付き URL の有効期間と、署名するシークレット（未設定なら起動ごとに生成する）
+	MediaURLTTL    time.Duration
+	MediaURLSecret string
+
+	// カテゴリー別集計のキャッシュを使う最大期間（0で無効）
+	SummaryCacheMaxStaleness time.Duration
+
+	// アイテムの登録・更新の検証ルール: ブランドを必須にするか、購入価格の上限（0で上限なし）、
+	// 購入日の範囲（YYYY-MM-DD、上限は today も可、空なら制限なし）
+	ValidationBrandRequired    bool
+	ValidationMaxPurchasePrice int
+	ValidationMinPurchaseDate  string
+	ValidationMaxPurchaseDate  string
+
+	// HTTPサーバーの待ち受けアドレスとタイムアウト（リクエスト全体の読み込み・ヘッダーの読み込み・レスポンスの書き込み・keep-alive の待機）
+	HTTPAddr              string
+	HTTPReadTimeout       time.Duration
+	HTTPReadHeaderTimeout time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+
+	// ハンドラーの処理期限（リクエストのコンテキストに設定し、過ぎたら503）と、
+	// ルートごとの上書き（例: "POST /labels/batch=30s,GET /items/:id=2s"、0で期限なし）
+	HandlerTimeout time.Duration
+	RouteTimeouts  string
+
+	// 終了シグナル（SIGINT / SIGTERM）を受けてから処理中のリクエストの完了を待つ最大時間と、
+	// その後にバックグラウンドの処理（Webhook・メール通知・ブローカーなど）を止めるときに段階ごとに待つ最大時間
+	ShutdownTimeout      time.Duration
+	ShutdownDrainTimeout time.Duration
+
+	// TLS の証明書と秘密鍵（両方指定するとHTTPSで待ち受け、HTTP/2 を使う）と、
+	// TLS を終端するロードバランサーの背後で平文の HTTP/2（h2c）を受け付けるかどうか
+	TLSCertFile    string
+	TLSKeyFile     string
+	HTTP2Cleartext bool
+	// 証明書ファイルの代わりに Let's Encrypt（ACME）で証明書を取得するドメインと、取得した証明書の保存先ディレクトリ・
+	// 期限切れなどの連絡を受け取るメールアドレス
+	TLSAutocertDomains  []string
+	TLSAutocertCacheDir string
+	TLSAutocertEmail    string
+	// HTTPS にリダイレクトする平文の HTTP の待ち受けアドレス（例: :80、空なら待ち受けない）
+	HTTPRedirectAddr string
+
+	// 管理用サーバー（pprof / 実行時統計）の有効化と待ち受けアドレス
+	AdminEnabled bool
+	AdminAddr    string
+
+	// 内部サービス向け gRPC API（ItemService）の有効化と待ち受けアドレス
+	GRPCEnabled bool
+	GRPCAddr    string
+
+	// 読み込んだ設定の一覧（Effective で出力する）
+	settings []setting
+	// 読み込みに使った環境変数（設定ファイルの変更時に読み込み直す）
+	getenv func(string) string
+}
+
+// 読み込んだ1つの設定
+type setting struct {
+	key    string
+	value  string
+	secret bool
+}
+
+// 環境変数（と .env ファイル、CONFIG_FILE の YAML ファイル）から設定を読み込んで検証する。
+// 不正な値・足りない値はすべてまとめて1つのエラーにする
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil {
 		log.Println("⚠️  .envファイルが見つかりませんでした。")
 	}
+	return LoadFrom(os.Getenv)
+}
+
+// getenv から設定を読み込んで検証する。CONFIG_FILE が指定されていれば、環境変数にない設定をそのファイルから読み込む
+func LoadFrom(getenv func(string) string) (*Config, error) {
+	c := &Config{getenv: getenv}
+	c.ConfigFile = getenv("CONFIG_FILE")
+	lookup := getenv
+	var fileValues map[string]string
+	if c.ConfigFile != "" {
+		values, err := readFile(c.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration:\nCONFIG_FILE: %w", err)
+		}
+		fileValues = values
+		lookup = func(key string) string {
+			if v := getenv(key); v != "" {
+				return v
+			}
+			return fileValues[key]
+		}
+	}
+
+	r := &reader{getenv: lookup}
+	r.record("CONFIG_FILE", c.ConfigFile, false)
+	c.ConfigReloadInterval = r.duration("CONFIG_RELOAD_INTERVAL", 5*time.Second)
+
+	c.LogLevel = r.string("LOG_LEVEL", "info")
+	c.RateLimitRPS = r.int("RATE_LIMIT_RPS", 0)
+	c.RateLimitBurst = r.int("RATE_LIMIT_BURST", 20)
+	c.MaxConcurrentRequests = r.int("MAX_CONCURRENT_REQUESTS", 100)
+	c.RouteConcurrencyLimits = r.string("ROUTE_CONCURRENCY_LIMITS", "")
+
+	c.JSONMaxBodySize = r.int("JSON_MAX_BODY_SIZE", 1<<20)
+	c.JSONMaxDepth = r.int("JSON_MAX_DEPTH", 32)
+
+	c.DBDriver = r.string("DB_DRIVER", "mysql")
+	c.SQLitePath = r.string("SQLITE_PATH", "items.db")
+
+	c.ItemStore = r.string("ITEM_STORE", "sql")
+	c.MongoURI = r.url("MONGODB_URI", "mongodb://localhost:27017")
+	c.MongoDatabase = r.string("MONGODB_DATABASE", "items_db")
+
+	c.SearchMinScore = r.float("SEARCH_MIN_SCORE", 0.7)
+	c.SearchIndexURL = r.url("SEARCH_INDEX_URL", "")
+	c.SearchIndexName = r.string("SEARCH_INDEX_NAME", "items")
+	c.SearchIndexUsername = r.string("SEARCH_INDEX_USERNAME", "")
+	c.SearchIndexPassword = r.secret("SEARCH_INDEX_PASSWORD")
+	c.SearchIndexTimeout = r.duration("SEARCH_INDEX_TIMEOUT", 5*time.Second)
+
+	c.MigrateOnStart = r.bool("MIGRATE_ON_START", true)
+
+	c.DBUser = r.string("DB_USER", "")
+	c.DBPassword = r.secret("DB_PASSWORD")
+	c.DBHost = r.string("DB_HOST", "")
+	c.DBPort = r.string("DB_PORT", "")
+	c.DBName = r.string("DB_NAME", "")
+
+	c.DBReadHost = r.string("DB_READ_HOST", "")
+	c.DBReadPort = r.string("DB_READ_PORT", c.DBPort)
+
+	c.DBMaxOpenConns = r.int("DB_MAX_OPEN_CONNS", 25)
+	c.DBMaxIdleConns = r.int("DB_MAX_IDLE_CONNS", 10)
+	c.DBConnMaxLifetime = r.duration("DB_CONN_MAX_LIFETIME", 5*time.Minute)
+	c.DBConnMaxIdleTime = r.duration("DB_CONN_MAX_IDLE_TIME", time.Minute)
+	c.DBConnectTimeout = r.duration("DB_CONNECT_TIMEOUT", 10*time.Second)
+	c.DBCircuitBreakerFailures = r.int("DB_CIRCUIT_BREAKER_FAILURES", 5)
+	c.DBCircuitBreakerOpenTimeout = r.duration("DB_CIRCUIT_BREAKER_OPEN_TIMEOUT", 30*time.Second)
+	c.DBCircuitBreakerHalfOpenRequests = r.int("DB_CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", 1)
+
+	c.SanitizeHTML = r.bool("SANITIZE_HTML", false)
+	c.ResponseFormat = r.string("RESPONSE_FORMAT", "json")
+	c.FastJSON = r.bool("FAST_JSON", true)
+	c.ErrorFormat = r.string("ERROR_FORMAT", "default")
+
+	c.PublicBaseURL = r.string("PUBLIC_BASE_URL", "http://localhost:8080")
+	c.LabelTemplate = r.string("LABEL_TEMPLATE", "a4-3x8")
+	c.LabelTemplatesFile = r.string("LABEL_TEMPLATES_FILE", "")
+
+	c.ShareLinkSecret = r.secret("SHARE_LINK_SECRET")
+
+	c.ExportRetention = r.duration("EXPORT_RETENTION", 24*time.Hour)
+
+	c.SlowQueryThreshold = r.duration("SLOW_QUERY_THRESHOLD", 200*time.Millisecond)
+	c.DBStatementTimeout = r.duration("DB_STATEMENT_TIMEOUT", 30*time.Second)
+
+	c.SentryDSN = r.secret("SENTRY_DSN")
+	c.AppEnv = r.string("APP_ENV", "development")
+	c.AppRelease = r.string("APP_RELEASE", "")
+
+	c.AccessLogEnabled = r.bool("ACCESS_LOG_ENABLED", true)
+	c.AccessLogFile = r.string("ACCESS_LOG_FILE", "")
+	c.AccessLogSampleRates = r.string("ACCESS_LOG_SAMPLE_RATES", "")
+	c.AccessLogRedact = r.list("ACCESS_LOG_REDACT", nil)
+
+	c.SLOLatencyThreshold = r.duration("SLO_LATENCY_THRESHOLD", 200*time.Millisecond)
+	c.SLOObjective = r.float("SLO_OBJECTIVE", 0.99)
+
+	c.SandboxAPIKeys = r.secretList("SANDBOX_API_KEYS")
+	c.SandboxTTL = r.duration("SANDBOX_TTL", 24*time.Hour)
+
+	c.IdempotencyTTL = r.duration("IDEMPOTENCY_TTL", 24*time.Hour)
+
+	c.WebSocketAllowedOrigins = r.list("WS_ALLOWED_ORIGINS", nil)
+
+	c.CORSAllowedOrigins = r.list("CORS_ALLOWED_ORIGINS", nil)
+	c.CORSAllowedMethods = r.list("CORS_ALLOWED_METHODS", []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"})
+	c.CORSAllowedHeaders = r.list("CORS_ALLOWED_HEADERS", []string{
+		"Accept", "Authorization", "Content-Type", "If-Match", "If-None-Match", "Idempotency-Key", "Prefer",
+		"API-Version", "X-Request-ID", "X-API-Key", "X-Sandbox", "X-Tenant-ID", "X-User-ID",
+	})
+	c.CORSAllowCredentials = r.bool("CORS_ALLOW_CREDENTIALS", false)
+	c.CORSMaxAge = r.duration("CORS_MAX_AGE", 10*time.Minute)
+
+	c.WebhookTimeout = r.duration("WEBHOOK_TIMEOUT", 10*time.Second)
+	c.WebhookMaxAttempts = r.int("WEBHOOK_MAX_ATTEMPTS", 8)
+	c.WebhookRetryBase = r.duration("WEBHOOK_RETRY_BASE", 30*time.Second)
+	c.WebhookRetryMax = r.duration("WEBHOOK_RETRY_MAX", time.Hour)
+	c.WebhookAllowPrivateNetworks = r.bool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false)
+
+	c.OutboxPollInterval = r.duration("OUTBOX_POLL_INTERVAL", time.Second)
+	c.OutboxRetention = r.duration("OUTBOX_RETENTION", 24*time.Hour)
+
+	c.BrokerDriver = r.string("BROKER_DRIVER", "")
+	c.BrokerURLs = r.list("BROKER_URLS", nil)
+	c.BrokerTopicPrefix = r.string("BROKER_TOPIC_PREFIX", "inventory")
+	c.BrokerFormat = r.string("BROKER_FORMAT", "json")
+
+	c.SMTPHost = r.string("SMTP_HOST", "")
+	c.SMTPPort = r.int("SMTP_PORT", 587)
+	c.SMTPUsername = r.string("SMTP_USERNAME", "")
+	c.SMTPPassword = r.secret("SMTP_PASSWORD")
+	c.SMTPFrom = r.string("SMTP_FROM", "inventory@localhost")
+	c.NotificationPriceThreshold = r.int("NOTIFICATION_PRICE_THRESHOLD", 1000000)
+	c.NotificationTemplateDir = r.string("NOTIFICATION_TEMPLATE_DIR", "")
+
+	c.ReminderAttributes = r.list("REMINDER_ATTRIBUTES", []string{"warranty_expires", "insurance_expires"})
+	c.ReminderDays = r.intList("REMINDER_DAYS", []int{30, 7})
+	c.ReminderInterval = r.duration("REMINDER_INTERVAL", time.Hour)
+
+	c.TrashRetentionDays = r.int("TRASH_RETENTION_DAYS", 30)
+	c.RevisionRetentionDays = r.int("REVISION_RETENTION_DAYS", 0)
+	c.RetentionInterval = r.duration("RETENTION_INTERVAL", time.Hour)
+	c.ErasureGraceDays = r.int("ERASURE_GRACE_DAYS", 30)
+	c.PrivacyAPIKeys = r.secretList("PRIVACY_API_KEYS")
+	c.ErasureTokenSecret = r.secret("ERASURE_TOKEN_SECRET")
+	c.UndoDeleteWindow = r.duration("UNDO_DELETE_WINDOW", 30*time.Second)
+	c.UndoTokenSecret = r.secret("UNDO_TOKEN_SECRET")
+
+	c.PortfolioSnapshotTime = r.string("PORTFOLIO_SNAPSHOT_TIME", "03:00")
+
+	c.MarketPriceURL = r.string("MARKET_PRICE_URL", "")
+	c.MarketPriceAPIKey = r.secret("MARKET_PRICE_API_KEY")
+	c.MarketPriceSource = r.string("MARKET_PRICE_SOURCE", "chrono24")
+	c.MarketPriceTimeout = r.duration("MARKET_PRICE_TIMEOUT", 5*time.Second)
+	c.MarketPriceCacheTTL = r.duration("MARKET_PRICE_CACHE_TTL", time.Hour)
+
+	c.ExchangeRateProvider = r.string("EXCHANGE_RATE_PROVIDER", "ecb")
+	c.ExchangeRateURL = r.string("EXCHANGE_RATE_URL", "")
+	c.ExchangeRateAPIKey = r.secret("EXCHANGE_RATE_API_KEY")
+	c.ExchangeRateTimeout = r.duration("EXCHANGE_RATE_TIMEOUT", 5*time.Second)
+	c.ExchangeRateCacheTTL = r.duration("EXCHANGE_RATE_CACHE_TTL", 6*time.Hour)
+
+	c.OCRProvider = r.string("OCR_PROVIDER", "none")
+	c.OCRURL = r.string("OCR_URL", "")
+	c.OCRAPIKey = r.secret("OCR_API_KEY")
+	c.OCRTimeout = r.duration("OCR_TIMEOUT", 8*time.Second)
+
+	c.ImageDir = r.string("IMAGE_DIR", "images")
+	c.ImageMaxSize = r.int("IMAGE_MAX_SIZE", 10<<20)
+
+	c.DocumentDir = r.string("DOCUMENT_DIR", "documents")
+	c.DocumentMaxSize = r.int("DOCUMENT_MAX_SIZE", 20<<20)
+
+	c.QuotaItemsPerUser = r.int("QUOTA_ITEMS_PER_USER", 0)
+	c.QuotaAttachmentsPerItem = r.int("QUOTA_ATTACHMENTS_PER_ITEM", 0)
+
+	c.BackupDir = r.string("BACKUP_DIR", "backups")
 
-	DBUser = os.Getenv("DB_USER")
-	DBPassword = os.Getenv("DB_PASSWORD")
-	DBHost = os.Getenv("DB_HOST")
-	DBPort = os.Getenv("DB_PORT")
-	DBName = os.Getenv("DB_NAME")
+	c.MediaStorage = r.string("MEDIA_STORAGE", "local")
+	c.MediaBucket = r.string("MEDIA_BUCKET", "")
+	c.MediaPrefix = r.string("MEDIA_PREFIX", "item-images/")
+	c.MediaDocumentPrefix = r.string("MEDIA_DOCUMENT_PREFIX", "item-documents/")
+	c.MediaBackupPrefix = r.string("MEDIA_BACKUP_PREFIX", "backups/")
+	c.MediaRegion = r.string("MEDIA_REGION", "")
+	c.MediaEndpoint = r.string("MEDIA_ENDPOINT", "")
+	c.MediaCleanupInterval = r.duration("MEDIA_CLEANUP_INTERVAL", time.Hour)
+	c.MediaURLTTL = r.duration("MEDIA_URL_TTL", 15*time.Minute)
+	c.MediaURLSecret = r.secret("MEDIA_URL_SECRET")
+
+	c.SummaryCacheMaxStaleness = r.duration("SUMMARY_CACHE_MAX_STALENESS", 30*time.Second)
+
+	c.ValidationBrandRequired = r.bool("VALIDATION_BRAND_REQUIRED", true)
+	c.ValidationMaxPurchasePrice = r.int("VALIDATION_MAX_PURCHASE_PRICE", 0)
+	c.ValidationMinPurchaseDate = r.string("VALIDATION_MIN_PURCHASE_DATE", "")
+	c.ValidationMaxPurchaseDate = r.string("VALIDATION_MAX_PURCHASE_DATE", "")
+
+	c.HTTPAddr = r.string("PORT", ":8080")
+	c.HTTPReadTimeout = r.duration("HTTP_READ_TIMEOUT", 30*time.Second)
+	c.HTTPReadHeaderTimeout = r.duration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second)
+	c.HTTPWriteTimeout = r.duration("HTTP_WRITE_TIMEOUT", 60*time.Second)
+	c.HTTPIdleTimeout = r.duration("HTTP_IDLE_TIMEOUT", 120*time.Second)
+	c.HandlerTimeout = r.duration("HANDLER_TIMEOUT", 10*time.Second)
+	c.RouteTimeouts = r.string("ROUTE_TIMEOUTS", "")
+	c.ShutdownTimeout = r.duration("SHUTDOWN_TIMEOUT", 20*time.Second)
+	c.ShutdownDrainTimeout = r.duration("SHUTDOWN_DRAIN_TIMEOUT", 10*time.Second)
+	c.TLSCertFile = r.string("TLS_CERT_FILE", "")
+	c.TLSKeyFile = r.string("TLS_KEY_FILE", "")
+	c.HTTP2Cleartext = r.bool("HTTP2_CLEARTEXT", false)
+	c.TLSAutocertDomains = r.list("TLS_AUTOCERT_DOMAINS", nil)
+	c.TLSAutocertCacheDir = r.string("TLS_AUTOCERT_CACHE_DIR", "autocert")
+	c.TLSAutocertEmail = r.string("TLS_AUTOCERT_EMAIL", "")
+	c.HTTPRedirectAddr = r.string("HTTP_REDIRECT_ADDR", "")
+
+	c.AdminEnabled = r.bool("ADMIN_ENABLED", false)
+	c.AdminAddr = r.string("ADMIN_ADDR", "127.0.0.1:6060")
+
+	c.GRPCEnabled = r.bool("GRPC_ENABLED", false)
+	c.GRPCAddr = r.string("GRPC_ADDR", ":9090")
+
+	c.settings = r.settings
+	errs := append(r.errs, c.validate()...)
+	errs = append(errs, unknownKeys(fileValues, r.settings)...)
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return c, nil
+}
+
+// 値どうしの組み合わせと、必須の値を検証する
+func (c *Config) validate() []error {
+	var errs []error
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+	oneOf := func(key, value string, allowed ...string) bool {
+		for _, a := range allowed {
+			if value == a {
+				return true
+			}
+		}
+		fail(key, "must be one of %s: %q", strings.Join(allowed, ", "), value)
+		return false
+	}
+	required := func(key, value, reason string) {
+		if value == "" {
+			fail(key, "is required %s", reason)
+		}
+	}
+	port := func(key, value string) {
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+			fail(key, "must be a port number between 1 and 65535: %q", value)
+		}
+	}
+	address := func(key, value string) {
+		if _, p, err := net.SplitHostPort(value); err != nil {
+			fail(key, "must be host:port (e.g. :8080): %q", value)
+		} else {
+			port(key, p)
+		}
+	}
+
+	oneOf("LOG_LEVEL", c.LogLevel, "info", "warn", "error")
+	if c.RateLimitRPS > 0 && c.RateLimitBurst < 1 {
+		fail("RATE_LIMIT_BURST", "must be at least 1 when RATE_LIMIT_RPS is set: %d", c.RateLimitBurst)
+	}
+	if c.SearchMinScore > 1 {
+		fail("SEARCH_MIN_SCORE", "must be between 0 and 1: %g", c.SearchMinScore)
+	}
+	if c.SLOLatencyThreshold <= 0 {
+		fail("SLO_LATENCY_THRESHOLD", "must be greater than 0: %s", c.SLOLatencyThreshold)
+	}
+	if c.SLOObjective <= 0 || c.SLOObjective >= 1 {
+		fail("SLO_OBJECTIVE", "must be greater than 0 and less than 1: %g", c.SLOObjective)
+	}
+	if c.JSONMaxBodySize < 1 {
+		fail("JSON_MAX_BODY_SIZE", "must be at least 1: %d", c.JSONMaxBodySize)
+	}
+	if c.JSONMaxDepth < 1 {
+		fail("JSON_MAX_DEPTH", "must be at least 1: %d", c.JSONMaxDepth)
+	}
+	if c.MediaURLTTL <= 0 {
+		fail("MEDIA_URL_TTL", "must be greater than 0: %s", c.MediaURLTTL)
+	}
+	if c.DBCircuitBreakerFailures > 0 {
+		if c.DBCircuitBreakerOpenTimeout <= 0 {
+			fail("DB_CIRCUIT_BREAKER_OPEN_TIMEOUT", "must be greater than 0 when DB_CIRCUIT_BREAKER_FAILURES is set: %s", c.DBCircuitBreakerOpenTimeout)
+		}
+		if c.DBCircuitBreakerHalfOpenRequests < 1 {
+			fail("DB_CIRCUIT_BREAKER_HALF_OPEN_REQUESTS", "must be at least 1 when DB_CIRCUIT_BREAKER_FAILURES is set: %d", c.DBCircuitBreakerHalfOpenRequests)
+		}
+	}
+
+	// データベース（DSN の組み立てに必要な値）
+	if oneOf("DB_DRIVER", c.DBDriver, "mysql", "sqlite") {
+		if c.DBDriver == "mysql" {
+			required("DB_USER", c.DBUser, "for mysql")
+			required("DB_HOST", c.DBHost, "for mysql")
+			required("DB_NAME", c.DBName, "for mysql")
+			if c.DBPort == "" {
+				required("DB_PORT", c.DBPort, "for mysql")
+			} else {
+				port("DB_PORT", c.DBPort)
+			}
+			if c.DBReadHost != "" {
+				port("DB_READ_PORT", c.DBReadPort)
+			}
+		} else {
+			required("SQLITE_PATH", c.SQLitePath, "for sqlite")
+		}
+	}
+	if oneOf("ITEM_STORE", c.ItemStore, "sql", "mongodb") && c.ItemStore == "mongodb" {
+		required("MONGODB_URI", c.MongoURI, "for mongodb")
+		required("MONGODB_DATABASE", c.MongoDatabase, "for mongodb")
+	}
+
+	// 待ち受けアドレス
+	address("PORT", c.HTTPAddr)
+	if c.AdminEnabled {
+		address("ADMIN_ADDR", c.AdminAddr)
+	}
+	if c.GRPCEnabled {
+		address("GRPC_ADDR", c.GRPCAddr)
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		fail("TLS_CERT_FILE", "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if len(c.TLSAutocertDomains) > 0 && c.TLSCertFile != "" {
+		fail("TLS_AUTOCERT_DOMAINS", "cannot be used with TLS_CERT_FILE")
+	}
+	if c.HTTPRedirectAddr != "" {
+		address("HTTP_REDIRECT_ADDR", c.HTTPRedirectAddr)
+		if c.TLSCertFile == "" && len(c.TLSAutocertDomains) == 0 {
+			fail("HTTP_REDIRECT_ADDR", "requires TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS")
+		}
+	}
+
+	// CORS のオリジンは "https://app.example.com" の形（サブドメインは "https://*.example.com"）
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == "*" {
+			if c.CORSAllowCredentials {
+				fail("CORS_ALLOWED_ORIGINS", "cannot be * when CORS_ALLOW_CREDENTIALS is true (list the origins instead)")
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" {
+			fail("CORS_ALLOWED_ORIGINS", "must be origins such as https://app.example.com: %q", origin)
+		}
+	}
+
+	// 外部サービス
+	if c.SMTPHost != "" {
+		port("SMTP_PORT", strconv.Itoa(c.SMTPPort))
+		if c.SMTPUsername != "" {
+			required("SMTP_PASSWORD", c.SMTPPassword, "when SMTP_USERNAME is set")
+		}
+	}
+	if oneOf("BROKER_DRIVER", c.BrokerDriver, "", "kafka", "nats") && c.BrokerDriver != "" {
+		if len(c.BrokerURLs) == 0 {
+			fail("BROKER_URLS", "is required when BROKER_DRIVER is set")
+		}
+		oneOf("BROKER_FORMAT", c.BrokerFormat, "json", "avro")
+	}
+	if c.SearchIndexURL != "" {
+		if u, err := url.Parse(c.SearchIndexURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("SEARCH_INDEX_URL", "must be an http(s) URL such as http://localhost:9200")
+		}
+	}
+	if oneOf("MEDIA_STORAGE", c.MediaStorage, "local", "s3", "gcs") && c.MediaStorage != "local" {
+		required("MEDIA_BUCKET", c.MediaBucket, "for "+c.MediaStorage)
+	}
+	if oneOf("EXCHANGE_RATE_PROVIDER", c.ExchangeRateProvider, "ecb", "openexchangerates", "none") && c.ExchangeRateProvider == "openexchangerates" {
+		required("EXCHANGE_RATE_API_KEY", c.ExchangeRateAPIKey, "for openexchangerates")
+	}
+	if oneOf("OCR_PROVIDER", c.OCRProvider, "none", "http", "google-vision") {
+		switch c.OCRProvider {
+		case "http":
+			required("OCR_URL", c.OCRURL, "for http")
+		case "google-vision":
+			required("OCR_API_KEY", c.OCRAPIKey, "for google-vision")
+		}
+	}
+	if c.WebhookMaxAttempts < 1 {
+		fail("WEBHOOK_MAX_ATTEMPTS", "must be at least 1: %d", c.WebhookMaxAttempts)
+	}
+	validDate := func(key, value string, allowToday bool) bool {
+		if value == "" || (allowToday && value == "today") {
+			return true
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			if allowToday {
+				fail(key, "must be YYYY-MM-DD or today: %q", value)
+			} else {
+				fail(key, "must be YYYY-MM-DD: %q", value)
+			}
+			return false
+		}
+		return true
+	}
+	minDate, maxDate := c.ValidationMinPurchaseDate, c.ValidationMaxPurchaseDate
+	minValid := validDate("VALIDATION_MIN_PURCHASE_DATE", minDate, false)
+	maxValid := validDate("VALIDATION_MAX_PURCHASE_DATE", maxDate, true)
+	// today との比較は日付によって変わるので、両方が日付のときだけ確かめる
+	if minValid && maxValid && minDate != "" && maxDate != "" && maxDate != "today" && minDate > maxDate {
+		fail("VALIDATION_MIN_PURCHASE_DATE", "must not be after VALIDATION_MAX_PURCHASE_DATE: %s > %s", minDate, maxDate)
+	}
+
+	if c.PortfolioSnapshotTime != "off" {
+		if _, err := time.Parse("15:04", c.PortfolioSnapshotTime); err != nil {
+			fail("PORTFOLIO_SNAPSHOT_TIME", "must be HH:MM or off: %q", c.PortfolioSnapshotTime)
+		}
+	}
+
+	// 本番では、再起動で発行済みのリンク・トークンが無効にならないようシークレットを必須にする
+	if c.AppEnv == "production" {
+		required("SHARE_LINK_SECRET", c.ShareLinkSecret, "in production")
+		required("UNDO_TOKEN_SECRET", c.UndoTokenSecret, "in production")
+		required("MEDIA_URL_SECRET", c.MediaURLSecret, "in production")
+	}
+	return errs
+}
+
+// 有効な設定を "キー=値" の一覧で返す（読み込んだ順）。シークレットと URL のパスワードは伏せる
+func (c *Config) Effective() []string {
+	lines := make([]string, len(c.settings))
+	for i, s := range c.settings {
+		value := s.value
+		if s.secret && value != "" {
+			value = "[REDACTED]"
+		}
+		lines[i] = s.key + "=" + value
+	}
+	return lines
 }
 
 // DB接続文字列を返す
-func GetDSN() string {
+func (c *Config) DSN() string {
+	return c.buildDSN(c.DBHost, c.DBPort)
+}
+
+// リードレプリカの接続文字列を返す
+func (c *Config) ReadDSN() string {
+	return c.buildDSN(c.DBReadHost, c.DBReadPort)
+}
+
+func (c *Config) buildDSN(host, port string) string {
 	return fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL&timeout=%s",
+		c.DBUser, c.DBPassword, host, port, c.DBName, c.DBConnectTimeout,
 	)
 }
+
+// 環境変数を読み込み、読み込んだ設定と不正な値のエラーを記録する
+type reader struct {
+	getenv   func(string) string
+	settings []setting
+	errs     []error
+}
+
+func (r *reader) record(key, value string, secret bool) {
+	r.settings = append(r.settings, setting{key: key, value: value, secret: secret})
+}
+
+func (r *reader) invalid(key, value, want string) {
+	r.errs = append(r.errs, fmt.Errorf("%s: must be %s: %q", key, want, value))
+}
+
+// 環境変数を取得し、未設定の場合はデフォルト値を返す
+func (r *reader) string(key, defaultValue string) string {
+	v := r.getenv(key)
+	if v == "" {
+		v = defaultValue
+	}
+	r.record(key, v, false)
+	return v
+}
+
+// シークレット（ログには出さない）
+func (r *reader) secret(key string) string {
+	v := r.getenv(key)
+	r.record(key, v, true)
+	return v
+}
+
+// 認証情報を含みうる URL（ログにはパスワードを伏せて出す）
+func (r *reader) url(key, defaultValue string) string {
+	v := r.getenv(key)
+	if v == "" {
+		v = defaultValue
+	}
+	u, err := url.Parse(v)
+	if err != nil {
+		r.invalid(key, "[REDACTED]", "a URL")
+		r.record(key, v, true)
+		return v
+	}
+	r.record(key, u.Redacted(), false)
+	return v
+}
+
+// カンマ区切りの環境変数を取得する（空要素は除く）。未設定の場合はデフォルト値を返す
+func (r *reader) list(key string, defaultValue []string) []string {
+	values := splitList(r.getenv(key))
+	if len(values) == 0 {
+		values = defaultValue
+	}
+	r.record(key, strings.Join(values, ","), false)
+	return values
+}
+
+// カンマ区切りのシークレット（ログには出さない）
+func (r *reader) secretList(key string) []string {
+	values := splitList(r.getenv(key))
+	r.record(key, strings.Join(values, ","), true)
+	return values
+}
+
+// 真偽値（true / false など）として取得し、未設定の場合はデフォルト値を返す
+func (r *reader) bool(key string, defaultValue bool) bool {
+	v := r.getenv(key)
+	b := defaultValue
+	if v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			r.invalid(key, v, "true or false")
+		} else {
+			b = parsed
+		}
+	}
+	r.record(key, strconv.FormatBool(b), false)
+	return b
+}
+
+// 0以上の整数として取得し、未設定の場合はデフォルト値を返す
+func (r *reader) int(key string, defaultValue int) int {
+	v := r.getenv(key)
+	n := defaultValue
+	if v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			r.invalid(key, v, "a non-negative integer")
+		} else {
+			n = parsed
+		}
+	}
+	r.record(key, strconv.Itoa(n), false)
+	return n
+}
+
+// 0以上の数値として取得し、未設定の場合はデフォルト値を返す
+func (r *reader) float(key string, defaultValue float64) float64 {
+	v := r.getenv(key)
+	n := defaultValue
+	if v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 || math.IsInf(parsed, 0) {
+			r.invalid(key, v, "a non-negative number")
+		} else {
+			n = parsed
+		}
+	}
+	r.record(key, strconv.FormatFloat(n, 'f', -1, 64), false)
+	return n
+}
+
+// カンマ区切りの0以上の整数の一覧として取得し、未設定の場合はデフォルト値を返す
+func (r *reader) intList(key string, defaultValue []int) []int {
+	values := splitList(r.getenv(key))
+	ints := defaultValue
+	if len(values) > 0 {
+		ints = make([]int, 0, len(values))
+		for _, v := range values {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				r.invalid(key, r.getenv(key), "a comma-separated list of non-negative integers")
+				ints = defaultValue
+				break
+			}
+			ints = append(ints, n)
+		}
+	}
+	strs := make([]string, len(ints))
+	for i, n := range ints {
+		strs[i] = strconv.Itoa(n)
+	}
+	r.record(key, strings.Join(strs, ","), false)
+	return ints
+}
+
+// 時間（30s, 5m など）として取得し、未設定の場合はデフォルト値を返す
+func (r *reader) duration(key string, defaultValue time.Duration) time.Duration {
+	v := r.getenv(key)
+	d := defaultValue
+	if v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed < 0 {
+			r.invalid(key, v, "a non-negative duration such as 30s or 5m")
+		} else {
+			d = parsed
+		}
+	}
+	r.record(key, d.String(), false)
+	return d
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML の設定ファイルを読み込む。キーは環境変数と同じ名前で、値は文字列・数値・真偽値かそれらのリスト
+//
+//	LOG_LEVEL: warn
+//	RATE_LIMIT_RPS: 20
+//	REMINDER_DAYS: [30, 7]
+//
+// 値は環境変数と同じ文字列として扱う（リストはカンマ区切りにする）
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseFile(data)
+}
+
+func parseFile(data []byte) (map[string]string, error) {
+	var doc map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("must be a YAML mapping of settings: %w", err)
+	}
+	values := make(map[string]string, len(doc))
+	for key, node := range doc {
+		v, err := nodeValue(&node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		values[key] = v
+	}
+	return values, nil
+}
+
+// 値の元の表記（"0.10" や "30s" などを YAML の型に変換せずに）を環境変数と同じ形で返す
+func nodeValue(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return "", nil
+		}
+		return node.Value, nil
+	case yaml.SequenceNode:
+		values := make([]string, len(node.Content))
+		for i, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("list items must be plain values (line %d)", item.Line)
+			}
+			values[i] = item.Value
+		}
+		return strings.Join(values, ","), nil
+	}
+	return "", fmt.Errorf("must be a plain value or a list (line %d)", node.Line)
+}
+
+// 設定ファイルにある、設定として読み込まなかったキー（書き間違いなど）のエラー
+func unknownKeys(fileValues map[string]string, settings []setting) []error {
+	known := make(map[string]bool, len(settings))
+	for _, s := range settings {
+		known[s.key] = true
+	}
+	var keys []string
+	for key := range fileValues {
+		if !known[key] || key == "CONFIG_FILE" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		if key == "CONFIG_FILE" {
+			errs[i] = fmt.Errorf("%s: can only be set as an environment variable", key)
+		} else {
+			errs[i] = fmt.Errorf("%s: unknown setting in CONFIG_FILE", key)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 再起動せずに変更を反映できる設定（Watcher が変更を通知する）
+var reloadableKeys = map[string]bool{
+	"LOG_LEVEL":                true,
+	"RATE_LIMIT_RPS":           true,
+	"RATE_LIMIT_BURST":         true,
+	"MAX_CONCURRENT_REQUESTS":  true,
+	"ROUTE_CONCURRENCY_LIMITS": true,
+	"FAST_JSON":                true,
+	"SANITIZE_HTML":            true,
+}
+
+// 再起動せずに変更を反映できる設定か
+func Reloadable(key string) bool {
+	return reloadableKeys[key]
+}
+
+// Watcher は設定ファイル（CONFIG_FILE）を一定の間隔で確認し、内容が変わったら設定を読み込み直す。
+// 再起動せずに反映できる設定（Reloadable）が変わった場合は、読み込み直した設定で apply を呼ぶ。
+// それ以外の設定の変更は反映せずにログに出す。不正な内容の場合は今の設定を使い続ける
+type Watcher struct {
+	current  *Config
+	content  []byte
+	apply    func(*Config)
+	interval time.Duration
+	logf     func(format string, args ...interface{})
+	lastErr  string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// cfg: 起動時に読み込んだ設定（CONFIG_FILE と CONFIG_RELOAD_INTERVAL を使う）
+func NewWatcher(cfg *Config, apply func(*Config)) *Watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watcher{
+		current:  cfg,
+		apply:    apply,
+		interval: cfg.ConfigReloadInterval,
+		logf:     log.Printf,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	// 起動時に読み込んだ内容と比べる（読めない場合は最初の確認で読み込み直す）
+	w.content, _ = os.ReadFile(cfg.ConfigFile)
+	return w
+}
+
+// 監視を開始する。Close まで戻らないので goroutine で呼び出す
+func (w *Watcher) Run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Check()
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// 監視を停止し、Run が終わるまで待つ
+func (w *Watcher) Close() {
+	w.once.Do(w.cancel)
+	<-w.done
+}
+
+// 設定ファイルが変わっていれば読み込み直す（Run が間隔ごとに呼ぶ）
+func (w *Watcher) Check() {
+	content, err := os.ReadFile(w.current.ConfigFile)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if bytes.Equal(content, w.content) {
+		return
+	}
+
+	next, err := LoadFrom(w.current.getenv)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	w.content = content
+	w.lastErr = ""
+
+	reloaded, restart := diff(w.current, next)
+	if len(restart) > 0 {
+		w.logf("⚠️  config: restart the server to apply the changes to %s", strings.Join(restart, ", "))
+	}
+	if len(reloaded) > 0 {
+		w.apply(next)
+		w.logf("config: reloaded %s", strings.Join(reloaded, ", "))
+	}
+	w.current = next
+}
+
+// 読み込めなかった場合は今の設定を使い続け、次の Check で再び読み込む。同じエラーは繰り返しログに出さない
+func (w *Watcher) fail(err error) {
+	w.content = nil
+	if err.Error() != w.lastErr {
+		w.lastErr = err.Error()
+		w.logf("⚠️  config: failed to reload %s, keeping the current settings: %v", w.current.ConfigFile, err)
+	}
+}
+
+// 変わった設定を、反映できるもの（"キー=値"）と再起動が必要なもの（キーだけ、シークレットの値は出さない）に分ける
+func diff(current, next *Config) (reloaded, restart []string) {
+	before := make(map[string]string, len(current.settings))
+	for _, s := range current.settings {
+		before[s.key] = s.value
+	}
+	for _, s := range next.settings {
+		if before[s.key] == s.value {
+			continue
+		}
+		if Reloadable(s.key) {
+			reloaded = append(reloaded, s.key+"="+s.value)
+		} else {
+			restart = append(restart, s.key)
+		}
+	}
+	return reloaded, restart
+}
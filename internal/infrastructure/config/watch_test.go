@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write("DB_DRIVER: sqlite\nLOG_LEVEL: info\n")
+	cfg, err := LoadFrom(env(map[string]string{"CONFIG_FILE": path, "SMTP_PASSWORD": "s3cret"}))
+	require.NoError(t, err)
+
+	var applied []*Config
+	var logs []string
+	w := NewWatcher(cfg, func(next *Config) { applied = append(applied, next) })
+	w.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+
+	t.Run("正常系: 変更がなければ何もしない", func(t *testing.T) {
+		w.Check()
+
+		assert.Empty(t, applied)
+		assert.Empty(t, logs)
+	})
+
+	t.Run("正常系: 反映できる設定の変更を通知する", func(t *testing.T) {
+		write("DB_DRIVER: sqlite\nLOG_LEVEL: warn\nRATE_LIMIT_RPS: 5\n")
+
+		w.Check()
+
+		require.Len(t, applied, 1)
+		assert.Equal(t, "warn", applied[0].LogLevel)
+		assert.Equal(t, 5, applied[0].RateLimitRPS)
+		assert.Equal(t, []string{"config: reloaded LOG_LEVEL=warn, RATE_LIMIT_RPS=5"}, logs)
+	})
+
+	t.Run("異常系: 不正な内容は反映せず、同じエラーは1回だけ出す", func(t *testing.T) {
+		applied, logs = nil, nil
+		write("DB_DRIVER: sqlite\nLOG_LEVEL: loud\n")
+
+		w.Check()
+		w.Check()
+
+		assert.Empty(t, applied)
+		require.Len(t, logs, 1)
+		assert.Contains(t, logs[0], "keeping the current settings")
+		assert.Contains(t, logs[0], `LOG_LEVEL: must be one of info, warn, error: "loud"`)
+	})
+
+	t.Run("正常系: 再起動が必要な設定の変更は反映しない", func(t *testing.T) {
+		applied, logs = nil, nil
+		write("DB_DRIVER: sqlite\nLOG_LEVEL: warn\nRATE_LIMIT_RPS: 5\nSQLITE_PATH: other.db\nSMTP_PASSWORD: other\n")
+
+		w.Check()
+
+		assert.Empty(t, applied)
+		// 環境変数の SMTP_PASSWORD が優先されるため変更にならない
+		assert.Equal(t, []string{"⚠️  config: restart the server to apply the changes to SQLITE_PATH"}, logs)
+	})
+
+	t.Run("異常系: ファイルを読めない", func(t *testing.T) {
+		applied, logs = nil, nil
+		require.NoError(t, os.Remove(path))
+
+		w.Check()
+
+		assert.Empty(t, applied)
+		require.Len(t, logs, 1)
+		assert.Contains(t, logs[0], "failed to reload")
+	})
+}
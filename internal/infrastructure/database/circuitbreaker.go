@@ -0,0 +1,186 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"Aicon-assignment/internal/infrastructure/circuitbreaker"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// DBのサーキットブレーカーの状態と件数（/debug/vars で参照できる）
+// state は現在の状態、opened / half_opened / closed は状態が変わった回数、failures は障害に数えたクエリ、
+// rejected は開いている間に実行しなかったクエリの件数
+var (
+	breakerMetrics = expvar.NewMap("db_circuit_breaker")
+	breakerState   = new(expvar.String)
+)
+
+func init() {
+	breakerState.Set(circuitbreaker.StateClosed.String())
+	breakerMetrics.Set("state", breakerState)
+}
+
+// DBのサーキットブレーカーの設定
+type BreakerConfig struct {
+	// 開くまでに続いた障害の回数（0でブレーカーを使わない）
+	FailureThreshold int
+	// 開いてから試しのクエリを通すまでの時間
+	OpenTimeout time.Duration
+	// 半開で通す試しのクエリの件数
+	HalfOpenRequests int
+}
+
+// 状態の変化をログと /debug/vars に記録するDB用のブレーカーを作る。FailureThreshold が0なら nil を返す
+func NewBreaker(cfg BreakerConfig) *circuitbreaker.Breaker {
+	if cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	return circuitbreaker.New(circuitbreaker.Settings{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      cfg.OpenTimeout,
+		HalfOpenRequests: cfg.HalfOpenRequests,
+		OnStateChange: func(from, to circuitbreaker.State) {
+			breakerState.Set(to.String())
+			breakerMetrics.Add(metricName(to), 1)
+			log.Printf("🔌 database circuit breaker: %s -> %s", from, to)
+		},
+	})
+}
+
+func metricName(state circuitbreaker.State) string {
+	switch state {
+	case circuitbreaker.StateOpen:
+		return "opened"
+	case circuitbreaker.StateHalfOpen:
+		return "half_opened"
+	}
+	return "closed"
+}
+
+// データベースの障害が続いたらクエリを実行せずにすぐ失敗させるラッパー
+// 開いている間のクエリは circuitbreaker.ErrOpen を返すため、接続待ちのゴルーチンが溜まらない
+type breakerHandler struct {
+	database.SqlHandler
+	breaker *circuitbreaker.Breaker
+}
+
+// breaker が nil の場合はそのまま返す
+func WithCircuitBreaker(h database.SqlHandler, breaker *circuitbreaker.Breaker) database.SqlHandler {
+	if breaker == nil {
+		return h
+	}
+	return &breakerHandler{SqlHandler: h, breaker: breaker}
+}
+
+func (h *breakerHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	done, err := h.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.SqlHandler.Execute(ctx, statement, args...)
+	done(err)
+	return result, err
+}
+
+func (h *breakerHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	done, err := h.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	done(err)
+	return rows, err
+}
+
+// QueryRow はScanするまで結果がわからないため、Scanの結果を報告する
+func (h *breakerHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	done, err := h.allow(ctx)
+	if err != nil {
+		return errorRow{err: err}
+	}
+	return &reportingRow{Row: h.SqlHandler.QueryRow(ctx, statement, args...), done: done}
+}
+
+// トランザクション全体を1回の呼び出しとして数える（中のクエリはブレーカーを通さない）
+func (h *breakerHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t, ok := h.SqlHandler.(database.Transactor)
+	if !ok {
+		return fn(ctx)
+	}
+	done, err := h.allow(ctx)
+	if err != nil {
+		return err
+	}
+	err = t.Transaction(ctx, fn)
+	done(err)
+	return err
+}
+
+// ラップしたハンドラーのSQL方言を引き継ぐ
+func (h *breakerHandler) Dialect() string {
+	return database.DialectOf(h.SqlHandler)
+}
+
+// クエリを実行してよいかをブレーカーに問い合わせ、結果を報告する関数を返す
+// トランザクションの中と、期限切れ・キャンセル済みのリクエストのクエリはブレーカーを通さない
+func (h *breakerHandler) allow(ctx context.Context) (func(err error), error) {
+	if _, inTx := database.TxFromContext(ctx); inTx || ctx.Err() != nil {
+		return func(error) {}, nil
+	}
+	done, err := h.breaker.Allow()
+	if err != nil {
+		breakerMetrics.Add("rejected", 1)
+		return nil, fmt.Errorf("%w: database queries are suspended after repeated failures", err)
+	}
+	return func(err error) {
+		if isUnavailable(err) {
+			breakerMetrics.Add("failures", 1)
+			done(false)
+			return
+		}
+		done(true)
+	}, nil
+}
+
+// 接続できない・応答がないなど、データベースそのものの障害を示すエラーかどうか
+// 行がない・制約違反などのデータベースが応答したエラーと、クライアントが切断したキャンセルは障害に数えない
+func isUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
+
+type reportingRow struct {
+	database.Row
+	done func(err error)
+}
+
+func (r *reportingRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	r.done(err)
+	return err
+}
+
+type errorRow struct {
+	err error
+}
+
+func (r errorRow) Scan(dest ...interface{}) error {
+	return r.err
+}
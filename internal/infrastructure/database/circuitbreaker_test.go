@@ -0,0 +1,103 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/circuitbreaker"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// トランザクションも使えるハンドラー
+type transactionalHandler struct {
+	recordingHandler
+}
+
+func (h *transactionalHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if h.err != nil {
+		return h.err
+	}
+	return fn(database.ContextWithTx(ctx, struct{}{}))
+}
+
+func TestBreakerHandler(t *testing.T) {
+	connRefused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	newHandler := func(err error) (database.SqlHandler, *transactionalHandler, *circuitbreaker.Breaker) {
+		inner := &transactionalHandler{recordingHandler{err: err}}
+		breaker := NewBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute, HalfOpenRequests: 1})
+		return WithCircuitBreaker(inner, breaker), inner, breaker
+	}
+
+	t.Run("正常系: 接続できないエラーが続くと開いてクエリを実行しない", func(t *testing.T) {
+		h, inner, breaker := newHandler(connRefused)
+
+		_, err := h.Execute(context.Background(), "UPDATE items SET name = ?", "x")
+		assert.ErrorIs(t, err, connRefused)
+		err = h.QueryRow(context.Background(), "SELECT 1").Scan()
+		assert.ErrorIs(t, err, connRefused)
+		require.Equal(t, circuitbreaker.StateOpen, breaker.State())
+
+		_, err = h.Query(context.Background(), "SELECT * FROM items")
+		assert.ErrorIs(t, err, circuitbreaker.ErrOpen)
+		assert.ErrorIs(t, h.QueryRow(context.Background(), "SELECT 1").Scan(), circuitbreaker.ErrOpen)
+		assert.ErrorIs(t, h.(database.Transactor).Transaction(context.Background(), func(ctx context.Context) error { return nil }), circuitbreaker.ErrOpen)
+		assert.Equal(t, 1, inner.executes)
+		assert.Equal(t, 1, inner.queries)
+	})
+
+	t.Run("正常系: データベースが応答したエラーは障害に数えない", func(t *testing.T) {
+		for _, err := range []error{sql.ErrNoRows, errors.New("Error 1062: Duplicate entry"), context.Canceled} {
+			h, _, breaker := newHandler(err)
+
+			for range 3 {
+				_ = h.QueryRow(context.Background(), "SELECT 1").Scan()
+			}
+
+			assert.Equal(t, circuitbreaker.StateClosed, breaker.State(), err.Error())
+		}
+	})
+
+	t.Run("正常系: 切れた接続と期限切れは障害に数える", func(t *testing.T) {
+		for _, err := range []error{driver.ErrBadConn, context.DeadlineExceeded} {
+			h, _, breaker := newHandler(err)
+
+			for range 2 {
+				_, _ = h.Execute(context.Background(), "DELETE FROM items")
+			}
+
+			assert.Equal(t, circuitbreaker.StateOpen, breaker.State(), err.Error())
+		}
+	})
+
+	t.Run("正常系: トランザクションは全体で1回と数え、中のクエリは止めない", func(t *testing.T) {
+		h, inner, breaker := newHandler(nil)
+
+		err := h.(database.Transactor).Transaction(context.Background(), func(ctx context.Context) error {
+			inner.err = connRefused
+			for range 3 {
+				_, _ = h.Execute(ctx, "INSERT INTO items (name) VALUES (?)", "x")
+			}
+			inner.err = nil
+			return errors.New("item has been modified")
+		})
+
+		assert.EqualError(t, err, "item has been modified")
+		assert.Equal(t, 3, inner.executes)
+		assert.Equal(t, circuitbreaker.StateClosed, breaker.State())
+	})
+
+	t.Run("正常系: 回数が0ならブレーカーを使わない", func(t *testing.T) {
+		inner := &recordingHandler{}
+
+		assert.Nil(t, NewBreaker(BreakerConfig{}))
+		assert.Same(t, inner, WithCircuitBreaker(inner, nil))
+	})
+}
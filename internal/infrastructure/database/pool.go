@@ -0,0 +1,42 @@
+package databaseInfra
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/infrastructure/config"
+)
+
+// コネクションプールの設定
+type PoolConfig struct {
+	MaxOpenConns    int           // 同時に開く接続の上限（0は無制限）
+	MaxIdleConns    int           // 保持するアイドル接続の上限
+	ConnMaxLifetime time.Duration // 接続を使い回す最長時間（0は無制限）
+	ConnMaxIdleTime time.Duration // アイドル接続を閉じるまでの時間（0は無制限）
+	ConnectTimeout  time.Duration // 接続確立（Ping）のタイムアウト
+}
+
+// 設定（DB_MAX_OPEN_CONNS など）から組み立てる
+func NewPoolConfig(cfg *config.Config) PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
+		ConnectTimeout:  cfg.DBConnectTimeout,
+	}
+}
+
+// プール設定を接続に反映する
+func (p PoolConfig) Apply(conn *sql.DB) {
+	conn.SetMaxOpenConns(p.MaxOpenConns)
+	conn.SetMaxIdleConns(p.MaxIdleConns)
+	conn.SetConnMaxLifetime(p.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+func (p PoolConfig) String() string {
+	return fmt.Sprintf("max_open=%d max_idle=%d max_lifetime=%s max_idle_time=%s connect_timeout=%s",
+		p.MaxOpenConns, p.MaxIdleConns, p.ConnMaxLifetime, p.ConnMaxIdleTime, p.ConnectTimeout)
+}
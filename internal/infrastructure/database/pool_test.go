@@ -0,0 +1,29 @@
+package databaseInfra
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolConfig_Apply(t *testing.T) {
+	// sql.Open は接続しないため、DBがなくてもプール設定を確認できる
+	conn, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:1)/db")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	pool := PoolConfig{
+		MaxOpenConns:    7,
+		MaxIdleConns:    3,
+		ConnMaxLifetime: 5 * time.Minute,
+		ConnMaxIdleTime: time.Minute,
+		ConnectTimeout:  2 * time.Second,
+	}
+	pool.Apply(conn)
+
+	assert.Equal(t, 7, conn.Stats().MaxOpenConnections)
+	assert.Equal(t, "max_open=7 max_idle=3 max_lifetime=5m0s max_idle_time=1m0s connect_timeout=2s", pool.String())
+}
@@ -0,0 +1,105 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// レプリカで失敗してプライマリで再実行したクエリの件数（/debug/vars で参照できる）
+var replicaFallbackCount = expvar.NewInt("db_replica_fallbacks")
+
+// 読み取り専用の処理（usecase.ReadOnly）のクエリだけをリードレプリカに振り分けるハンドラー
+// 書き込み・トランザクション・それ以外の読み取りはプライマリで実行する
+type replicaHandler struct {
+	database.SqlHandler
+	replica database.SqlHandler
+	logf    func(format string, args ...interface{})
+}
+
+// レプリカに接続できない場合はプライマリだけで動かす
+func withReadReplica(primary database.SqlHandler, dsn string, pool PoolConfig) database.SqlHandler {
+	replica, err := openMySQL(dsn, pool)
+	if err != nil {
+		log.Printf("⚠️  read replica is unavailable, using the primary for all queries: %v", err)
+		return primary
+	}
+	fmt.Println("✅ Successfully connected to the read replica!")
+	return newReplicaHandler(primary, replica)
+}
+
+func newReplicaHandler(primary, replica database.SqlHandler) *replicaHandler {
+	return &replicaHandler{SqlHandler: primary, replica: replica, logf: log.Printf}
+}
+
+func (h *replicaHandler) useReplica(ctx context.Context) bool {
+	if _, inTx := database.TxFromContext(ctx); inTx {
+		return false
+	}
+	return usecase.IsReadOnly(ctx)
+}
+
+func (h *replicaHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	if h.useReplica(ctx) {
+		rows, err := h.replica.Query(ctx, statement, args...)
+		if err == nil {
+			return rows, nil
+		}
+		h.fallback(err)
+	}
+	return h.SqlHandler.Query(ctx, statement, args...)
+}
+
+func (h *replicaHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	if !h.useReplica(ctx) {
+		return h.SqlHandler.QueryRow(ctx, statement, args...)
+	}
+	return &fallbackRow{
+		row:   h.replica.QueryRow(ctx, statement, args...),
+		retry: func() database.Row { return h.SqlHandler.QueryRow(ctx, statement, args...) },
+		onErr: h.fallback,
+	}
+}
+
+func (h *replicaHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t, ok := h.SqlHandler.(database.Transactor); ok {
+		return t.Transaction(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+func (h *replicaHandler) Dialect() string {
+	return database.DialectOf(h.SqlHandler)
+}
+
+func (h *replicaHandler) Close() error {
+	return errors.Join(h.replica.Close(), h.SqlHandler.Close())
+}
+
+func (h *replicaHandler) fallback(err error) {
+	replicaFallbackCount.Add(1)
+	h.logf("⚠️  read replica query failed, retrying on the primary: %v", err)
+}
+
+// QueryRow はScanするまでエラーがわからないため、Scanの失敗時にプライマリで再実行する
+// 行がない（sql.ErrNoRows）のは正常な結果なので再実行しない
+type fallbackRow struct {
+	row   database.Row
+	retry func() database.Row
+	onErr func(err error)
+}
+
+func (r *fallbackRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	r.onErr(err)
+	return r.retry().Scan(dest...)
+}
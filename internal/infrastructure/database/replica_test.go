@@ -0,0 +1,100 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 呼ばれた回数を数え、指定したエラーを返すハンドラー
+type recordingHandler struct {
+	queries, executes int
+	err               error
+}
+
+func (h *recordingHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	h.executes++
+	return nil, h.err
+}
+
+func (h *recordingHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	h.queries++
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &delayedRows{}, nil
+}
+
+func (h *recordingHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	h.queries++
+	return errRow{h.err}
+}
+
+func (h *recordingHandler) Close() error { return nil }
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(dest ...interface{}) error { return r.err }
+
+func newTestReplicaHandler(replicaErr error) (*replicaHandler, *recordingHandler, *recordingHandler) {
+	primary, replica := &recordingHandler{}, &recordingHandler{err: replicaErr}
+	h := newReplicaHandler(primary, replica)
+	h.logf = func(string, ...interface{}) {}
+	return h, primary, replica
+}
+
+func TestReplicaHandler_Routing(t *testing.T) {
+	readOnly := usecase.ReadOnly(context.Background())
+
+	t.Run("正常系: 読み取り専用のクエリはレプリカ", func(t *testing.T) {
+		h, primary, replica := newTestReplicaHandler(nil)
+		_, err := h.Query(readOnly, "SELECT 1")
+		require.NoError(t, err)
+		require.NoError(t, h.QueryRow(readOnly, "SELECT 1").Scan())
+		assert.Equal(t, 2, replica.queries)
+		assert.Equal(t, 0, primary.queries)
+	})
+
+	t.Run("正常系: 書き込みと通常の読み取りはプライマリ", func(t *testing.T) {
+		h, primary, replica := newTestReplicaHandler(nil)
+		_, _ = h.Execute(readOnly, "UPDATE items SET name = ?", "a")
+		_, _ = h.Query(context.Background(), "SELECT 1")
+		assert.Equal(t, 1, primary.executes)
+		assert.Equal(t, 1, primary.queries)
+		assert.Equal(t, 0, replica.queries+replica.executes)
+	})
+
+	t.Run("正常系: トランザクション中はプライマリ", func(t *testing.T) {
+		h, primary, replica := newTestReplicaHandler(nil)
+		_, _ = h.Query(database.ContextWithTx(readOnly, struct{}{}), "SELECT 1")
+		assert.Equal(t, 1, primary.queries)
+		assert.Equal(t, 0, replica.queries)
+	})
+}
+
+func TestReplicaHandler_Fallback(t *testing.T) {
+	readOnly := usecase.ReadOnly(context.Background())
+
+	t.Run("異常系: レプリカのエラーはプライマリで再実行", func(t *testing.T) {
+		h, primary, replica := newTestReplicaHandler(errors.New("connection refused"))
+		_, err := h.Query(readOnly, "SELECT 1")
+		require.NoError(t, err)
+		require.NoError(t, h.QueryRow(readOnly, "SELECT 1").Scan())
+		assert.Equal(t, 2, replica.queries)
+		assert.Equal(t, 2, primary.queries)
+	})
+
+	t.Run("正常系: 行がないのは再実行しない", func(t *testing.T) {
+		h, primary, _ := newTestReplicaHandler(sql.ErrNoRows)
+		err := h.QueryRow(readOnly, "SELECT 1").Scan()
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		assert.Equal(t, 0, primary.queries)
+	})
+}
@@ -0,0 +1,127 @@
+package databaseInfra
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// スロークエリの件数（/debug/vars で参照できる）
+var (
+	slowQueryCount       = expvar.NewInt("db_slow_queries")
+	slowQueriesStatement = expvar.NewMap("db_slow_queries_by_statement")
+)
+
+// ログに出す引数1つあたりの最大文字数
+const maxLoggedArgLength = 64
+
+// 閾値を超えたクエリをSQLと引数付きでログに出すラッパー
+type slowQueryHandler struct {
+	database.SqlHandler
+	threshold time.Duration
+	logf      func(format string, args ...interface{})
+}
+
+// threshold が0以下の場合は計測せずにそのまま返す
+func WithSlowQueryLog(h database.SqlHandler, threshold time.Duration) database.SqlHandler {
+	if threshold <= 0 {
+		return h
+	}
+	return &slowQueryHandler{SqlHandler: h, threshold: threshold, logf: log.Printf}
+}
+
+func (h *slowQueryHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	start := time.Now()
+	result, err := h.SqlHandler.Execute(ctx, statement, args...)
+	h.observe(start, statement, args)
+	return result, err
+}
+
+// 結果の読み出しが終わる（Close される）までを計測する
+func (h *slowQueryHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	start := time.Now()
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	if err != nil {
+		h.observe(start, statement, args)
+		return nil, err
+	}
+	return &timedRows{Rows: rows, done: func() { h.observe(start, statement, args) }}, nil
+}
+
+func (h *slowQueryHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	start := time.Now()
+	row := h.SqlHandler.QueryRow(ctx, statement, args...)
+	return &timedRow{Row: row, done: func() { h.observe(start, statement, args) }}
+}
+
+// ラップしたハンドラーのSQL方言を引き継ぐ
+func (h *slowQueryHandler) Dialect() string {
+	return database.DialectOf(h.SqlHandler)
+}
+
+// ラップしたハンドラーのトランザクションを引き継ぐ
+func (h *slowQueryHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t, ok := h.SqlHandler.(database.Transactor); ok {
+		return t.Transaction(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+func (h *slowQueryHandler) observe(start time.Time, statement string, args []interface{}) {
+	elapsed := time.Since(start)
+	if elapsed < h.threshold {
+		return
+	}
+
+	sql := compactSQL(statement)
+	slowQueryCount.Add(1)
+	slowQueriesStatement.Add(sql, 1)
+	h.logf("🐢 slow query (%s): %s args=%s", elapsed.Round(time.Millisecond), sql, formatArgs(args))
+}
+
+type timedRows struct {
+	database.Rows
+	done   func()
+	closed bool
+}
+
+func (r *timedRows) Close() error {
+	if !r.closed {
+		r.closed = true
+		r.done()
+	}
+	return r.Rows.Close()
+}
+
+type timedRow struct {
+	database.Row
+	done func()
+}
+
+func (r *timedRow) Scan(dest ...interface{}) error {
+	err := r.Row.Scan(dest...)
+	r.done()
+	return err
+}
+
+// 改行やインデントを1つの空白にまとめる
+func compactSQL(statement string) string {
+	return strings.Join(strings.Fields(statement), " ")
+}
+
+func formatArgs(args []interface{}) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		s := fmt.Sprintf("%v", arg)
+		if r := []rune(s); len(r) > maxLoggedArgLength {
+			s = string(r[:maxLoggedArgLength]) + "…"
+		}
+		parts = append(parts, s)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
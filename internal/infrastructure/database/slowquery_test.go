@@ -0,0 +1,112 @@
+package databaseInfra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 指定した時間だけ待ってから応答するハンドラー
+type delayedHandler struct {
+	delay time.Duration
+}
+
+func (h *delayedHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	time.Sleep(h.delay)
+	return nil, nil
+}
+
+func (h *delayedHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return &delayedRows{delay: h.delay}, nil
+}
+
+func (h *delayedHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	time.Sleep(h.delay)
+	return &delayedRows{}
+}
+
+func (h *delayedHandler) Close() error { return nil }
+
+type delayedRows struct {
+	delay time.Duration
+}
+
+func (r *delayedRows) Next() bool                     { time.Sleep(r.delay); return false }
+func (r *delayedRows) Scan(dest ...interface{}) error { return nil }
+func (r *delayedRows) Close() error                   { return nil }
+func (r *delayedRows) Err() error                     { return nil }
+
+func TestSlowQueryHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		run      func(database.SqlHandler)
+		expected string
+	}{
+		{
+			name:  "正常系: 閾値を超えたExecuteを記録",
+			delay: 20 * time.Millisecond,
+			run: func(h database.SqlHandler) {
+				_, _ = h.Execute(context.Background(), "DELETE FROM items\n        WHERE id = ?", 1)
+			},
+			expected: "DELETE FROM items WHERE id = ? args=[1]",
+		},
+		{
+			name:  "正常系: 行の読み出しまで含めて計測",
+			delay: 20 * time.Millisecond,
+			run: func(h database.SqlHandler) {
+				rows, _ := h.Query(context.Background(), "SELECT * FROM items")
+				for rows.Next() {
+				}
+				rows.Close()
+			},
+			expected: "SELECT * FROM items args=[]",
+		},
+		{
+			name:  "正常系: 閾値未満は記録しない",
+			delay: 0,
+			run: func(h database.SqlHandler) {
+				_ = h.QueryRow(context.Background(), "SELECT 1").Scan()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []string
+			h := &slowQueryHandler{
+				SqlHandler: &delayedHandler{delay: tt.delay},
+				threshold:  10 * time.Millisecond,
+				logf:       func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) },
+			}
+			before := slowQueryCount.Value()
+
+			tt.run(h)
+
+			if tt.expected == "" {
+				assert.Empty(t, logs)
+				assert.Equal(t, before, slowQueryCount.Value())
+				return
+			}
+			assert.Len(t, logs, 1)
+			assert.True(t, strings.HasSuffix(logs[0], tt.expected), logs[0])
+			assert.Equal(t, before+1, slowQueryCount.Value())
+		})
+	}
+}
+
+func TestFormatArgs(t *testing.T) {
+	long := strings.Repeat("あ", 100)
+	assert.Equal(t, "[1, abc, "+strings.Repeat("あ", 64)+"…]", formatArgs([]interface{}{1, "abc", long}))
+}
+
+func TestWithSlowQueryLog_Disabled(t *testing.T) {
+	h := &delayedHandler{}
+	assert.Same(t, database.SqlHandler(h), WithSlowQueryLog(h, 0))
+}
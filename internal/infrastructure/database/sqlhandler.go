@@ -4,11 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
 
 	_ "github.com/go-sql-driver/mysql"
 
-	"Aicon-assignment/internal/infrastructure/config"
+	domainErrors "Aicon-assignment/internal/domain/errors"
 	"Aicon-assignment/internal/interfaces/database"
 )
 
@@ -16,36 +15,60 @@ type MySqlHandler struct {
 	Conn *sql.DB
 }
 
-func NewSqlHandler() database.SqlHandler {
-	dsn := config.GetDSN()
+func NewSqlHandler(dsn string, pool PoolConfig) database.SqlHandler {
+	h, err := openMySQL(dsn, pool)
+	if err != nil {
+		panic(fmt.Sprintf("❌ %v", err))
+	}
+
+	fmt.Println("✅ Successfully connected to the database!")
+	fmt.Printf("✅ Connection pool: %s\n", pool)
+
+	return h
+}
+
+// 接続してPingで確認したハンドラーを返す
+func openMySQL(dsn string, pool PoolConfig) (*MySqlHandler, error) {
 	conn, err := sql.Open("mysql", dsn)
 	if err != nil {
-		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	pool.Apply(conn)
+
 	// DB接続が確立できているかを確認
-	if err := conn.Ping(); err != nil {
-		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
+	ctx := context.Background()
+	if pool.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pool.ConnectTimeout)
+		defer cancel()
+	}
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	fmt.Println("✅ Successfully connected to the database!")
+	return &MySqlHandler{Conn: conn}, nil
+}
 
-	sqlBytes, err := os.ReadFile("sql/init.sql")
-	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
-	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
-			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
-		} else {
-			fmt.Println("✅ Successfully initialized database from init.sql")
+// トランザクション中（ctx に *sql.Tx がある場合）はそのトランザクションで実行する
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (h *MySqlHandler) conn(ctx context.Context) queryer {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		if sqlTx, ok := tx.(*sql.Tx); ok {
+			return sqlTx
 		}
 	}
-
-	return &MySqlHandler{Conn: conn}
+	return h.Conn
 }
 
 func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+	result, err := h.conn(ctx).ExecContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +76,7 @@ func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...in
 }
 
 func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+	rows, err := h.conn(ctx).QueryContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,10 +84,41 @@ func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...inte
 }
 
 func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
+	row := h.conn(ctx).QueryRowContext(ctx, statement, args...)
 	return &mysqlRow{row: row}
 }
 
+// fn をトランザクション内で実行し、エラーまたはパニックの場合はロールバックする
+// すでにトランザクション中の場合は外側のトランザクションに参加する
+func (h *MySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := database.TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(database.ContextWithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %s)", err, rbErr.Error())
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
 func (h *MySqlHandler) Close() error {
 	if h.Conn != nil {
 		return h.Conn.Close()
@@ -0,0 +1,59 @@
+package databaseInfra
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// SQLite のドライバー名（modernc.org/sqlite、cgo不要）
+const sqliteDriverName = "sqlite"
+
+// ローカル開発用のSQLiteハンドラー（外部のDBサーバーが不要）
+type SQLiteHandler struct {
+	MySqlHandler
+}
+
+func (h *SQLiteHandler) Dialect() string {
+	return database.DialectSQLite
+}
+
+// ドライバーは sqlite ビルドタグを付けた場合のみ組み込まれる（sqlite_driver.go）
+func NewSQLiteHandler(path string, pool PoolConfig) (database.SqlHandler, error) {
+	if !slices.Contains(sql.Drivers(), sqliteDriverName) {
+		return nil, fmt.Errorf("sqlite support is not compiled in: build with -tags sqlite")
+	}
+
+	// 書き込み中の読み取りでロック待ちにならないようWALにする
+	conn, err := sql.Open(sqliteDriverName, "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	// SQLiteは書き込みが1接続ずつなので、接続を1つにしてロック競合を避ける
+	pool.MaxOpenConns, pool.MaxIdleConns = 1, 1
+	pool.Apply(conn)
+
+	fmt.Printf("✅ Using SQLite database at %s\n", path)
+	fmt.Printf("✅ Connection pool: %s\n", pool)
+	return &SQLiteHandler{MySqlHandler{Conn: conn}}, nil
+}
+
+// 設定（DB_DRIVER）に応じたハンドラーを返す
+func NewSqlHandlerFromConfig(cfg *config.Config) (database.SqlHandler, error) {
+	pool := NewPoolConfig(cfg)
+	switch cfg.DBDriver {
+	case "", "mysql":
+		primary := NewSqlHandler(cfg.DSN(), pool)
+		if cfg.DBReadHost == "" {
+			return primary, nil
+		}
+		return withReadReplica(primary, cfg.ReadDSN(), pool), nil
+	case "sqlite":
+		return NewSQLiteHandler(cfg.SQLitePath, pool)
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER: %s", cfg.DBDriver)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build sqlite
+
+package databaseInfra
+
+// DB_DRIVER=sqlite 用のドライバー
+//
+//	go build -tags sqlite -o main ./cmd
+import _ "modernc.org/sqlite"
@@ -0,0 +1,37 @@
+//go:build sqlite
+
+package databaseInfra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/migration"
+)
+
+func TestNewSQLiteHandler(t *testing.T) {
+	ctx := context.Background()
+	handler, err := NewSQLiteHandler(t.TempDir()+"/items.db", PoolConfig{})
+	require.NoError(t, err)
+	defer handler.Close()
+
+	t.Run("正常系: すべてのマイグレーションを適用して戻せる", func(t *testing.T) {
+		migrator, err := migration.New(handler)
+		require.NoError(t, err)
+
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Positive(t, applied)
+
+		var count int
+		require.NoError(t, handler.QueryRow(ctx, "SELECT COUNT(*) FROM items").Scan(&count))
+		assert.Positive(t, count, "サンプルデータが入っている")
+
+		reverted, err := migrator.Down(ctx, applied)
+		require.NoError(t, err)
+		assert.Equal(t, applied, reverted)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build !sqlite
+
+package databaseInfra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSQLiteHandler_WithoutDriver(t *testing.T) {
+	// sqlite ビルドタグなしではドライバーが登録されていない
+	_, err := NewSQLiteHandler(t.TempDir()+"/items.db", PoolConfig{})
+	assert.ErrorContains(t, err, "-tags sqlite")
+}
@@ -0,0 +1,118 @@
+package databaseInfra
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 期限を過ぎて取り消したクエリの件数（/debug/vars で参照できる）
+var statementTimeoutCount = expvar.NewInt("db_statement_timeouts")
+
+// 1つのSQL文に期限を付けるラッパー
+// リクエストの期限（HANDLER_TIMEOUT）がないバッチや、期限の長いリクエストでも、1つの遅いクエリが接続を使い続けないようにする
+type statementTimeoutHandler struct {
+	database.SqlHandler
+	timeout time.Duration
+}
+
+// timeout が0以下の場合はそのまま返す
+func WithStatementTimeout(h database.SqlHandler, timeout time.Duration) database.SqlHandler {
+	if timeout <= 0 {
+		return h
+	}
+	return &statementTimeoutHandler{SqlHandler: h, timeout: timeout}
+}
+
+func (h *statementTimeoutHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, h.timeout, errStatementTimeout)
+	defer cancel()
+	result, err := h.SqlHandler.Execute(ctx, statement, args...)
+	return result, h.timedOut(ctx, err)
+}
+
+// 期限は最初の結果が返るまでに付ける。エクスポートのように結果を少しずつ読むクエリは、読み出しの途中で取り消さない
+// （読み出し中もリクエストのコンテキストが取り消されれば止まる）
+func (h *statementTimeoutHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	timer := time.AfterFunc(h.timeout, func() { cancel(errStatementTimeout) })
+	rows, err := h.SqlHandler.Query(ctx, statement, args...)
+	expired := !timer.Stop()
+	if err != nil || expired {
+		if rows != nil {
+			rows.Close()
+		}
+		cancel(nil)
+		if expired {
+			return nil, h.timeoutError()
+		}
+		return nil, err
+	}
+	return &cancelRows{Rows: rows, cancel: func() { cancel(nil) }}, nil
+}
+
+// QueryRow はScanするまで結果を読まないため、Scanまでを期限の対象にする
+func (h *statementTimeoutHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	ctx, cancel := context.WithTimeoutCause(ctx, h.timeout, errStatementTimeout)
+	row := h.SqlHandler.QueryRow(ctx, statement, args...)
+	return &cancelRow{Row: row, scan: func(err error) error {
+		err = h.timedOut(ctx, err)
+		cancel()
+		return err
+	}}
+}
+
+// ラップしたハンドラーのSQL方言を引き継ぐ
+func (h *statementTimeoutHandler) Dialect() string {
+	return database.DialectOf(h.SqlHandler)
+}
+
+// トランザクションの中の文にもそれぞれ期限を付ける（トランザクション全体には付けない）
+func (h *statementTimeoutHandler) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t, ok := h.SqlHandler.(database.Transactor); ok {
+		return t.Transaction(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+var errStatementTimeout = errors.New("statement timeout")
+
+// 文の期限を過ぎて失敗した場合は、期限を過ぎたことがわかるエラーにする
+// 呼び出し元のコンテキストが先に終わった場合（クライアントの切断やリクエストの期限切れ）はそのまま返す
+func (h *statementTimeoutHandler) timedOut(ctx context.Context, err error) error {
+	if err == nil || context.Cause(ctx) != errStatementTimeout {
+		return err
+	}
+	return h.timeoutError()
+}
+
+func (h *statementTimeoutHandler) timeoutError() error {
+	statementTimeoutCount.Add(1)
+	return fmt.Errorf("%w: statement did not complete within %s", context.DeadlineExceeded, h.timeout)
+}
+
+type cancelRows struct {
+	database.Rows
+	once   sync.Once
+	cancel func()
+}
+
+func (r *cancelRows) Close() error {
+	err := r.Rows.Close()
+	r.once.Do(r.cancel)
+	return err
+}
+
+type cancelRow struct {
+	database.Row
+	scan func(err error) error
+}
+
+func (r *cancelRow) Scan(dest ...interface{}) error {
+	return r.scan(r.Row.Scan(dest...))
+}
@@ -0,0 +1,141 @@
+package databaseInfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// コンテキストが終わるまで応答しないハンドラー（delay が0でなければ delay 後に応答する）
+type waitingHandler struct {
+	delay time.Duration
+	// Query が返した行（読み出し中に取り消されたかを調べる）
+	rows *contextRows
+}
+
+func (h *waitingHandler) wait(ctx context.Context) error {
+	if h.delay == 0 {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(h.delay):
+		return nil
+	}
+}
+
+func (h *waitingHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	return nil, h.wait(ctx)
+}
+
+func (h *waitingHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	if err := h.wait(ctx); err != nil {
+		return nil, err
+	}
+	h.rows = &contextRows{ctx: ctx}
+	return h.rows, nil
+}
+
+func (h *waitingHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return errorRow{err: h.wait(ctx)}
+}
+
+func (h *waitingHandler) Close() error { return nil }
+
+type contextRows struct {
+	ctx context.Context
+}
+
+func (r *contextRows) Next() bool                     { return r.ctx.Err() == nil }
+func (r *contextRows) Scan(dest ...interface{}) error { return nil }
+func (r *contextRows) Close() error                   { return nil }
+func (r *contextRows) Err() error                     { return r.ctx.Err() }
+
+func TestStatementTimeoutHandler(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+
+	t.Run("異常系: 期限を過ぎたExecuteは期限切れのエラーになる", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{}, timeout)
+		_, err := h.Execute(context.Background(), "UPDATE items SET name = ?", "x")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "statement did not complete within 20ms")
+	})
+
+	t.Run("異常系: 期限を過ぎたQueryRowはScanで期限切れのエラーになる", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{}, timeout)
+		err := h.QueryRow(context.Background(), "SELECT 1").Scan()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "statement did not complete")
+	})
+
+	t.Run("異常系: 最初の結果が期限までに返らないQueryは期限切れのエラーになる", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{}, timeout)
+		rows, err := h.Query(context.Background(), "SELECT * FROM items")
+		assert.Nil(t, rows)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "statement did not complete")
+	})
+
+	t.Run("正常系: 結果が返ったQueryは期限を過ぎても読み出しを続け、Closeで取り消す", func(t *testing.T) {
+		inner := &waitingHandler{delay: time.Millisecond}
+		h := WithStatementTimeout(inner, timeout)
+		rows, err := h.Query(context.Background(), "SELECT * FROM items")
+		require.NoError(t, err)
+
+		time.Sleep(2 * timeout)
+		assert.True(t, rows.Next())
+		assert.NoError(t, rows.Err())
+
+		require.NoError(t, rows.Close())
+		assert.ErrorIs(t, inner.rows.ctx.Err(), context.Canceled)
+	})
+
+	t.Run("異常系: 呼び出し元のキャンセルはそのまま返す", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{}, time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(timeout, cancel)
+		_, err := h.Execute(ctx, "UPDATE items SET name = ?", "x")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotContains(t, err.Error(), "statement did not complete")
+	})
+
+	t.Run("異常系: リクエストの期限切れは文の期限切れとして数えない", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{}, time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		err := h.QueryRow(ctx, "SELECT 1").Scan()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotContains(t, err.Error(), "statement did not complete")
+	})
+
+	t.Run("正常系: 期限内に終わったクエリはそのまま返す", func(t *testing.T) {
+		h := WithStatementTimeout(&waitingHandler{delay: time.Millisecond}, time.Minute)
+		_, err := h.Execute(context.Background(), "UPDATE items SET name = ?", "x")
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: 0以下ならラップしない", func(t *testing.T) {
+		inner := &waitingHandler{}
+		assert.Same(t, database.SqlHandler(inner), WithStatementTimeout(inner, 0))
+	})
+
+	t.Run("正常系: トランザクションと方言を引き継ぐ", func(t *testing.T) {
+		inner := &transactionalHandler{}
+		h := WithStatementTimeout(inner, timeout)
+		called := false
+		require.NoError(t, h.(database.Transactor).Transaction(context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		}))
+		assert.True(t, called)
+		assert.Equal(t, database.DialectOf(inner), database.DialectOf(h))
+	})
+}
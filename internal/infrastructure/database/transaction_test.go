@@ -0,0 +1,91 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// コミット・ロールバックの回数だけを数えるドライバー
+type countingDriver struct {
+	commits, rollbacks, execs int
+}
+
+func (d *countingDriver) Open(name string) (driver.Conn, error) { return &countingConn{d}, nil }
+
+type countingConn struct{ d *countingDriver }
+
+func (c *countingConn) Prepare(query string) (driver.Stmt, error) { return &countingStmt{c.d}, nil }
+func (c *countingConn) Close() error                              { return nil }
+func (c *countingConn) Begin() (driver.Tx, error)                 { return &countingTx{c.d}, nil }
+
+type countingTx struct{ d *countingDriver }
+
+func (t *countingTx) Commit() error   { t.d.commits++; return nil }
+func (t *countingTx) Rollback() error { t.d.rollbacks++; return nil }
+
+type countingStmt struct{ d *countingDriver }
+
+func (s *countingStmt) Close() error  { return nil }
+func (s *countingStmt) NumInput() int { return -1 }
+func (s *countingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.execs++
+	return driver.RowsAffected(1), nil
+}
+func (s *countingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+var testDriver = &countingDriver{}
+
+func init() {
+	sql.Register("counting", testDriver)
+}
+
+func TestMySqlHandler_Transaction(t *testing.T) {
+	conn, err := sql.Open("counting", "")
+	require.NoError(t, err)
+	defer conn.Close()
+	h := &MySqlHandler{Conn: conn}
+	ctx := context.Background()
+
+	t.Run("正常系: 成功した場合はコミット", func(t *testing.T) {
+		*testDriver = countingDriver{}
+		err := h.Transaction(ctx, func(ctx context.Context) error {
+			_, err := h.Execute(ctx, "UPDATE items SET name = ?", "a")
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, countingDriver{commits: 1, execs: 1}, *testDriver)
+	})
+
+	t.Run("異常系: エラーの場合はロールバック", func(t *testing.T) {
+		*testDriver = countingDriver{}
+		boom := errors.New("boom")
+		err := h.Transaction(ctx, func(ctx context.Context) error { return boom })
+		assert.ErrorIs(t, err, boom)
+		assert.Equal(t, countingDriver{rollbacks: 1}, *testDriver)
+	})
+
+	t.Run("正常系: 入れ子は外側のトランザクションに参加する", func(t *testing.T) {
+		*testDriver = countingDriver{}
+		err := h.Transaction(ctx, func(ctx context.Context) error {
+			return h.Transaction(ctx, func(ctx context.Context) error { return nil })
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, testDriver.commits)
+	})
+
+	t.Run("異常系: パニックの場合もロールバック", func(t *testing.T) {
+		*testDriver = countingDriver{}
+		assert.Panics(t, func() {
+			_ = h.Transaction(ctx, func(ctx context.Context) error { panic("boom") })
+		})
+		assert.Equal(t, 1, testDriver.rollbacks)
+	})
+}
@@ -0,0 +1,71 @@
+// Package erasuretoken はデータの削除依頼（DELETE /me）の確認に使うトークンに署名・検証する。
+//
+// 形式: base64url("<期限のUNIX秒>.<ユーザーID>") "." base64url(HMAC-SHA256)
+// トークンはデータベースに保存しない。期限は呼び出し側が判断する。
+package erasuretoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var ErrInvalidToken = errors.New("erasuretoken: invalid token")
+
+var encoding = base64.RawURLEncoding
+
+// Signer は usecase.ErasureTokenSigner の実装
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+func (s *Signer) Sign(claims usecase.ErasureClaims) string {
+	// ユーザーIDには "." を含められるため、期限を先に置く
+	payload := encoding.EncodeToString([]byte(fmt.Sprintf("%d.%s", claims.ExpiresAt.Unix(), claims.UserID)))
+	return payload + "." + encoding.EncodeToString(s.mac(payload))
+}
+
+// Verify は署名を確かめて内容を返す
+func (s *Signer) Verify(token string) (usecase.ErasureClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return usecase.ErasureClaims{}, ErrInvalidToken
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return usecase.ErasureClaims{}, ErrInvalidToken
+	}
+	decoded, err := encoding.DecodeString(payload)
+	if err != nil {
+		return usecase.ErasureClaims{}, ErrInvalidToken
+	}
+	expiresAt, userID, ok := strings.Cut(string(decoded), ".")
+	if !ok || userID == "" {
+		return usecase.ErasureClaims{}, ErrInvalidToken
+	}
+	seconds, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return usecase.ErasureClaims{}, ErrInvalidToken
+	}
+	return usecase.ErasureClaims{
+		UserID:    userID,
+		ExpiresAt: time.Unix(seconds, 0).UTC(),
+	}, nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package erasuretoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("erasure-secret"))
+	claims := usecase.ErasureClaims{UserID: "alice.smith", ExpiresAt: time.Date(2024, 3, 31, 9, 10, 0, 0, time.UTC)}
+	token := signer.Sign(claims)
+
+	t.Run("正常系: 署名したトークンを検証する（ユーザーIDに . を含む）", func(t *testing.T) {
+		got, err := signer.Verify(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, claims, got)
+	})
+
+	t.Run("異常系: 別のシークレットで署名したトークン", func(t *testing.T) {
+		_, err := NewSigner([]byte("other-secret")).Verify(token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: ユーザーIDを書き換えたトークン", func(t *testing.T) {
+		_, signature, _ := strings.Cut(token, ".")
+		forged := encoding.EncodeToString([]byte("1711876200.bob")) + "." + signature
+
+		_, err := signer.Verify(forged)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 形式が不正", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "." + strings.Repeat("A", 43)} {
+			_, err := signer.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package errorreport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+type recordingReporter struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingReporter) Report(ctx context.Context, event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestMiddleware(t *testing.T) {
+	dbErr := errors.New("database error: connection refused")
+
+	tests := []struct {
+		name           string
+		handler        echo.HandlerFunc
+		expectedStatus int
+		expectedErr    string
+		expectedPanic  bool
+	}{
+		{
+			name:           "正常系: 2xxは通知しない",
+			handler:        func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 4xxは通知しない",
+			handler:        func(c echo.Context) error { return c.NoContent(http.StatusNotFound) },
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "正常系: 返された4xxのエラーは通知しない",
+			handler: func(c echo.Context) error {
+				return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "正常系: 返されたエラーの原因を通知",
+			handler:        func(c echo.Context) error { return fmt.Errorf("failed to retrieve item: %w", dbErr) },
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    "failed to retrieve item: " + dbErr.Error(),
+		},
+		{
+			name:           "正常系: パニックを500にして通知",
+			handler:        func(c echo.Context) error { panic("nil map") },
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    "panic: nil map",
+			expectedPanic:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &recordingReporter{}
+			e := echo.New()
+			e.HTTPErrorHandler = itemController.HTTPErrorHandler
+			e.Use(Middleware(reporter))
+			e.GET("/items/:id", tt.handler)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedErr == "" {
+				assert.Empty(t, reporter.events)
+				return
+			}
+			require.Len(t, reporter.events, 1)
+			event := reporter.events[0]
+			assert.Equal(t, tt.expectedErr, event.Err.Error())
+			assert.Equal(t, "/items/:id", event.Path)
+			assert.Equal(t, tt.expectedPanic, event.Panic)
+			assert.Equal(t, tt.expectedPanic, len(event.Stack) > 0)
+		})
+	}
+}
+
+func TestMiddleware_RedactsSecrets(t *testing.T) {
+	reporter := &recordingReporter{}
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.Use(Middleware(reporter))
+	e.GET("/v1/shared/:token", func(c echo.Context) error { return errors.New("database error") })
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/shared/signed-secret?token=query-secret&lang=ja", nil))
+
+	require.Len(t, reporter.events, 1)
+	event := reporter.events[0]
+	assert.Equal(t, "/v1/shared/:token", event.Path)
+	assert.NotContains(t, event.URL, "signed-secret")
+	assert.NotContains(t, event.URL, "query-secret")
+	assert.Contains(t, event.URL, "lang=ja")
+}
+
+func TestMiddleware_AbortHandler(t *testing.T) {
+	reporter := &recordingReporter{}
+	e := echo.New()
+	e.Use(Middleware(reporter))
+	e.GET("/exports/items", func(c echo.Context) error { panic(http.ErrAbortHandler) })
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/exports/items", nil))
+	}()
+	assert.Equal(t, http.ErrAbortHandler, recovered)
+	assert.Empty(t, reporter.events)
+}
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		name             string
+		dsn              string
+		expectedEndpoint string
+		expectedKey      string
+		wantErr          bool
+	}{
+		{
+			name:             "正常系: 標準のDSN",
+			dsn:              "https://abc123@o1.ingest.sentry.io/42",
+			expectedEndpoint: "https://o1.ingest.sentry.io/api/42/envelope/",
+			expectedKey:      "abc123",
+		},
+		{
+			name:             "正常系: パス付きのセルフホスト",
+			dsn:              "http://key@sentry.local:9000/sentry/7",
+			expectedEndpoint: "http://sentry.local:9000/sentry/api/7/envelope/",
+			expectedKey:      "key",
+		},
+		{name: "異常系: 公開鍵なし", dsn: "https://sentry.io/42", wantErr: true},
+		{name: "異常系: プロジェクトIDなし", dsn: "https://key@sentry.io/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, key, err := parseDSN(tt.dsn)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEndpoint, endpoint)
+			assert.Equal(t, tt.expectedKey, key)
+		})
+	}
+}
+
+func TestSentryReporter(t *testing.T) {
+	var mu sync.Mutex
+	var auth string
+	var lines []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "/api/42/envelope/", r.URL.Path)
+		auth = r.Header.Get("X-Sentry-Auth")
+		body, _ := io.ReadAll(r.Body)
+		scanner := bufio.NewScanner(strings.NewReader(string(body)))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}))
+	defer srv.Close()
+
+	reporter, err := NewSentryReporter(strings.Replace(srv.URL, "://", "://pubkey@", 1)+"/42", "production", "v1.2.3")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("User-Agent", "curl/8")
+	req.Header.Set("X-API-Key", "secret")
+	reporter.Report(context.Background(), Event{
+		Err:     errors.New("boom"),
+		Status:  500,
+		Method:  http.MethodGet,
+		Path:    "/items/:id",
+		URL:     "/items/1",
+		Request: req,
+	})
+	reporter.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Contains(t, auth, "sentry_key=pubkey")
+	require.Len(t, lines, 3)
+	assert.JSONEq(t, `{"type":"event"}`, lines[1])
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	assert.Equal(t, "production", event["environment"])
+	assert.Equal(t, "v1.2.3", event["release"])
+	assert.Equal(t, "error", event["level"])
+	assert.Contains(t, lines[2], `"value":"boom"`)
+	assert.Contains(t, lines[2], "curl/8")
+	assert.NotContains(t, lines[2], "secret")
+}
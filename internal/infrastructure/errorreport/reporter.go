@@ -0,0 +1,149 @@
+// Package errorreport は5xxレスポンスとパニックを外部のエラー収集サービスへ通知する。
+package errorreport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// 値を通知先に送らないパス・クエリパラメーター（共有リンクのトークンなど、それだけでアクセスできる値）
+var secretParams = map[string]bool{"token": true, "passphrase": true}
+
+const redacted = "[REDACTED]"
+
+// 通知するエラーの内容
+type Event struct {
+	Err     error
+	Status  int
+	Method  string
+	Path    string // ルートのパターン（例: /items/:id）
+	URL     string // 秘密のパラメーターの値は伏せてある
+	Stack   []byte // パニック時のスタックトレース
+	Panic   bool
+	Request *http.Request
+}
+
+// エラー通知先
+type ErrorReporter interface {
+	Report(ctx context.Context, event Event)
+}
+
+// 標準のログに出すだけの通知先（通知先が設定されていない場合に使う）
+type LogReporter struct{}
+
+func (LogReporter) Report(ctx context.Context, event Event) {
+	// スタックトレースはレベルでの絞り込みでエラーと切り離されないよう、同じメッセージで出す
+	if len(event.Stack) > 0 {
+		log.Printf("❌ %d %s %s: %v\n%s", event.Status, event.Method, event.URL, event.Err, event.Stack)
+		return
+	}
+	log.Printf("❌ %d %s %s: %v", event.Status, event.Method, event.URL, event.Err)
+}
+
+// パニックを500に変換し、5xxレスポンスを通知するミドルウェア
+// エラーハンドラーが保存した原因のエラーがあればそれを通知する。
+func Middleware(reporter ErrorReporter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					// http.ErrAbortHandler は送信途中のレスポンスを意図的に中断するためのもの（サーバーが接続を閉じる）
+					if r == http.ErrAbortHandler {
+						panic(r)
+					}
+					panicErr, ok := r.(error)
+					if !ok {
+						panicErr = fmt.Errorf("%v", r)
+					}
+					report(c, reporter, fmt.Errorf("panic: %w", panicErr), http.StatusInternalServerError, debug.Stack())
+					err = nil
+					if !c.Response().Committed {
+						err = itemController.RespondError(c, http.StatusInternalServerError, itemController.ErrorResponse{
+							Error: "internal server error",
+						})
+					}
+				}
+			}()
+
+			if err = next(c); err != nil {
+				// ステータスと原因を確定させるため、ここでエラーレスポンスを書き出す
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status < http.StatusInternalServerError {
+				return nil
+			}
+
+			cause := err
+			if stored, ok := c.Get(itemController.ContextKeyError).(error); ok && stored != nil {
+				cause = stored
+			}
+			if cause == nil {
+				cause = fmt.Errorf("%s %s responded %d", c.Request().Method, c.Path(), status)
+			}
+			report(c, reporter, cause, status, nil)
+			return nil
+		}
+	}
+}
+
+func report(c echo.Context, reporter ErrorReporter, err error, status int, stack []byte) {
+	req := c.Request()
+	reporter.Report(req.Context(), Event{
+		Err:     err,
+		Status:  status,
+		Method:  req.Method,
+		Path:    c.Path(),
+		URL:     redactedURL(c),
+		Stack:   stack,
+		Panic:   stack != nil,
+		Request: req,
+	})
+}
+
+// リクエストのURLから秘密のパスパラメーター・クエリパラメーターの値を伏せる
+func redactedURL(c echo.Context) string {
+	u := *c.Request().URL
+
+	values := make(map[string]string, len(c.ParamNames()))
+	sensitive := false
+	for i, name := range c.ParamNames() {
+		if secretParams[strings.ToLower(name)] {
+			sensitive = true
+		}
+		if i < len(c.ParamValues()) {
+			values[name] = c.ParamValues()[i]
+		}
+	}
+	if sensitive {
+		// ルートのパターンにパラメーターの値を当てはめ、秘密のものだけを伏せる
+		segments := strings.Split(c.Path(), "/")
+		for i, segment := range segments {
+			if name, ok := strings.CutPrefix(segment, ":"); ok {
+				segments[i] = values[name]
+				if secretParams[strings.ToLower(name)] {
+					segments[i] = redacted
+				}
+			}
+		}
+		u.Path, u.RawPath = strings.Join(segments, "/"), ""
+	}
+
+	query := u.Query()
+	for name := range query {
+		if secretParams[strings.ToLower(name)] {
+			query[name] = []string{redacted}
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,220 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sentryClient    = "aicon-items/1.0"
+	sentryQueueSize = 100
+)
+
+// 通知に含めるリクエストヘッダー（認証情報などは送らない）
+var sentryHeaders = []string{"User-Agent", "Content-Type", "X-Tenant-ID"}
+
+// Sentry へ envelope API でイベントを送る通知先
+// 送信はバックグラウンドで行い、キューが溢れた場合は破棄する。
+type SentryReporter struct {
+	dsn         string
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	client      *http.Client
+
+	queue chan []byte
+	wg    sync.WaitGroup
+	once  sync.Once
+}
+
+// dsn: https://<public_key>@<host>/<project_id>
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	endpoint, key, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SentryReporter{
+		dsn:         dsn,
+		endpoint:    endpoint,
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=%s", key, sentryClient),
+		environment: environment,
+		release:     release,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan []byte, sentryQueueSize),
+	}
+
+	r.wg.Add(1)
+	go r.worker()
+
+	return r, nil
+}
+
+func parseDSN(dsn string) (endpoint, key string, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", errors.New("invalid sentry DSN: missing public key")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	projectID := path[idx+1:]
+	if projectID == "" {
+		return "", "", errors.New("invalid sentry DSN: missing project id")
+	}
+
+	endpoint = fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:idx], projectID)
+	return endpoint, u.User.Username(), nil
+}
+
+func (r *SentryReporter) Report(ctx context.Context, event Event) {
+	payload, err := r.envelope(event)
+	if err != nil {
+		log.Printf("⚠️  failed to encode sentry event: %v", err)
+		return
+	}
+
+	select {
+	case r.queue <- payload:
+	default:
+		log.Printf("⚠️  sentry queue is full, dropping event: %v", event.Err)
+	}
+}
+
+// 送信待ちのイベントを送り切ってから終了する
+func (r *SentryReporter) Close() {
+	r.once.Do(func() {
+		close(r.queue)
+	})
+	r.wg.Wait()
+}
+
+func (r *SentryReporter) worker() {
+	defer r.wg.Done()
+	for payload := range r.queue {
+		if err := r.send(payload); err != nil {
+			log.Printf("⚠️  failed to send sentry event: %v", err)
+		}
+	}
+}
+
+func (r *SentryReporter) send(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (r *SentryReporter) envelope(event Event) ([]byte, error) {
+	id, err := eventID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	level := "error"
+	if event.Panic {
+		level = "fatal"
+	}
+
+	body := map[string]interface{}{
+		"event_id":  id,
+		"timestamp": now.Format(time.RFC3339Nano),
+		"platform":  "go",
+		"level":     level,
+		"logger":    "http",
+		"exception": map[string]interface{}{
+			"values": []map[string]interface{}{{
+				"type":  errorType(event.Err),
+				"value": event.Err.Error(),
+			}},
+		},
+		"tags": map[string]string{
+			"status": strconv.Itoa(event.Status),
+			"route":  event.Path,
+		},
+	}
+	if r.environment != "" {
+		body["environment"] = r.environment
+	}
+	if r.release != "" {
+		body["release"] = r.release
+	}
+	if event.Request != nil {
+		headers := make(map[string]string)
+		for _, h := range sentryHeaders {
+			if v := event.Request.Header.Get(h); v != "" {
+				headers[h] = v
+			}
+		}
+		body["request"] = map[string]interface{}{
+			"method":  event.Method,
+			"url":     event.URL,
+			"headers": headers,
+		}
+	}
+	if len(event.Stack) > 0 {
+		body["extra"] = map[string]string{"stack": string(event.Stack)}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(map[string]string{"event_id": id, "dsn": r.dsn, "sent_at": now.Format(time.RFC3339Nano)}); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(map[string]string{"type": "event"}); err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 最も内側のエラーの型名
+func errorType(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return fmt.Sprintf("%T", err)
+		}
+		err = next
+	}
+}
+
+func eventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
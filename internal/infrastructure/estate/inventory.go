@@ -0,0 +1,214 @@
+// Package estate は相続・遺言向けの資産目録をPDFとして描画する。
+package estate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/pdf"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	margin     = 40.0
+	rowHeight  = 18.0
+	fontSize   = 8.0
+	headerSize = 16.0
+)
+
+// 一覧の列（幅はポイント単位）
+var columns = []struct {
+	title string
+	width float64
+}{
+	{"No.", 45},
+	{"名称", 115},
+	{"カテゴリー", 55},
+	{"ブランド", 80},
+	{"購入日", 55},
+	{"購入価格", 65},
+	{"属性・保管場所", 70},
+	{"画像", 30},
+}
+
+type pdfRenderer struct{}
+
+func NewPDFRenderer() usecase.InventoryRenderer {
+	return &pdfRenderer{}
+}
+
+func (r *pdfRenderer) RenderInventory(inv *usecase.EstateInventory) ([]byte, error) {
+	doc := pdf.NewDocument()
+	pageNo := 0
+
+	newPage := func() (*pdf.Page, float64) {
+		pageNo++
+		page := doc.AddPage(pdf.A4Width, pdf.A4Height)
+		page.Text(margin, margin/2, fontSize, fmt.Sprintf("%d ページ", pageNo))
+		return page, pdf.A4Height - margin
+	}
+
+	page, y := newPage()
+
+	// 表紙情報
+	y -= headerSize
+	page.Text(margin, y, headerSize, "資産目録")
+	y -= rowHeight * 1.5
+	owner := inv.Owner
+	if owner == "" {
+		owner = "（全アイテム）"
+	}
+	page.Text(margin, y, 10, "所有者: "+owner)
+	y -= rowHeight
+	page.Text(margin, y, 10, "作成日: "+inv.GeneratedAt.Format("2006-01-02 15:04"))
+	y -= rowHeight
+	page.Text(margin, y, 10, fmt.Sprintf("件数: %d件　購入価格合計: %s%s", len(inv.Items), FormatYen(inv.TotalValue), converted(inv.Conversion, inv.TotalValue)))
+	if c := inv.Conversion; c != nil {
+		y -= rowHeight
+		page.Text(margin, y, 10, fmt.Sprintf("為替レート: 1 %s = %s %s（%s）", c.From, strconv.FormatFloat(c.Rate, 'g', 6, 64), c.To, c.RateDate))
+	}
+	y -= rowHeight * 1.5
+
+	y = drawHeaderRow(page, y)
+	for _, item := range inv.Items {
+		if y-rowHeight < margin {
+			page, y = newPage()
+			y = drawHeaderRow(page, y)
+		}
+
+		cells := []string{
+			"No. " + strconv.FormatInt(item.ID, 10),
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchaseDate,
+			formatPrice(item.Price()),
+			formatAttributes(item.Attributes),
+			formatImageIDs(item.ImageIDs),
+		}
+		y = drawRow(page, y, cells)
+	}
+
+	// カテゴリー別合計
+	if y-rowHeight*float64(len(inv.CategoryTotals)+2) < margin {
+		page, y = newPage()
+	}
+	y -= rowHeight
+	page.Text(margin, y, 10, "カテゴリー別合計")
+	categories := make([]string, 0, len(inv.CategoryTotals))
+	for category := range inv.CategoryTotals {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		y -= rowHeight
+		total := inv.CategoryTotals[category]
+		page.Text(margin, y, fontSize, fmt.Sprintf("%s: %s%s", category, FormatYen(total), converted(inv.Conversion, total)))
+	}
+
+	return doc.Bytes(), nil
+}
+
+func drawHeaderRow(page *pdf.Page, y float64) float64 {
+	width := 0.0
+	for _, col := range columns {
+		width += col.width
+	}
+	page.FillRect(margin, y-rowHeight, width, rowHeight, 0.9)
+	return drawRow(page, y, func() []string {
+		titles := make([]string, 0, len(columns))
+		for _, col := range columns {
+			titles = append(titles, col.title)
+		}
+		return titles
+	}())
+}
+
+func drawRow(page *pdf.Page, y float64, cells []string) float64 {
+	x := margin
+	for i, col := range columns {
+		page.StrokeRect(x, y-rowHeight, col.width, rowHeight, 0.25)
+		page.Text(x+3, y-rowHeight+5.5, fontSize, pdf.Truncate(cells[i], fontSize, col.width-6))
+		x += col.width
+	}
+	return y - rowHeight
+}
+
+func formatAttributes(attributes map[string]string) string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+attributes[key])
+	}
+	return strings.Join(parts, " ")
+}
+
+// 画像のIDを「#3 #7」形式にする（画像は GET /items/{id}/images/{image_id} で取得できる）
+func formatImageIDs(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, "#"+strconv.FormatInt(id, 10))
+	}
+	return strings.Join(parts, " ")
+}
+
+// 換算がある場合、円の金額に続ける「（USD 8,937.50）」を返す
+func converted(conversion *usecase.Conversion, amount int) string {
+	if conversion == nil {
+		return ""
+	}
+	return "（" + FormatAmount(conversion.Convert(amount), conversion.To) + "）"
+}
+
+// 金額を「¥1,500,000」形式にする
+func FormatYen(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return sign + "¥" + groupDigits(strconv.Itoa(amount))
+}
+
+// 購入価格を円は「¥1,500,000」、ほかの通貨は「USD 8,937.50」形式にする
+func formatPrice(price entity.Money) string {
+	if price.Currency == entity.DefaultCurrency {
+		return FormatYen(int(price.Amount))
+	}
+	return FormatAmount(float64(price.Amount)/math.Pow10(entity.CurrencyDigits(price.Currency)), price.Currency)
+}
+
+// 金額を「USD 8,937.50」形式にする（補助単位のない通貨は小数なし）
+func FormatAmount(amount float64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	formatted := strconv.FormatFloat(amount, 'f', usecase.CurrencyDecimals(currency), 64)
+	integer, fraction, _ := strings.Cut(formatted, ".")
+	if fraction != "" {
+		fraction = "." + fraction
+	}
+	return currency + " " + sign + groupDigits(integer) + fraction
+}
+
+func groupDigits(digits string) string {
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package estate
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestFormatYen(t *testing.T) {
+	assert.Equal(t, "¥0", FormatYen(0))
+	assert.Equal(t, "¥999", FormatYen(999))
+	assert.Equal(t, "¥1,500,000", FormatYen(1500000))
+	assert.Equal(t, "-¥12,345", FormatYen(-12345))
+}
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "USD 8,937.50", FormatAmount(8937.5, "USD"))
+	assert.Equal(t, "USD 0.07", FormatAmount(0.07, "USD"))
+	assert.Equal(t, "EUR -1,234,567.89", FormatAmount(-1234567.89, "EUR"))
+	assert.Equal(t, "KRW 1,200,000", FormatAmount(1200000, "KRW"))
+}
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "¥1,500,000", formatPrice(entity.Money{Amount: 1500000, Currency: "JPY"}))
+	assert.Equal(t, "USD 8,937.50", formatPrice(entity.Money{Amount: 893750, Currency: "USD"}))
+	assert.Equal(t, "KWD 1.250", formatPrice(entity.Money{Amount: 1250, Currency: "KWD"}))
+}
+
+func TestFormatImageIDs(t *testing.T) {
+	assert.Equal(t, "", formatImageIDs(nil))
+	assert.Equal(t, "#3 #7", formatImageIDs([]int64{3, 7}))
+}
+
+func TestRenderInventory(t *testing.T) {
+	var items []*entity.Item
+	for i := 1; i <= 60; i++ {
+		items = append(items, &entity.Item{
+			ID: int64(i), Name: fmt.Sprintf("アイテム%d", i), Category: "時計", Brand: "ROLEX",
+			PurchasePrice: 1000, PurchaseDate: "2023-01-01",
+			Attributes: map[string]string{"storage_box": "A-1"},
+		})
+	}
+
+	out, err := NewPDFRenderer().RenderInventory(&usecase.EstateInventory{
+		Owner:          "alice",
+		GeneratedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Items:          items,
+		TotalValue:     60000,
+		CategoryTotals: map[string]int{"時計": 60000},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	// 60件は1ページに収まらない
+	assert.GreaterOrEqual(t, bytes.Count(out, []byte("/Type /Page ")), 2)
+
+	t.Run("正常系: 換算した合計を含める", func(t *testing.T) {
+		out, err := NewPDFRenderer().RenderInventory(&usecase.EstateInventory{
+			GeneratedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Items:          items[:1],
+			TotalValue:     1000,
+			CategoryTotals: map[string]int{"時計": 1000},
+			Conversion:     &usecase.Conversion{From: "JPY", To: "USD", Rate: 0.0068, RateDate: "2024-03-08"},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	})
+}
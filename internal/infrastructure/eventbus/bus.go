@@ -0,0 +1,148 @@
+// Package eventbus はユースケースで発生したアイテムイベントをプロセス内の購読者に配信する。
+//
+// 購読の方法は2つある。
+//   - Handle: 集計キャッシュの破棄や Webhook の配信など、アプリケーション内の機能がハンドラーを登録する。
+//     Publish の中で登録順に同期して呼び出すため、リクエストの処理が終わる前に反映される
+//   - Subscribe: WebSocket など、チャネルでイベントを受け取る。配信はノンブロッキングで、
+//     受信が追いつかない購読者宛てのイベントは破棄する（破棄数は /debug/vars の events_dropped）
+package eventbus
+
+import (
+	"context"
+	"expvar"
+	"sync"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 購読者ごとのバッファサイズ
+const DefaultBufferSize = 64
+
+var droppedCount = expvar.NewInt("events_dropped")
+
+type Bus struct {
+	mu          sync.Mutex
+	handlers    []*handler
+	subscribers map[*subscriber]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+type handler struct {
+	publisher  usecase.ItemEventPublisher
+	eventTypes map[string]bool // 空ならすべてのイベント
+}
+
+type subscriber struct {
+	ch chan usecase.ItemEvent
+}
+
+func New(bufferSize int) *Bus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Bus{
+		subscribers: make(map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// ハンドラーを登録し、eventTypes のイベント（指定がなければすべて）を渡す。返された関数で登録を解除する。
+// ハンドラーは Publish と同じくブロックしてはならない。Close の後も呼び出す（処理中のリクエストの変更を反映するため）
+func (b *Bus) Handle(publisher usecase.ItemEventPublisher, eventTypes ...string) func() {
+	h := &handler{publisher: publisher}
+	if len(eventTypes) > 0 {
+		h.eventTypes = make(map[string]bool, len(eventTypes))
+		for _, t := range eventTypes {
+			h.eventTypes[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+
+	return func() { b.unhandle(h) }
+}
+
+func (b *Bus) unhandle(h *handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, registered := range b.handlers {
+		if registered == h {
+			// 配信中の Publish が持っているスライスを書き換えないよう、新しいスライスにする
+			b.handlers = append(b.handlers[:i:i], b.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+// 購読を開始する。返された関数で購読を解除するとチャネルが閉じられる。
+// バスが閉じられた後はすぐに閉じられたチャネルを返す。
+func (b *Bus) Subscribe() (<-chan usecase.ItemEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscriber{ch: make(chan usecase.ItemEvent, b.bufferSize)}
+	if b.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub.ch, func() { b.unsubscribe(sub) }
+}
+
+func (b *Bus) unsubscribe(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// ハンドラーと全購読者にイベントを配信する（usecase.ItemEventPublisher の実装）
+func (b *Bus) Publish(ctx context.Context, event usecase.ItemEvent) {
+	// ハンドラーはロックの外で呼び出す（ハンドラーから購読や登録の解除ができるように）
+	b.mu.Lock()
+	handlers := b.handlers
+	b.mu.Unlock()
+	for _, h := range handlers {
+		if h.eventTypes == nil || h.eventTypes[event.Type] {
+			h.publisher.Publish(ctx, event)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			droppedCount.Add(1)
+		}
+	}
+}
+
+// 購読者数
+func (b *Bus) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// 全購読者のチャネルを閉じ、以降の購読を受け付けない（ハンドラーはそのまま）
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		close(sub.ch)
+	}
+	b.subscribers = make(map[*subscriber]struct{})
+	b.closed = true
+}
@@ -0,0 +1,131 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 全購読者に配信する", func(t *testing.T) {
+		bus := New(4)
+		first, unsubscribeFirst := bus.Subscribe()
+		second, unsubscribeSecond := bus.Subscribe()
+		defer unsubscribeFirst()
+		defer unsubscribeSecond()
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+
+		assert.Equal(t, int64(1), (<-first).ItemID)
+		assert.Equal(t, int64(1), (<-second).ItemID)
+	})
+
+	t.Run("正常系: 購読を解除するとチャネルが閉じられ配信されない", func(t *testing.T) {
+		bus := New(4)
+		events, unsubscribe := bus.Subscribe()
+		unsubscribe()
+		unsubscribe()
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.Equal(t, 0, bus.Len())
+	})
+
+	t.Run("正常系: バッファが一杯の購読者宛てのイベントは破棄してブロックしない", func(t *testing.T) {
+		bus := New(1)
+		events, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+		before := droppedCount.Value()
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2})
+
+		assert.Equal(t, int64(1), (<-events).ItemID)
+		assert.Equal(t, before+1, droppedCount.Value())
+	})
+
+	t.Run("正常系: 閉じた後の購読はすぐに終了する", func(t *testing.T) {
+		bus := New(1)
+		events, _ := bus.Subscribe()
+		bus.Close()
+
+		_, ok := <-events
+		assert.False(t, ok)
+
+		late, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+		_, ok = <-late
+		assert.False(t, ok)
+	})
+}
+
+// recorder は受け取ったイベントを記録するハンドラー
+type recorder struct {
+	events []usecase.ItemEvent
+}
+
+func (r *recorder) Publish(_ context.Context, event usecase.ItemEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestBus_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 登録したハンドラーに同期して配信する", func(t *testing.T) {
+		bus := New(1)
+		all := &recorder{}
+		bus.Handle(all)
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 2})
+
+		assert.Len(t, all.events, 2)
+		assert.Equal(t, 0, bus.Len(), "ハンドラーはチャネルの購読者に含めない")
+	})
+
+	t.Run("正常系: 指定したイベントだけを配信する", func(t *testing.T) {
+		bus := New(1)
+		deletions := &recorder{}
+		bus.Handle(deletions, usecase.ItemDeleted)
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 1})
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1})
+
+		if assert.Len(t, deletions.events, 1) {
+			assert.Equal(t, usecase.ItemDeleted, deletions.events[0].Type)
+		}
+	})
+
+	t.Run("正常系: 登録を解除すると配信されない", func(t *testing.T) {
+		bus := New(1)
+		first, second := &recorder{}, &recorder{}
+		unhandle := bus.Handle(first)
+		bus.Handle(second)
+		unhandle()
+		unhandle()
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+
+		assert.Empty(t, first.events)
+		assert.Len(t, second.events, 1)
+	})
+
+	t.Run("正常系: 閉じた後もハンドラーには配信する", func(t *testing.T) {
+		bus := New(1)
+		handler := &recorder{}
+		bus.Handle(handler)
+		bus.Close()
+
+		bus.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 1})
+
+		assert.Len(t, handler.events, 1)
+	})
+}
@@ -0,0 +1,67 @@
+package exchangerate
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 提供元が使えないときに返してよい、期限切れのレートの古さの上限（週末・祝日をまたいでも使えるように）
+const maxStaleness = 72 * time.Hour
+
+var (
+	rateFetchCount   = expvar.NewInt("exchange_rate_fetches")
+	staleServedCount = expvar.NewInt("exchange_rate_stale_served")
+)
+
+// 提供元のレートを ttl の間キャッシュする
+// レートは1日1回公表されるため、問い合わせごとに取得せず、
+// 提供元が使えないときは maxStaleness 以内に取得したレートを返す。
+type CachingProvider struct {
+	inner usecase.ExchangeRateProvider
+	ttl   time.Duration
+	now   func() time.Time
+
+	// 期限切れのときに同時に届いた問い合わせが提供元を1回だけ呼ぶよう、取得中もロックする
+	mu        sync.Mutex
+	rates     *usecase.ExchangeRates
+	fetchedAt time.Time
+}
+
+func NewCachingProvider(inner usecase.ExchangeRateProvider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		inner: inner,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+func (p *CachingProvider) LatestRates(ctx context.Context) (*usecase.ExchangeRates, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.rates != nil && now.Sub(p.fetchedAt) < p.ttl {
+		return p.rates, nil
+	}
+
+	rateFetchCount.Add(1)
+	rates, err := p.inner.LatestRates(ctx)
+	if err != nil {
+		if p.rates != nil && domainErrors.IsExchangeRateError(err) && now.Sub(p.fetchedAt) < maxStaleness {
+			staleServedCount.Add(1)
+			log.Printf("⚠️  exchange rate provider failed, serving rates of %s: %v", p.rates.Date, err)
+			return p.rates, nil
+		}
+		return nil, err
+	}
+
+	p.rates = rates
+	p.fetchedAt = now
+	return rates, nil
+}
@@ -0,0 +1,97 @@
+// Package exchangerate は外部の提供元から為替レートを取得する。
+package exchangerate
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 欧州中央銀行（ECB）が営業日ごとに公表する参照レート（ユーロ基準、APIキー不要）
+const ECBDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECB の参照レートの XML から為替レートを取得する提供元
+type ECBProvider struct {
+	url    string
+	client *http.Client
+}
+
+// url が空なら ECBDailyURL を使う
+func NewECBProvider(url string, timeout time.Duration) *ECBProvider {
+	if url == "" {
+		url = ECBDailyURL
+	}
+	return &ECBProvider{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// <gesmes:Envelope><Cube><Cube time="2024-03-08"><Cube currency="USD" rate="1.0939"/>...</Cube></Cube></gesmes:Envelope>
+type ecbEnvelope struct {
+	Cube struct {
+		Daily struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *ECBProvider) LatestRates(ctx context.Context) (*usecase.ExchangeRates, error) {
+	body, err := get(ctx, p.client, p.url, "application/xml")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %w", domainErrors.ErrExchangeRateUnavailable, err)
+	}
+	if len(envelope.Cube.Daily.Rates) == 0 {
+		return nil, fmt.Errorf("%w: response has no rates", domainErrors.ErrExchangeRateUnavailable)
+	}
+
+	rates := &usecase.ExchangeRates{
+		Base:  "EUR",
+		Date:  envelope.Cube.Daily.Time,
+		Rates: make(map[string]float64, len(envelope.Cube.Daily.Rates)),
+	}
+	for _, r := range envelope.Cube.Daily.Rates {
+		rates.Rates[r.Currency] = r.Rate
+	}
+	return rates, nil
+}
+
+// 提供元に GET し、200 ならボディを返す。それ以外は ErrExchangeRateUnavailable
+func get(ctx context.Context, client *http.Client, url, accept string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrExchangeRateUnavailable, err)
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrExchangeRateUnavailable, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: provider responded %s", domainErrors.ErrExchangeRateUnavailable, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// 為替レートを取得できない場合の提供元
+type DisabledProvider struct{}
+
+func (DisabledProvider) LatestRates(ctx context.Context) (*usecase.ExchangeRates, error) {
+	return nil, fmt.Errorf("%w: no exchange rate provider is configured", domainErrors.ErrExchangeRateUnavailable)
+}
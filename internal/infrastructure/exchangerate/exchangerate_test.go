@@ -0,0 +1,195 @@
+package exchangerate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const ecbBody = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-03-08">
+			<Cube currency="USD" rate="1.0939"/>
+			<Cube currency="JPY" rate="160.52"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func serve(status int, body string, received **http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = r
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestECBProvider_LatestRates(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedRates *usecase.ExchangeRates
+		expectedErr   error
+	}{
+		{
+			name:   "正常系: 参照レートを読み取る",
+			status: http.StatusOK,
+			body:   ecbBody,
+			expectedRates: &usecase.ExchangeRates{
+				Base: "EUR", Date: "2024-03-08", Rates: map[string]float64{"USD": 1.0939, "JPY": 160.52},
+			},
+		},
+		{name: "異常系: 提供元の障害", status: http.StatusServiceUnavailable, expectedErr: domainErrors.ErrExchangeRateUnavailable},
+		{name: "異常系: 不正な応答", status: http.StatusOK, body: `{}`, expectedErr: domainErrors.ErrExchangeRateUnavailable},
+		{name: "異常系: レートがない", status: http.StatusOK, body: `<Envelope><Cube/></Envelope>`, expectedErr: domainErrors.ErrExchangeRateUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			server := serve(tt.status, tt.body, &received)
+			defer server.Close()
+
+			rates, err := NewECBProvider(server.URL, time.Second).LatestRates(context.Background())
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, rates)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRates, rates)
+		})
+	}
+
+	t.Run("異常系: 接続できない", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		rates, err := NewECBProvider(server.URL, time.Second).LatestRates(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Nil(t, rates)
+	})
+}
+
+func TestOpenExchangeRatesProvider_LatestRates(t *testing.T) {
+	t.Run("正常系: APIキーを付けて取得", func(t *testing.T) {
+		var received *http.Request
+		server := serve(http.StatusOK, `{"timestamp": 1709899200, "base": "USD", "rates": {"JPY": 147.05, "EUR": 0.9142}}`, &received)
+		defer server.Close()
+
+		rates, err := NewOpenExchangeRatesProvider(server.URL, "secret", time.Second).LatestRates(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret", received.URL.Query().Get("app_id"))
+		assert.Equal(t, &usecase.ExchangeRates{
+			Base: "USD", Date: "2024-03-08", Rates: map[string]float64{"JPY": 147.05, "EUR": 0.9142},
+		}, rates)
+	})
+
+	t.Run("異常系: APIキーが不正", func(t *testing.T) {
+		var received *http.Request
+		server := serve(http.StatusUnauthorized, `{"error": true, "status": 401, "message": "invalid_app_id"}`, &received)
+		defer server.Close()
+
+		rates, err := NewOpenExchangeRatesProvider(server.URL, "wrong", time.Second).LatestRates(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Nil(t, rates)
+	})
+}
+
+type stubProvider struct {
+	calls int
+	rates *usecase.ExchangeRates
+	err   error
+}
+
+func (p *stubProvider) LatestRates(ctx context.Context) (*usecase.ExchangeRates, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rates, nil
+}
+
+func TestCachingProvider_LatestRates(t *testing.T) {
+	start := time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC)
+	rates := &usecase.ExchangeRates{Base: "EUR", Date: "2024-03-08", Rates: map[string]float64{"JPY": 160.52}}
+
+	newProvider := func(inner usecase.ExchangeRateProvider, now *time.Time) *CachingProvider {
+		p := NewCachingProvider(inner, 6*time.Hour)
+		p.now = func() time.Time { return *now }
+		return p
+	}
+
+	t.Run("正常系: TTL の間はキャッシュを返す", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{rates: rates}
+		p := newProvider(inner, &now)
+
+		for i := 0; i < 3; i++ {
+			got, err := p.LatestRates(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, rates, got)
+			now = now.Add(time.Hour)
+		}
+		assert.Equal(t, 1, inner.calls)
+
+		now = start.Add(6 * time.Hour)
+		_, err := p.LatestRates(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("正常系: 提供元の障害時は期限切れのレートを返す", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{rates: rates}
+		p := newProvider(inner, &now)
+		_, err := p.LatestRates(context.Background())
+		require.NoError(t, err)
+
+		now = now.Add(48 * time.Hour)
+		inner.err = domainErrors.ErrExchangeRateUnavailable
+		got, err := p.LatestRates(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, rates, got)
+	})
+
+	t.Run("異常系: 古すぎるレートは返さない", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{rates: rates}
+		p := newProvider(inner, &now)
+		_, err := p.LatestRates(context.Background())
+		require.NoError(t, err)
+
+		now = now.Add(73 * time.Hour)
+		inner.err = domainErrors.ErrExchangeRateUnavailable
+		got, err := p.LatestRates(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Nil(t, got)
+	})
+
+	t.Run("異常系: 取得したことがなければエラー", func(t *testing.T) {
+		now := start
+		p := newProvider(&stubProvider{err: domainErrors.ErrExchangeRateUnavailable}, &now)
+
+		got, err := p.LatestRates(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Nil(t, got)
+	})
+}
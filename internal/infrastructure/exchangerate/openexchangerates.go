@@ -0,0 +1,60 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const OpenExchangeRatesURL = "https://openexchangerates.org/api/latest.json"
+
+// Open Exchange Rates（https://openexchangerates.org、APIキー（app_id）が必要）から為替レートを取得する提供元
+// 無料プランでは基準通貨は USD のみ
+type OpenExchangeRatesProvider struct {
+	url    string
+	appID  string
+	client *http.Client
+}
+
+// url が空なら OpenExchangeRatesURL を使う
+func NewOpenExchangeRatesProvider(url, appID string, timeout time.Duration) *OpenExchangeRatesProvider {
+	if url == "" {
+		url = OpenExchangeRatesURL
+	}
+	return &OpenExchangeRatesProvider{url: url, appID: appID, client: &http.Client{Timeout: timeout}}
+}
+
+// {"timestamp": 1709856000, "base": "USD", "rates": {"JPY": 147.5, ...}}
+type oxrResponse struct {
+	Timestamp int64              `json:"timestamp"`
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+func (p *OpenExchangeRatesProvider) LatestRates(ctx context.Context) (*usecase.ExchangeRates, error) {
+	body, err := get(ctx, p.client, p.url+"?"+url.Values{"app_id": {p.appID}}.Encode(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var resp oxrResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %w", domainErrors.ErrExchangeRateUnavailable, err)
+	}
+	if resp.Base == "" || len(resp.Rates) == 0 {
+		return nil, fmt.Errorf("%w: response has no rates", domainErrors.ErrExchangeRateUnavailable)
+	}
+
+	return &usecase.ExchangeRates{
+		Base:  resp.Base,
+		Date:  time.Unix(resp.Timestamp, 0).UTC().Format("2006-01-02"),
+		Rates: resp.Rates,
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Package export は非同期エクスポートジョブの保管先を提供する。
+package export
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// プロセス内のメモリにジョブを保持する（再起動で消える）
+type MemoryJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*entity.ExportJob
+	ttl  time.Duration
+}
+
+// ttl を過ぎた完了済みジョブは生成ファイルごと破棄する
+func NewMemoryJobStore(ttl time.Duration) *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs: make(map[string]*entity.ExportJob),
+		ttl:  ttl,
+	}
+}
+
+func (s *MemoryJobStore) Save(ctx context.Context, job *entity.ExportJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge()
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+func (s *MemoryJobStore) FindByID(ctx context.Context, id string) (*entity.ExportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, domainErrors.ErrExportNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (s *MemoryJobStore) purge() {
+	if s.ttl <= 0 {
+		return
+	}
+	deadline := time.Now().Add(-s.ttl)
+	for id, job := range s.jobs {
+		if job.CompletedAt != nil && job.CompletedAt.Before(deadline) {
+			delete(s.jobs, id)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package idempotency は Idempotency-Key ヘッダーによる再送の重複実行を防ぐミドルウェアを提供する。
+//
+// 同じキーで再送されたリクエストは処理を実行せず、保存しておいた最初のレスポンスをそのまま返す。
+// キーとレスポンスはプロセス内のメモリに一定時間保持する（再起動で消える）。
+package idempotency
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/sandbox"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// 保存したレスポンスを返したことを示すヘッダー
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// キーの最大長
+	MaxKeyLength = 255
+)
+
+// 保存したレスポンス
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+type entry struct {
+	key string
+	// リクエストボディのハッシュ（同じキーで別の内容を送った場合の検出用）
+	fingerprint [sha256.Size]byte
+	// 処理中は nil
+	response  *response
+	expiresAt time.Time
+}
+
+// キーごとのレスポンスの保存先
+type Store struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	// レスポンスを保存したエントリーを保存した順（= 期限の順）に並べたもの。期限切れは先頭から消す
+	expiry *list.List
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// ttl: レスポンスを保持する期間
+func NewStore(ttl time.Duration) *Store {
+	return &Store{
+		entries: make(map[string]*entry),
+		expiry:  list.New(),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// キーの処理を始める。処理済みなら保存したレスポンスを、処理中または別の内容で使われたキーならエラーを返す
+func (s *Store) begin(key string, fingerprint [sha256.Size]byte) (*response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(s.now())
+
+	e, ok := s.entries[key]
+	if !ok {
+		s.entries[key] = &entry{key: key, fingerprint: fingerprint}
+		return nil, nil
+	}
+	if e.fingerprint != fingerprint {
+		return nil, itemController.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was used for a different request")
+	}
+	if e.response == nil {
+		return nil, itemController.NewHTTPError(http.StatusConflict, "a request with this Idempotency-Key is in progress")
+	}
+	return e.response, nil
+}
+
+// レスポンスを保存する
+func (s *Store) complete(key string, resp *response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.response == nil {
+		e.response = resp
+		e.expiresAt = s.now().Add(s.ttl)
+		s.expiry.PushBack(e)
+	}
+}
+
+// 期限を過ぎたレスポンスを消す。期限の順に並んでいるため、期限内のエントリーに達したら終える
+func (s *Store) expire(now time.Time) {
+	for front := s.expiry.Front(); front != nil; front = s.expiry.Front() {
+		e := front.Value.(*entry)
+		if !now.After(e.expiresAt) {
+			return
+		}
+		s.expiry.Remove(front)
+		delete(s.entries, e.key)
+	}
+}
+
+// 処理中のキーを破棄し、同じキーで再実行できるようにする
+func (s *Store) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok && e.response == nil {
+		delete(s.entries, key)
+	}
+}
+
+// Idempotency-Key 付きのリクエストを1回だけ実行するミドルウェア
+// キーはテナント・ユーザー・サンドボックスのAPIキー・ルートごとに区別する。
+// 5xx のレスポンスは保存せず、同じキーでの再送で処理をやり直す。
+func Middleware(store *Store) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > MaxKeyLength {
+				return itemController.NewHTTPError(http.StatusBadRequest, "invalid Idempotency-Key header", "Idempotency-Key must be 255 characters or less")
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			key = scopedKey(c, key)
+			saved, err := store.begin(key, sha256.Sum256(body))
+			if err != nil {
+				return err
+			}
+			if saved != nil {
+				return replay(c, saved)
+			}
+
+			completed := false
+			// パニックした場合も処理中のままにしない
+			defer func() {
+				if !completed {
+					store.release(key)
+				}
+			}()
+
+			res := c.Response()
+			recorder := &recorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			err = next(c)
+			if err != nil {
+				// 保存するため、ここでエラーレスポンスを書き出す
+				c.Error(err)
+			}
+			res.Writer = recorder.ResponseWriter
+
+			if res.Status < http.StatusInternalServerError {
+				store.complete(key, &response{status: res.Status, header: res.Header().Clone(), body: recorder.body.Bytes()})
+				completed = true
+			}
+			return nil
+		}
+	}
+}
+
+// 保存したレスポンスを返す
+func replay(c echo.Context, saved *response) error {
+	header := c.Response().Header()
+	for k, v := range saved.header {
+		header[k] = append([]string(nil), v...)
+	}
+	header.Set(HeaderReplayed, "true")
+	c.Response().WriteHeader(saved.status)
+	_, err := c.Response().Write(saved.body)
+	return err
+}
+
+// 保存用のキー（テナント・ユーザー・サンドボックスのAPIキー・ルートとクライアントのキー）
+func scopedKey(c echo.Context, key string) string {
+	sandboxKey, _ := sandbox.KeyFromContext(c.Request().Context())
+	return itemController.TenantID(c) + "\x00" + itemController.UserID(c) + "\x00" + sandboxKey + "\x00" + c.Request().Method + " " + c.Path() + "\x00" + key
+}
+
+// 書き出したレスポンスボディを記録する
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
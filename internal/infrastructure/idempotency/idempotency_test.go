@@ -0,0 +1,254 @@
+package idempotency
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+type request struct {
+	key    string
+	tenant string
+	user   string
+	body   string
+}
+
+type result struct {
+	status   int
+	body     string
+	replayed bool
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		handlerStatus int
+		requests      []request
+		expected      []result
+		expectedCalls int
+	}{
+		{
+			name:          "正常系: キーなしは毎回実行する",
+			requests:      []request{{body: `{"name":"a"}`}, {body: `{"name":"a"}`}},
+			expected:      []result{{status: http.StatusCreated, body: `{"id":1}`}, {status: http.StatusCreated, body: `{"id":2}`}},
+			expectedCalls: 2,
+		},
+		{
+			name:     "正常系: 同じキーの再送は最初のレスポンスを返す",
+			requests: []request{{key: "k1", body: `{"name":"a"}`}, {key: "k1", body: `{"name":"a"}`}},
+			expected: []result{
+				{status: http.StatusCreated, body: `{"id":1}`},
+				{status: http.StatusCreated, body: `{"id":1}`, replayed: true},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:          "正常系: 別のキー・別のテナントは別のリクエスト",
+			requests:      []request{{key: "k1", body: `{}`}, {key: "k2", body: `{}`}, {key: "k1", tenant: "acme", body: `{}`}},
+			expected:      []result{{status: http.StatusCreated, body: `{"id":1}`}, {status: http.StatusCreated, body: `{"id":2}`}, {status: http.StatusCreated, body: `{"id":3}`}},
+			expectedCalls: 3,
+		},
+		{
+			name:     "正常系: 別のユーザーは同じキーでも別のリクエスト",
+			requests: []request{{key: "k1", user: "alice", body: `{}`}, {key: "k1", user: "bob", body: `{}`}, {key: "k1", user: "alice", body: `{}`}},
+			expected: []result{
+				{status: http.StatusCreated, body: `{"id":1}`},
+				{status: http.StatusCreated, body: `{"id":2}`},
+				{status: http.StatusCreated, body: `{"id":1}`, replayed: true},
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:          "正常系: 4xxのレスポンスも再送に返す",
+			handlerStatus: http.StatusBadRequest,
+			requests:      []request{{key: "k1", body: `{}`}, {key: "k1", body: `{}`}},
+			expected: []result{
+				{status: http.StatusBadRequest, body: `{"error":"validation failed","code":"VALIDATION_FAILED"}`},
+				{status: http.StatusBadRequest, body: `{"error":"validation failed","code":"VALIDATION_FAILED"}`, replayed: true},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:          "正常系: 5xxのレスポンスは保存せず再実行する",
+			handlerStatus: http.StatusInternalServerError,
+			requests:      []request{{key: "k1", body: `{}`}, {key: "k1", body: `{}`}},
+			expected: []result{
+				{status: http.StatusInternalServerError, body: `{"error":"internal server error","code":"INTERNAL_ERROR"}`},
+				{status: http.StatusInternalServerError, body: `{"error":"internal server error","code":"INTERNAL_ERROR"}`},
+			},
+			expectedCalls: 2,
+		},
+		{
+			name:     "異常系: 同じキーで別の内容",
+			requests: []request{{key: "k1", body: `{"name":"a"}`}, {key: "k1", body: `{"name":"b"}`}},
+			expected: []result{
+				{status: http.StatusCreated, body: `{"id":1}`},
+				{status: http.StatusUnprocessableEntity, body: `{"error":"Idempotency-Key was used for a different request","code":"IDEMPOTENCY_KEY_REUSED"}`},
+			},
+			expectedCalls: 1,
+		},
+		{
+			name:     "異常系: 長すぎるキー",
+			requests: []request{{key: strings.Repeat("k", MaxKeyLength+1), body: `{}`}},
+			expected: []result{
+				{status: http.StatusBadRequest, body: `{"error":"invalid Idempotency-Key header","code":"INVALID_IDEMPOTENCY_KEY","details":["Idempotency-Key must be 255 characters or less"],"detail_codes":["VALIDATION_INVALID"]}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			e := echo.New()
+			e.HTTPErrorHandler = itemController.HTTPErrorHandler
+			e.POST("/items", func(c echo.Context) error {
+				calls++
+				switch tt.handlerStatus {
+				case http.StatusBadRequest:
+					return itemController.NewHTTPError(http.StatusBadRequest, "validation failed")
+				case http.StatusInternalServerError:
+					return errors.New("database error")
+				}
+				c.Response().Header().Set(echo.HeaderLocation, "/items/"+strconv.Itoa(calls))
+				return c.JSONBlob(http.StatusCreated, []byte(`{"id":`+strconv.Itoa(calls)+`}`))
+			}, Middleware(NewStore(time.Hour)))
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(r.body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				if r.key != "" {
+					req.Header.Set(HeaderIdempotencyKey, r.key)
+				}
+				if r.tenant != "" {
+					req.Header.Set(itemController.HeaderTenantID, r.tenant)
+				}
+				if r.user != "" {
+					req.Header.Set(itemController.HeaderUserID, r.user)
+				}
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+
+				assert.Equal(t, tt.expected[i].status, rec.Code, "request %d", i)
+				assert.JSONEq(t, tt.expected[i].body, rec.Body.String(), "request %d", i)
+				if tt.expected[i].replayed {
+					assert.Equal(t, "true", rec.Header().Get(HeaderReplayed))
+					if rec.Code == http.StatusCreated {
+						assert.Equal(t, "/items/1", rec.Header().Get(echo.HeaderLocation))
+					}
+				} else {
+					assert.Empty(t, rec.Header().Get(HeaderReplayed))
+				}
+			}
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func TestMiddleware_InProgress(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	started, finish := make(chan struct{}), make(chan struct{})
+	e.POST("/items", func(c echo.Context) error {
+		close(started)
+		<-finish
+		return c.JSONBlob(http.StatusCreated, []byte(`{"id":1}`))
+	}, Middleware(NewStore(time.Hour)))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+		req.Header.Set(HeaderIdempotencyKey, "k1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() { first <- send() }()
+	<-started
+
+	rec := send()
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"error":"a request with this Idempotency-Key is in progress","code":"IDEMPOTENCY_KEY_IN_USE"}`, rec.Body.String())
+
+	close(finish)
+	assert.Equal(t, http.StatusCreated, (<-first).Code)
+	assert.Equal(t, "true", send().Header().Get(HeaderReplayed))
+}
+
+func TestStore_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	saved, err := store.begin("k1", [32]byte{1})
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+	store.complete("k1", &response{status: http.StatusCreated})
+
+	now = now.Add(59 * time.Minute)
+	saved, err = store.begin("k1", [32]byte{1})
+	assert.NoError(t, err)
+	assert.NotNil(t, saved)
+
+	// 期限を過ぎたキーは新しいリクエストとして扱う
+	now = now.Add(2 * time.Minute)
+	saved, err = store.begin("k1", [32]byte{2})
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+}
+
+func TestStore_ExpireInOrder(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	for i, key := range []string{"k1", "k2", "k3"} {
+		_, err := store.begin(key, [32]byte{})
+		assert.NoError(t, err)
+		store.complete(key, &response{status: http.StatusCreated})
+		if i < 2 {
+			now = now.Add(10 * time.Minute)
+		}
+	}
+	// 処理中のキーは期限の対象にしない
+	_, err := store.begin("k4", [32]byte{})
+	assert.NoError(t, err)
+
+	// k1・k2 の期限だけが過ぎている
+	now = now.Add(55 * time.Minute)
+	_, err = store.begin("k5", [32]byte{})
+	assert.NoError(t, err)
+
+	assert.NotContains(t, store.entries, "k1")
+	assert.NotContains(t, store.entries, "k2")
+	assert.Contains(t, store.entries, "k3")
+	assert.Contains(t, store.entries, "k4")
+	assert.Equal(t, 1, store.expiry.Len())
+
+	// 処理中のまま破棄したキーは一覧に残らない
+	store.release("k4")
+	now = now.Add(time.Hour)
+	_, err = store.begin("k6", [32]byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k5", "k6"}, sortedKeys(store.entries))
+	assert.Zero(t, store.expiry.Len())
+}
+
+func sortedKeys(entries map[string]*entry) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
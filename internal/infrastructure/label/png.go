@@ -0,0 +1,107 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"Aicon-assignment/internal/infrastructure/qrcode"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	// QRコードの1モジュールのピクセル数と、周囲の余白のモジュール数
+	pngModule = 8
+	pngQuiet  = 4
+	// 文字の1ドットのピクセル数と、行間のドット数
+	pngDot     = 2
+	pngLeading = 3
+)
+
+type pngRenderer struct{}
+
+// 1枚のラベルを PNG で描画する（上にQRコード、下に名前とシリアル）
+func NewPNGRenderer() usecase.LabelImageRenderer {
+	return &pngRenderer{}
+}
+
+func (r *pngRenderer) RenderImage(l usecase.Label) ([]byte, error) {
+	code, err := qrcode.EncodeString(l.Code, qrcode.LevelM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	qr := code.Image(pngModule, pngQuiet)
+	width := qr.Bounds().Dx()
+
+	// フォントは ASCII のみのため、描けない文字を含む名前（日本語など）は省いてシリアルだけを載せる
+	var lines []string
+	if name := strings.TrimSpace(l.Name); name != "" && drawable(name) {
+		lines = append(lines, fitText(name, width-pngQuiet*pngModule))
+	}
+	lines = append(lines, fitText(l.Serial, width-pngQuiet*pngModule))
+
+	lineHeight := (glyphHeight + pngLeading) * pngDot
+	// 文字の下にもQRコードの余白の半分をあける
+	height := qr.Bounds().Dy() + len(lines)*lineHeight + pngQuiet*pngModule/2
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	draw.Draw(img, qr.Bounds(), qr, image.Point{}, draw.Src)
+
+	y := qr.Bounds().Dy()
+	for _, line := range lines {
+		drawText(img, (width-textWidth(line))/2, y, line)
+		y += lineHeight
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// 文字列の幅（ピクセル）
+func textWidth(text string) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return (n*(glyphWidth+1) - 1) * pngDot
+}
+
+// maxWidth ピクセルに収まらなければ末尾を "..." にして切り詰める
+func fitText(text string, maxWidth int) string {
+	if textWidth(text) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"...") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// (x, y) を左上として文字列を黒で描く
+func drawText(img *image.Gray, x, y int, text string) {
+	for _, r := range text {
+		glyph := glyphs[r]
+		for row, line := range glyph {
+			for col, dot := range line {
+				if dot != '#' {
+					continue
+				}
+				for dy := 0; dy < pngDot; dy++ {
+					for dx := 0; dx < pngDot; dx++ {
+						img.Pix[img.PixOffset(x+col*pngDot+dx, y+row*pngDot+dy)] = 0
+					}
+				}
+			}
+		}
+		x += (glyphWidth + 1) * pngDot
+	}
+}
@@ -0,0 +1,45 @@
+package label
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestPNGRenderer_RenderImage(t *testing.T) {
+	render := func(name string) (width, height int) {
+		out, err := NewPNGRenderer().RenderImage(usecase.Label{Code: "http://example.com/items/42", Name: name, Serial: "No. 42"})
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		return img.Bounds().Dx(), img.Bounds().Dy()
+	}
+
+	t.Run("正常系: QRコードの下に名前とシリアル", func(t *testing.T) {
+		width, height := render("Rolex Daytona")
+
+		// 27 バイトの URL は誤り訂正レベル M ではバージョン3（29モジュール）になる
+		assert.Equal(t, (29+2*pngQuiet)*pngModule, width)
+		assert.Equal(t, width+2*(glyphHeight+pngLeading)*pngDot+pngQuiet*pngModule/2, height)
+	})
+
+	t.Run("正常系: 描けない文字を含む名前は省く", func(t *testing.T) {
+		width, height := render("ロレックス デイトナ")
+
+		assert.Equal(t, width+(glyphHeight+pngLeading)*pngDot+pngQuiet*pngModule/2, height)
+	})
+}
+
+func TestFitText(t *testing.T) {
+	assert.Equal(t, "No. 42", fitText("No. 42", 1000))
+
+	fitted := fitText("A very long item name that does not fit", 100)
+	assert.LessOrEqual(t, textWidth(fitted), 100)
+	assert.Contains(t, fitted, "...")
+}
@@ -0,0 +1,170 @@
+// Package label はQRコード付きのラベルシートをPDFとして描画する。
+package label
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"Aicon-assignment/internal/infrastructure/pdf"
+	"Aicon-assignment/internal/infrastructure/qrcode"
+	"Aicon-assignment/internal/usecase"
+)
+
+const mm = pdf.PointsPerMM
+
+// 組み込みのラベルテンプレート
+func DefaultTemplates() []usecase.LabelTemplate {
+	return []usecase.LabelTemplate{
+		{
+			// A4 24面 (70 x 37mm)
+			Name:      "a4-3x8",
+			PageWidth: pdf.A4Width, PageHeight: pdf.A4Height,
+			Columns: 3, Rows: 8,
+			LabelWidth: 70 * mm, LabelHeight: 37 * mm,
+			MarginLeft: 0, MarginTop: 0.5 * mm,
+		},
+		{
+			// A4 14面 (99.1 x 38.1mm)
+			Name:      "a4-2x7",
+			PageWidth: pdf.A4Width, PageHeight: pdf.A4Height,
+			Columns: 2, Rows: 7,
+			LabelWidth: 99.1 * mm, LabelHeight: 38.1 * mm,
+			MarginLeft: 4.65 * mm, MarginTop: 15.15 * mm,
+			GapX: 2.5 * mm,
+		},
+		{
+			// US Letter 30面 (2.625 x 1in)
+			Name:      "letter-3x10",
+			PageWidth: pdf.LetterWidth, PageHeight: pdf.LetterHeight,
+			Columns: 3, Rows: 10,
+			LabelWidth: 189, LabelHeight: 72,
+			MarginLeft: 13.5, MarginTop: 36,
+			GapX: 9,
+		},
+	}
+}
+
+// JSONファイルから追加のテンプレートを読み込む
+func LoadTemplates(path string) ([]usecase.LabelTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label templates: %w", err)
+	}
+
+	var templates []usecase.LabelTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse label templates: %w", err)
+	}
+
+	for _, tmpl := range templates {
+		if err := validateTemplate(tmpl); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+func validateTemplate(t usecase.LabelTemplate) error {
+	if t.Name == "" {
+		return errors.New("label template name is required")
+	}
+	if t.Columns <= 0 || t.Rows <= 0 {
+		return fmt.Errorf("label template %s: columns and rows must be positive", t.Name)
+	}
+	if t.LabelWidth <= 0 || t.LabelHeight <= 0 || t.PageWidth <= 0 || t.PageHeight <= 0 {
+		return fmt.Errorf("label template %s: sizes must be positive", t.Name)
+	}
+	right := t.MarginLeft + float64(t.Columns)*t.LabelWidth + float64(t.Columns-1)*t.GapX
+	bottom := t.MarginTop + float64(t.Rows)*t.LabelHeight + float64(t.Rows-1)*t.GapY
+	if right > t.PageWidth || bottom > t.PageHeight {
+		return fmt.Errorf("label template %s: labels do not fit on the page", t.Name)
+	}
+	return nil
+}
+
+type pdfRenderer struct{}
+
+func NewPDFRenderer() usecase.LabelRenderer {
+	return &pdfRenderer{}
+}
+
+func (r *pdfRenderer) Render(tmpl usecase.LabelTemplate, labels []usecase.Label) ([]byte, error) {
+	doc := pdf.NewDocument()
+	perPage := tmpl.Columns * tmpl.Rows
+
+	var page *pdf.Page
+	for i, l := range labels {
+		if i%perPage == 0 {
+			page = doc.AddPage(tmpl.PageWidth, tmpl.PageHeight)
+		}
+
+		pos := i % perPage
+		col, row := pos%tmpl.Columns, pos/tmpl.Columns
+		x := tmpl.MarginLeft + float64(col)*(tmpl.LabelWidth+tmpl.GapX)
+		y := tmpl.PageHeight - tmpl.MarginTop - float64(row)*(tmpl.LabelHeight+tmpl.GapY) - tmpl.LabelHeight
+
+		if err := drawLabel(page, tmpl, x, y, l); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc.Bytes(), nil
+}
+
+// 左側にQRコード、右側に名前とシリアルを配置する
+func drawLabel(page *pdf.Page, tmpl usecase.LabelTemplate, x, y float64, l usecase.Label) error {
+	w, h := tmpl.LabelWidth, tmpl.LabelHeight
+	pad := min(w, h) * 0.08
+
+	if tmpl.Border {
+		page.StrokeRect(x, y, w, h, 0.25)
+	}
+
+	code, err := qrcode.EncodeString(l.Code, qrcode.LevelM)
+	if err != nil {
+		return fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	side := min(h-pad*2, w/2)
+	drawQR(page, code, x+pad, y+(h-side)/2, side)
+
+	textX := x + pad + side + pad
+	textWidth := x + w - pad - textX
+	nameSize := min(h*0.16, 10)
+	serialSize := nameSize * 0.8
+
+	page.Text(textX, y+h/2+nameSize*0.3, nameSize, pdf.Truncate(l.Name, nameSize, textWidth))
+	page.Text(textX, y+h/2-serialSize*1.5, serialSize, pdf.Truncate(l.Serial, serialSize, textWidth))
+
+	return nil
+}
+
+// side ポイント四方（周囲2モジュールの余白込み）にQRコードを描画する
+func drawQR(page *pdf.Page, code *qrcode.Code, x, y, side float64) {
+	const quiet = 2
+	module := side / float64(code.Size+quiet*2)
+
+	for row := 0; row < code.Size; row++ {
+		// 横方向に連続する黒モジュールは1つの矩形にまとめる
+		for col := 0; col < code.Size; {
+			if !code.Black(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < code.Size && code.Black(col, row) {
+				col++
+			}
+			page.FillRect(
+				x+float64(start+quiet)*module,
+				y+side-float64(row+quiet+1)*module,
+				float64(col-start)*module,
+				module,
+				0,
+			)
+		}
+	}
+}
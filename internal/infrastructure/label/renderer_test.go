@@ -0,0 +1,89 @@
+package label
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDefaultTemplates(t *testing.T) {
+	for _, tmpl := range DefaultTemplates() {
+		assert.NoError(t, validateTemplate(tmpl), tmpl.Name)
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "正常系: 有効なテンプレート",
+			content: `[{"name":"small","page_width":200,"page_height":100,"columns":2,"rows":1,"label_width":90,"label_height":50}]`,
+		},
+		{
+			name:    "異常系: 名前が空",
+			content: `[{"page_width":200,"page_height":100,"columns":1,"rows":1,"label_width":90,"label_height":50}]`,
+			wantErr: "name is required",
+		},
+		{
+			name:    "異常系: ページに収まらない",
+			content: `[{"name":"big","page_width":200,"page_height":100,"columns":3,"rows":1,"label_width":90,"label_height":50}]`,
+			wantErr: "do not fit",
+		},
+		{
+			name:    "異常系: 不正なJSON",
+			content: `{`,
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "templates.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			templates, err := LoadTemplates(path)
+
+			if tt.wantErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, templates, 1)
+		})
+	}
+}
+
+func TestPDFRenderer_Render(t *testing.T) {
+	tmpl := usecase.LabelTemplate{
+		Name:      "tiny",
+		PageWidth: 300, PageHeight: 100,
+		Columns: 2, Rows: 1,
+		LabelWidth: 150, LabelHeight: 100,
+		Border: true,
+	}
+	labels := []usecase.Label{
+		{Code: "http://localhost:8080/items/1", Name: "ロレックス デイトナ", Serial: "No. 1"},
+		{Code: "http://localhost:8080/items/2", Name: "エルメス バーキン", Serial: "No. 2"},
+		{Code: "http://localhost:8080/items/3", Name: "ティファニー ネックレス", Serial: "No. 3"},
+	}
+
+	out, err := NewPDFRenderer().Render(tmpl, labels)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	// 1ページ2面なので3枚で2ページ
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Equal(t, len(labels), strings.Count(string(out), "re S"))
+}
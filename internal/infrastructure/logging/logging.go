@@ -0,0 +1,107 @@
+// Package logging は標準の log パッケージの出力をログレベルで絞り込む。
+//
+// メッセージのレベルは先頭の記号で判定する（❌ は error、⚠️ と 🐢 は warn、それ以外は info）。
+// レベルは実行中に変更できる（設定ファイルの LOG_LEVEL の変更を再起動せずに反映する）。
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// ログレベル
+type Level int32
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// info / warn / error を読み込む
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "info":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q (use info, warn or error)", s)
+}
+
+// メッセージの先頭の記号とレベル
+var markers = []struct {
+	prefix []byte
+	level  Level
+}{
+	{prefix: []byte("❌"), level: LevelError},
+	{prefix: []byte("⚠️"), level: LevelWarn},
+	{prefix: []byte("🐢"), level: LevelWarn},
+}
+
+// Filter は設定したレベル未満のメッセージを捨てる io.Writer（log.SetOutput に渡す）
+// log.Logger は1つのメッセージを1回の Write で書き込むため、Write ごとにレベルを判定する
+type Filter struct {
+	out   io.Writer
+	level atomic.Int32
+}
+
+func NewFilter(out io.Writer, level Level) *Filter {
+	f := &Filter{out: out}
+	f.SetLevel(level)
+	return f
+}
+
+// レベルを変更する（ログの出力中でもよい）
+func (f *Filter) SetLevel(level Level) {
+	f.level.Store(int32(level))
+}
+
+func (f *Filter) Level() Level {
+	return Level(f.level.Load())
+}
+
+func (f *Filter) Write(p []byte) (int, error) {
+	if levelOf(p) < f.Level() {
+		return len(p), nil
+	}
+	return f.out.Write(p)
+}
+
+// メッセージのレベルを返す。log が付ける日時（数字で始まる）を読み飛ばし、メッセージの最初の語で判定する
+func levelOf(p []byte) Level {
+	for _, field := range bytes.Fields(firstLine(p)) {
+		if field[0] >= '0' && field[0] <= '9' {
+			continue
+		}
+		for _, m := range markers {
+			if bytes.HasPrefix(field, m.prefix) {
+				return m.level
+			}
+		}
+		break
+	}
+	return LevelInfo
+}
+
+func firstLine(p []byte) []byte {
+	if i := bytes.IndexByte(p, '\n'); i >= 0 {
+		return p[:i]
+	}
+	return p
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+	require.NoError(t, err)
+	assert.Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("debug")
+	assert.Error(t, err)
+}
+
+func TestFilter(t *testing.T) {
+	var out bytes.Buffer
+	filter := NewFilter(&out, LevelWarn)
+	logger := log.New(filter, "", log.LstdFlags)
+
+	logger.Printf("retention: purged 3 items and 0 revisions")
+	logger.Printf("⚠️  outbox: failed to relay item events: %v", "db is down")
+	logger.Printf("🐢 slow query (300ms): SELECT 1")
+	logger.Printf("reminder: failed with ❌ in the message")
+	logger.Printf("❌ 500 GET /items: boom\ngoroutine 1 [running]:")
+
+	output := out.String()
+	assert.NotContains(t, output, "retention")
+	assert.NotContains(t, output, "reminder")
+	assert.Contains(t, output, "⚠️  outbox")
+	assert.Contains(t, output, "🐢 slow query")
+	assert.Contains(t, output, "❌ 500 GET /items: boom\ngoroutine 1 [running]:")
+
+	t.Run("正常系: 実行中にレベルを変更できる", func(t *testing.T) {
+		out.Reset()
+
+		filter.SetLevel(LevelError)
+		logger.Printf("⚠️  outbox: failed to relay item events")
+		filter.SetLevel(LevelInfo)
+		logger.Printf("retention: purged 1 items and 0 revisions")
+
+		assert.Equal(t, LevelInfo, filter.Level())
+		assert.NotContains(t, out.String(), "outbox")
+		assert.Contains(t, out.String(), "retention")
+	})
+}
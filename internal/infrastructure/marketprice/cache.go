@@ -0,0 +1,99 @@
+package marketprice
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 提供元が使えないときに返してよい、期限切れのキャッシュの古さの上限
+const maxStaleness = 24 * time.Hour
+
+var (
+	cacheHitCount    = expvar.NewInt("market_price_cache_hits")
+	cacheMissCount   = expvar.NewInt("market_price_cache_misses")
+	staleServedCount = expvar.NewInt("market_price_stale_served")
+)
+
+type cacheEntry struct {
+	quote     *usecase.MarketQuote
+	fetchedAt time.Time
+}
+
+// 提供元の結果をブランド・名前・カテゴリーごとに ttl の間キャッシュする
+// 同じ型番の問い合わせで API の呼び出し回数（多くは従量課金）を抑え、
+// 提供元が障害やタイムアウトで使えないときは maxStaleness 以内の古い結果を返す。
+type CachingProvider struct {
+	inner usecase.MarketPriceProvider
+	ttl   time.Duration
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func NewCachingProvider(inner usecase.MarketPriceProvider, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{
+		inner:   inner,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (p *CachingProvider) Quote(ctx context.Context, item *entity.Item) (*usecase.MarketQuote, error) {
+	key := cacheKey(item)
+	now := p.now()
+
+	p.mu.Lock()
+	entry, ok := p.entries[key]
+	p.mu.Unlock()
+
+	if ok && now.Sub(entry.fetchedAt) < p.ttl {
+		cacheHitCount.Add(1)
+		return copyQuote(entry.quote), nil
+	}
+	cacheMissCount.Add(1)
+
+	quote, err := p.inner.Quote(ctx, item)
+	if err != nil {
+		if ok && domainErrors.IsPriceProviderError(err) && now.Sub(entry.fetchedAt) < maxStaleness {
+			staleServedCount.Add(1)
+			log.Printf("⚠️  market price provider failed, serving cached price from %s: %v", entry.fetchedAt.Format(time.RFC3339), err)
+			return copyQuote(entry.quote), nil
+		}
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.entries[key] = cacheEntry{quote: copyQuote(quote), fetchedAt: now}
+	p.evictLocked(now)
+	p.mu.Unlock()
+
+	return quote, nil
+}
+
+// 古くなって使えないエントリを削除する
+func (p *CachingProvider) evictLocked(now time.Time) {
+	for key, entry := range p.entries {
+		if now.Sub(entry.fetchedAt) >= maxStaleness && now.Sub(entry.fetchedAt) >= p.ttl {
+			delete(p.entries, key)
+		}
+	}
+}
+
+func cacheKey(item *entity.Item) string {
+	return strings.ToLower(strings.Join([]string{item.Brand, item.Name, item.Category}, "|"))
+}
+
+func copyQuote(quote *usecase.MarketQuote) *usecase.MarketQuote {
+	copied := *quote
+	return &copied
+}
@@ -0,0 +1,123 @@
+package marketprice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const defaultCurrency = "JPY"
+
+// 相場 API（Chrono24 のような出品・取引価格の集計 API）から時価を取得する提供元
+//
+//	GET {baseURL}/prices?brand=...&name=...&category=...&currency=JPY
+//	Authorization: Bearer {apiKey}
+//
+// 応答: {"median_price": 1250000, "currency": "JPY", "listings": 42, "as_of": "2024-03-09T00:00:00Z"}
+type HTTPProvider struct {
+	baseURL string
+	apiKey  string
+	source  string
+	client  *http.Client
+}
+
+func NewHTTPProvider(baseURL, apiKey, source string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		source:  source,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type priceResponse struct {
+	MedianPrice float64   `json:"median_price"`
+	Currency    string    `json:"currency"`
+	Listings    int       `json:"listings"`
+	AsOf        time.Time `json:"as_of"`
+}
+
+func (p *HTTPProvider) Quote(ctx context.Context, item *entity.Item) (*usecase.MarketQuote, error) {
+	query := url.Values{}
+	query.Set("brand", item.Brand)
+	query.Set("name", item.Name)
+	query.Set("category", item.Category)
+	query.Set("currency", defaultCurrency)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/prices?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderUnavailable, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		if isTimeout(err) {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, domainErrors.ErrMarketPriceNotFound
+	case resp.StatusCode != http.StatusOK:
+		// 429 や 5xx を含め、提供元を使えない状態として扱う
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("%w: provider responded %s", domainErrors.ErrPriceProviderUnavailable, resp.Status)
+	}
+
+	var body priceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if isTimeout(err) {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: invalid response: %w", domainErrors.ErrPriceProviderUnavailable, err)
+	}
+	// 該当する出品・取引がなければ相場なし
+	if body.Listings <= 0 || body.MedianPrice <= 0 {
+		return nil, domainErrors.ErrMarketPriceNotFound
+	}
+
+	currency := body.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	return &usecase.MarketQuote{
+		Value:      int(body.MedianPrice + 0.5),
+		Currency:   currency,
+		Source:     p.source,
+		SampleSize: body.Listings,
+		QuotedAt:   body.AsOf,
+	}, nil
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr interface{ Timeout() bool }
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// 相場 API が設定されていない場合の提供元
+type DisabledProvider struct{}
+
+func (DisabledProvider) Quote(ctx context.Context, item *entity.Item) (*usecase.MarketQuote, error) {
+	return nil, fmt.Errorf("%w: no market price provider is configured", domainErrors.ErrPriceProviderUnavailable)
+}
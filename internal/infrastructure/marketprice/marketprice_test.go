@@ -0,0 +1,216 @@
+package marketprice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var testItem = &entity.Item{ID: 1, Name: "Submariner 126610LN", Category: "時計", Brand: "ROLEX"}
+
+func TestHTTPProvider_Quote(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		delay         time.Duration
+		expectedQuote *usecase.MarketQuote
+		expectedErr   error
+	}{
+		{
+			name:   "正常系: 中央値を評価額にする",
+			status: http.StatusOK,
+			body:   `{"median_price": 1249999.6, "currency": "JPY", "listings": 42, "as_of": "2024-03-09T00:00:00Z"}`,
+			expectedQuote: &usecase.MarketQuote{
+				Value: 1250000, Currency: "JPY", Source: "chrono24", SampleSize: 42,
+				QuotedAt: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "異常系: 404は相場なし",
+			status:      http.StatusNotFound,
+			expectedErr: domainErrors.ErrMarketPriceNotFound,
+		},
+		{
+			name:        "異常系: 出品がなければ相場なし",
+			status:      http.StatusOK,
+			body:        `{"median_price": 0, "currency": "JPY", "listings": 0}`,
+			expectedErr: domainErrors.ErrMarketPriceNotFound,
+		},
+		{
+			name:        "異常系: レート制限",
+			status:      http.StatusTooManyRequests,
+			expectedErr: domainErrors.ErrPriceProviderUnavailable,
+		},
+		{
+			name:        "異常系: 提供元の障害",
+			status:      http.StatusBadGateway,
+			expectedErr: domainErrors.ErrPriceProviderUnavailable,
+		},
+		{
+			name:        "異常系: 不正な応答",
+			status:      http.StatusOK,
+			body:        `<html>`,
+			expectedErr: domainErrors.ErrPriceProviderUnavailable,
+		},
+		{
+			name:        "異常系: タイムアウト",
+			status:      http.StatusOK,
+			body:        `{}`,
+			delay:       200 * time.Millisecond,
+			expectedErr: domainErrors.ErrPriceProviderTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				if tt.delay > 0 {
+					select {
+					case <-time.After(tt.delay):
+					case <-r.Context().Done():
+						return
+					}
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := NewHTTPProvider(server.URL+"/", "secret", "chrono24", 50*time.Millisecond)
+			quote, err := provider.Quote(context.Background(), testItem)
+
+			require.NotNil(t, received)
+			assert.Equal(t, "/prices", received.URL.Path)
+			assert.Equal(t, "ROLEX", received.URL.Query().Get("brand"))
+			assert.Equal(t, "Submariner 126610LN", received.URL.Query().Get("name"))
+			assert.Equal(t, "時計", received.URL.Query().Get("category"))
+			assert.Equal(t, "Bearer secret", received.Header.Get("Authorization"))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, quote)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedQuote, quote)
+		})
+	}
+
+	t.Run("異常系: 接続できない", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		quote, err := NewHTTPProvider(server.URL, "", "chrono24", time.Second).Quote(context.Background(), testItem)
+
+		assert.ErrorIs(t, err, domainErrors.ErrPriceProviderUnavailable)
+		assert.Nil(t, quote)
+	})
+}
+
+type stubProvider struct {
+	calls int
+	quote *usecase.MarketQuote
+	err   error
+}
+
+func (p *stubProvider) Quote(ctx context.Context, item *entity.Item) (*usecase.MarketQuote, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.quote, nil
+}
+
+func TestCachingProvider_Quote(t *testing.T) {
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	quote := &usecase.MarketQuote{Value: 1250000, Currency: "JPY", Source: "chrono24"}
+
+	newProvider := func(inner usecase.MarketPriceProvider, now *time.Time) *CachingProvider {
+		p := NewCachingProvider(inner, time.Hour)
+		p.now = func() time.Time { return *now }
+		return p
+	}
+
+	t.Run("正常系: TTL の間はキャッシュを返す", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{quote: quote}
+		p := newProvider(inner, &now)
+
+		_, err := p.Quote(context.Background(), testItem)
+		require.NoError(t, err)
+		now = now.Add(59 * time.Minute)
+		cached, err := p.Quote(context.Background(), &entity.Item{Name: "submariner 126610ln", Category: "時計", Brand: "rolex"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, inner.calls)
+		assert.Equal(t, quote, cached)
+
+		now = now.Add(time.Minute)
+		_, err = p.Quote(context.Background(), testItem)
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("正常系: 提供元の障害時は期限切れのキャッシュを返す", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{quote: quote}
+		p := newProvider(inner, &now)
+		_, err := p.Quote(context.Background(), testItem)
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Hour)
+		inner.err = domainErrors.ErrPriceProviderTimeout
+		stale, err := p.Quote(context.Background(), testItem)
+
+		require.NoError(t, err)
+		assert.Equal(t, quote, stale)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("異常系: 古すぎるキャッシュは返さない", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{quote: quote}
+		p := newProvider(inner, &now)
+		_, err := p.Quote(context.Background(), testItem)
+		require.NoError(t, err)
+
+		now = now.Add(25 * time.Hour)
+		inner.err = domainErrors.ErrPriceProviderUnavailable
+		stale, err := p.Quote(context.Background(), testItem)
+
+		assert.ErrorIs(t, err, domainErrors.ErrPriceProviderUnavailable)
+		assert.Nil(t, stale)
+	})
+
+	t.Run("異常系: 相場なしはキャッシュしない", func(t *testing.T) {
+		now := start
+		inner := &stubProvider{err: domainErrors.ErrMarketPriceNotFound}
+		p := newProvider(inner, &now)
+
+		for i := 0; i < 2; i++ {
+			_, err := p.Quote(context.Background(), testItem)
+			assert.ErrorIs(t, err, domainErrors.ErrMarketPriceNotFound)
+		}
+		assert.Equal(t, 2, inner.calls)
+	})
+}
+
+func TestDisabledProvider_Quote(t *testing.T) {
+	quote, err := DisabledProvider{}.Quote(context.Background(), testItem)
+
+	assert.True(t, errors.Is(err, domainErrors.ErrPriceProviderUnavailable))
+	assert.Nil(t, quote)
+}
@@ -0,0 +1,85 @@
+package mediastore
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// 保存してからこの時間が経っていないファイルは削除しない（アップロード中で、画像がまだコミットされていないことがある）
+	orphanGracePeriod = time.Hour
+	// 1回の削除の期限
+	cleanupTimeout = 10 * time.Minute
+)
+
+// 保存先のファイルのうち、どの記録からも参照されないものを削除する（usecase.ImageUsecase / DocumentUsecase）
+type OrphanedFileDeleter interface {
+	DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error)
+}
+
+// Cleaner は定期的に、削除されたアイテムの画像・書類などどの記録からも参照されないファイルを削除する
+type Cleaner struct {
+	deleters []OrphanedFileDeleter
+	interval time.Duration
+	now      func() time.Time
+	logf     func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewCleaner(interval time.Duration, deleters ...OrphanedFileDeleter) *Cleaner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Cleaner{
+		deleters: deleters,
+		interval: interval,
+		now:      time.Now,
+		logf:     log.Printf,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// 削除を開始する。Close まで戻らないので goroutine で呼び出す
+func (c *Cleaner) Run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.clean()
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// 削除を停止し、Run が終わるまで待つ
+func (c *Cleaner) Close() {
+	c.once.Do(c.cancel)
+	<-c.done
+}
+
+func (c *Cleaner) clean() {
+	ctx, cancel := context.WithTimeout(c.ctx, cleanupTimeout)
+	defer cancel()
+
+	modifiedBefore := c.now().Add(-orphanGracePeriod)
+	for _, deleter := range c.deleters {
+		// 1つの保存先の失敗で他の保存先の削除を止めない
+		deleted, err := deleter.DeleteOrphanedFiles(ctx, modifiedBefore)
+		if deleted > 0 {
+			c.logf("mediastore: deleted %d orphaned files", deleted)
+		}
+		if err != nil {
+			c.logf("⚠️  mediastore: failed to delete orphaned files: %v", err)
+		}
+	}
+}
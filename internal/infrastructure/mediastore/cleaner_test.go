@@ -0,0 +1,79 @@
+package mediastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDeleter は孤立したファイルの削除の呼び出しを記録する
+type fakeDeleter struct {
+	before  []time.Time
+	deleted int
+	err     error
+}
+
+func (u *fakeDeleter) DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	u.before = append(u.before, modifiedBefore)
+	return u.deleted, u.err
+}
+
+func TestCleaner_Clean(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		deleted      int
+		err          error
+		expectedLogs []string
+	}{
+		{name: "正常系: 猶予期間より古いファイルを削除する", deleted: 2, expectedLogs: []string{"mediastore: deleted 2 orphaned files"}},
+		{name: "正常系: 削除するファイルがなければ記録しない", deleted: 0},
+		{
+			name: "異常系: 失敗を記録する", deleted: 1, err: errors.New("access denied"),
+			expectedLogs: []string{
+				"mediastore: deleted 1 orphaned files",
+				"⚠️  mediastore: failed to delete orphaned files: access denied",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := &fakeDeleter{deleted: tt.deleted, err: tt.err}
+			cleaner := NewCleaner(time.Hour, images)
+			cleaner.now = func() time.Time { return now }
+			var logs []string
+			cleaner.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+
+			cleaner.clean()
+
+			assert.Equal(t, []time.Time{now.Add(-orphanGracePeriod)}, images.before)
+			assert.Equal(t, tt.expectedLogs, logs)
+		})
+	}
+}
+
+func TestCleaner_CleanEachStorage(t *testing.T) {
+	images := &fakeDeleter{err: errors.New("access denied")}
+	documents := &fakeDeleter{deleted: 1}
+	cleaner := NewCleaner(time.Hour, images, documents)
+	cleaner.logf = func(format string, args ...interface{}) {}
+
+	cleaner.clean()
+
+	assert.Len(t, images.before, 1)
+	assert.Len(t, documents.before, 1, "1つの保存先の失敗で他の保存先を止めない")
+}
+
+func TestCleaner_Close(t *testing.T) {
+	cleaner := NewCleaner(time.Hour, &fakeDeleter{})
+	go cleaner.Run()
+
+	cleaner.Close()
+	cleaner.Close()
+}
@@ -0,0 +1,98 @@
+//go:build gcs
+
+package mediastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GCS のバケットにファイルを保存する
+type gcsStorage struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// 認証情報はアプリケーションのデフォルト認証情報（GOOGLE_APPLICATION_CREDENTIALS、サービスアカウントなど）から取得する
+func newGCSStorage(ctx context.Context, cfg Config) (usecase.Storage, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &gcsStorage{
+		bucket: client.Bucket(cfg.Bucket),
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+// Writer はチャンクごとに送信する（再開可能なアップロード）ため、ファイル全体をメモリに溜めない
+func (s *gcsStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	// 書き込みに失敗したときは、Close せずにコンテキストを取り消してアップロードを中止する
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := s.bucket.Object(s.prefix + key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := io.Copy(w, r); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func (s *gcsStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	reader, err := s.bucket.Object(s.prefix + key).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, fmt.Errorf("media file %s: %w", key, domainErrors.ErrNotFound)
+		}
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (s *gcsStorage) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := s.bucket.Object(s.prefix + key).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *gcsStorage) List(ctx context.Context, fn func(usecase.StoredObject) error) error {
+	objects := s.bucket.Objects(ctx, &storage.Query{Prefix: s.prefix})
+	for {
+		attrs, err := objects.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		key := strings.TrimPrefix(attrs.Name, s.prefix)
+		// 接頭辞の下の「ディレクトリ」にあるオブジェクトはこのストレージのものではない
+		if checkKey(key) != nil {
+			continue
+		}
+		if err := fn(usecase.StoredObject{Key: key, ModifiedAt: attrs.Updated}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,184 @@
+//go:build gcs
+
+package mediastore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeGCS は GCS の JSON API（一覧・アップロード・削除）と XML API（読み出し）のうち、ドライバーが使う操作だけをメモリ上で実装する
+type fakeGCS struct {
+	mu          sync.Mutex
+	objects     map[string][]byte
+	contentType map[string]string
+}
+
+type gcsObject struct {
+	Bucket      string `json:"bucket"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        string `json:"size"`
+	Updated     string `json:"updated"`
+}
+
+func (f *fakeGCS) object(bucket, name string) gcsObject {
+	return gcsObject{
+		Bucket:      bucket,
+		Name:        name,
+		ContentType: f.contentType[name],
+		Size:        strconv.Itoa(len(f.objects[name])),
+		Updated:     "2024-03-10T09:00:00.000Z",
+	}
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := r.URL.EscapedPath()
+	switch {
+	case r.Method == http.MethodPost && strings.HasPrefix(path, "/upload/storage/v1/b/"):
+		bucket := strings.TrimSuffix(strings.TrimPrefix(path, "/upload/storage/v1/b/"), "/o")
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		parts := multipart.NewReader(r.Body, params["boundary"])
+		metaPart, err := parts.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var meta gcsObject
+		if err := json.NewDecoder(metaPart).Decode(&meta); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dataPart, err := parts.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(dataPart)
+		f.objects[meta.Name] = data
+		f.contentType[meta.Name] = meta.ContentType
+		writeJSON(w, f.object(bucket, meta.Name))
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/storage/v1/b/") && strings.HasSuffix(path, "/o"):
+		bucket := strings.TrimSuffix(strings.TrimPrefix(path, "/storage/v1/b/"), "/o")
+		prefix := r.URL.Query().Get("prefix")
+		names := make([]string, 0, len(f.objects))
+		for name := range f.objects {
+			if strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		items := make([]gcsObject, 0, len(names))
+		for _, name := range names {
+			items = append(items, f.object(bucket, name))
+		}
+		writeJSON(w, map[string]any{"kind": "storage#objects", "items": items})
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/storage/v1/b/"):
+		_, escaped, _ := strings.Cut(strings.TrimPrefix(path, "/storage/v1/b/"), "/o/")
+		name, _ := url.PathUnescape(escaped)
+		if _, ok := f.objects[name]; !ok {
+			http.Error(w, `{"error": {"code": 404, "message": "No such object"}}`, http.StatusNotFound)
+			return
+		}
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		// XML API: /バケット/オブジェクト名
+		_, escaped, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+		name, _ := url.PathUnescape(escaped)
+		data, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.contentType[name])
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestGCSStorage(t *testing.T) {
+	fake := &fakeGCS{objects: map[string][]byte{}, contentType: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	// エミュレーターを指定すると、クライアントは認証なしでそのホストに接続する
+	t.Setenv("STORAGE_EMULATOR_HOST", server.URL)
+
+	ctx := context.Background()
+	storage, err := New(ctx, Config{Driver: DriverGCS, Bucket: "media", Prefix: "item-images/"})
+	require.NoError(t, err)
+
+	t.Run("正常系: 接頭辞を付けて保存し、読み出して削除する", func(t *testing.T) {
+		require.NoError(t, storage.Put(ctx, "abc.png", strings.NewReader("png data"), 8, "image/png"))
+		assert.Equal(t, "png data", string(fake.objects["item-images/abc.png"]))
+		assert.Equal(t, "image/png", fake.contentType["item-images/abc.png"])
+
+		file, err := storage.Open(ctx, "abc.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		assert.Equal(t, "png data", string(data))
+
+		require.NoError(t, storage.Delete(ctx, "abc.png"))
+		_, err = storage.Open(ctx, "abc.png")
+		assert.ErrorIs(t, err, domainErrors.ErrNotFound)
+
+		// 既に無いファイルの削除は成功とする
+		assert.NoError(t, storage.Delete(ctx, "abc.png"))
+	})
+
+	t.Run("正常系: 接頭辞の下のオブジェクトだけを一覧にする", func(t *testing.T) {
+		fake.objects["item-images/a.png"] = []byte("a")
+		fake.objects["item-images/thumbs/a.png"] = []byte("a")
+		fake.objects["item-documents/a.pdf"] = []byte("a")
+
+		var objects []usecase.StoredObject
+		require.NoError(t, storage.List(ctx, func(object usecase.StoredObject) error {
+			objects = append(objects, object)
+			return nil
+		}))
+
+		require.Len(t, objects, 1)
+		assert.Equal(t, "a.png", objects[0].Key)
+		assert.True(t, time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC).Equal(objects[0].ModifiedAt))
+	})
+
+	t.Run("異常系: バケットの外を指すキー", func(t *testing.T) {
+		assert.Error(t, storage.Put(ctx, "../secret", strings.NewReader("x"), 1, "image/png"))
+		_, err := storage.Open(ctx, "a/b.png")
+		assert.Error(t, err)
+	})
+}
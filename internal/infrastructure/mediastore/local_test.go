@@ -0,0 +1,88 @@
+package mediastore
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir() + "/images"
+	storage, err := NewLocalStorage(dir)
+	require.NoError(t, err)
+
+	t.Run("正常系: 保存したファイルを読み出して削除する", func(t *testing.T) {
+		require.NoError(t, storage.Put(ctx, "abc.png", strings.NewReader("png data"), 8, "image/png"))
+
+		file, err := storage.Open(ctx, "abc.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		assert.Equal(t, "png data", string(data))
+
+		require.NoError(t, storage.Delete(ctx, "abc.png"))
+		_, err = storage.Open(ctx, "abc.png")
+		assert.ErrorIs(t, err, domainErrors.ErrNotFound)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries, "一時ファイルを残さない")
+	})
+
+	t.Run("正常系: 一時ファイルを除いて一覧を返す", func(t *testing.T) {
+		dir := t.TempDir()
+		storage, err := NewLocalStorage(dir)
+		require.NoError(t, err)
+		require.NoError(t, storage.Put(ctx, "a.png", strings.NewReader("a"), 1, "image/png"))
+		require.NoError(t, os.WriteFile(dir+"/.upload-123", []byte("partial"), 0o644))
+		modified := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+		require.NoError(t, os.Chtimes(dir+"/a.png", modified, modified))
+
+		var objects []usecase.StoredObject
+		require.NoError(t, storage.List(ctx, func(object usecase.StoredObject) error {
+			objects = append(objects, object)
+			return nil
+		}))
+
+		require.Len(t, objects, 1)
+		assert.Equal(t, "a.png", objects[0].Key)
+		assert.True(t, modified.Equal(objects[0].ModifiedAt))
+	})
+
+	t.Run("正常系: ないファイルの削除はエラーにしない", func(t *testing.T) {
+		assert.NoError(t, storage.Delete(ctx, "missing.jpg"))
+	})
+
+	t.Run("異常系: ディレクトリの外を指すキー", func(t *testing.T) {
+		for _, key := range []string{"", "../secret", "a/b.png", `a\b.png`, ".hidden"} {
+			assert.Error(t, storage.Put(ctx, key, strings.NewReader("x"), 1, "image/png"), key)
+			_, err := storage.Open(ctx, key)
+			assert.Error(t, err, key)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := New(ctx, Config{Driver: DriverLocal, Dir: t.TempDir()})
+	assert.NoError(t, err)
+	assert.IsType(t, &LocalStorage{}, storage)
+
+	_, err = New(ctx, Config{Driver: DriverS3})
+	assert.EqualError(t, err, "MEDIA_BUCKET is required for s3")
+
+	_, err = New(ctx, Config{Driver: "azure"})
+	assert.EqualError(t, err, "unsupported MEDIA_STORAGE: azure (supported: local, s3, gcs)")
+}
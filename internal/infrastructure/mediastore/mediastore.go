@@ -0,0 +1,59 @@
+// Package mediastore はアイテム画像などのファイルの保存先（ローカルディスク / S3 / GCS）を提供する。
+//
+// S3 と GCS のドライバーはビルドタグを付けた場合のみ組み込まれる:
+//
+//	go build -tags s3 -o main ./cmd
+//	go build -tags gcs -o main ./cmd
+package mediastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// ドライバー
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+	DriverGCS   = "gcs"
+)
+
+type Config struct {
+	Driver   string
+	Dir      string // local: 保存先のディレクトリ
+	Bucket   string // s3 / gcs: バケット名
+	Prefix   string // s3 / gcs: オブジェクト名の接頭辞（例: item-images/）
+	Region   string // s3: リージョン（未設定なら AWS の設定から取得する）
+	Endpoint string // s3: S3 互換ストレージ（MinIO など）のエンドポイント
+}
+
+// ドライバーに応じたストレージを作成する。認証情報は各クラウドの標準の方法（環境変数、ロールなど）で取得する
+func New(ctx context.Context, cfg Config) (usecase.Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(cfg.Dir)
+	case DriverS3:
+		if cfg.Bucket == "" {
+			return nil, fmt.Errorf("MEDIA_BUCKET is required for s3")
+		}
+		return newS3Storage(ctx, cfg)
+	case DriverGCS:
+		if cfg.Bucket == "" {
+			return nil, fmt.Errorf("MEDIA_BUCKET is required for gcs")
+		}
+		return newGCSStorage(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported MEDIA_STORAGE: %s (supported: local, s3, gcs)", cfg.Driver)
+	}
+}
+
+// キーは区切り文字を含まない名前に限る（ローカルではディレクトリの外、バケットでは接頭辞の外を指せないようにする）
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return fmt.Errorf("invalid media key: %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+//go:build s3
+
+package mediastore
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeS3 はパス形式（/バケット/キー）の S3 API のうち、ドライバーが使う操作だけをメモリ上で実装する
+type fakeS3 struct {
+	mu          sync.Mutex
+	objects     map[string][]byte
+	contentType map[string]string
+}
+
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Name     string
+	Prefix   string
+	KeyCount int
+	Contents []listedObject
+}
+
+type listedObject struct {
+	Key          string
+	LastModified string
+	Size         int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		prefix := r.URL.Query().Get("prefix")
+		result := listBucketResult{Name: bucket, Prefix: prefix}
+		keys := make([]string, 0, len(f.objects))
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result.Contents = append(result.Contents, listedObject{Key: k, LastModified: "2024-03-10T09:00:00.000Z", Size: len(f.objects[k])})
+		}
+		result.KeyCount = len(result.Contents)
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		f.contentType[key] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		_, _ = w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, contentType: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CONFIG_FILE", t.TempDir()+"/config")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", t.TempDir()+"/credentials")
+	// 偽のサーバーはチェックサム付きのチャンク形式を解釈しない
+	t.Setenv("AWS_REQUEST_CHECKSUM_CALCULATION", "when_required")
+	t.Setenv("AWS_RESPONSE_CHECKSUM_VALIDATION", "when_required")
+
+	ctx := context.Background()
+	storage, err := New(ctx, Config{Driver: DriverS3, Bucket: "media", Prefix: "item-images/", Region: "ap-northeast-1", Endpoint: server.URL})
+	require.NoError(t, err)
+
+	t.Run("正常系: 接頭辞を付けて保存し、読み出して削除する", func(t *testing.T) {
+		require.NoError(t, storage.Put(ctx, "abc.png", strings.NewReader("png data"), 8, "image/png"))
+		assert.Equal(t, "png data", string(fake.objects["item-images/abc.png"]))
+		assert.Equal(t, "image/png", fake.contentType["item-images/abc.png"])
+
+		file, err := storage.Open(ctx, "abc.png")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		assert.Equal(t, "png data", string(data))
+
+		require.NoError(t, storage.Delete(ctx, "abc.png"))
+		_, err = storage.Open(ctx, "abc.png")
+		assert.ErrorIs(t, err, domainErrors.ErrNotFound)
+	})
+
+	t.Run("正常系: 接頭辞の下のオブジェクトだけを一覧にする", func(t *testing.T) {
+		fake.objects["item-images/a.png"] = []byte("a")
+		fake.objects["item-images/thumbs/a.png"] = []byte("a")
+		fake.objects["item-documents/a.pdf"] = []byte("a")
+
+		var objects []usecase.StoredObject
+		require.NoError(t, storage.List(ctx, func(object usecase.StoredObject) error {
+			objects = append(objects, object)
+			return nil
+		}))
+
+		require.Len(t, objects, 1)
+		assert.Equal(t, "a.png", objects[0].Key)
+		assert.True(t, time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC).Equal(objects[0].ModifiedAt))
+	})
+
+	t.Run("異常系: バケットの外を指すキー", func(t *testing.T) {
+		assert.Error(t, storage.Put(ctx, "../secret", strings.NewReader("x"), 1, "image/png"))
+		_, err := storage.Open(ctx, "a/b.png")
+		assert.Error(t, err)
+	})
+}
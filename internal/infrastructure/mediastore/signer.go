@@ -0,0 +1,75 @@
+package mediastore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var ErrInvalidToken = errors.New("mediastore: invalid token")
+
+var tokenEncoding = base64.RawURLEncoding
+
+// URLSigner は画像・書類のファイルの URL（GET /media/{token}）のトークンに署名・検証する（usecase.MediaTokenSigner の実装）。
+//
+// 形式: base64url("<image か document>.<アイテムID>.<画像・書類のID>.<期限のUNIX秒>") "." base64url(HMAC-SHA256)
+// トークンはデータベースに保存しない。シークレットを変えると発行済みの URL はすべて無効になる。
+type URLSigner struct {
+	secret []byte
+}
+
+func NewURLSigner(secret []byte) *URLSigner {
+	return &URLSigner{secret: secret}
+}
+
+func (s *URLSigner) Sign(claims usecase.MediaClaims) string {
+	payload := tokenEncoding.EncodeToString([]byte(fmt.Sprintf("%s.%d.%d.%d", claims.Kind, claims.ItemID, claims.ID, claims.ExpiresAt.Unix())))
+	return payload + "." + tokenEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify は署名を確かめて内容を返す。期限切れかどうかは呼び出し側が判断する
+func (s *URLSigner) Verify(token string) (usecase.MediaClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return usecase.MediaClaims{}, ErrInvalidToken
+	}
+	mac, err := tokenEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return usecase.MediaClaims{}, ErrInvalidToken
+	}
+
+	decoded, err := tokenEncoding.DecodeString(payload)
+	if err != nil {
+		return usecase.MediaClaims{}, ErrInvalidToken
+	}
+	fields := strings.Split(string(decoded), ".")
+	if len(fields) != 4 || (fields[0] != usecase.MediaImage && fields[0] != usecase.MediaDocument) {
+		return usecase.MediaClaims{}, ErrInvalidToken
+	}
+	var ids [3]int64
+	for i, field := range fields[1:] {
+		if ids[i], err = strconv.ParseInt(field, 10, 64); err != nil {
+			return usecase.MediaClaims{}, ErrInvalidToken
+		}
+	}
+
+	return usecase.MediaClaims{
+		Kind:      fields[0],
+		ItemID:    ids[0],
+		ID:        ids[1],
+		ExpiresAt: time.Unix(ids[2], 0).UTC(),
+	}, nil
+}
+
+func (s *URLSigner) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
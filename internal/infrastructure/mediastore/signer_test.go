@@ -0,0 +1,57 @@
+package mediastore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestURLSigner(t *testing.T) {
+	signer := NewURLSigner([]byte("media-secret"))
+	claims := usecase.MediaClaims{Kind: usecase.MediaImage, ItemID: 42, ID: 7, ExpiresAt: time.Date(2024, 3, 17, 9, 0, 0, 0, time.UTC)}
+	token := signer.Sign(claims)
+
+	t.Run("正常系: 署名したトークンを検証する", func(t *testing.T) {
+		got, err := signer.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, claims, got)
+	})
+
+	t.Run("正常系: 書類のトークン", func(t *testing.T) {
+		document := claims
+		document.Kind = usecase.MediaDocument
+		got, err := signer.Verify(signer.Sign(document))
+		require.NoError(t, err)
+		assert.Equal(t, document, got)
+	})
+
+	t.Run("異常系: 別のシークレットで署名したトークン", func(t *testing.T) {
+		_, err := NewURLSigner([]byte("other-secret")).Verify(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 内容を書き換えたトークン", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(token, ".")
+		forged := tokenEncoding.EncodeToString([]byte("document.42.7.1710666000")) + "." + signature
+		assert.NotEqual(t, payload, forged)
+		_, err := signer.Verify(forged)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 種類が不正", func(t *testing.T) {
+		_, err := signer.Verify(signer.Sign(usecase.MediaClaims{Kind: "backup", ItemID: 42, ID: 7, ExpiresAt: claims.ExpiresAt}))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 形式が不正", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "." + strings.Repeat("A", 43)} {
+			_, err := signer.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
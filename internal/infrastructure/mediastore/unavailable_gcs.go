@@ -0,0 +1,15 @@
+//go:build !gcs
+
+package mediastore
+
+import (
+	"context"
+	"errors"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// GCS のドライバーは gcs ビルドタグを付けた場合のみ組み込まれる
+func newGCSStorage(ctx context.Context, cfg Config) (usecase.Storage, error) {
+	return nil, errors.New("gcs support is not compiled in: build with -tags gcs")
+}
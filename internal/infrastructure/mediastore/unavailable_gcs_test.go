@@ -0,0 +1,16 @@
+//go:build !gcs
+
+package mediastore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithoutGCSDriver(t *testing.T) {
+	// gcs ビルドタグなしではドライバーが組み込まれていない
+	_, err := New(context.Background(), Config{Driver: DriverGCS, Bucket: "media"})
+	assert.ErrorContains(t, err, "-tags gcs")
+}
@@ -0,0 +1,15 @@
+//go:build !s3
+
+package mediastore
+
+import (
+	"context"
+	"errors"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// S3 のドライバーは s3 ビルドタグを付けた場合のみ組み込まれる
+func newS3Storage(ctx context.Context, cfg Config) (usecase.Storage, error) {
+	return nil, errors.New("s3 support is not compiled in: build with -tags s3")
+}
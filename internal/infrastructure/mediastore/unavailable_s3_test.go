@@ -0,0 +1,16 @@
+//go:build !s3
+
+package mediastore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithoutS3Driver(t *testing.T) {
+	// s3 ビルドタグなしではドライバーが組み込まれていない
+	_, err := New(context.Background(), Config{Driver: DriverS3, Bucket: "media"})
+	assert.ErrorContains(t, err, "-tags s3")
+}
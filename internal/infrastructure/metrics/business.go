@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemEventTypes はアイテム数の増減として数えるイベント
+var ItemEventTypes = []string{usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted}
+
+// 一括登録の結果（inventory_imports_total の result）
+const (
+	ImportSucceeded = "succeeded"
+	// ImportRejected は入力が不正で1行も処理しなかった一括登録
+	ImportRejected = "rejected"
+	// ImportFailed は DB のエラーなどで途中で止まった一括登録
+	ImportFailed = "failed"
+)
+
+// カテゴリー別の件数・購入価格の合計を求めるリポジトリ（usecase.ItemRepository）
+type summaryRepository interface {
+	GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error)
+}
+
+// Business は業務のメトリクス（アイテム数・カテゴリー別の資産額・アイテムの登録と削除・一括登録の失敗）。
+// アイテム数と資産額は収集のたびに repo の集計から求め、イベントの数はイベントバスから受け取って数える
+type Business struct {
+	itemEvents *Counter
+	imports    *Counter
+	importRows *Counter
+}
+
+// NewBusiness は業務のメトリクスを reg に登録する。repo は集計キャッシュ（cache.ItemRepository）を渡し、
+// 収集のたびにクエリを実行しないようにする
+func NewBusiness(reg *Registry, repo summaryRepository) *Business {
+	b := &Business{
+		itemEvents: reg.NewCounter("inventory_item_events_total",
+			"Item changes committed, by event type (item.created includes items restored from the trash).", "type"),
+		imports: reg.NewCounter("inventory_imports_total",
+			"Item imports run (dry runs are not counted), by result: succeeded, rejected (invalid input) or failed.", "result"),
+		importRows: reg.NewCounter("inventory_import_rows_total",
+			"Rows of the item imports run (dry runs are not counted), by status: created, merged, skipped or failed.", "status"),
+	}
+	// 最初のイベントの前から 0 を出力し、rate() や increase() が最初の増加を取りこぼさないようにする
+	for _, eventType := range ItemEventTypes {
+		b.itemEvents.Add(0, eventType)
+	}
+	for _, result := range []string{ImportSucceeded, ImportRejected, ImportFailed} {
+		b.imports.Add(0, result)
+	}
+	for _, status := range []string{usecase.ImportCreated, usecase.ImportMerged, usecase.ImportSkipped, usecase.ImportFailed} {
+		b.importRows.Add(0, status)
+	}
+
+	reg.NewGaugeFunc("inventory_items", "Items not in the trash.", nil, func(ctx context.Context) ([]Sample, error) {
+		summary, err := repo.GetSummaryByCategory(usecase.ReadOnly(ctx), usecase.DateRange{})
+		if err != nil {
+			return nil, err
+		}
+		total := 0
+		for _, stats := range summary {
+			total += stats.Count
+		}
+		return []Sample{{Value: float64(total)}}, nil
+	})
+	reg.NewGaugeFunc("inventory_portfolio_value_jpy",
+		"Sum of the purchase prices of the items not in the trash, by category, in JPY.", []string{"category"},
+		func(ctx context.Context) ([]Sample, error) {
+			summary, err := repo.GetSummaryByCategory(usecase.ReadOnly(ctx), usecase.DateRange{})
+			if err != nil {
+				return nil, err
+			}
+			// アイテムのない組み込みのカテゴリーも 0 として出力する
+			categories := entity.SortedCategories(summary)
+			samples := make([]Sample, 0, len(categories))
+			for _, category := range categories {
+				samples = append(samples, Sample{LabelValues: []string{category}, Value: float64(summary[category].Sum)})
+			}
+			return samples, nil
+		})
+
+	return b
+}
+
+// Publish はコミットされたアイテムの変更を数える（usecase.ItemEventPublisher の実装。eventbus に ItemEventTypes で登録する）
+func (b *Business) Publish(_ context.Context, event usecase.ItemEvent) {
+	b.itemEvents.Inc(event.Type)
+}
+
+type importUsecase struct {
+	usecase.ImportUsecase
+	business *Business
+}
+
+// NewImportUsecase は inner の一括登録の結果と、行ごとの結果を数える
+func (b *Business) NewImportUsecase(inner usecase.ImportUsecase) usecase.ImportUsecase {
+	return &importUsecase{ImportUsecase: inner, business: b}
+}
+
+func (u *importUsecase) ImportItems(ctx context.Context, input usecase.ImportInput) (*usecase.ImportReport, error) {
+	report, err := u.ImportUsecase.ImportItems(ctx, input)
+	if input.DryRun {
+		return report, err
+	}
+
+	switch {
+	case err == nil:
+		u.business.imports.Inc(ImportSucceeded)
+	case errors.Is(err, domainErrors.ErrInvalidInput):
+		u.business.imports.Inc(ImportRejected)
+	default:
+		u.business.imports.Inc(ImportFailed)
+	}
+	if report != nil {
+		for _, row := range report.Rows {
+			u.business.importRows.Inc(row.Status)
+		}
+	}
+	return report, err
+}
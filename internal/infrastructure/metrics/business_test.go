@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeSummaryRepository はカテゴリー別の集計を返す
+type fakeSummaryRepository struct {
+	summary map[string]usecase.CategoryStats
+	err     error
+}
+
+func (r *fakeSummaryRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	return r.summary, r.err
+}
+
+// fakeImportUsecase は report と err をそのまま返す
+type fakeImportUsecase struct {
+	report *usecase.ImportReport
+	err    error
+}
+
+func (u *fakeImportUsecase) ImportItems(ctx context.Context, input usecase.ImportInput) (*usecase.ImportReport, error) {
+	return u.report, u.err
+}
+
+func writeMetrics(reg *Registry) string {
+	var sb strings.Builder
+	w := bufio.NewWriter(&sb)
+	reg.Write(context.Background(), w)
+	w.Flush()
+	return sb.String()
+}
+
+func TestBusiness_Gauges(t *testing.T) {
+	reg := NewRegistry()
+	NewBusiness(reg, &fakeSummaryRepository{summary: map[string]usecase.CategoryStats{
+		"時計":  {Count: 2, Sum: 1500000},
+		"バッグ": {Count: 1, Sum: 300000},
+		"ワイン": {Count: 4, Sum: 80000},
+	}})
+
+	out := writeMetrics(reg)
+
+	assert.Contains(t, out, "# TYPE inventory_items gauge\ninventory_items 7\n")
+	assert.Contains(t, out, `inventory_portfolio_value_jpy{category="時計"} 1.5e+06`)
+	assert.Contains(t, out, `inventory_portfolio_value_jpy{category="バッグ"} 300000`)
+	assert.Contains(t, out, `inventory_portfolio_value_jpy{category="靴"} 0`, "アイテムのない組み込みのカテゴリーは 0")
+	assert.Contains(t, out, `inventory_portfolio_value_jpy{category="ワイン"} 80000`, "テナントが追加したカテゴリー")
+}
+
+func TestBusiness_GaugesError(t *testing.T) {
+	reg := NewRegistry()
+	reg.logf = func(format string, args ...interface{}) {}
+	NewBusiness(reg, &fakeSummaryRepository{err: fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError)})
+
+	out := writeMetrics(reg)
+
+	assert.NotContains(t, out, "inventory_items ")
+	assert.NotContains(t, out, "inventory_portfolio_value_jpy{")
+	assert.Contains(t, out, `inventory_item_events_total{type="item.created"} 0`, "カウンターは出力する")
+}
+
+func TestBusiness_Publish(t *testing.T) {
+	b := NewBusiness(NewRegistry(), &fakeSummaryRepository{})
+
+	b.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+	b.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2})
+	b.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1})
+
+	assert.Equal(t, 2.0, b.itemEvents.Value(usecase.ItemCreated))
+	assert.Equal(t, 1.0, b.itemEvents.Value(usecase.ItemDeleted))
+	assert.Zero(t, b.itemEvents.Value(usecase.ItemUpdated))
+}
+
+func TestBusiness_ImportUsecase(t *testing.T) {
+	report := &usecase.ImportReport{Rows: []usecase.ImportRowResult{
+		{Row: 1, Status: usecase.ImportCreated}, {Row: 2, Status: usecase.ImportFailed}, {Row: 3, Status: usecase.ImportCreated},
+	}}
+
+	tests := []struct {
+		name           string
+		inner          *fakeImportUsecase
+		dryRun         bool
+		expectedResult string
+		expectedRows   map[string]float64
+	}{
+		{
+			name:           "正常系: 行ごとの結果を数える",
+			inner:          &fakeImportUsecase{report: report},
+			expectedResult: ImportSucceeded,
+			expectedRows:   map[string]float64{usecase.ImportCreated: 2, usecase.ImportFailed: 1},
+		},
+		{name: "正常系: dry run は数えない", inner: &fakeImportUsecase{report: report}, dryRun: true},
+		{
+			name:           "異常系: 不正な入力",
+			inner:          &fakeImportUsecase{err: fmt.Errorf("%w: rows are required", domainErrors.ErrInvalidInput)},
+			expectedResult: ImportRejected,
+		},
+		{
+			name:           "異常系: 途中で止まった",
+			inner:          &fakeImportUsecase{err: errors.New("failed to import row 2: database error")},
+			expectedResult: ImportFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBusiness(NewRegistry(), &fakeSummaryRepository{})
+
+			got, err := b.NewImportUsecase(tt.inner).ImportItems(context.Background(), usecase.ImportInput{DryRun: tt.dryRun})
+
+			assert.Equal(t, tt.inner.report, got)
+			assert.Equal(t, tt.inner.err, err)
+			for _, result := range []string{ImportSucceeded, ImportRejected, ImportFailed} {
+				expected := 0.0
+				if result == tt.expectedResult {
+					expected = 1
+				}
+				require.Equal(t, expected, b.imports.Value(result), result)
+			}
+			for _, status := range []string{usecase.ImportCreated, usecase.ImportMerged, usecase.ImportSkipped, usecase.ImportFailed} {
+				assert.Equal(t, tt.expectedRows[status], b.importRows.Value(status), status)
+			}
+		})
+	}
+}
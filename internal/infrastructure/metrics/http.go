@@ -0,0 +1,269 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SLO はリクエストのレイテンシーの目標
+type SLO struct {
+	// この時間以内に 5xx 以外で応答したリクエストを良いリクエストとする
+	LatencyThreshold time.Duration
+	// 良いリクエストの割合の目標（0〜1。例: 0.99）
+	Objective float64
+}
+
+// Window は SLI の割合を求める直近の期間
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// SLIWindows は複数の期間のバーンレートのアラート（1h と 5m、6h と 30m の組み合わせ）で使う期間
+var SLIWindows = []Window{
+	{Name: "5m", Duration: 5 * time.Minute},
+	{Name: "30m", Duration: 30 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "6h", Duration: 6 * time.Hour},
+}
+
+// latencyBuckets はレイテンシーのヒストグラムのバケットの上限（秒）。SLO の閾値は必ずバケットの上限にする
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.15, 0.2, 0.25, 0.3, 0.4, 0.5, 1, 2.5, 5, 10}
+
+// SLI は1分ごとに数え、直近の期間の分を合計する
+const sliSlot = time.Minute
+
+// HTTP はルートごとのレイテンシーのヒストグラムと、SLO に対する直近の期間の SLI・バーンレートを記録する
+type HTTP struct {
+	slo      SLO
+	duration *Histogram
+	sli      *sliTracker
+	now      func() time.Time
+}
+
+// NewHTTP は HTTP のメトリクスを reg に登録する
+func NewHTTP(reg *Registry, slo SLO) *HTTP {
+	buckets := slices.Clone(latencyBuckets)
+	if threshold := slo.LatencyThreshold.Seconds(); !slices.Contains(buckets, threshold) {
+		buckets = append(buckets, threshold)
+		slices.Sort(buckets)
+	}
+
+	h := &HTTP{
+		slo: slo,
+		duration: reg.NewHistogram("http_request_duration_seconds",
+			"Latency of the requests handled, by method, route and status class.", buckets, "method", "route", "code"),
+		sli: newSLITracker(SLIWindows),
+		now: time.Now,
+	}
+
+	reg.NewGaugeFunc("http_slo_latency_threshold_seconds", "Latency within which a request not answered with a 5xx is good.", nil,
+		func(ctx context.Context) ([]Sample, error) {
+			return []Sample{{Value: slo.LatencyThreshold.Seconds()}}, nil
+		})
+	reg.NewGaugeFunc("http_slo_objective", "Objective of the ratio of good requests.", nil, func(ctx context.Context) ([]Sample, error) {
+		return []Sample{{Value: slo.Objective}}, nil
+	})
+	reg.NewGaugeFunc("http_sli_ratio", "Ratio of good requests over the window, by method and route (absent without requests).",
+		[]string{"method", "route", "window"}, func(ctx context.Context) ([]Sample, error) {
+			return h.sliSamples(false), nil
+		})
+	reg.NewGaugeFunc("http_slo_burn_rate", "Rate at which the error budget is spent over the window (1 spends it exactly over the SLO period), by method and route.",
+		[]string{"method", "route", "window"}, func(ctx context.Context) ([]Sample, error) {
+			return h.sliSamples(true), nil
+		})
+	reg.NewGaugeFunc("http_service_sli_ratio", "Ratio of good requests over the window, over all routes (absent without requests).",
+		[]string{"window"}, func(ctx context.Context) ([]Sample, error) {
+			return h.serviceSamples(false), nil
+		})
+	reg.NewGaugeFunc("http_service_slo_burn_rate", "Rate at which the error budget is spent over the window, over all routes.",
+		[]string{"window"}, func(ctx context.Context) ([]Sample, error) {
+			return h.serviceSamples(true), nil
+		})
+
+	return h
+}
+
+// Middleware はリクエストのレイテンシーを、ルートのパターン（/items/:id など）ごとに記録する。
+// ステータスを確定させるため、エラーはここでレスポンスに書き出す。WebSocket の接続は記録しない
+func (h *HTTP) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.IsWebSocket() {
+				return next(c)
+			}
+
+			start := h.now()
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			h.Observe(c.Request().Method, c.Path(), c.Response().Status, h.now().Sub(start))
+			return nil
+		}
+	}
+}
+
+// standardMethods 以外のメソッドは other として記録し、任意のメソッドで系列が増えないようにする
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// Observe は1件のリクエストを記録する。ルートに一致しなかったリクエストの route は空
+func (h *HTTP) Observe(method, route string, status int, latency time.Duration) {
+	if !slices.Contains(standardMethods, method) {
+		method = "other"
+	}
+	h.duration.Observe(latency.Seconds(), method, route, strconv.Itoa(status/100)+"xx")
+	good := status < 500 && latency <= h.slo.LatencyThreshold
+	h.sli.add(h.now(), routeKey{method: method, route: route}, good)
+}
+
+func (h *HTTP) sliSamples(burnRate bool) []Sample {
+	var samples []Sample
+	for key, ratios := range h.sli.routeRatios(h.now()) {
+		for i, window := range h.sli.windows {
+			if ratio, ok := ratios[i]; ok {
+				samples = append(samples, Sample{LabelValues: []string{key.method, key.route, window.Name}, Value: h.value(ratio, burnRate)})
+			}
+		}
+	}
+	return samples
+}
+
+func (h *HTTP) serviceSamples(burnRate bool) []Sample {
+	var samples []Sample
+	ratios := h.sli.serviceRatios(h.now())
+	for i, window := range h.sli.windows {
+		if ratio, ok := ratios[i]; ok {
+			samples = append(samples, Sample{LabelValues: []string{window.Name}, Value: h.value(ratio, burnRate)})
+		}
+	}
+	return samples
+}
+
+// バーンレートは悪いリクエストの割合を、目標で許される悪いリクエストの割合（エラーバジェット）で割ったもの
+func (h *HTTP) value(ratio float64, burnRate bool) float64 {
+	if !burnRate {
+		return ratio
+	}
+	return (1 - ratio) / (1 - h.slo.Objective)
+}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+// sliTracker はルートごとと全体の良いリクエスト・全リクエストの数を、最長の期間の分だけ1分ごとに保持する
+type sliTracker struct {
+	windows []Window
+	slots   int
+
+	mu      sync.Mutex
+	routes  map[routeKey]*sliRing
+	service *sliRing
+}
+
+func newSLITracker(windows []Window) *sliTracker {
+	slots := 0
+	for _, w := range windows {
+		slots = max(slots, int(w.Duration/sliSlot))
+	}
+	return &sliTracker{
+		windows: windows,
+		slots:   slots,
+		routes:  make(map[routeKey]*sliRing),
+		service: newSLIRing(slots),
+	}
+}
+
+func (t *sliTracker) add(now time.Time, key routeKey, good bool) {
+	slot := now.Unix() / int64(sliSlot/time.Second)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ring, ok := t.routes[key]
+	if !ok {
+		ring = newSLIRing(t.slots)
+		t.routes[key] = ring
+	}
+	ring.add(slot, good)
+	t.service.add(slot, good)
+}
+
+// routeRatios はルートごとに、各期間の良いリクエストの割合（期間にリクエストがなければ含めない）を返す
+func (t *sliTracker) routeRatios(now time.Time) map[routeKey]map[int]float64 {
+	slot := now.Unix() / int64(sliSlot/time.Second)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ratios := make(map[routeKey]map[int]float64, len(t.routes))
+	for key, ring := range t.routes {
+		if r := t.ratios(ring, slot); len(r) > 0 {
+			ratios[key] = r
+		}
+	}
+	return ratios
+}
+
+func (t *sliTracker) serviceRatios(now time.Time) map[int]float64 {
+	slot := now.Unix() / int64(sliSlot/time.Second)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ratios(t.service, slot)
+}
+
+func (t *sliTracker) ratios(ring *sliRing, slot int64) map[int]float64 {
+	ratios := make(map[int]float64, len(t.windows))
+	for i, w := range t.windows {
+		good, total := ring.sum(slot, int(w.Duration/sliSlot))
+		if total > 0 {
+			ratios[i] = float64(good) / float64(total)
+		}
+	}
+	return ratios
+}
+
+// sliRing は1分ごとの数を循環して保持する。slots[i] は slot % len の分の数
+type sliRing struct {
+	slots []sliCount
+}
+
+type sliCount struct {
+	slot        int64
+	good, total uint64
+}
+
+func newSLIRing(slots int) *sliRing {
+	return &sliRing{slots: make([]sliCount, slots)}
+}
+
+func (r *sliRing) add(slot int64, good bool) {
+	c := &r.slots[slot%int64(len(r.slots))]
+	if c.slot != slot {
+		*c = sliCount{slot: slot}
+	}
+	c.total++
+	if good {
+		c.good++
+	}
+}
+
+// sum は現在の分を含む直近 n 分の数を合計する
+func (r *sliRing) sum(slot int64, n int) (good, total uint64) {
+	for _, c := range r.slots {
+		if c.slot > slot-int64(n) && c.slot <= slot {
+			good += c.good
+			total += c.total
+		}
+	}
+	return good, total
+}
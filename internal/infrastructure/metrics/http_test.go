@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sloNow = time.Date(2024, 3, 10, 9, 0, 30, 0, time.UTC)
+
+func newTestHTTP(reg *Registry, now *time.Time) *HTTP {
+	h := NewHTTP(reg, SLO{LatencyThreshold: 200 * time.Millisecond, Objective: 0.99})
+	h.now = func() time.Time { return *now }
+	return h
+}
+
+func TestHTTP_Middleware(t *testing.T) {
+	reg := NewRegistry()
+	now := sloNow
+	h := newTestHTTP(reg, &now)
+
+	e := echo.New()
+	e.Use(h.Middleware())
+	e.GET("/items/:id", func(c echo.Context) error {
+		now = now.Add(150 * time.Millisecond)
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "item not found")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	e.POST("/items", func(c echo.Context) error {
+		now = now.Add(300 * time.Millisecond)
+		return c.NoContent(http.StatusCreated)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/items/1", nil),
+		httptest.NewRequest(http.MethodGet, "/items/0", nil),
+		httptest.NewRequest(http.MethodPost, "/items", nil),
+	} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.NotEqual(t, http.StatusInternalServerError, rec.Code)
+	}
+
+	out := writeMetrics(reg)
+
+	assert.Contains(t, out, `http_request_duration_seconds_bucket{method="GET",route="/items/:id",code="2xx",le="0.2"} 1`)
+	assert.Contains(t, out, `http_request_duration_seconds_count{method="GET",route="/items/:id",code="4xx"} 1`, "エラーはステータスを確定させて記録する")
+	assert.Contains(t, out, `http_request_duration_seconds_bucket{method="POST",route="/items",code="2xx",le="0.2"} 0`)
+	assert.Contains(t, out, `http_request_duration_seconds_bucket{method="POST",route="/items",code="2xx",le="0.25"} 0`)
+	assert.Contains(t, out, `http_sli_ratio{method="GET",route="/items/:id",window="5m"} 1`)
+	assert.Contains(t, out, `http_sli_ratio{method="POST",route="/items",window="5m"} 0`)
+	assert.Contains(t, out, `http_slo_burn_rate{method="POST",route="/items",window="1h"} 99.9999`, "悪いリクエストだけなら 1/(1-0.99)")
+	assert.Contains(t, out, `http_service_sli_ratio{window="6h"} 0.6666666666666666`)
+	assert.Contains(t, out, "http_slo_latency_threshold_seconds 0.2\n")
+	assert.Contains(t, out, "http_slo_objective 0.99\n")
+}
+
+func TestHTTP_Buckets(t *testing.T) {
+	t.Run("正常系: SLO の閾値をバケットの上限に加える", func(t *testing.T) {
+		h := NewHTTP(NewRegistry(), SLO{LatencyThreshold: 350 * time.Millisecond, Objective: 0.999})
+		assert.Contains(t, h.duration.upperBounds, 0.35)
+		assert.IsIncreasing(t, h.duration.upperBounds)
+	})
+
+	t.Run("正常系: 閾値が既にバケットの上限なら加えない", func(t *testing.T) {
+		h := NewHTTP(NewRegistry(), SLO{LatencyThreshold: 200 * time.Millisecond, Objective: 0.99})
+		assert.Equal(t, latencyBuckets, h.duration.upperBounds)
+	})
+}
+
+func TestHTTP_Windows(t *testing.T) {
+	now := sloNow
+	h := newTestHTTP(NewRegistry(), &now)
+
+	// 2時間前の悪いリクエスト、40分前の悪いリクエスト、現在の良いリクエスト
+	now = sloNow.Add(-2 * time.Hour)
+	h.Observe(http.MethodGet, "/items", http.StatusInternalServerError, 10*time.Millisecond)
+	now = sloNow.Add(-40 * time.Minute)
+	h.Observe(http.MethodGet, "/items", http.StatusOK, time.Second)
+	now = sloNow
+	h.Observe(http.MethodGet, "/items", http.StatusOK, 200*time.Millisecond)
+
+	ratios := h.sli.serviceRatios(now)
+
+	assert.Equal(t, map[int]float64{0: 1, 1: 1, 2: 0.5, 3: 1.0 / 3}, ratios)
+
+	t.Run("正常系: 期間を過ぎたリクエストは数えない", func(t *testing.T) {
+		now = sloNow.Add(7 * time.Hour)
+		assert.Empty(t, h.sli.serviceRatios(now))
+		assert.Empty(t, h.sliSamples(false))
+	})
+
+	t.Run("正常系: 標準でないメソッドはまとめる", func(t *testing.T) {
+		now = sloNow.Add(7 * time.Hour)
+		h.Observe("PROPFIND", "", http.StatusMethodNotAllowed, time.Millisecond)
+		assert.Equal(t, []Sample{
+			{LabelValues: []string{"other", "", "5m"}, Value: 1}, {LabelValues: []string{"other", "", "30m"}, Value: 1},
+			{LabelValues: []string{"other", "", "1h"}, Value: 1}, {LabelValues: []string{"other", "", "6h"}, Value: 1},
+		}, h.sliSamples(false))
+	})
+
+	t.Run("正常系: 同じ枠を再利用する分は前の数を捨てる", func(t *testing.T) {
+		now = sloNow.Add(6 * time.Hour)
+		h.Observe(http.MethodGet, "/items", http.StatusOK, time.Millisecond)
+
+		good, total := h.sli.service.sum(now.Unix()/60, 360)
+		assert.Equal(t, uint64(1), good)
+		assert.Equal(t, uint64(1), total)
+	})
+}
+
+func TestHTTP_WebSocket(t *testing.T) {
+	reg := NewRegistry()
+	now := sloNow
+	h := newTestHTTP(reg, &now)
+
+	e := echo.New()
+	e.Use(h.Middleware())
+	e.GET("/ws", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set(echo.HeaderUpgrade, "websocket")
+	req.Header.Set(echo.HeaderConnection, "Upgrade")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.NotContains(t, writeMetrics(reg), `route="/ws"`)
+}
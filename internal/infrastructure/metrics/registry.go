@@ -0,0 +1,346 @@
+// Package metrics は Prometheus のテキスト形式（0.0.4）でメトリクスを公開する。
+//
+// クライアントライブラリは使わず、このアプリケーションで使う種類だけを実装する。
+//   - Counter: イベントのたびに加算するカウンター
+//   - GaugeFunc: 収集（スクレイプ）のたびに関数で値を求めるゲージ
+//   - Histogram: 観測した値をバケット（上限以下の数の累計）に数えるヒストグラム
+//
+// expvar（/debug/vars）の値は従来どおりで、ここには含めない。
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType は Prometheus のテキスト形式の Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry は登録されたメトリクスを登録順に出力する
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+	names    map[string]bool
+	logf     func(format string, args ...interface{})
+}
+
+// family は同じ名前のメトリクスの集まり（HELP・TYPE の行とサンプル）
+type family interface {
+	describe() *desc
+	collect(ctx context.Context) ([]Sample, error)
+}
+
+type desc struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+// Sample はメトリクスの1つの値。LabelValues は登録したラベル名の順
+type Sample struct {
+	LabelValues []string
+	Value       float64
+	// histogram は Histogram のサンプルだけが持つ
+	histogram *histogramValue
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool), logf: log.Printf}
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := f.describe().name
+	if r.names[name] {
+		panic(fmt.Sprintf("metrics: %s is already registered", name))
+	}
+	r.names[name] = true
+	r.families = append(r.families, f)
+}
+
+// Counter は単調に増加する値。ラベルの値の組み合わせごとに数える
+type Counter struct {
+	desc *desc
+
+	mu     sync.Mutex
+	values map[string]*Sample
+}
+
+// NewCounter はカウンターを登録する。名前は Prometheus の慣習どおり _total で終える
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	c := &Counter{
+		desc:   &desc{name: name, help: help, kind: "counter", labelNames: labelNames},
+		values: make(map[string]*Sample),
+	}
+	r.register(c)
+	return c
+}
+
+// Add は labelValues（登録したラベル名の順）の値に v を加える。v は負であってはならない
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic(fmt.Sprintf("metrics: %s cannot decrease", c.desc.name))
+	}
+	if len(labelValues) != len(c.desc.labelNames) {
+		panic(fmt.Sprintf("metrics: %s has %d label(s), got %d value(s)", c.desc.name, len(c.desc.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.values[key]
+	if !ok {
+		s = &Sample{LabelValues: append([]string(nil), labelValues...)}
+		c.values[key] = s
+	}
+	s.Value += v
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Value はラベルの値の組み合わせの現在の値（まだ数えていなければ 0）
+func (c *Counter) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.values[strings.Join(labelValues, "\xff")]; ok {
+		return s.Value
+	}
+	return 0
+}
+
+func (c *Counter) describe() *desc { return c.desc }
+
+func (c *Counter) collect(context.Context) ([]Sample, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	samples := make([]Sample, 0, len(c.values))
+	for _, s := range c.values {
+		samples = append(samples, Sample{LabelValues: s.LabelValues, Value: s.Value})
+	}
+	return samples, nil
+}
+
+// Histogram は観測した値の分布。ラベルの値の組み合わせごとに、上限ごとのバケットに数える
+type Histogram struct {
+	desc        *desc
+	upperBounds []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramSample
+}
+
+type histogramSample struct {
+	labelValues []string
+	value       histogramValue
+}
+
+type histogramValue struct {
+	upperBounds []float64
+	// counts[i] は upperBounds[i-1] を超え upperBounds[i] 以下の値の数（+Inf のバケットは count から求める）
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewHistogram はヒストグラムを登録する。buckets はバケットの上限（昇順。+Inf は自動で加える）
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if !slices.IsSorted(buckets) {
+		panic(fmt.Sprintf("metrics: buckets of %s must be in increasing order", name))
+	}
+	h := &Histogram{
+		desc:        &desc{name: name, help: help, kind: "histogram", labelNames: labelNames},
+		upperBounds: slices.Clone(buckets),
+		values:      make(map[string]*histogramSample),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe は labelValues（登録したラベル名の順）の分布に v を加える
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	if len(labelValues) != len(h.desc.labelNames) {
+		panic(fmt.Sprintf("metrics: %s has %d label(s), got %d value(s)", h.desc.name, len(h.desc.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.values[key]
+	if !ok {
+		s = &histogramSample{
+			labelValues: append([]string(nil), labelValues...),
+			value:       histogramValue{upperBounds: h.upperBounds, counts: make([]uint64, len(h.upperBounds))},
+		}
+		h.values[key] = s
+	}
+	if i, _ := slices.BinarySearch(h.upperBounds, v); i < len(h.upperBounds) {
+		s.value.counts[i]++
+	}
+	s.value.sum += v
+	s.value.count++
+}
+
+func (h *Histogram) describe() *desc { return h.desc }
+
+func (h *Histogram) collect(context.Context) ([]Sample, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	samples := make([]Sample, 0, len(h.values))
+	for _, s := range h.values {
+		value := s.value
+		value.counts = slices.Clone(value.counts)
+		samples = append(samples, Sample{LabelValues: s.labelValues, histogram: &value})
+	}
+	return samples, nil
+}
+
+type gaugeFunc struct {
+	desc *desc
+	fn   func(ctx context.Context) ([]Sample, error)
+}
+
+// NewGaugeFunc は収集のたびに fn で値を求めるゲージを登録する。
+// fn がエラーを返した収集では、このゲージだけを出力せずにログに残す（他のメトリクスは出力する）
+func (r *Registry) NewGaugeFunc(name, help string, labelNames []string, fn func(ctx context.Context) ([]Sample, error)) {
+	r.register(&gaugeFunc{
+		desc: &desc{name: name, help: help, kind: "gauge", labelNames: labelNames},
+		fn:   fn,
+	})
+}
+
+func (g *gaugeFunc) describe() *desc { return g.desc }
+
+func (g *gaugeFunc) collect(ctx context.Context) ([]Sample, error) {
+	samples, err := g.fn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range samples {
+		if len(s.LabelValues) != len(g.desc.labelNames) {
+			return nil, fmt.Errorf("%d label(s), got %d value(s)", len(g.desc.labelNames), len(s.LabelValues))
+		}
+	}
+	return samples, nil
+}
+
+// Handler は GET /metrics のハンドラー
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		bw := bufio.NewWriter(w)
+		r.Write(req.Context(), bw)
+		bw.Flush()
+	})
+}
+
+// Write はすべてのメトリクスをテキスト形式で書き出す。サンプルはラベルの値の順
+func (r *Registry) Write(ctx context.Context, w *bufio.Writer) {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+
+	for _, f := range families {
+		d := f.describe()
+		samples, err := f.collect(ctx)
+		if err != nil {
+			r.logf("⚠️  metrics: failed to collect %s: %v", d.name, err)
+			continue
+		}
+		sort.Slice(samples, func(i, j int) bool {
+			return lessLabelValues(samples[i].LabelValues, samples[j].LabelValues)
+		})
+
+		fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+		fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+		for _, s := range samples {
+			if s.histogram != nil {
+				writeHistogram(w, d, s)
+				continue
+			}
+			writeSample(w, d.name, d.labelNames, s.LabelValues, s.Value)
+		}
+	}
+}
+
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, value float64) {
+	w.WriteString(name)
+	writeLabels(w, labelNames, labelValues)
+	w.WriteByte(' ')
+	w.WriteString(formatValue(value))
+	w.WriteByte('\n')
+}
+
+// ヒストグラムは上限（le）ごとの累計のバケットと、合計（_sum）・件数（_count）を出力する
+func writeHistogram(w *bufio.Writer, d *desc, s Sample) {
+	h := s.histogram
+	labelNames := append(slices.Clone(d.labelNames), "le")
+	var cumulative uint64
+	for i, upper := range h.upperBounds {
+		cumulative += h.counts[i]
+		writeSample(w, d.name+"_bucket", labelNames, append(slices.Clone(s.LabelValues), formatValue(upper)), float64(cumulative))
+	}
+	writeSample(w, d.name+"_bucket", labelNames, append(slices.Clone(s.LabelValues), "+Inf"), float64(h.count))
+	writeSample(w, d.name+"_sum", d.labelNames, s.LabelValues, h.sum)
+	writeSample(w, d.name+"_count", d.labelNames, s.LabelValues, float64(h.count))
+}
+
+func writeLabels(w *bufio.Writer, names, values []string) {
+	if len(names) == 0 {
+		return
+	}
+	w.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(name)
+		w.WriteString(`="`)
+		w.WriteString(labelValueEscaper.Replace(values[i]))
+		w.WriteByte('"')
+	}
+	w.WriteByte('}')
+}
+
+var (
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func lessLabelValues(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Handler(t *testing.T) {
+	t.Run("正常系: 登録順に、サンプルはラベルの値の順に出力する", func(t *testing.T) {
+		reg := NewRegistry()
+		requests := reg.NewCounter("app_requests_total", "Requests handled.", "method", "code")
+		requests.Inc("POST", "201")
+		requests.Add(2, "GET", "200")
+		requests.Inc("GET", "200")
+		reg.NewGaugeFunc("app_temperature", "Temperature.", nil, func(ctx context.Context) ([]Sample, error) {
+			return []Sample{{Value: 21.5}}, nil
+		})
+
+		rec := httptest.NewRecorder()
+		reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, `# HELP app_requests_total Requests handled.
+# TYPE app_requests_total counter
+app_requests_total{method="GET",code="200"} 3
+app_requests_total{method="POST",code="201"} 1
+# HELP app_temperature Temperature.
+# TYPE app_temperature gauge
+app_temperature 21.5
+`, rec.Body.String())
+	})
+
+	t.Run("正常系: ラベルの値と説明をエスケープする", func(t *testing.T) {
+		reg := NewRegistry()
+		reg.NewGaugeFunc("app_info", "Line one\nline \\two.", []string{"name"}, func(ctx context.Context) ([]Sample, error) {
+			return []Sample{{LabelValues: []string{"say \"hi\"\n\\"}, Value: math.Inf(1)}}, nil
+		})
+
+		rec := httptest.NewRecorder()
+		reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, `# HELP app_info Line one\nline \\two.
+# TYPE app_info gauge
+app_info{name="say \"hi\"\n\\"} +Inf
+`, rec.Body.String())
+	})
+
+	t.Run("異常系: 値を求められないゲージだけを出力しない", func(t *testing.T) {
+		reg := NewRegistry()
+		var logged []string
+		reg.logf = func(format string, args ...interface{}) { logged = append(logged, fmt.Sprintf(format, args...)) }
+		reg.NewGaugeFunc("app_broken", "Broken.", nil, func(ctx context.Context) ([]Sample, error) {
+			return nil, errors.New("database error: connection refused")
+		})
+		reg.NewCounter("app_events_total", "Events.").Inc()
+
+		rec := httptest.NewRecorder()
+		reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "# HELP app_events_total Events.\n# TYPE app_events_total counter\napp_events_total 1\n", rec.Body.String())
+		assert.Equal(t, []string{"⚠️  metrics: failed to collect app_broken: database error: connection refused"}, logged)
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	reg := NewRegistry()
+	latency := reg.NewHistogram("app_latency_seconds", "Latency.", []float64{0.1, 0.2}, "route")
+	latency.Observe(0.05, "/a")
+	latency.Observe(0.2, "/a")
+	latency.Observe(3, "/a")
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, `# HELP app_latency_seconds Latency.
+# TYPE app_latency_seconds histogram
+app_latency_seconds_bucket{route="/a",le="0.1"} 1
+app_latency_seconds_bucket{route="/a",le="0.2"} 2
+app_latency_seconds_bucket{route="/a",le="+Inf"} 3
+app_latency_seconds_sum{route="/a"} 3.25
+app_latency_seconds_count{route="/a"} 3
+`, rec.Body.String())
+	assert.Panics(t, func() { reg.NewHistogram("app_size_bytes", "Size.", []float64{2, 1}) }, "バケットは昇順")
+}
+
+func TestCounter(t *testing.T) {
+	reg := NewRegistry()
+	counter := reg.NewCounter("app_events_total", "Events.", "type")
+
+	counter.Inc("a")
+	counter.Add(2.5, "a")
+
+	assert.Equal(t, 3.5, counter.Value("a"))
+	assert.Zero(t, counter.Value("b"))
+	assert.Panics(t, func() { counter.Add(-1, "a") }, "カウンターは減らせない")
+	assert.Panics(t, func() { counter.Inc() }, "ラベルの値の数が違う")
+	assert.Panics(t, func() { reg.NewCounter("app_events_total", "Again.") }, "同じ名前は登録できない")
+}
@@ -0,0 +1,238 @@
+// Package migration はバージョン付きのSQLマイグレーションを適用する。
+// マイグレーションは sql/<方言>/NNNN_名前.up.sql（と .down.sql）としてバイナリに埋め込まれる。
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+//go:embed sql
+var embedded embed.FS
+
+// 適用済みのバージョンを記録するテーブル（MySQL/SQLite共通の定義）
+const createVersionTable = `CREATE TABLE IF NOT EXISTS schema_version (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+)`
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// 1つのマイグレーション
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string // 空の場合はロールバック不可
+}
+
+// ディレクトリからマイグレーションを読み込み、バージョン順に並べる
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		m := fileNamePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names: %s, %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(data)
+		} else {
+			mig.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+type Migrator struct {
+	db         database.SqlHandler
+	migrations []Migration
+}
+
+// ハンドラーの方言に対応する埋め込みマイグレーションを使うMigratorを返す
+func New(db database.SqlHandler) (*Migrator, error) {
+	migrations, err := Load(embedded, "sql/"+database.DialectOf(db))
+	if err != nil {
+		return nil, err
+	}
+	return NewWithMigrations(db, migrations), nil
+}
+
+func NewWithMigrations(db database.SqlHandler, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// 未適用のマイグレーションをすべて適用し、適用した件数を返す
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range m.migrations {
+		if applied[mig.Version] {
+			continue
+		}
+		if err := m.exec(ctx, mig.Up); err != nil {
+			return count, fmt.Errorf("migration %d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := m.db.Execute(ctx, "INSERT INTO schema_version (version, name) VALUES (?, ?)", mig.Version, mig.Name); err != nil {
+			return count, fmt.Errorf("failed to record migration %d: %w", mig.Version, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// 適用済みのマイグレーションを新しい順に steps 件ロールバックし、戻した件数を返す
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		mig := m.migrations[i]
+		if !applied[mig.Version] {
+			continue
+		}
+		if mig.Down == "" {
+			return count, fmt.Errorf("migration %d_%s cannot be rolled back", mig.Version, mig.Name)
+		}
+		if err := m.exec(ctx, mig.Down); err != nil {
+			return count, fmt.Errorf("rollback of migration %d_%s failed: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := m.db.Execute(ctx, "DELETE FROM schema_version WHERE version = ?", mig.Version); err != nil {
+			return count, fmt.Errorf("failed to unrecord migration %d: %w", mig.Version, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// 適用済みの最新バージョン（未適用なら0）
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var latest int64
+	for v := range applied {
+		latest = max(latest, v)
+	}
+	return latest, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]bool, error) {
+	if _, err := m.db.Execute(ctx, createVersionTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+
+	rows, err := m.db.Query(ctx, "SELECT version FROM schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_version: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var v int64
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to read schema_version: %w", err)
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// ドライバーが複数文の実行に対応していないため、1文ずつ実行する
+func (m *Migrator) exec(ctx context.Context, script string) error {
+	statements := splitStatements(script)
+	if len(statements) == 0 {
+		return errors.New("migration contains no statements")
+	}
+	for _, stmt := range statements {
+		if _, err := m.db.Execute(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 行末の ; で文を区切る。コメント行は除き、トリガーの BEGIN ... END; はまとめて1文とする
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		current    []string
+		depth      int
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" && len(current) == 0 || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current = append(current, line)
+
+		upper := strings.ToUpper(trimmed)
+		switch {
+		case upper == "BEGIN" || strings.HasSuffix(upper, " BEGIN"):
+			depth++
+		case upper == "END;" || upper == "END":
+			depth--
+		}
+
+		if depth == 0 && strings.HasSuffix(trimmed, ";") {
+			stmt := strings.TrimSpace(strings.Join(current, "\n"))
+			statements = append(statements, strings.TrimSuffix(stmt, ";"))
+			current = nil
+		}
+	}
+	if stmt := strings.TrimSpace(strings.Join(current, "\n")); stmt != "" {
+		statements = append(statements, stmt)
+	}
+	return statements
+}
@@ -0,0 +1,212 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// schema_version を記録するだけの偽ハンドラー
+type fakeDB struct {
+	dialect    string
+	versions   []int64
+	statements []string
+	failOn     string
+}
+
+func (f *fakeDB) Dialect() string { return f.dialect }
+
+func (f *fakeDB) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	if f.failOn != "" && strings.Contains(statement, f.failOn) {
+		return nil, errors.New("syntax error")
+	}
+	switch {
+	case strings.HasPrefix(statement, "INSERT INTO schema_version"):
+		f.versions = append(f.versions, args[0].(int64))
+	case strings.HasPrefix(statement, "DELETE FROM schema_version"):
+		for i, v := range f.versions {
+			if v == args[0].(int64) {
+				f.versions = append(f.versions[:i], f.versions[i+1:]...)
+				break
+			}
+		}
+	case strings.HasPrefix(statement, "CREATE TABLE IF NOT EXISTS schema_version"):
+	default:
+		f.statements = append(f.statements, statement)
+	}
+	return nil, nil
+}
+
+func (f *fakeDB) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return &fakeRows{values: append([]int64{}, f.versions...), pos: -1}, nil
+}
+
+func (f *fakeDB) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return nil
+}
+
+func (f *fakeDB) Close() error { return nil }
+
+type fakeRows struct {
+	values []int64
+	pos    int
+}
+
+func (r *fakeRows) Next() bool { r.pos++; return r.pos < len(r.values) }
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = r.values[r.pos]
+	return nil
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Err() error   { return nil }
+
+func testMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+		{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id INT);\nCREATE INDEX idx_b ON b (id);", Down: "DROP TABLE b;"},
+		{Version: 3, Name: "seed", Up: "INSERT INTO a VALUES (1);"},
+	}
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeDB{}
+	m := NewWithMigrations(db, testMigrations())
+
+	n, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{
+		"CREATE TABLE a (id INT)",
+		"CREATE TABLE b (id INT)",
+		"CREATE INDEX idx_b ON b (id)",
+		"INSERT INTO a VALUES (1)",
+	}, db.statements)
+
+	t.Run("正常系: 適用済みのマイグレーションは再実行しない", func(t *testing.T) {
+		n, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		version, _ := m.Version(ctx)
+		assert.Equal(t, int64(3), version)
+	})
+
+	t.Run("異常系: downファイルがないマイグレーションは戻せない", func(t *testing.T) {
+		_, err := m.Down(ctx, 1)
+		assert.ErrorContains(t, err, "3_seed cannot be rolled back")
+	})
+
+	t.Run("正常系: 新しい順にロールバック", func(t *testing.T) {
+		db.versions = []int64{1, 2}
+		db.statements = nil
+		n, err := m.Down(ctx, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []string{"DROP TABLE b", "DROP TABLE a"}, db.statements)
+		version, _ := m.Version(ctx)
+		assert.Equal(t, int64(0), version)
+	})
+}
+
+func TestMigrator_UpFailure(t *testing.T) {
+	db := &fakeDB{failOn: "CREATE TABLE b"}
+	m := NewWithMigrations(db, testMigrations())
+
+	n, err := m.Up(context.Background())
+	assert.ErrorContains(t, err, "migration 2_create_b failed")
+	assert.Equal(t, 1, n)
+	// 失敗したマイグレーションは記録されない
+	assert.Equal(t, []int64{1}, db.versions)
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int64
+		wantErr string
+	}{
+		{
+			name: "正常系: バージョン順に並ぶ",
+			files: fstest.MapFS{
+				"m/0010_b.up.sql":   {Data: []byte("B;")},
+				"m/0002_a.up.sql":   {Data: []byte("A;")},
+				"m/0002_a.down.sql": {Data: []byte("DA;")},
+			},
+			want: []int64{2, 10},
+		},
+		{
+			name:    "異常系: 不正なファイル名",
+			files:   fstest.MapFS{"m/create.sql": {Data: []byte("A;")}},
+			wantErr: "invalid migration file name",
+		},
+		{
+			name:    "異常系: upファイルがない",
+			files:   fstest.MapFS{"m/0001_a.down.sql": {Data: []byte("A;")}},
+			wantErr: "has no up file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files, "m")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var versions []int64
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	// MySQL と SQLite で同じバージョンが揃っている
+	mysql, err := Load(embedded, "sql/"+database.DialectMySQL)
+	require.NoError(t, err)
+	sqlite, err := Load(embedded, "sql/"+database.DialectSQLite)
+	require.NoError(t, err)
+
+	require.Equal(t, len(mysql), len(sqlite))
+	for i := range mysql {
+		assert.Equal(t, mysql[i].Version, sqlite[i].Version)
+		assert.Equal(t, mysql[i].Name, sqlite[i].Name)
+		assert.NotEmpty(t, mysql[i].Down)
+		assert.NotEmpty(t, sqlite[i].Down)
+	}
+
+	m, err := New(&fakeDB{dialect: database.DialectSQLite})
+	require.NoError(t, err)
+	assert.Len(t, m.migrations, len(sqlite))
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- comment
+CREATE TABLE a (
+    id INT
+);
+
+CREATE TRIGGER t
+AFTER UPDATE ON a
+BEGIN
+    UPDATE a SET id = 1;
+END;
+INSERT INTO a VALUES (1)`
+
+	assert.Equal(t, []string{
+		"CREATE TABLE a (\n    id INT\n)",
+		"CREATE TRIGGER t\nAFTER UPDATE ON a\nBEGIN\n    UPDATE a SET id = 1;\nEND",
+		"INSERT INTO a VALUES (1)",
+	}, splitStatements(script))
+}
@@ -0,0 +1,8 @@
+-- Per-tenant settings (list defaults applied when requests omit parameters)
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_id VARCHAR(64) PRIMARY KEY COMMENT 'Tenant identifier (X-Tenant-ID header)',
+    sort_by VARCHAR(32) NOT NULL DEFAULT 'created_at' COMMENT 'Default sort field for item lists',
+    sort_order VARCHAR(4) NOT NULL DEFAULT 'desc' COMMENT 'Default sort order: asc, desc',
+    page_size INT NOT NULL DEFAULT 0 COMMENT 'Default page size (0 = no paging)',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for per-tenant settings';
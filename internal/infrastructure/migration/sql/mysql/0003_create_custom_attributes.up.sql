@@ -0,0 +1,13 @@
+-- Per-tenant custom attribute definitions (enumerated fields such as storage box)
+CREATE TABLE IF NOT EXISTS custom_attributes (
+    tenant_id VARCHAR(64) NOT NULL COMMENT 'Tenant identifier (X-Tenant-ID header)',
+    attr_key VARCHAR(50) NOT NULL COMMENT 'Attribute key used in item attributes',
+    label VARCHAR(100) NOT NULL COMMENT 'Display label',
+    attr_type VARCHAR(20) NOT NULL DEFAULT 'enum' COMMENT 'Attribute type: enum',
+    options JSON NOT NULL COMMENT 'Allowed values',
+    required BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether new items must set the attribute',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    PRIMARY KEY (tenant_id, attr_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for per-tenant custom attribute definitions';
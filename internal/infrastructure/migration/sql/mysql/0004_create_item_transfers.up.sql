@@ -0,0 +1,15 @@
+-- Ownership transfers between users; rows are never deleted and serve as the audit trail
+CREATE TABLE IF NOT EXISTS item_transfers (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Transferred item',
+    from_user VARCHAR(64) NOT NULL COMMENT 'Owner who requested the transfer',
+    to_user VARCHAR(64) NOT NULL COMMENT 'Recipient who must accept the transfer',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, accepted, rejected, cancelled',
+    note VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Message to the recipient',
+    resolved_by VARCHAR(64) NULL COMMENT 'User who accepted, rejected or cancelled the transfer',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    resolved_at TIMESTAMP NULL COMMENT 'When the transfer left the pending status',
+
+    INDEX idx_item_id (item_id),
+    INDEX idx_to_user_status (to_user, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item ownership transfers';
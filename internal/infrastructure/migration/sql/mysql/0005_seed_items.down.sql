@@ -0,0 +1,8 @@
+DELETE FROM items
+WHERE (name, brand) IN (
+    ('ロレックス デイトナ', 'ROLEX'),
+    ('エルメス バーキン', 'HERMÈS'),
+    ('ティファニー ネックレス', 'Tiffany & Co.'),
+    ('ルブタン パンプス', 'Christian Louboutin'),
+    ('アップルウォッチ', 'Apple')
+);
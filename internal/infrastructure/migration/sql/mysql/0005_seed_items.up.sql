@@ -0,0 +1,10 @@
+-- Sample data for testing (only into an empty table, so databases created by the old init.sql keep their rows)
+INSERT INTO items (name, category, brand, purchase_price, purchase_date)
+SELECT * FROM (
+    SELECT 'ロレックス デイトナ' AS name, '時計' AS category, 'ROLEX' AS brand, 1500000 AS purchase_price, '2023-01-15' AS purchase_date
+    UNION ALL SELECT 'エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20'
+    UNION ALL SELECT 'ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10'
+    UNION ALL SELECT 'ルブタン パンプス', '靴', 'Christian Louboutin', 150000, '2023-04-05'
+    UNION ALL SELECT 'アップルウォッチ', 'その他', 'Apple', 50000, '2023-05-12'
+) AS seed
+WHERE NOT EXISTS (SELECT 1 FROM items);
@@ -0,0 +1,27 @@
+-- Webhook registrations and the delivery log of item events sent to them
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL COMMENT 'Endpoint receiving the events',
+    events VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Comma-separated event types (empty = all events)',
+    secret VARCHAR(128) NOT NULL COMMENT 'Key used to sign the payloads',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for webhook registrations';
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT NOT NULL COMMENT 'Receiving webhook',
+    event_id VARCHAR(64) NOT NULL COMMENT 'Event identifier, identical for every attempt',
+    event_type VARCHAR(32) NOT NULL COMMENT 'item.created, item.updated, item.deleted',
+    item_id BIGINT NOT NULL COMMENT 'Item the event is about',
+    payload MEDIUMTEXT NOT NULL COMMENT 'JSON body sent on every attempt',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending, succeeded, failed',
+    attempts INT NOT NULL DEFAULT 0 COMMENT 'Number of attempts made',
+    response_status INT NOT NULL DEFAULT 0 COMMENT 'HTTP status of the last attempt (0 = no response)',
+    error VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Failure reason of the last attempt',
+    next_attempt_at TIMESTAMP NULL COMMENT 'When the next attempt is due while pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    completed_at TIMESTAMP NULL COMMENT 'When the delivery succeeded or gave up',
+
+    INDEX idx_webhook_id (webhook_id, id),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for webhook delivery attempts';
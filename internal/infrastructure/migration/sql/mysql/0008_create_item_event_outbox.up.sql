@@ -0,0 +1,12 @@
+-- Item events written in the transaction of the change they describe; a relay publishes them afterwards
+CREATE TABLE IF NOT EXISTS item_event_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_type VARCHAR(32) NOT NULL COMMENT 'item.created, item.updated, item.deleted',
+    item_id BIGINT NOT NULL COMMENT 'Item the event is about',
+    payload MEDIUMTEXT NOT NULL COMMENT 'Event as JSON',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    claimed_until TIMESTAMP NULL COMMENT 'Until when a relay has reserved the event',
+    published_at TIMESTAMP NULL COMMENT 'When the event was published (NULL = pending)',
+
+    INDEX idx_published_at (published_at, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Transactional outbox for item events';
@@ -0,0 +1,8 @@
+-- Rules for emailing high-value item changes
+CREATE TABLE IF NOT EXISTS notification_rules (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    min_price INT NOT NULL DEFAULT 0 COMMENT 'Items with a purchase price at or above this are notified',
+    events VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Comma-separated event types (empty = created and deleted)',
+    recipients TEXT NOT NULL COMMENT 'Comma-separated email addresses',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for email notification rules';
@@ -0,0 +1,15 @@
+-- Items lent out to friends or galleries; rows are kept after the return as the lending history
+CREATE TABLE IF NOT EXISTS item_loans (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Lent item',
+    lender VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'User who lent the item',
+    borrower VARCHAR(100) NOT NULL COMMENT 'Person or organization holding the item',
+    due_date DATE NOT NULL COMMENT 'Date the item is due back',
+    note VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Free-form note',
+    status VARCHAR(16) NOT NULL DEFAULT 'active' COMMENT 'active, returned',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    returned_at TIMESTAMP NULL COMMENT 'When the item was returned',
+
+    INDEX idx_item_id (item_id),
+    INDEX idx_status_due_date (status, due_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item loans';
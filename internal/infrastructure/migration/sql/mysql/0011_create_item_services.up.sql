@@ -0,0 +1,13 @@
+-- Maintenance history of items (e.g. watch overhauls); item responses include the total cost
+CREATE TABLE IF NOT EXISTS item_services (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Serviced item',
+    service_date DATE NOT NULL COMMENT 'Date the item was serviced',
+    vendor VARCHAR(100) NOT NULL COMMENT 'Vendor who serviced the item',
+    cost INT NOT NULL DEFAULT 0 COMMENT 'Cost of the service',
+    notes VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Free-form notes',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record last update timestamp',
+
+    INDEX idx_item_id_service_date (item_id, service_date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item service records';
@@ -0,0 +1,13 @@
+-- Market valuations of items fetched from an external price provider, newest last
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Valued item',
+    value INT NOT NULL COMMENT 'Market value',
+    currency CHAR(3) NOT NULL COMMENT 'ISO 4217 currency code',
+    source VARCHAR(50) NOT NULL COMMENT 'Price provider',
+    sample_size INT NOT NULL DEFAULT 0 COMMENT 'Listings or sales the value was derived from',
+    quoted_at TIMESTAMP NOT NULL COMMENT 'When the provider computed the value',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id_created_at (item_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item market valuations';
@@ -0,0 +1,12 @@
+-- Images of items; the files are kept in the image storage and item responses include their IDs
+CREATE TABLE IF NOT EXISTS item_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Pictured item',
+    file_name VARCHAR(255) NOT NULL COMMENT 'File name given on upload',
+    content_type VARCHAR(50) NOT NULL COMMENT 'MIME type detected from the contents',
+    size BIGINT NOT NULL COMMENT 'File size in bytes',
+    storage_key VARCHAR(100) NOT NULL COMMENT 'Name of the file in the image storage',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item images';
@@ -0,0 +1 @@
+DROP INDEX idx_storage_key ON item_images;
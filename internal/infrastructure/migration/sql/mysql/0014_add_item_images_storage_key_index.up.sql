@@ -0,0 +1,2 @@
+-- Lets the orphaned file cleanup look up images by their storage key
+CREATE INDEX idx_storage_key ON item_images (storage_key);
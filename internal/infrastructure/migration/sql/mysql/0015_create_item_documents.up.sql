@@ -0,0 +1,14 @@
+-- Documents of items such as receipts and authenticity certificates; the files are kept in the media storage
+CREATE TABLE IF NOT EXISTS item_documents (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Documented item',
+    type VARCHAR(20) NOT NULL COMMENT 'receipt, certificate or appraisal',
+    file_name VARCHAR(255) NOT NULL COMMENT 'File name given on upload',
+    content_type VARCHAR(50) NOT NULL COMMENT 'MIME type detected from the contents',
+    size BIGINT NOT NULL COMMENT 'File size in bytes',
+    storage_key VARCHAR(100) NOT NULL COMMENT 'Name of the file in the media storage',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id),
+    INDEX idx_storage_key (storage_key)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item documents';
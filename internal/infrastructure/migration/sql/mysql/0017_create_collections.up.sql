@@ -0,0 +1,20 @@
+-- User-defined groups of items, and the items of each group
+CREATE TABLE IF NOT EXISTS collections (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    owner_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'User who created the collection',
+    name VARCHAR(100) NOT NULL COMMENT 'Collection name',
+    description VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'Free-form description',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Last update timestamp',
+
+    INDEX idx_owner_id (owner_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for collections';
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id BIGINT NOT NULL COMMENT 'Collection',
+    item_id BIGINT NOT NULL COMMENT 'Item in the collection',
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the item was added',
+
+    PRIMARY KEY (collection_id, item_id),
+    INDEX idx_item_id (item_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the items of collections';
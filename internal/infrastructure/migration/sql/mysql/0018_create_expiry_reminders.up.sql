@@ -0,0 +1,21 @@
+-- Expiry reminders sent for date-typed custom attributes, so each reminder stage is sent once per expiry date
+CREATE TABLE IF NOT EXISTS expiry_reminders (
+    item_id BIGINT NOT NULL COMMENT 'Item the reminder was sent for',
+    attr_key VARCHAR(50) NOT NULL COMMENT 'Date-typed custom attribute holding the expiry date',
+    expires_on DATE NOT NULL COMMENT 'Expiry date the reminder was sent for',
+    days_left INT NOT NULL COMMENT 'Days until the expiry date when the reminder was sent',
+    reminded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the reminder was sent',
+
+    PRIMARY KEY (item_id, attr_key, expires_on),
+    INDEX idx_expires_on (expires_on)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for sent expiry reminders';
+
+-- Items whose expiry reminders are paused until a date
+CREATE TABLE IF NOT EXISTS reminder_snoozes (
+    item_id BIGINT PRIMARY KEY COMMENT 'Item whose reminders are paused',
+    snoozed_until DATE NOT NULL COMMENT 'Date reminders resume',
+    snoozed_by VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'User who paused the reminders',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_snoozed_until (snoozed_until)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for paused expiry reminders';
@@ -0,0 +1,10 @@
+-- Snapshots of items taken at every create and update, for the change history and rollbacks
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the snapshot was taken of',
+    revision BIGINT NOT NULL COMMENT 'Item version at the time of the snapshot',
+    snapshot TEXT NOT NULL COMMENT 'Item contents as JSON',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX idx_item_id_revision (item_id, revision)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item revisions';
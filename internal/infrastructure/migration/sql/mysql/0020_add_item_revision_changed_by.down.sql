@@ -0,0 +1 @@
+ALTER TABLE item_revisions DROP COLUMN changed_by;
@@ -0,0 +1,2 @@
+DROP INDEX idx_deleted_at ON items;
+ALTER TABLE items DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+-- Items moved to the trash keep their row until they are purged; NULL for items not in the trash
+ALTER TABLE items ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL AFTER updated_at;
+CREATE INDEX idx_deleted_at ON items (deleted_at);
@@ -0,0 +1,9 @@
+-- Accounts for the admin server, created with the create-admin command
+CREATE TABLE IF NOT EXISTS admin_users (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Login name, unique regardless of case',
+    password_hash VARCHAR(100) NOT NULL COMMENT 'bcrypt hash of the password',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX idx_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for admin server accounts';
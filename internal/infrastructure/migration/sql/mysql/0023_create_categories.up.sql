@@ -0,0 +1,8 @@
+-- Per-tenant categories added to the built-in ones (時計, バッグ, ジュエリー, 靴, その他)
+CREATE TABLE IF NOT EXISTS categories (
+    tenant_id VARCHAR(64) NOT NULL COMMENT 'Tenant identifier (X-Tenant-ID header)',
+    name VARCHAR(50) NOT NULL COMMENT 'Category name used in items.category',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (tenant_id, name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for per-tenant categories';
@@ -0,0 +1,36 @@
+-- Canonical brand names and their aliases; item brands are normalized to the canonical names
+CREATE TABLE IF NOT EXISTS brands (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL COMMENT 'Canonical brand name stored in items.brand',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE INDEX idx_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for brands';
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    brand_id BIGINT NOT NULL COMMENT 'Brand',
+    alias VARCHAR(100) NOT NULL COMMENT 'Other spelling of the brand name',
+
+    PRIMARY KEY (brand_id, alias)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the aliases of brands';
+
+-- Brands of the sample data
+INSERT INTO brands (name) VALUES
+    ('ROLEX'),
+    ('HERMÈS'),
+    ('Tiffany & Co.'),
+    ('Christian Louboutin'),
+    ('Apple');
+
+INSERT INTO brand_aliases (brand_id, alias)
+SELECT b.id, a.alias FROM brands b JOIN (
+    SELECT 'ROLEX' AS name, 'ロレックス' AS alias
+    UNION ALL SELECT 'HERMÈS', 'Hermes'
+    UNION ALL SELECT 'HERMÈS', 'エルメス'
+    UNION ALL SELECT 'Tiffany & Co.', 'Tiffany'
+    UNION ALL SELECT 'Tiffany & Co.', 'ティファニー'
+    UNION ALL SELECT 'Christian Louboutin', 'Louboutin'
+    UNION ALL SELECT 'Christian Louboutin', 'ルブタン'
+    UNION ALL SELECT 'Apple', 'アップル'
+) a ON a.name = b.name;
@@ -0,0 +1,3 @@
+ALTER TABLE items
+    DROP COLUMN currency,
+    MODIFY COLUMN purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen';
@@ -0,0 +1,5 @@
+-- Purchase prices are stored in the minor units of the item's currency (1 yen, 1 cent);
+-- existing prices are yen, which has no minor units, so they are kept as they are
+ALTER TABLE items
+    MODIFY COLUMN purchase_price BIGINT NOT NULL DEFAULT 0 COMMENT 'Purchase price in the minor units of currency',
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency of purchase_price' AFTER purchase_price;
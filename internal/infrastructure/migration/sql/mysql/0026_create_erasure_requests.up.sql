@@ -0,0 +1,8 @@
+-- Requests of users to erase their data, carried out by the retention job once the grace period has passed
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    user_id VARCHAR(64) PRIMARY KEY COMMENT 'User whose data is to be erased',
+    requested_at TIMESTAMP NOT NULL COMMENT 'When the erasure was requested',
+    erase_after TIMESTAMP NOT NULL COMMENT 'End of the grace period, until which the request can be cancelled',
+
+    INDEX idx_erase_after (erase_after)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for pending data erasure requests';
@@ -0,0 +1,2 @@
+ALTER TABLE item_documents DROP COLUMN sha256;
+ALTER TABLE item_images DROP COLUMN sha256;
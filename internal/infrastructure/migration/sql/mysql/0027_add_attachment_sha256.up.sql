@@ -0,0 +1,6 @@
+-- Files are stored under the SHA-256 of their contents, so identical uploads share one file;
+-- files uploaded before have no hash and keep their random names
+ALTER TABLE item_images
+    ADD COLUMN sha256 VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'Hex SHA-256 of the file contents' AFTER size;
+ALTER TABLE item_documents
+    ADD COLUMN sha256 VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'Hex SHA-256 of the file contents' AFTER size;
@@ -0,0 +1,11 @@
+-- Serial numbers, barcodes and QR codes of items, to find an item by scanning one of them.
+-- Codes are normalized for their kind before they are stored, and compared byte for byte.
+CREATE TABLE IF NOT EXISTS item_codes (
+    item_id BIGINT NOT NULL COMMENT 'Item the code belongs to',
+    type VARCHAR(20) NOT NULL COMMENT 'Kind of code: serial, ean or qr',
+    code VARCHAR(255) NOT NULL COLLATE utf8mb4_bin COMMENT 'Code, normalized for its kind',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (item_id, type, code),
+    INDEX idx_code (code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the scannable codes of items';
@@ -0,0 +1,3 @@
+ALTER TABLE item_event_outbox
+    DROP INDEX idx_item_id,
+    DROP INDEX idx_created_at;
@@ -0,0 +1,4 @@
+-- Published events are kept for OUTBOX_RETENTION so they can be replayed to a sink by time range or item
+ALTER TABLE item_event_outbox
+    ADD INDEX idx_created_at (created_at, id),
+    ADD INDEX idx_item_id (item_id, id);
@@ -0,0 +1,12 @@
+-- Value of the items per category, recorded every night to chart how the value of the collection evolves.
+-- The overall value of a day is the sum of its rows; a day's rows are replaced when it is recorded again.
+CREATE TABLE IF NOT EXISTS portfolio_snapshots (
+    taken_on DATE NOT NULL COMMENT 'Day the snapshot was taken',
+    category VARCHAR(50) NOT NULL COMMENT 'Category of the items',
+    item_count INT NOT NULL COMMENT 'Items in the category',
+    value BIGINT NOT NULL COMMENT 'Latest valuations of the items, or their purchase prices if never valued',
+    purchase_total BIGINT NOT NULL COMMENT 'Purchase prices of the items',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (taken_on, category)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the nightly portfolio value snapshots';
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_id TEXT PRIMARY KEY,
+    sort_by TEXT NOT NULL DEFAULT 'created_at',
+    sort_order TEXT NOT NULL DEFAULT 'desc',
+    page_size INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS custom_attributes (
+    tenant_id TEXT NOT NULL,
+    attr_key TEXT NOT NULL,
+    label TEXT NOT NULL,
+    attr_type TEXT NOT NULL DEFAULT 'enum',
+    options TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, attr_key)
+);
+
+-- MySQL の ON UPDATE CURRENT_TIMESTAMP 相当
+CREATE TRIGGER IF NOT EXISTS trg_custom_attributes_updated_at
+AFTER UPDATE ON custom_attributes
+BEGIN
+    UPDATE custom_attributes SET updated_at = CURRENT_TIMESTAMP
+    WHERE tenant_id = NEW.tenant_id AND attr_key = NEW.attr_key;
+END;
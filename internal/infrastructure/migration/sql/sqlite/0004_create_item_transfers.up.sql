@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    from_user TEXT NOT NULL,
+    to_user TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    note TEXT NOT NULL DEFAULT '',
+    resolved_by TEXT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    resolved_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_item_transfers_item_id ON item_transfers (item_id);
+CREATE INDEX IF NOT EXISTS idx_item_transfers_to_user_status ON item_transfers (to_user, status);
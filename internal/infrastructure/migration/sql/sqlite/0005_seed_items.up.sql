@@ -0,0 +1,10 @@
+-- Sample data (only into an empty table)
+INSERT INTO items (name, category, brand, purchase_price, purchase_date)
+SELECT * FROM (VALUES
+    ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),
+    ('エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20'),
+    ('ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10'),
+    ('ルブタン パンプス', '靴', 'Christian Louboutin', 150000, '2023-04-05'),
+    ('アップルウォッチ', 'その他', 'Apple', 50000, '2023-05-12')
+)
+WHERE NOT EXISTS (SELECT 1 FROM items);
@@ -0,0 +1,2 @@
+-- Version counter for optimistic locking; incremented on every update of the item
+ALTER TABLE items ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
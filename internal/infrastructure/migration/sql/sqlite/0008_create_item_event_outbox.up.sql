@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS item_event_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    item_id INTEGER NOT NULL,
+    payload TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    claimed_until DATETIME NULL,
+    published_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_item_event_outbox_published_at ON item_event_outbox (published_at, id);
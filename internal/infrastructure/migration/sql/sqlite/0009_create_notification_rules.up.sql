@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS notification_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    min_price INTEGER NOT NULL DEFAULT 0,
+    events TEXT NOT NULL DEFAULT '',
+    recipients TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_loans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    lender TEXT NOT NULL DEFAULT '',
+    borrower TEXT NOT NULL,
+    due_date DATE NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    returned_at DATETIME NULL
+);
+CREATE INDEX IF NOT EXISTS idx_item_loans_item_id ON item_loans (item_id);
+CREATE INDEX IF NOT EXISTS idx_item_loans_status_due_date ON item_loans (status, due_date);
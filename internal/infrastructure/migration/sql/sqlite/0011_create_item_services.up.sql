@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS item_services (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    service_date DATE NOT NULL,
+    vendor TEXT NOT NULL,
+    cost INTEGER NOT NULL DEFAULT 0,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_services_item_id_service_date ON item_services (item_id, service_date);
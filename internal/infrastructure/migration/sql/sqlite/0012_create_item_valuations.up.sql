@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    currency TEXT NOT NULL,
+    source TEXT NOT NULL,
+    sample_size INTEGER NOT NULL DEFAULT 0,
+    quoted_at DATETIME NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_valuations_item_id_created_at ON item_valuations (item_id, created_at);
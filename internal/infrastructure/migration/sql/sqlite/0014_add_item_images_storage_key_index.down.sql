@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_item_images_storage_key;
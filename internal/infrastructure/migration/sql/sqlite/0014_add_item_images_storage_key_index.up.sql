@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_item_images_storage_key ON item_images (storage_key);
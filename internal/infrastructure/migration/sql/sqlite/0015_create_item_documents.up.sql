@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS item_documents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_item_documents_item_id ON item_documents (item_id);
+CREATE INDEX IF NOT EXISTS idx_item_documents_storage_key ON item_documents (storage_key);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reminder_snoozes;
+DROP TABLE IF EXISTS expiry_reminders;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS expiry_reminders (
+    item_id INTEGER NOT NULL,
+    attr_key TEXT NOT NULL,
+    expires_on DATE NOT NULL,
+    days_left INTEGER NOT NULL,
+    reminded_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, attr_key, expires_on)
+);
+CREATE INDEX IF NOT EXISTS idx_expiry_reminders_expires_on ON expiry_reminders (expires_on);
+
+CREATE TABLE IF NOT EXISTS reminder_snoozes (
+    item_id INTEGER PRIMARY KEY,
+    snoozed_until DATE NOT NULL,
+    snoozed_by TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_reminder_snoozes_snoozed_until ON reminder_snoozes (snoozed_until);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS item_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,
+    snapshot TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_item_revisions_item_id_revision ON item_revisions (item_id, revision);
@@ -0,0 +1,2 @@
+-- User who made the change recorded by the revision, for the change feed of the item
+ALTER TABLE item_revisions ADD COLUMN changed_by TEXT NOT NULL DEFAULT '';
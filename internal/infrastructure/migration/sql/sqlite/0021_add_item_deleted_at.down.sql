@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_items_deleted_at;
+ALTER TABLE items DROP COLUMN deleted_at;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS categories (
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, name)
+);
@@ -0,0 +1,34 @@
+-- MySQL の照合順序（utf8mb4_unicode_ci）と同じく、正式名は大文字・小文字を区別せず一意にする
+CREATE TABLE IF NOT EXISTS brands (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL COLLATE NOCASE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_brands_name ON brands (name);
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    brand_id INTEGER NOT NULL,
+    alias TEXT NOT NULL,
+    PRIMARY KEY (brand_id, alias)
+);
+
+-- サンプルデータのブランド
+INSERT INTO brands (name) VALUES
+    ('ROLEX'),
+    ('HERMÈS'),
+    ('Tiffany & Co.'),
+    ('Christian Louboutin'),
+    ('Apple');
+
+INSERT INTO brand_aliases (brand_id, alias)
+SELECT b.id, a.alias FROM brands b JOIN (
+    SELECT 'ROLEX' AS name, 'ロレックス' AS alias
+    UNION ALL SELECT 'HERMÈS', 'Hermes'
+    UNION ALL SELECT 'HERMÈS', 'エルメス'
+    UNION ALL SELECT 'Tiffany & Co.', 'Tiffany'
+    UNION ALL SELECT 'Tiffany & Co.', 'ティファニー'
+    UNION ALL SELECT 'Christian Louboutin', 'Louboutin'
+    UNION ALL SELECT 'Christian Louboutin', 'ルブタン'
+    UNION ALL SELECT 'Apple', 'アップル'
+) a ON a.name = b.name;
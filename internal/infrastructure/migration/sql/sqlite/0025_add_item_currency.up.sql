@@ -0,0 +1,3 @@
+-- Purchase prices are stored in the minor units of the item's currency (1 yen, 1 cent);
+-- existing prices are yen, which has no minor units, so they are kept as they are
+ALTER TABLE items ADD COLUMN currency TEXT NOT NULL DEFAULT 'JPY';
@@ -0,0 +1,7 @@
+-- ユーザーのデータの削除依頼。猶予期間を過ぎると保持期間のジョブが削除する
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    user_id TEXT PRIMARY KEY,
+    requested_at DATETIME NOT NULL,
+    erase_after DATETIME NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_erase_after ON erasure_requests (erase_after);
@@ -0,0 +1,10 @@
+-- アイテムのシリアル番号・バーコード・QR コード。読み取ったコードからアイテムを探す。
+-- コードは種類ごとに正規化して保存し、そのまま（大文字・小文字を区別して）比べる
+CREATE TABLE IF NOT EXISTS item_codes (
+    item_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    code TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, type, code)
+);
+CREATE INDEX IF NOT EXISTS idx_item_codes_code ON item_codes (code);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_item_event_outbox_item_id;
+DROP INDEX IF EXISTS idx_item_event_outbox_created_at;
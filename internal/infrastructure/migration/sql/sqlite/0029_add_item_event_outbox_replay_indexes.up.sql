@@ -0,0 +1,3 @@
+-- Published events are kept for OUTBOX_RETENTION so they can be replayed to a sink by time range or item
+CREATE INDEX IF NOT EXISTS idx_item_event_outbox_created_at ON item_event_outbox (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_item_event_outbox_item_id ON item_event_outbox (item_id, id);
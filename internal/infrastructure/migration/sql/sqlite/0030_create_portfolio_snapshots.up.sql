@@ -0,0 +1,11 @@
+-- カテゴリーごとのアイテムの評価額。毎晩記録し、コレクションの評価額の推移を表示する。
+-- その日の全体の評価額は行の合計。同じ日に記録し直すとその日の行を置き換える
+CREATE TABLE IF NOT EXISTS portfolio_snapshots (
+    taken_on DATE NOT NULL,
+    category TEXT NOT NULL,
+    item_count INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    purchase_total INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (taken_on, category)
+);
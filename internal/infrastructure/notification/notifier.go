@@ -0,0 +1,239 @@
+// Package notification は高額アイテムの登録・削除と期限の接近を、通知ルールの宛先にメールで知らせる。
+//
+// 件名と本文は templates/<イベントの種類>.tmpl の "subject" / "body" テンプレートで作る。
+// NOTIFICATION_TEMPLATE_DIR に同じ名前のファイルを置くと、そのイベントのテンプレートを差し替えられる。
+// メールは送信に失敗しても再送しない（失敗数は /debug/vars の notification_send_errors）。
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"expvar"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/estate"
+	"Aicon-assignment/internal/usecase"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+// 通知するイベント
+var EventTypes = []string{usecase.ItemCreated, usecase.ItemDeleted, usecase.ItemExpiring}
+
+const (
+	// 送信を待つイベントのバッファサイズ
+	DefaultBufferSize = 256
+	// 通知ルールの読み込みの期限
+	storeTimeout = 5 * time.Second
+)
+
+var (
+	droppedCount   = expvar.NewInt("notification_events_dropped")
+	sentCount      = expvar.NewInt("notifications_sent")
+	sendErrorCount = expvar.NewInt("notification_send_errors")
+)
+
+type Config struct {
+	TemplateDir string // テンプレートを差し替えるディレクトリ（空なら組み込みのテンプレートのみ）
+	BufferSize  int
+}
+
+// テンプレートに渡すデータ
+type templateData struct {
+	Event      string
+	Item       *entity.Item
+	Rule       *entity.NotificationRule
+	Expiry     *entity.ExpiryReminder // item.expiring のときだけ
+	OccurredAt time.Time
+}
+
+var templateFuncs = template.FuncMap{
+	"yen": estate.FormatYen,
+	"datetime": func(t time.Time) string {
+		return t.Local().Format("2006-01-02 15:04:05 MST")
+	},
+}
+
+// Notifier はアイテムイベントを受け取り（usecase.ItemEventPublisher の実装）、当てはまる通知ルールの宛先にメールを送る
+type Notifier struct {
+	repo      usecase.NotificationRuleRepository
+	mailer    Mailer
+	templates map[string]*template.Template
+	events    chan usecase.ItemEvent
+	logf      func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewNotifier(repo usecase.NotificationRuleRepository, mailer Mailer, cfg Config) (*Notifier, error) {
+	templates, err := loadTemplates(cfg.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Notifier{
+		repo:      repo,
+		mailer:    mailer,
+		templates: templates,
+		events:    make(chan usecase.ItemEvent, bufferSize),
+		logf:      log.Printf,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// イベントの種類ごとのテンプレートを読み込む。dir にあるファイルは組み込みのものより優先する
+func loadTemplates(dir string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template, len(EventTypes))
+	for _, eventType := range EventTypes {
+		name := eventType + ".tmpl"
+		var fsys fs.FS
+		if dir != "" {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				fsys = os.DirFS(dir)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read notification template %s: %w", name, err)
+			}
+		}
+		if fsys == nil {
+			sub, err := fs.Sub(embedded, "templates")
+			if err != nil {
+				return nil, err
+			}
+			fsys = sub
+		}
+
+		t, err := template.New(name).Funcs(templateFuncs).ParseFS(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse notification template %s: %w", name, err)
+		}
+		for _, required := range []string{"subject", "body"} {
+			if t.Lookup(required) == nil {
+				return nil, fmt.Errorf("notification template %s must define %q", name, required)
+			}
+		}
+		templates[eventType] = t
+	}
+	return templates, nil
+}
+
+// イベントを送信の待ち行列に入れる。バッファが一杯ならイベントを破棄する（破棄数は /debug/vars の notification_events_dropped）
+func (n *Notifier) Publish(_ context.Context, event usecase.ItemEvent) {
+	if n.templates[event.Type] == nil || event.Item == nil {
+		return
+	}
+	select {
+	case n.events <- event:
+	default:
+		droppedCount.Add(1)
+	}
+}
+
+// 送信を開始する。Close まで戻らないので goroutine で呼び出す
+func (n *Notifier) Run() {
+	defer close(n.done)
+
+	for {
+		select {
+		case event := <-n.events:
+			n.notify(event)
+		case <-n.ctx.Done():
+			// 受け取り済みのイベントは送信してから終わる
+			for {
+				select {
+				case event := <-n.events:
+					n.notify(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// 送信を停止し、Run が終わるまで待つ
+func (n *Notifier) Close() {
+	n.once.Do(n.cancel)
+	<-n.done
+}
+
+// イベントに当てはまるルールごとにメールを送る。複数のルールに含まれる宛先には1通だけ送る
+func (n *Notifier) notify(event usecase.ItemEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	rules, err := n.repo.FindAll(ctx)
+	cancel()
+	if err != nil {
+		n.logf("⚠️  notification: failed to load notification rules, %s event for item %d is not notified: %v", event.Type, event.ItemID, err)
+		return
+	}
+
+	notified := make(map[string]bool)
+	for _, rule := range rules {
+		if !rule.Matches(event.Type, event.Item.PurchasePrice) {
+			continue
+		}
+		var to []string
+		for _, recipient := range rule.Recipients {
+			if !notified[strings.ToLower(recipient)] {
+				notified[strings.ToLower(recipient)] = true
+				to = append(to, recipient)
+			}
+		}
+		if len(to) == 0 {
+			continue
+		}
+
+		mail, err := n.render(event, rule)
+		if err != nil {
+			sendErrorCount.Add(1)
+			n.logf("⚠️  notification: failed to render %s email for item %d: %v", event.Type, event.ItemID, err)
+			return
+		}
+		mail.To = to
+		if err := n.mailer.Send(context.Background(), mail); err != nil {
+			sendErrorCount.Add(1)
+			n.logf("⚠️  notification: failed to send %s email for item %d (rule %d): %v", event.Type, event.ItemID, rule.ID, err)
+			continue
+		}
+		sentCount.Add(1)
+	}
+}
+
+func (n *Notifier) render(event usecase.ItemEvent, rule *entity.NotificationRule) (Mail, error) {
+	t := n.templates[event.Type]
+	data := templateData{Event: event.Type, Item: event.Item, Rule: rule, Expiry: event.Expiry, OccurredAt: event.OccurredAt}
+
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Mail{}, err
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return Mail{}, err
+	}
+	// 件名はヘッダーに入れるため1行にする
+	return Mail{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    body.String(),
+	}, nil
+}
@@ -0,0 +1,240 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeRepository はメモリ上の通知ルール
+type fakeRepository struct {
+	rules []*entity.NotificationRule
+	err   error
+}
+
+func (r *fakeRepository) Create(_ context.Context, rule *entity.NotificationRule) (*entity.NotificationRule, error) {
+	return rule, nil
+}
+
+func (r *fakeRepository) FindAll(context.Context) ([]*entity.NotificationRule, error) {
+	return r.rules, r.err
+}
+
+func (r *fakeRepository) FindByID(context.Context, int64) (*entity.NotificationRule, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeRepository) Delete(context.Context, int64) error {
+	return nil
+}
+
+// recordingMailer は送信されたメールを記録する
+type recordingMailer struct {
+	mu    sync.Mutex
+	mails []Mail
+	err   error
+}
+
+func (m *recordingMailer) Send(_ context.Context, mail Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.mails = append(m.mails, mail)
+	return nil
+}
+
+func (m *recordingMailer) sent() []Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Mail(nil), m.mails...)
+}
+
+func newTestNotifier(t *testing.T, repo usecase.NotificationRuleRepository, mailer Mailer, cfg Config) *Notifier {
+	t.Helper()
+	n, err := NewNotifier(repo, mailer, cfg)
+	require.NoError(t, err)
+	n.logf = t.Logf
+	return n
+}
+
+func testEvent(eventType string, price int) usecase.ItemEvent {
+	return usecase.ItemEvent{
+		Type:   eventType,
+		ItemID: 1,
+		Item: &entity.Item{
+			ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX",
+			PurchasePrice: price, PurchaseDate: "2023-01-15",
+		},
+		OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: しきい値以上のアイテムの登録を通知する", func(t *testing.T) {
+		repo := &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, MinPrice: 1000000, Recipients: []string{"owner@example.com"}},
+		}}
+		mailer := &recordingMailer{}
+		n := newTestNotifier(t, repo, mailer, Config{})
+		go n.Run()
+
+		n.Publish(ctx, testEvent(usecase.ItemCreated, 1500000))
+		n.Publish(ctx, testEvent(usecase.ItemCreated, 999999))
+		n.Close()
+
+		mails := mailer.sent()
+		require.Len(t, mails, 1)
+		assert.Equal(t, []string{"owner@example.com"}, mails[0].To)
+		assert.Equal(t, "【高額アイテム登録】ロレックス デイトナ（¥1,500,000）", mails[0].Subject)
+		assert.Contains(t, mails[0].Body, "購入価格: ¥1,500,000")
+		assert.Contains(t, mails[0].Body, "通知ルール #1（¥1,000,000以上のアイテム）")
+		assert.NotContains(t, mails[0].Body, "所有者", "所有者がなければ行を省く")
+	})
+
+	t.Run("正常系: 削除は削除用のテンプレートで通知する", func(t *testing.T) {
+		repo := &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, MinPrice: 0, Events: []string{usecase.ItemDeleted}, Recipients: []string{"owner@example.com"}},
+		}}
+		mailer := &recordingMailer{}
+		n := newTestNotifier(t, repo, mailer, Config{})
+		go n.Run()
+
+		n.Publish(ctx, testEvent(usecase.ItemCreated, 1500000))
+		n.Publish(ctx, testEvent(usecase.ItemDeleted, 1500000))
+		n.Close()
+
+		mails := mailer.sent()
+		require.Len(t, mails, 1)
+		assert.True(t, strings.HasPrefix(mails[0].Subject, "【高額アイテム削除】"))
+	})
+
+	t.Run("正常系: 期限の接近は属性と残りの日数を知らせる", func(t *testing.T) {
+		repo := &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, MinPrice: 0, Recipients: []string{"owner@example.com"}},
+		}}
+		mailer := &recordingMailer{}
+		n := newTestNotifier(t, repo, mailer, Config{})
+		event := testEvent(usecase.ItemExpiring, 1500000)
+		event.Expiry = &entity.ExpiryReminder{ItemID: 1, Attribute: "warranty_expires", ExpiresOn: "2024-02-01", DaysLeft: 30}
+
+		n.notify(event)
+
+		mails := mailer.sent()
+		require.Len(t, mails, 1)
+		assert.Equal(t, "【期限のお知らせ】ロレックス デイトナのwarranty_expiresまであと30日", mails[0].Subject)
+		assert.Contains(t, mails[0].Body, "期限（warranty_expires）: 2024-02-01（あと30日）")
+		assert.Contains(t, mails[0].Body, "POST /items/1/reminders/snooze")
+	})
+
+	t.Run("正常系: 複数のルールに含まれる宛先には1通だけ送る", func(t *testing.T) {
+		repo := &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, MinPrice: 100, Recipients: []string{"a@example.com", "b@example.com"}},
+			{ID: 2, MinPrice: 200, Recipients: []string{"B@example.com", "c@example.com"}},
+		}}
+		mailer := &recordingMailer{}
+		n := newTestNotifier(t, repo, mailer, Config{})
+
+		n.notify(testEvent(usecase.ItemCreated, 500))
+
+		mails := mailer.sent()
+		require.Len(t, mails, 2)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, mails[0].To)
+		assert.Equal(t, []string{"c@example.com"}, mails[1].To)
+	})
+
+	t.Run("正常系: 更新とアイテムのないイベントは受け取らない", func(t *testing.T) {
+		n := newTestNotifier(t, &fakeRepository{}, &recordingMailer{}, Config{})
+
+		n.Publish(ctx, testEvent(usecase.ItemUpdated, 1500000))
+		n.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1})
+
+		assert.Empty(t, n.events)
+	})
+
+	t.Run("異常系: 送信に失敗しても他のルールの宛先には送る", func(t *testing.T) {
+		repo := &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, Recipients: []string{"a@example.com"}},
+			{ID: 2, Recipients: []string{"b@example.com"}},
+		}}
+		mailer := &recordingMailer{err: errors.New("connection refused")}
+		n := newTestNotifier(t, repo, mailer, Config{})
+
+		errorsBefore := sendErrorCount.Value()
+		n.notify(testEvent(usecase.ItemCreated, 500))
+
+		assert.Equal(t, errorsBefore+2, sendErrorCount.Value())
+	})
+}
+
+func TestLoadTemplates(t *testing.T) {
+	t.Run("正常系: ディレクトリのテンプレートで差し替える", func(t *testing.T) {
+		dir := t.TempDir()
+		custom := `{{define "subject"}}New: {{.Item.Name}}{{end}}{{define "body"}}{{yen .Item.PurchasePrice}}{{end}}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "item.created.tmpl"), []byte(custom), 0o644))
+		mailer := &recordingMailer{}
+		n := newTestNotifier(t, &fakeRepository{rules: []*entity.NotificationRule{
+			{ID: 1, Recipients: []string{"owner@example.com"}},
+		}}, mailer, Config{TemplateDir: dir})
+
+		n.notify(testEvent(usecase.ItemCreated, 1500000))
+		n.notify(testEvent(usecase.ItemDeleted, 1500000))
+
+		mails := mailer.sent()
+		require.Len(t, mails, 2)
+		assert.Equal(t, "New: ロレックス デイトナ", mails[0].Subject)
+		assert.Equal(t, "¥1,500,000", mails[0].Body)
+		assert.True(t, strings.HasPrefix(mails[1].Subject, "【高額アイテム削除】"), "ないファイルは組み込みのテンプレート")
+	})
+
+	t.Run("異常系: subject のないテンプレート", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "item.deleted.tmpl"), []byte(`{{define "body"}}x{{end}}`), 0o644))
+
+		_, err := NewNotifier(&fakeRepository{}, &recordingMailer{}, Config{TemplateDir: dir})
+
+		assert.ErrorContains(t, err, `must define "subject"`)
+	})
+}
+
+func TestBuildMessage(t *testing.T) {
+	msg, err := buildMessage("inventory@example.com", Mail{
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "【高額アイテム登録】時計",
+		Body:    "購入価格: ¥1,500,000\n",
+	}, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	header, body, found := strings.Cut(string(msg), "\r\n\r\n")
+	require.True(t, found)
+	assert.Contains(t, header, "From: inventory@example.com\r\n")
+	assert.Contains(t, header, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, header, "Content-Type: text/plain; charset=UTF-8\r\n")
+	assert.Contains(t, header, "Message-ID: <1704164645.")
+
+	var subject string
+	for _, line := range strings.Split(header, "\r\n") {
+		if value, ok := strings.CutPrefix(line, "Subject: "); ok {
+			subject, err = new(mime.WordDecoder).DecodeHeader(value)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, "【高額アイテム登録】時計", subject)
+	assert.True(t, strings.HasSuffix(body, "\r\n"), "本文の改行は CRLF")
+	assert.NotContains(t, body, "購入価格", "本文は quoted-printable")
+}
@@ -0,0 +1,106 @@
+// Package ocr は外部の OCR サービスで画像の文字を読み取る。
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 画像をそのまま送り、読み取った文字列を JSON で受け取る汎用の OCR サービス（社内の Tesseract サーバーなど）
+//
+//	POST {url}
+//	Content-Type: image/png
+//	Authorization: Bearer {apiKey}
+//
+// 応答: {"text": "ROLEX ブティック銀座\n2024年3月9日\n..."}
+type HTTPProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewHTTPProvider(url, apiKey string, timeout time.Duration) *HTTPProvider {
+	return &HTTPProvider{url: url, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+type textResponse struct {
+	Text string `json:"text"`
+}
+
+func (p *HTTPProvider) RecognizeText(ctx context.Context, image []byte, contentType string) (string, error) {
+	header := http.Header{"Content-Type": {contentType}}
+	if p.apiKey != "" {
+		header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	var resp textResponse
+	if err := post(ctx, p.client, p.url, header, image, &resp); err != nil {
+		return "", err
+	}
+	return normalizeNewlines(resp.Text), nil
+}
+
+// 提供元に POST し、200 の JSON の応答を out に読み込む。それ以外は ErrOCRUnavailable（タイムアウトは ErrOCRTimeout）
+func post(ctx context.Context, client *http.Client, url string, header http.Header, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrOCRUnavailable, err)
+	}
+	req.Header = header
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return providerError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// 429 や 5xx を含め、提供元を使えない状態として扱う
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("%w: provider responded %s", domainErrors.ErrOCRUnavailable, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		if isTimeout(err) {
+			return fmt.Errorf("%w: %w", domainErrors.ErrOCRTimeout, err)
+		}
+		return fmt.Errorf("%w: invalid response: %w", domainErrors.ErrOCRUnavailable, err)
+	}
+	return nil
+}
+
+func providerError(err error) error {
+	if isTimeout(err) {
+		return fmt.Errorf("%w: %w", domainErrors.ErrOCRTimeout, err)
+	}
+	return fmt.Errorf("%w: %w", domainErrors.ErrOCRUnavailable, err)
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr interface{ Timeout() bool }
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// 読み取った文字列の改行を揃える
+func normalizeNewlines(text string) string {
+	return strings.ReplaceAll(text, "\r\n", "\n")
+}
+
+// OCR サービスが設定されていない場合の提供元
+type DisabledProvider struct{}
+
+func (DisabledProvider) RecognizeText(ctx context.Context, image []byte, contentType string) (string, error) {
+	return "", fmt.Errorf("%w: no OCR provider is configured", domainErrors.ErrOCRUnavailable)
+}
@@ -0,0 +1,173 @@
+package ocr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var testImage = []byte("\x89PNG\r\n\x1a\n")
+
+func TestHTTPProvider_RecognizeText(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		delay        time.Duration
+		expectedText string
+		expectedErr  error
+	}{
+		{
+			name:         "正常系: 読み取った文字列を返す",
+			status:       http.StatusOK,
+			body:         `{"text": "ROLEX\r\n合計 ¥2,500,000"}`,
+			expectedText: "ROLEX\n合計 ¥2,500,000",
+		},
+		{
+			name:        "異常系: レート制限",
+			status:      http.StatusTooManyRequests,
+			expectedErr: domainErrors.ErrOCRUnavailable,
+		},
+		{
+			name:        "異常系: 不正な応答",
+			status:      http.StatusOK,
+			body:        `<html>`,
+			expectedErr: domainErrors.ErrOCRUnavailable,
+		},
+		{
+			name:        "異常系: タイムアウト",
+			status:      http.StatusOK,
+			body:        `{}`,
+			delay:       200 * time.Millisecond,
+			expectedErr: domainErrors.ErrOCRTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				if tt.delay > 0 {
+					select {
+					case <-time.After(tt.delay):
+					case <-r.Context().Done():
+						return
+					}
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			text, err := NewHTTPProvider(server.URL+"/ocr", "secret", 50*time.Millisecond).RecognizeText(context.Background(), testImage, "image/png")
+
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "/ocr", received.URL.Path)
+			assert.Equal(t, "image/png", received.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer secret", received.Header.Get("Authorization"))
+			assert.Equal(t, testImage, receivedBody)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, text)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedText, text)
+		})
+	}
+
+	t.Run("異常系: 接続できない", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		_, err := NewHTTPProvider(server.URL, "", time.Second).RecognizeText(context.Background(), testImage, "image/png")
+
+		assert.ErrorIs(t, err, domainErrors.ErrOCRUnavailable)
+	})
+}
+
+func TestGoogleVisionProvider_RecognizeText(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		expectedText string
+		expectedErr  error
+	}{
+		{
+			name:         "正常系: 読み取った文字列を返す",
+			status:       http.StatusOK,
+			body:         `{"responses": [{"fullTextAnnotation": {"text": "ROLEX\n合計 ¥2,500,000\n"}}]}`,
+			expectedText: "ROLEX\n合計 ¥2,500,000\n",
+		},
+		{
+			name:         "正常系: 文字がない画像",
+			status:       http.StatusOK,
+			body:         `{"responses": [{}]}`,
+			expectedText: "",
+		},
+		{
+			name:        "異常系: 画像ごとのエラー",
+			status:      http.StatusOK,
+			body:        `{"responses": [{"error": {"code": 3, "message": "Bad image data."}}]}`,
+			expectedErr: domainErrors.ErrOCRUnavailable,
+		},
+		{
+			name:        "異常系: APIキーが無効",
+			status:      http.StatusForbidden,
+			body:        `{"error": {"code": 403, "message": "The request is missing a valid API key."}}`,
+			expectedErr: domainErrors.ErrOCRUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var request visionRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				json.NewDecoder(r.Body).Decode(&request)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			text, err := NewGoogleVisionProvider(server.URL, "key123", time.Second).RecognizeText(context.Background(), testImage, "image/png")
+
+			require.NotNil(t, received)
+			assert.Equal(t, "key123", received.URL.Query().Get("key"))
+			require.Len(t, request.Requests, 1)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(testImage), request.Requests[0].Image.Content)
+			assert.Equal(t, []visionFeature{{Type: "DOCUMENT_TEXT_DETECTION"}}, request.Requests[0].Features)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, text)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedText, text)
+		})
+	}
+}
+
+func TestDisabledProvider(t *testing.T) {
+	_, err := DisabledProvider{}.RecognizeText(context.Background(), testImage, "image/png")
+
+	assert.ErrorIs(t, err, domainErrors.ErrOCRUnavailable)
+}
@@ -0,0 +1,88 @@
+package ocr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const GoogleVisionURL = "https://vision.googleapis.com/v1/images:annotate"
+
+// Google Cloud Vision API（DOCUMENT_TEXT_DETECTION、APIキーが必要）で文字を読み取る提供元
+type GoogleVisionProvider struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// url が空なら GoogleVisionURL を使う
+func NewGoogleVisionProvider(url, apiKey string, timeout time.Duration) *GoogleVisionProvider {
+	if url == "" {
+		url = GoogleVisionURL
+	}
+	return &GoogleVisionProvider{url: url, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+// {"requests": [{"image": {"content": "<base64>"}, "features": [{"type": "DOCUMENT_TEXT_DETECTION"}], "imageContext": {"languageHints": ["ja", "en"]}}]}
+type visionRequest struct {
+	Requests []visionImageRequest `json:"requests"`
+}
+
+type visionImageRequest struct {
+	Image struct {
+		Content string `json:"content"`
+	} `json:"image"`
+	Features     []visionFeature `json:"features"`
+	ImageContext struct {
+		LanguageHints []string `json:"languageHints"`
+	} `json:"imageContext"`
+}
+
+type visionFeature struct {
+	Type string `json:"type"`
+}
+
+// {"responses": [{"fullTextAnnotation": {"text": "..."}, "error": {"code": 3, "message": "..."}}]}
+type visionResponse struct {
+	Responses []struct {
+		FullTextAnnotation struct {
+			Text string `json:"text"`
+		} `json:"fullTextAnnotation"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"responses"`
+}
+
+func (p *GoogleVisionProvider) RecognizeText(ctx context.Context, image []byte, contentType string) (string, error) {
+	var req visionImageRequest
+	req.Image.Content = base64.StdEncoding.EncodeToString(image)
+	req.Features = []visionFeature{{Type: "DOCUMENT_TEXT_DETECTION"}}
+	// レシートは日本語と英語が混在する
+	req.ImageContext.LanguageHints = []string{"ja", "en"}
+	body, err := json.Marshal(visionRequest{Requests: []visionImageRequest{req}})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", domainErrors.ErrOCRUnavailable, err)
+	}
+
+	var resp visionResponse
+	header := http.Header{"Content-Type": {"application/json"}}
+	if err := post(ctx, p.client, p.url+"?"+url.Values{"key": {p.apiKey}}.Encode(), header, body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Responses) == 0 {
+		return "", fmt.Errorf("%w: response has no results", domainErrors.ErrOCRUnavailable)
+	}
+	if e := resp.Responses[0].Error; e != nil {
+		return "", fmt.Errorf("%w: provider responded %d %s", domainErrors.ErrOCRUnavailable, e.Code, e.Message)
+	}
+	// 文字が見つからなければ fullTextAnnotation は空
+	return normalizeNewlines(resp.Responses[0].FullTextAnnotation.Text), nil
+}
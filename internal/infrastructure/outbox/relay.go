@@ -0,0 +1,172 @@
+// Package outbox はアイテムの変更と同じトランザクションで記録したイベント（トランザクショナルアウトボックス）を
+// イベントバスに流す。
+//
+// イベントは配信した後に配信済みにするため、配信と記録の間で停止した場合は再起動後にもう一度配信される
+// （少なくとも1回の配信）。購読者は同じイベントを2回以上受け取ることがある。
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	// 一度に取り出すイベントの件数
+	batchSize = 100
+	// 取り出したイベントを他のリレーに渡さない時間（この間に配信済みにできなければ再配信される）
+	claimTimeout = 30 * time.Second
+	// 配信済みのイベントを削除する間隔
+	cleanupInterval = time.Hour
+	// アウトボックスの読み書きの期限
+	storeTimeout = 5 * time.Second
+)
+
+type Config struct {
+	PollInterval time.Duration // 通知がなくても未配信のイベントを探す間隔（他のサーバーが記録したものや、停止前の残り）
+	Retention    time.Duration // 配信済みのイベントを残す期間
+}
+
+// 未設定の項目をデフォルト値で埋める
+func (c Config) withDefaults() Config {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.Retention <= 0 {
+		c.Retention = 24 * time.Hour
+	}
+	return c
+}
+
+// Relay はアウトボックスにイベントを記録し（usecase.ItemEventOutbox の実装）、コミットされたイベントを配信する
+type Relay struct {
+	repo      usecase.OutboxRepository
+	publisher usecase.ItemEventPublisher
+	cfg       Config
+	notify    chan struct{}
+	now       func() time.Time
+	logf      func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewRelay(repo usecase.OutboxRepository, publisher usecase.ItemEventPublisher, cfg Config) *Relay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg.withDefaults(),
+		notify:    make(chan struct{}, 1),
+		now:       time.Now,
+		logf:      log.Printf,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+}
+
+// イベントを記録する（ctx のトランザクションに参加する）
+func (r *Relay) Append(ctx context.Context, event usecase.ItemEvent) error {
+	return r.repo.Append(ctx, event)
+}
+
+// 記録したイベントがコミットされたことを知らせ、次のポーリングを待たずに配信させる
+func (r *Relay) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+		// 配信待ちの通知があれば、その配信でまとめて取り出される
+	}
+}
+
+// 配信を開始する。Close まで戻らないので goroutine で呼び出す
+func (r *Relay) Run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	cleanup := time.NewTicker(cleanupInterval)
+	defer cleanup.Stop()
+
+	// 前回の起動から残っているイベントを配信する
+	r.relay()
+	for {
+		select {
+		case <-r.notify:
+			r.relay()
+		case <-ticker.C:
+			r.relay()
+		case <-cleanup.C:
+			r.deletePublished()
+		case <-r.ctx.Done():
+			// 停止前にコミットされたイベントを配信してから終わる
+			r.relay()
+			return
+		}
+	}
+}
+
+// 配信を停止し、Run が終わるまで待つ
+func (r *Relay) Close() {
+	r.once.Do(r.cancel)
+	<-r.done
+}
+
+// 未配信のイベントを古い順に配信し、配信済みにする
+func (r *Relay) relay() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		n, err := r.relayBatch(ctx)
+		cancel()
+		if err != nil {
+			r.logf("⚠️  outbox: failed to relay item events: %v", err)
+			return
+		}
+		// 一杯まで取り出せたときは、まだ残っている可能性があるので続けて取り出す
+		if n < batchSize {
+			return
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	now := r.now()
+	pending, err := r.repo.FindPending(ctx, now, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range pending {
+		claimed, err := r.repo.Claim(ctx, event.ID, now, now.Add(claimTimeout))
+		if err != nil {
+			return 0, err
+		}
+		if !claimed {
+			continue
+		}
+
+		r.publisher.Publish(ctx, event.Event)
+
+		if err := r.repo.MarkPublished(ctx, event.ID, r.now()); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(pending), nil
+}
+
+// 保持期間を過ぎた配信済みのイベントを削除する
+func (r *Relay) deletePublished() {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if _, err := r.repo.DeletePublished(ctx, r.now().Add(-r.cfg.Retention)); err != nil {
+		r.logf("⚠️  outbox: failed to delete published item events: %v", err)
+	}
+}
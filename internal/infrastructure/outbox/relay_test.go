@@ -0,0 +1,204 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeRepository はメモリ上のアウトボックス
+type fakeRepository struct {
+	mu        sync.Mutex
+	events    []*fakeEvent
+	markErr   error
+	published int
+}
+
+type fakeEvent struct {
+	id           int64
+	event        usecase.ItemEvent
+	claimedUntil time.Time
+	publishedAt  *time.Time
+}
+
+func (r *fakeRepository) Append(_ context.Context, event usecase.ItemEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, &fakeEvent{id: int64(len(r.events) + 1), event: event})
+	return nil
+}
+
+func (r *fakeRepository) FindPending(_ context.Context, now time.Time, limit int) ([]*usecase.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []*usecase.OutboxEvent
+	for _, e := range r.events {
+		if e.publishedAt == nil && !e.claimedUntil.After(now) && len(pending) < limit {
+			pending = append(pending, &usecase.OutboxEvent{ID: e.id, Event: e.event})
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeRepository) Claim(_ context.Context, id int64, now, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.events[id-1]
+	if e.publishedAt != nil || e.claimedUntil.After(now) {
+		return false, nil
+	}
+	e.claimedUntil = until
+	return true, nil
+}
+
+func (r *fakeRepository) MarkPublished(_ context.Context, id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.events[id-1].publishedAt = &at
+	r.published++
+	return nil
+}
+
+func (r *fakeRepository) DeletePublished(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var kept []*fakeEvent
+	var deleted int64
+	for _, e := range r.events {
+		if e.publishedAt != nil && e.publishedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.events = kept
+	return deleted, nil
+}
+
+func (r *fakeRepository) publishedCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.published
+}
+
+// recordingPublisher は配信されたイベントを記録する
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []usecase.ItemEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event usecase.ItemEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) itemIDs() []int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ids []int64
+	for _, e := range p.events {
+		ids = append(ids, e.ItemID)
+	}
+	return ids
+}
+
+func newTestRelay(t *testing.T, repo usecase.OutboxRepository, publisher usecase.ItemEventPublisher, cfg Config) *Relay {
+	t.Helper()
+	r := NewRelay(repo, publisher, cfg)
+	r.logf = t.Logf
+	return r
+}
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 通知を受けて記録したイベントを古い順に配信する", func(t *testing.T) {
+		repo := &fakeRepository{}
+		publisher := &recordingPublisher{}
+		// ポーリングでは配信されないよう、間隔を長くする
+		relay := newTestRelay(t, repo, publisher, Config{PollInterval: time.Hour})
+		go relay.Run()
+		defer relay.Close()
+
+		require.NoError(t, relay.Append(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1}))
+		require.NoError(t, relay.Append(ctx, usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 2}))
+		relay.Notify()
+
+		require.Eventually(t, func() bool { return repo.publishedCount() == 2 }, 5*time.Second, 5*time.Millisecond)
+		assert.Equal(t, []int64{1, 2}, publisher.itemIDs())
+	})
+
+	t.Run("正常系: 起動時に前回の残りを配信する", func(t *testing.T) {
+		repo := &fakeRepository{}
+		_ = repo.Append(ctx, usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 7})
+		publisher := &recordingPublisher{}
+		relay := newTestRelay(t, repo, publisher, Config{PollInterval: time.Hour})
+		go relay.Run()
+		defer relay.Close()
+
+		require.Eventually(t, func() bool { return repo.publishedCount() == 1 }, 5*time.Second, 5*time.Millisecond)
+		assert.Equal(t, []int64{7}, publisher.itemIDs())
+	})
+
+	t.Run("正常系: 停止前にコミットされたイベントを配信してから終わる", func(t *testing.T) {
+		repo := &fakeRepository{}
+		publisher := &recordingPublisher{}
+		relay := newTestRelay(t, repo, publisher, Config{PollInterval: time.Hour})
+		go relay.Run()
+
+		_ = relay.Append(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 3})
+		relay.Close()
+
+		assert.Equal(t, []int64{3}, publisher.itemIDs())
+	})
+
+	t.Run("正常系: 配信済みにできなかったイベントは予約が切れた後にもう一度配信する", func(t *testing.T) {
+		repo := &fakeRepository{markErr: errors.New("connection lost")}
+		publisher := &recordingPublisher{}
+		now := time.Now()
+		relay := newTestRelay(t, repo, publisher, Config{})
+		relay.now = func() time.Time { return now }
+		_ = repo.Append(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+
+		relay.relay()
+		relay.relay()
+		assert.Equal(t, []int64{1}, publisher.itemIDs(), "予約中は再配信しない")
+
+		repo.markErr = nil
+		now = now.Add(claimTimeout)
+		relay.relay()
+		relay.relay()
+
+		assert.Equal(t, []int64{1, 1}, publisher.itemIDs())
+		assert.Equal(t, 1, repo.publishedCount())
+	})
+
+	t.Run("正常系: 保持期間を過ぎた配信済みのイベントを削除する", func(t *testing.T) {
+		repo := &fakeRepository{}
+		now := time.Now()
+		relay := newTestRelay(t, repo, &recordingPublisher{}, Config{Retention: time.Hour})
+		relay.now = func() time.Time { return now }
+		_ = repo.Append(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		relay.relay()
+		_ = repo.Append(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2})
+
+		now = now.Add(2 * time.Hour)
+		relay.deletePublished()
+
+		pending, err := repo.FindPending(ctx, now, batchSize)
+		require.NoError(t, err)
+		require.Len(t, repo.events, 1, "未配信のイベントは残す")
+		assert.Equal(t, int64(2), pending[0].Event.ItemID)
+	})
+}
@@ -0,0 +1,158 @@
+// Package pdf は矩形とテキストだけを扱う最小限のPDFライターを提供する。
+// テキストは埋め込み不要の日本語CIDフォント（HeiseiKakuGo-W5）で描画する。
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// 用紙サイズ（ポイント単位）
+const (
+	A4Width      = 595.28
+	A4Height     = 841.89
+	LetterWidth  = 612.0
+	LetterHeight = 792.0
+
+	// 1ミリメートルあたりのポイント数
+	PointsPerMM = 72.0 / 25.4
+)
+
+// PDFドキュメント
+type Document struct {
+	pages []*Page
+}
+
+// ページ（原点は左下）
+type Page struct {
+	Width  float64
+	Height float64
+
+	content bytes.Buffer
+}
+
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// ページを追加する
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{Width: width, Height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// 塗りつぶした矩形を描画する（gray: 0=黒, 1=白）
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "%s g %s %s %s %s re f\n", num(gray), num(x), num(y), num(w), num(h))
+}
+
+// 枠線のみの矩形を描画する
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w 0 G %s %s %s %s re S\n", num(lineWidth), num(x), num(y), num(w), num(h))
+}
+
+// テキストを描画する（x, y はベースラインの左端）
+func (p *Page) Text(x, y, size float64, text string) {
+	fmt.Fprintf(&p.content, "0 g BT /F1 %s Tf %s %s Td <%s> Tj ET\n", num(size), num(x), num(y), encodeUTF16(text))
+}
+
+// テキストの幅の概算（全角1em、半角0.5em）
+func TextWidth(text string, size float64) float64 {
+	w := 0.0
+	for _, r := range text {
+		if r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F) {
+			w += 0.5
+		} else {
+			w += 1
+		}
+	}
+	return w * size
+}
+
+// 幅 maxWidth に収まるようにテキストを切り詰める
+func Truncate(text string, size, maxWidth float64) string {
+	if TextWidth(text, size) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := string(runes) + "…"
+		if TextWidth(candidate, size) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// ドキュメントを書き出す
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: カタログ, 2: ページツリー, 3-5: フォント, 6以降: ページとコンテンツ
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	obj("<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-H /DescendantFonts [4 0 R] >>")
+	obj("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 " +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> " +
+		"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500 231 632 500] >>")
+	obj("<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] " +
+		"/ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 58 >>")
+
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			num(p.Width), num(p.Height), 7+i*2))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// ドキュメントをバイト列として返す
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// UCS-2 (UTF-16BE) の16進文字列
+func encodeUTF16(s string) string {
+	var buf bytes.Buffer
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buf, "%04X", u)
+	}
+	return buf.String()
+}
+
+// 小数点以下2桁までの数値表現
+func num(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
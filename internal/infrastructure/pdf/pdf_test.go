@@ -0,0 +1,58 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	doc := NewDocument()
+	page := doc.AddPage(A4Width, A4Height)
+	page.FillRect(10, 20, 30.5, 40, 0)
+	page.Text(10, 10, 9, "時計 ROLEX")
+	doc.AddPage(LetterWidth, LetterHeight)
+
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "0 g 10 20 30.5 40 re f")
+	assert.Contains(t, string(out), "<66428A0800200052004F004C00450058> Tj")
+
+	// xref の各オフセットがオブジェクトの先頭を指していること
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 9)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(strconv.Itoa(i+1)+" 0 obj")))
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     string
+	}{
+		{"正常系: 収まる", "ROLEX", 50, "ROLEX"},
+		{"正常系: 全角を切り詰め", "ロレックス デイトナ", 50, "ロレック…"},
+		{"正常系: 幅が足りない", "ROLEX", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Truncate(tt.text, 10, tt.maxWidth))
+		})
+	}
+}
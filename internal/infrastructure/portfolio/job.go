@@ -0,0 +1,104 @@
+// Package portfolio は毎日決まった時刻に、全体とカテゴリーごとのアイテムの評価額を記録する（GET /stats/portfolio-history）。
+//
+// 記録の回数と失敗の回数は expvar の portfolio（管理用サーバーの /debug/vars）に累計で記録する。
+package portfolio
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 1回の記録の期限
+const snapshotTimeout = 10 * time.Minute
+
+// 記録の回数と失敗の回数の累計
+var metrics = expvar.NewMap("portfolio")
+
+// 評価額を記録する（usecase.PortfolioUsecase）
+type Snapshotter interface {
+	TakeSnapshot(ctx context.Context, onlyIfMissing bool) (*usecase.PortfolioPoint, error)
+}
+
+// Job は起動時（その日の記録がない場合）と毎日の決まった時刻に評価額を記録する
+type Job struct {
+	snapshotter  Snapshotter
+	hour, minute int
+	now          func() time.Time
+	logf         func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// hour と minute はローカル時刻
+func NewJob(hour, minute int, snapshotter Snapshotter) *Job {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Job{
+		snapshotter: snapshotter,
+		hour:        hour,
+		minute:      minute,
+		now:         time.Now,
+		logf:        log.Printf,
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+}
+
+// 記録を開始する。Close まで戻らないので goroutine で呼び出す
+func (j *Job) Run() {
+	defer close(j.done)
+
+	// 停止中に記録の時刻を過ぎた日の分を、次の時刻を待たずに記録する
+	j.snapshot(true)
+	for {
+		timer := time.NewTimer(nextRun(j.now(), j.hour, j.minute).Sub(j.now()))
+		select {
+		case <-timer.C:
+			j.snapshot(false)
+		case <-j.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// 記録を停止し、Run が終わるまで待つ
+func (j *Job) Close() {
+	j.once.Do(j.cancel)
+	<-j.done
+}
+
+func (j *Job) snapshot(onlyIfMissing bool) {
+	ctx, cancel := context.WithTimeout(j.ctx, snapshotTimeout)
+	defer cancel()
+
+	point, err := j.snapshotter.TakeSnapshot(ctx, onlyIfMissing)
+	if err != nil {
+		metrics.Add("failures", 1)
+		j.logf("⚠️  portfolio: failed to take a snapshot: %v", err)
+		return
+	}
+	// その日の記録がすでにあれば記録しない
+	if point == nil {
+		return
+	}
+	metrics.Add("runs", 1)
+	j.logf("portfolio: recorded the value of %d items on %s: %d", point.ItemCount, point.Date, point.Value)
+}
+
+// now の後で最初に hour:minute になる時刻。夏時間の切り替えで存在しない時刻は time.Date に合わせてずらす
+func nextRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, hour, minute, 0, 0, now.Location())
+	}
+	return next
+}
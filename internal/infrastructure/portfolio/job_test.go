@@ -0,0 +1,123 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeSnapshotter は記録の呼び出しを記録する
+type fakeSnapshotter struct {
+	mu    sync.Mutex
+	calls []bool
+	point *usecase.PortfolioPoint
+	err   error
+}
+
+func (s *fakeSnapshotter) TakeSnapshot(ctx context.Context, onlyIfMissing bool) (*usecase.PortfolioPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, onlyIfMissing)
+	return s.point, s.err
+}
+
+func metric(key string) int64 {
+	v, ok := metrics.Get(key).(interface{ Value() int64 })
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestNextRun(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "正常系: 時刻の前なら同じ日",
+			now:      time.Date(2024, 3, 10, 1, 30, 0, 0, tokyo),
+			expected: time.Date(2024, 3, 10, 3, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "正常系: 時刻ちょうどなら次の日",
+			now:      time.Date(2024, 3, 10, 3, 0, 0, 0, tokyo),
+			expected: time.Date(2024, 3, 11, 3, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "正常系: 月末の時刻の後なら翌月の1日",
+			now:      time.Date(2024, 3, 31, 23, 0, 0, 0, tokyo),
+			expected: time.Date(2024, 4, 1, 3, 0, 0, 0, tokyo),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, nextRun(tt.now, 3, 0))
+		})
+	}
+}
+
+func TestJob_Snapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		point        *usecase.PortfolioPoint
+		err          error
+		expectedLogs []string
+		runs         int64
+		failures     int64
+	}{
+		{
+			name: "正常系: 記録した評価額をログと統計に記録する",
+			point: &usecase.PortfolioPoint{
+				Date:           "2024-03-10",
+				PortfolioValue: usecase.PortfolioValue{ItemCount: 3, Value: 4500},
+			},
+			expectedLogs: []string{"portfolio: recorded the value of 3 items on 2024-03-10: 4500"},
+			runs:         1,
+		},
+		{name: "正常系: その日の記録があれば記録しない"},
+		{
+			name:         "異常系: 失敗を記録する",
+			err:          errors.New("database error"),
+			expectedLogs: []string{"⚠️  portfolio: failed to take a snapshot: database error"},
+			failures:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeSnapshotter{point: tt.point, err: tt.err}
+			job := NewJob(3, 0, fake)
+			var logs []string
+			job.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+			runs, failures := metric("runs"), metric("failures")
+
+			job.snapshot(false)
+
+			assert.Equal(t, tt.expectedLogs, logs)
+			assert.Equal(t, tt.runs, metric("runs")-runs)
+			assert.Equal(t, tt.failures, metric("failures")-failures)
+		})
+	}
+}
+
+func TestJob_RunSnapshotsOnStartIfMissing(t *testing.T) {
+	fake := &fakeSnapshotter{}
+	job := NewJob(3, 0, fake)
+	go job.Run()
+
+	job.Close()
+	job.Close()
+
+	assert.Equal(t, []bool{true}, fake.calls)
+}
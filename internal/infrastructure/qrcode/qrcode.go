@@ -0,0 +1,192 @@
+// Package qrcode はバイトモードのQRコード（バージョン1〜10）を生成する。
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+)
+
+// 誤り訂正レベル
+type Level int
+
+const (
+	LevelL Level = iota
+	LevelM
+	LevelQ
+	LevelH
+)
+
+// フォーマット情報に埋め込むビット列
+func (l Level) formatBits() int {
+	switch l {
+	case LevelL:
+		return 1
+	case LevelM:
+		return 0
+	case LevelQ:
+		return 3
+	default:
+		return 2
+	}
+}
+
+const maxVersion = 10
+
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+// 生成済みのQRコード
+type Code struct {
+	Version int
+	Size    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// 指定したモジュールが暗（黒）かどうか
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// data をエンコードして、収まる最小バージョンのQRコードを生成する
+func Encode(data []byte, level Level) (*Code, error) {
+	version := 0
+	for v := 1; v <= maxVersion; v++ {
+		if dataBits(len(data), v) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrDataTooLong
+	}
+
+	codewords := addErrorCorrection(encodeData(data, version, level), version, level)
+
+	size := version*4 + 17
+	c := &Code{
+		Version:    version,
+		Size:       size,
+		modules:    make([][]bool, size),
+		isFunction: make([][]bool, size),
+	}
+	for i := range c.modules {
+		c.modules[i] = make([]bool, size)
+		c.isFunction[i] = make([]bool, size)
+	}
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(codewords)
+
+	// ペナルティが最小のマスクを選択
+	bestMask, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(level, mask)
+		if p := c.penalty(); minPenalty < 0 || p < minPenalty {
+			bestMask, minPenalty = mask, p
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(bestMask)
+	c.drawFormatBits(level, bestMask)
+
+	return c, nil
+}
+
+// 文字列をエンコードする
+func EncodeString(s string, level Level) (*Code, error) {
+	return Encode([]byte(s), level)
+}
+
+// モジュールあたり scale ピクセル、周囲に quiet モジュール分の余白をつけた画像を返す
+func (c *Code) Image(scale, quiet int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	dim := (c.Size + quiet*2) * scale
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray((x+quiet)*scale+dx, (y+quiet)*scale+dy, color.Gray{Y: 0})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// 文字数指示子のビット数
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// モード指示子 + 文字数指示子 + データのビット数
+func dataBits(n, version int) int {
+	return 4 + charCountBits(version) + n*8
+}
+
+func encodeData(data []byte, version int, level Level) []byte {
+	capacity := numDataCodewords(version, level) * 8
+	bb := &bitBuffer{}
+
+	bb.append(0x4, 4) // バイトモード
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	// 終端パターンとバイト境界へのパディング
+	terminator := capacity - bb.len()
+	if terminator > 4 {
+		terminator = 4
+	}
+	bb.append(0, terminator)
+	bb.append(0, (8-bb.len()%8)%8)
+
+	// 埋め草コード語
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	return bb.bytes()
+}
+
+type bitBuffer struct {
+	bits []bool
+}
+
+func (b *bitBuffer) append(val, n int) {
+	for i := n - 1; i >= 0; i-- {
+		b.bits = append(b.bits, (val>>uint(i))&1 == 1)
+	}
+}
+
+func (b *bitBuffer) len() int {
+	return len(b.bits)
+}
+
+func (b *bitBuffer) bytes() []byte {
+	out := make([]byte, (len(b.bits)+7)/8)
+	for i, bit := range b.bits {
+		if bit {
+			out[i>>3] |= 1 << uint(7-(i&7))
+		}
+	}
+	return out
+}
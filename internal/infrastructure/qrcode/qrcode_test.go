@@ -0,0 +1,191 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// "HELLO WORLD" 1-M のデータコード語と誤り訂正コード語
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	assert.Equal(t, expected, rsRemainder(data, rsGenerator(10)))
+}
+
+func TestFormatInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		level Level
+		mask  int
+		want  int
+	}{
+		{"M マスク0", LevelM, 0, 0b101010000010010},
+		{"L マスク4", LevelL, 4, 0b110011000101111},
+		{"H マスク7", LevelH, 7, 0b000100000111011},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatInfo(tt.level, tt.mask))
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		level       Level
+		wantVersion int
+	}{
+		{"正常系: 短いURL", "http://localhost:8080/items/1", LevelM, 3},
+		{"正常系: 最小バージョン", "1", LevelL, 1},
+		{"正常系: 日本語", "ロレックス デイトナ", LevelM, 3},
+		{"正常系: バージョン7以上", strings.Repeat("a", 150), LevelM, 8},
+		{"正常系: 2グループ構成", strings.Repeat("b", 100), LevelQ, 8},
+		{"正常系: 16ビットの文字数指示子", strings.Repeat("c", 250), LevelL, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := EncodeString(tt.data, tt.level)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantVersion, code.Version)
+			assert.Equal(t, tt.wantVersion*4+17, code.Size)
+			assert.Equal(t, tt.data, string(decode(t, code, tt.level)))
+		})
+	}
+}
+
+func TestEncode_TooLong(t *testing.T) {
+	_, err := EncodeString(strings.Repeat("x", 300), LevelH)
+	assert.ErrorIs(t, err, ErrDataTooLong)
+}
+
+func TestCode_Image(t *testing.T) {
+	code, err := EncodeString("label", LevelM)
+	require.NoError(t, err)
+
+	img := code.Image(2, 4)
+	assert.Equal(t, (code.Size+8)*2, img.Bounds().Dx())
+
+	// 余白は白、左上のファインダーは黒
+	assert.Equal(t, uint8(0xff), img.GrayAt(0, 0).Y)
+	assert.Equal(t, uint8(0), img.GrayAt(8, 8).Y)
+}
+
+// decode は配置済みのモジュールからデータを読み戻し、誤り訂正コード語を検証する
+func decode(t *testing.T, c *Code, level Level) []byte {
+	t.Helper()
+
+	// フォーマット情報からマスク番号を取得
+	bits := 0
+	for i := 0; i < 8; i++ {
+		if c.Black(c.Size-1-i, 8) {
+			bits |= 1 << uint(i)
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if c.Black(8, c.Size-15+i) {
+			bits |= 1 << uint(i)
+		}
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatInfo(level, m) == bits {
+			mask = m
+		}
+	}
+	require.NotEqual(t, -1, mask, "format info not found")
+
+	c.applyMask(mask)
+	defer c.applyMask(mask)
+
+	var raw []byte
+	var cur byte
+	n := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if c.isFunction[y][x] {
+					continue
+				}
+				cur = cur<<1 | boolToByte(c.modules[y][x])
+				n++
+				if n%8 == 0 {
+					raw = append(raw, cur)
+					cur = 0
+				}
+			}
+		}
+	}
+
+	// インターリーブを解除して各ブロックの剰余が0になることを確認
+	spec := blockTable[c.Version][level]
+	numBlocks := spec.g1Blocks + spec.g2Blocks
+	blocks := make([][]byte, numBlocks)
+	idx := 0
+	maxData := spec.g1Data
+	if spec.g2Blocks > 0 {
+		maxData = spec.g2Data
+	}
+	for i := 0; i < maxData; i++ {
+		for b := 0; b < numBlocks; b++ {
+			size := spec.g1Data
+			if b >= spec.g1Blocks {
+				size = spec.g2Data
+			}
+			if i < size {
+				blocks[b] = append(blocks[b], raw[idx])
+				idx++
+			}
+		}
+	}
+	var data []byte
+	for b := 0; b < numBlocks; b++ {
+		var ec []byte
+		for i := 0; i < spec.ecPerBlock; i++ {
+			ec = append(ec, raw[idx+i*numBlocks+b])
+		}
+		assert.Equal(t, rsRemainder(blocks[b], rsGenerator(spec.ecPerBlock)), ec)
+		data = append(data, blocks[b]...)
+	}
+
+	// バイトモードのデータを取り出す
+	require.Equal(t, byte(0x4), data[0]>>4)
+	countBits := charCountBits(c.Version)
+	pos := 4
+	readBits := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			v = v<<1 | int((data[pos>>3]>>uint(7-(pos&7)))&1)
+			pos++
+		}
+		return v
+	}
+	length := readBits(countBits)
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = byte(readBits(8))
+	}
+	return out
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+// Package ratelimit はクライアント（IPアドレス）ごとにリクエストの頻度を制限するミドルウェアを提供する。
+//
+// クライアントごとのトークンバケットで、1秒あたりの回数と一時的に超えてよい回数（バースト）を制限する。
+// 上限を超えたリクエストは処理せずに 429 と Retry-After を返す。
+// 上限は実行中に変更できる（設定ファイルの RATE_LIMIT_RPS / RATE_LIMIT_BURST の変更を再起動せずに反映する）。
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// 上限を超えたときのエラーメッセージ
+const Message = "rate limit exceeded"
+
+// バケットが満杯（しばらくリクエストがない）クライアントの状態を削除する間隔
+const sweepInterval = time.Minute
+
+// クライアントごとのリクエストの頻度の上限
+type Limiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	clients   map[string]*rate.Limiter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// perSecond: クライアントごとの1秒あたりのリクエスト数（0で制限しない）、burst: 一時的に超えてよい回数
+func New(perSecond, burst int) *Limiter {
+	l := &Limiter{clients: make(map[string]*rate.Limiter), now: time.Now}
+	l.SetLimit(perSecond, burst)
+	return l
+}
+
+// 上限を変更する。記録済みのクライアントにもすぐに適用する
+func (l *Limiter) SetLimit(perSecond, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.limit = rate.Limit(perSecond)
+	l.burst = burst
+	for _, client := range l.clients {
+		client.SetLimitAt(now, l.limit)
+		client.SetBurstAt(now, l.burst)
+	}
+}
+
+// key のリクエストを許可するかどうかと、許可しない場合に次のリクエストまで待つ時間を返す
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return true, 0
+	}
+	now := l.now()
+	l.sweep(now)
+
+	client, ok := l.clients[key]
+	if !ok {
+		client = rate.NewLimiter(l.limit, l.burst)
+		l.clients[key] = client
+	}
+	r := client.ReserveN(now, 1)
+	if !r.OK() {
+		// バーストが0の場合はすべて拒否する
+		return false, time.Second
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// バケットが満杯のクライアントは新しいクライアントと同じなので、状態を削除してメモリを解放する
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, client := range l.clients {
+		if client.TokensAt(now) >= float64(l.burst) {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// リクエストの頻度を制限するミドルウェア。ヘルスチェックは制限しない
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Path() == "/health" {
+				return next(c)
+			}
+			ok, retryAfter := l.Allow(c.RealIP())
+			if ok {
+				return next(c)
+			}
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			return itemController.RespondError(c, http.StatusTooManyRequests, itemController.ErrorResponse{
+				Error: Message,
+			})
+		}
+	}
+}
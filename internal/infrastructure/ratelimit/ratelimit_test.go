@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// テスト用の時計
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newTestLimiter(perSecond, burst int) (*Limiter, *clock) {
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(perSecond, burst)
+	l.now = c.Now
+	return l, c
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Run("正常系: バーストまで許可し、トークンが戻ったら再び許可する", func(t *testing.T) {
+		l, c := newTestLimiter(2, 3)
+
+		for i := 0; i < 3; i++ {
+			ok, _ := l.Allow("192.0.2.1")
+			assert.True(t, ok)
+		}
+		ok, retryAfter := l.Allow("192.0.2.1")
+		assert.False(t, ok)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+		// 別のクライアントは制限されない
+		ok, _ = l.Allow("192.0.2.2")
+		assert.True(t, ok)
+
+		c.now = c.now.Add(500 * time.Millisecond)
+		ok, _ = l.Allow("192.0.2.1")
+		assert.True(t, ok)
+	})
+
+	t.Run("正常系: 0は制限しない", func(t *testing.T) {
+		l, _ := newTestLimiter(0, 0)
+
+		for i := 0; i < 100; i++ {
+			ok, _ := l.Allow("192.0.2.1")
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("正常系: 上限の変更は記録済みのクライアントにも適用する", func(t *testing.T) {
+		l, _ := newTestLimiter(1, 1)
+		ok, _ := l.Allow("192.0.2.1")
+		require.True(t, ok)
+		ok, _ = l.Allow("192.0.2.1")
+		require.False(t, ok)
+
+		l.SetLimit(0, 0)
+		ok, _ = l.Allow("192.0.2.1")
+		assert.True(t, ok)
+
+		l.SetLimit(1, 1)
+		ok, retryAfter := l.Allow("192.0.2.1")
+		assert.False(t, ok)
+		assert.Equal(t, time.Second, retryAfter)
+	})
+
+	t.Run("正常系: バケットが満杯のクライアントは削除する", func(t *testing.T) {
+		l, c := newTestLimiter(10, 10)
+		l.Allow("192.0.2.1")
+		c.now = c.now.Add(time.Second)
+		l.Allow("192.0.2.2")
+		require.Len(t, l.clients, 2)
+
+		c.now = c.now.Add(sweepInterval)
+		l.Allow("192.0.2.3")
+
+		assert.Len(t, l.clients, 1)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	l, _ := newTestLimiter(1, 1)
+	e := echo.New()
+	e.Use(l.Middleware())
+	e.GET("/items", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	do := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, do("/items").Code)
+
+	rec := do("/items")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, Message, body["error"])
+
+	// ヘルスチェックは制限しない
+	assert.Equal(t, http.StatusOK, do("/health").Code)
+}
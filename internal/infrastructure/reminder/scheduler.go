@@ -0,0 +1,81 @@
+// Package reminder は定期的に期限（保証期限・保険の満期日など）の近いアイテムを調べ、期限の通知を記録する。
+//
+// 通知は item.expiring イベントとしてアウトボックスに記録され、アイテムの変更と同じく Webhook とメール通知に届く。
+package reminder
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// 1回の確認の期限
+const scanTimeout = 10 * time.Minute
+
+// 期限の近いアイテムの通知を記録する（usecase.ReminderUsecase）
+type ExpiryReminderSender interface {
+	SendExpiryReminders(ctx context.Context) (int, error)
+}
+
+// Scheduler は起動時と一定の間隔で期限の近いアイテムを調べる
+type Scheduler struct {
+	sender   ExpiryReminderSender
+	interval time.Duration
+	logf     func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewScheduler(interval time.Duration, sender ExpiryReminderSender) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		sender:   sender,
+		interval: interval,
+		logf:     log.Printf,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// 確認を開始する。Close まで戻らないので goroutine で呼び出す
+func (s *Scheduler) Run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// 停止中に期限の段階に達したアイテムを、次の間隔を待たずに通知する
+	s.scan()
+	for {
+		select {
+		case <-ticker.C:
+			s.scan()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// 確認を停止し、Run が終わるまで待つ
+func (s *Scheduler) Close() {
+	s.once.Do(s.cancel)
+	<-s.done
+}
+
+func (s *Scheduler) scan() {
+	ctx, cancel := context.WithTimeout(s.ctx, scanTimeout)
+	defer cancel()
+
+	// 途中で失敗しても、記録済みの通知は送信済みになっているため次の確認で残りを送る
+	sent, err := s.sender.SendExpiryReminders(ctx)
+	if sent > 0 {
+		s.logf("reminder: recorded %d expiry reminders", sent)
+	}
+	if err != nil {
+		s.logf("⚠️  reminder: failed to send expiry reminders: %v", err)
+	}
+}
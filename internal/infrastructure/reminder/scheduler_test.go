@@ -0,0 +1,71 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSender は通知の記録の呼び出しを数える
+type fakeSender struct {
+	mu    sync.Mutex
+	calls int
+	sent  int
+	err   error
+}
+
+func (s *fakeSender) SendExpiryReminders(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.sent, s.err
+}
+
+func TestScheduler_Scan(t *testing.T) {
+	tests := []struct {
+		name         string
+		sent         int
+		err          error
+		expectedLogs []string
+	}{
+		{name: "正常系: 記録した件数をログに出す", sent: 2, expectedLogs: []string{"reminder: recorded 2 expiry reminders"}},
+		{name: "正常系: 通知がなければ記録しない", sent: 0},
+		{
+			name: "異常系: 失敗を記録する", sent: 1, err: errors.New("database error"),
+			expectedLogs: []string{
+				"reminder: recorded 1 expiry reminders",
+				"⚠️  reminder: failed to send expiry reminders: database error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &fakeSender{sent: tt.sent, err: tt.err}
+			scheduler := NewScheduler(time.Hour, sender)
+			var logs []string
+			scheduler.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+
+			scheduler.scan()
+
+			assert.Equal(t, 1, sender.calls)
+			assert.Equal(t, tt.expectedLogs, logs)
+		})
+	}
+}
+
+func TestScheduler_RunScansOnStart(t *testing.T) {
+	sender := &fakeSender{}
+	scheduler := NewScheduler(time.Hour, sender)
+	go scheduler.Run()
+
+	scheduler.Close()
+	scheduler.Close()
+
+	assert.Equal(t, 1, sender.calls)
+}
@@ -0,0 +1,108 @@
+// Package retention は定期的に保持期間を過ぎたデータ（ゴミ箱のアイテム・アイテムのリビジョン）と、
+// 猶予期間を過ぎた削除依頼（DELETE /me）のユーザーのデータを完全に削除する。
+//
+// 削除した件数は expvar の retention（管理用サーバーの /debug/vars）に累計で記録する。
+package retention
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 1回の削除の期限
+const purgeTimeout = 10 * time.Minute
+
+// 実行の回数・失敗の回数・削除した件数の累計（dry run は数えない）
+var metrics = expvar.NewMap("retention")
+
+// 保持期間を過ぎたデータを削除する（usecase.RetentionUsecase）
+type Purger interface {
+	Purge(ctx context.Context, dryRun bool) (*usecase.RetentionReport, error)
+}
+
+// Job は起動時と一定の間隔で保持期間を過ぎたデータを削除する
+type Job struct {
+	purger   Purger
+	interval time.Duration
+	logf     func(format string, args ...interface{})
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewJob(interval time.Duration, purger Purger) *Job {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Job{
+		purger:   purger,
+		interval: interval,
+		logf:     log.Printf,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// 削除を開始する。Close まで戻らないので goroutine で呼び出す
+func (j *Job) Run() {
+	defer close(j.done)
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	// 停止中に保持期間を過ぎたデータを、次の間隔を待たずに削除する
+	j.purge()
+	for {
+		select {
+		case <-ticker.C:
+			j.purge()
+		case <-j.ctx.Done():
+			return
+		}
+	}
+}
+
+// 削除を停止し、Run が終わるまで待つ
+func (j *Job) Close() {
+	j.once.Do(j.cancel)
+	<-j.done
+}
+
+// Purge は間隔を待たずに削除する（管理用のエンドポイント）。dry run は削除する件数だけを返す
+func (j *Job) Purge(ctx context.Context, dryRun bool) (*usecase.RetentionReport, error) {
+	report, err := j.purger.Purge(ctx, dryRun)
+	if dryRun {
+		return report, err
+	}
+
+	metrics.Add("runs", 1)
+	if report != nil {
+		metrics.Add("purged_items", int64(report.Items))
+		metrics.Add("purged_revisions", int64(report.Revisions))
+		metrics.Add("erased_users", int64(report.Users))
+		if report.Items > 0 || report.Revisions > 0 {
+			j.logf("retention: purged %d items and %d revisions", report.Items, report.Revisions)
+		}
+		if report.Users > 0 {
+			j.logf("retention: erased the data of %d users at their request", report.Users)
+		}
+	}
+	if err != nil {
+		metrics.Add("failures", 1)
+		j.logf("⚠️  retention: failed to purge expired data: %v", err)
+	}
+	return report, err
+}
+
+func (j *Job) purge() {
+	ctx, cancel := context.WithTimeout(j.ctx, purgeTimeout)
+	defer cancel()
+
+	// 途中で失敗しても、削除済みのデータは次の削除の対象にならないため次の実行で残りを削除する
+	_, _ = j.Purge(ctx, false)
+}
@@ -0,0 +1,101 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakePurger は削除の呼び出しを数える
+type fakePurger struct {
+	mu     sync.Mutex
+	calls  int
+	report *usecase.RetentionReport
+	err    error
+}
+
+func (p *fakePurger) Purge(ctx context.Context, dryRun bool) (*usecase.RetentionReport, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.report == nil {
+		return &usecase.RetentionReport{DryRun: dryRun}, p.err
+	}
+	report := *p.report
+	report.DryRun = dryRun
+	return &report, p.err
+}
+
+func metric(key string) int64 {
+	v, ok := metrics.Get(key).(interface{ Value() int64 })
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestJob_Purge(t *testing.T) {
+	tests := []struct {
+		name         string
+		report       *usecase.RetentionReport
+		err          error
+		dryRun       bool
+		expectedLogs []string
+		purgedItems  int64
+		failures     int64
+	}{
+		{
+			name:         "正常系: 削除した件数をログと統計に記録する",
+			report:       &usecase.RetentionReport{Items: 3, Revisions: 10},
+			expectedLogs: []string{"retention: purged 3 items and 10 revisions"},
+			purgedItems:  3,
+		},
+		{name: "正常系: 削除するデータがなければ記録しない", report: &usecase.RetentionReport{}},
+		{name: "正常系: dry run は記録しない", report: &usecase.RetentionReport{Items: 3}, dryRun: true},
+		{
+			name: "異常系: 失敗を記録する", report: &usecase.RetentionReport{Items: 1}, err: errors.New("database error"),
+			expectedLogs: []string{
+				"retention: purged 1 items and 0 revisions",
+				"⚠️  retention: failed to purge expired data: database error",
+			},
+			purgedItems: 1,
+			failures:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakePurger{report: tt.report, err: tt.err}
+			job := NewJob(time.Hour, fake)
+			var logs []string
+			job.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+			items, failures := metric("purged_items"), metric("failures")
+
+			report, err := job.Purge(context.Background(), tt.dryRun)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.dryRun, report.DryRun)
+			assert.Equal(t, tt.expectedLogs, logs)
+			assert.Equal(t, tt.purgedItems, metric("purged_items")-items)
+			assert.Equal(t, tt.failures, metric("failures")-failures)
+		})
+	}
+}
+
+func TestJob_RunPurgesOnStart(t *testing.T) {
+	fake := &fakePurger{}
+	job := NewJob(time.Hour, fake)
+	go job.Run()
+
+	job.Close()
+	job.Close()
+
+	assert.Equal(t, 1, fake.calls)
+}
@@ -0,0 +1,117 @@
+// Package router はAPIのルートをグループごとに宣言し、まとめて Echo に登録する。
+//
+// ルートはグループ（/items など）に宣言し、グループとルートにミドルウェアを指定できる。
+// グループのミドルウェアは外側のグループから順に、ルートのミドルウェアより先に実行する。
+// 宣言したルートは Mount で接頭辞（"" や /v1 などのバージョン）ごとに同じ内容で登録する。
+package router
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 宣言されたルート
+type Route struct {
+	Method  string
+	Path    string
+	Handler echo.HandlerFunc
+	// グループとルートのミドルウェア（外側から順）
+	Middleware []echo.MiddlewareFunc
+}
+
+// Router はAPIのルートの宣言をまとめる
+type Router struct {
+	root *Group
+}
+
+// 空の Router を作る
+func New() *Router {
+	return &Router{root: &Group{}}
+}
+
+// 接頭辞を付けたルートのグループを宣言する。middleware はグループのすべてのルートに適用する
+func (r *Router) Group(prefix string, middleware ...echo.MiddlewareFunc) *Group {
+	return r.root.Group(prefix, middleware...)
+}
+
+// 宣言したルートを、宣言した順に完全なパスで返す
+func (r *Router) Routes() []Route {
+	var routes []Route
+	r.root.collect("", nil, &routes)
+	return routes
+}
+
+// 宣言したルートを g の下に登録する（g の接頭辞がパスの前に付く）
+func (r *Router) Mount(g *echo.Group) {
+	for _, route := range r.Routes() {
+		g.Add(route.Method, route.Path, route.Handler, route.Middleware...)
+	}
+}
+
+// Group はルートのグループ
+type Group struct {
+	prefix     string
+	middleware []echo.MiddlewareFunc
+	// ルートと子グループを宣言した順に並べる
+	entries []entry
+}
+
+type entry struct {
+	route *Route
+	group *Group
+}
+
+// グループの中に、接頭辞を付けたグループを宣言する
+func (g *Group) Group(prefix string, middleware ...echo.MiddlewareFunc) *Group {
+	child := &Group{prefix: prefix, middleware: middleware}
+	g.entries = append(g.entries, entry{group: child})
+	return child
+}
+
+// ルートを宣言する。path はグループの接頭辞からの相対パス
+func (g *Group) Add(method, path string, h echo.HandlerFunc, middleware ...echo.MiddlewareFunc) {
+	g.entries = append(g.entries, entry{route: &Route{Method: method, Path: path, Handler: h, Middleware: middleware}})
+}
+
+func (g *Group) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodGet, path, h, m...)
+}
+
+func (g *Group) HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodHead, path, h, m...)
+}
+
+func (g *Group) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodPost, path, h, m...)
+}
+
+func (g *Group) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodPut, path, h, m...)
+}
+
+func (g *Group) PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodPatch, path, h, m...)
+}
+
+func (g *Group) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	g.Add(http.MethodDelete, path, h, m...)
+}
+
+// グループのルートを、接頭辞とミドルウェアを外側のグループから積み重ねて routes に加える
+func (g *Group) collect(prefix string, middleware []echo.MiddlewareFunc, routes *[]Route) {
+	prefix += g.prefix
+	middleware = append(middleware[:len(middleware):len(middleware)], g.middleware...)
+	for _, e := range g.entries {
+		if e.group != nil {
+			e.group.collect(prefix, middleware, routes)
+			continue
+		}
+		*routes = append(*routes, Route{
+			Method:     e.route.Method,
+			Path:       prefix + e.route.Path,
+			Handler:    e.route.Handler,
+			Middleware: append(middleware[:len(middleware):len(middleware)], e.route.Middleware...),
+		})
+	}
+}
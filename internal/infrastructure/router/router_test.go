@@ -0,0 +1,82 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// 呼ばれた順に名前を記録するミドルウェア
+func trace(calls *[]string, name string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			*calls = append(*calls, name)
+			return next(c)
+		}
+	}
+}
+
+func TestRouter(t *testing.T) {
+	var calls []string
+	handler := func(c echo.Context) error {
+		calls = append(calls, "handler")
+		return c.String(http.StatusOK, c.Path())
+	}
+
+	r := New()
+	g := r.Group("")
+	g.GET("/dashboard", handler)
+	items := g.Group("/items", trace(&calls, "items"))
+	items.GET("", handler)
+	items.POST("", handler, trace(&calls, "idempotency"))
+	photos := items.Group("/:id/photos", trace(&calls, "photos"))
+	photos.DELETE("/:photo_id", handler)
+	g.GET("/lookup", handler)
+
+	t.Run("正常系: 宣言した順に完全なパスで返す", func(t *testing.T) {
+		var routes []string
+		for _, route := range r.Routes() {
+			routes = append(routes, route.Method+" "+route.Path)
+		}
+		assert.Equal(t, []string{
+			"GET /dashboard",
+			"GET /items",
+			"POST /items",
+			"DELETE /items/:id/photos/:photo_id",
+			"GET /lookup",
+		}, routes)
+	})
+
+	e := echo.New()
+	r.Mount(e.Group(""))
+	r.Mount(e.Group("/v1"))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		route    string
+		expected []string
+	}{
+		{name: "正常系: グループのミドルウェアなし", method: http.MethodGet, path: "/dashboard", route: "/dashboard", expected: []string{"handler"}},
+		{name: "正常系: グループのミドルウェア", method: http.MethodGet, path: "/items", route: "/items", expected: []string{"items", "handler"}},
+		{name: "正常系: ルートのミドルウェアはグループの後", method: http.MethodPost, path: "/v1/items", route: "/v1/items", expected: []string{"items", "idempotency", "handler"}},
+		{name: "正常系: 外側のグループから順に実行", method: http.MethodDelete, path: "/v1/items/1/photos/2", route: "/v1/items/:id/photos/:photo_id", expected: []string{"items", "photos", "handler"}},
+		{name: "正常系: 後から宣言したグループ外のルート", method: http.MethodGet, path: "/v1/lookup", route: "/v1/lookup", expected: []string{"handler"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.route, rec.Body.String())
+			assert.Equal(t, tt.expected, calls)
+		})
+	}
+}
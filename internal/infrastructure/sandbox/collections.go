@@ -0,0 +1,23 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムの削除で、同じIDの本番のアイテムをコレクションから外さないコレクションリポジトリ
+type collectionRepository struct {
+	usecase.CollectionRepository
+}
+
+func NewCollectionRepository(production usecase.CollectionRepository) usecase.CollectionRepository {
+	return &collectionRepository{CollectionRepository: production}
+}
+
+func (r *collectionRepository) RemoveItemFromAll(ctx context.Context, itemID int64) error {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil
+	}
+	return r.CollectionRepository.RemoveItemFromAll(ctx, itemID)
+}
@@ -0,0 +1,25 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムには書類がないため、アイテムの書類を返さない書類リポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムの削除で、本番の書類が消えないようにする）
+type itemDocumentRepository struct {
+	usecase.ItemDocumentRepository
+}
+
+func NewItemDocumentRepository(production usecase.ItemDocumentRepository) usecase.ItemDocumentRepository {
+	return &itemDocumentRepository{ItemDocumentRepository: production}
+}
+
+func (r *itemDocumentRepository) FindByItemID(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.ItemDocumentRepository.FindByItemID(ctx, itemID, docType)
+}
@@ -0,0 +1,27 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスでの変更をアウトボックスに記録しない（本番の購読者に配信しない）アウトボックス
+type eventOutbox struct {
+	production usecase.ItemEventOutbox
+}
+
+func NewEventOutbox(production usecase.ItemEventOutbox) usecase.ItemEventOutbox {
+	return &eventOutbox{production: production}
+}
+
+func (o *eventOutbox) Append(ctx context.Context, event usecase.ItemEvent) error {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil
+	}
+	return o.production.Append(ctx, event)
+}
+
+func (o *eventOutbox) Notify() {
+	o.production.Notify()
+}
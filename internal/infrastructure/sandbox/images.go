@@ -0,0 +1,32 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムには画像がないため、アイテムの画像を返さない画像リポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムに本番の画像が付いたり、削除で消えたりしないようにする）
+type itemImageRepository struct {
+	usecase.ItemImageRepository
+}
+
+func NewItemImageRepository(production usecase.ItemImageRepository) usecase.ItemImageRepository {
+	return &itemImageRepository{ItemImageRepository: production}
+}
+
+func (r *itemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.ItemImageRepository.FindByItemID(ctx, itemID)
+}
+
+func (r *itemImageRepository) ListIDsByItem(ctx context.Context, itemIDs []int64) (map[int64][]int64, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return map[int64][]int64{}, nil
+	}
+	return r.ItemImageRepository.ListIDsByItem(ctx, itemIDs)
+}
@@ -0,0 +1,93 @@
+package sandbox
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックス宛てのリクエストだけをキーごとのデータセットに振り分けるリポジトリ
+type ItemRepository struct {
+	production usecase.ItemRepository
+	store      *Store
+}
+
+func NewItemRepository(production usecase.ItemRepository, store *Store) *ItemRepository {
+	return &ItemRepository{
+		production: production,
+		store:      store,
+	}
+}
+
+func (r *ItemRepository) target(ctx context.Context) usecase.ItemRepository {
+	if key, ok := KeyFromContext(ctx); ok {
+		return r.store.itemsFor(key)
+	}
+	return r.production
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, query usecase.ItemQuery) ([]*entity.Item, error) {
+	return r.target(ctx).FindAll(ctx, query)
+}
+
+func (r *ItemRepository) Iterate(ctx context.Context, query usecase.ItemQuery, fn func(*entity.Item) error) error {
+	return r.target(ctx).Iterate(ctx, query, fn)
+}
+
+func (r *ItemRepository) FindAllStream(ctx context.Context, query usecase.ItemQuery) (usecase.ItemIterator, error) {
+	return r.target(ctx).FindAllStream(ctx, query)
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	return r.target(ctx).Count(ctx, filter)
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	return r.target(ctx).FindByID(ctx, id)
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	return r.target(ctx).Create(ctx, item)
+}
+
+func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+	return r.target(ctx).Delete(ctx, id)
+}
+
+func (r *ItemRepository) Trash(ctx context.Context, id int64, deletedAt time.Time) error {
+	return r.target(ctx).Trash(ctx, id, deletedAt)
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	return r.target(ctx).Restore(ctx, id)
+}
+
+func (r *ItemRepository) FindTrashed(ctx context.Context, query usecase.TrashQuery) ([]*entity.TrashedItem, error) {
+	return r.target(ctx).FindTrashed(ctx, query)
+}
+
+func (r *ItemRepository) CountTrashed(ctx context.Context) (int, error) {
+	return r.target(ctx).CountTrashed(ctx)
+}
+
+func (r *ItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.TrashedItem, error) {
+	return r.target(ctx).FindTrashedByID(ctx, id)
+}
+
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	return r.target(ctx).Update(ctx, item)
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	return r.target(ctx).GetSummaryByCategory(ctx, purchased)
+}
+
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) (map[string]*usecase.BrandTotals, error) {
+	return r.target(ctx).GetSummaryByBrand(ctx)
+}
+
+func (r *ItemRepository) CountByPriceBands(ctx context.Context, bounds []int) ([]int, error) {
+	return r.target(ctx).CountByPriceBands(ctx, bounds)
+}
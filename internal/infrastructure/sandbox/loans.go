@@ -0,0 +1,25 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムは貸し出せないため、貸出中として扱わない貸出リポジトリ
+// （本番の貸出と同じIDのサンドボックスのアイテムを削除できるようにする）
+type loanRepository struct {
+	usecase.LoanRepository
+}
+
+func NewLoanRepository(production usecase.LoanRepository) usecase.LoanRepository {
+	return &loanRepository{LoanRepository: production}
+}
+
+func (r *loanRepository) FindActiveByItemID(ctx context.Context, itemID int64) (*entity.Loan, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.LoanRepository.FindActiveByItemID(ctx, itemID)
+}
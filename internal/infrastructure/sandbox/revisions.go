@@ -0,0 +1,40 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムの変更は履歴に残さず、本番のアイテムの履歴も返さないリビジョンリポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムに本番の履歴が混ざったり、本番の状態に戻したりしないようにする）
+type itemRevisionRepository struct {
+	usecase.ItemRevisionRepository
+}
+
+func NewItemRevisionRepository(production usecase.ItemRevisionRepository) usecase.ItemRevisionRepository {
+	return &itemRevisionRepository{ItemRevisionRepository: production}
+}
+
+func (r *itemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) error {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil
+	}
+	return r.ItemRevisionRepository.Create(ctx, revision)
+}
+
+func (r *itemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.ItemRevisionRepository.FindByItemID(ctx, itemID)
+}
+
+func (r *itemRevisionRepository) FindByRevision(ctx context.Context, itemID, revision int64) (*entity.ItemRevision, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, domainErrors.ErrItemRevisionNotFound
+	}
+	return r.ItemRevisionRepository.FindByRevision(ctx, itemID, revision)
+}
@@ -0,0 +1,132 @@
+// Package sandbox は連携テスト用のサンドボックスを提供する。
+//
+// X-Sandbox: true と連携用APIキーを付けたリクエストは、キーごとに分離された
+// メモリ上のデータセットを読み書きし、本番データには触れない。
+// 一定時間使われなかったデータセットは自動的に破棄される。
+package sandbox
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+)
+
+const (
+	HeaderSandbox = "X-Sandbox"
+	HeaderAPIKey  = "X-API-Key"
+
+	// データセットあたりの最大アイテム数
+	DefaultMaxItems = 1000
+)
+
+type contextKey struct{}
+
+// リクエストがサンドボックス宛ての場合、そのAPIキーを返す
+func KeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(contextKey{}).(string)
+	return key, ok
+}
+
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// キーごとのデータセット
+type Store struct {
+	mu       sync.Mutex
+	datasets map[string]*dataset
+	ttl      time.Duration
+	maxItems int
+	now      func() time.Time
+}
+
+type dataset struct {
+	items    *memory.ItemRepository
+	lastUsed time.Time
+}
+
+// ttl: 最後に使われてから破棄するまでの時間、maxItems: データセットあたりの最大件数
+func NewStore(ttl time.Duration, maxItems int) *Store {
+	return &Store{
+		datasets: make(map[string]*dataset),
+		ttl:      ttl,
+		maxItems: maxItems,
+		now:      time.Now,
+	}
+}
+
+func (s *Store) itemsFor(key string) *memory.ItemRepository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, ds := range s.datasets {
+		if now.Sub(ds.lastUsed) > s.ttl {
+			delete(s.datasets, k)
+		}
+	}
+
+	ds, ok := s.datasets[key]
+	if !ok {
+		items := memory.NewItemRepository()
+		items.MaxItems = s.maxItems
+		ds = &dataset{items: items}
+		s.datasets[key] = ds
+	}
+	ds.lastUsed = now
+	return ds.items
+}
+
+// サンドボックスに対応しているルート（アイテムのCRUDとラベル印刷、バージョンの接頭辞を除いたパス）
+var supportedRoutes = map[string]bool{
+	"/items":         true,
+	"/items/:id":     true,
+	"/items/summary": true,
+	"/labels/batch":  true,
+}
+
+// X-Sandbox ヘッダーを解釈するミドルウェア
+// 登録済みの連携用キーがない場合や、対応していないルートへのリクエストは拒否する。
+func Middleware(apiKeys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !strings.EqualFold(c.Request().Header.Get(HeaderSandbox), "true") {
+				return next(c)
+			}
+
+			key := c.Request().Header.Get(HeaderAPIKey)
+			if !validKey(apiKeys, key) {
+				return itemController.NewHTTPError(http.StatusUnauthorized, "a valid integration API key is required for sandbox requests")
+			}
+			if !supportedRoutes[apiversion.Route(c.Path())] {
+				return itemController.NewHTTPError(http.StatusBadRequest, "sandbox is not supported for this endpoint")
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithKey(req.Context(), key)))
+			c.Response().Header().Set(HeaderSandbox, "true")
+			return next(c)
+		}
+	}
+}
+
+func validKey(apiKeys []string, key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, k := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,346 @@
+package sandbox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		headers        map[string]string
+		expectedStatus int
+		expectedKey    string
+	}{
+		{
+			name:           "正常系: ヘッダーなしは本番",
+			path:           "/items",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 有効なキーでサンドボックス",
+			path:           "/items",
+			headers:        map[string]string{HeaderSandbox: "true", HeaderAPIKey: "partner-1"},
+			expectedStatus: http.StatusOK,
+			expectedKey:    "partner-1",
+		},
+		{
+			name:           "正常系: バージョン付きのルート",
+			path:           "/v2/items",
+			headers:        map[string]string{HeaderSandbox: "true", HeaderAPIKey: "partner-1"},
+			expectedStatus: http.StatusOK,
+			expectedKey:    "partner-1",
+		},
+		{
+			name:           "異常系: キーなし",
+			path:           "/items",
+			headers:        map[string]string{HeaderSandbox: "true"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: 未登録のキー",
+			path:           "/items",
+			headers:        map[string]string{HeaderSandbox: "true", HeaderAPIKey: "unknown"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: 非対応のエンドポイント",
+			path:           "/transfers",
+			headers:        map[string]string{HeaderSandbox: "true", HeaderAPIKey: "partner-1"},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = itemController.HTTPErrorHandler
+			e.Use(Middleware([]string{"partner-1"}))
+			var gotKey string
+			handler := func(c echo.Context) error {
+				gotKey, _ = KeyFromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+			e.GET("/items", handler)
+			e.GET("/v2/items", handler)
+			e.GET("/transfers", handler)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedKey, gotKey)
+		})
+	}
+}
+
+// 本番側が呼ばれたら失敗するリポジトリ
+type panicRepository struct {
+	usecase.ItemRepository
+}
+
+func (panicRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	panic("production repository must not be used")
+}
+
+func TestItemRepository_Isolation(t *testing.T) {
+	store := NewStore(time.Hour, 2)
+	repo := NewItemRepository(panicRepository{}, store)
+	ctxA := WithKey(context.Background(), "a")
+	ctxB := WithKey(context.Background(), "b")
+
+	item, _ := entity.NewItem("時計", "時計", "ROLEX", 1000, "2024-01-01")
+	created, err := repo.Create(ctxA, item)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+
+	t.Run("正常系: 他のキーからは見えない", func(t *testing.T) {
+		_, err := repo.FindByID(ctxB, created.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		items, err := repo.FindAll(ctxB, usecase.ItemQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("正常系: 更新と削除", func(t *testing.T) {
+		created.Name = "変更後"
+		updated, err := repo.Update(ctxA, created)
+		require.NoError(t, err)
+		assert.Equal(t, "変更後", updated.Name)
+
+		require.NoError(t, repo.Delete(ctxA, created.ID))
+		assert.ErrorIs(t, repo.Delete(ctxA, created.ID), domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 件数の上限", func(t *testing.T) {
+		_, err := repo.Create(ctxB, item)
+		require.NoError(t, err)
+		_, err = repo.Create(ctxB, item)
+		require.NoError(t, err)
+		_, err = repo.Create(ctxB, item)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestStore_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewStore(time.Hour, 0)
+	store.now = func() time.Time { return now }
+	repo := NewItemRepository(panicRepository{}, store)
+	ctx := WithKey(context.Background(), "a")
+
+	item, _ := entity.NewItem("時計", "時計", "ROLEX", 1000, "2024-01-01")
+	_, err := repo.Create(ctx, item)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	items, err := repo.FindAll(ctx, usecase.ItemQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+// recordingOutbox は記録されたイベントを記録する
+type recordingOutbox struct {
+	events   []usecase.ItemEvent
+	notified int
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, event usecase.ItemEvent) error {
+	o.events = append(o.events, event)
+	return nil
+}
+
+func (o *recordingOutbox) Notify() {
+	o.notified++
+}
+
+func TestEventOutbox(t *testing.T) {
+	production := &recordingOutbox{}
+	outbox := NewEventOutbox(production)
+
+	require.NoError(t, outbox.Append(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1}))
+	require.NoError(t, outbox.Append(WithKey(context.Background(), "key-a"), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2}))
+	outbox.Notify()
+
+	require.Len(t, production.events, 1)
+	assert.Equal(t, int64(1), production.events[0].ItemID)
+	assert.Equal(t, 1, production.notified)
+}
+
+// activeLoans はすべてのアイテムが貸出中の貸出リポジトリ
+type activeLoans struct {
+	usecase.LoanRepository
+}
+
+func (activeLoans) FindActiveByItemID(_ context.Context, itemID int64) (*entity.Loan, error) {
+	return &entity.Loan{ItemID: itemID, Status: entity.LoanStatusActive}, nil
+}
+
+func TestLoanRepository(t *testing.T) {
+	repo := NewLoanRepository(activeLoans{})
+
+	loan, err := repo.FindActiveByItemID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.NotNil(t, loan)
+
+	loan, err = repo.FindActiveByItemID(WithKey(context.Background(), "key-a"), 1)
+	require.NoError(t, err)
+	assert.Nil(t, loan, "サンドボックスのアイテムは貸出中にならない")
+}
+
+// servicedItems はすべてのアイテムに整備記録がある整備記録リポジトリ
+type servicedItems struct {
+	usecase.ServiceRecordRepository
+}
+
+func (servicedItems) SummarizeByItem(_ context.Context, itemIDs []int64) (map[int64]*usecase.ServiceSummary, error) {
+	summaries := make(map[int64]*usecase.ServiceSummary)
+	for _, id := range itemIDs {
+		summaries[id] = &usecase.ServiceSummary{TotalCost: 50000, LastServiceDate: "2024-01-01"}
+	}
+	return summaries, nil
+}
+
+func TestServiceRecordRepository(t *testing.T) {
+	repo := NewServiceRecordRepository(servicedItems{})
+
+	summaries, err := repo.SummarizeByItem(context.Background(), []int64{1})
+	require.NoError(t, err)
+	assert.Len(t, summaries, 1)
+
+	summaries, err = repo.SummarizeByItem(WithKey(context.Background(), "key-a"), []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, summaries, "サンドボックスのアイテムに本番の整備費用を付けない")
+}
+
+// picturedItems はすべてのアイテムに画像がある画像リポジトリ
+type picturedItems struct {
+	usecase.ItemImageRepository
+}
+
+func (picturedItems) FindByItemID(_ context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	return []*entity.ItemImage{{ID: 10, ItemID: itemID}}, nil
+}
+
+func (picturedItems) ListIDsByItem(_ context.Context, itemIDs []int64) (map[int64][]int64, error) {
+	ids := make(map[int64][]int64)
+	for _, id := range itemIDs {
+		ids[id] = []int64{10}
+	}
+	return ids, nil
+}
+
+func TestItemImageRepository(t *testing.T) {
+	repo := NewItemImageRepository(picturedItems{})
+
+	ids, err := repo.ListIDsByItem(context.Background(), []int64{1})
+	require.NoError(t, err)
+	assert.Len(t, ids, 1)
+	images, err := repo.FindByItemID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Len(t, images, 1)
+
+	ctx := WithKey(context.Background(), "key-a")
+	ids, err = repo.ListIDsByItem(ctx, []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, ids, "サンドボックスのアイテムに本番の画像を付けない")
+	images, err = repo.FindByItemID(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, images, "サンドボックスのアイテムの削除で本番の画像を消さない")
+}
+
+// documentedItems はすべてのアイテムに書類がある書類リポジトリ
+type documentedItems struct {
+	usecase.ItemDocumentRepository
+}
+
+func (documentedItems) FindByItemID(_ context.Context, itemID int64, _ string) ([]*entity.ItemDocument, error) {
+	return []*entity.ItemDocument{{ID: 20, ItemID: itemID}}, nil
+}
+
+func TestItemDocumentRepository(t *testing.T) {
+	repo := NewItemDocumentRepository(documentedItems{})
+
+	documents, err := repo.FindByItemID(context.Background(), 1, "")
+	require.NoError(t, err)
+	assert.Len(t, documents, 1)
+
+	documents, err = repo.FindByItemID(WithKey(context.Background(), "key-a"), 1, "")
+	require.NoError(t, err)
+	assert.Empty(t, documents, "サンドボックスのアイテムの削除で本番の書類を消さない")
+}
+
+// taggedItems はすべてのアイテムにタグがあり、付け替えを記録するタグリポジトリ
+type taggedItems struct {
+	usecase.TagRepository
+	set *[]int64
+}
+
+func (taggedItems) ListNamesByItem(_ context.Context, itemIDs []int64) (map[int64][]string, error) {
+	names := make(map[int64][]string)
+	for _, id := range itemIDs {
+		names[id] = []string{"vintage"}
+	}
+	return names, nil
+}
+
+func (r taggedItems) SetItemTags(_ context.Context, itemID int64, _ []int64) error {
+	*r.set = append(*r.set, itemID)
+	return nil
+}
+
+func TestTagRepository(t *testing.T) {
+	var set []int64
+	repo := NewTagRepository(taggedItems{set: &set})
+
+	names, err := repo.ListNamesByItem(context.Background(), []int64{1})
+	require.NoError(t, err)
+	assert.Len(t, names, 1)
+	require.NoError(t, repo.SetItemTags(context.Background(), 1, nil))
+	assert.Equal(t, []int64{1}, set)
+
+	ctx := WithKey(context.Background(), "key-a")
+	names, err = repo.ListNamesByItem(ctx, []int64{1})
+	require.NoError(t, err)
+	assert.Empty(t, names, "サンドボックスのアイテムに本番のタグを付けない")
+	require.NoError(t, repo.SetItemTags(ctx, 1, nil))
+	assert.Equal(t, []int64{1}, set, "サンドボックスのアイテムの削除で本番のタグを外さない")
+}
+
+// collectedItems はコレクションから外したアイテムを記録するコレクションリポジトリ
+type collectedItems struct {
+	usecase.CollectionRepository
+	removed *[]int64
+}
+
+func (r collectedItems) RemoveItemFromAll(_ context.Context, itemID int64) error {
+	*r.removed = append(*r.removed, itemID)
+	return nil
+}
+
+func TestCollectionRepository(t *testing.T) {
+	var removed []int64
+	repo := NewCollectionRepository(collectedItems{removed: &removed})
+
+	require.NoError(t, repo.RemoveItemFromAll(context.Background(), 1))
+	require.NoError(t, repo.RemoveItemFromAll(WithKey(context.Background(), "key-a"), 2))
+	assert.Equal(t, []int64{1}, removed, "サンドボックスのアイテムの削除で本番のアイテムをコレクションから外さない")
+}
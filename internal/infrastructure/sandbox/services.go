@@ -0,0 +1,24 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムには整備記録がないため、整備費用を集計しない整備記録リポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムに本番の費用が付かないようにする）
+type serviceRecordRepository struct {
+	usecase.ServiceRecordRepository
+}
+
+func NewServiceRecordRepository(production usecase.ServiceRecordRepository) usecase.ServiceRecordRepository {
+	return &serviceRecordRepository{ServiceRecordRepository: production}
+}
+
+func (r *serviceRecordRepository) SummarizeByItem(ctx context.Context, itemIDs []int64) (map[int64]*usecase.ServiceSummary, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return map[int64]*usecase.ServiceSummary{}, nil
+	}
+	return r.ServiceRecordRepository.SummarizeByItem(ctx, itemIDs)
+}
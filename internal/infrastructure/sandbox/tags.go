@@ -0,0 +1,39 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムにはタグがないため、アイテムのタグを返さず、変えもしないタグリポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムに本番のタグが付いたり、削除で外れたりしないようにする）
+type tagRepository struct {
+	usecase.TagRepository
+}
+
+func NewTagRepository(production usecase.TagRepository) usecase.TagRepository {
+	return &tagRepository{TagRepository: production}
+}
+
+func (r *tagRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Tag, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.TagRepository.FindByItemID(ctx, itemID)
+}
+
+func (r *tagRepository) SetItemTags(ctx context.Context, itemID int64, tagIDs []int64) error {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil
+	}
+	return r.TagRepository.SetItemTags(ctx, itemID, tagIDs)
+}
+
+func (r *tagRepository) ListNamesByItem(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return map[int64][]string{}, nil
+	}
+	return r.TagRepository.ListNamesByItem(ctx, itemIDs)
+}
@@ -0,0 +1,25 @@
+package sandbox
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// サンドボックスのアイテムは譲渡できないため、アイテムの譲渡履歴を返さない譲渡リポジトリ
+// （本番のアイテムと同じIDのサンドボックスのアイテムの変更履歴に本番の譲渡が混ざらないようにする）
+type transferRepository struct {
+	usecase.TransferRepository
+}
+
+func NewTransferRepository(production usecase.TransferRepository) usecase.TransferRepository {
+	return &transferRepository{TransferRepository: production}
+}
+
+func (r *transferRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Transfer, error) {
+	if _, ok := KeyFromContext(ctx); ok {
+		return nil, nil
+	}
+	return r.TransferRepository.FindByItemID(ctx, itemID)
+}
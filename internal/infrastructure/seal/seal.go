@@ -0,0 +1,105 @@
+// Package seal はパスフレーズで生成ファイルを暗号化する。
+//
+// 形式: "AICSEAL1"(8) | salt(16) | nonce(12) | AES-256-GCM 暗号文
+// 鍵は scrypt(N=32768, r=8, p=1) でパスフレーズとsaltから導出する。
+package seal
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize  = 16
+	nonceSize = 12
+	keySize   = 32
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var magic = []byte("AICSEAL1")
+
+var (
+	ErrInvalidFormat = errors.New("seal: invalid format")
+	ErrDecrypt       = errors.New("seal: wrong passphrase or corrupted data")
+)
+
+// Encrypter は usecase.PackageEncrypter と usecase.BackupEncrypter の実装
+type Encrypter struct{}
+
+func NewEncrypter() *Encrypter {
+	return &Encrypter{}
+}
+
+func (e *Encrypter) Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	return Encrypt(plaintext, passphrase)
+}
+
+func (e *Encrypter) Decrypt(data []byte, passphrase string) ([]byte, error) {
+	return Decrypt(data, passphrase)
+}
+
+func Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("seal: failed to generate salt: %w", err)
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("seal: failed to generate nonce: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(magic)+saltSize+nonceSize+len(plaintext)+aead.Overhead())
+	out = append(out, magic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	// ヘッダーを追加データとして認証し、改ざんを検出する
+	return aead.Seal(out, nonce, plaintext, out), nil
+}
+
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	headerSize := len(magic) + saltSize + nonceSize
+	if len(data) < headerSize || !bytes.Equal(data[:len(magic)], magic) {
+		return nil, ErrInvalidFormat
+	}
+
+	salt := data[len(magic) : len(magic)+saltSize]
+	nonce := data[len(magic)+saltSize : headerSize]
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], data[:headerSize])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("seal: failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
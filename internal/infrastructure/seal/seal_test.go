@@ -0,0 +1,40 @@
+package seal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	plaintext := []byte("資産目録 %PDF-1.4")
+
+	sealed, err := Encrypt(plaintext, "correct horse battery")
+	require.NoError(t, err)
+	assert.Equal(t, "AICSEAL1", string(sealed[:8]))
+	assert.NotContains(t, string(sealed), "資産目録")
+
+	t.Run("正常系: 同じパスフレーズで復号", func(t *testing.T) {
+		out, err := Decrypt(sealed, "correct horse battery")
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, out)
+	})
+
+	t.Run("異常系: パスフレーズ違い", func(t *testing.T) {
+		_, err := Decrypt(sealed, "wrong passphrase!!")
+		assert.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("異常系: 改ざんされたヘッダー", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[10] ^= 0xff
+		_, err := Decrypt(tampered, "correct horse battery")
+		assert.ErrorIs(t, err, ErrDecrypt)
+	})
+
+	t.Run("異常系: 形式が不正", func(t *testing.T) {
+		_, err := Decrypt([]byte("not sealed"), "correct horse battery")
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+}
@@ -0,0 +1,316 @@
+// Package searchindex はアイテムを Elasticsearch / OpenSearch のインデックスに写し、検索に使う。
+//
+// アイテムの登録・更新・削除はイベントバス経由で Indexer がインデックスに反映し、
+// /items/search は Client で検索する（usecase.NewIndexedSearchUsecase）。
+// クライアントライブラリは使わず、両者に共通する REST API（_bulk, _search, _delete_by_query）を呼ぶ。
+package searchindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	DefaultIndexName = "items"
+	DefaultTimeout   = 5 * time.Second
+	// エラーの応答からエラーメッセージに含める長さ
+	maxErrorBodySize = 1024
+)
+
+type Config struct {
+	URL      string // http://localhost:9200
+	Index    string // インデックス名
+	Username string // Basic 認証（空なら認証しない）
+	Password string
+	Timeout  time.Duration // 1回のリクエストの期限
+}
+
+// Client は Elasticsearch / OpenSearch のインデックスを読み書きする（usecase.SearchIndex の実装）
+type Client struct {
+	baseURL  string
+	index    string
+	username string
+	password string
+	client   *http.Client
+	now      func() time.Time
+}
+
+func New(cfg Config) *Client {
+	index := cfg.Index
+	if index == "" {
+		index = DefaultIndexName
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		baseURL:  strings.TrimSuffix(cfg.URL, "/"),
+		index:    index,
+		username: cfg.Username,
+		password: cfg.Password,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+	}
+}
+
+// インデックスの文書。アイテムの項目（集計した整備費用・画像・タグは含めない）に、最後に登録した日時を加える
+type document struct {
+	*entity.Item
+	// 再インデックスで見つからなかった（削除された）アイテムを見分けるため、エポックからのミリ秒で持つ
+	IndexedAt int64 `json:"indexed_at"`
+}
+
+// インデックスのマッピング。検索する名前・ブランドは全文検索、カテゴリーは絞り込み用。
+// それ以外の項目は検索しないため、_source に保存するだけにする
+var indexMapping = map[string]interface{}{
+	"mappings": map[string]interface{}{
+		"dynamic": false,
+		"properties": map[string]interface{}{
+			"id":         map[string]string{"type": "long"},
+			"name":       map[string]string{"type": "text"},
+			"brand":      map[string]string{"type": "text"},
+			"category":   map[string]string{"type": "keyword"},
+			"indexed_at": map[string]string{"type": "long"},
+		},
+	},
+}
+
+// インデックスがなければ作成する。作成したら true を返す（アイテムは登録されていないため、再インデックスが必要）
+func (c *Client) EnsureIndex(ctx context.Context) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, "/"+c.index, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return false, fmt.Errorf("search index: HEAD /%s: unexpected status %s", c.index, resp.Status)
+	}
+
+	body, err := json.Marshal(indexMapping)
+	if err != nil {
+		return false, err
+	}
+	if err := c.call(ctx, http.MethodPut, "/"+c.index, body, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type searchRequest struct {
+	From           int                    `json:"from"`
+	Size           int                    `json:"size"`
+	TrackTotalHits bool                   `json:"track_total_hits"`
+	Query          map[string]interface{} `json:"query"`
+	Sort           []interface{}          `json:"sort"`
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			Source entity.Item `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// 名前・ブランド・カテゴリーにすべての語を含むアイテムを、関連度の高い順に返す。語は綴りの誤りを許す（fuzziness AUTO）
+func (c *Client) Search(ctx context.Context, query usecase.SearchQuery) ([]*entity.Item, int, error) {
+	boolQuery := map[string]interface{}{
+		"must": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     query.Text,
+				"fields":    []string{"name", "brand", "category"},
+				"fuzziness": "AUTO",
+				"operator":  "and",
+			},
+		},
+	}
+	if query.Category != "" {
+		boolQuery["filter"] = []interface{}{
+			map[string]interface{}{"term": map[string]string{"category": query.Category}},
+		}
+	}
+	body, err := json.Marshal(searchRequest{
+		From:           (query.Page - 1) * query.PageSize,
+		Size:           query.PageSize,
+		TrackTotalHits: true,
+		Query:          map[string]interface{}{"bool": boolQuery},
+		// 関連度が同じなら ID の順
+		Sort: []interface{}{"_score", map[string]string{"id": "asc"}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var resp searchResponse
+	if err := c.call(ctx, http.MethodPost, "/"+c.index+"/_search", body, &resp); err != nil {
+		return nil, 0, err
+	}
+	items := make([]*entity.Item, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		item := hit.Source
+		items = append(items, &item)
+	}
+	return items, resp.Hits.Total.Value, nil
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID    string          `json:"_id"`
+		Error json.RawMessage `json:"error"`
+	} `json:"items"`
+}
+
+// アイテムをまとめて登録する（同じ ID の文書は置き換える）
+func (c *Client) Index(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	indexedAt := c.now().UnixMilli()
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, item := range items {
+		action := map[string]interface{}{"index": map[string]string{"_index": c.index, "_id": strconv.FormatInt(item.ID, 10)}}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(document{Item: indexedItem(item), IndexedAt: indexedAt}); err != nil {
+			return err
+		}
+	}
+
+	var resp bulkResponse
+	if err := c.call(ctx, http.MethodPost, "/_bulk", body.Bytes(), &resp); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+	// 一部の文書だけが失敗しても 200 が返るため、最初の失敗を返す
+	failed := 0
+	var first string
+	for _, result := range resp.Items {
+		for _, r := range result {
+			if len(r.Error) > 0 && string(r.Error) != "null" {
+				if failed == 0 {
+					first = fmt.Sprintf("item %s: %s", r.ID, r.Error)
+				}
+				failed++
+			}
+		}
+	}
+	return fmt.Errorf("search index: failed to index %d of %d item(s), first: %s", failed, len(items), first)
+}
+
+// インデックスに保存するアイテムの項目（集計した値は一覧のアイテムにも含まれないため除く）
+func indexedItem(item *entity.Item) *entity.Item {
+	copied := *item
+	copied.MaintenanceCost = 0
+	copied.ImageIDs = nil
+	copied.Tags = nil
+	return &copied
+}
+
+// アイテムをインデックスから削除する（登録されていなければ何もしない）
+func (c *Client) Delete(ctx context.Context, id int64) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/"+c.index+"/_doc/"+strconv.FormatInt(id, 10), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return statusError(http.MethodDelete, "/"+c.index+"/_doc/{id}", resp)
+}
+
+// 最後に登録した日時が t より前の文書を削除する
+func (c *Client) DeleteIndexedBefore(ctx context.Context, t time.Time) (int, error) {
+	// 直前に登録した文書が削除の検索に見えるよう、先に refresh する
+	if err := c.call(ctx, http.MethodPost, "/"+c.index+"/_refresh", nil, nil); err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{"indexed_at": map[string]int64{"lt": t.UnixMilli()}},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var resp struct {
+		Deleted int `json:"deleted"`
+	}
+	// 削除中に更新された文書は残す（conflicts=proceed）。削除の結果もすぐ検索に反映されるよう refresh する
+	if err := c.call(ctx, http.MethodPost, "/"+c.index+"/_delete_by_query?conflicts=proceed&refresh=true", body, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Deleted, nil
+}
+
+// リクエストを送り、2xx 以外はエラーにする。out が nil でなければ応答の JSON を読み込む
+func (c *Client) call(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	resp, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return statusError(method, path, resp)
+	}
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("search index: %s %s: invalid response: %w", method, path, err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("search index: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		// _bulk は NDJSON だが、Elasticsearch・OpenSearch とも application/json で受け付ける
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("search index: %s %s: %w", method, path, err)
+	}
+	return resp, nil
+}
+
+func statusError(method, path string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return fmt.Errorf("search index: %s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(body))
+}
@@ -0,0 +1,235 @@
+package searchindex
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.SearchIndex = (*Client)(nil)
+
+// 受け取ったリクエストを記録し、決められた応答を返す Elasticsearch の代わり
+type fakeCluster struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	status   int
+	response string
+	// メソッドごとの応答のステータス（なければ status）
+	statusByMethod map[string]int
+}
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+	Auth   string
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.requests = append(f.requests, recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body), Auth: r.Header.Get("Authorization")})
+	f.mu.Unlock()
+	status := f.status
+	if s, ok := f.statusByMethod[r.Method]; ok {
+		status = s
+	}
+	if status != 0 {
+		w.WriteHeader(status)
+	}
+	io.WriteString(w, f.response)
+}
+
+func newTestClient(t *testing.T, cluster *fakeCluster) *Client {
+	t.Helper()
+	srv := httptest.NewServer(cluster)
+	t.Cleanup(srv.Close)
+	client := New(Config{URL: srv.URL + "/", Index: "test-items", Username: "elastic", Password: "changeme"})
+	client.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	return client
+}
+
+func TestClient_Search(t *testing.T) {
+	t.Run("正常系: 語とカテゴリーで検索し、アイテムと件数を返す", func(t *testing.T) {
+		cluster := &fakeCluster{response: `{"hits":{"total":{"value":42},"hits":[
+			{"_id":"3","_source":{"id":3,"name":"デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"version":2,"indexed_at":1700000000000}}]}}`}
+		client := newTestClient(t, cluster)
+
+		items, total, err := client.Search(context.Background(), usecase.SearchQuery{Text: "rolx", Category: "時計", Page: 3, PageSize: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, 42, total)
+		require.Len(t, items, 1)
+		assert.Equal(t, &entity.Item{ID: 3, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Version: 2}, items[0])
+
+		require.Len(t, cluster.requests, 1)
+		req := cluster.requests[0]
+		assert.Equal(t, "POST /test-items/_search", req.Method+" "+req.Path)
+		assert.True(t, strings.HasPrefix(req.Auth, "Basic "))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(req.Body), &body))
+		assert.Equal(t, 20.0, body["from"])
+		assert.Equal(t, 10.0, body["size"])
+		assert.JSONEq(t, `{"bool":{
+			"must":{"multi_match":{"query":"rolx","fields":["name","brand","category"],"fuzziness":"AUTO","operator":"and"}},
+			"filter":[{"term":{"category":"時計"}}]}}`, mustJSON(t, body["query"]))
+	})
+
+	t.Run("異常系: エラーの応答はエラーにする", func(t *testing.T) {
+		cluster := &fakeCluster{status: http.StatusNotFound, response: `{"error":{"type":"index_not_found_exception"}}`}
+
+		_, _, err := newTestClient(t, cluster).Search(context.Background(), usecase.SearchQuery{Text: "rolex", Page: 1, PageSize: 20})
+
+		assert.ErrorContains(t, err, "404 Not Found")
+		assert.ErrorContains(t, err, "index_not_found_exception")
+	})
+
+	t.Run("異常系: 接続できなければエラーにする", func(t *testing.T) {
+		client := New(Config{URL: "http://127.0.0.1:1", Timeout: time.Second})
+
+		_, _, err := client.Search(context.Background(), usecase.SearchQuery{Text: "rolex", Page: 1, PageSize: 20})
+
+		assert.ErrorContains(t, err, "search index")
+	})
+}
+
+func TestClient_Index(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", Tags: []string{"限定"}, ImageIDs: []int64{7}, MaintenanceCost: 30000},
+		{ID: 2, Name: "バーキン", Category: "バッグ", Brand: "HERMES"},
+	}
+
+	t.Run("正常系: アイテムを _bulk でまとめて登録する", func(t *testing.T) {
+		cluster := &fakeCluster{response: `{"errors":false,"items":[]}`}
+
+		require.NoError(t, newTestClient(t, cluster).Index(context.Background(), items))
+
+		require.Len(t, cluster.requests, 1)
+		req := cluster.requests[0]
+		assert.Equal(t, "POST /_bulk", req.Method+" "+req.Path)
+		var lines []string
+		scanner := bufio.NewScanner(strings.NewReader(req.Body))
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		require.Len(t, lines, 4)
+		assert.JSONEq(t, `{"index":{"_index":"test-items","_id":"1"}}`, lines[0])
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &doc))
+		assert.Equal(t, "デイトナ", doc["name"])
+		assert.Equal(t, 1700000000000.0, doc["indexed_at"])
+		// 集計した値は保存しない
+		assert.NotContains(t, doc, "tags")
+		assert.NotContains(t, doc, "image_ids")
+		assert.NotContains(t, doc, "maintenance_cost")
+		assert.JSONEq(t, `{"index":{"_index":"test-items","_id":"2"}}`, lines[2])
+		// 呼び出し元のアイテムは変えない
+		assert.Equal(t, []string{"限定"}, items[0].Tags)
+	})
+
+	t.Run("異常系: 一部の文書の失敗もエラーにする", func(t *testing.T) {
+		cluster := &fakeCluster{response: `{"errors":true,"items":[
+			{"index":{"_id":"1","status":201}},
+			{"index":{"_id":"2","status":400,"error":{"type":"mapper_parsing_exception"}}}]}`}
+
+		err := newTestClient(t, cluster).Index(context.Background(), items)
+
+		assert.ErrorContains(t, err, "failed to index 1 of 2 item(s)")
+		assert.ErrorContains(t, err, "item 2")
+		assert.ErrorContains(t, err, "mapper_parsing_exception")
+	})
+
+	t.Run("正常系: アイテムがなければリクエストしない", func(t *testing.T) {
+		cluster := &fakeCluster{}
+
+		require.NoError(t, newTestClient(t, cluster).Index(context.Background(), nil))
+
+		assert.Empty(t, cluster.requests)
+	})
+}
+
+func TestClient_Delete(t *testing.T) {
+	t.Run("正常系: 文書を削除する", func(t *testing.T) {
+		cluster := &fakeCluster{response: `{"result":"deleted"}`}
+
+		require.NoError(t, newTestClient(t, cluster).Delete(context.Background(), 5))
+
+		assert.Equal(t, "DELETE /test-items/_doc/5", cluster.requests[0].Method+" "+cluster.requests[0].Path)
+	})
+
+	t.Run("正常系: 登録されていない文書の削除はエラーにしない", func(t *testing.T) {
+		cluster := &fakeCluster{status: http.StatusNotFound, response: `{"result":"not_found"}`}
+
+		assert.NoError(t, newTestClient(t, cluster).Delete(context.Background(), 5))
+	})
+
+	t.Run("異常系: それ以外のエラーの応答はエラーにする", func(t *testing.T) {
+		cluster := &fakeCluster{status: http.StatusServiceUnavailable}
+
+		assert.ErrorContains(t, newTestClient(t, cluster).Delete(context.Background(), 5), "503")
+	})
+}
+
+func TestClient_DeleteIndexedBefore(t *testing.T) {
+	cluster := &fakeCluster{response: `{"deleted":3}`}
+
+	deleted, err := newTestClient(t, cluster).DeleteIndexedBefore(context.Background(), time.UnixMilli(1700000000000))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+	require.Len(t, cluster.requests, 2)
+	assert.Equal(t, "POST /test-items/_refresh", cluster.requests[0].Method+" "+cluster.requests[0].Path)
+	req := cluster.requests[1]
+	assert.Equal(t, "POST /test-items/_delete_by_query", req.Method+" "+req.Path)
+	assert.Equal(t, "conflicts=proceed&refresh=true", req.Query)
+	assert.JSONEq(t, `{"query":{"range":{"indexed_at":{"lt":1700000000000}}}}`, req.Body)
+}
+
+func TestClient_EnsureIndex(t *testing.T) {
+	t.Run("正常系: インデックスがなければマッピングを付けて作成する", func(t *testing.T) {
+		cluster := &fakeCluster{statusByMethod: map[string]int{http.MethodHead: http.StatusNotFound}}
+		client := newTestClient(t, cluster)
+
+		created, err := client.EnsureIndex(context.Background())
+
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		require.Len(t, cluster.requests, 2)
+		assert.Equal(t, "PUT /test-items", cluster.requests[1].Method+" "+cluster.requests[1].Path)
+		assert.Contains(t, cluster.requests[1].Body, `"category":{"type":"keyword"}`)
+	})
+
+	t.Run("正常系: インデックスがあれば何もしない", func(t *testing.T) {
+		cluster := &fakeCluster{}
+
+		created, err := newTestClient(t, cluster).EnsureIndex(context.Background())
+
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		require.Len(t, cluster.requests, 1)
+		assert.Equal(t, http.MethodHead, cluster.requests[0].Method)
+	})
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
@@ -0,0 +1,128 @@
+package searchindex
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	DefaultBufferSize = 1024
+	// 1件の反映の期限
+	indexTimeout = 10 * time.Second
+	// 停止時に反映待ちのイベントを反映し切るまで待つ時間
+	defaultShutdownTimeout = 10 * time.Second
+)
+
+// インデックスに反映するイベント
+var EventTypes = []string{usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted}
+
+var (
+	droppedCount    = expvar.NewInt("search_index_events_dropped")
+	indexErrorCount = expvar.NewInt("search_index_errors")
+)
+
+// Indexer はアイテムの登録・更新・削除をインデックスに反映する（usecase.ItemEventPublisher の実装）。
+// 反映はバックグラウンドで行い、反映待ちがバッファを超えたイベントは破棄する（破棄数は /debug/vars の search_index_events_dropped）。
+// 破棄や失敗で反映されなかった変更は、次の再インデックスで反映される
+type Indexer struct {
+	index    usecase.SearchIndex
+	shutdown time.Duration
+	logf     func(format string, args ...interface{})
+
+	mu     sync.RWMutex
+	events chan usecase.ItemEvent
+	closed bool
+	done   chan struct{}
+}
+
+// shutdownTimeout が0以下なら既定の10秒
+func NewIndexer(index usecase.SearchIndex, bufferSize int, shutdownTimeout time.Duration) *Indexer {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	return &Indexer{
+		index:    index,
+		shutdown: shutdownTimeout,
+		logf:     log.Printf,
+		events:   make(chan usecase.ItemEvent, bufferSize),
+		done:     make(chan struct{}),
+	}
+}
+
+func (i *Indexer) Publish(_ context.Context, event usecase.ItemEvent) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.closed {
+		droppedCount.Add(1)
+		return
+	}
+
+	select {
+	case i.events <- event:
+	default:
+		droppedCount.Add(1)
+	}
+}
+
+// 受け取ったイベントを順に反映する。Close まで戻らない
+func (i *Indexer) Run() {
+	defer close(i.done)
+
+	for event := range i.events {
+		ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+		err := i.apply(ctx, event)
+		cancel()
+		if err != nil {
+			indexErrorCount.Add(1)
+			i.logf("⚠️  search index: failed to apply %s event for item %d: %v", event.Type, event.ItemID, err)
+		}
+	}
+}
+
+// 再送するイベントをインデックスに反映する（usecase.ItemEventSink の実装）。反映待ちのイベントとは別に、反映し終えるまで待つ
+func (i *Indexer) Replay(ctx context.Context, event usecase.ItemEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, indexTimeout)
+	defer cancel()
+	return i.apply(ctx, event)
+}
+
+func (i *Indexer) apply(ctx context.Context, event usecase.ItemEvent) error {
+	switch event.Type {
+	case usecase.ItemCreated, usecase.ItemUpdated:
+		if event.Item == nil {
+			return nil
+		}
+		return i.index.Index(ctx, []*entity.Item{event.Item})
+	case usecase.ItemDeleted:
+		return i.index.Delete(ctx, event.ItemID)
+	}
+	return nil
+}
+
+// 反映待ちのイベントを反映し切ってから止める。ShutdownTimeout を過ぎたら残りを破棄する
+func (i *Indexer) Close() {
+	i.mu.Lock()
+	if i.closed {
+		i.mu.Unlock()
+		<-i.done
+		return
+	}
+	i.closed = true
+	close(i.events)
+	i.mu.Unlock()
+
+	select {
+	case <-i.done:
+	case <-time.After(i.shutdown):
+		i.logf("⚠️  search index: gave up indexing %d item event(s) on shutdown", len(i.events))
+	}
+}
@@ -0,0 +1,131 @@
+package searchindex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeIndex は反映された変更を記録する
+type fakeIndex struct {
+	mu      sync.Mutex
+	changes []string
+	err     error
+	block   chan struct{} // 閉じるまで反映を止める
+}
+
+func (f *fakeIndex) record(change string) error {
+	if f.block != nil {
+		<-f.block
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.changes = append(f.changes, change)
+	return f.err
+}
+
+func (f *fakeIndex) applied() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.changes...)
+}
+
+func (f *fakeIndex) Search(ctx context.Context, query usecase.SearchQuery) ([]*entity.Item, int, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeIndex) Index(ctx context.Context, items []*entity.Item) error {
+	return f.record("index " + items[0].Name)
+}
+
+func (f *fakeIndex) Delete(ctx context.Context, id int64) error {
+	return f.record("delete")
+}
+
+func (f *fakeIndex) DeleteIndexedBefore(ctx context.Context, t time.Time) (int, error) {
+	return 0, nil
+}
+
+func newTestIndexer(t *testing.T, index usecase.SearchIndex, bufferSize int) *Indexer {
+	t.Helper()
+	indexer := NewIndexer(index, bufferSize, time.Second)
+	indexer.logf = t.Logf
+	go indexer.Run()
+	return indexer
+}
+
+func TestIndexer(t *testing.T) {
+	t.Run("正常系: 登録・更新は登録し、削除は削除する", func(t *testing.T) {
+		index := &fakeIndex{}
+		indexer := newTestIndexer(t, index, 0)
+
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1, Item: &entity.Item{ID: 1, Name: "デイトナ"}})
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 1, Item: &entity.Item{ID: 1, Name: "サブマリーナ"}})
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemExpiring, ItemID: 1, Item: &entity.Item{ID: 1, Name: "サブマリーナ"}})
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1, Item: &entity.Item{ID: 1, Name: "サブマリーナ"}})
+		indexer.Close()
+
+		assert.Equal(t, []string{"index デイトナ", "index サブマリーナ", "delete"}, index.applied())
+	})
+
+	t.Run("正常系: 反映に失敗しても次のイベントを反映する", func(t *testing.T) {
+		index := &fakeIndex{err: errors.New("connection refused")}
+		indexer := newTestIndexer(t, index, 0)
+
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1, Item: &entity.Item{ID: 1, Name: "デイトナ"}})
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 2})
+		indexer.Close()
+
+		assert.Equal(t, []string{"index デイトナ", "delete"}, index.applied())
+	})
+
+	t.Run("正常系: バッファを超えたイベントは破棄し、Publish は止まらない", func(t *testing.T) {
+		index := &fakeIndex{block: make(chan struct{})}
+		indexer := newTestIndexer(t, index, 1)
+		dropped := droppedCount.Value()
+
+		for i := 0; i < 5; i++ {
+			indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: int64(i)})
+		}
+		close(index.block)
+		indexer.Close()
+
+		assert.GreaterOrEqual(t, droppedCount.Value()-dropped, int64(3))
+	})
+
+	t.Run("正常系: 停止後のイベントは破棄する", func(t *testing.T) {
+		index := &fakeIndex{}
+		indexer := newTestIndexer(t, index, 0)
+		indexer.Close()
+
+		indexer.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1})
+		indexer.Close()
+
+		assert.Empty(t, index.applied())
+	})
+}
+
+func TestIndexer_Replay(t *testing.T) {
+	t.Run("正常系: 反映し終えてから戻る", func(t *testing.T) {
+		index := &fakeIndex{}
+		indexer := newTestIndexer(t, index, 0)
+		defer indexer.Close()
+
+		assert.NoError(t, indexer.Replay(context.Background(), usecase.ItemEvent{Type: usecase.ItemUpdated, ItemID: 1, Item: &entity.Item{ID: 1, Name: "デイトナ"}}))
+		assert.Equal(t, []string{"index デイトナ"}, index.applied())
+	})
+
+	t.Run("異常系: 反映の失敗を返す", func(t *testing.T) {
+		indexer := newTestIndexer(t, &fakeIndex{err: errors.New("connection refused")}, 0)
+		defer indexer.Close()
+
+		assert.EqualError(t, indexer.Replay(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 1}), "connection refused")
+	})
+}
@@ -0,0 +1,352 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+var startedAt = time.Now()
+
+// 管理用サーバー（pprof と実行時統計、Prometheus のメトリクス、保持期間を過ぎたデータの削除、整合性チェック、バックアップ、検索インデックスの再構築、イベントの再送）
+// 本番のメモリ調査用。公開ポートとは分け、設定で有効にした場合のみ起動する。
+// search は検索インデックスを使わない場合 nil、replay はイベントを再送しない場合 nil
+func newAdminServer(addr string, registry *metrics.Registry, retention retentionPurger, integrity usecase.IntegrityUsecase, backups usecase.BackupUsecase, search searchReindexer, replay usecase.EventReplayUsecase) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	// expvar 形式（cmdline, memstats と登録済みの変数）
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/debug/runtime", runtimeStats)
+	// Prometheus のテキスト形式（業務のメトリクス）
+	mux.Handle("GET /metrics", registry.Handler())
+
+	// GET は削除する件数の確認（dry run）、POST は間隔を待たずに削除する
+	mux.HandleFunc("GET /retention", purgeExpired(retention, true))
+	mux.HandleFunc("POST /retention", purgeExpired(retention, false))
+
+	// GET は不整合の報告のみ、POST は修復も行う
+	mux.HandleFunc("GET /integrity", checkIntegrity(integrity, false))
+	mux.HandleFunc("POST /integrity", checkIntegrity(integrity, true))
+
+	// 鍵はリクエストボディで受け取り、保存しない
+	mux.HandleFunc("POST /backups", createBackup(backups))
+	mux.HandleFunc("GET /backups", listBackups(backups))
+	mux.HandleFunc("POST /backups/{id}/restore", restoreBackup(backups))
+
+	// すべてのアイテムを登録し直し、削除済みのアイテムをインデックスから除く
+	if search != nil {
+		mux.HandleFunc("POST /search/reindex", reindexSearch(search))
+	}
+
+	// アウトボックスに残っているイベントをシンク（webhook・broker・search）に送り直す。GET は件数の確認（dry run）
+	if replay != nil {
+		mux.HandleFunc("GET /events/replay", replayEvents(replay, true))
+		mux.HandleFunc("POST /events/replay", replayEvents(replay, false))
+	}
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// create-admin サブコマンドから呼ばれ、管理用サーバーのアカウントを作成する
+func CreateAdmin(ctx context.Context, cfg *config.Config, name, password string) (*entity.AdminUser, error) {
+	sqlHandler, err := databaseInfra.NewSqlHandlerFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlHandler.Close()
+
+	return usecase.NewAdminUserUsecase(&itemDatabase.AdminUserRepository{SqlHandler: sqlHandler}).CreateAdmin(ctx, name, password)
+}
+
+// 管理用サーバーのアカウント（create-admin で作成）があれば Basic 認証を求める。
+// アカウントが1つもなければ従来どおり認証なしで受け付ける
+func requireAdmin(admins usecase.AdminUserUsecase, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		has, err := admins.HasAdmins(r.Context())
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		if !has {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		name, password, ok := r.BasicAuth()
+		if ok {
+			ok, err = admins.Authenticate(r.Context(), name, password)
+			if err != nil {
+				writeAdminError(w, err)
+				return
+			}
+		}
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin", charset="UTF-8"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error string `json:"error"`
+			}{Error: "admin credentials required"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// 実行時統計
+type RuntimeStats struct {
+	Goroutines    int     `json:"goroutines"`
+	GOMAXPROCS    int     `json:"gomaxprocs"`
+	HeapAlloc     uint64  `json:"heap_alloc_bytes"`
+	HeapInuse     uint64  `json:"heap_inuse_bytes"`
+	HeapObjects   uint64  `json:"heap_objects"`
+	Sys           uint64  `json:"sys_bytes"`
+	NumGC         uint32  `json:"num_gc"`
+	LastGCPauseMS float64 `json:"last_gc_pause_ms"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+}
+
+func runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	stats := RuntimeStats{
+		Goroutines:    runtime.NumGoroutine(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		HeapAlloc:     m.HeapAlloc,
+		HeapInuse:     m.HeapInuse,
+		HeapObjects:   m.HeapObjects,
+		Sys:           m.Sys,
+		NumGC:         m.NumGC,
+		LastGCPauseMS: float64(m.PauseNs[(m.NumGC+255)%256]) / 1e6,
+		UptimeSeconds: time.Since(startedAt).Seconds(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// 保持期間を過ぎたデータを削除する（retention.Job）
+type retentionPurger interface {
+	Purge(ctx context.Context, dryRun bool) (*usecase.RetentionReport, error)
+}
+
+func purgeExpired(retention retentionPurger, dryRun bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := retention.Purge(r.Context(), dryRun)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			// 途中までに削除した件数も返す
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error  string                   `json:"error"`
+				Report *usecase.RetentionReport `json:"report,omitempty"`
+			}{Error: err.Error(), Report: report})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+func checkIntegrity(integrity usecase.IntegrityUsecase, repair bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := integrity.Check(r.Context(), repair)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			// 途中までに修復した結果も返す
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error  string                   `json:"error"`
+				Report *usecase.IntegrityReport `json:"report,omitempty"`
+			}{Error: err.Error(), Report: report})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// 検索インデックスを再構築する（usecase.IndexedSearchUsecase）
+type searchReindexer interface {
+	Reindex(ctx context.Context) (*usecase.ReindexReport, error)
+}
+
+func reindexSearch(search searchReindexer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := search.Reindex(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			// 途中までに登録した件数も返す
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error  string                 `json:"error"`
+				Report *usecase.ReindexReport `json:"report,omitempty"`
+			}{Error: err.Error(), Report: report})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// クエリパラメーター（sink・since・until・item_id・after_id・topic_prefix）から再送の指定を読み取る。
+// 日時は RFC 3339 形式
+func parseReplayRequest(r *http.Request, dryRun bool) (usecase.ReplayRequest, error) {
+	q := r.URL.Query()
+	req := usecase.ReplayRequest{Sink: q.Get("sink"), TopicPrefix: q.Get("topic_prefix"), DryRun: dryRun}
+
+	var errs []string
+	for name, t := range map[string]*time.Time{"since": &req.Since, "until": &req.Until} {
+		if v := q.Get(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				errs = append(errs, name+" must be an RFC 3339 date and time")
+				continue
+			}
+			*t = parsed
+		}
+	}
+	for name, id := range map[string]*int64{"item_id": &req.ItemID, "after_id": &req.AfterID} {
+		if v := q.Get(name); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				errs = append(errs, name+" must be an integer")
+				continue
+			}
+			*id = parsed
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return req, domainErrors.NewValidationError(errs...)
+	}
+	return req, nil
+}
+
+func replayEvents(replay usecase.EventReplayUsecase, dryRun bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := parseReplayRequest(r, dryRun)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		report, err := replay.Replay(r.Context(), req)
+		if err != nil && report == nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			// 途中までに再送した件数と、再開に使う最後のイベントの ID も返す
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(struct {
+				Error  string                `json:"error"`
+				Report *usecase.ReplayReport `json:"report,omitempty"`
+			}{Error: err.Error(), Report: report})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// バックアップの作成・復元のリクエストボディ
+type backupKeyRequest struct {
+	Key string `json:"key"`
+}
+
+// 鍵だけのボディの上限（バイト）
+const maxBackupRequestSize = 4 << 10
+
+func readBackupKey(w http.ResponseWriter, r *http.Request) (string, error) {
+	var req backupKeyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBackupRequestSize)).Decode(&req); err != nil {
+		return "", fmt.Errorf("%w: request body must be {\"key\": \"...\"}", domainErrors.ErrInvalidInput)
+	}
+	return req.Key, nil
+}
+
+func createBackup(backups usecase.BackupUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, err := readBackupKey(w, r)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		backup, err := backups.CreateBackup(r.Context(), key)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(backup)
+	}
+}
+
+func listBackups(backups usecase.BackupUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := backups.ListBackups(r.Context())
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(list)
+	}
+}
+
+func restoreBackup(backups usecase.BackupUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, err := readBackupKey(w, r)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		restore, err := backups.RestoreBackup(r.Context(), r.PathValue("id"), key)
+		if err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(restore)
+	}
+}
+
+// 入力の誤りは400、存在しないものは404、それ以外は500で返す
+func writeAdminError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case domainErrors.IsValidationError(err):
+		status = http.StatusBadRequest
+	case domainErrors.IsNotFoundError(err):
+		status = http.StatusNotFound
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
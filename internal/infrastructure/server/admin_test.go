@@ -0,0 +1,377 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeRetentionPurger は dry run かどうかを記録する
+type fakeRetentionPurger struct {
+	dryRuns []bool
+	err     error
+}
+
+func (p *fakeRetentionPurger) Purge(ctx context.Context, dryRun bool) (*usecase.RetentionReport, error) {
+	p.dryRuns = append(p.dryRuns, dryRun)
+	return &usecase.RetentionReport{DryRun: dryRun, Items: 2}, p.err
+}
+
+// fakeIntegrityChecker は修復するかどうかを記録する
+type fakeIntegrityChecker struct {
+	repairs []bool
+	err     error
+}
+
+func (c *fakeIntegrityChecker) Check(ctx context.Context, repair bool) (*usecase.IntegrityReport, error) {
+	c.repairs = append(c.repairs, repair)
+	return &usecase.IntegrityReport{Repair: repair, Issues: []*usecase.IntegrityIssue{}}, c.err
+}
+
+// fakeBackups は鍵 "correct horse battery" のバックアップを1つ持つ
+type fakeBackups struct {
+	created bool
+}
+
+func (b *fakeBackups) CreateBackup(ctx context.Context, key string) (*entity.Backup, error) {
+	if key != "correct horse battery" {
+		return nil, fmt.Errorf("%w: key must be at least 12 characters", domainErrors.ErrInvalidInput)
+	}
+	b.created = true
+	return &entity.Backup{ID: "20240301T000000Z-0123abcd", Size: 10, CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}, nil
+}
+
+func (b *fakeBackups) ListBackups(ctx context.Context) ([]*entity.Backup, error) {
+	return []*entity.Backup{{ID: "20240301T000000Z-0123abcd", CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}}, nil
+}
+
+func (b *fakeBackups) RestoreBackup(ctx context.Context, id, key string) (*usecase.BackupRestore, error) {
+	if id != "20240301T000000Z-0123abcd" {
+		return nil, domainErrors.ErrBackupNotFound
+	}
+	return &usecase.BackupRestore{ID: id, Rows: 5}, nil
+}
+
+func TestAdminServer(t *testing.T) {
+	handler := newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, &fakeBackups{}, nil, nil).Handler
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "正常系: 実行時統計", path: "/debug/runtime"},
+		{name: "正常系: expvar", path: "/debug/vars"},
+		{name: "正常系: pprof一覧", path: "/debug/pprof/"},
+		{name: "正常系: Prometheus のメトリクス", path: "/metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+
+	t.Run("正常系: 統計の内容", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+
+		var stats RuntimeStats
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+		assert.Positive(t, stats.Goroutines)
+		assert.Positive(t, stats.HeapAlloc)
+	})
+}
+
+func TestAdminServer_Retention(t *testing.T) {
+	t.Run("正常系: GET は dry run", func(t *testing.T) {
+		purger := &fakeRetentionPurger{}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), purger, &fakeIntegrityChecker{}, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/retention", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"dry_run":true,"items":2,"revisions":0,"users":0}`, rec.Body.String())
+		assert.Equal(t, []bool{true}, purger.dryRuns)
+	})
+
+	t.Run("正常系: POST は削除する", func(t *testing.T) {
+		purger := &fakeRetentionPurger{}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), purger, &fakeIntegrityChecker{}, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/retention", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []bool{false}, purger.dryRuns)
+	})
+
+	t.Run("異常系: 失敗したら削除した件数と一緒に返す", func(t *testing.T) {
+		purger := &fakeRetentionPurger{err: errors.New("database error")}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), purger, &fakeIntegrityChecker{}, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/retention", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"error":"database error","report":{"dry_run":false,"items":2,"revisions":0,"users":0}}`, rec.Body.String())
+	})
+}
+
+func TestAdminServer_Integrity(t *testing.T) {
+	t.Run("正常系: GET は報告のみ", func(t *testing.T) {
+		checker := &fakeIntegrityChecker{}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, checker, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/integrity", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"repair":false,"issues":[],"repaired":0}`, rec.Body.String())
+		assert.Equal(t, []bool{false}, checker.repairs)
+	})
+
+	t.Run("正常系: POST は修復する", func(t *testing.T) {
+		checker := &fakeIntegrityChecker{}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, checker, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/integrity", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []bool{true}, checker.repairs)
+	})
+
+	t.Run("異常系: 失敗したら途中までの結果と一緒に返す", func(t *testing.T) {
+		checker := &fakeIntegrityChecker{err: errors.New("database error")}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, checker, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/integrity", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"error":"database error","report":{"repair":true,"issues":[],"repaired":0}}`, rec.Body.String())
+	})
+}
+
+func TestAdminServer_Backups(t *testing.T) {
+	serve := func(backups *fakeBackups, method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler := newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, backups, nil, nil).Handler
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("正常系: 作成", func(t *testing.T) {
+		backups := &fakeBackups{}
+		rec := serve(backups, http.MethodPost, "/backups", `{"key":"correct horse battery"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"id":"20240301T000000Z-0123abcd","size":10,"created_at":"2024-03-01T00:00:00Z"}`, rec.Body.String())
+		assert.True(t, backups.created)
+	})
+
+	t.Run("正常系: 一覧", func(t *testing.T) {
+		rec := serve(&fakeBackups{}, http.MethodGet, "/backups", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{"id":"20240301T000000Z-0123abcd","created_at":"2024-03-01T00:00:00Z"}]`, rec.Body.String())
+	})
+
+	t.Run("正常系: 復元", func(t *testing.T) {
+		rec := serve(&fakeBackups{}, http.MethodPost, "/backups/20240301T000000Z-0123abcd/restore", `{"key":"correct horse battery"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id":"20240301T000000Z-0123abcd","rows":5}`, rec.Body.String())
+	})
+
+	t.Run("異常系: 鍵が短い", func(t *testing.T) {
+		backups := &fakeBackups{}
+		rec := serve(backups, http.MethodPost, "/backups", `{"key":"short"}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.False(t, backups.created)
+	})
+
+	t.Run("異常系: ボディが不正", func(t *testing.T) {
+		rec := serve(&fakeBackups{}, http.MethodPost, "/backups", `key=correct`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("異常系: 存在しないバックアップ", func(t *testing.T) {
+		rec := serve(&fakeBackups{}, http.MethodPost, "/backups/unknown/restore", `{"key":"correct horse battery"}`)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.JSONEq(t, `{"error":"backup not found"}`, rec.Body.String())
+	})
+}
+
+// fakeReindexer は再インデックスの回数を記録する
+type fakeReindexer struct {
+	calls int
+	err   error
+}
+
+func (r *fakeReindexer) Reindex(ctx context.Context) (*usecase.ReindexReport, error) {
+	r.calls++
+	return &usecase.ReindexReport{Indexed: 120, Removed: 3, Duration: "1.5s"}, r.err
+}
+
+func TestAdminServer_SearchReindex(t *testing.T) {
+	t.Run("正常系: 再インデックスの結果を返す", func(t *testing.T) {
+		reindexer := &fakeReindexer{}
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, &fakeBackups{}, reindexer, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/search/reindex", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"indexed":120,"removed":3,"duration":"1.5s"}`, rec.Body.String())
+		assert.Equal(t, 1, reindexer.calls)
+	})
+
+	t.Run("異常系: 失敗したら途中までの結果も返す", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, &fakeBackups{}, &fakeReindexer{err: errors.New("search index: connection refused")}, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/search/reindex", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"error":"search index: connection refused","report":{"indexed":120,"removed":3,"duration":"1.5s"}}`, rec.Body.String())
+	})
+
+	t.Run("異常系: 検索インデックスを使わない場合はない", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, &fakeBackups{}, nil, nil).Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/search/reindex", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// fakeReplay は受け取った再送の指定を記録する
+type fakeReplay struct {
+	requests []usecase.ReplayRequest
+	err      error
+}
+
+func (r *fakeReplay) Replay(ctx context.Context, req usecase.ReplayRequest) (*usecase.ReplayReport, error) {
+	r.requests = append(r.requests, req)
+	if domainErrors.IsValidationError(r.err) {
+		return nil, r.err
+	}
+	return &usecase.ReplayReport{Sink: req.Sink, DryRun: req.DryRun, Replayed: 7, Skipped: 1, LastEventID: 42, Duration: "20ms"}, r.err
+}
+
+func (r *fakeReplay) Sinks() []string {
+	return []string{"webhook"}
+}
+
+func TestAdminServer_EventReplay(t *testing.T) {
+	serve := func(replay usecase.EventReplayUsecase, method, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		newAdminServer("127.0.0.1:0", metrics.NewRegistry(), &fakeRetentionPurger{}, &fakeIntegrityChecker{}, &fakeBackups{}, nil, replay).Handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	t.Run("正常系: クエリパラメーターの範囲のイベントを再送する", func(t *testing.T) {
+		replay := &fakeReplay{}
+		rec := serve(replay, http.MethodPost, "/events/replay?sink=broker&since=2024-05-01T00:00:00Z&until=2024-05-02T00:00:00%2B09:00&item_id=3&after_id=10&topic_prefix=replay")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"sink":"broker","dry_run":false,"replayed":7,"skipped":1,"last_event_id":42,"duration":"20ms"}`, rec.Body.String())
+		require.Len(t, replay.requests, 1)
+		req := replay.requests[0]
+		assert.Equal(t, "broker", req.Sink)
+		assert.True(t, req.Since.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, req.Until.Equal(time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)))
+		assert.Equal(t, int64(3), req.ItemID)
+		assert.Equal(t, int64(10), req.AfterID)
+		assert.Equal(t, "replay", req.TopicPrefix)
+		assert.False(t, req.DryRun)
+	})
+
+	t.Run("正常系: GET は dry run", func(t *testing.T) {
+		replay := &fakeReplay{}
+		rec := serve(replay, http.MethodGet, "/events/replay?sink=webhook&item_id=3")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, replay.requests, 1)
+		assert.True(t, replay.requests[0].DryRun)
+	})
+
+	t.Run("異常系: 日時と ID の形式の誤りは400", func(t *testing.T) {
+		replay := &fakeReplay{}
+		rec := serve(replay, http.MethodPost, "/events/replay?sink=webhook&since=yesterday&item_id=x")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.JSONEq(t, `{"error":"invalid input: item_id must be an integer, since must be an RFC 3339 date and time"}`, rec.Body.String())
+		assert.Empty(t, replay.requests)
+	})
+
+	t.Run("異常系: 検証の誤りは400", func(t *testing.T) {
+		rec := serve(&fakeReplay{err: domainErrors.NewValidationError("since, until or item_id is required")}, http.MethodPost, "/events/replay?sink=webhook")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("異常系: 失敗したら途中までの結果も返す", func(t *testing.T) {
+		rec := serve(&fakeReplay{err: errors.New("failed to replay event 43 to webhook: database error")}, http.MethodPost, "/events/replay?sink=webhook&item_id=3")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"error":"failed to replay event 43 to webhook: database error","report":{"sink":"webhook","dry_run":false,"replayed":7,"skipped":1,"last_event_id":42,"duration":"20ms"}}`, rec.Body.String())
+	})
+}
+
+// fakeAdminUsers は名前 ops・パスワード "correct horse battery" のアカウントだけを持つ（users が false ならアカウントなし）
+type fakeAdminUsers struct {
+	users bool
+}
+
+func (a *fakeAdminUsers) CreateAdmin(ctx context.Context, name, password string) (*entity.AdminUser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (a *fakeAdminUsers) Authenticate(ctx context.Context, name, password string) (bool, error) {
+	return a.users && name == "ops" && password == "correct horse battery", nil
+}
+
+func (a *fakeAdminUsers) HasAdmins(ctx context.Context) (bool, error) {
+	return a.users, nil
+}
+
+func TestRequireAdmin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name     string
+		users    bool
+		user     string
+		password string
+		expected int
+	}{
+		{name: "正常系: アカウントがなければ認証なしで受け付ける", users: false, expected: http.StatusNoContent},
+		{name: "正常系: 名前とパスワードが一致する", users: true, user: "ops", password: "correct horse battery", expected: http.StatusNoContent},
+		{name: "異常系: 認証情報がない", users: true, expected: http.StatusUnauthorized},
+		{name: "異常系: パスワードが違う", users: true, user: "ops", password: "wrong", expected: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/backups", nil)
+			if tt.user != "" {
+				req.SetBasicAuth(tt.user, tt.password)
+			}
+			rec := httptest.NewRecorder()
+
+			requireAdmin(&fakeAdminUsers{users: tt.users}, next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+			if tt.expected == http.StatusUnauthorized {
+				assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "Basic")
+				assert.JSONEq(t, `{"error":"admin credentials required"}`, rec.Body.String())
+			}
+		})
+	}
+}
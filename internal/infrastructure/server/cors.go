@@ -0,0 +1,46 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/idempotency"
+	"Aicon-assignment/internal/infrastructure/sandbox"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+)
+
+// ブラウザーの JavaScript から読めるようにするレスポンスヘッダー（CORS では既定で Content-Type などしか読めない）
+var corsExposeHeaders = []string{
+	itemController.HeaderTotalCount,
+	pagination.HeaderLink,
+	echo.HeaderXRequestID,
+	itemController.HeaderETag,
+	echo.HeaderLocation,
+	echo.HeaderRetryAfter,
+	echo.HeaderContentDisposition,
+	apiversion.HeaderAPIVersion,
+	sandbox.HeaderSandbox,
+	itemController.HeaderUndoToken,
+	itemController.HeaderUndoExpiresAt,
+	idempotency.HeaderReplayed,
+	itemController.HeaderPreferenceApplied,
+}
+
+// 設定（CORS_ALLOWED_ORIGINS など）から CORS のミドルウェアを作る。オリジンが未設定なら nil を返す
+// 許可しないオリジンからのリクエストも処理はする（ブラウザーがレスポンスを読めないだけ）
+func corsMiddleware(cfg *config.Config) echo.MiddlewareFunc {
+	if len(cfg.CORSAllowedOrigins) == 0 {
+		return nil
+	}
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     cfg.CORSAllowedMethods,
+		AllowHeaders:     cfg.CORSAllowedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		ExposeHeaders:    corsExposeHeaders,
+		MaxAge:           int(cfg.CORSMaxAge.Seconds()),
+	})
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	load := func(t *testing.T, values map[string]string) *config.Config {
+		values["DB_DRIVER"] = "sqlite"
+		cfg, err := config.LoadFrom(func(key string) string { return values[key] })
+		require.NoError(t, err)
+		return cfg
+	}
+	newEcho := func(t *testing.T, values map[string]string) *echo.Echo {
+		cors := corsMiddleware(load(t, values))
+		require.NotNil(t, cors)
+		e := echo.New()
+		e.Use(cors)
+		e.GET("/items", func(c echo.Context) error {
+			c.Response().Header().Set("X-Total-Count", "3")
+			return c.NoContent(http.StatusOK)
+		})
+		return e
+	}
+	do := func(e *echo.Echo, method, origin string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/items", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("正常系: オリジンが未設定なら CORS を使わない", func(t *testing.T) {
+		assert.Nil(t, corsMiddleware(load(t, map[string]string{})))
+	})
+
+	t.Run("正常系: プリフライト", func(t *testing.T) {
+		e := newEcho(t, map[string]string{"CORS_ALLOWED_ORIGINS": "https://app.example.com"})
+
+		rec := do(e, http.MethodOptions, "https://app.example.com", map[string]string{
+			echo.HeaderAccessControlRequestMethod:  http.MethodPatch,
+			echo.HeaderAccessControlRequestHeaders: "If-Match, Idempotency-Key",
+		})
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), "PATCH")
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders), "Idempotency-Key")
+		assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	})
+
+	t.Run("正常系: 件数とリクエストIDのヘッダーを読めるようにする", func(t *testing.T) {
+		e := newEcho(t, map[string]string{"CORS_ALLOWED_ORIGINS": "https://*.example.com", "CORS_ALLOW_CREDENTIALS": "true"})
+
+		rec := do(e, http.MethodGet, "https://admin.example.com", nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://admin.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+		exposed := rec.Header().Get(echo.HeaderAccessControlExposeHeaders)
+		assert.Contains(t, exposed, "X-Total-Count")
+		assert.Contains(t, exposed, "Link")
+		assert.Contains(t, exposed, "X-Request-Id")
+	})
+
+	t.Run("異常系: 許可していないオリジン", func(t *testing.T) {
+		e := newEcho(t, map[string]string{"CORS_ALLOWED_ORIGINS": "https://app.example.com"})
+
+		rec := do(e, http.MethodGet, "https://evil.example.net", nil)
+
+		// 処理はするが、ブラウザーはレスポンスを読めない
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
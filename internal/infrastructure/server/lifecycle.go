@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"Aicon-assignment/internal/infrastructure/config"
+)
+
+// HTTPサーバーの設定
+type httpConfig struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	TLSCertFile       string
+	TLSKeyFile        string
+	H2C               bool
+
+	// Let's Encrypt で証明書を取得するドメイン（証明書ファイルの代わりに使う）と、取得した証明書の保存先・連絡先
+	AutocertDomains  []string
+	AutocertCacheDir string
+	AutocertEmail    string
+	// HTTPS にリダイレクトする平文の HTTP の待ち受けアドレス（空なら待ち受けない）。autocert の HTTP-01 チャレンジにも応答する
+	RedirectAddr string
+}
+
+// 設定からHTTPサーバーの設定を組み立てる
+func httpConfigFrom(cfg *config.Config) httpConfig {
+	return httpConfig{
+		Addr:              cfg.HTTPAddr,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		ShutdownTimeout:   cfg.ShutdownTimeout,
+		TLSCertFile:       cfg.TLSCertFile,
+		TLSKeyFile:        cfg.TLSKeyFile,
+		H2C:               cfg.HTTP2Cleartext,
+		AutocertDomains:   cfg.TLSAutocertDomains,
+		AutocertCacheDir:  cfg.TLSAutocertCacheDir,
+		AutocertEmail:     cfg.TLSAutocertEmail,
+		RedirectAddr:      cfg.HTTPRedirectAddr,
+	}
+}
+
+func (c httpConfig) useTLS() bool {
+	return (c.TLSCertFile != "" && c.TLSKeyFile != "") || c.useAutocert()
+}
+
+func (c httpConfig) useAutocert() bool {
+	return len(c.AutocertDomains) > 0
+}
+
+// HTTPサーバーのライフサイクル管理
+// タイムアウトと HTTP/2 を設定したサーバーを起動し、終了シグナル（SIGINT / SIGTERM）か
+// コンテキストのキャンセルで新規の受け付けを止め、処理中のリクエストの完了を待ってから戻る
+type lifecycle struct {
+	server *http.Server
+	// HTTPS へのリダイレクト（RedirectAddr を指定した場合のみ）
+	redirect *http.Server
+	cfg      httpConfig
+}
+
+func newLifecycle(handler http.Handler, cfg httpConfig) (*lifecycle, error) {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.useAutocert() && cfg.TLSCertFile != "" {
+		return nil, errors.New("TLS_AUTOCERT_DOMAINS cannot be used with TLS_CERT_FILE")
+	}
+	if cfg.RedirectAddr != "" && !cfg.useTLS() {
+		return nil, errors.New("HTTP_REDIRECT_ADDR requires TLS (TLS_CERT_FILE or TLS_AUTOCERT_DOMAINS)")
+	}
+
+	h2 := &http2.Server{IdleTimeout: cfg.IdleTimeout}
+	if cfg.H2C {
+		// TLS なしで HTTP/2 を受け付ける（HTTP/1.1 のリクエストもそのまま処理する）
+		handler = h2c.NewHandler(handler, h2)
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+	// 証明書は最初の TLS の接続で取得し、期限の前に更新する
+	var manager *autocert.Manager
+	if cfg.useAutocert() {
+		manager = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+			Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+			Email:      cfg.AutocertEmail,
+		}
+		srv.TLSConfig = manager.TLSConfig()
+	}
+	// TLS 使用時は ALPN で HTTP/2 をネゴシエートする
+	if err := http2.ConfigureServer(srv, h2); err != nil {
+		return nil, fmt.Errorf("failed to configure HTTP/2: %w", err)
+	}
+
+	lc := &lifecycle{server: srv, cfg: cfg}
+	if cfg.RedirectAddr != "" {
+		redirect := redirectToHTTPS(cfg.Addr)
+		if manager != nil {
+			redirect = manager.HTTPHandler(redirect)
+		}
+		lc.redirect = &http.Server{
+			Addr:              cfg.RedirectAddr,
+			Handler:           redirect,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+		}
+	}
+	return lc, nil
+}
+
+// 平文の HTTP のリクエストを、同じホスト・パスの HTTPS（httpsAddr のポート）にリダイレクトする
+// 308 はメソッドとボディを変えずに再送させる（POST なども HTTPS で送り直される）
+func redirectToHTTPS(httpsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+		if host == "" {
+			http.Error(w, "missing Host header", http.StatusBadRequest)
+			return
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+// シャットダウン開始時に呼ぶ処理を登録する
+// Shutdown はハイジャックされた接続（WebSocket）を待たないため、それらの終了に使う
+func (l *lifecycle) OnShutdown(f func()) {
+	l.server.RegisterOnShutdown(f)
+}
+
+// 待ち受けを開始し、終了シグナルかコンテキストのキャンセルまでブロックする
+func (l *lifecycle) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", l.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", l.cfg.Addr, err)
+	}
+	var redirectLn net.Listener
+	if l.redirect != nil {
+		if redirectLn, err = net.Listen("tcp", l.cfg.RedirectAddr); err != nil {
+			ln.Close()
+			return fmt.Errorf("failed to listen on %s: %w", l.cfg.RedirectAddr, err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	scheme := "http"
+	if l.cfg.useTLS() {
+		scheme = "https"
+	}
+	fmt.Printf("🚀 Server starting on %s (%s)\n", ln.Addr(), scheme)
+	if l.cfg.useAutocert() {
+		fmt.Printf("🔐 Using Let's Encrypt certificates for %s (cached in %s)\n", strings.Join(l.cfg.AutocertDomains, ", "), l.cfg.AutocertCacheDir)
+	}
+	if redirectLn != nil {
+		fmt.Printf("↪️  Redirecting HTTP on %s to HTTPS\n", redirectLn.Addr())
+	}
+
+	return l.serve(ctx, ln, redirectLn)
+}
+
+// redirectLn は HTTPS へのリダイレクトの待ち受け（使わない場合は nil）
+func (l *lifecycle) serve(ctx context.Context, ln, redirectLn net.Listener) error {
+	serveErr := make(chan error, 2)
+	go func() {
+		if l.cfg.useTLS() {
+			// autocert の場合は証明書を TLSConfig から取得する
+			serveErr <- l.server.ServeTLS(ln, l.cfg.TLSCertFile, l.cfg.TLSKeyFile)
+		} else {
+			serveErr <- l.server.Serve(ln)
+		}
+	}()
+	if redirectLn != nil {
+		go func() {
+			if err := l.redirect.Serve(redirectLn); !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("redirect server: %w", err)
+			}
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		if l.redirect != nil {
+			l.redirect.Close()
+		}
+		l.server.Close()
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+		fmt.Println("\n🛑 Shutting down server...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), l.cfg.ShutdownTimeout)
+	defer cancel()
+
+	// リダイレクトはすぐに終わるので、処理中のリクエストを待つ前に止める
+	if redirectLn != nil {
+		if err := l.redirect.Shutdown(shutdownCtx); err != nil {
+			l.redirect.Close()
+		}
+	}
+
+	if err := l.server.Shutdown(shutdownCtx); err != nil {
+		// 待ちきれなかったリクエストは接続ごと切断する
+		l.server.Close()
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	fmt.Println("✅ Server exited gracefully")
+	return nil
+}
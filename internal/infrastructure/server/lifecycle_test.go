@@ -0,0 +1,268 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func testHTTPConfig() httpConfig {
+	return httpConfig{
+		ReadTimeout:       time.Second,
+		ReadHeaderTimeout: time.Second,
+		WriteTimeout:      time.Second,
+		IdleTimeout:       time.Second,
+		ShutdownTimeout:   time.Second,
+	}
+}
+
+// テスト用にループバックで待ち受け、serve の戻り値を返すチャネルを返す
+func startLifecycle(t *testing.T, lc *lifecycle) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- lc.serve(ctx, ln, nil) }()
+	t.Cleanup(cancel)
+	return ln.Addr().String(), cancel, done
+}
+
+func TestNewLifecycle(t *testing.T) {
+	t.Run("正常系: タイムアウトを設定する", func(t *testing.T) {
+		cfg := testHTTPConfig()
+		cfg.Addr = ":8081"
+		cfg.WriteTimeout = 2 * time.Second
+
+		lc, err := newLifecycle(http.NotFoundHandler(), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, ":8081", lc.server.Addr)
+		assert.Equal(t, time.Second, lc.server.ReadTimeout)
+		assert.Equal(t, time.Second, lc.server.ReadHeaderTimeout)
+		assert.Equal(t, 2*time.Second, lc.server.WriteTimeout)
+		assert.Equal(t, time.Second, lc.server.IdleTimeout)
+		assert.Contains(t, lc.server.TLSConfig.NextProtos, "h2")
+	})
+
+	t.Run("正常系: Let's Encrypt の証明書を使う", func(t *testing.T) {
+		cfg := testHTTPConfig()
+		cfg.AutocertDomains = []string{"items.example.com"}
+		cfg.AutocertCacheDir = t.TempDir()
+		cfg.RedirectAddr = ":80"
+
+		lc, err := newLifecycle(http.NotFoundHandler(), cfg)
+		require.NoError(t, err)
+		assert.True(t, cfg.useTLS())
+		assert.NotNil(t, lc.server.TLSConfig.GetCertificate)
+		// TLS-ALPN-01 のチャレンジと HTTP/2
+		assert.Contains(t, lc.server.TLSConfig.NextProtos, "acme-tls/1")
+		assert.Contains(t, lc.server.TLSConfig.NextProtos, "h2")
+		require.NotNil(t, lc.redirect)
+		assert.Equal(t, ":80", lc.redirect.Addr)
+	})
+
+	tests := []struct {
+		name   string
+		modify func(*httpConfig)
+	}{
+		{name: "異常系: 証明書と秘密鍵の片方のみ", modify: func(c *httpConfig) { c.TLSCertFile = "tls.crt" }},
+		{name: "異常系: 証明書ファイルと Let's Encrypt の両方", modify: func(c *httpConfig) {
+			c.TLSCertFile, c.TLSKeyFile = "tls.crt", "tls.key"
+			c.AutocertDomains = []string{"items.example.com"}
+		}},
+		{name: "異常系: TLS なしでリダイレクト", modify: func(c *httpConfig) { c.RedirectAddr = ":80" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testHTTPConfig()
+			tt.modify(&cfg)
+
+			_, err := newLifecycle(http.NotFoundHandler(), cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRedirectToHTTPS(t *testing.T) {
+	tests := []struct {
+		name      string
+		httpsAddr string
+		host      string
+		target    string
+		expected  string
+	}{
+		{name: "正常系: 443 はポートを省く", httpsAddr: ":443", host: "items.example.com", target: "/items?page=2", expected: "https://items.example.com/items?page=2"},
+		{name: "正常系: HTTP のポートを HTTPS のポートに置き換える", httpsAddr: ":8443", host: "items.example.com:8080", target: "/items/1", expected: "https://items.example.com:8443/items/1"},
+		{name: "正常系: IPv6", httpsAddr: ":443", host: "[::1]:80", target: "/", expected: "https://[::1]/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+
+			redirectToHTTPS(tt.httpsAddr).ServeHTTP(rec, req)
+
+			// メソッドとボディを変えずに再送させる
+			assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			assert.Equal(t, tt.expected, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func TestLifecycle_Redirect(t *testing.T) {
+	cfg := testHTTPConfig()
+	cfg.Addr = ":8443"
+	cfg.AutocertDomains = []string{"items.example.com"}
+	cfg.AutocertCacheDir = t.TempDir()
+	cfg.RedirectAddr = "127.0.0.1:0"
+	lc, err := newLifecycle(http.NotFoundHandler(), cfg)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	redirectLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- lc.serve(ctx, ln, redirectLn) }()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	req, err := http.NewRequest(http.MethodGet, "http://"+redirectLn.Addr().String()+"/items", nil)
+	require.NoError(t, err)
+	req.Host = "items.example.com"
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusPermanentRedirect, resp.StatusCode)
+	assert.Equal(t, "https://items.example.com:8443/items", resp.Header.Get("Location"))
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestLifecycle_Shutdown(t *testing.T) {
+	t.Run("正常系: 処理中のリクエストの完了を待って終了する", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			io.WriteString(w, "done")
+		})
+		lc, err := newLifecycle(handler, testHTTPConfig())
+		require.NoError(t, err)
+		addr, cancel, done := startLifecycle(t, lc)
+
+		type result struct {
+			body string
+			err  error
+		}
+		responses := make(chan result, 1)
+		go func() {
+			resp, err := http.Get("http://" + addr + "/")
+			if err != nil {
+				responses <- result{err: err}
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			responses <- result{body: string(body), err: err}
+		}()
+
+		<-started
+		cancel()
+		select {
+		case <-done:
+			t.Fatal("server stopped before the in-flight request finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		res := <-responses
+		require.NoError(t, res.err)
+		assert.Equal(t, "done", res.body)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("異常系: 待機時間を過ぎたら強制終了する", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})
+		cfg := testHTTPConfig()
+		cfg.ShutdownTimeout = 50 * time.Millisecond
+		lc, err := newLifecycle(handler, cfg)
+		require.NoError(t, err)
+		addr, cancel, done := startLifecycle(t, lc)
+
+		go http.Get("http://" + addr + "/")
+		<-started
+		cancel()
+
+		assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+	})
+
+	t.Run("正常系: シャットダウン時に登録した処理を呼ぶ", func(t *testing.T) {
+		lc, err := newLifecycle(http.NotFoundHandler(), testHTTPConfig())
+		require.NoError(t, err)
+		called := make(chan struct{})
+		lc.OnShutdown(func() { close(called) })
+		_, cancel, done := startLifecycle(t, lc)
+
+		cancel()
+		assert.NoError(t, <-done)
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatal("shutdown hook was not called")
+		}
+	})
+}
+
+func TestLifecycle_H2C(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Proto)
+	})
+	cfg := testHTTPConfig()
+	cfg.H2C = true
+	lc, err := newLifecycle(handler, cfg)
+	require.NoError(t, err)
+	addr, _, _ := startLifecycle(t, lc)
+
+	t.Run("正常系: 平文の HTTP/2", func(t *testing.T) {
+		client := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		}}
+		resp, err := client.Get("http://" + addr + "/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "HTTP/2.0", string(body))
+	})
+
+	t.Run("正常系: HTTP/1.1 も受け付ける", func(t *testing.T) {
+		resp, err := http.Get("http://" + addr + "/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "HTTP/1.1", string(body))
+	})
+}
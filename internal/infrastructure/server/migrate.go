@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/migration"
+)
+
+// マイグレーションの実行結果
+type MigrateResult struct {
+	Command    string `json:"command"`
+	Applied    int    `json:"applied"`
+	RolledBack int    `json:"rolled_back"`
+	Version    int64  `json:"version"` // 実行後のスキーマバージョン
+}
+
+// migrate サブコマンドから呼ばれ、マイグレーションを実行する
+// command は up（未適用をすべて適用）、down（steps 件戻す）、version（現在のバージョンを返すだけ）のいずれか
+func Migrate(ctx context.Context, cfg *config.Config, command string, steps int) (*MigrateResult, error) {
+	switch command {
+	case "up", "version":
+	case "down":
+		if steps <= 0 {
+			return nil, fmt.Errorf("steps must be positive: %d", steps)
+		}
+	default:
+		return nil, fmt.Errorf("unknown migrate command: %s (use up, down or version)", command)
+	}
+
+	sqlHandler, err := databaseInfra.NewSqlHandlerFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlHandler.Close()
+
+	migrator, err := migration.New(sqlHandler)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrateResult{Command: command}
+	switch command {
+	case "up":
+		if result.Applied, err = migrator.Up(ctx); err != nil {
+			return nil, err
+		}
+	case "down":
+		if result.RolledBack, err = migrator.Down(ctx, steps); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Version, err = migrator.Version(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,344 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/router"
+	"Aicon-assignment/internal/interfaces/controller/attributes"
+	"Aicon-assignment/internal/interfaces/controller/brands"
+	"Aicon-assignment/internal/interfaces/controller/categories"
+	"Aicon-assignment/internal/interfaces/controller/clones"
+	"Aicon-assignment/internal/interfaces/controller/codes"
+	"Aicon-assignment/internal/interfaces/controller/collections"
+	"Aicon-assignment/internal/interfaces/controller/dashboards"
+	"Aicon-assignment/internal/interfaces/controller/documents"
+	"Aicon-assignment/internal/interfaces/controller/exports"
+	"Aicon-assignment/internal/interfaces/controller/images"
+	"Aicon-assignment/internal/interfaces/controller/imports"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/labels"
+	"Aicon-assignment/internal/interfaces/controller/loans"
+	"Aicon-assignment/internal/interfaces/controller/maintenance"
+	"Aicon-assignment/internal/interfaces/controller/media"
+	"Aicon-assignment/internal/interfaces/controller/merges"
+	"Aicon-assignment/internal/interfaces/controller/notifications"
+	"Aicon-assignment/internal/interfaces/controller/portfolios"
+	"Aicon-assignment/internal/interfaces/controller/privacy"
+	"Aicon-assignment/internal/interfaces/controller/quotas"
+	"Aicon-assignment/internal/interfaces/controller/receipts"
+	"Aicon-assignment/internal/interfaces/controller/reminders"
+	"Aicon-assignment/internal/interfaces/controller/reports"
+	"Aicon-assignment/internal/interfaces/controller/revisions"
+	"Aicon-assignment/internal/interfaces/controller/search"
+	"Aicon-assignment/internal/interfaces/controller/settings"
+	"Aicon-assignment/internal/interfaces/controller/shares"
+	"Aicon-assignment/internal/interfaces/controller/suggestions"
+	"Aicon-assignment/internal/interfaces/controller/summaries"
+	"Aicon-assignment/internal/interfaces/controller/tags"
+	"Aicon-assignment/internal/interfaces/controller/transfers"
+	"Aicon-assignment/internal/interfaces/controller/trash"
+	"Aicon-assignment/internal/interfaces/controller/valuations"
+	"Aicon-assignment/internal/interfaces/controller/webhooks"
+)
+
+// バージョンごとに登録するAPIのルートのハンドラー
+// バージョン間で異なる振る舞いはハンドラーとシリアライザーがリクエストのバージョン（apiversion）で切り替える
+type apiRoutes struct {
+	items         *itemController.ItemHandler
+	categories    *categories.CategoryHandler
+	brands        *brands.BrandHandler
+	clones        *clones.CloneHandler
+	revisions     *revisions.RevisionHandler
+	trash         *trash.TrashHandler
+	transfers     *transfers.TransferHandler
+	loans         *loans.LoanHandler
+	reminders     *reminders.ReminderHandler
+	merges        *merges.MergeHandler
+	imports       *imports.ImportHandler
+	summaries     *summaries.SummaryHandler
+	reports       *reports.ReportHandler
+	search        *search.SearchHandler
+	suggestions   *suggestions.SuggestionHandler
+	dashboards    *dashboards.DashboardHandler
+	maintenance   *maintenance.ServiceRecordHandler
+	valuations    *valuations.ValuationHandler
+	portfolios    *portfolios.PortfolioHandler
+	images        *images.ImageHandler
+	documents     *documents.DocumentHandler
+	receipts      *receipts.ReceiptHandler
+	tags          *tags.TagHandler
+	codes         *codes.ItemCodeHandler
+	collections   *collections.CollectionHandler
+	shares        *shares.ShareHandler
+	media         *media.MediaHandler
+	settings      *settings.SettingsHandler
+	attributes    *attributes.CustomAttributeHandler
+	exports       *exports.ExportHandler
+	labels        *labels.LabelHandler
+	webhooks      *webhooks.WebhookHandler
+	notifications *notifications.NotificationRuleHandler
+	quotas        *quotas.QuotaHandler
+	privacy       *privacy.PrivacyHandler
+
+	createIdempotency echo.MiddlewareFunc
+	requireUser       echo.MiddlewareFunc
+}
+
+// APIのルートを宣言する。接頭辞なしと /v1 などのバージョンの接頭辞の下に同じ内容で登録する
+// ルートやグループ（Group の引数）に指定したミドルウェアは、そのルートだけに適用する
+func (r apiRoutes) router() *router.Router {
+	api := router.New()
+	g := api.Group("")
+
+	// アイテムに関するエンドポイント
+	// 一覧と詳細は HEAD でヘッダー（件数・ETag・Content-Length）だけを返す。OPTIONS は登録済みのメソッドを Allow で返す
+	itemsGroup := g.Group("/items")
+	{
+		itemsGroup.GET("", r.items.GetItems)                                 // GET /items
+		itemsGroup.HEAD("", itemController.HeadHandler(r.items.GetItems))    // HEAD /items
+		itemsGroup.POST("", r.items.CreateItem, r.createIdempotency)         // POST /items
+		itemsGroup.GET("/:id", r.items.GetItem)                              // GET /items/{id}
+		itemsGroup.HEAD("/:id", itemController.HeadHandler(r.items.GetItem)) // HEAD /items/{id}
+		itemsGroup.PATCH("/:id", r.items.PatchItem)                          // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", r.items.DeleteItem)                        // DELETE /items/{id}
+		itemsGroup.GET("/summary", r.items.GetSummary)                       // GET /items/summary (bonus)
+		itemsGroup.GET("/top", r.summaries.GetTopItems)                      // GET /items/top?n=10&by=purchase_price
+
+		// データパイプライン向けの一括エクスポート（1行に1アイテムの NDJSON。ID の順で、after_id の後から再開できる）
+		itemsGroup.GET("/export", r.exports.ExportItems) // GET /items/export?format=ndjson&after_id=1000
+
+		// 名前・ブランド・カテゴリーの検索（綴りの誤りを許し、関連度の高い順）
+		itemsGroup.GET("/search", r.search.SearchItems) // GET /items/search?q=rolx&category=時計
+		// 名前の入力補完（語の先頭で一致する名前を、アイテムの多い順に。メモリ上の索引を使う）
+		itemsGroup.GET("/suggest", r.suggestions.SuggestNames) // GET /items/suggest?q=day
+
+		// 複製（ボディのフィールドで上書き）。複製は重複の検出の対象外
+		itemsGroup.POST("/:id/clone", r.clones.CloneItem) // POST /items/{id}/clone
+
+		// 変更履歴（登録・更新のたびに記録される）と、過去のリビジョンへの巻き戻し（通常の更新として記録される）
+		// changes は誰がいつどのフィールドを変えたか（譲渡による所有者の変更を含む）
+		itemsGroup.GET("/:id/revisions", r.revisions.GetRevisions)                // GET /items/{id}/revisions
+		itemsGroup.GET("/:id/changes", r.revisions.GetChanges)                    // GET /items/{id}/changes
+		itemsGroup.POST("/:id/revisions/:rev/rollback", r.revisions.RollbackItem) // POST /items/{id}/revisions/{rev}/rollback
+
+		// 削除の取り消し（DELETE /items/{id} の X-Undo-Token を送る）
+		itemsGroup.POST("/undo-delete", r.items.UndoDelete) // POST /items/undo-delete
+
+		// 削除したアイテムはゴミ箱に移り、完全に削除するまで残る（保持期間を過ぎると自動で完全に削除する）
+		itemsGroup.GET("/trash", r.trash.GetTrash)         // GET /items/trash?page=1&page_size=20
+		itemsGroup.DELETE("/:id/purge", r.trash.PurgeItem) // DELETE /items/{id}/purge
+
+		// 重複の統合（画像・書類・時価の評価・整備記録・タグを統合先に移し、重複を削除する）。dry_run は変更しない
+		itemsGroup.POST("/merge", r.merges.MergeItems) // POST /items/merge
+		// 一括登録（シリアル番号、または名前・ブランド・購入日の近い既存のアイテムと同じ行を報告し、行ごとに skip/merge/create で解決する）
+		itemsGroup.POST("/import", r.imports.ImportItems) // POST /items/import
+
+		itemsGroup.POST("/transfer", r.transfers.RequestBulkTransfer)  // POST /items/transfer
+		itemsGroup.POST("/:id/transfer", r.transfers.RequestTransfer)  // POST /items/{id}/transfer
+		itemsGroup.GET("/:id/transfers", r.transfers.GetItemTransfers) // GET /items/{id}/transfers
+
+		itemsGroup.POST("/:id/loans", r.loans.LendItem)    // POST /items/{id}/loans
+		itemsGroup.GET("/:id/loans", r.loans.GetItemLoans) // GET /items/{id}/loans
+
+		// 期限（保証期限・保険の満期日など）の通知の一時停止
+		itemsGroup.POST("/:id/reminders/snooze", r.reminders.SnoozeReminders)     // POST /items/{id}/reminders/snooze
+		itemsGroup.DELETE("/:id/reminders/snooze", r.reminders.UnsnoozeReminders) // DELETE /items/{id}/reminders/snooze
+
+		// 整備記録。登録・更新・削除でアイテムの maintenance_cost とバージョンが変わる
+		itemsGroup.GET("/unserviced", r.maintenance.GetUnservicedItems)                   // GET /items/unserviced?years=3
+		itemsGroup.POST("/:id/services", r.maintenance.CreateServiceRecord)               // POST /items/{id}/services
+		itemsGroup.GET("/:id/services", r.maintenance.GetServiceRecords)                  // GET /items/{id}/services
+		itemsGroup.GET("/:id/services/:service_id", r.maintenance.GetServiceRecord)       // GET /items/{id}/services/{service_id}
+		itemsGroup.PUT("/:id/services/:service_id", r.maintenance.UpdateServiceRecord)    // PUT /items/{id}/services/{service_id}
+		itemsGroup.DELETE("/:id/services/:service_id", r.maintenance.DeleteServiceRecord) // DELETE /items/{id}/services/{service_id}
+
+		// 時価の評価。提供元の障害は 502、タイムアウトは 504
+		itemsGroup.POST("/:id/refresh-market-value", r.valuations.RefreshMarketValue) // POST /items/{id}/refresh-market-value
+		itemsGroup.GET("/:id/valuations", r.valuations.GetValuations)                 // GET /items/{id}/valuations
+
+		// 画像（multipart/form-data の file）。アップロード・削除でアイテムの image_ids とバージョンが変わる
+		itemsGroup.POST("/:id/images", r.images.UploadImage)             // POST /items/{id}/images
+		itemsGroup.GET("/:id/images", r.images.GetImages)                // GET /items/{id}/images
+		itemsGroup.GET("/:id/images/:image_id", r.images.GetImage)       // GET /items/{id}/images/{image_id}
+		itemsGroup.DELETE("/:id/images/:image_id", r.images.DeleteImage) // DELETE /items/{id}/images/{image_id}
+		// ブラウザーが API のヘッダーなしで読み込める、署名付き・期限付きの URL（GET /media/{token}）
+		itemsGroup.POST("/:id/images/:image_id/url", r.media.CreateImageURL) // POST /items/{id}/images/{image_id}/url
+
+		// 領収書・鑑定書などの書類（multipart/form-data の file と type）
+		itemsGroup.POST("/:id/documents", r.documents.UploadDocument)                 // POST /items/{id}/documents
+		itemsGroup.GET("/:id/documents", r.documents.GetDocuments)                    // GET /items/{id}/documents?type=receipt
+		itemsGroup.GET("/:id/documents/:document_id", r.documents.GetDocument)        // GET /items/{id}/documents/{document_id}
+		itemsGroup.DELETE("/:id/documents/:document_id", r.documents.DeleteDocument)  // DELETE /items/{id}/documents/{document_id}
+		itemsGroup.POST("/:id/documents/:document_id/url", r.media.CreateDocumentURL) // POST /items/{id}/documents/{document_id}/url
+
+		// レシートの読み取り（登録はせず、下書きを返す）
+		itemsGroup.POST("/from-receipt", r.receipts.DraftItem) // POST /items/from-receipt
+
+		// ラベル（QRコード）
+		itemsGroup.GET("/:id/label", r.labels.GetItemLabel) // GET /items/{id}/label?format=png
+
+		// タグの付け替え（ない名前のタグは作る）。アイテムの tags とバージョンが変わる
+		itemsGroup.GET("/:id/tags", r.tags.GetItemTags) // GET /items/{id}/tags
+		itemsGroup.PUT("/:id/tags", r.tags.SetItemTags) // PUT /items/{id}/tags
+
+		// シリアル番号・バーコード（JAN/EAN・UPC）・QR コードの付け替えと、読み取ったコードからのアイテムの検索
+		// （ラベルの QR コードも探せる）。コードはアイテムに含まれないため、バージョンは変わらない
+		itemsGroup.GET("/:id/codes", r.codes.GetItemCodes)   // GET /items/{id}/codes
+		itemsGroup.PUT("/:id/codes", r.codes.SetItemCodes)   // PUT /items/{id}/codes
+		itemsGroup.GET("/by-code/:code", r.codes.LookupItem) // GET /items/by-code/{code}
+
+		// 共有リンク（署名付き・期限付きのトークン）の発行
+		itemsGroup.POST("/:id/share", r.shares.CreateShareLink) // POST /items/{id}/share
+	}
+
+	// 集計。件数と購入価格の合計はリポジトリで集計する（?currency= で換算した合計を含める）
+	summaryGroup := g.Group("/summary")
+	{
+		summaryGroup.GET("", r.items.GetSummary)                 // GET /summary?from=2023-01-01&to=2023-12-31 (GET /items/summary と同じ)
+		summaryGroup.GET("/brands", r.summaries.GetBrandSummary) // GET /summary/brands?currency=USD
+		summaryGroup.GET("/value", r.summaries.GetValueSummary)  // GET /summary/value?currency=USD
+	}
+
+	// 統計。購入日ごとの件数と購入価格の合計（グラフ用に、購入のない期間も0で含める）、価格帯ごとの件数、
+	// 毎日記録した評価額の推移
+	statsGroup := g.Group("/stats")
+	{
+		statsGroup.GET("/acquisitions", r.summaries.GetAcquisitionStats)        // GET /stats/acquisitions?interval=month
+		statsGroup.GET("/price-distribution", r.summaries.GetPriceDistribution) // GET /stats/price-distribution?buckets=10000,100000
+		statsGroup.GET("/portfolio-history", r.portfolios.GetPortfolioHistory)  // GET /stats/portfolio-history?interval=month
+	}
+
+	// レポート。年ごとの月別・カテゴリー別の支出（前年との比較つき）
+	reportsGroup := g.Group("/reports")
+	{
+		reportsGroup.GET("/spend", r.reports.GetSpendReport) // GET /reports/spend?year=2024
+	}
+
+	// アプリのホーム画面。件数・合計額・最近のアイテム・期限の近いアイテムを1回で返す
+	g.GET("/dashboard", r.dashboards.GetDashboard) // GET /dashboard
+
+	// 所有権の譲渡
+	transfersGroup := g.Group("/transfers")
+	{
+		transfersGroup.GET("", r.transfers.GetIncomingTransfers)       // GET /transfers
+		transfersGroup.POST("/:id/accept", r.transfers.AcceptTransfer) // POST /transfers/{id}/accept
+		transfersGroup.POST("/:id/reject", r.transfers.RejectTransfer) // POST /transfers/{id}/reject
+		transfersGroup.POST("/:id/cancel", r.transfers.CancelTransfer) // POST /transfers/{id}/cancel
+	}
+
+	// 貸出（貸出中のアイテムは削除できない）
+	loansGroup := g.Group("/loans")
+	{
+		loansGroup.GET("/overdue", r.loans.GetOverdueLoans) // GET /loans/overdue
+		loansGroup.POST("/:id/return", r.loans.ReturnLoan)  // POST /loans/{id}/return
+	}
+
+	// 利用枠（ユーザーのアイテム数と、アイテムごとの添付ファイル数の上限と使用量）
+	g.GET("/quota", r.quotas.GetQuota) // GET /quota?item_id=1
+
+	// 自分のデータのエクスポートと削除（削除は猶予期間の後に保持期間のジョブが行い、それまでは取り消せる）
+	// 連携先のAPIキーとユーザーを必須にする。削除の依頼には、直前に発行した確認（confirm）を送る
+	meGroup := g.Group("/me", r.requireUser)
+	{
+		meGroup.GET("/export", r.privacy.ExportUserData)                // GET /me/export
+		meGroup.POST("/erasure/confirmation", r.privacy.ConfirmErasure) // POST /me/erasure/confirmation
+		meGroup.DELETE("", r.privacy.RequestErasure)                    // DELETE /me
+		meGroup.GET("/erasure", r.privacy.GetErasureRequest)            // GET /me/erasure
+		meGroup.DELETE("/erasure", r.privacy.CancelErasure)             // DELETE /me/erasure
+	}
+
+	// テナント設定
+	g.GET("/settings/list", r.settings.GetListSettings)    // GET /settings/list
+	g.PUT("/settings/list", r.settings.UpdateListSettings) // PUT /settings/list
+
+	// カスタム属性スキーマ
+	attrsGroup := g.Group("/custom-attributes")
+	{
+		attrsGroup.GET("", r.attributes.GetAttributes)           // GET /custom-attributes
+		attrsGroup.PUT("/:key", r.attributes.PutAttribute)       // PUT /custom-attributes/{key}
+		attrsGroup.DELETE("/:key", r.attributes.DeleteAttribute) // DELETE /custom-attributes/{key}
+	}
+
+	// エクスポート
+	exportsGroup := g.Group("/exports")
+	{
+		exportsGroup.GET("/items", r.exports.ExportItems)           // GET /exports/items (CSV / JSON stream)
+		exportsGroup.POST("/estate", r.exports.StartEstateExport)   // POST /exports/estate
+		exportsGroup.GET("/:id", r.exports.GetExport)               // GET /exports/{id}
+		exportsGroup.GET("/:id/download", r.exports.DownloadExport) // GET /exports/{id}/download
+	}
+
+	// 共有リンクで見るアイテム（認証不要。購入価格は発行時に指定した場合だけ含む）
+	g.GET("/shared/:token", r.shares.GetSharedItem) // GET /shared/{token}
+
+	// 署名付き URL の画像・書類のファイル（認証不要。署名と期限だけを確かめる）
+	g.GET("/media/:token", r.media.GetMedia) // GET /media/{token}
+
+	// ラベル印刷と、読み取ったラベルからのアイテム検索
+	g.POST("/labels/batch", r.labels.PrintBatch) // POST /labels/batch
+	g.GET("/lookup", r.labels.Lookup)            // GET /lookup?code=...
+
+	// カテゴリーと、Accept-Language の言語での表示名（API ではカテゴリーそのものを使う）。
+	// テナントは組み込みのカテゴリーにカテゴリーを追加できる（アイテムが残っているカテゴリーは削除できない）
+	categoriesGroup := g.Group("/categories")
+	{
+		categoriesGroup.GET("", r.categories.GetCategories)           // GET /categories
+		categoriesGroup.POST("", r.categories.CreateCategory)         // POST /categories
+		categoriesGroup.DELETE("/:name", r.categories.DeleteCategory) // DELETE /categories/{name}
+	}
+
+	// ブランドの正式名と別名（アイテムの登録・更新でブランドを正式名に置き換える）と、入力補完の候補
+	brandsGroup := g.Group("/brands")
+	{
+		brandsGroup.GET("", r.brands.GetBrands)             // GET /brands
+		brandsGroup.POST("", r.brands.CreateBrand)          // POST /brands
+		brandsGroup.GET("/suggest", r.brands.SuggestBrands) // GET /brands/suggest?q=ro
+		brandsGroup.DELETE("/:id", r.brands.DeleteBrand)    // DELETE /brands/{id}
+	}
+
+	// タグ（全ユーザーで共有）。名前の変更・統合・削除では、タグが付いているアイテムのバージョンが変わる
+	tagsGroup := g.Group("/tags")
+	{
+		tagsGroup.GET("", r.tags.GetTags)              // GET /tags
+		tagsGroup.POST("", r.tags.CreateTag)           // POST /tags
+		tagsGroup.GET("/:id", r.tags.GetTag)           // GET /tags/{id}
+		tagsGroup.PATCH("/:id", r.tags.RenameTag)      // PATCH /tags/{id}
+		tagsGroup.DELETE("/:id", r.tags.DeleteTag)     // DELETE /tags/{id}
+		tagsGroup.POST("/:id/merge", r.tags.MergeTags) // POST /tags/{id}/merge
+	}
+
+	// コレクション（アイテムのまとまり）。変更できるのは作成したユーザーだけ
+	collectionsGroup := g.Group("/collections")
+	{
+		collectionsGroup.GET("", r.collections.GetCollections)                   // GET /collections?owner_id=alice
+		collectionsGroup.POST("", r.collections.CreateCollection)                // POST /collections
+		collectionsGroup.GET("/:id", r.collections.GetCollection)                // GET /collections/{id}
+		collectionsGroup.PUT("/:id", r.collections.UpdateCollection)             // PUT /collections/{id}
+		collectionsGroup.DELETE("/:id", r.collections.DeleteCollection)          // DELETE /collections/{id}
+		collectionsGroup.GET("/:id/items", r.collections.GetItems)               // GET /collections/{id}/items
+		collectionsGroup.POST("/:id/items", r.collections.AddItems)              // POST /collections/{id}/items
+		collectionsGroup.DELETE("/:id/items/:item_id", r.collections.RemoveItem) // DELETE /collections/{id}/items/{item_id}
+		collectionsGroup.GET("/:id/summary", r.collections.GetSummary)           // GET /collections/{id}/summary?currency=USD
+	}
+
+	// Webhook（アイテムの登録・更新・削除の通知先）
+	webhooksGroup := g.Group("/webhooks")
+	{
+		webhooksGroup.POST("", r.webhooks.CreateWebhook)               // POST /webhooks
+		webhooksGroup.GET("", r.webhooks.GetWebhooks)                  // GET /webhooks
+		webhooksGroup.GET("/:id", r.webhooks.GetWebhook)               // GET /webhooks/{id}
+		webhooksGroup.DELETE("/:id", r.webhooks.DeleteWebhook)         // DELETE /webhooks/{id}
+		webhooksGroup.GET("/:id/deliveries", r.webhooks.GetDeliveries) // GET /webhooks/{id}/deliveries
+	}
+
+	// メール通知のルール（高額アイテムの登録・削除）
+	rulesGroup := g.Group("/notification-rules")
+	{
+		rulesGroup.POST("", r.notifications.CreateRule)       // POST /notification-rules
+		rulesGroup.GET("", r.notifications.GetRules)          // GET /notification-rules
+		rulesGroup.GET("/:id", r.notifications.GetRule)       // GET /notification-rules/{id}
+		rulesGroup.DELETE("/:id", r.notifications.DeleteRule) // DELETE /notification-rules/{id}
+	}
+
+	return api
+}
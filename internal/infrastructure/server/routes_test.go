@@ -0,0 +1,20 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRoutes(t *testing.T) {
+	t.Run("正常系: 同じメソッドとパスのルートを重ねて宣言しない", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, route := range (apiRoutes{}).router().Routes() {
+			key := route.Method + " " + route.Path
+			assert.False(t, seen[key], "duplicate route %s", key)
+			seen[key] = true
+		}
+		assert.True(t, seen["GET /items/:id"])
+		assert.True(t, seen["GET /dashboard"])
+	})
+}
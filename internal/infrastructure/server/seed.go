@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ファイルを指定しない場合に登録するサンプルデータ（マイグレーション 0005 と同じ）
+var sampleItems = []usecase.CreateItemInput{
+	{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1500000), PurchaseDate: "2023-01-15"},
+	{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.MinorUnits(2000000), PurchaseDate: "2023-02-20"},
+	{Name: "ティファニー ネックレス", Category: "ジュエリー", Brand: "Tiffany & Co.", PurchasePrice: entity.MinorUnits(300000), PurchaseDate: "2023-03-10"},
+	{Name: "ルブタン パンプス", Category: "靴", Brand: "Christian Louboutin", PurchasePrice: entity.MinorUnits(150000), PurchaseDate: "2023-04-05"},
+	{Name: "アップルウォッチ", Category: "その他", Brand: "Apple", PurchasePrice: entity.MinorUnits(50000), PurchaseDate: "2023-05-12"},
+}
+
+// 登録の入力の共通項目
+type SeedOptions struct {
+	TenantID string // カスタム属性を検証するテナント
+	OwnerID  string // 登録するアイテムの所有者
+}
+
+// シードの実行結果。既存のアイテムとほぼ同じものは登録せずに skipped に数える
+type SeedResult struct {
+	Created int            `json:"created"`
+	Skipped int            `json:"skipped"`
+	Failed  []*SeedFailure `json:"failed"`
+}
+
+// 登録できなかったアイテム（index は入力の0から始まる位置）
+type SeedFailure struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// seed サブコマンドから呼ばれ、r の JSON 配列（POST /items のボディと同じ形）のアイテムを登録する。
+// r が nil ならサンプルデータを登録する。何度実行しても同じアイテムは重複して登録されない。
+// 通常の登録と同じく検証・変更履歴の記録は行うが、イベント（Webhook・通知）は送らない。
+func Seed(ctx context.Context, cfg *config.Config, r io.Reader, opts SeedOptions) (*SeedResult, error) {
+	inputs := sampleItems
+	if r != nil {
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		inputs = nil
+		if err := decoder.Decode(&inputs); err != nil {
+			return nil, fmt.Errorf("seed file must be a JSON array of items: %w", err)
+		}
+	}
+
+	sqlHandler, err := databaseInfra.NewSqlHandlerFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlHandler.Close()
+
+	itemRepo, closeItemRepo, err := newItemRepository(ctx, cfg, sqlHandler)
+	if err != nil {
+		return nil, err
+	}
+	defer closeItemRepo()
+
+	uow := &itemDatabase.UnitOfWork{SqlHandler: sqlHandler}
+	items := usecase.NewBrandNormalizingItemUsecase(
+		usecase.NewDuplicateCheckingItemUsecase(
+			usecase.NewRevisionItemUsecase(
+				usecase.NewItemUsecaseWithRules(itemRepo, &itemDatabase.SettingsRepository{SqlHandler: sqlHandler}, &itemDatabase.CustomAttributeRepository{SqlHandler: sqlHandler}, &itemDatabase.CategoryRepository{SqlHandler: sqlHandler}, uow, validationRules(cfg)),
+				&itemDatabase.ItemRevisionRepository{SqlHandler: sqlHandler}, uow),
+			itemRepo),
+		&itemDatabase.BrandRepository{SqlHandler: sqlHandler})
+
+	return seedItems(ctx, items, inputs, opts)
+}
+
+func seedItems(ctx context.Context, items usecase.ItemUsecase, inputs []usecase.CreateItemInput, opts SeedOptions) (*SeedResult, error) {
+	result := &SeedResult{Failed: []*SeedFailure{}}
+	for i, input := range inputs {
+		input.TenantID = opts.TenantID
+		input.OwnerID = opts.OwnerID
+		input.AllowDuplicate = false
+
+		_, err := items.CreateItem(ctx, input)
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.Is(err, domainErrors.ErrDuplicateItem):
+			result.Skipped++
+		case domainErrors.IsValidationError(err):
+			result.Failed = append(result.Failed, &SeedFailure{Index: i, Name: input.Name, Error: err.Error()})
+		default:
+			// DBのエラーなどは残りのアイテムも失敗するため中断する
+			return result, fmt.Errorf("failed to seed item %d (%s): %w", i, input.Name, err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestSeedItems(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewItemRepository()
+	items := usecase.NewDuplicateCheckingItemUsecase(usecase.NewItemUsecase(repo, nil, nil, nil, nil), repo)
+
+	t.Run("正常系: サンプルデータを登録する", func(t *testing.T) {
+		result, err := seedItems(ctx, items, sampleItems, SeedOptions{OwnerID: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &SeedResult{Created: 5, Failed: []*SeedFailure{}}, result)
+		created, err := repo.FindAll(ctx, usecase.ItemQuery{})
+		require.NoError(t, err)
+		require.Len(t, created, 5)
+		assert.Equal(t, "alice", created[0].OwnerID)
+	})
+
+	t.Run("正常系: 2回目は既存のアイテムと同じものを登録しない", func(t *testing.T) {
+		result, err := seedItems(ctx, items, sampleItems, SeedOptions{OwnerID: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &SeedResult{Skipped: 5, Failed: []*SeedFailure{}}, result)
+	})
+
+	t.Run("異常系: 不正なアイテムは失敗として報告し、残りを登録する", func(t *testing.T) {
+		inputs := []usecase.CreateItemInput{
+			{Name: "イス", Category: "家具", Brand: "IKEA", PurchasePrice: entity.MinorUnits(5000), PurchaseDate: "2023-02-01"},
+			{Name: "オメガ スピードマスター", Category: "時計", Brand: "OMEGA", PurchasePrice: entity.MinorUnits(800000), PurchaseDate: "2023-06-01"},
+		}
+
+		result, err := seedItems(ctx, items, inputs, SeedOptions{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, 0, result.Failed[0].Index)
+		assert.Equal(t, "イス", result.Failed[0].Name)
+		assert.Contains(t, result.Failed[0].Error, "invalid input")
+	})
+}
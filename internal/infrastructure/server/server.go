@@ -2,44 +2,627 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"log"
+	"maps"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/accesslog"
+	"Aicon-assignment/internal/infrastructure/broker"
+	"Aicon-assignment/internal/infrastructure/circuitbreaker"
+	"Aicon-assignment/internal/infrastructure/concurrency"
+	"Aicon-assignment/internal/infrastructure/config"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/erasuretoken"
+	"Aicon-assignment/internal/infrastructure/errorreport"
+	"Aicon-assignment/internal/infrastructure/estate"
+	"Aicon-assignment/internal/infrastructure/eventbus"
+	"Aicon-assignment/internal/infrastructure/exchangerate"
+	"Aicon-assignment/internal/infrastructure/export"
+	"Aicon-assignment/internal/infrastructure/idempotency"
+	"Aicon-assignment/internal/infrastructure/label"
+	"Aicon-assignment/internal/infrastructure/logging"
+	"Aicon-assignment/internal/infrastructure/marketprice"
+	"Aicon-assignment/internal/infrastructure/mediastore"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/migration"
+	"Aicon-assignment/internal/infrastructure/notification"
+	"Aicon-assignment/internal/infrastructure/ocr"
+	"Aicon-assignment/internal/infrastructure/outbox"
+	"Aicon-assignment/internal/infrastructure/portfolio"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/infrastructure/reminder"
+	"Aicon-assignment/internal/infrastructure/retention"
+	"Aicon-assignment/internal/infrastructure/sandbox"
+	"Aicon-assignment/internal/infrastructure/seal"
+	"Aicon-assignment/internal/infrastructure/searchindex"
+	"Aicon-assignment/internal/infrastructure/sharelink"
+	"Aicon-assignment/internal/infrastructure/timeout"
+	"Aicon-assignment/internal/infrastructure/undotoken"
+	"Aicon-assignment/internal/infrastructure/webhook"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/interfaces/controller/attributes"
+	"Aicon-assignment/internal/interfaces/controller/brands"
+	"Aicon-assignment/internal/interfaces/controller/categories"
+	"Aicon-assignment/internal/interfaces/controller/clones"
+	"Aicon-assignment/internal/interfaces/controller/codes"
+	"Aicon-assignment/internal/interfaces/controller/collections"
+	"Aicon-assignment/internal/interfaces/controller/dashboards"
+	"Aicon-assignment/internal/interfaces/controller/documents"
+	"Aicon-assignment/internal/interfaces/controller/events"
+	"Aicon-assignment/internal/interfaces/controller/exports"
+	"Aicon-assignment/internal/interfaces/controller/images"
+	"Aicon-assignment/internal/interfaces/controller/imports"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/labels"
+	"Aicon-assignment/internal/interfaces/controller/loans"
+	"Aicon-assignment/internal/interfaces/controller/maintenance"
+	"Aicon-assignment/internal/interfaces/controller/media"
+	"Aicon-assignment/internal/interfaces/controller/merges"
+	"Aicon-assignment/internal/interfaces/controller/notifications"
+	"Aicon-assignment/internal/interfaces/controller/portfolios"
+	"Aicon-assignment/internal/interfaces/controller/privacy"
+	"Aicon-assignment/internal/interfaces/controller/quotas"
+	"Aicon-assignment/internal/interfaces/controller/receipts"
+	"Aicon-assignment/internal/interfaces/controller/reminders"
+	"Aicon-assignment/internal/interfaces/controller/reports"
+	"Aicon-assignment/internal/interfaces/controller/revisions"
+	"Aicon-assignment/internal/interfaces/controller/search"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/interfaces/controller/settings"
+	"Aicon-assignment/internal/interfaces/controller/shares"
+	"Aicon-assignment/internal/interfaces/controller/suggestions"
+	"Aicon-assignment/internal/interfaces/controller/summaries"
 	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/interfaces/controller/tags"
+	"Aicon-assignment/internal/interfaces/controller/transfers"
+	trashController "Aicon-assignment/internal/interfaces/controller/trash"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/interfaces/controller/valuations"
+	"Aicon-assignment/internal/interfaces/controller/webhooks"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/repository/cache"
+	"Aicon-assignment/internal/interfaces/repository/coalesce"
+	"Aicon-assignment/internal/interfaces/repository/mongo"
+	"Aicon-assignment/internal/interfaces/rpc"
 	"Aicon-assignment/internal/usecase"
 )
 
 // サーバー用の構造体
-type Server struct{}
+type Server struct {
+	config *config.Config
+}
 
-func NewServer() *Server {
-	return &Server{}
+func NewServer(cfg *config.Config) *Server {
+	return &Server{config: cfg}
 }
 
 // サーバー起動
 func (s *Server) Run(ctx context.Context) error {
+	cfg := s.config
+	// 有効な設定（シークレットは伏せる）
+	fmt.Println("⚙️  Effective configuration:")
+	for _, line := range cfg.Effective() {
+		fmt.Printf("    %s\n", line)
+	}
+
 	e := echo.New()
 
+	// ログレベル（標準の log の出力を絞り込む）
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	logFilter := logging.NewFilter(os.Stderr, logLevel)
+	log.SetOutput(logFilter)
+	defer log.SetOutput(os.Stderr)
+
+	// アイテムAPIのデフォルトのレスポンス形式
+	if err := serializer.SetDefaultFormat(cfg.ResponseFormat); err != nil {
+		return err
+	}
+	serializer.SetFastJSON(cfg.FastJSON)
+	// アイテムの name / brand を出力時にHTMLエスケープするか（保存する値はエスケープしない）
+	serializer.SetHTMLEscaping(cfg.SanitizeHTML)
+
+	// エラーレスポンスの形式。ハンドラーが返したエラーはここでまとめてレスポンスにする
+	if err := serializer.SetErrorFormat(cfg.ErrorFormat); err != nil {
+		return err
+	}
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	// 不正なJSONボディはフィールドや位置を添えた400にする
+	e.Binder = &itemController.Binder{}
+	// 大きすぎる・入れ子が深すぎるJSONボディは読み込む前に拒否する
+	itemController.SetJSONLimits(cfg.JSONMaxBodySize, cfg.JSONMaxDepth)
+	// リクエストの構造体は validate タグで検証する
+	e.Validator = validator.New()
+
 	// 依存性注入
-	dbHandler := databaseInfra.NewSqlHandler()
+	sqlHandler, err := databaseInfra.NewSqlHandlerFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.MigrateOnStart {
+		migrator, err := migration.New(sqlHandler)
+		if err != nil {
+			return err
+		}
+		n, err := migrator.Up(ctx)
+		if err != nil {
+			sqlHandler.Close()
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+		fmt.Printf("✅ Database schema is up to date (%d migration(s) applied)\n", n)
+	}
+	// DBの障害が続いたらクエリを実行せずにすぐ503を返す（DB_CIRCUIT_BREAKER_FAILURES が0なら使わない）
+	dbBreaker := databaseInfra.NewBreaker(databaseInfra.BreakerConfig{
+		FailureThreshold: cfg.DBCircuitBreakerFailures,
+		OpenTimeout:      cfg.DBCircuitBreakerOpenTimeout,
+		HalfOpenRequests: cfg.DBCircuitBreakerHalfOpenRequests,
+	})
+	dbHandler := databaseInfra.WithCircuitBreaker(
+		databaseInfra.WithStatementTimeout(databaseInfra.WithSlowQueryLog(sqlHandler, cfg.SlowQueryThreshold), cfg.DBStatementTimeout),
+		dbBreaker,
+	)
+	// 処理中のリクエストが終わるまでDB接続プールは閉じない（defer は逆順に実行されるため最後に閉じる）
 	defer dbHandler.Close()
 
-	itemRepo := &itemDatabase.ItemRepository{
+	productionItemRepo, closeItemRepo, err := newItemRepository(ctx, cfg, dbHandler)
+	if err != nil {
+		return err
+	}
+	defer closeItemRepo()
+	// HTTP サーバーの停止後に、バックグラウンドの処理を段階ごとに止める（DB接続プールより先に止める）
+	shutdown := newShutdownCoordinator(cfg.ShutdownDrainTimeout)
+	defer shutdown.run()
+	// 同時に届いた同じ内容の読み取り（一覧・件数・集計）は1回のクエリにまとめる
+	coalescedItemRepo := coalesce.NewItemRepository(productionItemRepo)
+	// カテゴリー別集計はメモリにキャッシュし、アイテムの変更で破棄する
+	summaryCache := cache.NewItemRepository(coalescedItemRepo, cfg.SummaryCacheMaxStaleness)
+	// X-Sandbox 付きのリクエストはAPIキーごとのメモリ上のデータセットを使う
+	itemRepo := sandbox.NewItemRepository(summaryCache, sandbox.NewStore(cfg.SandboxTTL, sandbox.DefaultMaxItems))
+
+	settingsRepo := &itemDatabase.SettingsRepository{
+		SqlHandler: dbHandler,
+	}
+
+	attrRepo := &itemDatabase.CustomAttributeRepository{
+		SqlHandler: dbHandler,
+	}
+	// テナントが組み込みのカテゴリーに追加したカテゴリー
+	categoryRepo := &itemDatabase.CategoryRepository{
+		SqlHandler: dbHandler,
+	}
+	// ブランドの正式名と別名
+	brandRepo := &itemDatabase.BrandRepository{
+		SqlHandler: dbHandler,
+	}
+
+	transferRepo := &itemDatabase.TransferRepository{
 		SqlHandler: dbHandler,
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	loanRepo := &itemDatabase.LoanRepository{
+		SqlHandler: dbHandler,
+	}
+	reminderRepo := &itemDatabase.ReminderRepository{
+		SqlHandler: dbHandler,
+	}
+	mergeRepo := &itemDatabase.ItemMergeRepository{
+		SqlHandler: dbHandler,
+	}
+	serviceRepo := &itemDatabase.ServiceRecordRepository{
+		SqlHandler: dbHandler,
+	}
+	valuationRepo := &itemDatabase.ValuationRepository{
+		SqlHandler: dbHandler,
+	}
+	revisionRepo := &itemDatabase.ItemRevisionRepository{
+		SqlHandler: dbHandler,
+	}
+	imageRepo := &itemDatabase.ItemImageRepository{
+		SqlHandler: dbHandler,
+	}
+	documentRepo := &itemDatabase.ItemDocumentRepository{
+		SqlHandler: dbHandler,
+	}
+	tagRepo := &itemDatabase.TagRepository{
+		SqlHandler: dbHandler,
+	}
+	itemCodeRepo := &itemDatabase.ItemCodeRepository{
+		SqlHandler: dbHandler,
+	}
+	collectionRepo := &itemDatabase.CollectionRepository{
+		SqlHandler: dbHandler,
+	}
+	// 画像と書類は、孤立したファイルをそれぞれの記録と照合して削除できるよう、別のディレクトリ（接頭辞）に保存する
+	imageStorage, err := mediaStorage(cfg, cfg.ImageDir, cfg.MediaPrefix)
+	if err != nil {
+		return err
+	}
+	documentStorage, err := mediaStorage(cfg, cfg.DocumentDir, cfg.MediaDocumentPrefix)
+	if err != nil {
+		return err
+	}
+
+	webhookRepo := &itemDatabase.WebhookRepository{
+		SqlHandler: dbHandler,
+	}
+
+	notificationRuleRepo := &itemDatabase.NotificationRuleRepository{
+		SqlHandler: dbHandler,
+	}
+
+	outboxRepo := &itemDatabase.OutboxRepository{
+		SqlHandler: dbHandler,
+	}
+
+	// 複数ステップの更新（PATCH・削除・譲渡）は1つのトランザクションで実行する
+	uow := &itemDatabase.UnitOfWork{SqlHandler: dbHandler}
+
+	// アイテムの変更はイベントバスに流し、集計キャッシュ・Webhook・WebSocketの購読者が受け取る
+	eventBus := eventbus.New(eventbus.DefaultBufferSize)
+	eventBus.Handle(summaryCache)
+	// アイテム名の入力補完はメモリ上の索引で行い、アイテムの変更をイベントで反映する（既存のアイテムは起動後に読み込む）
+	itemNameIndex := usecase.NewItemNameIndex()
+	eventBus.Handle(itemNameIndex, usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted)
+	loadItemNameIndex(itemNameIndex, productionItemRepo, shutdown)
+	// 業務のメトリクス（管理用サーバーの /metrics）。アイテム数と資産額は集計キャッシュから求める
+	metricsRegistry := metrics.NewRegistry()
+	businessMetrics := metrics.NewBusiness(metricsRegistry, summaryCache)
+	eventBus.Handle(businessMetrics, metrics.ItemEventTypes...)
+	webhookDispatcher := webhook.NewDispatcher(webhookRepo, webhook.Config{
+		Timeout:     cfg.WebhookTimeout,
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		RetryBase:   cfg.WebhookRetryBase,
+		RetryMax:    cfg.WebhookRetryMax,
+
+		AllowPrivateNetworks: cfg.WebhookAllowPrivateNetworks,
+	})
+	go webhookDispatcher.Run()
+	// 受け取り済みのイベントを配信ログに記録してから止める
+	shutdown.add(stageWorkers, "webhook dispatcher", webhookDispatcher.Close)
+	eventBus.Handle(webhookDispatcher)
+	// 管理用サーバーからイベントを再送できるシンクと、それぞれに送るイベントの種類（ライブの購読と同じ）
+	replaySinks := map[string]usecase.ReplaySink{"webhook": {Sink: webhookDispatcher}}
+	if cfg.BrokerDriver != "" {
+		brokerPublisher, err := broker.New(broker.Config{
+			Driver:      cfg.BrokerDriver,
+			URLs:        cfg.BrokerURLs,
+			TopicPrefix: cfg.BrokerTopicPrefix,
+			Format:      cfg.BrokerFormat,
+
+			ShutdownTimeout: cfg.ShutdownDrainTimeout,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to connect to message broker: %w", err)
+		}
+		// 送信待ちのイベントを送り切ってから接続を閉じる
+		shutdown.add(stageWorkers, "broker publisher", func() { brokerPublisher.Close() })
+		// 期限の通知（item.expiring）はアイテムの変更ではないため、ブローカーには流さない（Avro のスキーマにも含めない）
+		eventBus.Handle(brokerPublisher, usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted)
+		replaySinks["broker"] = usecase.ReplaySink{Sink: brokerPublisher, EventTypes: []string{usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted}}
+		fmt.Printf("📨 Publishing item events to %s (%s)\n", cfg.BrokerDriver, cfg.BrokerFormat)
+	}
+
+	// 高額アイテムの登録・削除をメールで通知する（SMTP サーバーが未設定なら送信しない）
+	if cfg.SMTPHost != "" {
+		notifier, err := notification.NewNotifier(notificationRuleRepo, notification.NewSMTPMailer(notification.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}), notification.Config{TemplateDir: cfg.NotificationTemplateDir})
+		if err != nil {
+			return err
+		}
+		go notifier.Run()
+		// 受け取り済みのイベントのメールを送ってから止める
+		shutdown.add(stageWorkers, "notifier", notifier.Close)
+		eventBus.Handle(notifier, notification.EventTypes...)
+	}
+
+	// 検索インデックス（Elasticsearch / OpenSearch）を設定していれば、アイテムの登録・更新・削除をインデックスに反映する
+	var searchIndex *searchindex.Client
+	if cfg.SearchIndexURL != "" {
+		searchIndex = searchindex.New(searchindex.Config{
+			URL:      cfg.SearchIndexURL,
+			Index:    cfg.SearchIndexName,
+			Username: cfg.SearchIndexUsername,
+			Password: cfg.SearchIndexPassword,
+			Timeout:  cfg.SearchIndexTimeout,
+		})
+		indexer := searchindex.NewIndexer(searchIndex, searchindex.DefaultBufferSize, cfg.ShutdownDrainTimeout)
+		go indexer.Run()
+		// 受け取り済みの変更をインデックスに反映してから止める
+		shutdown.add(stageWorkers, "search indexer", indexer.Close)
+		eventBus.Handle(indexer, searchindex.EventTypes...)
+		replaySinks["search"] = usecase.ReplaySink{Sink: indexer, EventTypes: searchindex.EventTypes}
+	}
+
+	// イベントは変更と同じトランザクションでアウトボックスに記録し、コミット後にリレーがバスに流す（サンドボックスでの変更は記録しない）
+	relay := outbox.NewRelay(outboxRepo, eventBus, outbox.Config{
+		PollInterval: cfg.OutboxPollInterval,
+		Retention:    cfg.OutboxRetention,
+	})
+	go relay.Run()
+	// 停止前にコミットされたイベントをバスに流してから止める（ワーカーより先に止める）
+	shutdown.add(stageEvents, "outbox relay", relay.Close)
+	itemEvents := sandbox.NewEventOutbox(relay)
+
+	// 集計と資産目録の金額を指定の通貨に換算する
+	rates, err := exchangeRateProvider(cfg)
+	if err != nil {
+		return err
+	}
+	converter := usecase.NewCurrencyConverter(rates)
+
+	// アイテムの登録・更新のたびに、変更と同じトランザクションでリビジョンを記録する（サンドボックスでの変更は記録しない）
+	revisionItemUsecase := usecase.NewRevisionItemUsecase(usecase.NewItemUsecaseWithRules(itemRepo, settingsRepo, attrRepo, categoryRepo, uow, validationRules(cfg)), sandbox.NewItemRevisionRepository(revisionRepo), uow)
+
+	// ユーザーのアイテム数とアイテムごとの添付ファイル数の上限（QUOTA_* が0なら制限しない）
+	quotaUsecase := usecase.NewQuotaUsecase(itemRepo, imageRepo, documentRepo, usecase.QuotaLimits{
+		ItemsPerUser:       cfg.QuotaItemsPerUser,
+		AttachmentsPerItem: cfg.QuotaAttachmentsPerItem,
+	})
+
+	// ブランドは登録済みのブランドの正式名に置き換えてから、既存のアイテムとほぼ同じか確かめる
+	// 既存のアイテムとほぼ同じアイテムは、allow_duplicate を指定しない限り登録できない
+	// 貸出中のアイテムは削除できない（サンドボックスのアイテムは貸し出せないため対象外）
+	// アイテムには整備記録の費用の合計と画像のIDとタグを付けて返す（イベントのアイテムにも含まれる）。アイテムを削除すると画像と書類も削除し、タグとコレクションから外す
+	// （ファイルは、削除がコミットされた後に孤立したファイルとして削除される）
+	itemUsecase := usecase.NewEventingItemUsecase(
+		usecase.NewCurrencyConvertingItemUsecase(
+			usecase.NewCollectionItemUsecase(
+				usecase.NewTagItemUsecase(
+					usecase.NewDocumentItemUsecase(
+						usecase.NewImageItemUsecase(
+							usecase.NewMaintenanceCostItemUsecase(
+								usecase.NewLoanCheckingItemUsecase(usecase.NewBrandNormalizingItemUsecase(usecase.NewDuplicateCheckingItemUsecase(usecase.NewQuotaItemUsecase(revisionItemUsecase, quotaUsecase), itemRepo), brandRepo), itemRepo, sandbox.NewLoanRepository(loanRepo), uow),
+								sandbox.NewServiceRecordRepository(serviceRepo)),
+							sandbox.NewItemImageRepository(imageRepo)),
+						sandbox.NewItemDocumentRepository(documentRepo)),
+					sandbox.NewTagRepository(tagRepo)),
+				sandbox.NewCollectionRepository(collectionRepo)),
+			converter),
+		itemEvents, uow)
+	settingsUsecase := usecase.NewSettingsUsecase(settingsRepo)
+	attrUsecase := usecase.NewCustomAttributeUsecase(attrRepo)
+	categoryUsecase := usecase.NewCategoryUsecase(productionItemRepo, categoryRepo)
+	brandUsecase := usecase.NewBrandUsecase(productionItemRepo, brandRepo, uow)
+	entity.SetAllowPrivateWebhookHosts(cfg.WebhookAllowPrivateNetworks)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepo)
+	notificationRuleUsecase := usecase.NewNotificationRuleUsecase(notificationRuleRepo, cfg.NotificationPriceThreshold)
+	transferUsecase := usecase.NewEventingTransferUsecase(usecase.NewTransferUsecase(productionItemRepo, transferRepo, uow), productionItemRepo, itemEvents, uow)
+	loanUsecase := usecase.NewLoanUsecase(productionItemRepo, loanRepo, uow)
+	// 期限の通知はアウトボックスに記録し、Webhook とメール通知に届ける
+	reminderUsecase := usecase.NewReminderUsecase(productionItemRepo, reminderRepo, itemEvents, uow, cfg.ReminderAttributes, cfg.ReminderDays)
+	if cfg.ReminderInterval > 0 {
+		reminderScheduler := reminder.NewScheduler(cfg.ReminderInterval, reminderUsecase)
+		go reminderScheduler.Run()
+		shutdown.add(stageIntake, "reminder scheduler", reminderScheduler.Close)
+	}
+	serviceRecordUsecase := usecase.NewServiceRecordUsecase(productionItemRepo, serviceRepo, uow)
+	valuationUsecase := usecase.NewValuationUsecase(productionItemRepo, valuationRepo, marketPriceProvider(cfg))
+	// 全体とカテゴリーごとの評価額を毎日記録する（起動時にその日の記録がなければ時刻を待たずに記録する）
+	portfolioUsecase := usecase.NewPortfolioUsecase(productionItemRepo, valuationRepo, &itemDatabase.PortfolioSnapshotRepository{SqlHandler: dbHandler}, uow)
+	if cfg.PortfolioSnapshotTime != "off" {
+		// 形式は設定の読み込みで確かめている
+		at, _ := time.Parse("15:04", cfg.PortfolioSnapshotTime)
+		portfolioJob := portfolio.NewJob(at.Hour(), at.Minute(), portfolioUsecase)
+		go portfolioJob.Run()
+		shutdown.add(stageIntake, "portfolio job", portfolioJob.Close)
+	}
+	imageUsecase := usecase.NewQuotaImageUsecase(usecase.NewImageUsecase(productionItemRepo, imageRepo, imageStorage, uow, int64(cfg.ImageMaxSize)), quotaUsecase)
+	ocrProvider, err := ocrProvider(cfg)
+	if err != nil {
+		return err
+	}
+	// レシートの画像は画像と同じサイズの上限を使う
+	receiptUsecase := usecase.NewReceiptUsecase(ocrProvider, int64(cfg.ImageMaxSize))
+	cloneUsecase := usecase.NewCloneUsecase(itemUsecase)
+	// 巻き戻しは itemUsecase の更新として行う（検証・イベント・リビジョンの記録は通常の更新と同じ）
+	// 変更履歴はリビジョンと、所有者を変える承諾済みの譲渡から作る
+	revisionUsecase := usecase.NewRevisionUsecase(itemUsecase, sandbox.NewItemRevisionRepository(revisionRepo), sandbox.NewTransferRepository(transferRepo))
+	// 削除したアイテムはゴミ箱に移り、完全な削除は itemUsecase で行う（画像と書類も削除する）
+	trashUsecase := usecase.NewTrashUsecase(itemUsecase, itemRepo)
+	// ユーザーのデータのエクスポートと削除の依頼（アイテムは itemUsecase で削除するため、画像と書類も削除され、削除のイベントも記録される）
+	// 削除の依頼には、署名した期限付きの確認が必要
+	erasureSecret, err := erasureTokenSecret(cfg)
+	if err != nil {
+		return err
+	}
+	privacyUsecase := usecase.NewPrivacyUsecase(itemUsecase, itemRepo, imageRepo, documentRepo, collectionRepo, revisionRepo, transferRepo,
+		&itemDatabase.ErasureRequestRepository{SqlHandler: dbHandler}, erasuretoken.NewSigner(erasureSecret), time.Duration(cfg.ErasureGraceDays)*24*time.Hour)
+	// 保持期間を過ぎたゴミ箱のアイテムとリビジョン、猶予期間を過ぎた削除依頼のユーザーのデータを完全に削除する（管理用サーバーからも実行できる）
+	retentionUsecase := usecase.NewErasingRetentionUsecase(usecase.NewRetentionUsecase(itemUsecase, itemRepo, revisionRepo, usecase.RetentionPolicy{
+		Trash:     time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour,
+		Revisions: time.Duration(cfg.RevisionRetentionDays) * 24 * time.Hour,
+	}), privacyUsecase)
+	retentionJob := retention.NewJob(cfg.RetentionInterval, retentionUsecase)
+	if cfg.RetentionInterval > 0 {
+		go retentionJob.Run()
+		shutdown.add(stageIntake, "retention job", retentionJob.Close)
+	}
+	// 孤立した画像・無効なカテゴリー・負の価格の検出と修復（管理用サーバーから実行する）
+	integrityUsecase := usecase.NewIntegrityUsecase(itemRepo, imageRepo, categoryRepo)
+	// 全データのスナップショットを鍵で暗号化してファイルの保存先に置き、復元する（管理用サーバーから実行する）
+	backupStorage, err := mediaStorage(cfg, cfg.BackupDir, cfg.MediaBackupPrefix)
+	if err != nil {
+		return err
+	}
+	backupUsecase := usecase.NewBackupUsecase(&itemDatabase.BackupRepository{SqlHandler: dbHandler}, backupStorage, seal.NewEncrypter(), uow)
+	// 削除は取り消せる期間の間、トークンでゴミ箱から戻せる
+	undoSecret, err := undoTokenSecret(cfg)
+	if err != nil {
+		return err
+	}
+	undoDeleteUsecase := usecase.NewUndoDeleteUsecase(itemUsecase, itemRepo, undotoken.NewSigner(undoSecret), cfg.UndoDeleteWindow)
+	// 重複は itemUsecase で削除する（貸出中なら統合全体を取り消す。削除のイベントも記録される）
+	mergeUsecase := usecase.NewMergeUsecase(itemUsecase, productionItemRepo, mergeRepo, tagRepo, itemEvents, uow)
+	// 一括登録は itemUsecase で登録・更新する（重複は行ごとの解決方法に従うので、登録時の重複の検出は行わない）
+	importUsecase := businessMetrics.NewImportUsecase(usecase.NewImportUsecase(itemUsecase, itemRepo, brandRepo))
+	summaryUsecase := usecase.NewSummaryUsecase(itemUsecase, productionItemRepo, categoryRepo, converter)
+	reportUsecase := usecase.NewReportUsecase(productionItemRepo)
+	searchUsecase := usecase.NewSearchUsecase(productionItemRepo, categoryRepo, cfg.SearchMinScore)
+	// 検索インデックスがあれば検索はインデックスで行い、インデックスを使えないときはアイテムを読んで探す（再インデックスは管理用サーバーから実行する）
+	var searchReindexer usecase.IndexedSearchUsecase
+	if searchIndex != nil {
+		indexedSearch := usecase.NewIndexedSearchUsecase(searchIndex, productionItemRepo, categoryRepo, searchUsecase)
+		prepareSearchIndex(ctx, cfg, searchIndex, indexedSearch, shutdown)
+		searchUsecase, searchReindexer = indexedSearch, indexedSearch
+	}
+	// 期限の近いアイテムはリマインダーと同じ属性から探す
+	dashboardUsecase := usecase.NewDashboardUsecase(itemUsecase, productionItemRepo, cfg.ReminderAttributes)
+	tagUsecase := usecase.NewTagUsecase(productionItemRepo, tagRepo, uow)
+	// 読み取ったコードで見つからなければ、ラベルの QR コード（アイテムの URL）として探す
+	itemCodeUsecase := usecase.NewItemCodeUsecase(productionItemRepo, itemCodeRepo, uow, cfg.PublicBaseURL)
+	collectionUsecase := usecase.NewCollectionUsecase(productionItemRepo, collectionRepo, serviceRepo, converter, uow)
+	documentUsecase := usecase.NewQuotaDocumentUsecase(usecase.NewDocumentUsecase(productionItemRepo, documentRepo, documentStorage, uow, int64(cfg.DocumentMaxSize)), quotaUsecase)
+	if cfg.MediaCleanupInterval > 0 {
+		cleaner := mediastore.NewCleaner(cfg.MediaCleanupInterval, imageUsecase, documentUsecase)
+		go cleaner.Run()
+		shutdown.add(stageIntake, "media cleaner", cleaner.Close)
+	}
+	itemExportUsecase := usecase.NewItemExportUsecase(itemRepo, categoryRepo)
+	estateUsecase := usecase.NewEstateExportUsecase(productionItemRepo, imageRepo, export.NewMemoryJobStore(cfg.ExportRetention), estate.NewPDFRenderer(), seal.NewEncrypter(), converter)
+
+	labelTemplates := label.DefaultTemplates()
+	if cfg.LabelTemplatesFile != "" {
+		extra, err := label.LoadTemplates(cfg.LabelTemplatesFile)
+		if err != nil {
+			return err
+		}
+		labelTemplates = append(labelTemplates, extra...)
+	}
+	labelUsecase := usecase.NewLabelUsecase(itemRepo, label.NewPDFRenderer(), label.NewPNGRenderer(), labelTemplates, cfg.LabelTemplate, cfg.PublicBaseURL)
+
+	secret, err := shareLinkSecret(cfg)
+	if err != nil {
+		return err
+	}
+	shareUsecase := usecase.NewShareUsecase(productionItemRepo, sharelink.NewSigner(secret), cfg.PublicBaseURL)
+
+	mediaSecret, err := mediaURLSecret(cfg)
+	if err != nil {
+		return err
+	}
+	mediaURLUsecase := usecase.NewMediaURLUsecase(imageRepo, documentRepo, imageUsecase, documentUsecase,
+		mediastore.NewURLSigner(mediaSecret), cfg.PublicBaseURL, cfg.MediaURLTTL)
 
 	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	itemHandler := itemController.NewItemHandler(itemUsecase, undoDeleteUsecase)
+	cloneHandler := clones.NewCloneHandler(cloneUsecase)
+	revisionHandler := revisions.NewRevisionHandler(revisionUsecase)
+	trashHandler := trashController.NewTrashHandler(trashUsecase)
+	mergeHandler := merges.NewMergeHandler(mergeUsecase)
+	importHandler := imports.NewImportHandler(importUsecase)
+	summaryHandler := summaries.NewSummaryHandler(summaryUsecase)
+	reportHandler := reports.NewReportHandler(reportUsecase)
+	searchHandler := search.NewSearchHandler(searchUsecase)
+	suggestionHandler := suggestions.NewSuggestionHandler(usecase.NewNameSuggestUsecase(itemNameIndex))
+	categoryHandler := categories.NewCategoryHandler(categoryUsecase)
+	brandHandler := brands.NewBrandHandler(brandUsecase)
+	dashboardHandler := dashboards.NewDashboardHandler(dashboardUsecase)
+	labelHandler := labels.NewLabelHandler(labelUsecase)
+	settingsHandler := settings.NewSettingsHandler(settingsUsecase)
+	attrHandler := attributes.NewCustomAttributeHandler(attrUsecase)
+	transferHandler := transfers.NewTransferHandler(transferUsecase)
+	exportHandler := exports.NewExportHandler(estateUsecase, itemExportUsecase)
+	eventHandler := events.NewEventHandler(eventBus, cfg.WebSocketAllowedOrigins)
+	loanHandler := loans.NewLoanHandler(loanUsecase)
+	reminderHandler := reminders.NewReminderHandler(reminderUsecase)
+	serviceRecordHandler := maintenance.NewServiceRecordHandler(serviceRecordUsecase)
+	valuationHandler := valuations.NewValuationHandler(valuationUsecase)
+	portfolioHandler := portfolios.NewPortfolioHandler(portfolioUsecase)
+	imageHandler := images.NewImageHandler(imageUsecase)
+	documentHandler := documents.NewDocumentHandler(documentUsecase)
+	receiptHandler := receipts.NewReceiptHandler(receiptUsecase)
+	tagHandler := tags.NewTagHandler(tagUsecase)
+	itemCodeHandler := codes.NewItemCodeHandler(itemCodeUsecase)
+	collectionHandler := collections.NewCollectionHandler(collectionUsecase)
+	shareHandler := shares.NewShareHandler(shareUsecase)
+	mediaHandler := media.NewMediaHandler(mediaURLUsecase)
+	webhookHandler := webhooks.NewWebhookHandler(webhookUsecase)
+	notificationRuleHandler := notifications.NewNotificationRuleHandler(notificationRuleUsecase)
+	quotaHandler := quotas.NewQuotaHandler(quotaUsecase)
+	privacyHandler := privacy.NewPrivacyHandler(privacyUsecase)
+
+	// リクエストID（X-Request-ID がなければ生成する）。レスポンスに返し、アクセスログにも記録する
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			c.Request().Header.Set(echo.HeaderXRequestID, id)
+		},
+	}))
+
+	// ルートごとのレイテンシーと SLO の SLI（管理用サーバーの /metrics）。アクセスログより外側で、ログの出力も含めて計る
+	e.Use(metrics.NewHTTP(metricsRegistry, metrics.SLO{
+		LatencyThreshold: cfg.SLOLatencyThreshold,
+		Objective:        cfg.SLOObjective,
+	}).Middleware())
+
+	// アクセスログ
+	if cfg.AccessLogEnabled {
+		logConfig, closeLog, err := accessLogConfig(cfg)
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+		e.Use(accesslog.Middleware(logConfig))
+	}
+
+	// 5xxとパニックの通知
+	var reporter errorreport.ErrorReporter = errorreport.LogReporter{}
+	if cfg.SentryDSN != "" {
+		sentry, err := errorreport.NewSentryReporter(cfg.SentryDSN, cfg.AppEnv, cfg.AppRelease)
+		if err != nil {
+			return err
+		}
+		defer sentry.Close()
+		reporter = sentry
+	}
+	e.Use(errorreport.Middleware(reporter))
+
+	// ブラウザーのページからの呼び出し（CORS）。プリフライトはリクエストの頻度の上限に数えない
+	if cors := corsMiddleware(cfg); cors != nil {
+		e.Use(cors)
+	}
+
+	// クライアントごとのリクエストの頻度の上限（RATE_LIMIT_RPS が0なら制限しない）
+	limiter := ratelimit.New(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	e.Use(limiter.Middleware())
+
+	// 同時に処理するリクエストの上限。急なアクセスの増加でDBの接続を奪い合わないよう、上限を超えた分はすぐに503を返す
+	concurrencyPolicy, err := concurrencyLimitPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	concurrencyLimiter := concurrency.New(concurrencyPolicy)
+	e.Use(concurrencyLimiter.Middleware())
+
+	// 遅いDBで接続を占有し続けないよう、リクエストごとに処理期限を設ける
+	timeoutPolicy, err := handlerTimeoutPolicy(cfg)
+	if err != nil {
+		return err
+	}
+	e.Use(timeout.Middleware(timeoutPolicy))
+	if dbBreaker != nil {
+		e.Use(circuitbreaker.Middleware(dbBreaker))
+	}
+
+	// APIのバージョン（/v1・/v2 の接頭辞、接頭辞なしのルートは API-Version ヘッダー）を選択する
+	e.Use(apiversion.Middleware())
+
+	e.Use(sandbox.Middleware(cfg.SandboxAPIKeys))
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
@@ -47,47 +630,371 @@ func (s *Server) Run(ctx context.Context) error {
 		return nil
 	})
 
-	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
-	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.PatchItem)    // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+	// APIのルート。接頭辞なし（v1、API-Version ヘッダーで v2 も選べる）と /v1・/v2 に同じハンドラーを登録する
+	routes := apiRoutes{
+		items:         itemHandler,
+		categories:    categoryHandler,
+		brands:        brandHandler,
+		clones:        cloneHandler,
+		revisions:     revisionHandler,
+		trash:         trashHandler,
+		merges:        mergeHandler,
+		imports:       importHandler,
+		summaries:     summaryHandler,
+		reports:       reportHandler,
+		search:        searchHandler,
+		suggestions:   suggestionHandler,
+		dashboards:    dashboardHandler,
+		transfers:     transferHandler,
+		settings:      settingsHandler,
+		attributes:    attrHandler,
+		exports:       exportHandler,
+		labels:        labelHandler,
+		loans:         loanHandler,
+		reminders:     reminderHandler,
+		maintenance:   serviceRecordHandler,
+		valuations:    valuationHandler,
+		portfolios:    portfolioHandler,
+		images:        imageHandler,
+		documents:     documentHandler,
+		receipts:      receiptHandler,
+		tags:          tagHandler,
+		codes:         itemCodeHandler,
+		collections:   collectionHandler,
+		shares:        shareHandler,
+		media:         mediaHandler,
+		webhooks:      webhookHandler,
+		notifications: notificationRuleHandler,
+		quotas:        quotaHandler,
+		privacy:       privacyHandler,
+		// Idempotency-Key 付きで再送されたアイテム登録は、最初のレスポンスを返して重複登録を防ぐ
+		createIdempotency: idempotency.Middleware(idempotency.NewStore(cfg.IdempotencyTTL)),
+		requireUser:       requireUserAPIKey(cfg.PrivacyAPIKeys),
+	}
+	api := routes.router()
+	api.Mount(e.Group(""))
+	for _, v := range apiversion.Supported() {
+		api.Mount(e.Group(v.Prefix()))
+	}
+
+	// アイテム変更のリアルタイム配信
+	e.GET("/ws", eventHandler.Stream) // GET /ws (WebSocket)
+
+	if cfg.AdminEnabled {
+		admin := newAdminServer(cfg.AdminAddr, metricsRegistry, retentionJob, integrityUsecase, backupUsecase, searchReindexer, usecase.NewEventReplayUsecase(outboxRepo, replaySinks))
+		adminUsers := usecase.NewAdminUserUsecase(&itemDatabase.AdminUserRepository{SqlHandler: dbHandler})
+		admin.Handler = requireAdmin(adminUsers, admin.Handler)
+		go func() {
+			fmt.Printf("🔧 Admin server starting on %s\n", cfg.AdminAddr)
+			if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				e.Logger.Error("Admin server failed:", err)
+			}
+		}()
+		shutdown.add(stageIntake, "admin server", func() { admin.Close() })
+	}
+
+	// 内部サービス向けの gRPC API（HTTP と同じユースケースを使う）
+	if cfg.GRPCEnabled {
+		grpcServer, err := rpc.NewServer(itemUsecase)
+		if err != nil {
+			return err
+		}
+		lis, err := net.Listen("tcp", cfg.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC: %w", err)
+		}
+		go func() {
+			fmt.Printf("📡 gRPC server starting on %s\n", cfg.GRPCAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				e.Logger.Error("gRPC server failed:", err)
+			}
+		}()
+		shutdown.add(stageIntake, "gRPC server", grpcServer.GracefulStop)
+	}
+
+	// 設定ファイルの変更のうち、再起動せずに反映できるもの（ログレベル・リクエストの頻度の上限・レスポンスの出力方法）を反映する
+	if cfg.ConfigFile != "" && cfg.ConfigReloadInterval > 0 {
+		watcher := config.NewWatcher(cfg, func(next *config.Config) {
+			if level, err := logging.ParseLevel(next.LogLevel); err == nil {
+				logFilter.SetLevel(level)
+			}
+			limiter.SetLimit(next.RateLimitRPS, next.RateLimitBurst)
+			if policy, err := concurrencyLimitPolicy(next); err == nil {
+				concurrencyLimiter.SetPolicy(policy)
+			} else {
+				log.Printf("⚠️  config: keeping the current concurrency limits: %v", err)
+			}
+			serializer.SetFastJSON(next.FastJSON)
+			serializer.SetHTMLEscaping(next.SanitizeHTML)
+		})
+		go watcher.Run()
+		shutdown.add(stageIntake, "config watcher", watcher.Close)
+		fmt.Printf("👀 Watching %s for configuration changes\n", cfg.ConfigFile)
+	}
+
+	lc, err := newLifecycle(e, httpConfigFrom(cfg))
+	if err != nil {
+		return err
+	}
+	// WebSocket の購読を終了させる（ハイジャックされた接続はシャットダウンの完了待ちの対象外）
+	lc.OnShutdown(eventBus.Close)
+
+	return lc.Run(ctx)
+}
+
+// 設定（ITEM_STORE）に応じたアイテムリポジトリを返す
+// mongodb の場合もアイテム以外（設定・カスタム属性・譲渡）はSQLデータベースに保存する
+func newItemRepository(ctx context.Context, cfg *config.Config, dbHandler itemDatabase.SqlHandler) (usecase.ItemRepository, func(), error) {
+	switch cfg.ItemStore {
+	case "", "sql":
+		return &itemDatabase.ItemRepository{SqlHandler: dbHandler}, func() {}, nil
+	case "mongodb":
+		repo, err := mongo.Connect(ctx, cfg.MongoURI, cfg.MongoDatabase)
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Printf("✅ Using MongoDB for items (%s)\n", cfg.MongoDatabase)
+		return repo, func() { repo.Close(context.Background()) }, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported ITEM_STORE: %s", cfg.ItemStore)
+	}
+}
+
+// 設定（EXCHANGE_RATE_PROVIDER）に応じた為替レートの提供元を返す
+func exchangeRateProvider(cfg *config.Config) (usecase.ExchangeRateProvider, error) {
+	var provider usecase.ExchangeRateProvider
+	switch cfg.ExchangeRateProvider {
+	case "none":
+		return exchangerate.DisabledProvider{}, nil
+	case "", "ecb":
+		provider = exchangerate.NewECBProvider(cfg.ExchangeRateURL, cfg.ExchangeRateTimeout)
+	case "openexchangerates":
+		if cfg.ExchangeRateAPIKey == "" {
+			return nil, fmt.Errorf("EXCHANGE_RATE_API_KEY is required for openexchangerates")
+		}
+		provider = exchangerate.NewOpenExchangeRatesProvider(cfg.ExchangeRateURL, cfg.ExchangeRateAPIKey, cfg.ExchangeRateTimeout)
+	default:
+		return nil, fmt.Errorf("unsupported EXCHANGE_RATE_PROVIDER: %s", cfg.ExchangeRateProvider)
+	}
+	return exchangerate.NewCachingProvider(provider, cfg.ExchangeRateCacheTTL), nil
+}
+
+// 設定（OCR_PROVIDER）に応じたレシートの読み取りに使う OCR サービスを返す
+func ocrProvider(cfg *config.Config) (usecase.OCRProvider, error) {
+	switch cfg.OCRProvider {
+	case "", "none":
+		return ocr.DisabledProvider{}, nil
+	case "http":
+		if cfg.OCRURL == "" {
+			return nil, fmt.Errorf("OCR_URL is required for http")
+		}
+		return ocr.NewHTTPProvider(cfg.OCRURL, cfg.OCRAPIKey, cfg.OCRTimeout), nil
+	case "google-vision":
+		if cfg.OCRAPIKey == "" {
+			return nil, fmt.Errorf("OCR_API_KEY is required for google-vision")
+		}
+		return ocr.NewGoogleVisionProvider(cfg.OCRURL, cfg.OCRAPIKey, cfg.OCRTimeout), nil
+	default:
+		return nil, fmt.Errorf("unsupported OCR_PROVIDER: %s", cfg.OCRProvider)
+	}
+}
+
+// 設定（MEDIA_STORAGE）に応じたファイルの保存先を返す。dir はローカル、prefix は S3 / GCS で使う
+func mediaStorage(cfg *config.Config, dir, prefix string) (usecase.Storage, error) {
+	return mediastore.New(context.Background(), mediastore.Config{
+		Driver:   cfg.MediaStorage,
+		Dir:      dir,
+		Bucket:   cfg.MediaBucket,
+		Prefix:   prefix,
+		Region:   cfg.MediaRegion,
+		Endpoint: cfg.MediaEndpoint,
+	})
+}
+
+// 共有リンクの署名に使うシークレットを返す。未設定なら起動ごとに生成する
+func shareLinkSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.ShareLinkSecret != "" {
+		return []byte(cfg.ShareLinkSecret), nil
 	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate share link secret: %w", err)
+	}
+	fmt.Println("⚠️  SHARE_LINK_SECRET is not set; share links will stop working when the server restarts")
+	return secret, nil
+}
 
-	return s.startWithGracefulShutdown(ctx, e)
+// 取り消しのトークンは短い期間しか使えないため、未設定なら警告せずに起動ごとに生成する
+func undoTokenSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.UndoTokenSecret != "" {
+		return []byte(cfg.UndoTokenSecret), nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate undo token secret: %w", err)
+	}
+	return secret, nil
+}
+
+// 削除の確認は短い期間しか使えないため、未設定なら警告せずに起動ごとに生成する
+func erasureTokenSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.ErasureTokenSecret != "" {
+		return []byte(cfg.ErasureTokenSecret), nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate erasure token secret: %w", err)
+	}
+	return secret, nil
 }
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
+// 画像・書類の URL は短い期間しか使えないため、未設定なら警告せずに起動ごとに生成する
+func mediaURLSecret(cfg *config.Config) ([]byte, error) {
+	if cfg.MediaURLSecret != "" {
+		return []byte(cfg.MediaURLSecret), nil
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate media URL secret: %w", err)
+	}
+	return secret, nil
+}
+
+// 設定（MARKET_PRICE_URL）に応じた相場の提供元を返す
+func marketPriceProvider(cfg *config.Config) usecase.MarketPriceProvider {
+	if cfg.MarketPriceURL == "" {
+		return marketprice.DisabledProvider{}
+	}
+	return marketprice.NewCachingProvider(
+		marketprice.NewHTTPProvider(cfg.MarketPriceURL, cfg.MarketPriceAPIKey, cfg.MarketPriceSource, cfg.MarketPriceTimeout),
+		cfg.MarketPriceCacheTTL)
+}
+
+// 設定（VALIDATION_*）に応じたアイテムの検証ルールを返す
+func validationRules(cfg *config.Config) usecase.ValidationRules {
+	return usecase.ValidationRules{
+		BrandOptional:    !cfg.ValidationBrandRequired,
+		MaxPurchasePrice: cfg.ValidationMaxPurchasePrice,
+		MinPurchaseDate:  cfg.ValidationMinPurchaseDate,
+		MaxPurchaseDate:  cfg.ValidationMaxPurchaseDate,
+	}
+}
+
+// 検索インデックスがなければ作成する。作成したときはすべてのアイテムをバックグラウンドで登録する（終わるまでは一部のアイテムしか見つからない）。
+// 接続できなくても起動は続け、検索はアイテムを読んで探す
+func prepareSearchIndex(ctx context.Context, cfg *config.Config, index *searchindex.Client, search usecase.IndexedSearchUsecase, shutdown *shutdownCoordinator) {
+	ensureCtx, cancel := context.WithTimeout(ctx, cfg.SearchIndexTimeout)
+	created, err := index.EnsureIndex(ensureCtx)
+	cancel()
+	if err != nil {
+		log.Printf("⚠️  search index is unavailable, searching the database instead until it is: %v", err)
+		return
+	}
+	fmt.Printf("🔎 Searching items with search index %s\n", cfg.SearchIndexName)
+	if !created {
+		return
+	}
+
+	reindexCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
 	go func() {
-		port := ":8080"
-		fmt.Printf("🚀 Server starting on port %s\n", port)
+		defer close(done)
+		report, err := search.Reindex(reindexCtx)
+		if err != nil {
+			log.Printf("⚠️  failed to build search index %s (%d item(s) indexed): %v", cfg.SearchIndexName, report.Indexed, err)
+			return
+		}
+		fmt.Printf("🔎 Built search index %s with %d item(s) in %s\n", cfg.SearchIndexName, report.Indexed, report.Duration)
+	}()
+	// 登録中に停止したら中断する（残りは再インデックスで登録する）
+	shutdown.add(stageIntake, "search reindex", func() {
+		cancel()
+		<-done
+	})
+}
 
-		if err := e.Start(port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("Server startup failed:", err)
+// 既存のアイテムの名前を入力補完の索引にバックグラウンドで読み込む（読み込み中の補完は読み込み済みの名前から返す）
+func loadItemNameIndex(index *usecase.ItemNameIndex, itemRepo usecase.ItemReader, shutdown *shutdownCoordinator) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		start := time.Now()
+		loaded, err := index.Load(ctx, itemRepo)
+		if err != nil {
+			log.Printf("⚠️  failed to load item names for suggestions (%d item(s) loaded): %v", loaded, err)
+			return
 		}
+		fmt.Printf("💡 Loaded %d item name(s) for suggestions in %s\n", loaded, time.Since(start).Round(time.Millisecond))
 	}()
+	// 読み込み中に停止したら中断する
+	shutdown.add(stageIntake, "item name index", func() {
+		cancel()
+		<-done
+	})
+}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+// 設定からハンドラーの処理期限を組み立てる
+// WebSocket とストリーミングのエクスポートは長時間続くため、設定で上書きしない限り期限を設けない
+func handlerTimeoutPolicy(cfg *config.Config) (timeout.Policy, error) {
+	routes, err := timeout.ParseRoutes(cfg.RouteTimeouts)
+	if err != nil {
+		return timeout.Policy{}, err
+	}
+	policy := timeout.Policy{
+		Default: cfg.HandlerTimeout,
+		Routes: map[string]time.Duration{
+			"GET /ws":            0,
+			"GET /exports/items": 0,
+			"GET /items/export":  0,
+		},
+	}
+	maps.Copy(policy.Routes, routes)
+	return policy, nil
+}
 
-	select {
-	case <-quit:
-		fmt.Println("\n🛑 Shutting down server...")
-	case <-ctx.Done():
-		fmt.Println("\n🛑 Context cancelled, shutting down server...")
+// 設定から同時に処理するリクエストの上限を組み立てる
+// 重いルート（ストリーミングのエクスポート・インポート・ラベルの一括作成）には全体とは別の上限を設け、
+// 接続が長く続く WebSocket は数えない
+func concurrencyLimitPolicy(cfg *config.Config) (concurrency.Policy, error) {
+	routes, err := concurrency.ParseRoutes(cfg.RouteConcurrencyLimits)
+	if err != nil {
+		return concurrency.Policy{}, err
 	}
+	policy := concurrency.Policy{
+		Global: cfg.MaxConcurrentRequests,
+		Routes: map[string]int{
+			"GET /ws":            0,
+			"GET /exports/items": 4,
+			"GET /items/export":  4,
+			"POST /items/import": 4,
+			"POST /labels/batch": 4,
+		},
+	}
+	maps.Copy(policy.Routes, routes)
+	return policy, nil
+}
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// 設定からアクセスログの出力先とサンプリングを組み立てる
+func accessLogConfig(cfg *config.Config) (accesslog.Config, func(), error) {
+	rates, err := accesslog.ParseSampleRates(cfg.AccessLogSampleRates)
+	if err != nil {
+		return accesslog.Config{}, nil, err
+	}
 
-	if err := e.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+	logConfig := accesslog.Config{
+		Writer:       os.Stdout,
+		SampleRates:  rates,
+		RedactFields: append(append([]string{}, accesslog.DefaultRedactFields...), cfg.AccessLogRedact...),
+	}
+	if cfg.AccessLogFile == "" {
+		return logConfig, func() {}, nil
 	}
 
-	fmt.Println("✅ Server exited gracefully")
-	return nil
+	f, err := os.OpenFile(cfg.AccessLogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return accesslog.Config{}, nil, fmt.Errorf("failed to open access log: %w", err)
+	}
+	logConfig.Writer = f
+	return logConfig, func() { f.Close() }, nil
 }
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// 終了処理の段階。HTTP サーバーが止まった後、この順に止める
+type shutdownStage int
+
+const (
+	// 新しい処理の受け付け（gRPC・管理用サーバー・定期実行のジョブ・設定の監視）
+	stageIntake shutdownStage = iota
+	// イベントの発生源（アウトボックスのリレー）。コミット済みのイベントをイベントバスに流し切る
+	// （バスはハンドラーを同期して呼ぶため、リレーが止まればバスに残っているイベントはない）
+	stageEvents
+	// バスからイベントを受け取るワーカー（Webhook・メール通知・ブローカー）。キューに残っているイベントを処理し切る
+	stageWorkers
+
+	shutdownStages
+)
+
+// 終了時に止める処理
+type shutdownStep struct {
+	name string
+	stop func()
+}
+
+// shutdownCoordinator は HTTP サーバーの停止後に、バックグラウンドの処理を段階ごとに止める。
+// 同じ段階の処理は並行して止め、すべてが終わってから次の段階に進む。
+// 段階ごとに timeout を過ぎたら、終わっていない処理は待たずに次の段階に進む（終了を遅らせ続けないため）
+type shutdownCoordinator struct {
+	steps   [shutdownStages][]shutdownStep
+	timeout time.Duration
+	logf    func(format string, args ...interface{})
+	once    sync.Once
+}
+
+func newShutdownCoordinator(timeout time.Duration) *shutdownCoordinator {
+	return &shutdownCoordinator{timeout: timeout, logf: log.Printf}
+}
+
+// stage で止める処理を登録する
+func (c *shutdownCoordinator) add(stage shutdownStage, name string, stop func()) {
+	c.steps[stage] = append(c.steps[stage], shutdownStep{name: name, stop: stop})
+}
+
+// 登録した処理を段階の順に止める。2回目以降の呼び出しは何もしない
+func (c *shutdownCoordinator) run() {
+	c.once.Do(func() {
+		fmt.Println("⏳ Stopping background workers...")
+		for _, steps := range c.steps {
+			c.runStage(steps)
+		}
+		fmt.Println("✅ Background workers stopped")
+	})
+}
+
+func (c *shutdownCoordinator) runStage(steps []shutdownStep) {
+	if len(steps) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	done := make(chan int, len(steps))
+	for i, step := range steps {
+		go func() {
+			step.stop()
+			done <- i
+		}()
+	}
+
+	stopped := make([]bool, len(steps))
+	for range steps {
+		select {
+		case i := <-done:
+			stopped[i] = true
+		case <-ctx.Done():
+			// 期限と同時に終わったものは待てたものとして扱う
+			for len(done) > 0 {
+				stopped[<-done] = true
+			}
+			for i, step := range steps {
+				if !stopped[i] {
+					c.logf("⚠️  shutdown: gave up waiting for %s after %s", step.name, c.timeout)
+				}
+			}
+			return
+		}
+	}
+}
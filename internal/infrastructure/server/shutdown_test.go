@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownCoordinator(t *testing.T) {
+	t.Run("正常系: 段階の順に止める", func(t *testing.T) {
+		var mu sync.Mutex
+		var stopped []string
+		stop := func(name string) func() {
+			return func() {
+				mu.Lock()
+				defer mu.Unlock()
+				stopped = append(stopped, name)
+			}
+		}
+		c := newShutdownCoordinator(time.Second)
+		c.add(stageWorkers, "webhook dispatcher", stop("webhook dispatcher"))
+		c.add(stageEvents, "outbox relay", stop("outbox relay"))
+		c.add(stageIntake, "retention job", stop("retention job"))
+
+		c.run()
+		c.run()
+
+		assert.Equal(t, []string{"retention job", "outbox relay", "webhook dispatcher"}, stopped)
+	})
+
+	t.Run("異常系: 期限を過ぎたら待たずに次の段階に進む", func(t *testing.T) {
+		var logs []string
+		block := make(chan struct{})
+		defer close(block)
+		relayStopped := make(chan struct{})
+
+		c := newShutdownCoordinator(50 * time.Millisecond)
+		c.logf = func(format string, args ...interface{}) { logs = append(logs, fmt.Sprintf(format, args...)) }
+		c.add(stageIntake, "retention job", func() {})
+		c.add(stageIntake, "gRPC server", func() { <-block })
+		c.add(stageEvents, "outbox relay", func() { close(relayStopped) })
+
+		c.run()
+
+		assert.Equal(t, []string{"⚠️  shutdown: gave up waiting for gRPC server after 50ms"}, logs)
+		select {
+		case <-relayStopped:
+		case <-time.After(time.Second):
+			t.Fatal("outbox relay was not stopped")
+		}
+	})
+}
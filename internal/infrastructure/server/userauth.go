@@ -0,0 +1,40 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/sandbox"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// ユーザー本人のデータを扱うルート（/me）のミドルウェア
+// X-User-ID は誰でも付けられるため、ユーザーを認証した連携先（アプリのバックエンドなど）のAPIキー（X-API-Key）と
+// X-User-ID の両方を必須にする。キーが登録されていなければすべて拒否する。
+func requireUserAPIKey(apiKeys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !validUserAPIKey(apiKeys, c.Request().Header.Get(sandbox.HeaderAPIKey)) {
+				return itemController.NewHTTPError(http.StatusUnauthorized, "a valid API key is required for this endpoint")
+			}
+			if itemController.UserID(c) == "" {
+				return itemController.NewHTTPError(http.StatusUnauthorized, "X-User-ID is required for this endpoint")
+			}
+			return next(c)
+		}
+	}
+}
+
+func validUserAPIKey(apiKeys []string, key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, k := range apiKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
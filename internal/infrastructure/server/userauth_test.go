@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+func TestRequireUserAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiKeys  []string
+		apiKey   string
+		userID   string
+		expected int
+	}{
+		{name: "正常系: 登録済みのキーとユーザー", apiKeys: []string{"key-1", "key-2"}, apiKey: "key-2", userID: "alice", expected: http.StatusOK},
+		{name: "異常系: キーがない", apiKeys: []string{"key-1"}, userID: "alice", expected: http.StatusUnauthorized},
+		{name: "異常系: 登録されていないキー", apiKeys: []string{"key-1"}, apiKey: "key-3", userID: "alice", expected: http.StatusUnauthorized},
+		{name: "異常系: キーが登録されていなければ拒否する", apiKey: "key-1", userID: "alice", expected: http.StatusUnauthorized},
+		{name: "異常系: ユーザーがない", apiKeys: []string{"key-1"}, apiKey: "key-1", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = itemController.HTTPErrorHandler
+			e.GET("/me/export", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, requireUserAPIKey(tt.apiKeys))
+			req := httptest.NewRequest(http.MethodGet, "/me/export", nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			if tt.userID != "" {
+				req.Header.Set(itemController.HeaderUserID, tt.userID)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
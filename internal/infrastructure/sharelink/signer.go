@@ -0,0 +1,81 @@
+// Package sharelink はアイテムの共有リンクのトークンに署名・検証する。
+//
+// 形式: base64url("<アイテムID>.<期限のUNIX秒>.<価格を公開するなら1、しないなら0>") "." base64url(HMAC-SHA256)
+// トークンはデータベースに保存しない。シークレットを変えると発行済みのリンクはすべて無効になる。
+package sharelink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var ErrInvalidToken = errors.New("sharelink: invalid token")
+
+var encoding = base64.RawURLEncoding
+
+// Signer は usecase.ShareTokenSigner の実装
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+func (s *Signer) Sign(claims usecase.ShareClaims) string {
+	includePrice := "0"
+	if claims.IncludePrice {
+		includePrice = "1"
+	}
+	payload := encoding.EncodeToString([]byte(fmt.Sprintf("%d.%d.%s", claims.ItemID, claims.ExpiresAt.Unix(), includePrice)))
+	return payload + "." + encoding.EncodeToString(s.mac(payload))
+}
+
+// Verify は署名を確かめて内容を返す。期限切れかどうかは呼び出し側が判断する
+func (s *Signer) Verify(token string) (usecase.ShareClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+
+	decoded, err := encoding.DecodeString(payload)
+	if err != nil {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+	fields := strings.Split(string(decoded), ".")
+	if len(fields) != 3 || (fields[2] != "0" && fields[2] != "1") {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+	itemID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+	expiresAt, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return usecase.ShareClaims{}, ErrInvalidToken
+	}
+
+	return usecase.ShareClaims{
+		ItemID:       itemID,
+		ExpiresAt:    time.Unix(expiresAt, 0).UTC(),
+		IncludePrice: fields[2] == "1",
+	}, nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,52 @@
+package sharelink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("share-secret"))
+	claims := usecase.ShareClaims{ItemID: 42, ExpiresAt: time.Date(2024, 3, 17, 9, 0, 0, 0, time.UTC), IncludePrice: true}
+	token := signer.Sign(claims)
+
+	t.Run("正常系: 署名したトークンを検証する", func(t *testing.T) {
+		got, err := signer.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, claims, got)
+	})
+
+	t.Run("正常系: 価格を公開しないトークン", func(t *testing.T) {
+		hidden := claims
+		hidden.IncludePrice = false
+		got, err := signer.Verify(signer.Sign(hidden))
+		require.NoError(t, err)
+		assert.False(t, got.IncludePrice)
+	})
+
+	t.Run("異常系: 別のシークレットで署名したトークン", func(t *testing.T) {
+		_, err := NewSigner([]byte("other-secret")).Verify(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 内容を書き換えたトークン", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(token, ".")
+		forged := encoding.EncodeToString([]byte("43.1710666800.1")) + "." + signature
+		assert.NotEqual(t, payload, forged)
+		_, err := signer.Verify(forged)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("異常系: 形式が不正", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "." + strings.Repeat("A", 43)} {
+			_, err := signer.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidToken, token)
+		}
+	})
+}
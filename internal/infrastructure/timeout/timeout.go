@@ -0,0 +1,99 @@
+// Package timeout はリクエストのコンテキストにルートごとの処理期限を設定するミドルウェアを提供する。
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// ハンドラーの処理期限の設定
+// Routes のキーは "GET /items/:id" のようなメソッドとルートのパターンで、0 は期限なし（WebSocket・ストリーミング用）
+// "/*" で終わるパターンはルートのグループ（"POST /items/*" は /items 以下の POST、"GET /*" はすべての GET）に適用する
+// ルートはバージョンの接頭辞（/v1 など）を除いたパスで、すべてのバージョンに適用する
+type Policy struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// ルートの処理期限を返す（0 は期限なし）
+// ルートそのものの指定、最も長く一致するグループの指定、Default の順に使う
+func (p Policy) For(method, path string) time.Duration {
+	if d, ok := p.Routes[method+" "+path]; ok {
+		return d
+	}
+	d, matched := p.Default, -1
+	for route, timeout := range p.Routes {
+		routeMethod, pattern, _ := strings.Cut(route, " ")
+		prefix, ok := strings.CutSuffix(pattern, "/*")
+		if !ok || routeMethod != method || len(prefix) <= matched {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(path, prefix); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			d, matched = timeout, len(prefix)
+		}
+	}
+	return d
+}
+
+// "POST /labels/batch=30s,GET /exports/items=0" 形式のルートごとの期限を読み込む
+func ParseRoutes(s string) (map[string]time.Duration, error) {
+	routes := make(map[string]time.Duration)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(part, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		path = strings.TrimSpace(path)
+		if !ok || !hasPath || method == "" || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid route timeout %q: expected e.g. GET /items=5s", part)
+		}
+		if strings.Contains(strings.TrimSuffix(path, "/*"), "*") {
+			return nil, fmt.Errorf("invalid route timeout %q: * is only allowed as a trailing /* for a route group", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid route timeout %q: duration must be 0 or greater", part)
+		}
+		routes[strings.ToUpper(method)+" "+path] = d
+	}
+	return routes, nil
+}
+
+// リクエストのコンテキストに処理期限を設定するミドルウェア
+// 期限切れのエラーはエラーハンドラーが503にする。ハンドラーが応答しないまま期限を過ぎた場合はここで503を返す。
+func Middleware(policy Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			d := policy.For(c.Request().Method, apiversion.Route(c.Path()))
+			if d <= 0 {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), d)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+			if c.Response().Committed || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return err
+			}
+			if err != nil {
+				c.Set(itemController.ContextKeyError, err)
+			}
+			return itemController.RespondError(c, http.StatusServiceUnavailable, itemController.ErrorResponse{
+				Error: itemController.TimeoutMessage,
+			})
+		}
+	}
+}
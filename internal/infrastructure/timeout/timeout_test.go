@@ -0,0 +1,179 @@
+package timeout
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+func TestParseRoutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]time.Duration
+		wantErr  bool
+	}{
+		{name: "正常系: 空", input: "", expected: map[string]time.Duration{}},
+		{
+			name:  "正常系: 複数ルート",
+			input: "post /labels/batch=30s, GET /items/:id=2s,GET /ws=0",
+			expected: map[string]time.Duration{
+				"POST /labels/batch": 30 * time.Second,
+				"GET /items/:id":     2 * time.Second,
+				"GET /ws":            0,
+			},
+		},
+		{
+			name:  "正常系: ルートのグループ",
+			input: "GET /*=2s,POST /items/*=10s",
+			expected: map[string]time.Duration{
+				"GET /*":        2 * time.Second,
+				"POST /items/*": 10 * time.Second,
+			},
+		},
+		{name: "異常系: 期限なし", input: "GET /items", wantErr: true},
+		{name: "異常系: 末尾以外のワイルドカード", input: "GET /items/*/photos=5s", wantErr: true},
+		{name: "異常系: メソッドなし", input: "/items=5s", wantErr: true},
+		{name: "異常系: 不正な期間", input: "GET /items=soon", wantErr: true},
+		{name: "異常系: 負の期間", input: "GET /items=-1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes, err := ParseRoutes(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, routes)
+		})
+	}
+}
+
+func TestPolicy_For(t *testing.T) {
+	policy := Policy{
+		Default: 10 * time.Second,
+		Routes: map[string]time.Duration{
+			"GET /*":             2 * time.Second,
+			"GET /exports/items": 0,
+			"POST /items/*":      30 * time.Second,
+			"POST /items/:id/*":  5 * time.Second,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected time.Duration
+	}{
+		{name: "正常系: ルートの指定がグループより優先", method: http.MethodGet, path: "/exports/items", expected: 0},
+		{name: "正常系: すべての GET", method: http.MethodGet, path: "/items/:id", expected: 2 * time.Second},
+		{name: "正常系: グループの接頭辞そのもの", method: http.MethodPost, path: "/items", expected: 30 * time.Second},
+		{name: "正常系: 最も長く一致するグループ", method: http.MethodPost, path: "/items/:id/photos", expected: 5 * time.Second},
+		{name: "正常系: パスの途中では一致しない", method: http.MethodPost, path: "/items-archive", expected: 10 * time.Second},
+		{name: "正常系: 一致しなければ Default", method: http.MethodDelete, path: "/items/:id", expected: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.For(tt.method, tt.path))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	policy := Policy{
+		Default: 20 * time.Millisecond,
+		Routes:  map[string]time.Duration{"GET /ws": 0, "GET /exports/items": 0},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		handler        echo.HandlerFunc
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "正常系: 期限内のリクエスト",
+			path: "/items",
+			handler: func(c echo.Context) error {
+				_, ok := c.Request().Context().Deadline()
+				assert.True(t, ok)
+				return c.NoContent(http.StatusOK)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: 期限なしのルート",
+			path: "/ws",
+			handler: func(c echo.Context) error {
+				_, ok := c.Request().Context().Deadline()
+				assert.False(t, ok)
+				return c.NoContent(http.StatusOK)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: バージョン付きのルートにも同じ期限",
+			path: "/v1/exports/items",
+			handler: func(c echo.Context) error {
+				_, ok := c.Request().Context().Deadline()
+				assert.False(t, ok)
+				return c.NoContent(http.StatusOK)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 期限切れで返されたエラーは503",
+			path: "/items",
+			handler: func(c echo.Context) error {
+				<-c.Request().Context().Done()
+				return fmt.Errorf("failed to retrieve items: %w", c.Request().Context().Err())
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  itemController.TimeoutMessage,
+		},
+		{
+			name: "異常系: 応答しないまま期限を過ぎたら503",
+			path: "/items",
+			handler: func(c echo.Context) error {
+				<-c.Request().Context().Done()
+				return errors.New("query canceled")
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedError:  itemController.TimeoutMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = itemController.HTTPErrorHandler
+			e.Use(Middleware(policy))
+			e.GET(tt.path, tt.handler)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var errorResp itemController.ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package undotoken はアイテムの削除を取り消すトークンに署名・検証する。
+//
+// 形式: base64url("<アイテムID>.<削除日時のUNIX秒>") "." base64url(HMAC-SHA256)
+// トークンはデータベースに保存しない。期限は削除日時と取り消せる期間から呼び出し側が判断する。
+package undotoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var ErrInvalidToken = errors.New("undotoken: invalid token")
+
+var encoding = base64.RawURLEncoding
+
+// Signer は usecase.UndoTokenSigner の実装
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+func (s *Signer) Sign(claims usecase.UndoClaims) string {
+	payload := encoding.EncodeToString([]byte(fmt.Sprintf("%d.%d", claims.ItemID, claims.DeletedAt.Unix())))
+	return payload + "." + encoding.EncodeToString(s.mac(payload))
+}
+
+// Verify は署名を確かめて内容を返す
+func (s *Signer) Verify(token string) (usecase.UndoClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+	mac, err := encoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+
+	decoded, err := encoding.DecodeString(payload)
+	if err != nil {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+	id, deletedAt, ok := strings.Cut(string(decoded), ".")
+	if !ok {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+	itemID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+	seconds, err := strconv.ParseInt(deletedAt, 10, 64)
+	if err != nil {
+		return usecase.UndoClaims{}, ErrInvalidToken
+	}
+
+	return usecase.UndoClaims{
+		ItemID:    itemID,
+		DeletedAt: time.Unix(seconds, 0).UTC(),
+	}, nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,396 @@
+// Package webhook はアイテムイベントを登録された Webhook に署名付きのJSONで配信する。
+//
+// イベントはまず Webhook ごとの配信ログ（webhook_deliveries）に記録し、そこから送信する。
+// 失敗した配信は指数バックオフで再試行し、上限の回数に達したら failed にする。
+// 配信ログはDBにあるため、再起動や複数台構成でも未完了の配信は引き継がれる。
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 配信リクエストのヘッダー
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// 配信ログへの記録を待つイベントのバッファサイズ
+	DefaultBufferSize = 256
+	// 期限が来た配信を一度に取り出す件数と、同時に送信する件数
+	batchSize   = 50
+	concurrency = 8
+	// 配信ログの読み書きの期限
+	storeTimeout = 5 * time.Second
+	// 読み捨てるレスポンスボディの上限（接続を再利用するため）
+	maxDrainBytes = 64 << 10
+)
+
+var droppedCount = expvar.NewInt("webhook_events_dropped")
+
+type Config struct {
+	Timeout      time.Duration // 1回の試行の期限
+	MaxAttempts  int           // 配信を諦めるまでの試行回数
+	RetryBase    time.Duration // 最初の再試行までの間隔（以降は倍々）
+	RetryMax     time.Duration // 再試行の間隔の上限
+	PollInterval time.Duration // 期限が来た再試行を探す間隔
+	BufferSize   int
+	// 内部ネットワーク（ループバック・プライベート・リンクローカル）への配信を許可する（開発用）
+	AllowPrivateNetworks bool
+}
+
+// 未設定の項目をデフォルト値で埋める
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.RetryBase <= 0 {
+		c.RetryBase = 30 * time.Second
+	}
+	if c.RetryMax < c.RetryBase {
+		c.RetryMax = c.RetryBase
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = 5 * time.Second
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = DefaultBufferSize
+	}
+	return c
+}
+
+// 送信するJSON（イベントにイベントIDを加えたもの）
+type payload struct {
+	ID string `json:"id"`
+	usecase.ItemEvent
+}
+
+type Dispatcher struct {
+	repo   usecase.WebhookRepository
+	client *http.Client
+	cfg    Config
+	events chan usecase.ItemEvent
+	now    func() time.Time
+	logf   func(format string, args ...interface{})
+
+	// 送信中のリクエストは Close で中断する
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewDispatcher(repo usecase.WebhookRepository, cfg Config) *Dispatcher {
+	cfg = cfg.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		repo: repo,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: newTransport(cfg.AllowPrivateNetworks),
+			// リダイレクトには従わず、3xx は失敗として扱う（リダイレクト先で宛先の検査を迂回させない）
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg:    cfg,
+		events: make(chan usecase.ItemEvent, cfg.BufferSize),
+		now:    time.Now,
+		logf:   log.Printf,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+var errPrivateAddress = errors.New("webhook url resolves to a private network address")
+
+// 配信用のトランスポート。登録時の検査はホスト名の名前解決までは行わないため、接続する直前に
+// 解決済みのアドレスを検査する（DNS で内部のアドレスを返すホスト名や、登録後の DNS の書き換えも拒否する）
+func newTransport(allowPrivateNetworks bool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシを経由すると接続先の検査が意味をなさないため、環境変数のプロキシ設定は使わない
+	transport.Proxy = nil
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !entity.IsPublicWebhookAddr(addrPort.Addr()) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// イベントを配信の待ち行列に入れる。バッファが一杯ならイベントを破棄する（破棄数は /debug/vars の webhook_events_dropped）
+func (d *Dispatcher) Publish(_ context.Context, event usecase.ItemEvent) {
+	select {
+	case d.events <- event:
+	default:
+		droppedCount.Add(1)
+	}
+}
+
+// 配信を開始する。Close まで戻らないので goroutine で呼び出す
+func (d *Dispatcher) Run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	// 前回の起動から残っている配信を再開する
+	d.deliverDue()
+	for {
+		select {
+		case event := <-d.events:
+			d.record(event)
+			d.deliverDue()
+		case <-ticker.C:
+			d.deliverDue()
+		case <-d.ctx.Done():
+			// 受け取り済みのイベントは配信ログに記録し、次の起動（または他のサーバー）で送信する
+			for {
+				select {
+				case event := <-d.events:
+					d.record(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// 配信を停止し、Run が終わるまで待つ。送信中のリクエストは中断し、次の起動で再試行する
+func (d *Dispatcher) Close() {
+	d.once.Do(d.cancel)
+	<-d.done
+}
+
+// イベントを購読している Webhook ごとに配信ログへ記録する
+func (d *Dispatcher) record(event usecase.ItemEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := d.recordDeliveries(ctx, event); err != nil {
+		d.logf("⚠️  webhook: %v", err)
+	}
+}
+
+// 再送するイベントを配信ログに記録する（usecase.ItemEventSink の実装）。送信は通常の配信と同じく Run が行う
+func (d *Dispatcher) Replay(ctx context.Context, event usecase.ItemEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	return d.recordDeliveries(ctx, event)
+}
+
+// イベントを購読している Webhook ごとに配信ログへ記録する。記録に失敗した Webhook があっても残りには記録する
+func (d *Dispatcher) recordDeliveries(ctx context.Context, event usecase.ItemEvent) error {
+	webhooks, err := d.repo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks, %s event for item %d is not delivered: %w", event.Type, event.ItemID, err)
+	}
+
+	var body []byte
+	var errs []error
+	eventID := newEventID()
+	now := d.now()
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(payload{ID: eventID, ItemEvent: event}); err != nil {
+				return fmt.Errorf("failed to encode %s event for item %d: %w", event.Type, event.ItemID, err)
+			}
+		}
+		delivery := entity.NewWebhookDelivery(webhook.ID, eventID, event.Type, event.ItemID, string(body), now)
+		if _, err := d.repo.CreateDelivery(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("failed to record delivery of %s to webhook %d: %w", eventID, webhook.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 期限が来た配信を送信する。送信が終わるまで次の取り出しはしない
+func (d *Dispatcher) deliverDue() {
+	for d.ctx.Err() == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		due, err := d.repo.FindDueDeliveries(ctx, d.now(), batchSize)
+		cancel()
+		if err != nil {
+			d.logf("⚠️  webhook: failed to load due deliveries: %v", err)
+			return
+		}
+
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for _, delivery := range due {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(delivery *entity.WebhookDelivery) {
+				defer func() { <-sem; wg.Done() }()
+				d.attempt(delivery)
+			}(delivery)
+		}
+		wg.Wait()
+
+		// 一杯まで取り出せたときは、まだ残っている可能性があるので続けて取り出す
+		if len(due) < batchSize {
+			return
+		}
+	}
+}
+
+// 配信を1回試行し、結果を配信ログに記録する
+func (d *Dispatcher) attempt(delivery *entity.WebhookDelivery) {
+	webhook, ok := d.claim(delivery)
+	if !ok {
+		return
+	}
+
+	status, err := d.send(webhook, delivery)
+	if d.ctx.Err() != nil {
+		return
+	}
+	now := d.now()
+	if err == nil {
+		delivery.Succeed(status, now)
+	} else {
+		var next *time.Time
+		if delivery.Attempts+1 < d.cfg.MaxAttempts {
+			at := now.Add(d.backoff(delivery.Attempts + 1))
+			next = &at
+		}
+		delivery.Fail(status, err.Error(), now, next)
+	}
+	d.update(delivery)
+}
+
+// 配信を試行する権利を取り、送信先の Webhook を返す
+func (d *Dispatcher) claim(delivery *entity.WebhookDelivery) (*entity.Webhook, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	// 試行中に他のプロセスが同じ配信を送らないよう、試行の期限の2倍だけ次の試行を先送りする
+	// （試行の途中で停止した場合はその後に再試行される）
+	now := d.now()
+	claimed, err := d.repo.ClaimDelivery(ctx, delivery, now, now.Add(2*d.cfg.Timeout))
+	if err != nil {
+		d.logf("⚠️  webhook: failed to claim delivery %d: %v", delivery.ID, err)
+		return nil, false
+	}
+	if !claimed {
+		return nil, false
+	}
+
+	webhook, err := d.repo.FindByID(ctx, delivery.WebhookID)
+	if domainErrors.IsNotFoundError(err) {
+		// 削除された Webhook への配信は再試行しない
+		delivery.Fail(0, "webhook has been deleted", d.now(), nil)
+		d.update(delivery)
+		return nil, false
+	}
+	if err != nil {
+		d.logf("⚠️  webhook: failed to load webhook %d for delivery %d: %v", delivery.WebhookID, delivery.ID, err)
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// 試行の結果を配信ログに記録する（送信に時間がかかっても記録できるよう、期限は記録の時点から数える）
+func (d *Dispatcher) update(delivery *entity.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		d.logf("⚠️  webhook: failed to record attempt of delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// 配信を送信し、レスポンスのステータスを返す。2xx 以外は失敗
+func (d *Dispatcher) send(webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Aicon-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// n 回目の失敗の後、次の試行までの間隔（RetryBase × 2^(n-1)、上限 RetryMax）
+func (d *Dispatcher) backoff(n int) time.Duration {
+	wait := d.cfg.RetryBase
+	for i := 1; i < n && wait < d.cfg.RetryMax; i++ {
+		wait *= 2
+	}
+	if wait > d.cfg.RetryMax {
+		wait = d.cfg.RetryMax
+	}
+	return wait
+}
+
+// X-Webhook-Signature の値（t=<UNIX秒>,v1=<"<UNIX秒>.<ボディ>" の HMAC-SHA256 の16進数>）
+// 受信側は同じ計算をして v1 と比べ、t が古すぎるリクエストは再送攻撃として拒否する
+func Sign(secret string, at time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newEventID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
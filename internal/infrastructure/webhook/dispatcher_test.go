@@ -0,0 +1,428 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeRepository はメモリ上の Webhook リポジトリ
+type fakeRepository struct {
+	mu         sync.Mutex
+	webhooks   map[int64]*entity.Webhook
+	deliveries map[int64]*entity.WebhookDelivery
+	nextID     int64
+}
+
+func newFakeRepository(webhooks ...*entity.Webhook) *fakeRepository {
+	r := &fakeRepository{
+		webhooks:   make(map[int64]*entity.Webhook),
+		deliveries: make(map[int64]*entity.WebhookDelivery),
+	}
+	for _, w := range webhooks {
+		r.webhooks[w.ID] = w
+	}
+	return r
+}
+
+func (r *fakeRepository) Create(_ context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	created := *webhook
+	created.ID = r.nextID
+	r.webhooks[created.ID] = &created
+	return &created, nil
+}
+
+func (r *fakeRepository) FindAll(context.Context) ([]*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var webhooks []*entity.Webhook
+	for _, w := range r.webhooks {
+		copied := *w
+		webhooks = append(webhooks, &copied)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+func (r *fakeRepository) FindByID(_ context.Context, id int64) (*entity.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	copied := *w
+	return &copied, nil
+}
+
+func (r *fakeRepository) Delete(_ context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *fakeRepository) CreateDelivery(_ context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	created := *delivery
+	created.ID = r.nextID
+	r.deliveries[created.ID] = &created
+	copied := created
+	return &copied, nil
+}
+
+func (r *fakeRepository) FindDeliveries(_ context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+func (r *fakeRepository) FindDueDeliveries(_ context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == entity.DeliveryStatusPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *fakeRepository) ClaimDelivery(_ context.Context, delivery *entity.WebhookDelivery, now, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[delivery.ID]
+	if !ok || d.Status != entity.DeliveryStatusPending || d.Attempts != delivery.Attempts || d.NextAttemptAt == nil || d.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	d.NextAttemptAt = &until
+	return true, nil
+}
+
+func (r *fakeRepository) UpdateDelivery(_ context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+// 指定した Webhook の配信ログ（古い順）
+func (r *fakeRepository) deliveriesOf(webhookID int64) []entity.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []entity.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			deliveries = append(deliveries, *d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries
+}
+
+// startDispatcher は配信を開始する。送信先は httptest のサーバー（ループバック）なので、
+// AllowPrivateNetworks を指定しなければ許可する
+func startDispatcher(t *testing.T, repo usecase.WebhookRepository, cfg Config) *Dispatcher {
+	t.Helper()
+	return startDispatcherWith(t, repo, cfg, true)
+}
+
+func startDispatcherWith(t *testing.T, repo usecase.WebhookRepository, cfg Config, allowPrivate bool) *Dispatcher {
+	t.Helper()
+	cfg.AllowPrivateNetworks = allowPrivate
+	d := NewDispatcher(repo, cfg)
+	d.logf = t.Logf
+	go d.Run()
+	t.Cleanup(d.Close)
+	return d
+}
+
+func testEvent(eventType string) usecase.ItemEvent {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = 1
+	return usecase.ItemEvent{Type: eventType, ItemID: 1, Item: item, OccurredAt: time.Now()}
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := newFakeRepository(&entity.Webhook{ID: 100, URL: srv.URL, Secret: "whsec_test"})
+	d := startDispatcher(t, repo, Config{PollInterval: 10 * time.Millisecond})
+
+	d.Publish(context.Background(), testEvent(usecase.ItemCreated))
+
+	var req received
+	select {
+	case req = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("配信されなかった")
+	}
+
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, usecase.ItemCreated, req.header.Get(HeaderEvent))
+	assert.NotEmpty(t, req.header.Get(HeaderDelivery))
+
+	// 署名は "t=<UNIX秒>" とボディから再計算できる
+	signature := req.header.Get(HeaderSignature)
+	require.True(t, strings.HasPrefix(signature, "t="))
+	var unix int64
+	_, err := fmt.Sscanf(signature, "t=%d,", &unix)
+	require.NoError(t, err)
+	assert.Equal(t, Sign("whsec_test", time.Unix(unix, 0), req.body), signature)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(req.body, &body))
+	assert.True(t, strings.HasPrefix(body["id"].(string), "evt_"))
+	assert.Equal(t, usecase.ItemCreated, body["type"])
+	assert.Equal(t, float64(1), body["item_id"])
+	assert.Equal(t, "時計1", body["item"].(map[string]interface{})["name"])
+
+	require.Eventually(t, func() bool {
+		deliveries := repo.deliveriesOf(100)
+		return len(deliveries) == 1 && deliveries[0].Status == entity.DeliveryStatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	delivery := repo.deliveriesOf(100)[0]
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+	assert.NotNil(t, delivery.CompletedAt)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestDispatcher_EventFilter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	repo := newFakeRepository(
+		&entity.Webhook{ID: 1, URL: srv.URL, Events: []string{usecase.ItemDeleted}},
+		&entity.Webhook{ID: 2, URL: srv.URL},
+	)
+	d := startDispatcher(t, repo, Config{PollInterval: 10 * time.Millisecond})
+
+	d.Publish(context.Background(), testEvent(usecase.ItemUpdated))
+
+	require.Eventually(t, func() bool {
+		deliveries := repo.deliveriesOf(2)
+		return len(deliveries) == 1 && deliveries[0].Status == entity.DeliveryStatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, repo.deliveriesOf(1), "購読していないイベントは配信しない")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDispatcher_Replay(t *testing.T) {
+	repo := newFakeRepository(
+		&entity.Webhook{ID: 1, URL: "https://example.com/a", Events: []string{usecase.ItemDeleted}},
+		&entity.Webhook{ID: 2, URL: "https://example.com/b"},
+	)
+	// 配信ログへの記録だけを確かめるため、送信は開始しない
+	d := NewDispatcher(repo, Config{})
+
+	require.NoError(t, d.Replay(context.Background(), testEvent(usecase.ItemUpdated)))
+
+	deliveries := repo.deliveriesOf(2)
+	require.Len(t, deliveries, 1, "戻る前に配信ログに記録する")
+	assert.Equal(t, entity.DeliveryStatusPending, deliveries[0].Status)
+	assert.Equal(t, usecase.ItemUpdated, deliveries[0].EventType)
+	assert.Empty(t, repo.deliveriesOf(1), "購読していないイベントは記録しない")
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int32
+		expectedStatus   string
+		expectedAttempts int
+		expectedResponse int
+	}{
+		{
+			name:             "正常系: 失敗した配信を再試行して成功",
+			failures:         2,
+			expectedStatus:   entity.DeliveryStatusSucceeded,
+			expectedAttempts: 3,
+			expectedResponse: http.StatusOK,
+		},
+		{
+			name:             "異常系: 上限の回数まで失敗したら諦める",
+			failures:         100,
+			expectedStatus:   entity.DeliveryStatusFailed,
+			expectedAttempts: 4,
+			expectedResponse: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer srv.Close()
+
+			repo := newFakeRepository(&entity.Webhook{ID: 1, URL: srv.URL})
+			d := startDispatcher(t, repo, Config{
+				MaxAttempts:  4,
+				RetryBase:    5 * time.Millisecond,
+				RetryMax:     20 * time.Millisecond,
+				PollInterval: 5 * time.Millisecond,
+			})
+
+			d.Publish(context.Background(), testEvent(usecase.ItemDeleted))
+
+			require.Eventually(t, func() bool {
+				deliveries := repo.deliveriesOf(1)
+				return len(deliveries) == 1 && deliveries[0].Status != entity.DeliveryStatusPending
+			}, 5*time.Second, 5*time.Millisecond)
+
+			delivery := repo.deliveriesOf(1)[0]
+			assert.Equal(t, tt.expectedStatus, delivery.Status)
+			assert.Equal(t, tt.expectedAttempts, delivery.Attempts)
+			assert.Equal(t, tt.expectedResponse, delivery.ResponseStatus)
+			assert.Equal(t, int32(tt.expectedAttempts), calls.Load())
+			if tt.expectedStatus == entity.DeliveryStatusFailed {
+				assert.Equal(t, "endpoint responded with 500", delivery.Error)
+			}
+		})
+	}
+}
+
+func TestDispatcher_PrivateNetwork(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	// 登録後に内部のアドレスを指すようになった宛先（DNS の書き換えなど）にも接続しない
+	repo := newFakeRepository(&entity.Webhook{ID: 1, URL: srv.URL})
+	d := startDispatcherWith(t, repo, Config{MaxAttempts: 1, PollInterval: 5 * time.Millisecond}, false)
+
+	d.Publish(context.Background(), testEvent(usecase.ItemCreated))
+
+	require.Eventually(t, func() bool {
+		deliveries := repo.deliveriesOf(1)
+		return len(deliveries) == 1 && deliveries[0].Status == entity.DeliveryStatusFailed
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Contains(t, repo.deliveriesOf(1)[0].Error, errPrivateAddress.Error())
+	assert.Zero(t, calls.Load())
+}
+
+func TestDispatcher_Redirect(t *testing.T) {
+	var followed atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed.Add(1)
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer srv.Close()
+
+	repo := newFakeRepository(&entity.Webhook{ID: 1, URL: srv.URL})
+	d := startDispatcher(t, repo, Config{MaxAttempts: 1, PollInterval: 5 * time.Millisecond})
+
+	d.Publish(context.Background(), testEvent(usecase.ItemCreated))
+
+	require.Eventually(t, func() bool {
+		deliveries := repo.deliveriesOf(1)
+		return len(deliveries) == 1 && deliveries[0].Status == entity.DeliveryStatusFailed
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, http.StatusFound, repo.deliveriesOf(1)[0].ResponseStatus)
+	assert.Zero(t, followed.Load(), "リダイレクト先には送らない")
+}
+
+func TestDispatcher_DeletedWebhook(t *testing.T) {
+	repo := newFakeRepository()
+	now := time.Now()
+	_, _ = repo.CreateDelivery(context.Background(), entity.NewWebhookDelivery(42, "evt_1", usecase.ItemCreated, 1, "{}", now))
+
+	startDispatcher(t, repo, Config{PollInterval: 5 * time.Millisecond})
+
+	require.Eventually(t, func() bool {
+		deliveries := repo.deliveriesOf(42)
+		return len(deliveries) == 1 && deliveries[0].Status == entity.DeliveryStatusFailed
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, "webhook has been deleted", repo.deliveriesOf(42)[0].Error)
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(newFakeRepository(), Config{RetryBase: 30 * time.Second, RetryMax: 5 * time.Minute})
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{attempt: 1, expected: 30 * time.Second},
+		{attempt: 2, expected: time.Minute},
+		{attempt: 3, expected: 2 * time.Minute},
+		{attempt: 4, expected: 4 * time.Minute},
+		{attempt: 5, expected: 5 * time.Minute},
+		{attempt: 50, expected: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, d.backoff(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestSign(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+
+	signature := Sign("whsec_test", at, []byte(`{"id":"evt_1"}`))
+
+	assert.True(t, strings.HasPrefix(signature, "t=1700000000,v1="))
+	assert.Len(t, strings.TrimPrefix(signature, "t=1700000000,v1="), 64)
+	assert.Equal(t, signature, Sign("whsec_test", at, []byte(`{"id":"evt_1"}`)))
+	assert.NotEqual(t, signature, Sign("whsec_other", at, []byte(`{"id":"evt_1"}`)))
+	assert.NotEqual(t, signature, Sign("whsec_test", at, []byte(`{"id":"evt_2"}`)))
+}
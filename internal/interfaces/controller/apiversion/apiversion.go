@@ -0,0 +1,132 @@
+// Package apiversion selects the API version of a request so breaking changes can ship under a new version
+// while the handlers and usecases are shared between versions.
+//
+// Routes are registered unprefixed and under /v1 and /v2. A prefixed route fixes the version; an unprefixed one
+// uses the API-Version header (v1 if absent), so existing clients keep getting v1.
+package apiversion
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Version is an API version
+type Version int
+
+const (
+	V1 Version = 1
+	// V2 sends item lists in JSON and MessagePack as a paginated envelope instead of a bare array
+	V2 Version = 2
+
+	// Default is the version of unprefixed requests without an API-Version header
+	Default = V1
+	// Latest is the newest supported version
+	Latest = V2
+)
+
+// HeaderAPIVersion selects the version of unprefixed routes and reports the version of every response
+const HeaderAPIVersion = "API-Version"
+
+// UnsupportedMessage is the error message for an unknown or malformed API-Version header
+const UnsupportedMessage = "unsupported API version"
+
+func (v Version) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
+
+// Prefix returns the path prefix of the version's routes, such as /v1
+func (v Version) Prefix() string {
+	return "/" + v.String()
+}
+
+// Supported returns the supported versions, oldest first
+func Supported() []Version {
+	return []Version{V1, V2}
+}
+
+// Parse reads a version such as "v2", "V2" or "2"; ok is false for an unsupported version
+func Parse(s string) (Version, bool) {
+	s = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "v")
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	for _, v := range Supported() {
+		if int(v) == n {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+type contextKey struct{}
+
+// WithVersion returns a context carrying the API version of a request
+func WithVersion(ctx context.Context, v Version) context.Context {
+	return context.WithValue(ctx, contextKey{}, v)
+}
+
+// FromContext returns the API version of a request, or Default if none was selected
+func FromContext(ctx context.Context) Version {
+	if v, ok := ctx.Value(contextKey{}).(Version); ok {
+		return v
+	}
+	return Default
+}
+
+// Split separates the version prefix from a route path: "/v2/items/:id" is V2 and "/items/:id".
+// A path without a version prefix is returned unchanged with version 0.
+func Split(path string) (Version, string) {
+	for _, v := range Supported() {
+		prefix := v.Prefix()
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return v, strings.TrimPrefix(path, prefix)
+		}
+	}
+	return 0, path
+}
+
+// Route returns a route path without its version prefix, so per-route settings apply to every version
+func Route(path string) string {
+	_, route := Split(path)
+	return route
+}
+
+// Middleware selects the version of each request from its route prefix or API-Version header,
+// stores it in the request context and reports it in the API-Version response header.
+// An unsupported API-Version header is rejected with 400; on a prefixed route the header is ignored.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			v, _ := Split(c.Path())
+			if v == 0 {
+				// The response of an unprefixed route depends on the header
+				c.Response().Header().Add(echo.HeaderVary, HeaderAPIVersion)
+				v = Default
+				if header := c.Request().Header.Get(HeaderAPIVersion); header != "" {
+					var ok bool
+					if v, ok = Parse(header); !ok {
+						return echo.NewHTTPError(http.StatusBadRequest, UnsupportedMessage+": "+header+" (supported: "+supportedList()+")")
+					}
+				}
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithVersion(req.Context(), v)))
+			c.Response().Header().Set(HeaderAPIVersion, v.String())
+			return next(c)
+		}
+	}
+}
+
+func supportedList() string {
+	names := make([]string, 0, len(Supported()))
+	for _, v := range Supported() {
+		names = append(names, v.String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,102 @@
+package apiversion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Version
+		ok       bool
+	}{
+		{name: "正常系: v付き", input: "v2", expected: V2, ok: true},
+		{name: "正常系: 数字のみ", input: "1", expected: V1, ok: true},
+		{name: "正常系: 大文字と空白", input: " V2 ", expected: V2, ok: true},
+		{name: "異常系: 未対応のバージョン", input: "v3"},
+		{name: "異常系: 数字でない", input: "latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := Parse(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		expectedVersion Version
+		expectedRoute   string
+	}{
+		{name: "正常系: v1", path: "/v1/items/:id", expectedVersion: V1, expectedRoute: "/items/:id"},
+		{name: "正常系: v2", path: "/v2/exports/items", expectedVersion: V2, expectedRoute: "/exports/items"},
+		{name: "正常系: 接頭辞なし", path: "/items", expectedRoute: "/items"},
+		{name: "正常系: 接頭辞に似たパス", path: "/v1items", expectedRoute: "/v1items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, route := Split(tt.path)
+			assert.Equal(t, tt.expectedVersion, v)
+			assert.Equal(t, tt.expectedRoute, route)
+			assert.Equal(t, tt.expectedRoute, Route(tt.path))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		header          string
+		expectedStatus  int
+		expectedVersion string
+		expectedVary    string
+	}{
+		{name: "正常系: 接頭辞なしはv1", path: "/items", expectedStatus: http.StatusOK, expectedVersion: "v1", expectedVary: HeaderAPIVersion},
+		{name: "正常系: ヘッダーで選択", path: "/items", header: "v2", expectedStatus: http.StatusOK, expectedVersion: "v2", expectedVary: HeaderAPIVersion},
+		{name: "正常系: 接頭辞で選択", path: "/v2/items", expectedStatus: http.StatusOK, expectedVersion: "v2"},
+		{name: "正常系: 接頭辞があればヘッダーは無視", path: "/v1/items", header: "v9", expectedStatus: http.StatusOK, expectedVersion: "v1"},
+		{name: "異常系: 未対応のバージョン", path: "/items", header: "v9", expectedStatus: http.StatusBadRequest, expectedVary: HeaderAPIVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(Middleware())
+			var selected Version
+			handler := func(c echo.Context) error {
+				selected = FromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+			e.GET("/items", handler)
+			e.GET("/v1/items", handler)
+			e.GET("/v2/items", handler)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderAPIVersion, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedVersion, rec.Header().Get(HeaderAPIVersion))
+			assert.Equal(t, tt.expectedVary, rec.Header().Get(echo.HeaderVary))
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedVersion, selected.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package attributes
+
+import (
+	"net/http"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CustomAttributeHandler struct {
+	attrUsecase usecase.CustomAttributeUsecase
+}
+
+func NewCustomAttributeHandler(attrUsecase usecase.CustomAttributeUsecase) *CustomAttributeHandler {
+	return &CustomAttributeHandler{
+		attrUsecase: attrUsecase,
+	}
+}
+
+// GetAttributes lists the custom attribute schema of the requesting tenant
+func (h *CustomAttributeHandler) GetAttributes(c echo.Context) error {
+	attrs, err := h.attrUsecase.ListAttributes(c.Request().Context(), itemController.TenantID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, attrs)
+}
+
+// PutAttribute creates or replaces a custom attribute definition
+func (h *CustomAttributeHandler) PutAttribute(c echo.Context) error {
+	var input usecase.SaveCustomAttributeInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	attr, err := h.attrUsecase.SaveAttribute(c.Request().Context(), itemController.TenantID(c), c.Param("key"), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, attr)
+}
+
+// DeleteAttribute removes a custom attribute definition; values already set on items are kept
+func (h *CustomAttributeHandler) DeleteAttribute(c echo.Context) error {
+	err := h.attrUsecase.DeleteAttribute(c.Request().Context(), itemController.TenantID(c), c.Param("key"))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package brands
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type BrandHandler struct {
+	brandUsecase usecase.BrandUsecase
+}
+
+func NewBrandHandler(brandUsecase usecase.BrandUsecase) *BrandHandler {
+	return &BrandHandler{
+		brandUsecase: brandUsecase,
+	}
+}
+
+// GetBrands returns every registered brand with its aliases, ordered by name
+func (h *BrandHandler) GetBrands(c echo.Context) error {
+	brands, err := h.brandUsecase.ListBrands(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, brands)
+}
+
+func (h *BrandHandler) CreateBrand(c echo.Context) error {
+	var input usecase.BrandInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	brand, err := h.brandUsecase.CreateBrand(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, brand)
+}
+
+func (h *BrandHandler) DeleteBrand(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid brand ID")
+	}
+
+	if err := h.brandUsecase.DeleteBrand(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SuggestBrands returns the brands whose name or alias starts with ?q=, for autocompletion in clients.
+// ?limit= is the number of suggestions (10 by default).
+func (h *BrandHandler) SuggestBrands(c echo.Context) error {
+	query := usecase.BrandSuggestQuery{Text: c.QueryParam("q")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "limit must be an integer")
+		}
+		query.Limit = limit
+	}
+
+	suggestions, err := h.brandUsecase.SuggestBrands(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
@@ -0,0 +1,74 @@
+package categories
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/locale"
+	"Aicon-assignment/internal/usecase"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUsecase: categoryUsecase,
+	}
+}
+
+// Category is a category code, used in the API whatever the language, with its display name.
+// Categories added by the tenant are their own display name in every language.
+type Category struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+}
+
+// GetCategories lists the built-in categories, then the ones the requesting tenant added,
+// with their display names in the language negotiated from Accept-Language
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	custom, err := h.categoryUsecase.ListCategories(c.Request().Context(), itemController.TenantID(c))
+	if err != nil {
+		return err
+	}
+
+	lang := locale.Language(c)
+	categories := make([]Category, 0, len(entity.ValidCategories)+len(custom))
+	for _, code := range entity.GetValidCategories() {
+		categories = append(categories, Category{Code: code, Name: entity.CategoryDisplayName(code, lang)})
+	}
+	for _, category := range custom {
+		categories = append(categories, Category{Code: category.Name, Name: category.Name, Custom: true})
+	}
+	return c.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory adds a category to the requesting tenant
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var input usecase.CategoryInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	category, err := h.categoryUsecase.CreateCategory(c.Request().Context(), itemController.TenantID(c), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// DeleteCategory deletes a category the requesting tenant added; built-in categories cannot be deleted
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	err := h.categoryUsecase.DeleteCategory(c.Request().Context(), itemController.TenantID(c), c.Param("name"))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
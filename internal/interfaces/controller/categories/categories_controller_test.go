@@ -0,0 +1,144 @@
+package categories
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeCategoryUsecase はテナント acme が「家具」を追加した状態を返す
+type fakeCategoryUsecase struct {
+	tenantID string
+	created  string
+	deleted  string
+}
+
+func (f *fakeCategoryUsecase) ListCategories(ctx context.Context, tenantID string) ([]*entity.Category, error) {
+	f.tenantID = tenantID
+	if tenantID == "acme" {
+		return []*entity.Category{{Name: "家具"}}, nil
+	}
+	return []*entity.Category{}, nil
+}
+
+func (f *fakeCategoryUsecase) CreateCategory(ctx context.Context, tenantID string, input usecase.CategoryInput) (*entity.Category, error) {
+	f.tenantID, f.created = tenantID, input.Name
+	return &entity.Category{Name: input.Name}, nil
+}
+
+func (f *fakeCategoryUsecase) DeleteCategory(ctx context.Context, tenantID, name string) error {
+	f.tenantID, f.deleted = tenantID, name
+	if name != "家具" {
+		return domainErrors.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func newTestServer(fake *fakeCategoryUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	h := NewCategoryHandler(fake)
+	e.GET("/categories", h.GetCategories)
+	e.POST("/categories", h.CreateCategory)
+	e.DELETE("/categories/:name", h.DeleteCategory)
+	return e
+}
+
+func TestCategoryHandler_GetCategories(t *testing.T) {
+	tests := []struct {
+		name             string
+		acceptLanguage   string
+		tenantID         string
+		expectedBody     string
+		expectedLanguage string
+	}{
+		{
+			name:             "正常系: 英語の表示名",
+			acceptLanguage:   "en-US,en;q=0.9",
+			expectedBody:     `[{"code":"時計","name":"Watches","custom":false},{"code":"バッグ","name":"Bags","custom":false},{"code":"ジュエリー","name":"Jewelry","custom":false},{"code":"靴","name":"Shoes","custom":false},{"code":"その他","name":"Other","custom":false}]`,
+			expectedLanguage: "en",
+		},
+		{
+			name:             "正常系: 指定がなければ日本語",
+			expectedBody:     `[{"code":"時計","name":"時計","custom":false},{"code":"バッグ","name":"バッグ","custom":false},{"code":"ジュエリー","name":"ジュエリー","custom":false},{"code":"靴","name":"靴","custom":false},{"code":"その他","name":"その他","custom":false}]`,
+			expectedLanguage: "ja",
+		},
+		{
+			name:             "正常系: テナントが追加したカテゴリーは組み込みのカテゴリーの後に名前のまま",
+			acceptLanguage:   "en",
+			tenantID:         "acme",
+			expectedBody:     `[{"code":"時計","name":"Watches","custom":false},{"code":"バッグ","name":"Bags","custom":false},{"code":"ジュエリー","name":"Jewelry","custom":false},{"code":"靴","name":"Shoes","custom":false},{"code":"その他","name":"Other","custom":false},{"code":"家具","name":"家具","custom":true}]`,
+			expectedLanguage: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCategoryUsecase{}
+			req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if tt.tenantID != "" {
+				req.Header.Set(itemController.HeaderTenantID, tt.tenantID)
+			}
+			rec := httptest.NewRecorder()
+
+			newTestServer(fake).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedLanguage, rec.Header().Get("Content-Language"))
+			assert.Equal(t, tt.tenantID, fake.tenantID)
+		})
+	}
+}
+
+func TestCategoryHandler_CreateCategory(t *testing.T) {
+	fake := &fakeCategoryUsecase{}
+	req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"家具"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(itemController.HeaderTenantID, "acme")
+	rec := httptest.NewRecorder()
+
+	newTestServer(fake).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "acme", fake.tenantID)
+	assert.Equal(t, "家具", fake.created)
+}
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{name: "正常系: URLエンコードしたカテゴリー", path: "/categories/%E5%AE%B6%E5%85%B7", expectedCode: http.StatusNoContent},
+		{name: "異常系: 追加していないカテゴリー", path: "/categories/%E6%99%82%E8%A8%88", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCategoryUsecase{}
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			req.Header.Set(itemController.HeaderTenantID, "acme")
+			rec := httptest.NewRecorder()
+
+			newTestServer(fake).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, "acme", fake.tenantID)
+		})
+	}
+}
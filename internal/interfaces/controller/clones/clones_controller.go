@@ -0,0 +1,49 @@
+package clones
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CloneHandler struct {
+	cloneUsecase usecase.CloneUsecase
+}
+
+func NewCloneHandler(cloneUsecase usecase.CloneUsecase) *CloneHandler {
+	return &CloneHandler{
+		cloneUsecase: cloneUsecase,
+	}
+}
+
+// CloneItem creates a copy of the item owned by the acting user; fields in the body override the copied ones.
+// The new item is sent like the response of POST /items.
+func (h *CloneHandler) CloneItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	// An empty body copies the item as it is
+	var req usecase.CloneItemRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	req.TenantID = itemController.TenantID(c)
+	req.OwnerID = itemController.UserID(c)
+
+	item, err := h.cloneUsecase.CloneItem(c.Request().Context(), id, req)
+	if err != nil {
+		return err
+	}
+
+	return serializer.Respond(c, http.StatusCreated, item)
+}
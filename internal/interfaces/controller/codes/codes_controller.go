@@ -0,0 +1,90 @@
+package codes
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ItemCodeHandler struct {
+	itemCodeUsecase usecase.ItemCodeUsecase
+}
+
+func NewItemCodeHandler(itemCodeUsecase usecase.ItemCodeUsecase) *ItemCodeHandler {
+	return &ItemCodeHandler{
+		itemCodeUsecase: itemCodeUsecase,
+	}
+}
+
+// ItemCodesRequest is the complete list of codes of an item
+type ItemCodesRequest struct {
+	Codes []usecase.ItemCodeInput `json:"codes"`
+}
+
+// GetItemCodes returns the serial numbers, barcodes and QR codes of an item
+func (h *ItemCodeHandler) GetItemCodes(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	codes, err := h.itemCodeUsecase.ListItemCodes(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, codes)
+}
+
+// SetItemCodes replaces the codes of an item; an empty list removes them all
+func (h *ItemCodeHandler) SetItemCodes(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	var req ItemCodesRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	codes, err := h.itemCodeUsecase.SetItemCodes(c.Request().Context(), itemController.UserID(c), itemID, req.Codes)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, codes)
+}
+
+// LookupItem resolves a scanned code to the items it refers to. QR codes holding a URL have to be
+// percent-encoded to fit in the path; the router matches such a path as it was sent, without decoding it.
+func (h *ItemCodeHandler) LookupItem(c echo.Context) error {
+	code := c.Param("code")
+	if c.Request().URL.RawPath != "" {
+		unescaped, err := url.PathUnescape(code)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "invalid code")
+		}
+		code = unescaped
+	}
+
+	lookup, err := h.itemCodeUsecase.LookupCode(c.Request().Context(), code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, lookup)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
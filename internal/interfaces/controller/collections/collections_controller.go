@@ -0,0 +1,173 @@
+package collections
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CollectionHandler struct {
+	collectionUsecase usecase.CollectionUsecase
+}
+
+func NewCollectionHandler(collectionUsecase usecase.CollectionUsecase) *CollectionHandler {
+	return &CollectionHandler{
+		collectionUsecase: collectionUsecase,
+	}
+}
+
+// AddItemsRequest lists the items added to a collection
+type AddItemsRequest struct {
+	ItemIDs []int64 `json:"item_ids"`
+}
+
+// GetCollections returns the collections ordered by name, only those of ?owner_id= if given
+func (h *CollectionHandler) GetCollections(c echo.Context) error {
+	collections, err := h.collectionUsecase.ListCollections(c.Request().Context(), c.QueryParam("owner_id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, collections)
+}
+
+// CreateCollection creates a collection owned by the acting user
+func (h *CollectionHandler) CreateCollection(c echo.Context) error {
+	var input usecase.CollectionInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	collection, err := h.collectionUsecase.CreateCollection(c.Request().Context(), itemController.UserID(c), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, collection)
+}
+
+func (h *CollectionHandler) GetCollection(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	collection, err := h.collectionUsecase.GetCollection(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+// UpdateCollection replaces the name and description of a collection
+func (h *CollectionHandler) UpdateCollection(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.CollectionInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	collection, err := h.collectionUsecase.UpdateCollection(c.Request().Context(), itemController.UserID(c), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (h *CollectionHandler) DeleteCollection(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.collectionUsecase.DeleteCollection(c.Request().Context(), itemController.UserID(c), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetItems returns the items of a collection in the order they were added
+func (h *CollectionHandler) GetItems(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	items, err := h.collectionUsecase.ListCollectionItems(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
+// AddItems adds items to a collection and returns the collection with its new item count
+func (h *CollectionHandler) AddItems(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	var req AddItemsRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	collection, err := h.collectionUsecase.AddItems(c.Request().Context(), itemController.UserID(c), id, req.ItemIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, collection)
+}
+
+func (h *CollectionHandler) RemoveItem(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+	itemID, err := strconv.ParseInt(c.Param("item_id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	if err := h.collectionUsecase.RemoveItem(c.Request().Context(), itemController.UserID(c), id, itemID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSummary totals the items of a collection; with ?currency= it also converts the purchase prices to that currency
+func (h *CollectionHandler) GetSummary(c echo.Context) error {
+	id, err := collectionID(c)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.collectionUsecase.GetSummary(c.Request().Context(), id, c.QueryParam("currency"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+func collectionID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid collection ID")
+	}
+	return id, nil
+}
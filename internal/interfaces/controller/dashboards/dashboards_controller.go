@@ -0,0 +1,29 @@
+package dashboards
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DashboardHandler struct {
+	dashboardUsecase usecase.DashboardUsecase
+}
+
+func NewDashboardHandler(dashboardUsecase usecase.DashboardUsecase) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardUsecase: dashboardUsecase,
+	}
+}
+
+// GetDashboard returns the counts, total value, top categories, recent items and upcoming expiry dates in one response
+func (h *DashboardHandler) GetDashboard(c echo.Context) error {
+	dashboard, err := h.dashboardUsecase.GetDashboard(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dashboard)
+}
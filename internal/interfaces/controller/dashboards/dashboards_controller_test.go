@@ -0,0 +1,78 @@
+package dashboards
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockDashboardUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDashboardUsecase) GetDashboard(ctx context.Context) (*usecase.Dashboard, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.Dashboard), args.Error(1)
+}
+
+func newTestServer(dashboardUsecase usecase.DashboardUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.GET("/dashboard", NewDashboardHandler(dashboardUsecase).GetDashboard)
+	return e
+}
+
+func get(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDashboardHandler_GetDashboard(t *testing.T) {
+	t.Run("正常系: ホーム画面の内容をまとめて返す", func(t *testing.T) {
+		mockUsecase := new(MockDashboardUsecase)
+		mockUsecase.On("GetDashboard", mock.Anything).Return(&usecase.Dashboard{
+			Categories:    map[string]int{"時計": 1},
+			Total:         1,
+			TotalValue:    1500000,
+			TopCategories: []usecase.CategoryCount{{Category: "時計", Count: 1}},
+			RecentItems:   []*entity.Item{},
+			ExpiringSoon:  []usecase.ExpiringItem{{ItemID: 1, Name: "ロレックス", Attribute: "warranty_expires", ExpiresOn: "2024-03-20", DaysLeft: 10}},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/dashboard")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"categories": {"時計": 1},
+			"total": 1,
+			"total_value": 1500000,
+			"top_categories": [{"category": "時計", "count": 1}],
+			"recent_items": [],
+			"expiring_soon": [{"item_id": 1, "name": "ロレックス", "attribute": "warranty_expires", "expires_on": "2024-03-20", "days_left": 10}]
+		}`, rec.Body.String())
+	})
+
+	t.Run("異常系: 読み込みに失敗", func(t *testing.T) {
+		mockUsecase := new(MockDashboardUsecase)
+		mockUsecase.On("GetDashboard", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		rec := get(newTestServer(mockUsecase), "/dashboard")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,124 @@
+package documents
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type DocumentHandler struct {
+	documentUsecase usecase.DocumentUsecase
+}
+
+func NewDocumentHandler(documentUsecase usecase.DocumentUsecase) *DocumentHandler {
+	return &DocumentHandler{
+		documentUsecase: documentUsecase,
+	}
+}
+
+// UploadDocument adds the document sent as the "file" field of a multipart/form-data request to an item,
+// tagged with the "type" field (receipt, certificate or appraisal)
+func (h *DocumentHandler) UploadDocument(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "file is required")
+		}
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	document, err := h.documentUsecase.UploadDocument(c.Request().Context(), itemController.UserID(c), itemID,
+		usecase.DocumentUpload{Type: c.FormValue("type"), FileName: header.Filename, Size: header.Size, Content: file})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, document)
+}
+
+// GetDocuments returns the documents of an item in upload order, only those of the "type" query parameter if given
+func (h *DocumentHandler) GetDocuments(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	documents, err := h.documentUsecase.ListDocuments(c.Request().Context(), itemID, c.QueryParam("type"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, documents)
+}
+
+// GetDocument downloads the file of a document under the name it was uploaded with
+func (h *DocumentHandler) GetDocument(c echo.Context) error {
+	itemID, id, err := documentID(c)
+	if err != nil {
+		return err
+	}
+
+	document, file, err := h.documentUsecase.OpenDocument(c.Request().Context(), itemID, id)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Documents never change once uploaded; a replaced document gets a new ID
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(document.Size, 10))
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	header.Set("Cache-Control", "private, max-age=86400, immutable")
+	header.Set("X-Content-Type-Options", "nosniff")
+	itemController.SetReprDigest(c, document.SHA256)
+	return c.Stream(http.StatusOK, document.ContentType, file)
+}
+
+func (h *DocumentHandler) DeleteDocument(c echo.Context) error {
+	itemID, id, err := documentID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.documentUsecase.DeleteDocument(c.Request().Context(), itemController.UserID(c), itemID, id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
+
+func documentID(c echo.Context) (int64, int64, error) {
+	itemID, err := itemID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseInt(c.Param("document_id"), 10, 64)
+	if err != nil {
+		return 0, 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid document ID")
+	}
+	return itemID, id, nil
+}
@@ -0,0 +1,133 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+var eventTypes = []string{usecase.ItemCreated, usecase.ItemUpdated, usecase.ItemDeleted, usecase.ItemExpiring}
+
+type EventHandler struct {
+	subscriber     usecase.ItemEventSubscriber
+	allowedOrigins map[string]bool
+}
+
+// NewEventHandler creates the handler. allowedOrigins lists the origins (scheme://host[:port], or * for any)
+// whose pages may open the stream besides the API's own origin.
+func NewEventHandler(subscriber usecase.ItemEventSubscriber, allowedOrigins []string) *EventHandler {
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	return &EventHandler{
+		subscriber:     subscriber,
+		allowedOrigins: origins,
+	}
+}
+
+// Stream upgrades the request to a WebSocket and sends item events as JSON text messages until the client disconnects.
+// The optional types query parameter (comma-separated) limits the event types sent.
+func (h *EventHandler) Stream(c echo.Context) error {
+	types, err := parseEventTypes(c.QueryParam("types"))
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", err.Error())
+	}
+
+	server := websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			// The hijacked connection keeps the HTTP server's read/write deadlines, which would cut off long-lived streams
+			ws.SetDeadline(time.Time{})
+			h.stream(ws, types)
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// checkOrigin rejects (403) WebSocket handshakes from pages of other sites, which browsers let open
+// cross-origin connections with the user's cookies. Clients without an Origin header, such as other services,
+// are not browsers and are accepted.
+func (h *EventHandler) checkOrigin(config *websocket.Config, req *http.Request) error {
+	header := req.Header.Get("Origin")
+	if header == "" {
+		return nil
+	}
+	origin, err := url.Parse(header)
+	if err != nil || origin.Host == "" {
+		return fmt.Errorf("invalid origin %q", header)
+	}
+	if !strings.EqualFold(origin.Host, req.Host) && !h.allowedOrigins["*"] &&
+		!h.allowedOrigins[strings.ToLower(origin.Scheme+"://"+origin.Host)] {
+		return fmt.Errorf("origin %q is not allowed", header)
+	}
+	config.Origin = origin
+	return nil
+}
+
+func (h *EventHandler) stream(ws *websocket.Conn, types map[string]bool) {
+	events, unsubscribe := h.subscriber.Subscribe()
+	defer unsubscribe()
+
+	// Clients do not send anything; reading only detects when the connection is closed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var message string
+		for websocket.Message.Receive(ws, &message) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if len(types) > 0 && !types[event.Type] {
+				continue
+			}
+			if err := websocket.JSON.Send(ws, event); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// parseEventTypes returns the requested event types, or nil for all types
+func parseEventTypes(param string) (map[string]bool, error) {
+	if strings.TrimSpace(param) == "" {
+		return nil, nil
+	}
+
+	types := make(map[string]bool)
+	for _, t := range strings.Split(param, ",") {
+		t = strings.TrimSpace(t)
+		if !isEventType(t) {
+			return nil, fmt.Errorf("types must be a comma-separated list of: %s", strings.Join(eventTypes, ", "))
+		}
+		types[t] = true
+	}
+	return types, nil
+}
+
+func isEventType(t string) bool {
+	for _, known := range eventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
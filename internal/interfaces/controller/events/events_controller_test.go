@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"Aicon-assignment/internal/infrastructure/eventbus"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestEventHandler_Stream(t *testing.T) {
+	bus := eventbus.New(eventbus.DefaultBufferSize)
+	defer bus.Close()
+
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.GET("/ws", NewEventHandler(bus, []string{"https://app.example.com"}).Stream)
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+
+	t.Run("正常系: 指定した種類のイベントだけを受信する", func(t *testing.T) {
+		ws, err := websocket.Dial(wsURL+"?types=item.deleted", "", srv.URL)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		// 購読が登録されるまで待つ
+		require.Eventually(t, func() bool { return bus.Len() == 1 }, time.Second, 10*time.Millisecond)
+		bus.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 1})
+		bus.Publish(context.Background(), usecase.ItemEvent{Type: usecase.ItemDeleted, ItemID: 2})
+
+		var event usecase.ItemEvent
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, websocket.JSON.Receive(ws, &event))
+		assert.Equal(t, usecase.ItemDeleted, event.Type)
+		assert.Equal(t, int64(2), event.ItemID)
+	})
+
+	t.Run("正常系: 切断すると購読を解除する", func(t *testing.T) {
+		assert.Eventually(t, func() bool { return bus.Len() == 0 }, time.Second, 10*time.Millisecond)
+	})
+
+	// handshake は Origin を付けてWebSocketのハンドシェイクを送り、ステータスを返す
+	handshake := func(t *testing.T, origin string) int {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/ws", nil)
+		require.NoError(t, err)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("正常系: 許可したオリジンと Origin のないクライアントは接続できる", func(t *testing.T) {
+		assert.Equal(t, http.StatusSwitchingProtocols, handshake(t, "https://app.example.com"))
+		assert.Equal(t, http.StatusSwitchingProtocols, handshake(t, ""))
+	})
+
+	t.Run("異常系: 許可していないオリジンのページからは接続できない", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, handshake(t, "https://evil.example.com"))
+		assert.Equal(t, http.StatusForbidden, handshake(t, "null"))
+
+		_, err := websocket.Dial(wsURL, "", "https://evil.example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 不明なイベント種別", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/ws?types=item.moved")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,69 @@
+package exports
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ExportHandler struct {
+	estateUsecase     usecase.EstateExportUsecase
+	itemExportUsecase usecase.ItemExportUsecase
+}
+
+func NewExportHandler(estateUsecase usecase.EstateExportUsecase, itemExportUsecase usecase.ItemExportUsecase) *ExportHandler {
+	return &ExportHandler{
+		estateUsecase:     estateUsecase,
+		itemExportUsecase: itemExportUsecase,
+	}
+}
+
+// StartEstateExport queues an encrypted estate inventory package for the requesting user
+func (h *ExportHandler) StartEstateExport(c echo.Context) error {
+	var input usecase.EstateExportInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+	input.OwnerID = itemController.UserID(c)
+	// ?currency= takes precedence over the body, like the currency of GET /items/summary
+	if currency := c.QueryParam("currency"); currency != "" {
+		input.Currency = currency
+	}
+
+	job, err := h.estateUsecase.StartEstateExport(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/exports/"+job.ID)
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GetExport returns the status of an export job
+func (h *ExportHandler) GetExport(c echo.Context) error {
+	job, err := h.estateUsecase.GetExportJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// DownloadExport returns the generated file once the job has completed
+func (h *ExportHandler) DownloadExport(c echo.Context) error {
+	job, err := h.estateUsecase.GetExportJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if job.Status != entity.ExportStatusCompleted {
+		return itemController.NewHTTPError(http.StatusConflict, "export is not ready: "+job.Status)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+job.Kind+`-`+job.ID+`.pdf.sealed"`)
+	return c.Blob(http.StatusOK, echo.MIMEOctetStream, job.Data)
+}
@@ -0,0 +1,243 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// flushEvery is the number of rows written between flushes of the chunked response
+	flushEvery = 100
+	// streamWriteTimeout is how long a client may take to read each flushed chunk; it replaces the
+	// server's write timeout, which would otherwise cut off exports that take longer than one request
+	streamWriteTimeout = 30 * time.Second
+)
+
+// itemEncoder writes items in an export format
+type itemEncoder interface {
+	ContentType() string
+	Extension() string
+	Begin() error
+	Item(item *entity.Item) error
+	// Flush writes buffered rows to the response
+	Flush() error
+	End() error
+}
+
+var csvHeader = []string{"id", "name", "category", "brand", "purchase_price", "currency", "purchase_date", "owner_id", "attributes", "version", "created_at", "updated_at"}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) ContentType() string { return "text/csv; charset=UTF-8" }
+func (e *csvEncoder) Extension() string   { return "csv" }
+
+func (e *csvEncoder) Begin() error {
+	return e.w.Write(csvHeader)
+}
+
+func (e *csvEncoder) Item(item *entity.Item) error {
+	attributes := ""
+	if len(item.Attributes) > 0 {
+		b, err := json.Marshal(item.Attributes)
+		if err != nil {
+			return err
+		}
+		attributes = string(b)
+	}
+	return e.w.Write([]string{
+		strconv.FormatInt(item.ID, 10),
+		item.Name,
+		item.Category,
+		item.Brand,
+		item.Price().String(),
+		item.Price().Currency,
+		item.PurchaseDate,
+		item.OwnerID,
+		attributes,
+		strconv.FormatInt(item.Version, 10),
+		item.CreatedAt.Format(time.RFC3339),
+		item.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) End() error {
+	return e.Flush()
+}
+
+// jsonEncoder writes a JSON array with one item per line
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) ContentType() string { return echo.MIMEApplicationJSONCharsetUTF8 }
+func (e *jsonEncoder) Extension() string   { return "json" }
+
+func (e *jsonEncoder) Begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonEncoder) Item(item *entity.Item) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.count == 0 {
+		sep = "\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonEncoder) Flush() error { return nil }
+
+func (e *jsonEncoder) End() error {
+	closing := "]\n"
+	if e.count > 0 {
+		closing = "\n]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
+
+// ndjsonEncoder writes one JSON object per line (newline-delimited JSON) for data pipelines; unlike a JSON array,
+// every complete line is a complete item, so a client whose download stopped resumes after the ID of the last line
+type ndjsonEncoder struct {
+	w io.Writer
+}
+
+func (e *ndjsonEncoder) ContentType() string { return "application/x-ndjson" }
+func (e *ndjsonEncoder) Extension() string   { return "ndjson" }
+func (e *ndjsonEncoder) Begin() error        { return nil }
+
+func (e *ndjsonEncoder) Item(item *entity.Item) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+func (e *ndjsonEncoder) Flush() error { return nil }
+func (e *ndjsonEncoder) End() error   { return nil }
+
+func newItemEncoder(format string, w io.Writer) itemEncoder {
+	switch format {
+	case "", "csv":
+		return &csvEncoder{w: csv.NewWriter(w)}
+	case "json":
+		return &jsonEncoder{w: w}
+	case "ndjson":
+		return &ndjsonEncoder{w: w}
+	default:
+		return nil
+	}
+}
+
+// ExportItems streams all matching items as CSV (default), JSON or NDJSON with chunked transfer encoding.
+// Supports the category, brand, sort and order query parameters. NDJSON is exported in ID order and
+// resumes after the after_id query parameter instead of being sorted.
+func (h *ExportHandler) ExportItems(c echo.Context) error {
+	res := c.Response()
+	format := c.QueryParam("format")
+	enc := newItemEncoder(format, res)
+	if enc == nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "format must be csv, json or ndjson")
+	}
+	query := usecase.ItemExportQuery{
+		TenantID:  itemController.TenantID(c),
+		Category:  c.QueryParam("category"),
+		Brand:     c.QueryParam("brand"),
+		SortBy:    c.QueryParam("sort"),
+		SortOrder: c.QueryParam("order"),
+		Resumable: format == "ndjson",
+	}
+	if afterID := c.QueryParam("after_id"); afterID != "" {
+		if !query.Resumable {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "after_id is only supported by the ndjson format")
+		}
+		id, err := strconv.ParseInt(afterID, 10, 64)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "after_id must be an integer")
+		}
+		query.AfterID = id
+	}
+
+	// The response is committed with the first item, so validation errors can still be returned as JSON
+	rc := http.NewResponseController(res)
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		res.Header().Set(echo.HeaderContentType, enc.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.`+enc.Extension()+`"`)
+		res.WriteHeader(http.StatusOK)
+		return enc.Begin()
+	}
+	flush := func() error {
+		if err := enc.Flush(); err != nil {
+			return err
+		}
+		rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		res.Flush()
+		return nil
+	}
+
+	rows := 0
+	err := h.itemExportUsecase.ExportItems(c.Request().Context(), query, func(item *entity.Item) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := enc.Item(item); err != nil {
+			return err
+		}
+		rows++
+		if rows%flushEvery == 0 {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		if err = start(); err == nil {
+			err = enc.End()
+		}
+	}
+	if err != nil {
+		if !started {
+			return err
+		}
+		// Part of the file has been sent: abort the connection so the client sees a failed transfer
+		// instead of a truncated file that looks complete
+		c.Logger().Errorf("item export aborted after %d rows: %v", rows, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	res.Flush()
+	return nil
+}
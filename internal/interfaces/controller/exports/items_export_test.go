@@ -0,0 +1,189 @@
+package exports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeItemExportUsecase passes items to fn and fails after failAfter items when err is set
+type fakeItemExportUsecase struct {
+	items     []*entity.Item
+	err       error
+	failAfter int
+	query     usecase.ItemExportQuery
+}
+
+func (u *fakeItemExportUsecase) ExportItems(ctx context.Context, query usecase.ItemExportQuery, fn func(*entity.Item) error) error {
+	u.query = query
+	for i, item := range u.items {
+		if u.err != nil && i == u.failAfter {
+			return u.err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return u.err
+}
+
+func exportTestItems(n int) []*entity.Item {
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	items := make([]*entity.Item, 0, n)
+	for i := 1; i <= n; i++ {
+		items = append(items, &entity.Item{
+			ID:            int64(i),
+			Name:          fmt.Sprintf("時計, %d", i),
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 1000 * i,
+			PurchaseDate:  "2023-01-01",
+			Version:       1,
+			CreatedAt:     created,
+			UpdatedAt:     created,
+		})
+	}
+	return items
+}
+
+func serveExport(t *testing.T, fake *fakeItemExportUsecase, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	handler := NewExportHandler(nil, fake)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+	if err := handler.ExportItems(c); err != nil {
+		c.Error(err)
+	}
+	return rec
+}
+
+func TestExportHandler_ExportItems(t *testing.T) {
+	t.Run("正常系: CSV", func(t *testing.T) {
+		items := exportTestItems(2)
+		items[0].Attributes = map[string]string{"color": "black"}
+		items[1].PurchasePrice, items[1].Currency = 1234, "USD"
+		fake := &fakeItemExportUsecase{items: items}
+
+		rec := serveExport(t, fake, "/exports/items?category=時計&brand=ROLEX&sort=purchase_price&order=desc")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="items.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, usecase.ItemExportQuery{Category: "時計", Brand: "ROLEX", SortBy: "purchase_price", SortOrder: "desc"}, fake.query)
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, csvHeader, records[0])
+		assert.Equal(t, []string{"1", "時計, 1", "時計", "ROLEX", "1000", "JPY", "2023-01-01", "", `{"color":"black"}`, "1", "2024-01-01T09:00:00Z", "2024-01-01T09:00:00Z"}, records[1])
+		assert.Equal(t, "2", records[2][0])
+		// 価格は通貨の10進数の表記
+		assert.Equal(t, []string{"12.34", "USD"}, records[2][4:6])
+	})
+
+	t.Run("正常系: JSON", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{items: exportTestItems(3)}, "/exports/items?format=json")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+		var items []entity.Item
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &items))
+		require.Len(t, items, 3)
+		assert.Equal(t, int64(3), items[2].ID)
+	})
+
+	t.Run("正常系: NDJSON は1行に1アイテムで、after_id の後から ID の順に返す", func(t *testing.T) {
+		fake := &fakeItemExportUsecase{items: exportTestItems(3)[1:]}
+		rec := serveExport(t, fake, "/items/export?format=ndjson&category=時計&after_id=1")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="items.ndjson"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, usecase.ItemExportQuery{Category: "時計", Resumable: true, AfterID: 1}, fake.query)
+
+		lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		for i, line := range lines {
+			var item entity.Item
+			require.NoError(t, json.Unmarshal([]byte(line), &item))
+			assert.Equal(t, int64(i+2), item.ID)
+		}
+
+		rec = serveExport(t, &fakeItemExportUsecase{}, "/items/export?format=ndjson")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("正常系: 該当なしでもヘッダー・空配列を返す", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{}, "/exports/items")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, strings.Join(csvHeader, ",")+"\n", rec.Body.String())
+
+		rec = serveExport(t, &fakeItemExportUsecase{}, "/exports/items?format=json")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("正常系: 一定件数ごとにフラッシュする", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{items: exportTestItems(flushEvery + 1)}, "/exports/items")
+		assert.True(t, rec.Flushed)
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, flushEvery+2)
+	})
+
+	t.Run("異常系: 不正な形式", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{}, "/exports/items?format=xlsx")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "format must be csv, json or ndjson")
+	})
+
+	t.Run("異常系: after_id は NDJSON だけで、整数", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{}, "/exports/items?after_id=10")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "after_id is only supported by the ndjson format")
+
+		rec = serveExport(t, &fakeItemExportUsecase{}, "/items/export?format=ndjson&after_id=ten")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "after_id must be an integer")
+	})
+
+	t.Run("異常系: 書き出し前のバリデーションエラー", func(t *testing.T) {
+		fake := &fakeItemExportUsecase{err: fmt.Errorf("%w: category must be one of: 時計", domainErrors.ErrInvalidInput)}
+		rec := serveExport(t, fake, "/exports/items?category=車")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, echo.MIMEApplicationJSON, strings.Split(rec.Header().Get(echo.HeaderContentType), ";")[0])
+	})
+
+	t.Run("異常系: 書き出し前のデータベースエラー", func(t *testing.T) {
+		rec := serveExport(t, &fakeItemExportUsecase{err: domainErrors.ErrDatabaseError}, "/exports/items")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("異常系: 書き出し途中のエラーは接続を中断する", func(t *testing.T) {
+		fake := &fakeItemExportUsecase{items: exportTestItems(3), err: domainErrors.ErrDatabaseError, failAfter: 2}
+		var recovered interface{}
+		func() {
+			defer func() { recovered = recover() }()
+			serveExport(t, fake, "/exports/items")
+		}()
+		assert.Equal(t, http.ErrAbortHandler, recovered)
+	})
+}
@@ -0,0 +1,121 @@
+package images
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ImageHandler struct {
+	imageUsecase usecase.ImageUsecase
+}
+
+func NewImageHandler(imageUsecase usecase.ImageUsecase) *ImageHandler {
+	return &ImageHandler{
+		imageUsecase: imageUsecase,
+	}
+}
+
+// UploadImage adds the image sent as the "file" field of a multipart/form-data request to an item
+func (h *ImageHandler) UploadImage(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "file is required")
+		}
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	image, err := h.imageUsecase.UploadImage(c.Request().Context(), itemController.UserID(c), itemID,
+		usecase.ImageUpload{FileName: header.Filename, Size: header.Size, Content: file})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+// GetImages returns the images of an item in upload order
+func (h *ImageHandler) GetImages(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	images, err := h.imageUsecase.ListImages(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
+// GetImage sends the file of an image
+func (h *ImageHandler) GetImage(c echo.Context) error {
+	itemID, id, err := imageID(c)
+	if err != nil {
+		return err
+	}
+
+	image, file, err := h.imageUsecase.OpenImage(c.Request().Context(), itemID, id)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Images never change once uploaded; a replaced image gets a new ID
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(image.Size, 10))
+	header.Set("Cache-Control", "private, max-age=86400, immutable")
+	header.Set("X-Content-Type-Options", "nosniff")
+	itemController.SetReprDigest(c, image.SHA256)
+	return c.Stream(http.StatusOK, image.ContentType, file)
+}
+
+func (h *ImageHandler) DeleteImage(c echo.Context) error {
+	itemID, id, err := imageID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.imageUsecase.DeleteImage(c.Request().Context(), itemController.UserID(c), itemID, id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
+
+func imageID(c echo.Context) (int64, int64, error) {
+	itemID, err := itemID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseInt(c.Param("image_id"), 10, 64)
+	if err != nil {
+		return 0, 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid image ID")
+	}
+	return itemID, id, nil
+}
@@ -0,0 +1,39 @@
+package imports
+
+import (
+	"net/http"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ImportHandler struct {
+	importUsecase usecase.ImportUsecase
+}
+
+func NewImportHandler(importUsecase usecase.ImportUsecase) *ImportHandler {
+	return &ImportHandler{
+		importUsecase: importUsecase,
+	}
+}
+
+// ImportItems creates the items of the rows for the acting user, and reports the rows matching items the user
+// already has. With "dry_run": true nothing is changed and the report previews the import.
+func (h *ImportHandler) ImportItems(c echo.Context) error {
+	var input usecase.ImportInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+	input.TenantID = itemController.TenantID(c)
+	input.OwnerID = itemController.UserID(c)
+	input.UserID = itemController.UserID(c)
+
+	report, err := h.importUsecase.ImportItems(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,90 @@
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockImportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockImportUsecase) ImportItems(ctx context.Context, input usecase.ImportInput) (*usecase.ImportReport, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ImportReport), args.Error(1)
+}
+
+func newTestServer(importUsecase usecase.ImportUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.POST("/items/import", NewImportHandler(importUsecase).ImportItems)
+	return e
+}
+
+func post(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(itemController.HeaderUserID, "alice")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestImportHandler_ImportItems(t *testing.T) {
+	t.Run("正常系: 重複の候補と解決方法を返す", func(t *testing.T) {
+		mockUsecase := new(MockImportUsecase)
+		mockUsecase.On("ImportItems", mock.Anything, mock.MatchedBy(func(input usecase.ImportInput) bool {
+			return input.OwnerID == "alice" && input.UserID == "alice" && input.DryRun &&
+				len(input.Rows) == 1 && input.Rows[0].Name == "デイトナ" && input.Rows[0].OnDuplicate == "merge" && input.Rows[0].MergeInto == 3
+		})).Return(&usecase.ImportReport{DryRun: true, Merged: 1, Rows: []usecase.ImportRowResult{{
+			Row: 1, Status: usecase.ImportMerged, ItemID: 3,
+			Matches: []usecase.ImportMatch{{ID: 3, Name: "デイトナ", Reason: "serial"}},
+			Options: []string{"skip", "merge", "create"},
+		}}}, nil)
+
+		rec := post(newTestServer(mockUsecase), `{"dry_run": true, "rows": [{"name": "デイトナ", "on_duplicate": "merge", "merge_into": 3}]}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var report usecase.ImportReport
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, 1, report.Merged)
+		assert.Equal(t, "serial", report.Rows[0].Matches[0].Reason)
+		assert.Equal(t, []string{"skip", "merge", "create"}, report.Rows[0].Options)
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 不正なボディ", body: `{"rows": "x"}`, expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 行がない", body: `{"rows": []}`, err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockImportUsecase)
+			mockUsecase.On("ImportItems", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := post(newTestServer(mockUsecase), tt.body)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
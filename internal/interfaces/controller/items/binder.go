@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// Binder binds request bodies like echo.DefaultBinder, but strictly and with uniform errors:
+// a JSON body over the size or nesting limits (SetJSONLimits) is rejected before it is decoded,
+// a JSON body with fields the target struct does not have is rejected with a 400 listing them,
+// and a body that cannot be decoded is a 400 "invalid request format" whose detail names the field
+// of a type mismatch or the offset of a syntax error.
+type Binder struct {
+	echo.DefaultBinder
+}
+
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	if err := checkUnknownBodyFields(c, i); err != nil {
+		return err
+	}
+	if err := b.DefaultBinder.Bind(i, c); err != nil {
+		return invalidRequestFormat(err)
+	}
+	return nil
+}
+
+// checkUnknownBodyFields rejects a JSON object body with keys that do not match a field of the struct i points to.
+// The body is read within the JSON limits and restored for the binder; bodies that are not JSON objects are left
+// to the binder to report.
+func checkUnknownBodyFields(c echo.Context, i interface{}) error {
+	req := c.Request()
+	if req.ContentLength == 0 || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return nil
+	}
+
+	body, err := readJSONBody(c)
+	if err != nil {
+		return err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var fields map[string]interface{}
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+	if unknown := unknownFields(fields, i); len(unknown) > 0 {
+		return unknownFieldsError(unknown)
+	}
+	return nil
+}
+
+// unknownFields returns the keys of a decoded JSON object that encoding/json would ignore when decoding
+// into the struct v points to, sorted. Like encoding/json, keys match field names case-insensitively.
+// Only top-level keys are checked.
+func unknownFields(body map[string]interface{}, v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	addJSONFields(t, known)
+
+	var unknown []string
+	for key := range body {
+		if !known[strings.ToLower(key)] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// addJSONFields adds the lower-cased JSON names of a struct's fields, including those of embedded structs
+func addJSONFields(t reflect.Type, known map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addJSONFields(ft, known)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+}
+
+// unknownFieldsError is the error response for a body with unknown fields, one detail per field
+func unknownFieldsError(fields []string) error {
+	details := make([]string, len(fields))
+	for i, field := range fields {
+		details[i] = field + " is not a known field"
+	}
+	return NewHTTPError(http.StatusBadRequest, "unknown fields in request body", details...)
+}
+
+// invalidRequestFormat converts an error decoding a request body into the error response sent for it;
+// errors other than malformed bodies (such as an unsupported Content-Type) are returned as they are
+func invalidRequestFormat(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		if typeErr.Field != "" {
+			return NewHTTPError(http.StatusBadRequest, "invalid request format", typeErr.Field+" must be "+jsonTypeName(typeErr.Type))
+		}
+		return NewHTTPError(http.StatusBadRequest, "invalid request format", "request body must be "+jsonTypeName(typeErr.Type))
+	case errors.As(err, &syntaxErr):
+		return NewHTTPError(http.StatusBadRequest, "invalid request format", fmt.Sprintf("request body is not valid JSON (offset %d)", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return NewHTTPError(http.StatusBadRequest, "invalid request format", "request body is not valid JSON (unexpected end)")
+	}
+
+	var echoErr *echo.HTTPError
+	if errors.As(err, &echoErr) && echoErr.Code != http.StatusBadRequest {
+		return err
+	}
+	return NewHTTPError(http.StatusBadRequest, "invalid request format")
+}
+
+// jsonTypeName describes the JSON value expected for a Go type
+func jsonTypeName(t reflect.Type) string {
+	if t == reflect.TypeOf(entity.Amount{}) {
+		return "an integer (minor units) or a decimal string"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Pointer:
+		return jsonTypeName(t.Elem())
+	default:
+		return "an object"
+	}
+}
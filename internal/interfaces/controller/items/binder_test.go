@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestBinder(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		body            string
+		expectedStatus  int
+		expectedError   string
+		expectedDetails []string
+	}{
+		{
+			name:           "正常系: 正しいJSON",
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"name":"時計1","purchase_price":1000}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: フィールド名は大文字小文字を区別しない",
+			contentType:    echo.MIMEApplicationJSONCharsetUTF8,
+			body:           `{"Name":"時計1","PURCHASE_PRICE":1000}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: 未知のフィールド",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name":"時計1","purchase_prise":1000,"colour":"black","tenant_id":"t1"}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "unknown fields in request body",
+			expectedDetails: []string{"colour is not a known field", "purchase_prise is not a known field", "tenant_id is not a known field"},
+		},
+		{
+			name:           "正常系: 価格は10進数の文字列でもよい",
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"name":"時計1","purchase_price":"12.34","currency":"USD"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: 型が違うフィールド",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name":1000}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid request format",
+			expectedDetails: []string{"name must be a string"},
+		},
+		{
+			// 整数でない数値は検証で purchase_price のエラーとして報告する
+			name:           "正常系: 小数の数値の価格もバインドする",
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"name":"時計1","purchase_price":12.34}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "異常系: ボディがオブジェクトでない",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `[1, 2]`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid request format",
+			expectedDetails: []string{"request body must be an object"},
+		},
+		{
+			name:            "異常系: 構文エラー",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name": watch}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid request format",
+			expectedDetails: []string{"request body is not valid JSON (offset 10)"},
+		},
+		{
+			name:            "異常系: 大きすぎるボディ",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"name":"` + strings.Repeat("a", DefaultMaxJSONBodySize) + `"}`,
+			expectedStatus:  http.StatusRequestEntityTooLarge,
+			expectedError:   "request body too large",
+			expectedDetails: []string{"request body must be at most 1048576 bytes"},
+		},
+		{
+			name:            "異常系: 深すぎる入れ子",
+			contentType:     echo.MIMEApplicationJSON,
+			body:            `{"attributes":` + strings.Repeat("[", DefaultMaxJSONDepth) + strings.Repeat("]", DefaultMaxJSONDepth) + `}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid request format",
+			expectedDetails: []string{"request body must not be nested more than 32 levels deep"},
+		},
+		{
+			name:           "異常系: 対応していないContent-Type",
+			contentType:    "text/csv",
+			body:           `name,purchase_price`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Binder = &Binder{}
+			e.HTTPErrorHandler = HTTPErrorHandler
+			e.POST("/items", func(c echo.Context) error {
+				var input usecase.CreateItemInput
+				if err := c.Bind(&input); err != nil {
+					return err
+				}
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if len(tt.expectedDetails) > 0 {
+				var errorResp ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Error)
+				assert.Equal(t, tt.expectedDetails, errorResp.Details)
+			}
+		})
+	}
+}
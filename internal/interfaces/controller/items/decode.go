@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Default limits on JSON request bodies
+const (
+	DefaultMaxJSONBodySize = 1 << 20
+	DefaultMaxJSONDepth    = 32
+)
+
+var (
+	maxJSONBodySize atomic.Int64
+	maxJSONDepth    atomic.Int64
+)
+
+func init() {
+	maxJSONBodySize.Store(DefaultMaxJSONBodySize)
+	maxJSONDepth.Store(DefaultMaxJSONDepth)
+}
+
+// SetJSONLimits sets the largest JSON request body in bytes and the deepest nesting of objects and arrays accepted
+func SetJSONLimits(maxBodySize, maxDepth int) {
+	maxJSONBodySize.Store(int64(maxBodySize))
+	maxJSONDepth.Store(int64(maxDepth))
+}
+
+// readJSONBody reads a JSON request body within the limits set by SetJSONLimits.
+// A larger body is a 413 and a more deeply nested one a 400, so neither is decoded.
+func readJSONBody(c echo.Context) ([]byte, error) {
+	limit := maxJSONBodySize.Load()
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, NewHTTPError(http.StatusRequestEntityTooLarge, "request body too large", fmt.Sprintf("request body must be at most %d bytes", limit))
+	}
+	if depth := maxJSONDepth.Load(); jsonDepthExceeds(body, int(depth)) {
+		return nil, NewHTTPError(http.StatusBadRequest, "invalid request format", fmt.Sprintf("request body must not be nested more than %d levels deep", depth))
+	}
+	return body, nil
+}
+
+// decodeJSON decodes a single JSON value into v. Numbers decoded into interface values are json.Number,
+// so integers such as prices keep their exact value instead of being rounded through float64.
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return invalidRequestFormat(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return NewHTTPError(http.StatusBadRequest, "invalid request format", "request body must be a single JSON value")
+	}
+	return nil
+}
+
+// jsonDepthExceeds reports whether objects and arrays in data are nested more than max levels deep.
+// It only tracks brackets outside strings, so it also works on malformed JSON, which the decoder reports.
+func jsonDepthExceeds(data []byte, max int) bool {
+	depth := 0
+	inString, escaped := false, false
+	for _, b := range data {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+		case b == '"':
+			inString = true
+		case b == '{' || b == '[':
+			depth++
+			if depth > max {
+				return true
+			}
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	t.Run("正常系: 数値は精度を落とさずに読む", func(t *testing.T) {
+		var body map[string]interface{}
+		require.NoError(t, decodeJSON([]byte(`{"purchase_price": 9007199254740993}`), &body))
+
+		assert.Equal(t, json.Number("9007199254740993"), body["purchase_price"])
+	})
+
+	t.Run("異常系: 複数の値", func(t *testing.T) {
+		var body map[string]interface{}
+		err := decodeJSON([]byte(`{"name":"時計1"} {"name":"時計2"}`), &body)
+
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+	})
+}
+
+func TestJSONDepthExceeds(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected bool
+	}{
+		{name: "正常系: 上限ちょうど", body: `{"a":[{"b":1}]}`, expected: false},
+		{name: "正常系: 文字列の中の括弧は数えない", body: `{"a":"[[[[\"{{{{"}`, expected: false},
+		{name: "異常系: 上限を超える", body: `{"a":[{"b":[1]}]}`, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jsonDepthExceeds([]byte(tt.body), 3))
+		})
+	}
+}
+
+func TestReadJSONBody_Limits(t *testing.T) {
+	SetJSONLimits(16, 2)
+	defer SetJSONLimits(DefaultMaxJSONBodySize, DefaultMaxJSONDepth)
+
+	read := func(body string) error {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(body)), httptest.NewRecorder())
+		_, err := readJSONBody(c)
+		return err
+	}
+
+	assert.NoError(t, read(`{"a":[1]}`))
+	var httpErr *HTTPError
+	require.ErrorAs(t, read(`{"name":"0123456789"}`), &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Status)
+	require.ErrorAs(t, read(`{"a":[[1]]}`), &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderReprDigest carries the digest of the file sent (RFC 9530), so clients can check the download
+const HeaderReprDigest = "Repr-Digest"
+
+// SetReprDigest sets Repr-Digest from the hex SHA-256 of an uploaded file; files uploaded before
+// hashes were recorded have none, and get no header
+func SetReprDigest(c echo.Context, sha256Hex string) {
+	sum, err := hex.DecodeString(sha256Hex)
+	if err != nil || len(sum) == 0 {
+		return
+	}
+	c.Response().Header().Set(HeaderReprDigest, "sha-256=:"+base64.StdEncoding.EncodeToString(sum)+":")
+}
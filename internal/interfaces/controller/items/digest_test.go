@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetReprDigest(t *testing.T) {
+	tests := []struct {
+		name     string
+		sha256   string
+		expected string
+	}{
+		{
+			name:     "正常系: SHA-256 を base64 で送る",
+			sha256:   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: "sha-256=:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=:",
+		},
+		{name: "正常系: ハッシュのない古いファイル", sha256: ""},
+		{name: "異常系: 16進数でない", sha256: "not-a-hash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			SetReprDigest(c, tt.sha256)
+
+			assert.Equal(t, tt.expected, rec.Header().Get(HeaderReprDigest))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/usecase"
+)
+
+// HTTPError is an error response returned by a handler instead of writing it; HTTPErrorHandler sends it
+type HTTPError struct {
+	Status   int
+	Response ErrorResponse
+}
+
+// NewHTTPError returns an error response with the given status, message and details
+func NewHTTPError(status int, message string, details ...string) *HTTPError {
+	return &HTTPError{Status: status, Response: ErrorResponse{Error: message, Details: details}}
+}
+
+func (e *HTTPError) Error() string {
+	if len(e.Response.Details) == 0 {
+		return fmt.Sprintf("%d %s", e.Status, e.Response.Error)
+	}
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Response.Error, strings.Join(e.Response.Details, ", "))
+}
+
+// RespondError writes an error response of an endpoint that only speaks JSON,
+// as problem details when the client or ERROR_FORMAT asks for them (see serializer.RespondError)
+func RespondError(c echo.Context, status int, resp ErrorResponse) error {
+	return serializer.RespondError(c, status, resp)
+}
+
+// HTTPErrorHandler writes the response for an error returned by a handler or middleware.
+// Handlers return *HTTPError for request errors and domain errors as they are; the domain errors
+// are mapped to statuses here (see errorResponse). Routing and middleware errors such as unknown routes,
+// unsupported methods or oversized bodies are sent in the same format.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, resp := errorResponse(c, err)
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = serializer.Respond(c, status, resp)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// notFoundErrors are the not-found errors sent with their own message; other not-found errors are sent as "not found".
+// If the error adds context to the message (such as the ID of a missing item in a batch), it is sent as the detail.
+var notFoundErrors = []error{
+	domainErrors.ErrItemNotFound,
+	domainErrors.ErrAttributeNotFound,
+	domainErrors.ErrTransferNotFound,
+	domainErrors.ErrExportNotFound,
+	domainErrors.ErrWebhookNotFound,
+	domainErrors.ErrNotificationRuleNotFound,
+	domainErrors.ErrLoanNotFound,
+	domainErrors.ErrServiceRecordNotFound,
+	domainErrors.ErrMarketPriceNotFound,
+	domainErrors.ErrImageNotFound,
+	domainErrors.ErrDocumentNotFound,
+	domainErrors.ErrTagNotFound,
+	domainErrors.ErrCategoryNotFound,
+	domainErrors.ErrBrandNotFound,
+	domainErrors.ErrCollectionNotFound,
+	domainErrors.ErrShareLinkNotFound,
+	domainErrors.ErrMediaLinkNotFound,
+	domainErrors.ErrReminderSnoozeNotFound,
+	domainErrors.ErrItemRevisionNotFound,
+	domainErrors.ErrUndoTokenNotFound,
+	domainErrors.ErrErasureRequestNotFound,
+	domainErrors.ErrItemCodeNotFound,
+}
+
+// StatusClientClosedRequest is the status logged for a request the client disconnected from before it was answered.
+// The client does not read the response; the status keeps these requests out of the 5xx errors.
+const StatusClientClosedRequest = 499
+
+// errorResponse maps an error to the status and body sent for it.
+// Errors that are neither request nor domain errors are 500s (503 if the request ran past its deadline,
+// 499 if the client went away), with the cause of a 5xx kept on the context for error reporting.
+func errorResponse(c echo.Context, err error) (int, ErrorResponse) {
+	var httpErr *HTTPError
+	var echoErr *echo.HTTPError
+	var duplicateErr *usecase.DuplicateItemError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Status, httpErr.Response
+	case errors.As(err, &echoErr):
+		message := strings.ToLower(http.StatusText(echoErr.Code))
+		if m, ok := echoErr.Message.(string); ok && m != http.StatusText(echoErr.Code) {
+			message = m
+		}
+		if echoErr.Code < http.StatusInternalServerError {
+			return echoErr.Code, ErrorResponse{Error: message}
+		}
+	case domainErrors.IsValidationError(err):
+		return http.StatusBadRequest, ErrorResponse{
+			Error:   "validation failed",
+			Details: parseValidationErrorDetails(err),
+		}
+	case domainErrors.IsNotFoundError(err):
+		for _, notFound := range notFoundErrors {
+			if errors.Is(err, notFound) {
+				resp := ErrorResponse{Error: notFound.Error()}
+				if err.Error() != resp.Error {
+					resp.Details = []string{err.Error()}
+				}
+				return http.StatusNotFound, resp
+			}
+		}
+		return http.StatusNotFound, ErrorResponse{Error: domainErrors.ErrNotFound.Error()}
+	case domainErrors.IsQuotaExceededError(err):
+		return http.StatusForbidden, limitErrorResponse(err, domainErrors.ErrQuotaExceeded)
+	case domainErrors.IsForbiddenError(err):
+		return http.StatusForbidden, ErrorResponse{Error: err.Error()}
+	case domainErrors.IsPreconditionFailedError(err):
+		return http.StatusPreconditionFailed, ErrorResponse{Error: domainErrors.ErrPreconditionFailed.Error()}
+	case errors.As(err, &duplicateErr):
+		return http.StatusConflict, ErrorResponse{Error: domainErrors.ErrDuplicateItem.Error(), Duplicates: duplicateErr.Candidates}
+	case domainErrors.IsConflictError(err):
+		return http.StatusConflict, ErrorResponse{Error: err.Error()}
+	case domainErrors.IsImageTooLargeError(err):
+		return http.StatusRequestEntityTooLarge, limitErrorResponse(err, domainErrors.ErrImageTooLarge)
+	case domainErrors.IsUnsupportedImageTypeError(err):
+		return http.StatusUnsupportedMediaType, limitErrorResponse(err, domainErrors.ErrUnsupportedImageType)
+	case domainErrors.IsDocumentTooLargeError(err):
+		return http.StatusRequestEntityTooLarge, limitErrorResponse(err, domainErrors.ErrDocumentTooLarge)
+	case domainErrors.IsUnsupportedDocumentTypeError(err):
+		return http.StatusUnsupportedMediaType, limitErrorResponse(err, domainErrors.ErrUnsupportedDocumentType)
+	case domainErrors.IsPriceProviderError(err):
+		// The provider's response is not sent to the client; it is kept for error reporting like other 5xx causes
+		c.Set(ContextKeyError, err)
+		if errors.Is(err, domainErrors.ErrPriceProviderTimeout) {
+			return http.StatusGatewayTimeout, ErrorResponse{Error: domainErrors.ErrPriceProviderTimeout.Error()}
+		}
+		return http.StatusBadGateway, ErrorResponse{Error: domainErrors.ErrPriceProviderUnavailable.Error()}
+	case domainErrors.IsExchangeRateError(err):
+		c.Set(ContextKeyError, err)
+		return http.StatusBadGateway, ErrorResponse{Error: domainErrors.ErrExchangeRateUnavailable.Error()}
+	case domainErrors.IsOCRError(err):
+		c.Set(ContextKeyError, err)
+		if errors.Is(err, domainErrors.ErrOCRTimeout) {
+			return http.StatusGatewayTimeout, ErrorResponse{Error: domainErrors.ErrOCRTimeout.Error()}
+		}
+		return http.StatusBadGateway, ErrorResponse{Error: domainErrors.ErrOCRUnavailable.Error()}
+	case errors.Is(err, domainErrors.ErrTransient):
+		// A failure that may succeed if retried, such as a dependency that is open-circuited
+		c.Set(ContextKeyError, err)
+		return http.StatusServiceUnavailable, ErrorResponse{Error: domainErrors.ErrTransient.Error()}
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(c.Request().Context().Err(), context.Canceled) {
+		return StatusClientClosedRequest, ErrorResponse{Error: ClientClosedMessage}
+	}
+	c.Set(ContextKeyError, err)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(c.Request().Context().Err(), context.DeadlineExceeded) {
+		return http.StatusServiceUnavailable, ErrorResponse{Error: TimeoutMessage}
+	}
+	if echoErr != nil {
+		return echoErr.Code, ErrorResponse{Error: strings.ToLower(http.StatusText(echoErr.Code))}
+	}
+	return http.StatusInternalServerError, ErrorResponse{Error: "internal server error"}
+}
+
+// limitErrorResponse sends the limit the request broke ("file must be ...", "alice owns 100 items, ...") as the detail
+func limitErrorResponse(err, kind error) ErrorResponse {
+	resp := ErrorResponse{Error: kind.Error()}
+	if detail := strings.TrimPrefix(err.Error(), kind.Error()+": "); detail != err.Error() {
+		resp.Details = []string{detail}
+	}
+	return resp
+}
@@ -0,0 +1,300 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	dbErr := errors.New("database error")
+
+	tests := []struct {
+		name                string
+		method              string
+		path                string
+		accept              string
+		errorFormat         string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+		expectedCause       error
+	}{
+		{
+			name:                "異常系: 存在しないルート",
+			method:              http.MethodGet,
+			path:                "/unknown",
+			errorFormat:         serializer.ErrorFormatDefault,
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"not found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:                "異常系: 許可されていないメソッド",
+			method:              http.MethodPut,
+			path:                "/items",
+			errorFormat:         serializer.ErrorFormatDefault,
+			expectedStatus:      http.StatusMethodNotAllowed,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"method not allowed","code":"METHOD_NOT_ALLOWED"}`,
+		},
+		{
+			name:                "異常系: HTTPError のメッセージはそのまま返す",
+			method:              http.MethodPost,
+			path:                "/items",
+			errorFormat:         serializer.ErrorFormatDefault,
+			expectedStatus:      http.StatusRequestEntityTooLarge,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"request body must be 1MB or less","code":"PAYLOAD_TOO_LARGE"}`,
+		},
+		{
+			name:                "異常系: その他のエラーは500で原因を保存する",
+			method:              http.MethodGet,
+			path:                "/items",
+			errorFormat:         serializer.ErrorFormatDefault,
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"internal server error","code":"INTERNAL_ERROR"}`,
+			expectedCause:       dbErr,
+		},
+		{
+			name:                "異常系: problem+json",
+			method:              http.MethodGet,
+			path:                "/unknown",
+			accept:              "application/problem+json",
+			errorFormat:         serializer.ErrorFormatDefault,
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","instance":"/unknown","code":"NOT_FOUND"}`,
+		},
+		{
+			name:                "異常系: ERROR_FORMAT=problem",
+			method:              http.MethodPut,
+			path:                "/items",
+			errorFormat:         serializer.ErrorFormatProblem,
+			expectedStatus:      http.StatusMethodNotAllowed,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Method Not Allowed","status":405,"detail":"method not allowed","instance":"/items","code":"METHOD_NOT_ALLOWED"}`,
+		},
+		{
+			name:           "異常系: HEAD はボディなし",
+			method:         http.MethodHead,
+			path:           "/unknown",
+			errorFormat:    serializer.ErrorFormatDefault,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, serializer.SetErrorFormat(tt.errorFormat))
+			defer serializer.SetErrorFormat(serializer.ErrorFormatDefault)
+
+			var cause interface{}
+			e := echo.New()
+			e.HTTPErrorHandler = func(err error, c echo.Context) {
+				HTTPErrorHandler(err, c)
+				cause = c.Get(ContextKeyError)
+			}
+			e.GET("/items", func(c echo.Context) error { return dbErr })
+			e.POST("/items", func(c echo.Context) error {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "request body must be 1MB or less")
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.Equal(t, tt.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+			if tt.expectedCause != nil {
+				assert.Equal(t, tt.expectedCause, cause)
+			} else {
+				assert.Nil(t, cause)
+			}
+		})
+	}
+}
+
+func TestHTTPErrorHandler_DomainErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "異常系: ハンドラーのエラーレスポンス",
+			err:            NewHTTPError(http.StatusBadRequest, "validation failed", "page must be an integer"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":["page must be an integer"],"code":"VALIDATION_FAILED","detail_codes":["VALIDATION_PAGE_INVALID_FORMAT"]}`,
+		},
+		{
+			name:           "異常系: 検証エラー",
+			err:            fmt.Errorf("failed to create item: %w", domainErrors.NewValidationError("name is required", "category must be one of: 時計, バッグ")),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":["name is required","category must be one of: 時計, バッグ"],"code":"VALIDATION_FAILED","detail_codes":["VALIDATION_NAME_REQUIRED","VALIDATION_CATEGORY_INVALID_CHOICE"]}`,
+		},
+		{
+			name:           "異常系: 型のない検証エラーはメッセージを分割しない",
+			err:            fmt.Errorf("%w: by must be one of: value, count", domainErrors.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":["by must be one of: value, count"],"code":"VALIDATION_FAILED","detail_codes":["VALIDATION_BY_INVALID_CHOICE"]}`,
+		},
+		{
+			name:           "異常系: 見つからない",
+			err:            domainErrors.ErrTransferNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"transfer not found","code":"TRANSFER_NOT_FOUND"}`,
+		},
+		{
+			name:           "異常系: 見つからないIDを詳細に含める",
+			err:            fmt.Errorf("%w: id 5", domainErrors.ErrItemNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"item not found","details":["item not found: id 5"],"code":"ITEM_NOT_FOUND","detail_codes":["VALIDATION_INVALID"]}`,
+		},
+		{
+			name:           "異常系: 利用枠の超過は403",
+			err:            fmt.Errorf("%w: alice owns 3 items, the limit is 3", domainErrors.ErrQuotaExceeded),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"quota exceeded","details":["alice owns 3 items, the limit is 3"],"code":"QUOTA_EXCEEDED","detail_codes":["VALIDATION_INVALID"]}`,
+		},
+		{
+			name:           "異常系: 権限なし",
+			err:            fmt.Errorf("%w: only the recipient can accept a transfer", domainErrors.ErrForbidden),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"forbidden: only the recipient can accept a transfer","code":"FORBIDDEN"}`,
+		},
+		{
+			name:           "異常系: 事前条件の不一致",
+			err:            fmt.Errorf("%w: %w", domainErrors.ErrConflict, domainErrors.ErrPreconditionFailed),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"error":"precondition failed","code":"PRECONDITION_FAILED"}`,
+		},
+		{
+			name:           "異常系: 競合",
+			err:            fmt.Errorf("%w: transfer is not pending", domainErrors.ErrConflict),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"conflict: transfer is not pending","code":"CONFLICT"}`,
+		},
+		{
+			name:           "異常系: 重複の疑いがあるアイテムは候補を返す",
+			err:            &usecase.DuplicateItemError{Candidates: []usecase.DuplicateCandidate{{ID: 1, Name: "デイトナ", Brand: "ROLEX", PurchaseDate: "2023-01-15", Similarity: 0.92}}},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"possible duplicate item","code":"DUPLICATE_ITEM","duplicates":[{"id":1,"name":"デイトナ","brand":"ROLEX","purchase_date":"2023-01-15","similarity":0.92}]}`,
+		},
+		{
+			name:           "異常系: 相場の提供元のエラーは502",
+			err:            fmt.Errorf("%w: provider responded with 500", domainErrors.ErrPriceProviderUnavailable),
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"error":"price provider unavailable","code":"PRICE_PROVIDER_UNAVAILABLE"}`,
+		},
+		{
+			name:           "異常系: 相場の提供元のタイムアウトは504",
+			err:            fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderTimeout, context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedBody:   `{"error":"price provider unavailable: timed out","code":"PRICE_PROVIDER_TIMEOUT"}`,
+		},
+		{
+			name:           "異常系: 為替レートの提供元の障害は502",
+			err:            fmt.Errorf("%w: provider responded 503", domainErrors.ErrExchangeRateUnavailable),
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `{"error":"exchange rate provider unavailable","code":"EXCHANGE_RATE_UNAVAILABLE"}`,
+		},
+		{
+			name:           "異常系: OCR のタイムアウトは504",
+			err:            fmt.Errorf("%w: %w", domainErrors.ErrOCRTimeout, context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedBody:   `{"error":"OCR provider unavailable: timed out","code":"OCR_PROVIDER_TIMEOUT"}`,
+		},
+		{
+			name:           "異常系: 一時的な失敗は503",
+			err:            fmt.Errorf("failed to list items: %w", domainErrors.Transient(errors.New("connection reset by peer"))),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error":"temporarily unavailable","code":"SERVICE_UNAVAILABLE"}`,
+		},
+		{
+			name:           "異常系: 大きすぎる画像は413",
+			err:            fmt.Errorf("%w: file must be 10485760 bytes or less", domainErrors.ErrImageTooLarge),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"image too large","details":["file must be 10485760 bytes or less"],"code":"IMAGE_TOO_LARGE","detail_codes":["VALIDATION_FILE_INVALID"]}`,
+		},
+		{
+			name:           "異常系: 画像でないファイルは415",
+			err:            fmt.Errorf("%w: file must be one of: image/gif, image/jpeg", domainErrors.ErrUnsupportedImageType),
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"unsupported image type","details":["file must be one of: image/gif, image/jpeg"],"code":"UNSUPPORTED_IMAGE_TYPE","detail_codes":["VALIDATION_FILE_INVALID_CHOICE"]}`,
+		},
+		{
+			name:           "異常系: PDF・画像でない書類は415",
+			err:            fmt.Errorf("%w: file must be one of: application/pdf, image/jpeg", domainErrors.ErrUnsupportedDocumentType),
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"error":"unsupported document type","details":["file must be one of: application/pdf, image/jpeg"],"code":"UNSUPPORTED_DOCUMENT_TYPE","detail_codes":["VALIDATION_FILE_INVALID_CHOICE"]}`,
+		},
+		{
+			name:           "異常系: 期限切れは503",
+			err:            fmt.Errorf("failed to retrieve items: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error":"request timed out","code":"REQUEST_TIMEOUT"}`,
+		},
+		{
+			name:           "異常系: クライアントが切断したリクエストは499",
+			err:            fmt.Errorf("import stopped before row 3: %w", context.Canceled),
+			expectedStatus: StatusClientClosedRequest,
+			expectedBody:   `{"error":"client closed request","code":"CLIENT_CLOSED_REQUEST"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items", nil), rec)
+
+			HTTPErrorHandler(tt.err, c)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHTTPErrorHandler_ClientClosedRequest(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items", nil).WithContext(ctx), rec)
+
+	// Repositories wrap the cancellation as text, so it is told by the request context
+	HTTPErrorHandler(fmt.Errorf("%w: context canceled", domainErrors.ErrDatabaseError), c)
+
+	assert.Equal(t, StatusClientClosedRequest, rec.Code)
+	assert.Nil(t, c.Get(ContextKeyError))
+}
+
+// serve runs a handler like Echo does, sending a returned error through HTTPErrorHandler
+func serve(c echo.Context, h echo.HandlerFunc) {
+	if err := h(c); err != nil {
+		HTTPErrorHandler(err, c)
+	}
+}
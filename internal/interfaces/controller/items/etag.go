@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// itemETag is the ETag of a single item: its version, so it can be sent back in If-Match.
+// It is weak since the version identifies the item, not the bytes: the same version is sent as JSON, XML,
+// MessagePack, ... and in the shape of each API version (responses Vary on Accept and API-Version).
+func itemETag(version int64) string {
+	return `W/` + strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// setETag exposes the item version so clients can send it back in If-Match
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set(HeaderETag, itemETag(version))
+}
+
+// listETag identifies a page of items in a response format; it changes when any listed item
+// is updated (version and updated_at), the page contents change, or the total changes
+func listETag(format string, list *usecase.ItemList) string {
+	h := sha256.New()
+	h.Write([]byte(format + "|" + strconv.Itoa(list.Total) + "|" + strconv.Itoa(list.Page) + "|" + strconv.Itoa(list.PageSize)))
+	for _, item := range list.Items {
+		h.Write([]byte("|" + strconv.FormatInt(item.ID, 10) + ":" + strconv.FormatInt(item.Version, 10) + ":" + strconv.FormatInt(item.UpdatedAt.UnixNano(), 10)))
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// notModified reports whether If-None-Match lists etag (weak comparison) or is *
+func notModified(c echo.Context, etag string) bool {
+	header := c.Request().Header.Get(HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// respondNotModified responds 304; Vary is kept as on the 200 response since the representation depends on Accept
+func respondNotModified(c echo.Context) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	return c.NoContent(http.StatusNotModified)
+}
+
+// parseIfMatch reads the expected item version from an If-Match header such as "3" or W/"3"
+func parseIfMatch(value string) (int64, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		unquoted = value
+	}
+	return strconv.ParseInt(unquoted, 10, 64)
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetItem_IfNoneMatch(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		expectedStatus int
+	}{
+		{name: "正常系: ETagが一致すれば304", ifNoneMatch: `"3"`, expectedStatus: http.StatusNotModified},
+		{name: "正常系: 弱いETagや複数指定も一致とみなす", ifNoneMatch: `"1", W/"3"`, expectedStatus: http.StatusNotModified},
+		{name: "正常系: バージョンが変わっていれば200", ifNoneMatch: `"2"`, expectedStatus: http.StatusOK},
+		{name: "正常系: 指定なしは200", ifNoneMatch: "", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 3}, nil)
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(HeaderIfNoneMatch, tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			serve(c, handler.GetItem)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, `W/"3"`, rec.Header().Get(HeaderETag))
+			// 弱いETagは形式ごとに同じなので、キャッシュは Accept ごとに分ける
+			assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAccept)
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestItemHandler_GetItems_ETag(t *testing.T) {
+	e := echo.New()
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	version := apiversion.V1
+	get := func(list *usecase.ItemList, accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		mockUsecase := new(mocks.ItemUsecase)
+		mockUsecase.On("ListItems", mock.Anything, mock.Anything).Return(list, nil)
+		handler := &ItemHandler{itemUsecase: mockUsecase}
+
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req = req.WithContext(apiversion.WithVersion(req.Context(), version))
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set(HeaderIfNoneMatch, ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler.GetItems(e.NewContext(req, rec)))
+		return rec
+	}
+	list := func(version int64) *usecase.ItemList {
+		return &usecase.ItemList{Items: []*entity.Item{{ID: 1, Version: version, UpdatedAt: updatedAt}}, Total: 1, Page: 1}
+	}
+
+	first := get(list(1), "", "")
+	etag := first.Header().Get(HeaderETag)
+	require.NotEmpty(t, etag)
+
+	t.Run("正常系: 変更がなければ304", func(t *testing.T) {
+		rec := get(list(1), "", etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, etag, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("正常系: アイテムが更新されていれば200", func(t *testing.T) {
+		rec := get(list(2), "", etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("正常系: 形式が違えば別のETag", func(t *testing.T) {
+		rec := get(list(1), "application/xml", etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("正常系: APIバージョンが違えば別のETag", func(t *testing.T) {
+		version = apiversion.V2
+		defer func() { version = apiversion.V1 }()
+
+		rec := get(list(1), "", etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get(HeaderETag))
+	})
+}
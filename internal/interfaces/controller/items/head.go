@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeadHandler serves HEAD with a GET handler: the response has the status and headers of the GET response,
+// including the Content-Length of its body, but no body. Conditional requests (If-None-Match) work as for GET.
+func HeadHandler(get echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res := c.Response()
+		w := &headWriter{ResponseWriter: res.Writer}
+		res.Writer = w
+		err := get(c)
+		res.Writer = w.ResponseWriter
+
+		// The status is sent only now that the body length is known; if nothing was written, an error is sent as for GET
+		if w.status != 0 {
+			if bodyAllowedForStatus(w.status) && w.Header().Get(echo.HeaderContentLength) == "" {
+				w.Header().Set(echo.HeaderContentLength, strconv.Itoa(w.size))
+			}
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		return err
+	}
+}
+
+// headWriter holds back the status and counts and discards the body of a GET response
+type headWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *headWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.size += len(b)
+	return len(b), nil
+}
+
+// Flush does nothing: the headers can only be sent once the body length is known
+func (w *headWriter) Flush() {}
+
+func bodyAllowedForStatus(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestHeadHandler(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 3}
+
+	tests := []struct {
+		name            string
+		path            string
+		headers         map[string]string
+		setupMock       func(*mocks.ItemUsecase)
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name: "正常系: 詳細のヘッダーと本文の長さ",
+			path: "/items/1",
+			setupMock: func(m *mocks.ItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{HeaderETag: `W/"3"`, echo.HeaderContentType: echo.MIMEApplicationJSON},
+		},
+		{
+			name: "正常系: 一覧の件数",
+			path: "/items",
+			setupMock: func(m *mocks.ItemUsecase) {
+				m.On("ListItems", mock.Anything, mock.Anything).Return(&usecase.ItemList{Items: []*entity.Item{item}, Total: 1, Page: 1, PageSize: 20}, nil)
+			},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{HeaderTotalCount: "1"},
+		},
+		{
+			name:    "正常系: 変更がなければ304",
+			path:    "/items/1",
+			headers: map[string]string{HeaderIfNoneMatch: `"3"`},
+			setupMock: func(m *mocks.ItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+			},
+			expectedStatus:  http.StatusNotModified,
+			expectedHeaders: map[string]string{HeaderETag: `W/"3"`, echo.HeaderContentLength: ""},
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			path: "/items/2",
+			setupMock: func(m *mocks.ItemUsecase) {
+				m.On("GetItemByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			e := echo.New()
+			e.HTTPErrorHandler = HTTPErrorHandler
+			e.GET("/items", handler.GetItems)
+			e.HEAD("/items", HeadHandler(handler.GetItems))
+			e.GET("/items/:id", handler.GetItem)
+			e.HEAD("/items/:id", HeadHandler(handler.GetItem))
+
+			get := httptest.NewRecorder()
+			head := httptest.NewRecorder()
+			for _, rec := range []*httptest.ResponseRecorder{get, head} {
+				method := http.MethodGet
+				if rec == head {
+					method = http.MethodHead
+				}
+				req := httptest.NewRequest(method, tt.path, nil)
+				for k, v := range tt.headers {
+					req.Header.Set(k, v)
+				}
+				e.ServeHTTP(rec, req)
+			}
+
+			assert.Equal(t, tt.expectedStatus, head.Code)
+			assert.Empty(t, head.Body.String())
+			for k, v := range tt.expectedHeaders {
+				assert.Equal(t, v, head.Header().Get(k), k)
+			}
+			// HEAD has the status and headers of GET, with the length of the GET body
+			assert.Equal(t, get.Code, head.Code)
+			if head.Code == http.StatusOK {
+				assert.Equal(t, strconv.Itoa(get.Body.Len()), head.Header().Get(echo.HeaderContentLength))
+				assert.Equal(t, get.Header().Get(HeaderETag), head.Header().Get(HeaderETag))
+			}
+		})
+	}
+}
+
+func TestOptions_Allow(t *testing.T) {
+	noop := func(c echo.Context) error { return nil }
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.GET("/items", noop)
+	e.HEAD("/items", HeadHandler(noop))
+	e.POST("/items", noop)
+	e.GET("/items/:id", noop)
+	e.HEAD("/items/:id", HeadHandler(noop))
+	e.PATCH("/items/:id", noop)
+	e.DELETE("/items/:id", noop)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedAllow  string
+	}{
+		{name: "正常系: 一覧のOPTIONS", method: http.MethodOptions, path: "/items", expectedStatus: http.StatusNoContent, expectedAllow: "OPTIONS, GET, HEAD, POST"},
+		{name: "正常系: 詳細のOPTIONS", method: http.MethodOptions, path: "/items/1", expectedStatus: http.StatusNoContent, expectedAllow: "OPTIONS, DELETE, GET, HEAD, PATCH"},
+		{name: "異常系: 許可されていないメソッドにも Allow を付ける", method: http.MethodPut, path: "/items/1", expectedStatus: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, DELETE, GET, HEAD, PATCH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedAllow, rec.Header().Get(echo.HeaderAllow))
+		})
+	}
+}
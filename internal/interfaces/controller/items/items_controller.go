@@ -2,11 +2,20 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/interfaces/controller/locale"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/interfaces/controller/tabular"
+	"Aicon-assignment/internal/interfaces/controller/validator"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
@@ -17,23 +26,48 @@ const (
 	fieldID        = "id"
 	fieldCreatedAt = "created_at"
 	fieldUpdatedAt = "updated_at"
+
+	// HeaderTenantID identifies the tenant whose settings apply to the request
+	HeaderTenantID = "X-Tenant-ID"
+	// HeaderUserID identifies the acting user (item owner, transfer sender or recipient)
+	HeaderUserID = "X-User-ID"
+	// HeaderTotalCount carries the total number of items before paging
+	HeaderTotalCount = "X-Total-Count"
+	// HeaderIfMatch carries the item version the client expects when updating or deleting
+	HeaderIfMatch = "If-Match"
+	// HeaderETag carries the current item version, or a hash of the versions of a listed page
+	HeaderETag = "ETag"
+	// HeaderIfNoneMatch carries the ETags the client already has
+	HeaderIfNoneMatch = "If-None-Match"
+	// HeaderUndoToken carries the token for undoing a delete, and HeaderUndoExpiresAt until when it can be used
+	HeaderUndoToken     = "X-Undo-Token"
+	HeaderUndoExpiresAt = "X-Undo-Expires-At"
+
+	// ContextKeyError holds the cause of a 5xx response for error reporting
+	ContextKeyError = "error"
+
+	// TimeoutMessage is the error message of a 503 response for a request that ran past its deadline
+	TimeoutMessage = "request timed out"
+	// ClientClosedMessage is the error message of a response for a request the client gave up on (see StatusClientClosedRequest)
+	ClientClosedMessage = "client closed request"
+	// DatabaseUnavailableMessage is the error message of a 503 response while the database circuit breaker is open
+	DatabaseUnavailableMessage = "database unavailable"
 )
 
 type ItemHandler struct {
 	itemUsecase usecase.ItemUsecase
+	undoUsecase usecase.UndoDeleteUsecase
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
+func NewItemHandler(itemUsecase usecase.ItemUsecase, undoUsecase usecase.UndoDeleteUsecase) *ItemHandler {
 	return &ItemHandler{
 		itemUsecase: itemUsecase,
+		undoUsecase: undoUsecase,
 	}
 }
 
 // ErrorResponse represents the standard error response format
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
-}
+type ErrorResponse = serializer.ErrorResponse
 
 // parseItemID extracts and validates the item ID from the URL parameter
 func parseItemID(idStr string) (int64, error) {
@@ -43,30 +77,82 @@ func parseItemID(idStr string) (int64, error) {
 	return strconv.ParseInt(idStr, 10, 64)
 }
 
-// parseValidationErrorDetails extracts validation error details from a wrapped error
-func parseValidationErrorDetails(err error) []string {
-	details := []string{err.Error()}
-	if strings.Contains(err.Error(), ": ") {
-		parts := strings.SplitN(err.Error(), ": ", 2)
-		if len(parts) == 2 {
-			details = strings.Split(parts[1], ", ")
+// TenantID returns the tenant identified by the request, or an empty string for the default tenant
+func TenantID(c echo.Context) string {
+	return strings.TrimSpace(c.Request().Header.Get(HeaderTenantID))
+}
+
+// UserID returns the acting user identified by the request, or an empty string if none
+func UserID(c echo.Context) string {
+	return strings.TrimSpace(c.Request().Header.Get(HeaderUserID))
+}
+
+// parseListItemsQuery reads the optional filter, sort and paging parameters of GET /items
+func parseListItemsQuery(c echo.Context) (usecase.ListItemsQuery, []string) {
+	var errs []string
+	query := usecase.ListItemsQuery{
+		TenantID:  TenantID(c),
+		Category:  c.QueryParam("category"),
+		Brand:     c.QueryParam("brand"),
+		SortBy:    c.QueryParam("sort"),
+		SortOrder: c.QueryParam("order"),
+	}
+
+	if v := c.QueryParam("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "page must be an integer")
 		}
+		query.Page = page
+	}
+
+	if v := c.QueryParam("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "page_size must be an integer")
+		}
+		query.PageSize = &pageSize
+	}
+
+	if v := c.QueryParam("min_current_value"); v != "" {
+		minCurrentValue, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "min_current_value must be an integer")
+		}
+		query.MinCurrentValue = minCurrentValue
+	}
+
+	return query, errs
+}
+
+// parseValidationErrorDetails extracts validation error details from a wrapped error.
+// Violations found by the request validator and the fields of a domain validation error are used as they are;
+// any other validation error is a single detail.
+func parseValidationErrorDetails(err error) []string {
+	var violations validator.Errors
+	if errors.As(err, &violations) {
+		return violations.Details()
 	}
-	return details
+	if validationErr, ok := domainErrors.AsValidationError(err); ok {
+		return validationErr.Details()
+	}
+
+	message := err.Error()
+	if _, rest, ok := strings.Cut(message, domainErrors.ErrInvalidInput.Error()+": "); ok {
+		message = rest
+	}
+	return []string{message}
 }
 
 // checkImmutableFields validates that the request body doesn't contain immutable fields
 func checkImmutableFields(requestBody map[string]interface{}) []string {
 	var errors []string
-	immutableFields := map[string]string{
-		fieldID:        "id is immutable",
-		fieldCreatedAt: "created_at is immutable",
-		fieldUpdatedAt: "updated_at is immutable",
-	}
+	// Checked in a fixed order so the error details are stable
+	immutableFields := []string{fieldID, fieldCreatedAt, fieldUpdatedAt}
 
-	for field, message := range immutableFields {
+	for _, field := range immutableFields {
 		if _, exists := requestBody[field]; exists {
-			errors = append(errors, message)
+			errors = append(errors, field+" is immutable")
 		}
 	}
 
@@ -74,184 +160,254 @@ func checkImmutableFields(requestBody map[string]interface{}) []string {
 }
 
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	query, queryErrors := parseListItemsQuery(c)
+	if len(queryErrors) > 0 {
+		return NewHTTPError(http.StatusBadRequest, "validation failed", queryErrors...)
+	}
+
+	list, err := h.itemUsecase.ListItems(c.Request().Context(), query)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, items)
+	c.Response().Header().Set(HeaderTotalCount, strconv.Itoa(list.Total))
+	pagination.SetHeader(c, list.Page, list.PageSize, list.Total)
+	// The representation depends on the response format and, from v2 on, the API version
+	representation := serializer.Negotiate(c.Request()).Format()
+	if v := apiversion.FromContext(c.Request().Context()); v != apiversion.V1 {
+		representation += "|" + v.String()
+	}
+	etag := listETag(representation, list)
+	c.Response().Header().Set(HeaderETag, etag)
+	if notModified(c, etag) {
+		return respondNotModified(c)
+	}
+	return serializer.Respond(c, http.StatusOK, list)
 }
 
 func (h *ItemHandler) GetItem(c echo.Context) error {
 	id, err := parseItemID(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return NewHTTPError(http.StatusBadRequest, "invalid item ID")
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, item)
+	setETag(c, item.Version)
+	if notModified(c, itemETag(item.Version)) {
+		return respondNotModified(c)
+	}
+	return serializer.Respond(c, http.StatusOK, item)
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	// XML bodies use the same <item> element as XML responses
+	if serializer.IsXML(c.Request()) {
+		var err error
+		if input, err = serializer.DecodeCreateItemXML(c.Request().Body); err != nil {
+			return NewHTTPError(http.StatusBadRequest, "invalid request format")
+		}
+	} else if err := c.Bind(&input); err != nil {
+		return invalidRequestFormat(err)
 	}
-
-	// バリデーション
-	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+	if err := c.Validate(&input); err != nil {
+		return err
+	}
+	input.TenantID = TenantID(c)
+	input.OwnerID = UserID(c)
+	if v := c.QueryParam("allow_duplicate"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, "validation failed", "allow_duplicate must be true or false")
+		}
+		input.AllowDuplicate = allow
 	}
 
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, item)
+	return respondWritten(c, http.StatusCreated, item)
 }
 
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	id, err := parseItemID(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return NewHTTPError(http.StatusBadRequest, "invalid item ID")
 	}
 
-	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+	var ifMatch *int64
+	if header := c.Request().Header.Get(HeaderIfMatch); header != "" {
+		version, err := parseIfMatch(header)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, "invalid If-Match header")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		ifMatch = &version
 	}
 
+	undo, err := h.undoUsecase.DeleteItem(c.Request().Context(), id, ifMatch)
+	if err != nil {
+		return err
+	}
+
+	if undo != nil {
+		c.Response().Header().Set(HeaderUndoToken, undo.Token)
+		c.Response().Header().Set(HeaderUndoExpiresAt, undo.ExpiresAt.UTC().Format(time.RFC3339))
+	}
 	return c.NoContent(http.StatusNoContent)
 }
 
+type undoDeleteRequest struct {
+	UndoToken string `json:"undo_token"`
+}
+
+// UndoDelete restores the item deleted with the undo token in the body; the item is sent like the response of GET /items/{id}
+func (h *ItemHandler) UndoDelete(c echo.Context) error {
+	var req undoDeleteRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidRequestFormat(err)
+	}
+
+	item, err := h.undoUsecase.UndoDelete(c.Request().Context(), req.UndoToken)
+	if err != nil {
+		return err
+	}
+
+	return serializer.Respond(c, http.StatusOK, item)
+}
+
+// GetSummary returns the item counts by category, of the items purchased between ?from= and ?to= when given;
+// with ?currency= it also totals the purchase prices in that currency. ?format=csv sends it as CSV.
 func (h *ItemHandler) GetSummary(c echo.Context) error {
-	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
+	purchased := usecase.DateRange{From: c.QueryParam("from"), To: c.QueryParam("to")}
+	currency := c.QueryParam("currency")
+	if currency != "" && purchased != (usecase.DateRange{}) {
+		// 換算した合計は購入日で絞り込めないため、組み合わせると件数と合計が食い違う
+		return NewHTTPError(http.StatusBadRequest, "validation failed", "currency cannot be combined with from or to")
+	}
+
+	asCSV, err := tabular.IsCSV(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, summary)
+	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context(), purchased)
+	if err != nil {
+		return err
+	}
+
+	if currency != "" {
+		summary.Value, err = h.itemUsecase.GetValueSummary(c.Request().Context(), currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Display names follow Accept-Language; the categories themselves stay the category codes
+	summary.CategoryNames = entity.CategoryDisplayNames(locale.Language(c), entity.SortedCategories(summary.Categories))
+
+	if asCSV {
+		return tabular.CSV(c, "summary", categoryTable(summary))
+	}
+	return serializer.Respond(c, http.StatusOK, summary)
+}
+
+// categoryTable has one row per category; the value columns are only filled when a currency was requested
+func categoryTable(summary *usecase.CategorySummary) *tabular.Table {
+	table := tabular.NewTable("category", "category_name", "count", "min_purchase_price", "max_purchase_price", "avg_purchase_price", "total_purchase_price", "value", "currency")
+	for _, category := range entity.SortedCategories(summary.Categories) {
+		stats := summary.Stats[category]
+		name := summary.CategoryNames[category]
+		if summary.Value == nil {
+			table.Append(category, name, summary.Categories[category], stats.Min, stats.Max, stats.Avg, stats.Sum, "", "")
+			continue
+		}
+		table.Append(category, name, summary.Categories[category], stats.Min, stats.Max, stats.Avg, stats.Sum, summary.Value.Categories[category], summary.Value.Currency)
+	}
+	return table
 }
 
 func (h *ItemHandler) PatchItem(c echo.Context) error {
 	id, err := parseItemID(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	// JSON Merge Patch and JSON Patch bodies are applied to the item's current representation
+	if isDocumentPatch(c.Request()) {
+		req, err := h.documentPatchRequest(c, id)
+		if err != nil {
+			return err
+		}
+		return h.updateItem(c, id, req)
 	}
 
 	// Read and parse request body into a map first to check for immutable fields
+	body, err := readJSONBody(c)
+	if err != nil {
+		return err
+	}
 	var requestBody map[string]interface{}
-	if err := json.NewDecoder(c.Request().Body).Decode(&requestBody); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	if err := decodeJSON(body, &requestBody); err != nil {
+		return err
 	}
 
 	// Check for immutable fields
 	if immutableErrors := checkImmutableFields(requestBody); len(immutableErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: immutableErrors,
-		})
+		return NewHTTPError(http.StatusBadRequest, "validation failed", immutableErrors...)
+	}
+
+	// Reject fields that would otherwise be silently dropped, such as misspelled ones
+	if unknown := unknownFields(requestBody, &usecase.UpdateItemRequest{}); len(unknown) > 0 {
+		return unknownFieldsError(unknown)
 	}
 
 	// Parse into UpdateItemRequest struct
 	var req usecase.UpdateItemRequest
 	bodyBytes, err := json.Marshal(requestBody)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return invalidRequestFormat(err)
 	}
 
 	if err := json.Unmarshal(bodyBytes, &req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return invalidRequestFormat(err)
 	}
+	return h.updateItem(c, id, &req)
+}
 
-	item, err := h.itemUsecase.PatchItem(c.Request().Context(), id, &req)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+// updateItem validates an update request, applies the If-Match precondition and updates the item
+func (h *ItemHandler) updateItem(c echo.Context, id int64, req *usecase.UpdateItemRequest) error {
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+	req.TenantID = TenantID(c)
+	req.UserID = UserID(c)
+
+	// The expected version may be sent in the body or as an If-Match precondition; both must agree if given
+	if ifMatch := c.Request().Header.Get(HeaderIfMatch); ifMatch != "" {
+		version, err := parseIfMatch(ifMatch)
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, "invalid If-Match header")
 		}
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: parseValidationErrorDetails(err),
-			})
+		if req.Version != nil && *req.Version != version {
+			return NewHTTPError(http.StatusBadRequest, "version does not match If-Match header")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to update item",
-		})
+		req.IfMatch = &version
 	}
 
-	return c.JSON(http.StatusOK, item)
-}
-
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
-	var errs []string
-
-	// Basic required field validation
-	if input.Name == "" {
-		errs = append(errs, "name is required")
-	}
-	if input.Category == "" {
-		errs = append(errs, "category is required")
-	}
-	if input.Brand == "" {
-		errs = append(errs, "brand is required")
-	}
-	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
-	}
-	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	item, err := h.itemUsecase.PatchItem(c.Request().Context(), id, req)
+	if err != nil {
+		// A version conflict on update is reported as a modification of the item
+		if domainErrors.IsConflictError(err) && !domainErrors.IsPreconditionFailedError(err) {
+			return NewHTTPError(http.StatusConflict, "item has been modified")
+		}
+		return err
 	}
 
-	return errs
+	return respondWritten(c, http.StatusOK, item)
 }
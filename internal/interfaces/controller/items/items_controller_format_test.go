@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/interfaces/controller/jsonapi"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetItem_ResponseFormat(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name                string
+		defaultFormat       string
+		accept              string
+		found               bool
+		expectedStatus      int
+		expectedContentType string
+		expectedKey         string
+	}{
+		{
+			name:                "正常系: デフォルトはJSON",
+			defaultFormat:       serializer.FormatJSON,
+			found:               true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedKey:         "name",
+		},
+		{
+			name:                "正常系: AcceptでJSON:APIを指定",
+			defaultFormat:       serializer.FormatJSON,
+			accept:              "application/vnd.api+json",
+			found:               true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: jsonapi.MediaType,
+			expectedKey:         "data",
+		},
+		{
+			name:                "正常系: 設定でJSON:APIをデフォルトにしてもAcceptのJSONが優先",
+			defaultFormat:       serializer.FormatJSONAPI,
+			accept:              "application/json",
+			found:               true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedKey:         "name",
+		},
+		{
+			name:                "正常系: 設定でJSON:APIをデフォルトにする",
+			defaultFormat:       serializer.FormatJSONAPI,
+			accept:              "*/*",
+			found:               true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: jsonapi.MediaType,
+			expectedKey:         "data",
+		},
+		{
+			name:                "異常系: エラーはerrors配列で返す",
+			defaultFormat:       serializer.FormatJSON,
+			accept:              "application/vnd.api+json",
+			found:               false,
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: jsonapi.MediaType,
+			expectedKey:         "errors",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, serializer.SetDefaultFormat(tt.defaultFormat))
+			defer serializer.SetDefaultFormat(serializer.FormatJSON)
+
+			mockUsecase := new(mocks.ItemUsecase)
+			if tt.found {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1"}, nil)
+			} else {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			}
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			serve(c, handler.GetItem)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.expectedContentType)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Contains(t, body, tt.expectedKey)
+		})
+	}
+}
+
+func TestItemHandler_CreateItem_XML(t *testing.T) {
+	e := echo.New()
+	e.Validator = validator.New()
+
+	t.Run("正常系: XMLで登録しXMLで返す", func(t *testing.T) {
+		input := usecase.CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1000), PurchaseDate: "2024-01-01"}
+		mockUsecase := new(mocks.ItemUsecase)
+		mockUsecase.On("CreateItem", mock.Anything, input).Return(&entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, PurchaseDate: "2024-01-01"}, nil)
+		handler := &ItemHandler{itemUsecase: mockUsecase}
+
+		body := `<item><name>時計1</name><category>時計</category><brand>ROLEX</brand><purchase_price>1000</purchase_price><purchase_date>2024-01-01</purchase_date></item>`
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, "application/xml; charset=utf-8")
+		req.Header.Set(echo.HeaderAccept, "application/xml")
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, handler.CreateItem(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "application/xml", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "<item><id>1</id><name>時計1</name>")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正なXML", func(t *testing.T) {
+		handler := &ItemHandler{itemUsecase: new(mocks.ItemUsecase)}
+
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`<item><purchase_price>abc</purchase_price></item>`))
+		req.Header.Set(echo.HeaderContentType, "application/xml")
+		req.Header.Set(echo.HeaderAccept, "application/xml")
+		rec := httptest.NewRecorder()
+
+		serve(e.NewContext(req, rec), handler.CreateItem)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<error><message>invalid request format</message><code>INVALID_REQUEST_FORMAT</code></error>")
+	})
+}
+
+func TestItemHandler_GetItems_APIVersion(t *testing.T) {
+	list := &usecase.ItemList{Items: []*entity.Item{{ID: 1, Name: "時計1"}}, Total: 11, Page: 2, PageSize: 10}
+
+	tests := []struct {
+		name            string
+		path            string
+		apiVersion      string
+		expectedStatus  int
+		expectedVersion string
+		expectedBody    string
+	}{
+		{
+			name:            "正常系: 接頭辞なしはv1の配列",
+			path:            "/items",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v1",
+			expectedBody:    `[{"id":1,"name":"時計1","category":"","brand":"","purchase_price":0,"purchase_date":"","version":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]`,
+		},
+		{
+			name:            "正常系: /v2 はページの封筒",
+			path:            "/v2/items",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v2",
+			expectedBody:    `{"items":[{"id":1,"name":"時計1","category":"","brand":"","purchase_price":0,"purchase_date":"","version":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":11,"page":2,"page_size":10}`,
+		},
+		{
+			name:            "正常系: 接頭辞なしでもヘッダーでv2",
+			path:            "/items",
+			apiVersion:      "2",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v2",
+			expectedBody:    `{"items":[{"id":1,"name":"時計1","category":"","brand":"","purchase_price":0,"purchase_date":"","version":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"total":11,"page":2,"page_size":10}`,
+		},
+		{
+			name:            "正常系: 接頭辞がヘッダーより優先",
+			path:            "/v1/items",
+			apiVersion:      "v2",
+			expectedStatus:  http.StatusOK,
+			expectedVersion: "v1",
+			expectedBody:    `[{"id":1,"name":"時計1","category":"","brand":"","purchase_price":0,"purchase_date":"","version":0,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]`,
+		},
+		{
+			name:           "異常系: 未対応のバージョン",
+			path:           "/items",
+			apiVersion:     "v3",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"unsupported API version: v3 (supported: v1, v2)","code":"UNSUPPORTED_API_VERSION"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			mockUsecase.On("ListItems", mock.Anything, mock.Anything).Return(list, nil).Maybe()
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			e := echo.New()
+			e.HTTPErrorHandler = HTTPErrorHandler
+			e.Use(apiversion.Middleware())
+			e.GET("/items", handler.GetItems)
+			e.GET("/v1/items", handler.GetItems)
+			e.GET("/v2/items", handler.GetItems)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.apiVersion != "" {
+				req.Header.Set(apiversion.HeaderAPIVersion, tt.apiVersion)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedVersion, rec.Header().Get(apiversion.HeaderAPIVersion))
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestItemHandler_GetItems_Link(t *testing.T) {
+	mockUsecase := new(mocks.ItemUsecase)
+	mockUsecase.On("ListItems", mock.Anything, mock.Anything).Return(&usecase.ItemList{Items: []*entity.Item{{ID: 11}}, Total: 25, Page: 2, PageSize: 10}, nil)
+	handler := &ItemHandler{itemUsecase: mockUsecase}
+
+	req := httptest.NewRequest(http.MethodGet, "/items?brand=ROLEX&sort=purchase_price&order=desc&page=2&page_size=10", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.GetItems(echo.New().NewContext(req, rec)))
+
+	// 絞り込みと並び順を保ったまま、ページだけを差し替える
+	assert.Equal(t, `</items?brand=ROLEX&order=desc&page=1&page_size=10&sort=purchase_price>; rel="first", `+
+		`</items?brand=ROLEX&order=desc&page=1&page_size=10&sort=purchase_price>; rel="prev", `+
+		`</items?brand=ROLEX&order=desc&page=3&page_size=10&sort=purchase_price>; rel="next", `+
+		`</items?brand=ROLEX&order=desc&page=3&page_size=10&sort=purchase_price>; rel="last"`, rec.Header().Get(pagination.HeaderLink))
+}
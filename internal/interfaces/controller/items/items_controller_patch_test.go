@@ -2,7 +2,6 @@ package controller
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,67 +15,20 @@ import (
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/mocks"
 	"Aicon-assignment/internal/usecase"
 )
 
-// MockItemUsecase is a mock implementation of ItemUsecase for testing
-type MockItemUsecase struct {
-	mock.Mock
-}
-
-func (m *MockItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]*entity.Item), args.Error(1)
-}
-
-func (m *MockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
-	args := m.Called(ctx, input)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockItemUsecase) PatchItem(ctx context.Context, id int64, req *usecase.UpdateItemRequest) (*entity.Item, error) {
-	args := m.Called(ctx, id, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
-}
-
 func TestItemHandler_PatchItem(t *testing.T) {
 	e := echo.New()
+	e.Validator = validator.New()
 
 	tests := []struct {
 		name           string
 		itemID         string
 		requestBody    map[string]interface{}
-		setupMock      func(*MockItemUsecase)
+		setupMock      func(*mocks.ItemUsecase)
 		expectedStatus int
 		expectedError  string
 		expectedDetails []string
@@ -88,7 +40,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"name": "Updated Item Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				updatedItem, _ := entity.NewItem("Updated Item Name", "時計", "ROLEX", 1000000, "2023-01-01")
 				updatedItem.ID = 1
 				updatedItem.CreatedAt = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -114,14 +66,14 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"purchase_price": 2000000,
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				updatedItem, _ := entity.NewItem("時計1", "時計", "ROLEX", 2000000, "2023-01-01")
 				updatedItem.ID = 1
 				updatedItem.CreatedAt = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 				updatedItem.UpdatedAt = time.Now()
 
 				req := &usecase.UpdateItemRequest{
-					PurchasePrice: intPtr(2000000),
+					PurchasePrice: amountPtr(2000000),
 				}
 				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(updatedItem, nil)
 			},
@@ -139,7 +91,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"brand": "Updated Brand Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				updatedItem, _ := entity.NewItem("時計1", "時計", "Updated Brand Name", 1000000, "2023-01-01")
 				updatedItem.ID = 1
 				updatedItem.CreatedAt = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -167,7 +119,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				"brand":          "New Brand",
 				"purchase_price": 1500000,
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				updatedItem, _ := entity.NewItem("New Name", "時計", "New Brand", 1500000, "2023-01-01")
 				updatedItem.ID = 1
 				updatedItem.CreatedAt = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -176,7 +128,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				req := &usecase.UpdateItemRequest{
 					Name:          stringPtr("New Name"),
 					Brand:         stringPtr("New Brand"),
-					PurchasePrice: intPtr(1500000),
+					PurchasePrice: amountPtr(1500000),
 				}
 				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(updatedItem, nil)
 			},
@@ -196,7 +148,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"name": "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				req := &usecase.UpdateItemRequest{
 					Name: stringPtr("Updated Name"),
 				}
@@ -211,16 +163,12 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"purchase_price": -100,
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
-				req := &usecase.UpdateItemRequest{
-					PurchasePrice: intPtr(-100),
-				}
-				err := fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "purchase_price must be >= 0")
-				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return((*entity.Item)(nil), err)
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				// Rejected by the request validator before the usecase is called
 			},
 			expectedStatus:  http.StatusBadRequest,
 			expectedError:   "validation failed",
-			expectedDetails: []string{"purchase_price must be >= 0"},
+			expectedDetails: []string{"purchase_price must be 0 or greater"},
 		},
 		{
 			name:   "400 - immutable field (id)",
@@ -229,7 +177,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				"id":   999,
 				"name": "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				// Mock should not be called when immutable field is present
 			},
 			expectedStatus:  http.StatusBadRequest,
@@ -243,7 +191,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				"created_at": "2023-01-01T00:00:00Z",
 				"name":       "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				// Mock should not be called when immutable field is present
 			},
 			expectedStatus:  http.StatusBadRequest,
@@ -257,7 +205,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				"updated_at": "2023-01-01T00:00:00Z",
 				"name":       "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				// Mock should not be called when immutable field is present
 			},
 			expectedStatus:  http.StatusBadRequest,
@@ -272,20 +220,35 @@ func TestItemHandler_PatchItem(t *testing.T) {
 				"created_at": "2023-01-01T00:00:00Z",
 				"name":       "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				// Mock should not be called when immutable fields are present
 			},
 			expectedStatus:  http.StatusBadRequest,
 			expectedError:   "validation failed",
 			expectedDetails: []string{"id is immutable", "created_at is immutable"},
 		},
+		{
+			name:   "400 - unknown fields",
+			itemID: "1",
+			requestBody: map[string]interface{}{
+				"name":           "Updated Name",
+				"purchase_prise": 1000,
+				"category":       "バッグ",
+			},
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				// Rejected before the usecase is called
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "unknown fields in request body",
+			expectedDetails: []string{"category is not a known field", "purchase_prise is not a known field"},
+		},
 		{
 			name:   "400 - invalid item ID",
 			itemID: "invalid",
 			requestBody: map[string]interface{}{
 				"name": "Updated Name",
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
 				// Mock should not be called when ID is invalid
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -297,13 +260,8 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"name": string(make([]byte, 101)), // 101 characters
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
-				longName := string(make([]byte, 101))
-				req := &usecase.UpdateItemRequest{
-					Name: &longName,
-				}
-				err := fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "name must be 100 characters or less")
-				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return((*entity.Item)(nil), err)
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				// Rejected by the request validator before the usecase is called
 			},
 			expectedStatus:  http.StatusBadRequest,
 			expectedError:   "validation failed",
@@ -315,13 +273,8 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			requestBody: map[string]interface{}{
 				"brand": string(make([]byte, 101)), // 101 characters
 			},
-			setupMock: func(mockUsecase *MockItemUsecase) {
-				longBrand := string(make([]byte, 101))
-				req := &usecase.UpdateItemRequest{
-					Brand: &longBrand,
-				}
-				err := fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, "brand must be 100 characters or less")
-				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return((*entity.Item)(nil), err)
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				// Rejected by the request validator before the usecase is called
 			},
 			expectedStatus:  http.StatusBadRequest,
 			expectedError:   "validation failed",
@@ -331,7 +284,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockUsecase := new(MockItemUsecase)
+			mockUsecase := new(mocks.ItemUsecase)
 			tt.setupMock(mockUsecase)
 
 			handler := &ItemHandler{
@@ -349,7 +302,7 @@ func TestItemHandler_PatchItem(t *testing.T) {
 			c.SetParamNames("id")
 			c.SetParamValues(tt.itemID)
 
-			err = handler.PatchItem(c)
+			serve(c, handler.PatchItem)
 
 			// Echo handles errors automatically, so we check the response code
 			assert.Equal(t, tt.expectedStatus, rec.Code)
@@ -373,12 +326,213 @@ func TestItemHandler_PatchItem(t *testing.T) {
 	}
 }
 
+func TestItemHandler_PatchItem_Version(t *testing.T) {
+	e := echo.New()
+	e.Validator = validator.New()
+	version := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name           string
+		body           string
+		ifMatch        string
+		setupMock      func(*mocks.ItemUsecase)
+		expectedStatus int
+		expectedError  string
+		expectedETag   string
+	}{
+		{
+			name:    "Success - version from If-Match header",
+			body:    `{"name":"New Name"}`,
+			ifMatch: `"3"`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), IfMatch: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Name: "New Name", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `W/"4"`,
+		},
+		{
+			name:    "Success - weak If-Match header",
+			body:    `{"name":"New Name"}`,
+			ifMatch: `W/"3"`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), IfMatch: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Name: "New Name", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `W/"4"`,
+		},
+		{
+			name: "Success - version from body",
+			body: `{"name":"New Name","version":3}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), Version: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Name: "New Name", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `W/"4"`,
+		},
+		{
+			name:           "Error - invalid If-Match header",
+			body:           `{"name":"New Name"}`,
+			ifMatch:        `"abc"`,
+			setupMock:      func(mockUsecase *mocks.ItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid If-Match header",
+		},
+		{
+			name:           "Error - body version and If-Match disagree",
+			body:           `{"name":"New Name","version":2}`,
+			ifMatch:        `"3"`,
+			setupMock:      func(mockUsecase *mocks.ItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "version does not match If-Match header",
+		},
+		{
+			name:    "Success - body version and If-Match agree",
+			body:    `{"name":"New Name","version":3}`,
+			ifMatch: `"3"`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), Version: version(3), IfMatch: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Name: "New Name", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `W/"4"`,
+		},
+		{
+			name:    "Error - If-Match precondition failed",
+			body:    `{"name":"New Name"}`,
+			ifMatch: `"2"`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), IfMatch: version(2)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(nil, fmt.Errorf("%w: item 1 is at version 3", domainErrors.ErrPreconditionFailed))
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedError:  "precondition failed",
+		},
+		{
+			name: "Error - item modified since it was read",
+			body: `{"name":"New Name","version":2}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				req := &usecase.UpdateItemRequest{Name: stringPtr("New Name"), Version: version(2)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(nil, fmt.Errorf("%w: item 1 has been modified", domainErrors.ErrConflict))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "item has been modified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.ifMatch != "" {
+				req.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			serve(c, handler.PatchItem)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var errorResp ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Error)
+			}
+			assert.Equal(t, tt.expectedETag, rec.Header().Get(HeaderETag))
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int) *int {
-	return &i
+func amountPtr(minor int64) *entity.Amount {
+	amount := entity.MinorUnits(minor)
+	return &amount
 }
 
+
+func TestItemHandler_DeleteItem_IfMatch(t *testing.T) {
+	e := echo.New()
+	version := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		setupMock      func(*MockUndoDeleteUsecase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "Success - unconditional delete",
+			setupMock: func(mockUsecase *MockUndoDeleteUsecase) {
+				mockUsecase.On("DeleteItem", mock.Anything, int64(1), (*int64)(nil)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:    "Success - If-Match matches current version",
+			ifMatch: `"3"`,
+			setupMock: func(mockUsecase *MockUndoDeleteUsecase) {
+				mockUsecase.On("DeleteItem", mock.Anything, int64(1), version(3)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Error - invalid If-Match header",
+			ifMatch:        `"abc"`,
+			setupMock:      func(mockUsecase *MockUndoDeleteUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid If-Match header",
+		},
+		{
+			name:    "Error - If-Match precondition failed",
+			ifMatch: `W/"2"`,
+			setupMock: func(mockUsecase *MockUndoDeleteUsecase) {
+				mockUsecase.On("DeleteItem", mock.Anything, int64(1), version(2)).Return(nil, fmt.Errorf("%w: item 1 is at version 3", domainErrors.ErrPreconditionFailed))
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedError:  "precondition failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockUndoDeleteUsecase)
+			tt.setupMock(mockUsecase)
+			handler := &ItemHandler{undoUsecase: mockUsecase}
+
+			req := httptest.NewRequest(http.MethodDelete, "/items/1", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			serve(c, handler.DeleteItem)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var errorResp ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Error)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
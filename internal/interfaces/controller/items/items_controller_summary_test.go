@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetSummary_DateRange(t *testing.T) {
+	summary := &usecase.CategorySummary{Categories: map[string]int{"時計": 1}, Total: 1}
+
+	tests := []struct {
+		name           string
+		path           string
+		purchased      usecase.DateRange
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "正常系: 購入日で絞り込む",
+			path:           "/summary?from=2023-01-01&to=2023-12-31",
+			purchased:      usecase.DateRange{From: "2023-01-01", To: "2023-12-31"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 片側だけ指定",
+			path:           "/summary?from=2024-01-01",
+			purchased:      usecase.DateRange{From: "2024-01-01"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 不正な日付",
+			path:           "/summary?to=2023-13-01",
+			purchased:      usecase.DateRange{To: "2023-13-01"},
+			err:            domainErrors.ErrInvalidInput,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 通貨と組み合わせる",
+			path:           "/summary?from=2023-01-01&currency=USD",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			if tt.err != nil {
+				mockUsecase.On("GetCategorySummary", mock.Anything, tt.purchased).Return(nil, tt.err)
+			} else {
+				mockUsecase.On("GetCategorySummary", mock.Anything, tt.purchased).Return(summary, nil)
+			}
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			e := echo.New()
+			e.HTTPErrorHandler = HTTPErrorHandler
+			e.GET("/summary", handler.GetSummary)
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.JSONEq(t, `{"categories": {"時計": 1}, "total": 1,
+					"category_names": {"時計": "時計", "バッグ": "バッグ", "ジュエリー": "ジュエリー", "靴": "靴", "その他": "その他"}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestItemHandler_GetSummary_CSV(t *testing.T) {
+	summary := &usecase.CategorySummary{
+		Categories: map[string]int{"時計": 2, "バッグ": 0, "ジュエリー": 0, "靴": 0, "その他": 0},
+		Total:      2,
+		Stats: map[string]usecase.CategoryStats{
+			"時計": {Count: 2, Min: 800000, Max: 1500000, Avg: 1150000, Sum: 2300000},
+		},
+	}
+	mockUsecase := new(mocks.ItemUsecase)
+	mockUsecase.On("GetCategorySummary", mock.Anything, usecase.DateRange{}).Return(summary, nil)
+	handler := &ItemHandler{itemUsecase: mockUsecase}
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.GET("/summary", handler.GetSummary)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/summary?format=csv", nil)
+	req.Header.Set("Accept-Language", "en")
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+	assert.Equal(t, "category,category_name,count,min_purchase_price,max_purchase_price,avg_purchase_price,total_purchase_price,value,currency\n"+
+		"時計,Watches,2,800000,1500000,1150000,2300000,,\n"+
+		"バッグ,Bags,0,0,0,0,0,,\n"+
+		"ジュエリー,Jewelry,0,0,0,0,0,,\n"+
+		"靴,Shoes,0,0,0,0,0,,\n"+
+		"その他,Other,0,0,0,0,0,,\n", rec.Body.String())
+}
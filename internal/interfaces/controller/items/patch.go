@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/jsonpatch"
+	"Aicon-assignment/internal/usecase"
+)
+
+// isDocumentPatch reports whether a PATCH body is a JSON Merge Patch or JSON Patch document
+func isDocumentPatch(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	return mediaType == jsonpatch.MergePatchMediaType || mediaType == jsonpatch.PatchMediaType
+}
+
+// documentPatchRequest applies a JSON Merge Patch or JSON Patch body to the item's current JSON representation
+// and returns the update that turns the item into the patched document.
+// Unless the client sends a version (in the document or as If-Match), the update is conditional on the version
+// the patch was applied to, so a concurrent change fails with 409 instead of being overwritten.
+func (h *ItemHandler) documentPatchRequest(c echo.Context, id int64) (*usecase.UpdateItemRequest, error) {
+	body, err := readJSONBody(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The patch is parsed before the item is read so a malformed body is reported as such
+	var apply func(doc interface{}) (interface{}, error)
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType == jsonpatch.MergePatchMediaType {
+		var patch interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			return nil, invalidRequestFormat(err)
+		}
+		apply = func(doc interface{}) (interface{}, error) { return jsonpatch.MergePatch(doc, patch), nil }
+	} else {
+		ops, err := jsonpatch.Decode(body)
+		if err != nil {
+			return nil, patchError(err)
+		}
+		apply = func(doc interface{}) (interface{}, error) { return jsonpatch.Apply(doc, ops) }
+	}
+
+	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := itemDocument(item)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := apply(current)
+	if err != nil {
+		return nil, patchError(err)
+	}
+
+	req, err := updateFromDocuments(current, patched)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version == nil && c.Request().Header.Get(HeaderIfMatch) == "" {
+		req.Version = &item.Version
+	}
+	return req, nil
+}
+
+// patchError converts an error applying a patch document into the error response sent for it
+func patchError(err error) error {
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return NewHTTPError(http.StatusConflict, "patch test failed", err.Error())
+	case errors.Is(err, jsonpatch.ErrNotApplicable):
+		return NewHTTPError(http.StatusUnprocessableEntity, "patch cannot be applied", err.Error())
+	case errors.Is(err, jsonpatch.ErrInvalid):
+		return NewHTTPError(http.StatusBadRequest, "invalid patch document", err.Error())
+	}
+	return err
+}
+
+// itemDocument returns the item as a decoded JSON object, the document patches are applied to.
+// attributes is always present so JSON Patch operations can add to it.
+func itemDocument(item *entity.Item) (map[string]interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if _, ok := doc["attributes"]; !ok {
+		doc["attributes"] = map[string]interface{}{}
+	}
+	return doc, nil
+}
+
+// updateFromDocuments returns the update request for the members that differ between the current and patched item.
+// Removing name, brand or purchase_price is a validation error (they are required), changing a member that
+// PATCH cannot update is reported as immutable and adding a member the item does not have as unknown.
+func updateFromDocuments(current map[string]interface{}, patched interface{}) (*usecase.UpdateItemRequest, error) {
+	doc, ok := patched.(map[string]interface{})
+	if !ok {
+		return nil, NewHTTPError(http.StatusBadRequest, "validation failed", "item must be an object")
+	}
+
+	keys := make([]string, 0, len(current)+len(doc))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	for key := range doc {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	req := &usecase.UpdateItemRequest{}
+	var errs, unknown []string
+	for _, key := range keys {
+		oldValue, hadValue := current[key]
+		value, hasValue := doc[key]
+		if hadValue && hasValue && reflect.DeepEqual(oldValue, value) {
+			continue
+		}
+
+		switch key {
+		case "name", "brand":
+			s, err := patchedString(key, value, hasValue)
+			if err != "" {
+				errs = append(errs, err)
+				continue
+			}
+			if key == "name" {
+				req.Name = &s
+			} else {
+				req.Brand = &s
+			}
+		case "purchase_price":
+			if !hasValue {
+				errs = append(errs, key+" is required")
+				continue
+			}
+			// Like in request bodies, an integer is in minor units and a string a decimal amount
+			var price entity.Amount
+			if text, isString := value.(string); isString {
+				price = entity.DecimalAmount(text)
+			} else if n, ok := patchedInt(value); ok {
+				price = entity.MinorUnits(n)
+			} else {
+				errs = append(errs, key+" must be an integer or a decimal string")
+				continue
+			}
+			req.PurchasePrice = &price
+		case "version":
+			// Removing the version leaves the update conditional on the current one
+			if !hasValue {
+				continue
+			}
+			n, ok := patchedInt(value)
+			if !ok {
+				errs = append(errs, key+" must be an integer")
+				continue
+			}
+			req.Version = &n
+		case "attributes":
+			attrs, attrErrs := patchedAttributes(oldValue, value)
+			errs = append(errs, attrErrs...)
+			req.Attributes = attrs
+		default:
+			if hadValue {
+				errs = append(errs, key+" is immutable")
+			} else {
+				unknown = append(unknown, key)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, NewHTTPError(http.StatusBadRequest, "validation failed", errs...)
+	}
+	if len(unknown) > 0 {
+		return nil, unknownFieldsError(unknown)
+	}
+	return req, nil
+}
+
+// patchedString returns a required string member of the patched document, or the violation message
+func patchedString(key string, value interface{}, present bool) (string, string) {
+	if !present || value == nil {
+		return "", key + " is required"
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", key + " must be a string"
+	}
+	return s, ""
+}
+
+// patchedInt returns a JSON number that is an integer
+func patchedInt(value interface{}) (int64, bool) {
+	n, ok := value.(float64)
+	if !ok || n != math.Trunc(n) || n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, false
+	}
+	return int64(n), true
+}
+
+// patchedAttributes returns the attribute changes between the current and patched attributes;
+// an attribute that is no longer present is removed (a nil value)
+func patchedAttributes(oldValue, value interface{}) (map[string]*string, []string) {
+	oldAttrs, _ := oldValue.(map[string]interface{})
+	newAttrs, ok := value.(map[string]interface{})
+	if value != nil && !ok {
+		return nil, []string{"attributes must be an object"}
+	}
+
+	changes := make(map[string]*string)
+	for key := range oldAttrs {
+		if _, ok := newAttrs[key]; !ok {
+			changes[key] = nil
+		}
+	}
+
+	var errs []string
+	keys := make([]string, 0, len(newAttrs))
+	for key := range newAttrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if reflect.DeepEqual(oldAttrs[key], newAttrs[key]) {
+			continue
+		}
+		s, ok := newAttrs[key].(string)
+		if !ok {
+			errs = append(errs, "attributes."+strings.ToLower(key)+" must be a string")
+			continue
+		}
+		changes[key] = &s
+	}
+	return changes, errs
+}
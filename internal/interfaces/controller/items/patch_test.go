@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/jsonpatch"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_PatchItem_Documents(t *testing.T) {
+	e := echo.New()
+	e.Validator = validator.New()
+	version := func(v int64) *int64 { return &v }
+	current := func() *entity.Item {
+		return &entity.Item{
+			ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01",
+			Attributes: map[string]string{"color": "gold", "size": "40mm"}, Version: 3,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		contentType     string
+		body            string
+		ifMatch         string
+		setupMock       func(*mocks.ItemUsecase)
+		expectedStatus  int
+		expectedError   string
+		expectedDetails []string
+	}{
+		{
+			name:        "Success - merge patch updates members and removes attributes with null",
+			contentType: jsonpatch.MergePatchMediaType,
+			body:        `{"name":"時計2","attributes":{"color":null,"strap":"leather"}}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+				req := &usecase.UpdateItemRequest{
+					Name:       stringPtr("時計2"),
+					Attributes: map[string]*string{"color": nil, "strap": stringPtr("leather")},
+					Version:    version(3),
+				}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Name: "時計2", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Success - merge patch with If-Match is conditional on the header only",
+			contentType: jsonpatch.MergePatchMediaType + "; charset=utf-8",
+			body:        `{"purchase_price":1200000}`,
+			ifMatch:     `"3"`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+				req := &usecase.UpdateItemRequest{PurchasePrice: amountPtr(1200000), IfMatch: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Success - JSON Patch operations",
+			contentType: jsonpatch.PatchMediaType,
+			body: `[
+				{"op":"test","path":"/version","value":3},
+				{"op":"replace","path":"/brand","value":"OMEGA"},
+				{"op":"remove","path":"/attributes/size"},
+				{"op":"copy","from":"/attributes/color","path":"/attributes/dial"}
+			]`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+				req := &usecase.UpdateItemRequest{
+					Brand:      stringPtr("OMEGA"),
+					Attributes: map[string]*string{"size": nil, "dial": stringPtr("gold")},
+					Version:    version(3),
+				}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(&entity.Item{ID: 1, Brand: "OMEGA", Version: 4}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Error - JSON Patch test failed",
+			contentType: jsonpatch.PatchMediaType,
+			body:        `[{"op":"test","path":"/name","value":"時計9"},{"op":"replace","path":"/name","value":"時計2"}]`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+			},
+			expectedStatus:  http.StatusConflict,
+			expectedError:   "patch test failed",
+			expectedDetails: []string{"operation 0 (test /name): patch test failed"},
+		},
+		{
+			name:        "Error - JSON Patch path does not exist",
+			contentType: jsonpatch.PatchMediaType,
+			body:        `[{"op":"remove","path":"/attributes/strap"}]`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+			},
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedError:   "patch cannot be applied",
+			expectedDetails: []string{`operation 0 (remove /attributes/strap): patch cannot be applied: "strap" does not exist`},
+		},
+		{
+			name:            "Error - invalid JSON Patch document",
+			contentType:     jsonpatch.PatchMediaType,
+			body:            `[{"op":"rename","path":"/name"}]`,
+			setupMock:       func(mockUsecase *mocks.ItemUsecase) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid patch document",
+			expectedDetails: []string{`invalid patch: operation 0 has unknown op "rename"`},
+		},
+		{
+			name:            "Error - merge patch is not valid JSON",
+			contentType:     jsonpatch.MergePatchMediaType,
+			body:            `{"name":`,
+			setupMock:       func(mockUsecase *mocks.ItemUsecase) {},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "invalid request format",
+			expectedDetails: []string{"request body is not valid JSON (offset 8)"},
+		},
+		{
+			name:        "Error - merge patch clears a required member and changes an immutable one",
+			contentType: jsonpatch.MergePatchMediaType,
+			body:        `{"brand":null,"category":"バッグ","purchase_price":true}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"brand is required", "category is immutable", "purchase_price must be an integer or a decimal string"},
+		},
+		{
+			name:        "Error - patched values are validated",
+			contentType: jsonpatch.PatchMediaType,
+			body:        `[{"op":"replace","path":"/name","value":""}]`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "validation failed",
+			expectedDetails: []string{"name is required"},
+		},
+		{
+			name:        "Error - unknown member",
+			contentType: jsonpatch.PatchMediaType,
+			body:        `[{"op":"add","path":"/purchase_prise","value":1}]`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedError:   "unknown fields in request body",
+			expectedDetails: []string{"purchase_prise is not a known field"},
+		},
+		{
+			name:        "Error - item not found",
+			contentType: jsonpatch.MergePatchMediaType,
+			body:        `{"name":"時計2"}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "item not found",
+		},
+		{
+			name:        "Error - item modified after the patch was applied",
+			contentType: jsonpatch.MergePatchMediaType,
+			body:        `{"name":"時計2"}`,
+			setupMock: func(mockUsecase *mocks.ItemUsecase) {
+				mockUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(current(), nil)
+				req := &usecase.UpdateItemRequest{Name: stringPtr("時計2"), Version: version(3)}
+				mockUsecase.On("PatchItem", mock.Anything, int64(1), req).Return(nil, domainErrors.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "item has been modified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mocks.ItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := &ItemHandler{itemUsecase: mockUsecase}
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			serve(c, handler.PatchItem)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var errorResp ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Error)
+				assert.Equal(t, tt.expectedDetails, errorResp.Details)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+)
+
+const (
+	// HeaderPrefer carries the preferences of the client (RFC 7240), such as return=minimal
+	HeaderPrefer = "Prefer"
+	// HeaderPreferenceApplied tells the client which of its preferences were honored
+	HeaderPreferenceApplied = "Preference-Applied"
+
+	preferReturnMinimal = "return=minimal"
+)
+
+// prefersMinimal reports whether the request prefers return=minimal. Preferences are comma-separated
+// in one or more Prefer headers; names are case-insensitive, values may be quoted and parameters are ignored.
+func prefersMinimal(req *http.Request) bool {
+	for _, header := range req.Header.Values(HeaderPrefer) {
+		for _, preference := range strings.Split(header, ",") {
+			preference, _, _ = strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(preference, "=")
+			if strings.EqualFold(strings.TrimSpace(name), "return") && strings.Trim(strings.TrimSpace(value), `"`) == "minimal" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// respondWritten responds to a write with the item, or without a body when the client prefers return=minimal:
+// 201 for a created item, whose URL is in Location, and 204 for an updated one. The ETag carries the version either way.
+func respondWritten(c echo.Context, status int, item *entity.Item) error {
+	setETag(c, item.Version)
+	if status == http.StatusCreated {
+		c.Response().Header().Set(echo.HeaderLocation, "/items/"+strconv.FormatInt(item.ID, 10))
+	}
+	if !prefersMinimal(c.Request()) {
+		return serializer.Respond(c, status, item)
+	}
+
+	c.Response().Header().Set(HeaderPreferenceApplied, preferReturnMinimal)
+	if status == http.StatusOK {
+		status = http.StatusNoContent
+	}
+	return c.NoContent(status)
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/mocks"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestPrefersMinimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefer   []string
+		expected bool
+	}{
+		{name: "正常系: return=minimal", prefer: []string{"return=minimal"}, expected: true},
+		{name: "正常系: ほかの設定やパラメーターと並べる", prefer: []string{`respond-async, Return="minimal"; foo=bar`}, expected: true},
+		{name: "正常系: 複数のヘッダー", prefer: []string{"handling=lenient", "return=minimal"}, expected: true},
+		{name: "正常系: ヘッダーなし"},
+		{name: "正常系: return=representation", prefer: []string{"return=representation"}},
+		{name: "異常系: 値のない return", prefer: []string{"return"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/items", nil)
+			for _, v := range tt.prefer {
+				req.Header.Add(HeaderPrefer, v)
+			}
+			assert.Equal(t, tt.expected, prefersMinimal(req))
+		})
+	}
+}
+
+func TestItemHandler_PreferReturnMinimal(t *testing.T) {
+	e := echo.New()
+	e.Validator = validator.New()
+	item := &entity.Item{ID: 7, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, PurchaseDate: "2024-01-01", Version: 3}
+
+	t.Run("正常系: 登録は本文なしの201で Location を返す", func(t *testing.T) {
+		mockUsecase := mocks.NewItemUsecase(t)
+		mockUsecase.On("CreateItem", mock.Anything, mock.Anything).Return(item, nil)
+		handler := &ItemHandler{itemUsecase: mockUsecase}
+
+		body := `{"name":"時計1","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2024-01-01"}`
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderPrefer, "return=minimal")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.CreateItem(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, "/items/7", rec.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, `W/"3"`, rec.Header().Get(HeaderETag))
+		assert.Equal(t, "return=minimal", rec.Header().Get(HeaderPreferenceApplied))
+	})
+
+	t.Run("正常系: 更新は本文なしの204", func(t *testing.T) {
+		mockUsecase := mocks.NewItemUsecase(t)
+		mockUsecase.On("PatchItem", mock.Anything, int64(7), &usecase.UpdateItemRequest{Name: stringPtr("時計1")}).Return(item, nil)
+		handler := &ItemHandler{itemUsecase: mockUsecase}
+
+		req := httptest.NewRequest(http.MethodPatch, "/items/7", strings.NewReader(`{"name":"時計1"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderPrefer, "return=minimal")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("7")
+
+		require.NoError(t, handler.PatchItem(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, `W/"3"`, rec.Header().Get(HeaderETag))
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, "return=minimal", rec.Header().Get(HeaderPreferenceApplied))
+	})
+
+	t.Run("正常系: 指定しなければアイテムを返す", func(t *testing.T) {
+		mockUsecase := mocks.NewItemUsecase(t)
+		mockUsecase.On("CreateItem", mock.Anything, mock.Anything).Return(item, nil)
+		handler := &ItemHandler{itemUsecase: mockUsecase}
+
+		body := `{"name":"時計1","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2024-01-01"}`
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderPrefer, "return=representation")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.CreateItem(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"時計1"`)
+		assert.Equal(t, "/items/7", rec.Header().Get(echo.HeaderLocation))
+		assert.Empty(t, rec.Header().Get(HeaderPreferenceApplied))
+	})
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockUndoDeleteUsecase struct {
+	mock.Mock
+}
+
+func (m *MockUndoDeleteUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) (*usecase.UndoToken, error) {
+	args := m.Called(ctx, id, ifMatch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UndoToken), args.Error(1)
+}
+
+func (m *MockUndoDeleteUsecase) UndoDelete(ctx context.Context, token string) (*entity.Item, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func newUndoTestServer(undoUsecase usecase.UndoDeleteUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	handler := &ItemHandler{undoUsecase: undoUsecase}
+	e.DELETE("/items/:id", handler.DeleteItem)
+	e.POST("/items/undo-delete", handler.UndoDelete)
+	return e
+}
+
+func TestItemHandler_DeleteItem_UndoToken(t *testing.T) {
+	t.Run("正常系: 取り消しのトークンと期限をヘッダーで返す", func(t *testing.T) {
+		mockUsecase := new(MockUndoDeleteUsecase)
+		mockUsecase.On("DeleteItem", mock.Anything, int64(1), (*int64)(nil)).Return(&usecase.UndoToken{
+			Token:     "token",
+			ExpiresAt: time.Date(2024, 3, 1, 9, 0, 30, 0, time.UTC),
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		newUndoTestServer(mockUsecase).ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/items/1", nil))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "token", rec.Header().Get(HeaderUndoToken))
+		assert.Equal(t, "2024-03-01T09:00:30Z", rec.Header().Get(HeaderUndoExpiresAt))
+	})
+
+	t.Run("正常系: 取り消しが無効ならヘッダーを付けない", func(t *testing.T) {
+		mockUsecase := new(MockUndoDeleteUsecase)
+		mockUsecase.On("DeleteItem", mock.Anything, int64(1), (*int64)(nil)).Return(nil, nil)
+
+		rec := httptest.NewRecorder()
+		newUndoTestServer(mockUsecase).ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/items/1", nil))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderUndoToken))
+	})
+}
+
+func TestItemHandler_UndoDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockUndoDeleteUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 戻したアイテムを返す",
+			body: `{"undo_token":"token"}`,
+			setupMock: func(m *MockUndoDeleteUsecase) {
+				m.On("UndoDelete", mock.Anything, "token").Return(&entity.Item{ID: 1, Name: "ロレックス", Version: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 期限切れのトークン",
+			body: `{"undo_token":"token"}`,
+			setupMock: func(m *MockUndoDeleteUsecase) {
+				m.On("UndoDelete", mock.Anything, "token").Return(nil, domainErrors.ErrUndoTokenNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "異常系: 不正なJSON",
+			body:           `{`,
+			setupMock:      func(m *MockUndoDeleteUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockUndoDeleteUsecase)
+			tt.setupMock(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/undo-delete", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			newUndoTestServer(mockUsecase).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
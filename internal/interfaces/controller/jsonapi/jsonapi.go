@@ -0,0 +1,179 @@
+// Package jsonapi builds JSON:API (https://jsonapi.org) documents from the item entities,
+// for clients that request application/vnd.api+json.
+package jsonapi
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+)
+
+// MediaType is the JSON:API media type used for both Accept and Content-Type
+const MediaType = "application/vnd.api+json"
+
+// ItemType is the resource type of items
+const ItemType = "items"
+
+// Document is a top-level JSON:API document; exactly one of Data, Errors or Meta-only is set
+type Document struct {
+	Data    interface{}            `json:"data,omitempty"`
+	Errors  []Error                `json:"errors,omitempty"`
+	Meta    map[string]interface{} `json:"meta,omitempty"`
+	Links   *Links                 `json:"links,omitempty"`
+	JSONAPI Version                `json:"jsonapi"`
+}
+
+type Version struct {
+	Version string `json:"version"`
+}
+
+// Resource is a resource object
+type Resource struct {
+	Type       string      `json:"type"`
+	ID         string      `json:"id"`
+	Attributes interface{} `json:"attributes"`
+	Links      *Links      `json:"links,omitempty"`
+}
+
+// Links holds document or resource links; empty links are omitted
+type Links struct {
+	Self  string `json:"self,omitempty"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// Error is an error object
+type Error struct {
+	Status string `json:"status"`
+	Code   string `json:"code,omitempty"`
+	Title  string `json:"title"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// itemAttributes are the item fields other than the ID
+type itemAttributes struct {
+	Name            string            `json:"name"`
+	Category        string            `json:"category"`
+	Brand           string            `json:"brand"`
+	PurchasePrice   int               `json:"purchase_price"`
+	Currency        string            `json:"currency,omitempty"`
+	PurchaseDate    string            `json:"purchase_date"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	OwnerID         string            `json:"owner_id,omitempty"`
+	MaintenanceCost int               `json:"maintenance_cost,omitempty"`
+	ImageIDs        []int64           `json:"image_ids,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	Version         int64             `json:"version"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+func newDocument() Document {
+	return Document{JSONAPI: Version{Version: "1.1"}}
+}
+
+// ItemResource converts an item into a resource object
+func ItemResource(item *entity.Item) Resource {
+	id := strconv.FormatInt(item.ID, 10)
+	return Resource{
+		Type: ItemType,
+		ID:   id,
+		Attributes: itemAttributes{
+			Name:            item.Name,
+			Category:        item.Category,
+			Brand:           item.Brand,
+			PurchasePrice:   item.PurchasePrice,
+			Currency:        item.Currency,
+			PurchaseDate:    item.PurchaseDate,
+			Attributes:      item.Attributes,
+			OwnerID:         item.OwnerID,
+			MaintenanceCost: item.MaintenanceCost,
+			ImageIDs:        item.ImageIDs,
+			Tags:            item.Tags,
+			Version:         item.Version,
+			CreatedAt:       item.CreatedAt,
+			UpdatedAt:       item.UpdatedAt,
+		},
+		Links: &Links{Self: "/items/" + id},
+	}
+}
+
+// ItemDocument is the document for a single item
+func ItemDocument(item *entity.Item) Document {
+	doc := newDocument()
+	doc.Data = ItemResource(item)
+	return doc
+}
+
+// Page describes the page of a collection and the request it was returned for
+type Page struct {
+	URL      *url.URL // request URL; its page and page_size parameters are replaced in the links
+	Page     int
+	PageSize int // 0 means the collection is not paged
+	Total    int
+}
+
+// ItemListDocument is the document for a collection of items with pagination links
+func ItemListDocument(items []*entity.Item, page Page) Document {
+	doc := newDocument()
+
+	data := make([]Resource, 0, len(items))
+	for _, item := range items {
+		data = append(data, ItemResource(item))
+	}
+	doc.Data = data
+	doc.Meta = map[string]interface{}{"total": page.Total}
+	doc.Links = paginationLinks(page)
+
+	return doc
+}
+
+func paginationLinks(page Page) *Links {
+	if page.URL == nil {
+		return nil
+	}
+	links := &Links{Self: page.URL.RequestURI()}
+	if page.PageSize <= 0 {
+		return links
+	}
+
+	pages := pagination.NewLinks(page.URL, page.Page, page.PageSize, page.Total)
+	links.First, links.Prev, links.Next, links.Last = pages.First, pages.Prev, pages.Next, pages.Last
+	return links
+}
+
+// MetaDocument is a document without primary data, such as the category summary
+func MetaDocument(meta map[string]interface{}) Document {
+	doc := newDocument()
+	doc.Meta = meta
+	return doc
+}
+
+// ErrorDocument converts an error response into error objects, one per detail.
+// Each object carries the code of its detail from detailCodes, or code if there are no details.
+func ErrorDocument(status int, title, code string, details, detailCodes []string) Document {
+	doc := newDocument()
+	statusCode := strconv.Itoa(status)
+	if title == "" {
+		title = http.StatusText(status)
+	}
+
+	if len(details) == 0 {
+		doc.Errors = []Error{{Status: statusCode, Code: code, Title: title}}
+		return doc
+	}
+	for i, detail := range details {
+		e := Error{Status: statusCode, Title: title, Detail: detail}
+		if i < len(detailCodes) {
+			e.Code = detailCodes[i]
+		}
+		doc.Errors = append(doc.Errors, e)
+	}
+	return doc
+}
@@ -0,0 +1,114 @@
+package jsonapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestItemDocument(t *testing.T) {
+	item := &entity.Item{ID: 7, Name: "時計1", Category: "時計", Version: 2}
+
+	body, err := json.Marshal(ItemDocument(item))
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &doc))
+	data := doc["data"].(map[string]interface{})
+	assert.Equal(t, "items", data["type"])
+	assert.Equal(t, "7", data["id"])
+	attributes := data["attributes"].(map[string]interface{})
+	assert.Equal(t, "時計1", attributes["name"])
+	assert.NotContains(t, attributes, "id")
+	assert.Equal(t, map[string]interface{}{"version": "1.1"}, doc["jsonapi"])
+}
+
+func TestItemListDocument_Links(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawURL   string
+		page     int
+		pageSize int
+		total    int
+		expected Links
+	}{
+		{
+			name:     "正常系: 中間のページ",
+			rawURL:   "/items?category=%E6%99%82%E8%A8%88&page=2&page_size=10",
+			page:     2,
+			pageSize: 10,
+			total:    35,
+			expected: Links{
+				Self:  "/items?category=%E6%99%82%E8%A8%88&page=2&page_size=10",
+				First: "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10",
+				Prev:  "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10",
+				Next:  "/items?category=%E6%99%82%E8%A8%88&page=3&page_size=10",
+				Last:  "/items?category=%E6%99%82%E8%A8%88&page=4&page_size=10",
+			},
+		},
+		{
+			name:     "正常系: 最後のページにはnextがない",
+			rawURL:   "/items?page=4&page_size=10",
+			page:     4,
+			pageSize: 10,
+			total:    35,
+			expected: Links{
+				Self:  "/items?page=4&page_size=10",
+				First: "/items?page=1&page_size=10",
+				Prev:  "/items?page=3&page_size=10",
+				Last:  "/items?page=4&page_size=10",
+			},
+		},
+		{
+			name:     "正常系: 0件でも最初と最後のページを返す",
+			rawURL:   "/items?page_size=10",
+			page:     1,
+			pageSize: 10,
+			total:    0,
+			expected: Links{
+				Self:  "/items?page_size=10",
+				First: "/items?page=1&page_size=10",
+				Last:  "/items?page=1&page_size=10",
+			},
+		},
+		{
+			name:     "正常系: ページングなしはselfのみ",
+			rawURL:   "/items",
+			page:     1,
+			pageSize: 0,
+			total:    3,
+			expected: Links{Self: "/items"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.rawURL)
+			require.NoError(t, err)
+
+			doc := ItemListDocument(nil, Page{URL: u, Page: tt.page, PageSize: tt.pageSize, Total: tt.total})
+
+			assert.Equal(t, tt.expected, *doc.Links)
+			assert.Equal(t, []Resource{}, doc.Data)
+			assert.Equal(t, tt.total, doc.Meta["total"])
+		})
+	}
+}
+
+func TestErrorDocument(t *testing.T) {
+	doc := ErrorDocument(400, "validation failed", "VALIDATION_FAILED",
+		[]string{"name is required", "brand is required"},
+		[]string{"VALIDATION_NAME_REQUIRED", "VALIDATION_BRAND_REQUIRED"})
+	assert.Equal(t, []Error{
+		{Status: "400", Code: "VALIDATION_NAME_REQUIRED", Title: "validation failed", Detail: "name is required"},
+		{Status: "400", Code: "VALIDATION_BRAND_REQUIRED", Title: "validation failed", Detail: "brand is required"},
+	}, doc.Errors)
+
+	doc = ErrorDocument(404, "", "NOT_FOUND", nil, nil)
+	assert.Equal(t, []Error{{Status: "404", Code: "NOT_FOUND", Title: "Not Found"}}, doc.Errors)
+}
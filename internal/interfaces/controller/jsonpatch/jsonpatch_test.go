@@ -0,0 +1,122 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	// RFC 7386 Appendix A
+	tests := []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{name: "正常系: 値の置き換え", target: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "正常系: メンバーの追加", target: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "正常系: nullで削除", target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "正常系: 配列は置き換え", target: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "正常系: 入れ子のマージ", target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		{name: "正常系: 入れ子のnullは残らない", target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+		{name: "正常系: オブジェクト以外のパッチは全体を置き換え", target: `{"a":"foo"}`, patch: `["c"]`, expected: `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := decode(t, tt.target)
+			merged := MergePatch(target, decode(t, tt.patch))
+
+			actual, err := json.Marshal(merged)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+			// 元の文書は変更しない
+			assert.Equal(t, decode(t, tt.target), target)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	// RFC 6902 Appendix A
+	tests := []struct {
+		name        string
+		doc         string
+		patch       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "正常系: メンバーの追加", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz","value":"qux"}]`, expected: `{"baz":"qux","foo":"bar"}`},
+		{name: "正常系: 配列への挿入", doc: `{"foo":["bar","baz"]}`, patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`, expected: `{"foo":["bar","qux","baz"]}`},
+		{name: "正常系: 配列の末尾に追加", doc: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/-","value":["abc"]}]`, expected: `{"foo":["bar",["abc"]]}`},
+		{name: "正常系: メンバーの削除", doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, expected: `{"foo":"bar"}`},
+		{name: "正常系: 配列要素の削除", doc: `{"foo":["bar","qux","baz"]}`, patch: `[{"op":"remove","path":"/foo/1"}]`, expected: `{"foo":["bar","baz"]}`},
+		{name: "正常系: 値の置き換え", doc: `{"baz":"qux","foo":"bar"}`, patch: `[{"op":"replace","path":"/baz","value":"boo"}]`, expected: `{"baz":"boo","foo":"bar"}`},
+		{
+			name:     "正常系: 値の移動",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{name: "正常系: 値のコピー", doc: `{"a":{"b":1}}`, patch: `[{"op":"copy","from":"/a","path":"/c"}]`, expected: `{"a":{"b":1},"c":{"b":1}}`},
+		{name: "正常系: testが一致", doc: `{"baz":"qux","foo":["a",2,"c"]}`, patch: `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, expected: `{"baz":"qux","foo":["a",2,"c"]}`},
+		{name: "正常系: エスケープしたキー", doc: `{"a/b":1,"m~n":2}`, patch: `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, expected: `{"a/b":3}`},
+		{name: "正常系: nullの値を追加", doc: `{}`, patch: `[{"op":"add","path":"/a","value":null}]`, expected: `{"a":null}`},
+		{name: "異常系: testが不一致", doc: `{"baz":"qux"}`, patch: `[{"op":"test","path":"/baz","value":"bar"}]`, expectedErr: ErrTestFailed},
+		{name: "異常系: 親が存在しない", doc: `{"foo":"bar"}`, patch: `[{"op":"add","path":"/baz/bat","value":"qux"}]`, expectedErr: ErrNotApplicable},
+		{name: "異常系: 存在しない値の削除", doc: `{"foo":"bar"}`, patch: `[{"op":"remove","path":"/baz"}]`, expectedErr: ErrNotApplicable},
+		{name: "異常系: 配列の範囲外", doc: `{"foo":["bar"]}`, patch: `[{"op":"add","path":"/foo/2","value":"qux"}]`, expectedErr: ErrNotApplicable},
+		{name: "異常系: 配列の添字が不正", doc: `{"foo":["bar"]}`, patch: `[{"op":"replace","path":"/foo/01","value":"qux"}]`, expectedErr: ErrInvalid},
+		{name: "異常系: 自身の中への移動", doc: `{"a":{"b":1}}`, patch: `[{"op":"move","from":"/a","path":"/a/c"}]`, expectedErr: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Decode([]byte(tt.patch))
+			require.NoError(t, err)
+			doc := decode(t, tt.doc)
+
+			patched, err := Apply(doc, ops)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			actual, err := json.Marshal(patched)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(actual))
+			// 元の文書は変更しない
+			assert.Equal(t, decode(t, tt.doc), doc)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "異常系: 配列でない", patch: `{"op":"add","path":"/a","value":1}`},
+		{name: "異常系: 不明なop", patch: `[{"op":"merge","path":"/a","value":1}]`},
+		{name: "異常系: valueがない", patch: `[{"op":"replace","path":"/a"}]`},
+		{name: "異常系: pathが/で始まらない", patch: `[{"op":"remove","path":"a"}]`},
+		{name: "異常系: 不正なエスケープ", patch: `[{"op":"remove","path":"/a~2"}]`},
+		{name: "異常系: fromが不正", patch: `[{"op":"move","from":"a","path":"/b"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.patch))
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
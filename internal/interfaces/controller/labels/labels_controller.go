@@ -0,0 +1,73 @@
+package labels
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LabelHandler struct {
+	labelUsecase usecase.LabelUsecase
+}
+
+func NewLabelHandler(labelUsecase usecase.LabelUsecase) *LabelHandler {
+	return &LabelHandler{
+		labelUsecase: labelUsecase,
+	}
+}
+
+// PrintBatch renders printable label sheets for the requested items as a PDF
+func (h *LabelHandler) PrintBatch(c echo.Context) error {
+	var input usecase.LabelBatchInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	pdf, err := h.labelUsecase.RenderLabelBatch(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="labels.pdf"`)
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetItemLabel renders the label of one item, a PNG image by default or a PDF page with ?format=pdf
+func (h *LabelHandler) GetItemLabel(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = usecase.LabelFormatPNG
+	}
+
+	out, err := h.labelUsecase.RenderItemLabel(c.Request().Context(), usecase.ItemLabelInput{
+		ItemID:   id,
+		Format:   format,
+		Template: c.QueryParam("template"),
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="item-%d-label.%s"`, id, format))
+	return c.Blob(http.StatusOK, usecase.LabelContentTypes[format], out)
+}
+
+// Lookup resolves a scanned label code to the item it was printed for
+func (h *LabelHandler) Lookup(c echo.Context) error {
+	lookup, err := h.labelUsecase.LookupCode(c.Request().Context(), c.QueryParam("code"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, lookup)
+}
@@ -0,0 +1,81 @@
+package loans
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LoanHandler struct {
+	loanUsecase usecase.LoanUsecase
+}
+
+func NewLoanHandler(loanUsecase usecase.LoanUsecase) *LoanHandler {
+	return &LoanHandler{
+		loanUsecase: loanUsecase,
+	}
+}
+
+// LendItem records that an item has been lent to a borrower until a due date
+func (h *LoanHandler) LendItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.LoanInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	loan, err := h.loanUsecase.LendItem(c.Request().Context(), itemController.UserID(c), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, loan)
+}
+
+// GetItemLoans returns the lending history of an item
+func (h *LoanHandler) GetItemLoans(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	loans, err := h.loanUsecase.ListItemLoans(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loans)
+}
+
+// ReturnLoan records that a lent item has come back
+func (h *LoanHandler) ReturnLoan(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid loan ID")
+	}
+
+	loan, err := h.loanUsecase.ReturnLoan(c.Request().Context(), itemController.UserID(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loan)
+}
+
+// GetOverdueLoans returns the loans past their due date that have not been returned
+func (h *LoanHandler) GetOverdueLoans(c echo.Context) error {
+	loans, err := h.loanUsecase.ListOverdueLoans(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loans)
+}
@@ -0,0 +1,87 @@
+// Package locale selects the language of display names in a response from the Accept-Language header.
+//
+// Only display names are localized: category codes and every other field keep their canonical values,
+// so clients can rely on them whatever language they ask for.
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+const (
+	HeaderAcceptLanguage  = "Accept-Language"
+	HeaderContentLanguage = "Content-Language"
+)
+
+// Default is the language of responses to requests without an Accept-Language header naming a supported language
+func Default() string {
+	return entity.CategoryLanguages[0]
+}
+
+// Negotiate returns the supported language the Accept-Language header prefers most, or "" if it names none of them.
+// A tag matches a supported language by its primary subtag (en-US matches en); * matches the first supported language.
+func Negotiate(header string, supported []string) string {
+	type preference struct {
+		lang string
+		q    float64
+	}
+	var prefs []preference
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		if lang := match(tag, supported); lang != "" {
+			prefs = append(prefs, preference{lang: lang, q: q})
+		}
+	}
+	// The most preferred first; equally preferred languages in the order of the header
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	if len(prefs) == 0 {
+		return ""
+	}
+	return prefs[0].lang
+}
+
+func match(tag string, supported []string) string {
+	if tag == "*" && len(supported) > 0 {
+		return supported[0]
+	}
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, lang := range supported {
+		if lang == tag || lang == primary {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Language returns the language of the display names in the response to c, negotiated from its Accept-Language header.
+// It sets Content-Language to that language and adds Accept-Language to Vary, so caches keep the languages apart.
+func Language(c echo.Context) string {
+	lang := Negotiate(c.Request().Header.Get(HeaderAcceptLanguage), entity.CategoryLanguages)
+	if lang == "" {
+		lang = Default()
+	}
+	c.Response().Header().Set(HeaderContentLanguage, lang)
+	c.Response().Header().Add(echo.HeaderVary, HeaderAcceptLanguage)
+	return lang
+}
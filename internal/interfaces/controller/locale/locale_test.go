@@ -0,0 +1,61 @@
+package locale
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"ja", "en"}
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "正常系: 言語を1つ指定", header: "en", expected: "en"},
+		{name: "正常系: 地域付きのタグは主タグで一致する", header: "en-US", expected: "en"},
+		{name: "正常系: q値の高い言語", header: "en;q=0.5, ja;q=0.9", expected: "ja"},
+		{name: "正常系: 同じq値はヘッダーの順", header: "en, ja", expected: "en"},
+		{name: "正常系: 対応していない言語は飛ばす", header: "fr-FR, fr;q=0.9, en;q=0.8", expected: "en"},
+		{name: "正常系: * は既定の言語", header: "fr, *;q=0.5", expected: "ja"},
+		{name: "正常系: q=0 の言語は選ばない", header: "en;q=0, ja;q=0.1", expected: "ja"},
+		{name: "正常系: 大文字小文字を区別しない", header: "EN-gb", expected: "en"},
+		{name: "異常系: 対応する言語がない", header: "fr, de", expected: ""},
+		{name: "異常系: ヘッダーなし", header: "", expected: ""},
+		{name: "異常系: 不正なq値は無視する", header: "en;q=high", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.header, supported))
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	t.Run("正常系: 交渉した言語を Content-Language に返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(HeaderAcceptLanguage, "en-US,en;q=0.9")
+		rec := httptest.NewRecorder()
+
+		lang := Language(echo.New().NewContext(req, rec))
+
+		assert.Equal(t, "en", lang)
+		assert.Equal(t, "en", rec.Header().Get(HeaderContentLanguage))
+		assert.Equal(t, HeaderAcceptLanguage, rec.Header().Get(echo.HeaderVary))
+	})
+
+	t.Run("正常系: ヘッダーがなければ既定の言語", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		lang := Language(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+
+		assert.Equal(t, "ja", lang)
+		assert.Equal(t, "ja", rec.Header().Get(HeaderContentLanguage))
+	})
+}
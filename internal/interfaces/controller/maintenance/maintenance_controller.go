@@ -0,0 +1,142 @@
+package maintenance
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ServiceRecordHandler struct {
+	serviceUsecase usecase.ServiceRecordUsecase
+}
+
+func NewServiceRecordHandler(serviceUsecase usecase.ServiceRecordUsecase) *ServiceRecordHandler {
+	return &ServiceRecordHandler{
+		serviceUsecase: serviceUsecase,
+	}
+}
+
+// CreateServiceRecord records a service of an item
+func (h *ServiceRecordHandler) CreateServiceRecord(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.ServiceRecordInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	record, err := h.serviceUsecase.CreateServiceRecord(c.Request().Context(), itemController.UserID(c), itemID, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, record)
+}
+
+// GetServiceRecords returns the service history of an item, most recent first
+func (h *ServiceRecordHandler) GetServiceRecords(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	records, err := h.serviceUsecase.ListServiceRecords(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, records)
+}
+
+func (h *ServiceRecordHandler) GetServiceRecord(c echo.Context) error {
+	itemID, id, err := recordID(c)
+	if err != nil {
+		return err
+	}
+
+	record, err := h.serviceUsecase.GetServiceRecord(c.Request().Context(), itemID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// UpdateServiceRecord replaces the contents of a service record
+func (h *ServiceRecordHandler) UpdateServiceRecord(c echo.Context) error {
+	itemID, id, err := recordID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.ServiceRecordInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	record, err := h.serviceUsecase.UpdateServiceRecord(c.Request().Context(), itemController.UserID(c), itemID, id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+func (h *ServiceRecordHandler) DeleteServiceRecord(c echo.Context) error {
+	itemID, id, err := recordID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.serviceUsecase.DeleteServiceRecord(c.Request().Context(), itemController.UserID(c), itemID, id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetUnservicedItems returns the items not serviced within the last ?years= years
+func (h *ServiceRecordHandler) GetUnservicedItems(c echo.Context) error {
+	var years int
+	if v := c.QueryParam("years"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "years must be an integer")
+		}
+		years = n
+	}
+
+	items, err := h.serviceUsecase.ListUnservicedItems(c.Request().Context(), years)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
+
+func recordID(c echo.Context) (int64, int64, error) {
+	itemID, err := itemID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseInt(c.Param("service_id"), 10, 64)
+	if err != nil {
+		return 0, 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid service record ID")
+	}
+	return itemID, id, nil
+}
@@ -0,0 +1,88 @@
+package media
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MediaHandler struct {
+	mediaURLUsecase usecase.MediaURLUsecase
+}
+
+func NewMediaHandler(mediaURLUsecase usecase.MediaURLUsecase) *MediaHandler {
+	return &MediaHandler{
+		mediaURLUsecase: mediaURLUsecase,
+	}
+}
+
+// CreateImageURL issues a signed, expiring URL to the file of an image
+func (h *MediaHandler) CreateImageURL(c echo.Context) error {
+	itemID, id, err := fileID(c, "image_id", "invalid image ID")
+	if err != nil {
+		return err
+	}
+
+	url, err := h.mediaURLUsecase.CreateImageURL(c.Request().Context(), itemID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, url)
+}
+
+// CreateDocumentURL issues a signed, expiring URL to the file of a document
+func (h *MediaHandler) CreateDocumentURL(c echo.Context) error {
+	itemID, id, err := fileID(c, "document_id", "invalid document ID")
+	if err != nil {
+		return err
+	}
+
+	url, err := h.mediaURLUsecase.CreateDocumentURL(c.Request().Context(), itemID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, url)
+}
+
+// GetMedia is public: the token is the only credential. Images are shown inline, documents are downloaded
+// under the name they were uploaded with.
+func (h *MediaHandler) GetMedia(c echo.Context) error {
+	file, content, err := h.mediaURLUsecase.OpenMedia(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(file.Size, 10))
+	if file.Kind == usecase.MediaDocument {
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	}
+	// The file may be cached by the browser, but not for longer than the URL is valid
+	maxAge := int(time.Until(file.ExpiresAt).Seconds())
+	header.Set("Cache-Control", "private, max-age="+strconv.Itoa(max(maxAge, 0)))
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "no-referrer")
+	itemController.SetReprDigest(c, file.SHA256)
+	return c.Stream(http.StatusOK, file.ContentType, content)
+}
+
+func fileID(c echo.Context, param, message string) (int64, int64, error) {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		return 0, 0, itemController.NewHTTPError(http.StatusBadRequest, message)
+	}
+	return itemID, id, nil
+}
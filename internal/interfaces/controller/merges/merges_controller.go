@@ -0,0 +1,36 @@
+package merges
+
+import (
+	"net/http"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MergeHandler struct {
+	mergeUsecase usecase.MergeUsecase
+}
+
+func NewMergeHandler(mergeUsecase usecase.MergeUsecase) *MergeHandler {
+	return &MergeHandler{
+		mergeUsecase: mergeUsecase,
+	}
+}
+
+// MergeItems merges duplicate items into a primary item owned by the acting user.
+// With "dry_run": true nothing is changed and the response previews the merge.
+func (h *MergeHandler) MergeItems(c echo.Context) error {
+	var input usecase.MergeItemsInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	result, err := h.mergeUsecase.MergeItems(c.Request().Context(), itemController.UserID(c), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,88 @@
+package merges
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockMergeUsecase struct {
+	mock.Mock
+}
+
+func (m *MockMergeUsecase) MergeItems(ctx context.Context, actor string, input usecase.MergeItemsInput) (*usecase.MergeResult, error) {
+	args := m.Called(ctx, actor, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MergeResult), args.Error(1)
+}
+
+func newTestServer(mergeUsecase usecase.MergeUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.POST("/items/merge", NewMergeHandler(mergeUsecase).MergeItems)
+	return e
+}
+
+func post(e *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items/merge", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(itemController.HeaderUserID, "alice")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMergeHandler_MergeItems(t *testing.T) {
+	t.Run("正常系: dry_run で統合の結果を確認する", func(t *testing.T) {
+		mockUsecase := new(MockMergeUsecase)
+		mockUsecase.On("MergeItems", mock.Anything, "alice", usecase.MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{2, 3}, DryRun: true}).
+			Return(&usecase.MergeResult{DryRun: true, Item: &entity.Item{ID: 1, ImageIDs: []int64{3, 5}}, DeletedItemIDs: []int64{2, 3}, Moved: &usecase.ItemAttachments{ImageIDs: []int64{5}}}, nil)
+
+		rec := post(newTestServer(mockUsecase), `{"primary_id": 1, "duplicate_ids": [2, 3], "dry_run": true}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var result usecase.MergeResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.True(t, result.DryRun)
+		assert.Equal(t, []int64{2, 3}, result.DeletedItemIDs)
+		assert.Equal(t, []int64{5}, result.Moved.ImageIDs)
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 不正なボディ", body: `{"primary_id": "x"}`, expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 重複の指定がない", body: `{"primary_id": 1}`, err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 所有者以外", body: `{"primary_id": 1, "duplicate_ids": [2]}`, err: domainErrors.ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "異常系: 貸出中の重複", body: `{"primary_id": 1, "duplicate_ids": [2]}`, err: domainErrors.ErrConflict, expectedStatus: http.StatusConflict},
+		{name: "異常系: アイテムが見つからない", body: `{"primary_id": 1, "duplicate_ids": [9]}`, err: domainErrors.ErrItemNotFound, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockMergeUsecase)
+			mockUsecase.On("MergeItems", mock.Anything, "alice", mock.Anything).Return(nil, tt.err)
+
+			rec := post(newTestServer(mockUsecase), tt.body)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package notifications
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationRuleHandler struct {
+	ruleUsecase usecase.NotificationRuleUsecase
+}
+
+func NewNotificationRuleHandler(ruleUsecase usecase.NotificationRuleUsecase) *NotificationRuleHandler {
+	return &NotificationRuleHandler{
+		ruleUsecase: ruleUsecase,
+	}
+}
+
+func (h *NotificationRuleHandler) CreateRule(c echo.Context) error {
+	var input usecase.NotificationRuleInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	rule, err := h.ruleUsecase.CreateRule(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, rule)
+}
+
+// GetRules returns every notification rule
+func (h *NotificationRuleHandler) GetRules(c echo.Context) error {
+	rules, err := h.ruleUsecase.ListRules(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *NotificationRuleHandler) GetRule(c echo.Context) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.ruleUsecase.GetRule(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *NotificationRuleHandler) DeleteRule(c echo.Context) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.ruleUsecase.DeleteRule(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func ruleID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid notification rule ID")
+	}
+	return id, nil
+}
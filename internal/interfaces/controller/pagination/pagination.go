@@ -0,0 +1,73 @@
+// Package pagination builds the links between the pages of a paginated list, for the RFC 8288 Link header
+// and the links of JSON:API documents.
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderLink carries the links to the first, previous, next and last pages of a list (RFC 8288)
+const HeaderLink = "Link"
+
+// Links are the request URIs of the pages of a list; Prev and Next are empty on the first and last pages
+type Links struct {
+	First string
+	Prev  string
+	Next  string
+	Last  string
+}
+
+// NewLinks returns the links of page of a list of total entries with pageSize entries per page.
+// They are u with its page and page_size parameters replaced, so they keep the filters and sort of the request.
+// A page past the last one links back to the last page as its previous one.
+func NewLinks(u *url.URL, page, pageSize, total int) Links {
+	last := (total + pageSize - 1) / pageSize
+	if last < 1 {
+		last = 1
+	}
+	pageLink := func(n int) string {
+		link := *u
+		q := link.Query()
+		q.Set("page", strconv.Itoa(n))
+		q.Set("page_size", strconv.Itoa(pageSize))
+		link.RawQuery = q.Encode()
+		return link.RequestURI()
+	}
+
+	links := Links{First: pageLink(1), Last: pageLink(last)}
+	if page > 1 {
+		links.Prev = pageLink(min(page-1, last))
+	}
+	if page < last {
+		links.Next = pageLink(page + 1)
+	}
+	return links
+}
+
+// Header formats the links as the value of a Link header: `</items?page=2&page_size=20>; rel="next", ...`
+func (l Links) Header() string {
+	var values []string
+	for _, link := range []struct{ rel, uri string }{
+		{"first", l.First},
+		{"prev", l.Prev},
+		{"next", l.Next},
+		{"last", l.Last},
+	} {
+		if link.uri != "" {
+			values = append(values, "<"+link.uri+`>; rel="`+link.rel+`"`)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// SetHeader sets the Link header of a response with a page of a list; nothing is set if the list is not paged (pageSize 0)
+func SetHeader(c echo.Context, page, pageSize, total int) {
+	if pageSize <= 0 {
+		return
+	}
+	c.Response().Header().Set(HeaderLink, NewLinks(c.Request().URL, page, pageSize, total).Header())
+}
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLinks(t *testing.T) {
+	u, err := url.Parse("/items?category=%E6%99%82%E8%A8%88&sort=purchase_price&page=2&page_size=10")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		page     int
+		total    int
+		expected Links
+	}{
+		{
+			name:  "正常系: 途中のページ",
+			page:  2,
+			total: 35,
+			expected: Links{
+				First: "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+				Prev:  "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+				Next:  "/items?category=%E6%99%82%E8%A8%88&page=3&page_size=10&sort=purchase_price",
+				Last:  "/items?category=%E6%99%82%E8%A8%88&page=4&page_size=10&sort=purchase_price",
+			},
+		},
+		{
+			name:  "正常系: 最初のページには前がない",
+			page:  1,
+			total: 35,
+			expected: Links{
+				First: "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+				Next:  "/items?category=%E6%99%82%E8%A8%88&page=2&page_size=10&sort=purchase_price",
+				Last:  "/items?category=%E6%99%82%E8%A8%88&page=4&page_size=10&sort=purchase_price",
+			},
+		},
+		{
+			name:  "正常系: 最後より後のページの前は最後のページ",
+			page:  9,
+			total: 35,
+			expected: Links{
+				First: "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+				Prev:  "/items?category=%E6%99%82%E8%A8%88&page=4&page_size=10&sort=purchase_price",
+				Last:  "/items?category=%E6%99%82%E8%A8%88&page=4&page_size=10&sort=purchase_price",
+			},
+		},
+		{
+			name:  "正常系: 空の一覧は1ページ",
+			page:  1,
+			total: 0,
+			expected: Links{
+				First: "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+				Last:  "/items?category=%E6%99%82%E8%A8%88&page=1&page_size=10&sort=purchase_price",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewLinks(u, tt.page, 10, tt.total))
+		})
+	}
+}
+
+func TestLinks_Header(t *testing.T) {
+	links := Links{First: "/items?page=1", Next: "/items?page=2", Last: "/items?page=3"}
+
+	assert.Equal(t, `</items?page=1>; rel="first", </items?page=2>; rel="next", </items?page=3>; rel="last"`, links.Header())
+}
+
+func TestSetHeader(t *testing.T) {
+	get := func(pageSize int) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/v2/items?brand=ROLEX&page=2&page_size=1", nil), rec)
+		SetHeader(c, 2, pageSize, 3)
+		return rec
+	}
+
+	t.Run("正常系: リクエストのパスと絞り込みを引き継ぐ", func(t *testing.T) {
+		assert.Equal(t, `</v2/items?brand=ROLEX&page=1&page_size=1>; rel="first", `+
+			`</v2/items?brand=ROLEX&page=1&page_size=1>; rel="prev", `+
+			`</v2/items?brand=ROLEX&page=3&page_size=1>; rel="next", `+
+			`</v2/items?brand=ROLEX&page=3&page_size=1>; rel="last"`, get(1).Header().Get(HeaderLink))
+	})
+
+	t.Run("正常系: ページングしない一覧にはつけない", func(t *testing.T) {
+		assert.Empty(t, get(0).Header().Get(HeaderLink))
+	})
+}
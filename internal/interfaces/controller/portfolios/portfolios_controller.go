@@ -0,0 +1,43 @@
+package portfolios
+
+import (
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/tabular"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PortfolioHandler struct {
+	portfolioUsecase usecase.PortfolioUsecase
+}
+
+func NewPortfolioHandler(portfolioUsecase usecase.PortfolioUsecase) *PortfolioHandler {
+	return &PortfolioHandler{
+		portfolioUsecase: portfolioUsecase,
+	}
+}
+
+// GetPortfolioHistory returns the recorded value of the items, overall and by category, per ?interval= (day or month; day if omitted)
+func (h *PortfolioHandler) GetPortfolioHistory(c echo.Context) error {
+	history, err := h.portfolioUsecase.GetHistory(c.Request().Context(), c.QueryParam("interval"))
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "portfolio-history", history, func() *tabular.Table { return historyTable(history) })
+}
+
+// historyTable has a row per category of each point (in the order of entity.SortedCategories, so every point has
+// the standard categories), then a total row
+func historyTable(history *usecase.PortfolioHistory) *tabular.Table {
+	table := tabular.NewTable("period", "date", "category", "item_count", "value", "purchase_total")
+	for _, point := range history.Points {
+		for _, category := range entity.SortedCategories(point.Categories) {
+			value := point.Categories[category]
+			table.Append(point.Period, point.Date, category, value.ItemCount, value.Value, value.PurchaseTotal)
+		}
+		table.Append(point.Period, point.Date, "total", point.ItemCount, point.Value, point.PurchaseTotal)
+	}
+	return table
+}
@@ -0,0 +1,107 @@
+package portfolios
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockPortfolioUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPortfolioUsecase) TakeSnapshot(ctx context.Context, onlyIfMissing bool) (*usecase.PortfolioPoint, error) {
+	args := m.Called(ctx, onlyIfMissing)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PortfolioPoint), args.Error(1)
+}
+
+func (m *MockPortfolioUsecase) GetHistory(ctx context.Context, interval string) (*usecase.PortfolioHistory, error) {
+	args := m.Called(ctx, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PortfolioHistory), args.Error(1)
+}
+
+func newTestServer(portfolioUsecase usecase.PortfolioUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.GET("/stats/portfolio-history", NewPortfolioHandler(portfolioUsecase).GetPortfolioHistory)
+	return e
+}
+
+func get(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPortfolioHandler_GetPortfolioHistory(t *testing.T) {
+	history := &usecase.PortfolioHistory{
+		Interval: "month",
+		Points: []usecase.PortfolioPoint{{
+			Period:         "2024-02",
+			Date:           "2024-02-29",
+			PortfolioValue: usecase.PortfolioValue{ItemCount: 2, Value: 1600, PurchaseTotal: 1500},
+			Categories: map[string]usecase.PortfolioValue{
+				"バッグ": {ItemCount: 1, Value: 500, PurchaseTotal: 600},
+				"時計":  {ItemCount: 1, Value: 1100, PurchaseTotal: 900},
+			},
+		}},
+	}
+
+	t.Run("正常系: 月ごとの評価額", func(t *testing.T) {
+		mockUsecase := new(MockPortfolioUsecase)
+		mockUsecase.On("GetHistory", mock.Anything, "month").Return(history, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/portfolio-history?interval=month")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"interval": "month", "points": [{
+			"period": "2024-02", "date": "2024-02-29", "item_count": 2, "value": 1600, "purchase_total": 1500,
+			"categories": {
+				"バッグ": {"item_count": 1, "value": 500, "purchase_total": 600},
+				"時計": {"item_count": 1, "value": 1100, "purchase_total": 900}
+			}
+		}]}`, rec.Body.String())
+	})
+
+	t.Run("正常系: CSV はカテゴリーごとの行と合計の行", func(t *testing.T) {
+		mockUsecase := new(MockPortfolioUsecase)
+		mockUsecase.On("GetHistory", mock.Anything, "").Return(history, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/portfolio-history?format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="portfolio-history.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "period,date,category,item_count,value,purchase_total\n"+
+			"2024-02,2024-02-29,時計,1,1100,900\n"+
+			"2024-02,2024-02-29,バッグ,1,500,600\n"+
+			"2024-02,2024-02-29,ジュエリー,0,0,0\n"+
+			"2024-02,2024-02-29,靴,0,0,0\n"+
+			"2024-02,2024-02-29,その他,0,0,0\n"+
+			"2024-02,2024-02-29,total,2,1600,1500\n", rec.Body.String())
+	})
+
+	t.Run("異常系: 不正な間隔", func(t *testing.T) {
+		mockUsecase := new(MockPortfolioUsecase)
+		mockUsecase.On("GetHistory", mock.Anything, "week").Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := get(newTestServer(mockUsecase), "/stats/portfolio-history?interval=week")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package privacy
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type PrivacyHandler struct {
+	privacyUsecase usecase.PrivacyUsecase
+}
+
+func NewPrivacyHandler(privacyUsecase usecase.PrivacyUsecase) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyUsecase: privacyUsecase,
+	}
+}
+
+// ExportUserData returns everything stored about the requesting user as a JSON file
+func (h *PrivacyHandler) ExportUserData(c echo.Context) error {
+	export, err := h.privacyUsecase.ExportUserData(c.Request().Context(), itemController.UserID(c))
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="user-data.json"`)
+	return c.JSON(http.StatusOK, export)
+}
+
+// ConfirmErasure issues the confirmation the requesting user sends back to RequestErasure
+func (h *PrivacyHandler) ConfirmErasure(c echo.Context) error {
+	confirmation, err := h.privacyUsecase.ConfirmErasure(c.Request().Context(), itemController.UserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, confirmation)
+}
+
+// RequestErasure schedules the erasure of the requesting user's data. The user confirms it by sending the
+// confirmation issued by ConfirmErasure as "confirm" in the body (or the query, for clients that cannot send
+// a body with DELETE).
+func (h *PrivacyHandler) RequestErasure(c echo.Context) error {
+	var input usecase.ErasureInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+	if input.Confirm == "" {
+		input.Confirm = c.QueryParam("confirm")
+	}
+	input.UserID = itemController.UserID(c)
+
+	request, err := h.privacyUsecase.RequestErasure(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/me/erasure")
+	return c.JSON(http.StatusAccepted, request)
+}
+
+// GetErasureRequest returns the pending erasure request of the requesting user
+func (h *PrivacyHandler) GetErasureRequest(c echo.Context) error {
+	request, err := h.privacyUsecase.GetErasureRequest(c.Request().Context(), itemController.UserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// CancelErasure withdraws the pending erasure request of the requesting user
+func (h *PrivacyHandler) CancelErasure(c echo.Context) error {
+	if err := h.privacyUsecase.CancelErasure(c.Request().Context(), itemController.UserID(c)); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,69 @@
+// Package problem builds RFC 7807 problem details (https://www.rfc-editor.org/rfc/rfc7807)
+// from error responses, for clients that request application/problem+json.
+package problem
+
+import (
+	"net/http"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MediaType is the problem details media type used for both Accept and Content-Type
+const MediaType = "application/problem+json"
+
+// DefaultType is the problem type of errors that have no more specific type than their HTTP status
+const DefaultType = "about:blank"
+
+// Details is a problem details object.
+// Code and Errors are extension members: the machine-readable code of the error
+// and the individual validation errors, with the field they refer to when known.
+type Details struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is one validation error; Field is empty if the message does not name a field
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// New converts an error response into problem details.
+// The title is the HTTP status text as required for about:blank, the message becomes the detail
+// and instance is the path of the request the error occurred on. detailCodes holds the code of each detail.
+func New(status int, code, message string, details, detailCodes []string, instance string) Details {
+	p := Details{
+		Type:     DefaultType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   message,
+		Instance: instance,
+		Code:     code,
+	}
+	for i, detail := range details {
+		fieldErr := FieldError{Field: domainErrors.ValidationField(detail), Message: detail}
+		if i < len(detailCodes) {
+			fieldErr.Code = detailCodes[i]
+		}
+		p.Errors = append(p.Errors, fieldErr)
+	}
+	return p
+}
+
+// Accepted reports whether an Accept header lists the problem details media type
+func Accepted(accept string) bool {
+	for _, accepted := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), MediaType) {
+			return true
+		}
+	}
+	return false
+}
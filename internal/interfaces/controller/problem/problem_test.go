@@ -0,0 +1,70 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		code     string
+		message  string
+		details  []string
+		codes    []string
+		expected string
+	}{
+		{
+			name:     "正常系: 詳細なし",
+			status:   http.StatusNotFound,
+			code:     "ITEM_NOT_FOUND",
+			message:  "item not found",
+			expected: `{"type":"about:blank","title":"Not Found","status":404,"detail":"item not found","instance":"/items/1","code":"ITEM_NOT_FOUND"}`,
+		},
+		{
+			name:    "正常系: フィールドのエラー",
+			status:  http.StatusBadRequest,
+			code:    "VALIDATION_FAILED",
+			message: "validation failed",
+			details: []string{"name is required", "page_size must be an integer", "category must be one of: 時計, バッグ", "invalid template"},
+			codes:   []string{"VALIDATION_NAME_REQUIRED", "VALIDATION_PAGE_SIZE_INVALID_FORMAT", "VALIDATION_CATEGORY_INVALID_CHOICE", "VALIDATION_INVALID"},
+			expected: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/items/1","code":"VALIDATION_FAILED","errors":[` +
+				`{"field":"name","code":"VALIDATION_NAME_REQUIRED","message":"name is required"},` +
+				`{"field":"page_size","code":"VALIDATION_PAGE_SIZE_INVALID_FORMAT","message":"page_size must be an integer"},` +
+				`{"field":"category","code":"VALIDATION_CATEGORY_INVALID_CHOICE","message":"category must be one of: 時計, バッグ"},` +
+				`{"code":"VALIDATION_INVALID","message":"invalid template"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(New(tt.status, tt.code, tt.message, tt.details, tt.codes, "/items/1"))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestAccepted(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		expected bool
+	}{
+		{name: "正常系: 指定なし", accept: "", expected: false},
+		{name: "正常系: JSONのみ", accept: "application/json", expected: false},
+		{name: "正常系: 複数指定の中にある", accept: "application/json, application/problem+json;q=0.9", expected: true},
+		{name: "正常系: 大文字小文字は区別しない", accept: "Application/Problem+JSON", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Accepted(tt.accept))
+		})
+	}
+}
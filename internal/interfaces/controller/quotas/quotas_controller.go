@@ -0,0 +1,41 @@
+package quotas
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type QuotaHandler struct {
+	quotaUsecase usecase.QuotaUsecase
+}
+
+func NewQuotaHandler(quotaUsecase usecase.QuotaUsecase) *QuotaHandler {
+	return &QuotaHandler{
+		quotaUsecase: quotaUsecase,
+	}
+}
+
+// GetQuota returns how many items the requesting user owns against the limit, and with ?item_id=
+// how many attachments one of their items has against the limit
+func (h *QuotaHandler) GetQuota(c echo.Context) error {
+	var itemID int64
+	if v := c.QueryParam("item_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "item_id must be a positive integer")
+		}
+		itemID = id
+	}
+
+	report, err := h.quotaUsecase.GetQuota(c.Request().Context(), itemController.UserID(c), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
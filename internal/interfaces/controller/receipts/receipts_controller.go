@@ -0,0 +1,46 @@
+package receipts
+
+import (
+	"errors"
+	"net/http"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReceiptHandler struct {
+	receiptUsecase usecase.ReceiptUsecase
+}
+
+func NewReceiptHandler(receiptUsecase usecase.ReceiptUsecase) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptUsecase: receiptUsecase,
+	}
+}
+
+// DraftItem reads the receipt image sent as the "file" field of a multipart/form-data request
+// and returns an item draft prefilled from it. The item is not created; the client confirms it with POST /items.
+func (h *ReceiptHandler) DraftItem(c echo.Context) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "file is required")
+		}
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	draft, err := h.receiptUsecase.DraftItemFromReceipt(c.Request().Context(),
+		usecase.ImageUpload{FileName: header.Filename, Size: header.Size, Content: file})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
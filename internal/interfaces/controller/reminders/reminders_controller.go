@@ -0,0 +1,55 @@
+package reminders
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReminderHandler struct {
+	reminderUsecase usecase.ReminderUsecase
+}
+
+func NewReminderHandler(reminderUsecase usecase.ReminderUsecase) *ReminderHandler {
+	return &ReminderHandler{
+		reminderUsecase: reminderUsecase,
+	}
+}
+
+// SnoozeReminders pauses the expiry reminders of an item until a date
+func (h *ReminderHandler) SnoozeReminders(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.SnoozeRemindersInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	snooze, err := h.reminderUsecase.SnoozeReminders(c.Request().Context(), itemController.UserID(c), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, snooze)
+}
+
+// UnsnoozeReminders resumes the expiry reminders of an item
+func (h *ReminderHandler) UnsnoozeReminders(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	if err := h.reminderUsecase.UnsnoozeReminders(c.Request().Context(), itemController.UserID(c), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package reminders
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockReminderUsecase struct {
+	mock.Mock
+}
+
+func (m *MockReminderUsecase) SendExpiryReminders(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockReminderUsecase) SnoozeReminders(ctx context.Context, actor string, itemID int64, input usecase.SnoozeRemindersInput) (*entity.ReminderSnooze, error) {
+	args := m.Called(ctx, actor, itemID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ReminderSnooze), args.Error(1)
+}
+
+func (m *MockReminderUsecase) UnsnoozeReminders(ctx context.Context, actor string, itemID int64) error {
+	args := m.Called(ctx, actor, itemID)
+	return args.Error(0)
+}
+
+// newTestServer registers the reminder routes as the server does
+func newTestServer(reminderUsecase usecase.ReminderUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	h := NewReminderHandler(reminderUsecase)
+	e.POST("/items/:id/reminders/snooze", h.SnoozeReminders)
+	e.DELETE("/items/:id/reminders/snooze", h.UnsnoozeReminders)
+	return e
+}
+
+func do(e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	req.Header.Set(itemController.HeaderUserID, "alice")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReminderHandler_SnoozeReminders(t *testing.T) {
+	t.Run("正常系: 指定の日まで通知を止める", func(t *testing.T) {
+		mockUsecase := new(MockReminderUsecase)
+		mockUsecase.On("SnoozeReminders", mock.Anything, "alice", int64(1), usecase.SnoozeRemindersInput{Until: "2024-04-01"}).
+			Return(&entity.ReminderSnooze{ItemID: 1, Until: "2024-04-01", SnoozedBy: "alice"}, nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodPost, "/items/1/reminders/snooze", `{"until": "2024-04-01"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var snooze entity.ReminderSnooze
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snooze))
+		assert.Equal(t, "2024-04-01", snooze.Until)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 不正なアイテムID", path: "/items/abc/reminders/snooze", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 過去の日付", path: "/items/1/reminders/snooze", err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 所有者以外", path: "/items/1/reminders/snooze", err: domainErrors.ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "異常系: アイテムが見つからない", path: "/items/1/reminders/snooze", err: domainErrors.ErrItemNotFound, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockReminderUsecase)
+			mockUsecase.On("SnoozeReminders", mock.Anything, "alice", int64(1), mock.Anything).Return(nil, tt.err)
+
+			rec := do(newTestServer(mockUsecase), http.MethodPost, tt.path, `{"until": "2024-04-01"}`)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestReminderHandler_UnsnoozeReminders(t *testing.T) {
+	t.Run("正常系: 通知を再開する", func(t *testing.T) {
+		mockUsecase := new(MockReminderUsecase)
+		mockUsecase.On("UnsnoozeReminders", mock.Anything, "alice", int64(1)).Return(nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodDelete, "/items/1/reminders/snooze", "")
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 止めていないアイテム", func(t *testing.T) {
+		mockUsecase := new(MockReminderUsecase)
+		mockUsecase.On("UnsnoozeReminders", mock.Anything, "alice", int64(1)).Return(domainErrors.ErrReminderSnoozeNotFound)
+
+		rec := do(newTestServer(mockUsecase), http.MethodDelete, "/items/1/reminders/snooze", "")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "REMINDER_SNOOZE_NOT_FOUND")
+	})
+}
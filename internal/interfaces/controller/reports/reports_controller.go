@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/tabular"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler struct {
+	reportUsecase usecase.ReportUsecase
+}
+
+func NewReportHandler(reportUsecase usecase.ReportUsecase) *ReportHandler {
+	return &ReportHandler{
+		reportUsecase: reportUsecase,
+	}
+}
+
+// GetSpendReport returns the money spent per month and per category in ?year= (the current year if omitted), as CSV with ?format=csv
+func (h *ReportHandler) GetSpendReport(c echo.Context) error {
+	var year int
+	if v := c.QueryParam("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "year must be an integer")
+		}
+		year = n
+	}
+
+	report, err := h.reportUsecase.GetSpendReport(c.Request().Context(), year)
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "spend-"+strconv.Itoa(report.Year), report, func() *tabular.Table { return spendTable(report) })
+}
+
+// spendTable has a row per month, a row per category and a total row, told apart by the type column
+func spendTable(report *usecase.SpendReport) *tabular.Table {
+	table := tabular.NewTable("type", "key", "count", "spend", "previous_spend", "change")
+	for _, line := range report.Months {
+		table.Append("month", line.Month, line.Count, line.Spend, line.PreviousSpend, line.Change)
+	}
+	for _, category := range entity.SortedCategories(report.Categories) {
+		if line, ok := report.Categories[category]; ok {
+			table.Append("category", category, line.Count, line.Spend, line.PreviousSpend, line.Change)
+		}
+	}
+	table.Append("total", strconv.Itoa(report.Year), report.Count, report.Spend, report.PreviousSpend, report.Change)
+	return table
+}
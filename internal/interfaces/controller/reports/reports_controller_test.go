@@ -0,0 +1,135 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockReportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockReportUsecase) GetSpendReport(ctx context.Context, year int) (*usecase.SpendReport, error) {
+	args := m.Called(ctx, year)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.SpendReport), args.Error(1)
+}
+
+func newTestServer(reportUsecase usecase.ReportUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.GET("/reports/spend", NewReportHandler(reportUsecase).GetSpendReport)
+	return e
+}
+
+func get(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReportHandler_GetSpendReport(t *testing.T) {
+	t.Run("正常系: 指定の年の支出", func(t *testing.T) {
+		mockUsecase := new(MockReportUsecase)
+		mockUsecase.On("GetSpendReport", mock.Anything, 2024).Return(&usecase.SpendReport{
+			Year:          2024,
+			Count:         1,
+			Spend:         1500000,
+			PreviousSpend: 500000,
+			Change:        1000000,
+			Months:        []*usecase.SpendLine{{Month: "2024-01", Count: 1, Spend: 1500000, PreviousSpend: 500000, Change: 1000000}},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/reports/spend?year=2024")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var report usecase.SpendReport
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, 1000000, report.Change)
+		assert.Equal(t, "2024-01", report.Months[0].Month)
+	})
+
+	t.Run("正常系: 年の指定がなければ今年", func(t *testing.T) {
+		mockUsecase := new(MockReportUsecase)
+		mockUsecase.On("GetSpendReport", mock.Anything, 0).Return(&usecase.SpendReport{Year: 2026}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/reports/spend")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: CSVは月・カテゴリー・合計の行", func(t *testing.T) {
+		mockUsecase := new(MockReportUsecase)
+		mockUsecase.On("GetSpendReport", mock.Anything, 2024).Return(&usecase.SpendReport{
+			Year:          2024,
+			Count:         1,
+			Spend:         1500000,
+			PreviousSpend: 500000,
+			Change:        1000000,
+			Months: []*usecase.SpendLine{
+				{Month: "2024-01", Count: 1, Spend: 1500000, PreviousSpend: 500000, Change: 1000000},
+				{Month: "2024-02"},
+			},
+			Categories: map[string]*usecase.SpendLine{
+				"バッグ": {},
+				"時計":  {Count: 1, Spend: 1500000, PreviousSpend: 500000, Change: 1000000},
+			},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/reports/spend?year=2024&format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="spend-2024.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "type,key,count,spend,previous_spend,change\n"+
+			"month,2024-01,1,1500000,500000,1000000\n"+
+			"month,2024-02,0,0,0,0\n"+
+			"category,時計,1,1500000,500000,1000000\n"+
+			"category,バッグ,0,0,0,0\n"+
+			"total,2024,1,1500000,500000,1000000\n", rec.Body.String())
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		mockUsecase := new(MockReportUsecase)
+		mockUsecase.On("GetSpendReport", mock.Anything, 0).Return(&usecase.SpendReport{Year: 2026}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/reports/spend?format=xlsx")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 年が整数でない", path: "/reports/spend?year=今年", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 範囲外の年", path: "/reports/spend?year=-1", err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockReportUsecase)
+			mockUsecase.On("GetSpendReport", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := get(newTestServer(mockUsecase), tt.path)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package revisions
+
+import (
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/serializer"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RevisionHandler struct {
+	revisionUsecase usecase.RevisionUsecase
+}
+
+func NewRevisionHandler(revisionUsecase usecase.RevisionUsecase) *RevisionHandler {
+	return &RevisionHandler{
+		revisionUsecase: revisionUsecase,
+	}
+}
+
+// GetRevisions returns the change history of an item, oldest first
+func (h *RevisionHandler) GetRevisions(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.revisionUsecase.ListRevisions(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// GetChanges returns the change feed of an item: who changed which fields and when, oldest first
+func (h *RevisionHandler) GetChanges(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	changes, err := h.revisionUsecase.ListChanges(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
+
+// RollbackItem restores an item to a revision; the updated item is sent like the response of PATCH /items/{id}
+func (h *RevisionHandler) RollbackItem(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+	revision, err := strconv.ParseInt(c.Param("rev"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid revision")
+	}
+
+	item, err := h.revisionUsecase.RollbackItem(c.Request().Context(), itemController.UserID(c), itemController.TenantID(c), itemID, revision)
+	if err != nil {
+		if domainErrors.IsConflictError(err) {
+			return itemController.NewHTTPError(http.StatusConflict, "item has been modified")
+		}
+		return err
+	}
+
+	return serializer.Respond(c, http.StatusOK, item)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
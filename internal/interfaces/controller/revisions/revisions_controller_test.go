@@ -0,0 +1,189 @@
+package revisions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockRevisionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockRevisionUsecase) ListRevisions(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemRevision), args.Error(1)
+}
+
+func (m *MockRevisionUsecase) ListChanges(ctx context.Context, itemID int64) ([]*usecase.ItemChange, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*usecase.ItemChange), args.Error(1)
+}
+
+func (m *MockRevisionUsecase) RollbackItem(ctx context.Context, actor, tenantID string, itemID, revision int64) (*entity.Item, error) {
+	args := m.Called(ctx, actor, tenantID, itemID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func newTestServer(revisionUsecase usecase.RevisionUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	handler := NewRevisionHandler(revisionUsecase)
+	e.GET("/items/:id/revisions", handler.GetRevisions)
+	e.GET("/items/:id/changes", handler.GetChanges)
+	e.POST("/items/:id/revisions/:rev/rollback", handler.RollbackItem)
+	return e
+}
+
+func serve(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(itemController.HeaderTenantID, "acme")
+	req.Header.Set(itemController.HeaderUserID, "alice")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRevisionHandler_GetRevisions(t *testing.T) {
+	t.Run("正常系: 変更履歴を返す", func(t *testing.T) {
+		mockUsecase := new(MockRevisionUsecase)
+		mockUsecase.On("ListRevisions", mock.Anything, int64(1)).Return([]*entity.ItemRevision{{
+			ID:       10,
+			ItemID:   1,
+			Revision: 2,
+			Snapshot: entity.ItemSnapshot{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1400000, PurchaseDate: "2023-01-15"},
+			Changes:  []entity.FieldChange{{Field: "name", From: "ロレックス", To: "デイトナ"}},
+		}}, nil)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/1/revisions")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var revisions []map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+		require.Len(t, revisions, 1)
+		assert.Equal(t, float64(2), revisions[0]["revision"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"field": "name", "from": "ロレックス", "to": "デイトナ"}}, revisions[0]["changes"])
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		mockUsecase := new(MockRevisionUsecase)
+		mockUsecase.On("ListRevisions", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/999/revisions")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestRevisionHandler_GetChanges(t *testing.T) {
+	t.Run("正常系: 誰がいつどのフィールドを変えたかを返す", func(t *testing.T) {
+		mockUsecase := new(MockRevisionUsecase)
+		mockUsecase.On("ListChanges", mock.Anything, int64(1)).Return([]*usecase.ItemChange{{
+			Type:       usecase.ChangeTransferred,
+			TransferID: 8,
+			ChangedBy:  "bob",
+			ChangedAt:  time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+			Changes:    []entity.FieldChange{{Field: "owner_id", From: "alice", To: "bob"}},
+		}}, nil)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/1/changes")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[{
+			"type": "transferred",
+			"transfer_id": 8,
+			"changed_by": "bob",
+			"changed_at": "2024-03-04T09:00:00Z",
+			"changes": [{"field": "owner_id", "from": "alice", "to": "bob"}]
+		}]`, rec.Body.String())
+	})
+
+	t.Run("異常系: 不正なアイテムID", func(t *testing.T) {
+		rec := serve(newTestServer(new(MockRevisionUsecase)), http.MethodGet, "/items/abc/changes")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestRevisionHandler_RollbackItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockRevisionUsecase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "正常系: 巻き戻したアイテムを返す",
+			path: "/items/1/revisions/2/rollback",
+			setupMock: func(m *MockRevisionUsecase) {
+				m.On("RollbackItem", mock.Anything, "alice", "acme", int64(1), int64(2)).Return(&entity.Item{ID: 1, Name: "ロレックス", Version: 6}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 不正なリビジョン",
+			path:           "/items/1/revisions/abc/rollback",
+			setupMock:      func(m *MockRevisionUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid revision",
+		},
+		{
+			name: "異常系: 記録されていないリビジョン",
+			path: "/items/1/revisions/3/rollback",
+			setupMock: func(m *MockRevisionUsecase) {
+				m.On("RollbackItem", mock.Anything, "alice", "acme", int64(1), int64(3)).Return(nil, domainErrors.ErrItemRevisionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  domainErrors.ErrItemRevisionNotFound.Error(),
+		},
+		{
+			name: "異常系: 巻き戻しの間に更新された",
+			path: "/items/1/revisions/2/rollback",
+			setupMock: func(m *MockRevisionUsecase) {
+				m.On("RollbackItem", mock.Anything, "alice", "acme", int64(1), int64(2)).Return(nil, domainErrors.ErrConflict)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "item has been modified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockRevisionUsecase)
+			tt.setupMock(mockUsecase)
+
+			rec := serve(newTestServer(mockUsecase), http.MethodPost, tt.path)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedError != "" {
+				var resp map[string]interface{}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tt.expectedError, resp["error"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/usecase"
+)
+
+type SearchHandler struct {
+	searchUsecase usecase.SearchUsecase
+}
+
+func NewSearchHandler(searchUsecase usecase.SearchUsecase) *SearchHandler {
+	return &SearchHandler{
+		searchUsecase: searchUsecase,
+	}
+}
+
+// SearchItems finds the items matching ?q= by name, brand or category, tolerating typos, the most relevant first.
+// ?category= narrows the search; ?page= and ?page_size= page through the results.
+func (h *SearchHandler) SearchItems(c echo.Context) error {
+	query := usecase.SearchQuery{
+		TenantID: itemController.TenantID(c),
+		Text:     c.QueryParam("q"),
+		Category: c.QueryParam("category"),
+	}
+	var errs []string
+	if v := c.QueryParam("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "page must be an integer")
+		}
+		query.Page = page
+	}
+	if v := c.QueryParam("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "page_size must be an integer")
+		}
+		query.PageSize = pageSize
+	}
+	if len(errs) > 0 {
+		return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", errs...)
+	}
+
+	result, err := h.searchUsecase.Search(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(itemController.HeaderTotalCount, strconv.Itoa(result.Total))
+	pagination.SetHeader(c, result.Page, result.PageSize, result.Total)
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockSearchUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSearchUsecase) Search(ctx context.Context, query usecase.SearchQuery) (*usecase.SearchResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.SearchResult), args.Error(1)
+}
+
+func get(searchUsecase usecase.SearchUsecase, path string) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.GET("/items/search", NewSearchHandler(searchUsecase).SearchItems)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestSearchHandler_SearchItems(t *testing.T) {
+	t.Run("正常系: 関連度つきで返す", func(t *testing.T) {
+		mockUsecase := new(MockSearchUsecase)
+		mockUsecase.On("Search", mock.Anything, usecase.SearchQuery{Text: "Rolx", Category: "時計", Page: 2, PageSize: 1}).Return(&usecase.SearchResult{
+			Items: []usecase.SearchHit{{
+				Item:  &entity.Item{ID: 3, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: "2023-01-15", Version: 1},
+				Score: 0.8,
+			}},
+			Total:    2,
+			Page:     2,
+			PageSize: 1,
+		}, nil)
+
+		rec := get(mockUsecase, "/items/search?q=Rolx&category=%E6%99%82%E8%A8%88&page=2&page_size=1")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get(itemController.HeaderTotalCount))
+		assert.Equal(t, `</items/search?category=%E6%99%82%E8%A8%88&page=1&page_size=1&q=Rolx>; rel="first", `+
+			`</items/search?category=%E6%99%82%E8%A8%88&page=1&page_size=1&q=Rolx>; rel="prev", `+
+			`</items/search?category=%E6%99%82%E8%A8%88&page=2&page_size=1&q=Rolx>; rel="last"`, rec.Header().Get(pagination.HeaderLink))
+		assert.JSONEq(t, `{
+			"items": [{
+				"id": 3, "name": "デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "currency": "JPY",
+				"purchase_date": "2023-01-15", "version": 1, "created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z",
+				"score": 0.8
+			}],
+			"total": 2,
+			"page": 2,
+			"page_size": 1
+		}`, rec.Body.String())
+	})
+
+	t.Run("異常系: ページが整数でない", func(t *testing.T) {
+		rec := get(new(MockSearchUsecase), "/items/search?q=rolex&page=two")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "page must be an integer")
+	})
+
+	t.Run("異常系: 検索語がない", func(t *testing.T) {
+		mockUsecase := new(MockSearchUsecase)
+		mockUsecase.On("Search", mock.Anything, usecase.SearchQuery{}).Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := get(mockUsecase, "/items/search")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
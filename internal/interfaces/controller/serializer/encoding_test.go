@@ -0,0 +1,236 @@
+package serializer
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestMessagePackSerializer(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []byte
+	}{
+		{
+			name:     "正常系: マップのキーは並べ替える",
+			value:    map[string]interface{}{"b": []interface{}{true, nil}, "a": 1, "c": "x"},
+			expected: []byte{0x83, 0xa1, 'a', 0x01, 0xa1, 'b', 0x92, 0xc3, 0xc0, 0xa1, 'c', 0xa1, 'x'},
+		},
+		{
+			name:     "正常系: 整数は最小の形式で表す",
+			value:    []int64{-1, -100, 200, 300, 70000},
+			expected: []byte{0x95, 0xff, 0xd0, 0x9c, 0xcc, 0xc8, 0xcd, 0x01, 0x2c, 0xce, 0x00, 0x01, 0x11, 0x70},
+		},
+		{
+			name:     "正常系: 小数",
+			value:    1.5,
+			expected: []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "正常系: アイテム一覧は配列のみ",
+			value:    &usecase.ItemList{Items: []*entity.Item{}, Total: 0},
+			expected: []byte{0x90},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := messagePackSerializer{}.Serialize(httptest.NewRequest("GET", "/items", nil), 200, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
+
+func TestProtobufSerializer(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []byte
+	}{
+		{
+			name:  "正常系: Item（ゼロ値のフィールドは省略）",
+			value: &entity.Item{ID: 1, Name: "a", Attributes: map[string]string{"k": "v"}, Version: 2},
+			expected: []byte{
+				0x08, 0x01, // id
+				0x12, 0x01, 'a', // name
+				0x3a, 0x06, 0x0a, 0x01, 'k', 0x12, 0x01, 'v', // attributes
+				0x48, 0x02, // version
+			},
+		},
+		{
+			name:  "正常系: Item の画像のID（packed）",
+			value: &entity.Item{ID: 1, ImageIDs: []int64{3, 300}},
+			expected: []byte{
+				0x08, 0x01, // id
+				0x6a, 0x03, 0x03, 0xac, 0x02, // image_ids
+			},
+		},
+		{
+			name:  "正常系: Item のタグ（repeated string）",
+			value: &entity.Item{ID: 1, Tags: []string{"a", "bc"}},
+			expected: []byte{
+				0x08, 0x01, // id
+				0x72, 0x01, 'a', // tags
+				0x72, 0x02, 'b', 'c', // tags
+			},
+		},
+		{
+			name:  "正常系: ListItemsResponse",
+			value: &usecase.ItemList{Items: []*entity.Item{{ID: 1}}, Total: 3, Page: 1, PageSize: 1},
+			expected: []byte{
+				0x0a, 0x02, 0x08, 0x01, // items
+				0x10, 0x03, // total
+				0x18, 0x01, // page
+				0x20, 0x01, // page_size
+			},
+		},
+		{
+			name: "正常系: CategorySummary（通貨を指定）",
+			value: &usecase.CategorySummary{Categories: map[string]int{"a": 1}, Total: 1, Value: &usecase.ValueSummary{
+				Currency: "USD", Categories: map[string]float64{"a": 2}, Total: 2, ExchangeRate: 1,
+			}},
+			expected: []byte{
+				0x0a, 0x05, 0x0a, 0x01, 'a', 0x10, 0x01, // categories
+				0x10, 0x01, // total
+				0x1a, 0x25, // value
+				0x0a, 0x03, 'U', 'S', 'D', // currency
+				0x12, 0x0c, 0x0a, 0x01, 'a', 0x11, 0, 0, 0, 0, 0, 0, 0, 0x40, // categories
+				0x19, 0, 0, 0, 0, 0, 0, 0, 0x40, // total
+				0x21, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, // exchange_rate
+			},
+		},
+		{
+			name:     "正常系: ErrorResponse",
+			value:    ErrorResponse{Error: "x", Details: []string{"d"}},
+			expected: []byte{0x0a, 0x01, 'x', 0x12, 0x01, 'd'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := protobufSerializer{}.Serialize(httptest.NewRequest("GET", "/items", nil), 200, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
+
+func TestXMLSerializer(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name:  "正常系: アイテム",
+			value: &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, PurchaseDate: "2024-01-01", Attributes: map[string]string{"storage_box": "A-1"}, Version: 1, CreatedAt: createdAt},
+			expected: `<item><id>1</id><name>時計1</name><category>時計</category><brand>ROLEX</brand><purchase_price>1000</purchase_price><purchase_date>2024-01-01</purchase_date>` +
+				`<attributes><attribute key="storage_box">A-1</attribute></attributes><version>1</version><created_at>2024-01-02T03:04:05Z</created_at></item>`,
+		},
+		{
+			name:     "正常系: 画像のあるアイテム",
+			value:    &entity.Item{ID: 1, Name: "a", ImageIDs: []int64{3, 5}},
+			expected: `<item><id>1</id><name>a</name><category></category><brand></brand><purchase_price>0</purchase_price><purchase_date></purchase_date><image_ids><image_id>3</image_id><image_id>5</image_id></image_ids></item>`,
+		},
+		{
+			name:     "正常系: タグのあるアイテム",
+			value:    &entity.Item{ID: 1, Name: "a", Tags: []string{"vintage", "記念日"}},
+			expected: `<item><id>1</id><name>a</name><category></category><brand></brand><purchase_price>0</purchase_price><purchase_date></purchase_date><tags><tag>vintage</tag><tag>記念日</tag></tags></item>`,
+		},
+		{
+			name:     "正常系: アイテム一覧",
+			value:    &usecase.ItemList{Items: []*entity.Item{{ID: 1, Name: "a"}}, Total: 5, Page: 2, PageSize: 1},
+			expected: `<items total="5" page="2" page_size="1"><item><id>1</id><name>a</name><category></category><brand></brand><purchase_price>0</purchase_price><purchase_date></purchase_date></item></items>`,
+		},
+		{
+			name:     "正常系: カテゴリー別集計",
+			value:    &usecase.CategorySummary{Categories: map[string]int{"時計": 2, "バッグ": 1}, Total: 3},
+			expected: `<summary total="3"><category name="バッグ">1</category><category name="時計">2</category></summary>`,
+		},
+		{
+			name:     "正常系: 表示名を含むカテゴリー別集計",
+			value:    &usecase.CategorySummary{Categories: map[string]int{"時計": 2}, Total: 2, CategoryNames: map[string]string{"時計": "Watches"}},
+			expected: `<summary total="2"><category name="時計" display_name="Watches">2</category></summary>`,
+		},
+		{
+			name: "正常系: 購入価格の集計を含むカテゴリー別集計",
+			value: &usecase.CategorySummary{Categories: map[string]int{"時計": 2}, Total: 2, Stats: map[string]usecase.CategoryStats{
+				"時計": {Count: 2, Min: 500000, Max: 1000000, Avg: 750000, Sum: 1500000},
+			}},
+			expected: `<summary total="2"><category name="時計">2</category>` +
+				`<stats><category name="時計" count="2" min="500000" max="1000000" avg="750000" sum="1500000"></category></stats></summary>`,
+		},
+		{
+			name: "正常系: 通貨を指定したカテゴリー別集計",
+			value: &usecase.CategorySummary{Categories: map[string]int{"時計": 2}, Total: 2, Value: &usecase.ValueSummary{
+				Currency: "USD", Categories: map[string]float64{"時計": 6875.5}, Total: 6875.5, ExchangeRate: 0.006875, RateDate: "2024-03-08",
+			}},
+			expected: `<summary total="2"><category name="時計">2</category>` +
+				`<value currency="USD" total="6875.5" exchange_rate="0.006875" rate_date="2024-03-08"><category name="時計">6875.5</category></value></summary>`,
+		},
+		{
+			name: "正常系: 通貨別の合計を含むカテゴリー別集計",
+			value: &usecase.CategorySummary{Categories: map[string]int{"時計": 2}, Total: 2, Value: &usecase.ValueSummary{
+				Currency: "USD", Categories: map[string]float64{"時計": 7975}, Total: 7975, ExchangeRate: 0.006875, RateDate: "2024-03-08",
+				Native: map[string]*usecase.NativeValue{
+					"JPY": {Categories: map[string]float64{"時計": 1000000}, Total: 1000000, Converted: 6875, ExchangeRate: 0.006875},
+					"USD": {Categories: map[string]float64{"時計": 1100}, Total: 1100, Converted: 1100, ExchangeRate: 1},
+				},
+			}},
+			expected: `<summary total="2"><category name="時計">2</category>` +
+				`<value currency="USD" total="7975" exchange_rate="0.006875" rate_date="2024-03-08"><category name="時計">7975</category>` +
+				`<native currency="JPY" total="1e+06" converted="6875" exchange_rate="0.006875"><category name="時計">1e+06</category></native>` +
+				`<native currency="USD" total="1100" converted="1100" exchange_rate="1"><category name="時計">1100</category></native></value></summary>`,
+		},
+		{
+			name:     "正常系: エラー",
+			value:    ErrorResponse{Error: "validation failed", Details: []string{"name is required"}},
+			expected: `<error><message>validation failed</message><details><detail>name is required</detail></details></error>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := xmlSerializer{}.Serialize(httptest.NewRequest("GET", "/items", nil), 200, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, xml.Header+tt.expected, string(body))
+		})
+	}
+}
+
+func TestDecodeCreateItemXML(t *testing.T) {
+	input, err := DecodeCreateItemXML(strings.NewReader(`<?xml version="1.0"?>
+<item>
+  <name>時計1</name>
+  <category>時計</category>
+  <brand>ROLEX</brand>
+  <purchase_price>1000</purchase_price>
+  <purchase_date>2024-01-01</purchase_date>
+  <attributes><attribute key="storage_box">A-1</attribute></attributes>
+</item>`))
+
+	require.NoError(t, err)
+	assert.Equal(t, usecase.CreateItemInput{
+		Name:          "時計1",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: entity.MinorUnits(1000),
+		PurchaseDate:  "2024-01-01",
+		Attributes:    map[string]string{"storage_box": "A-1"},
+	}, input)
+
+	_, err = DecodeCreateItemXML(strings.NewReader(`<item><purchase_price>abc</purchase_price></item>`))
+	assert.Error(t, err)
+}
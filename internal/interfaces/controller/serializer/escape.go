@@ -0,0 +1,49 @@
+package serializer
+
+import (
+	"html"
+	"sync/atomic"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// escapeHTML enables HTML escaping of item names and brands in JSON responses (see SetHTMLEscaping)
+var escapeHTML atomic.Bool
+
+// SetHTMLEscaping enables or disables HTML escaping of item names and brands in the JSON and JSON:API responses,
+// for clients that insert them into pages without escaping. Stored values are never escaped, so the setting can
+// be changed at any time (including while serving requests) and values are escaped exactly once.
+func SetHTMLEscaping(enabled bool) {
+	escapeHTML.Store(enabled)
+}
+
+// htmlSafe returns v with item names and brands HTML-escaped when s is a JSON format and escaping is enabled
+func htmlSafe(s Serializer, v interface{}) interface{} {
+	if !escapeHTML.Load() || (s.Format() != FormatJSON && s.Format() != FormatJSONAPI) {
+		return v
+	}
+	switch v := v.(type) {
+	case *entity.Item:
+		return escapeItem(v)
+	case *usecase.ItemList:
+		list := *v
+		list.Items = make([]*entity.Item, len(v.Items))
+		for i, item := range v.Items {
+			list.Items[i] = escapeItem(item)
+		}
+		return &list
+	}
+	return v
+}
+
+// escapeItem returns a copy of item with the name and brand escaped; item itself may be cached and is not modified
+func escapeItem(item *entity.Item) *entity.Item {
+	if item == nil {
+		return nil
+	}
+	escaped := *item
+	escaped.Name = html.EscapeString(item.Name)
+	escaped.Brand = html.EscapeString(item.Brand)
+	return &escaped
+}
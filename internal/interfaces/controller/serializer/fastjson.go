@@ -0,0 +1,238 @@
+package serializer
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// fastJSON enables the reflection-free encoder for items and item lists (see SetFastJSON)
+var fastJSON atomic.Bool
+
+func init() {
+	fastJSON.Store(true)
+}
+
+// SetFastJSON enables or disables the hand-written JSON encoder for items and item lists.
+// Its output is identical to encoding/json; disabling it falls back to json.Marshal.
+// It is safe to call while serving requests.
+func SetFastJSON(enabled bool) {
+	fastJSON.Store(enabled)
+}
+
+// maxPooledBuffer is the largest buffer kept for reuse, so one huge page does not pin its memory
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 16<<10)
+		return &b
+	},
+}
+
+func getBuffer() []byte {
+	return (*bufferPool.Get().(*[]byte))[:0]
+}
+
+func putBuffer(b []byte) {
+	if cap(b) > maxPooledBuffer {
+		return
+	}
+	b = b[:0]
+	bufferPool.Put(&b)
+}
+
+// appendFastJSON encodes the values the fast path supports; ok is false for other values
+func appendFastJSON(b []byte, v interface{}) (_ []byte, ok bool, err error) {
+	switch v := v.(type) {
+	case *entity.Item:
+		b, err = appendItemJSON(b, v)
+		return b, true, err
+	case []*entity.Item:
+		b, err = appendItemsJSON(b, v)
+		return b, true, err
+	case itemPage:
+		b = append(b, `{"items":`...)
+		if b, err = appendItemsJSON(b, v.Items); err != nil {
+			return nil, true, err
+		}
+		b = append(b, `,"total":`...)
+		b = strconv.AppendInt(b, int64(v.Total), 10)
+		b = append(b, `,"page":`...)
+		b = strconv.AppendInt(b, int64(v.Page), 10)
+		b = append(b, `,"page_size":`...)
+		b = strconv.AppendInt(b, int64(v.PageSize), 10)
+		return append(b, '}'), true, nil
+	default:
+		return b, false, nil
+	}
+}
+
+func appendItemsJSON(b []byte, items []*entity.Item) ([]byte, error) {
+	if items == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, '[')
+	for i, item := range items {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		var err error
+		if b, err = appendItemJSON(b, item); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+// appendItemJSON appends item encoded like json.Marshal would, following entity.Item's field tags
+func appendItemJSON(b []byte, item *entity.Item) ([]byte, error) {
+	if item == nil {
+		return append(b, "null"...), nil
+	}
+	b = append(b, `{"id":`...)
+	b = strconv.AppendInt(b, item.ID, 10)
+	b = append(b, `,"name":`...)
+	b = appendJSONString(b, item.Name)
+	b = append(b, `,"category":`...)
+	b = appendJSONString(b, item.Category)
+	b = append(b, `,"brand":`...)
+	b = appendJSONString(b, item.Brand)
+	b = append(b, `,"purchase_price":`...)
+	b = strconv.AppendInt(b, int64(item.PurchasePrice), 10)
+	if item.Currency != "" {
+		b = append(b, `,"currency":`...)
+		b = appendJSONString(b, item.Currency)
+	}
+	b = append(b, `,"purchase_date":`...)
+	b = appendJSONString(b, item.PurchaseDate)
+	if len(item.Attributes) > 0 {
+		b = append(b, `,"attributes":{`...)
+		var keyBuf [16]string
+		keys := keyBuf[:0]
+		for k := range item.Attributes {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for i, k := range keys {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, k)
+			b = append(b, ':')
+			b = appendJSONString(b, item.Attributes[k])
+		}
+		b = append(b, '}')
+	}
+	if item.OwnerID != "" {
+		b = append(b, `,"owner_id":`...)
+		b = appendJSONString(b, item.OwnerID)
+	}
+	if item.MaintenanceCost != 0 {
+		b = append(b, `,"maintenance_cost":`...)
+		b = strconv.AppendInt(b, int64(item.MaintenanceCost), 10)
+	}
+	if len(item.ImageIDs) > 0 {
+		b = append(b, `,"image_ids":[`...)
+		for i, id := range item.ImageIDs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendInt(b, id, 10)
+		}
+		b = append(b, ']')
+	}
+	if len(item.Tags) > 0 {
+		b = append(b, `,"tags":[`...)
+		for i, tag := range item.Tags {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, tag)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"version":`...)
+	b = strconv.AppendInt(b, item.Version, 10)
+	var err error
+	b = append(b, `,"created_at":`...)
+	if b, err = appendJSONTime(b, item.CreatedAt); err != nil {
+		return nil, err
+	}
+	b = append(b, `,"updated_at":`...)
+	if b, err = appendJSONTime(b, item.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return append(b, '}'), nil
+}
+
+// appendJSONTime matches time.Time.MarshalJSON
+func appendJSONTime(b []byte, t time.Time) ([]byte, error) {
+	if y := t.Year(); y < 0 || y >= 10000 {
+		return nil, errors.New("Time.MarshalJSON: year outside of range [0,9999]")
+	}
+	b = append(b, '"')
+	b = t.AppendFormat(b, time.RFC3339Nano)
+	return append(b, '"'), nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString matches encoding/json's string encoding with HTML escaping:
+// <, > and & as well as U+2028 and U+2029 are escaped and invalid UTF-8 is replaced with a raw U+FFFD
+func appendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,157 @@
+package serializer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/usecase"
+)
+
+func newFastJSONItem(i int) *entity.Item {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.FixedZone("JST", 9*60*60))
+	return &entity.Item{
+		ID:              int64(i + 1),
+		Name:            fmt.Sprintf("ロレックス デイトジャスト %d", i),
+		Category:        "時計",
+		Brand:           "ROLEX",
+		PurchasePrice:   1500000 + i,
+		Currency:        "JPY",
+		PurchaseDate:    "2023-01-15",
+		Attributes:      map[string]string{"color": "black", "size": "36mm", "condition": "A"},
+		OwnerID:         "user-1",
+		MaintenanceCost: 85000,
+		ImageIDs:        []int64{4, 7},
+		Tags:            []string{"vintage", "記念日"},
+		Version:         3,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt.Add(time.Hour).UTC(),
+	}
+}
+
+func TestAppendItemJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		item *entity.Item
+	}{
+		{name: "正常系: 全項目あり", item: newFastJSONItem(0)},
+		{name: "正常系: 属性・所有者なしは省略", item: &entity.Item{ID: 1, Name: "バッグ", Category: "バッグ", CreatedAt: time.Unix(0, 0).UTC()}},
+		{name: "正常系: 空の属性は省略", item: &entity.Item{ID: 1, Attributes: map[string]string{}}},
+		{name: "正常系: nil", item: nil},
+		{
+			name: "正常系: エスケープが必要な文字列",
+			item: &entity.Item{
+				Name:       "<b>\"Tom\" & 'Jerry'</b> \\ /",
+				Brand:      "a\b\f\n\r\t\x00\x01\x1f\x7f",
+				Category:   "line\u2028para\u2029end",
+				OwnerID:    "bad\xff\xfeutf8\xe3\x81",
+				Attributes: map[string]string{"z<": "1", "a&": "2", "日本語": "値"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := json.Marshal(tt.item)
+			require.NoError(t, err)
+
+			actual, err := appendItemJSON(nil, tt.item)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestAppendItemJSON_Fields(t *testing.T) {
+	// entity.Item に項目を追加したらエンコーダーも更新する
+	var names []string
+	typ := reflect.TypeOf(entity.Item{})
+	for i := 0; i < typ.NumField(); i++ {
+		names = append(names, typ.Field(i).Name)
+	}
+	assert.Equal(t, []string{
+		"ID", "Name", "Category", "Brand", "PurchasePrice", "Currency", "PurchaseDate",
+		"Attributes", "OwnerID", "MaintenanceCost", "ImageIDs", "Tags", "Version", "CreatedAt", "UpdatedAt",
+	}, names)
+}
+
+func TestAppendItemJSON_InvalidTime(t *testing.T) {
+	item := &entity.Item{CreatedAt: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)}
+	_, err := appendItemJSON(nil, item)
+	assert.Error(t, err)
+}
+
+func TestJSONSerializer_FastJSON(t *testing.T) {
+	items := []*entity.Item{newFastJSONItem(0), newFastJSONItem(1)}
+	tests := []struct {
+		name    string
+		value   interface{}
+		version apiversion.Version
+	}{
+		{name: "正常系: アイテム", value: items[0]},
+		{name: "正常系: アイテム一覧", value: &usecase.ItemList{Items: items, Total: 2}},
+		{name: "正常系: v2のアイテム一覧", value: &usecase.ItemList{Items: items, Total: 12, Page: 2, PageSize: 10}, version: apiversion.V2},
+		{name: "正常系: v2の空の一覧", value: &usecase.ItemList{Total: 0, Page: 1, PageSize: 10}, version: apiversion.V2},
+		{name: "正常系: 空の一覧", value: []*entity.Item{}},
+		{name: "正常系: nil の一覧", value: []*entity.Item(nil)},
+		{name: "正常系: 対象外の値は encoding/json", value: map[string]int{"時計": 2}},
+	}
+
+	for _, tt := range tests {
+		for _, enabled := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/fast=%v", tt.name, enabled), func(t *testing.T) {
+				SetFastJSON(enabled)
+				defer SetFastJSON(true)
+
+				req := httptest.NewRequest("GET", "/items", nil)
+				if tt.version != 0 {
+					req = req.WithContext(apiversion.WithVersion(req.Context(), tt.version))
+				}
+				expected, err := json.Marshal(plainValue(req, tt.value))
+				require.NoError(t, err)
+
+				body, err := jsonSerializer{}.Serialize(req, 200, tt.value)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(body))
+			})
+		}
+	}
+}
+
+func BenchmarkJSONSerializer_ItemList(b *testing.B) {
+	items := make([]*entity.Item, 100)
+	for i := range items {
+		items[i] = newFastJSONItem(i)
+	}
+	list := &usecase.ItemList{Items: items, Total: len(items)}
+	req := httptest.NewRequest("GET", "/items", nil)
+
+	for _, bm := range []struct {
+		name string
+		fast bool
+	}{{"std", false}, {"fast", true}} {
+		b.Run(bm.name, func(b *testing.B) {
+			SetFastJSON(bm.fast)
+			defer SetFastJSON(true)
+
+			s := jsonSerializer{}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				body, err := s.Serialize(req, 200, list)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.SetBytes(int64(len(body)))
+				s.release(body)
+			}
+		})
+	}
+}
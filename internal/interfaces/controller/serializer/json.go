@@ -0,0 +1,77 @@
+package serializer
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/jsonapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+type jsonSerializer struct{}
+
+func (jsonSerializer) Format() string { return FormatJSON }
+
+func (jsonSerializer) MediaTypes() []string { return []string{echo.MIMEApplicationJSON} }
+
+func (jsonSerializer) Serialize(req *http.Request, status int, v interface{}) ([]byte, error) {
+	v = plainValue(req, v)
+	if fastJSON.Load() {
+		// Items and item lists are encoded without reflection into a pooled buffer (released by Respond)
+		b, ok, err := appendFastJSON(getBuffer(), v)
+		if ok {
+			return b, err
+		}
+		putBuffer(b)
+	}
+	return json.Marshal(v)
+}
+
+// release returns a body produced by Serialize to the buffer pool once it has been written
+func (jsonSerializer) release(body []byte) {
+	putBuffer(body)
+}
+
+type jsonAPISerializer struct{}
+
+func (jsonAPISerializer) Format() string { return FormatJSONAPI }
+
+func (jsonAPISerializer) MediaTypes() []string { return []string{jsonapi.MediaType} }
+
+func (jsonAPISerializer) Serialize(req *http.Request, status int, v interface{}) ([]byte, error) {
+	var doc jsonapi.Document
+	switch v := v.(type) {
+	case *entity.Item:
+		doc = jsonapi.ItemDocument(v)
+	case *usecase.ItemList:
+		doc = jsonapi.ItemListDocument(v.Items, jsonapi.Page{
+			URL:      req.URL,
+			Page:     v.Page,
+			PageSize: v.PageSize,
+			Total:    v.Total,
+		})
+	case *usecase.CategorySummary:
+		meta := map[string]interface{}{
+			"categories": v.Categories,
+			"total":      v.Total,
+		}
+		if v.Stats != nil {
+			meta["stats"] = v.Stats
+		}
+		if v.Value != nil {
+			meta["value"] = v.Value
+		}
+		if v.CategoryNames != nil {
+			meta["category_names"] = v.CategoryNames
+		}
+		doc = jsonapi.MetaDocument(meta)
+	case ErrorResponse:
+		doc = jsonapi.ErrorDocument(status, v.Error, v.Code, v.Details, v.DetailCodes)
+	default:
+		return nil, errUnsupportedValue
+	}
+	return json.Marshal(doc)
+}
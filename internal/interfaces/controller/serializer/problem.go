@@ -0,0 +1,71 @@
+package serializer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Error formats, selectable with ERROR_FORMAT
+const (
+	// ErrorFormatDefault sends ErrorResponse in the negotiated format
+	ErrorFormatDefault = "default"
+	// ErrorFormatProblem sends RFC 7807 problem details instead of ErrorResponse to JSON clients
+	ErrorFormatProblem = "problem"
+)
+
+// problemErrors is set when JSON clients get problem details without asking for them (see SetErrorFormat)
+var problemErrors bool
+
+// SetErrorFormat sets how errors are sent to clients that do not list application/problem+json in Accept.
+// Clients that do always get problem details.
+func SetErrorFormat(format string) error {
+	switch format {
+	case ErrorFormatDefault:
+		problemErrors = false
+	case ErrorFormatProblem:
+		problemErrors = true
+	default:
+		return fmt.Errorf("unsupported error format: %s (must be one of: %s, %s)", format, ErrorFormatDefault, ErrorFormatProblem)
+	}
+	return nil
+}
+
+// wantsProblem reports whether an error for req is sent as problem details rather than with s
+func wantsProblem(req *http.Request, s Serializer) bool {
+	if problem.Accepted(req.Header.Get(echo.HeaderAccept)) {
+		return true
+	}
+	return problemErrors && s.Format() == FormatJSON
+}
+
+// RespondError writes an error response of an endpoint that only speaks JSON:
+// problem details when the client or ERROR_FORMAT asks for them, otherwise ErrorResponse as JSON
+func RespondError(c echo.Context, status int, resp ErrorResponse) error {
+	resp = withCodes(status, resp)
+	if wantsProblem(c.Request(), jsonSerializer{}) {
+		return respondProblem(c, status, resp)
+	}
+	return c.JSON(status, resp)
+}
+
+func respondProblem(c echo.Context, status int, resp ErrorResponse) error {
+	// duplicates is an extension member like code and errors
+	body, err := json.Marshal(struct {
+		problem.Details
+		Duplicates []usecase.DuplicateCandidate `json:"duplicates,omitempty"`
+	}{
+		Details:    problem.New(status, resp.Code, resp.Error, resp.Details, resp.DetailCodes, c.Request().URL.Path),
+		Duplicates: resp.Duplicates,
+	})
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	return c.Blob(status, problem.MediaType, body)
+}
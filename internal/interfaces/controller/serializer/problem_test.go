@@ -0,0 +1,120 @@
+package serializer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetErrorFormat(t *testing.T) {
+	assert.Error(t, SetErrorFormat("xml"))
+	assert.False(t, problemErrors)
+}
+
+func TestRespond_Problem(t *testing.T) {
+	resp := ErrorResponse{Error: "validation failed", Details: []string{"name is required"}}
+	problemBody := `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/items",` +
+		`"code":"VALIDATION_FAILED","errors":[{"field":"name","code":"VALIDATION_NAME_REQUIRED","message":"name is required"}]}`
+
+	tests := []struct {
+		name                string
+		accept              string
+		errorFormat         string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "正常系: 指定なしは従来の形式",
+			errorFormat:         ErrorFormatDefault,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"validation failed","code":"VALIDATION_FAILED","details":["name is required"],"detail_codes":["VALIDATION_NAME_REQUIRED"]}`,
+		},
+		{
+			name:                "正常系: Accept で problem+json を指定",
+			accept:              "application/json, application/problem+json",
+			errorFormat:         ErrorFormatDefault,
+			expectedContentType: "application/problem+json",
+			expectedBody:        problemBody,
+		},
+		{
+			name:                "正常系: ERROR_FORMAT=problem",
+			errorFormat:         ErrorFormatProblem,
+			expectedContentType: "application/problem+json",
+			expectedBody:        problemBody,
+		},
+		{
+			name:                "正常系: ERROR_FORMAT=problem でもJSON:APIはその形式",
+			accept:              "application/vnd.api+json",
+			errorFormat:         ErrorFormatProblem,
+			expectedContentType: "application/vnd.api+json",
+			expectedBody:        `{"errors":[{"status":"400","code":"VALIDATION_NAME_REQUIRED","title":"validation failed","detail":"name is required"}],"jsonapi":{"version":"1.1"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetErrorFormat(tt.errorFormat))
+			defer SetErrorFormat(ErrorFormatDefault)
+
+			req := httptest.NewRequest(http.MethodPost, "/items", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, Respond(echo.New().NewContext(req, rec), http.StatusBadRequest, resp))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	resp := ErrorResponse{Error: "label template not found"}
+
+	tests := []struct {
+		name                string
+		accept              string
+		errorFormat         string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "正常系: 従来の形式",
+			accept:              "application/xml",
+			errorFormat:         ErrorFormatDefault,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        `{"error":"label template not found","code":"LABEL_TEMPLATE_NOT_FOUND"}`,
+		},
+		{
+			name:                "正常系: ERROR_FORMAT=problem",
+			accept:              "application/xml",
+			errorFormat:         ErrorFormatProblem,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"label template not found","instance":"/labels","code":"LABEL_TEMPLATE_NOT_FOUND"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetErrorFormat(tt.errorFormat))
+			defer SetErrorFormat(ErrorFormatDefault)
+
+			req := httptest.NewRequest(http.MethodGet, "/labels", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, RespondError(echo.New().NewContext(req, rec), http.StatusNotFound, resp))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package serializer
+
+import (
+	"encoding/binary"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// protobufSerializer encodes the messages of api/proto/item/v1/item.proto:
+// Item, ListItemsResponse, CategorySummary and ErrorResponse.
+// The wire format is written directly so the HTTP API does not depend on the generated gRPC code.
+type protobufSerializer struct{}
+
+func (protobufSerializer) Format() string { return FormatProtobuf }
+
+func (protobufSerializer) MediaTypes() []string {
+	return []string{"application/x-protobuf", "application/protobuf", "application/vnd.google.protobuf"}
+}
+
+func (protobufSerializer) Serialize(req *http.Request, status int, v interface{}) ([]byte, error) {
+	var m protoMessage
+	switch v := v.(type) {
+	case *entity.Item:
+		m = itemMessage(v)
+	case *usecase.ItemList:
+		// ListItemsResponse
+		for _, item := range v.Items {
+			m.message(1, itemMessage(item))
+		}
+		m.varint(2, uint64(v.Total))
+		m.varint(3, uint64(v.Page))
+		m.varint(4, uint64(v.PageSize))
+	case *usecase.CategorySummary:
+		// CategorySummary
+		for _, category := range sortedKeys(v.Categories) {
+			var entry protoMessage
+			entry.string(1, category)
+			entry.varint(2, uint64(int64(v.Categories[category])))
+			m.message(1, entry)
+		}
+		m.varint(2, uint64(v.Total))
+		if v.Value != nil {
+			m.message(3, valueSummaryMessage(v.Value))
+		}
+	case ErrorResponse:
+		// ErrorResponse
+		m.string(1, v.Error)
+		for _, detail := range v.Details {
+			m.string(2, detail)
+		}
+		m.string(3, v.Code)
+		for _, code := range v.DetailCodes {
+			m.string(4, code)
+		}
+	default:
+		return nil, errUnsupportedValue
+	}
+	return m, nil
+}
+
+func itemMessage(item *entity.Item) protoMessage {
+	var m protoMessage
+	m.varint(1, uint64(item.ID))
+	m.string(2, item.Name)
+	m.string(3, item.Category)
+	m.string(4, item.Brand)
+	m.varint(5, uint64(int64(item.PurchasePrice)))
+	m.string(6, item.PurchaseDate)
+	for _, key := range sortedKeys(item.Attributes) {
+		var entry protoMessage
+		entry.string(1, key)
+		entry.string(2, item.Attributes[key])
+		m.message(7, entry)
+	}
+	m.string(8, item.OwnerID)
+	m.varint(9, uint64(item.Version))
+	if !item.CreatedAt.IsZero() {
+		m.message(10, timestampMessage(item.CreatedAt))
+	}
+	if !item.UpdatedAt.IsZero() {
+		m.message(11, timestampMessage(item.UpdatedAt))
+	}
+	m.varint(12, uint64(int64(item.MaintenanceCost)))
+	m.packedVarints(13, item.ImageIDs)
+	for _, tag := range item.Tags {
+		m.string(14, tag)
+	}
+	return m
+}
+
+// timestampMessage encodes a google.protobuf.Timestamp
+func timestampMessage(t time.Time) protoMessage {
+	var m protoMessage
+	m.varint(1, uint64(t.Unix()))
+	m.varint(2, uint64(t.Nanosecond()))
+	return m
+}
+
+// protoMessage is an encoded message; fields with the zero value are omitted as in proto3
+type protoMessage []byte
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+func (m *protoMessage) tag(field, wireType int) {
+	*m = binary.AppendUvarint(*m, uint64(field)<<3|uint64(wireType))
+}
+
+// varint writes an integer field; negative values must be sign-extended to 64 bits by the caller (uint64(int64(x)))
+func (m *protoMessage) varint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	m.tag(field, wireVarint)
+	*m = binary.AppendUvarint(*m, v)
+}
+
+// packedVarints writes a repeated integer field in the packed encoding proto3 uses by default
+func (m *protoMessage) packedVarints(field int, vs []int64) {
+	if len(vs) == 0 {
+		return
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = binary.AppendUvarint(packed, uint64(v))
+	}
+	m.tag(field, wireBytes)
+	*m = binary.AppendUvarint(*m, uint64(len(packed)))
+	*m = append(*m, packed...)
+}
+
+func (m *protoMessage) double(field int, v float64) {
+	if v == 0 {
+		return
+	}
+	m.tag(field, wireFixed64)
+	*m = binary.LittleEndian.AppendUint64(*m, math.Float64bits(v))
+}
+
+func (m *protoMessage) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	m.tag(field, wireBytes)
+	*m = binary.AppendUvarint(*m, uint64(len(s)))
+	*m = append(*m, s...)
+}
+
+// message writes an embedded message field; empty messages are still written so that map entries are kept
+func (m *protoMessage) message(field int, sub protoMessage) {
+	m.tag(field, wireBytes)
+	*m = binary.AppendUvarint(*m, uint64(len(sub)))
+	*m = append(*m, sub...)
+}
+
+func valueSummaryMessage(v *usecase.ValueSummary) protoMessage {
+	var m protoMessage
+	m.string(1, v.Currency)
+	for _, category := range sortedKeys(v.Categories) {
+		var entry protoMessage
+		entry.string(1, category)
+		entry.double(2, v.Categories[category])
+		m.message(2, entry)
+	}
+	m.double(3, v.Total)
+	m.double(4, v.ExchangeRate)
+	m.string(5, v.RateDate)
+	return m
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
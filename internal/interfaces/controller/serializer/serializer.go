@@ -0,0 +1,182 @@
+// Package serializer encodes controller responses in the representation negotiated with the Accept header
+// (JSON, JSON:API, MessagePack, Protobuf or XML).
+package serializer
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Response formats, selectable with RESPONSE_FORMAT
+const (
+	FormatJSON        = "json"
+	FormatJSONAPI     = "jsonapi"
+	FormatMessagePack = "msgpack"
+	FormatProtobuf    = "protobuf"
+	FormatXML         = "xml"
+)
+
+// ErrorResponse represents the standard error response format.
+// Code and DetailCodes are stable machine-readable codes; Respond fills them in from the domain error catalog when not set.
+type ErrorResponse struct {
+	Error   string   `json:"error"`
+	Code    string   `json:"code,omitempty"`
+	Details []string `json:"details,omitempty"`
+	// DetailCodes holds the code of each entry of Details, in the same order
+	DetailCodes []string `json:"detail_codes,omitempty"`
+	// Duplicates are the existing items an item to be created closely matches (DUPLICATE_ITEM)
+	Duplicates []usecase.DuplicateCandidate `json:"duplicates,omitempty"`
+}
+
+// withCodes fills in the codes of resp that were not set from the domain error catalog
+func withCodes(status int, resp ErrorResponse) ErrorResponse {
+	if resp.Code == "" {
+		resp.Code = string(domainErrors.CodeFor(status, resp.Error))
+	}
+	if len(resp.DetailCodes) != len(resp.Details) {
+		resp.DetailCodes = make([]string, len(resp.Details))
+		for i, detail := range resp.Details {
+			resp.DetailCodes[i] = string(domainErrors.ValidationCode(detail))
+		}
+	}
+	return resp
+}
+
+// errUnsupportedValue is returned by serializers that have no representation for a value
+var errUnsupportedValue = errors.New("value has no representation in this format")
+
+// Serializer encodes responses in one format.
+// Values are *entity.Item, *usecase.ItemList, *usecase.CategorySummary, ErrorResponse or any JSON-encodable value;
+// a serializer returns errUnsupportedValue for values it cannot represent, which are then sent as JSON.
+type Serializer interface {
+	Format() string
+	// MediaTypes lists the media types accepted for the format; the first is the response Content-Type
+	MediaTypes() []string
+	Serialize(req *http.Request, status int, v interface{}) ([]byte, error)
+}
+
+var (
+	serializers = []Serializer{
+		jsonSerializer{},
+		jsonAPISerializer{},
+		messagePackSerializer{},
+		protobufSerializer{},
+		xmlSerializer{},
+	}
+
+	// defaultSerializer is used when the Accept header does not ask for a supported format
+	defaultSerializer Serializer = jsonSerializer{}
+)
+
+// SetDefaultFormat sets the format used when the client does not choose one with Accept
+func SetDefaultFormat(format string) error {
+	for _, s := range serializers {
+		if s.Format() == format {
+			defaultSerializer = s
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported response format: %s (must be one of: %s)", format, strings.Join(Formats(), ", "))
+}
+
+// Formats returns the names of the supported formats
+func Formats() []string {
+	formats := make([]string, 0, len(serializers))
+	for _, s := range serializers {
+		formats = append(formats, s.Format())
+	}
+	return formats
+}
+
+// Negotiate returns the serializer for the first supported media type in the Accept header, or the default one
+func Negotiate(req *http.Request) Serializer {
+	for _, accepted := range strings.Split(req.Header.Get(echo.HeaderAccept), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		for _, s := range serializers {
+			for _, mt := range s.MediaTypes() {
+				if mt == mediaType {
+					return s
+				}
+			}
+		}
+	}
+	return defaultSerializer
+}
+
+// Respond writes v with the negotiated serializer
+func Respond(c echo.Context, status int, v interface{}) error {
+	s := Negotiate(c.Request())
+	if resp, ok := v.(ErrorResponse); ok {
+		resp = withCodes(status, resp)
+		if wantsProblem(c.Request(), s) {
+			return respondProblem(c, status, resp)
+		}
+		v = resp
+	}
+	body, err := s.Serialize(c.Request(), status, htmlSafe(s, v))
+	if errors.Is(err, errUnsupportedValue) {
+		s = jsonSerializer{}
+		body, err = s.Serialize(c.Request(), status, htmlSafe(s, v))
+	}
+	if err != nil {
+		return err
+	}
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	err = c.Blob(status, s.MediaTypes()[0], body)
+	if r, ok := s.(releaser); ok {
+		r.release(body)
+	}
+	return err
+}
+
+// releaser is implemented by serializers that encode into reusable buffers
+type releaser interface {
+	release(body []byte)
+}
+
+// contentType returns the media type of the request body without parameters
+func contentType(req *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// itemPage is the paginated envelope item lists are sent in by the plain formats from API v2 on
+type itemPage struct {
+	Items    []*entity.Item `json:"items"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+// plainValue returns the value sent by the plain formats (JSON and MessagePack); item lists are sent as a bare array
+// in API v1 (with the total in X-Total-Count) and as an itemPage envelope from v2 on
+func plainValue(req *http.Request, v interface{}) interface{} {
+	list, ok := v.(*usecase.ItemList)
+	if !ok {
+		return v
+	}
+	if apiversion.FromContext(req.Context()) < apiversion.V2 {
+		return list.Items
+	}
+	page := itemPage{Items: list.Items, Total: list.Total, Page: list.Page, PageSize: list.PageSize}
+	if page.Items == nil {
+		page.Items = []*entity.Item{}
+	}
+	return page
+}
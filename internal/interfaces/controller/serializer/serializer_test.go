@@ -0,0 +1,103 @@
+package serializer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		accept         string
+		defaultFormat  string
+		expectedFormat string
+	}{
+		{name: "正常系: 指定なしはデフォルト", accept: "", defaultFormat: FormatJSON, expectedFormat: FormatJSON},
+		{name: "正常系: MessagePack", accept: "application/msgpack", defaultFormat: FormatJSON, expectedFormat: FormatMessagePack},
+		{name: "正常系: Protobuf（パラメーター付き）", accept: "text/html, application/x-protobuf;q=0.9", defaultFormat: FormatJSON, expectedFormat: FormatProtobuf},
+		{name: "正常系: JSON:API", accept: "application/vnd.api+json", defaultFormat: FormatJSON, expectedFormat: FormatJSONAPI},
+		{name: "正常系: 未対応の形式のみならデフォルト", accept: "text/html, */*", defaultFormat: FormatMessagePack, expectedFormat: FormatMessagePack},
+		{name: "正常系: 明示したJSONはデフォルトより優先", accept: "application/json", defaultFormat: FormatProtobuf, expectedFormat: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, SetDefaultFormat(tt.defaultFormat))
+			defer SetDefaultFormat(FormatJSON)
+
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			assert.Equal(t, tt.expectedFormat, Negotiate(req).Format())
+		})
+	}
+}
+
+func TestSetDefaultFormat(t *testing.T) {
+	assert.Error(t, SetDefaultFormat("yaml"))
+	assert.Equal(t, FormatJSON, defaultSerializer.Format())
+}
+
+func TestRespond(t *testing.T) {
+	e := echo.New()
+
+	t.Run("正常系: ネゴシエーションした形式で返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set(echo.HeaderAccept, "application/x-protobuf")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, Respond(e.NewContext(req, rec), http.StatusOK, &entity.Item{ID: 1}))
+
+		assert.Equal(t, "application/x-protobuf", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, echo.HeaderAccept, rec.Header().Get(echo.HeaderVary))
+		assert.Equal(t, []byte{0x08, 0x01}, rec.Body.Bytes())
+	})
+
+	t.Run("正常系: 表現できない値はJSONで返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set(echo.HeaderAccept, "application/x-protobuf")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, Respond(e.NewContext(req, rec), http.StatusOK, map[string]string{"status": "ok"}))
+
+		assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	})
+
+	t.Run("正常系: HTMLエスケープは出力時に1回だけ行う", func(t *testing.T) {
+		SetHTMLEscaping(true)
+		defer SetHTMLEscaping(false)
+		item := &entity.Item{ID: 1, Name: "<b>Tiffany & Co.</b>", Brand: "Tiffany &amp; Co."}
+
+		for _, accept := range []string{echo.MIMEApplicationJSON, "application/vnd.api+json"} {
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			req.Header.Set(echo.HeaderAccept, accept)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, Respond(e.NewContext(req, rec), http.StatusOK, item))
+
+			assert.Contains(t, rec.Body.String(), `\u0026lt;b\u0026gt;Tiffany \u0026amp; Co.\u0026lt;/b\u0026gt;`, accept)
+			assert.Contains(t, rec.Body.String(), `Tiffany \u0026amp;amp; Co.`, accept)
+		}
+		// 保存されている値（キャッシュされたアイテム）は書き換えない
+		assert.Equal(t, "<b>Tiffany & Co.</b>", item.Name)
+	})
+
+	t.Run("正常系: HTMLエスケープが無効なら保存した値のまま返す", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, Respond(e.NewContext(req, rec), http.StatusOK, &usecase.ItemList{Items: []*entity.Item{{ID: 1, Name: "A&B"}}}))
+
+		assert.Contains(t, rec.Body.String(), `"name":"A\u0026B"`)
+	})
+}
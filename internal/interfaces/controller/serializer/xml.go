@@ -0,0 +1,292 @@
+package serializer
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// xmlSerializer encodes items, item lists, the summary and errors as XML for integrations that cannot consume JSON
+type xmlSerializer struct{}
+
+func (xmlSerializer) Format() string { return FormatXML }
+
+func (xmlSerializer) MediaTypes() []string { return []string{"application/xml", "text/xml"} }
+
+func (xmlSerializer) Serialize(req *http.Request, status int, v interface{}) ([]byte, error) {
+	var doc interface{}
+	switch v := v.(type) {
+	case *entity.Item:
+		doc = newXMLItem(v)
+	case *usecase.ItemList:
+		list := xmlItemList{Total: v.Total, Page: v.Page, PageSize: v.PageSize, Items: make([]xmlItem, 0, len(v.Items))}
+		for _, item := range v.Items {
+			list.Items = append(list.Items, newXMLItem(item))
+		}
+		doc = list
+	case *usecase.CategorySummary:
+		summary := xmlSummary{Total: v.Total}
+		for _, category := range sortedKeys(v.Categories) {
+			summary.Categories = append(summary.Categories, xmlCategoryCount{Name: category, DisplayName: v.CategoryNames[category], Count: v.Categories[category]})
+		}
+		if v.Stats != nil {
+			stats := &xmlStats{}
+			for _, category := range sortedKeys(v.Stats) {
+				s := v.Stats[category]
+				stats.Categories = append(stats.Categories, xmlCategoryStats{Name: category, Count: s.Count, Min: s.Min, Max: s.Max, Avg: s.Avg, Sum: s.Sum})
+			}
+			summary.Stats = stats
+		}
+		if v.Value != nil {
+			value := &xmlValueSummary{Currency: v.Value.Currency, Total: v.Value.Total, ExchangeRate: v.Value.ExchangeRate, RateDate: v.Value.RateDate}
+			for _, category := range sortedKeys(v.Value.Categories) {
+				value.Categories = append(value.Categories, xmlCategoryValue{Name: category, Value: v.Value.Categories[category]})
+			}
+			for _, currency := range sortedKeys(v.Value.Native) {
+				n := v.Value.Native[currency]
+				native := xmlNativeValue{Currency: currency, Total: n.Total, Converted: n.Converted, ExchangeRate: n.ExchangeRate}
+				for _, category := range sortedKeys(n.Categories) {
+					native.Categories = append(native.Categories, xmlCategoryValue{Name: category, Value: n.Categories[category]})
+				}
+				value.Native = append(value.Native, native)
+			}
+			summary.Value = value
+		}
+		doc = summary
+	case ErrorResponse:
+		x := xmlError{Message: v.Error, Code: v.Code}
+		if len(v.Details) > 0 {
+			x.Details = &xmlDetails{}
+			for i, detail := range v.Details {
+				d := xmlDetail{Message: detail}
+				if i < len(v.DetailCodes) {
+					d.Code = v.DetailCodes[i]
+				}
+				x.Details.Details = append(x.Details.Details, d)
+			}
+		}
+		if len(v.Duplicates) > 0 {
+			x.Duplicates = &xmlDuplicates{}
+			for _, candidate := range v.Duplicates {
+				x.Duplicates.Items = append(x.Duplicates.Items, xmlDuplicate{
+					ID:           candidate.ID,
+					Similarity:   candidate.Similarity,
+					Name:         candidate.Name,
+					Brand:        candidate.Brand,
+					PurchaseDate: candidate.PurchaseDate,
+				})
+			}
+		}
+		doc = x
+	default:
+		return nil, errUnsupportedValue
+	}
+
+	body, err := xml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// xmlItem is the XML representation of an item; attributes become <attribute key="...">value</attribute>
+type xmlItem struct {
+	XMLName         xml.Name       `xml:"item"`
+	ID              int64          `xml:"id,omitempty"`
+	Name            string         `xml:"name"`
+	Category        string         `xml:"category"`
+	Brand           string         `xml:"brand"`
+	PurchasePrice   int            `xml:"purchase_price"`
+	Currency        string         `xml:"currency,omitempty"`
+	PurchaseDate    string         `xml:"purchase_date"`
+	Attributes      *xmlAttributes `xml:"attributes,omitempty"`
+	OwnerID         string         `xml:"owner_id,omitempty"`
+	MaintenanceCost int            `xml:"maintenance_cost,omitempty"`
+	ImageIDs        *xmlImageIDs   `xml:"image_ids,omitempty"`
+	Tags            *xmlTags       `xml:"tags,omitempty"`
+	Version         int64          `xml:"version,omitempty"`
+	CreatedAt       *time.Time     `xml:"created_at,omitempty"`
+	UpdatedAt       *time.Time     `xml:"updated_at,omitempty"`
+}
+
+// xmlImageIDs wraps the image IDs as <image_ids><image_id>1</image_id>...</image_ids>, omitted if the item has none
+type xmlImageIDs struct {
+	IDs []int64 `xml:"image_id"`
+}
+
+// xmlTags wraps the tag names as <tags><tag>vintage</tag>...</tags>, omitted if the item has none
+type xmlTags struct {
+	Tags []string `xml:"tag"`
+}
+
+// xmlAttributes wraps the attribute list so that items without attributes have no <attributes> element
+type xmlAttributes struct {
+	Attributes []xmlAttribute `xml:"attribute"`
+}
+
+type xmlAttribute struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlItemList struct {
+	XMLName  xml.Name  `xml:"items"`
+	Total    int       `xml:"total,attr"`
+	Page     int       `xml:"page,attr"`
+	PageSize int       `xml:"page_size,attr"`
+	Items    []xmlItem `xml:"item"`
+}
+
+type xmlSummary struct {
+	XMLName    xml.Name           `xml:"summary"`
+	Total      int                `xml:"total,attr"`
+	Categories []xmlCategoryCount `xml:"category"`
+	Stats      *xmlStats          `xml:"stats,omitempty"`
+	Value      *xmlValueSummary   `xml:"value,omitempty"`
+}
+
+type xmlCategoryCount struct {
+	Name        string `xml:"name,attr"`
+	DisplayName string `xml:"display_name,attr,omitempty"`
+	Count       int    `xml:",chardata"`
+}
+
+// xmlStats lists the purchase price aggregates as <stats><category name="..." count="..." min="..." .../></stats>
+type xmlStats struct {
+	Categories []xmlCategoryStats `xml:"category"`
+}
+
+type xmlCategoryStats struct {
+	Name  string  `xml:"name,attr"`
+	Count int     `xml:"count,attr"`
+	Min   int     `xml:"min,attr"`
+	Max   int     `xml:"max,attr"`
+	Avg   float64 `xml:"avg,attr"`
+	Sum   int     `xml:"sum,attr"`
+}
+
+type xmlValueSummary struct {
+	Currency     string             `xml:"currency,attr"`
+	Total        float64            `xml:"total,attr"`
+	ExchangeRate float64            `xml:"exchange_rate,attr"`
+	RateDate     string             `xml:"rate_date,attr,omitempty"`
+	Categories   []xmlCategoryValue `xml:"category"`
+	Native       []xmlNativeValue   `xml:"native"`
+}
+
+// xmlNativeValue is the totals in one currency the items were bought in, as <native currency="USD" ...><category .../></native>
+type xmlNativeValue struct {
+	Currency     string             `xml:"currency,attr"`
+	Total        float64            `xml:"total,attr"`
+	Converted    float64            `xml:"converted,attr"`
+	ExchangeRate float64            `xml:"exchange_rate,attr"`
+	Categories   []xmlCategoryValue `xml:"category"`
+}
+
+type xmlCategoryValue struct {
+	Name  string  `xml:"name,attr"`
+	Value float64 `xml:",chardata"`
+}
+
+type xmlError struct {
+	XMLName xml.Name    `xml:"error"`
+	Message string      `xml:"message"`
+	Code    string      `xml:"code,omitempty"`
+	Details *xmlDetails `xml:"details,omitempty"`
+	// Duplicates are the candidates of a DUPLICATE_ITEM error
+	Duplicates *xmlDuplicates `xml:"duplicates,omitempty"`
+}
+
+type xmlDuplicates struct {
+	Items []xmlDuplicate `xml:"item"`
+}
+
+type xmlDuplicate struct {
+	ID           int64   `xml:"id,attr"`
+	Similarity   float64 `xml:"similarity,attr"`
+	Name         string  `xml:"name"`
+	Brand        string  `xml:"brand"`
+	PurchaseDate string  `xml:"purchase_date"`
+}
+
+type xmlDetails struct {
+	Details []xmlDetail `xml:"detail"`
+}
+
+type xmlDetail struct {
+	Code    string `xml:"code,attr,omitempty"`
+	Message string `xml:",chardata"`
+}
+
+func newXMLItem(item *entity.Item) xmlItem {
+	x := xmlItem{
+		ID:              item.ID,
+		Name:            item.Name,
+		Category:        item.Category,
+		Brand:           item.Brand,
+		PurchasePrice:   item.PurchasePrice,
+		Currency:        item.Currency,
+		PurchaseDate:    item.PurchaseDate,
+		OwnerID:         item.OwnerID,
+		MaintenanceCost: item.MaintenanceCost,
+		Version:         item.Version,
+	}
+	if len(item.ImageIDs) > 0 {
+		x.ImageIDs = &xmlImageIDs{IDs: item.ImageIDs}
+	}
+	if len(item.Tags) > 0 {
+		x.Tags = &xmlTags{Tags: item.Tags}
+	}
+	if len(item.Attributes) > 0 {
+		x.Attributes = &xmlAttributes{}
+		for _, key := range sortedKeys(item.Attributes) {
+			x.Attributes.Attributes = append(x.Attributes.Attributes, xmlAttribute{Key: key, Value: item.Attributes[key]})
+		}
+	}
+	if !item.CreatedAt.IsZero() {
+		x.CreatedAt = &item.CreatedAt
+	}
+	if !item.UpdatedAt.IsZero() {
+		x.UpdatedAt = &item.UpdatedAt
+	}
+	return x
+}
+
+// IsXML reports whether a request body is XML, judging by its Content-Type
+func IsXML(req *http.Request) bool {
+	for _, mt := range (xmlSerializer{}).MediaTypes() {
+		if contentType(req) == mt {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeCreateItemXML reads a create request in the same <item> format as XML responses.
+// The purchase price is an integer in minor units of the currency, as in responses.
+func DecodeCreateItemXML(r io.Reader) (usecase.CreateItemInput, error) {
+	var x xmlItem
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return usecase.CreateItemInput{}, err
+	}
+
+	input := usecase.CreateItemInput{
+		Name:          x.Name,
+		Category:      x.Category,
+		Brand:         x.Brand,
+		PurchasePrice: entity.MinorUnits(int64(x.PurchasePrice)),
+		Currency:      x.Currency,
+		PurchaseDate:  x.PurchaseDate,
+	}
+	if x.Attributes != nil && len(x.Attributes.Attributes) > 0 {
+		input.Attributes = make(map[string]string, len(x.Attributes.Attributes))
+		for _, attr := range x.Attributes.Attributes {
+			input.Attributes[attr.Key] = attr.Value
+		}
+	}
+	return input, nil
+}
@@ -0,0 +1,45 @@
+package settings
+
+import (
+	"net/http"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SettingsHandler struct {
+	settingsUsecase usecase.SettingsUsecase
+}
+
+func NewSettingsHandler(settingsUsecase usecase.SettingsUsecase) *SettingsHandler {
+	return &SettingsHandler{
+		settingsUsecase: settingsUsecase,
+	}
+}
+
+// GetListSettings returns the list defaults of the requesting tenant
+func (h *SettingsHandler) GetListSettings(c echo.Context) error {
+	settings, err := h.settingsUsecase.GetListSettings(c.Request().Context(), itemController.TenantID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
+
+// UpdateListSettings replaces the list defaults of the requesting tenant
+func (h *SettingsHandler) UpdateListSettings(c echo.Context) error {
+	var input usecase.UpdateListSettingsInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	settings, err := h.settingsUsecase.UpdateListSettings(c.Request().Context(), itemController.TenantID(c), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,54 @@
+package shares
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ShareHandler struct {
+	shareUsecase usecase.ShareUsecase
+}
+
+func NewShareHandler(shareUsecase usecase.ShareUsecase) *ShareHandler {
+	return &ShareHandler{
+		shareUsecase: shareUsecase,
+	}
+}
+
+// CreateShareLink issues a signed, expiring link to a read-only view of the item
+func (h *ShareHandler) CreateShareLink(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	// An empty body shares for the default period without the price
+	var input usecase.ShareLinkInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	link, err := h.shareUsecase.CreateShareLink(c.Request().Context(), itemController.UserID(c), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, link)
+}
+
+// GetSharedItem is public: the token is the only credential
+func (h *ShareHandler) GetSharedItem(c echo.Context) error {
+	item, err := h.shareUsecase.GetSharedItem(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return err
+	}
+
+	// The view must not outlive the link in shared caches
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.JSON(http.StatusOK, item)
+}
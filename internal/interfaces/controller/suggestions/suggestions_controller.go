@@ -0,0 +1,41 @@
+package suggestions
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type SuggestionHandler struct {
+	nameSuggestUsecase usecase.NameSuggestUsecase
+}
+
+func NewSuggestionHandler(nameSuggestUsecase usecase.NameSuggestUsecase) *SuggestionHandler {
+	return &SuggestionHandler{
+		nameSuggestUsecase: nameSuggestUsecase,
+	}
+}
+
+// SuggestNames returns the item names with a word starting with ?q=, for the search box.
+// ?limit= is the number of suggestions (10 by default).
+func (h *SuggestionHandler) SuggestNames(c echo.Context) error {
+	query := usecase.NameSuggestQuery{Text: c.QueryParam("q")}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "limit must be an integer")
+		}
+		query.Limit = limit
+	}
+
+	suggestions, err := h.nameSuggestUsecase.SuggestNames(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
+}
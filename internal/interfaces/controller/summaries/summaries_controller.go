@@ -0,0 +1,105 @@
+package summaries
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/tabular"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SummaryHandler struct {
+	summaryUsecase usecase.SummaryUsecase
+}
+
+func NewSummaryHandler(summaryUsecase usecase.SummaryUsecase) *SummaryHandler {
+	return &SummaryHandler{
+		summaryUsecase: summaryUsecase,
+	}
+}
+
+// GetBrandSummary returns the item counts by brand; ?currency= adds the purchase price totals in that currency.
+// Like the other summaries and stats, it is sent as CSV with ?format=csv.
+func (h *SummaryHandler) GetBrandSummary(c echo.Context) error {
+	summary, err := h.summaryUsecase.GetBrandSummary(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "brands", summary, func() *tabular.Table { return brandTable(summary) })
+}
+
+// GetAcquisitionStats returns the items purchased and the amount spent per ?interval= (month, quarter or year; month if omitted)
+func (h *SummaryHandler) GetAcquisitionStats(c echo.Context) error {
+	stats, err := h.summaryUsecase.GetAcquisitionStats(c.Request().Context(), c.QueryParam("interval"))
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "acquisitions", stats, func() *tabular.Table { return acquisitionTable(stats) })
+}
+
+// GetTopItems returns the ?n= (10 if omitted) most valuable items by ?by=, within ?category= or per category with ?per_category=true
+func (h *SummaryHandler) GetTopItems(c echo.Context) error {
+	input := usecase.TopItemsInput{
+		TenantID: itemController.TenantID(c),
+		By:       c.QueryParam("by"),
+		Category: c.QueryParam("category"),
+	}
+	if v := c.QueryParam("n"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "n must be an integer")
+		}
+		input.N = n
+	}
+	if v := c.QueryParam("per_category"); v != "" {
+		perCategory, err := strconv.ParseBool(v)
+		if err != nil {
+			return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "per_category must be true or false")
+		}
+		input.PerCategory = perCategory
+	}
+
+	top, err := h.summaryUsecase.GetTopItems(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "top-items", top, func() *tabular.Table { return topItemsTable(top) })
+}
+
+// GetValueSummary returns the purchase price totals overall and by category, in JPY unless ?currency= is given
+func (h *SummaryHandler) GetValueSummary(c echo.Context) error {
+	summary, err := h.summaryUsecase.GetValueSummary(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "value", summary, func() *tabular.Table { return valueTable(summary) })
+}
+
+// GetPriceDistribution returns the item counts per purchase price band; ?buckets= gives the upper bounds of the bands, comma separated
+func (h *SummaryHandler) GetPriceDistribution(c echo.Context) error {
+	var bounds []int
+	if v := c.QueryParam("buckets"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			bound, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", "buckets must be comma separated integers")
+			}
+			bounds = append(bounds, bound)
+		}
+	}
+
+	distribution, err := h.summaryUsecase.GetPriceDistribution(c.Request().Context(), bounds)
+	if err != nil {
+		return err
+	}
+
+	return tabular.Respond(c, "price-distribution", distribution, func() *tabular.Table { return priceDistributionTable(distribution) })
+}
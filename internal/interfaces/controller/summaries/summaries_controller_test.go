@@ -0,0 +1,341 @@
+package summaries
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockSummaryUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSummaryUsecase) GetBrandSummary(ctx context.Context, currency string) (*usecase.BrandSummary, error) {
+	args := m.Called(ctx, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BrandSummary), args.Error(1)
+}
+
+func (m *MockSummaryUsecase) GetAcquisitionStats(ctx context.Context, interval string) (*usecase.AcquisitionStats, error) {
+	args := m.Called(ctx, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.AcquisitionStats), args.Error(1)
+}
+
+func (m *MockSummaryUsecase) GetTopItems(ctx context.Context, input usecase.TopItemsInput) (*usecase.TopItems, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TopItems), args.Error(1)
+}
+
+func (m *MockSummaryUsecase) GetValueSummary(ctx context.Context, currency string) (*usecase.ValueSummary, error) {
+	args := m.Called(ctx, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ValueSummary), args.Error(1)
+}
+
+func (m *MockSummaryUsecase) GetPriceDistribution(ctx context.Context, bounds []int) (*usecase.PriceDistribution, error) {
+	args := m.Called(ctx, bounds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PriceDistribution), args.Error(1)
+}
+
+func newTestServer(summaryUsecase usecase.SummaryUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	h := NewSummaryHandler(summaryUsecase)
+	e.GET("/summary/brands", h.GetBrandSummary)
+	e.GET("/summary/value", h.GetValueSummary)
+	e.GET("/stats/acquisitions", h.GetAcquisitionStats)
+	e.GET("/stats/price-distribution", h.GetPriceDistribution)
+	e.GET("/items/top", h.GetTopItems)
+	return e
+}
+
+func get(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSummaryHandler_GetBrandSummary(t *testing.T) {
+	t.Run("正常系: ブランドごとの件数", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetBrandSummary", mock.Anything, "").Return(&usecase.BrandSummary{Brands: map[string]int{"ROLEX": 2}, Total: 2}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/summary/brands")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"brands": {"ROLEX": 2}, "total": 2}`, rec.Body.String())
+	})
+
+	t.Run("正常系: 通貨を指定すると購入価格の合計を含める", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetBrandSummary", mock.Anything, "USD").Return(&usecase.BrandSummary{
+			Brands: map[string]int{"ROLEX": 2},
+			Total:  2,
+			Value:  &usecase.BrandValueSummary{Currency: "USD", Brands: map[string]float64{"ROLEX": 20625}, Total: 20625, ExchangeRate: 0.006875},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/summary/brands?currency=USD")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var summary usecase.BrandSummary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+		require.NotNil(t, summary.Value)
+		assert.Equal(t, 20625.0, summary.Value.Brands["ROLEX"])
+	})
+
+	t.Run("正常系: CSVはブランド名の順", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetBrandSummary", mock.Anything, "USD").Return(&usecase.BrandSummary{
+			Brands: map[string]int{"ROLEX": 2, "HERMES": 1},
+			Total:  3,
+			Value:  &usecase.BrandValueSummary{Currency: "USD", Brands: map[string]float64{"ROLEX": 20625, "HERMES": 13750}, Total: 34375, ExchangeRate: 0.006875},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/summary/brands?currency=USD&format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "brand,count,value,currency\nHERMES,1,13750,USD\nROLEX,2,20625,USD\n", rec.Body.String())
+	})
+
+	t.Run("異常系: 対応していない通貨", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetBrandSummary", mock.Anything, "XXX").Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := get(newTestServer(mockUsecase), "/summary/brands?currency=XXX")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestSummaryHandler_GetValueSummary(t *testing.T) {
+	t.Run("正常系: 購入価格の合計", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetValueSummary", mock.Anything, "").Return(&usecase.ValueSummary{
+			Currency:     "JPY",
+			Categories:   map[string]float64{"時計": 1000000, "バッグ": 300000},
+			Total:        1300000,
+			ExchangeRate: 1,
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/summary/value")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"currency": "JPY", "categories": {"時計": 1000000, "バッグ": 300000}, "total": 1300000, "exchange_rate": 1}`, rec.Body.String())
+	})
+
+	t.Run("正常系: CSVは全カテゴリーと合計の行", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetValueSummary", mock.Anything, "").Return(&usecase.ValueSummary{
+			Currency:     "JPY",
+			Categories:   map[string]float64{"時計": 1000000, "バッグ": 300000, "ジュエリー": 0, "靴": 0, "その他": 0},
+			Total:        1300000,
+			ExchangeRate: 1,
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/summary/value?format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "category,value,currency\n時計,1000000,JPY\nバッグ,300000,JPY\nジュエリー,0,JPY\n靴,0,JPY\nその他,0,JPY\ntotal,1300000,JPY\n", rec.Body.String())
+	})
+
+	t.Run("異常系: 為替レートを取得できない", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetValueSummary", mock.Anything, "USD").Return(nil, domainErrors.ErrExchangeRateUnavailable)
+
+		rec := get(newTestServer(mockUsecase), "/summary/value?currency=USD")
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+	})
+}
+
+func TestSummaryHandler_GetAcquisitionStats(t *testing.T) {
+	t.Run("正常系: 四半期ごとの件数と購入価格の合計", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetAcquisitionStats", mock.Anything, "quarter").Return(&usecase.AcquisitionStats{
+			Interval: "quarter",
+			Buckets:  []usecase.AcquisitionBucket{{Period: "2024-Q1", Start: "2024-01-01", Count: 2, Spend: 2000000}},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/acquisitions?interval=quarter")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"interval": "quarter", "buckets": [{"period": "2024-Q1", "start": "2024-01-01", "count": 2, "spend": 2000000}]}`, rec.Body.String())
+	})
+
+	t.Run("正常系: CSV", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetAcquisitionStats", mock.Anything, "").Return(&usecase.AcquisitionStats{
+			Interval: "month",
+			Buckets: []usecase.AcquisitionBucket{
+				{Period: "2024-01", Start: "2024-01-01", Count: 2, Spend: 2000000},
+				{Period: "2024-02", Start: "2024-02-01"},
+			},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/acquisitions?format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="acquisitions.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "period,start,count,spend\n2024-01,2024-01-01,2,2000000\n2024-02,2024-02-01,0,0\n", rec.Body.String())
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetAcquisitionStats", mock.Anything, "").Return(&usecase.AcquisitionStats{Interval: "month"}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/acquisitions?format=xml")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("異常系: 不正な間隔", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetAcquisitionStats", mock.Anything, "week").Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := get(newTestServer(mockUsecase), "/stats/acquisitions?interval=week")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestSummaryHandler_GetTopItems(t *testing.T) {
+	t.Run("正常系: カテゴリーごとの上位", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetTopItems", mock.Anything, usecase.TopItemsInput{N: 3, By: "purchase_price", PerCategory: true}).Return(&usecase.TopItems{
+			By:         "purchase_price",
+			Categories: map[string][]*entity.Item{"時計": {{ID: 1, Name: "ロレックス デイトナ", PurchasePrice: 1500000}}},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/items/top?n=3&by=purchase_price&per_category=true")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var top usecase.TopItems
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &top))
+		assert.Equal(t, int64(1), top.Categories["時計"][0].ID)
+	})
+
+	t.Run("正常系: カテゴリーごとの上位をCSVで", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetTopItems", mock.Anything, usecase.TopItemsInput{N: 1, PerCategory: true}).Return(&usecase.TopItems{
+			By: "purchase_price",
+			Categories: map[string][]*entity.Item{
+				"時計":  {{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}},
+				"バッグ": {{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMES", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"}},
+				"靴":   {},
+			},
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/items/top?n=1&per_category=true&format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "category,rank,id,name,brand,purchase_price,currency,purchase_date\n"+
+			"時計,1,1,ロレックス デイトナ,ROLEX,1500000,JPY,2023-01-15\n"+
+			"バッグ,1,2,エルメス バーキン,HERMES,2000000,JPY,2023-02-20\n", rec.Body.String())
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 件数が整数でない", path: "/items/top?n=ten", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: per_category が真偽値でない", path: "/items/top?per_category=yes", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 並び替えられない項目", path: "/items/top?by=name", err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockSummaryUsecase)
+			mockUsecase.On("GetTopItems", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := get(newTestServer(mockUsecase), tt.path)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestSummaryHandler_GetPriceDistribution(t *testing.T) {
+	t.Run("正常系: 指定の境界で数える", func(t *testing.T) {
+		upper := 100000
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetPriceDistribution", mock.Anything, []int{100000}).Return(&usecase.PriceDistribution{
+			Bands: []usecase.PriceBand{{Min: 0, Max: &upper, Count: 2}, {Min: 100000, Count: 3}},
+			Total: 5,
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/price-distribution?buckets=100000")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"bands": [{"min": 0, "max": 100000, "count": 2}, {"min": 100000, "count": 3}], "total": 5}`, rec.Body.String())
+	})
+
+	t.Run("正常系: CSVでは最後の帯の上限は空", func(t *testing.T) {
+		upper := 100000
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetPriceDistribution", mock.Anything, []int{100000}).Return(&usecase.PriceDistribution{
+			Bands: []usecase.PriceBand{{Min: 0, Max: &upper, Count: 2}, {Min: 100000, Count: 3}},
+			Total: 5,
+		}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/price-distribution?buckets=100000&format=csv")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "min,max,count\n0,100000,2\n100000,,3\n", rec.Body.String())
+	})
+
+	t.Run("正常系: 指定がなければ既定の帯", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		mockUsecase.On("GetPriceDistribution", mock.Anything, []int(nil)).Return(&usecase.PriceDistribution{Bands: []usecase.PriceBand{}}, nil)
+
+		rec := get(newTestServer(mockUsecase), "/stats/price-distribution")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 境界が整数でない", path: "/stats/price-distribution?buckets=10000,abc", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 昇順でない", path: "/stats/price-distribution?buckets=50000,10000", err: domainErrors.ErrInvalidInput, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockSummaryUsecase)
+			mockUsecase.On("GetPriceDistribution", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := get(newTestServer(mockUsecase), tt.path)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
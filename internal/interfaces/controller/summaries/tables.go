@@ -0,0 +1,72 @@
+package summaries
+
+import (
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/tabular"
+	"Aicon-assignment/internal/usecase"
+)
+
+// brandTable has one row per brand in name order; the value columns are only filled when a currency was requested
+func brandTable(summary *usecase.BrandSummary) *tabular.Table {
+	brands := make([]string, 0, len(summary.Brands))
+	for brand := range summary.Brands {
+		brands = append(brands, brand)
+	}
+	sort.Strings(brands)
+
+	table := tabular.NewTable("brand", "count", "value", "currency")
+	for _, brand := range brands {
+		if summary.Value == nil {
+			table.Append(brand, summary.Brands[brand], "", "")
+			continue
+		}
+		table.Append(brand, summary.Brands[brand], summary.Value.Brands[brand], summary.Value.Currency)
+	}
+	return table
+}
+
+func valueTable(summary *usecase.ValueSummary) *tabular.Table {
+	table := tabular.NewTable("category", "value", "currency")
+	for _, category := range entity.SortedCategories(summary.Categories) {
+		table.Append(category, summary.Categories[category], summary.Currency)
+	}
+	table.Append("total", summary.Total, summary.Currency)
+	return table
+}
+
+func acquisitionTable(stats *usecase.AcquisitionStats) *tabular.Table {
+	table := tabular.NewTable("period", "start", "count", "spend")
+	for _, bucket := range stats.Buckets {
+		table.Append(bucket.Period, bucket.Start, bucket.Count, bucket.Spend)
+	}
+	return table
+}
+
+// priceDistributionTable leaves max empty for the last, open band
+func priceDistributionTable(distribution *usecase.PriceDistribution) *tabular.Table {
+	table := tabular.NewTable("min", "max", "count")
+	for _, band := range distribution.Bands {
+		table.Append(band.Min, band.Max, band.Count)
+	}
+	return table
+}
+
+// topItemsTable ranks the items overall, or within each category (in the order of entity.SortedCategories)
+func topItemsTable(top *usecase.TopItems) *tabular.Table {
+	table := tabular.NewTable("category", "rank", "id", "name", "brand", "purchase_price", "currency", "purchase_date")
+	appendItems := func(items []*entity.Item) {
+		for i, item := range items {
+			table.Append(item.Category, i+1, item.ID, item.Name, item.Brand, item.PurchasePrice, item.Price().Currency, item.PurchaseDate)
+		}
+	}
+	if top.Categories == nil {
+		appendItems(top.Items)
+		return table
+	}
+	for _, category := range entity.SortedCategories(top.Categories) {
+		appendItems(top.Categories[category])
+	}
+	return table
+}
@@ -0,0 +1,100 @@
+// Package tabular lets the stats and report endpoints answer with CSV (?format=csv) instead of JSON,
+// so their results can be opened in a spreadsheet as they are.
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Formats selectable with ?format=
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Table is the tabular form of a response: a header row and the data rows
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+func NewTable(header ...string) *Table {
+	return &Table{Header: header, Rows: [][]string{}}
+}
+
+// Append adds a row; nil pointers become empty cells
+func (t *Table) Append(values ...interface{}) {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = cell(v)
+	}
+	t.Rows = append(t.Rows, row)
+}
+
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// IsCSV reports whether the request asks for CSV. Unknown formats are an ErrInvalidInput.
+func IsCSV(c echo.Context) (bool, error) {
+	switch c.QueryParam("format") {
+	case "", FormatJSON:
+		return false, nil
+	case FormatCSV:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: format must be %s or %s", domainErrors.ErrInvalidInput, FormatJSON, FormatCSV)
+	}
+}
+
+// Respond sends v as JSON, or the table built by toTable as name.csv when the request asks for CSV
+func Respond(c echo.Context, name string, v interface{}, toTable func() *Table) error {
+	asCSV, err := IsCSV(c)
+	if err != nil {
+		return err
+	}
+	if !asCSV {
+		return c.JSON(http.StatusOK, v)
+	}
+	return CSV(c, name, toTable())
+}
+
+// CSV sends table as an attachment named name.csv
+func CSV(c echo.Context, name string, table *Table) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+name+`.csv"`)
+	res.WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(res)
+	if err := w.Write(table.Header); err != nil {
+		return err
+	}
+	if err := w.WriteAll(table.Rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
@@ -0,0 +1,87 @@
+package tabular
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestTable_Append(t *testing.T) {
+	upper := 100000
+	table := NewTable("a", "b", "c", "d", "e")
+	table.Append("時計", 3, 1150000.5, &upper, (*int)(nil))
+
+	assert.Equal(t, [][]string{{"時計", "3", "1150000.5", "100000", ""}}, table.Rows)
+}
+
+func TestRespond(t *testing.T) {
+	v := map[string]int{"count": 2}
+	toTable := func() *Table {
+		table := NewTable("name", "count")
+		table.Append("カンマ, を含む", 2)
+		return table
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "正常系: 既定はJSON",
+			path:                "/stats",
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        "{\"count\":2}\n",
+		},
+		{
+			name:                "正常系: format=json",
+			path:                "/stats?format=json",
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedBody:        "{\"count\":2}\n",
+		},
+		{
+			name:                "正常系: format=csv",
+			path:                "/stats?format=csv",
+			expectedContentType: "text/csv; charset=UTF-8",
+			expectedBody:        "name,count\n\"カンマ, を含む\",2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, tt.path, nil), rec)
+
+			require.NoError(t, Respond(c, "stats", v, toTable))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.expectedContentType)
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+
+	t.Run("正常系: CSVは添付ファイル", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/stats?format=csv", nil), rec)
+
+		require.NoError(t, Respond(c, "stats", v, toTable))
+
+		assert.Equal(t, `attachment; filename="stats.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/stats?format=xlsx", nil), rec)
+
+		err := Respond(c, "stats", v, toTable)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,172 @@
+package tags
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+// MergeTagsRequest lists the tags merged into the tag of the URL
+type MergeTagsRequest struct {
+	SourceIDs []int64 `json:"source_ids"`
+}
+
+// ItemTagsRequest is the complete list of tag names of an item
+type ItemTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// GetTags returns every tag with the number of items it is attached to, ordered by name
+func (h *TagHandler) GetTags(c echo.Context) error {
+	tags, err := h.tagUsecase.ListTags(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (h *TagHandler) CreateTag(c echo.Context) error {
+	var input usecase.TagInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	tag, err := h.tagUsecase.CreateTag(c.Request().Context(), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, tag)
+}
+
+func (h *TagHandler) GetTag(c echo.Context) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	tag, err := h.tagUsecase.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+// RenameTag renames a tag on every item it is attached to
+func (h *TagHandler) RenameTag(c echo.Context) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	var input usecase.TagInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	tag, err := h.tagUsecase.RenameTag(c.Request().Context(), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.tagUsecase.DeleteTag(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// MergeTags moves the items of the source tags to the tag of the URL and returns it with its new item count
+func (h *TagHandler) MergeTags(c echo.Context) error {
+	id, err := tagID(c)
+	if err != nil {
+		return err
+	}
+
+	var req MergeTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tag, err := h.tagUsecase.MergeTags(c.Request().Context(), id, req.SourceIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tag)
+}
+
+func (h *TagHandler) GetItemTags(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	tags, err := h.tagUsecase.ListItemTags(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// SetItemTags replaces the tags of an item; an empty list removes them all
+func (h *TagHandler) SetItemTags(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	var req ItemTagsRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	tags, err := h.tagUsecase.SetItemTags(c.Request().Context(), itemController.UserID(c), itemID, req.Tags)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func tagID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid tag ID")
+	}
+	return id, nil
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
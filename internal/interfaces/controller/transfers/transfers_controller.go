@@ -0,0 +1,109 @@
+package transfers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TransferHandler struct {
+	transferUsecase usecase.TransferUsecase
+}
+
+func NewTransferHandler(transferUsecase usecase.TransferUsecase) *TransferHandler {
+	return &TransferHandler{
+		transferUsecase: transferUsecase,
+	}
+}
+
+// RequestTransfer offers a single item to another user
+func (h *TransferHandler) RequestTransfer(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.TransferInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	transfer, err := h.transferUsecase.RequestTransfer(c.Request().Context(), itemController.UserID(c), id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, transfer)
+}
+
+// RequestBulkTransfer offers several items to the same user at once
+func (h *TransferHandler) RequestBulkTransfer(c echo.Context) error {
+	var input usecase.BulkTransferInput
+	if err := c.Bind(&input); err != nil {
+		return err
+	}
+
+	transfers, err := h.transferUsecase.RequestBulkTransfer(c.Request().Context(), itemController.UserID(c), input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, transfers)
+}
+
+// GetItemTransfers returns the transfer history of an item
+func (h *TransferHandler) GetItemTransfers(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	transfers, err := h.transferUsecase.ListItemTransfers(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, transfers)
+}
+
+// GetIncomingTransfers returns the pending transfers addressed to the requesting user
+func (h *TransferHandler) GetIncomingTransfers(c echo.Context) error {
+	transfers, err := h.transferUsecase.ListIncomingTransfers(c.Request().Context(), itemController.UserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, transfers)
+}
+
+func (h *TransferHandler) AcceptTransfer(c echo.Context) error {
+	return h.resolve(c, h.transferUsecase.AcceptTransfer)
+}
+
+func (h *TransferHandler) RejectTransfer(c echo.Context) error {
+	return h.resolve(c, h.transferUsecase.RejectTransfer)
+}
+
+func (h *TransferHandler) CancelTransfer(c echo.Context) error {
+	return h.resolve(c, h.transferUsecase.CancelTransfer)
+}
+
+func (h *TransferHandler) resolve(c echo.Context, action func(ctx context.Context, actor string, id int64) (*entity.Transfer, error)) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid transfer ID")
+	}
+
+	transfer, err := action(c.Request().Context(), itemController.UserID(c), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
@@ -0,0 +1,230 @@
+package transfers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockTransferUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTransferUsecase) RequestTransfer(ctx context.Context, actor string, itemID int64, input usecase.TransferInput) (*entity.Transfer, error) {
+	args := m.Called(ctx, actor, itemID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferUsecase) RequestBulkTransfer(ctx context.Context, actor string, input usecase.BulkTransferInput) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, actor, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferUsecase) AcceptTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return m.resolve("AcceptTransfer", ctx, actor, id)
+}
+
+func (m *MockTransferUsecase) RejectTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return m.resolve("RejectTransfer", ctx, actor, id)
+}
+
+func (m *MockTransferUsecase) CancelTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return m.resolve("CancelTransfer", ctx, actor, id)
+}
+
+func (m *MockTransferUsecase) resolve(method string, ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	args := m.MethodCalled(method, ctx, actor, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferUsecase) ListIncomingTransfers(ctx context.Context, actor string) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferUsecase) ListItemTransfers(ctx context.Context, itemID int64) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+// newTestServer registers the transfer routes as the server does
+func newTestServer(transferUsecase usecase.TransferUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	h := NewTransferHandler(transferUsecase)
+	e.POST("/items/transfer", h.RequestBulkTransfer)
+	e.POST("/items/:id/transfer", h.RequestTransfer)
+	e.GET("/items/:id/transfers", h.GetItemTransfers)
+	e.GET("/transfers", h.GetIncomingTransfers)
+	e.POST("/transfers/:id/accept", h.AcceptTransfer)
+	e.POST("/transfers/:id/reject", h.RejectTransfer)
+	e.POST("/transfers/:id/cancel", h.CancelTransfer)
+	return e
+}
+
+func do(e *echo.Echo, method, path, user, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if user != "" {
+		req.Header.Set(itemController.HeaderUserID, user)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTransferHandler_RequestTransfer(t *testing.T) {
+	t.Run("正常系: 操作するユーザーから受取人に申請する", func(t *testing.T) {
+		mockUsecase := new(MockTransferUsecase)
+		mockUsecase.On("RequestTransfer", mock.Anything, "alice", int64(1), usecase.TransferInput{ToUser: "bob", Note: "形見分け"}).
+			Return(&entity.Transfer{ID: 10, ItemID: 1, FromUser: "alice", ToUser: "bob", Status: entity.TransferStatusPending}, nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodPost, "/items/1/transfer", "alice", `{"to_user": "bob", "note": "形見分け"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var transfer entity.Transfer
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &transfer))
+		assert.Equal(t, int64(10), transfer.ID)
+		assert.Equal(t, entity.TransferStatusPending, transfer.Status)
+	})
+
+	t.Run("正常系: 複数のアイテムを一括で申請する", func(t *testing.T) {
+		mockUsecase := new(MockTransferUsecase)
+		mockUsecase.On("RequestBulkTransfer", mock.Anything, "alice", usecase.BulkTransferInput{ItemIDs: []int64{1, 2}, ToUser: "bob"}).
+			Return([]*entity.Transfer{{ID: 10, ItemID: 1}, {ID: 11, ItemID: 2}}, nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodPost, "/items/transfer", "alice", `{"item_ids": [1, 2], "to_user": "bob"}`)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var transfers []entity.Transfer
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &transfers))
+		assert.Len(t, transfers, 2)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "異常系: 不正なアイテムID", path: "/items/abc/transfer", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 所有者以外の申請", path: "/items/1/transfer", err: domainErrors.ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "異常系: 承諾待ちの譲渡がある", path: "/items/1/transfer", err: domainErrors.ErrConflict, expectedStatus: http.StatusConflict},
+		{name: "異常系: アイテムが見つからない", path: "/items/1/transfer", err: domainErrors.ErrItemNotFound, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTransferUsecase)
+			mockUsecase.On("RequestTransfer", mock.Anything, "alice", int64(1), mock.Anything).Return(nil, tt.err)
+
+			rec := do(newTestServer(mockUsecase), http.MethodPost, tt.path, "alice", `{"to_user": "bob"}`)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestTransferHandler_Resolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		user           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "正常系: 受取人が承諾する", method: "AcceptTransfer", path: "/transfers/10/accept", user: "bob", expectedStatus: http.StatusOK},
+		{name: "正常系: 受取人が拒否する", method: "RejectTransfer", path: "/transfers/10/reject", user: "bob", expectedStatus: http.StatusOK},
+		{name: "正常系: 送り主が取り消す", method: "CancelTransfer", path: "/transfers/10/cancel", user: "alice", expectedStatus: http.StatusOK},
+		{name: "異常系: 受取人以外の承諾", method: "AcceptTransfer", path: "/transfers/10/accept", user: "carol", err: domainErrors.ErrForbidden, expectedStatus: http.StatusForbidden},
+		{name: "異常系: 確定済みの譲渡", method: "CancelTransfer", path: "/transfers/10/cancel", user: "alice", err: domainErrors.ErrConflict, expectedStatus: http.StatusConflict},
+		{name: "異常系: 譲渡が見つからない", method: "RejectTransfer", path: "/transfers/10/reject", user: "bob", err: domainErrors.ErrNotFound, expectedStatus: http.StatusNotFound},
+		{name: "異常系: 不正な譲渡ID", method: "AcceptTransfer", path: "/transfers/x/accept", user: "bob", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTransferUsecase)
+			var transfer *entity.Transfer
+			if tt.err == nil {
+				transfer = &entity.Transfer{ID: 10, Status: entity.TransferStatusAccepted, ResolvedBy: tt.user}
+			}
+			mockUsecase.On(tt.method, mock.Anything, tt.user, int64(10)).Return(transfer, tt.err)
+
+			rec := do(newTestServer(mockUsecase), http.MethodPost, tt.path, tt.user, "")
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				mockUsecase.AssertExpectations(t)
+				assert.Contains(t, rec.Body.String(), `"resolved_by":"`+tt.user+`"`)
+			}
+		})
+	}
+}
+
+func TestTransferHandler_List(t *testing.T) {
+	t.Run("正常系: 自分宛ての承諾待ちの譲渡", func(t *testing.T) {
+		mockUsecase := new(MockTransferUsecase)
+		mockUsecase.On("ListIncomingTransfers", mock.Anything, "bob").Return([]*entity.Transfer{{ID: 10, ToUser: "bob"}}, nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodGet, "/transfers", "bob", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"to_user":"bob"`)
+	})
+
+	t.Run("異常系: ユーザーの指定がない", func(t *testing.T) {
+		mockUsecase := new(MockTransferUsecase)
+		mockUsecase.On("ListIncomingTransfers", mock.Anything, "").Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := do(newTestServer(mockUsecase), http.MethodGet, "/transfers", "", "")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("正常系: アイテムの譲渡履歴", func(t *testing.T) {
+		mockUsecase := new(MockTransferUsecase)
+		mockUsecase.On("ListItemTransfers", mock.Anything, int64(1)).Return([]*entity.Transfer{
+			{ID: 11, ItemID: 1, Status: entity.TransferStatusPending},
+			{ID: 10, ItemID: 1, Status: entity.TransferStatusRejected, ResolvedBy: "bob"},
+		}, nil)
+
+		rec := do(newTestServer(mockUsecase), http.MethodGet, "/items/1/transfers", "", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var transfers []entity.Transfer
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &transfers))
+		assert.Len(t, transfers, 2)
+		assert.Equal(t, "bob", transfers[1].ResolvedBy)
+	})
+}
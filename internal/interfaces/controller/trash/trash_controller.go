@@ -0,0 +1,66 @@
+package trash
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashHandler struct {
+	trashUsecase usecase.TrashUsecase
+}
+
+func NewTrashHandler(trashUsecase usecase.TrashUsecase) *TrashHandler {
+	return &TrashHandler{
+		trashUsecase: trashUsecase,
+	}
+}
+
+// GetTrash returns a page (?page=&page_size=) of the deleted items, most recently deleted first
+func (h *TrashHandler) GetTrash(c echo.Context) error {
+	var errs []string
+	page, pageSize := 0, 0
+	if v := c.QueryParam("page"); v != "" {
+		var err error
+		if page, err = strconv.Atoi(v); err != nil {
+			errs = append(errs, "page must be an integer")
+		}
+	}
+	if v := c.QueryParam("page_size"); v != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(v); err != nil {
+			errs = append(errs, "page_size must be an integer")
+		}
+	}
+	if len(errs) > 0 {
+		return itemController.NewHTTPError(http.StatusBadRequest, "validation failed", errs...)
+	}
+
+	list, err := h.trashUsecase.ListTrash(c.Request().Context(), page, pageSize)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(itemController.HeaderTotalCount, strconv.Itoa(list.Total))
+	pagination.SetHeader(c, list.Page, list.PageSize, list.Total)
+	return c.JSON(http.StatusOK, list)
+}
+
+// PurgeItem permanently deletes an item in the trash
+func (h *TrashHandler) PurgeItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+
+	if err := h.trashUsecase.PurgeItem(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+package trash
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/pagination"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockTrashUsecase struct {
+	mock.Mock
+}
+
+func (m *MockTrashUsecase) ListTrash(ctx context.Context, page, pageSize int) (*usecase.TrashList, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TrashList), args.Error(1)
+}
+
+func (m *MockTrashUsecase) PurgeItem(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func newTestServer(trashUsecase usecase.TrashUsecase) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	handler := NewTrashHandler(trashUsecase)
+	e.GET("/items/trash", handler.GetTrash)
+	e.DELETE("/items/:id/purge", handler.PurgeItem)
+	return e
+}
+
+func serve(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestTrashHandler_GetTrash(t *testing.T) {
+	t.Run("正常系: 削除日時つきでアイテムを返す", func(t *testing.T) {
+		mockUsecase := new(MockTrashUsecase)
+		mockUsecase.On("ListTrash", mock.Anything, 2, 10).Return(&usecase.TrashList{
+			Items: []*entity.TrashedItem{{
+				Item:      &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Version: 2},
+				DeletedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+			}},
+			Total:    11,
+			Page:     2,
+			PageSize: 10,
+		}, nil)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/trash?page=2&page_size=10")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "11", rec.Header().Get(itemController.HeaderTotalCount))
+		assert.Equal(t, `</items/trash?page=1&page_size=10>; rel="first", </items/trash?page=1&page_size=10>; rel="prev", `+
+			`</items/trash?page=2&page_size=10>; rel="last"`, rec.Header().Get(pagination.HeaderLink))
+		assert.JSONEq(t, `{
+			"items": [{
+				"id": 1, "name": "ロレックス", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000,
+				"purchase_date": "2023-01-15", "version": 2,
+				"created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z",
+				"deleted_at": "2024-03-01T09:00:00Z"
+			}],
+			"total": 11, "page": 2, "page_size": 10
+		}`, rec.Body.String())
+	})
+
+	t.Run("異常系: ページが整数でない", func(t *testing.T) {
+		mockUsecase := new(MockTrashUsecase)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/trash?page=x")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUsecase.AssertNotCalled(t, "ListTrash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: ページの範囲外", func(t *testing.T) {
+		mockUsecase := new(MockTrashUsecase)
+		mockUsecase.On("ListTrash", mock.Anything, 0, 500).Return(nil, domainErrors.ErrInvalidInput)
+
+		rec := serve(newTestServer(mockUsecase), http.MethodGet, "/items/trash?page_size=500")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestTrashHandler_PurgeItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "正常系: 完全に削除する", path: "/items/1/purge", expectedStatus: http.StatusNoContent},
+		{name: "異常系: ゴミ箱にない", path: "/items/1/purge", err: domainErrors.ErrItemNotFound, expectedStatus: http.StatusNotFound},
+		{name: "異常系: 不正なアイテムID", path: "/items/abc/purge", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTrashUsecase)
+			mockUsecase.On("PurgeItem", mock.Anything, int64(1)).Return(tt.err).Maybe()
+
+			rec := serve(newTestServer(mockUsecase), http.MethodDelete, tt.path)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package validator checks request structs against their `validate` struct tags with go-playground/validator
+// and reports every violation as a message naming the JSON field (e.g. "name must be 100 characters or less").
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	playground "github.com/go-playground/validator/v10"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// FieldError is one violated rule of a field
+type FieldError struct {
+	// Field is the JSON name of the field
+	Field string
+	// Tag is the violated rule, such as "max"
+	Tag string
+	// Param is the parameter of the rule, such as "100" for max=100
+	Param string
+	// Message describes the violation, such as "name must be 100 characters or less"
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// Errors holds the violations of a struct, at most one per field, in field order.
+// It wraps ErrInvalidInput so it is reported like other validation errors.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	return domainErrors.ErrInvalidInput.Error() + ": " + strings.Join(e.Details(), ", ")
+}
+
+func (e Errors) Unwrap() error {
+	return domainErrors.ErrInvalidInput
+}
+
+// Details returns the messages of the violations
+func (e Errors) Details() []string {
+	details := make([]string, len(e))
+	for i, fieldErr := range e {
+		details[i] = fieldErr.Message
+	}
+	return details
+}
+
+// Validator validates structs by their `validate` tags; it implements echo.Validator
+type Validator struct {
+	validate *playground.Validate
+	// messages are the rule parts of the violation messages ("must be ...") of the registered rules
+	messages map[string]string
+}
+
+// New returns a validator with the built-in rules of go-playground/validator and the item rules:
+// nonempty (a value that is not empty; unlike required, it also rejects a pointer to an empty value),
+// category (at most 50 characters; whether the category is valid for the tenant is checked by the usecase),
+// date (YYYY-MM-DD) and amount (an entity.Amount that is well-formed and not negative; its decimal places
+// are checked by the usecase, which knows the currency)
+func New() *Validator {
+	validate := playground.New()
+	// Violations name the field as it is sent in request bodies
+	validate.RegisterTagNameFunc(func(sf reflect.StructField) string {
+		if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+			return name
+		}
+		return sf.Name
+	})
+
+	v := &Validator{validate: validate, messages: make(map[string]string)}
+	v.mustRegister("nonempty", func(fl playground.FieldLevel) bool {
+		return !fl.Field().IsZero()
+	}, "is required")
+	v.mustRegister("category", func(fl playground.FieldLevel) bool {
+		return entity.IsValidCategoryName(fl.Field().String())
+	}, "must be 50 characters or less")
+	v.mustRegister("date", func(fl playground.FieldLevel) bool {
+		return entity.IsValidDateFormat(fl.Field().String())
+	}, "must be in YYYY-MM-DD format")
+	// The message of amount is the reason the amount is invalid (see message)
+	v.mustRegister("amount", func(fl playground.FieldLevel) bool {
+		amount, ok := fl.Field().Interface().(entity.Amount)
+		return ok && amount.Validate() == nil
+	}, "must be an amount")
+	return v
+}
+
+// RegisterValidation adds or replaces a rule; message is the rule part of the violation message ("must be ...").
+// Rules must be registered before the first Validate call of a struct that uses them.
+func (v *Validator) RegisterValidation(tag string, fn playground.Func, message string) error {
+	if err := v.validate.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	v.messages[tag] = message
+	return nil
+}
+
+func (v *Validator) mustRegister(tag string, fn playground.Func, message string) {
+	if err := v.RegisterValidation(tag, fn, message); err != nil {
+		panic(err)
+	}
+}
+
+// Validate checks a struct (or pointer to struct) and returns Errors if any rule is violated.
+// An undefined rule is a programming error and panics.
+func (v *Validator) Validate(i interface{}) error {
+	if rv := reflect.ValueOf(i); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+
+	err := v.validate.Struct(i)
+	var violations playground.ValidationErrors
+	if !errors.As(err, &violations) {
+		return err
+	}
+
+	errs := make(Errors, len(violations))
+	for i, fieldErr := range violations {
+		errs[i] = FieldError{
+			Field:   fieldErr.Field(),
+			Tag:     fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: fieldErr.Field() + " " + v.message(fieldErr),
+		}
+	}
+	return errs
+}
+
+// message returns the rule part of the message of a violation
+func (v *Validator) message(fieldErr playground.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		switch fieldErr.Kind() {
+		case reflect.String:
+			return "must be at least " + param + " characters"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return "must contain at least " + param + " items"
+		}
+		return "must be " + param + " or greater"
+	case "max":
+		switch fieldErr.Kind() {
+		case reflect.String:
+			return "must be " + param + " characters or less"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return "must contain " + param + " items or less"
+		}
+		return "must be " + param + " or less"
+	case "gte":
+		return "must be >= " + param
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "amount":
+		if amount, ok := fieldErr.Value().(entity.Amount); ok {
+			if err := amount.Validate(); err != nil {
+				return err.Error()
+			}
+		}
+	}
+	if message, ok := v.messages[fieldErr.Tag()]; ok {
+		return message
+	}
+	return "is invalid"
+}
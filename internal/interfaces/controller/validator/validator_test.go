@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestValidator_CreateItemInput(t *testing.T) {
+	valid := func() usecase.CreateItemInput {
+		return usecase.CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1000), PurchaseDate: "2024-01-01"}
+	}
+
+	tests := []struct {
+		name            string
+		modify          func(*usecase.CreateItemInput)
+		expectedDetails []string
+	}{
+		{
+			name:   "正常系: すべて有効",
+			modify: func(*usecase.CreateItemInput) {},
+		},
+		{
+			name:   "正常系: 100文字ちょうど（マルチバイト）",
+			modify: func(in *usecase.CreateItemInput) { in.Name = strings.Repeat("時", 100) },
+		},
+		{
+			// ブランドが必須かどうかは usecase の検証ルールで確かめる
+			name:            "異常系: 必須項目が空",
+			modify:          func(in *usecase.CreateItemInput) { *in = usecase.CreateItemInput{} },
+			expectedDetails: []string{"name is required", "category is required", "purchase_date is required"},
+		},
+		{
+			name: "異常系: 長さ・カテゴリー・価格・日付",
+			modify: func(in *usecase.CreateItemInput) {
+				in.Name = strings.Repeat("a", 101)
+				in.Category = strings.Repeat("家", 51)
+				in.PurchasePrice = entity.MinorUnits(-1)
+				in.PurchaseDate = "2024/01/01"
+			},
+			expectedDetails: []string{
+				"name must be 100 characters or less",
+				"category must be 50 characters or less",
+				"purchase_price must be 0 or greater",
+				"purchase_date must be in YYYY-MM-DD format",
+			},
+		},
+		{
+			name:   "正常系: 10進数の文字列の価格",
+			modify: func(in *usecase.CreateItemInput) { in.PurchasePrice = entity.DecimalAmount("12.34") },
+		},
+		{
+			name:            "異常系: 負の10進数の価格",
+			modify:          func(in *usecase.CreateItemInput) { in.PurchasePrice = entity.DecimalAmount("-0.5") },
+			expectedDetails: []string{"purchase_price must be 0 or greater"},
+		},
+		{
+			name:            "異常系: 整数でない数値の価格",
+			modify:          func(in *usecase.CreateItemInput) { _ = json.Unmarshal([]byte("12.34"), &in.PurchasePrice) },
+			expectedDetails: []string{`purchase_price must be an integer (minor units) or a decimal string such as "12.34": 12.34`},
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid()
+			tt.modify(&input)
+
+			err := v.Validate(&input)
+			if tt.expectedDetails == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var violations Errors
+			require.ErrorAs(t, err, &violations)
+			assert.Equal(t, tt.expectedDetails, violations.Details())
+			assert.True(t, domainErrors.IsValidationError(err))
+		})
+	}
+}
+
+func TestValidator_UpdateItemRequest(t *testing.T) {
+	str := func(s string) *string { return &s }
+	amount := func(n int64) *entity.Amount { a := entity.MinorUnits(n); return &a }
+
+	tests := []struct {
+		name            string
+		req             usecase.UpdateItemRequest
+		expectedDetails []string
+	}{
+		{
+			name: "正常系: 指定しないフィールドは検証しない",
+			req:  usecase.UpdateItemRequest{},
+		},
+		{
+			name: "正常系: 有効な値",
+			req:  usecase.UpdateItemRequest{Name: str("時計2"), PurchasePrice: amount(0)},
+		},
+		{
+			name:            "異常系: 空の名前と負の価格",
+			req:             usecase.UpdateItemRequest{Name: str(""), Brand: str(strings.Repeat("b", 101)), PurchasePrice: amount(-100)},
+			expectedDetails: []string{"name is required", "brand must be 100 characters or less", "purchase_price must be 0 or greater"},
+		},
+	}
+
+	v := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(&tt.req)
+			if tt.expectedDetails == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var violations Errors
+			require.ErrorAs(t, err, &violations)
+			assert.Equal(t, tt.expectedDetails, violations.Details())
+		})
+	}
+}
+
+func TestValidator_Rules(t *testing.T) {
+	type request struct {
+		Tags    []string `json:"tags" validate:"omitempty,max=2"`
+		Sort    string   `json:"sort" validate:"omitempty,oneof=name price"`
+		Code    string   `json:"code" validate:"required,min=3,upper"`
+		Comment string
+	}
+
+	v := New()
+	require.NoError(t, v.RegisterValidation("upper", func(fl playground.FieldLevel) bool {
+		return strings.ToUpper(fl.Field().String()) == fl.Field().String()
+	}, "must be upper case"))
+
+	tests := []struct {
+		name     string
+		req      request
+		expected Errors
+	}{
+		{
+			name: "正常系: 省略可能な項目は空なら検証しない",
+			req:  request{Code: "ABC"},
+		},
+		{
+			name: "異常系: 各規則の違反",
+			req:  request{Tags: []string{"a", "b", "c"}, Sort: "date", Code: "ab"},
+			expected: Errors{
+				{Field: "tags", Tag: "max", Param: "2", Message: "tags must contain 2 items or less"},
+				{Field: "sort", Tag: "oneof", Param: "name price", Message: "sort must be one of: name, price"},
+				{Field: "code", Tag: "min", Param: "3", Message: "code must be at least 3 characters"},
+			},
+		},
+		{
+			name: "異常系: 独自の規則",
+			req:  request{Code: "abc"},
+			expected: Errors{
+				{Field: "code", Tag: "upper", Message: "code must be upper case"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.req)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestValidator_UndefinedRule(t *testing.T) {
+	type request struct {
+		Name string `validate:"required,unknown"`
+	}
+
+	assert.Panics(t, func() { _ = New().Validate(request{Name: "a"}) })
+	assert.Error(t, New().Validate("not a struct"))
+}
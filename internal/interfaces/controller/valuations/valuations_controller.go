@@ -0,0 +1,59 @@
+package valuations
+
+import (
+	"net/http"
+	"strconv"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ValuationHandler struct {
+	valuationUsecase usecase.ValuationUsecase
+}
+
+func NewValuationHandler(valuationUsecase usecase.ValuationUsecase) *ValuationHandler {
+	return &ValuationHandler{
+		valuationUsecase: valuationUsecase,
+	}
+}
+
+// RefreshMarketValue fetches the market value of an item from the price provider and records it
+func (h *ValuationHandler) RefreshMarketValue(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	valuation, err := h.valuationUsecase.RefreshMarketValue(c.Request().Context(), itemController.UserID(c), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, valuation)
+}
+
+// GetValuations returns the valuation history of an item, newest first
+func (h *ValuationHandler) GetValuations(c echo.Context) error {
+	itemID, err := itemID(c)
+	if err != nil {
+		return err
+	}
+
+	valuations, err := h.valuationUsecase.ListValuations(c.Request().Context(), itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, valuations)
+}
+
+func itemID(c echo.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, itemController.NewHTTPError(http.StatusBadRequest, "invalid item ID")
+	}
+	return id, nil
+}
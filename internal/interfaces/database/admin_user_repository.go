@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type AdminUserRepository struct {
+	SqlHandler
+}
+
+func (r *AdminUserRepository) Create(ctx context.Context, user *entity.AdminUser) (*entity.AdminUser, error) {
+	query := `INSERT INTO admin_users (name, password_hash, created_at) VALUES (?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, user.Name, user.PasswordHash, user.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created := *user
+	created.ID = id
+	return &created, nil
+}
+
+func (r *AdminUserRepository) FindByName(ctx context.Context, name string) (*entity.AdminUser, error) {
+	// 名前の列の照合順序で、大文字・小文字を区別せずに比べる
+	query := `SELECT id, name, password_hash, created_at FROM admin_users WHERE name = ?`
+
+	var user entity.AdminUser
+	err := r.QueryRow(ctx, query, name).Scan(&user.ID, &user.Name, &user.PasswordHash, &user.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrAdminUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &user, nil
+}
+
+func (r *AdminUserRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM admin_users`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
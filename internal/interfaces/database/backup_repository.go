@@ -0,0 +1,242 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// backupTables are the tables included in backups, in the order they were created by the migrations.
+// A table added by a new migration has to be added here as well.
+var backupTables = []string{
+	"items",
+	"tenant_settings",
+	"custom_attributes",
+	"item_transfers",
+	"webhooks",
+	"webhook_deliveries",
+	"item_event_outbox",
+	"notification_rules",
+	"item_loans",
+	"item_services",
+	"item_valuations",
+	"item_images",
+	"item_documents",
+	"tags",
+	"item_tags",
+	"collections",
+	"collection_items",
+	"expiry_reminders",
+	"reminder_snoozes",
+	"item_revisions",
+	"admin_users",
+	"erasure_requests",
+	"item_codes",
+	"portfolio_snapshots",
+}
+
+// snapshotTimeFormat is how date and time values are written to snapshots; both MySQL and SQLite read it back
+const snapshotTimeFormat = "2006-01-02 15:04:05.999999"
+
+type snapshot struct {
+	Tables []*snapshotTable `json:"tables"`
+}
+
+// snapshotTable holds the rows of a table, with their values in the order of the columns
+type snapshotTable struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// BackupRepository implements usecase.DatabaseSnapshotter. Snapshots are JSON documents with the rows of every
+// table in backupTables, and are restored as they were taken, including the IDs.
+type BackupRepository struct {
+	SqlHandler
+}
+
+func (r *BackupRepository) Snapshot(ctx context.Context) ([]byte, error) {
+	s := snapshot{Tables: make([]*snapshotTable, 0, len(backupTables))}
+	for _, table := range backupTables {
+		t, err := r.snapshotTable(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		s.Tables = append(s.Tables, t)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return data, nil
+}
+
+func (r *BackupRepository) snapshotTable(ctx context.Context, table string) (*snapshotTable, error) {
+	columns, err := r.columns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.Query(ctx, `SELECT `+strings.Join(columns, ", ")+` FROM `+table)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	t := &snapshotTable{Name: table, Columns: columns, Rows: [][]interface{}{}}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		for i, v := range values {
+			values[i] = snapshotValue(v)
+		}
+		t.Rows = append(t.Rows, values)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return t, nil
+}
+
+// snapshotValue converts a value read by the driver to one that is written to JSON as it is stored:
+// text read as bytes becomes a string and times are written without their time zone, in UTC
+func snapshotValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC().Format(snapshotTimeFormat)
+	default:
+		return v
+	}
+}
+
+// Restore deletes the rows of every table in backupTables, including the tables the snapshot does not have,
+// and inserts the rows of the snapshot. It is meant to run in a transaction, so that a failure changes nothing.
+func (r *BackupRepository) Restore(ctx context.Context, data []byte) (int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keeps IDs and prices as integers
+	decoder.UseNumber()
+	var s snapshot
+	if err := decoder.Decode(&s); err != nil {
+		return 0, fmt.Errorf("%w: invalid snapshot: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	// Table and column names are written into the statements, so every one is checked against the database first
+	for _, t := range s.Tables {
+		if err := r.checkTable(ctx, t); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, table := range backupTables {
+		if _, err := r.Execute(ctx, `DELETE FROM `+table); err != nil {
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	restored := 0
+	for _, t := range s.Tables {
+		query := `INSERT INTO ` + t.Name + ` (` + strings.Join(t.Columns, ", ") + `) VALUES (?` + strings.Repeat(", ?", len(t.Columns)-1) + `)`
+		for _, row := range t.Rows {
+			if len(row) != len(t.Columns) {
+				return 0, fmt.Errorf("%w: invalid snapshot: row of %s has %d values for %d columns", domainErrors.ErrInvalidInput, t.Name, len(row), len(t.Columns))
+			}
+			args := make([]interface{}, len(row))
+			for i, v := range row {
+				args[i] = restoreValue(v)
+			}
+			if _, err := r.Execute(ctx, query, args...); err != nil {
+				return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			}
+			restored++
+		}
+	}
+	return restored, nil
+}
+
+// checkTable checks that a table of a snapshot is a backed up table and has no columns the database does not have
+func (r *BackupRepository) checkTable(ctx context.Context, t *snapshotTable) error {
+	known := false
+	for _, table := range backupTables {
+		known = known || table == t.Name
+	}
+	if !known {
+		return fmt.Errorf("%w: invalid snapshot: unknown table %q", domainErrors.ErrInvalidInput, t.Name)
+	}
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("%w: invalid snapshot: table %s has no columns", domainErrors.ErrInvalidInput, t.Name)
+	}
+
+	columns, err := r.columns(ctx, t.Name)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		existing[c] = true
+	}
+	for _, c := range t.Columns {
+		if !existing[c] {
+			return fmt.Errorf("%w: invalid snapshot: table %s has no column %q", domainErrors.ErrInvalidInput, t.Name, c)
+		}
+	}
+	return nil
+}
+
+// restoreValue converts a number of a snapshot back to an integer, or a float if it has a fraction
+func restoreValue(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// columns returns the column names of a table in the order they were defined
+func (r *BackupRepository) columns(ctx context.Context, table string) ([]string, error) {
+	query := `SELECT column_name FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position`
+	if DialectOf(r.SqlHandler) == DialectSQLite {
+		query = `SELECT name FROM pragma_table_info(?) ORDER BY cid`
+	}
+
+	rows, err := r.Query(ctx, query, table)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%w: table %s does not exist", domainErrors.ErrDatabaseError, table)
+	}
+	return columns, nil
+}
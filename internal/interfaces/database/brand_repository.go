@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type BrandRepository struct {
+	SqlHandler
+}
+
+func (r *BrandRepository) FindAll(ctx context.Context) ([]*entity.Brand, error) {
+	rows, err := r.Query(ctx, `SELECT id, name, created_at, updated_at FROM brands ORDER BY name, id`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var brands []*entity.Brand
+	byID := make(map[int64]*entity.Brand)
+	for rows.Next() {
+		brand := &entity.Brand{Aliases: []string{}}
+		if err := rows.Scan(&brand.ID, &brand.Name, &brand.CreatedAt, &brand.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		brands = append(brands, brand)
+		byID[brand.ID] = brand
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 別名は1回のクエリでまとめて読み、ブランドに振り分ける
+	aliasRows, err := r.Query(ctx, `SELECT brand_id, alias FROM brand_aliases ORDER BY brand_id, alias`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer aliasRows.Close()
+
+	for aliasRows.Next() {
+		var brandID int64
+		var alias string
+		if err := aliasRows.Scan(&brandID, &alias); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if brand, ok := byID[brandID]; ok {
+			brand.Aliases = append(brand.Aliases, alias)
+		}
+	}
+	if err = aliasRows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return brands, nil
+}
+
+func (r *BrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	query := `INSERT INTO brands (name, created_at, updated_at) VALUES (?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, brand.Name, brand.CreatedAt, brand.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	for _, alias := range brand.Aliases {
+		if _, err := r.Execute(ctx, `INSERT INTO brand_aliases (brand_id, alias) VALUES (?, ?)`, id, alias); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	created := *brand
+	created.ID = id
+	return &created, nil
+}
+
+func (r *BrandRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM brand_aliases WHERE brand_id = ?`, id); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM brands WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrBrandNotFound
+	}
+
+	return nil
+}
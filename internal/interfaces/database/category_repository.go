@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CategoryRepository struct {
+	SqlHandler
+}
+
+func (r *CategoryRepository) FindAll(ctx context.Context, tenantID string) ([]*entity.Category, error) {
+	query := `
+        SELECT name, created_at
+        FROM categories
+        WHERE tenant_id = ?
+        ORDER BY name
+    `
+
+	return queryAll(ctx, r.SqlHandler, func(row scanner) (*entity.Category, error) {
+		var category entity.Category
+		err := row.Scan(&category.Name, &category.CreatedAt)
+		return &category, err
+	}, query, tenantID)
+}
+
+func (r *CategoryRepository) FindAllNames(ctx context.Context) ([]string, error) {
+	return queryAll(ctx, r.SqlHandler, func(row scanner) (string, error) {
+		var name string
+		err := row.Scan(&name)
+		return name, err
+	}, `SELECT DISTINCT name FROM categories ORDER BY name`)
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, tenantID string, category *entity.Category) (*entity.Category, error) {
+	query := `INSERT INTO categories (tenant_id, name, created_at) VALUES (?, ?, ?)`
+
+	if _, err := r.Execute(ctx, query, tenantID, category.Name, category.CreatedAt); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, tenantID, name string) error {
+	return execOne(ctx, r.SqlHandler, domainErrors.ErrCategoryNotFound, `DELETE FROM categories WHERE tenant_id = ? AND name = ?`, tenantID, name)
+}
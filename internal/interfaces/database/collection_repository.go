@@ -0,0 +1,223 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CollectionRepository struct {
+	SqlHandler
+}
+
+// コレクションの列と、コレクションのアイテムの数
+const collectionColumns = `c.id, c.owner_id, c.name, c.description, COUNT(ci.item_id), c.created_at, c.updated_at`
+
+const collectionFrom = ` FROM collections c LEFT JOIN collection_items ci ON ci.collection_id = c.id `
+
+const collectionGroupBy = ` GROUP BY c.id, c.owner_id, c.name, c.description, c.created_at, c.updated_at`
+
+func (r *CollectionRepository) Create(ctx context.Context, collection *entity.Collection) (*entity.Collection, error) {
+	query := `
+        INSERT INTO collections (owner_id, name, description, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		collection.OwnerID,
+		collection.Name,
+		collection.Description,
+		collection.CreatedAt,
+		collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *CollectionRepository) FindAll(ctx context.Context, ownerID string) ([]*entity.Collection, error) {
+	query := `SELECT ` + collectionColumns + collectionFrom
+	var args []interface{}
+	if ownerID != "" {
+		query += `WHERE c.owner_id = ?`
+		args = append(args, ownerID)
+	}
+	query += collectionGroupBy + ` ORDER BY c.name, c.id`
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var collections []*entity.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		collections = append(collections, collection)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return collections, nil
+}
+
+func (r *CollectionRepository) FindByID(ctx context.Context, id int64) (*entity.Collection, error) {
+	query := `SELECT ` + collectionColumns + collectionFrom + `WHERE c.id = ?` + collectionGroupBy
+
+	collection, err := scanCollection(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return collection, nil
+}
+
+func (r *CollectionRepository) Update(ctx context.Context, collection *entity.Collection) error {
+	query := `UPDATE collections SET name = ?, description = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.Execute(ctx, query, collection.Name, collection.Description, collection.UpdatedAt, collection.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM collection_items WHERE collection_id = ?`, id); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM collections WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) ItemIDs(ctx context.Context, collectionID int64) ([]int64, error) {
+	query := `SELECT item_id FROM collection_items WHERE collection_id = ? ORDER BY added_at, item_id`
+
+	rows, err := r.Query(ctx, query, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return ids, nil
+}
+
+func (r *CollectionRepository) AddItems(ctx context.Context, collectionID int64, itemIDs []int64, addedAt time.Time) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO collection_items (collection_id, item_id, added_at) VALUES (?, ?, ?)` +
+		strings.Repeat(", (?, ?, ?)", len(itemIDs)-1)
+	args := make([]interface{}, 0, len(itemIDs)*3)
+	for _, id := range itemIDs {
+		args = append(args, collectionID, id, addedAt)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) RemoveItem(ctx context.Context, collectionID, itemID int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM collection_items WHERE collection_id = ? AND item_id = ?`, collectionID, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CollectionRepository) RemoveItemFromAll(ctx context.Context, itemID int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM collection_items WHERE item_id = ?`, itemID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func scanCollection(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Collection, error) {
+	var collection entity.Collection
+
+	err := scanner.Scan(
+		&collection.ID,
+		&collection.OwnerID,
+		&collection.Name,
+		&collection.Description,
+		&collection.ItemCount,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CustomAttributeRepository struct {
+	SqlHandler
+}
+
+func (r *CustomAttributeRepository) FindAll(ctx context.Context, tenantID string) ([]*entity.CustomAttribute, error) {
+	query := `
+        SELECT attr_key, label, attr_type, options, required, created_at, updated_at
+        FROM custom_attributes
+        WHERE tenant_id = ?
+        ORDER BY attr_key
+    `
+
+	rows, err := r.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var attrs []*entity.CustomAttribute
+	for rows.Next() {
+		attr, err := scanCustomAttribute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		attrs = append(attrs, attr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return attrs, nil
+}
+
+func (r *CustomAttributeRepository) Save(ctx context.Context, tenantID string, attr *entity.CustomAttribute) (*entity.CustomAttribute, error) {
+	query := `
+        INSERT INTO custom_attributes (tenant_id, attr_key, label, attr_type, options, required)
+        VALUES (?, ?, ?, ?, ?, ?)
+    ` + upsertClause(r.SqlHandler, []string{"tenant_id", "attr_key"}, "label", "attr_type", "options", "required")
+
+	options, err := json.Marshal(attr.Options)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode options: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	_, err = r.Execute(ctx, query,
+		tenantID,
+		attr.Key,
+		attr.Label,
+		attr.Type,
+		string(options),
+		attr.Required,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.findByKey(ctx, tenantID, attr.Key)
+}
+
+func (r *CustomAttributeRepository) Delete(ctx context.Context, tenantID, key string) error {
+	query := `DELETE FROM custom_attributes WHERE tenant_id = ? AND attr_key = ?`
+
+	result, err := r.Execute(ctx, query, tenantID, key)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrAttributeNotFound
+	}
+
+	return nil
+}
+
+func (r *CustomAttributeRepository) findByKey(ctx context.Context, tenantID, key string) (*entity.CustomAttribute, error) {
+	query := `
+        SELECT attr_key, label, attr_type, options, required, created_at, updated_at
+        FROM custom_attributes
+        WHERE tenant_id = ? AND attr_key = ?
+    `
+
+	attr, err := scanCustomAttribute(r.QueryRow(ctx, query, tenantID, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrAttributeNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return attr, nil
+}
+
+func scanCustomAttribute(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.CustomAttribute, error) {
+	var attr entity.CustomAttribute
+	var options []byte
+
+	err := scanner.Scan(
+		&attr.Key,
+		&attr.Label,
+		&attr.Type,
+		&options,
+		&attr.Required,
+		&attr.CreatedAt,
+		&attr.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(options, &attr.Options); err != nil {
+		return nil, err
+	}
+
+	return &attr, nil
+}
@@ -0,0 +1,38 @@
+package database
+
+import "strings"
+
+// SQL方言
+const (
+	DialectMySQL  = "mysql"
+	DialectSQLite = "sqlite"
+)
+
+// Dialector is implemented by handlers that know their SQL dialect; handlers without it are treated as MySQL
+type Dialector interface {
+	Dialect() string
+}
+
+// DialectOf returns the SQL dialect of a handler
+func DialectOf(h SqlHandler) string {
+	if d, ok := h.(Dialector); ok {
+		return d.Dialect()
+	}
+	return DialectMySQL
+}
+
+// upsertClause returns the clause that turns an INSERT into an update of columns when a row with the same keys exists
+func upsertClause(h SqlHandler, keys []string, columns ...string) string {
+	sets := make([]string, 0, len(columns))
+	if DialectOf(h) == DialectSQLite {
+		for _, c := range columns {
+			sets = append(sets, c+" = excluded."+c)
+		}
+		return "ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET " + strings.Join(sets, ", ")
+	}
+
+	for _, c := range columns {
+		sets = append(sets, c+" = VALUES("+c+")")
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mysqlHandler struct {
+	SqlHandler
+}
+
+type sqliteHandler struct {
+	SqlHandler
+}
+
+func (sqliteHandler) Dialect() string { return DialectSQLite }
+
+func TestUpsertClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  SqlHandler
+		expected string
+	}{
+		{
+			name:     "正常系: MySQL",
+			handler:  mysqlHandler{},
+			expected: "ON DUPLICATE KEY UPDATE label = VALUES(label), options = VALUES(options)",
+		},
+		{
+			name:     "正常系: SQLite",
+			handler:  sqliteHandler{},
+			expected: "ON CONFLICT (tenant_id, attr_key) DO UPDATE SET label = excluded.label, options = excluded.options",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, upsertClause(tt.handler, []string{"tenant_id", "attr_key"}, "label", "options"))
+		})
+	}
+}
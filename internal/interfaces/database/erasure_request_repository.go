@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ErasureRequestRepository struct {
+	SqlHandler
+}
+
+const erasureRequestColumns = `user_id, requested_at, erase_after`
+
+func (r *ErasureRequestRepository) Create(ctx context.Context, request *entity.ErasureRequest) (*entity.ErasureRequest, error) {
+	// 依頼済みなら最初の依頼を残す（依頼し直しても猶予期間は延びない）
+	query := `
+        INSERT INTO erasure_requests (user_id, requested_at, erase_after)
+        VALUES (?, ?, ?)
+    ` + upsertClause(r.SqlHandler, []string{"user_id"}, "user_id")
+
+	_, err := r.Execute(ctx, query,
+		request.UserID,
+		request.RequestedAt,
+		request.EraseAfter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByUserID(ctx, request.UserID)
+}
+
+func (r *ErasureRequestRepository) FindByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	query := `SELECT ` + erasureRequestColumns + ` FROM erasure_requests WHERE user_id = ?`
+
+	var request entity.ErasureRequest
+	err := r.QueryRow(ctx, query, userID).Scan(&request.UserID, &request.RequestedAt, &request.EraseAfter)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrErasureRequestNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &request, nil
+}
+
+func (r *ErasureRequestRepository) FindDue(ctx context.Context, now time.Time) ([]*entity.ErasureRequest, error) {
+	query := `SELECT ` + erasureRequestColumns + ` FROM erasure_requests WHERE erase_after <= ? ORDER BY erase_after, user_id`
+
+	rows, err := r.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var requests []*entity.ErasureRequest
+	for rows.Next() {
+		var request entity.ErasureRequest
+		if err := rows.Scan(&request.UserID, &request.RequestedAt, &request.EraseAfter); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		requests = append(requests, &request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return requests, nil
+}
+
+func (r *ErasureRequestRepository) Delete(ctx context.Context, userID string) error {
+	result, err := r.Execute(ctx, `DELETE FROM erasure_requests WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrErasureRequestNotFound
+	}
+
+	return nil
+}
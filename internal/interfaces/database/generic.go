@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// リポジトリに共通する処理（複数行・1行の読み取り、挿入、1行の更新・削除、ページング）
+// ドライバーのエラーは ErrDatabaseError で包み、行がないことは呼び出し側が渡す NotFound のエラーにする
+
+// scanner は Row と Rows に共通する読み取り。別名にして、既存の scanXxx(scanner interface{ Scan(...) }) をそのまま渡せるようにする
+type scanner = interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryAll は query の結果の行をすべて scan で読み取る。行がなければ空（nil）のスライスを返す
+func queryAll[T any](ctx context.Context, h SqlHandler, scan func(scanner) (T, error), query string, args ...interface{}) ([]T, error) {
+	rows, err := h.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var values []T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		values = append(values, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return values, nil
+}
+
+// queryOne は query の結果の1行を scan で読み取る。行がなければ notFound を返す
+func queryOne[T any](ctx context.Context, h SqlHandler, notFound error, scan func(scanner) (T, error), query string, args ...interface{}) (T, error) {
+	v, err := scan(h.QueryRow(ctx, query, args...))
+	if err != nil {
+		var zero T
+		if errors.Is(err, sql.ErrNoRows) {
+			return zero, notFound
+		}
+		return zero, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return v, nil
+}
+
+// insert は INSERT 文を実行し、生成された ID を返す
+func insert(ctx context.Context, h SqlHandler, query string, args ...interface{}) (int64, error) {
+	result, err := h.Execute(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return id, nil
+}
+
+// execOne は1行を対象にする UPDATE・DELETE 文を実行する。対象の行がなければ notFound を返す
+func execOne(ctx context.Context, h SqlHandler, notFound error, query string, args ...interface{}) error {
+	result, err := h.Execute(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// paginate は query に LIMIT・OFFSET を付ける。limit が 0 以下なら全件のまま
+func paginate(query string, args []interface{}, limit, offset int) (string, []interface{}) {
+	if limit <= 0 {
+		return query, args
+	}
+	return query + " LIMIT ? OFFSET ?", append(args, limit, max(offset, 0))
+}
@@ -0,0 +1,22 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	query, args := paginate("SELECT id FROM items WHERE owner_id = ?", []interface{}{"alice"}, 20, 40)
+	assert.Equal(t, "SELECT id FROM items WHERE owner_id = ? LIMIT ? OFFSET ?", query)
+	assert.Equal(t, []interface{}{"alice", 20, 40}, args)
+
+	// 件数の指定がなければ全件
+	query, args = paginate("SELECT id FROM items", nil, 0, 40)
+	assert.Equal(t, "SELECT id FROM items", query)
+	assert.Empty(t, args)
+
+	// 負のオフセットは 0
+	_, args = paginate("SELECT id FROM items", nil, 10, -5)
+	assert.Equal(t, []interface{}{10, 0}, args)
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ItemCodeRepository struct {
+	SqlHandler
+}
+
+func (r *ItemCodeRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemCode, error) {
+	rows, err := r.Query(ctx, `SELECT type, code, created_at FROM item_codes WHERE item_id = ? ORDER BY type, code`, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	codes := []*entity.ItemCode{}
+	for rows.Next() {
+		var code entity.ItemCode
+		if err := rows.Scan(&code.Type, &code.Code, &code.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		codes = append(codes, &code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return codes, nil
+}
+
+func (r *ItemCodeRepository) SetItemCodes(ctx context.Context, itemID int64, codes []*entity.ItemCode) error {
+	if _, err := r.Execute(ctx, `DELETE FROM item_codes WHERE item_id = ?`, itemID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO item_codes (item_id, type, code, created_at) VALUES (?, ?, ?, ?)` + strings.Repeat(", (?, ?, ?, ?)", len(codes)-1)
+	args := make([]interface{}, 0, len(codes)*4)
+	for _, code := range codes {
+		args = append(args, itemID, code.Type, code.Code, code.CreatedAt)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+// FindItems は code の索引で候補を探し、種類も一致するものだけを返す
+func (r *ItemCodeRepository) FindItems(ctx context.Context, codes []*entity.ItemCode) ([]usecase.ItemCodeMatch, error) {
+	matches := []usecase.ItemCodeMatch{}
+	if len(codes) == 0 {
+		return matches, nil
+	}
+
+	wanted := make(map[entity.ItemCode]bool, len(codes))
+	args := make([]interface{}, len(codes))
+	for i, code := range codes {
+		wanted[entity.ItemCode{Type: code.Type, Code: code.Code}] = true
+		args[i] = code.Code
+	}
+	query := `SELECT item_id, type, code FROM item_codes WHERE code IN (?` + strings.Repeat(", ?", len(codes)-1) + `) ORDER BY item_id, type`
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var match usecase.ItemCodeMatch
+		var code string
+		if err := rows.Scan(&match.ItemID, &match.Type, &code); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if wanted[entity.ItemCode{Type: match.Type, Code: code}] {
+			matches = append(matches, match)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return matches, nil
+}
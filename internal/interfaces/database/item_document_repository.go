@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemDocumentRepository struct {
+	SqlHandler
+}
+
+const itemDocumentColumns = `id, item_id, type, file_name, content_type, size, sha256, storage_key, created_at`
+
+func (r *ItemDocumentRepository) Create(ctx context.Context, document *entity.ItemDocument) (*entity.ItemDocument, error) {
+	query := `
+        INSERT INTO item_documents (item_id, type, file_name, content_type, size, sha256, storage_key, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		document.ItemID,
+		document.Type,
+		document.FileName,
+		document.ContentType,
+		document.Size,
+		document.SHA256,
+		document.StorageKey,
+		document.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ItemDocumentRepository) FindByID(ctx context.Context, id int64) (*entity.ItemDocument, error) {
+	query := `SELECT ` + itemDocumentColumns + ` FROM item_documents WHERE id = ?`
+
+	document, err := scanItemDocument(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return document, nil
+}
+
+func (r *ItemDocumentRepository) FindByItemID(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error) {
+	query := `SELECT ` + itemDocumentColumns + ` FROM item_documents WHERE item_id = ?`
+	args := []interface{}{itemID}
+	if docType != "" {
+		query += ` AND type = ?`
+		args = append(args, docType)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var documents []*entity.ItemDocument
+	for rows.Next() {
+		document, err := scanItemDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		documents = append(documents, document)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return documents, nil
+}
+
+func (r *ItemDocumentRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_documents WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrDocumentNotFound
+	}
+
+	return nil
+}
+
+func (r *ItemDocumentRepository) ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(keys) == 0 {
+		return existing, nil
+	}
+
+	query := `SELECT storage_key FROM item_documents WHERE storage_key IN (?` + strings.Repeat(", ?", len(keys)-1) + `)`
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		existing[key] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return existing, nil
+}
+
+func scanItemDocument(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemDocument, error) {
+	var document entity.ItemDocument
+
+	err := scanner.Scan(
+		&document.ID,
+		&document.ItemID,
+		&document.Type,
+		&document.FileName,
+		&document.ContentType,
+		&document.Size,
+		&document.SHA256,
+		&document.StorageKey,
+		&document.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
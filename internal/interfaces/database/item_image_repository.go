@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemImageRepository struct {
+	SqlHandler
+}
+
+const itemImageColumns = `id, item_id, file_name, content_type, size, sha256, storage_key, created_at`
+
+func (r *ItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	query := `
+        INSERT INTO item_images (item_id, file_name, content_type, size, sha256, storage_key, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		image.ItemID,
+		image.FileName,
+		image.ContentType,
+		image.Size,
+		image.SHA256,
+		image.StorageKey,
+		image.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ItemImageRepository) FindByID(ctx context.Context, id int64) (*entity.ItemImage, error) {
+	query := `SELECT ` + itemImageColumns + ` FROM item_images WHERE id = ?`
+
+	image, err := scanItemImage(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return image, nil
+}
+
+func (r *ItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	query := `SELECT ` + itemImageColumns + ` FROM item_images WHERE item_id = ? ORDER BY id`
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var images []*entity.ItemImage
+	for rows.Next() {
+		image, err := scanItemImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		images = append(images, image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return images, nil
+}
+
+func (r *ItemImageRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_images WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrImageNotFound
+	}
+
+	return nil
+}
+
+func (r *ItemImageRepository) ListIDsByItem(ctx context.Context, itemIDs []int64) (map[int64][]int64, error) {
+	if len(itemIDs) == 0 {
+		return make(map[int64][]int64), nil
+	}
+
+	query := `SELECT item_id, id FROM item_images WHERE item_id IN (?` + strings.Repeat(", ?", len(itemIDs)-1) + `) ORDER BY id`
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	return r.listIDsByItem(ctx, query, args...)
+}
+
+func (r *ItemImageRepository) ListAllIDsByItem(ctx context.Context) (map[int64][]int64, error) {
+	return r.listIDsByItem(ctx, `SELECT item_id, id FROM item_images ORDER BY id`)
+}
+
+func (r *ItemImageRepository) listIDsByItem(ctx context.Context, query string, args ...interface{}) (map[int64][]int64, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	ids := make(map[int64][]int64)
+	for rows.Next() {
+		var itemID, id int64
+		if err := rows.Scan(&itemID, &id); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		ids[itemID] = append(ids[itemID], id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return ids, nil
+}
+
+func (r *ItemImageRepository) ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(keys) == 0 {
+		return existing, nil
+	}
+
+	query := `SELECT storage_key FROM item_images WHERE storage_key IN (?` + strings.Repeat(", ?", len(keys)-1) + `)`
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		existing[key] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return existing, nil
+}
+
+func scanItemImage(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemImage, error) {
+	var image entity.ItemImage
+
+	err := scanner.Scan(
+		&image.ID,
+		&image.ItemID,
+		&image.FileName,
+		&image.ContentType,
+		&image.Size,
+		&image.SHA256,
+		&image.StorageKey,
+		&image.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ItemMergeRepository struct {
+	SqlHandler
+}
+
+// 統合で付け替えるテーブル（アイテムのIDを item_id に持つ）
+var attachmentTables = []string{"item_images", "item_documents", "item_valuations", "item_services"}
+
+func (r *ItemMergeRepository) FindAttachments(ctx context.Context, itemIDs []int64) (*usecase.ItemAttachments, error) {
+	attachments := &usecase.ItemAttachments{}
+	targets := []*[]int64{&attachments.ImageIDs, &attachments.DocumentIDs, &attachments.ValuationIDs, &attachments.ServiceRecordIDs}
+	if len(itemIDs) == 0 {
+		for _, target := range targets {
+			*target = []int64{}
+		}
+		return attachments, nil
+	}
+
+	in, args := itemIDArgs(itemIDs)
+	for i, table := range attachmentTables {
+		ids, err := r.findIDs(ctx, `SELECT id FROM `+table+` WHERE item_id IN `+in+` ORDER BY id`, args...)
+		if err != nil {
+			return nil, err
+		}
+		*targets[i] = ids
+	}
+
+	return attachments, nil
+}
+
+func (r *ItemMergeRepository) MoveAttachments(ctx context.Context, itemIDs []int64, targetID int64) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	in, args := itemIDArgs(itemIDs)
+	for _, table := range attachmentTables {
+		query := `UPDATE ` + table + ` SET item_id = ? WHERE item_id IN ` + in
+		if _, err := r.Execute(ctx, query, append([]interface{}{targetID}, args...)...); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (r *ItemMergeRepository) findIDs(ctx context.Context, query string, args ...interface{}) ([]int64, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return ids, nil
+}
+
+// itemIDArgs returns the IN clause and arguments for the given item IDs
+func itemIDArgs(itemIDs []int64) (string, []interface{}) {
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	return `(?` + strings.Repeat(", ?", len(itemIDs)-1) + `)`, args
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemRevisionRepository struct {
+	SqlHandler
+}
+
+const itemRevisionColumns = `id, item_id, revision, snapshot, changed_by, created_at`
+
+func (r *ItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) error {
+	snapshot, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode item snapshot: %w", err)
+	}
+
+	query := `
+        INSERT INTO item_revisions (item_id, revision, snapshot, changed_by, created_at)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	_, err = r.Execute(ctx, query,
+		revision.ItemID,
+		revision.Revision,
+		string(snapshot),
+		revision.ChangedBy,
+		revision.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *ItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	query := `SELECT ` + itemRevisionColumns + ` FROM item_revisions WHERE item_id = ? ORDER BY revision`
+
+	return r.findRevisions(ctx, query, itemID)
+}
+
+func (r *ItemRevisionRepository) FindByChangedBy(ctx context.Context, userID string) ([]*entity.ItemRevision, error) {
+	query := `SELECT ` + itemRevisionColumns + ` FROM item_revisions WHERE changed_by = ? ORDER BY created_at, id`
+
+	return r.findRevisions(ctx, query, userID)
+}
+
+func (r *ItemRevisionRepository) findRevisions(ctx context.Context, query string, args ...interface{}) ([]*entity.ItemRevision, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var revisions []*entity.ItemRevision
+	for rows.Next() {
+		revision, err := scanItemRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return revisions, nil
+}
+
+func (r *ItemRevisionRepository) FindByRevision(ctx context.Context, itemID, revision int64) (*entity.ItemRevision, error) {
+	query := `SELECT ` + itemRevisionColumns + ` FROM item_revisions WHERE item_id = ? AND revision = ?`
+
+	found, err := scanItemRevision(r.QueryRow(ctx, query, itemID, revision))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemRevisionNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return found, nil
+}
+
+func (r *ItemRevisionRepository) CountCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	var count int
+	err := r.QueryRow(ctx, `SELECT COUNT(*) FROM item_revisions WHERE created_at < ?`, before).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
+
+func (r *ItemRevisionRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.Execute(ctx, `DELETE FROM item_revisions WHERE created_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return int(rowsAffected), nil
+}
+
+func (r *ItemRevisionRepository) DeleteByItemID(ctx context.Context, itemID int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM item_revisions WHERE item_id = ?`, itemID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *ItemRevisionRepository) ReplaceChangedBy(ctx context.Context, userID, pseudonym string) error {
+	if _, err := r.Execute(ctx, `UPDATE item_revisions SET changed_by = ? WHERE changed_by = ?`, pseudonym, userID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func scanItemRevision(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemRevision, error) {
+	var revision entity.ItemRevision
+	var snapshot string
+
+	err := scanner.Scan(
+		&revision.ID,
+		&revision.ItemID,
+		&revision.Revision,
+		&snapshot,
+		&revision.ChangedBy,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(snapshot), &revision.Snapshot); err != nil {
+		return nil, fmt.Errorf("revision %d is not valid JSON: %s", revision.ID, err.Error())
+	}
+
+	return &revision, nil
+}
@@ -3,52 +3,130 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
 )
 
 type ItemRepository struct {
 	SqlHandler
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
-        FROM items
-        ORDER BY created_at DESC
-    `
+const itemColumns = `id, name, category, brand, purchase_price, currency, purchase_date, attributes, owner_id, version, created_at, updated_at`
+
+// notTrashed leaves out the items in the trash
+const notTrashed = `deleted_at IS NULL`
+
+// basePrice is the purchase price of an item bought in the currency given as its argument (BaseCurrency), and NULL
+// for the other items, which the aggregates then leave out
+const basePrice = `CASE WHEN currency = ? THEN purchase_price END`
+
+// currentValue is the value of the item's latest valuation (NULL if it was never valued), looked up
+// per row through the (item_id, created_at) index of item_valuations
+const currentValue = `(SELECT v.value FROM item_valuations v WHERE v.item_id = items.id ORDER BY v.created_at DESC, v.id DESC LIMIT 1)`
 
-	rows, err := r.Query(ctx, query)
+func (r *ItemRepository) FindAll(ctx context.Context, q usecase.ItemQuery) ([]*entity.Item, error) {
+	var items []*entity.Item
+	err := r.Iterate(ctx, q, func(item *entity.Item) error {
+		items = append(items, item)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
-	defer rows.Close()
+	return items, nil
+}
 
-	var items []*entity.Item
-	for rows.Next() {
-		item, err := scanItem(rows)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+// Iterate scans the matching rows one at a time; the connection is held until iteration finishes
+func (r *ItemRepository) Iterate(ctx context.Context, q usecase.ItemQuery, fn func(*entity.Item) error) error {
+	it, err := r.FindAllStream(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			return err
 		}
-		items = append(items, item)
 	}
+	return it.Err()
+}
 
-	if err = rows.Err(); err != nil {
+// FindAllStream returns an iterator over the rows of the query; the connection is held until it is closed
+func (r *ItemRepository) FindAllStream(ctx context.Context, q usecase.ItemQuery) (usecase.ItemIterator, error) {
+	where, args := itemQueryWhereClause(q)
+	query := `
+        SELECT ` + itemColumns + `
+        FROM items` + where + itemOrderClause(q.SortBy, q.SortOrder)
+	query, args = paginate(query, args, q.Limit, q.Offset)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
+	return &itemIterator{rows: rows}, nil
+}
 
-	return items, nil
+// itemIterator scans the rows of a query into items one at a time
+type itemIterator struct {
+	rows Rows
+	item *entity.Item
+	err  error
+}
+
+func (it *itemIterator) Next() bool {
+	if it.err != nil || !it.rows.Next() {
+		return false
+	}
+	item, err := scanItem(it.rows)
+	if err != nil {
+		it.err = fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return false
+	}
+	it.item = item
+	return true
+}
+
+func (it *itemIterator) Item() *entity.Item {
+	return it.item
+}
+
+func (it *itemIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if err := it.rows.Err(); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (it *itemIterator) Close() error {
+	return it.rows.Close()
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	where, args := itemWhereClause(filter)
+
+	var count int
+	if err := r.QueryRow(ctx, "SELECT COUNT(*) FROM items"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        WHERE id = ?
-    `
+        WHERE id = ? AND ` + notTrashed + `
+    ` + lockClause(ctx, r.SqlHandler)
 
 	row := r.QueryRow(ctx, query, id)
 
@@ -65,16 +143,24 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item,
 
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (name, category, brand, purchase_price, currency, purchase_date, attributes, owner_id)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
     `
 
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.Execute(ctx, query,
 		item.Name,
 		item.Category,
 		item.Brand,
 		item.PurchasePrice,
+		item.Price().Currency,
 		item.PurchaseDate,
+		attributes,
+		sql.NullString{String: item.OwnerID, Valid: item.OwnerID != ""},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
@@ -108,19 +194,95 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *ItemRepository) Trash(ctx context.Context, id int64, deletedAt time.Time) error {
+	query := `UPDATE items SET deleted_at = ? WHERE id = ? AND ` + notTrashed
+
+	result, err := r.Execute(ctx, query, deletedAt, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	query := `UPDATE items SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+
+	result, err := r.Execute(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) FindTrashed(ctx context.Context, q usecase.TrashQuery) ([]*entity.TrashedItem, error) {
+	query := `SELECT ` + itemColumns + `, deleted_at FROM items WHERE deleted_at IS NOT NULL`
+	var args []interface{}
+	if !q.DeletedBefore.IsZero() {
+		query += " AND deleted_at < ?"
+		args = append(args, q.DeletedBefore)
+	}
+	query += " ORDER BY deleted_at DESC, id DESC"
+	query, args = paginate(query, args, q.Limit, q.Offset)
+
+	return queryAll(ctx, r.SqlHandler, scanTrashedItem, query, args...)
+}
+
+func (r *ItemRepository) CountTrashed(ctx context.Context) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, "SELECT COUNT(*) FROM items WHERE deleted_at IS NOT NULL").Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
+
+func (r *ItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.TrashedItem, error) {
+	query := `SELECT ` + itemColumns + `, deleted_at FROM items WHERE id = ? AND deleted_at IS NOT NULL` + lockClause(ctx, r.SqlHandler)
+
+	return queryOne(ctx, r.SqlHandler, domainErrors.ErrItemNotFound, scanTrashedItem, query, id)
+}
+
 func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	query := `
-        UPDATE items 
-        SET name = ?, brand = ?, purchase_price = ?, updated_at = ?
-        WHERE id = ?
+        UPDATE items
+        SET category = ?, name = ?, brand = ?, purchase_price = ?, attributes = ?, owner_id = ?, updated_at = ?, version = version + 1
+        WHERE id = ? AND version = ? AND ` + notTrashed + `
     `
 
+	attributes, err := marshalAttributes(item.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.Execute(ctx, query,
+		item.Category,
 		item.Name,
 		item.Brand,
 		item.PurchasePrice,
+		attributes,
+		sql.NullString{String: item.OwnerID, Valid: item.OwnerID != ""},
 		item.UpdatedAt,
 		item.ID,
+		item.Version,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
@@ -131,34 +293,43 @@ func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity
 		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
+	// 更新されなかった場合は、削除（ゴミ箱への移動を含む）されたのか他の更新でバージョンが変わったのかを区別する
 	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+		if _, err := r.FindByID(ctx, item.ID); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.Conflict("item %d has been modified", item.ID)
 	}
 
 	return r.FindByID(ctx, item.ID)
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	where, whereArgs := purchaseDateWhereClause(purchased)
 	query := `
-        SELECT category, COUNT(*) as count
-        FROM items
+        SELECT category, COUNT(*) as count,
+               COALESCE(MIN(` + basePrice + `), 0), COALESCE(MAX(` + basePrice + `), 0),
+               COALESCE(AVG(` + basePrice + `), 0), COALESCE(SUM(` + basePrice + `), 0)
+        FROM items` + where + `
         GROUP BY category
     `
+	args := []interface{}{usecase.BaseCurrency, usecase.BaseCurrency, usecase.BaseCurrency, usecase.BaseCurrency}
+	args = append(args, whereArgs...)
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	summary := make(map[string]usecase.CategoryStats)
 	for rows.Next() {
 		var category string
-		var count int
-		if err := rows.Scan(&category, &count); err != nil {
+		var stats usecase.CategoryStats
+		if err := rows.Scan(&category, &stats.Count, &stats.Min, &stats.Max, &stats.Avg, &stats.Sum); err != nil {
 			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 		}
-		summary[category] = count
+		summary[category] = stats
 	}
 
 	if err = rows.Err(); err != nil {
@@ -168,11 +339,158 @@ func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]i
 	return summary, nil
 }
 
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) (map[string]*usecase.BrandTotals, error) {
+	query := `
+        SELECT brand, COUNT(*), COALESCE(SUM(` + basePrice + `), 0)
+        FROM items
+        WHERE ` + notTrashed + `
+        GROUP BY brand
+    `
+
+	rows, err := r.Query(ctx, query, usecase.BaseCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	summary := make(map[string]*usecase.BrandTotals)
+	for rows.Next() {
+		var brand string
+		totals := &usecase.BrandTotals{}
+		if err := rows.Scan(&brand, &totals.Count, &totals.PurchaseTotal); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		summary[brand] = totals
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return summary, nil
+}
+
+func (r *ItemRepository) CountByPriceBands(ctx context.Context, bounds []int) ([]int, error) {
+	// CASE WHEN purchase_price < ? THEN 0 WHEN purchase_price < ? THEN 1 ... ELSE len(bounds) END
+	var band strings.Builder
+	band.WriteString("CASE")
+	args := make([]interface{}, 0, len(bounds))
+	for i, bound := range bounds {
+		fmt.Fprintf(&band, " WHEN purchase_price < ? THEN %d", i)
+		args = append(args, bound)
+	}
+	fmt.Fprintf(&band, " ELSE %d END", len(bounds))
+
+	query := "SELECT " + band.String() + " AS band, COUNT(*) FROM items WHERE " + notTrashed + " AND currency = ? GROUP BY band"
+	args = append(args, usecase.BaseCurrency)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	counts := make([]int, len(bounds)+1)
+	for rows.Next() {
+		var band, count int
+		if err := rows.Scan(&band, &count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		counts[band] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return counts, nil
+}
+
+// itemWhereClause builds the WHERE clause and its arguments for a filter
+func itemWhereClause(filter usecase.ItemFilter) (string, []interface{}) {
+	return filterWhereClause([]string{notTrashed}, nil, filter)
+}
+
+// itemQueryWhereClause builds the WHERE clause and its arguments for a query, which selects either the items
+// not in the trash or the ones moved to the trash before TrashedBefore
+func itemQueryWhereClause(q usecase.ItemQuery) (string, []interface{}) {
+	conditions, args := []string{notTrashed}, []interface{}(nil)
+	if !q.TrashedBefore.IsZero() {
+		conditions, args = []string{"deleted_at < ?"}, []interface{}{q.TrashedBefore}
+	}
+	if q.AfterID > 0 {
+		conditions = append(conditions, "id > ?")
+		args = append(args, q.AfterID)
+	}
+	return filterWhereClause(conditions, args, q.ItemFilter)
+}
+
+// filterWhereClause adds the conditions of a filter to conditions and args
+func filterWhereClause(conditions []string, args []interface{}, filter usecase.ItemFilter) (string, []interface{}) {
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Category)
+	}
+	if filter.Brand != "" {
+		conditions = append(conditions, "brand = ?")
+		args = append(args, filter.Brand)
+	}
+	if filter.OwnerID != "" {
+		conditions = append(conditions, "owner_id = ?")
+		args = append(args, filter.OwnerID)
+	}
+	if filter.Currency != "" {
+		conditions = append(conditions, "currency = ?")
+		args = append(args, filter.Currency)
+	}
+	if filter.MinCurrentValue > 0 {
+		conditions = append(conditions, currentValue+" >= ?")
+		args = append(args, filter.MinCurrentValue)
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// purchaseDateWhereClause builds the WHERE clause and its arguments for a purchase date range
+func purchaseDateWhereClause(purchased usecase.DateRange) (string, []interface{}) {
+	conditions := []string{notTrashed}
+	var args []interface{}
+	if purchased.From != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, purchased.From)
+	}
+	if purchased.To != "" {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, purchased.To)
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// itemOrderClause builds the ORDER BY clause; only sortable fields are accepted so the column name is never user input
+func itemOrderClause(sortBy, sortOrder string) string {
+	direction := "DESC"
+	if sortOrder == entity.SortAsc {
+		direction = "ASC"
+	}
+	if sortBy == usecase.SortByID {
+		return " ORDER BY id " + direction
+	}
+	if sortBy == usecase.SortByCurrentValue {
+		// NULL の並び順は MySQL と SQLite で同じだが、向きによって先頭にも末尾にもなるため、評価のないアイテムを常に最後にする
+		return fmt.Sprintf(" ORDER BY %s IS NULL, %s %s, id %s", currentValue, currentValue, direction, direction)
+	}
+	if !entity.IsSortableField(sortBy) {
+		sortBy = "created_at"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, direction, direction)
+}
+
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*entity.Item, error) {
 	var item entity.Item
 	var purchaseDate string
+	var attributes []byte
+	var ownerID sql.NullString
 	var createdAt, updatedAt time.Time
 
 	err := scanner.Scan(
@@ -181,7 +499,11 @@ func scanItem(scanner interface {
 		&item.Category,
 		&item.Brand,
 		&item.PurchasePrice,
+		&item.Currency,
 		&purchaseDate,
+		&attributes,
+		&ownerID,
+		&item.Version,
 		&createdAt,
 		&updatedAt,
 	)
@@ -189,16 +511,61 @@ func scanItem(scanner interface {
 		return nil, err
 	}
 
+	if len(attributes) > 0 {
+		if err := json.Unmarshal(attributes, &item.Attributes); err != nil {
+			return nil, err
+		}
+	}
+
 	if purchaseDate != "" {
 		if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
 			item.PurchaseDate = parsedDate.Format("2006-01-02")
+		} else if parsedDate, err := time.Parse(time.RFC3339, purchaseDate); err == nil {
+			// SQLite のドライバーは DATE 型の列を日時の文字列で返す
+			item.PurchaseDate = parsedDate.Format("2006-01-02")
 		} else {
 			item.PurchaseDate = purchaseDate
 		}
 	}
 
+	item.OwnerID = ownerID.String
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
 
 	return &item, nil
 }
+
+// scanTrashedItem scans the item columns followed by deleted_at
+func scanTrashedItem(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.TrashedItem, error) {
+	var deletedAt time.Time
+	item, err := scanItem(scannerFunc(func(dest ...interface{}) error {
+		return scanner.Scan(append(dest, &deletedAt)...)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.TrashedItem{Item: item, DeletedAt: deletedAt}, nil
+}
+
+// scannerFunc adapts a function to the Scan method of *sql.Row and *sql.Rows
+type scannerFunc func(dest ...interface{}) error
+
+func (f scannerFunc) Scan(dest ...interface{}) error {
+	return f(dest...)
+}
+
+// marshalAttributes encodes custom attributes for the JSON column (NULL when empty)
+func marshalAttributes(attributes map[string]string) (interface{}, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode attributes: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemWhereClause(t *testing.T) {
+	// ゴミ箱のアイテムは常に除く
+	where, args := itemWhereClause(usecase.ItemFilter{})
+	assert.Equal(t, " WHERE deleted_at IS NULL", where)
+	assert.Empty(t, args)
+
+	where, args = itemWhereClause(usecase.ItemFilter{Category: "時計", OwnerID: "alice"})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND category = ? AND owner_id = ?", where)
+	assert.Equal(t, []interface{}{"時計", "alice"}, args)
+
+	where, args = itemWhereClause(usecase.ItemFilter{Currency: "JPY"})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND currency = ?", where)
+	assert.Equal(t, []interface{}{"JPY"}, args)
+
+	// 時価は最新の評価をサブクエリで求める
+	where, args = itemWhereClause(usecase.ItemFilter{MinCurrentValue: 1000000})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND "+currentValue+" >= ?", where)
+	assert.Equal(t, []interface{}{1000000}, args)
+}
+
+func TestItemQueryWhereClause(t *testing.T) {
+	where, args := itemQueryWhereClause(usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Brand: "ROLEX"}})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND brand = ?", where)
+	assert.Equal(t, []interface{}{"ROLEX"}, args)
+
+	// TrashedBefore を指定するとゴミ箱のアイテムを選ぶ
+	before := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	where, args = itemQueryWhereClause(usecase.ItemQuery{ItemFilter: usecase.ItemFilter{OwnerID: "alice"}, TrashedBefore: before})
+	assert.Equal(t, " WHERE deleted_at < ? AND owner_id = ?", where)
+	assert.Equal(t, []interface{}{before, "alice"}, args)
+
+	where, args = itemQueryWhereClause(usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Category: "時計"}, AfterID: 42})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND id > ? AND category = ?", where)
+	assert.Equal(t, []interface{}{int64(42), "時計"}, args)
+}
+
+func TestItemOrderClause(t *testing.T) {
+	tests := []struct {
+		name      string
+		sortBy    string
+		sortOrder string
+		expected  string
+	}{
+		{name: "正常系: デフォルトは作成日時の降順", expected: " ORDER BY created_at DESC, id DESC"},
+		{name: "正常系: 昇順", sortBy: "purchase_price", sortOrder: "asc", expected: " ORDER BY purchase_price ASC, id ASC"},
+		{name: "正常系: IDの順", sortBy: "id", sortOrder: "asc", expected: " ORDER BY id ASC"},
+		{name: "正常系: 時価の順（評価のないアイテムは最後）", sortBy: "current_value", sortOrder: "asc", expected: " ORDER BY " + currentValue + " IS NULL, " + currentValue + " ASC, id ASC"},
+		{name: "異常系: ソートできない列は使わない", sortBy: "name; DROP TABLE items", expected: " ORDER BY created_at DESC, id DESC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, itemOrderClause(tt.sortBy, tt.sortOrder))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type LoanRepository struct {
+	SqlHandler
+}
+
+const loanColumns = `id, item_id, lender, borrower, due_date, note, status, created_at, returned_at`
+
+func (r *LoanRepository) Create(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	query := `
+        INSERT INTO item_loans (item_id, lender, borrower, due_date, note, status)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	id, err := insert(ctx, r.SqlHandler, query,
+		loan.ItemID,
+		loan.Lender,
+		loan.Borrower,
+		loan.DueDate,
+		loan.Note,
+		loan.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *LoanRepository) FindByID(ctx context.Context, id int64) (*entity.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE id = ?`
+
+	return queryOne(ctx, r.SqlHandler, domainErrors.ErrLoanNotFound, scanLoan, query, id)
+}
+
+func (r *LoanRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE item_id = ? ORDER BY created_at, id`
+
+	return queryAll(ctx, r.SqlHandler, scanLoan, query, itemID)
+}
+
+// FindActiveByItemID は貸し出し中でなければ nil を返す
+func (r *LoanRepository) FindActiveByItemID(ctx context.Context, itemID int64) (*entity.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE item_id = ? AND status = ? ORDER BY id DESC LIMIT 1`
+
+	return queryOne(ctx, r.SqlHandler, nil, scanLoan, query, itemID, entity.LoanStatusActive)
+}
+
+func (r *LoanRepository) FindOverdue(ctx context.Context, today string) ([]*entity.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM item_loans WHERE status = ? AND due_date < ? ORDER BY due_date, id`
+
+	return queryAll(ctx, r.SqlHandler, scanLoan, query, entity.LoanStatusActive, today)
+}
+
+func (r *LoanRepository) MarkReturned(ctx context.Context, loan *entity.Loan) error {
+	// active のときだけ更新して、同時に返却された場合の二重処理を防ぐ
+	query := `UPDATE item_loans SET status = ?, returned_at = ? WHERE id = ? AND status = ?`
+
+	return execOne(ctx, r.SqlHandler, domainErrors.Conflict("loan %d is no longer active", loan.ID), query,
+		loan.Status,
+		loan.ReturnedAt,
+		loan.ID,
+		entity.LoanStatusActive,
+	)
+}
+
+func scanLoan(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Loan, error) {
+	var loan entity.Loan
+	var dueDate string
+	var returnedAt sql.NullTime
+
+	err := scanner.Scan(
+		&loan.ID,
+		&loan.ItemID,
+		&loan.Lender,
+		&loan.Borrower,
+		&dueDate,
+		&loan.Note,
+		&loan.Status,
+		&loan.CreatedAt,
+		&returnedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	loan.DueDate = dateOnly(dueDate)
+	if returnedAt.Valid {
+		loan.ReturnedAt = &returnedAt.Time
+	}
+
+	return &loan, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type NotificationRuleRepository struct {
+	SqlHandler
+}
+
+const notificationRuleColumns = `id, min_price, events, recipients, created_at`
+
+func (r *NotificationRuleRepository) Create(ctx context.Context, rule *entity.NotificationRule) (*entity.NotificationRule, error) {
+	query := `
+        INSERT INTO notification_rules (min_price, events, recipients)
+        VALUES (?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		rule.MinPrice,
+		strings.Join(rule.Events, ","),
+		strings.Join(rule.Recipients, ","),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *NotificationRuleRepository) FindAll(ctx context.Context) ([]*entity.NotificationRule, error) {
+	query := `SELECT ` + notificationRuleColumns + ` FROM notification_rules ORDER BY id`
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var rules []*entity.NotificationRule
+	for rows.Next() {
+		rule, err := scanNotificationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return rules, nil
+}
+
+func (r *NotificationRuleRepository) FindByID(ctx context.Context, id int64) (*entity.NotificationRule, error) {
+	query := `SELECT ` + notificationRuleColumns + ` FROM notification_rules WHERE id = ?`
+
+	rule, err := scanNotificationRule(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrNotificationRuleNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return rule, nil
+}
+
+func (r *NotificationRuleRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM notification_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrNotificationRuleNotFound
+	}
+
+	return nil
+}
+
+func scanNotificationRule(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.NotificationRule, error) {
+	var rule entity.NotificationRule
+	var events, recipients string
+
+	err := scanner.Scan(
+		&rule.ID,
+		&rule.MinPrice,
+		&events,
+		&recipients,
+		&rule.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Events = []string{}
+	if events != "" {
+		rule.Events = strings.Split(events, ",")
+	}
+	rule.Recipients = []string{}
+	if recipients != "" {
+		rule.Recipients = strings.Split(recipients, ",")
+	}
+
+	return &rule, nil
+}
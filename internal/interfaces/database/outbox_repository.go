@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type OutboxRepository struct {
+	SqlHandler
+}
+
+func (r *OutboxRepository) Append(ctx context.Context, event usecase.ItemEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode item event: %w", err)
+	}
+
+	query := `
+        INSERT INTO item_event_outbox (event_type, item_id, payload, created_at)
+        VALUES (?, ?, ?, ?)
+    `
+
+	_, err = r.Execute(ctx, query,
+		event.Type,
+		event.ItemID,
+		string(payload),
+		event.OccurredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*usecase.OutboxEvent, error) {
+	query := `
+        SELECT id, payload FROM item_event_outbox
+        WHERE published_at IS NULL AND (claimed_until IS NULL OR claimed_until <= ?)
+        ORDER BY id
+        LIMIT ?
+    `
+
+	return queryAll(ctx, r.SqlHandler, scanOutboxEvent, query, now, limit)
+}
+
+func (r *OutboxRepository) Claim(ctx context.Context, id int64, now, until time.Time) (bool, error) {
+	// 未配信で、他のリレーの予約が切れているときだけ予約する
+	query := `
+        UPDATE item_event_outbox
+        SET claimed_until = ?
+        WHERE id = ? AND published_at IS NULL AND (claimed_until IS NULL OR claimed_until <= ?)
+    `
+
+	result, err := r.Execute(ctx, query, until, id, now)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.Execute(ctx, `UPDATE item_event_outbox SET published_at = ? WHERE id = ?`, at, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.Execute(ctx, `DELETE FROM item_event_outbox WHERE published_at IS NOT NULL AND published_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return n, nil
+}
+
+func (r *OutboxRepository) FindEvents(ctx context.Context, query usecase.ItemEventQuery) ([]*usecase.OutboxEvent, error) {
+	// 記録順（ID順）に読み、AfterID から続きを読めるようにする
+	where := []string{"id > ?"}
+	args := []interface{}{query.AfterID}
+	if !query.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, query.Since)
+	}
+	if !query.Until.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, query.Until)
+	}
+	if query.ItemID != 0 {
+		where = append(where, "item_id = ?")
+		args = append(args, query.ItemID)
+	}
+
+	q, args := paginate("SELECT id, payload FROM item_event_outbox WHERE "+strings.Join(where, " AND ")+" ORDER BY id", args, query.Limit, 0)
+	return queryAll(ctx, r.SqlHandler, scanOutboxEvent, q, args...)
+}
+
+func scanOutboxEvent(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*usecase.OutboxEvent, error) {
+	var event usecase.OutboxEvent
+	var payload string
+	if err := scanner.Scan(&event.ID, &payload); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(payload), &event.Event); err != nil {
+		return nil, fmt.Errorf("outbox event %d is not valid JSON: %w", event.ID, err)
+	}
+	return &event, nil
+}
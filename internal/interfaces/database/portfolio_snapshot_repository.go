@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type PortfolioSnapshotRepository struct {
+	SqlHandler
+}
+
+const portfolioSnapshotColumns = `taken_on, category, item_count, value, purchase_total, created_at`
+
+// Save deletes the day's rows before inserting the new ones; run it in a transaction so that a failure keeps the old rows
+func (r *PortfolioSnapshotRepository) Save(ctx context.Context, takenOn string, snapshots []*entity.PortfolioSnapshot) error {
+	if _, err := r.Execute(ctx, `DELETE FROM portfolio_snapshots WHERE taken_on = ?`, takenOn); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        INSERT INTO portfolio_snapshots (taken_on, category, item_count, value, purchase_total, created_at)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+	for _, snapshot := range snapshots {
+		_, err := r.Execute(ctx, query,
+			takenOn,
+			snapshot.Category,
+			snapshot.ItemCount,
+			snapshot.Value,
+			snapshot.PurchaseTotal,
+			snapshot.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *PortfolioSnapshotRepository) FindAll(ctx context.Context) ([]*entity.PortfolioSnapshot, error) {
+	query := `SELECT ` + portfolioSnapshotColumns + ` FROM portfolio_snapshots ORDER BY taken_on, category`
+
+	return queryAll(ctx, r.SqlHandler, scanPortfolioSnapshot, query)
+}
+
+func (r *PortfolioSnapshotRepository) LatestDate(ctx context.Context) (string, error) {
+	var latest sql.NullString
+	if err := r.QueryRow(ctx, `SELECT MAX(taken_on) FROM portfolio_snapshots`).Scan(&latest); err != nil {
+		return "", fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return dateOnly(latest.String), nil
+}
+
+func scanPortfolioSnapshot(row scanner) (*entity.PortfolioSnapshot, error) {
+	var snapshot entity.PortfolioSnapshot
+	var takenOn string
+
+	err := row.Scan(
+		&takenOn,
+		&snapshot.Category,
+		&snapshot.ItemCount,
+		&snapshot.Value,
+		&snapshot.PurchaseTotal,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.TakenOn = dateOnly(takenOn)
+
+	return &snapshot, nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ReminderRepository struct {
+	SqlHandler
+}
+
+func (r *ReminderRepository) FindSent(ctx context.Context, since string) ([]*entity.ExpiryReminder, error) {
+	query := `
+        SELECT item_id, attr_key, expires_on, days_left, reminded_at
+        FROM expiry_reminders
+        WHERE expires_on >= ?
+    `
+
+	rows, err := r.Query(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var reminders []*entity.ExpiryReminder
+	for rows.Next() {
+		var reminder entity.ExpiryReminder
+		var expiresOn string
+		if err := rows.Scan(&reminder.ItemID, &reminder.Attribute, &expiresOn, &reminder.DaysLeft, &reminder.RemindedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		reminder.ExpiresOn = dateOnly(expiresOn)
+		reminders = append(reminders, &reminder)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return reminders, nil
+}
+
+func (r *ReminderRepository) SaveSent(ctx context.Context, reminder *entity.ExpiryReminder) error {
+	query := `
+        INSERT INTO expiry_reminders (item_id, attr_key, expires_on, days_left, reminded_at)
+        VALUES (?, ?, ?, ?, ?)
+    ` + upsertClause(r.SqlHandler, []string{"item_id", "attr_key", "expires_on"}, "days_left", "reminded_at")
+
+	_, err := r.Execute(ctx, query,
+		reminder.ItemID,
+		reminder.Attribute,
+		reminder.ExpiresOn,
+		reminder.DaysLeft,
+		reminder.RemindedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *ReminderRepository) FindSnoozes(ctx context.Context, today string) ([]*entity.ReminderSnooze, error) {
+	query := `
+        SELECT item_id, snoozed_until, snoozed_by, created_at
+        FROM reminder_snoozes
+        WHERE snoozed_until > ?
+    `
+
+	rows, err := r.Query(ctx, query, today)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var snoozes []*entity.ReminderSnooze
+	for rows.Next() {
+		var snooze entity.ReminderSnooze
+		var until string
+		if err := rows.Scan(&snooze.ItemID, &until, &snooze.SnoozedBy, &snooze.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		snooze.Until = dateOnly(until)
+		snoozes = append(snoozes, &snooze)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return snoozes, nil
+}
+
+func (r *ReminderRepository) SaveSnooze(ctx context.Context, snooze *entity.ReminderSnooze) error {
+	query := `
+        INSERT INTO reminder_snoozes (item_id, snoozed_until, snoozed_by, created_at)
+        VALUES (?, ?, ?, ?)
+    ` + upsertClause(r.SqlHandler, []string{"item_id"}, "snoozed_until", "snoozed_by", "created_at")
+
+	_, err := r.Execute(ctx, query,
+		snooze.ItemID,
+		snooze.Until,
+		snooze.SnoozedBy,
+		snooze.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *ReminderRepository) DeleteSnooze(ctx context.Context, itemID int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM reminder_snoozes WHERE item_id = ?`, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrReminderSnoozeNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ServiceRecordRepository struct {
+	SqlHandler
+}
+
+const serviceRecordColumns = `id, item_id, service_date, vendor, cost, notes, created_at, updated_at`
+
+func (r *ServiceRecordRepository) Create(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error) {
+	query := `
+        INSERT INTO item_services (item_id, service_date, vendor, cost, notes)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		record.ItemID,
+		record.ServiceDate,
+		record.Vendor,
+		record.Cost,
+		record.Notes,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ServiceRecordRepository) FindByID(ctx context.Context, id int64) (*entity.ServiceRecord, error) {
+	query := `SELECT ` + serviceRecordColumns + ` FROM item_services WHERE id = ?`
+
+	record, err := scanServiceRecord(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrServiceRecordNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return record, nil
+}
+
+func (r *ServiceRecordRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ServiceRecord, error) {
+	query := `SELECT ` + serviceRecordColumns + ` FROM item_services WHERE item_id = ? ORDER BY service_date DESC, id DESC`
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var records []*entity.ServiceRecord
+	for rows.Next() {
+		record, err := scanServiceRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return records, nil
+}
+
+func (r *ServiceRecordRepository) Update(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error) {
+	query := `
+        UPDATE item_services
+        SET service_date = ?, vendor = ?, cost = ?, notes = ?, updated_at = ?
+        WHERE id = ?
+    `
+
+	result, err := r.Execute(ctx, query,
+		record.ServiceDate,
+		record.Vendor,
+		record.Cost,
+		record.Notes,
+		record.UpdatedAt,
+		record.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return nil, domainErrors.ErrServiceRecordNotFound
+	}
+
+	return r.FindByID(ctx, record.ID)
+}
+
+func (r *ServiceRecordRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_services WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrServiceRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *ServiceRecordRepository) SummarizeByItem(ctx context.Context, itemIDs []int64) (map[int64]*usecase.ServiceSummary, error) {
+	summaries := make(map[int64]*usecase.ServiceSummary)
+	if itemIDs != nil && len(itemIDs) == 0 {
+		return summaries, nil
+	}
+
+	query := `SELECT item_id, SUM(cost), MAX(service_date) FROM item_services`
+	var args []interface{}
+	if itemIDs != nil {
+		query += ` WHERE item_id IN (?` + strings.Repeat(", ?", len(itemIDs)-1) + `)`
+		for _, id := range itemIDs {
+			args = append(args, id)
+		}
+	}
+	query += ` GROUP BY item_id`
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		var summary usecase.ServiceSummary
+		var lastServiceDate string
+		if err := rows.Scan(&itemID, &summary.TotalCost, &lastServiceDate); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		summary.LastServiceDate = dateOnly(lastServiceDate)
+		summaries[itemID] = &summary
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return summaries, nil
+}
+
+func scanServiceRecord(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ServiceRecord, error) {
+	var record entity.ServiceRecord
+	var serviceDate string
+
+	err := scanner.Scan(
+		&record.ID,
+		&record.ItemID,
+		&serviceDate,
+		&record.Vendor,
+		&record.Cost,
+		&record.Notes,
+		&record.CreatedAt,
+		&record.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	record.ServiceDate = dateOnly(serviceDate)
+
+	return &record, nil
+}
+
+// ドライバーによっては DATE が時刻付きで返るため、日付の部分だけにする
+func dateOnly(value string) string {
+	if len(value) > 10 {
+		if parsed, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			return parsed.Format("2006-01-02")
+		}
+	}
+	return value
+}
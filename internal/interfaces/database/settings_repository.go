@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type SettingsRepository struct {
+	SqlHandler
+}
+
+func (r *SettingsRepository) FindListSettings(ctx context.Context, tenantID string) (*entity.ListSettings, error) {
+	query := `
+        SELECT sort_by, sort_order, page_size, updated_at
+        FROM tenant_settings
+        WHERE tenant_id = ?
+    `
+
+	var settings entity.ListSettings
+	err := r.QueryRow(ctx, query, tenantID).Scan(
+		&settings.SortBy,
+		&settings.SortOrder,
+		&settings.PageSize,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &settings, nil
+}
+
+func (r *SettingsRepository) SaveListSettings(ctx context.Context, tenantID string, settings *entity.ListSettings) error {
+	query := `
+        INSERT INTO tenant_settings (tenant_id, sort_by, sort_order, page_size, updated_at)
+        VALUES (?, ?, ?, ?, ?)
+    ` + upsertClause(r.SqlHandler, []string{"tenant_id"}, "sort_by", "sort_order", "page_size", "updated_at")
+
+	_, err := r.Execute(ctx, query,
+		tenantID,
+		settings.SortBy,
+		settings.SortOrder,
+		settings.PageSize,
+		settings.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
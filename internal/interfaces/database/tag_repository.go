@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TagRepository struct {
+	SqlHandler
+}
+
+// タグの列と、タグが付いているアイテムの数
+const tagColumns = `t.id, t.name, COUNT(it.item_id), t.created_at, t.updated_at`
+
+const tagFrom = ` FROM tags t LEFT JOIN item_tags it ON it.tag_id = t.id `
+
+const tagGroupBy = ` GROUP BY t.id, t.name, t.created_at, t.updated_at`
+
+func (r *TagRepository) Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	query := `INSERT INTO tags (name, created_at, updated_at) VALUES (?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, tag.Name, tag.CreatedAt, tag.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *TagRepository) FindAll(ctx context.Context) ([]*entity.Tag, error) {
+	return r.findTags(ctx, `SELECT `+tagColumns+tagFrom+tagGroupBy+` ORDER BY t.name, t.id`)
+}
+
+func (r *TagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	query := `SELECT ` + tagColumns + tagFrom + `WHERE t.id = ?` + tagGroupBy
+
+	tag, err := scanTag(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tag, nil
+}
+
+func (r *TagRepository) FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// 名前の列の照合順序で、大文字・小文字を区別せずに比べる
+	query := `SELECT ` + tagColumns + tagFrom + `WHERE t.name IN (?` + strings.Repeat(", ?", len(names)-1) + `)` +
+		tagGroupBy + ` ORDER BY t.name, t.id`
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+
+	return r.findTags(ctx, query, args...)
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *entity.Tag) error {
+	result, err := r.Execute(ctx, `UPDATE tags SET name = ?, updated_at = ? WHERE id = ?`, tag.Name, tag.UpdatedAt, tag.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	// MySQL は値が変わらない行を数えないため、大文字・小文字だけの変更などでは存在を確かめ直す
+	if rowsAffected == 0 {
+		if _, err := r.FindByID(ctx, tag.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM item_tags WHERE tag_id = ?`, id); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	result, err := r.Execute(ctx, `DELETE FROM tags WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	in := `(?` + strings.Repeat(", ?", len(sourceIDs)-1) + `)`
+	sources := make([]interface{}, len(sourceIDs))
+	for i, id := range sourceIDs {
+		sources[i] = id
+	}
+
+	// すでに付け先のタグが付いているアイテムには付け直さない
+	insert := `
+        INSERT INTO item_tags (item_id, tag_id)
+        SELECT DISTINCT item_id, ? FROM item_tags
+        WHERE tag_id IN ` + in + `
+          AND item_id NOT IN (SELECT item_id FROM item_tags WHERE tag_id = ?)
+    `
+	args := append(append([]interface{}{targetID}, sources...), targetID)
+	if _, err := r.Execute(ctx, insert, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if _, err := r.Execute(ctx, `DELETE FROM item_tags WHERE tag_id IN `+in, sources...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if _, err := r.Execute(ctx, `DELETE FROM tags WHERE id IN `+in, sources...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *TagRepository) ItemIDs(ctx context.Context, tagIDs []int64) ([]int64, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT DISTINCT item_id FROM item_tags WHERE tag_id IN (?` + strings.Repeat(", ?", len(tagIDs)-1) + `) ORDER BY item_id`
+	args := make([]interface{}, len(tagIDs))
+	for i, id := range tagIDs {
+		args[i] = id
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return ids, nil
+}
+
+func (r *TagRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Tag, error) {
+	query := `SELECT ` + tagColumns + tagFrom +
+		`WHERE t.id IN (SELECT tag_id FROM item_tags WHERE item_id = ?)` + tagGroupBy + ` ORDER BY t.name, t.id`
+
+	return r.findTags(ctx, query, itemID)
+}
+
+func (r *TagRepository) SetItemTags(ctx context.Context, itemID int64, tagIDs []int64) error {
+	if _, err := r.Execute(ctx, `DELETE FROM item_tags WHERE item_id = ?`, itemID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO item_tags (item_id, tag_id) VALUES (?, ?)` + strings.Repeat(", (?, ?)", len(tagIDs)-1)
+	args := make([]interface{}, 0, len(tagIDs)*2)
+	for _, id := range tagIDs {
+		args = append(args, itemID, id)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *TagRepository) ListNamesByItem(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	names := make(map[int64][]string)
+	if len(itemIDs) == 0 {
+		return names, nil
+	}
+
+	query := `
+        SELECT it.item_id, t.name FROM item_tags it JOIN tags t ON t.id = it.tag_id
+        WHERE it.item_id IN (?` + strings.Repeat(", ?", len(itemIDs)-1) + `)
+        ORDER BY t.name, t.id
+    `
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		var name string
+		if err := rows.Scan(&itemID, &name); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		names[itemID] = append(names[itemID], name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return names, nil
+}
+
+func (r *TagRepository) findTags(ctx context.Context, query string, args ...interface{}) ([]*entity.Tag, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var tags []*entity.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tags, nil
+}
+
+func scanTag(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Tag, error) {
+	var tag entity.Tag
+
+	err := scanner.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.ItemCount,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
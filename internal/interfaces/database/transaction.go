@@ -0,0 +1,44 @@
+package database
+
+import "context"
+
+// Transactor is implemented by handlers that can run statements in a transaction
+type Transactor interface {
+	// Transaction runs fn in a transaction that every statement executed with fn's context joins.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// ContextWithTx returns a context carrying a handler's open transaction
+func ContextWithTx(ctx context.Context, tx interface{}) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any
+func TxFromContext(ctx context.Context) (interface{}, bool) {
+	tx := ctx.Value(txKey{})
+	return tx, tx != nil
+}
+
+// UnitOfWork implements usecase.UnitOfWork on a SqlHandler; handlers without transactions run fn directly
+type UnitOfWork struct {
+	SqlHandler
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t, ok := u.SqlHandler.(Transactor); ok {
+		return t.Transaction(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+// lockClause returns the clause that locks the selected rows until the surrounding transaction ends.
+// SQLite locks the whole database for the transaction, so only MySQL needs it.
+func lockClause(ctx context.Context, h SqlHandler) string {
+	if _, ok := TxFromContext(ctx); ok && DialectOf(h) == DialectMySQL {
+		return " FOR UPDATE"
+	}
+	return ""
+}
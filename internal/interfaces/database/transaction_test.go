@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// トランザクションに対応していないハンドラー
+type plainHandler struct {
+	SqlHandler
+	dialect string
+}
+
+func (h plainHandler) Dialect() string { return h.dialect }
+
+func TestUnitOfWork_WithoutTransactor(t *testing.T) {
+	uow := &UnitOfWork{SqlHandler: plainHandler{}}
+	boom := errors.New("boom")
+
+	called := false
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		called = true
+		return boom
+	})
+
+	assert.True(t, called)
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestLockClause(t *testing.T) {
+	inTx := ContextWithTx(context.Background(), struct{}{})
+
+	assert.Empty(t, lockClause(context.Background(), plainHandler{dialect: DialectMySQL}))
+	assert.Equal(t, " FOR UPDATE", lockClause(inTx, plainHandler{dialect: DialectMySQL}))
+	assert.Empty(t, lockClause(inTx, plainHandler{dialect: DialectSQLite}))
+}
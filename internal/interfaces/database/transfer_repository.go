@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TransferRepository struct {
+	SqlHandler
+}
+
+const transferColumns = `id, item_id, from_user, to_user, status, note, resolved_by, created_at, resolved_at`
+
+func (r *TransferRepository) Create(ctx context.Context, transfer *entity.Transfer) (*entity.Transfer, error) {
+	query := `
+        INSERT INTO item_transfers (item_id, from_user, to_user, status, note)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	id, err := insert(ctx, r.SqlHandler, query,
+		transfer.ItemID,
+		transfer.FromUser,
+		transfer.ToUser,
+		transfer.Status,
+		transfer.Note,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *TransferRepository) FindByID(ctx context.Context, id int64) (*entity.Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM item_transfers WHERE id = ?`
+
+	return queryOne(ctx, r.SqlHandler, domainErrors.ErrTransferNotFound, scanTransfer, query, id)
+}
+
+func (r *TransferRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM item_transfers WHERE item_id = ? ORDER BY created_at, id`
+
+	return queryAll(ctx, r.SqlHandler, scanTransfer, query, itemID)
+}
+
+func (r *TransferRepository) FindPendingByRecipient(ctx context.Context, userID string) ([]*entity.Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM item_transfers WHERE to_user = ? AND status = ? ORDER BY created_at, id`
+
+	return queryAll(ctx, r.SqlHandler, scanTransfer, query, userID, entity.TransferStatusPending)
+}
+
+func (r *TransferRepository) Resolve(ctx context.Context, transfer *entity.Transfer) error {
+	// pending のときだけ更新して、同時に承諾・取消された場合の二重処理を防ぐ
+	query := `
+        UPDATE item_transfers
+        SET status = ?, resolved_by = ?, resolved_at = ?
+        WHERE id = ? AND status = ?
+    `
+
+	return execOne(ctx, r.SqlHandler, domainErrors.Conflict("transfer %d is no longer pending", transfer.ID), query,
+		transfer.Status,
+		transfer.ResolvedBy,
+		transfer.ResolvedAt,
+		transfer.ID,
+		entity.TransferStatusPending,
+	)
+}
+
+func (r *TransferRepository) FindByUser(ctx context.Context, userID string) ([]*entity.Transfer, error) {
+	query := `SELECT ` + transferColumns + ` FROM item_transfers WHERE from_user = ? OR to_user = ? OR resolved_by = ? ORDER BY created_at, id`
+
+	return queryAll(ctx, r.SqlHandler, scanTransfer, query, userID, userID, userID)
+}
+
+func (r *TransferRepository) ReplaceUser(ctx context.Context, userID, pseudonym string) error {
+	// 譲渡の記録は監査証跡として残し、ユーザーだけを置き換える
+	for _, column := range []string{"from_user", "to_user", "resolved_by"} {
+		query := `UPDATE item_transfers SET ` + column + ` = ? WHERE ` + column + ` = ?`
+		if _, err := r.Execute(ctx, query, pseudonym, userID); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func scanTransfer(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Transfer, error) {
+	var transfer entity.Transfer
+	var resolvedBy sql.NullString
+	var resolvedAt sql.NullTime
+
+	err := scanner.Scan(
+		&transfer.ID,
+		&transfer.ItemID,
+		&transfer.FromUser,
+		&transfer.ToUser,
+		&transfer.Status,
+		&transfer.Note,
+		&resolvedBy,
+		&transfer.CreatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.ResolvedBy = resolvedBy.String
+	if resolvedAt.Valid {
+		transfer.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &transfer, nil
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ValuationRepository struct {
+	SqlHandler
+}
+
+const valuationColumns = `id, item_id, value, currency, source, sample_size, quoted_at, created_at`
+
+func (r *ValuationRepository) Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error) {
+	query := `
+        INSERT INTO item_valuations (item_id, value, currency, source, sample_size, quoted_at, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	id, err := insert(ctx, r.SqlHandler, query,
+		valuation.ItemID,
+		valuation.Value,
+		valuation.Currency,
+		valuation.Source,
+		valuation.SampleSize,
+		valuation.QuotedAt,
+		valuation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `SELECT ` + valuationColumns + ` FROM item_valuations WHERE id = ?`
+	return queryOne(ctx, r.SqlHandler, fmt.Errorf("%w: valuation %d not found after insert", domainErrors.ErrDatabaseError, id), scanValuation, query, id)
+}
+
+func (r *ValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	query := `SELECT ` + valuationColumns + ` FROM item_valuations WHERE item_id = ? ORDER BY created_at DESC, id DESC`
+
+	return queryAll(ctx, r.SqlHandler, scanValuation, query, itemID)
+}
+
+// FindLatestValues picks the latest valuation of each item in the same order as FindByItemID
+func (r *ValuationRepository) FindLatestValues(ctx context.Context) (map[int64]int, error) {
+	query := `
+        SELECT v.item_id, v.value
+        FROM item_valuations v
+        WHERE v.id = (
+            SELECT latest.id FROM item_valuations latest
+            WHERE latest.item_id = v.item_id
+            ORDER BY latest.created_at DESC, latest.id DESC
+            LIMIT 1
+        )
+    `
+
+	type latestValue struct {
+		itemID int64
+		value  int
+	}
+	rows, err := queryAll(ctx, r.SqlHandler, func(row scanner) (latestValue, error) {
+		var v latestValue
+		err := row.Scan(&v.itemID, &v.value)
+		return v, err
+	}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		values[row.itemID] = row.value
+	}
+	return values, nil
+}
+
+func scanValuation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Valuation, error) {
+	var valuation entity.Valuation
+
+	err := scanner.Scan(
+		&valuation.ID,
+		&valuation.ItemID,
+		&valuation.Value,
+		&valuation.Currency,
+		&valuation.Source,
+		&valuation.SampleSize,
+		&valuation.QuotedAt,
+		&valuation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valuation, nil
+}
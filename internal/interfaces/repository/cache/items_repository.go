@@ -0,0 +1,114 @@
+// Package cache provides an in-process cache in front of an item repository.
+package cache
+
+import (
+	"context"
+	"expvar"
+	"maps"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var (
+	summaryHits   = expvar.NewInt("summary_cache_hits")
+	summaryMisses = expvar.NewInt("summary_cache_misses")
+)
+
+// ItemRepository caches the category summary of the wrapped repository (only the one over all purchase dates).
+// The cache is dropped on every write made through it and on every item event it receives
+// (it is a usecase.ItemEventPublisher, so it also sees changes committed in transactions),
+// and is never served when older than maxStaleness, which bounds staleness caused by other instances.
+type ItemRepository struct {
+	usecase.ItemRepository
+
+	maxStaleness time.Duration
+	now          func() time.Time
+
+	mu         sync.Mutex
+	summary    map[string]usecase.CategoryStats
+	cachedAt   time.Time
+	generation uint64
+}
+
+// NewItemRepository wraps inner; a maxStaleness of 0 disables caching
+func NewItemRepository(inner usecase.ItemRepository, maxStaleness time.Duration) *ItemRepository {
+	return &ItemRepository{
+		ItemRepository: inner,
+		maxStaleness:   maxStaleness,
+		now:            time.Now,
+	}
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	if purchased != (usecase.DateRange{}) {
+		return r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+	}
+
+	r.mu.Lock()
+	if r.summary != nil && r.now().Sub(r.cachedAt) < r.maxStaleness {
+		summary := maps.Clone(r.summary)
+		r.mu.Unlock()
+		summaryHits.Add(1)
+		return summary, nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+	summaryMisses.Add(1)
+
+	summary, err := r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+	if err != nil {
+		return nil, err
+	}
+
+	// Results loaded while a write was invalidating the cache may already be stale, so they are not stored
+	r.mu.Lock()
+	if r.generation == generation && r.maxStaleness > 0 {
+		r.summary = maps.Clone(summary)
+		r.cachedAt = r.now()
+	}
+	r.mu.Unlock()
+
+	return summary, nil
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.Invalidate()
+	return r.ItemRepository.Create(ctx, item)
+}
+
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.Invalidate()
+	return r.ItemRepository.Update(ctx, item)
+}
+
+func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+	defer r.Invalidate()
+	return r.ItemRepository.Delete(ctx, id)
+}
+
+func (r *ItemRepository) Trash(ctx context.Context, id int64, deletedAt time.Time) error {
+	defer r.Invalidate()
+	return r.ItemRepository.Trash(ctx, id, deletedAt)
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	defer r.Invalidate()
+	return r.ItemRepository.Restore(ctx, id)
+}
+
+// Publish invalidates the cache when an item change has been committed
+func (r *ItemRepository) Publish(ctx context.Context, event usecase.ItemEvent) {
+	r.Invalidate()
+}
+
+// Invalidate drops the cached summary
+func (r *ItemRepository) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.summary = nil
+}
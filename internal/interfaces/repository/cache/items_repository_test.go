@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+var (
+	_ usecase.ItemRepository     = (*ItemRepository)(nil)
+	_ usecase.ItemEventPublisher = (*ItemRepository)(nil)
+)
+
+// countingRepository は集計の呼び出し回数を数える
+type countingRepository struct {
+	*memory.ItemRepository
+	calls  int
+	during func()
+}
+
+func (r *countingRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	r.calls++
+	if r.during != nil {
+		r.during()
+	}
+	return r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+}
+
+// counts は集計からカテゴリーごとの件数だけを取り出す
+func counts(summary map[string]usecase.CategoryStats) map[string]int {
+	counts := make(map[string]int, len(summary))
+	for category, stats := range summary {
+		counts[category] = stats.Count
+	}
+	return counts
+}
+
+func newItem(name, category string) *entity.Item {
+	item, _ := entity.NewItem(name, category, "ROLEX", 1000, "2024-01-01")
+	return item
+}
+
+func setup(maxStaleness time.Duration) (*ItemRepository, *countingRepository, *time.Time) {
+	inner := &countingRepository{ItemRepository: memory.NewItemRepository(newItem("時計1", "時計"))}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := NewItemRepository(inner, maxStaleness)
+	repo.now = func() time.Time { return now }
+	return repo, inner, &now
+}
+
+func TestItemRepository_Summary(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 期間内はキャッシュを返す", func(t *testing.T) {
+		repo, inner, now := setup(time.Minute)
+
+		first, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		require.NoError(t, err)
+		first["時計"] = usecase.CategoryStats{Count: 100} // 返した値を変更してもキャッシュは変わらない
+		*now = now.Add(59 * time.Second)
+		second, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]int{"時計": 1}, counts(second))
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("正常系: 購入日で絞り込んだ集計はキャッシュしない", func(t *testing.T) {
+		repo, inner, _ := setup(time.Minute)
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		summary, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{From: "2025-01-01"})
+		require.NoError(t, err)
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{From: "2025-01-01"})
+
+		assert.Empty(t, summary)
+		assert.Equal(t, 3, inner.calls)
+	})
+
+	t.Run("正常系: 最大期間を過ぎたら取り直す", func(t *testing.T) {
+		repo, inner, now := setup(time.Minute)
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		*now = now.Add(time.Minute)
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("正常系: 登録・削除で破棄する", func(t *testing.T) {
+		repo, inner, _ := setup(time.Minute)
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		created, err := repo.Create(ctx, newItem("バッグ1", "バッグ"))
+		require.NoError(t, err)
+		summary, _ := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		assert.Equal(t, map[string]int{"時計": 1, "バッグ": 1}, counts(summary))
+
+		require.NoError(t, repo.Delete(ctx, created.ID))
+		summary, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		assert.Equal(t, map[string]int{"時計": 1}, counts(summary))
+		assert.Equal(t, 3, inner.calls)
+	})
+
+	t.Run("正常系: イベントを受け取ったら破棄する", func(t *testing.T) {
+		repo, inner, _ := setup(time.Minute)
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		repo.Publish(ctx, usecase.ItemEvent{Type: usecase.ItemCreated, ItemID: 2})
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("正常系: 取得中に破棄された結果はキャッシュしない", func(t *testing.T) {
+		repo, inner, _ := setup(time.Minute)
+		inner.during = repo.Invalidate
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		inner.during = nil
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("正常系: 0なら常に取得する", func(t *testing.T) {
+		repo, inner, _ := setup(0)
+
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+
+		assert.Equal(t, 2, inner.calls)
+	})
+}
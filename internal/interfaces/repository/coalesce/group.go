@@ -0,0 +1,75 @@
+package coalesce
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// call is an in-flight group.Do call
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+	// dups is the number of callers that joined the call
+	dups int
+}
+
+// group runs at most one function per key at a time; callers with the same key that arrive
+// while it runs wait for it and share its result (a minimal generic singleflight)
+type group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+// Do returns the result of fn for key, starting fn only if no call for key is in flight.
+// fn runs without the caller's cancellation (other callers may still be waiting for it) but keeps its deadline,
+// and each caller stops waiting when its own ctx is done. shared reports whether the result came from another caller's call.
+func (g *group[K, V]) Do(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (v V, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	c, shared := g.calls[key]
+	if shared {
+		c.dups++
+	} else {
+		c = &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+		callCtx, cancel := detach(ctx)
+		go g.run(callCtx, cancel, key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err, shared
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err(), shared
+	}
+}
+
+func (g *group[K, V]) run(ctx context.Context, cancel context.CancelFunc, key K, c *call[V], fn func(ctx context.Context) (V, error)) {
+	defer func() {
+		cancel()
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("coalesced call panicked: %v", r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.val, c.err = fn(ctx)
+}
+
+// detach returns a context with ctx's values and deadline but without its cancellation
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return context.WithCancel(detached)
+}
@@ -0,0 +1,48 @@
+package coalesce
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup_Do(t *testing.T) {
+	t.Run("異常系: パニックはエラーとして返す", func(t *testing.T) {
+		var g group[string, int]
+		_, err, _ := g.Do(context.Background(), "key", func(context.Context) (int, error) {
+			panic("boom")
+		})
+		assert.EqualError(t, err, "coalesced call panicked: boom")
+
+		// 終わった呼び出しは次の呼び出しに影響しない
+		v, err, shared := g.Do(context.Background(), "key", func(context.Context) (int, error) { return 1, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+		assert.False(t, shared)
+	})
+
+	t.Run("正常系: 呼び出し元の期限は引き継ぎ、キャンセルは引き継がない", func(t *testing.T) {
+		var g group[string, bool]
+		deadline := time.Now().Add(time.Hour)
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		cancel()
+
+		started := make(chan context.Context, 1)
+		release := make(chan struct{})
+		_, err, _ := g.Do(ctx, "key", func(ctx context.Context) (bool, error) {
+			started <- ctx
+			<-release
+			return true, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		callCtx := <-started
+		assert.NoError(t, callCtx.Err())
+		got, ok := callCtx.Deadline()
+		assert.True(t, ok)
+		assert.True(t, got.Equal(deadline))
+		close(release)
+	})
+}
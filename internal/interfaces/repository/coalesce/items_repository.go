@@ -0,0 +1,88 @@
+// Package coalesce merges identical concurrent reads of an item repository into one call.
+package coalesce
+
+import (
+	"context"
+	"expvar"
+	"maps"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var coalescedReads = expvar.NewInt("coalesced_reads")
+
+// ItemRepository shares the result of an in-flight FindAll, Count or GetSummaryByCategory call
+// with concurrent callers asking for the same query, so a burst of identical list or summary requests
+// reaches the wrapped repository once. Only read-only contexts (usecase.ReadOnly) are coalesced: they already
+// tolerate replica lag, and a caller arriving just after a write may receive the result of a read started before it.
+// Reads inside transactions and all writes go straight to the wrapped repository.
+type ItemRepository struct {
+	usecase.ItemRepository
+
+	findAll group[usecase.ItemQuery, []*entity.Item]
+	count   group[usecase.ItemFilter, int]
+	summary group[usecase.DateRange, map[string]usecase.CategoryStats]
+}
+
+func NewItemRepository(inner usecase.ItemRepository) *ItemRepository {
+	return &ItemRepository{ItemRepository: inner}
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, query usecase.ItemQuery) ([]*entity.Item, error) {
+	if !usecase.IsReadOnly(ctx) {
+		return r.ItemRepository.FindAll(ctx, query)
+	}
+
+	items, err, shared := r.findAll.Do(ctx, query, func(ctx context.Context) ([]*entity.Item, error) {
+		return r.ItemRepository.FindAll(ctx, query)
+	})
+	if err != nil || !shared {
+		return items, err
+	}
+	coalescedReads.Add(1)
+	// Callers may modify the items they receive, so each one that joined another call gets its own copy
+	return cloneItems(items), nil
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	if !usecase.IsReadOnly(ctx) {
+		return r.ItemRepository.Count(ctx, filter)
+	}
+
+	count, err, shared := r.count.Do(ctx, filter, func(ctx context.Context) (int, error) {
+		return r.ItemRepository.Count(ctx, filter)
+	})
+	if err == nil && shared {
+		coalescedReads.Add(1)
+	}
+	return count, err
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	if !usecase.IsReadOnly(ctx) {
+		return r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+	}
+
+	summary, err, shared := r.summary.Do(ctx, purchased, func(ctx context.Context) (map[string]usecase.CategoryStats, error) {
+		return r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+	})
+	if err != nil || !shared {
+		return summary, err
+	}
+	coalescedReads.Add(1)
+	return maps.Clone(summary), nil
+}
+
+func cloneItems(items []*entity.Item) []*entity.Item {
+	if items == nil {
+		return nil
+	}
+	cloned := make([]*entity.Item, len(items))
+	for i, item := range items {
+		c := *item
+		c.Attributes = maps.Clone(item.Attributes)
+		cloned[i] = &c
+	}
+	return cloned
+}
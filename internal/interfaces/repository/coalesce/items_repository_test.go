@@ -0,0 +1,201 @@
+package coalesce
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.ItemRepository = (*ItemRepository)(nil)
+
+// blockingRepository は release が閉じられるまで読み取りを止め、呼び出し回数を数える
+type blockingRepository struct {
+	*memory.ItemRepository
+	release chan struct{}
+	calls   atomic.Int32
+	err     error
+}
+
+func (r *blockingRepository) wait() {
+	r.calls.Add(1)
+	<-r.release
+}
+
+func (r *blockingRepository) FindAll(ctx context.Context, query usecase.ItemQuery) ([]*entity.Item, error) {
+	r.wait()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.ItemRepository.FindAll(ctx, query)
+}
+
+func (r *blockingRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	r.wait()
+	return r.ItemRepository.Count(ctx, filter)
+}
+
+func (r *blockingRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	r.wait()
+	return r.ItemRepository.GetSummaryByCategory(ctx, purchased)
+}
+
+func newBlockingRepository() *blockingRepository {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2024-01-01")
+	item.Attributes = map[string]string{"color": "black"}
+	return &blockingRepository{ItemRepository: memory.NewItemRepository(item), release: make(chan struct{})}
+}
+
+// waitForWaiters は key の呼び出しに n 件の呼び出し元が合流するまで待つ
+func waitForWaiters[K comparable, V any](t *testing.T, g *group[K, V], key K, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		c, ok := g.calls[key]
+		return ok && c.dups == n
+	}, time.Second, time.Millisecond)
+}
+
+// runConcurrently は fn を n 個のゴルーチンで呼び、全件の終了を待つ関数を返す
+func runConcurrently(n int, fn func(i int)) func() {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(i)
+		}()
+	}
+	return wg.Wait
+}
+
+func TestItemRepository_FindAll(t *testing.T) {
+	ctx := usecase.ReadOnly(context.Background())
+	query := usecase.ItemQuery{SortBy: "created_at", SortOrder: "asc"}
+
+	t.Run("正常系: 同時の同じ一覧取得は1回にまとめる", func(t *testing.T) {
+		inner := newBlockingRepository()
+		repo := NewItemRepository(inner)
+
+		results := make([][]*entity.Item, 5)
+		wait := runConcurrently(5, func(i int) {
+			items, err := repo.FindAll(ctx, query)
+			assert.NoError(t, err)
+			results[i] = items
+		})
+		waitForWaiters(t, &repo.findAll, query, 4)
+		close(inner.release)
+		wait()
+
+		assert.Equal(t, int32(1), inner.calls.Load())
+		for _, items := range results {
+			require.Len(t, items, 1)
+			assert.Equal(t, "時計1", items[0].Name)
+		}
+		// 呼び出し元ごとに別のアイテムを返す
+		results[0][0].Attributes["color"] = "white"
+		for _, items := range results[1:] {
+			assert.False(t, results[0][0] == items[0])
+			assert.Equal(t, "black", items[0].Attributes["color"])
+		}
+	})
+
+	t.Run("正常系: 条件が異なる一覧取得はまとめない", func(t *testing.T) {
+		inner := newBlockingRepository()
+		close(inner.release)
+		repo := NewItemRepository(inner)
+
+		_, err := repo.FindAll(ctx, query)
+		require.NoError(t, err)
+		_, err = repo.FindAll(ctx, usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Category: "時計"}})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), inner.calls.Load())
+	})
+
+	t.Run("正常系: 読み取り専用でない呼び出しはまとめない", func(t *testing.T) {
+		inner := newBlockingRepository()
+		repo := NewItemRepository(inner)
+
+		wait := runConcurrently(3, func(int) {
+			_, err := repo.FindAll(context.Background(), query)
+			assert.NoError(t, err)
+		})
+		require.Eventually(t, func() bool { return inner.calls.Load() == 3 }, time.Second, time.Millisecond)
+		close(inner.release)
+		wait()
+	})
+
+	t.Run("異常系: エラーも共有する", func(t *testing.T) {
+		inner := newBlockingRepository()
+		inner.err = errors.New("database error")
+		repo := NewItemRepository(inner)
+
+		wait := runConcurrently(3, func(int) {
+			_, err := repo.FindAll(ctx, query)
+			assert.ErrorIs(t, err, inner.err)
+		})
+		waitForWaiters(t, &repo.findAll, query, 2)
+		close(inner.release)
+		wait()
+		assert.Equal(t, int32(1), inner.calls.Load())
+	})
+
+	t.Run("異常系: キャンセルした呼び出し元だけが待つのをやめる", func(t *testing.T) {
+		inner := newBlockingRepository()
+		repo := NewItemRepository(inner)
+		leaderCtx, cancel := context.WithCancel(ctx)
+
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := repo.FindAll(leaderCtx, query)
+			leaderErr <- err
+		}()
+		require.Eventually(t, func() bool { return inner.calls.Load() == 1 }, time.Second, time.Millisecond)
+		wait := runConcurrently(1, func(int) {
+			items, err := repo.FindAll(ctx, query)
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+		})
+		waitForWaiters(t, &repo.findAll, query, 1)
+
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+		close(inner.release)
+		wait()
+		assert.Equal(t, int32(1), inner.calls.Load())
+	})
+}
+
+func TestItemRepository_CountAndSummary(t *testing.T) {
+	ctx := usecase.ReadOnly(context.Background())
+	inner := newBlockingRepository()
+	repo := NewItemRepository(inner)
+
+	wait := runConcurrently(6, func(i int) {
+		if i%2 == 0 {
+			count, err := repo.Count(ctx, usecase.ItemFilter{})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+			return
+		}
+		summary, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]usecase.CategoryStats{"時計": {Count: 1, Min: 1000, Max: 1000, Avg: 1000, Sum: 1000}}, summary)
+	})
+	waitForWaiters(t, &repo.count, usecase.ItemFilter{}, 2)
+	waitForWaiters(t, &repo.summary, usecase.DateRange{}, 2)
+	close(inner.release)
+	wait()
+
+	assert.Equal(t, int32(2), inner.calls.Load())
+}
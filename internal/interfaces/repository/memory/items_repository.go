@@ -0,0 +1,403 @@
+// Package memory provides thread-safe in-memory repositories for demos, sandboxes and tests.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemRepository is an in-memory implementation of usecase.ItemRepository.
+// It mirrors the MySQL repository: queries default to newest first and Update
+// only changes the fields the MySQL UPDATE statement writes.
+type ItemRepository struct {
+	mu     sync.RWMutex
+	items  map[int64]*entity.Item
+	trash  map[int64]*entity.TrashedItem
+	nextID int64
+
+	// MaxItems limits the number of stored items (0 means unlimited); set it before use
+	MaxItems int
+}
+
+// NewItemRepository creates a repository seeded with the given items; items without an ID get one assigned
+func NewItemRepository(seed ...*entity.Item) *ItemRepository {
+	r := &ItemRepository{
+		items:  make(map[int64]*entity.Item),
+		trash:  make(map[int64]*entity.TrashedItem),
+		nextID: 1,
+	}
+	for _, item := range seed {
+		stored := copyItem(item)
+		if stored.ID == 0 {
+			stored.ID = r.nextID
+		}
+		if stored.ID >= r.nextID {
+			r.nextID = stored.ID + 1
+		}
+		r.items[stored.ID] = stored
+	}
+	return r
+}
+
+// FindAll retrieves the items matching the query
+func (r *ItemRepository) FindAll(ctx context.Context, query usecase.ItemQuery) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]*entity.Item, 0, len(r.items))
+	if query.TrashedBefore.IsZero() {
+		for _, item := range r.items {
+			if item.ID > query.AfterID && matches(item, query.ItemFilter) {
+				items = append(items, copyItem(item))
+			}
+		}
+	} else {
+		for _, item := range r.trash {
+			if item.DeletedAt.Before(query.TrashedBefore) && item.ID > query.AfterID && matches(item.Item, query.ItemFilter) {
+				items = append(items, copyItem(item.Item))
+			}
+		}
+	}
+	sortItems(items, query.SortBy, query.SortOrder)
+
+	if query.Limit > 0 {
+		start := min(max(query.Offset, 0), len(items))
+		end := min(start+query.Limit, len(items))
+		items = items[start:end]
+	}
+	return items, nil
+}
+
+// Iterate calls fn for each item of a snapshot taken by FindAll, so fn may modify the repository
+func (r *ItemRepository) Iterate(ctx context.Context, query usecase.ItemQuery, fn func(*entity.Item) error) error {
+	items, err := r.FindAll(ctx, query)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindAllStream returns an iterator over a snapshot taken by FindAll, so the repository may be modified meanwhile
+func (r *ItemRepository) FindAllStream(ctx context.Context, query usecase.ItemQuery) (usecase.ItemIterator, error) {
+	items, err := r.FindAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewSliceItemIterator(items), nil
+}
+
+// Count returns the number of items matching the filter
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, item := range r.items {
+		if matches(item, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindByID retrieves an item by ID
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return copyItem(item), nil
+}
+
+// Create stores a new item with the next ID and fresh timestamps
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.MaxItems > 0 && len(r.items)+len(r.trash) >= r.MaxItems {
+		return nil, fmt.Errorf("%w: repository is limited to %d items", domainErrors.ErrDatabaseError, r.MaxItems)
+	}
+
+	created := copyItem(item)
+	created.ID = r.nextID
+	created.Version = 1
+	r.nextID++
+	now := time.Now()
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	r.items[created.ID] = created
+	return copyItem(created), nil
+}
+
+// Delete permanently deletes an item by ID, whether or not it is in the trash
+func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, stored := r.items[id]
+	_, trashed := r.trash[id]
+	if !stored && !trashed {
+		return domainErrors.ErrItemNotFound
+	}
+	delete(r.items, id)
+	delete(r.trash, id)
+	return nil
+}
+
+// Trash moves an item to the trash
+func (r *ItemRepository) Trash(ctx context.Context, id int64, deletedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok {
+		return domainErrors.ErrItemNotFound
+	}
+	delete(r.items, id)
+	r.trash[id] = &entity.TrashedItem{Item: item, DeletedAt: deletedAt}
+	return nil
+}
+
+// Restore takes an item out of the trash
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.trash[id]
+	if !ok {
+		return domainErrors.ErrItemNotFound
+	}
+	delete(r.trash, id)
+	r.items[id] = item.Item
+	return nil
+}
+
+// FindTrashed retrieves the items in the trash matching the query, most recently deleted first
+func (r *ItemRepository) FindTrashed(ctx context.Context, query usecase.TrashQuery) ([]*entity.TrashedItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := make([]*entity.TrashedItem, 0, len(r.trash))
+	for _, item := range r.trash {
+		if query.DeletedBefore.IsZero() || item.DeletedAt.Before(query.DeletedBefore) {
+			items = append(items, copyTrashedItem(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if c := items[i].DeletedAt.Compare(items[j].DeletedAt); c != 0 {
+			return c > 0
+		}
+		return items[i].ID > items[j].ID
+	})
+
+	if query.Limit > 0 {
+		start := min(max(query.Offset, 0), len(items))
+		end := min(start+query.Limit, len(items))
+		items = items[start:end]
+	}
+	return items, nil
+}
+
+// CountTrashed returns the number of items in the trash
+func (r *ItemRepository) CountTrashed(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.trash), nil
+}
+
+// FindTrashedByID retrieves an item in the trash by ID
+func (r *ItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.TrashedItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.trash[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return copyTrashedItem(item), nil
+}
+
+// Update updates the name, brand, purchase price, attributes and owner of an existing item if its version is unchanged
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.items[item.ID]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	if current.Version != item.Version {
+		return nil, domainErrors.Conflict("item %d has been modified", item.ID)
+	}
+
+	current.Category = item.Category
+	current.Name = item.Name
+	current.Brand = item.Brand
+	current.PurchasePrice = item.PurchasePrice
+	current.Attributes = copyAttributes(item.Attributes)
+	current.OwnerID = item.OwnerID
+	current.UpdatedAt = item.UpdatedAt
+	current.Version++
+	return copyItem(current), nil
+}
+
+// GetSummaryByCategory returns item counts and purchase price aggregates grouped by category;
+// the aggregates only cover the items bought in BaseCurrency
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := make(map[string]usecase.CategoryStats)
+	priced := make(map[string]int)
+	for _, item := range r.items {
+		// YYYY-MM-DD の文字列は日付の順に並ぶ
+		if (purchased.From != "" && item.PurchaseDate < purchased.From) || (purchased.To != "" && item.PurchaseDate > purchased.To) {
+			continue
+		}
+		stats := summary[item.Category]
+		stats.Count++
+		if inBaseCurrency(item) {
+			n := priced[item.Category]
+			if n == 0 || item.PurchasePrice < stats.Min {
+				stats.Min = item.PurchasePrice
+			}
+			if n == 0 || item.PurchasePrice > stats.Max {
+				stats.Max = item.PurchasePrice
+			}
+			priced[item.Category] = n + 1
+			stats.Sum += item.PurchasePrice
+			stats.Avg = float64(stats.Sum) / float64(n+1)
+		}
+		summary[item.Category] = stats
+	}
+	return summary, nil
+}
+
+// GetSummaryByBrand returns item counts and purchase price totals (of the items in BaseCurrency) grouped by brand
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) (map[string]*usecase.BrandTotals, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := make(map[string]*usecase.BrandTotals)
+	for _, item := range r.items {
+		totals, ok := summary[item.Brand]
+		if !ok {
+			totals = &usecase.BrandTotals{}
+			summary[item.Brand] = totals
+		}
+		totals.Count++
+		if inBaseCurrency(item) {
+			totals.PurchaseTotal += item.PurchasePrice
+		}
+	}
+	return summary, nil
+}
+
+// CountByPriceBands counts the items bought in BaseCurrency per purchase price band
+func (r *ItemRepository) CountByPriceBands(ctx context.Context, bounds []int) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make([]int, len(bounds)+1)
+	for _, item := range r.items {
+		if !inBaseCurrency(item) {
+			continue
+		}
+		band := sort.SearchInts(bounds, item.PurchasePrice+1)
+		counts[band]++
+	}
+	return counts, nil
+}
+
+// Len returns the number of stored items, including the ones in the trash
+func (r *ItemRepository) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.items) + len(r.trash)
+}
+
+// matches reports whether item matches filter. The repository holds no valuations, so no item has a current value
+// and a MinCurrentValue leaves out every item, as it does for items never valued in the SQL repository.
+func matches(item *entity.Item, filter usecase.ItemFilter) bool {
+	return (filter.Category == "" || item.Category == filter.Category) &&
+		(filter.Brand == "" || item.Brand == filter.Brand) &&
+		(filter.OwnerID == "" || item.OwnerID == filter.OwnerID) &&
+		(filter.Currency == "" || item.Price().Currency == filter.Currency) &&
+		filter.MinCurrentValue == 0
+}
+
+// inBaseCurrency reports whether the purchase price of item is in BaseCurrency, the only one the aggregates cover
+func inBaseCurrency(item *entity.Item) bool {
+	return item.Price().Currency == usecase.BaseCurrency
+}
+
+// sortItems orders items like the SQL repository: by the sort field, then by ID in the same direction
+func sortItems(items []*entity.Item, field, order string) {
+	compare := func(a, b *entity.Item) int {
+		switch field {
+		case "name":
+			return strings.Compare(a.Name, b.Name)
+		case "purchase_price":
+			return cmp.Compare(a.PurchasePrice, b.PurchasePrice)
+		case "purchase_date":
+			return strings.Compare(a.PurchaseDate, b.PurchaseDate)
+		case "updated_at":
+			return a.UpdatedAt.Compare(b.UpdatedAt)
+		case usecase.SortByID, usecase.SortByCurrentValue:
+			// No item here has been valued, so they are in ID order
+			return 0
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		c := compare(items[i], items[j])
+		if c == 0 {
+			c = cmp.Compare(items[i].ID, items[j].ID)
+		}
+		if order == entity.SortAsc {
+			return c < 0
+		}
+		return c > 0
+	})
+}
+
+// copyItem returns a deep copy so callers never share state with the repository
+func copyItem(item *entity.Item) *entity.Item {
+	copied := *item
+	copied.Attributes = copyAttributes(item.Attributes)
+	return &copied
+}
+
+func copyTrashedItem(item *entity.TrashedItem) *entity.TrashedItem {
+	return &entity.TrashedItem{Item: copyItem(item.Item), DeletedAt: item.DeletedAt}
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(attributes))
+	for k, v := range attributes {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,362 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.ItemRepository = (*ItemRepository)(nil)
+
+func newItem(name, category string) *entity.Item {
+	item, _ := entity.NewItem(name, category, "ROLEX", 1000, "2024-01-01")
+	return item
+}
+
+func TestItemRepository_CRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+
+	created, err := repo.Create(ctx, newItem("時計1", "時計"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+
+	t.Run("正常系: 取得した値を変更しても保存内容は変わらない", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		found.Name = "変更"
+		again, _ := repo.FindByID(ctx, created.ID)
+		assert.Equal(t, "時計1", again.Name)
+	})
+
+	t.Run("正常系: 更新", func(t *testing.T) {
+		created.Name = "時計1改"
+		updated, err := repo.Update(ctx, created)
+		require.NoError(t, err)
+		assert.Equal(t, "時計1改", updated.Name)
+		assert.Equal(t, "時計", updated.Category)
+		assert.Equal(t, int64(2), updated.Version)
+	})
+
+	t.Run("異常系: 古いバージョンでの更新は競合", func(t *testing.T) {
+		stale := *created
+		stale.Version = 1
+		_, err := repo.Update(ctx, &stale)
+		assert.ErrorIs(t, err, domainErrors.ErrConflict)
+		found, _ := repo.FindByID(ctx, created.ID)
+		assert.Equal(t, int64(2), found.Version)
+	})
+
+	t.Run("正常系: 集計", func(t *testing.T) {
+		_, err := repo.Create(ctx, newItem("バッグ1", "バッグ"))
+		require.NoError(t, err)
+		watch := newItem("時計2", "時計")
+		watch.PurchasePrice = 2000
+		_, err = repo.Create(ctx, watch)
+		require.NoError(t, err)
+		summary, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]usecase.CategoryStats{
+			"時計":  {Count: 2, Min: 1000, Max: 2000, Avg: 1500, Sum: 3000},
+			"バッグ": {Count: 1, Min: 1000, Max: 1000, Avg: 1000, Sum: 1000},
+		}, summary)
+
+		brands, err := repo.GetSummaryByBrand(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*usecase.BrandTotals{"ROLEX": {Count: 3, PurchaseTotal: 4000}}, brands)
+
+		// 購入日の範囲は両端を含む
+		summary, err = repo.GetSummaryByCategory(ctx, usecase.DateRange{From: "2024-01-01", To: "2024-01-01"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, summary["時計"].Count+summary["バッグ"].Count)
+		summary, err = repo.GetSummaryByCategory(ctx, usecase.DateRange{To: "2023-12-31"})
+		require.NoError(t, err)
+		assert.Empty(t, summary)
+
+		// 境界の価格はその上の帯に入る
+		bands, err := repo.CountByPriceBands(ctx, []int{1000, 2000, 5000})
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 2, 1, 0}, bands)
+	})
+
+	t.Run("異常系: 存在しないID", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		_, err = repo.Update(ctx, &entity.Item{ID: 999})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, 999), domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("正常系: 削除", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, created.ID))
+		assert.Equal(t, 2, repo.Len())
+	})
+}
+
+func TestItemRepository_Seed(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	older := newItem("古い", "時計")
+	older.ID, older.CreatedAt = 5, base
+	newer := newItem("新しい", "時計")
+	newer.CreatedAt = base.Add(time.Hour)
+
+	repo := NewItemRepository(older, newer)
+
+	items, err := repo.FindAll(context.Background(), usecase.ItemQuery{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "新しい", items[0].Name)
+	assert.Equal(t, int64(6), items[0].ID)
+
+	created, err := repo.Create(context.Background(), newItem("追加", "時計"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), created.ID)
+}
+
+func TestItemRepository_MaxItems(t *testing.T) {
+	repo := NewItemRepository()
+	repo.MaxItems = 1
+
+	_, err := repo.Create(context.Background(), newItem("1", "時計"))
+	require.NoError(t, err)
+	_, err = repo.Create(context.Background(), newItem("2", "時計"))
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+}
+
+func TestItemRepository_Concurrent(t *testing.T) {
+	repo := NewItemRepository()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			item, _ := repo.Create(context.Background(), newItem("並行", "時計"))
+			_, _ = repo.FindAll(context.Background(), usecase.ItemQuery{})
+			_, _ = repo.FindByID(context.Background(), item.ID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, repo.Len())
+}
+
+func TestItemRepository_Query(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var seed []*entity.Item
+	for i, tc := range []struct {
+		category string
+		price    int
+	}{{"時計", 300}, {"バッグ", 100}, {"時計", 200}, {"時計", 200}} {
+		item := newItem("アイテム", tc.category)
+		item.PurchasePrice = tc.price
+		item.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		seed = append(seed, item)
+	}
+	repo := NewItemRepository(seed...)
+
+	tests := []struct {
+		name  string
+		query usecase.ItemQuery
+		want  []int64
+	}{
+		{name: "正常系: デフォルトは作成日時の降順", query: usecase.ItemQuery{}, want: []int64{4, 3, 2, 1}},
+		{name: "正常系: カテゴリーで絞り込み", query: usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Category: "時計"}}, want: []int64{4, 3, 1}},
+		{name: "正常系: 同じ値はIDで並ぶ", query: usecase.ItemQuery{SortBy: "purchase_price", SortOrder: "asc"}, want: []int64{2, 3, 4, 1}},
+		{name: "正常系: ページング", query: usecase.ItemQuery{Limit: 2, Offset: 1}, want: []int64{3, 2}},
+		{name: "正常系: 範囲外のオフセットは空", query: usecase.ItemQuery{Limit: 2, Offset: 10}, want: []int64{}},
+		// 評価を持たないため、時価の順は ID の順で、時価の下限を指定すると何も返さない
+		{name: "正常系: 時価の順", query: usecase.ItemQuery{SortBy: usecase.SortByCurrentValue, SortOrder: "desc"}, want: []int64{4, 3, 2, 1}},
+		{name: "正常系: 時価の下限", query: usecase.ItemQuery{ItemFilter: usecase.ItemFilter{MinCurrentValue: 1}}, want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := repo.FindAll(ctx, tt.query)
+			require.NoError(t, err)
+			ids := []int64{}
+			for _, item := range items {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+
+	count, err := repo.Count(ctx, usecase.ItemFilter{Category: "時計"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestItemRepository_Summaries(t *testing.T) {
+	ctx := context.Background()
+	watch := newItem("時計1", "時計")
+	watch.PurchasePrice = 1500000
+	// 通貨を持つ前のアイテムは DefaultCurrency
+	legacy := newItem("時計2", "時計")
+	legacy.PurchasePrice = 500000
+	legacy.Currency = ""
+	dollars := newItem("時計3", "時計")
+	dollars.PurchasePrice = 1299900
+	dollars.Currency = "USD"
+	repo := NewItemRepository(watch, legacy, dollars)
+
+	t.Run("正常系: 件数はすべてのアイテム、集計は BaseCurrency のアイテムのみ", func(t *testing.T) {
+		summary, err := repo.GetSummaryByCategory(ctx, usecase.DateRange{})
+		require.NoError(t, err)
+		assert.Equal(t, usecase.CategoryStats{Count: 3, Min: 500000, Max: 1500000, Avg: 1000000, Sum: 2000000}, summary["時計"])
+
+		brands, err := repo.GetSummaryByBrand(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &usecase.BrandTotals{Count: 3, PurchaseTotal: 2000000}, brands["ROLEX"])
+	})
+
+	t.Run("正常系: 価格帯は BaseCurrency のアイテムのみ数える", func(t *testing.T) {
+		counts, err := repo.CountByPriceBands(ctx, []int{1000000})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 1}, counts)
+	})
+
+	t.Run("正常系: 通貨で絞り込み", func(t *testing.T) {
+		items, err := repo.FindAll(ctx, usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Currency: usecase.BaseCurrency}})
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+
+		count, err := repo.Count(ctx, usecase.ItemFilter{Currency: "USD"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestItemRepository_Iterate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository(newItem("アイテム1", "時計"), newItem("アイテム2", "バッグ"), newItem("アイテム3", "時計"))
+
+	t.Run("正常系: 条件に合うアイテムを順に渡す", func(t *testing.T) {
+		var ids []int64
+		err := repo.Iterate(ctx, usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Category: "時計"}, SortOrder: "asc"}, func(item *entity.Item) error {
+			ids = append(ids, item.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, ids)
+	})
+
+	t.Run("異常系: コールバックのエラーで中断する", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := repo.Iterate(ctx, usecase.ItemQuery{}, func(item *entity.Item) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestItemRepository_FindAllStream(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository(newItem("アイテム1", "時計"), newItem("アイテム2", "バッグ"), newItem("アイテム3", "時計"), newItem("アイテム4", "時計"))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Trash(ctx, 3, base))
+	require.NoError(t, repo.Trash(ctx, 4, base.Add(time.Hour)))
+
+	collect := func(t *testing.T, query usecase.ItemQuery) []int64 {
+		it, err := repo.FindAllStream(ctx, query)
+		require.NoError(t, err)
+		defer it.Close()
+		ids := []int64{}
+		for it.Next() {
+			ids = append(ids, it.Item().ID)
+		}
+		require.NoError(t, it.Err())
+		return ids
+	}
+
+	t.Run("正常系: 条件に合うアイテムを順に読む", func(t *testing.T) {
+		assert.Equal(t, []int64{1, 2}, collect(t, usecase.ItemQuery{SortOrder: "asc"}))
+	})
+
+	t.Run("正常系: ID の順に AfterID の後から読む", func(t *testing.T) {
+		assert.Equal(t, []int64{2}, collect(t, usecase.ItemQuery{SortBy: usecase.SortByID, SortOrder: "asc", AfterID: 1}))
+	})
+
+	t.Run("正常系: 読んでいる間にアイテムを削除できる", func(t *testing.T) {
+		it, err := repo.FindAllStream(ctx, usecase.ItemQuery{})
+		require.NoError(t, err)
+		defer it.Close()
+		require.True(t, it.Next())
+		require.NoError(t, repo.Delete(ctx, 1))
+		require.NoError(t, repo.Delete(ctx, 2))
+		assert.True(t, it.Next())
+		assert.False(t, it.Next())
+	})
+
+	t.Run("正常系: TrashedBefore より前にゴミ箱に移したアイテムを読む", func(t *testing.T) {
+		assert.Equal(t, []int64{3}, collect(t, usecase.ItemQuery{ItemFilter: usecase.ItemFilter{Category: "時計"}, TrashedBefore: base.Add(time.Minute)}))
+		assert.Equal(t, []int64{4, 3}, collect(t, usecase.ItemQuery{TrashedBefore: base.Add(2 * time.Hour)}))
+	})
+}
+
+func TestItemRepository_Trash(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository(newItem("アイテム1", "時計"), newItem("アイテム2", "バッグ"), newItem("アイテム3", "時計"))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Trash(ctx, 1, base))
+	require.NoError(t, repo.Trash(ctx, 3, base.Add(time.Hour)))
+
+	t.Run("正常系: ゴミ箱のアイテムは通常の取得・集計から除かれる", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		count, err := repo.Count(ctx, usecase.ItemFilter{Category: "時計"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("正常系: 削除の新しい順に並ぶ", func(t *testing.T) {
+		items, err := repo.FindTrashed(ctx, usecase.TrashQuery{})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, int64(3), items[0].ID)
+		assert.Equal(t, base.Add(time.Hour), items[0].DeletedAt)
+		assert.Equal(t, "アイテム1", items[1].Name)
+	})
+
+	t.Run("正常系: 削除日時で絞り込み", func(t *testing.T) {
+		items, err := repo.FindTrashed(ctx, usecase.TrashQuery{DeletedBefore: base.Add(time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, int64(1), items[0].ID)
+	})
+
+	t.Run("異常系: ゴミ箱のアイテムは再び移動できない", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Trash(ctx, 1, base), domainErrors.ErrItemNotFound)
+		_, err := repo.FindTrashedByID(ctx, 2)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("正常系: ゴミ箱から完全に削除", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, 1))
+		_, err := repo.FindTrashedByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		count, err := repo.CountTrashed(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 2, repo.Len())
+	})
+
+	t.Run("正常系: ゴミ箱から戻す", func(t *testing.T) {
+		require.NoError(t, repo.Restore(ctx, 3))
+		item, err := repo.FindByID(ctx, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "アイテム3", item.Name)
+		assert.ErrorIs(t, repo.Restore(ctx, 3), domainErrors.ErrItemNotFound)
+	})
+}
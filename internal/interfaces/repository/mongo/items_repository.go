@@ -0,0 +1,478 @@
+//go:build mongodb
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemRepository stores items in the items collection
+type ItemRepository struct {
+	client   *mongo.Client
+	items    *mongo.Collection
+	counters *mongo.Collection
+}
+
+// Connect connects to MongoDB and prepares the indexes used by the repository
+func Connect(ctx context.Context, uri, database string) (Repository, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to ping mongodb: %w", err)
+	}
+
+	db := client.Database(database)
+	r := &ItemRepository{
+		client:   client,
+		items:    db.Collection(itemsCollection),
+		counters: db.Collection(countersCollection),
+	}
+
+	// 一覧（作成日時の降順）・カテゴリー集計・ゴミ箱（削除日時の降順）用のインデックス
+	_, err = r.items.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "category", Value: 1}}},
+		{Keys: bson.D{{Key: "deleted_at", Value: -1}}},
+	})
+	if err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to create mongodb indexes: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *ItemRepository) Close(ctx context.Context) error {
+	return r.client.Disconnect(ctx)
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, query usecase.ItemQuery) ([]*entity.Item, error) {
+	var items []*entity.Item
+	err := r.Iterate(ctx, query, func(item *entity.Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *ItemRepository) Iterate(ctx context.Context, query usecase.ItemQuery, fn func(*entity.Item) error) error {
+	it, err := r.FindAllStream(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+func (r *ItemRepository) FindAllStream(ctx context.Context, query usecase.ItemQuery) (usecase.ItemIterator, error) {
+	if err := checkCurrentValue(query.SortBy, query.ItemFilter); err != nil {
+		return nil, err
+	}
+	direction := -1
+	if query.SortOrder == entity.SortAsc {
+		direction = 1
+	}
+	sort := bson.D{{Key: sortField(query.SortBy), Value: direction}, {Key: "_id", Value: direction}}
+	if query.SortBy == usecase.SortByID {
+		sort = bson.D{{Key: "_id", Value: direction}}
+	}
+	opts := options.Find().SetSort(sort)
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit)).SetSkip(int64(max(query.Offset, 0)))
+	}
+
+	cursor, err := r.items.Find(ctx, queryDocument(query), opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return &itemIterator{ctx: ctx, cursor: cursor}, nil
+}
+
+// itemIterator decodes the documents of a cursor into items one at a time
+type itemIterator struct {
+	ctx    context.Context
+	cursor *mongo.Cursor
+	item   *entity.Item
+	err    error
+}
+
+func (it *itemIterator) Next() bool {
+	if it.err != nil || !it.cursor.Next(it.ctx) {
+		return false
+	}
+	var doc itemDocument
+	if err := it.cursor.Decode(&doc); err != nil {
+		it.err = fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return false
+	}
+	it.item = doc.toEntity()
+	return true
+}
+
+func (it *itemIterator) Item() *entity.Item {
+	return it.item
+}
+
+func (it *itemIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if err := it.cursor.Err(); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (it *itemIterator) Close() error {
+	return it.cursor.Close(it.ctx)
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	if err := checkCurrentValue("", filter); err != nil {
+		return 0, err
+	}
+	count, err := r.items.CountDocuments(ctx, filterDocument(filter))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return int(count), nil
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	var doc itemDocument
+	err := r.items.FindOne(ctx, bson.D{{Key: "_id", Value: id}, notTrashed}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return doc.toEntity(), nil
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	id, err := r.nextID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := toDocument(item)
+	doc.ID = id
+	doc.Version = 1
+	if _, err := r.items.InsertOne(ctx, doc); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.items.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if result.DeletedCount == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *ItemRepository) Trash(ctx context.Context, id int64, deletedAt time.Time) error {
+	filter := bson.D{{Key: "_id", Value: id}, notTrashed}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "deleted_at", Value: deletedAt}}}}
+	result, err := r.items.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if result.MatchedCount == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	filter := bson.D{{Key: "_id", Value: id}, trashed}
+	update := bson.D{{Key: "$unset", Value: bson.D{{Key: "deleted_at", Value: ""}}}}
+	result, err := r.items.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if result.MatchedCount == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+	return nil
+}
+
+func (r *ItemRepository) FindTrashed(ctx context.Context, query usecase.TrashQuery) ([]*entity.TrashedItem, error) {
+	deletedAt := bson.D{{Key: "$ne", Value: nil}}
+	if !query.DeletedBefore.IsZero() {
+		deletedAt = append(deletedAt, bson.E{Key: "$lt", Value: query.DeletedBefore})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit)).SetSkip(int64(max(query.Offset, 0)))
+	}
+
+	cursor, err := r.items.Find(ctx, bson.D{{Key: "deleted_at", Value: deletedAt}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	var docs []itemDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	items := make([]*entity.TrashedItem, len(docs))
+	for i, doc := range docs {
+		items[i] = doc.toTrashedEntity()
+	}
+	return items, nil
+}
+
+func (r *ItemRepository) CountTrashed(ctx context.Context) (int, error) {
+	count, err := r.items.CountDocuments(ctx, bson.D{trashed})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return int(count), nil
+}
+
+func (r *ItemRepository) FindTrashedByID(ctx context.Context, id int64) (*entity.TrashedItem, error) {
+	var doc itemDocument
+	err := r.items.FindOne(ctx, bson.D{{Key: "_id", Value: id}, trashed}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return doc.toTrashedEntity(), nil
+}
+
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	// MySQL版と同じく、カテゴリー・名前・ブランド・購入価格・属性・所有者のみ更新する
+	set := bson.D{
+		{Key: "category", Value: item.Category},
+		{Key: "name", Value: item.Name},
+		{Key: "brand", Value: item.Brand},
+		{Key: "purchase_price", Value: item.PurchasePrice},
+		{Key: "updated_at", Value: item.UpdatedAt},
+	}
+	unset := bson.D{}
+	if len(item.Attributes) > 0 {
+		set = append(set, bson.E{Key: "attributes", Value: item.Attributes})
+	} else {
+		unset = append(unset, bson.E{Key: "attributes", Value: ""})
+	}
+	if item.OwnerID != "" {
+		set = append(set, bson.E{Key: "owner_id", Value: item.OwnerID})
+	} else {
+		unset = append(unset, bson.E{Key: "owner_id", Value: ""})
+	}
+	update := bson.D{{Key: "$set", Value: set}}
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+	update = append(update, bson.E{Key: "$inc", Value: bson.D{{Key: "version", Value: int64(1)}}})
+
+	filter := bson.D{{Key: "_id", Value: item.ID}, {Key: "version", Value: item.Version}, notTrashed}
+	result, err := r.items.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	// 更新されなかった場合は、削除されたのか他の更新でバージョンが変わったのかを区別する
+	if result.MatchedCount == 0 {
+		if _, err := r.FindByID(ctx, item.ID); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.Conflict("item %d has been modified", item.ID)
+	}
+
+	return r.FindByID(ctx, item.ID)
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, purchased usecase.DateRange) (map[string]usecase.CategoryStats, error) {
+	// SELECT category, COUNT(*), MIN/MAX/AVG/SUM(BaseCurrency の purchase_price) FROM items WHERE purchase_date ... GROUP BY category 相当
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: purchaseDateDocument(purchased)}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$category"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "min", Value: bson.D{{Key: "$min", Value: basePrice}}},
+			{Key: "max", Value: bson.D{{Key: "$max", Value: basePrice}}},
+			{Key: "avg", Value: bson.D{{Key: "$avg", Value: basePrice}}},
+			{Key: "sum", Value: bson.D{{Key: "$sum", Value: basePrice}}},
+		}}},
+	}
+
+	cursor, err := r.items.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	var rows []categoryCount
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return summarize(rows), nil
+}
+
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) (map[string]*usecase.BrandTotals, error) {
+	// SELECT brand, COUNT(*), SUM(BaseCurrency の purchase_price) FROM items GROUP BY brand 相当
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{notTrashed}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$brand"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "purchase_total", Value: bson.D{{Key: "$sum", Value: basePrice}}},
+		}}},
+	}
+
+	cursor, err := r.items.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	var rows []brandTotals
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return summarizeBrands(rows), nil
+}
+
+func (r *ItemRepository) CountByPriceBands(ctx context.Context, bounds []int) ([]int, error) {
+	// SQL の CASE WHEN purchase_price < ? THEN 0 ... ELSE len(bounds) END と同じ帯の番号でまとめる（BaseCurrency のアイテムのみ）
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{notTrashed, inBaseCurrency}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: priceBandExpression(bounds)},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+
+	cursor, err := r.items.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	var rows []priceBandCount
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return bandCounts(rows, len(bounds)), nil
+}
+
+// priceBandExpression numbers the band of purchase_price: the index of the first bound it is below, or len(bounds)
+func priceBandExpression(bounds []int) bson.D {
+	branches := make(bson.A, 0, len(bounds))
+	for i, bound := range bounds {
+		branches = append(branches, bson.D{
+			{Key: "case", Value: bson.D{{Key: "$lt", Value: bson.A{"$purchase_price", bound}}}},
+			{Key: "then", Value: i},
+		})
+	}
+	return bson.D{{Key: "$switch", Value: bson.D{
+		{Key: "branches", Value: branches},
+		{Key: "default", Value: len(bounds)},
+	}}}
+}
+
+// purchaseDateDocument matches the items purchased within purchased; purchase_date is stored as YYYY-MM-DD, which sorts by date
+func purchaseDateDocument(purchased usecase.DateRange) bson.D {
+	bounds := bson.D{}
+	if purchased.From != "" {
+		bounds = append(bounds, bson.E{Key: "$gte", Value: purchased.From})
+	}
+	if purchased.To != "" {
+		bounds = append(bounds, bson.E{Key: "$lte", Value: purchased.To})
+	}
+	if len(bounds) == 0 {
+		return bson.D{notTrashed}
+	}
+	return bson.D{notTrashed, {Key: "purchase_date", Value: bounds}}
+}
+
+// notTrashed matches the items not in the trash (deleted_at is null or missing); trashed the ones in it
+var (
+	notTrashed = bson.E{Key: "deleted_at", Value: nil}
+	trashed    = bson.E{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}}
+)
+
+// 通貨を持つ前に保存されたドキュメントには currency がない（BaseCurrency）
+var (
+	currency       = bson.D{{Key: "$ifNull", Value: bson.A{"$currency", usecase.BaseCurrency}}}
+	inBaseCurrency = bson.E{Key: "currency", Value: bson.D{{Key: "$in", Value: bson.A{usecase.BaseCurrency, nil}}}}
+	// BaseCurrency のアイテムの購入価格。ほかの通貨のアイテムは null になり、$min / $max / $avg / $sum の対象にならない
+	basePrice = bson.D{{Key: "$cond", Value: bson.A{bson.D{{Key: "$eq", Value: bson.A{currency, usecase.BaseCurrency}}}, "$purchase_price", nil}}}
+)
+
+// fieldCondition はフィルターの1つの条件。BaseCurrency の条件は currency のないドキュメントにも一致させる
+func fieldCondition(field filterField) bson.E {
+	if field.key == inBaseCurrency.Key && field.value == usecase.BaseCurrency {
+		return inBaseCurrency
+	}
+	return bson.E{Key: field.key, Value: field.value}
+}
+
+func filterDocument(filter usecase.ItemFilter) bson.D {
+	doc := bson.D{notTrashed}
+	for _, field := range filterFields(filter) {
+		doc = append(doc, fieldCondition(field))
+	}
+	return doc
+}
+
+// queryDocument はクエリの条件。TrashedBefore を指定すると、ゴミ箱のアイテムのうちそれより前に移したものを選ぶ
+func queryDocument(query usecase.ItemQuery) bson.D {
+	doc := bson.D{notTrashed}
+	if !query.TrashedBefore.IsZero() {
+		doc = bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$lt", Value: query.TrashedBefore}}}}
+	}
+	if query.AfterID > 0 {
+		doc = append(doc, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: query.AfterID}}})
+	}
+	for _, field := range filterFields(query.ItemFilter) {
+		doc = append(doc, fieldCondition(field))
+	}
+	return doc
+}
+
+// counters コレクションのシーケンスを1つ進めて、新しいアイテムIDを払い出す
+func (r *ItemRepository) nextID(ctx context.Context) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := r.counters.FindOneAndUpdate(ctx,
+		bson.D{{Key: "_id", Value: itemsCounterID}},
+		bson.D{{Key: "$inc", Value: bson.D{{Key: "seq", Value: int64(1)}}}},
+		opts,
+	).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to allocate item id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return counter.Seq, nil
+}
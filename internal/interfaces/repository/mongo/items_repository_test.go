@@ -0,0 +1,84 @@
+//go:build mongodb
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.ItemRepository = (*ItemRepository)(nil)
+
+// connectForTest connects to MONGODB_TEST_URI with a database of its own, dropped after the test
+func connectForTest(t *testing.T) *ItemRepository {
+	t.Helper()
+
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+
+	ctx := context.Background()
+	repo, err := Connect(ctx, uri, fmt.Sprintf("items_test_%d", time.Now().UnixNano()))
+	require.NoError(t, err)
+	r := repo.(*ItemRepository)
+	t.Cleanup(func() {
+		_ = r.items.Database().Drop(ctx)
+		_ = r.Close(ctx)
+	})
+	return r
+}
+
+func TestItemRepository_CRUD(t *testing.T) {
+	r := connectForTest(t)
+	ctx := context.Background()
+
+	item, err := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+	require.NoError(t, err)
+	item.OwnerID = "alice"
+	item.Attributes = map[string]string{"storage_box": "A-1"}
+
+	created, err := r.Create(ctx, item)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+	assert.Equal(t, int64(1), created.Version)
+
+	// 譲渡の承諾と同じく、所有者を変更して属性を消す
+	created.OwnerID = "bob"
+	created.Attributes = nil
+	updated, err := r.Update(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", updated.OwnerID)
+	assert.Empty(t, updated.Attributes)
+	assert.Equal(t, int64(2), updated.Version)
+
+	_, err = r.Update(ctx, created)
+	assert.ErrorIs(t, err, domainErrors.ErrConflict)
+
+	count, err := r.Count(ctx, usecase.ItemFilter{OwnerID: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	summary, err := r.GetSummaryByCategory(ctx, usecase.DateRange{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary["時計"].Count)
+
+	summary, err = r.GetSummaryByCategory(ctx, usecase.DateRange{From: "2024-01-01"})
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+
+	require.NoError(t, r.Delete(ctx, created.ID))
+	_, err = r.FindByID(ctx, created.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	assert.ErrorIs(t, r.Delete(ctx, created.ID), domainErrors.ErrItemNotFound)
+}
@@ -0,0 +1,183 @@
+// Package mongo provides a MongoDB implementation of usecase.ItemRepository.
+//
+// The driver is only compiled in with the mongodb build tag:
+//
+//	go get go.mongodb.org/mongo-driver@v1
+//	go build -tags mongodb -o main ./cmd
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	itemsCollection    = "items"
+	countersCollection = "counters"
+
+	// counters コレクションでアイテムIDの採番に使うドキュメントのID
+	itemsCounterID = "items"
+)
+
+// Repository is an ItemRepository backed by a MongoDB connection that must be closed
+type Repository interface {
+	usecase.ItemRepository
+	Close(ctx context.Context) error
+}
+
+// itemDocument is the BSON representation of an item; _id holds the numeric item ID
+type itemDocument struct {
+	ID            int64             `bson:"_id"`
+	Name          string            `bson:"name"`
+	Category      string            `bson:"category"`
+	Brand         string            `bson:"brand"`
+	PurchasePrice int               `bson:"purchase_price"`
+	Currency      string            `bson:"currency,omitempty"` // 通貨を持つ前に保存されたドキュメントにはない（DefaultCurrency）
+	PurchaseDate  string            `bson:"purchase_date"`
+	Attributes    map[string]string `bson:"attributes,omitempty"`
+	OwnerID       string            `bson:"owner_id,omitempty"`
+	Version       int64             `bson:"version"`
+	CreatedAt     time.Time         `bson:"created_at"`
+	UpdatedAt     time.Time         `bson:"updated_at"`
+	// ゴミ箱に移動した日時（ゴミ箱にないアイテムにはフィールドがない）
+	DeletedAt *time.Time `bson:"deleted_at,omitempty"`
+}
+
+// categoryCount is one row of the summary aggregation ($group by category)
+type categoryCount struct {
+	Category string  `bson:"_id"`
+	Count    int     `bson:"count"`
+	Min      int     `bson:"min"`
+	Max      int     `bson:"max"`
+	Avg      float64 `bson:"avg"`
+	Sum      int     `bson:"sum"`
+}
+
+// brandTotals is one row of the brand summary aggregation ($group by brand)
+type brandTotals struct {
+	Brand         string `bson:"_id"`
+	Count         int    `bson:"count"`
+	PurchaseTotal int    `bson:"purchase_total"`
+}
+
+// priceBandCount is one row of the price band aggregation ($group by band number)
+type priceBandCount struct {
+	Band  int `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+func toDocument(item *entity.Item) itemDocument {
+	return itemDocument{
+		ID:            item.ID,
+		Name:          item.Name,
+		Category:      item.Category,
+		Brand:         item.Brand,
+		PurchasePrice: item.PurchasePrice,
+		Currency:      item.Price().Currency,
+		PurchaseDate:  item.PurchaseDate,
+		Attributes:    item.Attributes,
+		OwnerID:       item.OwnerID,
+		Version:       item.Version,
+		CreatedAt:     item.CreatedAt,
+		UpdatedAt:     item.UpdatedAt,
+	}
+}
+
+func (d itemDocument) toTrashedEntity() *entity.TrashedItem {
+	item := &entity.TrashedItem{Item: d.toEntity()}
+	if d.DeletedAt != nil {
+		item.DeletedAt = *d.DeletedAt
+	}
+	return item
+}
+
+func (d itemDocument) toEntity() *entity.Item {
+	currency := d.Currency
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	return &entity.Item{
+		ID:            d.ID,
+		Name:          d.Name,
+		Category:      d.Category,
+		Brand:         d.Brand,
+		PurchasePrice: d.PurchasePrice,
+		Currency:      currency,
+		PurchaseDate:  d.PurchaseDate,
+		Attributes:    d.Attributes,
+		OwnerID:       d.OwnerID,
+		Version:       d.Version,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+type filterField struct {
+	key   string
+	value string
+}
+
+// filterFields returns the document fields an item filter matches on
+func filterFields(filter usecase.ItemFilter) []filterField {
+	var fields []filterField
+	if filter.Category != "" {
+		fields = append(fields, filterField{"category", filter.Category})
+	}
+	if filter.Brand != "" {
+		fields = append(fields, filterField{"brand", filter.Brand})
+	}
+	if filter.OwnerID != "" {
+		fields = append(fields, filterField{"owner_id", filter.OwnerID})
+	}
+	if filter.Currency != "" {
+		fields = append(fields, filterField{"currency", filter.Currency})
+	}
+	return fields
+}
+
+// checkCurrentValue rejects sorting and filtering by current value: the valuations are kept in the SQL database,
+// which the item documents cannot be joined with
+func checkCurrentValue(sortBy string, filter usecase.ItemFilter) error {
+	if sortBy == usecase.SortByCurrentValue || filter.MinCurrentValue > 0 {
+		return domainErrors.NewValidationError("current_value is not supported with ITEM_STORE=mongodb")
+	}
+	return nil
+}
+
+// sortField maps a sortable field to its document field (_id is the tie-breaker and never a sort field)
+func sortField(sortBy string) string {
+	if !entity.IsSortableField(sortBy) {
+		return "created_at"
+	}
+	return sortBy
+}
+
+func summarize(rows []categoryCount) map[string]usecase.CategoryStats {
+	summary := make(map[string]usecase.CategoryStats, len(rows))
+	for _, row := range rows {
+		summary[row.Category] = usecase.CategoryStats{Count: row.Count, Min: row.Min, Max: row.Max, Avg: row.Avg, Sum: row.Sum}
+	}
+	return summary
+}
+
+func bandCounts(rows []priceBandCount, bounds int) []int {
+	counts := make([]int, bounds+1)
+	for _, row := range rows {
+		if row.Band >= 0 && row.Band <= bounds {
+			counts[row.Band] = row.Count
+		}
+	}
+	return counts
+}
+
+func summarizeBrands(rows []brandTotals) map[string]*usecase.BrandTotals {
+	summary := make(map[string]*usecase.BrandTotals, len(rows))
+	for _, row := range rows {
+		summary[row.Brand] = &usecase.BrandTotals{Count: row.Count, PurchaseTotal: row.PurchaseTotal}
+	}
+	return summary
+}
@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDocumentMapping(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	item := &entity.Item{
+		ID:            3,
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		Currency:      "JPY",
+		PurchaseDate:  "2023-01-15",
+		Attributes:    map[string]string{"storage_box": "A-1"},
+		OwnerID:       "user-1",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	assert.Equal(t, item, toDocument(item).toEntity())
+
+	// 通貨を持つ前に保存されたドキュメントは DefaultCurrency
+	assert.Equal(t, entity.DefaultCurrency, itemDocument{}.toEntity().Currency)
+}
+
+func TestSummarize(t *testing.T) {
+	rows := []categoryCount{
+		{Category: "時計", Count: 2, Min: 500000, Max: 1000000, Avg: 750000, Sum: 1500000},
+		{Category: "バッグ", Count: 1, Min: 300000, Max: 300000, Avg: 300000, Sum: 300000},
+	}
+	assert.Equal(t, map[string]usecase.CategoryStats{
+		"時計":  {Count: 2, Min: 500000, Max: 1000000, Avg: 750000, Sum: 1500000},
+		"バッグ": {Count: 1, Min: 300000, Max: 300000, Avg: 300000, Sum: 300000},
+	}, summarize(rows))
+	assert.Empty(t, summarize(nil))
+}
+
+func TestSummarizeBrands(t *testing.T) {
+	rows := []brandTotals{{Brand: "ROLEX", Count: 2, PurchaseTotal: 3000000}, {Brand: "HERMES", Count: 1, PurchaseTotal: 2000000}}
+	assert.Equal(t, map[string]*usecase.BrandTotals{
+		"ROLEX":  {Count: 2, PurchaseTotal: 3000000},
+		"HERMES": {Count: 1, PurchaseTotal: 2000000},
+	}, summarizeBrands(rows))
+	assert.Empty(t, summarizeBrands(nil))
+}
+
+func TestFilterFields(t *testing.T) {
+	assert.Empty(t, filterFields(usecase.ItemFilter{}))
+	assert.Equal(t, []filterField{{"category", "時計"}, {"owner_id", "alice"}, {"currency", "USD"}},
+		filterFields(usecase.ItemFilter{Category: "時計", OwnerID: "alice", Currency: "USD"}))
+
+	assert.Equal(t, "purchase_price", sortField("purchase_price"))
+	assert.Equal(t, "created_at", sortField("$where"))
+}
+
+func TestCheckCurrentValue(t *testing.T) {
+	assert.NoError(t, checkCurrentValue("purchase_price", usecase.ItemFilter{Category: "時計"}))
+
+	err := checkCurrentValue(usecase.SortByCurrentValue, usecase.ItemFilter{})
+	assert.True(t, domainErrors.IsValidationError(err))
+	err = checkCurrentValue("", usecase.ItemFilter{MinCurrentValue: 1000})
+	assert.True(t, domainErrors.IsValidationError(err))
+}
+
+func TestBandCounts(t *testing.T) {
+	rows := []priceBandCount{{Band: 0, Count: 3}, {Band: 2, Count: 1}}
+	assert.Equal(t, []int{3, 0, 1}, bandCounts(rows, 2))
+	assert.Equal(t, []int{0, 0, 0}, bandCounts(nil, 2))
+}
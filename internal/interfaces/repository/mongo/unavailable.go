@@ -0,0 +1,13 @@
+//go:build !mongodb
+
+package mongo
+
+import (
+	"context"
+	"errors"
+)
+
+// Connect is unavailable unless the binary is built with the mongodb tag
+func Connect(ctx context.Context, uri, database string) (Repository, error) {
+	return nil, errors.New("mongodb support is not compiled in: build with -tags mongodb")
+}
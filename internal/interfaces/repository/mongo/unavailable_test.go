@@ -0,0 +1,16 @@
+//go:build !mongodb
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnect_WithoutDriver(t *testing.T) {
+	// mongodb ビルドタグなしではドライバーが組み込まれていない
+	_, err := Connect(context.Background(), "mongodb://localhost:27017", "items_db")
+	assert.ErrorContains(t, err, "-tags mongodb")
+}
@@ -0,0 +1,26 @@
+// Package rpc serves the item API over gRPC (api/proto/item/v1/item.proto) using the same
+// usecases as the HTTP controllers.
+//
+// The generated itempb package is committed; after changing the .proto file, regenerate it with protoc and the plugins:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.36.9
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
+//	go generate ./internal/interfaces/rpc
+package rpc
+
+//go:generate protoc -I ../../../api/proto --go_out=. --go_opt=module=Aicon-assignment/internal/interfaces/rpc --go-grpc_out=. --go-grpc_opt=module=Aicon-assignment/internal/interfaces/rpc item/v1/item.proto
+
+import "net"
+
+const (
+	// MetadataTenantID identifies the tenant, like the X-Tenant-ID header
+	MetadataTenantID = "x-tenant-id"
+	// MetadataUserID identifies the acting user, like the X-User-ID header
+	MetadataUserID = "x-user-id"
+)
+
+// Server is a gRPC server with the ItemService registered
+type Server interface {
+	Serve(lis net.Listener) error
+	GracefulStop()
+}
@@ -0,0 +1,206 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/rpc/itempb"
+	"Aicon-assignment/internal/usecase"
+)
+
+// NewServer creates a gRPC server with the ItemService registered
+func NewServer(itemUsecase usecase.ItemUsecase) (Server, error) {
+	s := grpc.NewServer()
+	itempb.RegisterItemServiceServer(s, &itemService{itemUsecase: itemUsecase})
+	return s, nil
+}
+
+type itemService struct {
+	itempb.UnimplementedItemServiceServer
+	itemUsecase usecase.ItemUsecase
+}
+
+func (s *itemService) GetItem(ctx context.Context, req *itempb.GetItemRequest) (*itempb.Item, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid item ID")
+	}
+
+	item, err := s.itemUsecase.GetItemByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err, "failed to retrieve item")
+	}
+
+	return toProto(item), nil
+}
+
+func (s *itemService) ListItems(ctx context.Context, req *itempb.ListItemsRequest) (*itempb.ListItemsResponse, error) {
+	query := usecase.ListItemsQuery{
+		TenantID:  metadataValue(ctx, MetadataTenantID),
+		Category:  req.GetCategory(),
+		Brand:     req.GetBrand(),
+		SortBy:    req.GetSortBy(),
+		SortOrder: req.GetSortOrder(),
+		Page:      int(req.GetPage()),
+	}
+	if req.PageSize != nil {
+		pageSize := int(req.GetPageSize())
+		query.PageSize = &pageSize
+	}
+
+	list, err := s.itemUsecase.ListItems(ctx, query)
+	if err != nil {
+		return nil, toStatus(err, "failed to retrieve items")
+	}
+
+	resp := &itempb.ListItemsResponse{
+		Items:    make([]*itempb.Item, 0, len(list.Items)),
+		Total:    int32(list.Total),
+		Page:     int32(list.Page),
+		PageSize: int32(list.PageSize),
+	}
+	for _, item := range list.Items {
+		resp.Items = append(resp.Items, toProto(item))
+	}
+
+	return resp, nil
+}
+
+func (s *itemService) CreateItem(ctx context.Context, req *itempb.CreateItemRequest) (*itempb.Item, error) {
+	item, err := s.itemUsecase.CreateItem(ctx, usecase.CreateItemInput{
+		TenantID:      metadataValue(ctx, MetadataTenantID),
+		OwnerID:       metadataValue(ctx, MetadataUserID),
+		Name:          req.GetName(),
+		Category:      req.GetCategory(),
+		Brand:         req.GetBrand(),
+		PurchasePrice: entity.MinorUnits(req.GetPurchasePrice()),
+		Currency:      req.GetCurrency(),
+		PurchaseDate:  req.GetPurchaseDate(),
+		Attributes:    req.GetAttributes(),
+	})
+	if err != nil {
+		return nil, toStatus(err, "failed to create item")
+	}
+
+	return toProto(item), nil
+}
+
+func (s *itemService) PatchItem(ctx context.Context, req *itempb.PatchItemRequest) (*itempb.Item, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid item ID")
+	}
+
+	version := req.GetVersion()
+	update := &usecase.UpdateItemRequest{
+		TenantID: metadataValue(ctx, MetadataTenantID),
+		UserID:   metadataValue(ctx, MetadataUserID),
+		Name:     req.Name,
+		Brand:    req.Brand,
+		Version:  &version,
+	}
+	if req.PurchasePrice != nil {
+		price := entity.MinorUnits(req.GetPurchasePrice())
+		update.PurchasePrice = &price
+	}
+	if len(req.GetAttributes()) > 0 || len(req.GetRemoveAttributes()) > 0 {
+		update.Attributes = make(map[string]*string, len(req.GetAttributes())+len(req.GetRemoveAttributes()))
+		for key, value := range req.GetAttributes() {
+			update.Attributes[key] = &value
+		}
+		// 削除指定は HTTP API の null と同じ扱い
+		for _, key := range req.GetRemoveAttributes() {
+			update.Attributes[key] = nil
+		}
+	}
+
+	item, err := s.itemUsecase.PatchItem(ctx, req.GetId(), update)
+	if err != nil {
+		return nil, toStatus(err, "failed to update item")
+	}
+
+	return toProto(item), nil
+}
+
+func (s *itemService) DeleteItem(ctx context.Context, req *itempb.DeleteItemRequest) (*itempb.DeleteItemResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid item ID")
+	}
+
+	if err := s.itemUsecase.DeleteItem(ctx, req.GetId(), nil); err != nil {
+		return nil, toStatus(err, "failed to delete item")
+	}
+
+	return &itempb.DeleteItemResponse{}, nil
+}
+
+func (s *itemService) GetSummary(ctx context.Context, req *itempb.GetSummaryRequest) (*itempb.CategorySummary, error) {
+	summary, err := s.itemUsecase.GetCategorySummary(ctx, usecase.DateRange{})
+	if err != nil {
+		return nil, toStatus(err, "failed to retrieve summary")
+	}
+
+	categories := make(map[string]int32, len(summary.Categories))
+	for category, count := range summary.Categories {
+		categories[category] = int32(count)
+	}
+
+	return &itempb.CategorySummary{Categories: categories, Total: int32(summary.Total)}, nil
+}
+
+// toStatus maps domain errors to gRPC status codes the same way the HTTP controllers map them to status codes
+func toStatus(err error, message string) error {
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return status.Error(codes.NotFound, "item not found")
+	case domainErrors.IsValidationError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case domainErrors.IsPreconditionFailedError(err):
+		return status.Error(codes.FailedPrecondition, "precondition failed")
+	case domainErrors.IsConflictError(err):
+		return status.Error(codes.Aborted, "item has been modified")
+	case domainErrors.IsForbiddenError(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, message)
+	}
+}
+
+// metadataValue returns the first value of an incoming metadata key, or an empty string if none
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+func toProto(item *entity.Item) *itempb.Item {
+	return &itempb.Item{
+		Id:              item.ID,
+		Name:            item.Name,
+		Category:        item.Category,
+		Brand:           item.Brand,
+		PurchasePrice:   int64(item.PurchasePrice),
+		Currency:        item.Price().Currency,
+		PurchaseDate:    item.PurchaseDate,
+		Attributes:      item.Attributes,
+		OwnerId:         item.OwnerID,
+		MaintenanceCost: int64(item.MaintenanceCost),
+		ImageIds:        item.ImageIDs,
+		Tags:            item.Tags,
+		Version:         item.Version,
+		CreatedAt:       timestamppb.New(item.CreatedAt),
+		UpdatedAt:       timestamppb.New(item.UpdatedAt),
+	}
+}
@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/rpc/itempb"
+	"Aicon-assignment/internal/usecase"
+)
+
+// stubItemUsecase records the requests it receives; methods that are not overridden panic
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+
+	items   map[int64]*entity.Item
+	created usecase.CreateItemInput
+	patched *usecase.UpdateItemRequest
+	list    usecase.ListItemsQuery
+	err     error
+}
+
+func (s *stubItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, ok := s.items[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return item, nil
+}
+
+func (s *stubItemUsecase) ListItems(ctx context.Context, query usecase.ListItemsQuery) (*usecase.ItemList, error) {
+	s.list = query
+	return &usecase.ItemList{Items: []*entity.Item{s.items[1]}, Total: 1, Page: 1, PageSize: 20}, nil
+}
+
+func (s *stubItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	s.created = input
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &entity.Item{ID: 2, Name: input.Name, Category: input.Category, Brand: input.Brand, Currency: input.Currency, OwnerID: input.OwnerID, Version: 1}, nil
+}
+
+func (s *stubItemUsecase) PatchItem(ctx context.Context, id int64, req *usecase.UpdateItemRequest) (*entity.Item, error) {
+	s.patched = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	item := *s.items[id]
+	item.Version++
+	return &item, nil
+}
+
+func (s *stubItemUsecase) GetCategorySummary(ctx context.Context, purchased usecase.DateRange) (*usecase.CategorySummary, error) {
+	return &usecase.CategorySummary{Categories: map[string]int{"時計": 2, "バッグ": 1}, Total: 3}, nil
+}
+
+// newTestClient serves the item usecase over an in-memory listener
+func newTestClient(t *testing.T, itemUsecase usecase.ItemUsecase) itempb.ItemServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server, err := NewServer(itemUsecase)
+	require.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(server.GracefulStop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return itempb.NewItemServiceClient(conn)
+}
+
+func TestItemService(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	stub := &stubItemUsecase{items: map[int64]*entity.Item{
+		1: {ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			Tags: []string{"ヴィンテージ"}, Version: 3, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}}
+	client := newTestClient(t, stub)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataTenantID, "acme", MetadataUserID, "alice")
+
+	t.Run("正常系: アイテムの取得", func(t *testing.T) {
+		item, err := client.GetItem(ctx, &itempb.GetItemRequest{Id: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.GetName())
+		assert.Equal(t, int64(1500000), item.GetPurchasePrice())
+		assert.Equal(t, "JPY", item.GetCurrency())
+		assert.Equal(t, []string{"ヴィンテージ"}, item.GetTags())
+		assert.Equal(t, createdAt, item.GetCreatedAt().AsTime())
+	})
+
+	t.Run("異常系: 存在しないアイテムは NotFound", func(t *testing.T) {
+		_, err := client.GetItem(ctx, &itempb.GetItemRequest{Id: 9})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = client.GetItem(ctx, &itempb.GetItemRequest{Id: 0})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("正常系: 一覧はメタデータのテナントを使う", func(t *testing.T) {
+		pageSize := int32(20)
+		resp, err := client.ListItems(ctx, &itempb.ListItemsRequest{Category: "時計", PageSize: &pageSize})
+
+		require.NoError(t, err)
+		assert.Len(t, resp.GetItems(), 1)
+		assert.Equal(t, int32(1), resp.GetTotal())
+		assert.Equal(t, "acme", stub.list.TenantID)
+		assert.Equal(t, "時計", stub.list.Category)
+		assert.Equal(t, 20, *stub.list.PageSize)
+	})
+
+	t.Run("正常系: 登録者はメタデータのユーザー", func(t *testing.T) {
+		item, err := client.CreateItem(ctx, &itempb.CreateItemRequest{Name: "ケリー", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-01", Currency: "USD"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), item.GetId())
+		assert.Equal(t, "USD", item.GetCurrency())
+		assert.Equal(t, "alice", stub.created.OwnerID)
+		assert.Equal(t, entity.MinorUnits(2000000), stub.created.PurchasePrice)
+		assert.Equal(t, "USD", stub.created.Currency)
+	})
+
+	t.Run("正常系: 部分更新と属性の削除", func(t *testing.T) {
+		name := "デイトナ"
+		item, err := client.PatchItem(ctx, &itempb.PatchItemRequest{Id: 1, Name: &name, Version: 3,
+			Attributes: map[string]string{"storage_box": "A-1"}, RemoveAttributes: []string{"serial"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), item.GetVersion())
+		assert.Equal(t, "デイトナ", *stub.patched.Name)
+		assert.Equal(t, int64(3), *stub.patched.Version)
+		assert.Equal(t, "A-1", *stub.patched.Attributes["storage_box"])
+		assert.Nil(t, stub.patched.Attributes["serial"])
+		assert.Contains(t, stub.patched.Attributes, "serial")
+	})
+
+	t.Run("異常系: バージョンの競合は Aborted", func(t *testing.T) {
+		stub.err = domainErrors.ErrConflict
+		defer func() { stub.err = nil }()
+
+		_, err := client.PatchItem(ctx, &itempb.PatchItemRequest{Id: 1, Version: 1})
+		assert.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("正常系: カテゴリー別集計", func(t *testing.T) {
+		summary, err := client.GetSummary(ctx, &itempb.GetSummaryRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), summary.GetTotal())
+		assert.Equal(t, int32(2), summary.GetCategories()["時計"])
+	})
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{domainErrors.ErrItemNotFound, codes.NotFound},
+		{domainErrors.ErrInvalidInput, codes.InvalidArgument},
+		{domainErrors.ErrPreconditionFailed, codes.FailedPrecondition},
+		{domainErrors.ErrConflict, codes.Aborted},
+		{domainErrors.ErrForbidden, codes.PermissionDenied},
+		{domainErrors.ErrDatabaseError, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, status.Code(toStatus(tt.err, "failed")))
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package mocks provides testify mocks of the usecase interfaces for tests of the packages that use them
+// (controllers, servers). The mocks are generated with mockery; run go generate after changing an interface.
+//
+// A mock is created with its New function, which asserts the expectations when the test ends:
+//
+//	items := mocks.NewItemUsecase(t)
+//	items.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+package mocks
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --dir ../usecase --name ItemUsecase --outpkg mocks --filename item_usecase.go --output .
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --dir ../usecase --name ItemRepository --outpkg mocks --filename item_repository.go --output .
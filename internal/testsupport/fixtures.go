@@ -0,0 +1,100 @@
+// Package testsupport helps integration tests exercise the item API without a database or hand-written mocks.
+//
+// It provides an item fixture builder with valid defaults, the in-memory item repository,
+// and an HTTP test server that serves the item API on top of that repository:
+//
+//	srv := testsupport.NewServer(t, testsupport.WithItems(
+//		testsupport.NewItem(testsupport.WithName("オメガ スピードマスター"), testsupport.WithPurchasePrice(800000)),
+//	))
+//	resp, err := http.Get(srv.URL + "/items/1")
+package testsupport
+
+import (
+	"maps"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+)
+
+// Default values of NewItem; they pass entity validation
+const (
+	DefaultName          = "ロレックス デイトナ"
+	DefaultCategory      = "時計"
+	DefaultBrand         = "ROLEX"
+	DefaultPurchasePrice = 1500000
+	DefaultPurchaseDate  = "2023-01-15"
+)
+
+// ItemOption changes a field of an item built by NewItem
+type ItemOption func(*entity.Item)
+
+// NewItem builds an item with valid defaults, changed by opts.
+// The ID is left at 0 so a repository assigns one; options are not validated, so they can also build invalid items.
+func NewItem(opts ...ItemOption) *entity.Item {
+	now := time.Now()
+	item := &entity.Item{
+		Name:          DefaultName,
+		Category:      DefaultCategory,
+		Brand:         DefaultBrand,
+		PurchasePrice: DefaultPurchasePrice,
+		PurchaseDate:  DefaultPurchaseDate,
+		Version:       1,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	for _, opt := range opts {
+		opt(item)
+	}
+	return item
+}
+
+// WithID sets the item ID
+func WithID(id int64) ItemOption {
+	return func(item *entity.Item) { item.ID = id }
+}
+
+// WithName sets the item name
+func WithName(name string) ItemOption {
+	return func(item *entity.Item) { item.Name = name }
+}
+
+// WithCategory sets the item category
+func WithCategory(category string) ItemOption {
+	return func(item *entity.Item) { item.Category = category }
+}
+
+// WithBrand sets the item brand
+func WithBrand(brand string) ItemOption {
+	return func(item *entity.Item) { item.Brand = brand }
+}
+
+// WithPurchasePrice sets the purchase price in yen
+func WithPurchasePrice(price int) ItemOption {
+	return func(item *entity.Item) { item.PurchasePrice = price }
+}
+
+// WithPurchaseDate sets the purchase date (YYYY-MM-DD)
+func WithPurchaseDate(date string) ItemOption {
+	return func(item *entity.Item) { item.PurchaseDate = date }
+}
+
+// WithOwner sets the owner's user ID
+func WithOwner(ownerID string) ItemOption {
+	return func(item *entity.Item) { item.OwnerID = ownerID }
+}
+
+// WithAttributes sets custom attribute values, replacing any set before
+func WithAttributes(attrs map[string]string) ItemOption {
+	return func(item *entity.Item) { item.Attributes = maps.Clone(attrs) }
+}
+
+// WithVersion sets the optimistic locking version
+func WithVersion(version int64) ItemOption {
+	return func(item *entity.Item) { item.Version = version }
+}
+
+// NewItemRepository returns an in-memory item repository seeded with items; items without an ID get one assigned
+func NewItemRepository(items ...*entity.Item) *memory.ItemRepository {
+	return memory.NewItemRepository(items...)
+}
@@ -0,0 +1,89 @@
+package testsupport
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/router"
+	"Aicon-assignment/internal/infrastructure/undotoken"
+	"Aicon-assignment/internal/interfaces/controller/apiversion"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/validator"
+	"Aicon-assignment/internal/interfaces/repository/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+// UndoDeleteWindow is how long the undo token of a deletion made through a Server stays valid
+const UndoDeleteWindow = 30 * time.Second
+
+// Server serves the item API on an in-memory repository.
+// It handles errors, binding and validation like the production server, without its other middleware
+// (access log, rate limit, timeouts, sandbox).
+type Server struct {
+	*httptest.Server
+
+	// Items is the repository behind the API; tests can seed it or inspect what requests stored
+	Items *memory.ItemRepository
+	// Echo is the server's router; tests can register additional routes on it
+	Echo *echo.Echo
+}
+
+type serverOptions struct {
+	items []*entity.Item
+}
+
+// ServerOption configures a Server created by NewServer
+type ServerOption func(*serverOptions)
+
+// WithItems seeds the server's repository with items; items without an ID get one assigned
+func WithItems(items ...*entity.Item) ServerOption {
+	return func(o *serverOptions) { o.items = append(o.items, items...) }
+}
+
+// NewServer starts a server with the item routes registered unprefixed and under /v1 and /v2,
+// like the production server. It is closed when the test finishes.
+//
+// Registered routes: GET/HEAD/POST /items, GET/HEAD/PATCH/DELETE /items/:id, GET /items/summary and POST /items/undo-delete.
+func NewServer(t testing.TB, opts ...ServerOption) *Server {
+	t.Helper()
+
+	var o serverOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	repo := memory.NewItemRepository(o.items...)
+	items := usecase.NewDuplicateCheckingItemUsecase(usecase.NewItemUsecase(repo, nil, nil, nil, nil), repo)
+	undo := usecase.NewUndoDeleteUsecase(items, repo, undotoken.NewSigner([]byte("testsupport")), UndoDeleteWindow)
+	handler := itemController.NewItemHandler(items, undo)
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HTTPErrorHandler = itemController.HTTPErrorHandler
+	e.Binder = &itemController.Binder{}
+	e.Validator = validator.New()
+	e.Use(apiversion.Middleware())
+
+	api := router.New()
+	g := api.Group("/items")
+	g.GET("", handler.GetItems)
+	g.HEAD("", itemController.HeadHandler(handler.GetItems))
+	g.POST("", handler.CreateItem)
+	g.GET("/:id", handler.GetItem)
+	g.HEAD("/:id", itemController.HeadHandler(handler.GetItem))
+	g.PATCH("/:id", handler.PatchItem)
+	g.DELETE("/:id", handler.DeleteItem)
+	g.GET("/summary", handler.GetSummary)
+	g.POST("/undo-delete", handler.UndoDelete)
+	api.Mount(e.Group(""))
+	for _, v := range apiversion.Supported() {
+		api.Mount(e.Group(v.Prefix()))
+	}
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return &Server{Server: srv, Items: repo, Echo: e}
+}
@@ -0,0 +1,83 @@
+package testsupport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestNewItem(t *testing.T) {
+	t.Run("正常系: デフォルト値は検証を通る", func(t *testing.T) {
+		assert.NoError(t, NewItem().Validate())
+	})
+
+	t.Run("正常系: オプションで変更する", func(t *testing.T) {
+		attrs := map[string]string{"serial": "A1"}
+		item := NewItem(WithID(7), WithName("バーキン"), WithCategory("バッグ"), WithBrand("HERMES"),
+			WithPurchasePrice(2000000), WithPurchaseDate("2022-05-01"), WithOwner("alice"), WithAttributes(attrs))
+		attrs["serial"] = "changed"
+
+		assert.Equal(t, int64(7), item.ID)
+		assert.Equal(t, "バーキン", item.Name)
+		assert.Equal(t, "バッグ", item.Category)
+		assert.Equal(t, "HERMES", item.Brand)
+		assert.Equal(t, 2000000, item.PurchasePrice)
+		assert.Equal(t, "2022-05-01", item.PurchaseDate)
+		assert.Equal(t, "alice", item.OwnerID)
+		assert.Equal(t, map[string]string{"serial": "A1"}, item.Attributes)
+	})
+
+	t.Run("異常系: 不正な値のアイテムも作れる", func(t *testing.T) {
+		assert.Error(t, NewItem(WithCategory("家具")).Validate())
+	})
+}
+
+func TestNewServer(t *testing.T) {
+	srv := NewServer(t, WithItems(NewItem(), NewItem(WithName("バーキン"), WithCategory("バッグ"), WithBrand("HERMES"))))
+
+	t.Run("正常系: 登録済みのアイテムを取得する", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/v1/items/2")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var item entity.Item
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&item))
+		assert.Equal(t, "バーキン", item.Name)
+	})
+
+	t.Run("正常系: 登録したアイテムはリポジトリに保存される", func(t *testing.T) {
+		body := `{"name":"スピードマスター","category":"時計","brand":"OMEGA","purchase_price":800000,"purchase_date":"2023-06-01"}`
+		resp, err := http.Post(srv.URL+"/items", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		stored, err := srv.Items.FindByID(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, "スピードマスター", stored.Name)
+	})
+
+	t.Run("異常系: 検証エラーは本番と同じ400", func(t *testing.T) {
+		resp, err := http.Post(srv.URL+"/items", "application/json", strings.NewReader(`{"name":""}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/items/99")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
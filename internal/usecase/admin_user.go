@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// maxAdminNameLength is the longest admin account name
+const maxAdminNameLength = 50
+
+// AdminUserUsecase manages the accounts that sign in to the admin server with HTTP basic authentication.
+// Passwords are stored as bcrypt hashes only.
+type AdminUserUsecase interface {
+	// CreateAdmin creates an account; a name that is already taken is ErrConflict
+	CreateAdmin(ctx context.Context, name, password string) (*entity.AdminUser, error)
+	// Authenticate reports whether the name and password belong to an account
+	Authenticate(ctx context.Context, name, password string) (bool, error)
+	// HasAdmins reports whether any account exists
+	HasAdmins(ctx context.Context) (bool, error)
+}
+
+type adminUserUsecase struct {
+	repo AdminUserRepository
+	cost int
+	now  func() time.Time
+}
+
+func NewAdminUserUsecase(repo AdminUserRepository) AdminUserUsecase {
+	return &adminUserUsecase{
+		repo: repo,
+		cost: bcrypt.DefaultCost,
+		now:  time.Now,
+	}
+}
+
+func (u *adminUserUsecase) CreateAdmin(ctx context.Context, name, password string) (*entity.AdminUser, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput)
+	case utf8.RuneCountInString(name) > maxAdminNameLength:
+		return nil, fmt.Errorf("%w: name must be at most %d characters", domainErrors.ErrInvalidInput, maxAdminNameLength)
+	case strings.Contains(name, ":"):
+		// Basic 認証では ":" が名前とパスワードの区切りになる
+		return nil, fmt.Errorf("%w: name must not contain ':'", domainErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(password) < minPassphraseLength {
+		return nil, fmt.Errorf("%w: password must be at least %d characters", domainErrors.ErrInvalidInput, minPassphraseLength)
+	}
+
+	if _, err := u.repo.FindByName(ctx, name); err == nil {
+		return nil, domainErrors.Conflict("admin user %q already exists", name)
+	} else if !domainErrors.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to retrieve admin user: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), u.cost)
+	if err != nil {
+		// bcrypt は72バイトまでしか扱えない
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return nil, fmt.Errorf("%w: password must be at most 72 bytes", domainErrors.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := u.repo.Create(ctx, &entity.AdminUser{
+		Name:         name,
+		PasswordHash: string(hash),
+		CreatedAt:    u.now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admin user: %w", err)
+	}
+	return user, nil
+}
+
+func (u *adminUserUsecase) Authenticate(ctx context.Context, name, password string) (bool, error) {
+	user, err := u.repo.FindByName(ReadOnly(ctx), strings.TrimSpace(name))
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to retrieve admin user: %w", err)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil, nil
+}
+
+func (u *adminUserUsecase) HasAdmins(ctx context.Context) (bool, error) {
+	n, err := u.repo.Count(ReadOnly(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to count admin users: %w", err)
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeAdminUserRepository は名前の大文字・小文字を区別せずにアカウントを保持する
+type fakeAdminUserRepository struct {
+	users []*entity.AdminUser
+}
+
+func (r *fakeAdminUserRepository) Create(ctx context.Context, user *entity.AdminUser) (*entity.AdminUser, error) {
+	created := *user
+	created.ID = int64(len(r.users) + 1)
+	r.users = append(r.users, &created)
+	return &created, nil
+}
+
+func (r *fakeAdminUserRepository) FindByName(ctx context.Context, name string) (*entity.AdminUser, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Name, name) {
+			return user, nil
+		}
+	}
+	return nil, domainErrors.ErrAdminUserNotFound
+}
+
+func (r *fakeAdminUserRepository) Count(ctx context.Context) (int, error) {
+	return len(r.users), nil
+}
+
+func newTestAdminUserUsecase(repo AdminUserRepository) *adminUserUsecase {
+	return &adminUserUsecase{
+		repo: repo,
+		cost: bcrypt.MinCost,
+		now:  func() time.Time { return time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestAdminUserUsecase_CreateAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: パスワードはハッシュだけを保存する", func(t *testing.T) {
+		repo := &fakeAdminUserRepository{}
+		u := newTestAdminUserUsecase(repo)
+
+		user, err := u.CreateAdmin(ctx, " ops ", "correct horse battery")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), user.ID)
+		assert.Equal(t, "ops", user.Name)
+		assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), user.CreatedAt)
+		assert.NotContains(t, user.PasswordHash, "correct horse")
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("correct horse battery")))
+	})
+
+	t.Run("異常系: 同じ名前のアカウントがある", func(t *testing.T) {
+		repo := &fakeAdminUserRepository{}
+		u := newTestAdminUserUsecase(repo)
+		_, err := u.CreateAdmin(ctx, "ops", "correct horse battery")
+		require.NoError(t, err)
+
+		_, err = u.CreateAdmin(ctx, "OPS", "another long password")
+
+		assert.ErrorIs(t, err, domainErrors.ErrConflict)
+		assert.Len(t, repo.users, 1)
+	})
+
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		expected string
+	}{
+		{name: "異常系: 名前が空", user: " ", password: "correct horse battery", expected: "name is required"},
+		{name: "異常系: 名前にコロンを含む", user: "ops:1", password: "correct horse battery", expected: "must not contain ':'"},
+		{name: "異常系: 名前が長すぎる", user: strings.Repeat("a", 51), password: "correct horse battery", expected: "at most 50 characters"},
+		{name: "異常系: パスワードが短い", user: "ops", password: "short", expected: "at least 12 characters"},
+		{name: "異常系: パスワードが長すぎる", user: "ops", password: strings.Repeat("a", 73), expected: "at most 72 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAdminUserRepository{}
+			u := newTestAdminUserUsecase(repo)
+
+			_, err := u.CreateAdmin(ctx, tt.user, tt.password)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.ErrorContains(t, err, tt.expected)
+			assert.Empty(t, repo.users)
+		})
+	}
+}
+
+func TestAdminUserUsecase_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeAdminUserRepository{}
+	u := newTestAdminUserUsecase(repo)
+
+	has, err := u.HasAdmins(ctx)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = u.CreateAdmin(ctx, "ops", "correct horse battery")
+	require.NoError(t, err)
+	has, err = u.HasAdmins(ctx)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	tests := []struct {
+		name     string
+		user     string
+		password string
+		expected bool
+	}{
+		{name: "正常系: 名前とパスワードが一致する", user: "ops", password: "correct horse battery", expected: true},
+		{name: "正常系: 名前は大文字・小文字を区別しない", user: "Ops", password: "correct horse battery", expected: true},
+		{name: "異常系: パスワードが違う", user: "ops", password: "wrong horse battery", expected: false},
+		{name: "異常系: アカウントがない", user: "someone", password: "correct horse battery", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := u.Authenticate(ctx, tt.user, tt.password)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// backupExtension is appended to the backup ID to name its file in the storage
+const backupExtension = ".backup"
+
+// Backup IDs are the creation time followed by a random suffix, so they sort by creation time
+var backupIDPattern = regexp.MustCompile(`^\d{8}T\d{6}Z-[0-9a-f]{8}$`)
+
+// DatabaseSnapshotter reads and replaces the whole contents of the database
+type DatabaseSnapshotter interface {
+	// Snapshot returns the contents of every table
+	Snapshot(ctx context.Context) ([]byte, error)
+
+	// Restore replaces the contents of every table with those of a snapshot and returns the number of rows restored
+	Restore(ctx context.Context, snapshot []byte) (int, error)
+}
+
+// BackupEncrypter protects backups with a key given by the operator
+type BackupEncrypter interface {
+	PackageEncrypter
+	Decrypt(data []byte, passphrase string) ([]byte, error)
+}
+
+// BackupUsecase backs up all the data to a storage and restores it, so that operators need no access to the database
+type BackupUsecase interface {
+	// CreateBackup stores an encrypted snapshot of all the data
+	CreateBackup(ctx context.Context, key string) (*entity.Backup, error)
+
+	// ListBackups returns the stored backups, newest first
+	ListBackups(ctx context.Context) ([]*entity.Backup, error)
+
+	// RestoreBackup replaces all the data with that of a backup
+	RestoreBackup(ctx context.Context, id, key string) (*BackupRestore, error)
+}
+
+// BackupRestore is the result of restoring a backup
+type BackupRestore struct {
+	ID   string `json:"id"`
+	Rows int    `json:"rows"`
+}
+
+type backupUsecase struct {
+	snapshotter DatabaseSnapshotter
+	storage     Storage
+	encrypter   BackupEncrypter
+	uow         UnitOfWork
+	now         func() time.Time
+}
+
+// NewBackupUsecase creates the usecase. Snapshots are taken and restored in a transaction of uow,
+// so a snapshot is consistent and a failed restore leaves the data as it was.
+func NewBackupUsecase(snapshotter DatabaseSnapshotter, storage Storage, encrypter BackupEncrypter, uow UnitOfWork) BackupUsecase {
+	return &backupUsecase{
+		snapshotter: snapshotter,
+		storage:     storage,
+		encrypter:   encrypter,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+// CreateBackup holds the snapshot in memory until it has been encrypted and stored; the key is not stored
+func (u *backupUsecase) CreateBackup(ctx context.Context, key string) (*entity.Backup, error) {
+	if err := checkBackupKey(key); err != nil {
+		return nil, err
+	}
+
+	var snapshot []byte
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		var err error
+		snapshot, err = u.snapshotter.Snapshot(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	encrypted, err := u.encrypter.Encrypt(snapshot, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+	}
+
+	suffix, err := newJobID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup ID: %w", err)
+	}
+	createdAt := u.now().UTC().Truncate(time.Second)
+	backup := &entity.Backup{
+		ID:        createdAt.Format("20060102T150405Z") + "-" + suffix[:8],
+		Size:      int64(len(encrypted)),
+		CreatedAt: createdAt,
+	}
+
+	if err := u.storage.Put(ctx, backup.ID+backupExtension, bytes.NewReader(encrypted), backup.Size, "application/octet-stream"); err != nil {
+		return nil, fmt.Errorf("failed to store backup: %w", err)
+	}
+	return backup, nil
+}
+
+func (u *backupUsecase) ListBackups(ctx context.Context) ([]*entity.Backup, error) {
+	backups := []*entity.Backup{}
+	err := u.storage.List(ctx, func(object StoredObject) error {
+		id, ok := strings.CutSuffix(object.Key, backupExtension)
+		if !ok || !backupIDPattern.MatchString(id) {
+			return nil
+		}
+		createdAt, err := time.Parse("20060102T150405Z", id[:16])
+		if err != nil {
+			return nil
+		}
+		backups = append(backups, &entity.Backup{ID: id, CreatedAt: createdAt})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ID > backups[j].ID })
+	return backups, nil
+}
+
+// RestoreBackup reports a wrong key as ErrInvalidInput; nothing is changed unless the backup can be decrypted
+func (u *backupUsecase) RestoreBackup(ctx context.Context, id, key string) (*BackupRestore, error) {
+	// The ID becomes a file name, so only IDs of the generated form are looked up
+	if !backupIDPattern.MatchString(id) {
+		return nil, domainErrors.ErrBackupNotFound
+	}
+
+	file, err := u.storage.Open(ctx, id+backupExtension)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrBackupNotFound
+		}
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	encrypted, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	snapshot, err := u.encrypter.Decrypt(encrypted, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: backup cannot be decrypted with the key", domainErrors.ErrInvalidInput)
+	}
+
+	restore := &BackupRestore{ID: id}
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		var err error
+		restore.Rows, err = u.snapshotter.Restore(ctx, snapshot)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
+	}
+	return restore, nil
+}
+
+// checkBackupKey applies the same minimum length as the passphrases of estate packages
+func checkBackupKey(key string) error {
+	if utf8.RuneCountInString(key) < minPassphraseLength {
+		return fmt.Errorf("%w: key must be at least %d characters", domainErrors.ErrInvalidInput, minPassphraseLength)
+	}
+	return nil
+}
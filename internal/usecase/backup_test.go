@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeSnapshotter は1つのスナップショットを保持する
+type fakeSnapshotter struct {
+	data       []byte
+	restored   []byte
+	restoreErr error
+}
+
+func (s *fakeSnapshotter) Snapshot(ctx context.Context) ([]byte, error) {
+	return s.data, nil
+}
+
+func (s *fakeSnapshotter) Restore(ctx context.Context, snapshot []byte) (int, error) {
+	if s.restoreErr != nil {
+		return 0, s.restoreErr
+	}
+	s.restored = snapshot
+	return 3, nil
+}
+
+// xorEncrypter は鍵の先頭バイトとの XOR で暗号化し、先頭に鍵を付けて鍵違いを検出する
+type xorEncrypter struct{}
+
+func (xorEncrypter) Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	out := append([]byte(passphrase), plaintext...)
+	for i := len(passphrase); i < len(out); i++ {
+		out[i] ^= passphrase[0]
+	}
+	return out, nil
+}
+
+func (xorEncrypter) Decrypt(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(passphrase)) {
+		return nil, errors.New("wrong passphrase")
+	}
+	out := bytes.Clone(data[len(passphrase):])
+	for i := range out {
+		out[i] ^= passphrase[0]
+	}
+	return out, nil
+}
+
+func TestBackupUsecase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 1, 9, 30, 15, 500, time.UTC)
+	key := "correct horse battery"
+
+	newUsecase := func(snapshotter *fakeSnapshotter, storage *memoryStorage) *backupUsecase {
+		u := NewBackupUsecase(snapshotter, storage, xorEncrypter{}, nil).(*backupUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+
+	t.Run("正常系: 暗号化したスナップショットを保存して復元する", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{data: []byte(`{"tables":[]}`)}
+		storage := newMemoryStorage()
+		u := newUsecase(snapshotter, storage)
+
+		backup, err := u.CreateBackup(ctx, key)
+		require.NoError(t, err)
+		assert.Regexp(t, `^20240301T093015Z-[0-9a-f]{8}$`, backup.ID)
+		assert.Equal(t, now.Truncate(time.Second), backup.CreatedAt)
+		stored := storage.files[backup.ID+".backup"]
+		assert.Equal(t, int64(len(stored)), backup.Size)
+		assert.NotContains(t, string(stored), `"tables"`)
+
+		restore, err := u.RestoreBackup(ctx, backup.ID, key)
+		require.NoError(t, err)
+		assert.Equal(t, &BackupRestore{ID: backup.ID, Rows: 3}, restore)
+		assert.Equal(t, snapshotter.data, snapshotter.restored)
+	})
+
+	t.Run("正常系: 一覧は新しい順でバックアップ以外のファイルを含まない", func(t *testing.T) {
+		storage := newMemoryStorage()
+		storage.files["20240301T093015Z-0123abcd.backup"] = []byte("a")
+		storage.files["20240302T000000Z-89abcdef.backup"] = []byte("b")
+		storage.files["notes.txt"] = []byte("c")
+		storage.files["../etc.backup"] = []byte("d")
+
+		backups, err := newUsecase(&fakeSnapshotter{}, storage).ListBackups(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.Backup{
+			{ID: "20240302T000000Z-89abcdef", CreatedAt: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: "20240301T093015Z-0123abcd", CreatedAt: time.Date(2024, 3, 1, 9, 30, 15, 0, time.UTC)},
+		}, backups)
+	})
+
+	t.Run("正常系: バックアップがなければ空の一覧", func(t *testing.T) {
+		backups, err := newUsecase(&fakeSnapshotter{}, newMemoryStorage()).ListBackups(ctx)
+
+		require.NoError(t, err)
+		assert.Empty(t, backups)
+		assert.NotNil(t, backups)
+	})
+
+	t.Run("異常系: 短い鍵では作成しない", func(t *testing.T) {
+		storage := newMemoryStorage()
+
+		_, err := newUsecase(&fakeSnapshotter{}, storage).CreateBackup(ctx, "short")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, storage.files)
+	})
+
+	t.Run("異常系: 鍵が違えば復元しない", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{data: []byte(`{"tables":[]}`)}
+		u := newUsecase(snapshotter, newMemoryStorage())
+		backup, err := u.CreateBackup(ctx, key)
+		require.NoError(t, err)
+
+		_, err = u.RestoreBackup(ctx, backup.ID, "wrong key for backup")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, snapshotter.restored)
+	})
+
+	t.Run("異常系: 存在しないバックアップ", func(t *testing.T) {
+		u := newUsecase(&fakeSnapshotter{}, newMemoryStorage())
+
+		_, err := u.RestoreBackup(ctx, "20240301T093015Z-0123abcd", key)
+		assert.ErrorIs(t, err, domainErrors.ErrBackupNotFound)
+
+		// 生成した形式以外のIDはファイル名として使わない
+		_, err = u.RestoreBackup(ctx, "../secrets", key)
+		assert.ErrorIs(t, err, domainErrors.ErrBackupNotFound)
+	})
+
+	t.Run("異常系: 復元の失敗", func(t *testing.T) {
+		snapshotter := &fakeSnapshotter{data: []byte(`{"tables":[]}`), restoreErr: errors.New("db down")}
+		u := newUsecase(snapshotter, newMemoryStorage())
+		backup, err := u.CreateBackup(ctx, key)
+		require.NoError(t, err)
+
+		_, err = u.RestoreBackup(ctx, backup.ID, key)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// defaultSuggestions is the number of brand or item name suggestions returned when no limit is given
+	defaultSuggestions = 10
+	// maxSuggestions is the largest limit accepted for suggestions
+	maxSuggestions = 50
+)
+
+// BrandUsecase manages the canonical brand names and their aliases, and suggests brands for autocompletion.
+// Names match regardless of case, width, spaces and punctuation ("rolex", "ＲＯＬＥＸ" and "ROLEX" are the same).
+type BrandUsecase interface {
+	ListBrands(ctx context.Context) ([]*entity.Brand, error)
+	// CreateBrand adds a brand; its name and aliases may not match the name or an alias of another brand
+	CreateBrand(ctx context.Context, input BrandInput) (*entity.Brand, error)
+	DeleteBrand(ctx context.Context, id int64) error
+	// SuggestBrands returns the brands starting with the query, among the registered brands and the brands of items,
+	// the brands with the most items first
+	SuggestBrands(ctx context.Context, query BrandSuggestQuery) ([]BrandSuggestion, error)
+}
+
+type BrandInput struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// BrandSuggestQuery is the start of a brand name or alias, and the number of suggestions to return
+type BrandSuggestQuery struct {
+	Text  string
+	Limit int
+}
+
+// BrandSuggestion is a brand, under its canonical name when it is registered, with the number of its items
+type BrandSuggestion struct {
+	Name      string `json:"name"`
+	ItemCount int    `json:"item_count"`
+}
+
+type brandUsecase struct {
+	itemRepo  ItemReader
+	brandRepo BrandRepository
+	uow       UnitOfWork
+	now       func() time.Time
+}
+
+// NewBrandUsecase creates the brand usecase; uow may be nil, in which case no transactions are used
+func NewBrandUsecase(itemRepo ItemReader, brandRepo BrandRepository, uow UnitOfWork) BrandUsecase {
+	return &brandUsecase{
+		itemRepo:  itemRepo,
+		brandRepo: brandRepo,
+		uow:       uow,
+		now:       time.Now,
+	}
+}
+
+func (u *brandUsecase) ListBrands(ctx context.Context) ([]*entity.Brand, error) {
+	brands, err := u.brandRepo.FindAll(ReadOnly(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brands: %w", err)
+	}
+
+	if brands == nil {
+		brands = []*entity.Brand{}
+	}
+
+	return brands, nil
+}
+
+func (u *brandUsecase) CreateBrand(ctx context.Context, input BrandInput) (*entity.Brand, error) {
+	brand, err := entity.NewBrand(input.Name, input.Aliases, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+	for _, name := range brand.Names() {
+		if normalizeForMatch(name) == "" {
+			return nil, fmt.Errorf("%w: brand names must contain a letter or digit: %q", domainErrors.ErrInvalidInput, name)
+		}
+	}
+
+	var created *entity.Brand
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		index, err := loadBrandIndex(ctx, u.brandRepo)
+		if err != nil {
+			return err
+		}
+		for _, name := range brand.Names() {
+			if existing, ok := index.lookup(name); ok {
+				return domainErrors.Conflict("%q is already a name of brand %q", name, existing)
+			}
+		}
+
+		created, err = u.brandRepo.Create(ctx, brand)
+		if err != nil {
+			return fmt.Errorf("failed to create brand: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// DeleteBrand leaves the items of the brand unchanged; their brand is no longer normalized
+func (u *brandUsecase) DeleteBrand(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.brandRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrBrandNotFound
+		}
+		return fmt.Errorf("failed to delete brand: %w", err)
+	}
+
+	return nil
+}
+
+func (u *brandUsecase) SuggestBrands(ctx context.Context, query BrandSuggestQuery) ([]BrandSuggestion, error) {
+	text := strings.TrimSpace(query.Text)
+	var errs []string
+	if text == "" {
+		errs = append(errs, "q is required")
+	} else if len(text) > maxBrandLength {
+		errs = append(errs, fmt.Sprintf("q must be %d characters or less", maxBrandLength))
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSuggestions
+	}
+	if limit < 1 || limit > maxSuggestions {
+		errs = append(errs, fmt.Sprintf("limit must be between 1 and %d", maxSuggestions))
+	}
+	if len(errs) > 0 {
+		return nil, domainErrors.NewValidationError(errs...)
+	}
+
+	prefix := normalizeForMatch(text)
+	if prefix == "" {
+		return []BrandSuggestion{}, nil
+	}
+
+	ctx = ReadOnly(ctx)
+	brands, err := u.brandRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brands: %w", err)
+	}
+	totals, err := u.itemRepo.GetSummaryByBrand(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize items by brand: %w", err)
+	}
+
+	// Registered brands match by their name or any alias, and count the items under any of them
+	index := newBrandIndex(brands)
+	matched := make(map[string]bool)
+	counts := make(map[string]int)
+	for _, brand := range brands {
+		for _, name := range brand.Names() {
+			if strings.HasPrefix(normalizeForMatch(name), prefix) {
+				matched[brand.Name] = true
+			}
+		}
+	}
+	for name, total := range totals {
+		canonical, ok := index.lookup(name)
+		if !ok {
+			canonical = name
+			if strings.HasPrefix(normalizeForMatch(name), prefix) {
+				matched[name] = true
+			}
+		}
+		counts[canonical] += total.Count
+	}
+
+	suggestions := make([]BrandSuggestion, 0, len(matched))
+	for name := range matched {
+		suggestions = append(suggestions, BrandSuggestion{Name: name, ItemCount: counts[name]})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].ItemCount != suggestions[j].ItemCount {
+			return suggestions[i].ItemCount > suggestions[j].ItemCount
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// brandIndex maps the normalized names and aliases of the registered brands to their canonical names
+type brandIndex map[string]string
+
+func newBrandIndex(brands []*entity.Brand) brandIndex {
+	index := make(brandIndex)
+	for _, brand := range brands {
+		for _, name := range brand.Names() {
+			if key := normalizeForMatch(name); key != "" {
+				index[key] = brand.Name
+			}
+		}
+	}
+	return index
+}
+
+// loadBrandIndex reads the registered brands (none when no repository is configured)
+func loadBrandIndex(ctx context.Context, repo BrandRepository) (brandIndex, error) {
+	if repo == nil {
+		return brandIndex{}, nil
+	}
+
+	brands, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brands: %w", err)
+	}
+
+	return newBrandIndex(brands), nil
+}
+
+// lookup returns the canonical name of the brand that name is the name or an alias of
+func (index brandIndex) lookup(name string) (string, bool) {
+	key := normalizeForMatch(name)
+	if key == "" {
+		return "", false
+	}
+	canonical, ok := index[key]
+	return canonical, ok
+}
+
+// canonical returns the canonical name for brand, or brand itself when it is not registered
+func (index brandIndex) canonical(brand string) string {
+	if canonical, ok := index.lookup(brand); ok {
+		return canonical
+	}
+	return brand
+}
+
+type brandNormalizingItemUsecase struct {
+	ItemUsecase
+	brandRepo BrandRepository
+}
+
+// NewBrandNormalizingItemUsecase replaces the brand of created and patched items, and of the ?brand= filter,
+// with the canonical name of the registered brand it is the name or an alias of ("ロレックス" becomes "ROLEX").
+// Brands that are not registered are kept as given.
+func NewBrandNormalizingItemUsecase(inner ItemUsecase, brandRepo BrandRepository) ItemUsecase {
+	return &brandNormalizingItemUsecase{
+		ItemUsecase: inner,
+		brandRepo:   brandRepo,
+	}
+}
+
+func (u *brandNormalizingItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	index, err := loadBrandIndex(ctx, u.brandRepo)
+	if err != nil {
+		return nil, err
+	}
+	input.Brand = index.canonical(input.Brand)
+
+	return u.ItemUsecase.CreateItem(ctx, input)
+}
+
+func (u *brandNormalizingItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	if req.Brand != nil {
+		index, err := loadBrandIndex(ctx, u.brandRepo)
+		if err != nil {
+			return nil, err
+		}
+		brand := index.canonical(*req.Brand)
+		normalized := *req
+		normalized.Brand = &brand
+		req = &normalized
+	}
+
+	return u.ItemUsecase.PatchItem(ctx, id, req)
+}
+
+func (u *brandNormalizingItemUsecase) ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error) {
+	if query.Brand != "" {
+		index, err := loadBrandIndex(ReadOnly(ctx), u.brandRepo)
+		if err != nil {
+			return nil, err
+		}
+		query.Brand = index.canonical(query.Brand)
+	}
+
+	return u.ItemUsecase.ListItems(ctx, query)
+}
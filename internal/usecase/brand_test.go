@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockBrandRepository はブランドリポジトリのモック
+type MockBrandRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandRepository) FindAll(ctx context.Context) ([]*entity.Brand, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	args := m.Called(ctx, brand)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// registeredBrands は ROLEX（別名 Rolex・ロレックス）と HERMÈS（別名 Hermes）を登録したブランドリポジトリ
+func registeredBrands() *MockBrandRepository {
+	repo := new(MockBrandRepository)
+	repo.On("FindAll", mock.Anything).Return([]*entity.Brand{
+		{ID: 1, Name: "HERMÈS", Aliases: []string{"Hermes"}},
+		{ID: 2, Name: "ROLEX", Aliases: []string{"Rolex", "ロレックス"}},
+	}, nil)
+	return repo
+}
+
+func TestBrandUsecase_CreateBrand(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		input       BrandInput
+		expected    *entity.Brand
+		expectedErr error
+	}{
+		{
+			name:     "正常系: 正式名と同じ別名・重複した別名は除く",
+			input:    BrandInput{Name: " OMEGA ", Aliases: []string{"オメガ", "OMEGA", "オメガ"}},
+			expected: &entity.Brand{Name: "OMEGA", Aliases: []string{"オメガ"}, CreatedAt: now, UpdatedAt: now},
+		},
+		{name: "異常系: 名前が空", input: BrandInput{Name: " "}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 文字も数字もない名前", input: BrandInput{Name: "OMEGA", Aliases: []string{"!!"}}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: ほかのブランドの名前（大文字・小文字の違い）", input: BrandInput{Name: "rolex"}, expectedErr: domainErrors.ErrConflict},
+		{name: "異常系: ほかのブランドの別名", input: BrandInput{Name: "OMEGA", Aliases: []string{"ＨＥＲＭＥＳ"}}, expectedErr: domainErrors.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := registeredBrands()
+			repo.On("Create", mock.Anything, mock.Anything).Return(&entity.Brand{ID: 3, Name: "OMEGA"}, nil)
+			u := NewBrandUsecase(new(MockItemRepository), repo, nil).(*brandUsecase)
+			u.now = func() time.Time { return now }
+
+			brand, err := u.CreateBrand(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), brand.ID)
+			repo.AssertCalled(t, "Create", mock.Anything, tt.expected)
+		})
+	}
+}
+
+func TestBrandUsecase_DeleteBrand(t *testing.T) {
+	repo := new(MockBrandRepository)
+	repo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	repo.On("Delete", mock.Anything, int64(9)).Return(domainErrors.ErrBrandNotFound)
+	u := NewBrandUsecase(new(MockItemRepository), repo, nil)
+
+	assert.NoError(t, u.DeleteBrand(context.Background(), 1))
+	assert.ErrorIs(t, u.DeleteBrand(context.Background(), 9), domainErrors.ErrBrandNotFound)
+	assert.ErrorIs(t, u.DeleteBrand(context.Background(), 0), domainErrors.ErrInvalidInput)
+}
+
+func TestBrandUsecase_SuggestBrands(t *testing.T) {
+	totals := map[string]*BrandTotals{
+		"ROLEX":        {Count: 2},
+		"ロレックス":        {Count: 1},
+		"Rodania":      {Count: 1},
+		"Roger Dubuis": {Count: 5},
+		"OMEGA":        {Count: 4},
+	}
+	tests := []struct {
+		name        string
+		query       BrandSuggestQuery
+		expected    []BrandSuggestion
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 別名のアイテムも正式名の件数に数え、件数の多い順",
+			query: BrandSuggestQuery{Text: "ro"},
+			expected: []BrandSuggestion{
+				{Name: "Roger Dubuis", ItemCount: 5},
+				{Name: "ROLEX", ItemCount: 3},
+				{Name: "Rodania", ItemCount: 1},
+			},
+		},
+		{
+			name:     "正常系: 別名で探すと正式名を返す",
+			query:    BrandSuggestQuery{Text: "ロレ"},
+			expected: []BrandSuggestion{{Name: "ROLEX", ItemCount: 3}},
+		},
+		{
+			name:     "正常系: アイテムのない登録済みのブランド",
+			query:    BrandSuggestQuery{Text: "Herm"},
+			expected: []BrandSuggestion{{Name: "HERMÈS", ItemCount: 0}},
+		},
+		{
+			name:     "正常系: 件数の上限",
+			query:    BrandSuggestQuery{Text: "RO", Limit: 1},
+			expected: []BrandSuggestion{{Name: "Roger Dubuis", ItemCount: 5}},
+		},
+		{
+			name:     "正常系: 一致しない",
+			query:    BrandSuggestQuery{Text: "zz"},
+			expected: []BrandSuggestion{},
+		},
+		{name: "異常系: q がない", query: BrandSuggestQuery{Text: " "}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 上限が大きすぎる", query: BrandSuggestQuery{Text: "ro", Limit: 51}, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("GetSummaryByBrand", mock.Anything).Return(totals, nil)
+
+			suggestions, err := NewBrandUsecase(itemRepo, registeredBrands(), nil).SuggestBrands(context.Background(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, suggestions)
+		})
+	}
+}
+
+func TestBrandNormalizingItemUsecase(t *testing.T) {
+	t.Run("正常系: 登録時に別名を正式名に置き換える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		u := NewBrandNormalizingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), registeredBrands())
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "デイトナ", Category: "時計", Brand: "ロレックス", PurchasePrice: entity.MinorUnits(1), PurchaseDate: "2023-01-15"})
+
+		require.NoError(t, err)
+		itemRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Brand == "ROLEX" }))
+	})
+
+	t.Run("正常系: 登録されていないブランドはそのまま", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		u := NewBrandNormalizingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), registeredBrands())
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "スピードマスター", Category: "時計", Brand: "omega", PurchasePrice: entity.MinorUnits(1), PurchaseDate: "2023-01-15"})
+
+		require.NoError(t, err)
+		itemRepo.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Brand == "omega" }))
+	})
+
+	t.Run("正常系: 更新時に大文字・小文字の違う名前を正式名に置き換える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 1, PurchaseDate: "2023-02-20", Version: 1}, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Version: 2}, nil)
+		u := NewBrandNormalizingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), registeredBrands())
+		brand, version := "ｈｅｒｍｅｓ", int64(1)
+		req := &UpdateItemRequest{Brand: &brand, Version: &version}
+
+		_, err := u.PatchItem(context.Background(), 1, req)
+
+		require.NoError(t, err)
+		itemRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Brand == "HERMÈS" }))
+		assert.Equal(t, "ｈｅｒｍｅｓ", *req.Brand)
+	})
+
+	t.Run("正常系: 絞り込みのブランドを正式名に置き換える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		query := ItemQuery{ItemFilter: ItemFilter{Brand: "ROLEX"}, SortBy: "created_at", SortOrder: "desc"}
+		itemRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{{ID: 1, Brand: "ROLEX"}}, nil)
+		u := NewBrandNormalizingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), registeredBrands())
+
+		list, err := u.ListItems(context.Background(), ListItemsQuery{Brand: "ロレックス"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total)
+	})
+}
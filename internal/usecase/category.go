@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CategoryUsecase manages the categories a tenant adds to the built-in ones (entity.ValidCategories).
+// Items of the tenant may use them like the built-in categories.
+type CategoryUsecase interface {
+	// ListCategories returns the categories the tenant added, ordered by name
+	ListCategories(ctx context.Context, tenantID string) ([]*entity.Category, error)
+	CreateCategory(ctx context.Context, tenantID string, input CategoryInput) (*entity.Category, error)
+	// DeleteCategory deletes a category the tenant added; it is refused while items are in the category
+	DeleteCategory(ctx context.Context, tenantID, name string) error
+}
+
+type CategoryInput struct {
+	Name string `json:"name"`
+}
+
+type categoryUsecase struct {
+	itemRepo     ItemReader
+	categoryRepo CategoryRepository
+	now          func() time.Time
+}
+
+func NewCategoryUsecase(itemRepo ItemReader, categoryRepo CategoryRepository) CategoryUsecase {
+	return &categoryUsecase{
+		itemRepo:     itemRepo,
+		categoryRepo: categoryRepo,
+		now:          time.Now,
+	}
+}
+
+func (u *categoryUsecase) ListCategories(ctx context.Context, tenantID string) ([]*entity.Category, error) {
+	categories, err := u.categoryRepo.FindAll(ReadOnly(ctx), tenantOrDefault(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	if categories == nil {
+		categories = []*entity.Category{}
+	}
+
+	return categories, nil
+}
+
+func (u *categoryUsecase) CreateCategory(ctx context.Context, tenantID string, input CategoryInput) (*entity.Category, error) {
+	category, err := entity.NewCategory(input.Name, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	existing, err := loadCustomCategories(ctx, u.categoryRepo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(existing, category.Name) {
+		return nil, domainErrors.Conflict("category %q already exists", category.Name)
+	}
+
+	created, err := u.categoryRepo.Create(ctx, tenantOrDefault(tenantID), category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return created, nil
+}
+
+// DeleteCategory counts the items of every tenant in the category, as items are not kept per tenant
+func (u *categoryUsecase) DeleteCategory(ctx context.Context, tenantID, name string) error {
+	existing, err := loadCustomCategories(ctx, u.categoryRepo, tenantID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(existing, name) {
+		return domainErrors.ErrCategoryNotFound
+	}
+
+	count, err := u.itemRepo.Count(ctx, ItemFilter{Category: name})
+	if err != nil {
+		return fmt.Errorf("failed to count items: %w", err)
+	}
+	if count > 0 {
+		return domainErrors.Conflict("category %q still has %d item(s); move them to another category first", name, count)
+	}
+
+	if err := u.categoryRepo.Delete(ctx, tenantOrDefault(tenantID), name); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrCategoryNotFound
+		}
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+// loadCustomCategories returns the names of the categories the tenant added (none when no repository is configured)
+func loadCustomCategories(ctx context.Context, repo CategoryRepository, tenantID string) ([]string, error) {
+	if repo == nil {
+		return nil, nil
+	}
+
+	categories, err := repo.FindAll(ctx, tenantOrDefault(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	return entity.CategoryNames(categories), nil
+}
+
+// customCategoriesFor returns the names of the tenant's categories unless category is a built-in one,
+// so that the built-in categories are checked without reading the repository
+func customCategoriesFor(ctx context.Context, repo CategoryRepository, tenantID, category string) ([]string, error) {
+	if entity.IsValidCategory(category) {
+		return nil, nil
+	}
+	return loadCustomCategories(ctx, repo, tenantID)
+}
+
+// checkCategory accepts an empty category (no filter), a built-in category or one the tenant added
+func checkCategory(ctx context.Context, repo CategoryRepository, tenantID, category string) error {
+	if category == "" {
+		return nil
+	}
+
+	custom, err := customCategoriesFor(ctx, repo, tenantID, category)
+	if err != nil {
+		return err
+	}
+	if !entity.IsValidCategory(category, custom...) {
+		return fmt.Errorf("%w: category must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(append(entity.GetValidCategories(), custom...), ", "))
+	}
+
+	return nil
+}
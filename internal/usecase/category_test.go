@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockCategoryRepository はカテゴリーリポジトリのモック
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FindAll(ctx context.Context, tenantID string) ([]*entity.Category, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindAllNames(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, tenantID string, category *entity.Category) (*entity.Category, error) {
+	args := m.Called(ctx, tenantID, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, tenantID, name string) error {
+	args := m.Called(ctx, tenantID, name)
+	return args.Error(0)
+}
+
+// furnitureCategories はテナント acme が「家具」「家電」を追加したカテゴリーリポジトリ
+func furnitureCategories() *MockCategoryRepository {
+	repo := new(MockCategoryRepository)
+	repo.On("FindAll", mock.Anything, "acme").Return([]*entity.Category{{Name: "家具"}, {Name: "家電"}}, nil)
+	repo.On("FindAll", mock.Anything, DefaultTenantID).Return(nil, nil)
+	return repo
+}
+
+func TestCategoryUsecase_CreateCategory(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		input       CategoryInput
+		expectedErr error
+	}{
+		{name: "正常系: カテゴリーを追加", input: CategoryInput{Name: " 楽器 "}},
+		{name: "異常系: 名前が空", input: CategoryInput{Name: ""}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 組み込みのカテゴリー", input: CategoryInput{Name: "時計"}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 追加済みのカテゴリー", input: CategoryInput{Name: "家具"}, expectedErr: domainErrors.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := furnitureCategories()
+			repo.On("Create", mock.Anything, "acme", &entity.Category{Name: "楽器", CreatedAt: now}).
+				Return(&entity.Category{Name: "楽器", CreatedAt: now}, nil)
+			u := NewCategoryUsecase(new(MockItemRepository), repo).(*categoryUsecase)
+			u.now = func() time.Time { return now }
+
+			category, err := u.CreateCategory(context.Background(), "acme", tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "楽器", category.Name)
+		})
+	}
+}
+
+func TestCategoryUsecase_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		category    string
+		itemCount   int
+		expectedErr error
+	}{
+		{name: "正常系: アイテムのないカテゴリーを削除", category: "家具"},
+		{name: "異常系: アイテムが残っている", category: "家具", itemCount: 2, expectedErr: domainErrors.ErrConflict},
+		{name: "異常系: 追加していないカテゴリー", category: "楽器", expectedErr: domainErrors.ErrCategoryNotFound},
+		{name: "異常系: 組み込みのカテゴリーは削除できない", category: "時計", expectedErr: domainErrors.ErrCategoryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := furnitureCategories()
+			repo.On("Delete", mock.Anything, "acme", tt.category).Return(nil)
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("Count", mock.Anything, ItemFilter{Category: tt.category}).Return(tt.itemCount, nil)
+
+			err := NewCategoryUsecase(itemRepo, repo).DeleteCategory(context.Background(), "acme", tt.category)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			repo.AssertCalled(t, "Delete", mock.Anything, "acme", tt.category)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_CustomCategory(t *testing.T) {
+	input := CreateItemInput{Name: "イス", Category: "家具", Brand: "IKEA", PurchasePrice: entity.MinorUnits(5000), PurchaseDate: "2023-02-01"}
+
+	t.Run("正常系: テナントが追加したカテゴリー", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Category == "家具" })).
+			Return(&entity.Item{ID: 1, Category: "家具"}, nil)
+		in := input
+		in.TenantID = "acme"
+
+		item, err := NewItemUsecase(itemRepo, nil, nil, furnitureCategories(), nil).CreateItem(context.Background(), in)
+
+		require.NoError(t, err)
+		assert.Equal(t, "家具", item.Category)
+	})
+
+	t.Run("異常系: ほかのテナントが追加したカテゴリー", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(itemRepo, nil, nil, furnitureCategories(), nil).CreateItem(context.Background(), input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 組み込みのカテゴリーはリポジトリを読まない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Category: "時計"}, nil)
+		categoryRepo := new(MockCategoryRepository)
+		in := input
+		in.Category = "時計"
+
+		_, err := NewItemUsecase(itemRepo, nil, nil, categoryRepo, nil).CreateItem(context.Background(), in)
+
+		require.NoError(t, err)
+		categoryRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ListItems_CustomCategory(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	query := ItemQuery{ItemFilter: ItemFilter{Category: "家具"}, SortBy: "created_at", SortOrder: "desc"}
+	itemRepo.On("FindAll", mock.Anything, query).Return([]*entity.Item{{ID: 1, Category: "家具"}}, nil)
+	u := NewItemUsecase(itemRepo, nil, nil, furnitureCategories(), nil)
+
+	list, err := u.ListItems(context.Background(), ListItemsQuery{TenantID: "acme", Category: "家具"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+
+	_, err = u.ListItems(context.Background(), ListItemsQuery{Category: "家具"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
+
+func TestItemUsecase_GetCategorySummary_CustomCategory(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(map[string]CategoryStats{
+		"時計": {Count: 1, Min: 100, Max: 100, Avg: 100, Sum: 100},
+		"家具": {Count: 2, Min: 10, Max: 30, Avg: 20, Sum: 40},
+	}, nil)
+
+	summary, err := NewItemUsecase(itemRepo, nil, nil, nil, nil).GetCategorySummary(context.Background(), DateRange{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Total)
+	assert.Equal(t, 2, summary.Categories["家具"])
+	assert.Equal(t, 0, summary.Categories["バッグ"])
+	assert.Equal(t, 40, summary.Stats["家具"].Sum)
+}
+
+func TestSummaryUsecase_GetTopItems_CustomCategory(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+
+	top, err := NewSummaryUsecase(nil, itemRepo, furnitureCategories(), nil).GetTopItems(context.Background(), TopItemsInput{TenantID: "acme", PerCategory: true})
+
+	require.NoError(t, err)
+	assert.Len(t, top.Categories, len(entity.ValidCategories)+2)
+	assert.Contains(t, top.Categories, "家電")
+}
+
+func TestIntegrityUsecase_Check_CustomCategory(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{
+		{ID: 1, Category: "家具", PurchasePrice: 100, Version: 1},
+		{ID: 2, Category: "車", PurchasePrice: 100, Version: 1},
+	}, nil))
+	itemRepo.On("FindTrashed", mock.Anything, TrashQuery{}).Return([]*entity.TrashedItem{}, nil)
+	imageRepo := new(MockItemImageRepository)
+	imageRepo.On("ListAllIDsByItem", mock.Anything).Return(map[int64][]int64{}, nil)
+	categoryRepo := new(MockCategoryRepository)
+	categoryRepo.On("FindAllNames", mock.Anything).Return([]string{"家具"}, nil)
+
+	report, err := NewIntegrityUsecase(itemRepo, imageRepo, categoryRepo).Check(context.Background(), false)
+
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, IssueInvalidCategory, report.Issues[0].Kind)
+	assert.Equal(t, int64(2), report.Issues[0].ItemID)
+}
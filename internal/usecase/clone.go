@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CloneItemRequest overrides fields of the item being cloned; fields left out are copied from it.
+// The clone has the currency of the item, so PurchasePrice is in that currency.
+// It is validated by its validate tags when bound from a request, and again when the clone is created.
+type CloneItemRequest struct {
+	TenantID      string         `json:"-"`
+	OwnerID       string         `json:"-"`
+	Name          *string        `json:"name,omitempty" validate:"omitnil,nonempty,max=100"`
+	Category      *string        `json:"category,omitempty" validate:"omitnil,nonempty,category"`
+	Brand         *string        `json:"brand,omitempty" validate:"omitnil,max=100"`
+	PurchasePrice *entity.Amount `json:"purchase_price,omitempty" validate:"omitnil,amount"`
+	PurchaseDate  *string        `json:"purchase_date,omitempty" validate:"omitnil,nonempty,date"`
+	// Attributes are merged into the copied attributes; a null value leaves the attribute out
+	Attributes map[string]*string `json:"attributes,omitempty"`
+}
+
+// CloneUsecase creates items as copies of existing ones, e.g. for several similar pieces bought together
+type CloneUsecase interface {
+	// CloneItem creates a new item from the fields of item id and the overrides of req, owned by req.OwnerID.
+	// Only the fields of the item are copied; images, documents, tags, loans and service records are not.
+	CloneItem(ctx context.Context, id int64, req CloneItemRequest) (*entity.Item, error)
+}
+
+type cloneUsecase struct {
+	itemUsecase ItemUsecase
+}
+
+// NewCloneUsecase creates the clone usecase. Clones are created through itemUsecase, so that they are
+// validated and announced like any other new item.
+func NewCloneUsecase(itemUsecase ItemUsecase) CloneUsecase {
+	return &cloneUsecase{
+		itemUsecase: itemUsecase,
+	}
+}
+
+func (u *cloneUsecase) CloneItem(ctx context.Context, id int64, req CloneItemRequest) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	source, err := u.itemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.itemUsecase.CreateItem(ctx, cloneInput(source, req))
+}
+
+// cloneInput copies source into a CreateItemInput and applies the overrides of req.
+// A clone is similar to its source on purpose, so it is not refused as a duplicate.
+// Stored names and brands are sanitized but never HTML-escaped, and sanitizing is idempotent,
+// so they are copied as stored; only the overrides are new user input.
+func cloneInput(source *entity.Item, req CloneItemRequest) CreateItemInput {
+	input := CreateItemInput{
+		TenantID:       req.TenantID,
+		OwnerID:        req.OwnerID,
+		Name:           source.Name,
+		Category:       source.Category,
+		Brand:          source.Brand,
+		PurchasePrice:  entity.MinorUnits(int64(source.PurchasePrice)),
+		Currency:       source.Price().Currency,
+		PurchaseDate:   source.PurchaseDate,
+		Attributes:     maps.Clone(source.Attributes),
+		AllowDuplicate: true,
+	}
+
+	if req.Name != nil {
+		input.Name = *req.Name
+	}
+	if req.Category != nil {
+		input.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.Brand != nil {
+		input.Brand = *req.Brand
+	}
+	if req.PurchasePrice != nil {
+		input.PurchasePrice = *req.PurchasePrice
+	}
+	if req.PurchaseDate != nil {
+		input.PurchaseDate = *req.PurchaseDate
+	}
+	for key, value := range req.Attributes {
+		if value == nil {
+			delete(input.Attributes, key)
+			continue
+		}
+		if input.Attributes == nil {
+			input.Attributes = make(map[string]string)
+		}
+		input.Attributes[key] = *value
+	}
+
+	return input
+}
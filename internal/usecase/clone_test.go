@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestCloneInput(t *testing.T) {
+	source := &entity.Item{
+		ID:              1,
+		Name:            "ピアス（左）",
+		Category:        "ジュエリー",
+		Brand:           "TIFFANY",
+		PurchasePrice:   80000,
+		Currency:        "USD",
+		PurchaseDate:    "2024-05-01",
+		Attributes:      map[string]string{"storage_box": "A-2", "material": "PT950"},
+		OwnerID:         "bob",
+		MaintenanceCost: 5000,
+		Tags:            []string{"ペア"},
+		Version:         4,
+	}
+	name := "ピアス（右）"
+	price := entity.MinorUnits(75000)
+	box := "A-3"
+
+	tests := []struct {
+		name     string
+		req      CloneItemRequest
+		expected CreateItemInput
+	}{
+		{
+			name: "正常系: フィールドをそのまま複製する",
+			req:  CloneItemRequest{TenantID: "acme", OwnerID: "alice"},
+			expected: CreateItemInput{
+				TenantID: "acme", OwnerID: "alice", Name: "ピアス（左）", Category: "ジュエリー", Brand: "TIFFANY",
+				PurchasePrice: entity.MinorUnits(80000), Currency: "USD", PurchaseDate: "2024-05-01",
+				Attributes:     map[string]string{"storage_box": "A-2", "material": "PT950"},
+				AllowDuplicate: true,
+			},
+		},
+		{
+			name: "正常系: 指定したフィールドと属性を上書きし、null の属性は除く",
+			req: CloneItemRequest{
+				OwnerID:       "alice",
+				Name:          &name,
+				PurchasePrice: &price,
+				Attributes:    map[string]*string{"storage_box": &box, "material": nil},
+			},
+			expected: CreateItemInput{
+				OwnerID: "alice", Name: "ピアス（右）", Category: "ジュエリー", Brand: "TIFFANY",
+				PurchasePrice: entity.MinorUnits(75000), Currency: "USD", PurchaseDate: "2024-05-01",
+				Attributes:     map[string]string{"storage_box": "A-3"},
+				AllowDuplicate: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cloneInput(source, tt.req))
+			// 複製元の属性は変更しない
+			assert.Equal(t, map[string]string{"storage_box": "A-2", "material": "PT950"}, source.Attributes)
+		})
+	}
+}
+
+func TestCloneUsecase_CloneItem(t *testing.T) {
+	source := &entity.Item{ID: 1, Name: "ピアス（左）", Category: "ジュエリー", Brand: "TIFFANY", PurchasePrice: 80000, PurchaseDate: "2024-05-01"}
+
+	t.Run("正常系: 重複の検出で拒否せずに登録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(source, nil)
+		itemRepo.On("Iterate", mock.Anything, mock.Anything, mock.Anything).Return(iterateItems([]*entity.Item{source}, nil)).Maybe()
+		var saved *entity.Item
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 2}, nil)
+		items := NewDuplicateCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo)
+
+		item, err := NewCloneUsecase(items).CloneItem(context.Background(), 1, CloneItemRequest{OwnerID: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), item.ID)
+		assert.Equal(t, "ピアス（左）", saved.Name)
+		assert.Equal(t, "alice", saved.OwnerID)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 保存されている名前とブランドをそのまま複製する", func(t *testing.T) {
+		// 100バイトちょうどの名前も、エスケープ済みに見える文字列も、変えずに複製する
+		stored := &entity.Item{ID: 3, Name: strings.Repeat("&", 100), Category: "ジュエリー", Brand: "Tiffany &amp; <Co.>",
+			PurchasePrice: 80000, PurchaseDate: "2024-05-01"}
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(3)).Return(stored, nil)
+		var saved *entity.Item
+		itemRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 4}, nil)
+
+		_, err := NewCloneUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil)).CloneItem(context.Background(), 3, CloneItemRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, stored.Name, saved.Name)
+		assert.Equal(t, stored.Brand, saved.Brand)
+	})
+
+	t.Run("異常系: 上書きした値が不正", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(source, nil)
+		price := entity.MinorUnits(-1)
+
+		_, err := NewCloneUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil)).CloneItem(context.Background(), 1, CloneItemRequest{PurchasePrice: &price})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 複製元が見つからない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrNotFound)
+
+		_, err := NewCloneUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil)).CloneItem(context.Background(), 9, CloneItemRequest{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
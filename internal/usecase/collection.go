@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// maxCollectionItems is the number of items a collection may contain
+const maxCollectionItems = 500
+
+// CollectionUsecase manages user-defined collections of items.
+// Collections can be read by anyone; only their owner may change them. Collections without an owner,
+// created without a user ID, may be changed by any user. Collections are not part of the item
+// representation, so adding or removing items does not change the item version.
+type CollectionUsecase interface {
+	// ListCollections returns the collections of ownerID, or every collection if it is empty
+	ListCollections(ctx context.Context, ownerID string) ([]*entity.Collection, error)
+	CreateCollection(ctx context.Context, actor string, input CollectionInput) (*entity.Collection, error)
+	GetCollection(ctx context.Context, id int64) (*entity.Collection, error)
+	UpdateCollection(ctx context.Context, actor string, id int64, input CollectionInput) (*entity.Collection, error)
+	// DeleteCollection deletes a collection; its items are kept
+	DeleteCollection(ctx context.Context, actor string, id int64) error
+	// ListCollectionItems returns the items of a collection in the order they were added
+	ListCollectionItems(ctx context.Context, id int64) ([]*entity.Item, error)
+	// AddItems adds items to a collection; items already in it are skipped
+	AddItems(ctx context.Context, actor string, id int64, itemIDs []int64) (*entity.Collection, error)
+	RemoveItem(ctx context.Context, actor string, id, itemID int64) error
+	// GetSummary totals the items of a collection; with a currency the purchase prices are also converted to it
+	GetSummary(ctx context.Context, id int64, currency string) (*CollectionSummary, error)
+}
+
+type CollectionInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CollectionSummary totals the items of a collection
+type CollectionSummary struct {
+	CollectionID int64          `json:"collection_id"`
+	ItemCount    int            `json:"item_count"`
+	Categories   map[string]int `json:"categories"`
+	// PurchaseTotal is the sum of the purchase prices of the items bought in BaseCurrency, PurchaseByCategory the sums by category
+	PurchaseTotal      int            `json:"purchase_total"`
+	PurchaseByCategory map[string]int `json:"purchase_by_category"`
+	// MaintenanceCost is the total cost of the service records of the items
+	MaintenanceCost int `json:"maintenance_cost"`
+	// Value is only set when the summary was requested in a currency
+	Value *ValueSummary `json:"value,omitempty"`
+}
+
+type collectionUsecase struct {
+	itemRepo       ItemReader
+	collectionRepo CollectionRepository
+	serviceRepo    ServiceRecordRepository
+	converter      CurrencyConverter
+	uow            UnitOfWork
+	now            func() time.Time
+}
+
+// NewCollectionUsecase creates the collection usecase; uow may be nil, in which case no transactions are used
+func NewCollectionUsecase(itemRepo ItemReader, collectionRepo CollectionRepository, serviceRepo ServiceRecordRepository,
+	converter CurrencyConverter, uow UnitOfWork) CollectionUsecase {
+	return &collectionUsecase{
+		itemRepo:       itemRepo,
+		collectionRepo: collectionRepo,
+		serviceRepo:    serviceRepo,
+		converter:      converter,
+		uow:            uow,
+		now:            time.Now,
+	}
+}
+
+func (u *collectionUsecase) ListCollections(ctx context.Context, ownerID string) ([]*entity.Collection, error) {
+	collections, err := u.collectionRepo.FindAll(ReadOnly(ctx), ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve collections: %w", err)
+	}
+
+	if collections == nil {
+		collections = []*entity.Collection{}
+	}
+
+	return collections, nil
+}
+
+func (u *collectionUsecase) CreateCollection(ctx context.Context, actor string, input CollectionInput) (*entity.Collection, error) {
+	collection, err := entity.NewCollection(actor, input.Name, input.Description, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	created, err := u.collectionRepo.Create(ctx, collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *collectionUsecase) GetCollection(ctx context.Context, id int64) (*entity.Collection, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	return u.findCollection(ReadOnly(ctx), id)
+}
+
+func (u *collectionUsecase) UpdateCollection(ctx context.Context, actor string, id int64, input CollectionInput) (*entity.Collection, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	collection, err := u.ownedCollection(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := collection.Change(input.Name, input.Description, u.now()); err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	if err := u.collectionRepo.Update(ctx, collection); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	return collection, nil
+}
+
+func (u *collectionUsecase) DeleteCollection(ctx context.Context, actor string, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if _, err := u.ownedCollection(ctx, actor, id); err != nil {
+			return err
+		}
+
+		if err := u.collectionRepo.Delete(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrCollectionNotFound
+			}
+			return fmt.Errorf("failed to delete collection: %w", err)
+		}
+		return nil
+	})
+}
+
+func (u *collectionUsecase) ListCollectionItems(ctx context.Context, id int64) ([]*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.findCollection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return u.collectionItems(ctx, id)
+}
+
+func (u *collectionUsecase) AddItems(ctx context.Context, actor string, id int64, itemIDs []int64) (*entity.Collection, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("%w: item_ids is required", domainErrors.ErrInvalidInput)
+	}
+	for _, itemID := range itemIDs {
+		if itemID <= 0 {
+			return nil, fmt.Errorf("%w: item_ids must be positive item IDs", domainErrors.ErrInvalidInput)
+		}
+	}
+
+	var updated *entity.Collection
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if _, err := u.ownedCollection(ctx, actor, id); err != nil {
+			return err
+		}
+
+		existing, err := u.collectionRepo.ItemIDs(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve collection items: %w", err)
+		}
+		seen := make(map[int64]bool, len(existing)+len(itemIDs))
+		for _, itemID := range existing {
+			seen[itemID] = true
+		}
+
+		var added []int64
+		for _, itemID := range itemIDs {
+			if seen[itemID] {
+				continue
+			}
+			seen[itemID] = true
+
+			if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+				if domainErrors.IsNotFoundError(err) {
+					return domainErrors.NotFound(domainErrors.ErrItemNotFound, "id %d", itemID)
+				}
+				return fmt.Errorf("failed to retrieve item: %w", err)
+			}
+			added = append(added, itemID)
+		}
+		if len(existing)+len(added) > maxCollectionItems {
+			return fmt.Errorf("%w: a collection must contain %d items or less", domainErrors.ErrInvalidInput, maxCollectionItems)
+		}
+
+		if len(added) > 0 {
+			if err := u.collectionRepo.AddItems(ctx, id, added, u.now()); err != nil {
+				return fmt.Errorf("failed to add collection items: %w", err)
+			}
+		}
+
+		updated, err = u.findCollection(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *collectionUsecase) RemoveItem(ctx context.Context, actor string, id, itemID int64) error {
+	if id <= 0 || itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.ownedCollection(ctx, actor, id); err != nil {
+		return err
+	}
+
+	if err := u.collectionRepo.RemoveItem(ctx, id, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.NotFound(domainErrors.ErrItemNotFound, "item %d is not in collection %d", itemID, id)
+		}
+		return fmt.Errorf("failed to remove collection item: %w", err)
+	}
+
+	return nil
+}
+
+// GetSummary converts the totals rather than each price, like the item value summary, so that rounding errors do not add up
+func (u *collectionUsecase) GetSummary(ctx context.Context, id int64, currency string) (*CollectionSummary, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	// Fetch the rate first so that an unsupported currency fails before the items are read
+	var conversion *Conversion
+	if currency != "" {
+		normalized, err := NormalizeCurrency(currency)
+		if err != nil {
+			return nil, err
+		}
+		conversion, err = u.converter.Conversion(ctx, BaseCurrency, normalized)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.findCollection(ctx, id); err != nil {
+		return nil, err
+	}
+	items, err := u.collectionItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &CollectionSummary{
+		CollectionID:       id,
+		ItemCount:          len(items),
+		Categories:         make(map[string]int),
+		PurchaseByCategory: make(map[string]int),
+	}
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+		summary.Categories[item.Category]++
+		price, _ := basePrice(item)
+		summary.PurchaseTotal += price
+		summary.PurchaseByCategory[item.Category] += price
+	}
+
+	if len(ids) > 0 {
+		services, err := u.serviceRepo.SummarizeByItem(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve service records: %w", err)
+		}
+		for _, service := range services {
+			summary.MaintenanceCost += service.TotalCost
+		}
+	}
+
+	if conversion != nil {
+		summary.Value = &ValueSummary{
+			Currency:     conversion.To,
+			Categories:   make(map[string]float64, len(summary.PurchaseByCategory)),
+			Total:        conversion.Convert(summary.PurchaseTotal),
+			ExchangeRate: conversion.Rate,
+			RateDate:     conversion.RateDate,
+		}
+		for category, total := range summary.PurchaseByCategory {
+			summary.Value.Categories[category] = conversion.Convert(total)
+		}
+	}
+
+	return summary, nil
+}
+
+// findCollection retrieves a collection, reporting a missing collection as ErrCollectionNotFound
+func (u *collectionUsecase) findCollection(ctx context.Context, id int64) (*entity.Collection, error) {
+	collection, err := u.collectionRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCollectionNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve collection: %w", err)
+	}
+	return collection, nil
+}
+
+// ownedCollection retrieves a collection after checking that actor may change it
+func (u *collectionUsecase) ownedCollection(ctx context.Context, actor string, id int64) (*entity.Collection, error) {
+	collection, err := u.findCollection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.OwnerID != "" && collection.OwnerID != actor {
+		return nil, domainErrors.PermissionDenied("collection %d is not owned by %s", id, actor)
+	}
+	return collection, nil
+}
+
+// collectionItems retrieves the items of a collection in the order they were added
+func (u *collectionUsecase) collectionItems(ctx context.Context, id int64) ([]*entity.Item, error) {
+	itemIDs, err := u.collectionRepo.ItemIDs(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve collection items: %w", err)
+	}
+
+	items := make([]*entity.Item, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			// Deleted items are removed from their collections, but may still be listed by a read started before
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+type collectionItemUsecase struct {
+	ItemUsecase
+	collectionRepo CollectionRepository
+}
+
+// NewCollectionItemUsecase removes deleted items from their collections
+func NewCollectionItemUsecase(inner ItemUsecase, collectionRepo CollectionRepository) ItemUsecase {
+	return &collectionItemUsecase{
+		ItemUsecase:    inner,
+		collectionRepo: collectionRepo,
+	}
+}
+
+// DeleteItem removes the item from its collections along with it, in the caller's transaction if any
+func (u *collectionItemUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) error {
+	if err := u.ItemUsecase.DeleteItem(ctx, id, ifMatch); err != nil {
+		return err
+	}
+
+	if err := u.collectionRepo.RemoveItemFromAll(ctx, id); err != nil {
+		return fmt.Errorf("failed to remove item from collections: %w", err)
+	}
+	return nil
+}
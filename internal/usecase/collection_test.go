@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockCollectionRepository はコレクションリポジトリのモック
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) Create(ctx context.Context, collection *entity.Collection) (*entity.Collection, error) {
+	args := m.Called(ctx, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) FindAll(ctx context.Context, ownerID string) ([]*entity.Collection, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) FindByID(ctx context.Context, id int64) (*entity.Collection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) Update(ctx context.Context, collection *entity.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) ItemIDs(ctx context.Context, collectionID int64) ([]int64, error) {
+	args := m.Called(ctx, collectionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockCollectionRepository) AddItems(ctx context.Context, collectionID int64, itemIDs []int64, addedAt time.Time) error {
+	args := m.Called(ctx, collectionID, itemIDs, addedAt)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) RemoveItem(ctx context.Context, collectionID, itemID int64) error {
+	args := m.Called(ctx, collectionID, itemID)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) RemoveItemFromAll(ctx context.Context, itemID int64) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+var collectionNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestCollectionUsecase(itemRepo ItemRepository, collectionRepo CollectionRepository, serviceRepo ServiceRecordRepository) CollectionUsecase {
+	u := NewCollectionUsecase(itemRepo, collectionRepo, serviceRepo, NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}), nil).(*collectionUsecase)
+	u.now = func() time.Time { return collectionNow }
+	return u
+}
+
+func TestCollectionUsecase_CreateCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       CollectionInput
+		expectedErr error
+	}{
+		{name: "正常系: 作成したユーザーを所有者にする", input: CollectionInput{Name: " 祖父の時計 ", Description: "形見"}},
+		{name: "異常系: 名前が空", input: CollectionInput{Name: "  "}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 長すぎる説明", input: CollectionInput{Name: "時計", Description: strings.Repeat("a", 501)}, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionRepo := new(MockCollectionRepository)
+			collectionRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Collection{ID: 1}, nil).Maybe()
+
+			collection, err := newTestCollectionUsecase(nil, collectionRepo, nil).CreateCollection(context.Background(), "alice", tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, collection)
+				collectionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), collection.ID)
+			collectionRepo.AssertCalled(t, "Create", mock.Anything, &entity.Collection{
+				OwnerID:     "alice",
+				Name:        "祖父の時計",
+				Description: "形見",
+				CreatedAt:   collectionNow,
+				UpdatedAt:   collectionNow,
+			})
+		})
+	}
+}
+
+func TestCollectionUsecase_UpdateCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		ownerID     string
+		actor       string
+		expectedErr error
+	}{
+		{name: "正常系: 所有者が変更する", ownerID: "alice", actor: "alice"},
+		{name: "正常系: 所有者のいないコレクションは誰でも変更できる", ownerID: "", actor: "bob"},
+		{name: "異常系: 所有者以外は変更できない", ownerID: "alice", actor: "bob", expectedErr: domainErrors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionRepo := new(MockCollectionRepository)
+			collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1, OwnerID: tt.ownerID, Name: "時計"}, nil)
+			collectionRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			collection, err := newTestCollectionUsecase(nil, collectionRepo, nil).
+				UpdateCollection(context.Background(), tt.actor, 1, CollectionInput{Name: "祖父の時計"})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				collectionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "祖父の時計", collection.Name)
+			assert.Equal(t, collectionNow, collection.UpdatedAt)
+		})
+	}
+
+	t.Run("異常系: コレクションが見つからない", func(t *testing.T) {
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrNotFound)
+
+		_, err := newTestCollectionUsecase(nil, collectionRepo, nil).UpdateCollection(context.Background(), "alice", 9, CollectionInput{Name: "時計"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCollectionNotFound)
+	})
+}
+
+func TestCollectionUsecase_AddItems(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 追加済みと重複したアイテムを除いて追加する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.Item{ID: 3}, nil)
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1, OwnerID: "alice", ItemCount: 2}, nil)
+		collectionRepo.On("ItemIDs", mock.Anything, int64(1)).Return([]int64{1, 2}, nil)
+		collectionRepo.On("AddItems", mock.Anything, int64(1), []int64{3}, collectionNow).Return(nil)
+
+		_, err := newTestCollectionUsecase(itemRepo, collectionRepo, nil).AddItems(ctx, "alice", 1, []int64{2, 3, 3})
+
+		require.NoError(t, err)
+		collectionRepo.AssertExpectations(t)
+		itemRepo.AssertNumberOfCalls(t, "FindByID", 1)
+	})
+
+	t.Run("正常系: すべて追加済みなら何もしない", func(t *testing.T) {
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1}, nil)
+		collectionRepo.On("ItemIDs", mock.Anything, int64(1)).Return([]int64{1}, nil)
+
+		_, err := newTestCollectionUsecase(new(MockItemRepository), collectionRepo, nil).AddItems(ctx, "alice", 1, []int64{1})
+
+		require.NoError(t, err)
+		collectionRepo.AssertNotCalled(t, "AddItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(5)).Return(nil, domainErrors.ErrItemNotFound)
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1}, nil)
+		collectionRepo.On("ItemIDs", mock.Anything, int64(1)).Return([]int64(nil), nil)
+
+		_, err := newTestCollectionUsecase(itemRepo, collectionRepo, nil).AddItems(ctx, "alice", 1, []int64{5})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		collectionRepo.AssertNotCalled(t, "AddItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: アイテムの数の上限を超える", func(t *testing.T) {
+		existing := make([]int64, maxCollectionItems)
+		for i := range existing {
+			existing[i] = int64(i + 1)
+		}
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, mock.Anything).Return(&entity.Item{}, nil)
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1}, nil)
+		collectionRepo.On("ItemIDs", mock.Anything, int64(1)).Return(existing, nil)
+
+		_, err := newTestCollectionUsecase(itemRepo, collectionRepo, nil).AddItems(ctx, "alice", 1, []int64{1000})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 所有者以外は追加できない", func(t *testing.T) {
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1, OwnerID: "alice"}, nil)
+
+		_, err := newTestCollectionUsecase(nil, collectionRepo, nil).AddItems(ctx, "bob", 1, []int64{1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestCollectionUsecase_RemoveItem(t *testing.T) {
+	t.Run("異常系: コレクションにないアイテム", func(t *testing.T) {
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1}, nil)
+		collectionRepo.On("RemoveItem", mock.Anything, int64(1), int64(7)).Return(domainErrors.ErrNotFound)
+
+		err := newTestCollectionUsecase(nil, collectionRepo, nil).RemoveItem(context.Background(), "alice", 1, 7)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestCollectionUsecase_GetSummary(t *testing.T) {
+	newRepos := func() (*MockItemRepository, *MockCollectionRepository, *MockServiceRecordRepository) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Category: "時計", PurchasePrice: 12000}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2, Category: "時計", PurchasePrice: 4000}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(3)).Return(nil, domainErrors.ErrItemNotFound)
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Collection{ID: 1}, nil)
+		collectionRepo.On("ItemIDs", mock.Anything, int64(1)).Return([]int64{1, 2, 3}, nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("SummarizeByItem", mock.Anything, []int64{1, 2}).Return(map[int64]*ServiceSummary{1: {TotalCost: 3000}, 2: {TotalCost: 500}}, nil)
+		return itemRepo, collectionRepo, serviceRepo
+	}
+
+	t.Run("正常系: 削除済みのアイテムを除いて合計する", func(t *testing.T) {
+		summary, err := newTestCollectionUsecase(newRepos()).GetSummary(context.Background(), 1, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.ItemCount)
+		assert.Equal(t, map[string]int{"時計": 2}, summary.Categories)
+		assert.Equal(t, 16000, summary.PurchaseTotal)
+		assert.Equal(t, map[string]int{"時計": 16000}, summary.PurchaseByCategory)
+		assert.Equal(t, 3500, summary.MaintenanceCost)
+		assert.Nil(t, summary.Value)
+	})
+
+	t.Run("正常系: 指定した通貨に換算する", func(t *testing.T) {
+		summary, err := newTestCollectionUsecase(newRepos()).GetSummary(context.Background(), 1, "usd")
+
+		require.NoError(t, err)
+		require.NotNil(t, summary.Value)
+		assert.Equal(t, "USD", summary.Value.Currency)
+		assert.Equal(t, 110.0, summary.Value.Total)
+		assert.Equal(t, map[string]float64{"時計": 110.0}, summary.Value.Categories)
+	})
+
+	t.Run("異常系: 対応していない通貨", func(t *testing.T) {
+		_, err := newTestCollectionUsecase(newRepos()).GetSummary(context.Background(), 1, "XYZ")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestCollectionItemUsecase(t *testing.T) {
+	t.Run("正常系: アイテムの削除でコレクションから外す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("RemoveItemFromAll", mock.Anything, int64(1)).Return(nil)
+
+		err := NewCollectionItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), collectionRepo).DeleteItem(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+		collectionRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: アイテムの削除に失敗したらコレクションは変えない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		collectionRepo := new(MockCollectionRepository)
+
+		err := NewCollectionItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), collectionRepo).DeleteItem(context.Background(), 1, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		collectionRepo.AssertNotCalled(t, "RemoveItemFromAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// BaseCurrency is the currency totals and conversions are computed in: the currency of items registered without one
+const BaseCurrency = entity.DefaultCurrency
+
+// basePrice returns the purchase price of an item bought in BaseCurrency. The totals that are not converted leave
+// out the other items (ok is false), as their minor units cannot be added to BaseCurrency amounts.
+func basePrice(item *entity.Item) (price int, ok bool) {
+	if item.Price().Currency != BaseCurrency {
+		return 0, false
+	}
+	return item.PurchasePrice, true
+}
+
+// ExchangeRates are the rates of one day: one unit of Base is worth Rates[c] units of currency c
+type ExchangeRates struct {
+	Base  string
+	Date  string // YYYY-MM-DD
+	Rates map[string]float64
+}
+
+// ExchangeRateProvider retrieves the latest exchange rates from an external source
+type ExchangeRateProvider interface {
+	// LatestRates returns the latest published rates. Returns ErrExchangeRateUnavailable if they cannot be retrieved.
+	LatestRates(ctx context.Context) (*ExchangeRates, error)
+}
+
+// Conversion converts amounts from one currency to another at the rate of a given day
+type Conversion struct {
+	From     string
+	To       string
+	Rate     float64
+	RateDate string // YYYY-MM-DD
+}
+
+// Convert converts an amount and rounds it to the minor units of the target currency
+func (c *Conversion) Convert(amount int) float64 {
+	return RoundAmount(float64(amount)*c.Rate, c.To)
+}
+
+// CurrencyDecimals returns the number of decimal places amounts in the currency are given with
+func CurrencyDecimals(currency string) int {
+	return entity.CurrencyDigits(currency)
+}
+
+// RoundAmount rounds an amount to the minor units of the currency
+func RoundAmount(amount float64, currency string) float64 {
+	scale := math.Pow10(CurrencyDecimals(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// NormalizeCurrency upper-cases a currency code and checks that it is an ISO 4217 code
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !entity.IsValidCurrency(currency) {
+		return "", fmt.Errorf("%w: currency must be in ISO 4217 format (e.g. USD)", domainErrors.ErrInvalidInput)
+	}
+	return currency, nil
+}
+
+// CurrencyConverter converts amounts between currencies
+type CurrencyConverter interface {
+	// Conversion returns the conversion between two currencies at the latest rates.
+	// Returns ErrInvalidInput if the provider has no rate for either currency.
+	Conversion(ctx context.Context, from, to string) (*Conversion, error)
+}
+
+type currencyConverter struct {
+	provider ExchangeRateProvider
+}
+
+func NewCurrencyConverter(provider ExchangeRateProvider) CurrencyConverter {
+	return &currencyConverter{provider: provider}
+}
+
+// Conversion computes cross rates through the provider's base currency, so any two published currencies can be converted
+func (c *currencyConverter) Conversion(ctx context.Context, from, to string) (*Conversion, error) {
+	if from == to {
+		return &Conversion{From: from, To: to, Rate: 1}, nil
+	}
+
+	rates, err := c.provider.LatestRates(ctx)
+	if err != nil {
+		if domainErrors.IsExchangeRateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrExchangeRateUnavailable, err)
+	}
+
+	fromRate, ok := rates.rate(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: currency must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(rates.currencies(), ", "))
+	}
+	toRate, ok := rates.rate(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: currency must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(rates.currencies(), ", "))
+	}
+
+	return &Conversion{From: from, To: to, Rate: toRate / fromRate, RateDate: rates.Date}, nil
+}
+
+func (r *ExchangeRates) rate(currency string) (float64, bool) {
+	if currency == r.Base {
+		return 1, true
+	}
+	rate, ok := r.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+func (r *ExchangeRates) currencies() []string {
+	currencies := []string{r.Base}
+	for currency := range r.Rates {
+		if currency != r.Base {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+type currencyConvertingItemUsecase struct {
+	ItemUsecase
+	converter CurrencyConverter
+}
+
+// NewCurrencyConvertingItemUsecase lets inner's value summary be requested in any currency the converter supports
+func NewCurrencyConvertingItemUsecase(inner ItemUsecase, converter CurrencyConverter) ItemUsecase {
+	return &currencyConvertingItemUsecase{
+		ItemUsecase: inner,
+		converter:   converter,
+	}
+}
+
+// GetValueSummary converts the totals of each currency the items were bought in rather than each price,
+// so that rounding errors do not add up
+func (u *currencyConvertingItemUsecase) GetValueSummary(ctx context.Context, currency string) (*ValueSummary, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the rate first so that an unsupported currency fails before the items are read
+	conversion, err := u.converter.Conversion(ctx, BaseCurrency, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := u.ItemUsecase.GetValueSummary(ctx, BaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ValueSummary{
+		Currency:     currency,
+		Categories:   make(map[string]float64, len(base.Categories)),
+		ExchangeRate: conversion.Rate,
+		RateDate:     conversion.RateDate,
+		Native:       base.Native,
+	}
+	for category := range base.Categories {
+		summary.Categories[category] = 0
+	}
+	for from, native := range base.Native {
+		rate := conversion
+		if from != BaseCurrency {
+			rate, err = u.converter.Conversion(ctx, from, currency)
+			if errors.Is(err, domainErrors.ErrInvalidInput) {
+				// The requested currency is supported, so it is the items' currency the provider has no rate for
+				return nil, fmt.Errorf("%w: no rate for %s, the currency of some items", domainErrors.ErrExchangeRateUnavailable, from)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		if summary.RateDate == "" {
+			summary.RateDate = rate.RateDate
+		}
+		native.ExchangeRate = rate.Rate
+		native.Converted = RoundAmount(native.Total*rate.Rate, currency)
+		for category, total := range native.Categories {
+			summary.Categories[category] += RoundAmount(total*rate.Rate, currency)
+		}
+		summary.Total += native.Converted
+	}
+
+	// Round again so that the sums do not pick up floating point errors
+	for category, total := range summary.Categories {
+		summary.Categories[category] = RoundAmount(total, currency)
+	}
+	summary.Total = RoundAmount(summary.Total, currency)
+
+	return summary, nil
+}
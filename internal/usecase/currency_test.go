@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fixedRateProvider は決まったレートを返す為替レートの提供元
+type fixedRateProvider struct {
+	rates *ExchangeRates
+	err   error
+	calls int
+}
+
+func (p *fixedRateProvider) LatestRates(ctx context.Context) (*ExchangeRates, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rates, nil
+}
+
+// ECB と同じくユーロ基準のレート
+var ecbRates = &ExchangeRates{
+	Base: "EUR",
+	Date: "2024-03-08",
+	Rates: map[string]float64{
+		"JPY": 160.0,
+		"USD": 1.1,
+		"GBP": 0.85,
+	},
+}
+
+func TestCurrencyConverter_Conversion(t *testing.T) {
+	tests := []struct {
+		name         string
+		from         string
+		to           string
+		providerErr  error
+		expectedRate float64
+		expectedErr  error
+	}{
+		{name: "正常系: 基準通貨を経由したクロスレート", from: "JPY", to: "USD", expectedRate: 1.1 / 160},
+		{name: "正常系: 基準通貨への換算", from: "JPY", to: "EUR", expectedRate: 1 / 160.0},
+		{name: "正常系: 同じ通貨はレートを取得しない", from: "JPY", to: "JPY", expectedRate: 1},
+		{name: "異常系: 提供元にない通貨", from: "JPY", to: "XYZ", expectedErr: domainErrors.ErrInvalidInput},
+		{
+			name:        "異常系: 提供元の障害",
+			from:        "JPY",
+			to:          "USD",
+			providerErr: errors.New("connection refused"),
+			expectedErr: domainErrors.ErrExchangeRateUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fixedRateProvider{rates: ecbRates, err: tt.providerErr}
+
+			conversion, err := NewCurrencyConverter(provider).Conversion(context.Background(), tt.from, tt.to)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, conversion)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expectedRate, conversion.Rate, 1e-12)
+			assert.Equal(t, tt.to, conversion.To)
+			if tt.from == tt.to {
+				assert.Equal(t, 0, provider.calls)
+			} else {
+				assert.Equal(t, "2024-03-08", conversion.RateDate)
+			}
+		})
+	}
+
+	t.Run("異常系: エラーに対応している通貨を含める", func(t *testing.T) {
+		_, err := NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}).Conversion(context.Background(), "JPY", "XYZ")
+
+		assert.EqualError(t, err, "invalid input: currency must be one of: EUR, GBP, JPY, USD")
+	})
+}
+
+func TestConversion_Convert(t *testing.T) {
+	assert.Equal(t, 8487.65, (&Conversion{To: "USD", Rate: 1.1 / 160}).Convert(1234567))
+	assert.Equal(t, 14545.0, (&Conversion{To: "JPY", Rate: 160 / 1.1}).Convert(100))
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	currency, err := NormalizeCurrency(" usd ")
+	require.NoError(t, err)
+	assert.Equal(t, "USD", currency)
+
+	for _, invalid := range []string{"", "US", "USDX", "U$D"} {
+		_, err := NormalizeCurrency(invalid)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput, invalid)
+	}
+}
+
+func TestItemUsecase_GetValueSummary(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchasePrice: 1000000},
+		{ID: 2, Category: "バッグ", PurchasePrice: 300000},
+		{ID: 3, Category: "時計", PurchasePrice: 600000},
+	}
+
+	t.Run("正常系: 基準通貨でカテゴリー別に合計", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		summary, err := NewItemUsecase(itemRepo, nil, nil, nil, nil).GetValueSummary(context.Background(), "jpy")
+
+		require.NoError(t, err)
+		assert.Equal(t, "JPY", summary.Currency)
+		assert.Equal(t, 1600000.0, summary.Categories["時計"])
+		assert.Equal(t, 300000.0, summary.Categories["バッグ"])
+		assert.Equal(t, 0.0, summary.Categories["靴"])
+		assert.Equal(t, 1900000.0, summary.Total)
+		assert.Equal(t, 1.0, summary.ExchangeRate)
+		assert.Equal(t, &NativeValue{
+			Categories:   map[string]float64{"時計": 1600000, "バッグ": 300000},
+			Total:        1900000,
+			Converted:    1900000,
+			ExchangeRate: 1,
+		}, summary.Native["JPY"])
+	})
+
+	t.Run("正常系: 基準通貨以外のアイテムは通貨別の合計だけに入れる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(append(items,
+			&entity.Item{ID: 4, Category: "時計", PurchasePrice: 1234, Currency: "USD"},
+		), nil))
+
+		summary, err := NewItemUsecase(itemRepo, nil, nil, nil, nil).GetValueSummary(context.Background(), "JPY")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1600000.0, summary.Categories["時計"])
+		assert.Equal(t, 1900000.0, summary.Total)
+		// 最小単位（セント）ではなくドルで合計し、換算はしない
+		assert.Equal(t, &NativeValue{
+			Categories: map[string]float64{"時計": 12.34},
+			Total:      12.34,
+		}, summary.Native["USD"])
+	})
+
+	t.Run("異常系: 換算なしでは基準通貨以外は使えない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		summary, err := NewItemUsecase(itemRepo, nil, nil, nil, nil).GetValueSummary(context.Background(), "USD")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, summary)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCurrencyConvertingItemUsecase_GetValueSummary(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchasePrice: 1000000},
+		{ID: 2, Category: "バッグ", PurchasePrice: 300000},
+	}
+
+	t.Run("正常系: 合計を指定の通貨に換算", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+		u := NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}))
+
+		summary, err := u.GetValueSummary(context.Background(), "usd")
+
+		require.NoError(t, err)
+		assert.Equal(t, "USD", summary.Currency)
+		assert.Equal(t, 6875.0, summary.Categories["時計"])
+		assert.Equal(t, 2062.5, summary.Categories["バッグ"])
+		assert.Equal(t, 0.0, summary.Categories["靴"])
+		assert.Equal(t, 8937.5, summary.Total)
+		assert.InDelta(t, 1.1/160, summary.ExchangeRate, 1e-12)
+		assert.Equal(t, "2024-03-08", summary.RateDate)
+		assert.Equal(t, 8937.5, summary.Native["JPY"].Converted)
+	})
+
+	mixed := append([]*entity.Item{
+		{ID: 3, Category: "時計", PurchasePrice: 110000, Currency: "USD"},
+		{ID: 4, Category: "バッグ", PurchasePrice: 8500, Currency: "GBP"},
+	}, items...)
+
+	t.Run("正常系: アイテムの通貨ごとに換算して合計", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(mixed, nil))
+		u := NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}))
+
+		summary, err := u.GetValueSummary(context.Background(), "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, 7975.0, summary.Categories["時計"])
+		assert.Equal(t, 2172.5, summary.Categories["バッグ"])
+		assert.Equal(t, 10147.5, summary.Total)
+		assert.InDelta(t, 1.1/160, summary.ExchangeRate, 1e-12)
+
+		require.Len(t, summary.Native, 3)
+		assert.Equal(t, 1100.0, summary.Native["USD"].Total)
+		assert.Equal(t, 1100.0, summary.Native["USD"].Converted)
+		assert.Equal(t, 1.0, summary.Native["USD"].ExchangeRate)
+		assert.Equal(t, map[string]float64{"バッグ": 85}, summary.Native["GBP"].Categories)
+		assert.Equal(t, 110.0, summary.Native["GBP"].Converted)
+		assert.InDelta(t, 1.1/0.85, summary.Native["GBP"].ExchangeRate, 1e-12)
+	})
+
+	t.Run("正常系: 基準通貨でもほかの通貨のアイテムは換算する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(mixed, nil))
+		u := NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}))
+
+		summary, err := u.GetValueSummary(context.Background(), "JPY")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1160000.0, summary.Categories["時計"])
+		assert.Equal(t, 316000.0, summary.Categories["バッグ"])
+		assert.Equal(t, 1476000.0, summary.Total)
+		assert.Equal(t, 1.0, summary.ExchangeRate)
+		// 換算したレートの日付
+		assert.Equal(t, "2024-03-08", summary.RateDate)
+	})
+
+	t.Run("異常系: 提供元にレートのない通貨のアイテム", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(append(mixed,
+			&entity.Item{ID: 5, Category: "靴", PurchasePrice: 10000, Currency: "CHF"},
+		), nil))
+		u := NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), NewCurrencyConverter(&fixedRateProvider{rates: ecbRates}))
+
+		summary, err := u.GetValueSummary(context.Background(), "USD")
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.NotErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, summary)
+	})
+
+	t.Run("異常系: 提供元の障害ではアイテムを読まない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u := NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil),
+			NewCurrencyConverter(&fixedRateProvider{err: domainErrors.ErrExchangeRateUnavailable}))
+
+		summary, err := u.GetValueSummary(context.Background(), "USD")
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Nil(t, summary)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CustomAttributeUsecase interface {
+	ListAttributes(ctx context.Context, tenantID string) ([]*entity.CustomAttribute, error)
+	SaveAttribute(ctx context.Context, tenantID, key string, input SaveCustomAttributeInput) (*entity.CustomAttribute, error)
+	DeleteAttribute(ctx context.Context, tenantID, key string) error
+}
+
+type SaveCustomAttributeInput struct {
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+type customAttributeUsecase struct {
+	attrRepo CustomAttributeRepository
+}
+
+func NewCustomAttributeUsecase(attrRepo CustomAttributeRepository) CustomAttributeUsecase {
+	return &customAttributeUsecase{
+		attrRepo: attrRepo,
+	}
+}
+
+func (u *customAttributeUsecase) ListAttributes(ctx context.Context, tenantID string) ([]*entity.CustomAttribute, error) {
+	attrs, err := u.attrRepo.FindAll(ctx, tenantOrDefault(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve custom attributes: %w", err)
+	}
+
+	return attrs, nil
+}
+
+func (u *customAttributeUsecase) SaveAttribute(ctx context.Context, tenantID, key string, input SaveCustomAttributeInput) (*entity.CustomAttribute, error) {
+	attr, err := entity.NewCustomAttribute(key, input.Label, input.Type, input.Options, input.Required)
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	saved, err := u.attrRepo.Save(ctx, tenantOrDefault(tenantID), attr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save custom attribute: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (u *customAttributeUsecase) DeleteAttribute(ctx context.Context, tenantID, key string) error {
+	err := u.attrRepo.Delete(ctx, tenantOrDefault(tenantID), key)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrAttributeNotFound
+		}
+		return fmt.Errorf("failed to delete custom attribute: %w", err)
+	}
+
+	return nil
+}
+
+// loadAttributeDefinitions returns the tenant's attribute definitions (none when no repository is configured)
+func loadAttributeDefinitions(ctx context.Context, repo CustomAttributeRepository, tenantID string) ([]*entity.CustomAttribute, error) {
+	if repo == nil {
+		return nil, nil
+	}
+
+	defs, err := repo.FindAll(ctx, tenantOrDefault(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve custom attributes: %w", err)
+	}
+
+	return defs, nil
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockCustomAttributeRepository はカスタム属性リポジトリのモック
+type MockCustomAttributeRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomAttributeRepository) FindAll(ctx context.Context, tenantID string) ([]*entity.CustomAttribute, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CustomAttribute), args.Error(1)
+}
+
+func (m *MockCustomAttributeRepository) Save(ctx context.Context, tenantID string, attr *entity.CustomAttribute) (*entity.CustomAttribute, error) {
+	args := m.Called(ctx, tenantID, attr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomAttribute), args.Error(1)
+}
+
+func (m *MockCustomAttributeRepository) Delete(ctx context.Context, tenantID, key string) error {
+	args := m.Called(ctx, tenantID, key)
+	return args.Error(0)
+}
+
+func storageBoxAttribute(required bool) []*entity.CustomAttribute {
+	return []*entity.CustomAttribute{
+		{Key: "storage_box", Label: "保管ボックス", Type: entity.AttributeTypeEnum, Options: []string{"A-1", "A-2", "B-1"}, Required: required},
+	}
+}
+
+func TestCustomAttributeUsecase_SaveAttribute(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		input       SaveCustomAttributeInput
+		setupMock   func(*MockCustomAttributeRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: enum属性を保存",
+			key:   "storage_box",
+			input: SaveCustomAttributeInput{Label: "保管ボックス", Options: []string{"A-1", "A-2"}},
+			setupMock: func(m *MockCustomAttributeRepository) {
+				m.On("Save", mock.Anything, DefaultTenantID, mock.AnythingOfType("*entity.CustomAttribute")).
+					Return(storageBoxAttribute(false)[0], nil)
+			},
+		},
+		{
+			name:        "異常系: 不正なキー",
+			key:         "Storage-Box",
+			input:       SaveCustomAttributeInput{Label: "保管ボックス", Options: []string{"A-1"}},
+			setupMock:   func(m *MockCustomAttributeRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 選択肢が重複",
+			key:         "storage_box",
+			input:       SaveCustomAttributeInput{Label: "保管ボックス", Options: []string{"A-1", "A-1"}},
+			setupMock:   func(m *MockCustomAttributeRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 日付型に選択肢",
+			key:         "warranty_expires",
+			input:       SaveCustomAttributeInput{Label: "保証期限", Type: entity.AttributeTypeDate, Options: []string{"2026-03-31"}},
+			setupMock:   func(m *MockCustomAttributeRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 未対応の型",
+			key:         "storage_box",
+			input:       SaveCustomAttributeInput{Label: "保管ボックス", Type: "text", Options: []string{"A-1"}},
+			setupMock:   func(m *MockCustomAttributeRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockCustomAttributeRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewCustomAttributeUsecase(mockRepo)
+
+			attr, err := usecase.SaveAttribute(context.Background(), "", tt.key, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, attr)
+				mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "storage_box", attr.Key)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCustomAttributeUsecase_DeleteAttribute(t *testing.T) {
+	mockRepo := new(MockCustomAttributeRepository)
+	mockRepo.On("Delete", mock.Anything, "acme", "missing").Return(domainErrors.ErrAttributeNotFound)
+	usecase := NewCustomAttributeUsecase(mockRepo)
+
+	err := usecase.DeleteAttribute(context.Background(), "acme", "missing")
+
+	assert.ErrorIs(t, err, domainErrors.ErrAttributeNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemUsecase_CreateItem_Attributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		attributes  map[string]string
+		required    bool
+		expectedErr error
+	}{
+		{
+			name:       "正常系: 選択肢に含まれる値",
+			attributes: map[string]string{"storage_box": "A-2"},
+		},
+		{
+			name:     "正常系: 任意属性は省略できる",
+			required: false,
+		},
+		{
+			name:        "異常系: 選択肢にない値",
+			attributes:  map[string]string{"storage_box": "Z-9"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 未定義の属性",
+			attributes:  map[string]string{"color": "red"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 必須属性の欠落",
+			required:    true,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockAttrs := new(MockCustomAttributeRepository)
+			mockAttrs.On("FindAll", mock.Anything, "acme").Return(storageBoxAttribute(tt.required), nil)
+			var saved *entity.Item
+			if tt.expectedErr == nil {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+					Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.Item) }).
+					Return(&entity.Item{ID: 1}, nil)
+			}
+			usecase := NewItemUsecase(mockRepo, nil, mockAttrs, nil, nil)
+
+			_, err := usecase.CreateItem(context.Background(), CreateItemInput{
+				TenantID:      "acme",
+				Name:          "時計1",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: entity.MinorUnits(1000000),
+				PurchaseDate:  "2023-01-01",
+				Attributes:    tt.attributes,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.attributes, saved.Attributes)
+			}
+		})
+	}
+}
+
+func TestItemUsecase_PatchItem_Attributes(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		attributes  map[string]*string
+		current     map[string]string
+		required    bool
+		expected    map[string]string
+		expectedErr error
+	}{
+		{
+			name:       "正常系: 属性値を変更",
+			attributes: map[string]*string{"storage_box": strPtr("B-1")},
+			current:    map[string]string{"storage_box": "A-1"},
+			expected:   map[string]string{"storage_box": "B-1"},
+		},
+		{
+			name:       "正常系: nullで任意属性を削除",
+			attributes: map[string]*string{"storage_box": nil},
+			current:    map[string]string{"storage_box": "A-1"},
+			expected:   map[string]string{},
+		},
+		{
+			name:        "異常系: 選択肢にない値",
+			attributes:  map[string]*string{"storage_box": strPtr("Z-9")},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 必須属性は削除できない",
+			attributes:  map[string]*string{"storage_box": nil},
+			current:     map[string]string{"storage_box": "A-1"},
+			required:    true,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+			existing.ID = 1
+			existing.Attributes = tt.current
+
+			mockRepo := new(MockItemRepository)
+			mockAttrs := new(MockCustomAttributeRepository)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+			mockAttrs.On("FindAll", mock.Anything, DefaultTenantID).Return(storageBoxAttribute(tt.required), nil)
+			if tt.expectedErr == nil {
+				mockRepo.On("Update", mock.Anything, existing).Return(existing, nil)
+			}
+			usecase := NewItemUsecase(mockRepo, nil, mockAttrs, nil, nil)
+
+			item, err := usecase.PatchItem(context.Background(), 1, &UpdateItemRequest{Attributes: tt.attributes, Version: &existing.Version})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, item.Attributes)
+			}
+		})
+	}
+}
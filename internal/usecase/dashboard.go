@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+const (
+	dashboardRecentItems   = 5
+	dashboardTopCategories = 3
+	// dashboardExpiringDays is how many days ahead the dashboard looks for expiry dates
+	dashboardExpiringDays = 30
+)
+
+type DashboardUsecase interface {
+	// GetDashboard returns what the home screen of the app shows, read concurrently
+	GetDashboard(ctx context.Context) (*Dashboard, error)
+}
+
+// Dashboard combines the category summary, the latest items and the upcoming expiry dates
+type Dashboard struct {
+	Categories map[string]int `json:"categories"`
+	Total      int            `json:"total"`
+	// TotalValue is the sum of the purchase prices of the items bought in BaseCurrency
+	TotalValue    int             `json:"total_value"`
+	TopCategories []CategoryCount `json:"top_categories"`
+	RecentItems   []*entity.Item  `json:"recent_items"`
+	ExpiringSoon  []ExpiringItem  `json:"expiring_soon"`
+}
+
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// ExpiringItem is an expiry date (such as the end of a warranty) held in a date-typed custom attribute of an item
+type ExpiringItem struct {
+	ItemID    int64  `json:"item_id"`
+	Name      string `json:"name"`
+	Attribute string `json:"attribute"`
+	ExpiresOn string `json:"expires_on"`
+	DaysLeft  int    `json:"days_left"`
+}
+
+type dashboardUsecase struct {
+	itemUsecase ItemUsecase
+	itemRepo    ItemReader
+	attributes  []string
+	now         func() time.Time
+}
+
+// NewDashboardUsecase creates the dashboard usecase. The counts are read through itemUsecase, so they come from the
+// same (cached) summary as GET /items/summary; attributes are the keys of the expiry date attributes, like the reminders'.
+func NewDashboardUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, attributes []string) DashboardUsecase {
+	return &dashboardUsecase{
+		itemUsecase: itemUsecase,
+		itemRepo:    itemRepo,
+		attributes:  attributes,
+		now:         time.Now,
+	}
+}
+
+// GetDashboard reads the summary, the recent items and the expiry dates at the same time; the first error cancels the other reads
+func (u *dashboardUsecase) GetDashboard(ctx context.Context) (*Dashboard, error) {
+	g, ctx := errgroup.WithContext(ReadOnly(ctx))
+
+	var summary *CategorySummary
+	g.Go(func() error {
+		var err error
+		summary, err = u.itemUsecase.GetCategorySummary(ctx, DateRange{})
+		return err
+	})
+
+	var recent []*entity.Item
+	g.Go(func() error {
+		var err error
+		recent, err = u.itemRepo.FindAll(ctx, ItemQuery{SortBy: "created_at", SortOrder: entity.SortDesc, Limit: dashboardRecentItems})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve recent items: %w", err)
+		}
+		return nil
+	})
+
+	var expiring []ExpiringItem
+	g.Go(func() error {
+		var err error
+		expiring, err = u.expiringItems(ctx)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	dashboard := &Dashboard{
+		Categories:    summary.Categories,
+		Total:         summary.Total,
+		TopCategories: topCategories(summary.Categories),
+		RecentItems:   recent,
+		ExpiringSoon:  expiring,
+	}
+	for _, stats := range summary.Stats {
+		dashboard.TotalValue += stats.Sum
+	}
+	if dashboard.RecentItems == nil {
+		dashboard.RecentItems = []*entity.Item{}
+	}
+	return dashboard, nil
+}
+
+// expiringItems returns the expiry dates from today to dashboardExpiringDays ahead, soonest first
+func (u *dashboardUsecase) expiringItems(ctx context.Context) ([]ExpiringItem, error) {
+	expiring := []ExpiringItem{}
+	if len(u.attributes) == 0 {
+		return expiring, nil
+	}
+
+	today := u.now().Format("2006-01-02")
+	err := u.itemRepo.Iterate(ctx, ItemQuery{}, func(item *entity.Item) error {
+		for _, attribute := range u.attributes {
+			expiresOn, ok := item.Attributes[attribute]
+			if !ok {
+				continue
+			}
+			daysLeft, ok := daysUntil(today, expiresOn)
+			if !ok || daysLeft < 0 || daysLeft > dashboardExpiringDays {
+				continue
+			}
+			expiring = append(expiring, ExpiringItem{
+				ItemID:    item.ID,
+				Name:      item.Name,
+				Attribute: attribute,
+				ExpiresOn: expiresOn,
+				DaysLeft:  daysLeft,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve expiring items: %w", err)
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].DaysLeft < expiring[j].DaysLeft
+	})
+	return expiring, nil
+}
+
+// topCategories returns the categories with the most items (ties in the order of entity.SortedCategories), skipping empty ones
+func topCategories(counts map[string]int) []CategoryCount {
+	top := []CategoryCount{}
+	for _, category := range entity.SortedCategories(counts) {
+		if counts[category] > 0 {
+			top = append(top, CategoryCount{Category: category, Count: counts[category]})
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Count > top[j].Count
+	})
+	if len(top) > dashboardTopCategories {
+		top = top[:dashboardTopCategories]
+	}
+	return top
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestDashboardUsecase_GetDashboard(t *testing.T) {
+	ctx := context.Background()
+	stats := map[string]CategoryStats{
+		"時計":    {Count: 3, Sum: 3000000},
+		"バッグ":   {Count: 1, Sum: 2000000},
+		"ジュエリー": {Count: 2, Sum: 500000},
+		"靴":     {Count: 2, Sum: 100000},
+	}
+	recent := []*entity.Item{{ID: 8, Name: "新しい時計"}, {ID: 7, Name: "スニーカー"}}
+	items := []*entity.Item{
+		{ID: 1, Name: "ロレックス", Attributes: map[string]string{"warranty_expires": "2024-04-09"}},
+		{ID: 2, Name: "バーキン", Attributes: map[string]string{"warranty_expires": "2024-03-09", "insurance_expires": "2024-03-20"}},
+		{ID: 3, Name: "期限切れ", Attributes: map[string]string{"warranty_expires": "2024-03-08"}},
+		{ID: 4, Name: "まだ先", Attributes: map[string]string{"warranty_expires": "2024-04-10"}},
+		{ID: 5, Name: "日付でない", Attributes: map[string]string{"warranty_expires": "未定"}},
+		{ID: 6, Name: "属性なし"},
+	}
+
+	newUsecase := func(itemRepo *MockItemRepository) DashboardUsecase {
+		u := NewDashboardUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, []string{"warranty_expires", "insurance_expires"}).(*dashboardUsecase)
+		u.now = func() time.Time { return time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC) }
+		return u
+	}
+
+	t.Run("正常系: 集計・最近のアイテム・期限の近いアイテムをまとめて返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(stats, nil)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{SortBy: "created_at", SortOrder: "desc", Limit: 5}).Return(recent, nil)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		dashboard, err := newUsecase(itemRepo).GetDashboard(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 8, dashboard.Total)
+		assert.Equal(t, 0, dashboard.Categories["その他"])
+		assert.Equal(t, 5600000, dashboard.TotalValue)
+		// 同数のカテゴリーはカテゴリーの定義順
+		assert.Equal(t, []CategoryCount{{"時計", 3}, {"ジュエリー", 2}, {"靴", 2}}, dashboard.TopCategories)
+		assert.Equal(t, recent, dashboard.RecentItems)
+		assert.Equal(t, []ExpiringItem{
+			{ItemID: 2, Name: "バーキン", Attribute: "insurance_expires", ExpiresOn: "2024-03-20", DaysLeft: 10},
+			{ItemID: 1, Name: "ロレックス", Attribute: "warranty_expires", ExpiresOn: "2024-04-09", DaysLeft: 30},
+		}, dashboard.ExpiringSoon)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アイテムがなくても空の配列を返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(map[string]CategoryStats{}, nil)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), nil)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{}, nil))
+
+		dashboard, err := newUsecase(itemRepo).GetDashboard(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []CategoryCount{}, dashboard.TopCategories)
+		assert.Equal(t, []*entity.Item{}, dashboard.RecentItems)
+		assert.Equal(t, []ExpiringItem{}, dashboard.ExpiringSoon)
+	})
+
+	t.Run("異常系: いずれかの読み込みに失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(stats, nil)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), domainErrors.ErrDatabaseError)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		_, err := newUsecase(itemRepo).GetDashboard(ctx)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
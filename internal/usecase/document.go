@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// DocumentTypes are the accepted document file types, detected from the file contents, and the extensions they are stored with:
+// PDFs, and images for photos of paper documents
+var DocumentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+}
+
+// DocumentUsecase manages the documents of items, such as receipts and authenticity certificates kept as evidence for insurance claims
+type DocumentUsecase interface {
+	UploadDocument(ctx context.Context, actor string, itemID int64, upload DocumentUpload) (*entity.ItemDocument, error)
+	// ListDocuments returns the documents of an item in upload order, only those of docType unless it is empty
+	ListDocuments(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error)
+	// OpenDocument returns a document with its file; the caller closes the file
+	OpenDocument(ctx context.Context, itemID, id int64) (*entity.ItemDocument, io.ReadCloser, error)
+	DeleteDocument(ctx context.Context, actor string, itemID, id int64) error
+	// DeleteOrphanedFiles removes the stored files no document refers to, such as the files of deleted items,
+	// that were last modified before the given time, and returns how many were removed
+	DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error)
+}
+
+// DocumentUpload is an uploaded file of Size bytes tagged with a document type.
+// Content is read twice: once to hash it, then to store it.
+type DocumentUpload struct {
+	Type     string
+	FileName string
+	Size     int64
+	Content  io.ReadSeeker
+}
+
+type documentUsecase struct {
+	itemRepo     ItemReader
+	documentRepo ItemDocumentRepository
+	storage      Storage
+	uow          UnitOfWork
+	maxSize      int64
+	now          func() time.Time
+}
+
+// NewDocumentUsecase creates the document usecase accepting files of up to maxSize bytes;
+// uow may be nil, in which case no transactions are used
+func NewDocumentUsecase(itemRepo ItemReader, documentRepo ItemDocumentRepository, storage Storage, uow UnitOfWork, maxSize int64) DocumentUsecase {
+	return &documentUsecase{
+		itemRepo:     itemRepo,
+		documentRepo: documentRepo,
+		storage:      storage,
+		uow:          uow,
+		maxSize:      maxSize,
+		now:          time.Now,
+	}
+}
+
+func (u *documentUsecase) UploadDocument(ctx context.Context, actor string, itemID int64, upload DocumentUpload) (*entity.ItemDocument, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	file, err := checkUpload(upload.Content, upload.Size, u.maxSize, DocumentTypes,
+		domainErrors.ErrDocumentTooLarge, domainErrors.ErrUnsupportedDocumentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := hashUpload(upload.Content, upload.Size, file); err != nil {
+		return nil, err
+	}
+	document, err := entity.NewItemDocument(itemID, upload.Type, upload.FileName, file.contentType, upload.Size, file.sha256, file.key, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	var created *entity.ItemDocument
+	err = u.changeDocuments(ctx, actor, itemID, func(ctx context.Context) error {
+		// Stored again even if an identical file is already stored, as with images
+		if err := u.storage.Put(ctx, document.StorageKey, file.content, document.Size, document.ContentType); err != nil {
+			return fmt.Errorf("failed to save document: %w", err)
+		}
+
+		created, err = u.documentRepo.Create(ctx, document)
+		if err != nil {
+			return fmt.Errorf("failed to create document: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// The file is saved inside the transaction, so it is removed again if anything after it failed
+		u.removeFile(ctx, document)
+		return nil, err
+	}
+	ensureStored(ctx, u.storage, file, upload.Content, upload.Size)
+
+	return created, nil
+}
+
+func (u *documentUsecase) ListDocuments(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if docType != "" && !entity.IsDocumentType(docType) {
+		return nil, fmt.Errorf("%w: type must be one of: receipt, certificate, appraisal", domainErrors.ErrInvalidInput)
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	documents, err := u.documentRepo.FindByItemID(ctx, itemID, docType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+
+	if documents == nil {
+		documents = []*entity.ItemDocument{}
+	}
+
+	return documents, nil
+}
+
+func (u *documentUsecase) OpenDocument(ctx context.Context, itemID, id int64) (*entity.ItemDocument, io.ReadCloser, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, nil, domainErrors.ErrInvalidInput
+	}
+
+	document, err := u.findDocument(ReadOnly(ctx), itemID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := u.storage.Open(ctx, document.StorageKey)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, nil, domainErrors.NotFound(domainErrors.ErrDocumentNotFound, "file of document %d is missing", id)
+		}
+		return nil, nil, fmt.Errorf("failed to open document: %w", err)
+	}
+
+	return document, file, nil
+}
+
+func (u *documentUsecase) DeleteDocument(ctx context.Context, actor string, itemID, id int64) error {
+	if itemID <= 0 || id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	var deleted *entity.ItemDocument
+	err := u.changeDocuments(ctx, actor, itemID, func(ctx context.Context) error {
+		document, err := u.findDocument(ctx, itemID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := u.documentRepo.Delete(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to delete document: %w", err)
+		}
+		deleted = document
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The file is removed only once the deletion is committed
+	u.removeFile(ctx, deleted)
+	return nil
+}
+
+func (u *documentUsecase) DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	return deleteOrphanedFiles(ctx, u.storage, u.documentRepo.ExistingStorageKeys, modifiedBefore)
+}
+
+// changeDocuments runs fn in a transaction after checking that actor may change the item.
+// Unlike images, documents are not part of the item, so its version is left as is.
+func (u *documentUsecase) changeDocuments(ctx context.Context, actor string, itemID int64, fn func(ctx context.Context) error) error {
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		return fn(ctx)
+	})
+}
+
+// findDocument retrieves a document of the item; documents of other items are reported as not found
+func (u *documentUsecase) findDocument(ctx context.Context, itemID, id int64) (*entity.ItemDocument, error) {
+	document, err := u.documentRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve document: %w", err)
+	}
+	if document.ItemID != itemID {
+		return nil, domainErrors.ErrDocumentNotFound
+	}
+
+	return document, nil
+}
+
+// removeFile removes the file of a document whose record is gone unless other documents have the same contents;
+// failures are logged, as with images
+func (u *documentUsecase) removeFile(ctx context.Context, document *entity.ItemDocument) {
+	removeUnreferencedFiles(ctx, u.storage, u.documentRepo.ExistingStorageKeys, []string{document.StorageKey})
+}
+
+type documentItemUsecase struct {
+	ItemUsecase
+	documentRepo ItemDocumentRepository
+}
+
+// NewDocumentItemUsecase deletes the documents of items purged through inner.
+// The files are left to DeleteOrphanedFiles, so they are only removed once the deletion is committed.
+func NewDocumentItemUsecase(inner ItemUsecase, documentRepo ItemDocumentRepository) ItemUsecase {
+	return &documentItemUsecase{
+		ItemUsecase:  inner,
+		documentRepo: documentRepo,
+	}
+}
+
+// PurgeItem deletes the document records along with the item, in the caller's transaction if any.
+// They are kept while the item is in the trash, so a restored item still has them.
+func (u *documentItemUsecase) PurgeItem(ctx context.Context, id int64) error {
+	if err := u.ItemUsecase.PurgeItem(ctx, id); err != nil {
+		return err
+	}
+
+	documents, err := u.documentRepo.FindByItemID(ctx, id, "")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+	for _, document := range documents {
+		if err := u.documentRepo.Delete(ctx, document.ID); err != nil && !domainErrors.IsNotFoundError(err) {
+			return fmt.Errorf("failed to delete document: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemDocumentRepository は書類リポジトリのモック
+type MockItemDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemDocumentRepository) Create(ctx context.Context, document *entity.ItemDocument) (*entity.ItemDocument, error) {
+	args := m.Called(ctx, document)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemDocument), args.Error(1)
+}
+
+func (m *MockItemDocumentRepository) FindByID(ctx context.Context, id int64) (*entity.ItemDocument, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemDocument), args.Error(1)
+}
+
+func (m *MockItemDocumentRepository) FindByItemID(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error) {
+	args := m.Called(ctx, itemID, docType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemDocument), args.Error(1)
+}
+
+func (m *MockItemDocumentRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemDocumentRepository) ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+// PDF のシグネチャで始まるファイル
+var testPDF = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\n")
+
+func newTestDocumentUsecase(itemRepo ItemRepository, documentRepo ItemDocumentRepository, storage Storage) DocumentUsecase {
+	u := NewDocumentUsecase(itemRepo, documentRepo, storage, nil, 64).(*documentUsecase)
+	u.now = func() time.Time { return imageToday }
+	return u
+}
+
+func TestDocumentUsecase_UploadDocument(t *testing.T) {
+	tests := []struct {
+		name         string
+		actor        string
+		docType      string
+		fileName     string
+		content      []byte
+		item         *entity.Item
+		expectedType string
+		expectedErr  error
+	}{
+		{
+			name: "正常系: PDF の領収書を登録する", actor: "alice", docType: "receipt", fileName: "receipt.pdf", content: testPDF,
+			item: &entity.Item{ID: 1, OwnerID: "alice"}, expectedType: "application/pdf",
+		},
+		{
+			name: "正常系: 写真の鑑定書を登録する", actor: "alice", docType: "Certificate", fileName: "cert.png", content: testPNG,
+			item: &entity.Item{ID: 1}, expectedType: "image/png",
+		},
+		{name: "異常系: 上限より大きい", actor: "alice", docType: "receipt", fileName: "big.pdf", content: append(testPDF, make([]byte, 64)...), expectedErr: domainErrors.ErrDocumentTooLarge},
+		{name: "異常系: PDF・画像でない", actor: "alice", docType: "receipt", fileName: "receipt.pdf", content: []byte("hello, world"), expectedErr: domainErrors.ErrUnsupportedDocumentType},
+		{name: "異常系: 種類がない", actor: "alice", fileName: "receipt.pdf", content: testPDF, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 不正な種類", actor: "alice", docType: "invoice", fileName: "receipt.pdf", content: testPDF, expectedErr: domainErrors.ErrInvalidInput},
+		{
+			name: "異常系: 所有者以外", actor: "bob", docType: "receipt", fileName: "receipt.pdf", content: testPDF,
+			item: &entity.Item{ID: 1, OwnerID: "alice"}, expectedErr: domainErrors.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			if tt.item != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+			}
+			documentRepo := new(MockItemDocumentRepository)
+			var created *entity.ItemDocument
+			documentRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				created = args.Get(1).(*entity.ItemDocument)
+			}).Return(&entity.ItemDocument{ID: 7, ItemID: 1}, nil).Maybe()
+			documentRepo.On("ExistingStorageKeys", mock.Anything, mock.Anything).Return(map[string]bool{}, nil).Maybe()
+			storage := newMemoryStorage()
+
+			document, err := newTestDocumentUsecase(itemRepo, documentRepo, storage).UploadDocument(context.Background(), tt.actor, 1,
+				DocumentUpload{Type: tt.docType, FileName: tt.fileName, Size: int64(len(tt.content)), Content: bytes.NewReader(tt.content)})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, document)
+				assert.Empty(t, storage.files, "失敗したらファイルを残さない")
+				documentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), document.ID)
+			require.NotNil(t, created)
+			assert.Equal(t, strings.ToLower(tt.docType), created.Type)
+			assert.Equal(t, tt.expectedType, created.ContentType)
+			sum := sha256.Sum256(tt.content)
+			assert.Equal(t, hex.EncodeToString(sum[:]), created.SHA256)
+			assert.Equal(t, tt.content, storage.files[created.StorageKey])
+			itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDocumentUsecase_ListDocuments(t *testing.T) {
+	t.Run("正常系: 種類で絞り込む", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		documentRepo.On("FindByItemID", mock.Anything, int64(1), "certificate").Return([]*entity.ItemDocument{{ID: 2, Type: "certificate"}}, nil)
+
+		documents, err := newTestDocumentUsecase(itemRepo, documentRepo, nil).ListDocuments(context.Background(), 1, "certificate")
+
+		require.NoError(t, err)
+		assert.Len(t, documents, 1)
+	})
+
+	t.Run("正常系: 書類がなければ空の一覧", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		documentRepo.On("FindByItemID", mock.Anything, int64(1), "").Return(nil, nil)
+
+		documents, err := newTestDocumentUsecase(itemRepo, documentRepo, nil).ListDocuments(context.Background(), 1, "")
+
+		require.NoError(t, err)
+		assert.NotNil(t, documents)
+		assert.Empty(t, documents)
+	})
+
+	t.Run("異常系: 不正な種類", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := newTestDocumentUsecase(itemRepo, new(MockItemDocumentRepository), nil).ListDocuments(context.Background(), 1, "invoice")
+
+		assert.EqualError(t, err, "invalid input: type must be one of: receipt, certificate, appraisal")
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDocumentUsecase_OpenDocument(t *testing.T) {
+	document := &entity.ItemDocument{ID: 5, ItemID: 1, Type: "receipt", ContentType: "application/pdf", StorageKey: "abc.pdf"}
+
+	tests := []struct {
+		name        string
+		itemID      int64
+		files       map[string][]byte
+		expectedErr error
+	}{
+		{name: "正常系: ファイルを開く", itemID: 1, files: map[string][]byte{"abc.pdf": testPDF}},
+		{name: "異常系: 別のアイテムの書類", itemID: 2, files: map[string][]byte{"abc.pdf": testPDF}, expectedErr: domainErrors.ErrDocumentNotFound},
+		{name: "異常系: ファイルがない", itemID: 1, files: map[string][]byte{}, expectedErr: domainErrors.ErrDocumentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documentRepo := new(MockItemDocumentRepository)
+			documentRepo.On("FindByID", mock.Anything, int64(5)).Return(document, nil)
+			storage := newMemoryStorage()
+			storage.files = tt.files
+
+			found, file, err := newTestDocumentUsecase(nil, documentRepo, storage).OpenDocument(context.Background(), tt.itemID, 5)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, file)
+				return
+			}
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, document, found)
+			data, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, testPDF, data)
+		})
+	}
+}
+
+func TestDocumentUsecase_DeleteDocument(t *testing.T) {
+	t.Run("正常系: 記録とファイルを削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		documentRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ItemDocument{ID: 5, ItemID: 1, StorageKey: "abc.pdf"}, nil)
+		documentRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		documentRepo.On("ExistingStorageKeys", mock.Anything, []string{"abc.pdf"}).Return(map[string]bool{}, nil)
+		storage := newMemoryStorage()
+		storage.files["abc.pdf"] = testPDF
+
+		err := newTestDocumentUsecase(itemRepo, documentRepo, storage).DeleteDocument(context.Background(), "alice", 1, 5)
+
+		require.NoError(t, err)
+		assert.Empty(t, storage.files)
+	})
+
+	t.Run("正常系: 同じ内容の書類が残っていればファイルは残す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		documentRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ItemDocument{ID: 5, ItemID: 1, StorageKey: "abc.pdf"}, nil)
+		documentRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		documentRepo.On("ExistingStorageKeys", mock.Anything, []string{"abc.pdf"}).Return(map[string]bool{"abc.pdf": true}, nil)
+		storage := newMemoryStorage()
+		storage.files["abc.pdf"] = testPDF
+
+		err := newTestDocumentUsecase(itemRepo, documentRepo, storage).DeleteDocument(context.Background(), "alice", 1, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPDF, storage.files["abc.pdf"])
+	})
+
+	t.Run("異常系: 所有者以外は削除できない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		storage := newMemoryStorage()
+		storage.files["abc.pdf"] = testPDF
+
+		err := newTestDocumentUsecase(itemRepo, documentRepo, storage).DeleteDocument(context.Background(), "bob", 1, 5)
+
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		assert.Len(t, storage.files, 1)
+		documentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDocumentItemUsecase_PurgeItem(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.TrashedItem{Item: &entity.Item{ID: 1}}, nil)
+	itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	documentRepo := new(MockItemDocumentRepository)
+	documentRepo.On("FindByItemID", mock.Anything, int64(1), "").Return([]*entity.ItemDocument{{ID: 3, ItemID: 1}}, nil)
+	documentRepo.On("Delete", mock.Anything, int64(3)).Return(nil)
+
+	err := NewDocumentItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), documentRepo).PurgeItem(context.Background(), 1)
+
+	require.NoError(t, err)
+	documentRepo.AssertExpectations(t)
+}
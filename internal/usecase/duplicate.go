@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// Names and brands match when at least this similar (1 - edit distance / length of the longer one)
+	duplicateMinSimilarity = 0.8
+	// Purchase dates match when at most this many days apart
+	duplicateMaxDateDays = 3
+	// maxDuplicateCandidates is the number of candidates reported, the most similar first
+	maxDuplicateCandidates = 5
+)
+
+// DuplicateCandidate is an existing item that the item being created closely matches
+type DuplicateCandidate struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Brand        string `json:"brand"`
+	PurchaseDate string `json:"purchase_date"`
+	// Similarity averages the similarity of the name, the brand and the purchase date, from 0.8 to 1
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateItemError is returned when an item to be created looks like an item that already exists.
+// It is a conflict; the item can be created anyway with CreateItemInput.AllowDuplicate.
+type DuplicateItemError struct {
+	Candidates []DuplicateCandidate
+}
+
+func (e *DuplicateItemError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		ids[i] = fmt.Sprint(candidate.ID)
+	}
+	return fmt.Sprintf("%s: similar to item %s", domainErrors.ErrDuplicateItem.Error(), strings.Join(ids, ", "))
+}
+
+func (e *DuplicateItemError) Unwrap() []error {
+	return []error{domainErrors.ErrDuplicateItem, domainErrors.ErrConflict}
+}
+
+type duplicateCheckingItemUsecase struct {
+	ItemUsecase
+	itemRepo ItemReader
+}
+
+// NewDuplicateCheckingItemUsecase refuses to create an item whose name, brand and purchase date closely match
+// an item of the same category and owner, unless the input allows duplicates.
+// The check is not atomic with the create: two identical requests at the same time may both succeed.
+func NewDuplicateCheckingItemUsecase(inner ItemUsecase, itemRepo ItemReader) ItemUsecase {
+	return &duplicateCheckingItemUsecase{
+		ItemUsecase: inner,
+		itemRepo:    itemRepo,
+	}
+}
+
+func (u *duplicateCheckingItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	if !input.AllowDuplicate {
+		candidates, err := u.findDuplicates(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			return nil, &DuplicateItemError{Candidates: candidates}
+		}
+	}
+
+	return u.ItemUsecase.CreateItem(ctx, input)
+}
+
+func (u *duplicateCheckingItemUsecase) findDuplicates(ctx context.Context, input CreateItemInput) ([]DuplicateCandidate, error) {
+	// Invalid input is left to the inner usecase to report
+	matcher, ok := newDuplicateMatcher(input)
+	if !ok {
+		return nil, nil
+	}
+
+	query := ItemQuery{ItemFilter: ItemFilter{
+		Category: strings.TrimSpace(input.Category),
+		OwnerID:  strings.TrimSpace(input.OwnerID),
+	}}
+	var candidates []DuplicateCandidate
+	err := u.itemRepo.Iterate(ReadOnly(ctx), query, func(item *entity.Item) error {
+		if candidate, ok := matcher.match(item); ok {
+			candidates = append(candidates, candidate)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	return rankDuplicateCandidates(candidates), nil
+}
+
+// duplicateMatcher matches items against the name, brand and purchase date of an item to be created.
+// The category and owner are left to the caller to compare.
+type duplicateMatcher struct {
+	name         string
+	brand        string
+	purchaseDate time.Time
+}
+
+// newDuplicateMatcher returns false when the input has no name or no valid purchase date to match
+func newDuplicateMatcher(input CreateItemInput) (duplicateMatcher, bool) {
+	purchaseDate, err := time.Parse("2006-01-02", strings.TrimSpace(input.PurchaseDate))
+	if err != nil {
+		return duplicateMatcher{}, false
+	}
+	name := normalizeForMatch(entity.SanitizeString(input.Name))
+	if name == "" {
+		return duplicateMatcher{}, false
+	}
+
+	return duplicateMatcher{
+		name:         name,
+		brand:        normalizeForMatch(entity.SanitizeString(input.Brand)),
+		purchaseDate: purchaseDate,
+	}, true
+}
+
+// match returns the item as a candidate when its name, brand and purchase date are all close enough
+func (m duplicateMatcher) match(item *entity.Item) (DuplicateCandidate, bool) {
+	itemDate, err := time.Parse("2006-01-02", item.PurchaseDate)
+	if err != nil {
+		return DuplicateCandidate{}, false
+	}
+	days := math.Abs(m.purchaseDate.Sub(itemDate).Hours() / 24)
+	if days > duplicateMaxDateDays {
+		return DuplicateCandidate{}, false
+	}
+	nameSimilarity := similarity(m.name, normalizeForMatch(item.Name))
+	if nameSimilarity < duplicateMinSimilarity {
+		return DuplicateCandidate{}, false
+	}
+	brandSimilarity := similarity(m.brand, normalizeForMatch(item.Brand))
+	if brandSimilarity < duplicateMinSimilarity {
+		return DuplicateCandidate{}, false
+	}
+
+	dateSimilarity := 1 - days/(duplicateMaxDateDays+1)
+	return DuplicateCandidate{
+		ID:           item.ID,
+		Name:         item.Name,
+		Brand:        item.Brand,
+		PurchaseDate: item.PurchaseDate,
+		Similarity:   math.Round((nameSimilarity+brandSimilarity+dateSimilarity)/3*100) / 100,
+	}, true
+}
+
+// rankDuplicateCandidates orders the candidates the most similar first, and keeps the first maxDuplicateCandidates
+func rankDuplicateCandidates(candidates []DuplicateCandidate) []DuplicateCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Similarity != candidates[j].Similarity {
+			return candidates[i].Similarity > candidates[j].Similarity
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+	if len(candidates) > maxDuplicateCandidates {
+		candidates = candidates[:maxDuplicateCandidates]
+	}
+	return candidates
+}
+
+// normalizeForMatch folds case and full-width letters and digits, and drops spaces and punctuation,
+// so that "ROLEX Daytona", "rolex  daytona" and "ＲＯＬＥＸ デイトナ" compare on their letters only
+func normalizeForMatch(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		// Full-width ASCII (U+FF01-U+FF5E) to ASCII
+		if r >= 0xFF01 && r <= 0xFF5E {
+			r -= 0xFEE0
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// similarity is 1 minus the Levenshtein distance of a and b divided by the length of the longer, in runes
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longer := max(len(ra), len(rb))
+	if longer == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longer)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestDuplicateCheckingItemUsecase_CreateItem(t *testing.T) {
+	existing := []*entity.Item{
+		{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+		{ID: 2, Name: "ロレックス サブマリーナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+		{ID: 3, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-02-15"},
+		{ID: 4, Name: "ロレックス デイトナ", Category: "時計", Brand: "OMEGA", PurchaseDate: "2023-01-15"},
+	}
+	input := CreateItemInput{
+		OwnerID:       "alice",
+		Name:          "ロレックス　デイトナ",
+		Category:      "時計",
+		Brand:         "Ｒｏｌｅｘ",
+		PurchasePrice: entity.MinorUnits(1500000),
+		PurchaseDate:  "2023-01-16",
+	}
+
+	tests := []struct {
+		name           string
+		input          func(CreateItemInput) CreateItemInput
+		expectedIDs    []int64
+		expectedCreate bool
+	}{
+		{
+			name:        "異常系: 名前・ブランド・購入日がほぼ同じアイテムがある",
+			input:       func(in CreateItemInput) CreateItemInput { return in },
+			expectedIDs: []int64{1},
+		},
+		{
+			name:           "正常系: allow_duplicate で重複を承知して登録する",
+			input:          func(in CreateItemInput) CreateItemInput { in.AllowDuplicate = true; return in },
+			expectedCreate: true,
+		},
+		{
+			name:           "正常系: 購入日が離れていれば重複ではない",
+			input:          func(in CreateItemInput) CreateItemInput { in.PurchaseDate = "2023-01-25"; return in },
+			expectedCreate: true,
+		},
+		{
+			name:           "正常系: 名前が違えば重複ではない",
+			input:          func(in CreateItemInput) CreateItemInput { in.Name = "オメガ スピードマスター"; return in },
+			expectedCreate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("Iterate", mock.Anything, ItemQuery{ItemFilter: ItemFilter{Category: "時計", OwnerID: "alice"}}, mock.Anything).Return(iterateItems(existing, nil)).Maybe()
+			itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 10}, nil).Maybe()
+			u := NewDuplicateCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo)
+
+			item, err := u.CreateItem(context.Background(), tt.input(input))
+
+			if !tt.expectedCreate {
+				var duplicateErr *DuplicateItemError
+				require.ErrorAs(t, err, &duplicateErr)
+				assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+				assert.ErrorIs(t, err, domainErrors.ErrConflict)
+				ids := make([]int64, len(duplicateErr.Candidates))
+				for i, candidate := range duplicateErr.Candidates {
+					ids[i] = candidate.ID
+				}
+				assert.Equal(t, tt.expectedIDs, ids)
+				itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(10), item.ID)
+		})
+	}
+
+	t.Run("正常系: 類似度が低いアイテムは候補に含めない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, mock.Anything, mock.Anything).Return(iterateItems([]*entity.Item{
+			{ID: 5, Name: "Speedmaster Pro", Brand: "OMEGA", PurchaseDate: "2024-05-03"},
+			{ID: 6, Name: "Speedmaster", Brand: "OMEGA", PurchaseDate: "2024-05-01"},
+		}, nil))
+		u := NewDuplicateCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo)
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "speedmaster", Category: "時計", Brand: "Omega", PurchaseDate: "2024-05-01"})
+
+		var duplicateErr *DuplicateItemError
+		require.ErrorAs(t, err, &duplicateErr)
+		require.Len(t, duplicateErr.Candidates, 1)
+		assert.Equal(t, DuplicateCandidate{ID: 6, Name: "Speedmaster", Brand: "OMEGA", PurchaseDate: "2024-05-01", Similarity: 1}, duplicateErr.Candidates[0])
+	})
+
+	t.Run("異常系: 入力が不正なら重複を探さずに検証エラーを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u := NewDuplicateCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo)
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023/01/15"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{name: "正常系: 大文字・小文字と空白・記号を無視する", a: "ROLEX Daytona", b: "rolex-daytona", expected: 1},
+		{name: "正常系: 全角の英数字を半角として比べる", a: "ＲＯＬＥＸ １１６５００", b: "Rolex 116500", expected: 1},
+		{name: "正常系: 1文字違い", a: "Speedmaster", b: "Speedmastr", expected: 1 - 1.0/11},
+		{name: "正常系: まったく違う", a: "abc", b: "xyz", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, similarity(normalizeForMatch(tt.a), normalizeForMatch(tt.b)), 1e-9)
+		})
+	}
+}
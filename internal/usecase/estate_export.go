@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	ExportKindEstate = "estate"
+
+	minPassphraseLength = 12
+)
+
+// EstateInventory is the content of an estate package
+type EstateInventory struct {
+	Owner          string
+	GeneratedAt    time.Time
+	Items          []*entity.Item
+	TotalValue     int
+	CategoryTotals map[string]int
+	// Conversion is set when the totals are also to be shown in another currency
+	Conversion *Conversion
+}
+
+// InventoryRenderer renders an estate inventory as a printable document
+type InventoryRenderer interface {
+	RenderInventory(inv *EstateInventory) ([]byte, error)
+}
+
+// PackageEncrypter protects a generated package with a passphrase
+type PackageEncrypter interface {
+	Encrypt(plaintext []byte, passphrase string) ([]byte, error)
+}
+
+type EstateExportUsecase interface {
+	StartEstateExport(ctx context.Context, input EstateExportInput) (*entity.ExportJob, error)
+	GetExportJob(ctx context.Context, id string) (*entity.ExportJob, error)
+}
+
+type EstateExportInput struct {
+	OwnerID    string `json:"-"`
+	Passphrase string `json:"passphrase"`
+	// Currency the totals are also shown in, besides BaseCurrency (optional)
+	Currency string `json:"currency,omitempty"`
+}
+
+type estateExportUsecase struct {
+	itemRepo  ItemReader
+	imageRepo ItemImageRepository
+	jobRepo   ExportJobRepository
+	renderer  InventoryRenderer
+	encrypter PackageEncrypter
+	converter CurrencyConverter
+	async     func(func())
+}
+
+// NewEstateExportUsecase creates the estate export usecase.
+// imageRepo may be nil, in which case the inventory lists no images.
+// converter may be nil, in which case packages can only be generated in BaseCurrency.
+func NewEstateExportUsecase(itemRepo ItemReader, imageRepo ItemImageRepository, jobRepo ExportJobRepository, renderer InventoryRenderer, encrypter PackageEncrypter, converter CurrencyConverter) EstateExportUsecase {
+	return &estateExportUsecase{
+		itemRepo:  itemRepo,
+		imageRepo: imageRepo,
+		jobRepo:   jobRepo,
+		renderer:  renderer,
+		encrypter: encrypter,
+		converter: converter,
+		async:     func(f func()) { go f() },
+	}
+}
+
+// StartEstateExport queues the package generation and returns the pending job.
+// The passphrase is only held in memory until the package has been encrypted.
+// The exchange rate is fetched before the job is queued, so that an unsupported currency or an unavailable provider is reported to the caller.
+func (u *estateExportUsecase) StartEstateExport(ctx context.Context, input EstateExportInput) (*entity.ExportJob, error) {
+	if utf8.RuneCountInString(input.Passphrase) < minPassphraseLength {
+		return nil, fmt.Errorf("%w: passphrase must be at least %d characters", domainErrors.ErrInvalidInput, minPassphraseLength)
+	}
+
+	var conversion *Conversion
+	if input.Currency != "" {
+		currency, err := NormalizeCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if currency != BaseCurrency {
+			if u.converter == nil {
+				return nil, fmt.Errorf("%w: currency must be one of: %s", domainErrors.ErrInvalidInput, BaseCurrency)
+			}
+			conversion, err = u.converter.Conversion(ctx, BaseCurrency, currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	job := &entity.ExportJob{
+		ID:        id,
+		Kind:      ExportKindEstate,
+		Status:    entity.ExportStatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := u.jobRepo.Save(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create export job: %w", err)
+	}
+
+	snapshot := *job
+	u.async(func() {
+		// リクエストの終了後も処理を続けるため、新しいコンテキストを使う
+		u.runEstateExport(context.Background(), &snapshot, input, conversion)
+	})
+
+	return job, nil
+}
+
+func (u *estateExportUsecase) GetExportJob(ctx context.Context, id string) (*entity.ExportJob, error) {
+	job, err := u.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrExportNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve export job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (u *estateExportUsecase) runEstateExport(ctx context.Context, job *entity.ExportJob, input EstateExportInput, conversion *Conversion) {
+	job.Status = entity.ExportStatusRunning
+	_ = u.jobRepo.Save(ctx, job)
+
+	data, err := u.buildEstatePackage(ctx, input, conversion)
+
+	now := time.Now()
+	job.CompletedAt = &now
+	if err != nil {
+		job.Status = entity.ExportStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = entity.ExportStatusCompleted
+		job.Data = data
+		job.Size = len(data)
+	}
+	_ = u.jobRepo.Save(ctx, job)
+}
+
+func (u *estateExportUsecase) buildEstatePackage(ctx context.Context, input EstateExportInput, conversion *Conversion) ([]byte, error) {
+	// 所有者を指定した場合はその所有者のアイテムのみ
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{ItemFilter: ItemFilter{OwnerID: input.OwnerID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	if err := u.setImageIDs(ctx, items); err != nil {
+		return nil, err
+	}
+
+	inv := &EstateInventory{
+		Owner:          input.OwnerID,
+		GeneratedAt:    time.Now(),
+		CategoryTotals: make(map[string]int),
+		Conversion:     conversion,
+	}
+	for _, item := range items {
+		inv.Items = append(inv.Items, item)
+		inv.TotalValue += item.PurchasePrice
+		inv.CategoryTotals[item.Category] += item.PurchasePrice
+	}
+
+	doc, err := u.renderer.RenderInventory(inv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render inventory: %w", err)
+	}
+
+	encrypted, err := u.encrypter.Encrypt(doc, input.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt package: %w", err)
+	}
+
+	return encrypted, nil
+}
+
+// setImageIDs sets the image IDs of the items, so that the inventory shows which photos belong to which item
+func (u *estateExportUsecase) setImageIDs(ctx context.Context, items []*entity.Item) error {
+	if u.imageRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	imageIDs, err := u.imageRepo.ListIDsByItem(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	for _, item := range items {
+		item.ImageIDs = imageIDs[item.ID]
+	}
+	return nil
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeJobRepository は保存されたジョブを記録するだけのリポジトリ
+type fakeJobRepository struct {
+	jobs map[string]entity.ExportJob
+}
+
+func (r *fakeJobRepository) Save(ctx context.Context, job *entity.ExportJob) error {
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *fakeJobRepository) FindByID(ctx context.Context, id string) (*entity.ExportJob, error) {
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domainErrors.ErrExportNotFound
+	}
+	return &job, nil
+}
+
+// MockInventoryRenderer は資産目録レンダラーのモック
+type MockInventoryRenderer struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRenderer) RenderInventory(inv *EstateInventory) ([]byte, error) {
+	args := m.Called(inv)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// reverseEncrypter はテスト用にバイト列を反転するだけの暗号化
+type reverseEncrypter struct{}
+
+func (reverseEncrypter) Encrypt(plaintext []byte, passphrase string) ([]byte, error) {
+	out := make([]byte, len(plaintext))
+	for i, b := range plaintext {
+		out[len(out)-1-i] = b
+	}
+	return out, nil
+}
+
+func newSyncEstateExportUsecase(itemRepo ItemRepository, jobs ExportJobRepository, renderer InventoryRenderer) EstateExportUsecase {
+	u := NewEstateExportUsecase(itemRepo, nil, jobs, renderer, reverseEncrypter{}, nil).(*estateExportUsecase)
+	u.async = func(f func()) { f() }
+	return u
+}
+
+func TestEstateExportUsecase_StartEstateExport(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchasePrice: 1000, OwnerID: "alice"},
+		{ID: 2, Category: "バッグ", PurchasePrice: 500, OwnerID: "bob"},
+		{ID: 3, Category: "時計", PurchasePrice: 200, OwnerID: "alice"},
+	}
+
+	t.Run("正常系: 所有者のアイテムだけを暗号化して出力", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{ItemFilter: ItemFilter{OwnerID: "alice"}}).Return([]*entity.Item{items[0], items[2]}, nil)
+		renderer := new(MockInventoryRenderer)
+		renderer.On("RenderInventory", mock.MatchedBy(func(inv *EstateInventory) bool {
+			return len(inv.Items) == 2 && inv.TotalValue == 1200 && inv.CategoryTotals["時計"] == 1200
+		})).Return([]byte("abc"), nil)
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(itemRepo, jobs, renderer)
+
+		job, err := usecase.StartEstateExport(context.Background(), EstateExportInput{OwnerID: "alice", Passphrase: "correct horse battery"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExportStatusPending, job.Status)
+
+		done, err := usecase.GetExportJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExportStatusCompleted, done.Status)
+		assert.Equal(t, []byte("cba"), done.Data)
+		assert.Equal(t, 3, done.Size)
+		assert.NotNil(t, done.CompletedAt)
+		renderer.AssertExpectations(t)
+	})
+
+	t.Run("正常系: アイテムの画像IDを目録に含める", func(t *testing.T) {
+		withImage := &entity.Item{ID: 1, Category: "時計", PurchasePrice: 1000}
+		withoutImage := &entity.Item{ID: 2, Category: "バッグ", PurchasePrice: 500}
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{}).Return([]*entity.Item{withImage, withoutImage}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ListIDsByItem", mock.Anything, []int64{1, 2}).Return(map[int64][]int64{1: {3, 7}}, nil)
+		renderer := new(MockInventoryRenderer)
+		renderer.On("RenderInventory", mock.MatchedBy(func(inv *EstateInventory) bool {
+			return assert.ObjectsAreEqual([]int64{3, 7}, inv.Items[0].ImageIDs) && inv.Items[1].ImageIDs == nil
+		})).Return([]byte("abc"), nil)
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(itemRepo, jobs, renderer)
+		usecase.(*estateExportUsecase).imageRepo = imageRepo
+
+		job, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "correct horse battery"})
+		require.NoError(t, err)
+
+		done, err := usecase.GetExportJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExportStatusCompleted, done.Status)
+		renderer.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 短いパスフレーズ", func(t *testing.T) {
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(new(MockItemRepository), jobs, new(MockInventoryRenderer))
+
+		_, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "short"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, jobs.jobs)
+	})
+
+	t.Run("正常系: 指定の通貨での合計を含める", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(items, nil)
+		renderer := new(MockInventoryRenderer)
+		renderer.On("RenderInventory", mock.MatchedBy(func(inv *EstateInventory) bool {
+			return inv.Conversion != nil && inv.Conversion.To == "USD" && inv.Conversion.RateDate == "2024-03-08"
+		})).Return([]byte("abc"), nil)
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(itemRepo, jobs, renderer)
+		usecase.(*estateExportUsecase).converter = NewCurrencyConverter(&fixedRateProvider{rates: ecbRates})
+
+		job, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "correct horse battery", Currency: "usd"})
+		require.NoError(t, err)
+
+		done, err := usecase.GetExportJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExportStatusCompleted, done.Status)
+		renderer.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 為替レートを取得できなければジョブを作らない", func(t *testing.T) {
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(new(MockItemRepository), jobs, new(MockInventoryRenderer))
+		usecase.(*estateExportUsecase).converter = NewCurrencyConverter(&fixedRateProvider{err: domainErrors.ErrExchangeRateUnavailable})
+
+		_, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "correct horse battery", Currency: "USD"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrExchangeRateUnavailable)
+		assert.Empty(t, jobs.jobs)
+	})
+
+	t.Run("異常系: 換算なしでは基準通貨以外は使えない", func(t *testing.T) {
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(new(MockItemRepository), jobs, new(MockInventoryRenderer))
+
+		_, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "correct horse battery", Currency: "USD"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, jobs.jobs)
+	})
+
+	t.Run("異常系: 生成に失敗したジョブはfailedになる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(items, nil)
+		renderer := new(MockInventoryRenderer)
+		renderer.On("RenderInventory", mock.Anything).Return(nil, errors.New("boom"))
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(itemRepo, jobs, renderer)
+
+		job, err := usecase.StartEstateExport(context.Background(), EstateExportInput{Passphrase: "correct horse battery"})
+		require.NoError(t, err)
+
+		done, err := usecase.GetExportJob(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ExportStatusFailed, done.Status)
+		assert.Contains(t, done.Error, "boom")
+		assert.Nil(t, done.Data)
+	})
+
+	t.Run("異常系: 存在しないジョブ", func(t *testing.T) {
+		jobs := &fakeJobRepository{jobs: map[string]entity.ExportJob{}}
+		usecase := newSyncEstateExportUsecase(new(MockItemRepository), jobs, new(MockInventoryRenderer))
+
+		_, err := usecase.GetExportJob(context.Background(), "missing")
+
+		assert.ErrorIs(t, err, domainErrors.ErrExportNotFound)
+	})
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// replayBatchSize is the number of recorded events read at once by a replay
+const replayBatchSize = 500
+
+// ItemEventSink receives replayed item events, to rebuild a projection kept downstream
+// (webhook receivers, consumers of a broker topic, the search index)
+type ItemEventSink interface {
+	// Replay delivers an event, or queues it durably, before returning; an error stops the replay
+	Replay(ctx context.Context, event ItemEvent) error
+}
+
+// TopicSink is a sink that can send replayed events to other topics than the live events,
+// so that consumers rebuilding a projection can read them apart
+type TopicSink interface {
+	ItemEventSink
+
+	// WithTopicPrefix returns the sink sending to the topics of prefix instead
+	WithTopicPrefix(prefix string) ItemEventSink
+}
+
+// ReplaySink is a sink events can be replayed to, with the event types it handles (all if empty)
+type ReplaySink struct {
+	Sink       ItemEventSink
+	EventTypes []string
+}
+
+// ReplayRequest selects the recorded events to replay and the sink to replay them to.
+// At least one of Since, Until and ItemID is required.
+type ReplayRequest struct {
+	Sink   string
+	Since  time.Time
+	Until  time.Time
+	ItemID int64
+
+	// AfterID skips the events up to that ID, to resume a replay that stopped (LastEventID of its report)
+	AfterID int64
+
+	// TopicPrefix sends the events to other topics; only for sinks with topics (TopicSink)
+	TopicPrefix string
+
+	// DryRun counts the events without replaying them
+	DryRun bool
+}
+
+// ReplayReport is the outcome of a replay
+type ReplayReport struct {
+	Sink   string `json:"sink"`
+	DryRun bool   `json:"dry_run"`
+	// Replayed is the number of events replayed (to be replayed in a dry run), Skipped the number of events
+	// of types the sink does not handle
+	Replayed int `json:"replayed"`
+	Skipped  int `json:"skipped"`
+	// LastEventID is the ID of the last event read; a replay that stopped resumes after it
+	LastEventID int64  `json:"last_event_id,omitempty"`
+	Duration    string `json:"duration"`
+}
+
+type EventReplayUsecase interface {
+	// Replay sends the recorded events matching the request to a sink, oldest first. The events are sent
+	// as they were recorded: an item event describes the item at the time of the change, not as it is now.
+	Replay(ctx context.Context, req ReplayRequest) (*ReplayReport, error)
+
+	// Sinks returns the names of the sinks events can be replayed to
+	Sinks() []string
+}
+
+type eventReplayUsecase struct {
+	eventLog ItemEventLog
+	sinks    map[string]ReplaySink
+}
+
+// NewEventReplayUsecase creates the replay usecase over the events kept in the outbox;
+// sinks are the sinks configured, by name (webhook, broker, search)
+func NewEventReplayUsecase(eventLog ItemEventLog, sinks map[string]ReplaySink) EventReplayUsecase {
+	return &eventReplayUsecase{eventLog: eventLog, sinks: sinks}
+}
+
+func (u *eventReplayUsecase) Sinks() []string {
+	names := make([]string, 0, len(u.sinks))
+	for name := range u.sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (u *eventReplayUsecase) Replay(ctx context.Context, req ReplayRequest) (*ReplayReport, error) {
+	sink, err := u.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	report := &ReplayReport{Sink: req.Sink, DryRun: req.DryRun}
+	query := ItemEventQuery{Since: req.Since, Until: req.Until, ItemID: req.ItemID, AfterID: req.AfterID, Limit: replayBatchSize}
+	err = u.replay(ctx, sink, query, req.DryRun, report)
+	report.Duration = time.Since(started).Round(time.Millisecond).String()
+	return report, err
+}
+
+func (u *eventReplayUsecase) replay(ctx context.Context, sink ReplaySink, query ItemEventQuery, dryRun bool, report *ReplayReport) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("replay stopped: %w", err)
+		}
+		events, err := u.eventLog.FindEvents(ReadOnly(ctx), query)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve item events: %w", err)
+		}
+
+		for _, event := range events {
+			if len(sink.EventTypes) > 0 && !slices.Contains(sink.EventTypes, event.Event.Type) {
+				report.Skipped++
+				report.LastEventID = event.ID
+				continue
+			}
+			if !dryRun {
+				if err := sink.Sink.Replay(ctx, event.Event); err != nil {
+					return fmt.Errorf("failed to replay event %d to %s: %w", event.ID, report.Sink, err)
+				}
+			}
+			report.Replayed++
+			report.LastEventID = event.ID
+		}
+
+		if len(events) < query.Limit {
+			return nil
+		}
+		query.AfterID = events[len(events)-1].ID
+	}
+}
+
+// validate checks the request and returns the sink to replay to
+func (u *eventReplayUsecase) validate(req ReplayRequest) (ReplaySink, error) {
+	var errs []string
+	sink, ok := u.sinks[req.Sink]
+	switch {
+	case req.Sink == "":
+		errs = append(errs, "sink is required")
+	case !ok:
+		errs = append(errs, fmt.Sprintf("sink must be one of: %s", strings.Join(u.Sinks(), ", ")))
+	}
+	if req.Since.IsZero() && req.Until.IsZero() && req.ItemID == 0 {
+		errs = append(errs, "since, until or item_id is required")
+	}
+	if !req.Since.IsZero() && !req.Until.IsZero() && !req.Since.Before(req.Until) {
+		errs = append(errs, "until must be after since")
+	}
+	if req.ItemID < 0 {
+		errs = append(errs, "item_id must be greater than 0")
+	}
+	if req.AfterID < 0 {
+		errs = append(errs, "after_id must be 0 or greater")
+	}
+	if req.TopicPrefix != "" && ok {
+		topics, isTopicSink := sink.Sink.(TopicSink)
+		if isTopicSink {
+			sink.Sink = topics.WithTopicPrefix(req.TopicPrefix)
+		} else {
+			errs = append(errs, fmt.Sprintf("topic_prefix is not supported by the %s sink", req.Sink))
+		}
+	}
+	if len(errs) > 0 {
+		return ReplaySink{}, domainErrors.NewValidationError(errs...)
+	}
+	return sink, nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeEventLog はアウトボックスに記録されたイベント（ID順）
+type fakeEventLog struct {
+	events  []*OutboxEvent
+	queries []ItemEventQuery
+}
+
+func (f *fakeEventLog) FindEvents(ctx context.Context, query ItemEventQuery) ([]*OutboxEvent, error) {
+	f.queries = append(f.queries, query)
+	var found []*OutboxEvent
+	for _, e := range f.events {
+		if e.ID <= query.AfterID || (query.ItemID != 0 && e.Event.ItemID != query.ItemID) ||
+			(!query.Since.IsZero() && e.Event.OccurredAt.Before(query.Since)) ||
+			(!query.Until.IsZero() && !e.Event.OccurredAt.Before(query.Until)) {
+			continue
+		}
+		if len(found) == query.Limit {
+			break
+		}
+		found = append(found, e)
+	}
+	return found, nil
+}
+
+// recordingSink は再送されたイベントのアイテムIDを記録する。failAt のアイテムで失敗する
+type recordingSink struct {
+	itemIDs     []int64
+	failAt      int64
+	topicPrefix string
+}
+
+func (s *recordingSink) Replay(ctx context.Context, event ItemEvent) error {
+	if event.ItemID == s.failAt {
+		return errors.New("sink unavailable")
+	}
+	s.itemIDs = append(s.itemIDs, event.ItemID)
+	return nil
+}
+
+type recordingTopicSink struct {
+	*recordingSink
+}
+
+func (s recordingTopicSink) WithTopicPrefix(prefix string) ItemEventSink {
+	s.topicPrefix = prefix
+	return s.recordingSink
+}
+
+func newEventLog(n int, start time.Time) *fakeEventLog {
+	log := &fakeEventLog{}
+	for i := 1; i <= n; i++ {
+		eventType := ItemUpdated
+		if i%3 == 0 {
+			eventType = ItemExpiring
+		}
+		log.events = append(log.events, &OutboxEvent{ID: int64(i), Event: ItemEvent{
+			Type:       eventType,
+			ItemID:     int64(i%5 + 1),
+			OccurredAt: start.Add(time.Duration(i) * time.Minute),
+		}})
+	}
+	return log
+}
+
+func TestEventReplayUsecase_Replay(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 期間内のイベントを古い順に再送し、扱わない種類は数えるだけ", func(t *testing.T) {
+		log := newEventLog(6, start)
+		sink := &recordingSink{}
+		u := NewEventReplayUsecase(log, map[string]ReplaySink{"broker": {Sink: sink, EventTypes: []string{ItemUpdated}}})
+
+		report, err := u.Replay(ctx, ReplayRequest{Sink: "broker", Since: start.Add(2 * time.Minute), Until: start.Add(6 * time.Minute)})
+
+		require.NoError(t, err)
+		// ID 2〜5 のうち、ID 3 は item.expiring
+		assert.Equal(t, []int64{3, 5, 1}, sink.itemIDs)
+		assert.Equal(t, 3, report.Replayed)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Equal(t, int64(5), report.LastEventID)
+		assert.Equal(t, "broker", report.Sink)
+	})
+
+	t.Run("正常系: アイテムのイベントを一定の件数ずつ読む", func(t *testing.T) {
+		log := newEventLog(replayBatchSize*2+10, start)
+		sink := &recordingSink{}
+		u := NewEventReplayUsecase(log, map[string]ReplaySink{"webhook": {Sink: sink}})
+
+		report, err := u.Replay(ctx, ReplayRequest{Sink: "webhook", ItemID: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, (replayBatchSize*2+10)/5, report.Replayed)
+		require.Len(t, log.queries, 1, "1件のアイテムのイベントは1回で読み切る")
+
+		log.queries = nil
+		report, err = u.Replay(ctx, ReplayRequest{Sink: "webhook", Since: start})
+		require.NoError(t, err)
+		assert.Equal(t, replayBatchSize*2+10, report.Replayed)
+		require.Len(t, log.queries, 3)
+		assert.Equal(t, int64(replayBatchSize), log.queries[1].AfterID)
+		assert.Equal(t, int64(replayBatchSize*2), log.queries[2].AfterID)
+	})
+
+	t.Run("正常系: dry run は再送せずに数える", func(t *testing.T) {
+		sink := &recordingSink{}
+		u := NewEventReplayUsecase(newEventLog(4, start), map[string]ReplaySink{"search": {Sink: sink}})
+
+		report, err := u.Replay(ctx, ReplayRequest{Sink: "search", Since: start, DryRun: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, report.Replayed)
+		assert.True(t, report.DryRun)
+		assert.Empty(t, sink.itemIDs)
+	})
+
+	t.Run("正常系: 止まった再送を最後のイベントの後から再開する", func(t *testing.T) {
+		log := newEventLog(5, start)
+		sink := &recordingSink{failAt: 4}
+		u := NewEventReplayUsecase(log, map[string]ReplaySink{"webhook": {Sink: sink}})
+
+		report, err := u.Replay(ctx, ReplayRequest{Sink: "webhook", Since: start})
+		require.EqualError(t, err, "failed to replay event 3 to webhook: sink unavailable")
+		assert.Equal(t, int64(2), report.LastEventID, "途中までの結果も返す")
+		assert.Equal(t, []int64{2, 3}, sink.itemIDs)
+
+		sink.failAt = 0
+		report, err = u.Replay(ctx, ReplayRequest{Sink: "webhook", Since: start, AfterID: report.LastEventID})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 3, 4, 5, 1}, sink.itemIDs)
+		assert.Equal(t, int64(5), report.LastEventID)
+	})
+
+	t.Run("正常系: トピックのあるシンクには別のトピックを指定できる", func(t *testing.T) {
+		sink := &recordingSink{}
+		u := NewEventReplayUsecase(newEventLog(1, start), map[string]ReplaySink{"broker": {Sink: recordingTopicSink{sink}}})
+
+		_, err := u.Replay(ctx, ReplayRequest{Sink: "broker", ItemID: 2, TopicPrefix: "replay"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "replay", sink.topicPrefix)
+		assert.Equal(t, []int64{2}, sink.itemIDs)
+	})
+
+	t.Run("異常系: 入力の誤り", func(t *testing.T) {
+		u := NewEventReplayUsecase(newEventLog(1, start), map[string]ReplaySink{"webhook": {Sink: &recordingSink{}}, "search": {Sink: &recordingSink{}}})
+
+		_, err := u.Replay(ctx, ReplayRequest{Sink: "kafka"})
+		require.True(t, domainErrors.IsValidationError(err))
+		validationErr, ok := domainErrors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []string{"sink must be one of: search, webhook", "since, until or item_id is required"}, validationErr.Details())
+
+		_, err = u.Replay(ctx, ReplayRequest{Sink: "webhook", Since: start, Until: start, TopicPrefix: "replay"})
+		validationErr, ok = domainErrors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []string{"until must be after since", "topic_prefix is not supported by the webhook sink"}, validationErr.Details())
+	})
+}
+
+func TestEventReplayUsecase_Sinks(t *testing.T) {
+	u := NewEventReplayUsecase(&fakeEventLog{}, map[string]ReplaySink{"webhook": {}, "broker": {}})
+	assert.Equal(t, []string{"broker", "webhook"}, u.Sinks())
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// Item event types
+const (
+	ItemCreated = "item.created"
+	ItemUpdated = "item.updated"
+	ItemDeleted = "item.deleted"
+	// ItemExpiring is not a change: the reminder job records it when a date-typed attribute of the item is close
+	ItemExpiring = "item.expiring"
+)
+
+// ItemEvent describes a committed change to an item; for deletions Item is the item as it was before the deletion.
+// Expiry is only set for ItemExpiring events.
+type ItemEvent struct {
+	Type       string                 `json:"type"`
+	ItemID     int64                  `json:"item_id"`
+	Item       *entity.Item           `json:"item,omitempty"`
+	Expiry     *entity.ExpiryReminder `json:"expiry,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// ItemEventPublisher delivers item events to subscribers; Publish must not block
+type ItemEventPublisher interface {
+	Publish(ctx context.Context, event ItemEvent)
+}
+
+// ItemEventSubscriber streams item events; the returned function ends the subscription and closes the channel
+type ItemEventSubscriber interface {
+	Subscribe() (<-chan ItemEvent, func())
+}
+
+// ItemEventOutbox records item events in the transaction of the change they describe, so an event is
+// stored if and only if the change is committed; a relay publishes the recorded events afterwards
+type ItemEventOutbox interface {
+	// Append records an event; it is called with the context of the transaction making the change
+	Append(ctx context.Context, event ItemEvent) error
+
+	// Notify tells the relay that recorded events have been committed; it must not block
+	Notify()
+}
+
+type eventingItemUsecase struct {
+	ItemUsecase
+	outbox ItemEventOutbox
+	uow    UnitOfWork
+}
+
+// NewEventingItemUsecase records an event for every item created, patched, deleted or restored through inner.
+// Each operation runs in one transaction with the recording of its event; uow may be nil, in which case
+// no transactions are used and an event can be lost if the process stops between the change and the recording.
+func NewEventingItemUsecase(inner ItemUsecase, outbox ItemEventOutbox, uow UnitOfWork) ItemUsecase {
+	return &eventingItemUsecase{
+		ItemUsecase: inner,
+		outbox:      outbox,
+		uow:         uow,
+	}
+}
+
+func (u *eventingItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	var created *entity.Item
+	err := u.record(ctx, func(ctx context.Context) (ItemEvent, error) {
+		item, err := u.ItemUsecase.CreateItem(ctx, input)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+		created = item
+		return newItemEvent(ItemCreated, item.ID, item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (u *eventingItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	var updated *entity.Item
+	err := u.record(ctx, func(ctx context.Context) (ItemEvent, error) {
+		item, err := u.ItemUsecase.PatchItem(ctx, id, req)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+		updated = item
+		return newItemEvent(ItemUpdated, item.ID, item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (u *eventingItemUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) error {
+	return u.record(ctx, func(ctx context.Context) (ItemEvent, error) {
+		// Subscribers such as notifications need to know what was removed, e.g. its price
+		item, err := u.ItemUsecase.GetItemByID(ctx, id)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+		if err := u.ItemUsecase.DeleteItem(ctx, id, ifMatch); err != nil {
+			return ItemEvent{}, err
+		}
+		return newItemEvent(ItemDeleted, id, item), nil
+	})
+}
+
+// RestoreItem records the restored item as created, as it appears again to subscribers
+func (u *eventingItemUsecase) RestoreItem(ctx context.Context, id int64, deletedAt time.Time) error {
+	return u.record(ctx, func(ctx context.Context) (ItemEvent, error) {
+		if err := u.ItemUsecase.RestoreItem(ctx, id, deletedAt); err != nil {
+			return ItemEvent{}, err
+		}
+		item, err := u.ItemUsecase.GetItemByID(ctx, id)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+		return newItemEvent(ItemCreated, id, item), nil
+	})
+}
+
+// record runs change and appends the event it returns in one transaction
+func (u *eventingItemUsecase) record(ctx context.Context, change func(ctx context.Context) (ItemEvent, error)) error {
+	return recordEvent(ctx, u.uow, u.outbox, change)
+}
+
+type eventingTransferUsecase struct {
+	TransferUsecase
+	itemRepo ItemReader
+	outbox   ItemEventOutbox
+	uow      UnitOfWork
+}
+
+// NewEventingTransferUsecase records an item update when an accepted transfer changes the item's owner
+func NewEventingTransferUsecase(inner TransferUsecase, itemRepo ItemReader, outbox ItemEventOutbox, uow UnitOfWork) TransferUsecase {
+	return &eventingTransferUsecase{
+		TransferUsecase: inner,
+		itemRepo:        itemRepo,
+		outbox:          outbox,
+		uow:             uow,
+	}
+}
+
+func (u *eventingTransferUsecase) AcceptTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	var accepted *entity.Transfer
+	err := recordEvent(ctx, u.uow, u.outbox, func(ctx context.Context) (ItemEvent, error) {
+		transfer, err := u.TransferUsecase.AcceptTransfer(ctx, actor, id)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+		accepted = transfer
+
+		// A failed lookup only leaves the item out of the event; it must not undo the transfer
+		item, err := u.itemRepo.FindByID(ctx, transfer.ItemID)
+		if err != nil {
+			item = nil
+		}
+		return newItemEvent(ItemUpdated, transfer.ItemID, item), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return accepted, nil
+}
+
+// recordEvent runs change and appends the event it returns in one transaction, then notifies the outbox.
+// The inner usecases' own transactions join this one.
+func recordEvent(ctx context.Context, uow UnitOfWork, outbox ItemEventOutbox, change func(ctx context.Context) (ItemEvent, error)) error {
+	err := inTransaction(ctx, uow, func(ctx context.Context) error {
+		event, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		if err := outbox.Append(ctx, event); err != nil {
+			return fmt.Errorf("failed to record item event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	outbox.Notify()
+	return nil
+}
+
+func newItemEvent(eventType string, itemID int64, item *entity.Item) ItemEvent {
+	return ItemEvent{
+		Type:       eventType,
+		ItemID:     itemID,
+		Item:       item,
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// recordingOutbox は記録されたイベントと通知の回数を記録する
+type recordingOutbox struct {
+	events   []ItemEvent
+	notified int
+	err      error
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, event ItemEvent) error {
+	if o.err != nil {
+		return o.err
+	}
+	o.events = append(o.events, event)
+	return nil
+}
+
+func (o *recordingOutbox) Notify() {
+	o.notified++
+}
+
+// recordingUnitOfWork はトランザクションの結果（コミット・ロールバック）を記録する
+type recordingUnitOfWork struct {
+	committed  int
+	rolledBack int
+}
+
+func (u *recordingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		u.rolledBack++
+		return err
+	}
+	u.committed++
+	return nil
+}
+
+func TestEventingItemUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 作成したアイテムのイベントを配信する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		created := &entity.Item{ID: 1, Name: "時計1", Category: "時計"}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(created, nil)
+		outbox := &recordingOutbox{}
+
+		_, err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, nil).CreateItem(ctx, CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1000), PurchaseDate: "2023-01-01",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, outbox.events, 1)
+		assert.Equal(t, ItemCreated, outbox.events[0].Type)
+		assert.Equal(t, created, outbox.events[0].Item)
+		assert.Equal(t, 1, outbox.notified)
+	})
+
+	t.Run("正常系: 削除は削除前のアイテムを含むイベントを配信する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		deleted := &entity.Item{ID: 1, PurchasePrice: 1500000}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(deleted, nil)
+		mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		outbox := &recordingOutbox{}
+
+		err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, nil).DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		require.Len(t, outbox.events, 1)
+		assert.Equal(t, ItemDeleted, outbox.events[0].Type)
+		assert.Equal(t, int64(1), outbox.events[0].ItemID)
+		assert.Equal(t, deleted, outbox.events[0].Item)
+	})
+
+	t.Run("正常系: ゴミ箱から戻したアイテムは作成のイベントを配信する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		deletedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+		restored := &entity.Item{ID: 1, PurchasePrice: 1500000}
+		mockRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.TrashedItem{Item: restored, DeletedAt: deletedAt}, nil)
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(restored, nil)
+		outbox := &recordingOutbox{}
+
+		err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, nil).RestoreItem(ctx, 1, deletedAt)
+
+		require.NoError(t, err)
+		require.Len(t, outbox.events, 1)
+		assert.Equal(t, ItemCreated, outbox.events[0].Type)
+		assert.Equal(t, restored, outbox.events[0].Item)
+	})
+
+	t.Run("異常系: 失敗した更新は配信しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		outbox := &recordingOutbox{}
+		version := int64(1)
+
+		_, err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, nil).PatchItem(ctx, 1, &UpdateItemRequest{Version: &version})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, outbox.events)
+		assert.Zero(t, outbox.notified)
+	})
+
+	t.Run("正常系: 変更とイベントの記録を1つのトランザクションで行う", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		outbox := &recordingOutbox{}
+		uow := &recordingUnitOfWork{}
+
+		err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, uow).DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.committed)
+		assert.Len(t, outbox.events, 1)
+	})
+
+	t.Run("異常系: イベントを記録できなければ変更をロールバックする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		outbox := &recordingOutbox{err: domainErrors.ErrDatabaseError}
+		uow := &recordingUnitOfWork{}
+
+		err := NewEventingItemUsecase(NewItemUsecase(mockRepo, nil, nil, nil, nil), outbox, uow).DeleteItem(ctx, 1, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, uow.rolledBack)
+		assert.Zero(t, uow.committed)
+		assert.Zero(t, outbox.notified)
+	})
+}
+
+func TestEventingTransferUsecase_AcceptTransfer(t *testing.T) {
+	transfer, _ := entity.NewTransfer(1, "alice", "bob", "")
+	transfer.ID = 10
+	item := &entity.Item{ID: 1, OwnerID: "alice"}
+
+	mockItems := new(MockItemRepository)
+	mockTransfers := new(MockTransferRepository)
+	mockTransfers.On("FindByID", mock.Anything, int64(10)).Return(transfer, nil)
+	mockTransfers.On("Resolve", mock.Anything, mock.Anything).Return(nil)
+	mockItems.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Once()
+	mockItems.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, OwnerID: "bob"}, nil)
+	mockItems.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "bob"}, nil)
+	outbox := &recordingOutbox{}
+
+	_, err := NewEventingTransferUsecase(NewTransferUsecase(mockItems, mockTransfers, nil), mockItems, outbox, nil).AcceptTransfer(context.Background(), "bob", 10)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, ItemUpdated, outbox.events[0].Type)
+	assert.Equal(t, "bob", outbox.events[0].Item.OwnerID)
+}
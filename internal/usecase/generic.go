@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Page is one page of a list with the number of entries across all pages
+type Page[T any] struct {
+	Items    []T `json:"items"`
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// findByID looks up an entity by ID with find. An ID that is not positive is ErrInvalidInput, a missing entity is
+// notFound, and other errors are wrapped as "failed to retrieve <name>".
+func findByID[T any](ctx context.Context, id int64, find func(context.Context, int64) (T, error), notFound error, name string) (T, error) {
+	var zero T
+	if id <= 0 {
+		return zero, domainErrors.ErrInvalidInput
+	}
+
+	v, err := find(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return zero, notFound
+		}
+		return zero, fmt.Errorf("failed to retrieve %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// listPage reads one page of a list: find reads limit entries from offset and count the number across all pages.
+// page is 1-based (1 if 0) and pageSize is defaultPageSize if 0, at most entity.MaxPageSize.
+// Errors are wrapped as "failed to retrieve <name>" and "failed to count <name>"; Items is never nil.
+func listPage[T any](ctx context.Context, page, pageSize, defaultPageSize int, name string,
+	find func(ctx context.Context, limit, offset int) ([]T, error), count func(ctx context.Context) (int, error)) (*Page[T], error) {
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if page < 0 {
+		return nil, fmt.Errorf("%w: page must be 1 or greater", domainErrors.ErrInvalidInput)
+	}
+	if pageSize < 0 || pageSize > entity.MaxPageSize {
+		return nil, fmt.Errorf("%w: page_size must be between 1 and %d", domainErrors.ErrInvalidInput, entity.MaxPageSize)
+	}
+
+	items, err := find(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s: %w", name, err)
+	}
+	total, err := count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s: %w", name, err)
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
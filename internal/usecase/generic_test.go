@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestFindByID(t *testing.T) {
+	loan := &entity.Loan{ID: 1}
+	find := func(err error) func(context.Context, int64) (*entity.Loan, error) {
+		return func(ctx context.Context, id int64) (*entity.Loan, error) {
+			if err != nil {
+				return nil, err
+			}
+			return loan, nil
+		}
+	}
+
+	tests := []struct {
+		name        string
+		id          int64
+		findErr     error
+		expected    *entity.Loan
+		expectedErr error
+	}{
+		{name: "正常系: 見つかる", id: 1, expected: loan},
+		{name: "異常系: ID が正でない", id: 0, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 見つからない", id: 2, findErr: fmt.Errorf("wrapped: %w", domainErrors.ErrNotFound), expectedErr: domainErrors.ErrLoanNotFound},
+		{name: "異常系: データベースのエラー", id: 3, findErr: domainErrors.ErrDatabaseError, expectedErr: domainErrors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findByID(context.Background(), tt.id, find(tt.findErr), domainErrors.ErrLoanNotFound, "loan")
+
+			assert.Equal(t, tt.expected, got)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+
+	t.Run("異常系: 見つからない以外のエラーは名前を付けて包む", func(t *testing.T) {
+		_, err := findByID(context.Background(), 1, find(domainErrors.ErrDatabaseError), domainErrors.ErrLoanNotFound, "loan")
+		assert.EqualError(t, err, "failed to retrieve loan: database error")
+	})
+}
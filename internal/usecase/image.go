@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ImageTypes are the accepted image types, detected from the file contents, and the extensions they are stored with
+var ImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ImageUsecase manages the images of items.
+// Uploading or deleting an image increments the item version, since its image_ids change with them.
+type ImageUsecase interface {
+	UploadImage(ctx context.Context, actor string, itemID int64, upload ImageUpload) (*entity.ItemImage, error)
+	ListImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+	// OpenImage returns an image with its file; the caller closes the file
+	OpenImage(ctx context.Context, itemID, id int64) (*entity.ItemImage, io.ReadCloser, error)
+	DeleteImage(ctx context.Context, actor string, itemID, id int64) error
+	// DeleteOrphanedFiles removes the stored files no image refers to, such as the files of deleted items,
+	// that were last modified before the given time, and returns how many were removed
+	DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error)
+}
+
+// ImageUpload is an uploaded file of Size bytes. Content is read twice: once to hash it, then to store it.
+type ImageUpload struct {
+	FileName string
+	Size     int64
+	Content  io.ReadSeeker
+}
+
+type imageUsecase struct {
+	itemRepo  ItemRepository
+	imageRepo ItemImageRepository
+	storage   Storage
+	uow       UnitOfWork
+	maxSize   int64
+	now       func() time.Time
+}
+
+// NewImageUsecase creates the image usecase accepting files of up to maxSize bytes;
+// uow may be nil, in which case no transactions are used
+func NewImageUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage Storage, uow UnitOfWork, maxSize int64) ImageUsecase {
+	return &imageUsecase{
+		itemRepo:  itemRepo,
+		imageRepo: imageRepo,
+		storage:   storage,
+		uow:       uow,
+		maxSize:   maxSize,
+		now:       time.Now,
+	}
+}
+
+func (u *imageUsecase) UploadImage(ctx context.Context, actor string, itemID int64, upload ImageUpload) (*entity.ItemImage, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	file, err := checkUpload(upload.Content, upload.Size, u.maxSize, ImageTypes,
+		domainErrors.ErrImageTooLarge, domainErrors.ErrUnsupportedImageType)
+	if err != nil {
+		return nil, err
+	}
+	if err := hashUpload(upload.Content, upload.Size, file); err != nil {
+		return nil, err
+	}
+	image, err := entity.NewItemImage(itemID, upload.FileName, file.contentType, upload.Size, file.sha256, file.key, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	var created *entity.ItemImage
+	err = u.changeImages(ctx, actor, itemID, func(ctx context.Context) error {
+		// The file is stored again even if an identical file is already stored, which keeps it from being
+		// taken for an orphan by DeleteOrphanedFiles until the record is committed
+		if err := u.storage.Put(ctx, image.StorageKey, file.content, image.Size, image.ContentType); err != nil {
+			return fmt.Errorf("failed to save image: %w", err)
+		}
+
+		created, err = u.imageRepo.Create(ctx, image)
+		if err != nil {
+			return fmt.Errorf("failed to create image: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// The file is saved inside the transaction, so it is removed again if anything after it failed
+		u.removeFiles(ctx, []*entity.ItemImage{image})
+		return nil, err
+	}
+	ensureStored(ctx, u.storage, file, upload.Content, upload.Size)
+
+	return created, nil
+}
+
+func (u *imageUsecase) ListImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	ctx = ReadOnly(ctx)
+	if _, err := findByID(ctx, itemID, u.itemRepo.FindByID, domainErrors.ErrItemNotFound, "item"); err != nil {
+		return nil, err
+	}
+
+	images, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	if images == nil {
+		images = []*entity.ItemImage{}
+	}
+
+	return images, nil
+}
+
+func (u *imageUsecase) OpenImage(ctx context.Context, itemID, id int64) (*entity.ItemImage, io.ReadCloser, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, nil, domainErrors.ErrInvalidInput
+	}
+
+	image, err := u.findImage(ReadOnly(ctx), itemID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := u.storage.Open(ctx, image.StorageKey)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, nil, domainErrors.NotFound(domainErrors.ErrImageNotFound, "file of image %d is missing", id)
+		}
+		return nil, nil, fmt.Errorf("failed to open image: %w", err)
+	}
+
+	return image, file, nil
+}
+
+func (u *imageUsecase) DeleteImage(ctx context.Context, actor string, itemID, id int64) error {
+	if itemID <= 0 || id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	var deleted *entity.ItemImage
+	err := u.changeImages(ctx, actor, itemID, func(ctx context.Context) error {
+		image, err := u.findImage(ctx, itemID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := u.imageRepo.Delete(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to delete image: %w", err)
+		}
+		deleted = image
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The file is removed only once the deletion is committed
+	u.removeFiles(ctx, []*entity.ItemImage{deleted})
+	return nil
+}
+
+func (u *imageUsecase) DeleteOrphanedFiles(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	return deleteOrphanedFiles(ctx, u.storage, u.imageRepo.ExistingStorageKeys, modifiedBefore)
+}
+
+// changeImages runs fn in a transaction after checking that actor may change the item, then increments
+// the item version. Items without an owner (created before ownership was tracked) may be changed by any user.
+func (u *imageUsecase) changeImages(ctx context.Context, actor string, itemID int64, fn func(ctx context.Context) error) error {
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		item.UpdatedAt = u.now()
+		if _, err := u.itemRepo.Update(ctx, item); err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return nil
+	})
+}
+
+// findImage retrieves an image of the item; images of other items are reported as not found
+func (u *imageUsecase) findImage(ctx context.Context, itemID, id int64) (*entity.ItemImage, error) {
+	image, err := u.imageRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+	if image.ItemID != itemID {
+		return nil, domainErrors.ErrImageNotFound
+	}
+
+	return image, nil
+}
+
+// removeFiles removes the files of images whose records are gone, unless other images have the same contents.
+// A file left behind only takes up space, so failures are logged rather than failing a request whose changes
+// are already committed.
+func (u *imageUsecase) removeFiles(ctx context.Context, images []*entity.ItemImage) {
+	keys := make([]string, len(images))
+	for i, image := range images {
+		keys[i] = image.StorageKey
+	}
+	removeUnreferencedFiles(ctx, u.storage, u.imageRepo.ExistingStorageKeys, keys)
+}
+
+type imageItemUsecase struct {
+	ItemUsecase
+	imageRepo ItemImageRepository
+}
+
+// NewImageItemUsecase fills in the image IDs of the items returned by inner, and deletes the images of purged items.
+// The files of deleted images are left to DeleteOrphanedFiles, so they are only removed once the deletion is committed.
+func NewImageItemUsecase(inner ItemUsecase, imageRepo ItemImageRepository) ItemUsecase {
+	return &imageItemUsecase{
+		ItemUsecase: inner,
+		imageRepo:   imageRepo,
+	}
+}
+
+func (u *imageItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
+	items, err := u.ItemUsecase.GetAllItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setImageIDs(ReadOnly(ctx), items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (u *imageItemUsecase) ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error) {
+	list, err := u.ItemUsecase.ListItems(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setImageIDs(ReadOnly(ctx), list.Items); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (u *imageItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, err := u.ItemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setImageIDs(ReadOnly(ctx), []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *imageItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	item, err := u.ItemUsecase.PatchItem(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setImageIDs(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// PurgeItem deletes the image records along with the item, in the caller's transaction if any.
+// They are kept while the item is in the trash, so a restored item still has them.
+func (u *imageItemUsecase) PurgeItem(ctx context.Context, id int64) error {
+	if err := u.ItemUsecase.PurgeItem(ctx, id); err != nil {
+		return err
+	}
+
+	images, err := u.imageRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve images: %w", err)
+	}
+	for _, image := range images {
+		if err := u.imageRepo.Delete(ctx, image.ID); err != nil && !domainErrors.IsNotFoundError(err) {
+			return fmt.Errorf("failed to delete image: %w", err)
+		}
+	}
+	return nil
+}
+
+func (u *imageItemUsecase) setImageIDs(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	imageIDs, err := u.imageRepo.ListIDsByItem(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	for _, item := range items {
+		item.ImageIDs = imageIDs[item.ID]
+	}
+	return nil
+}
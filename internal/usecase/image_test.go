@@ -0,0 +1,520 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemImageRepository は画像リポジトリのモック
+type MockItemImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	args := m.Called(ctx, image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByID(ctx context.Context, id int64) (*entity.ItemImage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemImageRepository) ListAllIDsByItem(ctx context.Context) (map[int64][]int64, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]int64), args.Error(1)
+}
+
+func (m *MockItemImageRepository) ListIDsByItem(ctx context.Context, itemIDs []int64) (map[int64][]int64, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]int64), args.Error(1)
+}
+
+func (m *MockItemImageRepository) ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	args := m.Called(ctx, keys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+// memoryStorage はメモリに保存するストレージ
+type memoryStorage struct {
+	files    map[string][]byte
+	modified map[string]time.Time
+	putErr   error
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: make(map[string][]byte), modified: make(map[string]time.Time)}
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if s.putErr != nil {
+		return s.putErr
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.files[key] = data
+	s.modified[key] = imageToday
+	return nil
+}
+
+func (s *memoryStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.files[key]
+	if !ok {
+		return nil, fmt.Errorf("file %s: %w", key, domainErrors.ErrNotFound)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	delete(s.files, key)
+	delete(s.modified, key)
+	return nil
+}
+
+func (s *memoryStorage) List(ctx context.Context, fn func(StoredObject) error) error {
+	keys := make([]string, 0, len(s.files))
+	for key := range s.files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn(StoredObject{Key: key, ModifiedAt: s.modified[key]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PNG のシグネチャで始まるファイル
+var testPNG = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 24)...)
+
+var imageToday = time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+
+func newTestImageUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage Storage) ImageUsecase {
+	u := NewImageUsecase(itemRepo, imageRepo, storage, nil, 64).(*imageUsecase)
+	u.now = func() time.Time { return imageToday }
+	return u
+}
+
+func TestImageUsecase_UploadImage(t *testing.T) {
+	diskFull := errors.New("disk full")
+
+	tests := []struct {
+		name        string
+		actor       string
+		fileName    string
+		content     []byte
+		item        *entity.Item
+		findErr     error
+		createErr   error
+		size        int64
+		putErr      error
+		expectedErr error
+	}{
+		{name: "正常系: 画像を登録する", actor: "alice", fileName: "dial.png", content: testPNG, item: &entity.Item{ID: 1, OwnerID: "alice"}},
+		{name: "正常系: パスを含むファイル名は名前だけにする", actor: "bob", fileName: `C:\photos\dial.png`, content: testPNG, item: &entity.Item{ID: 1}},
+		{name: "異常系: 上限より大きい", actor: "alice", fileName: "big.png", content: append(testPNG, make([]byte, 64)...), expectedErr: domainErrors.ErrImageTooLarge},
+		{
+			name: "異常系: 申告より長いファイル", actor: "alice", fileName: "dial.png", content: append(testPNG, 0), size: int64(len(testPNG)),
+			item: &entity.Item{ID: 1}, expectedErr: errLongerThanDeclared,
+		},
+		{name: "異常系: 画像でない", actor: "alice", fileName: "notes.png", content: []byte("hello, world"), expectedErr: domainErrors.ErrUnsupportedImageType},
+		{name: "異常系: 空のファイル", actor: "alice", fileName: "empty.png", content: nil, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 所有者以外", actor: "bob", fileName: "dial.png", content: testPNG, item: &entity.Item{ID: 1, OwnerID: "alice"}, expectedErr: domainErrors.ErrForbidden},
+		{name: "異常系: アイテムが存在しない", actor: "alice", fileName: "dial.png", content: testPNG, findErr: domainErrors.ErrItemNotFound, expectedErr: domainErrors.ErrItemNotFound},
+		{
+			name: "異常系: 保存に失敗", actor: "alice", fileName: "dial.png", content: testPNG, item: &entity.Item{ID: 1},
+			putErr: diskFull, expectedErr: diskFull,
+		},
+		{
+			name: "異常系: 登録に失敗したらファイルを消す", actor: "alice", fileName: "dial.png", content: testPNG, item: &entity.Item{ID: 1},
+			createErr: domainErrors.ErrDatabaseError, expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			imageRepo := new(MockItemImageRepository)
+			storage := newMemoryStorage()
+			storage.putErr = tt.putErr
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.content))
+			}
+
+			if tt.item != nil || tt.findErr != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, tt.findErr)
+			}
+			var created *entity.ItemImage
+			var result interface{} = &entity.ItemImage{ID: 5, ItemID: 1}
+			if tt.createErr != nil {
+				result = nil
+			}
+			imageRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				created = args.Get(1).(*entity.ItemImage)
+			}).Return(result, tt.createErr).Maybe()
+			imageRepo.On("ExistingStorageKeys", mock.Anything, mock.Anything).Return(map[string]bool{}, nil).Maybe()
+			itemRepo.On("Update", mock.Anything, mock.Anything).Return(tt.item, nil).Maybe()
+
+			image, err := newTestImageUsecase(itemRepo, imageRepo, storage).UploadImage(context.Background(), tt.actor, 1,
+				ImageUpload{FileName: tt.fileName, Size: size, Content: bytes.NewReader(tt.content)})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, image)
+				assert.Empty(t, storage.files, "失敗したらファイルを残さない")
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), image.ID)
+			require.NotNil(t, created)
+			assert.Equal(t, "dial.png", created.FileName)
+			assert.Equal(t, "image/png", created.ContentType)
+			assert.Equal(t, int64(len(testPNG)), created.Size)
+			sum := sha256.Sum256(testPNG)
+			assert.Equal(t, hex.EncodeToString(sum[:]), created.SHA256)
+			assert.Equal(t, created.SHA256+".png", created.StorageKey)
+			assert.Equal(t, testPNG, storage.files[created.StorageKey])
+			itemRepo.AssertCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestImageUsecase_UploadImage_SameContent(t *testing.T) {
+	sum := sha256.Sum256(testPNG)
+	key := hex.EncodeToString(sum[:]) + ".png"
+
+	newUsecase := func(storage *memoryStorage, imageRepo *MockItemImageRepository) ImageUsecase {
+		itemRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1}
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(item, nil)
+		return newTestImageUsecase(itemRepo, imageRepo, storage)
+	}
+	upload := func() ImageUpload {
+		return ImageUpload{FileName: "dial.png", Size: int64(len(testPNG)), Content: bytes.NewReader(testPNG)}
+	}
+
+	t.Run("正常系: 同じ内容のファイルは1つだけ保存する", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.ItemImage{ID: 5, ItemID: 1}, nil)
+		storage := newMemoryStorage()
+		u := newUsecase(storage, imageRepo)
+
+		for i := 0; i < 2; i++ {
+			_, err := u.UploadImage(context.Background(), "alice", 1, upload())
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, map[string][]byte{key: testPNG}, storage.files)
+	})
+
+	t.Run("正常系: 登録に失敗しても他の画像のファイルは消さない", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, []string{key}).Return(map[string]bool{key: true}, nil)
+		storage := newMemoryStorage()
+		storage.files[key] = testPNG
+
+		_, err := newUsecase(storage, imageRepo).UploadImage(context.Background(), "alice", 1, upload())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, testPNG, storage.files[key])
+	})
+
+	t.Run("正常系: 登録までの間に同じ内容の画像の削除で消えたファイルを保存し直す", func(t *testing.T) {
+		storage := newMemoryStorage()
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			delete(storage.files, key)
+		}).Return(&entity.ItemImage{ID: 5, ItemID: 1}, nil)
+
+		_, err := newUsecase(storage, imageRepo).UploadImage(context.Background(), "alice", 1, upload())
+
+		require.NoError(t, err)
+		assert.Equal(t, testPNG, storage.files[key])
+	})
+}
+
+func TestImageUsecase_ListImages(t *testing.T) {
+	t.Run("正常系: 画像がなければ空の一覧", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, nil)
+
+		images, err := newTestImageUsecase(itemRepo, imageRepo, nil).ListImages(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemImage{}, images)
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrNotFound)
+		imageRepo := new(MockItemImageRepository)
+
+		images, err := newTestImageUsecase(itemRepo, imageRepo, nil).ListImages(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Nil(t, images)
+	})
+}
+
+func TestImageUsecase_OpenImage(t *testing.T) {
+	image := &entity.ItemImage{ID: 5, ItemID: 1, ContentType: "image/png", StorageKey: "abc.png"}
+
+	tests := []struct {
+		name        string
+		itemID      int64
+		files       map[string][]byte
+		expectedErr error
+	}{
+		{name: "正常系: ファイルを開く", itemID: 1, files: map[string][]byte{"abc.png": testPNG}},
+		{name: "異常系: 別のアイテムの画像", itemID: 2, files: map[string][]byte{"abc.png": testPNG}, expectedErr: domainErrors.ErrImageNotFound},
+		{name: "異常系: ファイルがない", itemID: 1, files: map[string][]byte{}, expectedErr: domainErrors.ErrImageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imageRepo := new(MockItemImageRepository)
+			imageRepo.On("FindByID", mock.Anything, int64(5)).Return(image, nil)
+			storage := newMemoryStorage()
+			storage.files = tt.files
+
+			found, file, err := newTestImageUsecase(nil, imageRepo, storage).OpenImage(context.Background(), tt.itemID, 5)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, file)
+				return
+			}
+			require.NoError(t, err)
+			defer file.Close()
+			assert.Equal(t, image, found)
+			data, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, testPNG, data)
+		})
+	}
+}
+
+func TestImageUsecase_DeleteImage(t *testing.T) {
+	t.Run("正常系: 記録とファイルを削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, OwnerID: "alice"}
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(item, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "abc.png"}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, []string{"abc.png"}).Return(map[string]bool{}, nil)
+		storage := newMemoryStorage()
+		storage.files["abc.png"] = testPNG
+
+		err := newTestImageUsecase(itemRepo, imageRepo, storage).DeleteImage(context.Background(), "alice", 1, 5)
+
+		require.NoError(t, err)
+		assert.Empty(t, storage.files)
+		itemRepo.AssertCalled(t, "Update", mock.Anything, item)
+	})
+
+	t.Run("正常系: 同じ内容の画像が残っていればファイルは残す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, OwnerID: "alice"}
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(item, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "abc.png"}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, []string{"abc.png"}).Return(map[string]bool{"abc.png": true}, nil)
+		storage := newMemoryStorage()
+		storage.files["abc.png"] = testPNG
+
+		err := newTestImageUsecase(itemRepo, imageRepo, storage).DeleteImage(context.Background(), "alice", 1, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, testPNG, storage.files["abc.png"])
+	})
+
+	t.Run("異常系: 別のアイテムの画像は削除しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "abc.png"}, nil)
+		storage := newMemoryStorage()
+		storage.files["abc.png"] = testPNG
+
+		err := newTestImageUsecase(itemRepo, imageRepo, storage).DeleteImage(context.Background(), "alice", 2, 5)
+
+		assert.ErrorIs(t, err, domainErrors.ErrImageNotFound)
+		assert.Len(t, storage.files, 1)
+		imageRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestImageItemUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 一覧のアイテムに画像のIDを付ける", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ListIDsByItem", mock.Anything, []int64{1, 2}).Return(map[int64][]int64{2: {3, 4}}, nil)
+
+		items, err := NewImageItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), imageRepo).GetAllItems(ctx)
+
+		require.NoError(t, err)
+		assert.Nil(t, items[0].ImageIDs)
+		assert.Equal(t, []int64{3, 4}, items[1].ImageIDs)
+	})
+
+	t.Run("正常系: ゴミ箱への移動では画像の記録を残す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		imageRepo := new(MockItemImageRepository)
+
+		err := NewImageItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), imageRepo).DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		imageRepo.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: アイテムの完全削除で画像の記録も削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(&entity.TrashedItem{Item: &entity.Item{ID: 1}}, nil)
+		itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{
+			{ID: 3, ItemID: 1, StorageKey: "a.png"},
+			{ID: 4, ItemID: 1, StorageKey: "b.jpg"},
+		}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(3)).Return(nil)
+		imageRepo.On("Delete", mock.Anything, int64(4)).Return(nil)
+
+		err := NewImageItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), imageRepo).PurgeItem(ctx, 1)
+
+		require.NoError(t, err)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: ゴミ箱にないアイテムは完全に削除せず画像も残す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		imageRepo := new(MockItemImageRepository)
+
+		err := NewImageItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), imageRepo).PurgeItem(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		imageRepo.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestImageUsecase_DeleteOrphanedFiles(t *testing.T) {
+	ctx := context.Background()
+	cutoff := imageToday.Add(-time.Hour)
+
+	t.Run("正常系: どの画像からも参照されない古いファイルだけを削除する", func(t *testing.T) {
+		storage := newMemoryStorage()
+		for key, modified := range map[string]time.Time{
+			"kept.png":   cutoff.Add(-time.Minute),
+			"orphan.png": cutoff.Add(-time.Minute),
+			"recent.png": cutoff.Add(time.Minute),
+		} {
+			storage.files[key] = testPNG
+			storage.modified[key] = modified
+		}
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, []string{"kept.png", "orphan.png"}).
+			Return(map[string]bool{"kept.png": true}, nil)
+
+		deleted, err := newTestImageUsecase(nil, imageRepo, storage).DeleteOrphanedFiles(ctx, cutoff)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.Contains(t, storage.files, "kept.png")
+		assert.Contains(t, storage.files, "recent.png", "アップロード中かもしれないファイルは残す")
+		assert.NotContains(t, storage.files, "orphan.png")
+	})
+
+	t.Run("正常系: ファイルが多ければ分けて確認する", func(t *testing.T) {
+		storage := newMemoryStorage()
+		for i := 0; i < orphanBatchSize+1; i++ {
+			key := fmt.Sprintf("%03d.png", i)
+			storage.files[key] = testPNG
+			storage.modified[key] = cutoff.Add(-time.Minute)
+		}
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, mock.Anything).Return(map[string]bool{}, nil)
+
+		deleted, err := newTestImageUsecase(nil, imageRepo, storage).DeleteOrphanedFiles(ctx, cutoff)
+
+		require.NoError(t, err)
+		assert.Equal(t, orphanBatchSize+1, deleted)
+		assert.Empty(t, storage.files)
+		imageRepo.AssertNumberOfCalls(t, "ExistingStorageKeys", 2)
+	})
+
+	t.Run("異常系: 記録を確認できなければ削除しない", func(t *testing.T) {
+		storage := newMemoryStorage()
+		storage.files["orphan.png"] = testPNG
+		storage.modified["orphan.png"] = cutoff.Add(-time.Minute)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ExistingStorageKeys", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		deleted, err := newTestImageUsecase(nil, imageRepo, storage).DeleteOrphanedFiles(ctx, cutoff)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 0, deleted)
+		assert.Contains(t, storage.files, "orphan.png")
+	})
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// maxImportRows is the number of rows accepted in one import
+	maxImportRows = 500
+	// serialAttribute is the custom attribute holding the serial number of an item
+	serialAttribute = "serial"
+)
+
+// What to do with a row that matches an existing item
+const (
+	// ImportSkip leaves the row out (the default)
+	ImportSkip = "skip"
+	// ImportMerge updates the matching item with the purchase price and attributes of the row
+	ImportMerge = "merge"
+	// ImportCreate creates the item anyway
+	ImportCreate = "create"
+)
+
+// importResolutions are the resolutions offered for a row with matches
+var importResolutions = []string{ImportSkip, ImportMerge, ImportCreate}
+
+// The status of a row in an import report
+const (
+	ImportCreated = "created"
+	ImportMerged  = "merged"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// ImportUsecase imports rows of items, e.g. from a spreadsheet, detecting the rows that match items the owner
+// already has, or earlier rows of the same import
+type ImportUsecase interface {
+	// ImportItems creates the item of each row in order, and resolves the rows matching an item by their
+	// OnDuplicate. Rows fail on their own: the rows before and after a failed row are still imported.
+	// With DryRun nothing is changed and the report previews the import.
+	ImportItems(ctx context.Context, input ImportInput) (*ImportReport, error)
+}
+
+type ImportInput struct {
+	TenantID string `json:"-"`
+	// OwnerID owns the created items; only the items of the owner are matched
+	OwnerID string `json:"-"`
+	// UserID is recorded in the change history of the merged items
+	UserID string      `json:"-"`
+	Rows   []ImportRow `json:"rows"`
+	// OnDuplicate is the resolution of the rows that do not set their own
+	OnDuplicate string `json:"on_duplicate,omitempty"`
+	DryRun      bool   `json:"dry_run"`
+}
+
+// ImportRow is an item to create, with what to do if it matches an existing item
+type ImportRow struct {
+	CreateItemInput
+	// OnDuplicate is one of skip, merge and create
+	OnDuplicate string `json:"on_duplicate,omitempty"`
+	// MergeInto is the matching item to merge the row into; the most likely match when left out
+	MergeInto int64 `json:"merge_into,omitempty"`
+}
+
+// ImportReport is the outcome of every row, in the order of the rows
+type ImportReport struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Merged  int               `json:"merged"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	// Row is the position of the row in the import, from 1
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	// ItemID is the item created or merged into; in a dry run, only items that already exist have an ID
+	ItemID int64 `json:"item_id,omitempty"`
+	// Matches are the items the row would duplicate, the most likely first
+	Matches []ImportMatch `json:"matches,omitempty"`
+	// Options are the resolutions to choose from for a row with matches
+	Options []string `json:"options,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ImportMatch is an item, or an earlier row of the same import, that a row matches
+type ImportMatch struct {
+	// ID is missing for the rows that a dry run would create
+	ID int64 `json:"id,omitempty"`
+	// Row is set when the item is an earlier row of the import
+	Row          int    `json:"row,omitempty"`
+	Name         string `json:"name"`
+	Brand        string `json:"brand"`
+	PurchaseDate string `json:"purchase_date"`
+	// Reason is "serial" for the same serial number, or "similar" for a close name, brand and purchase date
+	Reason     string  `json:"reason"`
+	Similarity float64 `json:"similarity,omitempty"`
+}
+
+type importUsecase struct {
+	itemUsecase ItemUsecase
+	itemRepo    ItemReader
+	brandRepo   BrandRepository
+}
+
+// NewImportUsecase creates the import usecase. Items are created and merged through itemUsecase, so that they are
+// validated and announced like any other change; brandRepo may be nil, in which case brands match as written.
+func NewImportUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, brandRepo BrandRepository) ImportUsecase {
+	return &importUsecase{
+		itemUsecase: itemUsecase,
+		itemRepo:    itemRepo,
+		brandRepo:   brandRepo,
+	}
+}
+
+// importCandidate is an item that later rows may match
+type importCandidate struct {
+	item *entity.Item // ID 0 for a row created in a dry run
+	row  int
+}
+
+func (u *importUsecase) ImportItems(ctx context.Context, input ImportInput) (*ImportReport, error) {
+	if err := validateImportInput(input); err != nil {
+		return nil, err
+	}
+
+	brands, err := loadBrandIndex(ReadOnly(ctx), u.brandRepo)
+	if err != nil {
+		return nil, err
+	}
+	ownerID := strings.TrimSpace(input.OwnerID)
+	var candidates []*importCandidate
+	err = u.itemRepo.Iterate(ReadOnly(ctx), ItemQuery{ItemFilter: ItemFilter{OwnerID: ownerID}}, func(item *entity.Item) error {
+		candidates = append(candidates, &importCandidate{item: item})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	report := &ImportReport{DryRun: input.DryRun, Rows: make([]ImportRowResult, 0, len(input.Rows))}
+	for i, row := range input.Rows {
+		// The rows already imported stay imported; the others are not started once the client has gone away
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("import stopped before row %d: %w", i+1, err)
+		}
+		row.TenantID = input.TenantID
+		row.OwnerID = ownerID
+		row.Brand = brands.canonical(row.Brand)
+		if row.OnDuplicate == "" {
+			row.OnDuplicate = input.OnDuplicate
+		}
+
+		result := ImportRowResult{Row: i + 1}
+		matches := findImportMatches(row.CreateItemInput, candidates, brands)
+		for _, match := range matches {
+			result.Matches = append(result.Matches, match.ImportMatch)
+		}
+		if len(matches) > 0 {
+			result.Options = importResolutions
+		}
+
+		created, err := u.importRow(ctx, input.UserID, input.DryRun, row, matches, &result)
+		switch {
+		case err == nil:
+		case errors.Is(err, domainErrors.ErrInvalidInput), errors.Is(err, domainErrors.ErrConflict),
+			domainErrors.IsNotFoundError(err), domainErrors.IsQuotaExceededError(err):
+			result.Status, result.ItemID, result.Error = ImportFailed, 0, err.Error()
+		default:
+			return nil, fmt.Errorf("failed to import row %d: %w", i+1, err)
+		}
+		if created != nil {
+			candidates = append(candidates, &importCandidate{item: created, row: i + 1})
+		}
+
+		switch result.Status {
+		case ImportCreated:
+			report.Created++
+		case ImportMerged:
+			report.Merged++
+		case ImportSkipped:
+			report.Skipped++
+		case ImportFailed:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+// importRow creates or merges a row according to its matches, and returns the item it created, for later rows to match
+func (u *importUsecase) importRow(ctx context.Context, userID string, dryRun bool, row ImportRow, matches []importMatch, result *ImportRowResult) (*entity.Item, error) {
+	resolution := ImportCreate
+	if len(matches) > 0 {
+		resolution = row.OnDuplicate
+		if resolution == "" {
+			resolution = ImportSkip
+		}
+	}
+	if row.MergeInto != 0 && resolution != ImportMerge {
+		return nil, fmt.Errorf("%w: merge_into is only used to merge a row matching items", domainErrors.ErrInvalidInput)
+	}
+
+	switch resolution {
+	case ImportSkip:
+		result.Status = ImportSkipped
+		return nil, nil
+
+	case ImportMerge:
+		target, err := mergeTarget(row, matches)
+		if err != nil {
+			return nil, err
+		}
+		// The purchase price of the row replaces the price of the item, whose currency cannot be changed
+		if currency := target.item.Price().Currency; row.Currency != "" && !strings.EqualFold(strings.TrimSpace(row.Currency), currency) {
+			return nil, fmt.Errorf("%w: currency must be %s to merge into item %d", domainErrors.ErrInvalidInput, currency, target.item.ID)
+		}
+		result.Status, result.ItemID = ImportMerged, target.item.ID
+		if dryRun {
+			return nil, nil
+		}
+		merged, err := u.itemUsecase.PatchItem(ctx, target.item.ID, importMergeRequest(userID, row, target.item))
+		if err != nil {
+			return nil, err
+		}
+		target.item = merged
+		return nil, nil
+	}
+
+	result.Status = ImportCreated
+	if dryRun {
+		// Later rows of a dry run match the item the row would create
+		return &entity.Item{
+			Name:         entity.SanitizeString(row.Name),
+			Category:     strings.TrimSpace(row.Category),
+			Brand:        entity.SanitizeString(row.Brand),
+			PurchaseDate: strings.TrimSpace(row.PurchaseDate),
+			Attributes:   row.Attributes,
+		}, nil
+	}
+	row.AllowDuplicate = true
+	created, err := u.itemUsecase.CreateItem(ctx, row.CreateItemInput)
+	if err != nil {
+		return nil, err
+	}
+	result.ItemID = created.ID
+	return created, nil
+}
+
+func validateImportInput(input ImportInput) error {
+	var errs []string
+	if len(input.Rows) == 0 {
+		errs = append(errs, "rows is required")
+	} else if len(input.Rows) > maxImportRows {
+		errs = append(errs, fmt.Sprintf("rows must be %d or fewer", maxImportRows))
+	}
+	if !validImportResolution(input.OnDuplicate) {
+		errs = append(errs, "on_duplicate must be one of skip, merge and create")
+	}
+	for i, row := range input.Rows {
+		if !validImportResolution(row.OnDuplicate) {
+			errs = append(errs, fmt.Sprintf("rows[%d].on_duplicate must be one of skip, merge and create", i))
+		}
+		if row.MergeInto < 0 {
+			errs = append(errs, fmt.Sprintf("rows[%d].merge_into must be positive", i))
+		}
+	}
+	if len(errs) > 0 {
+		return domainErrors.NewValidationError(errs...)
+	}
+	return nil
+}
+
+func validImportResolution(resolution string) bool {
+	return resolution == "" || resolution == ImportSkip || resolution == ImportMerge || resolution == ImportCreate
+}
+
+// importMatch is a match with the item it refers to
+type importMatch struct {
+	ImportMatch
+	*importCandidate
+}
+
+// findImportMatches returns the candidates with the serial number of the row, then the candidates of the same
+// category with a close name, brand and purchase date, the most similar first
+func findImportMatches(input CreateItemInput, candidates []*importCandidate, brands brandIndex) []importMatch {
+	var matches []importMatch
+	seen := make(map[*importCandidate]bool)
+	if serial := strings.TrimSpace(input.Attributes[serialAttribute]); serial != "" {
+		for _, candidate := range candidates {
+			if strings.EqualFold(strings.TrimSpace(candidate.item.Attributes[serialAttribute]), serial) {
+				seen[candidate] = true
+				matches = append(matches, newImportMatch(candidate, "serial", 0))
+			}
+		}
+	}
+
+	matcher, ok := newDuplicateMatcher(input)
+	if !ok {
+		return matches
+	}
+	category := strings.TrimSpace(input.Category)
+	var similar []importMatch
+	for _, candidate := range candidates {
+		if seen[candidate] || candidate.item.Category != category {
+			continue
+		}
+		item := *candidate.item
+		item.Brand = brands.canonical(item.Brand)
+		if duplicate, ok := matcher.match(&item); ok {
+			similar = append(similar, newImportMatch(candidate, "similar", duplicate.Similarity))
+		}
+	}
+	// Stable, so that equally similar items stay in the order they were read, then of their rows
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Similarity > similar[j].Similarity })
+	if len(similar) > maxDuplicateCandidates {
+		similar = similar[:maxDuplicateCandidates]
+	}
+	matches = append(matches, similar...)
+	return matches
+}
+
+func newImportMatch(candidate *importCandidate, reason string, similarity float64) importMatch {
+	return importMatch{
+		ImportMatch: ImportMatch{
+			ID:           candidate.item.ID,
+			Row:          candidate.row,
+			Name:         candidate.item.Name,
+			Brand:        candidate.item.Brand,
+			PurchaseDate: candidate.item.PurchaseDate,
+			Reason:       reason,
+			Similarity:   similarity,
+		},
+		importCandidate: candidate,
+	}
+}
+
+// mergeTarget is the match chosen by the row, or its first match
+func mergeTarget(row ImportRow, matches []importMatch) (importMatch, error) {
+	if row.MergeInto == 0 {
+		return matches[0], nil
+	}
+	for _, match := range matches {
+		if match.item.ID == row.MergeInto {
+			return match, nil
+		}
+	}
+	return importMatch{}, fmt.Errorf("%w: merge_into %d is not an item the row matches", domainErrors.ErrInvalidInput, row.MergeInto)
+}
+
+// importMergeRequest updates item with the purchase price and the attributes of the row; its name, category,
+// brand and purchase date are kept
+func importMergeRequest(userID string, row ImportRow, item *entity.Item) *UpdateItemRequest {
+	req := &UpdateItemRequest{
+		TenantID:      row.TenantID,
+		UserID:        userID,
+		PurchasePrice: &row.PurchasePrice,
+		Version:       &item.Version,
+	}
+	if len(row.Attributes) > 0 {
+		req.Attributes = make(map[string]*string, len(row.Attributes))
+		for key, value := range row.Attributes {
+			req.Attributes[key] = &value
+		}
+	}
+	return req
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// importedItems は alice の既存のアイテム（デイトナはシリアル番号 A1）を返すリポジトリ
+func importedItems() *MockItemRepository {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("Iterate", mock.Anything, ItemQuery{ItemFilter: ItemFilter{OwnerID: "alice"}}, mock.Anything).Return(iterateItems([]*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			Attributes: map[string]string{"serial": "A1"}, OwnerID: "alice", Version: 3},
+		{ID: 2, Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20", OwnerID: "alice", Version: 1},
+	}, nil))
+	return itemRepo
+}
+
+func importRow(name, category, brand, date string, attributes map[string]string) ImportRow {
+	return ImportRow{CreateItemInput: CreateItemInput{
+		Name: name, Category: category, Brand: brand, PurchasePrice: entity.MinorUnits(100), PurchaseDate: date, Attributes: attributes,
+	}}
+}
+
+func TestImportUsecase_ImportItems_DryRun(t *testing.T) {
+	itemRepo := importedItems()
+	u := NewImportUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, registeredBrands())
+
+	report, err := u.ImportItems(context.Background(), ImportInput{
+		OwnerID: "alice",
+		DryRun:  true,
+		Rows: []ImportRow{
+			importRow("デイトナ", "時計", "ロレックス", "2023-01-16", nil),
+			importRow("サブマリーナ", "時計", "ROLEX", "2024-06-01", map[string]string{"serial": " a1 "}),
+			importRow("スピードマスター", "時計", "OMEGA", "2024-03-01", nil),
+			importRow("スピードマスタ", "時計", "OMEGA", "2024-03-02", nil),
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &ImportReport{
+		DryRun:  true,
+		Created: 1,
+		Skipped: 3,
+		Rows: []ImportRowResult{
+			{
+				Row: 1, Status: ImportSkipped, Options: []string{"skip", "merge", "create"},
+				Matches: []ImportMatch{{ID: 1, Name: "デイトナ", Brand: "ROLEX", PurchaseDate: "2023-01-15", Reason: "similar", Similarity: 0.92}},
+			},
+			{
+				Row: 2, Status: ImportSkipped, Options: []string{"skip", "merge", "create"},
+				Matches: []ImportMatch{{ID: 1, Name: "デイトナ", Brand: "ROLEX", PurchaseDate: "2023-01-15", Reason: "serial"}},
+			},
+			{Row: 3, Status: ImportCreated},
+			{
+				Row: 4, Status: ImportSkipped, Options: []string{"skip", "merge", "create"},
+				Matches: []ImportMatch{{Row: 3, Name: "スピードマスター", Brand: "OMEGA", PurchaseDate: "2024-03-01", Reason: "similar", Similarity: 0.88}},
+			},
+		},
+	}, report)
+	itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestImportUsecase_ImportItems(t *testing.T) {
+	itemRepo := importedItems()
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Version: 3}, nil)
+	itemRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Version: 4}, nil)
+	itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Name == "デイトナ" })).
+		Return(&entity.Item{ID: 10, Name: "デイトナ"}, nil)
+	itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Name == "スピードマスター" })).
+		Return(&entity.Item{ID: 11, Name: "スピードマスター", Category: "時計", Brand: "OMEGA", PurchaseDate: "2024-03-01"}, nil)
+	attrRepo := new(MockCustomAttributeRepository)
+	attrRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.CustomAttribute{
+		{Key: "box", Type: entity.AttributeTypeEnum, Options: []string{"あり", "なし"}},
+	}, nil)
+	u := NewImportUsecase(NewItemUsecase(itemRepo, nil, attrRepo, nil, nil), itemRepo, registeredBrands())
+
+	merge := importRow("デイトナ", "時計", "ROLEX", "2023-01-15", map[string]string{"box": "あり"})
+	merge.OnDuplicate = ImportMerge
+	anyway := importRow("デイトナ", "時計", "ROLEX", "2023-01-15", nil)
+	anyway.OnDuplicate = ImportCreate
+	wrongTarget := importRow("バーキン", "バッグ", "Hermes", "2023-02-20", nil)
+	wrongTarget.OnDuplicate, wrongTarget.MergeInto = ImportMerge, 1
+	otherCurrency := importRow("デイトナ", "時計", "ROLEX", "2023-01-15", nil)
+	otherCurrency.OnDuplicate, otherCurrency.Currency = ImportMerge, "USD"
+
+	report, err := u.ImportItems(context.Background(), ImportInput{
+		OwnerID: "alice",
+		UserID:  "alice",
+		Rows: []ImportRow{
+			merge,
+			anyway,
+			wrongTarget,
+			importRow("スピードマスター", "時計", "OMEGA", "2024-03-01", nil),
+			importRow("スピードマスター", "時計", "OMEGA", "2024-03-01", nil),
+			importRow("", "時計", "OMEGA", "2024-03-01", nil),
+			otherCurrency,
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 1, 3}, []int{report.Created, report.Merged, report.Skipped, report.Failed})
+
+	t.Run("正常系: 一致したアイテムに価格と属性をまとめる", func(t *testing.T) {
+		assert.Equal(t, ImportMerged, report.Rows[0].Status)
+		assert.Equal(t, int64(1), report.Rows[0].ItemID)
+		itemRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == 100 && item.Attributes["box"] == "あり"
+		}))
+	})
+
+	t.Run("正常系: 一致しても登録する", func(t *testing.T) {
+		assert.Equal(t, ImportCreated, report.Rows[1].Status)
+		assert.Equal(t, int64(10), report.Rows[1].ItemID)
+		assert.Len(t, report.Rows[1].Matches, 1)
+	})
+
+	t.Run("異常系: 一致しないアイテムにはまとめられない", func(t *testing.T) {
+		assert.Equal(t, ImportFailed, report.Rows[2].Status)
+		assert.Contains(t, report.Rows[2].Error, "merge_into 1 is not an item the row matches")
+		assert.Equal(t, int64(2), report.Rows[2].Matches[0].ID)
+	})
+
+	t.Run("正常系: 同じインポートの前の行と一致する", func(t *testing.T) {
+		assert.Equal(t, ImportCreated, report.Rows[3].Status)
+		assert.Equal(t, ImportSkipped, report.Rows[4].Status)
+		assert.Equal(t, ImportMatch{ID: 11, Row: 4, Name: "スピードマスター", Brand: "OMEGA", PurchaseDate: "2024-03-01", Reason: "similar", Similarity: 1},
+			report.Rows[4].Matches[0])
+	})
+
+	t.Run("異常系: 不正な行だけが失敗する", func(t *testing.T) {
+		assert.Equal(t, ImportFailed, report.Rows[5].Status)
+		assert.Contains(t, report.Rows[5].Error, "invalid input")
+	})
+
+	t.Run("異常系: 通貨の違う行はまとめられない", func(t *testing.T) {
+		assert.Equal(t, ImportFailed, report.Rows[6].Status)
+		assert.Contains(t, report.Rows[6].Error, "currency must be JPY to merge into item 1")
+	})
+}
+
+func TestImportUsecase_ImportItems_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	itemRepo := importedItems()
+	// 1行目を登録したところでクライアントが切断した
+	itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 10, Name: "スピードマスター"}, nil).
+		Run(func(mock.Arguments) { cancel() })
+	u := NewImportUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, registeredBrands())
+
+	_, err := u.ImportItems(ctx, ImportInput{
+		OwnerID: "alice",
+		UserID:  "alice",
+		Rows: []ImportRow{
+			importRow("スピードマスター", "時計", "OMEGA", "2024-03-01", nil),
+			importRow("サブマリーナ", "時計", "ROLEX", "2024-06-01", nil),
+		},
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "import stopped before row 2")
+	itemRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestImportUsecase_ImportItems_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ImportInput
+	}{
+		{name: "異常系: 行がない", input: ImportInput{}},
+		{name: "異常系: 不明な解決方法", input: ImportInput{Rows: []ImportRow{{}}, OnDuplicate: "replace"}},
+		{name: "異常系: 行の不明な解決方法", input: ImportInput{Rows: []ImportRow{{OnDuplicate: "replace"}}}},
+		{name: "異常系: 行が多すぎる", input: ImportInput{Rows: make([]ImportRow, maxImportRows+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			_, err := NewImportUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, nil).ImportItems(context.Background(), tt.input)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
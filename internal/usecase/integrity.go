@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Kinds of inconsistencies found by the integrity check
+const (
+	// IssueOrphanedImage is an image whose item no longer exists; repaired by deleting the image
+	IssueOrphanedImage = "orphaned_image"
+	// IssueInvalidCategory is an item whose category is neither a built-in one nor one added by a tenant;
+	// repaired by moving the item to RepairCategory
+	IssueInvalidCategory = "invalid_category"
+	// IssueNegativePrice is an item with a negative purchase price; repaired by setting the price to 0
+	IssueNegativePrice = "negative_price"
+)
+
+// RepairCategory is the category items with an invalid category are moved to
+const RepairCategory = "その他"
+
+// IntegrityUsecase scans the stored data for inconsistencies left by old data or by edits outside the API
+type IntegrityUsecase interface {
+	// Check reports the inconsistencies; with repair it also repairs them
+	Check(ctx context.Context, repair bool) (*IntegrityReport, error)
+}
+
+// IntegrityIssue is one inconsistency. ImageID is only set for images.
+type IntegrityIssue struct {
+	Kind     string `json:"kind"`
+	ItemID   int64  `json:"item_id"`
+	ImageID  int64  `json:"image_id,omitempty"`
+	Detail   string `json:"detail"`
+	Repaired bool   `json:"repaired"`
+}
+
+// IntegrityReport is the result of a check
+type IntegrityReport struct {
+	Repair   bool              `json:"repair"`
+	Issues   []*IntegrityIssue `json:"issues"`
+	Repaired int               `json:"repaired"`
+}
+
+type integrityUsecase struct {
+	itemRepo     ItemRepository
+	imageRepo    ItemImageRepository
+	categoryRepo CategoryRepository
+}
+
+// NewIntegrityUsecase creates the usecase. Deleting an orphaned image only deletes its record;
+// the file is deleted afterwards by the media cleaner.
+// Items are not kept per tenant, so a category added by any tenant is valid. categoryRepo may be nil,
+// in which case only the built-in categories are.
+func NewIntegrityUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, categoryRepo CategoryRepository) IntegrityUsecase {
+	return &integrityUsecase{
+		itemRepo:     itemRepo,
+		imageRepo:    imageRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Check collects every issue before repairing any, so repairs are not made while the items are being read.
+// An issue that cannot be repaired because the item was changed in the meantime is reported as not repaired;
+// on any other error the report of what was repaired so far is returned along with the error.
+func (u *integrityUsecase) Check(ctx context.Context, repair bool) (*IntegrityReport, error) {
+	report := &IntegrityReport{Repair: repair, Issues: []*IntegrityIssue{}}
+	readCtx := ReadOnly(ctx)
+
+	var custom []string
+	if u.categoryRepo != nil {
+		names, err := u.categoryRepo.FindAllNames(readCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+		}
+		custom = names
+	}
+
+	var invalid []*entity.Item
+	items := make(map[int64]bool)
+	err := u.itemRepo.Iterate(readCtx, ItemQuery{}, func(item *entity.Item) error {
+		items[item.ID] = true
+		if !entity.IsValidCategory(item.Category, custom...) || item.PurchasePrice < 0 {
+			invalid = append(invalid, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	// Images of items in the trash are kept until the item is purged
+	trashed, err := u.itemRepo.FindTrashed(readCtx, TrashQuery{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve trash: %w", err)
+	}
+	for _, item := range trashed {
+		items[item.ID] = true
+	}
+
+	images, err := u.imageRepo.ListAllIDsByItem(readCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	var orphans []*IntegrityIssue
+	for itemID, imageIDs := range images {
+		if items[itemID] {
+			continue
+		}
+		for _, imageID := range imageIDs {
+			orphans = append(orphans, &IntegrityIssue{
+				Kind:    IssueOrphanedImage,
+				ItemID:  itemID,
+				ImageID: imageID,
+				Detail:  fmt.Sprintf("item %d does not exist", itemID),
+			})
+		}
+	}
+	// Ordered by image ID, as they are collected from a map
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].ImageID < orphans[j].ImageID })
+	report.Issues = append(report.Issues, orphans...)
+
+	for _, item := range invalid {
+		issues := itemIssues(item, custom)
+		report.Issues = append(report.Issues, issues...)
+		if !repair {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, fmt.Errorf("repair stopped: %w", err)
+		}
+		repaired, err := u.repairItem(ctx, item, custom)
+		if err != nil {
+			return report, err
+		}
+		if repaired {
+			for _, issue := range issues {
+				issue.Repaired = true
+				report.Repaired++
+			}
+		}
+	}
+
+	if repair {
+		for _, issue := range orphans {
+			if err := ctx.Err(); err != nil {
+				return report, fmt.Errorf("repair stopped: %w", err)
+			}
+			if err := u.imageRepo.Delete(ctx, issue.ImageID); err != nil && !domainErrors.IsNotFoundError(err) {
+				return report, fmt.Errorf("failed to delete image %d: %w", issue.ImageID, err)
+			}
+			issue.Repaired = true
+			report.Repaired++
+		}
+	}
+
+	return report, nil
+}
+
+// itemIssues lists the issues of an item found to be invalid; custom are the categories added by tenants
+func itemIssues(item *entity.Item, custom []string) []*IntegrityIssue {
+	var issues []*IntegrityIssue
+	if !entity.IsValidCategory(item.Category, custom...) {
+		issues = append(issues, &IntegrityIssue{
+			Kind:   IssueInvalidCategory,
+			ItemID: item.ID,
+			Detail: fmt.Sprintf("category %q is not valid", item.Category),
+		})
+	}
+	if item.PurchasePrice < 0 {
+		issues = append(issues, &IntegrityIssue{
+			Kind:   IssueNegativePrice,
+			ItemID: item.ID,
+			Detail: fmt.Sprintf("purchase price %d is negative", item.PurchasePrice),
+		})
+	}
+	return issues
+}
+
+// repairItem stores the repaired item, reporting false if it was changed or deleted since it was read
+func (u *integrityUsecase) repairItem(ctx context.Context, item *entity.Item, custom []string) (bool, error) {
+	if !entity.IsValidCategory(item.Category, custom...) {
+		item.Category = RepairCategory
+	}
+	if item.PurchasePrice < 0 {
+		item.PurchasePrice = 0
+	}
+
+	if _, err := u.itemRepo.Update(ctx, item); err != nil {
+		if domainErrors.IsConflictError(err) || domainErrors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to repair item %d: %w", item.ID, err)
+	}
+	return true, nil
+}
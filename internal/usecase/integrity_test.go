@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestIntegrityUsecase_Check(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// 1: 正常, 2: 削除されたカテゴリー, 3: 負の価格, 4: ゴミ箱
+	setup := func() (*MockItemRepository, *MockItemImageRepository) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{
+			{ID: 1, Category: "時計", PurchasePrice: 100, Version: 1},
+			{ID: 2, Category: "家具", PurchasePrice: 200, Version: 1},
+			{ID: 3, Category: "バッグ", PurchasePrice: -1, Version: 1},
+		}, nil))
+		itemRepo.On("FindTrashed", mock.Anything, TrashQuery{}).Return([]*entity.TrashedItem{trashedItem(4, deletedAt)}, nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("ListAllIDsByItem", mock.Anything).Return(map[int64][]int64{
+			1: {10},
+			4: {11},
+			9: {13, 12},
+		}, nil)
+		return itemRepo, imageRepo
+	}
+
+	t.Run("正常系: 不整合を報告するだけで修復しない", func(t *testing.T) {
+		itemRepo, imageRepo := setup()
+
+		report, err := NewIntegrityUsecase(itemRepo, imageRepo, nil).Check(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, &IntegrityReport{Issues: []*IntegrityIssue{
+			{Kind: IssueOrphanedImage, ItemID: 9, ImageID: 12, Detail: "item 9 does not exist"},
+			{Kind: IssueOrphanedImage, ItemID: 9, ImageID: 13, Detail: "item 9 does not exist"},
+			{Kind: IssueInvalidCategory, ItemID: 2, Detail: `category "家具" is not valid`},
+			{Kind: IssueNegativePrice, ItemID: 3, Detail: "purchase price -1 is negative"},
+		}}, report)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		imageRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 修復する", func(t *testing.T) {
+		itemRepo, imageRepo := setup()
+		itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.ID == 2 && item.Category == RepairCategory
+		})).Return(&entity.Item{ID: 2}, nil)
+		itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.ID == 3 && item.PurchasePrice == 0
+		})).Return(&entity.Item{ID: 3}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(12)).Return(nil)
+		imageRepo.On("Delete", mock.Anything, int64(13)).Return(domainErrors.ErrImageNotFound)
+
+		report, err := NewIntegrityUsecase(itemRepo, imageRepo, nil).Check(ctx, true)
+
+		require.NoError(t, err)
+		assert.True(t, report.Repair)
+		assert.Equal(t, 4, report.Repaired)
+		for _, issue := range report.Issues {
+			assert.True(t, issue.Repaired)
+		}
+		imageRepo.AssertNotCalled(t, "Delete", mock.Anything, int64(10))
+		imageRepo.AssertNotCalled(t, "Delete", mock.Anything, int64(11))
+	})
+
+	t.Run("正常系: 読み取り後に更新されたアイテムは修復しない", func(t *testing.T) {
+		itemRepo, imageRepo := setup()
+		itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.ID == 2 })).
+			Return(nil, domainErrors.ErrConflict)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 3}, nil)
+		imageRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+		report, err := NewIntegrityUsecase(itemRepo, imageRepo, nil).Check(ctx, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Repaired)
+		assert.False(t, report.Issues[2].Repaired)
+	})
+
+	t.Run("異常系: 修復に失敗した場合はそれまでの結果とエラーを返す", func(t *testing.T) {
+		itemRepo, imageRepo := setup()
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+		report, err := NewIntegrityUsecase(itemRepo, imageRepo, nil).Check(ctx, true)
+
+		assert.Error(t, err)
+		require.NotNil(t, report)
+		assert.Equal(t, 0, report.Repaired)
+	})
+
+	t.Run("異常系: アイテムの取得に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{}, errors.New("db down")))
+
+		report, err := NewIntegrityUsecase(itemRepo, new(MockItemImageRepository), nil).Check(ctx, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+	})
+}
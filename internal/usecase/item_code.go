@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// maxItemCodes is the number of codes an item may have
+const maxItemCodes = 20
+
+// ItemCodeLabel is the type reported for an item found by the code of its own label (see LabelUsecase)
+const ItemCodeLabel = "label"
+
+// ItemCodeUsecase manages the serial numbers, barcodes and QR codes of items, and finds items by a scanned code.
+// Codes are not part of the item, so changing them does not change the item version.
+type ItemCodeUsecase interface {
+	ListItemCodes(ctx context.Context, itemID int64) ([]*entity.ItemCode, error)
+	// SetItemCodes replaces the codes of an item; the same code given twice is stored once
+	SetItemCodes(ctx context.Context, actor string, itemID int64, codes []ItemCodeInput) ([]*entity.ItemCode, error)
+	// LookupCode returns the items a scanned code refers to: the items with it as a serial number, barcode or
+	// QR code, or else the item whose label it was read from. Items in the trash are not returned.
+	LookupCode(ctx context.Context, code string) (*ItemCodeLookup, error)
+}
+
+type ItemCodeInput struct {
+	Type string `json:"type"`
+	Code string `json:"code"`
+}
+
+// ItemCodeLookup lists the items a code refers to, in ID order. A barcode identifies a product rather than
+// one item, so more than one item can have it.
+type ItemCodeLookup struct {
+	Code  string                `json:"code"`
+	Items []*ItemCodeLookupItem `json:"items"`
+}
+
+// ItemCodeLookupItem is an item found by a code, with the type of the code it was found by
+type ItemCodeLookupItem struct {
+	ItemID int64  `json:"item_id"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Type   string `json:"type"`
+}
+
+type itemCodeUsecase struct {
+	itemRepo ItemReader
+	codeRepo ItemCodeRepository
+	uow      UnitOfWork
+	baseURL  string
+	now      func() time.Time
+}
+
+// NewItemCodeUsecase creates the item code usecase; uow may be nil, in which case no transactions are used.
+// The URLs of the items found are built from baseURL, like the URLs of labels.
+func NewItemCodeUsecase(itemRepo ItemReader, codeRepo ItemCodeRepository, uow UnitOfWork, baseURL string) ItemCodeUsecase {
+	return &itemCodeUsecase{
+		itemRepo: itemRepo,
+		codeRepo: codeRepo,
+		uow:      uow,
+		baseURL:  baseURL,
+		now:      time.Now,
+	}
+}
+
+func (u *itemCodeUsecase) ListItemCodes(ctx context.Context, itemID int64) ([]*entity.ItemCode, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return u.itemCodes(ctx, itemID)
+}
+
+func (u *itemCodeUsecase) SetItemCodes(ctx context.Context, actor string, itemID int64, inputs []ItemCodeInput) ([]*entity.ItemCode, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	codes, err := u.newItemCodes(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []*entity.ItemCode
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		if err := u.codeRepo.SetItemCodes(ctx, itemID, codes); err != nil {
+			return fmt.Errorf("failed to update item codes: %w", err)
+		}
+
+		stored, err = u.itemCodes(ctx, itemID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (u *itemCodeUsecase) LookupCode(ctx context.Context, code string) (*ItemCodeLookup, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, fmt.Errorf("%w: code is required", domainErrors.ErrInvalidInput)
+	}
+
+	ctx = ReadOnly(ctx)
+	matches, err := u.codeRepo.FindItems(ctx, scannedCodes(code))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item codes: %w", err)
+	}
+	// A code stored on an item takes precedence over an item ID: "4901234567894" is more likely a barcode
+	if len(matches) == 0 {
+		if id, ok := parseLabelCode(code); ok {
+			matches = []ItemCodeMatch{{ItemID: id, Type: ItemCodeLabel}}
+		}
+	}
+
+	lookup := &ItemCodeLookup{Code: code, Items: []*ItemCodeLookupItem{}}
+	seen := make(map[int64]bool, len(matches))
+	for _, match := range matches {
+		if seen[match.ItemID] {
+			continue
+		}
+		seen[match.ItemID] = true
+
+		item, err := u.itemRepo.FindByID(ctx, match.ItemID)
+		if err != nil {
+			// The codes of items in the trash (or purged) are kept, but the items are not found by them
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		lookup.Items = append(lookup.Items, &ItemCodeLookupItem{
+			ItemID: item.ID,
+			Name:   item.Name,
+			URL:    fmt.Sprintf("%s/items/%d", u.baseURL, item.ID),
+			Type:   match.Type,
+		})
+	}
+	if len(lookup.Items) == 0 {
+		return nil, domainErrors.NotFound(domainErrors.ErrItemCodeNotFound, "no item has the code %q", code)
+	}
+
+	return lookup, nil
+}
+
+// newItemCodes validates and normalizes the codes of an item, dropping repeated codes
+func (u *itemCodeUsecase) newItemCodes(inputs []ItemCodeInput) ([]*entity.ItemCode, error) {
+	var errs []domainErrors.FieldError
+	codes := make([]*entity.ItemCode, 0, len(inputs))
+	seen := make(map[entity.ItemCode]bool, len(inputs))
+	now := u.now()
+	for i, input := range inputs {
+		code, err := entity.NewItemCode(input.Type, input.Code, now)
+		if err != nil {
+			// The field is that of the code, such as codes[0].type
+			fieldErr := domainErrors.NewFieldError(err.Error())
+			fieldErr.Field = strings.TrimSuffix(fmt.Sprintf("codes[%d].%s", i, fieldErr.Field), ".")
+			fieldErr.Message = fmt.Sprintf("codes[%d]: %s", i, err.Error())
+			errs = append(errs, fieldErr)
+			continue
+		}
+		key := entity.ItemCode{Type: code.Type, Code: code.Code}
+		if !seen[key] {
+			seen[key] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, domainErrors.Validation(errs...)
+	}
+	if len(codes) > maxItemCodes {
+		return nil, fmt.Errorf("%w: an item can have at most %d codes", domainErrors.ErrInvalidInput, maxItemCodes)
+	}
+	return codes, nil
+}
+
+func (u *itemCodeUsecase) itemCodes(ctx context.Context, itemID int64) ([]*entity.ItemCode, error) {
+	codes, err := u.codeRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item codes: %w", err)
+	}
+	if codes == nil {
+		codes = []*entity.ItemCode{}
+	}
+	return codes, nil
+}
+
+// scannedCodes returns the codes a scanned value can be, normalized for each type: the scanner does not
+// tell whether it read a serial number, a barcode or a QR code
+func scannedCodes(code string) []*entity.ItemCode {
+	var codes []*entity.ItemCode
+	for _, codeType := range entity.ItemCodeTypes {
+		if c, err := entity.NewItemCode(codeType, code, time.Time{}); err == nil {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
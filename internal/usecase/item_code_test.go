@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockItemCodeRepository はアイテムのコードのリポジトリのモック
+type MockItemCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemCodeRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemCode, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemCode), args.Error(1)
+}
+
+func (m *MockItemCodeRepository) SetItemCodes(ctx context.Context, itemID int64, codes []*entity.ItemCode) error {
+	args := m.Called(ctx, itemID, codes)
+	return args.Error(0)
+}
+
+func (m *MockItemCodeRepository) FindItems(ctx context.Context, codes []*entity.ItemCode) ([]ItemCodeMatch, error) {
+	args := m.Called(ctx, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ItemCodeMatch), args.Error(1)
+}
+
+var codeNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestItemCodeUsecase(itemRepo ItemRepository, codeRepo ItemCodeRepository) ItemCodeUsecase {
+	u := NewItemCodeUsecase(itemRepo, codeRepo, nil, "https://inventory.example.com").(*itemCodeUsecase)
+	u.now = func() time.Time { return codeNow }
+	return u
+}
+
+func TestItemCodeUsecase_SetItemCodes(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		inputs      []ItemCodeInput
+		expected    []*entity.ItemCode
+		expectedErr string
+	}{
+		{
+			name:  "正常系: 正規化し、重複は1つにする",
+			actor: "alice",
+			inputs: []ItemCodeInput{
+				{Type: "serial", Code: "z123456"}, {Type: "ean", Code: "036000291452"}, {Type: "Serial", Code: " Z123456 "},
+			},
+			expected: []*entity.ItemCode{
+				{Type: entity.ItemCodeSerial, Code: "Z123456", CreatedAt: codeNow}, {Type: entity.ItemCodeEAN, Code: "0036000291452", CreatedAt: codeNow},
+			},
+		},
+		{name: "正常系: 空の配列ですべて外す", actor: "alice", inputs: nil, expected: []*entity.ItemCode{}},
+		{
+			name:        "異常系: 不正なコードをまとめて報告する",
+			actor:       "alice",
+			inputs:      []ItemCodeInput{{Type: "ean", Code: "4901234567895"}, {Type: "serial", Code: "A1"}, {Type: "isbn", Code: "1"}},
+			expectedErr: `invalid input: codes[0]: ean "4901234567895" must be an EAN-8, UPC-A, EAN-13 or GTIN-14 with a valid check digit, codes[2]: type must be one of: serial, ean, qr`,
+		},
+		{name: "異常系: 所有者以外", actor: "bob", inputs: []ItemCodeInput{{Type: "serial", Code: "A1"}}, expectedErr: "forbidden: item 1 is not owned by bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil).Maybe()
+			codeRepo := new(MockItemCodeRepository)
+			codeRepo.On("SetItemCodes", mock.Anything, int64(1), tt.expected).Return(nil).Maybe()
+			codeRepo.On("FindByItemID", mock.Anything, int64(1)).Return(tt.expected, nil).Maybe()
+
+			codes, err := newTestItemCodeUsecase(itemRepo, codeRepo).SetItemCodes(context.Background(), tt.actor, 1, tt.inputs)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				codeRepo.AssertNotCalled(t, "SetItemCodes", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, codes)
+			codeRepo.AssertCalled(t, "SetItemCodes", mock.Anything, int64(1), tt.expected)
+			itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestItemCodeUsecase_LookupCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		candidates  []*entity.ItemCode
+		matches     []ItemCodeMatch
+		expected    []*ItemCodeLookupItem
+		expectedErr error
+	}{
+		{
+			name: "正常系: バーコードの商品のアイテムをすべて返す",
+			code: "036000291452",
+			candidates: []*entity.ItemCode{
+				{Type: entity.ItemCodeSerial, Code: "036000291452"}, {Type: entity.ItemCodeEAN, Code: "0036000291452"}, {Type: entity.ItemCodeQR, Code: "036000291452"},
+			},
+			matches: []ItemCodeMatch{{ItemID: 1, Type: entity.ItemCodeEAN}, {ItemID: 2, Type: entity.ItemCodeEAN}},
+			expected: []*ItemCodeLookupItem{
+				{ItemID: 1, Name: "Daytona", URL: "https://inventory.example.com/items/1", Type: entity.ItemCodeEAN},
+				{ItemID: 2, Name: "Submariner", URL: "https://inventory.example.com/items/2", Type: entity.ItemCodeEAN},
+			},
+		},
+		{
+			name:       "正常系: ゴミ箱のアイテムと、同じアイテムの2つ目のコードは除く",
+			code:       "z123",
+			candidates: []*entity.ItemCode{{Type: entity.ItemCodeSerial, Code: "Z123"}, {Type: entity.ItemCodeQR, Code: "z123"}},
+			matches:    []ItemCodeMatch{{ItemID: 1, Type: entity.ItemCodeQR}, {ItemID: 1, Type: entity.ItemCodeSerial}, {ItemID: 3, Type: entity.ItemCodeSerial}},
+			expected:   []*ItemCodeLookupItem{{ItemID: 1, Name: "Daytona", URL: "https://inventory.example.com/items/1", Type: entity.ItemCodeQR}},
+		},
+		{
+			name:       "正常系: 登録されたコードがなければラベルの QR コードとして探す",
+			code:       "https://old.example.com/items/2",
+			candidates: []*entity.ItemCode{{Type: entity.ItemCodeSerial, Code: "HTTPS://OLD.EXAMPLE.COM/ITEMS/2"}, {Type: entity.ItemCodeQR, Code: "https://old.example.com/items/2"}},
+			expected:   []*ItemCodeLookupItem{{ItemID: 2, Name: "Submariner", URL: "https://inventory.example.com/items/2", Type: ItemCodeLabel}},
+		},
+		{
+			name:        "異常系: どのアイテムのコードでもない",
+			code:        "No such code",
+			candidates:  []*entity.ItemCode{{Type: entity.ItemCodeSerial, Code: "NO SUCH CODE"}, {Type: entity.ItemCodeQR, Code: "No such code"}},
+			expectedErr: domainErrors.ErrItemCodeNotFound,
+		},
+		{name: "異常系: 空", code: " ", expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "Daytona"}, nil).Maybe()
+			itemRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2, Name: "Submariner"}, nil).Maybe()
+			itemRepo.On("FindByID", mock.Anything, int64(3)).Return(nil, domainErrors.ErrItemNotFound).Maybe()
+			codeRepo := new(MockItemCodeRepository)
+			codeRepo.On("FindItems", mock.Anything, mock.Anything).Return(tt.matches, nil).Maybe()
+
+			lookup, err := newTestItemCodeUsecase(itemRepo, codeRepo).LookupCode(context.Background(), tt.code)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, lookup)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, lookup.Items)
+			codeRepo.AssertCalled(t, "FindItems", mock.Anything, tt.candidates)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ItemExportQuery selects and orders the items of a bulk export; the order defaults to created_at ascending
+type ItemExportQuery struct {
+	// TenantID is the tenant exporting; the categories it added are valid for Category
+	TenantID  string
+	Category  string
+	Brand     string
+	SortBy    string
+	SortOrder string
+
+	// Resumable exports the items in ascending ID order instead, so an export that stopped can be resumed after
+	// the last ID received with AfterID. SortBy and SortOrder must be empty.
+	Resumable bool
+	AfterID   int64
+}
+
+// ItemExportUsecase streams every matching item for CSV/JSON/NDJSON exports
+type ItemExportUsecase interface {
+	// ExportItems validates the query and calls fn for each matching item in order.
+	// Items are read from the repository cursor one at a time, so the result set is never held in memory.
+	// fn is not called when the query is invalid; an error returned by fn stops the export and is returned.
+	ExportItems(ctx context.Context, query ItemExportQuery, fn func(*entity.Item) error) error
+}
+
+type itemExportUsecase struct {
+	itemRepo     ItemReader
+	categoryRepo CategoryRepository
+}
+
+// NewItemExportUsecase creates the export usecase; categoryRepo may be nil, in which case only the built-in
+// categories are valid for the category filter
+func NewItemExportUsecase(itemRepo ItemReader, categoryRepo CategoryRepository) ItemExportUsecase {
+	return &itemExportUsecase{
+		itemRepo:     itemRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (u *itemExportUsecase) ExportItems(ctx context.Context, query ItemExportQuery, fn func(*entity.Item) error) error {
+	itemQuery := ItemQuery{
+		ItemFilter: ItemFilter{
+			Category: query.Category,
+			Brand:    strings.TrimSpace(query.Brand),
+		},
+	}
+	if query.Resumable {
+		var errs []string
+		if query.SortBy != "" {
+			errs = append(errs, "sort must be empty in a resumable export, which is in ID order")
+		}
+		if query.SortOrder != "" {
+			errs = append(errs, "order must be empty in a resumable export, which is in ID order")
+		}
+		if query.AfterID < 0 {
+			errs = append(errs, "after_id must be 0 or greater")
+		}
+		if len(errs) > 0 {
+			return domainErrors.NewValidationError(errs...)
+		}
+		itemQuery.SortBy, itemQuery.SortOrder, itemQuery.AfterID = SortByID, entity.SortAsc, query.AfterID
+	} else {
+		settings := entity.ListSettings{
+			SortBy:    "created_at",
+			SortOrder: entity.SortAsc,
+		}
+		if query.SortBy != "" {
+			settings.SortBy = query.SortBy
+		}
+		if query.SortOrder != "" {
+			settings.SortOrder = strings.ToLower(query.SortOrder)
+		}
+		if err := settings.Validate(); err != nil {
+			return domainErrors.Invalid(err)
+		}
+		itemQuery.SortBy, itemQuery.SortOrder = settings.SortBy, settings.SortOrder
+	}
+
+	if err := checkCategory(ctx, u.categoryRepo, query.TenantID, query.Category); err != nil {
+		return err
+	}
+
+	it, err := u.itemRepo.FindAllStream(ReadOnly(ctx), itemQuery)
+	if err != nil {
+		return err
+	}
+	return eachItem(it, fn)
+}
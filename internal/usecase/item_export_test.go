@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemExportUsecase_ExportItems(t *testing.T) {
+	item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2023-01-01")
+	item2, _ := entity.NewItem("時計2", "時計", "OMEGA", 2000, "2023-01-02")
+	errStop := errors.New("client disconnected")
+
+	tests := []struct {
+		name          string
+		query         ItemExportQuery
+		expectedQuery *ItemQuery
+		repoErr       error
+		fnErr         error
+		expectedItems []*entity.Item
+		expectedErr   error
+	}{
+		{
+			name:          "正常系: デフォルトは作成日時の昇順",
+			query:         ItemExportQuery{},
+			expectedQuery: &ItemQuery{SortBy: "created_at", SortOrder: entity.SortAsc},
+			expectedItems: []*entity.Item{item1, item2},
+		},
+		{
+			name:          "正常系: 絞り込みと並び替え",
+			query:         ItemExportQuery{Category: "時計", Brand: " ROLEX ", SortBy: "purchase_price", SortOrder: "DESC"},
+			expectedQuery: &ItemQuery{ItemFilter: ItemFilter{Category: "時計", Brand: "ROLEX"}, SortBy: "purchase_price", SortOrder: entity.SortDesc},
+			expectedItems: []*entity.Item{item1, item2},
+		},
+		{
+			name:          "正常系: 再開できるエクスポートは after_id の後から ID の順",
+			query:         ItemExportQuery{Brand: "ROLEX", Resumable: true, AfterID: 10},
+			expectedQuery: &ItemQuery{ItemFilter: ItemFilter{Brand: "ROLEX"}, SortBy: SortByID, SortOrder: entity.SortAsc, AfterID: 10},
+			expectedItems: []*entity.Item{item1, item2},
+		},
+		{
+			name:        "異常系: 再開できるエクスポートは並び替えられない",
+			query:       ItemExportQuery{SortBy: "name", SortOrder: "asc", Resumable: true, AfterID: -1},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 不正なカテゴリー",
+			query:       ItemExportQuery{Category: "車"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: ソートできない列",
+			query:       ItemExportQuery{SortBy: "brand"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:          "異常系: 書き込みに失敗したら中断する",
+			query:         ItemExportQuery{},
+			expectedQuery: &ItemQuery{SortBy: "created_at", SortOrder: entity.SortAsc},
+			fnErr:         errStop,
+			expectedItems: []*entity.Item{item1},
+			expectedErr:   errStop,
+		},
+		{
+			name:          "異常系: 読み込み途中のデータベースエラー",
+			query:         ItemExportQuery{},
+			expectedQuery: &ItemQuery{SortBy: "created_at", SortOrder: entity.SortAsc},
+			repoErr:       domainErrors.ErrDatabaseError,
+			expectedItems: []*entity.Item{item1, item2},
+			expectedErr:   domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedQuery != nil {
+				mockRepo.On("FindAllStream", mock.MatchedBy(IsReadOnly), *tt.expectedQuery).Return(newTestItemIterator([]*entity.Item{item1, item2}, tt.repoErr), nil)
+			}
+
+			var exported []*entity.Item
+			err := NewItemExportUsecase(mockRepo, nil).ExportItems(context.Background(), tt.query, func(item *entity.Item) error {
+				exported = append(exported, item)
+				return tt.fnErr
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedItems, exported)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
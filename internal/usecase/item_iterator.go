@@ -0,0 +1,52 @@
+package usecase
+
+import "Aicon-assignment/internal/domain/entity"
+
+// sliceItemIterator iterates over items already loaded
+type sliceItemIterator struct {
+	items []*entity.Item
+	next  int
+}
+
+// NewSliceItemIterator returns an iterator over items, for repositories that hold their items in memory
+func NewSliceItemIterator(items []*entity.Item) ItemIterator {
+	return &sliceItemIterator{items: items}
+}
+
+func (it *sliceItemIterator) Next() bool {
+	if it.next >= len(it.items) {
+		return false
+	}
+	it.next++
+	return true
+}
+
+func (it *sliceItemIterator) Item() *entity.Item {
+	return it.items[it.next-1]
+}
+
+func (it *sliceItemIterator) Err() error {
+	return nil
+}
+
+func (it *sliceItemIterator) Close() error {
+	it.items = nil
+	return nil
+}
+
+// eachItem calls fn for each item of it and closes it. Iteration stops at the first error returned by fn,
+// which is returned unchanged.
+func eachItem(it ItemIterator, fn func(*entity.Item) error) (err error) {
+	defer func() {
+		if closeErr := it.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	for it.Next() {
+		if err := fn(it.Item()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
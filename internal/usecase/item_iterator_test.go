@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// testItemIterator は items を読んだ後に err で終わるイテレーター。閉じたかを記録する
+type testItemIterator struct {
+	ItemIterator
+	err    error
+	closed bool
+}
+
+func newTestItemIterator(items []*entity.Item, err error) *testItemIterator {
+	return &testItemIterator{ItemIterator: NewSliceItemIterator(items), err: err}
+}
+
+func (it *testItemIterator) Err() error {
+	return it.err
+}
+
+func (it *testItemIterator) Close() error {
+	it.closed = true
+	return it.ItemIterator.Close()
+}
+
+// iterateItems は MockItemRepository の Iterate の戻り値。items を順に fn に渡し、最後に err を返す
+//
+//	itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+func iterateItems(items []*entity.Item, err error) func(context.Context, ItemQuery, func(*entity.Item) error) error {
+	return func(ctx context.Context, query ItemQuery, fn func(*entity.Item) error) error {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+func TestEachItem(t *testing.T) {
+	items := []*entity.Item{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	t.Run("正常系: すべてのアイテムを順に渡して閉じる", func(t *testing.T) {
+		it := newTestItemIterator(items, nil)
+		var ids []int64
+		err := eachItem(it, func(item *entity.Item) error {
+			ids = append(ids, item.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, ids)
+		assert.True(t, it.closed)
+	})
+
+	t.Run("異常系: コールバックのエラーで中断して閉じる", func(t *testing.T) {
+		errStop := errors.New("stop")
+		it := newTestItemIterator(items, nil)
+		calls := 0
+		err := eachItem(it, func(item *entity.Item) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, calls)
+		assert.True(t, it.closed)
+	})
+
+	t.Run("異常系: 読み込み途中のエラーを返す", func(t *testing.T) {
+		errRead := errors.New("connection reset")
+		it := newTestItemIterator(items[:1], errRead)
+		err := eachItem(it, func(item *entity.Item) error { return nil })
+		assert.ErrorIs(t, err, errRead)
+		assert.True(t, it.closed)
+	})
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const maxLabelBatchSize = 500
+
+// Formats of the label of a single item
+const (
+	LabelFormatPNG = "png"
+	LabelFormatPDF = "pdf"
+)
+
+// LabelContentTypes are the content types of the label formats
+var LabelContentTypes = map[string]string{
+	LabelFormatPNG: "image/png",
+	LabelFormatPDF: "application/pdf",
+}
+
+// The serial printed under the QR code ("No. 42"), which can be typed in when a code cannot be scanned
+var labelSerial = regexp.MustCompile(`^(?i:no\.?\s*)?(\d+)$`)
+
+// The path of the item URL encoded in QR codes, under any base URL so that labels printed before a move keep working
+var labelItemPath = regexp.MustCompile(`/items/(\d+)/?$`)
+
+// LabelTemplate describes the layout of a printable label sheet (sizes in points)
+type LabelTemplate struct {
+	Name        string  `json:"name"`
+	PageWidth   float64 `json:"page_width"`
+	PageHeight  float64 `json:"page_height"`
+	Columns     int     `json:"columns"`
+	Rows        int     `json:"rows"`
+	LabelWidth  float64 `json:"label_width"`
+	LabelHeight float64 `json:"label_height"`
+	MarginLeft  float64 `json:"margin_left"`
+	MarginTop   float64 `json:"margin_top"`
+	GapX        float64 `json:"gap_x"`
+	GapY        float64 `json:"gap_y"`
+	Border      bool    `json:"border"`
+}
+
+// Label is the content printed on a single label
+type Label struct {
+	Code   string // QR payload
+	Name   string
+	Serial string
+}
+
+// LabelRenderer renders labels onto printable sheets
+type LabelRenderer interface {
+	Render(tmpl LabelTemplate, labels []Label) ([]byte, error)
+}
+
+// LabelImageRenderer renders a single label as a PNG image
+type LabelImageRenderer interface {
+	RenderImage(l Label) ([]byte, error)
+}
+
+type LabelUsecase interface {
+	RenderLabelBatch(ctx context.Context, input LabelBatchInput) ([]byte, error)
+	// RenderItemLabel renders the label of one item, as an image or as a PDF page the size of one label of the template
+	RenderItemLabel(ctx context.Context, input ItemLabelInput) ([]byte, error)
+	// LookupCode resolves a scanned label code, the item URL or the serial under it, to the item
+	LookupCode(ctx context.Context, code string) (*LabelLookup, error)
+}
+
+type LabelBatchInput struct {
+	ItemIDs  []int64 `json:"item_ids"`
+	Template string  `json:"template"`
+}
+
+type ItemLabelInput struct {
+	ItemID   int64
+	Format   string // png or pdf
+	Template string // for pdf; the default template if empty
+}
+
+// LabelLookup is the item a label code refers to
+type LabelLookup struct {
+	ItemID int64  `json:"item_id"`
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+}
+
+type labelUsecase struct {
+	itemRepo        ItemReader
+	renderer        LabelRenderer
+	imageRenderer   LabelImageRenderer
+	templates       map[string]LabelTemplate
+	defaultTemplate string
+	baseURL         string
+}
+
+func NewLabelUsecase(itemRepo ItemReader, renderer LabelRenderer, imageRenderer LabelImageRenderer, templates []LabelTemplate, defaultTemplate, baseURL string) LabelUsecase {
+	byName := make(map[string]LabelTemplate, len(templates))
+	for _, tmpl := range templates {
+		byName[tmpl.Name] = tmpl
+	}
+
+	return &labelUsecase{
+		itemRepo:        itemRepo,
+		renderer:        renderer,
+		imageRenderer:   imageRenderer,
+		templates:       byName,
+		defaultTemplate: defaultTemplate,
+		baseURL:         baseURL,
+	}
+}
+
+func (u *labelUsecase) RenderLabelBatch(ctx context.Context, input LabelBatchInput) ([]byte, error) {
+	if len(input.ItemIDs) == 0 {
+		return nil, fmt.Errorf("%w: item_ids is required", domainErrors.ErrInvalidInput)
+	}
+	if len(input.ItemIDs) > maxLabelBatchSize {
+		return nil, fmt.Errorf("%w: item_ids must contain %d items or less", domainErrors.ErrInvalidInput, maxLabelBatchSize)
+	}
+
+	tmpl, err := u.template(input.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]Label, 0, len(input.ItemIDs))
+	for _, id := range input.ItemIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: invalid item id: %d", domainErrors.ErrInvalidInput, id)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, domainErrors.NotFound(domainErrors.ErrItemNotFound, "id %d", id)
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+
+		labels = append(labels, u.label(item))
+	}
+
+	// Rendering is not cancellable, so it is not started for a client that has gone away
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	out, err := u.renderer.Render(tmpl, labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render labels: %w", err)
+	}
+
+	return out, nil
+}
+
+func (u *labelUsecase) RenderItemLabel(ctx context.Context, input ItemLabelInput) ([]byte, error) {
+	if input.ItemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if _, ok := LabelContentTypes[input.Format]; !ok {
+		return nil, fmt.Errorf("%w: format must be one of: png, pdf", domainErrors.ErrInvalidInput)
+	}
+	var tmpl LabelTemplate
+	if input.Format == LabelFormatPDF {
+		var err error
+		if tmpl, err = u.template(input.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, input.ItemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	var out []byte
+	if input.Format == LabelFormatPDF {
+		// A page holding just the one label, for label printers that print a label per page
+		single := tmpl
+		single.PageWidth, single.PageHeight = tmpl.LabelWidth, tmpl.LabelHeight
+		single.Columns, single.Rows = 1, 1
+		single.MarginLeft, single.MarginTop, single.GapX, single.GapY = 0, 0, 0, 0
+		out, err = u.renderer.Render(single, []Label{u.label(item)})
+	} else {
+		out, err = u.imageRenderer.RenderImage(u.label(item))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render label: %w", err)
+	}
+
+	return out, nil
+}
+
+func (u *labelUsecase) LookupCode(ctx context.Context, code string) (*LabelLookup, error) {
+	id, ok := parseLabelCode(code)
+	if !ok {
+		return nil, fmt.Errorf("%w: code must be an item URL or item ID", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return &LabelLookup{ItemID: item.ID, Name: item.Name, URL: u.itemURL(item.ID)}, nil
+}
+
+func (u *labelUsecase) template(name string) (LabelTemplate, error) {
+	if name == "" {
+		name = u.defaultTemplate
+	}
+	tmpl, ok := u.templates[name]
+	if !ok {
+		return LabelTemplate{}, fmt.Errorf("%w: unknown label template: %s", domainErrors.ErrInvalidInput, name)
+	}
+	return tmpl, nil
+}
+
+// label is the label of an item: a QR code of its URL, with its name and serial
+func (u *labelUsecase) label(item *entity.Item) Label {
+	return Label{
+		Code:   u.itemURL(item.ID),
+		Name:   item.Name,
+		Serial: "No. " + strconv.FormatInt(item.ID, 10),
+	}
+}
+
+func (u *labelUsecase) itemURL(id int64) string {
+	return fmt.Sprintf("%s/items/%d", u.baseURL, id)
+}
+
+// parseLabelCode returns the item ID of a scanned or typed label code
+func parseLabelCode(code string) (int64, bool) {
+	code = strings.TrimSpace(code)
+
+	var digits string
+	if m := labelSerial.FindStringSubmatch(code); m != nil {
+		digits = m[1]
+	} else if parsed, err := url.Parse(code); err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") {
+		if m := labelItemPath.FindStringSubmatch(parsed.Path); m != nil {
+			digits = m[1]
+		}
+	}
+	if digits == "" {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
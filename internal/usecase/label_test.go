@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockLabelRenderer はラベル描画のモック
+type MockLabelRenderer struct {
+	mock.Mock
+}
+
+func (m *MockLabelRenderer) Render(tmpl LabelTemplate, labels []Label) ([]byte, error) {
+	args := m.Called(tmpl, labels)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// MockLabelImageRenderer はラベル画像描画のモック
+type MockLabelImageRenderer struct {
+	mock.Mock
+}
+
+func (m *MockLabelImageRenderer) RenderImage(l Label) ([]byte, error) {
+	args := m.Called(l)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestLabelUsecase_RenderLabelBatch(t *testing.T) {
+	templates := []LabelTemplate{{Name: "a4-3x8"}, {Name: "letter-3x10"}}
+
+	tests := []struct {
+		name        string
+		input       LabelBatchInput
+		setupMock   func(*MockItemRepository, *MockLabelRenderer)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: デフォルトテンプレートで描画",
+			input: LabelBatchInput{ItemIDs: []int64{1}},
+			setupMock: func(mockRepo *MockItemRepository, mockRenderer *MockLabelRenderer) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRenderer.On("Render", LabelTemplate{Name: "a4-3x8"}, []Label{
+					{Code: "http://example.com/items/1", Name: "ロレックス デイトナ", Serial: "No. 1"},
+				}).Return([]byte("%PDF"), nil)
+			},
+		},
+		{
+			name:  "正常系: テンプレートを指定",
+			input: LabelBatchInput{ItemIDs: []int64{2}, Template: "letter-3x10"},
+			setupMock: func(mockRepo *MockItemRepository, mockRenderer *MockLabelRenderer) {
+				item, _ := entity.NewItem("エルメス バーキン", "バッグ", "HERMÈS", 2000000, "2023-02-20")
+				item.ID = 2
+				mockRepo.On("FindByID", mock.Anything, int64(2)).Return(item, nil)
+				mockRenderer.On("Render", LabelTemplate{Name: "letter-3x10"}, mock.Anything).Return([]byte("%PDF"), nil)
+			},
+		},
+		{
+			name:        "異常系: アイテムIDが空",
+			input:       LabelBatchInput{},
+			setupMock:   func(*MockItemRepository, *MockLabelRenderer) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 上限を超えるアイテム数",
+			input:       LabelBatchInput{ItemIDs: make([]int64, maxLabelBatchSize+1)},
+			setupMock:   func(*MockItemRepository, *MockLabelRenderer) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 存在しないテンプレート",
+			input:       LabelBatchInput{ItemIDs: []int64{1}, Template: "unknown"},
+			setupMock:   func(*MockItemRepository, *MockLabelRenderer) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			input: LabelBatchInput{ItemIDs: []int64{999}},
+			setupMock: func(mockRepo *MockItemRepository, mockRenderer *MockLabelRenderer) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRenderer := new(MockLabelRenderer)
+			tt.setupMock(mockRepo, mockRenderer)
+			usecase := NewLabelUsecase(mockRepo, mockRenderer, new(MockLabelImageRenderer), templates, "a4-3x8", "http://example.com")
+
+			out, err := usecase.RenderLabelBatch(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, out)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("%PDF"), out)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockRenderer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLabelUsecase_RenderItemLabel(t *testing.T) {
+	templates := []LabelTemplate{{
+		Name: "a4-3x8", PageWidth: 595, PageHeight: 842, Columns: 3, Rows: 8,
+		LabelWidth: 180, LabelHeight: 100, MarginLeft: 10, MarginTop: 20, GapX: 5, GapY: 2,
+	}}
+	label := Label{Code: "http://example.com/items/1", Name: "ロレックス デイトナ", Serial: "No. 1"}
+
+	tests := []struct {
+		name        string
+		input       ItemLabelInput
+		setupMock   func(*MockItemRepository, *MockLabelRenderer, *MockLabelImageRenderer)
+		expected    []byte
+		expectedErr error
+	}{
+		{
+			name:  "正常系: PNG で描画",
+			input: ItemLabelInput{ItemID: 1, Format: LabelFormatPNG},
+			setupMock: func(mockRepo *MockItemRepository, _ *MockLabelRenderer, mockImage *MockLabelImageRenderer) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockImage.On("RenderImage", label).Return([]byte("\x89PNG"), nil)
+			},
+			expected: []byte("\x89PNG"),
+		},
+		{
+			name:  "正常系: PDF はラベル1枚分の大きさのページに描画",
+			input: ItemLabelInput{ItemID: 1, Format: LabelFormatPDF},
+			setupMock: func(mockRepo *MockItemRepository, mockRenderer *MockLabelRenderer, _ *MockLabelImageRenderer) {
+				item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRenderer.On("Render", LabelTemplate{
+					Name: "a4-3x8", PageWidth: 180, PageHeight: 100, Columns: 1, Rows: 1, LabelWidth: 180, LabelHeight: 100,
+				}, []Label{label}).Return([]byte("%PDF"), nil)
+			},
+			expected: []byte("%PDF"),
+		},
+		{
+			name:        "異常系: 不正な形式",
+			input:       ItemLabelInput{ItemID: 1, Format: "gif"},
+			setupMock:   func(*MockItemRepository, *MockLabelRenderer, *MockLabelImageRenderer) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 存在しないテンプレート",
+			input:       ItemLabelInput{ItemID: 1, Format: LabelFormatPDF, Template: "unknown"},
+			setupMock:   func(*MockItemRepository, *MockLabelRenderer, *MockLabelImageRenderer) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 存在しないアイテム",
+			input: ItemLabelInput{ItemID: 999, Format: LabelFormatPNG},
+			setupMock: func(mockRepo *MockItemRepository, _ *MockLabelRenderer, _ *MockLabelImageRenderer) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRenderer := new(MockLabelRenderer)
+			mockImage := new(MockLabelImageRenderer)
+			tt.setupMock(mockRepo, mockRenderer, mockImage)
+			usecase := NewLabelUsecase(mockRepo, mockRenderer, mockImage, templates, "a4-3x8", "http://example.com")
+
+			out, err := usecase.RenderItemLabel(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, out)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, out)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockRenderer.AssertExpectations(t)
+			mockImage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLabelUsecase_LookupCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		expectedID  int64
+		expectedErr error
+	}{
+		{name: "正常系: ラベルのURL", code: "http://example.com/items/42", expectedID: 42},
+		{name: "正常系: 移転前のURL", code: "https://old.example.com/app/items/42/", expectedID: 42},
+		{name: "正常系: シリアル", code: " No. 42 ", expectedID: 42},
+		{name: "正常系: アイテムIDのみ", code: "42", expectedID: 42},
+		{name: "異常系: 空", code: "", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: アイテムのURLではない", code: "http://example.com/webhooks/42", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: http(s) 以外のURL", code: "ftp://example.com/items/42", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: ID が 0", code: "0", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 存在しないアイテム", code: "999", expectedID: 999, expectedErr: domainErrors.ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedID != 0 {
+				if tt.expectedErr != nil {
+					mockRepo.On("FindByID", mock.Anything, tt.expectedID).Return(nil, domainErrors.ErrItemNotFound)
+				} else {
+					item, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+					item.ID = tt.expectedID
+					mockRepo.On("FindByID", mock.Anything, tt.expectedID).Return(item, nil)
+				}
+			}
+			usecase := NewLabelUsecase(mockRepo, new(MockLabelRenderer), new(MockLabelImageRenderer), nil, "", "http://example.com")
+
+			lookup, err := usecase.LookupCode(context.Background(), tt.code)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, lookup)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &LabelLookup{ItemID: 42, Name: "ロレックス デイトナ", URL: "http://example.com/items/42"}, lookup)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type LoanUsecase interface {
+	LendItem(ctx context.Context, actor string, itemID int64, input LoanInput) (*entity.Loan, error)
+	ReturnLoan(ctx context.Context, actor string, id int64) (*entity.Loan, error)
+	ListItemLoans(ctx context.Context, itemID int64) ([]*entity.Loan, error)
+	ListOverdueLoans(ctx context.Context) ([]*entity.Loan, error)
+}
+
+type LoanInput struct {
+	Borrower string `json:"borrower"`
+	DueDate  string `json:"due_date"`
+	Note     string `json:"note"`
+}
+
+type loanUsecase struct {
+	itemRepo ItemReader
+	loanRepo LoanRepository
+	uow      UnitOfWork
+	now      func() time.Time
+}
+
+// NewLoanUsecase creates the loan usecase; uow may be nil, in which case no transactions are used
+func NewLoanUsecase(itemRepo ItemReader, loanRepo LoanRepository, uow UnitOfWork) LoanUsecase {
+	return &loanUsecase{
+		itemRepo: itemRepo,
+		loanRepo: loanRepo,
+		uow:      uow,
+		now:      time.Now,
+	}
+}
+
+// LendItem records that the item has been lent out; an item can be on one loan at a time.
+// Items without an owner (created before ownership was tracked) may be lent by any user.
+func (u *loanUsecase) LendItem(ctx context.Context, actor string, itemID int64, input LoanInput) (*entity.Loan, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var created *entity.Loan
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		// The item row is locked for the rest of the transaction, so a concurrent loan or delete waits for this one
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		active, err := u.loanRepo.FindActiveByItemID(ctx, itemID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve loans: %w", err)
+		}
+		if active != nil {
+			return domainErrors.Conflict("item %d is already on loan to %s", itemID, active.Borrower)
+		}
+
+		loan, err := entity.NewLoan(itemID, actor, input.Borrower, input.DueDate, input.Note, u.now())
+		if err != nil {
+			return domainErrors.Invalid(err)
+		}
+
+		created, err = u.loanRepo.Create(ctx, loan)
+		if err != nil {
+			return fmt.Errorf("failed to create loan: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ReturnLoan records that a lent item is back; only the lender may record the return
+func (u *loanUsecase) ReturnLoan(ctx context.Context, actor string, id int64) (*entity.Loan, error) {
+	loan, err := findByID(ctx, id, u.loanRepo.FindByID, domainErrors.ErrLoanNotFound, "loan")
+	if err != nil {
+		return nil, err
+	}
+	if loan.Lender != "" && loan.Lender != actor {
+		return nil, domainErrors.PermissionDenied("loan %d cannot be returned by %s", id, actor)
+	}
+	if !loan.IsActive() {
+		return nil, domainErrors.Conflict("loan %d is already %s", id, loan.Status)
+	}
+
+	loan.Return(u.now())
+	if err := u.loanRepo.MarkReturned(ctx, loan); err != nil {
+		if domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to return loan: %w", err)
+	}
+
+	return loan, nil
+}
+
+func (u *loanUsecase) ListItemLoans(ctx context.Context, itemID int64) ([]*entity.Loan, error) {
+	if _, err := findByID(ctx, itemID, u.itemRepo.FindByID, domainErrors.ErrItemNotFound, "item"); err != nil {
+		return nil, err
+	}
+
+	loans, err := u.loanRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve loans: %w", err)
+	}
+
+	if loans == nil {
+		loans = []*entity.Loan{}
+	}
+
+	return loans, nil
+}
+
+// ListOverdueLoans returns the loans past their due date that have not been returned, earliest due first
+func (u *loanUsecase) ListOverdueLoans(ctx context.Context) ([]*entity.Loan, error) {
+	loans, err := u.loanRepo.FindOverdue(ReadOnly(ctx), u.now().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve overdue loans: %w", err)
+	}
+
+	if loans == nil {
+		loans = []*entity.Loan{}
+	}
+
+	return loans, nil
+}
+
+type loanCheckingItemUsecase struct {
+	ItemUsecase
+	itemRepo ItemReader
+	loanRepo LoanRepository
+	uow      UnitOfWork
+}
+
+// NewLoanCheckingItemUsecase refuses to delete items that are on loan; the check and the delete run in one transaction
+func NewLoanCheckingItemUsecase(inner ItemUsecase, itemRepo ItemReader, loanRepo LoanRepository, uow UnitOfWork) ItemUsecase {
+	return &loanCheckingItemUsecase{
+		ItemUsecase: inner,
+		itemRepo:    itemRepo,
+		loanRepo:    loanRepo,
+		uow:         uow,
+	}
+}
+
+func (u *loanCheckingItemUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		// Lock the item first, so a loan cannot start between the check and the delete
+		if _, err := u.itemRepo.FindByID(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to check item existence: %w", err)
+		}
+
+		loan, err := u.loanRepo.FindActiveByItemID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve loans: %w", err)
+		}
+		if loan != nil {
+			return domainErrors.Conflict("item %d is on loan to %s until %s", id, loan.Borrower, loan.DueDate)
+		}
+
+		return u.ItemUsecase.DeleteItem(ctx, id, ifMatch)
+	})
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockLoanRepository は貸出リポジトリのモック
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) Create(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	args := m.Called(ctx, loan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindByID(ctx context.Context, id int64) (*entity.Loan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Loan, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindActiveByItemID(ctx context.Context, itemID int64) (*entity.Loan, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindOverdue(ctx context.Context, today string) ([]*entity.Loan, error) {
+	args := m.Called(ctx, today)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) MarkReturned(ctx context.Context, loan *entity.Loan) error {
+	args := m.Called(ctx, loan)
+	return args.Error(0)
+}
+
+// 2024-03-10 を今日とする
+var loanToday = time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+
+func newTestLoanUsecase(itemRepo ItemRepository, loanRepo LoanRepository) LoanUsecase {
+	u := NewLoanUsecase(itemRepo, loanRepo, nil).(*loanUsecase)
+	u.now = func() time.Time { return loanToday }
+	return u
+}
+
+func TestLoanUsecase_LendItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		input       LoanInput
+		item        *entity.Item
+		active      *entity.Loan
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 所有者が貸し出す",
+			actor: "alice",
+			input: LoanInput{Borrower: "ギャラリーA", DueDate: "2024-04-30", Note: "展示用"},
+			item:  ownedItem(1, "alice"),
+		},
+		{
+			name:  "正常系: 今日が返却期限",
+			actor: "alice",
+			input: LoanInput{Borrower: "bob", DueDate: "2024-03-10"},
+			item:  ownedItem(1, "alice"),
+		},
+		{
+			name:        "異常系: 所有者以外は貸し出せない",
+			actor:       "mallory",
+			input:       LoanInput{Borrower: "bob", DueDate: "2024-04-30"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:        "異常系: 貸出中のアイテム",
+			actor:       "alice",
+			input:       LoanInput{Borrower: "bob", DueDate: "2024-04-30"},
+			item:        ownedItem(1, "alice"),
+			active:      &entity.Loan{ID: 9, ItemID: 1, Borrower: "carol", Status: entity.LoanStatusActive},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: 過去の返却期限",
+			actor:       "alice",
+			input:       LoanInput{Borrower: "bob", DueDate: "2024-03-09"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 借り手がない",
+			actor:       "alice",
+			input:       LoanInput{DueDate: "2024/04/30"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: アイテムが存在しない",
+			actor:       "alice",
+			input:       LoanInput{Borrower: "bob", DueDate: "2024-04-30"},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			loanRepo := new(MockLoanRepository)
+			if tt.item != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+				loanRepo.On("FindActiveByItemID", mock.Anything, int64(1)).Return(tt.active, nil).Maybe()
+			} else {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			}
+			var stored *entity.Loan
+			if tt.expectedErr == nil {
+				loanRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Loan")).
+					Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.Loan) }).
+					Return(&entity.Loan{ID: 1, ItemID: 1, Status: entity.LoanStatusActive}, nil)
+			}
+
+			loan, err := newTestLoanUsecase(itemRepo, loanRepo).LendItem(context.Background(), tt.actor, 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, loan)
+				loanRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), loan.ID)
+			assert.Equal(t, tt.actor, stored.Lender)
+			assert.Equal(t, tt.input.Borrower, stored.Borrower)
+			assert.Equal(t, tt.input.DueDate, stored.DueDate)
+		})
+	}
+}
+
+func TestLoanUsecase_ReturnLoan(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		loan        *entity.Loan
+		markErr     error
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 貸し出したユーザーが返却を記録",
+			actor: "alice",
+			loan:  &entity.Loan{ID: 1, Lender: "alice", Status: entity.LoanStatusActive},
+		},
+		{
+			name:  "正常系: 貸主のない貸出は誰でも返却を記録できる",
+			actor: "bob",
+			loan:  &entity.Loan{ID: 1, Status: entity.LoanStatusActive},
+		},
+		{
+			name:        "異常系: 貸主以外",
+			actor:       "mallory",
+			loan:        &entity.Loan{ID: 1, Lender: "alice", Status: entity.LoanStatusActive},
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:        "異常系: 返却済み",
+			actor:       "alice",
+			loan:        &entity.Loan{ID: 1, Lender: "alice", Status: entity.LoanStatusReturned},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: 同時に返却された",
+			actor:       "alice",
+			loan:        &entity.Loan{ID: 1, Lender: "alice", Status: entity.LoanStatusActive},
+			markErr:     domainErrors.ErrConflict,
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: 貸出が存在しない",
+			actor:       "alice",
+			expectedErr: domainErrors.ErrLoanNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			if tt.loan != nil {
+				loanRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.loan, nil)
+			} else {
+				loanRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrLoanNotFound)
+			}
+			loanRepo.On("MarkReturned", mock.Anything, mock.AnythingOfType("*entity.Loan")).Return(tt.markErr).Maybe()
+
+			loan, err := newTestLoanUsecase(new(MockItemRepository), loanRepo).ReturnLoan(context.Background(), tt.actor, 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entity.LoanStatusReturned, loan.Status)
+			assert.Equal(t, loanToday, *loan.ReturnedAt)
+		})
+	}
+}
+
+func TestLoanUsecase_ListOverdueLoans(t *testing.T) {
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("FindOverdue", mock.Anything, "2024-03-10").Return(nil, nil)
+
+	loans, err := newTestLoanUsecase(new(MockItemRepository), loanRepo).ListOverdueLoans(context.Background())
+
+	require.NoError(t, err)
+	assert.NotNil(t, loans)
+	loanRepo.AssertExpectations(t)
+}
+
+func TestLoanCheckingItemUsecase_DeleteItem(t *testing.T) {
+	t.Run("正常系: 貸出中でなければ削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, ""), nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("FindActiveByItemID", mock.Anything, int64(1)).Return(nil, nil)
+		usecase := NewLoanCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, loanRepo, nil)
+
+		err := usecase.DeleteItem(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+		itemRepo.AssertCalled(t, "Trash", mock.Anything, int64(1), mock.Anything)
+	})
+
+	t.Run("異常系: 貸出中のアイテムは削除できない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, ""), nil)
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("FindActiveByItemID", mock.Anything, int64(1)).
+			Return(&entity.Loan{ID: 3, ItemID: 1, Borrower: "ギャラリーA", DueDate: "2024-04-30", Status: entity.LoanStatusActive}, nil)
+		usecase := NewLoanCheckingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, loanRepo, nil)
+
+		err := usecase.DeleteItem(context.Background(), 1, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrConflict)
+		assert.ErrorContains(t, err, "on loan to ギャラリーA until 2024-04-30")
+		itemRepo.AssertNotCalled(t, "Trash", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// DefaultServiceIntervalYears is the interval of the unserviced items report when none is given
+	// (mechanical watches are usually overhauled every 3 to 5 years)
+	DefaultServiceIntervalYears = 3
+	maxServiceIntervalYears     = 100
+)
+
+// ServiceRecordUsecase manages the maintenance history of items.
+// Changing the records of an item increments its version, since its maintenance_cost changes with them.
+type ServiceRecordUsecase interface {
+	CreateServiceRecord(ctx context.Context, actor string, itemID int64, input ServiceRecordInput) (*entity.ServiceRecord, error)
+	ListServiceRecords(ctx context.Context, itemID int64) ([]*entity.ServiceRecord, error)
+	GetServiceRecord(ctx context.Context, itemID, id int64) (*entity.ServiceRecord, error)
+	UpdateServiceRecord(ctx context.Context, actor string, itemID, id int64, input ServiceRecordInput) (*entity.ServiceRecord, error)
+	DeleteServiceRecord(ctx context.Context, actor string, itemID, id int64) error
+	// ListUnservicedItems returns the items whose last service (or purchase, if never serviced) is more than
+	// the given number of years ago, longest unserviced first; years 0 means DefaultServiceIntervalYears
+	ListUnservicedItems(ctx context.Context, years int) ([]*UnservicedItem, error)
+}
+
+type ServiceRecordInput struct {
+	ServiceDate string `json:"service_date"`
+	Vendor      string `json:"vendor"`
+	Cost        int    `json:"cost"`
+	Notes       string `json:"notes"`
+}
+
+// UnservicedItem is an item listed by the unserviced items report
+type UnservicedItem struct {
+	Item *entity.Item `json:"item"`
+	// LastServiceDate is empty if the item has never been serviced
+	LastServiceDate string `json:"last_service_date,omitempty"`
+}
+
+type serviceRecordUsecase struct {
+	itemRepo    ItemRepository
+	serviceRepo ServiceRecordRepository
+	uow         UnitOfWork
+	now         func() time.Time
+}
+
+// NewServiceRecordUsecase creates the service record usecase; uow may be nil, in which case no transactions are used
+func NewServiceRecordUsecase(itemRepo ItemRepository, serviceRepo ServiceRecordRepository, uow UnitOfWork) ServiceRecordUsecase {
+	return &serviceRecordUsecase{
+		itemRepo:    itemRepo,
+		serviceRepo: serviceRepo,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+func (u *serviceRecordUsecase) CreateServiceRecord(ctx context.Context, actor string, itemID int64, input ServiceRecordInput) (*entity.ServiceRecord, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var created *entity.ServiceRecord
+	err := u.changeRecords(ctx, actor, itemID, func(ctx context.Context) error {
+		record, err := entity.NewServiceRecord(itemID, input.ServiceDate, input.Vendor, input.Cost, input.Notes, u.now())
+		if err != nil {
+			return domainErrors.Invalid(err)
+		}
+
+		created, err = u.serviceRepo.Create(ctx, record)
+		if err != nil {
+			return fmt.Errorf("failed to create service record: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *serviceRecordUsecase) ListServiceRecords(ctx context.Context, itemID int64) ([]*entity.ServiceRecord, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	records, err := u.serviceRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve service records: %w", err)
+	}
+
+	if records == nil {
+		records = []*entity.ServiceRecord{}
+	}
+
+	return records, nil
+}
+
+func (u *serviceRecordUsecase) GetServiceRecord(ctx context.Context, itemID, id int64) (*entity.ServiceRecord, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	return u.findRecord(ReadOnly(ctx), itemID, id)
+}
+
+func (u *serviceRecordUsecase) UpdateServiceRecord(ctx context.Context, actor string, itemID, id int64, input ServiceRecordInput) (*entity.ServiceRecord, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var updated *entity.ServiceRecord
+	err := u.changeRecords(ctx, actor, itemID, func(ctx context.Context) error {
+		record, err := u.findRecord(ctx, itemID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := record.Change(input.ServiceDate, input.Vendor, input.Cost, input.Notes, u.now()); err != nil {
+			return domainErrors.Invalid(err)
+		}
+
+		updated, err = u.serviceRepo.Update(ctx, record)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to update service record: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *serviceRecordUsecase) DeleteServiceRecord(ctx context.Context, actor string, itemID, id int64) error {
+	if itemID <= 0 || id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	return u.changeRecords(ctx, actor, itemID, func(ctx context.Context) error {
+		if _, err := u.findRecord(ctx, itemID, id); err != nil {
+			return err
+		}
+
+		if err := u.serviceRepo.Delete(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to delete service record: %w", err)
+		}
+		return nil
+	})
+}
+
+func (u *serviceRecordUsecase) ListUnservicedItems(ctx context.Context, years int) ([]*UnservicedItem, error) {
+	if years == 0 {
+		years = DefaultServiceIntervalYears
+	}
+	if years < 1 || years > maxServiceIntervalYears {
+		return nil, fmt.Errorf("%w: years must be between 1 and %d", domainErrors.ErrInvalidInput, maxServiceIntervalYears)
+	}
+
+	ctx = ReadOnly(ctx)
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{SortBy: "purchase_date", SortOrder: entity.SortAsc})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	summaries, err := u.serviceRepo.SummarizeByItem(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve service records: %w", err)
+	}
+
+	cutoff := u.now().AddDate(-years, 0, 0).Format("2006-01-02")
+	unserviced := []*UnservicedItem{}
+	for _, item := range items {
+		entry := &UnservicedItem{Item: item}
+		if summary, ok := summaries[item.ID]; ok {
+			item.MaintenanceCost = summary.TotalCost
+			entry.LastServiceDate = summary.LastServiceDate
+		}
+		if sinceDate(entry) < cutoff {
+			unserviced = append(unserviced, entry)
+		}
+	}
+
+	sort.SliceStable(unserviced, func(i, j int) bool {
+		return sinceDate(unserviced[i]) < sinceDate(unserviced[j])
+	})
+
+	return unserviced, nil
+}
+
+// sinceDate is the date an item has gone unserviced since: its last service, or its purchase if never serviced
+func sinceDate(entry *UnservicedItem) string {
+	if entry.LastServiceDate != "" {
+		return entry.LastServiceDate
+	}
+	return entry.Item.PurchaseDate
+}
+
+// changeRecords runs fn in a transaction after checking that actor may maintain the item, then increments
+// the item version. Items without an owner (created before ownership was tracked) may be maintained by any user.
+func (u *serviceRecordUsecase) changeRecords(ctx context.Context, actor string, itemID int64, fn func(ctx context.Context) error) error {
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		// The item row is locked for the rest of the transaction, so concurrent changes to its records are serialized
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		item.UpdatedAt = u.now()
+		if _, err := u.itemRepo.Update(ctx, item); err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return nil
+	})
+}
+
+// findRecord retrieves a record of the item; records of other items are reported as not found
+func (u *serviceRecordUsecase) findRecord(ctx context.Context, itemID, id int64) (*entity.ServiceRecord, error) {
+	record, err := u.serviceRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrServiceRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve service record: %w", err)
+	}
+	if record.ItemID != itemID {
+		return nil, domainErrors.ErrServiceRecordNotFound
+	}
+
+	return record, nil
+}
+
+type maintenanceCostItemUsecase struct {
+	ItemUsecase
+	serviceRepo ServiceRecordRepository
+}
+
+// NewMaintenanceCostItemUsecase fills in the maintenance cost of the items returned by inner from their service records
+func NewMaintenanceCostItemUsecase(inner ItemUsecase, serviceRepo ServiceRecordRepository) ItemUsecase {
+	return &maintenanceCostItemUsecase{
+		ItemUsecase: inner,
+		serviceRepo: serviceRepo,
+	}
+}
+
+func (u *maintenanceCostItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
+	items, err := u.ItemUsecase.GetAllItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setMaintenanceCosts(ReadOnly(ctx), items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (u *maintenanceCostItemUsecase) ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error) {
+	list, err := u.ItemUsecase.ListItems(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setMaintenanceCosts(ReadOnly(ctx), list.Items); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (u *maintenanceCostItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, err := u.ItemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setMaintenanceCosts(ReadOnly(ctx), []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *maintenanceCostItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	item, err := u.ItemUsecase.PatchItem(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setMaintenanceCosts(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *maintenanceCostItemUsecase) setMaintenanceCosts(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	summaries, err := u.serviceRepo.SummarizeByItem(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve service records: %w", err)
+	}
+
+	for _, item := range items {
+		if summary, ok := summaries[item.ID]; ok {
+			item.MaintenanceCost = summary.TotalCost
+		}
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockServiceRecordRepository は整備記録リポジトリのモック
+type MockServiceRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceRecordRepository) Create(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error) {
+	args := m.Called(ctx, record)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ServiceRecord), args.Error(1)
+}
+
+func (m *MockServiceRecordRepository) FindByID(ctx context.Context, id int64) (*entity.ServiceRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ServiceRecord), args.Error(1)
+}
+
+func (m *MockServiceRecordRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ServiceRecord, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ServiceRecord), args.Error(1)
+}
+
+func (m *MockServiceRecordRepository) Update(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error) {
+	args := m.Called(ctx, record)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ServiceRecord), args.Error(1)
+}
+
+func (m *MockServiceRecordRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockServiceRecordRepository) SummarizeByItem(ctx context.Context, itemIDs []int64) (map[int64]*ServiceSummary, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*ServiceSummary), args.Error(1)
+}
+
+// 2024-03-10 を今日とする
+var serviceToday = time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+
+func newTestServiceRecordUsecase(itemRepo ItemRepository, serviceRepo ServiceRecordRepository) ServiceRecordUsecase {
+	u := NewServiceRecordUsecase(itemRepo, serviceRepo, nil).(*serviceRecordUsecase)
+	u.now = func() time.Time { return serviceToday }
+	return u
+}
+
+func TestServiceRecordUsecase_CreateServiceRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		input       ServiceRecordInput
+		item        *entity.Item
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 所有者が整備を記録",
+			actor: "alice",
+			input: ServiceRecordInput{ServiceDate: "2024-03-01", Vendor: "日本ロレックス", Cost: 85000, Notes: "オーバーホール"},
+			item:  ownedItem(1, "alice"),
+		},
+		{
+			name:  "正常系: 所有者のないアイテムは誰でも記録できる",
+			actor: "bob",
+			input: ServiceRecordInput{ServiceDate: "2024-03-10", Vendor: "時計店A"},
+			item:  ownedItem(1, ""),
+		},
+		{
+			name:        "異常系: 所有者以外は記録できない",
+			actor:       "mallory",
+			input:       ServiceRecordInput{ServiceDate: "2024-03-01", Vendor: "時計店A"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:        "異常系: 未来の日付",
+			actor:       "alice",
+			input:       ServiceRecordInput{ServiceDate: "2024-03-11", Vendor: "時計店A"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 業者がなく費用が負",
+			actor:       "alice",
+			input:       ServiceRecordInput{ServiceDate: "2024-03-01", Cost: -1},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: アイテムが存在しない",
+			actor:       "alice",
+			input:       ServiceRecordInput{ServiceDate: "2024-03-01", Vendor: "時計店A"},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			serviceRepo := new(MockServiceRecordRepository)
+			if tt.item != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+			} else {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			}
+			var stored *entity.ServiceRecord
+			if tt.expectedErr == nil {
+				serviceRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ServiceRecord")).
+					Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.ServiceRecord) }).
+					Return(&entity.ServiceRecord{ID: 1, ItemID: 1}, nil)
+				itemRepo.On("Update", mock.Anything, tt.item).Return(tt.item, nil)
+			}
+
+			record, err := newTestServiceRecordUsecase(itemRepo, serviceRepo).CreateServiceRecord(context.Background(), tt.actor, 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, record)
+				serviceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), record.ID)
+			assert.Equal(t, tt.input.ServiceDate, stored.ServiceDate)
+			assert.Equal(t, tt.input.Vendor, stored.Vendor)
+			assert.Equal(t, tt.input.Cost, stored.Cost)
+			// maintenance_cost が変わるため、アイテムのバージョンを上げる
+			itemRepo.AssertCalled(t, "Update", mock.Anything, tt.item)
+			assert.Equal(t, serviceToday, tt.item.UpdatedAt)
+		})
+	}
+}
+
+func TestServiceRecordUsecase_UpdateServiceRecord(t *testing.T) {
+	input := ServiceRecordInput{ServiceDate: "2024-02-01", Vendor: "時計店B", Cost: 30000}
+
+	t.Run("正常系: 記録を置き換える", func(t *testing.T) {
+		item := ownedItem(1, "alice")
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(item, nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("FindByID", mock.Anything, int64(5)).
+			Return(&entity.ServiceRecord{ID: 5, ItemID: 1, ServiceDate: "2024-01-01", Vendor: "時計店A", Cost: 1000}, nil)
+		var updated *entity.ServiceRecord
+		serviceRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.ServiceRecord")).
+			Run(func(args mock.Arguments) { updated = args.Get(1).(*entity.ServiceRecord) }).
+			Return(&entity.ServiceRecord{ID: 5, ItemID: 1, Vendor: "時計店B", Cost: 30000}, nil)
+
+		record, err := newTestServiceRecordUsecase(itemRepo, serviceRepo).UpdateServiceRecord(context.Background(), "alice", 1, 5, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, 30000, record.Cost)
+		assert.Equal(t, "2024-02-01", updated.ServiceDate)
+		assert.Equal(t, serviceToday, updated.UpdatedAt)
+		itemRepo.AssertCalled(t, "Update", mock.Anything, item)
+	})
+
+	t.Run("異常系: 他のアイテムの記録", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ServiceRecord{ID: 5, ItemID: 2}, nil)
+
+		record, err := newTestServiceRecordUsecase(itemRepo, serviceRepo).UpdateServiceRecord(context.Background(), "alice", 1, 5, input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrServiceRecordNotFound)
+		assert.Nil(t, record)
+		serviceRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServiceRecordUsecase_DeleteServiceRecord(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		findErr     error
+		expectedErr error
+	}{
+		{name: "正常系: 所有者が削除", actor: "alice"},
+		{name: "異常系: 所有者以外", actor: "mallory", expectedErr: domainErrors.ErrForbidden},
+		{name: "異常系: 記録が存在しない", actor: "alice", findErr: domainErrors.ErrServiceRecordNotFound, expectedErr: domainErrors.ErrServiceRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := ownedItem(1, "alice")
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			itemRepo.On("Update", mock.Anything, item).Return(item, nil).Maybe()
+			serviceRepo := new(MockServiceRecordRepository)
+			if tt.findErr != nil {
+				serviceRepo.On("FindByID", mock.Anything, int64(5)).Return(nil, tt.findErr)
+			} else {
+				serviceRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.ServiceRecord{ID: 5, ItemID: 1}, nil).Maybe()
+			}
+			serviceRepo.On("Delete", mock.Anything, int64(5)).Return(nil).Maybe()
+
+			err := newTestServiceRecordUsecase(itemRepo, serviceRepo).DeleteServiceRecord(context.Background(), tt.actor, 1, 5)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				serviceRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			serviceRepo.AssertCalled(t, "Delete", mock.Anything, int64(5))
+			itemRepo.AssertCalled(t, "Update", mock.Anything, item)
+		})
+	}
+}
+
+func TestServiceRecordUsecase_ListServiceRecords(t *testing.T) {
+	t.Run("正常系: 記録がなければ空の一覧", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, ""), nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, nil)
+
+		records, err := newTestServiceRecordUsecase(itemRepo, serviceRepo).ListServiceRecords(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.NotNil(t, records)
+		assert.Empty(t, records)
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := newTestServiceRecordUsecase(itemRepo, new(MockServiceRecordRepository)).ListServiceRecords(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestServiceRecordUsecase_ListUnservicedItems(t *testing.T) {
+	item := func(id int64, purchaseDate string) *entity.Item {
+		return &entity.Item{ID: id, PurchaseDate: purchaseDate}
+	}
+
+	tests := []struct {
+		name        string
+		years       int
+		expectedIDs []int64
+		expectedErr error
+	}{
+		// 今日は 2024-03-10。3年前は 2021-03-10
+		{name: "正常系: 3年以上整備していないアイテムを古い順に", years: 0, expectedIDs: []int64{3, 1}},
+		{name: "正常系: 年数を指定", years: 5, expectedIDs: []int64{3}},
+		{name: "異常系: 年数が範囲外", years: -1, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 年数が大きすぎる", years: 101, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{
+				item(1, "2015-01-01"), // 2020-06-01 に整備
+				item(2, "2015-01-01"), // 2023-01-01 に整備
+				item(3, "2018-05-01"), // 整備記録なし
+				item(4, "2022-01-01"), // 整備記録なし（購入から3年未満）
+				item(5, "2021-03-10"), // 整備記録なし（購入からちょうど3年）
+			}, nil)
+			serviceRepo := new(MockServiceRecordRepository)
+			serviceRepo.On("SummarizeByItem", mock.Anything, []int64(nil)).Return(map[int64]*ServiceSummary{
+				1: {TotalCost: 85000, LastServiceDate: "2020-06-01"},
+				2: {TotalCost: 30000, LastServiceDate: "2023-01-01"},
+			}, nil)
+
+			unserviced, err := newTestServiceRecordUsecase(itemRepo, serviceRepo).ListUnservicedItems(context.Background(), tt.years)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			var ids []int64
+			for _, u := range unserviced {
+				ids = append(ids, u.Item.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			if len(unserviced) == 2 {
+				assert.Equal(t, "", unserviced[0].LastServiceDate)
+				assert.Equal(t, "2020-06-01", unserviced[1].LastServiceDate)
+				assert.Equal(t, 85000, unserviced[1].Item.MaintenanceCost)
+			}
+		})
+	}
+}
+
+func TestMaintenanceCostItemUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 一覧のアイテムに整備費用の合計を付ける", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("SummarizeByItem", mock.Anything, []int64{1, 2}).
+			Return(map[int64]*ServiceSummary{2: {TotalCost: 120000, LastServiceDate: "2023-01-01"}}, nil)
+
+		items, err := NewMaintenanceCostItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), serviceRepo).GetAllItems(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, items[0].MaintenanceCost)
+		assert.Equal(t, 120000, items[1].MaintenanceCost)
+	})
+
+	t.Run("正常系: 詳細のアイテムに整備費用の合計を付ける", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("SummarizeByItem", mock.Anything, []int64{1}).
+			Return(map[int64]*ServiceSummary{1: {TotalCost: 85000, LastServiceDate: "2023-01-01"}}, nil)
+
+		item, err := NewMaintenanceCostItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), serviceRepo).GetItemByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 85000, item.MaintenanceCost)
+	})
+
+	t.Run("異常系: 集計に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		serviceRepo := new(MockServiceRecordRepository)
+		serviceRepo.On("SummarizeByItem", mock.Anything, []int64{1}).Return(nil, domainErrors.ErrDatabaseError)
+
+		item, err := NewMaintenanceCostItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), serviceRepo).GetItemByID(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, item)
+	})
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Kinds of file a media URL is issued for
+const (
+	MediaImage    = "image"
+	MediaDocument = "document"
+)
+
+// MediaClaims is what a media URL token grants: the download of one image or document until ExpiresAt
+type MediaClaims struct {
+	Kind      string
+	ItemID    int64
+	ID        int64
+	ExpiresAt time.Time
+}
+
+// MediaTokenSigner signs media URL tokens so that they need not be stored
+type MediaTokenSigner interface {
+	Sign(claims MediaClaims) string
+	// Verify returns the claims of a token signed by Sign, whether or not it has expired
+	Verify(token string) (MediaClaims, error)
+}
+
+// MediaURLUsecase issues short-lived URLs to the files of images and documents, so that a browser can load them
+// (in an <img> tag, or as a download link) without sending the API headers. The token in the URL is the only
+// credential; like share links, the URLs cannot be revoked one by one and are valid until they expire.
+type MediaURLUsecase interface {
+	// CreateImageURL issues a URL to the file of an image of an item
+	CreateImageURL(ctx context.Context, itemID, id int64) (*MediaURL, error)
+	// CreateDocumentURL issues a URL to the file of a document of an item
+	CreateDocumentURL(ctx context.Context, itemID, id int64) (*MediaURL, error)
+	// OpenMedia returns the file a token was issued for; the caller closes the file
+	OpenMedia(ctx context.Context, token string) (*MediaFile, io.ReadCloser, error)
+}
+
+type MediaURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MediaFile describes the file opened through a media URL
+type MediaFile struct {
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        int64
+	SHA256      string // empty for files uploaded before hashes were recorded
+	ExpiresAt   time.Time
+}
+
+type mediaURLUsecase struct {
+	imageRepo    ItemImageRepository
+	documentRepo ItemDocumentRepository
+	images       ImageUsecase
+	documents    DocumentUsecase
+	signer       MediaTokenSigner
+	baseURL      string
+	ttl          time.Duration
+	now          func() time.Time
+}
+
+// NewMediaURLUsecase creates the usecase issuing URLs that are valid for ttl.
+// The files are opened with images and documents, which know the storage each kind is kept in.
+func NewMediaURLUsecase(imageRepo ItemImageRepository, documentRepo ItemDocumentRepository, images ImageUsecase, documents DocumentUsecase,
+	signer MediaTokenSigner, baseURL string, ttl time.Duration) MediaURLUsecase {
+	return &mediaURLUsecase{
+		imageRepo:    imageRepo,
+		documentRepo: documentRepo,
+		images:       images,
+		documents:    documents,
+		signer:       signer,
+		baseURL:      baseURL,
+		ttl:          ttl,
+		now:          time.Now,
+	}
+}
+
+func (u *mediaURLUsecase) CreateImageURL(ctx context.Context, itemID, id int64) (*MediaURL, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	image, err := u.imageRepo.FindByID(ReadOnly(ctx), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+	if image.ItemID != itemID {
+		return nil, domainErrors.ErrImageNotFound
+	}
+
+	return u.url(MediaImage, itemID, id), nil
+}
+
+func (u *mediaURLUsecase) CreateDocumentURL(ctx context.Context, itemID, id int64) (*MediaURL, error) {
+	if itemID <= 0 || id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	document, err := u.documentRepo.FindByID(ReadOnly(ctx), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve document: %w", err)
+	}
+	if document.ItemID != itemID {
+		return nil, domainErrors.ErrDocumentNotFound
+	}
+
+	return u.url(MediaDocument, itemID, id), nil
+}
+
+func (u *mediaURLUsecase) url(kind string, itemID, id int64) *MediaURL {
+	// Tokens carry whole seconds
+	claims := MediaClaims{
+		Kind:      kind,
+		ItemID:    itemID,
+		ID:        id,
+		ExpiresAt: u.now().Add(u.ttl).UTC().Truncate(time.Second),
+	}
+	return &MediaURL{
+		URL:       fmt.Sprintf("%s/media/%s", u.baseURL, u.signer.Sign(claims)),
+		ExpiresAt: claims.ExpiresAt,
+	}
+}
+
+// OpenMedia reports invalid and expired tokens, and tokens of deleted files, alike as ErrMediaLinkNotFound,
+// so that the public endpoint does not tell which files exist
+func (u *mediaURLUsecase) OpenMedia(ctx context.Context, token string) (*MediaFile, io.ReadCloser, error) {
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return nil, nil, domainErrors.ErrMediaLinkNotFound
+	}
+	if !u.now().Before(claims.ExpiresAt) {
+		return nil, nil, domainErrors.NotFound(domainErrors.ErrMediaLinkNotFound, "expired at %s", claims.ExpiresAt.Format(time.RFC3339))
+	}
+
+	var file *MediaFile
+	var content io.ReadCloser
+	switch claims.Kind {
+	case MediaImage:
+		image, f, err := u.images.OpenImage(ctx, claims.ItemID, claims.ID)
+		if err != nil {
+			return nil, nil, mediaOpenError(err)
+		}
+		file = &MediaFile{FileName: image.FileName, ContentType: image.ContentType, Size: image.Size, SHA256: image.SHA256}
+		content = f
+	case MediaDocument:
+		document, f, err := u.documents.OpenDocument(ctx, claims.ItemID, claims.ID)
+		if err != nil {
+			return nil, nil, mediaOpenError(err)
+		}
+		file = &MediaFile{FileName: document.FileName, ContentType: document.ContentType, Size: document.Size, SHA256: document.SHA256}
+		content = f
+	default:
+		return nil, nil, domainErrors.ErrMediaLinkNotFound
+	}
+
+	file.Kind, file.ExpiresAt = claims.Kind, claims.ExpiresAt
+	return file, content, nil
+}
+
+func mediaOpenError(err error) error {
+	if domainErrors.IsNotFoundError(err) {
+		return domainErrors.ErrMediaLinkNotFound
+	}
+	return err
+}
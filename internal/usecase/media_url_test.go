@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeMediaTokenSigner はトークンと内容の対応を覚えておく署名
+type fakeMediaTokenSigner struct {
+	issued map[string]MediaClaims
+}
+
+func (s *fakeMediaTokenSigner) Sign(claims MediaClaims) string {
+	token := fmt.Sprintf("token-%s-%d", claims.Kind, claims.ID)
+	s.issued[token] = claims
+	return token
+}
+
+func (s *fakeMediaTokenSigner) Verify(token string) (MediaClaims, error) {
+	claims, ok := s.issued[token]
+	if !ok {
+		return MediaClaims{}, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+var mediaNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+type mediaURLFixture struct {
+	usecase      *mediaURLUsecase
+	signer       *fakeMediaTokenSigner
+	imageRepo    *MockItemImageRepository
+	documentRepo *MockItemDocumentRepository
+	images       *memoryStorage
+	documents    *memoryStorage
+}
+
+func newMediaURLFixture() *mediaURLFixture {
+	f := &mediaURLFixture{
+		signer:       &fakeMediaTokenSigner{issued: make(map[string]MediaClaims)},
+		imageRepo:    new(MockItemImageRepository),
+		documentRepo: new(MockItemDocumentRepository),
+		images:       newMemoryStorage(),
+		documents:    newMemoryStorage(),
+	}
+	itemRepo := new(MockItemRepository)
+	f.usecase = NewMediaURLUsecase(f.imageRepo, f.documentRepo,
+		newTestImageUsecase(itemRepo, f.imageRepo, f.images), newTestDocumentUsecase(itemRepo, f.documentRepo, f.documents),
+		f.signer, "https://inventory.example.com", 15*time.Minute).(*mediaURLUsecase)
+	f.usecase.now = func() time.Time { return mediaNow }
+	return f
+}
+
+func TestMediaURLUsecase_CreateImageURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		image       *entity.ItemImage
+		findErr     error
+		expectedErr error
+	}{
+		{name: "正常系: 画像の URL を発行する", image: &entity.ItemImage{ID: 5, ItemID: 1}},
+		{name: "異常系: 画像が存在しない", findErr: domainErrors.ErrImageNotFound, expectedErr: domainErrors.ErrImageNotFound},
+		{name: "異常系: 別のアイテムの画像", image: &entity.ItemImage{ID: 5, ItemID: 2}, expectedErr: domainErrors.ErrImageNotFound},
+		{name: "異常系: データベースのエラー", findErr: domainErrors.ErrDatabaseError, expectedErr: domainErrors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMediaURLFixture()
+			f.imageRepo.On("FindByID", mock.Anything, int64(5)).Return(tt.image, tt.findErr)
+
+			url, err := f.usecase.CreateImageURL(context.Background(), 1, 5)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, f.signer.issued)
+				return
+			}
+			require.NoError(t, err)
+			expiresAt := mediaNow.Add(15 * time.Minute)
+			assert.Equal(t, &MediaURL{URL: "https://inventory.example.com/media/token-image-5", ExpiresAt: expiresAt}, url)
+			assert.Equal(t, MediaClaims{Kind: MediaImage, ItemID: 1, ID: 5, ExpiresAt: expiresAt}, f.signer.issued["token-image-5"])
+		})
+	}
+}
+
+func TestMediaURLUsecase_CreateDocumentURL(t *testing.T) {
+	t.Run("正常系: 書類の URL を発行する", func(t *testing.T) {
+		f := newMediaURLFixture()
+		f.documentRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.ItemDocument{ID: 3, ItemID: 1}, nil)
+
+		url, err := f.usecase.CreateDocumentURL(context.Background(), 1, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://inventory.example.com/media/token-document-3", url.URL)
+		assert.Equal(t, MediaDocument, f.signer.issued["token-document-3"].Kind)
+	})
+
+	t.Run("異常系: 別のアイテムの書類", func(t *testing.T) {
+		f := newMediaURLFixture()
+		f.documentRepo.On("FindByID", mock.Anything, int64(3)).Return(&entity.ItemDocument{ID: 3, ItemID: 2}, nil)
+
+		_, err := f.usecase.CreateDocumentURL(context.Background(), 1, 3)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDocumentNotFound)
+	})
+
+	t.Run("異常系: IDが不正", func(t *testing.T) {
+		_, err := newMediaURLFixture().usecase.CreateDocumentURL(context.Background(), 1, 0)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestMediaURLUsecase_OpenMedia(t *testing.T) {
+	image := &entity.ItemImage{ID: 5, ItemID: 1, FileName: "dial.png", ContentType: "image/png", Size: int64(len(testPNG)), SHA256: "ab12", StorageKey: "a.png"}
+	document := &entity.ItemDocument{ID: 3, ItemID: 1, FileName: "receipt.pdf", ContentType: "application/pdf", Size: int64(len(testPDF)), StorageKey: "b.pdf"}
+	expiresAt := mediaNow.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		claims      *MediaClaims
+		expected    *MediaFile
+		content     []byte
+		expectedErr error
+	}{
+		{
+			name:     "正常系: 画像を開く",
+			claims:   &MediaClaims{Kind: MediaImage, ItemID: 1, ID: 5, ExpiresAt: expiresAt},
+			expected: &MediaFile{Kind: MediaImage, FileName: "dial.png", ContentType: "image/png", Size: image.Size, SHA256: "ab12", ExpiresAt: expiresAt},
+			content:  testPNG,
+		},
+		{
+			name:     "正常系: 書類を開く",
+			claims:   &MediaClaims{Kind: MediaDocument, ItemID: 1, ID: 3, ExpiresAt: expiresAt},
+			expected: &MediaFile{Kind: MediaDocument, FileName: "receipt.pdf", ContentType: "application/pdf", Size: document.Size, ExpiresAt: expiresAt},
+			content:  testPDF,
+		},
+		{name: "異常系: 署名が不正", expectedErr: domainErrors.ErrMediaLinkNotFound},
+		{
+			name:        "異常系: 期限切れ",
+			claims:      &MediaClaims{Kind: MediaImage, ItemID: 1, ID: 5, ExpiresAt: mediaNow},
+			expectedErr: domainErrors.ErrMediaLinkNotFound,
+		},
+		{
+			name:        "異常系: 削除された画像",
+			claims:      &MediaClaims{Kind: MediaImage, ItemID: 1, ID: 6, ExpiresAt: expiresAt},
+			expectedErr: domainErrors.ErrMediaLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMediaURLFixture()
+			f.imageRepo.On("FindByID", mock.Anything, int64(5)).Return(image, nil).Maybe()
+			f.imageRepo.On("FindByID", mock.Anything, int64(6)).Return(nil, domainErrors.ErrImageNotFound).Maybe()
+			f.documentRepo.On("FindByID", mock.Anything, int64(3)).Return(document, nil).Maybe()
+			f.images.files[image.StorageKey] = testPNG
+			f.documents.files[document.StorageKey] = testPDF
+			token := "forged"
+			if tt.claims != nil {
+				token = f.signer.Sign(*tt.claims)
+			}
+
+			file, content, err := f.usecase.OpenMedia(context.Background(), token)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			defer content.Close()
+			assert.Equal(t, tt.expected, file)
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, data)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// maxMergeSize is the maximum number of duplicates merged into an item at once
+const maxMergeSize = 50
+
+// MergeUsecase combines items registered more than once into one of them
+type MergeUsecase interface {
+	// MergeItems moves the images, documents, valuations, service records and tags of the duplicates onto the
+	// primary item and deletes the duplicates, in one transaction. With DryRun nothing is changed and the result
+	// previews the merge.
+	MergeItems(ctx context.Context, actor string, input MergeItemsInput) (*MergeResult, error)
+}
+
+type MergeItemsInput struct {
+	PrimaryID    int64   `json:"primary_id"`
+	DuplicateIDs []int64 `json:"duplicate_ids"`
+	DryRun       bool    `json:"dry_run"`
+}
+
+// ItemAttachments are the IDs of the records attached to items, in ID order
+type ItemAttachments struct {
+	ImageIDs         []int64 `json:"image_ids"`
+	DocumentIDs      []int64 `json:"document_ids"`
+	ValuationIDs     []int64 `json:"valuation_ids"`
+	ServiceRecordIDs []int64 `json:"service_record_ids"`
+}
+
+type MergeResult struct {
+	DryRun bool `json:"dry_run"`
+	// Item is the primary item after the merge; in a dry run, its version is still the current one
+	Item *entity.Item `json:"item"`
+	// DeletedItemIDs are the duplicates deleted by the merge
+	DeletedItemIDs []int64 `json:"deleted_item_ids"`
+	// Moved are the records moved from the duplicates to the primary item
+	Moved *ItemAttachments `json:"moved"`
+}
+
+type mergeUsecase struct {
+	itemUsecase ItemUsecase
+	itemRepo    ItemRepository
+	mergeRepo   ItemMergeRepository
+	tagRepo     TagRepository
+	outbox      ItemEventOutbox
+	uow         UnitOfWork
+	now         func() time.Time
+}
+
+// NewMergeUsecase creates the merge usecase. Items are read and the duplicates deleted through itemUsecase, so the
+// merged item is returned like GET /items/{id} and each deleted duplicate is announced like DELETE /items/{id};
+// the primary item is announced as updated.
+func NewMergeUsecase(itemUsecase ItemUsecase, itemRepo ItemRepository, mergeRepo ItemMergeRepository, tagRepo TagRepository, outbox ItemEventOutbox, uow UnitOfWork) MergeUsecase {
+	return &mergeUsecase{
+		itemUsecase: itemUsecase,
+		itemRepo:    itemRepo,
+		mergeRepo:   mergeRepo,
+		tagRepo:     tagRepo,
+		outbox:      outbox,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+func (u *mergeUsecase) MergeItems(ctx context.Context, actor string, input MergeItemsInput) (*MergeResult, error) {
+	if err := validateMergeInput(input); err != nil {
+		return nil, err
+	}
+
+	if input.DryRun {
+		return u.plan(ReadOnly(ctx), actor, input)
+	}
+
+	var result *MergeResult
+	err := recordEvent(ctx, u.uow, u.outbox, func(ctx context.Context) (ItemEvent, error) {
+		plan, err := u.plan(ctx, actor, input)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+
+		if err := u.mergeRepo.MoveAttachments(ctx, input.DuplicateIDs, input.PrimaryID); err != nil {
+			return ItemEvent{}, fmt.Errorf("failed to move attachments: %w", err)
+		}
+		if err := u.setTags(ctx, input.PrimaryID, plan.Item.Tags); err != nil {
+			return ItemEvent{}, err
+		}
+		// Duplicates on loan cannot be deleted, which rolls back the whole merge
+		for _, id := range input.DuplicateIDs {
+			if err := u.itemUsecase.DeleteItem(ctx, id, nil); err != nil {
+				return ItemEvent{}, err
+			}
+		}
+
+		// The image IDs, tags and maintenance cost of the primary item have changed, so its version is incremented
+		primary, err := u.itemRepo.FindByID(ctx, input.PrimaryID)
+		if err != nil {
+			return ItemEvent{}, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		primary.UpdatedAt = u.now()
+		if _, err := u.itemRepo.Update(ctx, primary); err != nil {
+			return ItemEvent{}, fmt.Errorf("failed to update item: %w", err)
+		}
+		merged, err := u.itemUsecase.GetItemByID(ctx, input.PrimaryID)
+		if err != nil {
+			return ItemEvent{}, err
+		}
+
+		plan.Item = merged
+		result = plan
+		return newItemEvent(ItemUpdated, merged.ID, merged), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func validateMergeInput(input MergeItemsInput) error {
+	if input.PrimaryID <= 0 {
+		return fmt.Errorf("%w: primary_id is required", domainErrors.ErrInvalidInput)
+	}
+	if len(input.DuplicateIDs) == 0 {
+		return fmt.Errorf("%w: duplicate_ids is required", domainErrors.ErrInvalidInput)
+	}
+	if len(input.DuplicateIDs) > maxMergeSize {
+		return fmt.Errorf("%w: duplicate_ids must contain %d items or less", domainErrors.ErrInvalidInput, maxMergeSize)
+	}
+
+	seen := map[int64]bool{input.PrimaryID: true}
+	for _, id := range input.DuplicateIDs {
+		if id <= 0 {
+			return fmt.Errorf("%w: invalid item id: %d", domainErrors.ErrInvalidInput, id)
+		}
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate item id: %d", domainErrors.ErrInvalidInput, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// plan checks that actor may merge the items and previews the merged primary item.
+// Items without an owner (created before ownership was tracked) may be merged by any user.
+func (u *mergeUsecase) plan(ctx context.Context, actor string, input MergeItemsInput) (*MergeResult, error) {
+	primary, err := u.findItem(ctx, actor, input.PrimaryID)
+	if err != nil {
+		return nil, err
+	}
+	duplicates := make([]*entity.Item, 0, len(input.DuplicateIDs))
+	for _, id := range input.DuplicateIDs {
+		duplicate, err := u.findItem(ctx, actor, id)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	moved, err := u.mergeRepo.FindAttachments(ctx, input.DuplicateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attachments: %w", err)
+	}
+	merged, err := mergedItem(primary, duplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergeResult{
+		DryRun:         input.DryRun,
+		Item:           merged,
+		DeletedItemIDs: input.DuplicateIDs,
+		Moved:          moved,
+	}, nil
+}
+
+func (u *mergeUsecase) findItem(ctx context.Context, actor string, id int64) (*entity.Item, error) {
+	item, err := u.itemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.NotFound(domainErrors.ErrItemNotFound, "id %d", id)
+		}
+		return nil, err
+	}
+	if item.OwnerID != "" && item.OwnerID != actor {
+		return nil, domainErrors.PermissionDenied("item %d is not owned by %s", id, actor)
+	}
+	return item, nil
+}
+
+// mergedItem returns a copy of primary with the image IDs, tags and maintenance cost of the duplicates added.
+// The fields of the primary item are kept as they are.
+func mergedItem(primary *entity.Item, duplicates []*entity.Item) (*entity.Item, error) {
+	merged := *primary
+	merged.ImageIDs = slices.Clone(primary.ImageIDs)
+	merged.Tags = slices.Clone(primary.Tags)
+
+	seen := make(map[string]bool, len(merged.Tags))
+	for _, tag := range merged.Tags {
+		seen[strings.ToLower(tag)] = true
+	}
+	for _, duplicate := range duplicates {
+		merged.ImageIDs = append(merged.ImageIDs, duplicate.ImageIDs...)
+		merged.MaintenanceCost += duplicate.MaintenanceCost
+		for _, tag := range duplicate.Tags {
+			if key := strings.ToLower(tag); !seen[key] {
+				seen[key] = true
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+	}
+	if len(merged.Tags) > maxItemTags {
+		return nil, fmt.Errorf("%w: the merged item would have more than %d tags", domainErrors.ErrInvalidInput, maxItemTags)
+	}
+
+	slices.Sort(merged.ImageIDs)
+	sort.Slice(merged.Tags, func(i, j int) bool {
+		return strings.ToLower(merged.Tags[i]) < strings.ToLower(merged.Tags[j])
+	})
+	return &merged, nil
+}
+
+// setTags replaces the tags of the primary item with the merged ones; the duplicates' tags are removed along with them
+func (u *mergeUsecase) setTags(ctx context.Context, itemID int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags, err := u.tagRepo.FindByNames(ctx, names)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+	ids := make([]int64, len(tags))
+	for i, tag := range tags {
+		ids[i] = tag.ID
+	}
+	if err := u.tagRepo.SetItemTags(ctx, itemID, ids); err != nil {
+		return fmt.Errorf("failed to set item tags: %w", err)
+	}
+	return nil
+}
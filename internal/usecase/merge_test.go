@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeItemMergeRepository は付け替えた記録を保持する
+type fakeItemMergeRepository struct {
+	attachments *ItemAttachments
+	movedFrom   []int64
+	movedTo     int64
+}
+
+func (r *fakeItemMergeRepository) FindAttachments(ctx context.Context, itemIDs []int64) (*ItemAttachments, error) {
+	return r.attachments, nil
+}
+
+func (r *fakeItemMergeRepository) MoveAttachments(ctx context.Context, itemIDs []int64, targetID int64) error {
+	r.movedFrom, r.movedTo = itemIDs, targetID
+	return nil
+}
+
+func newTestMergeUsecase(itemRepo ItemRepository, mergeRepo ItemMergeRepository, tagRepo TagRepository, outbox ItemEventOutbox, uow UnitOfWork) MergeUsecase {
+	itemUsecase := NewEventingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), outbox, nil)
+	return NewMergeUsecase(itemUsecase, itemRepo, mergeRepo, tagRepo, outbox, uow)
+}
+
+func TestMergeUsecase_MergeItems(t *testing.T) {
+	ctx := context.Background()
+	attachments := &ItemAttachments{ImageIDs: []int64{5}, DocumentIDs: []int64{2}, ValuationIDs: []int64{}, ServiceRecordIDs: []int64{4}}
+
+	newItemRepo := func() *MockItemRepository {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "ロレックス デイトナ", OwnerID: "alice", ImageIDs: []int64{3}, Tags: []string{"時計"}, MaintenanceCost: 10000, Version: 2}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(&entity.Item{ID: 2, Name: "デイトナ", OwnerID: "alice", ImageIDs: []int64{5}, Tags: []string{"限定", "時計"}, MaintenanceCost: 5000}, nil)
+		return itemRepo
+	}
+
+	t.Run("正常系: 重複の記録とタグを付け替えて削除する", func(t *testing.T) {
+		itemRepo := newItemRepo()
+		itemRepo.On("Trash", mock.Anything, int64(2), mock.Anything).Return(nil)
+		itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.ID == 1 })).Return(&entity.Item{ID: 1, Version: 3}, nil)
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("FindByNames", mock.Anything, []string{"時計", "限定"}).Return([]*entity.Tag{{ID: 7, Name: "限定"}, {ID: 8, Name: "時計"}}, nil)
+		tagRepo.On("SetItemTags", mock.Anything, int64(1), []int64{7, 8}).Return(nil)
+		mergeRepo := &fakeItemMergeRepository{attachments: attachments}
+		outbox := &recordingOutbox{}
+		uow := &recordingUnitOfWork{}
+
+		result, err := newTestMergeUsecase(itemRepo, mergeRepo, tagRepo, outbox, uow).MergeItems(ctx, "alice", MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{2}})
+
+		require.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, []int64{2}, result.DeletedItemIDs)
+		assert.Equal(t, attachments, result.Moved)
+		assert.Equal(t, []int64{2}, mergeRepo.movedFrom)
+		assert.Equal(t, int64(1), mergeRepo.movedTo)
+		tagRepo.AssertExpectations(t)
+		itemRepo.AssertCalled(t, "Trash", mock.Anything, int64(2), mock.Anything)
+		assert.Equal(t, 1, uow.committed)
+		require.Len(t, outbox.events, 2)
+		assert.Equal(t, ItemDeleted, outbox.events[0].Type)
+		assert.Equal(t, ItemUpdated, outbox.events[1].Type)
+		assert.Equal(t, int64(1), outbox.events[1].ItemID)
+	})
+
+	t.Run("正常系: dry_run は変更せずに統合後のアイテムを返す", func(t *testing.T) {
+		itemRepo := newItemRepo()
+		tagRepo := new(MockTagRepository)
+		mergeRepo := &fakeItemMergeRepository{attachments: attachments}
+		outbox := &recordingOutbox{}
+
+		result, err := newTestMergeUsecase(itemRepo, mergeRepo, tagRepo, outbox, nil).MergeItems(ctx, "alice", MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{2}, DryRun: true})
+
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, "ロレックス デイトナ", result.Item.Name)
+		assert.Equal(t, []int64{3, 5}, result.Item.ImageIDs)
+		assert.Equal(t, []string{"時計", "限定"}, result.Item.Tags)
+		assert.Equal(t, 15000, result.Item.MaintenanceCost)
+		assert.Equal(t, int64(2), result.Item.Version)
+		assert.Nil(t, mergeRepo.movedFrom)
+		itemRepo.AssertNotCalled(t, "Trash", mock.Anything, mock.Anything, mock.Anything)
+		assert.Empty(t, outbox.events)
+	})
+
+	tests := []struct {
+		name        string
+		actor       string
+		input       MergeItemsInput
+		expectedErr error
+	}{
+		{name: "異常系: 重複の指定がない", actor: "alice", input: MergeItemsInput{PrimaryID: 1}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 統合先を重複に含める", actor: "alice", input: MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{2, 1}}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 所有者以外", actor: "bob", input: MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{2}}, expectedErr: domainErrors.ErrForbidden},
+		{name: "異常系: 重複が見つからない", actor: "alice", input: MergeItemsInput{PrimaryID: 1, DuplicateIDs: []int64{9}}, expectedErr: domainErrors.ErrItemNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := newItemRepo()
+			itemRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrItemNotFound)
+			mergeRepo := &fakeItemMergeRepository{attachments: attachments}
+			outbox := &recordingOutbox{}
+
+			_, err := newTestMergeUsecase(itemRepo, mergeRepo, new(MockTagRepository), outbox, &recordingUnitOfWork{}).MergeItems(ctx, tt.actor, tt.input)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, mergeRepo.movedFrom)
+			assert.Empty(t, outbox.events)
+		})
+	}
+}
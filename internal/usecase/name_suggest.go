@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// NameSuggestUsecase suggests item names for the search box, from an in-memory index of the item names
+type NameSuggestUsecase interface {
+	// SuggestNames returns the item names with a word starting with the query, the most frequent names first
+	SuggestNames(ctx context.Context, query NameSuggestQuery) ([]NameSuggestion, error)
+}
+
+// NameSuggestQuery is the start of a word of an item name, and the number of suggestions to return
+type NameSuggestQuery struct {
+	Text  string
+	Limit int
+}
+
+// NameSuggestion is an item name with the number of items that have it.
+// Names differing only in case, width, spaces or punctuation are one suggestion.
+type NameSuggestion struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type nameSuggestUsecase struct {
+	index *ItemNameIndex
+}
+
+func NewNameSuggestUsecase(index *ItemNameIndex) NameSuggestUsecase {
+	return &nameSuggestUsecase{
+		index: index,
+	}
+}
+
+func (u *nameSuggestUsecase) SuggestNames(ctx context.Context, query NameSuggestQuery) ([]NameSuggestion, error) {
+	text := strings.TrimSpace(query.Text)
+	var errs []string
+	if text == "" {
+		errs = append(errs, "q is required")
+	} else if len(text) > maxNameLength {
+		errs = append(errs, fmt.Sprintf("q must be %d characters or less", maxNameLength))
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSuggestions
+	}
+	if limit < 1 || limit > maxSuggestions {
+		errs = append(errs, fmt.Sprintf("limit must be between 1 and %d", maxSuggestions))
+	}
+	if len(errs) > 0 {
+		return nil, domainErrors.NewValidationError(errs...)
+	}
+
+	return u.index.Suggest(text, limit), nil
+}
+
+// ItemNameIndex keeps the names of the items in memory, so that names are suggested without reading the database.
+// It is an ItemEventPublisher: registered on the event bus, it follows the items created, updated and deleted
+// (moved to the trash) after Load has read the existing items.
+type ItemNameIndex struct {
+	mu sync.RWMutex
+	// itemNames is the name of each item, by item ID
+	itemNames map[int64]string
+	// names are the distinct names, by normalized name
+	names map[string]*indexedName
+	// keys are sorted, so that the keys starting with a prefix are next to each other
+	keys []nameKey
+	// deleted are the items deleted while Load runs, which it must not add back
+	deleted map[int64]bool
+}
+
+type indexedName struct {
+	name  string // as first seen
+	count int
+}
+
+// nameKey is a name from one of its words to its end, normalized, e.g. "daytona" for "ROLEX Daytona"
+type nameKey struct {
+	key  string
+	name string // normalized name
+}
+
+func NewItemNameIndex() *ItemNameIndex {
+	return &ItemNameIndex{
+		itemNames: make(map[int64]string),
+		names:     make(map[string]*indexedName),
+	}
+}
+
+// Load adds the items of the repository. Items already changed by an event since Load started are left as the event set them.
+func (x *ItemNameIndex) Load(ctx context.Context, itemRepo ItemReader) (int, error) {
+	x.mu.Lock()
+	x.deleted = make(map[int64]bool)
+	x.mu.Unlock()
+	defer func() {
+		x.mu.Lock()
+		x.deleted = nil
+		x.mu.Unlock()
+	}()
+
+	loaded := 0
+	err := itemRepo.Iterate(ReadOnly(ctx), ItemQuery{}, func(item *entity.Item) error {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+		if _, ok := x.itemNames[item.ID]; ok || x.deleted[item.ID] {
+			return nil
+		}
+		x.add(item.ID, item.Name)
+		loaded++
+		return nil
+	})
+	if err != nil {
+		return loaded, fmt.Errorf("failed to load item names: %w", err)
+	}
+
+	return loaded, nil
+}
+
+// Publish applies an item change to the index
+func (x *ItemNameIndex) Publish(_ context.Context, event ItemEvent) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	switch event.Type {
+	case ItemCreated, ItemUpdated:
+		if event.Item == nil {
+			return
+		}
+		if name, ok := x.itemNames[event.ItemID]; ok {
+			if name == event.Item.Name {
+				return
+			}
+			x.remove(event.ItemID)
+		}
+		x.add(event.ItemID, event.Item.Name)
+	case ItemDeleted:
+		x.remove(event.ItemID)
+		if x.deleted != nil {
+			x.deleted[event.ItemID] = true
+		}
+	}
+}
+
+// Suggest returns up to limit names with a word starting with prefix, the names of the most items first
+func (x *ItemNameIndex) Suggest(prefix string, limit int) []NameSuggestion {
+	suggestions := []NameSuggestion{}
+	prefix = normalizeForMatch(prefix)
+	if prefix == "" {
+		return suggestions
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	start := sort.Search(len(x.keys), func(i int) bool { return x.keys[i].key >= prefix })
+	for _, k := range x.keys[start:] {
+		if !strings.HasPrefix(k.key, prefix) {
+			break
+		}
+		if seen[k.name] {
+			continue
+		}
+		seen[k.name] = true
+		indexed := x.names[k.name]
+		suggestions = append(suggestions, NameSuggestion{Name: indexed.name, Count: indexed.count})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// Len is the number of distinct names
+func (x *ItemNameIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.names)
+}
+
+// add and remove are called with the lock held
+func (x *ItemNameIndex) add(id int64, name string) {
+	normalized := normalizeForMatch(name)
+	if normalized == "" {
+		return
+	}
+	x.itemNames[id] = name
+
+	if indexed, ok := x.names[normalized]; ok {
+		indexed.count++
+		return
+	}
+	x.names[normalized] = &indexedName{name: name, count: 1}
+	for _, key := range nameKeys(name, normalized) {
+		i, _ := slices.BinarySearchFunc(x.keys, key, compareNameKeys)
+		x.keys = slices.Insert(x.keys, i, key)
+	}
+}
+
+func (x *ItemNameIndex) remove(id int64) {
+	name, ok := x.itemNames[id]
+	if !ok {
+		return
+	}
+	delete(x.itemNames, id)
+
+	normalized := normalizeForMatch(name)
+	indexed, ok := x.names[normalized]
+	if !ok {
+		return
+	}
+	if indexed.count--; indexed.count > 0 {
+		return
+	}
+	for _, key := range nameKeys(indexed.name, normalized) {
+		if i, found := slices.BinarySearchFunc(x.keys, key, compareNameKeys); found {
+			x.keys = slices.Delete(x.keys, i, i+1)
+		}
+	}
+	delete(x.names, normalized)
+}
+
+// nameKeys returns the keys of a name, one from each of its words, without duplicates
+func nameKeys(name, normalized string) []nameKey {
+	words := strings.Fields(name)
+	keys := make([]nameKey, 0, len(words))
+	for i := range words {
+		key := nameKey{key: normalizeForMatch(strings.Join(words[i:], "")), name: normalized}
+		if key.key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func compareNameKeys(a, b nameKey) int {
+	if c := strings.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	return strings.Compare(a.name, b.name)
+}
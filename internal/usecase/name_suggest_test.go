@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func itemEvent(eventType string, id int64, name string) ItemEvent {
+	return ItemEvent{Type: eventType, ItemID: id, Item: &entity.Item{ID: id, Name: name}}
+}
+
+func TestItemNameIndex_Suggest(t *testing.T) {
+	index := NewItemNameIndex()
+	for _, event := range []ItemEvent{
+		itemEvent(ItemCreated, 1, "ロレックス デイトナ"),
+		itemEvent(ItemCreated, 2, "ロレックス　デイトナ"),
+		itemEvent(ItemCreated, 3, "ロレックス サブマリーナ"),
+		itemEvent(ItemCreated, 4, "Rolex Daytona"),
+		itemEvent(ItemCreated, 5, "ROLEX DAYTONA"),
+		itemEvent(ItemCreated, 6, "Day-Date"),
+	} {
+		index.Publish(context.Background(), event)
+	}
+
+	tests := []struct {
+		name     string
+		prefix   string
+		limit    int
+		expected []NameSuggestion
+	}{
+		{
+			name:   "正常系: 表記の違う同じ名前はまとめ、アイテムの多い順",
+			prefix: "ロレ",
+			limit:  10,
+			expected: []NameSuggestion{
+				{Name: "ロレックス デイトナ", Count: 2},
+				{Name: "ロレックス サブマリーナ", Count: 1},
+			},
+		},
+		{
+			name:   "正常系: 2語目の先頭でも一致する",
+			prefix: "day",
+			limit:  10,
+			expected: []NameSuggestion{
+				{Name: "Rolex Daytona", Count: 2},
+				{Name: "Day-Date", Count: 1},
+			},
+		},
+		{
+			name:     "正常系: 件数の上限",
+			prefix:   "デイ",
+			limit:    1,
+			expected: []NameSuggestion{{Name: "ロレックス デイトナ", Count: 2}},
+		},
+		{
+			name:     "正常系: 語の途中では一致しない",
+			prefix:   "tona",
+			limit:    10,
+			expected: []NameSuggestion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, index.Suggest(tt.prefix, tt.limit))
+		})
+	}
+}
+
+func TestItemNameIndex_Publish(t *testing.T) {
+	ctx := context.Background()
+	index := NewItemNameIndex()
+	index.Publish(ctx, itemEvent(ItemCreated, 1, "Speedmaster"))
+	index.Publish(ctx, itemEvent(ItemCreated, 2, "Speedmaster"))
+
+	t.Run("正常系: 名前の変更", func(t *testing.T) {
+		index.Publish(ctx, itemEvent(ItemUpdated, 2, "Seamaster"))
+
+		assert.Equal(t, []NameSuggestion{{Name: "Speedmaster", Count: 1}}, index.Suggest("speed", 10))
+		assert.Equal(t, []NameSuggestion{{Name: "Seamaster", Count: 1}}, index.Suggest("sea", 10))
+	})
+
+	t.Run("正常系: 削除すると名前がなくなる", func(t *testing.T) {
+		index.Publish(ctx, ItemEvent{Type: ItemDeleted, ItemID: 1})
+
+		assert.Empty(t, index.Suggest("speed", 10))
+		assert.Equal(t, 1, index.Len())
+	})
+
+	t.Run("正常系: 復元（登録のイベント）", func(t *testing.T) {
+		index.Publish(ctx, itemEvent(ItemCreated, 1, "Speedmaster"))
+
+		assert.Equal(t, []NameSuggestion{{Name: "Speedmaster", Count: 1}}, index.Suggest("speed", 10))
+	})
+}
+
+func TestItemNameIndex_Load(t *testing.T) {
+	index := NewItemNameIndex()
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// 読み込み中の変更は、読み込んだ内容より新しい
+			index.Publish(context.Background(), itemEvent(ItemUpdated, 1, "Seamaster"))
+			index.Publish(context.Background(), ItemEvent{Type: ItemDeleted, ItemID: 2})
+		}).
+		Return(iterateItems([]*entity.Item{{ID: 1, Name: "Speedmaster"}, {ID: 2, Name: "Submariner"}, {ID: 3, Name: "Santos"}}, nil))
+
+	loaded, err := index.Load(context.Background(), itemRepo)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+	assert.Equal(t, []NameSuggestion{
+		{Name: "Santos", Count: 1},
+		{Name: "Seamaster", Count: 1},
+	}, index.Suggest("s", 10))
+}
+
+func TestNameSuggestUsecase_SuggestNames(t *testing.T) {
+	index := NewItemNameIndex()
+	index.Publish(context.Background(), itemEvent(ItemCreated, 1, "エルメス バーキン"))
+	u := NewNameSuggestUsecase(index)
+
+	suggestions, err := u.SuggestNames(context.Background(), NameSuggestQuery{Text: " バー "})
+	require.NoError(t, err)
+	assert.Equal(t, []NameSuggestion{{Name: "エルメス バーキン", Count: 1}}, suggestions)
+
+	_, err = u.SuggestNames(context.Background(), NameSuggestQuery{Text: ""})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+
+	_, err = u.SuggestNames(context.Background(), NameSuggestQuery{Text: "バー", Limit: -1})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type NotificationRuleUsecase interface {
+	CreateRule(ctx context.Context, input NotificationRuleInput) (*entity.NotificationRule, error)
+	ListRules(ctx context.Context) ([]*entity.NotificationRule, error)
+	GetRule(ctx context.Context, id int64) (*entity.NotificationRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
+
+// NotificationRuleInput describes a rule; MinPrice defaults to the configured price threshold when omitted
+type NotificationRuleInput struct {
+	MinPrice   *int     `json:"min_price"`
+	Events     []string `json:"events"`
+	Recipients []string `json:"recipients"`
+}
+
+type notificationRuleUsecase struct {
+	ruleRepo         NotificationRuleRepository
+	defaultThreshold int
+}
+
+func NewNotificationRuleUsecase(ruleRepo NotificationRuleRepository, defaultThreshold int) NotificationRuleUsecase {
+	return &notificationRuleUsecase{
+		ruleRepo:         ruleRepo,
+		defaultThreshold: defaultThreshold,
+	}
+}
+
+func (u *notificationRuleUsecase) CreateRule(ctx context.Context, input NotificationRuleInput) (*entity.NotificationRule, error) {
+	minPrice := u.defaultThreshold
+	if input.MinPrice != nil {
+		minPrice = *input.MinPrice
+	}
+
+	rule, err := entity.NewNotificationRule(minPrice, input.Events, input.Recipients)
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	created, err := u.ruleRepo.Create(ctx, rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification rule: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *notificationRuleUsecase) ListRules(ctx context.Context) ([]*entity.NotificationRule, error) {
+	rules, err := u.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve notification rules: %w", err)
+	}
+
+	if rules == nil {
+		rules = []*entity.NotificationRule{}
+	}
+
+	return rules, nil
+}
+
+func (u *notificationRuleUsecase) GetRule(ctx context.Context, id int64) (*entity.NotificationRule, error) {
+	rule, err := u.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve notification rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (u *notificationRuleUsecase) DeleteRule(ctx context.Context, id int64) error {
+	if err := u.ruleRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete notification rule: %w", err)
+	}
+
+	return nil
+}
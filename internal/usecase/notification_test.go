@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockNotificationRuleRepository は通知ルールのリポジトリのモック
+type MockNotificationRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRuleRepository) Create(ctx context.Context, rule *entity.NotificationRule) (*entity.NotificationRule, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleRepository) FindAll(ctx context.Context) ([]*entity.NotificationRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleRepository) FindByID(ctx context.Context, id int64) (*entity.NotificationRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestNotificationRuleUsecase_CreateRule(t *testing.T) {
+	minPrice := 500000
+	zero := 0
+
+	tests := []struct {
+		name             string
+		input            NotificationRuleInput
+		expectedMinPrice int
+		expectedEvents   []string
+		expectedErr      string
+	}{
+		{
+			name:             "正常系: 価格とイベントを指定して登録",
+			input:            NotificationRuleInput{MinPrice: &minPrice, Events: []string{"item.deleted"}, Recipients: []string{"owner@example.com"}},
+			expectedMinPrice: 500000,
+			expectedEvents:   []string{"item.deleted"},
+		},
+		{
+			name:             "正常系: 価格未指定は設定のしきい値",
+			input:            NotificationRuleInput{Recipients: []string{"owner@example.com", " owner@example.com"}},
+			expectedMinPrice: 1000000,
+			expectedEvents:   []string{},
+		},
+		{
+			name:             "正常系: 0円を指定するとすべてのアイテムを通知",
+			input:            NotificationRuleInput{MinPrice: &zero, Recipients: []string{"owner@example.com"}},
+			expectedMinPrice: 0,
+			expectedEvents:   []string{},
+		},
+		{
+			name:        "異常系: 宛先がない",
+			input:       NotificationRuleInput{},
+			expectedErr: "recipients is required",
+		},
+		{
+			name:        "異常系: メールアドレスでない宛先",
+			input:       NotificationRuleInput{Recipients: []string{"Owner <owner@example.com>"}},
+			expectedErr: "recipients must be email addresses",
+		},
+		{
+			name:        "異常系: 通知できないイベント",
+			input:       NotificationRuleInput{Events: []string{"item.updated"}, Recipients: []string{"owner@example.com"}},
+			expectedErr: "events must be one of item.created, item.deleted, item.expiring",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockNotificationRuleRepository)
+			var stored *entity.NotificationRule
+			if tt.expectedErr == "" {
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.NotificationRule")).
+					Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.NotificationRule) }).
+					Return(&entity.NotificationRule{ID: 1}, nil)
+			}
+			usecase := NewNotificationRuleUsecase(repo, 1000000)
+
+			rule, err := usecase.CreateRule(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), rule.ID)
+			assert.Equal(t, tt.expectedMinPrice, stored.MinPrice)
+			assert.Equal(t, tt.expectedEvents, stored.Events)
+			assert.Equal(t, []string{"owner@example.com"}, stored.Recipients)
+		})
+	}
+}
+
+func TestNotificationRuleUsecase_ListRules(t *testing.T) {
+	repo := new(MockNotificationRuleRepository)
+	repo.On("FindAll", mock.Anything).Return(nil, nil)
+	usecase := NewNotificationRuleUsecase(repo, 0)
+
+	rules, err := usecase.ListRules(context.Background())
+
+	require.NoError(t, err)
+	assert.NotNil(t, rules)
+	assert.Empty(t, rules)
+}
+
+func TestNotificationRuleUsecase_DeleteRule(t *testing.T) {
+	repo := new(MockNotificationRuleRepository)
+	repo.On("Delete", mock.Anything, int64(9)).Return(domainErrors.ErrNotificationRuleNotFound)
+	usecase := NewNotificationRuleUsecase(repo, 0)
+
+	err := usecase.DeleteRule(context.Background(), 9)
+
+	assert.ErrorIs(t, err, domainErrors.ErrNotificationRuleNotFound)
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// IntervalDay is the interval of the portfolio history with one point per snapshot taken
+const IntervalDay = "day"
+
+type PortfolioUsecase interface {
+	// TakeSnapshot records the value of the items today, overall and per category, replacing the snapshot taken
+	// earlier today. With onlyIfMissing an earlier snapshot of today is kept, and nil is returned.
+	TakeSnapshot(ctx context.Context, onlyIfMissing bool) (*PortfolioPoint, error)
+	// GetHistory returns the recorded values per day or month (IntervalDay if empty), oldest first
+	GetHistory(ctx context.Context, interval string) (*PortfolioHistory, error)
+}
+
+type PortfolioHistory struct {
+	Interval string           `json:"interval"`
+	Points   []PortfolioPoint `json:"points"`
+}
+
+// PortfolioPoint is the value of the items on Date, the day of the snapshot. Per month, it is the last snapshot of the month.
+type PortfolioPoint struct {
+	Period string `json:"period"`
+	Date   string `json:"date"`
+	PortfolioValue
+	Categories map[string]PortfolioValue `json:"categories"`
+}
+
+// PortfolioValue totals the items of a snapshot. Value counts the latest valuation of each item, or its purchase price
+// if it was never valued; like the current value sort, amounts are added without converting their currencies.
+type PortfolioValue struct {
+	ItemCount     int `json:"item_count"`
+	Value         int `json:"value"`
+	PurchaseTotal int `json:"purchase_total"`
+}
+
+type portfolioUsecase struct {
+	itemRepo      ItemReader
+	valuationRepo ValuationRepository
+	snapshotRepo  PortfolioSnapshotRepository
+	uow           UnitOfWork
+	now           func() time.Time
+}
+
+// NewPortfolioUsecase creates the portfolio usecase. uow may be nil, in which case a snapshot replacing the one
+// of the same day is not saved atomically.
+func NewPortfolioUsecase(itemRepo ItemReader, valuationRepo ValuationRepository, snapshotRepo PortfolioSnapshotRepository, uow UnitOfWork) PortfolioUsecase {
+	return &portfolioUsecase{
+		itemRepo:      itemRepo,
+		valuationRepo: valuationRepo,
+		snapshotRepo:  snapshotRepo,
+		uow:           uow,
+		now:           time.Now,
+	}
+}
+
+// TakeSnapshot reads the items from a cursor, so the memory used grows with the number of items valued
+// (whose latest values are looked up first) rather than with the number of items
+func (u *portfolioUsecase) TakeSnapshot(ctx context.Context, onlyIfMissing bool) (*PortfolioPoint, error) {
+	now := u.now()
+	today := now.Format("2006-01-02")
+	readCtx := ReadOnly(ctx)
+
+	if onlyIfMissing {
+		latest, err := u.snapshotRepo.LatestDate(readCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve latest snapshot: %w", err)
+		}
+		if latest >= today {
+			return nil, nil
+		}
+	}
+
+	values, err := u.valuationRepo.FindLatestValues(readCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	byCategory := make(map[string]*entity.PortfolioSnapshot)
+	it, err := u.itemRepo.FindAllStream(readCtx, ItemQuery{SortBy: SortByID, SortOrder: entity.SortAsc})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	err = eachItem(it, func(item *entity.Item) error {
+		snapshot, ok := byCategory[item.Category]
+		if !ok {
+			snapshot = &entity.PortfolioSnapshot{TakenOn: today, Category: item.Category, CreatedAt: now}
+			byCategory[item.Category] = snapshot
+		}
+		snapshot.ItemCount++
+		snapshot.PurchaseTotal += item.PurchasePrice
+		if value, ok := values[item.ID]; ok {
+			snapshot.Value += value
+		} else {
+			snapshot.Value += item.PurchasePrice
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	snapshots := make([]*entity.PortfolioSnapshot, 0, len(byCategory))
+	for _, snapshot := range byCategory {
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Category < snapshots[j].Category })
+
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		return u.snapshotRepo.Save(ctx, today, snapshots)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	point := newPortfolioPoint(today, today)
+	for _, snapshot := range snapshots {
+		point.add(snapshot)
+	}
+	return point, nil
+}
+
+// GetHistory only has points for the days a snapshot was taken; days the job did not run are missing, not zero
+func (u *portfolioUsecase) GetHistory(ctx context.Context, interval string) (*PortfolioHistory, error) {
+	if interval == "" {
+		interval = IntervalDay
+	}
+	if interval != IntervalDay && interval != IntervalMonth {
+		return nil, fmt.Errorf("%w: interval must be one of: %s, %s", domainErrors.ErrInvalidInput, IntervalDay, IntervalMonth)
+	}
+
+	snapshots, err := u.snapshotRepo.FindAll(ReadOnly(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve snapshots: %w", err)
+	}
+
+	// スナップショットは日付の順なので、月ごとの場合は後の日のスナップショットで置き換えていく
+	history := &PortfolioHistory{Interval: interval, Points: []PortfolioPoint{}}
+	var point *PortfolioPoint
+	for _, snapshot := range snapshots {
+		period := snapshot.TakenOn
+		if interval == IntervalMonth && len(period) >= 7 {
+			period = period[:7]
+		}
+		if point == nil || point.Date != snapshot.TakenOn {
+			if point != nil && point.Period != period {
+				history.Points = append(history.Points, *point)
+			}
+			point = newPortfolioPoint(period, snapshot.TakenOn)
+		}
+		point.add(snapshot)
+	}
+	if point != nil {
+		history.Points = append(history.Points, *point)
+	}
+
+	return history, nil
+}
+
+func newPortfolioPoint(period, date string) *PortfolioPoint {
+	return &PortfolioPoint{Period: period, Date: date, Categories: make(map[string]PortfolioValue)}
+}
+
+// add counts a category's snapshot in the point and in the overall totals
+func (p *PortfolioPoint) add(snapshot *entity.PortfolioSnapshot) {
+	value := PortfolioValue{ItemCount: snapshot.ItemCount, Value: snapshot.Value, PurchaseTotal: snapshot.PurchaseTotal}
+	p.Categories[snapshot.Category] = value
+	p.ItemCount += value.ItemCount
+	p.Value += value.Value
+	p.PurchaseTotal += value.PurchaseTotal
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakePortfolioSnapshotRepository はスナップショットを日付とカテゴリーの順に保持する
+type fakePortfolioSnapshotRepository struct {
+	snapshots []*entity.PortfolioSnapshot
+	saves     int
+}
+
+func (r *fakePortfolioSnapshotRepository) Save(ctx context.Context, takenOn string, snapshots []*entity.PortfolioSnapshot) error {
+	r.saves++
+	kept := r.snapshots[:0]
+	for _, snapshot := range r.snapshots {
+		if snapshot.TakenOn != takenOn {
+			kept = append(kept, snapshot)
+		}
+	}
+	r.snapshots = append(kept, snapshots...)
+	return nil
+}
+
+func (r *fakePortfolioSnapshotRepository) FindAll(ctx context.Context) ([]*entity.PortfolioSnapshot, error) {
+	return r.snapshots, nil
+}
+
+func (r *fakePortfolioSnapshotRepository) LatestDate(ctx context.Context) (string, error) {
+	if len(r.snapshots) == 0 {
+		return "", nil
+	}
+	return r.snapshots[len(r.snapshots)-1].TakenOn, nil
+}
+
+func TestPortfolioUsecase_TakeSnapshot(t *testing.T) {
+	now := time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)
+	items := func() ItemIterator {
+		return newTestItemIterator([]*entity.Item{
+			{ID: 1, Category: "時計", PurchasePrice: 1000},
+			{ID: 2, Category: "時計", PurchasePrice: 2000},
+			{ID: 3, Category: "バッグ", PurchasePrice: 500},
+		}, nil)
+	}
+	newUsecase := func(itemRepo *MockItemRepository, valuationRepo *MockValuationRepository, snapshotRepo *fakePortfolioSnapshotRepository, uow UnitOfWork) *portfolioUsecase {
+		u := NewPortfolioUsecase(itemRepo, valuationRepo, snapshotRepo, uow).(*portfolioUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+
+	t.Run("正常系: 評価のないアイテムは購入価格で数え、カテゴリーごとに1つのトランザクションで保存する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.MatchedBy(IsReadOnly), ItemQuery{SortBy: SortByID, SortOrder: entity.SortAsc}).Return(items(), nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestValues", mock.MatchedBy(IsReadOnly)).Return(map[int64]int{1: 1500, 3: 400}, nil)
+		snapshotRepo := &fakePortfolioSnapshotRepository{}
+		uow := &fakeUnitOfWork{}
+
+		point, err := newUsecase(itemRepo, valuationRepo, snapshotRepo, uow).TakeSnapshot(context.Background(), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-10", point.Date)
+		assert.Equal(t, PortfolioValue{ItemCount: 3, Value: 3900, PurchaseTotal: 3500}, point.PortfolioValue)
+		assert.Equal(t, map[string]PortfolioValue{
+			"時計":  {ItemCount: 2, Value: 3500, PurchaseTotal: 3000},
+			"バッグ": {ItemCount: 1, Value: 400, PurchaseTotal: 500},
+		}, point.Categories)
+		assert.Equal(t, 1, uow.calls)
+		require.Len(t, snapshotRepo.snapshots, 2)
+		assert.Equal(t, "バッグ", snapshotRepo.snapshots[0].Category)
+		assert.Equal(t, now, snapshotRepo.snapshots[0].CreatedAt)
+	})
+
+	t.Run("正常系: その日の記録を置き換える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(items(), nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestValues", mock.Anything).Return(map[int64]int{}, nil)
+		snapshotRepo := &fakePortfolioSnapshotRepository{snapshots: []*entity.PortfolioSnapshot{
+			{TakenOn: "2024-03-09", Category: "時計", ItemCount: 1, Value: 1000},
+			{TakenOn: "2024-03-10", Category: "靴", ItemCount: 1, Value: 300},
+		}}
+
+		_, err := newUsecase(itemRepo, valuationRepo, snapshotRepo, nil).TakeSnapshot(context.Background(), false)
+
+		require.NoError(t, err)
+		require.Len(t, snapshotRepo.snapshots, 3)
+		assert.Equal(t, "2024-03-09", snapshotRepo.snapshots[0].TakenOn)
+		assert.Equal(t, []string{"バッグ", "時計"}, []string{snapshotRepo.snapshots[1].Category, snapshotRepo.snapshots[2].Category})
+	})
+
+	t.Run("正常系: onlyIfMissing はその日の記録があれば何もしない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		valuationRepo := new(MockValuationRepository)
+		snapshotRepo := &fakePortfolioSnapshotRepository{snapshots: []*entity.PortfolioSnapshot{{TakenOn: "2024-03-10", Category: "時計"}}}
+
+		point, err := newUsecase(itemRepo, valuationRepo, snapshotRepo, nil).TakeSnapshot(context.Background(), true)
+
+		require.NoError(t, err)
+		assert.Nil(t, point)
+		assert.Zero(t, snapshotRepo.saves)
+		itemRepo.AssertNotCalled(t, "FindAllStream", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: アイテムの取得に失敗したら保存しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(newTestItemIterator(nil, domainErrors.ErrDatabaseError), nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestValues", mock.Anything).Return(map[int64]int{}, nil)
+		snapshotRepo := &fakePortfolioSnapshotRepository{}
+
+		_, err := newUsecase(itemRepo, valuationRepo, snapshotRepo, nil).TakeSnapshot(context.Background(), false)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Zero(t, snapshotRepo.saves)
+	})
+
+	t.Run("異常系: 評価の取得に失敗", func(t *testing.T) {
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindLatestValues", mock.Anything).Return(nil, errors.New("connection refused"))
+
+		_, err := newUsecase(new(MockItemRepository), valuationRepo, &fakePortfolioSnapshotRepository{}, nil).TakeSnapshot(context.Background(), false)
+
+		assert.ErrorContains(t, err, "failed to retrieve valuations: connection refused")
+	})
+}
+
+func TestPortfolioUsecase_GetHistory(t *testing.T) {
+	snapshotRepo := &fakePortfolioSnapshotRepository{snapshots: []*entity.PortfolioSnapshot{
+		{TakenOn: "2024-02-28", Category: "時計", ItemCount: 1, Value: 1000, PurchaseTotal: 900},
+		{TakenOn: "2024-02-29", Category: "バッグ", ItemCount: 1, Value: 500, PurchaseTotal: 600},
+		{TakenOn: "2024-02-29", Category: "時計", ItemCount: 1, Value: 1100, PurchaseTotal: 900},
+		{TakenOn: "2024-03-01", Category: "時計", ItemCount: 2, Value: 3000, PurchaseTotal: 2900},
+	}}
+	u := NewPortfolioUsecase(new(MockItemRepository), new(MockValuationRepository), snapshotRepo, nil)
+
+	t.Run("正常系: 日ごと（既定）", func(t *testing.T) {
+		history, err := u.GetHistory(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, IntervalDay, history.Interval)
+		require.Len(t, history.Points, 3)
+		assert.Equal(t, "2024-02-29", history.Points[1].Period)
+		assert.Equal(t, PortfolioValue{ItemCount: 2, Value: 1600, PurchaseTotal: 1500}, history.Points[1].PortfolioValue)
+		assert.Equal(t, map[string]PortfolioValue{
+			"バッグ": {ItemCount: 1, Value: 500, PurchaseTotal: 600},
+			"時計":  {ItemCount: 1, Value: 1100, PurchaseTotal: 900},
+		}, history.Points[1].Categories)
+	})
+
+	t.Run("正常系: 月ごとは月の最後の記録", func(t *testing.T) {
+		history, err := u.GetHistory(context.Background(), IntervalMonth)
+
+		require.NoError(t, err)
+		require.Len(t, history.Points, 2)
+		assert.Equal(t, "2024-02", history.Points[0].Period)
+		assert.Equal(t, "2024-02-29", history.Points[0].Date)
+		assert.Equal(t, 1600, history.Points[0].Value)
+		assert.Len(t, history.Points[0].Categories, 2)
+		assert.Equal(t, "2024-03", history.Points[1].Period)
+		assert.Equal(t, 3000, history.Points[1].Value)
+	})
+
+	t.Run("正常系: 記録がなければ空", func(t *testing.T) {
+		history, err := NewPortfolioUsecase(nil, nil, &fakePortfolioSnapshotRepository{}, nil).GetHistory(context.Background(), IntervalMonth)
+
+		require.NoError(t, err)
+		assert.Empty(t, history.Points)
+		assert.NotNil(t, history.Points)
+	})
+
+	t.Run("異常系: 不正な間隔", func(t *testing.T) {
+		_, err := u.GetHistory(context.Background(), "year")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "interval must be one of: day, month")
+	})
+}
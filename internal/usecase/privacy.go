@@ -0,0 +1,426 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// UserDataExport is everything stored about a user
+type UserDataExport struct {
+	UserID      string         `json:"user_id"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Items       []*entity.Item `json:"items"`
+	// TrashedItems are the user's deleted items, kept until they are purged
+	TrashedItems []*entity.TrashedItem `json:"trashed_items"`
+	// Images and Documents are the metadata of the files attached to the user's items
+	Images      []*entity.ItemImage    `json:"images"`
+	Documents   []*entity.ItemDocument `json:"documents"`
+	Collections []*entity.Collection   `json:"collections"`
+	// Revisions are the recorded revisions of the user's items and of the changes the user made to other items
+	Revisions []*entity.ItemRevision `json:"revisions"`
+	// Transfers are the transfers the user sent, received or resolved
+	Transfers []*entity.Transfer `json:"transfers"`
+	// ErasureRequest is the user's pending erasure request, if any
+	ErasureRequest *entity.ErasureRequest `json:"erasure_request,omitempty"`
+}
+
+// ErasureInput is a user's request to erase their data
+type ErasureInput struct {
+	UserID string `json:"-"`
+	// Confirm is a confirmation issued to the user by ConfirmErasure, so that an erasure is not requested by mistake
+	// or with only the user's ID
+	Confirm string `json:"confirm"`
+}
+
+// erasureConfirmationWindow is how long a confirmation can be used for requesting the erasure
+const erasureConfirmationWindow = 10 * time.Minute
+
+// ErasureConfirmation is the confirmation a user sends back to request the erasure of their data
+type ErasureConfirmation struct {
+	Confirm   string    `json:"confirm"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ErasureClaims is what an erasure confirmation grants: requesting the erasure of one user's data until ExpiresAt
+type ErasureClaims struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// ErasureTokenSigner signs erasure confirmations so that they need not be stored
+type ErasureTokenSigner interface {
+	Sign(claims ErasureClaims) string
+	// Verify returns the claims of a token signed by Sign, whether or not it has expired
+	Verify(token string) (ErasureClaims, error)
+}
+
+// PrivacyUsecase exports the data of a user and erases it on request.
+//
+// An erasure is carried out once its grace period has passed, by EraseDue: the user's items are purged with their
+// images, documents and revisions, their collections are deleted, and the user is replaced with a pseudonym in the
+// revisions of other items and in the transfers, which are kept as the audit trail.
+type PrivacyUsecase interface {
+	// ExportUserData returns everything stored about the user
+	ExportUserData(ctx context.Context, userID string) (*UserDataExport, error)
+	// ConfirmErasure issues the confirmation the user has to send with RequestErasure
+	ConfirmErasure(ctx context.Context, userID string) (*ErasureConfirmation, error)
+	// RequestErasure schedules the erasure of the user's data at the end of the grace period.
+	// Requesting it again returns the pending request unchanged.
+	RequestErasure(ctx context.Context, input ErasureInput) (*entity.ErasureRequest, error)
+	// GetErasureRequest returns the user's pending request; ErrErasureRequestNotFound if there is none
+	GetErasureRequest(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+	// CancelErasure withdraws the user's pending request; ErrErasureRequestNotFound if there is none
+	CancelErasure(ctx context.Context, userID string) error
+	// EraseDue erases the data of the users whose grace period has passed and returns how many were erased;
+	// with dryRun it only returns how many would be erased
+	EraseDue(ctx context.Context, dryRun bool) (int, error)
+}
+
+type privacyUsecase struct {
+	itemUsecase    ItemUsecase
+	itemRepo       ItemReader
+	imageRepo      ItemImageRepository
+	documentRepo   ItemDocumentRepository
+	collectionRepo CollectionRepository
+	revisionRepo   ItemRevisionRepository
+	transferRepo   TransferRepository
+	erasureRepo    ErasureRequestRepository
+	signer         ErasureTokenSigner
+	gracePeriod    time.Duration
+	now            func() time.Time
+}
+
+// NewPrivacyUsecase creates the usecase. Items are erased with itemUsecase, so the deletion events are published
+// and their images and documents are deleted with them. Erasure confirmations are signed with signer.
+func NewPrivacyUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, imageRepo ItemImageRepository, documentRepo ItemDocumentRepository,
+	collectionRepo CollectionRepository, revisionRepo ItemRevisionRepository, transferRepo TransferRepository,
+	erasureRepo ErasureRequestRepository, signer ErasureTokenSigner, gracePeriod time.Duration) PrivacyUsecase {
+	return &privacyUsecase{
+		itemUsecase:    itemUsecase,
+		itemRepo:       itemRepo,
+		imageRepo:      imageRepo,
+		documentRepo:   documentRepo,
+		collectionRepo: collectionRepo,
+		revisionRepo:   revisionRepo,
+		transferRepo:   transferRepo,
+		erasureRepo:    erasureRepo,
+		signer:         signer,
+		gracePeriod:    gracePeriod,
+		now:            time.Now,
+	}
+}
+
+func (u *privacyUsecase) ExportUserData(ctx context.Context, userID string) (*UserDataExport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+	ctx = ReadOnly(ctx)
+
+	export := &UserDataExport{
+		UserID:       userID,
+		GeneratedAt:  u.now().UTC(),
+		Items:        []*entity.Item{},
+		TrashedItems: []*entity.TrashedItem{},
+		Images:       []*entity.ItemImage{},
+		Documents:    []*entity.ItemDocument{},
+		Revisions:    []*entity.ItemRevision{},
+	}
+
+	items, trashed, err := u.ownedItems(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	export.Items = append(export.Items, items...)
+	export.TrashedItems = append(export.TrashedItems, trashed...)
+
+	ids := make([]int64, 0, len(items)+len(trashed))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	for _, item := range trashed {
+		ids = append(ids, item.ID)
+	}
+
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		images, err := u.imageRepo.FindByItemID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve images: %w", err)
+		}
+		export.Images = append(export.Images, images...)
+
+		documents, err := u.documentRepo.FindByItemID(ctx, id, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve documents: %w", err)
+		}
+		export.Documents = append(export.Documents, documents...)
+
+		revisions, err := u.revisionRepo.FindByItemID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+		}
+		for _, revision := range revisions {
+			seen[revision.ID] = true
+		}
+		export.Revisions = append(export.Revisions, revisions...)
+	}
+
+	changes, err := u.revisionRepo.FindByChangedBy(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+	for _, revision := range changes {
+		if !seen[revision.ID] {
+			export.Revisions = append(export.Revisions, revision)
+		}
+	}
+	sort.SliceStable(export.Revisions, func(i, j int) bool {
+		a, b := export.Revisions[i], export.Revisions[j]
+		if a.ItemID != b.ItemID {
+			return a.ItemID < b.ItemID
+		}
+		return a.Revision < b.Revision
+	})
+
+	collections, err := u.collectionRepo.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve collections: %w", err)
+	}
+	export.Collections = append([]*entity.Collection{}, collections...)
+
+	transfers, err := u.transferRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transfers: %w", err)
+	}
+	export.Transfers = append([]*entity.Transfer{}, transfers...)
+
+	request, err := u.erasureRepo.FindByUserID(ctx, userID)
+	if err != nil && !domainErrors.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to retrieve erasure request: %w", err)
+	}
+	export.ErasureRequest = request
+
+	return export, nil
+}
+
+func (u *privacyUsecase) ConfirmErasure(ctx context.Context, userID string) (*ErasureConfirmation, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+
+	claims := ErasureClaims{UserID: userID, ExpiresAt: u.now().UTC().Truncate(time.Second).Add(erasureConfirmationWindow)}
+	return &ErasureConfirmation{
+		Confirm:   u.signer.Sign(claims),
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+// RequestErasure reports confirmations that are invalid, expired or issued to another user as ErrInvalidInput
+func (u *privacyUsecase) RequestErasure(ctx context.Context, input ErasureInput) (*entity.ErasureRequest, error) {
+	if input.UserID == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+	confirm := strings.TrimSpace(input.Confirm)
+	if confirm == "" {
+		return nil, fmt.Errorf("%w: confirm is required; get one from POST /me/erasure/confirmation", domainErrors.ErrInvalidInput)
+	}
+	claims, err := u.signer.Verify(confirm)
+	if err != nil || claims.UserID != input.UserID {
+		return nil, fmt.Errorf("%w: confirm must be a confirmation issued to you by POST /me/erasure/confirmation", domainErrors.ErrInvalidInput)
+	}
+	if !u.now().Before(claims.ExpiresAt) {
+		return nil, fmt.Errorf("%w: confirm expired at %s", domainErrors.ErrInvalidInput, claims.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
+	request, err := u.erasureRepo.Create(ctx, entity.NewErasureRequest(input.UserID, u.now(), u.gracePeriod))
+	if err != nil {
+		return nil, fmt.Errorf("failed to request erasure: %w", err)
+	}
+	return request, nil
+}
+
+func (u *privacyUsecase) GetErasureRequest(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+
+	request, err := u.erasureRepo.FindByUserID(ReadOnly(ctx), userID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrErasureRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve erasure request: %w", err)
+	}
+	return request, nil
+}
+
+func (u *privacyUsecase) CancelErasure(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+
+	if err := u.erasureRepo.Delete(ctx, userID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrErasureRequestNotFound
+		}
+		return fmt.Errorf("failed to cancel erasure: %w", err)
+	}
+	return nil
+}
+
+// EraseDue goes on with the other users when the data of one cannot be erased (an item on loan cannot be deleted,
+// for instance); their requests are kept and tried again on the next run. The errors are returned together.
+func (u *privacyUsecase) EraseDue(ctx context.Context, dryRun bool) (int, error) {
+	if dryRun {
+		ctx = ReadOnly(ctx)
+	}
+	requests, err := u.erasureRepo.FindDue(ctx, u.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve erasure requests: %w", err)
+	}
+	if dryRun {
+		return len(requests), nil
+	}
+
+	erased := 0
+	var errs []error
+	for _, request := range requests {
+		if err := ctx.Err(); err != nil {
+			return erased, fmt.Errorf("erasure stopped: %w", err)
+		}
+		if err := u.erase(ctx, request.UserID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to erase the data of %s: %w", request.UserID, err))
+			continue
+		}
+		erased++
+	}
+	return erased, errors.Join(errs...)
+}
+
+// erase deletes the data of a user and removes the request once nothing is left. The steps can be repeated,
+// so an erasure that fails half way is completed by the next run.
+func (u *privacyUsecase) erase(ctx context.Context, userID string) error {
+	items, trashed, err := u.ownedItems(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := u.itemUsecase.DeleteItem(ctx, item.ID, nil); err != nil && !domainErrors.IsNotFoundError(err) {
+			return err
+		}
+		if err := u.purge(ctx, item.ID); err != nil {
+			return err
+		}
+	}
+	for _, item := range trashed {
+		if err := u.purge(ctx, item.ID); err != nil {
+			return err
+		}
+	}
+
+	collections, err := u.collectionRepo.FindAll(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve collections: %w", err)
+	}
+	for _, collection := range collections {
+		if err := u.collectionRepo.Delete(ctx, collection.ID); err != nil && !domainErrors.IsNotFoundError(err) {
+			return fmt.Errorf("failed to delete collection: %w", err)
+		}
+	}
+
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return fmt.Errorf("failed to create pseudonym: %w", err)
+	}
+	if err := u.revisionRepo.ReplaceChangedBy(ctx, userID, pseudonym); err != nil {
+		return fmt.Errorf("failed to anonymize revisions: %w", err)
+	}
+	if err := u.transferRepo.ReplaceUser(ctx, userID, pseudonym); err != nil {
+		return fmt.Errorf("failed to anonymize transfers: %w", err)
+	}
+
+	// Items created while the erasure ran are erased by the next run
+	remaining, err := u.itemRepo.Count(ctx, ItemFilter{OwnerID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to count items: %w", err)
+	}
+	if remaining > 0 {
+		return fmt.Errorf("%d items were created during the erasure", remaining)
+	}
+
+	if err := u.erasureRepo.Delete(ctx, userID); err != nil && !domainErrors.IsNotFoundError(err) {
+		return fmt.Errorf("failed to complete erasure request: %w", err)
+	}
+	return nil
+}
+
+// purge permanently deletes an item in the trash and its revisions
+func (u *privacyUsecase) purge(ctx context.Context, itemID int64) error {
+	if err := u.itemUsecase.PurgeItem(ctx, itemID); err != nil && !domainErrors.IsNotFoundError(err) {
+		return err
+	}
+	if err := u.revisionRepo.DeleteByItemID(ctx, itemID); err != nil {
+		return fmt.Errorf("failed to delete revisions: %w", err)
+	}
+	return nil
+}
+
+// ownedItems returns the items of the user and the user's items in the trash
+func (u *privacyUsecase) ownedItems(ctx context.Context, userID string) ([]*entity.Item, []*entity.TrashedItem, error) {
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{ItemFilter: ItemFilter{OwnerID: userID}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	// The trash cannot be filtered by owner
+	all, err := u.itemRepo.FindTrashed(ctx, TrashQuery{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve trash: %w", err)
+	}
+	var trashed []*entity.TrashedItem
+	for _, item := range all {
+		if item.OwnerID == userID {
+			trashed = append(trashed, item)
+		}
+	}
+	return items, trashed, nil
+}
+
+// newPseudonym returns the name that replaces an erased user; it cannot be traced back to the user
+func newPseudonym() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "erased-user-" + hex.EncodeToString(b), nil
+}
+
+type erasingRetentionUsecase struct {
+	RetentionUsecase
+	privacy PrivacyUsecase
+}
+
+// NewErasingRetentionUsecase also carries out the erasure requests whose grace period has passed when the expired
+// data is purged, and reports the number of users erased
+func NewErasingRetentionUsecase(inner RetentionUsecase, privacy PrivacyUsecase) RetentionUsecase {
+	return &erasingRetentionUsecase{RetentionUsecase: inner, privacy: privacy}
+}
+
+func (u *erasingRetentionUsecase) Purge(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	report, err := u.RetentionUsecase.Purge(ctx, dryRun)
+	if err != nil {
+		return report, err
+	}
+
+	report.Users, err = u.privacy.EraseDue(ctx, dryRun)
+	return report, err
+}
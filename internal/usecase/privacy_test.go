@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeErasureRequestRepository は削除依頼をメモリに記録する
+type fakeErasureRequestRepository struct {
+	requests map[string]*entity.ErasureRequest
+}
+
+func newFakeErasureRequestRepository(requests ...*entity.ErasureRequest) *fakeErasureRequestRepository {
+	r := &fakeErasureRequestRepository{requests: make(map[string]*entity.ErasureRequest)}
+	for _, request := range requests {
+		r.requests[request.UserID] = request
+	}
+	return r
+}
+
+func (r *fakeErasureRequestRepository) Create(ctx context.Context, request *entity.ErasureRequest) (*entity.ErasureRequest, error) {
+	if _, ok := r.requests[request.UserID]; !ok {
+		r.requests[request.UserID] = request
+	}
+	return r.requests[request.UserID], nil
+}
+
+func (r *fakeErasureRequestRepository) FindByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error) {
+	if request, ok := r.requests[userID]; ok {
+		return request, nil
+	}
+	return nil, domainErrors.ErrErasureRequestNotFound
+}
+
+func (r *fakeErasureRequestRepository) FindDue(ctx context.Context, now time.Time) ([]*entity.ErasureRequest, error) {
+	var due []*entity.ErasureRequest
+	for _, request := range r.requests {
+		if !request.EraseAfter.After(now) {
+			due = append(due, request)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].UserID < due[j].UserID })
+	return due, nil
+}
+
+func (r *fakeErasureRequestRepository) Delete(ctx context.Context, userID string) error {
+	if _, ok := r.requests[userID]; !ok {
+		return domainErrors.ErrErasureRequestNotFound
+	}
+	delete(r.requests, userID)
+	return nil
+}
+
+// fakeErasureTokenSigner はトークンと内容の対応を覚えておく署名
+type fakeErasureTokenSigner struct {
+	issued map[string]ErasureClaims
+}
+
+func (s *fakeErasureTokenSigner) Sign(claims ErasureClaims) string {
+	token := fmt.Sprintf("token-%s-%d", claims.UserID, claims.ExpiresAt.Unix())
+	s.issued[token] = claims
+	return token
+}
+
+func (s *fakeErasureTokenSigner) Verify(token string) (ErasureClaims, error) {
+	claims, ok := s.issued[token]
+	if !ok {
+		return ErasureClaims{}, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+func TestPrivacyUsecase_ExportUserData(t *testing.T) {
+	now := time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: ユーザーのアイテムと添付ファイル・リビジョン・コレクション・譲渡を返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{ItemFilter: ItemFilter{OwnerID: "alice"}}).Return([]*entity.Item{ownedItem(1, "alice")}, nil)
+		bobs := trashedItem(3, now)
+		bobs.OwnerID = "bob"
+		alices := trashedItem(2, now)
+		alices.OwnerID = "alice"
+		itemRepo.On("FindTrashed", mock.Anything, TrashQuery{}).Return([]*entity.TrashedItem{alices, bobs}, nil)
+
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 10, ItemID: 1}}, nil)
+		imageRepo.On("FindByItemID", mock.Anything, int64(2)).Return(nil, nil)
+		documentRepo := new(MockItemDocumentRepository)
+		documentRepo.On("FindByItemID", mock.Anything, int64(1), "").Return(nil, nil)
+		documentRepo.On("FindByItemID", mock.Anything, int64(2), "").Return([]*entity.ItemDocument{{ID: 20, ItemID: 2}}, nil)
+		revisionRepo := &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+			{ID: 1, ItemID: 9, Revision: 2, ChangedBy: "alice"},
+			{ID: 2, ItemID: 1, Revision: 1, ChangedBy: "alice"},
+			{ID: 3, ItemID: 1, Revision: 2, ChangedBy: "carol"},
+			{ID: 4, ItemID: 9, Revision: 1, ChangedBy: "bob"},
+		}}
+		collectionRepo := new(MockCollectionRepository)
+		collectionRepo.On("FindAll", mock.Anything, "alice").Return([]*entity.Collection{{ID: 5, OwnerID: "alice"}}, nil)
+		transferRepo := new(MockTransferRepository)
+		transferRepo.On("FindByUser", mock.Anything, "alice").Return([]*entity.Transfer{{ID: 7, FromUser: "bob", ToUser: "alice"}}, nil)
+
+		u := NewPrivacyUsecase(nil, itemRepo, imageRepo, documentRepo, collectionRepo, revisionRepo, transferRepo, newFakeErasureRequestRepository(), nil, 0).(*privacyUsecase)
+		u.now = func() time.Time { return now }
+
+		export, err := u.ExportUserData(context.Background(), "alice")
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice", export.UserID)
+		assert.Equal(t, now, export.GeneratedAt)
+		assert.Len(t, export.Items, 1)
+		assert.Equal(t, []*entity.TrashedItem{alices}, export.TrashedItems)
+		assert.Equal(t, []*entity.ItemImage{{ID: 10, ItemID: 1}}, export.Images)
+		assert.Equal(t, []*entity.ItemDocument{{ID: 20, ItemID: 2}}, export.Documents)
+		// 自分のアイテムのリビジョン（ほかのユーザーの変更を含む）と、ほかのアイテムに自分が行った変更
+		var revisionIDs []int64
+		for _, revision := range export.Revisions {
+			revisionIDs = append(revisionIDs, revision.ID)
+		}
+		assert.Equal(t, []int64{2, 3, 1}, revisionIDs)
+		assert.Len(t, export.Collections, 1)
+		assert.Len(t, export.Transfers, 1)
+		assert.Nil(t, export.ErasureRequest)
+	})
+
+	t.Run("異常系: ユーザーの指定がない", func(t *testing.T) {
+		u := NewPrivacyUsecase(nil, nil, nil, nil, nil, nil, nil, nil, nil, 0)
+
+		_, err := u.ExportUserData(context.Background(), "")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestPrivacyUsecase_RequestErasure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC)
+	newUsecase := func(erasureRepo ErasureRequestRepository) *privacyUsecase {
+		signer := &fakeErasureTokenSigner{issued: make(map[string]ErasureClaims)}
+		u := NewPrivacyUsecase(nil, nil, nil, nil, nil, nil, nil, erasureRepo, signer, 30*24*time.Hour).(*privacyUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+	confirm := func(t *testing.T, u *privacyUsecase, userID string) string {
+		confirmation, err := u.ConfirmErasure(ctx, userID)
+		require.NoError(t, err)
+		return confirmation.Confirm
+	}
+
+	t.Run("正常系: 確認は一定時間だけ使える", func(t *testing.T) {
+		confirmation, err := newUsecase(newFakeErasureRequestRepository()).ConfirmErasure(ctx, "alice")
+
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(10*time.Minute), confirmation.ExpiresAt)
+		assert.NotEmpty(t, confirmation.Confirm)
+	})
+
+	t.Run("正常系: 猶予期間の後に削除する依頼を記録し、依頼し直しても猶予期間は延びない", func(t *testing.T) {
+		erasureRepo := newFakeErasureRequestRepository()
+		u := newUsecase(erasureRepo)
+
+		request, err := u.RequestErasure(ctx, ErasureInput{UserID: "alice", Confirm: confirm(t, u, "alice")})
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.ErasureRequest{UserID: "alice", RequestedAt: now, EraseAfter: now.AddDate(0, 0, 30)}, request)
+
+		u.now = func() time.Time { return now.AddDate(0, 0, 10) }
+		again, err := u.RequestErasure(ctx, ErasureInput{UserID: "alice", Confirm: confirm(t, u, "alice")})
+		require.NoError(t, err)
+		assert.Equal(t, request, again)
+	})
+
+	t.Run("異常系: ユーザーIDは確認にならない", func(t *testing.T) {
+		erasureRepo := newFakeErasureRequestRepository()
+
+		_, err := newUsecase(erasureRepo).RequestErasure(ctx, ErasureInput{UserID: "alice", Confirm: "alice"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, erasureRepo.requests)
+	})
+
+	t.Run("異常系: 確認がない", func(t *testing.T) {
+		_, err := newUsecase(newFakeErasureRequestRepository()).RequestErasure(ctx, ErasureInput{UserID: "alice"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "confirm is required")
+	})
+
+	t.Run("異常系: ほかのユーザーの確認", func(t *testing.T) {
+		erasureRepo := newFakeErasureRequestRepository()
+		u := newUsecase(erasureRepo)
+
+		_, err := u.RequestErasure(ctx, ErasureInput{UserID: "alice", Confirm: confirm(t, u, "bob")})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, erasureRepo.requests)
+	})
+
+	t.Run("異常系: 期限切れの確認", func(t *testing.T) {
+		erasureRepo := newFakeErasureRequestRepository()
+		u := newUsecase(erasureRepo)
+		token := confirm(t, u, "alice")
+		u.now = func() time.Time { return now.Add(10 * time.Minute) }
+
+		_, err := u.RequestErasure(ctx, ErasureInput{UserID: "alice", Confirm: token})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "confirm expired at 2024-03-31T09:10:00Z")
+		assert.Empty(t, erasureRepo.requests)
+	})
+
+	t.Run("正常系: 依頼を取り消す", func(t *testing.T) {
+		erasureRepo := newFakeErasureRequestRepository(entity.NewErasureRequest("alice", now, time.Hour))
+		u := newUsecase(erasureRepo)
+
+		require.NoError(t, u.CancelErasure(ctx, "alice"))
+		assert.ErrorIs(t, u.CancelErasure(ctx, "alice"), domainErrors.ErrErasureRequestNotFound)
+		_, err := u.GetErasureRequest(ctx, "alice")
+		assert.ErrorIs(t, err, domainErrors.ErrErasureRequestNotFound)
+	})
+}
+
+func TestPrivacyUsecase_EraseDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	type fixture struct {
+		itemRepo       *MockItemRepository
+		collectionRepo *MockCollectionRepository
+		revisionRepo   *fakeItemRevisionRepository
+		transferRepo   *MockTransferRepository
+		erasureRepo    *fakeErasureRequestRepository
+		usecase        *privacyUsecase
+	}
+	setup := func() *fixture {
+		f := &fixture{
+			itemRepo:       new(MockItemRepository),
+			collectionRepo: new(MockCollectionRepository),
+			revisionRepo: &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+				{ID: 1, ItemID: 1, Revision: 1, ChangedBy: "alice"},
+				{ID: 2, ItemID: 2, Revision: 1, ChangedBy: "alice"},
+				{ID: 3, ItemID: 9, Revision: 2, ChangedBy: "alice"},
+				{ID: 4, ItemID: 9, Revision: 1, ChangedBy: "bob"},
+			}},
+			transferRepo: new(MockTransferRepository),
+			erasureRepo: newFakeErasureRequestRepository(
+				&entity.ErasureRequest{UserID: "alice", EraseAfter: now.Add(-time.Hour)},
+				// 猶予期間中
+				&entity.ErasureRequest{UserID: "bob", EraseAfter: now.Add(time.Hour)},
+			),
+		}
+		f.usecase = NewPrivacyUsecase(NewItemUsecase(f.itemRepo, nil, nil, nil, nil), f.itemRepo, nil, nil, f.collectionRepo, f.revisionRepo, f.transferRepo, f.erasureRepo, nil, 0).(*privacyUsecase)
+		f.usecase.now = func() time.Time { return now }
+
+		trashed := trashedItem(2, now)
+		trashed.OwnerID = "alice"
+		f.itemRepo.On("FindAll", mock.Anything, ItemQuery{ItemFilter: ItemFilter{OwnerID: "alice"}}).Return([]*entity.Item{ownedItem(1, "alice")}, nil)
+		f.itemRepo.On("FindTrashed", mock.Anything, TrashQuery{}).Return([]*entity.TrashedItem{trashed}, nil)
+		f.itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		f.itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		f.itemRepo.On("FindTrashedByID", mock.Anything, mock.Anything).Return(trashed, nil)
+		f.itemRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		f.collectionRepo.On("FindAll", mock.Anything, "alice").Return([]*entity.Collection{{ID: 5, OwnerID: "alice"}}, nil)
+		f.collectionRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+		f.transferRepo.On("ReplaceUser", mock.Anything, "alice", mock.Anything).Return(nil)
+		return f
+	}
+
+	t.Run("正常系: 猶予期間を過ぎたユーザーのデータを削除し、ほかのアイテムの履歴と譲渡では仮名に置き換える", func(t *testing.T) {
+		f := setup()
+		f.itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(0, nil)
+
+		erased, err := f.usecase.EraseDue(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		f.itemRepo.AssertCalled(t, "Trash", mock.Anything, int64(1), mock.Anything)
+		f.itemRepo.AssertCalled(t, "Delete", mock.Anything, int64(1))
+		f.itemRepo.AssertCalled(t, "Delete", mock.Anything, int64(2))
+		f.collectionRepo.AssertCalled(t, "Delete", mock.Anything, int64(5))
+
+		// 削除したアイテムのリビジョンは残さない
+		require.Len(t, f.revisionRepo.revisions, 2)
+		pseudonym := f.revisionRepo.revisions[0].ChangedBy
+		assert.True(t, strings.HasPrefix(pseudonym, "erased-user-"), pseudonym)
+		assert.Equal(t, "bob", f.revisionRepo.revisions[1].ChangedBy)
+		f.transferRepo.AssertCalled(t, "ReplaceUser", mock.Anything, "alice", pseudonym)
+
+		// 依頼は完了したので消え、猶予期間中の依頼は残る
+		assert.Len(t, f.erasureRepo.requests, 1)
+		assert.Contains(t, f.erasureRepo.requests, "bob")
+	})
+
+	t.Run("異常系: 削除中に登録されたアイテムが残れば依頼を残して次の実行で削除する", func(t *testing.T) {
+		f := setup()
+		f.itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(1, nil)
+
+		erased, err := f.usecase.EraseDue(ctx, false)
+
+		assert.EqualError(t, err, "failed to erase the data of alice: 1 items were created during the erasure")
+		assert.Equal(t, 0, erased)
+		assert.Contains(t, f.erasureRepo.requests, "alice")
+	})
+
+	t.Run("正常系: dry run は件数だけを返す", func(t *testing.T) {
+		f := setup()
+
+		erased, err := f.usecase.EraseDue(ctx, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, erased)
+		f.itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		assert.Len(t, f.erasureRepo.requests, 2)
+	})
+}
+
+func TestErasingRetentionUsecase_Purge(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	erasureRepo := newFakeErasureRequestRepository(&entity.ErasureRequest{UserID: "alice"})
+	privacy := NewPrivacyUsecase(nil, itemRepo, nil, nil, nil, nil, nil, erasureRepo, nil, 0)
+	u := NewErasingRetentionUsecase(NewRetentionUsecase(nil, itemRepo, nil, RetentionPolicy{}), privacy)
+
+	report, err := u.Purge(context.Background(), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, &RetentionReport{DryRun: true, Users: 1}, report)
+}
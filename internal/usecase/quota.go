@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// QuotaLimits are the most items a user may own and the most attachments (images and documents together)
+// an item may have; 0 is no limit
+type QuotaLimits struct {
+	ItemsPerUser       int
+	AttachmentsPerItem int
+}
+
+// QuotaUsage is how much of a limit is used; Limit is 0 when there is no limit
+type QuotaUsage struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+// ItemAttachmentUsage is how many of the attachments allowed per item an item has
+type ItemAttachmentUsage struct {
+	ItemID int64 `json:"item_id"`
+	QuotaUsage
+}
+
+// QuotaReport is the usage of a user's quotas
+type QuotaReport struct {
+	UserID string     `json:"user_id"`
+	Items  QuotaUsage `json:"items"`
+	// AttachmentsPerItem is the limit of attachments per item (0 is no limit)
+	AttachmentsPerItem int `json:"attachments_per_item"`
+	// Attachments is the usage of the item asked for, if any
+	Attachments *ItemAttachmentUsage `json:"attachments,omitempty"`
+}
+
+// QuotaUsecase reports and enforces the quotas. The checks are not atomic with the create or upload that follows:
+// requests at the same time may together go over a limit by a few.
+type QuotaUsecase interface {
+	// GetQuota returns the usage of the user's quotas, and of the attachments of itemID unless it is 0
+	GetQuota(ctx context.Context, userID string, itemID int64) (*QuotaReport, error)
+	// CheckItemQuota fails with ErrQuotaExceeded if the owner cannot own another item.
+	// Items without an owner are not limited.
+	CheckItemQuota(ctx context.Context, ownerID string) error
+	// CheckAttachmentQuota fails with ErrQuotaExceeded if the item cannot have another attachment
+	CheckAttachmentQuota(ctx context.Context, itemID int64) error
+}
+
+type quotaUsecase struct {
+	itemRepo     ItemReader
+	imageRepo    ItemImageRepository
+	documentRepo ItemDocumentRepository
+	limits       QuotaLimits
+}
+
+func NewQuotaUsecase(itemRepo ItemReader, imageRepo ItemImageRepository, documentRepo ItemDocumentRepository, limits QuotaLimits) QuotaUsecase {
+	return &quotaUsecase{
+		itemRepo:     itemRepo,
+		imageRepo:    imageRepo,
+		documentRepo: documentRepo,
+		limits:       limits,
+	}
+}
+
+func (u *quotaUsecase) GetQuota(ctx context.Context, userID string, itemID int64) (*QuotaReport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+	if itemID < 0 {
+		return nil, fmt.Errorf("%w: invalid item id: %d", domainErrors.ErrInvalidInput, itemID)
+	}
+	ctx = ReadOnly(ctx)
+
+	items, err := u.itemCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	report := &QuotaReport{
+		UserID:             userID,
+		Items:              QuotaUsage{Used: items, Limit: u.limits.ItemsPerUser},
+		AttachmentsPerItem: u.limits.AttachmentsPerItem,
+	}
+	if itemID == 0 {
+		return report, nil
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if item.OwnerID != userID {
+		return nil, domainErrors.PermissionDenied("item %d is not owned by %s", itemID, userID)
+	}
+	attachments, err := u.attachmentCount(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	report.Attachments = &ItemAttachmentUsage{ItemID: itemID, QuotaUsage: QuotaUsage{Used: attachments, Limit: u.limits.AttachmentsPerItem}}
+	return report, nil
+}
+
+func (u *quotaUsecase) CheckItemQuota(ctx context.Context, ownerID string) error {
+	if u.limits.ItemsPerUser <= 0 || ownerID == "" {
+		return nil
+	}
+	items, err := u.itemCount(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if items >= u.limits.ItemsPerUser {
+		return fmt.Errorf("%w: %s owns %d items, the limit is %d", domainErrors.ErrQuotaExceeded, ownerID, items, u.limits.ItemsPerUser)
+	}
+	return nil
+}
+
+func (u *quotaUsecase) CheckAttachmentQuota(ctx context.Context, itemID int64) error {
+	if u.limits.AttachmentsPerItem <= 0 || itemID <= 0 {
+		return nil
+	}
+	attachments, err := u.attachmentCount(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if attachments >= u.limits.AttachmentsPerItem {
+		return fmt.Errorf("%w: item %d has %d attachments, the limit is %d", domainErrors.ErrQuotaExceeded, itemID, attachments, u.limits.AttachmentsPerItem)
+	}
+	return nil
+}
+
+// itemCount counts the items the owner has, not those in the trash
+func (u *quotaUsecase) itemCount(ctx context.Context, ownerID string) (int, error) {
+	count, err := u.itemRepo.Count(ctx, ItemFilter{OwnerID: ownerID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count items: %w", err)
+	}
+	return count, nil
+}
+
+// attachmentCount counts the images and documents of an item
+func (u *quotaUsecase) attachmentCount(ctx context.Context, itemID int64) (int, error) {
+	images, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+	documents, err := u.documentRepo.FindByItemID(ctx, itemID, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve documents: %w", err)
+	}
+	return len(images) + len(documents), nil
+}
+
+type quotaItemUsecase struct {
+	ItemUsecase
+	quota QuotaUsecase
+}
+
+// NewQuotaItemUsecase refuses to create an item for an owner who has as many items as the quota allows
+func NewQuotaItemUsecase(inner ItemUsecase, quota QuotaUsecase) ItemUsecase {
+	return &quotaItemUsecase{ItemUsecase: inner, quota: quota}
+}
+
+func (u *quotaItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	if err := u.quota.CheckItemQuota(ctx, input.OwnerID); err != nil {
+		return nil, err
+	}
+	return u.ItemUsecase.CreateItem(ctx, input)
+}
+
+type quotaImageUsecase struct {
+	ImageUsecase
+	quota QuotaUsecase
+}
+
+// NewQuotaImageUsecase refuses to upload an image to an item that has as many attachments as the quota allows
+func NewQuotaImageUsecase(inner ImageUsecase, quota QuotaUsecase) ImageUsecase {
+	return &quotaImageUsecase{ImageUsecase: inner, quota: quota}
+}
+
+func (u *quotaImageUsecase) UploadImage(ctx context.Context, actor string, itemID int64, upload ImageUpload) (*entity.ItemImage, error) {
+	if err := u.quota.CheckAttachmentQuota(ctx, itemID); err != nil {
+		return nil, err
+	}
+	return u.ImageUsecase.UploadImage(ctx, actor, itemID, upload)
+}
+
+type quotaDocumentUsecase struct {
+	DocumentUsecase
+	quota QuotaUsecase
+}
+
+// NewQuotaDocumentUsecase refuses to upload a document to an item that has as many attachments as the quota allows
+func NewQuotaDocumentUsecase(inner DocumentUsecase, quota QuotaUsecase) DocumentUsecase {
+	return &quotaDocumentUsecase{DocumentUsecase: inner, quota: quota}
+}
+
+func (u *quotaDocumentUsecase) UploadDocument(ctx context.Context, actor string, itemID int64, upload DocumentUpload) (*entity.ItemDocument, error) {
+	if err := u.quota.CheckAttachmentQuota(ctx, itemID); err != nil {
+		return nil, err
+	}
+	return u.DocumentUsecase.UploadDocument(ctx, actor, itemID, upload)
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// itemAttachments は画像2枚と書類1件のアイテム1のリポジトリを返す
+func itemAttachments() (*MockItemImageRepository, *MockItemDocumentRepository) {
+	imageRepo := new(MockItemImageRepository)
+	imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 1}, {ID: 2}}, nil)
+	documentRepo := new(MockItemDocumentRepository)
+	documentRepo.On("FindByItemID", mock.Anything, int64(1), "").Return([]*entity.ItemDocument{{ID: 1}}, nil)
+	return imageRepo, documentRepo
+}
+
+func TestQuotaUsecase_GetQuota(t *testing.T) {
+	ctx := context.Background()
+	limits := QuotaLimits{ItemsPerUser: 100, AttachmentsPerItem: 5}
+
+	t.Run("正常系: アイテム数と添付ファイル数の使用量を返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(12, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil)
+		imageRepo, documentRepo := itemAttachments()
+
+		report, err := NewQuotaUsecase(itemRepo, imageRepo, documentRepo, limits).GetQuota(ctx, "alice", 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, &QuotaReport{
+			UserID:             "alice",
+			Items:              QuotaUsage{Used: 12, Limit: 100},
+			AttachmentsPerItem: 5,
+			Attachments:        &ItemAttachmentUsage{ItemID: 1, QuotaUsage: QuotaUsage{Used: 3, Limit: 5}},
+		}, report)
+	})
+
+	t.Run("正常系: アイテムを指定しなければアイテム数だけを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(0, nil)
+
+		report, err := NewQuotaUsecase(itemRepo, nil, nil, QuotaLimits{}).GetQuota(ctx, "alice", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, &QuotaReport{UserID: "alice"}, report)
+	})
+
+	t.Run("異常系: ユーザーの指定がない", func(t *testing.T) {
+		_, err := NewQuotaUsecase(new(MockItemRepository), nil, nil, limits).GetQuota(ctx, "", 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: ほかのユーザーのアイテム", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Count", mock.Anything, mock.Anything).Return(0, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "bob"}, nil)
+
+		_, err := NewQuotaUsecase(itemRepo, nil, nil, limits).GetQuota(ctx, "alice", 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestQuotaItemUsecase_CreateItem(t *testing.T) {
+	ctx := context.Background()
+	input := CreateItemInput{OwnerID: "alice", Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"}
+
+	t.Run("異常系: 上限に達したユーザーは登録できない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(3, nil)
+		quota := NewQuotaUsecase(itemRepo, nil, nil, QuotaLimits{ItemsPerUser: 3})
+
+		_, err := NewQuotaItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), quota).CreateItem(ctx, input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+		assert.EqualError(t, err, "quota exceeded: alice owns 3 items, the limit is 3")
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 上限未満なら登録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(2, nil)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 10, OwnerID: "alice"}, nil)
+		quota := NewQuotaUsecase(itemRepo, nil, nil, QuotaLimits{ItemsPerUser: 3})
+
+		item, err := NewQuotaItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), quota).CreateItem(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), item.ID)
+	})
+
+	t.Run("正常系: 所有者のないアイテムと上限が0は数えない", func(t *testing.T) {
+		for _, tt := range []struct {
+			owner string
+			limit int
+		}{{"", 3}, {"alice", 0}} {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 10}, nil)
+			quota := NewQuotaUsecase(itemRepo, nil, nil, QuotaLimits{ItemsPerUser: tt.limit})
+			input := input
+			input.OwnerID = tt.owner
+
+			_, err := NewQuotaItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), quota).CreateItem(ctx, input)
+
+			require.NoError(t, err)
+			itemRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestQuotaUsecase_CheckAttachmentQuota(t *testing.T) {
+	ctx := context.Background()
+	imageRepo, documentRepo := itemAttachments()
+
+	t.Run("異常系: 画像と書類を合わせて上限に達したアイテムにはアップロードできない", func(t *testing.T) {
+		quota := NewQuotaUsecase(nil, imageRepo, documentRepo, QuotaLimits{AttachmentsPerItem: 3})
+
+		_, err := NewQuotaImageUsecase(nil, quota).UploadImage(ctx, "alice", 1, ImageUpload{})
+		assert.EqualError(t, err, "quota exceeded: item 1 has 3 attachments, the limit is 3")
+
+		_, err = NewQuotaDocumentUsecase(nil, quota).UploadDocument(ctx, "alice", 1, DocumentUpload{})
+		assert.ErrorIs(t, err, domainErrors.ErrQuotaExceeded)
+	})
+
+	t.Run("正常系: 上限未満", func(t *testing.T) {
+		quota := NewQuotaUsecase(nil, imageRepo, documentRepo, QuotaLimits{AttachmentsPerItem: 4})
+
+		assert.NoError(t, quota.CheckAttachmentQuota(ctx, 1))
+	})
+}
+
+func TestImportUsecase_ImportItems_QuotaExceeded(t *testing.T) {
+	itemRepo := importedItems()
+	itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(2, nil).Once()
+	itemRepo.On("Count", mock.Anything, ItemFilter{OwnerID: "alice"}).Return(3, nil)
+	itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 10, Name: "スピードマスター"}, nil)
+	quota := NewQuotaUsecase(itemRepo, nil, nil, QuotaLimits{ItemsPerUser: 3})
+	u := NewImportUsecase(NewQuotaItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), quota), itemRepo, registeredBrands())
+
+	report, err := u.ImportItems(context.Background(), ImportInput{
+		OwnerID: "alice",
+		UserID:  "alice",
+		Rows: []ImportRow{
+			importRow("スピードマスター", "時計", "OMEGA", "2024-03-01", nil),
+			importRow("サブマリーナ", "時計", "ROLEX", "2024-06-01", nil),
+		},
+	})
+
+	// 上限を超える行だけが失敗する
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, []int{report.Created, report.Failed})
+	assert.Equal(t, ImportFailed, report.Rows[1].Status)
+	assert.Equal(t, "quota exceeded: alice owns 3 items, the limit is 3", report.Rows[1].Error)
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import "context"
+
+type readOnlyKey struct{}
+
+// ReadOnly marks ctx as a read-only operation that tolerates replication lag,
+// allowing the data layer to serve it from a read replica
+func ReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether ctx was marked by ReadOnly
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// OCRProvider reads the text of images with an external OCR service
+type OCRProvider interface {
+	// RecognizeText returns the text of the image, one line of the image per line. Returns ErrOCRUnavailable
+	// (ErrOCRTimeout if the service did not answer in time) if the service cannot be used.
+	RecognizeText(ctx context.Context, image []byte, contentType string) (string, error)
+}
+
+// ReceiptDraft is an item prefilled from a receipt, for the client to complete and confirm with POST /items
+type ReceiptDraft struct {
+	Draft CreateItemInput `json:"draft"`
+	// MissingFields are the required fields of the draft that could not be read from the receipt
+	MissingFields []string `json:"missing_fields"`
+	// Text is the text read from the receipt, so that the client can show it next to the draft
+	Text string `json:"text"`
+}
+
+type ReceiptUsecase interface {
+	// DraftItemFromReceipt reads the name, price and date of a purchase from a receipt image.
+	// Nothing is saved; the image is only sent to the OCR provider.
+	DraftItemFromReceipt(ctx context.Context, upload ImageUpload) (*ReceiptDraft, error)
+}
+
+type receiptUsecase struct {
+	provider OCRProvider
+	maxSize  int64
+}
+
+// NewReceiptUsecase creates the receipt usecase accepting images of up to maxSize bytes
+func NewReceiptUsecase(provider OCRProvider, maxSize int64) ReceiptUsecase {
+	return &receiptUsecase{
+		provider: provider,
+		maxSize:  maxSize,
+	}
+}
+
+func (u *receiptUsecase) DraftItemFromReceipt(ctx context.Context, upload ImageUpload) (*ReceiptDraft, error) {
+	if upload.Size <= 0 {
+		return nil, fmt.Errorf("%w: file must not be empty", domainErrors.ErrInvalidInput)
+	}
+
+	file, err := checkUpload(upload.Content, upload.Size, u.maxSize, ImageTypes,
+		domainErrors.ErrImageTooLarge, domainErrors.ErrUnsupportedImageType)
+	if err != nil {
+		return nil, err
+	}
+	image, err := io.ReadAll(file.content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	text, err := u.provider.RecognizeText(ctx, image, file.contentType)
+	if err != nil {
+		if domainErrors.IsOCRError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrOCRUnavailable, err)
+	}
+
+	draft := parseReceipt(text)
+	return &ReceiptDraft{
+		Draft:         draft,
+		MissingFields: missingDraftFields(draft),
+		Text:          text,
+	}, nil
+}
+
+var (
+	// 2024-03-09, 2024/3/9, 2024.03.09, 2024年3月9日
+	receiptDate = regexp.MustCompile(`(\d{4})\s*[-/.年]\s*(\d{1,2})\s*[-/.月]\s*(\d{1,2})`)
+	// 令和6年3月9日, R6.3.9
+	receiptReiwaDate = regexp.MustCompile(`(?:令和|\bR)\s*(\d{1,2}|元)\s*[年./]\s*(\d{1,2})\s*[月./]\s*(\d{1,2})`)
+	// ¥2,500,000, \2500000, 2,500,000円
+	receiptAmount = regexp.MustCompile(`[¥\\]\s*(\d{1,3}(?:,\d{3})+|\d+)|(\d{1,3}(?:,\d{3})+|\d+)\s*円`)
+	// Amounts without a currency sign, accepted only on the total line
+	receiptNumber = regexp.MustCompile(`\d{1,3}(?:,\d{3})+|\d+`)
+	// Quantities: x2, @1, 2点, 2個
+	receiptQuantity = regexp.MustCompile(`(?:^|\s)(?:[x×@]\s*\d{1,3}|\d{1,3}\s*[点個])`)
+)
+
+// Words marking the line of the amount paid
+var receiptTotalWords = []string{"合計", "お買上", "お買い上げ", "金額", "ご請求", "TOTAL", "AMOUNT"}
+
+// Words marking lines that are neither purchased items nor the amount paid
+var receiptOtherWords = []string{
+	"小計", "SUBTOTAL", "SUB TOTAL", "税", "TAX", "預", "釣", "CHANGE", "CASH", "ポイント", "POINT", "割引", "DISCOUNT",
+}
+
+// parseReceipt reads the item name, price and purchase date from the text of a receipt.
+// The name is the first purchased item line, or the "但し" line of a Japanese receipt that lists no items.
+func parseReceipt(text string) CreateItemInput {
+	var lines []string
+	for _, line := range strings.Split(normalizeReceiptText(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var draft CreateItemInput
+	for _, line := range lines {
+		if date, ok := receiptLineDate(line); ok {
+			draft.PurchaseDate = date
+			break
+		}
+	}
+	draft.PurchasePrice = entity.MinorUnits(int64(receiptTotal(lines)))
+	draft.Name = receiptItemName(lines)
+	return draft
+}
+
+// normalizeReceiptText converts full-width letters, digits and signs, common on Japanese receipts, to ASCII
+func normalizeReceiptText(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - '！' + '!'
+		case r == '￥':
+			return '¥'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+func receiptLineDate(line string) (string, bool) {
+	var year int
+	var match []string
+	if match = receiptReiwaDate.FindStringSubmatch(line); match != nil {
+		// 令和元年 is 2019
+		reiwa := 1
+		if match[1] != "元" {
+			reiwa, _ = strconv.Atoi(match[1])
+		}
+		year = 2018 + reiwa
+	} else if match = receiptDate.FindStringSubmatch(line); match != nil {
+		year, _ = strconv.Atoi(match[1])
+	} else {
+		return "", false
+	}
+
+	month, _ := strconv.Atoi(match[2])
+	day, _ := strconv.Atoi(match[3])
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// Reject dates such as 2024/13/45 that time.Date would roll over
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return "", false
+	}
+	return date.Format("2006-01-02"), true
+}
+
+// receiptTotal returns the amount paid: the amount of the first total line, or the amount with a currency sign
+// on the line after it if the amount is printed below the word. Without a total line, the largest amount on the receipt is used.
+func receiptTotal(lines []string) int {
+	for i, line := range lines {
+		if !receiptTotalLine(line) {
+			continue
+		}
+		if amount, ok := receiptLineTotal(line); ok {
+			return amount
+		}
+		if i+1 < len(lines) && !receiptOtherLine(lines[i+1]) {
+			if amounts := receiptAmounts(lines[i+1]); len(amounts) > 0 {
+				return amounts[len(amounts)-1]
+			}
+		}
+	}
+
+	largest := 0
+	for _, line := range lines {
+		if receiptOtherLine(line) {
+			continue
+		}
+		for _, amount := range receiptAmounts(line) {
+			largest = max(largest, amount)
+		}
+	}
+	return largest
+}
+
+func receiptTotalLine(line string) bool {
+	return containsAny(strings.ToUpper(line), receiptTotalWords) && !receiptOtherLine(line)
+}
+
+// receiptOtherLine reports whether the line is a subtotal, tax, payment or discount line.
+// "税込" (tax included) is allowed, since totals are often printed as "合計(税込)".
+func receiptOtherLine(line string) bool {
+	return containsAny(strings.ReplaceAll(strings.ToUpper(line), "税込", ""), receiptOtherWords)
+}
+
+// receiptLineTotal returns the last amount of the line, with or without a currency sign
+func receiptLineTotal(line string) (int, bool) {
+	if amounts := receiptAmounts(line); len(amounts) > 0 {
+		return amounts[len(amounts)-1], true
+	}
+	numbers := receiptNumber.FindAllString(receiptQuantity.ReplaceAllString(line, " "), -1)
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	return parseReceiptAmount(numbers[len(numbers)-1])
+}
+
+func receiptAmounts(line string) []int {
+	var amounts []int
+	for _, match := range receiptAmount.FindAllStringSubmatch(line, -1) {
+		digits := match[1]
+		if digits == "" {
+			digits = match[2]
+		}
+		if amount, ok := parseReceiptAmount(digits); ok {
+			amounts = append(amounts, amount)
+		}
+	}
+	return amounts
+}
+
+func parseReceiptAmount(digits string) (int, bool) {
+	amount, err := strconv.Atoi(strings.ReplaceAll(digits, ",", ""))
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return amount, true
+}
+
+// receiptItemName returns the text of the first line with an amount that is not a total or other line
+func receiptItemName(lines []string) string {
+	for _, line := range lines {
+		if len(receiptAmounts(line)) == 0 || receiptTotalLine(line) || receiptOtherLine(line) {
+			continue
+		}
+		if _, ok := receiptLineDate(line); ok {
+			continue
+		}
+		text := receiptQuantity.ReplaceAllString(receiptAmount.ReplaceAllString(line, " "), " ")
+		if name := receiptName(text); name != "" {
+			return name
+		}
+	}
+
+	// 但し 時計代として
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "但"); ok {
+			rest = strings.TrimPrefix(strings.TrimPrefix(rest, "し"), "書き")
+			rest = strings.TrimSuffix(strings.TrimSpace(rest), "として")
+			if name := receiptName(strings.TrimSuffix(rest, "代")); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// receiptName trims separators from the text of a line, and cuts it to the longest valid name
+func receiptName(text string) string {
+	text = strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("-:*.,", r)
+	})
+	if !strings.ContainsFunc(text, unicode.IsLetter) {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > 100 {
+		text = strings.TrimSpace(string(runes[:100]))
+	}
+	return text
+}
+
+func containsAny(s string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingDraftFields returns the JSON names of the required fields left empty in the draft
+func missingDraftFields(draft CreateItemInput) []string {
+	missing := []string{}
+	if draft.Name == "" {
+		missing = append(missing, "name")
+	}
+	if draft.Category == "" {
+		missing = append(missing, "category")
+	}
+	if draft.Brand == "" {
+		missing = append(missing, "brand")
+	}
+	if draft.PurchasePrice == entity.MinorUnits(0) {
+		missing = append(missing, "purchase_price")
+	}
+	if draft.PurchaseDate == "" {
+		missing = append(missing, "purchase_date")
+	}
+	return missing
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fixedOCRProvider は決まった文字列を返す OCR の提供元
+type fixedOCRProvider struct {
+	text        string
+	err         error
+	image       []byte
+	contentType string
+}
+
+func (p *fixedOCRProvider) RecognizeText(ctx context.Context, image []byte, contentType string) (string, error) {
+	p.image = image
+	p.contentType = contentType
+	return p.text, p.err
+}
+
+func TestReceiptUsecase_DraftItemFromReceipt(t *testing.T) {
+	receipt := "ROLEX ブティック銀座\n2024年3月9日\nデイトナ 116500LN ¥2,500,000\n合計 ¥2,500,000"
+
+	tests := []struct {
+		name        string
+		content     []byte
+		providerErr error
+		expectedErr error
+	}{
+		{name: "正常系: 読み取った内容で下書きを作る", content: testPNG},
+		{name: "異常系: 空のファイル", content: []byte{}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 上限より大きい", content: append(testPNG, make([]byte, 64)...), expectedErr: domainErrors.ErrImageTooLarge},
+		{name: "異常系: 画像ではない", content: []byte("%PDF-1.7"), expectedErr: domainErrors.ErrUnsupportedImageType},
+		{name: "異常系: OCR のタイムアウト", content: testPNG, providerErr: domainErrors.ErrOCRTimeout, expectedErr: domainErrors.ErrOCRTimeout},
+		{name: "異常系: OCR の障害", content: testPNG, providerErr: errors.New("connection refused"), expectedErr: domainErrors.ErrOCRUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fixedOCRProvider{text: receipt, err: tt.providerErr}
+			u := NewReceiptUsecase(provider, int64(len(testPNG)+32))
+
+			draft, err := u.DraftItemFromReceipt(context.Background(), ImageUpload{
+				FileName: "receipt.png", Size: int64(len(tt.content)), Content: bytes.NewReader(tt.content),
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, draft)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, CreateItemInput{Name: "デイトナ 116500LN", PurchasePrice: entity.MinorUnits(2500000), PurchaseDate: "2024-03-09"}, draft.Draft)
+			assert.Equal(t, []string{"category", "brand"}, draft.MissingFields)
+			assert.Equal(t, receipt, draft.Text)
+			assert.Equal(t, testPNG, provider.image)
+			assert.Equal(t, "image/png", provider.contentType)
+		})
+	}
+}
+
+func TestParseReceipt(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected CreateItemInput
+	}{
+		{
+			name:     "正常系: 商品の行と合計",
+			text:     "HERMES 銀座店\nTEL 03-1234-5678\n2024/02/20 14:32\nバーキン 30 x1 ¥2,000,000\n小計 ¥2,000,000\n内消費税 ¥181,818\n合計(税込) ¥2,000,000\nお預り ¥2,000,000\nお釣り ¥0",
+			expected: CreateItemInput{Name: "バーキン 30", PurchasePrice: entity.MinorUnits(2000000), PurchaseDate: "2024-02-20"},
+		},
+		{
+			name:     "正常系: 全角の数字と円表記",
+			text:     "令和６年３月９日\nカルティエ タンク　１点　８５０，０００円\nお買上げ合計\n８５０，０００円",
+			expected: CreateItemInput{Name: "カルティエ タンク", PurchasePrice: entity.MinorUnits(850000), PurchaseDate: "2024-03-09"},
+		},
+		{
+			name:     "正常系: 商品のない領収書は但し書きを名前にする",
+			text:     "領収書\n山田 太郎 様\n金額 ¥1,200,000-\n但し 時計代として\n2023.01.15",
+			expected: CreateItemInput{Name: "時計", PurchasePrice: entity.MinorUnits(1200000), PurchaseDate: "2023-01-15"},
+		},
+		{
+			name:     "正常系: 英語のレシート",
+			text:     "OMEGA Boutique\nDate: 2022-11-03\nSpeedmaster Moonwatch \\1,050,000\nSUBTOTAL \\1,050,000\nTAX \\95,454\nTOTAL 1,050,000\nCASH \\1,100,000",
+			expected: CreateItemInput{Name: "Speedmaster Moonwatch", PurchasePrice: entity.MinorUnits(1050000), PurchaseDate: "2022-11-03"},
+		},
+		{
+			name:     "正常系: 存在しない日付は読まない",
+			text:     "2024/13/45\nTOTAL ¥5,000",
+			expected: CreateItemInput{PurchasePrice: entity.MinorUnits(5000)},
+		},
+		{
+			name:     "正常系: 読み取れるものがない",
+			text:     "THANK YOU",
+			expected: CreateItemInput{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseReceipt(tt.text))
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// DefaultReminderDays are the days before an expiry date at which a reminder is sent
+var DefaultReminderDays = []int{30, 7}
+
+type ReminderUsecase interface {
+	// SendExpiryReminders records an ItemExpiring event for each expiry date that has reached a reminder stage
+	// since the last reminder, and returns how many were recorded
+	SendExpiryReminders(ctx context.Context) (int, error)
+	SnoozeReminders(ctx context.Context, actor string, itemID int64, input SnoozeRemindersInput) (*entity.ReminderSnooze, error)
+	UnsnoozeReminders(ctx context.Context, actor string, itemID int64) error
+}
+
+type SnoozeRemindersInput struct {
+	Until string `json:"until"`
+}
+
+type reminderUsecase struct {
+	itemRepo     ItemReader
+	reminderRepo ReminderRepository
+	outbox       ItemEventOutbox
+	uow          UnitOfWork
+	attributes   []string
+	days         []int
+	now          func() time.Time
+}
+
+// NewReminderUsecase creates the expiry reminder usecase. attributes are the keys of the date-typed custom attributes
+// holding expiry dates, and days the days before an expiry date at which a reminder is sent (DefaultReminderDays if empty).
+// Reminders are recorded through the outbox, so they reach webhooks and email notifications like item changes do.
+func NewReminderUsecase(itemRepo ItemReader, reminderRepo ReminderRepository, outbox ItemEventOutbox, uow UnitOfWork, attributes []string, days []int) ReminderUsecase {
+	if len(days) == 0 {
+		days = DefaultReminderDays
+	}
+	sorted := append([]int(nil), days...)
+	sort.Ints(sorted)
+
+	return &reminderUsecase{
+		itemRepo:     itemRepo,
+		reminderRepo: reminderRepo,
+		outbox:       outbox,
+		uow:          uow,
+		attributes:   attributes,
+		days:         sorted,
+		now:          time.Now,
+	}
+}
+
+// reminderKey identifies the expiry date of an item attribute; a new date (such as a renewed warranty) starts the stages over
+type reminderKey struct {
+	itemID    int64
+	attribute string
+	expiresOn string
+}
+
+func (u *reminderUsecase) SendExpiryReminders(ctx context.Context) (int, error) {
+	if len(u.attributes) == 0 {
+		return 0, nil
+	}
+
+	now := u.now()
+	today := now.Format("2006-01-02")
+	readCtx := ReadOnly(ctx)
+	items, err := u.itemRepo.FindAll(readCtx, ItemQuery{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	sent, err := u.reminderRepo.FindSent(readCtx, today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve reminders: %w", err)
+	}
+	snoozes, err := u.reminderRepo.FindSnoozes(readCtx, today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve reminder snoozes: %w", err)
+	}
+
+	lastSent := make(map[reminderKey]int, len(sent))
+	for _, reminder := range sent {
+		lastSent[reminderKey{reminder.ItemID, reminder.Attribute, reminder.ExpiresOn}] = reminder.DaysLeft
+	}
+	snoozed := make(map[int64]bool, len(snoozes))
+	for _, snooze := range snoozes {
+		snoozed[snooze.ItemID] = snooze.Active(today)
+	}
+
+	count := 0
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		// 停止中に達した段階の通知は、停止が終わってから送る
+		if snoozed[item.ID] {
+			continue
+		}
+		for _, attribute := range u.attributes {
+			expiresOn, ok := item.Attributes[attribute]
+			if !ok {
+				continue
+			}
+			daysLeft, ok := daysUntil(today, expiresOn)
+			if !ok {
+				continue
+			}
+			stage, ok := u.stage(daysLeft)
+			if !ok {
+				continue
+			}
+			if previous, ok := lastSent[reminderKey{item.ID, attribute, expiresOn}]; ok {
+				if previousStage, _ := u.stage(previous); previousStage <= stage {
+					continue
+				}
+			}
+
+			if err := u.send(ctx, item, attribute, expiresOn, daysLeft, now); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// send records the reminder and its event in one transaction, so a reminder is marked as sent if and only if its event is recorded
+func (u *reminderUsecase) send(ctx context.Context, item *entity.Item, attribute, expiresOn string, daysLeft int, now time.Time) error {
+	reminder := &entity.ExpiryReminder{
+		ItemID:     item.ID,
+		Attribute:  attribute,
+		ExpiresOn:  expiresOn,
+		DaysLeft:   daysLeft,
+		RemindedAt: now,
+	}
+
+	return recordEvent(ctx, u.uow, u.outbox, func(ctx context.Context) (ItemEvent, error) {
+		if err := u.reminderRepo.SaveSent(ctx, reminder); err != nil {
+			return ItemEvent{}, fmt.Errorf("failed to record reminder: %w", err)
+		}
+		event := newItemEvent(ItemExpiring, item.ID, item)
+		event.Expiry = reminder
+		return event, nil
+	})
+}
+
+// stage returns the reminder stage (the fewest days in u.days that daysLeft has reached), or false if no reminder is due
+func (u *reminderUsecase) stage(daysLeft int) (int, bool) {
+	if daysLeft < 0 {
+		return 0, false
+	}
+	for _, days := range u.days {
+		if daysLeft <= days {
+			return days, true
+		}
+	}
+	return 0, false
+}
+
+// daysUntil returns the days from today to a YYYY-MM-DD date, or false if the value is not a date
+func daysUntil(today, date string) (int, bool) {
+	from, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return 0, false
+	}
+	to, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, false
+	}
+	return int(to.Sub(from).Hours() / 24), true
+}
+
+// SnoozeReminders pauses the reminders of an item until a date; only the owner may snooze them.
+// Items without an owner (created before ownership was tracked) may be snoozed by any user.
+func (u *reminderUsecase) SnoozeReminders(ctx context.Context, actor string, itemID int64, input SnoozeRemindersInput) (*entity.ReminderSnooze, error) {
+	if err := u.checkOwner(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	snooze, err := entity.NewReminderSnooze(itemID, actor, input.Until, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+	if err := u.reminderRepo.SaveSnooze(ctx, snooze); err != nil {
+		return nil, fmt.Errorf("failed to save reminder snooze: %w", err)
+	}
+
+	return snooze, nil
+}
+
+// UnsnoozeReminders resumes the reminders of an item; reminders due in the meantime are sent by the next scan
+func (u *reminderUsecase) UnsnoozeReminders(ctx context.Context, actor string, itemID int64) error {
+	if err := u.checkOwner(ctx, actor, itemID); err != nil {
+		return err
+	}
+
+	if err := u.reminderRepo.DeleteSnooze(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete reminder snooze: %w", err)
+	}
+
+	return nil
+}
+
+func (u *reminderUsecase) checkOwner(ctx context.Context, actor string, itemID int64) error {
+	if itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if item.OwnerID != "" && item.OwnerID != actor {
+		return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+	}
+
+	return nil
+}
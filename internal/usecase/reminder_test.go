@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeReminderRepository は送った通知と停止をメモリ上に保持する
+type fakeReminderRepository struct {
+	sent    map[reminderKey]*entity.ExpiryReminder
+	snoozes map[int64]*entity.ReminderSnooze
+}
+
+func newFakeReminderRepository() *fakeReminderRepository {
+	return &fakeReminderRepository{
+		sent:    map[reminderKey]*entity.ExpiryReminder{},
+		snoozes: map[int64]*entity.ReminderSnooze{},
+	}
+}
+
+func (r *fakeReminderRepository) FindSent(ctx context.Context, since string) ([]*entity.ExpiryReminder, error) {
+	var reminders []*entity.ExpiryReminder
+	for _, reminder := range r.sent {
+		if reminder.ExpiresOn >= since {
+			reminders = append(reminders, reminder)
+		}
+	}
+	return reminders, nil
+}
+
+func (r *fakeReminderRepository) SaveSent(ctx context.Context, reminder *entity.ExpiryReminder) error {
+	r.sent[reminderKey{reminder.ItemID, reminder.Attribute, reminder.ExpiresOn}] = reminder
+	return nil
+}
+
+func (r *fakeReminderRepository) FindSnoozes(ctx context.Context, today string) ([]*entity.ReminderSnooze, error) {
+	var snoozes []*entity.ReminderSnooze
+	for _, snooze := range r.snoozes {
+		if snooze.Active(today) {
+			snoozes = append(snoozes, snooze)
+		}
+	}
+	return snoozes, nil
+}
+
+func (r *fakeReminderRepository) SaveSnooze(ctx context.Context, snooze *entity.ReminderSnooze) error {
+	r.snoozes[snooze.ItemID] = snooze
+	return nil
+}
+
+func (r *fakeReminderRepository) DeleteSnooze(ctx context.Context, itemID int64) error {
+	if _, ok := r.snoozes[itemID]; !ok {
+		return domainErrors.ErrReminderSnoozeNotFound
+	}
+	delete(r.snoozes, itemID)
+	return nil
+}
+
+func newTestReminderUsecase(items []*entity.Item, reminders *fakeReminderRepository, outbox ItemEventOutbox, today *time.Time) ReminderUsecase {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(items, nil)
+	u := NewReminderUsecase(itemRepo, reminders, outbox, nil, []string{"warranty_expires", "insurance_expires"}, []int{7, 30}).(*reminderUsecase)
+	u.now = func() time.Time { return *today }
+	return u
+}
+
+func TestReminderUsecase_SendExpiryReminders(t *testing.T) {
+	ctx := context.Background()
+	items := []*entity.Item{
+		{ID: 1, Name: "ロレックス デイトナ", Attributes: map[string]string{"warranty_expires": "2024-04-01", "insurance_expires": "2025-01-01"}},
+		{ID: 2, Name: "エルメス バーキン", Attributes: map[string]string{"insurance_expires": "2024-03-15"}},
+		{ID: 3, Name: "期限切れ", Attributes: map[string]string{"warranty_expires": "2024-03-01"}},
+		{ID: 4, Name: "日付以外", Attributes: map[string]string{"warranty_expires": "来月"}},
+	}
+
+	t.Run("正常系: 期限の近い属性ごとに1回ずつ通知する", func(t *testing.T) {
+		today := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+		reminders := newFakeReminderRepository()
+		outbox := &recordingOutbox{}
+		u := newTestReminderUsecase(items, reminders, outbox, &today)
+
+		count, err := u.SendExpiryReminders(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		require.Len(t, outbox.events, 2)
+		assert.Equal(t, ItemExpiring, outbox.events[0].Type)
+		assert.Equal(t, int64(1), outbox.events[0].ItemID)
+		assert.Equal(t, &entity.ExpiryReminder{ItemID: 1, Attribute: "warranty_expires", ExpiresOn: "2024-04-01", DaysLeft: 22, RemindedAt: today}, outbox.events[0].Expiry)
+		assert.Equal(t, "insurance_expires", outbox.events[1].Expiry.Attribute)
+		assert.Equal(t, 5, outbox.events[1].Expiry.DaysLeft)
+
+		// 同じ段階の通知は繰り返さない
+		count, err = u.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		// 次の段階（7日前）に達したら、もう一度通知する
+		today = time.Date(2024, 3, 26, 9, 0, 0, 0, time.Local)
+		count, err = u.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 6, outbox.events[2].Expiry.DaysLeft)
+	})
+
+	t.Run("正常系: 停止中のアイテムは停止が終わってから通知する", func(t *testing.T) {
+		today := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+		reminders := newFakeReminderRepository()
+		reminders.snoozes[1] = &entity.ReminderSnooze{ItemID: 1, Until: "2024-03-20"}
+		outbox := &recordingOutbox{}
+		u := newTestReminderUsecase(items[:1], reminders, outbox, &today)
+
+		count, err := u.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		today = time.Date(2024, 3, 20, 9, 0, 0, 0, time.Local)
+		count, err = u.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("正常系: 期限が更新されたら最初の段階から通知する", func(t *testing.T) {
+		today := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+		reminders := newFakeReminderRepository()
+		reminders.sent[reminderKey{1, "warranty_expires", "2024-03-12"}] = &entity.ExpiryReminder{ItemID: 1, Attribute: "warranty_expires", ExpiresOn: "2024-03-12", DaysLeft: 2}
+		outbox := &recordingOutbox{}
+		u := newTestReminderUsecase(items[:1], reminders, outbox, &today)
+
+		count, err := u.SendExpiryReminders(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, "2024-04-01", outbox.events[0].Expiry.ExpiresOn)
+	})
+
+	t.Run("異常系: イベントを記録できなければ送信済みにしない", func(t *testing.T) {
+		today := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+		reminders := newFakeReminderRepository()
+		outbox := &recordingOutbox{err: domainErrors.ErrDatabaseError}
+		uow := &recordingUnitOfWork{}
+		u := newTestReminderUsecase(items[:1], reminders, outbox, &today)
+		u.(*reminderUsecase).uow = uow
+
+		_, err := u.SendExpiryReminders(ctx)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, uow.rolledBack)
+	})
+}
+
+func TestReminderUsecase_SnoozeReminders(t *testing.T) {
+	ctx := context.Background()
+	today := time.Date(2024, 3, 10, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		actor       string
+		until       string
+		findErr     error
+		expectedErr error
+	}{
+		{name: "正常系: 所有者が停止する", actor: "alice", until: "2024-04-01"},
+		{name: "異常系: 所有者以外", actor: "bob", until: "2024-04-01", expectedErr: domainErrors.ErrForbidden},
+		{name: "異常系: 過去の日付", actor: "alice", until: "2024-03-01", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: アイテムが見つからない", actor: "alice", until: "2024-04-01", findErr: domainErrors.ErrItemNotFound, expectedErr: domainErrors.ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			if tt.findErr != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, tt.findErr)
+			} else {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice"}, nil)
+			}
+			reminders := newFakeReminderRepository()
+			u := NewReminderUsecase(itemRepo, reminders, &recordingOutbox{}, nil, nil, nil).(*reminderUsecase)
+			u.now = func() time.Time { return today }
+
+			snooze, err := u.SnoozeReminders(ctx, tt.actor, 1, SnoozeRemindersInput{Until: tt.until})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, reminders.snoozes)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "2024-04-01", snooze.Until)
+			assert.Equal(t, snooze, reminders.snoozes[1])
+		})
+	}
+
+	t.Run("異常系: 停止していないアイテムの再開", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		u := NewReminderUsecase(itemRepo, newFakeReminderRepository(), &recordingOutbox{}, nil, nil, nil)
+
+		err := u.UnsnoozeReminders(ctx, "alice", 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrReminderSnoozeNotFound)
+	})
+}
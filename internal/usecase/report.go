@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ReportUsecase interface {
+	// GetSpendReport returns the money spent per month and per category in year (the current year if 0),
+	// compared with the previous year
+	GetSpendReport(ctx context.Context, year int) (*SpendReport, error)
+}
+
+// SpendReport counts the items purchased in Year and totals the purchase prices of those bought in BaseCurrency.
+// Previous* are the totals of the year before and Change the difference to them.
+type SpendReport struct {
+	Year          int                   `json:"year"`
+	Count         int                   `json:"count"`
+	Spend         int                   `json:"spend"`
+	PreviousSpend int                   `json:"previous_spend"`
+	Change        int                   `json:"change"`
+	Months        []*SpendLine          `json:"months"`
+	Categories    map[string]*SpendLine `json:"categories"`
+}
+
+type SpendLine struct {
+	// Month is only set on the lines of Months (YYYY-MM)
+	Month         string `json:"month,omitempty"`
+	Count         int    `json:"count"`
+	Spend         int    `json:"spend"`
+	PreviousSpend int    `json:"previous_spend"`
+	Change        int    `json:"change"`
+}
+
+type reportUsecase struct {
+	itemRepo ItemReader
+	now      func() time.Time
+}
+
+func NewReportUsecase(itemRepo ItemReader) ReportUsecase {
+	return &reportUsecase{
+		itemRepo: itemRepo,
+		now:      time.Now,
+	}
+}
+
+func (u *reportUsecase) GetSpendReport(ctx context.Context, year int) (*SpendReport, error) {
+	if year == 0 {
+		year = u.now().Year()
+	}
+	if year < 1 || year > 9999 {
+		return nil, fmt.Errorf("%w: year must be between 1 and 9999", domainErrors.ErrInvalidInput)
+	}
+
+	report := &SpendReport{
+		Year:       year,
+		Months:     make([]*SpendLine, 12),
+		Categories: make(map[string]*SpendLine),
+	}
+	for i := range report.Months {
+		report.Months[i] = &SpendLine{Month: fmt.Sprintf("%04d-%02d", year, i+1)}
+	}
+	for _, category := range entity.GetValidCategories() {
+		report.Categories[category] = &SpendLine{}
+	}
+
+	err := u.itemRepo.Iterate(ReadOnly(ctx), ItemQuery{}, func(item *entity.Item) error {
+		date, err := time.Parse("2006-01-02", item.PurchaseDate)
+		if err != nil {
+			// 購入日が YYYY-MM-DD でないアイテム（バリデーション導入前のデータ）は数えない
+			return nil
+		}
+		month := report.Months[date.Month()-1]
+		category, ok := report.Categories[item.Category]
+		if !ok {
+			category = &SpendLine{}
+			report.Categories[item.Category] = category
+		}
+
+		price, _ := basePrice(item)
+		switch date.Year() {
+		case year:
+			report.Count++
+			report.Spend += price
+			month.Count++
+			month.Spend += price
+			category.Count++
+			category.Spend += price
+		case year - 1:
+			report.PreviousSpend += price
+			month.PreviousSpend += price
+			category.PreviousSpend += price
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spend report: %w", err)
+	}
+
+	report.Change = report.Spend - report.PreviousSpend
+	for _, month := range report.Months {
+		month.Change = month.Spend - month.PreviousSpend
+	}
+	for _, category := range report.Categories {
+		category.Change = category.Spend - category.PreviousSpend
+	}
+
+	return report, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestReportUsecase_GetSpendReport(t *testing.T) {
+	ctx := context.Background()
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchaseDate: "2024-01-15", PurchasePrice: 1500000},
+		{ID: 2, Category: "バッグ", PurchaseDate: "2024-01-20", PurchasePrice: 2000000},
+		{ID: 3, Category: "時計", PurchaseDate: "2024-06-01", PurchasePrice: 800000},
+		{ID: 4, Category: "時計", PurchaseDate: "2023-01-10", PurchasePrice: 500000},
+		{ID: 5, Category: "靴", PurchaseDate: "2023-12-24", PurchasePrice: 150000},
+		{ID: 6, Category: "ジュエリー", PurchaseDate: "2022-05-01", PurchasePrice: 300000},
+		{ID: 7, Category: "その他", PurchaseDate: "不明", PurchasePrice: 100000},
+	}
+
+	t.Run("正常系: 月別・カテゴリー別に集計し、前年と比べる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		report, err := NewReportUsecase(itemRepo).GetSpendReport(ctx, 2024)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2024, report.Year)
+		assert.Equal(t, 3, report.Count)
+		assert.Equal(t, 4300000, report.Spend)
+		assert.Equal(t, 650000, report.PreviousSpend)
+		assert.Equal(t, 3650000, report.Change)
+
+		require.Len(t, report.Months, 12)
+		assert.Equal(t, &SpendLine{Month: "2024-01", Count: 2, Spend: 3500000, PreviousSpend: 500000, Change: 3000000}, report.Months[0])
+		assert.Equal(t, &SpendLine{Month: "2024-06", Count: 1, Spend: 800000, Change: 800000}, report.Months[5])
+		assert.Equal(t, &SpendLine{Month: "2024-12", PreviousSpend: 150000, Change: -150000}, report.Months[11])
+
+		assert.Equal(t, &SpendLine{Count: 2, Spend: 2300000, PreviousSpend: 500000, Change: 1800000}, report.Categories["時計"])
+		assert.Equal(t, &SpendLine{PreviousSpend: 150000, Change: -150000}, report.Categories["靴"])
+		assert.Equal(t, &SpendLine{}, report.Categories["ジュエリー"])
+	})
+
+	t.Run("正常系: 年の指定がなければ今年", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+		u := NewReportUsecase(itemRepo).(*reportUsecase)
+		u.now = func() time.Time { return time.Date(2023, 3, 10, 9, 0, 0, 0, time.UTC) }
+
+		report, err := u.GetSpendReport(ctx, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2023, report.Year)
+		assert.Equal(t, 650000, report.Spend)
+		assert.Equal(t, 300000, report.PreviousSpend)
+	})
+
+	t.Run("正常系: ほかの通貨のアイテムは数えるが支出には含めない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{
+			{ID: 1, Category: "時計", PurchaseDate: "2024-01-15", PurchasePrice: 1500000},
+			{ID: 2, Category: "時計", PurchaseDate: "2024-01-20", PurchasePrice: 1299900, Currency: "USD"},
+			{ID: 3, Category: "時計", PurchaseDate: "2023-01-20", PurchasePrice: 50000, Currency: "EUR"},
+		}, nil))
+
+		report, err := NewReportUsecase(itemRepo).GetSpendReport(ctx, 2024)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Count)
+		assert.Equal(t, 1500000, report.Spend)
+		assert.Zero(t, report.PreviousSpend)
+		assert.Equal(t, &SpendLine{Count: 2, Spend: 1500000, Change: 1500000}, report.Categories["時計"])
+	})
+
+	t.Run("異常系: 不正な年", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := NewReportUsecase(itemRepo).GetSpendReport(ctx, -1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,27 +2,637 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
-// ItemRepository defines the interface for item data access
-type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+// The tests of this package use MockItemRepository; tests of other packages use the mocks package.
+//go:generate go run github.com/vektra/mockery/v2@v2.53.3 --name ItemRepository --inpackage --testonly --structname MockItemRepository --filename mock_item_repository_test.go --output .
+
+// UnitOfWork runs multi-step operations atomically
+type UnitOfWork interface {
+	// Do runs fn in a single transaction; repository calls made with fn's context take part in it
+	// and are rolled back when fn returns an error
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// ItemFilter narrows down the items of a query; empty fields match every item
+type ItemFilter struct {
+	Category string
+	Brand    string
+	OwnerID  string
+	// Currency restricts the items to those bought in it (an ISO 4217 code; items without one are in BaseCurrency)
+	Currency string
+
+	// MinCurrentValue restricts the items to those whose current value is at least it (0 = no restriction);
+	// items never valued are left out
+	MinCurrentValue int
+}
+
+// DateRange restricts items to those purchased between From and To, both inclusive and in YYYY-MM-DD format;
+// an empty bound leaves that side open
+type DateRange struct {
+	From string
+	To   string
+}
+
+// SortByCurrentValue orders the items of a query by their current value, the value of their latest valuation.
+// Items never valued come last in either order.
+const SortByCurrentValue = "current_value"
+
+// SortByID orders the items of a query by ID alone, for reading them in pages with ItemQuery.AfterID.
+// It is not one of entity.SortableFields, as users choose from these.
+const SortByID = "id"
+
+// ItemQuery describes which items to retrieve and in which order
+type ItemQuery struct {
+	ItemFilter
+
+	// SortBy is one of entity.SortableFields or SortByID (created_at when empty); ties are broken by ID
+	SortBy    string
+	SortOrder string
+
+	// AfterID restricts the items to those with a greater ID (0 = no restriction), to resume reading
+	// in ascending ID order (SortByID) after the last item read
+	AfterID int64
+
+	// Limit is the maximum number of items (0 = no limit); Offset is only applied together with Limit
+	Limit  int
+	Offset int
+
+	// TrashedBefore selects the items moved to the trash before it instead of the items not in the trash
+	// (zero = the items not in the trash)
+	TrashedBefore time.Time
+}
+
+// ItemIterator reads the items of a query one at a time from a cursor. It must be closed, also after an error.
+//
+//	for it.Next() {
+//		item := it.Item()
+//	}
+//	err := it.Err()
+type ItemIterator interface {
+	// Next advances to the next item; false at the end of the items or on an error
+	Next() bool
+
+	// Item returns the item Next advanced to
+	Item() *entity.Item
+
+	// Err returns the error that ended the iteration, nil at the end of the items
+	Err() error
+
+	// Close releases the cursor and the connection it holds
+	Close() error
+}
+
+// TrashQuery describes which items in the trash to retrieve; they are returned most recently deleted first
+type TrashQuery struct {
+	// DeletedBefore restricts the items to those moved to the trash before it (zero = no restriction)
+	DeletedBefore time.Time
+
+	// Limit is the maximum number of items (0 = no limit); Offset is only applied together with Limit
+	Limit  int
+	Offset int
+}
+
+// ItemReader is the read side of item data access. Usecases that only look items up depend on it,
+// so read-only decorators (caches, read replicas) need not implement the writes.
+// Items moved to the trash are left out by every method except the ones for the trash and queries with TrashedBefore.
+type ItemReader interface {
+	// FindAll retrieves the items matching the query
+	FindAll(ctx context.Context, query ItemQuery) ([]*entity.Item, error)
+
+	// Iterate calls fn for each item matching the query, in order, reading from a cursor instead of
+	// loading the whole result set. Iteration stops at the first error returned by fn, which is returned unchanged.
+	Iterate(ctx context.Context, query ItemQuery, fn func(*entity.Item) error) error
+
+	// FindAllStream returns an iterator over the items matching the query, in order, reading from a cursor so
+	// the memory used does not grow with the number of items. The cursor holds a connection until it is closed.
+	FindAllStream(ctx context.Context, query ItemQuery) (ItemIterator, error)
+
+	// Count returns the number of items matching the filter
+	Count(ctx context.Context, filter ItemFilter) (int, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
+	// FindTrashed retrieves the items in the trash matching the query
+	FindTrashed(ctx context.Context, query TrashQuery) ([]*entity.TrashedItem, error)
+
+	// CountTrashed returns the number of items in the trash
+	CountTrashed(ctx context.Context) (int, error)
+
+	// FindTrashedByID retrieves an item in the trash by ID; ErrItemNotFound if it is not in the trash
+	FindTrashedByID(ctx context.Context, id int64) (*entity.TrashedItem, error)
+
+	// GetSummaryByCategory returns item counts and purchase price aggregates grouped by category (bonus feature),
+	// counting only the items purchased within purchased. The aggregates only cover the items bought in BaseCurrency.
+	GetSummaryByCategory(ctx context.Context, purchased DateRange) (map[string]CategoryStats, error)
+
+	// GetSummaryByBrand returns item counts and purchase price totals grouped by brand; like the category aggregates,
+	// the totals only cover the items bought in BaseCurrency
+	GetSummaryByBrand(ctx context.Context) (map[string]*BrandTotals, error)
+
+	// CountByPriceBands counts the items per purchase price band. bounds are the ascending exclusive upper bounds
+	// of the bands; the result has len(bounds)+1 counts, the last one for the prices at or above the last bound.
+	// Only the items bought in BaseCurrency are counted, as bounds are amounts in it.
+	CountByPriceBands(ctx context.Context, bounds []int) ([]int, error)
+}
+
+// ItemWriter is the write side of item data access
+type ItemWriter interface {
 	// Create creates a new item and returns it with the generated ID
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Delete deletes an item by ID
+	// Update stores the category, name, brand, purchase price, attributes and owner of an existing item whose version
+	// still equals item.Version and increments the version. Returns ErrConflict if the item has been updated in the meantime.
+	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
+
+	// Delete permanently deletes an item by ID, whether or not it is in the trash
 	Delete(ctx context.Context, id int64) error
 
-	// Update updates an existing item
-	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
+	// Trash moves an item to the trash. Returns ErrItemNotFound if the item does not exist or is already in the trash.
+	Trash(ctx context.Context, id int64, deletedAt time.Time) error
+
+	// Restore takes an item out of the trash. Returns ErrItemNotFound if the item is not in the trash.
+	Restore(ctx context.Context, id int64) error
+}
+
+// ItemRepository defines the interface for item data access, for the usecases that both read and write items
+type ItemRepository interface {
+	ItemReader
+	ItemWriter
+}
+
+// CategoryStats are the number of items of a category and aggregates of the purchase prices of those bought in
+// BaseCurrency (all 0 for an empty category, or one without items in BaseCurrency)
+type CategoryStats struct {
+	Count int     `json:"count"`
+	Min   int     `json:"min_purchase_price"`
+	Max   int     `json:"max_purchase_price"`
+	Avg   float64 `json:"avg_purchase_price"`
+	Sum   int     `json:"total_purchase_price"`
+}
+
+// BrandTotals are the number of items of a brand and the sum of the purchase prices of those bought in BaseCurrency
+type BrandTotals struct {
+	Count         int
+	PurchaseTotal int
+}
+
+// SettingsRepository stores per-tenant settings
+type SettingsRepository interface {
+	// FindListSettings returns the tenant's list settings, or nil if none are stored
+	FindListSettings(ctx context.Context, tenantID string) (*entity.ListSettings, error)
+
+	// SaveListSettings creates or replaces the tenant's list settings
+	SaveListSettings(ctx context.Context, tenantID string, settings *entity.ListSettings) error
+}
+
+// CustomAttributeRepository stores per-tenant custom attribute definitions
+type CustomAttributeRepository interface {
+	// FindAll retrieves all attribute definitions of a tenant ordered by key
+	FindAll(ctx context.Context, tenantID string) ([]*entity.CustomAttribute, error)
+
+	// Save creates or replaces an attribute definition
+	Save(ctx context.Context, tenantID string, attr *entity.CustomAttribute) (*entity.CustomAttribute, error)
+
+	// Delete deletes an attribute definition by key
+	Delete(ctx context.Context, tenantID, key string) error
+}
+
+// CategoryRepository stores the categories tenants add to the built-in ones
+type CategoryRepository interface {
+	// FindAll retrieves the categories of a tenant ordered by name
+	FindAll(ctx context.Context, tenantID string) ([]*entity.Category, error)
+
+	// FindAllNames retrieves the names of the categories of every tenant, each name once, ordered by name
+	FindAllNames(ctx context.Context) ([]string, error)
+
+	// Create adds a category to a tenant
+	Create(ctx context.Context, tenantID string, category *entity.Category) (*entity.Category, error)
+
+	// Delete deletes a category by name
+	Delete(ctx context.Context, tenantID, name string) error
+}
+
+// BrandRepository stores the canonical brand names and their aliases
+type BrandRepository interface {
+	// FindAll retrieves every brand with its aliases, ordered by name
+	FindAll(ctx context.Context) ([]*entity.Brand, error)
+
+	// Create stores a brand and its aliases
+	Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error)
+
+	// Delete deletes a brand and its aliases
+	Delete(ctx context.Context, id int64) error
+}
+
+// TransferRepository stores ownership transfers; the records double as the audit trail
+type TransferRepository interface {
+	// Create creates a new transfer and returns it with the generated ID
+	Create(ctx context.Context, transfer *entity.Transfer) (*entity.Transfer, error)
+
+	// FindByID retrieves a transfer by ID
+	FindByID(ctx context.Context, id int64) (*entity.Transfer, error)
+
+	// FindByItemID retrieves the transfer history of an item, oldest first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Transfer, error)
+
+	// FindPendingByRecipient retrieves pending transfers addressed to a user
+	FindPendingByRecipient(ctx context.Context, userID string) ([]*entity.Transfer, error)
+
+	// Resolve stores the final status of a pending transfer; the item is moved to the recipient through the
+	// ItemRepository, which may be another store. Returns ErrConflict if the transfer is no longer pending.
+	Resolve(ctx context.Context, transfer *entity.Transfer) error
+
+	// FindByUser retrieves the transfers a user sent, received or resolved, oldest first
+	FindByUser(ctx context.Context, userID string) ([]*entity.Transfer, error)
+
+	// ReplaceUser replaces a user with pseudonym wherever they appear in the transfers
+	ReplaceUser(ctx context.Context, userID, pseudonym string) error
+}
+
+// LoanRepository stores item loans; returned loans are kept as the lending history
+type LoanRepository interface {
+	// Create creates a new loan and returns it with the generated ID
+	Create(ctx context.Context, loan *entity.Loan) (*entity.Loan, error)
+
+	// FindByID retrieves a loan by ID
+	FindByID(ctx context.Context, id int64) (*entity.Loan, error)
+
+	// FindByItemID retrieves the loans of an item, oldest first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Loan, error)
+
+	// FindActiveByItemID retrieves the loan an item is currently out on, or nil if it is not on loan
+	FindActiveByItemID(ctx context.Context, itemID int64) (*entity.Loan, error)
+
+	// FindOverdue retrieves active loans due before the given date (YYYY-MM-DD), earliest due first
+	FindOverdue(ctx context.Context, today string) ([]*entity.Loan, error)
+
+	// MarkReturned stores the return of an active loan. Returns ErrConflict if the loan is no longer active.
+	MarkReturned(ctx context.Context, loan *entity.Loan) error
+}
+
+// ReminderRepository stores the expiry reminders sent and the items whose reminders are snoozed
+type ReminderRepository interface {
+	// FindSent retrieves the reminders sent for expiry dates on or after the given date (YYYY-MM-DD)
+	FindSent(ctx context.Context, since string) ([]*entity.ExpiryReminder, error)
+
+	// SaveSent records a sent reminder, replacing the one sent earlier for the same item, attribute and expiry date
+	SaveSent(ctx context.Context, reminder *entity.ExpiryReminder) error
+
+	// FindSnoozes retrieves the snoozes still in effect on the given date (YYYY-MM-DD)
+	FindSnoozes(ctx context.Context, today string) ([]*entity.ReminderSnooze, error)
+
+	// SaveSnooze creates or replaces the snooze of an item
+	SaveSnooze(ctx context.Context, snooze *entity.ReminderSnooze) error
+
+	// DeleteSnooze deletes the snooze of an item. Returns ErrReminderSnoozeNotFound if the item has none.
+	DeleteSnooze(ctx context.Context, itemID int64) error
+}
+
+// ServiceSummary aggregates the service records of an item
+type ServiceSummary struct {
+	TotalCost       int
+	LastServiceDate string // YYYY-MM-DD
+}
+
+// ServiceRecordRepository stores the maintenance history of items
+type ServiceRecordRepository interface {
+	// Create creates a new record and returns it with the generated ID
+	Create(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error)
+
+	// FindByID retrieves a record by ID
+	FindByID(ctx context.Context, id int64) (*entity.ServiceRecord, error)
+
+	// FindByItemID retrieves the records of an item, most recent service first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ServiceRecord, error)
+
+	// Update replaces the contents of a record
+	Update(ctx context.Context, record *entity.ServiceRecord) (*entity.ServiceRecord, error)
+
+	// Delete deletes a record
+	Delete(ctx context.Context, id int64) error
+
+	// SummarizeByItem returns the summaries of the given items, or of every item if itemIDs is nil.
+	// Items without records are not included.
+	SummarizeByItem(ctx context.Context, itemIDs []int64) (map[int64]*ServiceSummary, error)
+}
+
+// ValuationRepository stores the market valuations of items
+type ValuationRepository interface {
+	// Create creates a new valuation and returns it with the generated ID
+	Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error)
+
+	// FindByItemID retrieves the valuations of an item, newest first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error)
+
+	// FindLatestValues returns the value of the latest valuation of every item valued, by item ID
+	FindLatestValues(ctx context.Context) (map[int64]int, error)
+}
+
+// ItemRevisionRepository stores the snapshots of items taken at every create and update.
+// Changes are not stored; they are derived from the previous revision when the history is read.
+type ItemRevisionRepository interface {
+	// Create records a revision; it joins the transaction carried by ctx
+	Create(ctx context.Context, revision *entity.ItemRevision) error
+
+	// FindByItemID retrieves the revisions of an item, oldest first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error)
+
+	// FindByRevision retrieves one revision of an item. Returns ErrItemRevisionNotFound if it was not recorded.
+	FindByRevision(ctx context.Context, itemID, revision int64) (*entity.ItemRevision, error)
+
+	// CountCreatedBefore returns the number of revisions recorded before the given time
+	CountCreatedBefore(ctx context.Context, before time.Time) (int, error)
+
+	// DeleteCreatedBefore deletes the revisions recorded before the given time and returns how many were deleted
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error)
+
+	// FindByChangedBy retrieves the revisions recorded for the changes of a user, oldest first
+	FindByChangedBy(ctx context.Context, userID string) ([]*entity.ItemRevision, error)
+
+	// DeleteByItemID deletes the revisions of an item
+	DeleteByItemID(ctx context.Context, itemID int64) error
+
+	// ReplaceChangedBy replaces a user with pseudonym as the author of the revisions
+	ReplaceChangedBy(ctx context.Context, userID, pseudonym string) error
+}
+
+// ErasureRequestRepository stores the requests of users to erase their data, until they are carried out
+type ErasureRequestRepository interface {
+	// Create stores a request unless the user already has one, and returns the stored request
+	Create(ctx context.Context, request *entity.ErasureRequest) (*entity.ErasureRequest, error)
+
+	// FindByUserID retrieves the request of a user. Returns ErrErasureRequestNotFound if the user has none.
+	FindByUserID(ctx context.Context, userID string) (*entity.ErasureRequest, error)
+
+	// FindDue retrieves the requests whose grace period ended before the given time, oldest first
+	FindDue(ctx context.Context, now time.Time) ([]*entity.ErasureRequest, error)
+
+	// Delete deletes the request of a user. Returns ErrErasureRequestNotFound if the user has none.
+	Delete(ctx context.Context, userID string) error
+}
+
+// ItemImageRepository stores the metadata of item images; the files are kept in a Storage
+type ItemImageRepository interface {
+	// Create creates a new image and returns it with the generated ID
+	Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error)
+
+	// FindByID retrieves an image by ID
+	FindByID(ctx context.Context, id int64) (*entity.ItemImage, error)
+
+	// FindByItemID retrieves the images of an item in upload order
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+
+	// Delete deletes an image
+	Delete(ctx context.Context, id int64) error
+
+	// ListIDsByItem returns the image IDs of the given items in upload order. Items without images are not included.
+	ListIDsByItem(ctx context.Context, itemIDs []int64) (map[int64][]int64, error)
+
+	// ListAllIDsByItem returns the image IDs of every item that has images, in upload order
+	ListAllIDsByItem(ctx context.Context) (map[int64][]int64, error)
+
+	// ExistingStorageKeys returns which of the given storage keys an image refers to
+	ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+// ItemDocumentRepository stores the metadata of item documents; the files are kept in a Storage
+type ItemDocumentRepository interface {
+	// Create creates a new document and returns it with the generated ID
+	Create(ctx context.Context, document *entity.ItemDocument) (*entity.ItemDocument, error)
+
+	// FindByID retrieves a document by ID
+	FindByID(ctx context.Context, id int64) (*entity.ItemDocument, error)
+
+	// FindByItemID retrieves the documents of an item in upload order, only those of docType unless it is empty
+	FindByItemID(ctx context.Context, itemID int64, docType string) ([]*entity.ItemDocument, error)
+
+	// Delete deletes a document
+	Delete(ctx context.Context, id int64) error
+
+	// ExistingStorageKeys returns which of the given storage keys a document refers to
+	ExistingStorageKeys(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+// TagRepository stores the tags shared by all items and the tags of each item.
+// Tag names are compared regardless of case.
+type TagRepository interface {
+	// Create creates a new tag and returns it with the generated ID
+	Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error)
+
+	// FindAll retrieves every tag with its item count, ordered by name
+	FindAll(ctx context.Context) ([]*entity.Tag, error)
+
+	// FindByID retrieves a tag with its item count
+	FindByID(ctx context.Context, id int64) (*entity.Tag, error)
+
+	// FindByNames retrieves the tags with the given names, ordered by name. Names without a tag are skipped.
+	FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error)
+
+	// Update stores the name of a tag
+	Update(ctx context.Context, tag *entity.Tag) error
+
+	// Delete deletes a tag and removes it from its items
+	Delete(ctx context.Context, id int64) error
+
+	// Merge moves the items of the source tags to the target tag, then deletes the source tags
+	Merge(ctx context.Context, targetID int64, sourceIDs []int64) error
+
+	// ItemIDs returns the IDs of the items with any of the given tags, in ID order
+	ItemIDs(ctx context.Context, tagIDs []int64) ([]int64, error)
+
+	// FindByItemID retrieves the tags of an item, ordered by name
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Tag, error)
+
+	// SetItemTags replaces the tags of an item
+	SetItemTags(ctx context.Context, itemID int64, tagIDs []int64) error
+
+	// ListNamesByItem returns the tag names of the given items ordered by name. Items without tags are not included.
+	ListNamesByItem(ctx context.Context, itemIDs []int64) (map[int64][]string, error)
+}
+
+// ItemCodeRepository stores the serial numbers, barcodes and QR codes of items. Codes are stored normalized
+// for their type (see entity.NewItemCode) and compared exactly.
+type ItemCodeRepository interface {
+	// FindByItemID retrieves the codes of an item, ordered by type and code
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemCode, error)
+
+	// SetItemCodes replaces the codes of an item
+	SetItemCodes(ctx context.Context, itemID int64, codes []*entity.ItemCode) error
+
+	// FindItems returns the items having any of the given codes, in item ID order;
+	// an item having more than one of them is returned once for each
+	FindItems(ctx context.Context, codes []*entity.ItemCode) ([]ItemCodeMatch, error)
+}
+
+// ItemCodeMatch is an item found by one of its codes
+type ItemCodeMatch struct {
+	ItemID int64
+	Type   string
+}
+
+// CollectionRepository stores user-defined collections and the items in them
+type CollectionRepository interface {
+	// Create creates a new collection and returns it with the generated ID
+	Create(ctx context.Context, collection *entity.Collection) (*entity.Collection, error)
+
+	// FindAll retrieves the collections of ownerID, or every collection if it is empty, with their item counts ordered by name
+	FindAll(ctx context.Context, ownerID string) ([]*entity.Collection, error)
+
+	// FindByID retrieves a collection with its item count
+	FindByID(ctx context.Context, id int64) (*entity.Collection, error)
+
+	// Update stores the name and description of a collection
+	Update(ctx context.Context, collection *entity.Collection) error
+
+	// Delete deletes a collection; its items are only removed from it
+	Delete(ctx context.Context, id int64) error
+
+	// ItemIDs returns the IDs of the items in a collection, in the order they were added
+	ItemIDs(ctx context.Context, collectionID int64) ([]int64, error)
+
+	// AddItems adds items to a collection; the caller skips the items already in it
+	AddItems(ctx context.Context, collectionID int64, itemIDs []int64, addedAt time.Time) error
+
+	// RemoveItem removes an item from a collection. Returns ErrNotFound if the item is not in it.
+	RemoveItem(ctx context.Context, collectionID, itemID int64) error
+
+	// RemoveItemFromAll removes an item from every collection it is in
+	RemoveItemFromAll(ctx context.Context, itemID int64) error
+}
+
+// ItemMergeRepository moves the records attached to items when duplicates are merged into one item
+type ItemMergeRepository interface {
+	// FindAttachments returns the IDs of the images, documents, valuations and service records of the given items
+	FindAttachments(ctx context.Context, itemIDs []int64) (*ItemAttachments, error)
+
+	// MoveAttachments moves the images, documents, valuations and service records of the given items to targetID
+	MoveAttachments(ctx context.Context, itemIDs []int64, targetID int64) error
+}
+
+// ExportJobRepository keeps asynchronous export jobs and their generated files
+type ExportJobRepository interface {
+	// Save creates or replaces a job
+	Save(ctx context.Context, job *entity.ExportJob) error
+
+	// FindByID retrieves a job by ID
+	FindByID(ctx context.Context, id string) (*entity.ExportJob, error)
+}
+
+// WebhookRepository stores webhook registrations and the log of their deliveries
+type WebhookRepository interface {
+	// Create creates a new webhook and returns it with the generated ID
+	Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+
+	// FindAll retrieves every webhook ordered by ID
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+
+	// FindByID retrieves a webhook by ID
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+
+	// Delete deletes a webhook together with its delivery log
+	Delete(ctx context.Context, id int64) error
+
+	// CreateDelivery creates a new delivery and returns it with the generated ID
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+
+	// FindDeliveries retrieves the latest deliveries of a webhook, newest first
+	FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+
+	// FindDueDeliveries retrieves pending deliveries whose next attempt is due at now, oldest first
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+
+	// ClaimDelivery moves the next attempt of a delivery that is still due at now to until, so that no other
+	// process attempts it in the meantime. Returns false if the delivery has been claimed or attempted since it was read.
+	ClaimDelivery(ctx context.Context, delivery *entity.WebhookDelivery, now, until time.Time) (bool, error)
+
+	// UpdateDelivery stores the result of an attempt
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+// NotificationRuleRepository stores the rules for emailing item changes
+type NotificationRuleRepository interface {
+	// Create creates a new rule and returns it with the generated ID
+	Create(ctx context.Context, rule *entity.NotificationRule) (*entity.NotificationRule, error)
+
+	// FindAll retrieves every rule ordered by ID
+	FindAll(ctx context.Context) ([]*entity.NotificationRule, error)
+
+	// FindByID retrieves a rule by ID
+	FindByID(ctx context.Context, id int64) (*entity.NotificationRule, error)
+
+	// Delete deletes a rule
+	Delete(ctx context.Context, id int64) error
+}
+
+// OutboxEvent is an item event recorded in the outbox
+type OutboxEvent struct {
+	ID    int64
+	Event ItemEvent
+}
+
+// OutboxRepository stores the item events recorded with the changes they describe until they are published
+type OutboxRepository interface {
+	// Append records an event; it joins the transaction carried by ctx
+	Append(ctx context.Context, event ItemEvent) error
+
+	// FindPending retrieves unpublished events that no relay has claimed at now, oldest first
+	FindPending(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+
+	// Claim reserves an unpublished event for the caller until the given time, so that other relays skip it.
+	// Returns false if the event has been published or claimed by another relay since it was read.
+	Claim(ctx context.Context, id int64, now, until time.Time) (bool, error)
+
+	// MarkPublished records that an event has been published
+	MarkPublished(ctx context.Context, id int64, at time.Time) error
+
+	// DeletePublished deletes events published before the given time and returns how many were deleted
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ItemEventQuery selects events recorded in the outbox; zero fields do not restrict
+type ItemEventQuery struct {
+	// Since and Until restrict the events to those that occurred in [Since, Until)
+	Since  time.Time
+	Until  time.Time
+	ItemID int64
+
+	// AfterID restricts the events to those recorded after the event with that ID, to read them in batches
+	AfterID int64
+	Limit   int
+}
+
+// ItemEventLog reads the events kept in the outbox, published or not; published events are kept
+// for the retention of the outbox
+type ItemEventLog interface {
+	// FindEvents retrieves the events matching the query, oldest first
+	FindEvents(ctx context.Context, query ItemEventQuery) ([]*OutboxEvent, error)
+}
+
+// AdminUserRepository stores the accounts of the admin server. Names are compared regardless of case.
+type AdminUserRepository interface {
+	// Create stores a new account; the name is unique
+	Create(ctx context.Context, user *entity.AdminUser) (*entity.AdminUser, error)
+
+	// FindByName retrieves an account by name; ErrAdminUserNotFound if there is none
+	FindByName(ctx context.Context, name string) (*entity.AdminUser, error)
+
+	// Count returns the number of accounts
+	Count(ctx context.Context) (int, error)
+}
+
+// PortfolioSnapshotRepository stores the values of the items recorded every day per category
+type PortfolioSnapshotRepository interface {
+	// Save stores the snapshots taken on a day (YYYY-MM-DD), replacing the ones already stored for that day
+	Save(ctx context.Context, takenOn string, snapshots []*entity.PortfolioSnapshot) error
+
+	// FindAll retrieves every snapshot, oldest day first and in category order within a day
+	FindAll(ctx context.Context) ([]*entity.PortfolioSnapshot, error)
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+	// LatestDate returns the day of the latest snapshot, or an empty string if none was taken
+	LatestDate(ctx context.Context) (string, error)
 }
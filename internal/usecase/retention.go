@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// RetentionPolicy is how long data is kept before the retention job deletes it permanently; 0 keeps it forever
+type RetentionPolicy struct {
+	// Trash is how long deleted items stay in the trash
+	Trash time.Duration
+	// Revisions is how long the recorded revisions of items are kept
+	Revisions time.Duration
+}
+
+// RetentionUsecase permanently deletes the data older than the retention policy
+type RetentionUsecase interface {
+	// Purge deletes the expired data and reports how much was deleted; with dryRun it only reports
+	// how much would be deleted
+	Purge(ctx context.Context, dryRun bool) (*RetentionReport, error)
+}
+
+// RetentionReport is what a retention run deleted, or would delete in a dry run.
+// The cutoffs are left out for the kinds of data kept forever.
+type RetentionReport struct {
+	DryRun          bool       `json:"dry_run"`
+	Items           int        `json:"items"`
+	TrashedBefore   *time.Time `json:"trashed_before,omitempty"`
+	Revisions       int        `json:"revisions"`
+	RevisionsBefore *time.Time `json:"revisions_before,omitempty"`
+	// Users is how many users' data was erased at their request (see NewErasingRetentionUsecase)
+	Users int `json:"users"`
+}
+
+type retentionUsecase struct {
+	itemUsecase  ItemUsecase
+	itemRepo     ItemReader
+	revisionRepo ItemRevisionRepository
+	policy       RetentionPolicy
+	now          func() time.Time
+}
+
+// NewRetentionUsecase creates the usecase. Items are purged with itemUsecase.PurgeItem, so their images and
+// documents are deleted with them.
+func NewRetentionUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, revisionRepo ItemRevisionRepository, policy RetentionPolicy) RetentionUsecase {
+	return &retentionUsecase{
+		itemUsecase:  itemUsecase,
+		itemRepo:     itemRepo,
+		revisionRepo: revisionRepo,
+		policy:       policy,
+		now:          time.Now,
+	}
+}
+
+// Purge returns the report of what was deleted so far along with an error, as the deletions are not undone
+func (u *retentionUsecase) Purge(ctx context.Context, dryRun bool) (*RetentionReport, error) {
+	now := u.now().UTC()
+	report := &RetentionReport{DryRun: dryRun}
+
+	if u.policy.Trash > 0 {
+		before := now.Add(-u.policy.Trash)
+		report.TrashedBefore = &before
+		items, err := u.purgeTrash(ctx, before, dryRun)
+		report.Items = items
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if u.policy.Revisions > 0 {
+		before := now.Add(-u.policy.Revisions)
+		report.RevisionsBefore = &before
+		var err error
+		if dryRun {
+			report.Revisions, err = u.revisionRepo.CountCreatedBefore(ReadOnly(ctx), before)
+		} else {
+			report.Revisions, err = u.revisionRepo.DeleteCreatedBefore(ctx, before)
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to delete revisions: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// purgeBatchSize is the number of expired items read from the trash before they are purged
+const purgeBatchSize = 500
+
+// purgeTrash purges the items one at a time, so a failure leaves the ones already purged deleted. The trash is
+// read in batches and the cursor is closed before a batch is purged, as the purge writes to the table the cursor
+// reads (SQLite has a single connection); purged items are gone from the next batch.
+func (u *retentionUsecase) purgeTrash(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	query := ItemQuery{TrashedBefore: before, SortBy: "created_at", SortOrder: entity.SortAsc}
+	if dryRun {
+		count := 0
+		it, err := u.itemRepo.FindAllStream(ReadOnly(ctx), query)
+		if err == nil {
+			err = eachItem(it, func(*entity.Item) error {
+				count++
+				return nil
+			})
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to retrieve trash: %w", err)
+		}
+		return count, nil
+	}
+
+	query.Limit = purgeBatchSize
+	purged := 0
+	for {
+		ids, err := u.trashedIDs(ctx, query)
+		if err != nil {
+			return purged, fmt.Errorf("failed to retrieve trash: %w", err)
+		}
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return purged, fmt.Errorf("purge stopped: %w", err)
+			}
+			if err := u.itemUsecase.PurgeItem(ctx, id); err != nil {
+				// Purged by hand since it was listed
+				if domainErrors.IsNotFoundError(err) {
+					continue
+				}
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// trashedIDs reads the IDs of the items of a query from the trash, closing the cursor before returning
+func (u *retentionUsecase) trashedIDs(ctx context.Context, query ItemQuery) ([]int64, error) {
+	it, err := u.itemRepo.FindAllStream(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, query.Limit)
+	err = eachItem(it, func(item *entity.Item) error {
+		ids = append(ids, item.ID)
+		return nil
+	})
+	return ids, err
+}
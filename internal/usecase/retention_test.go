@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestRetentionUsecase_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	policy := RetentionPolicy{Trash: 30 * 24 * time.Hour, Revisions: 365 * 24 * time.Hour}
+	trashedBefore := now.Add(-policy.Trash)
+	revisionsBefore := now.Add(-policy.Revisions)
+
+	newUsecase := func(itemRepo *MockItemRepository, revisionRepo ItemRevisionRepository, policy RetentionPolicy) *retentionUsecase {
+		u := NewRetentionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, revisionRepo, policy).(*retentionUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+	revisions := func() *fakeItemRevisionRepository {
+		return &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+			{ItemID: 1, Revision: 1, CreatedAt: now.AddDate(-2, 0, 0)},
+			{ItemID: 1, Revision: 2, CreatedAt: now.AddDate(0, -1, 0)},
+		}}
+	}
+
+	trashQuery := ItemQuery{TrashedBefore: trashedBefore, SortBy: "created_at", SortOrder: entity.SortAsc}
+	trashed := func(ids ...int64) ItemIterator {
+		var items []*entity.Item
+		for _, id := range ids {
+			items = append(items, trashedItem(id, now.AddDate(0, -2, 0)).Item)
+		}
+		return NewSliceItemIterator(items)
+	}
+
+	t.Run("正常系: 保持期間を過ぎたアイテムとリビジョンを完全に削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.Anything, ItemQuery{TrashedBefore: trashedBefore, SortBy: "created_at", SortOrder: entity.SortAsc, Limit: purgeBatchSize}).
+			Return(trashed(1, 2), nil)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, now), nil)
+		// 一覧の後に手動で完全に削除された
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrItemNotFound)
+		itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		revisionRepo := revisions()
+
+		report, err := newUsecase(itemRepo, revisionRepo, policy).Purge(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, &RetentionReport{Items: 1, TrashedBefore: &trashedBefore, Revisions: 1, RevisionsBefore: &revisionsBefore}, report)
+		assert.Len(t, revisionRepo.revisions, 1)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, int64(2))
+	})
+
+	t.Run("正常系: dry run は件数だけを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.MatchedBy(IsReadOnly), trashQuery).Return(trashed(1), nil)
+		revisionRepo := revisions()
+
+		report, err := newUsecase(itemRepo, revisionRepo, policy).Purge(ctx, true)
+
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 1, report.Items)
+		assert.Equal(t, 1, report.Revisions)
+		assert.Len(t, revisionRepo.revisions, 2)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 保持期間が0なら削除しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		revisionRepo := revisions()
+
+		report, err := newUsecase(itemRepo, revisionRepo, RetentionPolicy{}).Purge(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, &RetentionReport{}, report)
+		assert.Len(t, revisionRepo.revisions, 2)
+		itemRepo.AssertNotCalled(t, "FindAllStream", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 失敗したら削除した件数と一緒に返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(trashed(1, 2), nil)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, now), nil)
+		itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrDatabaseError)
+		revisionRepo := revisions()
+
+		report, err := newUsecase(itemRepo, revisionRepo, policy).Purge(ctx, false)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, report.Items)
+		// 残りは次の実行で削除する
+		assert.Len(t, revisionRepo.revisions, 2)
+	})
+
+	t.Run("異常系: 途中でキャンセルされたら残りは削除しない", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(trashed(1, 2), nil)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, now), nil)
+		itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil).Run(func(mock.Arguments) { cancel() })
+		revisionRepo := revisions()
+
+		report, err := newUsecase(itemRepo, revisionRepo, policy).Purge(ctx, false)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, report.Items)
+		itemRepo.AssertNotCalled(t, "FindTrashedByID", mock.Anything, int64(2))
+		assert.Len(t, revisionRepo.revisions, 2)
+	})
+
+	t.Run("正常系: ゴミ箱を一定の件数ずつ読み、読み終えてから削除する", func(t *testing.T) {
+		ids := make([]int64, purgeBatchSize+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+		itemRepo := new(MockItemRepository)
+		first := trashed(ids[:purgeBatchSize]...)
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(first, nil).Once()
+		itemRepo.On("FindAllStream", mock.Anything, mock.Anything).Return(trashed(ids[purgeBatchSize:]...), nil).Once()
+		itemRepo.On("FindTrashedByID", mock.Anything, mock.Anything).Return(trashedItem(1, now), nil).
+			Run(func(mock.Arguments) { assert.False(t, first.Next(), "削除の前にカーソルを閉じる") })
+		itemRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+		report, err := newUsecase(itemRepo, revisions(), RetentionPolicy{Trash: policy.Trash}).Purge(ctx, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, purgeBatchSize+1, report.Items)
+		itemRepo.AssertNumberOfCalls(t, "FindAllStream", 2)
+	})
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Item change types
+const (
+	ChangeCreated     = "created"
+	ChangeUpdated     = "updated"
+	ChangeTransferred = "transferred"
+)
+
+type RevisionUsecase interface {
+	// ListRevisions returns the revisions of an item, oldest first, each with its changes from the previous one
+	ListRevisions(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error)
+
+	// ListChanges returns who changed which fields of an item and when, oldest first
+	ListChanges(ctx context.Context, itemID int64) ([]*ItemChange, error)
+
+	// RollbackItem restores the name, brand, purchase price and attributes of an item to those of a revision
+	// through the normal update, which records a new revision. The category and purchase date cannot be updated
+	// and stay as they are.
+	RollbackItem(ctx context.Context, actor, tenantID string, itemID, revision int64) (*entity.Item, error)
+}
+
+// ItemChange is an entry of the change feed of an item: the fields one user changed at one time.
+// Revision is set for creates and updates, the transfer ID for ownership transfers.
+type ItemChange struct {
+	Type       string               `json:"type"`
+	Revision   int64                `json:"revision,omitempty"`
+	TransferID int64                `json:"transfer_id,omitempty"`
+	ChangedBy  string               `json:"changed_by,omitempty"`
+	ChangedAt  time.Time            `json:"changed_at"`
+	Changes    []entity.FieldChange `json:"changes"`
+}
+
+type revisionUsecase struct {
+	itemUsecase  ItemUsecase
+	revisionRepo ItemRevisionRepository
+	transferRepo TransferRepository
+}
+
+// NewRevisionUsecase creates the revision usecase; rollbacks are applied with itemUsecase.PatchItem,
+// so they are validated, recorded as events and added to the history like any other update.
+// The change feed combines the revisions with the accepted transfers, which change the owner without a revision.
+func NewRevisionUsecase(itemUsecase ItemUsecase, revisionRepo ItemRevisionRepository, transferRepo TransferRepository) RevisionUsecase {
+	return &revisionUsecase{
+		itemUsecase:  itemUsecase,
+		revisionRepo: revisionRepo,
+		transferRepo: transferRepo,
+	}
+}
+
+func (u *revisionUsecase) ListRevisions(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	if _, err := u.itemUsecase.GetItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := u.revisionRepo.FindByItemID(ReadOnly(ctx), itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve revisions: %w", err)
+	}
+
+	var previous *entity.ItemSnapshot
+	for _, revision := range revisions {
+		revision.Changes = snapshotChanges(previous, revision.Snapshot)
+		previous = &revision.Snapshot
+	}
+	if revisions == nil {
+		revisions = []*entity.ItemRevision{}
+	}
+	return revisions, nil
+}
+
+// ListChanges leaves out revisions that changed none of the recorded fields, such as a rollback to the current state
+func (u *revisionUsecase) ListChanges(ctx context.Context, itemID int64) ([]*ItemChange, error) {
+	revisions, err := u.ListRevisions(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := u.transferRepo.FindByItemID(ReadOnly(ctx), itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transfers: %w", err)
+	}
+
+	changes := []*ItemChange{}
+	for _, revision := range revisions {
+		if len(revision.Changes) == 0 {
+			continue
+		}
+		// Items start at version 1; the first revision of an item created before revisions were recorded is an update
+		changeType := ChangeUpdated
+		if revision.Revision == 1 {
+			changeType = ChangeCreated
+		}
+		changes = append(changes, &ItemChange{
+			Type:      changeType,
+			Revision:  revision.Revision,
+			ChangedBy: revision.ChangedBy,
+			ChangedAt: revision.CreatedAt,
+			Changes:   revision.Changes,
+		})
+	}
+	for _, transfer := range transfers {
+		if transfer.Status != entity.TransferStatusAccepted || transfer.ResolvedAt == nil {
+			continue
+		}
+		changes = append(changes, &ItemChange{
+			Type:       ChangeTransferred,
+			TransferID: transfer.ID,
+			ChangedBy:  transfer.ResolvedBy,
+			ChangedAt:  *transfer.ResolvedAt,
+			Changes:    []entity.FieldChange{{Field: "owner_id", From: transfer.FromUser, To: transfer.ToUser}},
+		})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ChangedAt.Before(changes[j].ChangedAt)
+	})
+	return changes, nil
+}
+
+// RollbackItem updates the item from the version read here, so a concurrent update makes it fail with ErrConflict
+func (u *revisionUsecase) RollbackItem(ctx context.Context, actor, tenantID string, itemID, revision int64) (*entity.Item, error) {
+	if revision <= 0 {
+		return nil, fmt.Errorf("%w: revision must be a positive integer", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.itemUsecase.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := u.revisionRepo.FindByRevision(ReadOnly(ctx), itemID, revision)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve revision: %w", err)
+	}
+
+	snapshot := target.Snapshot
+	// The currency of an item does not change, so the snapshot's price is in the item's currency
+	price := entity.MinorUnits(int64(snapshot.PurchasePrice))
+	req := &UpdateItemRequest{
+		TenantID:      tenantID,
+		UserID:        actor,
+		Name:          &snapshot.Name,
+		Brand:         &snapshot.Brand,
+		PurchasePrice: &price,
+		Version:       &item.Version,
+	}
+	// Attributes added after the revision are removed
+	attributes := make(map[string]*string)
+	for key := range item.Attributes {
+		if _, ok := snapshot.Attributes[key]; !ok {
+			attributes[key] = nil
+		}
+	}
+	for key := range snapshot.Attributes {
+		value := snapshot.Attributes[key]
+		attributes[key] = &value
+	}
+	if len(attributes) > 0 {
+		req.Attributes = attributes
+	}
+
+	return u.itemUsecase.PatchItem(ctx, itemID, req)
+}
+
+// snapshotChanges lists the fields that differ between two snapshots, attributes last in key order;
+// previous is nil for the first revision, whose changes are the values the item was created with
+func snapshotChanges(previous *entity.ItemSnapshot, current entity.ItemSnapshot) []entity.FieldChange {
+	var before entity.ItemSnapshot
+	if previous != nil {
+		before = *previous
+	}
+
+	changes := []entity.FieldChange{}
+	fields := []entity.FieldChange{
+		{Field: "name", From: before.Name, To: current.Name},
+		{Field: "category", From: before.Category, To: current.Category},
+		{Field: "brand", From: before.Brand, To: current.Brand},
+		{Field: "purchase_price", From: before.PurchasePrice, To: current.PurchasePrice},
+		{Field: "purchase_date", From: before.PurchaseDate, To: current.PurchaseDate},
+	}
+	for _, field := range fields {
+		if previous == nil {
+			field.From = nil
+		} else if field.From == field.To {
+			continue
+		}
+		changes = append(changes, field)
+	}
+
+	keys := make([]string, 0, len(before.Attributes)+len(current.Attributes))
+	for key := range before.Attributes {
+		keys = append(keys, key)
+	}
+	for key := range current.Attributes {
+		if _, ok := before.Attributes[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		from, hadValue := before.Attributes[key]
+		to, hasValue := current.Attributes[key]
+		if hadValue == hasValue && from == to {
+			continue
+		}
+		change := entity.FieldChange{Field: "attributes." + key}
+		if hadValue {
+			change.From = from
+		}
+		if hasValue {
+			change.To = to
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+type revisionItemUsecase struct {
+	ItemUsecase
+	revisionRepo ItemRevisionRepository
+	uow          UnitOfWork
+	now          func() time.Time
+}
+
+// NewRevisionItemUsecase records a revision of every item created or patched through inner,
+// in the same transaction as the change; the owner of a new item and the user making an update are recorded as the author
+func NewRevisionItemUsecase(inner ItemUsecase, revisionRepo ItemRevisionRepository, uow UnitOfWork) ItemUsecase {
+	return &revisionItemUsecase{
+		ItemUsecase:  inner,
+		revisionRepo: revisionRepo,
+		uow:          uow,
+		now:          time.Now,
+	}
+}
+
+func (u *revisionItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	return u.record(ctx, input.OwnerID, func(ctx context.Context) (*entity.Item, error) {
+		return u.ItemUsecase.CreateItem(ctx, input)
+	})
+}
+
+func (u *revisionItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	return u.record(ctx, req.UserID, func(ctx context.Context) (*entity.Item, error) {
+		return u.ItemUsecase.PatchItem(ctx, id, req)
+	})
+}
+
+// record runs change and records the item it returns as a revision by actor in one transaction
+func (u *revisionItemUsecase) record(ctx context.Context, actor string, change func(ctx context.Context) (*entity.Item, error)) (*entity.Item, error) {
+	var changed *entity.Item
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := change(ctx)
+		if err != nil {
+			return err
+		}
+		changed = item
+
+		revision := &entity.ItemRevision{
+			ItemID:    item.ID,
+			Revision:  item.Version,
+			Snapshot:  entity.NewItemSnapshot(item),
+			ChangedBy: actor,
+			CreatedAt: u.now(),
+		}
+		if err := u.revisionRepo.Create(ctx, revision); err != nil {
+			return fmt.Errorf("failed to record item revision: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
@@ -0,0 +1,418 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeItemRevisionRepository はリビジョンをメモリに記録する
+type fakeItemRevisionRepository struct {
+	revisions []*entity.ItemRevision
+	createErr error
+}
+
+func (r *fakeItemRevisionRepository) Create(ctx context.Context, revision *entity.ItemRevision) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.revisions = append(r.revisions, revision)
+	return nil
+}
+
+func (r *fakeItemRevisionRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemRevision, error) {
+	var found []*entity.ItemRevision
+	for _, revision := range r.revisions {
+		if revision.ItemID == itemID {
+			copied := *revision
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeItemRevisionRepository) FindByRevision(ctx context.Context, itemID, revision int64) (*entity.ItemRevision, error) {
+	for _, found := range r.revisions {
+		if found.ItemID == itemID && found.Revision == revision {
+			return found, nil
+		}
+	}
+	return nil, domainErrors.ErrItemRevisionNotFound
+}
+
+func (r *fakeItemRevisionRepository) CountCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	count := 0
+	for _, revision := range r.revisions {
+		if revision.CreatedAt.Before(before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeItemRevisionRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int, error) {
+	var kept []*entity.ItemRevision
+	for _, revision := range r.revisions {
+		if !revision.CreatedAt.Before(before) {
+			kept = append(kept, revision)
+		}
+	}
+	deleted := len(r.revisions) - len(kept)
+	r.revisions = kept
+	return deleted, nil
+}
+
+func (r *fakeItemRevisionRepository) FindByChangedBy(ctx context.Context, userID string) ([]*entity.ItemRevision, error) {
+	var found []*entity.ItemRevision
+	for _, revision := range r.revisions {
+		if revision.ChangedBy == userID {
+			copied := *revision
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeItemRevisionRepository) DeleteByItemID(ctx context.Context, itemID int64) error {
+	var kept []*entity.ItemRevision
+	for _, revision := range r.revisions {
+		if revision.ItemID != itemID {
+			kept = append(kept, revision)
+		}
+	}
+	r.revisions = kept
+	return nil
+}
+
+func (r *fakeItemRevisionRepository) ReplaceChangedBy(ctx context.Context, userID, pseudonym string) error {
+	for _, revision := range r.revisions {
+		if revision.ChangedBy == userID {
+			revision.ChangedBy = pseudonym
+		}
+	}
+	return nil
+}
+
+func TestSnapshotChanges(t *testing.T) {
+	created := entity.ItemSnapshot{
+		Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+		Attributes: map[string]string{"serial": "A123", "box": "あり"},
+	}
+
+	tests := []struct {
+		name     string
+		previous *entity.ItemSnapshot
+		current  entity.ItemSnapshot
+		expected []entity.FieldChange
+	}{
+		{
+			name:    "正常系: 最初のリビジョンは登録時の値",
+			current: created,
+			expected: []entity.FieldChange{
+				{Field: "name", From: nil, To: "ロレックス"},
+				{Field: "category", From: nil, To: "時計"},
+				{Field: "brand", From: nil, To: "ROLEX"},
+				{Field: "purchase_price", From: nil, To: 1500000},
+				{Field: "purchase_date", From: nil, To: "2023-01-15"},
+				{Field: "attributes.box", From: nil, To: "あり"},
+				{Field: "attributes.serial", From: nil, To: "A123"},
+			},
+		},
+		{
+			name:     "正常系: 変わったフィールドと属性だけ",
+			previous: &created,
+			current: entity.ItemSnapshot{
+				Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1400000, PurchaseDate: "2023-01-15",
+				Attributes: map[string]string{"serial": "A124", "warranty_expires": "2026-01-15"},
+			},
+			expected: []entity.FieldChange{
+				{Field: "name", From: "ロレックス", To: "デイトナ"},
+				{Field: "purchase_price", From: 1500000, To: 1400000},
+				{Field: "attributes.box", From: "あり", To: nil},
+				{Field: "attributes.serial", From: "A123", To: "A124"},
+				{Field: "attributes.warranty_expires", From: nil, To: "2026-01-15"},
+			},
+		},
+		{
+			name:     "正常系: 変更なし",
+			previous: &created,
+			current:  created,
+			expected: []entity.FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, snapshotChanges(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestRevisionItemUsecase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	newUsecase := func(itemRepo ItemRepository, revisionRepo ItemRevisionRepository, uow UnitOfWork) ItemUsecase {
+		u := NewRevisionItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), revisionRepo, uow).(*revisionItemUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+
+	t.Run("正常系: 登録したアイテムを最初のリビジョンとして記録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{
+			ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Version: 1,
+		}, nil)
+		revisionRepo := &fakeItemRevisionRepository{}
+
+		_, err := newUsecase(itemRepo, revisionRepo, nil).CreateItem(ctx, CreateItemInput{
+			OwnerID: "alice", Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1500000), PurchaseDate: "2023-01-15",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemRevision{{
+			ItemID:   1,
+			Revision: 1,
+			Snapshot: entity.ItemSnapshot{
+				Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			},
+			ChangedBy: "alice",
+			CreatedAt: now,
+		}}, revisionRepo.revisions)
+	})
+
+	t.Run("正常系: 更新後のバージョンで記録する", func(t *testing.T) {
+		item := &entity.Item{ID: 1, Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Version: 3}
+		updated := *item
+		updated.Name = "デイトナ"
+		updated.Version = 4
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(&updated, nil)
+		revisionRepo := &fakeItemRevisionRepository{}
+		name := "デイトナ"
+		version := int64(3)
+
+		_, err := newUsecase(itemRepo, revisionRepo, nil).PatchItem(ctx, 1, &UpdateItemRequest{UserID: "bob", Name: &name, Version: &version})
+
+		require.NoError(t, err)
+		require.Len(t, revisionRepo.revisions, 1)
+		assert.Equal(t, int64(4), revisionRepo.revisions[0].Revision)
+		assert.Equal(t, "bob", revisionRepo.revisions[0].ChangedBy)
+		assert.Equal(t, "デイトナ", revisionRepo.revisions[0].Snapshot.Name)
+	})
+
+	t.Run("異常系: 更新に失敗したら記録しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrNotFound)
+		revisionRepo := &fakeItemRevisionRepository{}
+		name := "デイトナ"
+		version := int64(3)
+
+		_, err := newUsecase(itemRepo, revisionRepo, nil).PatchItem(ctx, 1, &UpdateItemRequest{Name: &name, Version: &version})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, revisionRepo.revisions)
+	})
+
+	t.Run("異常系: 記録に失敗したら変更ごとロールバックさせる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Version: 1}, nil)
+		uow := &fakeUnitOfWork{}
+
+		_, err := newUsecase(itemRepo, &fakeItemRevisionRepository{createErr: domainErrors.ErrDatabaseError}, uow).CreateItem(ctx, CreateItemInput{
+			Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.MinorUnits(1500000), PurchaseDate: "2023-01-15",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, uow.calls)
+		assert.ErrorIs(t, uow.result, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestRevisionUsecase_ListRevisions(t *testing.T) {
+	ctx := context.Background()
+	first := entity.ItemSnapshot{Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+	second := first
+	second.PurchasePrice = 1400000
+
+	t.Run("正常系: 1つ前のリビジョンからの変更を付けて返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 2}, nil)
+		revisionRepo := &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+			{ItemID: 1, Revision: 1, Snapshot: first},
+			{ItemID: 2, Revision: 1, Snapshot: first},
+			{ItemID: 1, Revision: 2, Snapshot: second},
+		}}
+
+		revisions, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), revisionRepo, nil).ListRevisions(ctx, 1)
+
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Len(t, revisions[0].Changes, 5)
+		assert.Equal(t, []entity.FieldChange{{Field: "purchase_price", From: 1500000, To: 1400000}}, revisions[1].Changes)
+	})
+
+	t.Run("正常系: 履歴のないアイテムは空の配列", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 1}, nil)
+
+		revisions, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), &fakeItemRevisionRepository{}, nil).ListRevisions(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemRevision{}, revisions)
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrNotFound)
+
+		_, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), &fakeItemRevisionRepository{}, nil).ListRevisions(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestRevisionUsecase_ListChanges(t *testing.T) {
+	ctx := context.Background()
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 9, 0, 0, 0, time.UTC) }
+	first := entity.ItemSnapshot{Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+	second := first
+	second.PurchasePrice = 1400000
+
+	t.Run("正常系: リビジョンと承諾済みの譲渡を日時順に返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 4}, nil)
+		revisionRepo := &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+			{ItemID: 1, Revision: 1, Snapshot: first, ChangedBy: "alice", CreatedAt: day(1)},
+			{ItemID: 1, Revision: 2, Snapshot: second, ChangedBy: "alice", CreatedAt: day(3)},
+			// 同じ内容への巻き戻しは変更がないため含めない
+			{ItemID: 1, Revision: 4, Snapshot: second, ChangedBy: "bob", CreatedAt: day(5)},
+		}}
+		rejectedAt, acceptedAt := day(2), day(4)
+		transferRepo := new(MockTransferRepository)
+		transferRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.Transfer{
+			{ID: 7, ItemID: 1, FromUser: "alice", ToUser: "bob", Status: entity.TransferStatusRejected, ResolvedBy: "bob", ResolvedAt: &rejectedAt},
+			{ID: 8, ItemID: 1, FromUser: "alice", ToUser: "bob", Status: entity.TransferStatusAccepted, ResolvedBy: "bob", ResolvedAt: &acceptedAt},
+			{ID: 9, ItemID: 1, FromUser: "bob", ToUser: "carol", Status: entity.TransferStatusPending},
+		}, nil)
+
+		changes, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), revisionRepo, transferRepo).ListChanges(ctx, 1)
+
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+		assert.Equal(t, ChangeCreated, changes[0].Type)
+		assert.Equal(t, "alice", changes[0].ChangedBy)
+		assert.Len(t, changes[0].Changes, 5)
+		assert.Equal(t, &ItemChange{
+			Type:      ChangeUpdated,
+			Revision:  2,
+			ChangedBy: "alice",
+			ChangedAt: day(3),
+			Changes:   []entity.FieldChange{{Field: "purchase_price", From: 1500000, To: 1400000}},
+		}, changes[1])
+		assert.Equal(t, &ItemChange{
+			Type:       ChangeTransferred,
+			TransferID: 8,
+			ChangedBy:  "bob",
+			ChangedAt:  day(4),
+			Changes:    []entity.FieldChange{{Field: "owner_id", From: "alice", To: "bob"}},
+		}, changes[2])
+	})
+
+	t.Run("正常系: 記録の前に登録したアイテムの最初のリビジョンは更新", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 3}, nil)
+		revisionRepo := &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{
+			{ItemID: 1, Revision: 3, Snapshot: second, ChangedBy: "alice", CreatedAt: day(3)},
+		}}
+		transferRepo := new(MockTransferRepository)
+		transferRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.Transfer(nil), nil)
+
+		changes, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), revisionRepo, transferRepo).ListChanges(ctx, 1)
+
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeUpdated, changes[0].Type)
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrNotFound)
+		transferRepo := new(MockTransferRepository)
+
+		_, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), &fakeItemRevisionRepository{}, transferRepo).ListChanges(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		transferRepo.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRevisionUsecase_RollbackItem(t *testing.T) {
+	ctx := context.Background()
+	revisionRepo := &fakeItemRevisionRepository{revisions: []*entity.ItemRevision{{
+		ItemID:   1,
+		Revision: 2,
+		Snapshot: entity.ItemSnapshot{
+			Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			Attributes: map[string]string{"warranty_expires": "2026-01-15"},
+		},
+	}}}
+	attrRepo := new(MockCustomAttributeRepository)
+	attrRepo.On("FindAll", mock.Anything, "acme").Return([]*entity.CustomAttribute{
+		{Key: "warranty_expires", Type: entity.AttributeTypeDate},
+		{Key: "box", Type: entity.AttributeTypeEnum, Options: []string{"あり", "なし"}},
+	}, nil)
+
+	t.Run("正常系: リビジョンの内容に戻し、その後に増えた属性は除く", func(t *testing.T) {
+		current := &entity.Item{
+			ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1400000, PurchaseDate: "2023-01-15",
+			Attributes: map[string]string{"warranty_expires": "2027-01-15", "box": "あり"}, Version: 5,
+		}
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(current, nil)
+
+		item, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, attrRepo, nil, nil), revisionRepo, nil).RollbackItem(ctx, "alice", "acme", 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス", item.Name)
+		assert.Equal(t, 1500000, item.PurchasePrice)
+		assert.Equal(t, map[string]string{"warranty_expires": "2026-01-15"}, item.Attributes)
+		// リビジョンの内容は変更しない
+		assert.Equal(t, map[string]string{"warranty_expires": "2026-01-15"}, revisionRepo.revisions[0].Snapshot.Attributes)
+	})
+
+	t.Run("異常系: 読み込んだ後に更新された", func(t *testing.T) {
+		current := &entity.Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1400000, PurchaseDate: "2023-01-15", Version: 5}
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrConflict)
+
+		_, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, attrRepo, nil, nil), revisionRepo, nil).RollbackItem(ctx, "alice", "acme", 1, 2)
+
+		assert.ErrorIs(t, err, domainErrors.ErrConflict)
+	})
+
+	t.Run("異常系: 記録されていないリビジョン", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 5}, nil)
+
+		_, err := NewRevisionUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), revisionRepo, nil).RollbackItem(ctx, "alice", "", 1, 3)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemRevisionNotFound)
+		itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正なリビジョン", func(t *testing.T) {
+		_, err := NewRevisionUsecase(NewItemUsecase(new(MockItemRepository), nil, nil, nil, nil), revisionRepo, nil).RollbackItem(ctx, "alice", "", 1, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// DefaultSearchMinScore is the relevance an item needs to be found when none is configured
+	DefaultSearchMinScore = 0.7
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	maxSearchTerms        = 10
+)
+
+type SearchUsecase interface {
+	// Search finds the items whose name, brand or category match the words of the query, tolerating typos,
+	// the most relevant first
+	Search(ctx context.Context, query SearchQuery) (*SearchResult, error)
+}
+
+// SearchQuery is a full-text search over the items
+type SearchQuery struct {
+	// TenantID is the tenant searching; the categories it added are valid for Category
+	TenantID string
+	// Text is the words to look for, separated by spaces; every word counts towards the relevance
+	Text string
+	// Category restricts the search to one category (all if empty)
+	Category string
+	// Page is 1-based (1 if 0); PageSize is the number of items per page (20 if 0)
+	Page     int
+	PageSize int
+}
+
+// SearchResult is a page of the items found, the most relevant first
+type SearchResult struct {
+	Items []SearchHit `json:"items"`
+	// Total is the number of items found, across all pages
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// SearchHit is an item found by a search with its relevance
+type SearchHit struct {
+	*entity.Item
+	// Score is the relevance from 0 to 1; 1 means every word appears as typed. Items found by scanning the repository
+	// score at least the minimum score; with a search index, the index decides which items match.
+	Score float64 `json:"score"`
+}
+
+type searchUsecase struct {
+	itemRepo     ItemReader
+	categoryRepo CategoryRepository
+	minScore     float64
+}
+
+// NewSearchUsecase creates the search usecase. minScore (0 to 1) is how strict the matching is:
+// items scoring less are not found, so 1 finds only items containing every word as typed and lower values
+// tolerate more typos ("Rolx" scores 0.8 against ROLEX).
+// categoryRepo may be nil, in which case only the built-in categories are valid for the category filter.
+func NewSearchUsecase(itemRepo ItemReader, categoryRepo CategoryRepository, minScore float64) SearchUsecase {
+	return &searchUsecase{itemRepo: itemRepo, categoryRepo: categoryRepo, minScore: minScore}
+}
+
+func (u *searchUsecase) Search(ctx context.Context, query SearchQuery) (*SearchResult, error) {
+	terms, query, err := validateSearchQuery(ctx, u.categoryRepo, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []SearchHit
+	err = u.itemRepo.Iterate(ReadOnly(ctx), ItemQuery{ItemFilter: ItemFilter{Category: query.Category}}, func(item *entity.Item) error {
+		if score := searchScore(terms, item); score >= u.minScore {
+			hits = append(hits, SearchHit{Item: item, Score: math.Round(score*100) / 100})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	result := &SearchResult{Items: []SearchHit{}, Total: len(hits), Page: query.Page, PageSize: query.PageSize}
+	if offset := (query.Page - 1) * query.PageSize; offset < len(hits) {
+		result.Items = hits[offset:min(offset+query.PageSize, len(hits))]
+	}
+	return result, nil
+}
+
+// validateSearchQuery checks the query and sets the page defaults; it returns the normalized words to look for
+func validateSearchQuery(ctx context.Context, categoryRepo CategoryRepository, query SearchQuery) ([]string, SearchQuery, error) {
+	terms, err := searchTerms(query.Text)
+	if err != nil {
+		return nil, query, err
+	}
+	if err := checkCategory(ctx, categoryRepo, query.TenantID, query.Category); err != nil {
+		return nil, query, err
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultSearchPageSize
+	}
+	if query.Page < 1 {
+		return nil, query, fmt.Errorf("%w: page must be at least 1", domainErrors.ErrInvalidInput)
+	}
+	if query.PageSize < 1 || query.PageSize > maxSearchPageSize {
+		return nil, query, fmt.Errorf("%w: page_size must be between 1 and %d", domainErrors.ErrInvalidInput, maxSearchPageSize)
+	}
+	return terms, query, nil
+}
+
+// searchTerms splits the query into normalized words
+func searchTerms(text string) ([]string, error) {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		if term := normalizeForMatch(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: q is required", domainErrors.ErrInvalidInput)
+	}
+	if len(terms) > maxSearchTerms {
+		return nil, fmt.Errorf("%w: q must have at most %d words", domainErrors.ErrInvalidInput, maxSearchTerms)
+	}
+	return terms, nil
+}
+
+// searchScore averages how well each term matches the item's words: a term contained in a word scores 1,
+// otherwise the similarity to the closest word
+func searchScore(terms []string, item *entity.Item) float64 {
+	var words []string
+	for _, field := range []string{item.Name, item.Brand, item.Category} {
+		for _, word := range strings.Fields(field) {
+			if w := normalizeForMatch(word); w != "" {
+				words = append(words, w)
+			}
+		}
+	}
+
+	var total float64
+	for _, term := range terms {
+		best := 0.0
+		for _, word := range words {
+			if strings.Contains(word, term) {
+				best = 1
+				break
+			}
+			best = max(best, similarity(term, word))
+		}
+		total += best
+	}
+	return total / float64(len(terms))
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// reindexBatchSize is the number of items sent to the search index at once by a reindex
+const reindexBatchSize = 500
+
+// SearchIndex is an external full-text index mirroring the items, such as Elasticsearch,
+// that answers searches without reading every item from the repository
+type SearchIndex interface {
+	// Search returns a page of the indexed items matching the query, the most relevant first,
+	// and the number of matching items across all pages. The query has been validated and has its page defaults set.
+	Search(ctx context.Context, query SearchQuery) ([]*entity.Item, int, error)
+
+	// Index adds items to the index or replaces them
+	Index(ctx context.Context, items []*entity.Item) error
+
+	// Delete removes an item from the index; removing an item that is not indexed is not an error
+	Delete(ctx context.Context, id int64) error
+
+	// DeleteIndexedBefore removes the items last indexed before t and returns how many were removed
+	DeleteIndexedBefore(ctx context.Context, t time.Time) (int, error)
+}
+
+type IndexedSearchUsecase interface {
+	SearchUsecase
+
+	// Reindex indexes every item in the repository and then removes the indexed items it did not find,
+	// repairing changes the index missed. Searches keep using the index meanwhile.
+	Reindex(ctx context.Context) (*ReindexReport, error)
+}
+
+// ReindexReport is the outcome of a reindex
+type ReindexReport struct {
+	// Indexed is the number of items indexed, Removed the number of indexed items that no longer exist
+	Indexed  int    `json:"indexed"`
+	Removed  int    `json:"removed"`
+	Duration string `json:"duration"`
+}
+
+type indexedSearchUsecase struct {
+	index        SearchIndex
+	itemRepo     ItemReader
+	categoryRepo CategoryRepository
+	fallback     SearchUsecase
+}
+
+// NewIndexedSearchUsecase creates a search usecase answered by the search index. When the index fails
+// (unreachable, missing, timed out) the search is answered by fallback, which scans the repository.
+// categoryRepo may be nil, in which case only the built-in categories are valid for the category filter.
+func NewIndexedSearchUsecase(index SearchIndex, itemRepo ItemReader, categoryRepo CategoryRepository, fallback SearchUsecase) IndexedSearchUsecase {
+	return &indexedSearchUsecase{index: index, itemRepo: itemRepo, categoryRepo: categoryRepo, fallback: fallback}
+}
+
+func (u *indexedSearchUsecase) Search(ctx context.Context, query SearchQuery) (*SearchResult, error) {
+	terms, query, err := validateSearchQuery(ctx, u.categoryRepo, query)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := u.index.Search(ctx, query)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to search items: %w", err)
+		}
+		log.Printf("⚠️  search index unavailable, searching the repository instead: %v", err)
+		return u.fallback.Search(ctx, query)
+	}
+
+	result := &SearchResult{Items: make([]SearchHit, 0, len(items)), Total: total, Page: query.Page, PageSize: query.PageSize}
+	for _, item := range items {
+		result.Items = append(result.Items, SearchHit{Item: item, Score: math.Round(searchScore(terms, item)*100) / 100})
+	}
+	return result, nil
+}
+
+func (u *indexedSearchUsecase) Reindex(ctx context.Context) (*ReindexReport, error) {
+	// Items indexed from now on, by this reindex or by changes made meanwhile, are kept
+	started := time.Now()
+	report := &ReindexReport{}
+
+	batch := make([]*entity.Item, 0, reindexBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("reindex stopped: %w", err)
+		}
+		if err := u.index.Index(ctx, batch); err != nil {
+			return fmt.Errorf("failed to index items: %w", err)
+		}
+		report.Indexed += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	it, err := u.itemRepo.FindAllStream(ReadOnly(ctx), ItemQuery{})
+	if err == nil {
+		err = eachItem(it, func(item *entity.Item) error {
+			batch = append(batch, item)
+			if len(batch) == reindexBatchSize {
+				return flush()
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		report.Duration = time.Since(started).Round(time.Millisecond).String()
+		return report, err
+	}
+
+	removed, err := u.index.DeleteIndexedBefore(ctx, started)
+	report.Removed = removed
+	report.Duration = time.Since(started).Round(time.Millisecond).String()
+	if err != nil {
+		return report, fmt.Errorf("failed to remove deleted items from the index: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type fakeSearchIndex struct {
+	items     []*entity.Item
+	total     int
+	searchErr error
+	indexErr  error
+	removed   int
+
+	queries       []SearchQuery
+	indexed       [][]int64
+	indexedBefore time.Time
+}
+
+func (f *fakeSearchIndex) Search(ctx context.Context, query SearchQuery) ([]*entity.Item, int, error) {
+	f.queries = append(f.queries, query)
+	return f.items, f.total, f.searchErr
+}
+
+func (f *fakeSearchIndex) Index(ctx context.Context, items []*entity.Item) error {
+	var ids []int64
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	f.indexed = append(f.indexed, ids)
+	return f.indexErr
+}
+
+func (f *fakeSearchIndex) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (f *fakeSearchIndex) DeleteIndexedBefore(ctx context.Context, t time.Time) (int, error) {
+	f.indexedBefore = t
+	return f.removed, nil
+}
+
+func TestIndexedSearchUsecase_Search(t *testing.T) {
+	t.Run("正常系: 検索インデックスの結果を返す", func(t *testing.T) {
+		index := &fakeSearchIndex{
+			items: []*entity.Item{{ID: 3, Name: "デイトナ", Category: "時計", Brand: "ROLEX"}, {ID: 1, Name: "サブマリーナ", Category: "時計", Brand: "Rolx"}},
+			total: 12,
+		}
+		repo := new(MockItemRepository)
+
+		result, err := NewIndexedSearchUsecase(index, repo, nil, NewSearchUsecase(repo, nil, DefaultSearchMinScore)).Search(context.Background(), SearchQuery{Text: "rolex", Page: 2, PageSize: 2})
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, int64(3), result.Items[0].ID)
+		assert.Equal(t, 1.0, result.Items[0].Score)
+		assert.Equal(t, 0.8, result.Items[1].Score)
+		assert.Equal(t, 12, result.Total)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, []SearchQuery{{Text: "rolex", Page: 2, PageSize: 2}}, index.queries)
+		repo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 検索インデックスが使えなければリポジトリから探す", func(t *testing.T) {
+		index := &fakeSearchIndex{searchErr: errors.New("connection refused")}
+		repo := new(MockItemRepository)
+		repo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX"}}, nil))
+
+		result, err := NewIndexedSearchUsecase(index, repo, nil, NewSearchUsecase(repo, nil, DefaultSearchMinScore)).Search(context.Background(), SearchQuery{Text: "rolex"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, int64(1), result.Items[0].ID)
+		assert.Equal(t, 20, result.PageSize)
+	})
+
+	t.Run("異常系: 不正な検索条件は検索インデックスに問い合わせない", func(t *testing.T) {
+		index := &fakeSearchIndex{}
+		repo := new(MockItemRepository)
+
+		_, err := NewIndexedSearchUsecase(index, repo, nil, NewSearchUsecase(repo, nil, DefaultSearchMinScore)).Search(context.Background(), SearchQuery{Text: "rolex", PageSize: 101})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, index.queries)
+	})
+}
+
+func TestIndexedSearchUsecase_Reindex(t *testing.T) {
+	items := make([]*entity.Item, reindexBatchSize+1)
+	for i := range items {
+		items[i] = &entity.Item{ID: int64(i + 1)}
+	}
+
+	t.Run("正常系: すべてのアイテムをまとめて登録し、見つからなかったアイテムを削除する", func(t *testing.T) {
+		index := &fakeSearchIndex{removed: 2}
+		repo := new(MockItemRepository)
+		repo.On("FindAllStream", mock.MatchedBy(IsReadOnly), ItemQuery{}).Return(NewSliceItemIterator(items), nil)
+		started := time.Now()
+
+		report, err := NewIndexedSearchUsecase(index, repo, nil, NewSearchUsecase(repo, nil, DefaultSearchMinScore)).Reindex(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, len(items), report.Indexed)
+		assert.Equal(t, 2, report.Removed)
+		require.Len(t, index.indexed, 2)
+		assert.Len(t, index.indexed[0], reindexBatchSize)
+		assert.Equal(t, []int64{int64(len(items))}, index.indexed[1])
+		assert.False(t, index.indexedBefore.Before(started))
+	})
+
+	t.Run("異常系: 登録に失敗したら削除しない", func(t *testing.T) {
+		index := &fakeSearchIndex{indexErr: errors.New("cluster_block_exception")}
+		repo := new(MockItemRepository)
+		repo.On("FindAllStream", mock.MatchedBy(IsReadOnly), ItemQuery{}).Return(NewSliceItemIterator(items), nil)
+
+		report, err := NewIndexedSearchUsecase(index, repo, nil, NewSearchUsecase(repo, nil, DefaultSearchMinScore)).Reindex(context.Background())
+
+		assert.ErrorContains(t, err, "cluster_block_exception")
+		assert.Equal(t, 0, report.Indexed)
+		assert.True(t, index.indexedBefore.IsZero())
+	})
+}
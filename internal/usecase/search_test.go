@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestSearchUsecase_Search(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX"},
+		{ID: 2, Name: "スピードマスター", Category: "時計", Brand: "OMEGA"},
+		{ID: 3, Name: "サブマリーナ Date", Category: "時計", Brand: "Rolex"},
+		{ID: 4, Name: "バーキン", Category: "バッグ", Brand: "HERMES"},
+		{ID: 5, Name: "ウォッチ", Category: "その他", Brand: "ROLEK"},
+	}
+
+	tests := []struct {
+		name           string
+		query          SearchQuery
+		minScore       float64
+		expectedIDs    []int64
+		expectedScores []float64
+		expectedTotal  int
+	}{
+		{
+			name:           "正常系: 綴りの誤りを許して探す",
+			query:          SearchQuery{Text: "Rolx"},
+			minScore:       DefaultSearchMinScore,
+			expectedIDs:    []int64{1, 3},
+			expectedScores: []float64{0.8, 0.8},
+			expectedTotal:  2,
+		},
+		{
+			name:           "正常系: スコアの高い順",
+			query:          SearchQuery{Text: "rolex"},
+			minScore:       DefaultSearchMinScore,
+			expectedIDs:    []int64{1, 3, 5},
+			expectedScores: []float64{1, 1, 0.8},
+			expectedTotal:  3,
+		},
+		{
+			name:           "正常系: すべての語がスコアに数える",
+			query:          SearchQuery{Text: "rolex date"},
+			minScore:       DefaultSearchMinScore,
+			expectedIDs:    []int64{3},
+			expectedScores: []float64{1},
+			expectedTotal:  1,
+		},
+		{
+			name:           "正常系: 全角や語の一部でも探す",
+			query:          SearchQuery{Text: "ｏｍｅ"},
+			minScore:       DefaultSearchMinScore,
+			expectedIDs:    []int64{2},
+			expectedScores: []float64{1},
+			expectedTotal:  1,
+		},
+		{
+			name:          "正常系: 最低スコアが1なら綴りの誤りを許さない",
+			query:         SearchQuery{Text: "Rolx"},
+			minScore:      1,
+			expectedIDs:   []int64{},
+			expectedTotal: 0,
+		},
+		{
+			name:           "正常系: ページング",
+			query:          SearchQuery{Text: "rolex", Page: 2, PageSize: 1},
+			minScore:       DefaultSearchMinScore,
+			expectedIDs:    []int64{3},
+			expectedScores: []float64{1},
+			expectedTotal:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockItemRepository)
+			repo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+			result, err := NewSearchUsecase(repo, nil, tt.minScore).Search(context.Background(), tt.query)
+
+			require.NoError(t, err)
+			ids := []int64{}
+			var scores []float64
+			for _, hit := range result.Items {
+				ids = append(ids, hit.ID)
+				scores = append(scores, hit.Score)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedScores, scores)
+			assert.Equal(t, tt.expectedTotal, result.Total)
+		})
+	}
+
+	t.Run("正常系: カテゴリーで絞り込む", func(t *testing.T) {
+		repo := new(MockItemRepository)
+		repo.On("Iterate", mock.Anything, ItemQuery{ItemFilter: ItemFilter{Category: "バッグ"}}, mock.Anything).Return(iterateItems(items[3:], nil))
+
+		result, err := NewSearchUsecase(repo, nil, DefaultSearchMinScore).Search(context.Background(), SearchQuery{Text: "hermes", Category: "バッグ"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, int64(4), result.Items[0].ID)
+	})
+
+	for _, query := range []SearchQuery{
+		{Text: "  "},
+		{Text: "rolex", Category: "家具"},
+		{Text: "rolex", PageSize: 101},
+		{Text: "rolex", Page: -1},
+	} {
+		t.Run("異常系: 不正な検索条件 "+query.Text+query.Category, func(t *testing.T) {
+			_, err := NewSearchUsecase(new(MockItemRepository), nil, DefaultSearchMinScore).Search(context.Background(), query)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		})
+	}
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"maps"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,44 +21,142 @@ const (
 
 type ItemUsecase interface {
 	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
-	DeleteItem(ctx context.Context, id int64) error
+	// DeleteItem moves an item to the trash; if ifMatch is non-nil the item must still be at that version
+	DeleteItem(ctx context.Context, id int64, ifMatch *int64) error
+	// PurgeItem permanently deletes an item in the trash
+	PurgeItem(ctx context.Context, id int64) error
+	// RestoreItem takes an item out of the trash if it is still there from the deletion at deletedAt
+	RestoreItem(ctx context.Context, id int64, deletedAt time.Time) error
 	PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error)
-	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	// GetCategorySummary returns the item counts and purchase price aggregates by category of the items purchased within purchased
+	GetCategorySummary(ctx context.Context, purchased DateRange) (*CategorySummary, error)
+	// GetValueSummary returns the purchase prices of the items totalled by category, in the given currency
+	GetValueSummary(ctx context.Context, currency string) (*ValueSummary, error)
 }
 
+// CreateItemInput is validated by its validate tags when bound from a request, and again by the entity
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	TenantID      string        `json:"-"`
+	OwnerID       string        `json:"-"`
+	Name          string        `json:"name" validate:"required,max=100"`
+	Category      string        `json:"category" validate:"required,category"`
+	Brand         string        `json:"brand" validate:"max=100"`
+	PurchasePrice entity.Amount `json:"purchase_price" validate:"amount"`
+	// Currency is the ISO 4217 code of the purchase price; entity.DefaultCurrency if empty
+	Currency     string            `json:"currency,omitempty"`
+	PurchaseDate string            `json:"purchase_date" validate:"required,date"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	// AllowDuplicate creates the item even if it closely matches an existing item
+	AllowDuplicate bool `json:"-"`
 }
 
+// UpdateItemRequest is validated by its validate tags when bound from a request; fields left out are not checked
 type UpdateItemRequest struct {
-	Name          *string `json:"name,omitempty"`
-	Brand         *string `json:"brand,omitempty"`
-	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	TenantID string `json:"-"`
+	// UserID is the user making the update; it is recorded in the item's change history
+	UserID string  `json:"-"`
+	Name   *string `json:"name,omitempty" validate:"omitnil,nonempty,max=100"`
+	Brand  *string `json:"brand,omitempty" validate:"omitnil,max=100"`
+	// PurchasePrice is in the item's currency, which cannot be changed
+	PurchasePrice *entity.Amount `json:"purchase_price,omitempty" validate:"omitnil,amount"`
+	// Attributes are merged into the item's attributes; a null value removes the attribute
+	Attributes map[string]*string `json:"attributes,omitempty"`
+	// Version is the item version the client last read; the update fails with ErrConflict if it has changed
+	Version *int64 `json:"version,omitempty"`
+	// IfMatch is the version from an If-Match precondition; a mismatch fails with ErrPreconditionFailed
+	IfMatch *int64 `json:"-"`
+}
+
+// ListItemsQuery holds per-request list parameters; zero values fall back to the tenant's settings
+type ListItemsQuery struct {
+	TenantID  string
+	Category  string
+	Brand     string
+	SortBy    string
+	SortOrder string
+	Page      int
+	PageSize  *int
+	// MinCurrentValue leaves out the items whose current value is lower or which were never valued (0 = no restriction)
+	MinCurrentValue int
+}
+
+type ItemList struct {
+	Items    []*entity.Item
+	Total    int
+	Page     int
+	PageSize int
 }
 
 type CategorySummary struct {
 	Categories map[string]int `json:"categories"`
 	Total      int            `json:"total"`
+	// Stats are the purchase price aggregates (min/max/avg/sum) of the items of each category bought in BaseCurrency;
+	// Count in them counts every item
+	Stats map[string]CategoryStats `json:"stats,omitempty"`
+	// Value is only set when the summary was requested in a currency
+	Value *ValueSummary `json:"value,omitempty"`
+	// CategoryNames maps each category to its display name in the language of the request; the keys of
+	// Categories and Stats stay the category codes
+	CategoryNames map[string]string `json:"category_names,omitempty"`
+}
+
+// ValueSummary holds purchase price totals converted to Currency at ExchangeRate (the worth of 1 BaseCurrency).
+// Native holds the totals of the items bought in each currency, before conversion.
+type ValueSummary struct {
+	Currency     string                  `json:"currency"`
+	Categories   map[string]float64      `json:"categories"`
+	Total        float64                 `json:"total"`
+	ExchangeRate float64                 `json:"exchange_rate"`
+	RateDate     string                  `json:"rate_date,omitempty"`
+	Native       map[string]*NativeValue `json:"native,omitempty"`
+}
+
+// NativeValue totals the purchase prices of the items bought in one currency, in that currency
+type NativeValue struct {
+	Categories map[string]float64 `json:"categories"`
+	Total      float64            `json:"total"`
+	// Converted is Total in the currency of the summary, at ExchangeRate (the worth of 1 unit of this currency);
+	// both are 0 if the totals were not converted
+	Converted    float64 `json:"converted"`
+	ExchangeRate float64 `json:"exchange_rate"`
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo     ItemRepository
+	settingsRepo SettingsRepository
+	attrRepo     CustomAttributeRepository
+	categoryRepo CategoryRepository
+	uow          UnitOfWork
+	rules        ValidationRules
+}
+
+// NewItemUsecase creates the item usecase.
+// settingsRepo, attrRepo and categoryRepo may be nil, in which case list defaults are used, no custom attributes
+// are defined and only the built-in categories are valid.
+// uow may be nil, in which case multi-step operations run without a transaction.
+// Items are checked against the built-in validation rules (the zero ValidationRules).
+func NewItemUsecase(itemRepo ItemRepository, settingsRepo SettingsRepository, attrRepo CustomAttributeRepository, categoryRepo CategoryRepository, uow UnitOfWork) ItemUsecase {
+	return NewItemUsecaseWithRules(itemRepo, settingsRepo, attrRepo, categoryRepo, uow, ValidationRules{})
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
+// NewItemUsecaseWithRules creates the item usecase with the validation rules of the deployment
+func NewItemUsecaseWithRules(itemRepo ItemRepository, settingsRepo SettingsRepository, attrRepo CustomAttributeRepository, categoryRepo CategoryRepository, uow UnitOfWork, rules ValidationRules) ItemUsecase {
 	return &itemUsecase{
-		itemRepo: itemRepo,
+		itemRepo:     itemRepo,
+		settingsRepo: settingsRepo,
+		attrRepo:     attrRepo,
+		categoryRepo: categoryRepo,
+		uow:          uow,
+		rules:        rules,
 	}
 }
 
 func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+	ctx = ReadOnly(ctx)
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
@@ -63,10 +164,81 @@ func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
 	return items, nil
 }
 
+func (u *itemUsecase) ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error) {
+	ctx = ReadOnly(ctx)
+	defaults, err := loadListSettings(ctx, u.settingsRepo, query.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// リクエストで指定された値はテナントの設定より優先する
+	settings := *defaults
+	if query.SortBy != "" {
+		settings.SortBy = query.SortBy
+	}
+	if query.SortOrder != "" {
+		settings.SortOrder = strings.ToLower(query.SortOrder)
+	}
+	if query.PageSize != nil {
+		settings.PageSize = *query.PageSize
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	page := query.Page
+	if page == 0 {
+		page = 1
+	}
+	if page < 0 {
+		return nil, fmt.Errorf("%w: page must be 1 or greater", domainErrors.ErrInvalidInput)
+	}
+	if query.MinCurrentValue < 0 {
+		return nil, fmt.Errorf("%w: min_current_value must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	if err := checkCategory(ctx, u.categoryRepo, query.TenantID, query.Category); err != nil {
+		return nil, err
+	}
+
+	// 絞り込み・並び替え・ページングはリポジトリ（SQL）で行う。時価も最新の評価をSQLで結合して求める
+	filter := ItemFilter{
+		Category:        query.Category,
+		Brand:           strings.TrimSpace(query.Brand),
+		MinCurrentValue: query.MinCurrentValue,
+	}
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{
+		ItemFilter: filter,
+		SortBy:     settings.SortBy,
+		SortOrder:  settings.SortOrder,
+		Limit:      settings.PageSize,
+		Offset:     (page - 1) * settings.PageSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	total := len(items)
+	if settings.PageSize > 0 {
+		total, err = u.itemRepo.Count(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count items: %w", err)
+		}
+	}
+
+	return &ItemList{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: settings.PageSize,
+	}, nil
+}
+
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
+	ctx = ReadOnly(ctx)
 
 	item, err := u.itemRepo.FindByID(ctx, id)
 	if err != nil {
@@ -80,17 +252,46 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 }
 
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	// 組み込みのカテゴリーでなければ、テナントが追加したカテゴリーも有効とする
+	customCategories, err := customCategoriesFor(ctx, u.categoryRepo, input.TenantID, strings.TrimSpace(input.Category))
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := purchasePrice(input.PurchasePrice, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	// バリデーションして、新しいエンティティを作成
 	item, err := entity.NewItem(
 		input.Name,
 		input.Category,
 		input.Brand,
-		input.PurchasePrice,
+		int(price.Amount),
 		input.PurchaseDate,
+		customCategories...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, domainErrors.Invalid(err)
+	}
+	item.Currency = price.Currency
+	if violations := u.rules.check(item, time.Now(), itemRuleFields...); len(violations) > 0 {
+		return nil, domainErrors.NewValidationError(violations...)
+	}
+
+	// カスタム属性をテナントの定義で検証
+	defs, err := loadAttributeDefinitions(ctx, u.attrRepo, input.TenantID)
+	if err != nil {
+		return nil, err
 	}
+	if attrErrors := entity.ValidateAttributes(defs, input.Attributes, true); len(attrErrors) > 0 {
+		return nil, domainErrors.NewValidationError(attrErrors...)
+	}
+	if len(input.Attributes) > 0 {
+		item.Attributes = input.Attributes
+	}
+	item.OwnerID = strings.TrimSpace(input.OwnerID)
 
 	createdItem, err := u.itemRepo.Create(ctx, item)
 	if err != nil {
@@ -100,99 +301,327 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 	return createdItem, nil
 }
 
-func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+func (u *itemUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) error {
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return domainErrors.ErrItemNotFound
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		// The row is locked for the rest of the transaction, so the version cannot change before the delete
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to check item existence: %w", err)
+		}
+		if ifMatch != nil && item.Version != *ifMatch {
+			return fmt.Errorf("%w: item %d is at version %d", domainErrors.ErrPreconditionFailed, id, item.Version)
 		}
-		return fmt.Errorf("failed to check item existence: %w", err)
+
+		// Undo tokens carry whole seconds
+		err = u.itemRepo.Trash(ctx, id, time.Now().UTC().Truncate(time.Second))
+		if err != nil {
+			return fmt.Errorf("failed to delete item: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (u *itemUsecase) PurgeItem(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
 	}
 
-	err = u.itemRepo.Delete(ctx, id)
-	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if _, err := u.itemRepo.FindTrashedByID(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+
+		if err := u.itemRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to purge item: %w", err)
+		}
+		return nil
+	})
+}
+
+// RestoreItem compares the deletion times in whole seconds, as the databases store them
+func (u *itemUsecase) RestoreItem(ctx context.Context, id int64, deletedAt time.Time) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
 	}
 
-	return nil
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindTrashedByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		// Restored and deleted again since
+		if item.DeletedAt.Unix() != deletedAt.Unix() {
+			return domainErrors.NotFound(domainErrors.ErrItemNotFound, "item %d was deleted again at %s", id, item.DeletedAt.UTC().Format(time.RFC3339))
+		}
+
+		if err := u.itemRepo.Restore(ctx, id); err != nil {
+			return fmt.Errorf("failed to restore item: %w", err)
+		}
+		return nil
+	})
 }
 
+// PatchItem reads and updates the item in one transaction; the expected version guards against
+// overwriting changes made since the client read the item
 func (u *itemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
+	if req.Version == nil && req.IfMatch == nil {
+		return nil, fmt.Errorf("%w: version is required (body field or If-Match header)", domainErrors.ErrInvalidInput)
+	}
 
-	// Fetch existing item
-	item, err := u.itemRepo.FindByID(ctx, id)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return nil, domainErrors.ErrItemNotFound
+	var updatedItem *entity.Item
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		// Fetch existing item
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if err := checkExpectedVersion(req, item); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to retrieve item: %w", err)
-	}
 
-	// Apply partial updates
-	if req.Name != nil {
-		item.Name = *req.Name
-	}
-	if req.Brand != nil {
-		item.Brand = *req.Brand
-	}
-	if req.PurchasePrice != nil {
-		item.PurchasePrice = *req.PurchasePrice
-	}
+		// Apply partial updates
+		if req.Name != nil {
+			item.Name = entity.SanitizeString(*req.Name)
+		}
+		if req.Brand != nil {
+			item.Brand = entity.SanitizeString(*req.Brand)
+		}
+		var validationErrors []string
+		if req.PurchasePrice != nil {
+			// The price is in the item's currency
+			if price, err := req.PurchasePrice.Money(item.Price().Currency); err != nil {
+				validationErrors = append(validationErrors, "purchase_price "+err.Error())
+			} else {
+				item.PurchasePrice = int(price.Amount)
+			}
+		}
 
-	// Update timestamp
-	item.UpdatedAt = time.Now()
+		// Update timestamp
+		item.UpdatedAt = time.Now()
+
+		// Validate updated fields
+		validationErrors = append(validationErrors, validateUpdateRequest(req, item)...)
+		// Only the rules of the patched fields apply, so that items from before a rule changed can still be edited
+		validationErrors = append(validationErrors, u.rules.check(item, time.Now(), patchedRuleFields(req)...)...)
+		if len(req.Attributes) > 0 {
+			attrErrors, err := u.mergeAttributes(ctx, req, item)
+			if err != nil {
+				return err
+			}
+			validationErrors = append(validationErrors, attrErrors...)
+		}
+		if len(validationErrors) > 0 {
+			return domainErrors.NewValidationError(validationErrors...)
+		}
 
-	// Validate updated fields
-	if validationErrors := validateUpdateRequest(req, item); len(validationErrors) > 0 {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(validationErrors, ", "))
-	}
+		// Save updated item (the repository increments the version only if it is still unchanged)
+		updatedItem, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			if domainErrors.IsConflictError(err) {
+				if req.IfMatch != nil {
+					return fmt.Errorf("%w: %v", domainErrors.ErrPreconditionFailed, err)
+				}
+				return err
+			}
+			return fmt.Errorf("failed to update item: %w", err)
+		}
 
-	// Save updated item
-	updatedItem, err := u.itemRepo.Update(ctx, item)
+		return nil
+	})
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return nil, domainErrors.ErrItemNotFound
-		}
-		return nil, fmt.Errorf("failed to update item: %w", err)
+		return nil, err
 	}
 
 	return updatedItem, nil
 }
 
-func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
+func (u *itemUsecase) GetCategorySummary(ctx context.Context, purchased DateRange) (*CategorySummary, error) {
+	if err := validateDateRange(purchased); err != nil {
+		return nil, err
+	}
+
+	ctx = ReadOnly(ctx)
+	categoryStats, err := u.itemRepo.GetSummaryByCategory(ctx, purchased)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category summary: %w", err)
 	}
 
 	// 合計計算
 	total := 0
-	for _, count := range categoryCounts {
-		total += count
+	for _, stats := range categoryStats {
+		total += stats.Count
 	}
 
+	// アイテムのない組み込みのカテゴリーは件数・集計とも0。テナントが追加したカテゴリーはアイテムがあれば含める
 	summary := make(map[string]int)
-	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
-		}
+	stats := make(map[string]CategoryStats)
+	for _, category := range entity.SortedCategories(categoryStats) {
+		categoryStat := categoryStats[category]
+		categoryStat.Avg = RoundAmount(categoryStat.Avg, BaseCurrency)
+		summary[category] = categoryStat.Count
+		stats[category] = categoryStat
 	}
 
 	return &CategorySummary{
 		Categories: summary,
 		Total:      total,
+		Stats:      stats,
 	}, nil
 }
 
+// validateDateRange checks that the bounds are YYYY-MM-DD dates and that From is not after To
+func validateDateRange(r DateRange) error {
+	if _, err := time.Parse("2006-01-02", r.From); r.From != "" && err != nil {
+		return fmt.Errorf("%w: from must be a date in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+	}
+	if _, err := time.Parse("2006-01-02", r.To); r.To != "" && err != nil {
+		return fmt.Errorf("%w: to must be a date in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+	}
+	if r.From != "" && r.To != "" && r.From > r.To {
+		return fmt.Errorf("%w: from must not be after to", domainErrors.ErrInvalidInput)
+	}
+	return nil
+}
+
+// GetValueSummary only supports BaseCurrency, and only totals the items bought in it; the items bought in other
+// currencies are only in Native. NewCurrencyConvertingItemUsecase converts them and supports other currencies.
+func (u *itemUsecase) GetValueSummary(ctx context.Context, currency string) (*ValueSummary, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if currency != BaseCurrency {
+		return nil, fmt.Errorf("%w: currency must be one of: %s", domainErrors.ErrInvalidInput, BaseCurrency)
+	}
+
+	summary := &ValueSummary{
+		Currency:     BaseCurrency,
+		Categories:   make(map[string]float64),
+		ExchangeRate: 1,
+		Native:       make(map[string]*NativeValue),
+	}
+	for _, category := range entity.GetValidCategories() {
+		summary.Categories[category] = 0
+	}
+
+	err = u.itemRepo.Iterate(ReadOnly(ctx), ItemQuery{}, func(item *entity.Item) error {
+		price := item.Price()
+		native, ok := summary.Native[price.Currency]
+		if !ok {
+			native = &NativeValue{Categories: make(map[string]float64)}
+			summary.Native[price.Currency] = native
+		}
+		// Minor units are summed exactly and only turned into amounts of the currency at the end
+		native.Categories[item.Category] += float64(price.Amount)
+		native.Total += float64(price.Amount)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get value summary: %w", err)
+	}
+
+	for currency, native := range summary.Native {
+		scale := math.Pow10(entity.CurrencyDigits(currency))
+		for category, total := range native.Categories {
+			native.Categories[category] = total / scale
+		}
+		native.Total /= scale
+	}
+	if native, ok := summary.Native[BaseCurrency]; ok {
+		maps.Copy(summary.Categories, native.Categories)
+		summary.Total = native.Total
+		native.Converted, native.ExchangeRate = native.Total, 1
+	}
+
+	return summary, nil
+}
+
+// mergeAttributes applies the attribute changes of a PATCH request to the item and validates them.
+// Only the attributes being changed are checked, so values whose option was later removed stay readable.
+func (u *itemUsecase) mergeAttributes(ctx context.Context, req *UpdateItemRequest, item *entity.Item) ([]string, error) {
+	defs, err := loadAttributeDefinitions(ctx, u.attrRepo, req.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]string)
+	var removed []string
+	for key, value := range req.Attributes {
+		if value == nil {
+			removed = append(removed, key)
+			continue
+		}
+		changed[key] = *value
+	}
+
+	validationErrors := entity.ValidateAttributes(defs, changed, false)
+	sort.Strings(removed)
+	for _, key := range removed {
+		for _, def := range defs {
+			if def.Key == key && def.Required {
+				validationErrors = append(validationErrors, fmt.Sprintf("attributes.%s is required", key))
+			}
+		}
+	}
+
+	attributes := make(map[string]string, len(item.Attributes)+len(changed))
+	for key, value := range item.Attributes {
+		attributes[key] = value
+	}
+	for key, value := range changed {
+		attributes[key] = value
+	}
+	for _, key := range removed {
+		delete(attributes, key)
+	}
+	item.Attributes = attributes
+
+	return validationErrors, nil
+}
+
+// inTransaction runs fn through the unit of work, or directly when there is none
+func inTransaction(ctx context.Context, uow UnitOfWork, fn func(ctx context.Context) error) error {
+	if uow == nil {
+		return fn(ctx)
+	}
+	return uow.Do(ctx, fn)
+}
+
+// checkExpectedVersion compares the item's current version with the versions the client expects.
+// An If-Match precondition is reported as ErrPreconditionFailed, a stale body version as ErrConflict.
+func checkExpectedVersion(req *UpdateItemRequest, item *entity.Item) error {
+	if req.IfMatch != nil && item.Version != *req.IfMatch {
+		return fmt.Errorf("%w: item %d is at version %d", domainErrors.ErrPreconditionFailed, item.ID, item.Version)
+	}
+	if req.Version != nil && item.Version != *req.Version {
+		return domainErrors.Conflict("item %d has been modified (current version %d)", item.ID, item.Version)
+	}
+	return nil
+}
+
 // validateUpdateRequest validates the fields being updated in a PATCH request
 func validateUpdateRequest(req *UpdateItemRequest, item *entity.Item) []string {
 	var validationErrors []string
@@ -205,12 +634,9 @@ func validateUpdateRequest(req *UpdateItemRequest, item *entity.Item) []string {
 		}
 	}
 
-	if req.Brand != nil {
-		if item.Brand == "" {
-			validationErrors = append(validationErrors, "brand is required")
-		} else if len(item.Brand) > maxBrandLength {
-			validationErrors = append(validationErrors, fmt.Sprintf("brand must be %d characters or less", maxBrandLength))
-		}
+	// Whether a brand is required is a validation rule
+	if req.Brand != nil && len(item.Brand) > maxBrandLength {
+		validationErrors = append(validationErrors, fmt.Sprintf("brand must be %d characters or less", maxBrandLength))
 	}
 
 	if req.PurchasePrice != nil {
@@ -221,3 +647,32 @@ func validateUpdateRequest(req *UpdateItemRequest, item *entity.Item) []string {
 
 	return validationErrors
 }
+
+// purchasePrice resolves the purchase price of a new item in currency (entity.DefaultCurrency if empty)
+func purchasePrice(amount entity.Amount, currency string) (entity.Money, error) {
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	} else {
+		var err error
+		if currency, err = NormalizeCurrency(currency); err != nil {
+			return entity.Money{}, err
+		}
+	}
+	price, err := amount.Money(currency)
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("%w: purchase_price %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	return price, nil
+}
+
+// patchedRuleFields returns the fields of the validation rules that req changes
+func patchedRuleFields(req *UpdateItemRequest) []string {
+	var fields []string
+	if req.Brand != nil {
+		fields = append(fields, "brand")
+	}
+	if req.PurchasePrice != nil {
+		fields = append(fields, "purchase_price")
+	}
+	return fields
+}
@@ -12,56 +12,9 @@ import (
 	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
-// MockItemRepository はtestify/mockを使用したモックリポジトリ
-type MockItemRepository struct {
-	mock.Mock
-}
-
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*entity.Item), args.Error(1)
-}
-
-func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
-	args := m.Called(ctx, item)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
-	args := m.Called(ctx, item)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Item), args.Error(1)
-}
-
-func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
-	args := m.Called(ctx)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(map[string]int), args.Error(1)
-}
-
 func TestNewItemUsecase(t *testing.T) {
 	mockRepo := new(MockItemRepository)
-	usecase := NewItemUsecase(mockRepo)
+	usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 	assert.NotNil(t, usecase)
 }
@@ -79,7 +32,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(items, nil)
 			},
 			expectedCount: 2,
 			expectedErr:   nil,
@@ -88,7 +41,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			name: "正常系: アイテムが0件",
 			setupMock: func(mockRepo *MockItemRepository) {
 				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(items, nil)
 			},
 			expectedCount: 0,
 			expectedErr:   nil,
@@ -96,7 +49,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("FindAll", mock.Anything, ItemQuery{}).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectedCount: 0,
 			expectedErr:   domainErrors.ErrDatabaseError,
@@ -107,7 +60,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 			ctx := context.Background()
 			items, err := usecase.GetAllItems(ctx)
@@ -176,7 +129,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 			ctx := context.Background()
 			item, err := usecase.GetItemByID(ctx, tt.id)
@@ -212,7 +165,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.MinorUnits(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -228,7 +181,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.MinorUnits(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -243,7 +196,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "無効なカテゴリー",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.MinorUnits(100000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -258,7 +211,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "時計",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.MinorUnits(100000),
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -272,7 +225,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 			ctx := context.Background()
 			item, err := usecase.CreateItem(ctx, tt.input)
@@ -289,7 +242,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				assert.Equal(t, tt.input.Name, item.Name)
 				assert.Equal(t, tt.input.Category, item.Category)
 				assert.Equal(t, tt.input.Brand, item.Brand)
-				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice)
+				assert.Equal(t, tt.input.PurchasePrice, entity.MinorUnits(int64(item.PurchasePrice)))
 				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate)
 			}
 
@@ -298,6 +251,59 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_CreateItem_Currency(t *testing.T) {
+	input := func(price entity.Amount, currency string) CreateItemInput {
+		return CreateItemInput{Name: "スピードマスター", Category: "時計", Brand: "OMEGA", PurchasePrice: price, Currency: currency, PurchaseDate: "2024-03-01"}
+	}
+
+	t.Run("正常系: 10進数の価格を通貨の最小単位で保存する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == 630050 && item.Currency == "USD"
+		})).Return(&entity.Item{ID: 1}, nil)
+		u := NewItemUsecase(mockRepo, nil, nil, nil, nil)
+
+		_, err := u.CreateItem(context.Background(), input(entity.DecimalAmount("6300.50"), " usd"))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 通貨を省略すると DefaultCurrency", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == 1500000 && item.Currency == entity.DefaultCurrency
+		})).Return(&entity.Item{ID: 1}, nil)
+		u := NewItemUsecase(mockRepo, nil, nil, nil, nil)
+
+		_, err := u.CreateItem(context.Background(), input(entity.DecimalAmount("1500000"), ""))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name     string
+		input    CreateItemInput
+		expected string
+	}{
+		{name: "異常系: 通貨の桁数より多い小数", input: input(entity.DecimalAmount("12.345"), "USD"), expected: `invalid input: purchase_price must have at most 2 decimal places in USD: "12.345"`},
+		{name: "異常系: 円の小数", input: input(entity.DecimalAmount("0.5"), ""), expected: `invalid input: purchase_price must be a whole number in JPY: "0.5"`},
+		{name: "異常系: 不正な通貨", input: input(entity.MinorUnits(100), "dollar"), expected: "invalid input: currency must be in ISO 4217 format (e.g. USD)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			u := NewItemUsecase(mockRepo, nil, nil, nil, nil)
+
+			_, err := u.CreateItem(context.Background(), tt.input)
+
+			assert.EqualError(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestItemUsecase_DeleteItem(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -313,7 +319,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+				mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
 			},
 			expectError: false,
 		},
@@ -350,7 +356,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+				mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -360,10 +366,10 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 			ctx := context.Background()
-			err := usecase.DeleteItem(ctx, tt.id)
+			err := usecase.DeleteItem(ctx, tt.id, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -391,11 +397,11 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: 複数カテゴリーのアイテムがある場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{
-					"時計":  2,
-					"バッグ": 1,
+				summary := map[string]CategoryStats{
+					"時計":  {Count: 2, Min: 500000, Max: 1000001, Avg: 750000.5, Sum: 1500001},
+					"バッグ": {Count: 1, Min: 300000, Max: 300000, Avg: 300000, Sum: 300000},
 				}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(summary, nil)
 			},
 			expectedTotal:      3,
 			expectedWatchCount: 2,
@@ -405,8 +411,8 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: アイテムが0件の場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				summary := map[string]CategoryStats{}
+				mockRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return(summary, nil)
 			},
 			expectedTotal:      0,
 			expectedWatchCount: 0,
@@ -416,7 +422,7 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]int)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("GetSummaryByCategory", mock.Anything, DateRange{}).Return((map[string]CategoryStats)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -426,10 +432,10 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockItemRepository)
 			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+			usecase := NewItemUsecase(mockRepo, nil, nil, nil, nil)
 
 			ctx := context.Background()
-			summary, err := usecase.GetCategorySummary(ctx)
+			summary, err := usecase.GetCategorySummary(ctx, DateRange{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -449,9 +455,238 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			expectedCategories := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
 			for _, category := range expectedCategories {
 				assert.Contains(t, summary.Categories, category)
+				assert.Contains(t, summary.Stats, category)
+			}
+			assert.Equal(t, CategoryStats{}, summary.Stats["靴"])
+			if tt.expectedWatchCount > 0 {
+				// 平均は円の単位に丸める
+				assert.Equal(t, CategoryStats{Count: 2, Min: 500000, Max: 1000001, Avg: 750001, Sum: 1500001}, summary.Stats["時計"])
 			}
 
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestItemUsecase_GetCategorySummary_DateRange(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 購入日の範囲をリポジトリに渡す", func(t *testing.T) {
+		purchased := DateRange{From: "2023-01-01", To: "2023-12-31"}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, purchased).Return(map[string]CategoryStats{"時計": {Count: 1}}, nil)
+
+		summary, err := NewItemUsecase(mockRepo, nil, nil, nil, nil).GetCategorySummary(ctx, purchased)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name      string
+		purchased DateRange
+	}{
+		{name: "異常系: 開始日が日付でない", purchased: DateRange{From: "2023/01/01"}},
+		{name: "異常系: 終了日が存在しない日付", purchased: DateRange{To: "2023-02-30"}},
+		{name: "異常系: 開始日が終了日より後", purchased: DateRange{From: "2024-01-01", To: "2023-12-31"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+
+			_, err := NewItemUsecase(mockRepo, nil, nil, nil, nil).GetCategorySummary(ctx, tt.purchased)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "GetSummaryByCategory", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestItemUsecase_PatchItem_Version(t *testing.T) {
+	name := "新しい名前"
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name        string
+		version     *int64
+		ifMatch     *int64
+		updateErr   error
+		expectedErr error
+		callsUpdate bool
+	}{
+		{
+			name:        "正常系: バージョンが一致すれば更新する",
+			version:     int64Ptr(3),
+			callsUpdate: true,
+		},
+		{
+			name:        "異常系: バージョン未指定",
+			version:     nil,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 読み込み後に更新されていた場合は競合",
+			version:     int64Ptr(2),
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: 保存時に競合した場合も競合を返す",
+			version:     int64Ptr(3),
+			updateErr:   domainErrors.ErrConflict,
+			expectedErr: domainErrors.ErrConflict,
+			callsUpdate: true,
+		},
+		{
+			name:        "正常系: If-Matchのバージョンが一致すれば更新する",
+			ifMatch:     int64Ptr(3),
+			callsUpdate: true,
+		},
+		{
+			name:        "異常系: If-Matchのバージョンが異なる場合は事前条件エラー",
+			ifMatch:     int64Ptr(2),
+			expectedErr: domainErrors.ErrPreconditionFailed,
+		},
+		{
+			name:        "異常系: If-Match指定時に保存で競合した場合も事前条件エラー",
+			ifMatch:     int64Ptr(3),
+			updateErr:   domainErrors.ErrConflict,
+			expectedErr: domainErrors.ErrPreconditionFailed,
+			callsUpdate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2023-01-01")
+			item.ID = 1
+			item.Version = 3
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			if tt.updateErr != nil {
+				mockRepo.On("Update", mock.Anything, item).Return(nil, tt.updateErr)
+			} else {
+				mockRepo.On("Update", mock.Anything, item).Return(item, nil)
+			}
+
+			_, err := NewItemUsecase(mockRepo, nil, nil, nil, nil).PatchItem(context.Background(), 1, &UpdateItemRequest{Name: &name, Version: tt.version, IfMatch: tt.ifMatch})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.callsUpdate {
+				mockRepo.AssertCalled(t, "Update", mock.Anything, item)
+			} else {
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// fakeUnitOfWork はトランザクションの実行回数と結果を記録する
+type fakeUnitOfWork struct {
+	calls  int
+	result error
+}
+
+func (u *fakeUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	u.calls++
+	u.result = fn(ctx)
+	return u.result
+}
+
+func TestItemUsecase_UnitOfWork(t *testing.T) {
+	name := "新しい名前"
+	var version int64
+
+	t.Run("正常系: PATCHは取得と更新を1つのトランザクションで行う", func(t *testing.T) {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2023-01-01")
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, item).Return(item, nil)
+		uow := &fakeUnitOfWork{}
+
+		_, err := NewItemUsecase(mockRepo, nil, nil, nil, uow).PatchItem(context.Background(), 1, &UpdateItemRequest{Name: &name, Version: &version})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.calls)
+		assert.NoError(t, uow.result)
+	})
+
+	t.Run("異常系: 更新に失敗した場合はエラーでロールバックさせる", func(t *testing.T) {
+		item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2023-01-01")
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, item).Return(nil, domainErrors.ErrDatabaseError)
+		uow := &fakeUnitOfWork{}
+
+		_, err := NewItemUsecase(mockRepo, nil, nil, nil, uow).PatchItem(context.Background(), 1, &UpdateItemRequest{Name: &name, Version: &version})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.ErrorIs(t, uow.result, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("正常系: 削除も1つのトランザクションで行う", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		uow := &fakeUnitOfWork{}
+
+		err := NewItemUsecase(mockRepo, nil, nil, nil, uow).DeleteItem(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.calls)
+	})
+}
+
+func TestItemUsecase_DeleteItem_IfMatch(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name        string
+		ifMatch     *int64
+		expectedErr error
+		callsDelete bool
+	}{
+		{
+			name:        "正常系: If-Match未指定なら削除する",
+			callsDelete: true,
+		},
+		{
+			name:        "正常系: If-Matchのバージョンが一致すれば削除する",
+			ifMatch:     int64Ptr(3),
+			callsDelete: true,
+		},
+		{
+			name:        "異常系: If-Matchのバージョンが異なる場合は削除しない",
+			ifMatch:     int64Ptr(2),
+			expectedErr: domainErrors.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000, "2023-01-01")
+			item.ID = 1
+			item.Version = 3
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+			mockRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+
+			err := NewItemUsecase(mockRepo, nil, nil, nil, nil).DeleteItem(context.Background(), 1, tt.ifMatch)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.callsDelete {
+				mockRepo.AssertCalled(t, "Trash", mock.Anything, int64(1), mock.Anything)
+			} else {
+				mockRepo.AssertNotCalled(t, "Trash", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// DefaultTenantID is used when a request does not identify a tenant
+const DefaultTenantID = "default"
+
+type SettingsUsecase interface {
+	GetListSettings(ctx context.Context, tenantID string) (*entity.ListSettings, error)
+	UpdateListSettings(ctx context.Context, tenantID string, input UpdateListSettingsInput) (*entity.ListSettings, error)
+}
+
+type UpdateListSettingsInput struct {
+	SortBy    string `json:"sort_by"`
+	SortOrder string `json:"sort_order"`
+	PageSize  int    `json:"page_size"`
+}
+
+type settingsUsecase struct {
+	settingsRepo SettingsRepository
+}
+
+func NewSettingsUsecase(settingsRepo SettingsRepository) SettingsUsecase {
+	return &settingsUsecase{
+		settingsRepo: settingsRepo,
+	}
+}
+
+func (u *settingsUsecase) GetListSettings(ctx context.Context, tenantID string) (*entity.ListSettings, error) {
+	return loadListSettings(ctx, u.settingsRepo, tenantID)
+}
+
+func (u *settingsUsecase) UpdateListSettings(ctx context.Context, tenantID string, input UpdateListSettingsInput) (*entity.ListSettings, error) {
+	settings, err := entity.NewListSettings(input.SortBy, input.SortOrder, input.PageSize)
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	if err := u.settingsRepo.SaveListSettings(ctx, tenantOrDefault(tenantID), settings); err != nil {
+		return nil, fmt.Errorf("failed to save list settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// loadListSettings returns the tenant's stored list settings, falling back to the defaults
+func loadListSettings(ctx context.Context, repo SettingsRepository, tenantID string) (*entity.ListSettings, error) {
+	if repo == nil {
+		return entity.DefaultListSettings(), nil
+	}
+
+	settings, err := repo.FindListSettings(ctx, tenantOrDefault(tenantID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve list settings: %w", err)
+	}
+	if settings == nil {
+		return entity.DefaultListSettings(), nil
+	}
+
+	return settings, nil
+}
+
+func tenantOrDefault(tenantID string) string {
+	if tenantID == "" {
+		return DefaultTenantID
+	}
+	return tenantID
+}
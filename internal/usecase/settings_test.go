@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockSettingsRepository はテナント設定リポジトリのモック
+type MockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockSettingsRepository) FindListSettings(ctx context.Context, tenantID string) (*entity.ListSettings, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ListSettings), args.Error(1)
+}
+
+func (m *MockSettingsRepository) SaveListSettings(ctx context.Context, tenantID string, settings *entity.ListSettings) error {
+	args := m.Called(ctx, tenantID, settings)
+	return args.Error(0)
+}
+
+func TestItemUsecase_ListItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         ListItemsQuery
+		stored        *entity.ListSettings
+		expectedQuery ItemQuery
+		expectedTotal int
+		expectedErr   error
+	}{
+		{
+			name:          "正常系: 設定なしはデフォルト（作成日時の降順、全件）",
+			query:         ListItemsQuery{},
+			expectedQuery: ItemQuery{SortBy: "created_at", SortOrder: "desc"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "正常系: テナント設定を適用",
+			query:         ListItemsQuery{TenantID: "acme"},
+			stored:        &entity.ListSettings{SortBy: "purchase_price", SortOrder: "asc", PageSize: 2},
+			expectedQuery: ItemQuery{SortBy: "purchase_price", SortOrder: "asc", Limit: 2},
+			expectedTotal: 3,
+		},
+		{
+			name:          "正常系: リクエストの指定がテナント設定より優先",
+			query:         ListItemsQuery{TenantID: "acme", SortOrder: "desc", Page: 2, PageSize: intPtr(1)},
+			stored:        &entity.ListSettings{SortBy: "purchase_price", SortOrder: "asc", PageSize: 2},
+			expectedQuery: ItemQuery{SortBy: "purchase_price", SortOrder: "desc", Limit: 1, Offset: 1},
+			expectedTotal: 3,
+		},
+		{
+			name:          "正常系: カテゴリーとブランドで絞り込み",
+			query:         ListItemsQuery{Category: "時計", Brand: " ROLEX ", PageSize: intPtr(10)},
+			expectedQuery: ItemQuery{ItemFilter: ItemFilter{Category: "時計", Brand: "ROLEX"}, SortBy: "created_at", SortOrder: "desc", Limit: 10},
+			expectedTotal: 3,
+		},
+		{
+			name:          "正常系: 時価で並び替えて下限で絞り込み",
+			query:         ListItemsQuery{SortBy: "current_value", MinCurrentValue: 1000000, PageSize: intPtr(10)},
+			expectedQuery: ItemQuery{ItemFilter: ItemFilter{MinCurrentValue: 1000000}, SortBy: SortByCurrentValue, SortOrder: "desc", Limit: 10},
+			expectedTotal: 3,
+		},
+		{
+			name:        "異常系: 時価の下限が負",
+			query:       ListItemsQuery{MinCurrentValue: -1},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: ソートできないフィールド",
+			query:       ListItemsQuery{SortBy: "brand"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: ページサイズが上限超過",
+			query:       ListItemsQuery{PageSize: intPtr(entity.MaxPageSize + 1)},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 存在しないカテゴリー",
+			query:       ListItemsQuery{Category: "家具"},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockSettings := new(MockSettingsRepository)
+			tenant := tt.query.TenantID
+			if tenant == "" {
+				tenant = DefaultTenantID
+			}
+			mockSettings.On("FindListSettings", mock.Anything, tenant).Return(tt.stored, nil)
+			items := []*entity.Item{{ID: 2}, {ID: 1}}
+			if tt.expectedErr == nil {
+				mockRepo.On("FindAll", mock.Anything, tt.expectedQuery).Return(items, nil)
+				// ページングする場合のみ総件数を数える
+				if tt.expectedQuery.Limit > 0 {
+					mockRepo.On("Count", mock.Anything, tt.expectedQuery.ItemFilter).Return(3, nil)
+				}
+			}
+			usecase := NewItemUsecase(mockRepo, mockSettings, nil, nil, nil)
+
+			list, err := usecase.ListItems(context.Background(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, items, list.Items)
+			assert.Equal(t, tt.expectedTotal, list.Total)
+			mockRepo.AssertExpectations(t)
+			mockSettings.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSettingsUsecase_UpdateListSettings(t *testing.T) {
+	t.Run("正常系: 省略した値はデフォルトで保存", func(t *testing.T) {
+		mockSettings := new(MockSettingsRepository)
+		mockSettings.On("SaveListSettings", mock.Anything, "acme", mock.MatchedBy(func(s *entity.ListSettings) bool {
+			return s.SortBy == "created_at" && s.SortOrder == "asc" && s.PageSize == 20
+		})).Return(nil)
+
+		settings, err := NewSettingsUsecase(mockSettings).UpdateListSettings(context.Background(), "acme", UpdateListSettingsInput{
+			SortOrder: "ASC",
+			PageSize:  20,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "asc", settings.SortOrder)
+		mockSettings.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正な並び順", func(t *testing.T) {
+		mockSettings := new(MockSettingsRepository)
+
+		_, err := NewSettingsUsecase(mockSettings).UpdateListSettings(context.Background(), "", UpdateListSettingsInput{
+			SortOrder: "random",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockSettings.AssertExpectations(t)
+	})
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// defaultShareLinkDays is how long a share link is valid when no expiry is given
+	defaultShareLinkDays = 7
+	maxShareLinkDays     = 90
+)
+
+// ShareClaims is what a share link token grants: a read-only view of one item until ExpiresAt
+type ShareClaims struct {
+	ItemID       int64
+	ExpiresAt    time.Time
+	IncludePrice bool
+}
+
+// ShareTokenSigner signs share link tokens so that they need not be stored
+type ShareTokenSigner interface {
+	Sign(claims ShareClaims) string
+	// Verify returns the claims of a token signed by Sign, whether or not it has expired
+	Verify(token string) (ShareClaims, error)
+}
+
+// ShareUsecase creates public, read-only links to single items, e.g. to show an item to an appraiser
+// without giving them API access. Links are valid until they expire; they cannot be revoked one by one.
+type ShareUsecase interface {
+	// CreateShareLink creates a link to an item; only the item's owner may share an owned item
+	CreateShareLink(ctx context.Context, actor string, itemID int64, input ShareLinkInput) (*ShareLink, error)
+	// GetSharedItem returns the redacted view of the item a token was issued for
+	GetSharedItem(ctx context.Context, token string) (*SharedItem, error)
+}
+
+type ShareLinkInput struct {
+	// ExpiresInDays defaults to defaultShareLinkDays when zero
+	ExpiresInDays int `json:"expires_in_days"`
+	// IncludePrice shows the purchase price on the shared view
+	IncludePrice bool `json:"include_price"`
+}
+
+type ShareLink struct {
+	Token        string    `json:"token"`
+	URL          string    `json:"url"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	IncludePrice bool      `json:"include_price"`
+}
+
+// SharedItem is the view of an item shown through a share link; owner, version and costs are left out,
+// and the purchase price unless the link was created with include_price
+type SharedItem struct {
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	Category      string            `json:"category"`
+	Brand         string            `json:"brand"`
+	PurchaseDate  string            `json:"purchase_date"`
+	PurchasePrice *int              `json:"purchase_price,omitempty"`
+	Currency      string            `json:"currency,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+}
+
+type shareUsecase struct {
+	itemRepo ItemReader
+	signer   ShareTokenSigner
+	baseURL  string
+	now      func() time.Time
+}
+
+func NewShareUsecase(itemRepo ItemReader, signer ShareTokenSigner, baseURL string) ShareUsecase {
+	return &shareUsecase{
+		itemRepo: itemRepo,
+		signer:   signer,
+		baseURL:  baseURL,
+		now:      time.Now,
+	}
+}
+
+func (u *shareUsecase) CreateShareLink(ctx context.Context, actor string, itemID int64, input ShareLinkInput) (*ShareLink, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	days := input.ExpiresInDays
+	if days == 0 {
+		days = defaultShareLinkDays
+	}
+	if days < 0 || days > maxShareLinkDays {
+		return nil, fmt.Errorf("%w: expires_in_days must be between 1 and %d", domainErrors.ErrInvalidInput, maxShareLinkDays)
+	}
+
+	item, err := u.itemRepo.FindByID(ReadOnly(ctx), itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if item.OwnerID != "" && item.OwnerID != actor {
+		return nil, domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+	}
+
+	// Tokens carry whole seconds
+	claims := ShareClaims{
+		ItemID:       itemID,
+		ExpiresAt:    u.now().Add(time.Duration(days) * 24 * time.Hour).UTC().Truncate(time.Second),
+		IncludePrice: input.IncludePrice,
+	}
+	token := u.signer.Sign(claims)
+
+	return &ShareLink{
+		Token:        token,
+		URL:          fmt.Sprintf("%s/shared/%s", u.baseURL, token),
+		ExpiresAt:    claims.ExpiresAt,
+		IncludePrice: claims.IncludePrice,
+	}, nil
+}
+
+// GetSharedItem reports invalid, expired and deleted-item tokens alike as ErrShareLinkNotFound,
+// so that the public endpoint does not tell which items exist
+func (u *shareUsecase) GetSharedItem(ctx context.Context, token string) (*SharedItem, error) {
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return nil, domainErrors.ErrShareLinkNotFound
+	}
+	if !u.now().Before(claims.ExpiresAt) {
+		return nil, domainErrors.NotFound(domainErrors.ErrShareLinkNotFound, "expired at %s", claims.ExpiresAt.Format(time.RFC3339))
+	}
+
+	item, err := u.itemRepo.FindByID(ReadOnly(ctx), claims.ItemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return sharedItem(item, claims), nil
+}
+
+func sharedItem(item *entity.Item, claims ShareClaims) *SharedItem {
+	shared := &SharedItem{
+		ID:           item.ID,
+		Name:         item.Name,
+		Category:     item.Category,
+		Brand:        item.Brand,
+		PurchaseDate: item.PurchaseDate,
+		Attributes:   item.Attributes,
+		ExpiresAt:    claims.ExpiresAt,
+	}
+	if claims.IncludePrice {
+		price := item.PurchasePrice
+		shared.PurchasePrice, shared.Currency = &price, item.Price().Currency
+	}
+	return shared
+}
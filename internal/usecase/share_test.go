@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeShareTokenSigner はトークンと内容の対応を覚えておく署名
+type fakeShareTokenSigner struct {
+	issued map[string]ShareClaims
+}
+
+func (s *fakeShareTokenSigner) Sign(claims ShareClaims) string {
+	token := "token-" + claims.ExpiresAt.Format("20060102")
+	s.issued[token] = claims
+	return token
+}
+
+func (s *fakeShareTokenSigner) Verify(token string) (ShareClaims, error) {
+	claims, ok := s.issued[token]
+	if !ok {
+		return ShareClaims{}, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+var shareNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestShareUsecase(itemRepo ItemRepository) (*shareUsecase, *fakeShareTokenSigner) {
+	signer := &fakeShareTokenSigner{issued: make(map[string]ShareClaims)}
+	u := NewShareUsecase(itemRepo, signer, "https://inventory.example.com").(*shareUsecase)
+	u.now = func() time.Time { return shareNow }
+	return u, signer
+}
+
+func TestShareUsecase_CreateShareLink(t *testing.T) {
+	tests := []struct {
+		name              string
+		ownerID           string
+		input             ShareLinkInput
+		expectedExpiresAt time.Time
+		expectedErr       error
+	}{
+		{name: "正常系: 期限を省略すると7日間", ownerID: "alice", expectedExpiresAt: shareNow.AddDate(0, 0, 7)},
+		{name: "正常系: 価格を公開する30日間のリンク", ownerID: "alice", input: ShareLinkInput{ExpiresInDays: 30, IncludePrice: true}, expectedExpiresAt: shareNow.AddDate(0, 0, 30)},
+		{name: "正常系: 所有者のいないアイテムは誰でも共有できる", ownerID: "", expectedExpiresAt: shareNow.AddDate(0, 0, 7)},
+		{name: "異常系: 期限が長すぎる", ownerID: "alice", input: ShareLinkInput{ExpiresInDays: 91}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 期限が負", ownerID: "alice", input: ShareLinkInput{ExpiresInDays: -1}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 所有者以外は共有できない", ownerID: "bob", expectedErr: domainErrors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: tt.ownerID}, nil).Maybe()
+			u, signer := newTestShareUsecase(itemRepo)
+
+			link, err := u.CreateShareLink(context.Background(), "alice", 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, signer.issued)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedExpiresAt, link.ExpiresAt)
+			assert.Equal(t, tt.input.IncludePrice, link.IncludePrice)
+			assert.Equal(t, "https://inventory.example.com/shared/"+link.Token, link.URL)
+			assert.Equal(t, ShareClaims{ItemID: 1, ExpiresAt: tt.expectedExpiresAt, IncludePrice: tt.input.IncludePrice}, signer.issued[link.Token])
+		})
+	}
+
+	t.Run("異常系: アイテムが見つからない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrNotFound)
+		u, _ := newTestShareUsecase(itemRepo)
+
+		_, err := u.CreateShareLink(context.Background(), "alice", 9, ShareLinkInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestShareUsecase_GetSharedItem(t *testing.T) {
+	item := &entity.Item{
+		ID:              1,
+		Name:            "ロレックス デイトナ",
+		Category:        "時計",
+		Brand:           "ROLEX",
+		PurchasePrice:   1500000,
+		PurchaseDate:    "2023-01-15",
+		Attributes:      map[string]string{"serial": "Z123456"},
+		OwnerID:         "alice",
+		MaintenanceCost: 42000,
+		Version:         3,
+	}
+
+	tests := []struct {
+		name          string
+		claims        ShareClaims
+		expectedPrice *int
+		// 価格を公開するときだけ通貨も公開する
+		expectedCurrency string
+		expectedErr      error
+	}{
+		{name: "正常系: 価格を伏せて返す", claims: ShareClaims{ItemID: 1, ExpiresAt: shareNow.Add(time.Hour)}},
+		{name: "正常系: 価格を公開するリンク", claims: ShareClaims{ItemID: 1, ExpiresAt: shareNow.Add(time.Hour), IncludePrice: true}, expectedPrice: &item.PurchasePrice, expectedCurrency: "JPY"},
+		{name: "異常系: 期限切れ", claims: ShareClaims{ItemID: 1, ExpiresAt: shareNow}, expectedErr: domainErrors.ErrShareLinkNotFound},
+		{name: "異常系: 削除されたアイテム", claims: ShareClaims{ItemID: 2, ExpiresAt: shareNow.Add(time.Hour)}, expectedErr: domainErrors.ErrShareLinkNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Maybe()
+			itemRepo.On("FindByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrItemNotFound).Maybe()
+			u, signer := newTestShareUsecase(itemRepo)
+			signer.issued["token"] = tt.claims
+
+			shared, err := u.GetSharedItem(context.Background(), "token")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, shared)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &SharedItem{
+				ID:            1,
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchaseDate:  "2023-01-15",
+				PurchasePrice: tt.expectedPrice,
+				Currency:      tt.expectedCurrency,
+				Attributes:    map[string]string{"serial": "Z123456"},
+				ExpiresAt:     tt.claims.ExpiresAt,
+			}, shared)
+		})
+	}
+
+	t.Run("異常系: 署名が不正なトークン", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u, _ := newTestShareUsecase(itemRepo)
+
+		_, err := u.GetSharedItem(context.Background(), "forged")
+
+		assert.ErrorIs(t, err, domainErrors.ErrShareLinkNotFound)
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
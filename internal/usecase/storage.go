@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// http.DetectContentType looks at no more than the first 512 bytes
+	sniffLen = 512
+	// Number of stored files checked against the records at a time when deleting orphaned files
+	orphanBatchSize = 100
+)
+
+// Storage keeps media files such as item images, on local disk or in an object storage (S3 / GCS)
+type Storage interface {
+	// Put stores the size bytes read from r under the given key, streaming them rather than buffering the whole file
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Open opens a stored file. Returns an error wrapping ErrNotFound if there is none.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes a stored file; removing a missing file is not an error
+	Delete(ctx context.Context, key string) error
+
+	// List calls fn for each stored file, in no particular order; an error from fn stops the listing
+	List(ctx context.Context, fn func(StoredObject) error) error
+}
+
+// StoredObject is a file in a Storage
+type StoredObject struct {
+	Key        string
+	ModifiedAt time.Time
+}
+
+// uploadedFile is an upload whose type was detected from its contents
+type uploadedFile struct {
+	contentType string
+	extension   string
+	// key is the name to store the file under: a random ID with the extension of its type,
+	// or the SHA-256 of the contents once hashUpload has read them
+	key     string
+	sha256  string    // hex SHA-256 of the contents, set by hashUpload
+	content io.Reader // the whole file, including the bytes read to detect its type
+}
+
+// checkUpload checks that an upload of size bytes is within maxSize and of one of the accepted types
+// (content type to extension), returning tooLarge or unsupported if not. Only the start of the file is read;
+// the rest is streamed to the storage as it is read, and fails if it is not exactly size bytes long.
+func checkUpload(r io.Reader, size, maxSize int64, types map[string]string, tooLarge, unsupported error) (*uploadedFile, error) {
+	if size > maxSize {
+		return nil, fmt.Errorf("%w: file must be %d bytes or less", tooLarge, maxSize)
+	}
+
+	// The type is detected from the first bytes of the contents; the file name and the declared type are not trusted
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	extension, ok := types[contentType]
+	if n > 0 && !ok {
+		return nil, fmt.Errorf("%w: file must be one of: %s", unsupported, typeNames(types))
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file name: %w", err)
+	}
+	return &uploadedFile{
+		contentType: contentType,
+		extension:   extension,
+		key:         id + extension,
+		content:     &exactReader{r: io.MultiReader(bytes.NewReader(head), r), remaining: size},
+	}, nil
+}
+
+// hashUpload reads an upload checked by checkUpload to its end to compute the SHA-256 of the contents, and names
+// the file after it, so that identical files are stored once under the same key. r is then rewound to be stored.
+func hashUpload(r io.ReadSeeker, size int64, file *uploadedFile) error {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file.content); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	file.sha256 = hex.EncodeToString(hash.Sum(nil))
+	file.key = file.sha256 + file.extension
+	return file.rewind(r, size)
+}
+
+// rewind reads the contents of the upload again from the start
+func (f *uploadedFile) rewind(r io.ReadSeeker, size int64) error {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	f.content = &exactReader{r: r, remaining: size}
+	return nil
+}
+
+// ensureStored stores a committed upload again if its file is missing. Files are shared by the records of identical
+// uploads, so the deletion of another record can remove the file between the upload storing it and committing
+// its own record. Failures are logged: the record is already committed.
+func ensureStored(ctx context.Context, storage Storage, file *uploadedFile, r io.ReadSeeker, size int64) {
+	stored, err := storage.Open(ctx, file.key)
+	if err == nil {
+		stored.Close()
+		return
+	}
+	if domainErrors.IsNotFoundError(err) {
+		if err = file.rewind(r, size); err == nil {
+			err = storage.Put(ctx, file.key, file.content, size, file.contentType)
+		}
+	}
+	if err != nil {
+		log.Printf("⚠️  failed to check stored file %s: %v", file.key, err)
+	}
+}
+
+// removeUnreferencedFiles removes the files of deleted records that no record refers to any longer, according to
+// existing; a file stays as long as a record of an identical upload refers to it. Failures are logged: a file left
+// behind only takes up space, and deleteOrphanedFiles removes it later.
+func removeUnreferencedFiles(ctx context.Context, storage Storage, existing func(ctx context.Context, keys []string) (map[string]bool, error), keys []string) {
+	referenced, err := existing(ctx, keys)
+	if err != nil {
+		log.Printf("⚠️  failed to retrieve stored file records: %v", err)
+		return
+	}
+	for _, key := range keys {
+		if referenced[key] {
+			continue
+		}
+		if err := storage.Delete(ctx, key); err != nil {
+			log.Printf("⚠️  failed to remove file %s: %v", key, err)
+		}
+	}
+}
+
+func typeNames(types map[string]string) string {
+	names := make([]string, 0, len(types))
+	for contentType := range types {
+		names = append(names, contentType)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// deleteOrphanedFiles removes the files in storage last modified before the given time that no record refers to,
+// according to existing, and returns how many were removed
+func deleteOrphanedFiles(ctx context.Context, storage Storage, existing func(ctx context.Context, keys []string) (map[string]bool, error), modifiedBefore time.Time) (int, error) {
+	// Files are checked in batches, so that the listing does not hold every key of the storage in memory
+	var batch []string
+	deleted := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		referenced, err := existing(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve stored file records: %w", err)
+		}
+		for _, key := range batch {
+			if referenced[key] {
+				continue
+			}
+			if err := storage.Delete(ctx, key); err != nil {
+				return fmt.Errorf("failed to delete file %s: %w", key, err)
+			}
+			deleted++
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err := storage.List(ctx, func(object StoredObject) error {
+		// Recent files may belong to an upload whose record is not committed yet
+		if !object.ModifiedAt.Before(modifiedBefore) {
+			return nil
+		}
+		batch = append(batch, object.Key)
+		if len(batch) == orphanBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = flush()
+	}
+	return deleted, err
+}
+
+var errLongerThanDeclared = errors.New("file is longer than its declared size")
+
+// exactReader reads exactly remaining bytes from r, failing if it has more or fewer,
+// so that a storage is never left with a file of another size than the one recorded
+type exactReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	if e.remaining <= 0 {
+		// Check that nothing follows the expected end
+		var b [1]byte
+		if n, _ := e.r.Read(b[:]); n > 0 {
+			return 0, errLongerThanDeclared
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > e.remaining {
+		p = p[:e.remaining]
+	}
+	n, err := e.r.Read(p)
+	e.remaining -= int64(n)
+	if err == io.EOF && e.remaining > 0 {
+		return n, io.ErrUnexpectedEOF
+	}
+	if err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
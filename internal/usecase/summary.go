@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Intervals of the acquisition time series
+const (
+	IntervalMonth   = "month"
+	IntervalQuarter = "quarter"
+	IntervalYear    = "year"
+)
+
+const (
+	defaultTopItems = 10
+	maxTopItems     = 100
+)
+
+// DefaultPriceBands are the upper bounds of the price bands when none are requested
+var DefaultPriceBands = []int{10000, 50000, 100000, 500000, 1000000, 5000000}
+
+const maxPriceBands = 20
+
+// TopItemFields are the fields the most valuable items can be ranked by
+var TopItemFields = []string{"purchase_price"}
+
+// intervalMonths is the length of each interval in months
+var intervalMonths = map[string]int{
+	IntervalMonth:   1,
+	IntervalQuarter: 3,
+	IntervalYear:    12,
+}
+
+type SummaryUsecase interface {
+	// GetBrandSummary returns the item counts by brand, with the purchase price totals in currency when one is given
+	GetBrandSummary(ctx context.Context, currency string) (*BrandSummary, error)
+	// GetValueSummary returns the purchase prices of the items totalled overall and by category, in BaseCurrency if currency is empty
+	GetValueSummary(ctx context.Context, currency string) (*ValueSummary, error)
+	// GetAcquisitionStats returns the items purchased and the amount spent per month, quarter or year (IntervalMonth if empty)
+	GetAcquisitionStats(ctx context.Context, interval string) (*AcquisitionStats, error)
+	// GetTopItems returns the most valuable items, overall or per category
+	GetTopItems(ctx context.Context, input TopItemsInput) (*TopItems, error)
+	// GetPriceDistribution counts the items per purchase price band, bounds being the ascending upper bounds of the bands (DefaultPriceBands if empty)
+	GetPriceDistribution(ctx context.Context, bounds []int) (*PriceDistribution, error)
+}
+
+type BrandSummary struct {
+	Brands map[string]int `json:"brands"`
+	Total  int            `json:"total"`
+	// Value is only set when the summary was requested in a currency
+	Value *BrandValueSummary `json:"value,omitempty"`
+}
+
+// BrandValueSummary holds purchase price totals converted to Currency at ExchangeRate (the worth of 1 BaseCurrency)
+type BrandValueSummary struct {
+	Currency     string             `json:"currency"`
+	Brands       map[string]float64 `json:"brands"`
+	Total        float64            `json:"total"`
+	ExchangeRate float64            `json:"exchange_rate"`
+	RateDate     string             `json:"rate_date,omitempty"`
+}
+
+type AcquisitionStats struct {
+	Interval string              `json:"interval"`
+	Buckets  []AcquisitionBucket `json:"buckets"`
+}
+
+// AcquisitionBucket counts the items purchased in a period (such as 2024-03, 2024-Q1 or 2024); Spend totals the
+// purchase prices of those bought in BaseCurrency
+type AcquisitionBucket struct {
+	Period string `json:"period"`
+	Start  string `json:"start"`
+	Count  int    `json:"count"`
+	Spend  int    `json:"spend"`
+}
+
+type TopItemsInput struct {
+	// TenantID is the tenant asking; the categories it added are ranked and valid for Category
+	TenantID string
+	// N is the number of items (per category with PerCategory), 10 if 0
+	N int
+	// By is one of TopItemFields (purchase_price if empty)
+	By          string
+	Category    string
+	PerCategory bool
+}
+
+// TopItems holds the items bought in BaseCurrency, as prices in different currencies cannot be compared,
+// ranked in Items, or in Categories when they were requested per category
+type TopItems struct {
+	By         string                    `json:"by"`
+	Items      []*entity.Item            `json:"items,omitempty"`
+	Categories map[string][]*entity.Item `json:"categories,omitempty"`
+}
+
+type PriceDistribution struct {
+	Bands []PriceBand `json:"bands"`
+	Total int         `json:"total"`
+}
+
+// PriceBand counts the items bought in BaseCurrency with Min <= purchase_price < Max. The last band has no Max.
+type PriceBand struct {
+	Min   int  `json:"min"`
+	Max   *int `json:"max,omitempty"`
+	Count int  `json:"count"`
+}
+
+type summaryUsecase struct {
+	itemUsecase  ItemUsecase
+	itemRepo     ItemReader
+	categoryRepo CategoryRepository
+	converter    CurrencyConverter
+}
+
+// NewSummaryUsecase creates the summary usecase. The value summary is read through itemUsecase,
+// so it is converted (and cached) like the one of GET /items/summary.
+// categoryRepo may be nil, in which case the top items are only ranked within the built-in categories.
+func NewSummaryUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, categoryRepo CategoryRepository, converter CurrencyConverter) SummaryUsecase {
+	return &summaryUsecase{
+		itemUsecase:  itemUsecase,
+		itemRepo:     itemRepo,
+		categoryRepo: categoryRepo,
+		converter:    converter,
+	}
+}
+
+// GetBrandSummary aggregates in the repository (GROUP BY brand) rather than reading every item,
+// and converts the totals rather than each price, like the category value summary
+func (u *summaryUsecase) GetBrandSummary(ctx context.Context, currency string) (*BrandSummary, error) {
+	// Fetch the rate first so that an unsupported currency fails before the items are read
+	conversion, err := u.conversion(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := u.itemRepo.GetSummaryByBrand(ReadOnly(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brand summary: %w", err)
+	}
+
+	summary := &BrandSummary{Brands: make(map[string]int, len(totals))}
+	purchaseTotal := 0
+	for brand, brandTotals := range totals {
+		summary.Brands[brand] = brandTotals.Count
+		summary.Total += brandTotals.Count
+		purchaseTotal += brandTotals.PurchaseTotal
+	}
+
+	if conversion != nil {
+		summary.Value = &BrandValueSummary{
+			Currency:     conversion.To,
+			Brands:       make(map[string]float64, len(totals)),
+			Total:        conversion.Convert(purchaseTotal),
+			ExchangeRate: conversion.Rate,
+			RateDate:     conversion.RateDate,
+		}
+		for brand, brandTotals := range totals {
+			summary.Value.Brands[brand] = conversion.Convert(brandTotals.PurchaseTotal)
+		}
+	}
+
+	return summary, nil
+}
+
+func (u *summaryUsecase) GetValueSummary(ctx context.Context, currency string) (*ValueSummary, error) {
+	if currency == "" {
+		currency = BaseCurrency
+	}
+	return u.itemUsecase.GetValueSummary(ctx, currency)
+}
+
+// GetAcquisitionStats buckets the items by purchase_date. The series runs from the first to the last period
+// with a purchase, including the periods without one, so that it can be charted as is.
+func (u *summaryUsecase) GetAcquisitionStats(ctx context.Context, interval string) (*AcquisitionStats, error) {
+	if interval == "" {
+		interval = IntervalMonth
+	}
+	months, ok := intervalMonths[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: interval must be one of: %s, %s, %s", domainErrors.ErrInvalidInput, IntervalMonth, IntervalQuarter, IntervalYear)
+	}
+
+	buckets := make(map[time.Time]*AcquisitionBucket)
+	var first, last time.Time
+	err := u.itemRepo.Iterate(ReadOnly(ctx), ItemQuery{}, func(item *entity.Item) error {
+		date, err := time.Parse("2006-01-02", item.PurchaseDate)
+		if err != nil {
+			// 購入日が YYYY-MM-DD でないアイテム（バリデーション導入前のデータ）は数えない
+			return nil
+		}
+		start := periodStart(date, months)
+		bucket, ok := buckets[start]
+		if !ok {
+			bucket = &AcquisitionBucket{}
+			buckets[start] = bucket
+		}
+		bucket.Count++
+		if price, ok := basePrice(item); ok {
+			bucket.Spend += price
+		}
+
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get acquisition stats: %w", err)
+	}
+
+	stats := &AcquisitionStats{Interval: interval, Buckets: []AcquisitionBucket{}}
+	if len(buckets) == 0 {
+		return stats, nil
+	}
+	for start := first; !start.After(last); start = start.AddDate(0, months, 0) {
+		bucket := AcquisitionBucket{Period: periodName(start, interval), Start: start.Format("2006-01-02")}
+		if b, ok := buckets[start]; ok {
+			bucket.Count, bucket.Spend = b.Count, b.Spend
+		}
+		stats.Buckets = append(stats.Buckets, bucket)
+	}
+
+	return stats, nil
+}
+
+// GetTopItems ranks in the repository (ORDER BY ... LIMIT n), one query per category with PerCategory
+func (u *summaryUsecase) GetTopItems(ctx context.Context, input TopItemsInput) (*TopItems, error) {
+	if input.N == 0 {
+		input.N = defaultTopItems
+	}
+	if input.N < 1 || input.N > maxTopItems {
+		return nil, fmt.Errorf("%w: n must be between 1 and %d", domainErrors.ErrInvalidInput, maxTopItems)
+	}
+	if input.By == "" {
+		input.By = TopItemFields[0]
+	}
+	if !isTopItemField(input.By) {
+		return nil, fmt.Errorf("%w: by must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(TopItemFields, ", "))
+	}
+	ctx = ReadOnly(ctx)
+	if err := checkCategory(ctx, u.categoryRepo, input.TenantID, input.Category); err != nil {
+		return nil, err
+	}
+
+	top := &TopItems{By: input.By}
+	if !input.PerCategory {
+		items, err := u.topItems(ctx, input, input.Category)
+		if err != nil {
+			return nil, err
+		}
+		top.Items = items
+		return top, nil
+	}
+
+	categories := []string{input.Category}
+	if input.Category == "" {
+		custom, err := loadCustomCategories(ctx, u.categoryRepo, input.TenantID)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(entity.GetValidCategories(), custom...)
+	}
+	top.Categories = make(map[string][]*entity.Item, len(categories))
+	for _, category := range categories {
+		items, err := u.topItems(ctx, input, category)
+		if err != nil {
+			return nil, err
+		}
+		top.Categories[category] = items
+	}
+	return top, nil
+}
+
+func (u *summaryUsecase) topItems(ctx context.Context, input TopItemsInput, category string) ([]*entity.Item, error) {
+	items, err := u.itemRepo.FindAll(ctx, ItemQuery{
+		ItemFilter: ItemFilter{Category: category, Currency: BaseCurrency},
+		SortBy:     input.By,
+		SortOrder:  entity.SortDesc,
+		Limit:      input.N,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve top items: %w", err)
+	}
+	if items == nil {
+		items = []*entity.Item{}
+	}
+	return items, nil
+}
+
+// GetPriceDistribution buckets in the repository (GROUP BY band) rather than reading every item
+func (u *summaryUsecase) GetPriceDistribution(ctx context.Context, bounds []int) (*PriceDistribution, error) {
+	if len(bounds) == 0 {
+		bounds = DefaultPriceBands
+	}
+	if len(bounds) > maxPriceBands {
+		return nil, fmt.Errorf("%w: at most %d buckets can be given", domainErrors.ErrInvalidInput, maxPriceBands)
+	}
+	for i, bound := range bounds {
+		if bound <= 0 || (i > 0 && bound <= bounds[i-1]) {
+			return nil, fmt.Errorf("%w: buckets must be positive and in ascending order", domainErrors.ErrInvalidInput)
+		}
+	}
+
+	counts, err := u.itemRepo.CountByPriceBands(ReadOnly(ctx), bounds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items by price band: %w", err)
+	}
+
+	distribution := &PriceDistribution{Bands: make([]PriceBand, len(counts))}
+	for i, count := range counts {
+		band := PriceBand{Count: count}
+		if i > 0 {
+			band.Min = bounds[i-1]
+		}
+		if i < len(bounds) {
+			band.Max = &bounds[i]
+		}
+		distribution.Bands[i] = band
+		distribution.Total += count
+	}
+	return distribution, nil
+}
+
+func isTopItemField(field string) bool {
+	for _, f := range TopItemFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// periodStart returns the first day of the period of the given length in months that date falls in
+func periodStart(date time.Time, months int) time.Time {
+	month := (int(date.Month())-1)/months*months + 1
+	return time.Date(date.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func periodName(start time.Time, interval string) string {
+	switch interval {
+	case IntervalQuarter:
+		return fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+	case IntervalYear:
+		return start.Format("2006")
+	default:
+		return start.Format("2006-01")
+	}
+}
+
+// conversion returns the conversion from BaseCurrency to currency, or nil if no currency was requested
+func (u *summaryUsecase) conversion(ctx context.Context, currency string) (*Conversion, error) {
+	if currency == "" {
+		return nil, nil
+	}
+	normalized, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	return u.converter.Conversion(ctx, BaseCurrency, normalized)
+}
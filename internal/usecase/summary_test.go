@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestSummaryUsecase_GetBrandSummary(t *testing.T) {
+	ctx := context.Background()
+	totals := map[string]*BrandTotals{
+		"ROLEX":  {Count: 2, PurchaseTotal: 3000000},
+		"HERMES": {Count: 1, PurchaseTotal: 2000000},
+	}
+
+	t.Run("正常系: ブランドごとの件数", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByBrand", mock.Anything).Return(totals, nil)
+		provider := &fixedRateProvider{rates: ecbRates}
+
+		summary, err := NewSummaryUsecase(nil, itemRepo, nil, NewCurrencyConverter(provider)).GetBrandSummary(ctx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"ROLEX": 2, "HERMES": 1}, summary.Brands)
+		assert.Equal(t, 3, summary.Total)
+		assert.Nil(t, summary.Value)
+		assert.Zero(t, provider.calls)
+	})
+
+	t.Run("正常系: 通貨を指定すると購入価格の合計を換算する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByBrand", mock.Anything).Return(totals, nil)
+
+		summary, err := NewSummaryUsecase(nil, itemRepo, nil, NewCurrencyConverter(&fixedRateProvider{rates: ecbRates})).GetBrandSummary(ctx, "usd")
+
+		require.NoError(t, err)
+		require.NotNil(t, summary.Value)
+		assert.Equal(t, "USD", summary.Value.Currency)
+		assert.Equal(t, map[string]float64{"ROLEX": 20625, "HERMES": 13750}, summary.Value.Brands)
+		assert.Equal(t, 34375.0, summary.Value.Total)
+		assert.Equal(t, "2024-03-08", summary.Value.RateDate)
+	})
+
+	t.Run("異常系: 対応していない通貨はアイテムを読む前に失敗する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := NewSummaryUsecase(nil, itemRepo, nil, NewCurrencyConverter(&fixedRateProvider{rates: ecbRates})).GetBrandSummary(ctx, "XXX")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "GetSummaryByBrand", mock.Anything)
+	})
+
+	t.Run("異常系: 集計に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("GetSummaryByBrand", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewSummaryUsecase(nil, itemRepo, nil, NewCurrencyConverter(&fixedRateProvider{rates: ecbRates})).GetBrandSummary(ctx, "")
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestSummaryUsecase_GetValueSummary(t *testing.T) {
+	ctx := context.Background()
+	items := []*entity.Item{
+		{ID: 1, Category: "時計", PurchasePrice: 1000000},
+		{ID: 2, Category: "バッグ", PurchasePrice: 300000},
+	}
+
+	newUsecase := func(itemRepo *MockItemRepository) SummaryUsecase {
+		converter := NewCurrencyConverter(&fixedRateProvider{rates: ecbRates})
+		return NewSummaryUsecase(NewCurrencyConvertingItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), converter), itemRepo, nil, converter)
+	}
+
+	t.Run("正常系: 通貨の指定がなければ円で合計する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		summary, err := newUsecase(itemRepo).GetValueSummary(ctx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "JPY", summary.Currency)
+		assert.Equal(t, 1000000.0, summary.Categories["時計"])
+		assert.Equal(t, 0.0, summary.Categories["靴"])
+		assert.Equal(t, 1300000.0, summary.Total)
+	})
+
+	t.Run("正常系: 指定の通貨に換算する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+		summary, err := newUsecase(itemRepo).GetValueSummary(ctx, "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, "USD", summary.Currency)
+		assert.Equal(t, 8937.5, summary.Total)
+	})
+
+	t.Run("異常系: 不正な通貨", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := newUsecase(itemRepo).GetValueSummary(ctx, "円")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSummaryUsecase_GetAcquisitionStats(t *testing.T) {
+	ctx := context.Background()
+	items := []*entity.Item{
+		{ID: 1, PurchaseDate: "2023-11-20", PurchasePrice: 300000},
+		{ID: 2, PurchaseDate: "2024-02-01", PurchasePrice: 1500000},
+		{ID: 3, PurchaseDate: "2024-02-29", PurchasePrice: 500000},
+		{ID: 4, PurchaseDate: "2024-04-10", PurchasePrice: 200000},
+		{ID: 5, PurchaseDate: "不明", PurchasePrice: 100000},
+	}
+
+	tests := []struct {
+		name     string
+		interval string
+		expected []AcquisitionBucket
+	}{
+		{
+			name: "正常系: 既定は月ごと。購入のない月も0で含める",
+			expected: []AcquisitionBucket{
+				{Period: "2023-11", Start: "2023-11-01", Count: 1, Spend: 300000},
+				{Period: "2023-12", Start: "2023-12-01"},
+				{Period: "2024-01", Start: "2024-01-01"},
+				{Period: "2024-02", Start: "2024-02-01", Count: 2, Spend: 2000000},
+				{Period: "2024-03", Start: "2024-03-01"},
+				{Period: "2024-04", Start: "2024-04-01", Count: 1, Spend: 200000},
+			},
+		},
+		{
+			name:     "正常系: 四半期ごと",
+			interval: "quarter",
+			expected: []AcquisitionBucket{
+				{Period: "2023-Q4", Start: "2023-10-01", Count: 1, Spend: 300000},
+				{Period: "2024-Q1", Start: "2024-01-01", Count: 2, Spend: 2000000},
+				{Period: "2024-Q2", Start: "2024-04-01", Count: 1, Spend: 200000},
+			},
+		},
+		{
+			name:     "正常系: 年ごと",
+			interval: "year",
+			expected: []AcquisitionBucket{
+				{Period: "2023", Start: "2023-01-01", Count: 1, Spend: 300000},
+				{Period: "2024", Start: "2024-01-01", Count: 3, Spend: 2200000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems(items, nil))
+
+			stats, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetAcquisitionStats(ctx, tt.interval)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stats.Buckets)
+		})
+	}
+
+	t.Run("正常系: ほかの通貨のアイテムは数えるが合計には含めない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{
+			{ID: 1, PurchaseDate: "2024-02-01", PurchasePrice: 1500000},
+			{ID: 2, PurchaseDate: "2024-02-10", PurchasePrice: 1299900, Currency: "USD"},
+		}, nil))
+
+		stats, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetAcquisitionStats(ctx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []AcquisitionBucket{{Period: "2024-02", Start: "2024-02-01", Count: 2, Spend: 1500000}}, stats.Buckets)
+	})
+
+	t.Run("正常系: アイテムがなければ空", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Iterate", mock.Anything, ItemQuery{}, mock.Anything).Return(iterateItems([]*entity.Item{}, nil))
+
+		stats, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetAcquisitionStats(ctx, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, &AcquisitionStats{Interval: "month", Buckets: []AcquisitionBucket{}}, stats)
+	})
+
+	t.Run("異常系: 不正な間隔", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+
+		_, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetAcquisitionStats(ctx, "week")
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "Iterate", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSummaryUsecase_GetTopItems(t *testing.T) {
+	ctx := context.Background()
+	watches := []*entity.Item{{ID: 1, Category: "時計", PurchasePrice: 1500000}, {ID: 3, Category: "時計", PurchasePrice: 800000}}
+
+	t.Run("正常系: 並び替えと件数の制限はリポジトリで行い、BaseCurrency のアイテムだけを比べる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{ItemFilter: ItemFilter{Currency: BaseCurrency}, SortBy: "purchase_price", SortOrder: "desc", Limit: 10}).Return(watches, nil)
+
+		top, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetTopItems(ctx, TopItemsInput{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "purchase_price", top.By)
+		assert.Equal(t, watches, top.Items)
+		assert.Nil(t, top.Categories)
+	})
+
+	t.Run("正常系: カテゴリーごと", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, ItemQuery{ItemFilter: ItemFilter{Category: "時計", Currency: BaseCurrency}, SortBy: "purchase_price", SortOrder: "desc", Limit: 2}).Return(watches, nil)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), nil)
+
+		top, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetTopItems(ctx, TopItemsInput{N: 2, PerCategory: true})
+
+		require.NoError(t, err)
+		assert.Nil(t, top.Items)
+		assert.Len(t, top.Categories, len(entity.GetValidCategories()))
+		assert.Equal(t, watches, top.Categories["時計"])
+		assert.Equal(t, []*entity.Item{}, top.Categories["靴"])
+	})
+
+	tests := []struct {
+		name  string
+		input TopItemsInput
+	}{
+		{name: "異常系: 件数が多すぎる", input: TopItemsInput{N: 101}},
+		{name: "異常系: 件数が負", input: TopItemsInput{N: -1}},
+		{name: "異常系: 並び替えられない項目", input: TopItemsInput{By: "name"}},
+		{name: "異常系: 不正なカテゴリー", input: TopItemsInput{Category: "車"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+
+			_, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetTopItems(ctx, tt.input)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSummaryUsecase_GetPriceDistribution(t *testing.T) {
+	ctx := context.Background()
+	upper := func(v int) *int { return &v }
+
+	t.Run("正常系: 指定の境界で数える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("CountByPriceBands", mock.Anything, []int{100000, 1000000}).Return([]int{2, 3, 1}, nil)
+
+		distribution, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetPriceDistribution(ctx, []int{100000, 1000000})
+
+		require.NoError(t, err)
+		assert.Equal(t, &PriceDistribution{
+			Bands: []PriceBand{
+				{Min: 0, Max: upper(100000), Count: 2},
+				{Min: 100000, Max: upper(1000000), Count: 3},
+				{Min: 1000000, Count: 1},
+			},
+			Total: 6,
+		}, distribution)
+	})
+
+	t.Run("正常系: 境界の指定がなければ既定の帯", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("CountByPriceBands", mock.Anything, DefaultPriceBands).Return(make([]int, len(DefaultPriceBands)+1), nil)
+
+		distribution, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetPriceDistribution(ctx, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, distribution.Bands, len(DefaultPriceBands)+1)
+		assert.Zero(t, distribution.Total)
+	})
+
+	tests := []struct {
+		name   string
+		bounds []int
+	}{
+		{name: "異常系: 昇順でない", bounds: []int{100000, 50000}},
+		{name: "異常系: 同じ境界", bounds: []int{100000, 100000}},
+		{name: "異常系: 0以下", bounds: []int{0, 100000}},
+		{name: "異常系: 境界が多すぎる", bounds: make([]int, 21)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+
+			_, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetPriceDistribution(ctx, tt.bounds)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			itemRepo.AssertNotCalled(t, "CountByPriceBands", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("異常系: 集計に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("CountByPriceBands", mock.Anything, DefaultPriceBands).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewSummaryUsecase(nil, itemRepo, nil, nil).GetPriceDistribution(ctx, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
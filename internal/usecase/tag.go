@@ -0,0 +1,478 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// maxItemTags is the number of tags an item may have
+const maxItemTags = 20
+
+// TagUsecase manages the tags shared by all items and the tags of each item.
+// Any change to the tags of an item, including renaming, merging or deleting one of them,
+// increments the item version, since its tags field changes with them.
+type TagUsecase interface {
+	ListTags(ctx context.Context) ([]*entity.Tag, error)
+	CreateTag(ctx context.Context, input TagInput) (*entity.Tag, error)
+	GetTag(ctx context.Context, id int64) (*entity.Tag, error)
+	// RenameTag renames a tag on every item it is attached to. Renaming it to the name of another tag
+	// is a conflict; MergeTags is used to combine them instead.
+	RenameTag(ctx context.Context, id int64, input TagInput) (*entity.Tag, error)
+	// DeleteTag deletes a tag and removes it from its items
+	DeleteTag(ctx context.Context, id int64) error
+	// MergeTags moves the items of the source tags to the target tag and deletes the source tags
+	MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (*entity.Tag, error)
+	ListItemTags(ctx context.Context, itemID int64) ([]*entity.Tag, error)
+	// SetItemTags replaces the tags of an item, creating the tags that do not exist yet
+	SetItemTags(ctx context.Context, actor string, itemID int64, names []string) ([]*entity.Tag, error)
+}
+
+type TagInput struct {
+	Name string `json:"name"`
+}
+
+type tagUsecase struct {
+	itemRepo ItemRepository
+	tagRepo  TagRepository
+	uow      UnitOfWork
+	now      func() time.Time
+}
+
+// NewTagUsecase creates the tag usecase; uow may be nil, in which case no transactions are used
+func NewTagUsecase(itemRepo ItemRepository, tagRepo TagRepository, uow UnitOfWork) TagUsecase {
+	return &tagUsecase{
+		itemRepo: itemRepo,
+		tagRepo:  tagRepo,
+		uow:      uow,
+		now:      time.Now,
+	}
+}
+
+func (u *tagUsecase) ListTags(ctx context.Context) ([]*entity.Tag, error) {
+	tags, err := u.tagRepo.FindAll(ReadOnly(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	if tags == nil {
+		tags = []*entity.Tag{}
+	}
+
+	return tags, nil
+}
+
+func (u *tagUsecase) CreateTag(ctx context.Context, input TagInput) (*entity.Tag, error) {
+	tag, err := entity.NewTag(input.Name, u.now())
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	if err := u.checkNameFree(ctx, tag.Name, 0); err != nil {
+		return nil, err
+	}
+
+	created, err := u.tagRepo.Create(ctx, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *tagUsecase) GetTag(ctx context.Context, id int64) (*entity.Tag, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	return u.findTag(ReadOnly(ctx), id)
+}
+
+func (u *tagUsecase) RenameTag(ctx context.Context, id int64, input TagInput) (*entity.Tag, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	var renamed *entity.Tag
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		tag, err := u.findTag(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		tag.Name = entity.NormalizeTagName(input.Name)
+		if err := tag.Validate(); err != nil {
+			return domainErrors.Invalid(err)
+		}
+		// Changing only the case of the name is a rename of the same tag
+		if err := u.checkNameFree(ctx, tag.Name, id); err != nil {
+			return err
+		}
+
+		tag.UpdatedAt = u.now()
+		if err := u.tagRepo.Update(ctx, tag); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to update tag: %w", err)
+		}
+		if err := u.touchItemsWithTags(ctx, []int64{id}); err != nil {
+			return err
+		}
+
+		renamed = tag
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renamed, nil
+}
+
+func (u *tagUsecase) DeleteTag(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	return inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if _, err := u.findTag(ctx, id); err != nil {
+			return err
+		}
+		// The items are read before the tag is removed from them
+		if err := u.touchItemsWithTags(ctx, []int64{id}); err != nil {
+			return err
+		}
+
+		if err := u.tagRepo.Delete(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return err
+			}
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+		return nil
+	})
+}
+
+func (u *tagUsecase) MergeTags(ctx context.Context, targetID int64, sourceIDs []int64) (*entity.Tag, error) {
+	if targetID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	// Duplicates and the target itself are ignored, so that merging is safe to retry with the same request
+	var sources []int64
+	seen := map[int64]bool{targetID: true}
+	for _, id := range sourceIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: source_ids must be positive tag IDs", domainErrors.ErrInvalidInput)
+		}
+		if !seen[id] {
+			seen[id] = true
+			sources = append(sources, id)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%w: source_ids must contain a tag other than the target", domainErrors.ErrInvalidInput)
+	}
+
+	var merged *entity.Tag
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if _, err := u.findTag(ctx, targetID); err != nil {
+			return err
+		}
+		for _, id := range sources {
+			if _, err := u.findTag(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		// Only the items of the source tags change; the items of the target keep their tag
+		if err := u.touchItemsWithTags(ctx, sources); err != nil {
+			return err
+		}
+		if err := u.tagRepo.Merge(ctx, targetID, sources); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		tag, err := u.findTag(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		merged = tag
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+func (u *tagUsecase) ListItemTags(ctx context.Context, itemID int64) ([]*entity.Tag, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return u.itemTags(ctx, itemID)
+}
+
+func (u *tagUsecase) SetItemTags(ctx context.Context, actor string, itemID int64, names []string) ([]*entity.Tag, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	names, err := uniqueTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*entity.Tag
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		if item.OwnerID != "" && item.OwnerID != actor {
+			return domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+		}
+
+		ids, err := u.tagIDs(ctx, names)
+		if err != nil {
+			return err
+		}
+		if err := u.tagRepo.SetItemTags(ctx, itemID, ids); err != nil {
+			return fmt.Errorf("failed to update item tags: %w", err)
+		}
+
+		item.UpdatedAt = u.now()
+		if _, err := u.itemRepo.Update(ctx, item); err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+
+		tags, err = u.itemTags(ctx, itemID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// findTag retrieves a tag, reporting a missing tag as ErrTagNotFound
+func (u *tagUsecase) findTag(ctx context.Context, id int64) (*entity.Tag, error) {
+	tag, err := u.tagRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve tag: %w", err)
+	}
+	return tag, nil
+}
+
+// checkNameFree reports a conflict if a tag other than id already has the name, regardless of case
+func (u *tagUsecase) checkNameFree(ctx context.Context, name string, id int64) error {
+	existing, err := u.tagRepo.FindByNames(ctx, []string{name})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+	for _, tag := range existing {
+		if tag.ID != id {
+			return domainErrors.Conflict("tag %q already exists as tag %d; merge the tags instead", name, tag.ID)
+		}
+	}
+	return nil
+}
+
+// tagIDs returns the IDs of the tags with the given names, creating the missing tags
+func (u *tagUsecase) tagIDs(ctx context.Context, names []string) ([]int64, error) {
+	existing, err := u.tagRepo.FindByNames(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+	byName := make(map[string]int64, len(existing))
+	for _, tag := range existing {
+		byName[strings.ToLower(tag.Name)] = tag.ID
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		if id, ok := byName[strings.ToLower(name)]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		tag, err := entity.NewTag(name, u.now())
+		if err != nil {
+			return nil, domainErrors.Invalid(err)
+		}
+		created, err := u.tagRepo.Create(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tag: %w", err)
+		}
+		ids = append(ids, created.ID)
+	}
+	return ids, nil
+}
+
+func (u *tagUsecase) itemTags(ctx context.Context, itemID int64) ([]*entity.Tag, error) {
+	tags, err := u.tagRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	if tags == nil {
+		tags = []*entity.Tag{}
+	}
+
+	return tags, nil
+}
+
+// touchItemsWithTags increments the version of the items with any of the given tags
+func (u *tagUsecase) touchItemsWithTags(ctx context.Context, tagIDs []int64) error {
+	itemIDs, err := u.tagRepo.ItemIDs(ctx, tagIDs)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tagged items: %w", err)
+	}
+
+	now := u.now()
+	for _, id := range itemIDs {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			// Tags left on a deleted item are removed along with the tag
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		item.UpdatedAt = now
+		if _, err := u.itemRepo.Update(ctx, item); err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+	}
+	return nil
+}
+
+// uniqueTagNames normalizes the names and drops the ones repeated regardless of case, keeping the first
+func uniqueTagNames(names []string) ([]string, error) {
+	var unique []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		tag := entity.Tag{Name: entity.NormalizeTagName(name)}
+		if err := tag.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: tags: %s", domainErrors.ErrInvalidInput, err.Error())
+		}
+		if key := strings.ToLower(tag.Name); !seen[key] {
+			seen[key] = true
+			unique = append(unique, tag.Name)
+		}
+	}
+	if len(unique) > maxItemTags {
+		return nil, fmt.Errorf("%w: tags must contain %d tags or less", domainErrors.ErrInvalidInput, maxItemTags)
+	}
+	return unique, nil
+}
+
+type tagItemUsecase struct {
+	ItemUsecase
+	tagRepo TagRepository
+}
+
+// NewTagItemUsecase fills in the tags of the items returned by inner, and removes the tags of deleted items
+func NewTagItemUsecase(inner ItemUsecase, tagRepo TagRepository) ItemUsecase {
+	return &tagItemUsecase{
+		ItemUsecase: inner,
+		tagRepo:     tagRepo,
+	}
+}
+
+func (u *tagItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
+	items, err := u.ItemUsecase.GetAllItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setTags(ReadOnly(ctx), items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (u *tagItemUsecase) ListItems(ctx context.Context, query ListItemsQuery) (*ItemList, error) {
+	list, err := u.ItemUsecase.ListItems(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setTags(ReadOnly(ctx), list.Items); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (u *tagItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, err := u.ItemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setTags(ReadOnly(ctx), []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *tagItemUsecase) PatchItem(ctx context.Context, id int64, req *UpdateItemRequest) (*entity.Item, error) {
+	item, err := u.ItemUsecase.PatchItem(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setTags(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem removes the tags of the item along with it, in the caller's transaction if any; the tags themselves are kept
+func (u *tagItemUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) error {
+	if err := u.ItemUsecase.DeleteItem(ctx, id, ifMatch); err != nil {
+		return err
+	}
+
+	if err := u.tagRepo.SetItemTags(ctx, id, nil); err != nil {
+		return fmt.Errorf("failed to remove item tags: %w", err)
+	}
+	return nil
+}
+
+func (u *tagItemUsecase) setTags(ctx context.Context, items []*entity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	names, err := u.tagRepo.ListNamesByItem(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	for _, item := range items {
+		item.Tags = names[item.ID]
+	}
+	return nil
+}
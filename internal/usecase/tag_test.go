@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockTagRepository はタグリポジトリのモック
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *entity.Tag) (*entity.Tag, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindAll(ctx context.Context) ([]*entity.Tag, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByID(ctx context.Context, id int64) (*entity.Tag, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByNames(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	args := m.Called(ctx, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *entity.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, targetID int64, sourceIDs []int64) error {
+	args := m.Called(ctx, targetID, sourceIDs)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) ItemIDs(ctx context.Context, tagIDs []int64) ([]int64, error) {
+	args := m.Called(ctx, tagIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Tag, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) SetItemTags(ctx context.Context, itemID int64, tagIDs []int64) error {
+	args := m.Called(ctx, itemID, tagIDs)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) ListNamesByItem(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]string), args.Error(1)
+}
+
+var tagNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestTagUsecase(itemRepo ItemRepository, tagRepo TagRepository) TagUsecase {
+	u := NewTagUsecase(itemRepo, tagRepo, nil).(*tagUsecase)
+	u.now = func() time.Time { return tagNow }
+	return u
+}
+
+// expectTouched は items のバージョンが上がることを期待する
+func expectTouched(itemRepo *MockItemRepository, ids ...int64) {
+	for _, id := range ids {
+		item := &entity.Item{ID: id}
+		itemRepo.On("FindByID", mock.Anything, id).Return(item, nil)
+		itemRepo.On("Update", mock.Anything, item).Return(item, nil)
+	}
+}
+
+func TestTagUsecase_CreateTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		existing    []*entity.Tag
+		expectedErr error
+	}{
+		{name: "正常系: 空白をまとめて登録する", input: "  Vintage   Watch ", existing: nil},
+		{name: "異常系: 名前が空", input: "   ", expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 長すぎる名前", input: fmt.Sprintf("%051d", 0), expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 大文字・小文字だけが違う名前がある", input: "vintage watch", existing: []*entity.Tag{{ID: 3, Name: "Vintage Watch"}}, expectedErr: domainErrors.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagRepo := new(MockTagRepository)
+			tagRepo.On("FindByNames", mock.Anything, mock.Anything).Return(tt.existing, nil).Maybe()
+			tagRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Tag{ID: 1, Name: "Vintage Watch"}, nil).Maybe()
+
+			tag, err := newTestTagUsecase(nil, tagRepo).CreateTag(context.Background(), TagInput{Name: tt.input})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tag)
+				tagRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), tag.ID)
+			tagRepo.AssertCalled(t, "FindByNames", mock.Anything, []string{"Vintage Watch"})
+		})
+	}
+}
+
+func TestTagUsecase_RenameTag(t *testing.T) {
+	t.Run("正常系: 名前を変えてタグの付いたアイテムのバージョンを上げる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		expectTouched(itemRepo, 1, 2)
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Tag{ID: 5, Name: "vintage"}, nil)
+		tagRepo.On("FindByNames", mock.Anything, []string{"Antique"}).Return(nil, nil)
+		tagRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		tagRepo.On("ItemIDs", mock.Anything, []int64{5}).Return([]int64{1, 2}, nil)
+
+		tag, err := newTestTagUsecase(itemRepo, tagRepo).RenameTag(context.Background(), 5, TagInput{Name: "Antique"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Antique", tag.Name)
+		assert.Equal(t, tagNow, tag.UpdatedAt)
+		itemRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("正常系: 大文字・小文字だけを変える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Tag{ID: 5, Name: "vintage"}, nil)
+		tagRepo.On("FindByNames", mock.Anything, []string{"Vintage"}).Return([]*entity.Tag{{ID: 5, Name: "vintage"}}, nil)
+		tagRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		tagRepo.On("ItemIDs", mock.Anything, []int64{5}).Return(nil, nil)
+
+		tag, err := newTestTagUsecase(itemRepo, tagRepo).RenameTag(context.Background(), 5, TagInput{Name: "Vintage"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Vintage", tag.Name)
+	})
+
+	t.Run("異常系: ほかのタグと同じ名前", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Tag{ID: 5, Name: "vintage"}, nil)
+		tagRepo.On("FindByNames", mock.Anything, []string{"antique"}).Return([]*entity.Tag{{ID: 6, Name: "Antique"}}, nil)
+
+		tag, err := newTestTagUsecase(nil, tagRepo).RenameTag(context.Background(), 5, TagInput{Name: "antique"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrConflict)
+		assert.Nil(t, tag)
+		tagRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: タグが存在しない", func(t *testing.T) {
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("FindByID", mock.Anything, int64(5)).Return(nil, domainErrors.ErrTagNotFound)
+
+		_, err := newTestTagUsecase(nil, tagRepo).RenameTag(context.Background(), 5, TagInput{Name: "antique"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrTagNotFound)
+	})
+}
+
+func TestTagUsecase_DeleteTag(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	expectTouched(itemRepo, 1)
+	tagRepo := new(MockTagRepository)
+	tagRepo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Tag{ID: 5, Name: "vintage"}, nil)
+	tagRepo.On("ItemIDs", mock.Anything, []int64{5}).Return([]int64{1}, nil)
+	tagRepo.On("Delete", mock.Anything, int64(5)).Return(nil)
+
+	err := newTestTagUsecase(itemRepo, tagRepo).DeleteTag(context.Background(), 5)
+
+	require.NoError(t, err)
+	itemRepo.AssertNumberOfCalls(t, "Update", 1)
+	tagRepo.AssertExpectations(t)
+}
+
+func TestTagUsecase_MergeTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		sourceIDs   []int64
+		missing     int64
+		expectedErr error
+	}{
+		{name: "正常系: 重複と統合先を除いて統合する", sourceIDs: []int64{6, 7, 6, 5}},
+		{name: "異常系: 統合先しかない", sourceIDs: []int64{5}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 不正なID", sourceIDs: []int64{6, 0}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: 統合元が存在しない", sourceIDs: []int64{6, 7}, missing: 7, expectedErr: domainErrors.ErrTagNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			expectTouched(itemRepo, 1, 2)
+			tagRepo := new(MockTagRepository)
+			for _, id := range []int64{5, 6, 7} {
+				if id == tt.missing {
+					tagRepo.On("FindByID", mock.Anything, id).Return(nil, domainErrors.ErrTagNotFound)
+					continue
+				}
+				tagRepo.On("FindByID", mock.Anything, id).Return(&entity.Tag{ID: id, ItemCount: 3}, nil).Maybe()
+			}
+			tagRepo.On("ItemIDs", mock.Anything, []int64{6, 7}).Return([]int64{1, 2}, nil).Maybe()
+			tagRepo.On("Merge", mock.Anything, int64(5), []int64{6, 7}).Return(nil).Maybe()
+
+			tag, err := newTestTagUsecase(itemRepo, tagRepo).MergeTags(context.Background(), 5, tt.sourceIDs)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tag)
+				tagRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), tag.ID)
+			tagRepo.AssertCalled(t, "Merge", mock.Anything, int64(5), []int64{6, 7})
+			itemRepo.AssertNumberOfCalls(t, "Update", 2)
+		})
+	}
+}
+
+func TestTagUsecase_SetItemTags(t *testing.T) {
+	tooMany := make([]string, maxItemTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag%d", i)
+	}
+
+	tests := []struct {
+		name        string
+		actor       string
+		names       []string
+		expectedErr error
+	}{
+		{name: "正常系: ない名前のタグを作って付け替える", actor: "alice", names: []string{"vintage", " Gift ", "VINTAGE"}},
+		{name: "正常系: 空にすると外す", actor: "alice", names: []string{}},
+		{name: "異常系: 所有者以外", actor: "bob", names: []string{"vintage"}, expectedErr: domainErrors.ErrForbidden},
+		{name: "異常系: 空の名前", actor: "alice", names: []string{"vintage", " "}, expectedErr: domainErrors.ErrInvalidInput},
+		{name: "異常系: タグが多すぎる", actor: "alice", names: tooMany, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			item := &entity.Item{ID: 1, OwnerID: "alice"}
+			itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Maybe()
+			itemRepo.On("Update", mock.Anything, item).Return(item, nil).Maybe()
+			tagRepo := new(MockTagRepository)
+			tagRepo.On("FindByNames", mock.Anything, mock.Anything).Return([]*entity.Tag{{ID: 5, Name: "Vintage"}}, nil).Maybe()
+			tagRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Tag{ID: 6, Name: "Gift"}, nil).Maybe()
+			tagRepo.On("SetItemTags", mock.Anything, int64(1), mock.Anything).Return(nil).Maybe()
+			tagRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, nil).Maybe()
+
+			tags, err := newTestTagUsecase(itemRepo, tagRepo).SetItemTags(context.Background(), tt.actor, 1, tt.names)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tags)
+				tagRepo.AssertNotCalled(t, "SetItemTags", mock.Anything, mock.Anything, mock.Anything)
+				itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []*entity.Tag{}, tags)
+			itemRepo.AssertCalled(t, "Update", mock.Anything, item)
+			if len(tt.names) > 0 {
+				tagRepo.AssertCalled(t, "FindByNames", mock.Anything, []string{"vintage", "Gift"})
+				tagRepo.AssertNumberOfCalls(t, "Create", 1)
+				tagRepo.AssertCalled(t, "SetItemTags", mock.Anything, int64(1), []int64{5, 6})
+			}
+		})
+	}
+}
+
+func TestTagItemUsecase(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 一覧のアイテムにタグを付ける", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("ListNamesByItem", mock.Anything, []int64{1, 2}).Return(map[int64][]string{2: {"gift", "vintage"}}, nil)
+
+		items, err := NewTagItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), tagRepo).GetAllItems(ctx)
+
+		require.NoError(t, err)
+		assert.Nil(t, items[0].Tags)
+		assert.Equal(t, []string{"gift", "vintage"}, items[1].Tags)
+	})
+
+	t.Run("正常系: アイテムの削除でタグを外す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		tagRepo := new(MockTagRepository)
+		tagRepo.On("SetItemTags", mock.Anything, int64(1), []int64(nil)).Return(nil)
+
+		err := NewTagItemUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), tagRepo).DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		tagRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const maxBulkTransferSize = 100
+
+type TransferUsecase interface {
+	RequestTransfer(ctx context.Context, actor string, itemID int64, input TransferInput) (*entity.Transfer, error)
+	RequestBulkTransfer(ctx context.Context, actor string, input BulkTransferInput) ([]*entity.Transfer, error)
+	AcceptTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error)
+	RejectTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error)
+	CancelTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error)
+	ListIncomingTransfers(ctx context.Context, actor string) ([]*entity.Transfer, error)
+	ListItemTransfers(ctx context.Context, itemID int64) ([]*entity.Transfer, error)
+}
+
+type TransferInput struct {
+	ToUser string `json:"to_user"`
+	Note   string `json:"note"`
+}
+
+type BulkTransferInput struct {
+	ItemIDs []int64 `json:"item_ids"`
+	ToUser  string  `json:"to_user"`
+	Note    string  `json:"note"`
+}
+
+type transferUsecase struct {
+	itemRepo     ItemRepository
+	transferRepo TransferRepository
+	uow          UnitOfWork
+}
+
+// NewTransferUsecase creates the transfer usecase; uow may be nil, in which case no transactions are used
+func NewTransferUsecase(itemRepo ItemRepository, transferRepo TransferRepository, uow UnitOfWork) TransferUsecase {
+	return &transferUsecase{
+		itemRepo:     itemRepo,
+		transferRepo: transferRepo,
+		uow:          uow,
+	}
+}
+
+func (u *transferUsecase) RequestTransfer(ctx context.Context, actor string, itemID int64, input TransferInput) (*entity.Transfer, error) {
+	var created *entity.Transfer
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		transfer, err := u.prepareTransfer(ctx, actor, itemID, input.ToUser, input.Note)
+		if err != nil {
+			return err
+		}
+
+		created, err = u.transferRepo.Create(ctx, transfer)
+		if err != nil {
+			return fmt.Errorf("failed to create transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// RequestBulkTransfer checks every item before creating any transfer and creates them in one transaction,
+// so a bad ID or a failed insert leaves nothing half-done
+func (u *transferUsecase) RequestBulkTransfer(ctx context.Context, actor string, input BulkTransferInput) ([]*entity.Transfer, error) {
+	if len(input.ItemIDs) == 0 {
+		return nil, fmt.Errorf("%w: item_ids is required", domainErrors.ErrInvalidInput)
+	}
+	if len(input.ItemIDs) > maxBulkTransferSize {
+		return nil, fmt.Errorf("%w: item_ids must contain %d items or less", domainErrors.ErrInvalidInput, maxBulkTransferSize)
+	}
+
+	seen := make(map[int64]bool, len(input.ItemIDs))
+	for _, id := range input.ItemIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate item id: %d", domainErrors.ErrInvalidInput, id)
+		}
+		seen[id] = true
+	}
+
+	var created []*entity.Transfer
+	err := inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		transfers := make([]*entity.Transfer, 0, len(input.ItemIDs))
+		for _, id := range input.ItemIDs {
+			transfer, err := u.prepareTransfer(ctx, actor, id, input.ToUser, input.Note)
+			if err != nil {
+				return err
+			}
+			transfers = append(transfers, transfer)
+		}
+
+		created = make([]*entity.Transfer, 0, len(transfers))
+		for _, transfer := range transfers {
+			t, err := u.transferRepo.Create(ctx, transfer)
+			if err != nil {
+				return fmt.Errorf("failed to create transfer: %w", err)
+			}
+			created = append(created, t)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// AcceptTransfer moves the item to the recipient; only the recipient may accept
+func (u *transferUsecase) AcceptTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return u.resolve(ctx, actor, id, entity.TransferStatusAccepted)
+}
+
+// RejectTransfer declines a transfer; only the recipient may reject
+func (u *transferUsecase) RejectTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return u.resolve(ctx, actor, id, entity.TransferStatusRejected)
+}
+
+// CancelTransfer withdraws a transfer; only the sender may cancel
+func (u *transferUsecase) CancelTransfer(ctx context.Context, actor string, id int64) (*entity.Transfer, error) {
+	return u.resolve(ctx, actor, id, entity.TransferStatusCancelled)
+}
+
+func (u *transferUsecase) ListIncomingTransfers(ctx context.Context, actor string) ([]*entity.Transfer, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+
+	transfers, err := u.transferRepo.FindPendingByRecipient(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func (u *transferUsecase) ListItemTransfers(ctx context.Context, itemID int64) ([]*entity.Transfer, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	transfers, err := u.transferRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// prepareTransfer validates that the actor may hand over the item and builds a pending transfer.
+// Items without an owner (created before ownership was tracked) may be handed over by any user.
+func (u *transferUsecase) prepareTransfer(ctx context.Context, actor string, itemID int64, toUser, note string) (*entity.Transfer, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+	if itemID <= 0 {
+		return nil, fmt.Errorf("%w: invalid item id: %d", domainErrors.ErrInvalidInput, itemID)
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.NotFound(domainErrors.ErrItemNotFound, "id %d", itemID)
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if item.OwnerID != "" && item.OwnerID != actor {
+		return nil, domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+	}
+
+	history, err := u.transferRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve transfers: %w", err)
+	}
+	for _, t := range history {
+		if t.IsPending() {
+			return nil, domainErrors.Conflict("item %d already has a pending transfer", itemID)
+		}
+	}
+
+	transfer, err := entity.NewTransfer(itemID, actor, toUser, note)
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	return transfer, nil
+}
+
+// moveItem makes the recipient of an accepted transfer the owner of the item
+func (u *transferUsecase) moveItem(ctx context.Context, transfer *entity.Transfer) error {
+	item, err := u.itemRepo.FindByID(ctx, transfer.ItemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	item.OwnerID = transfer.ToUser
+	item.UpdatedAt = time.Now()
+	if _, err := u.itemRepo.Update(ctx, item); err != nil {
+		return fmt.Errorf("failed to update item owner: %w", err)
+	}
+	return nil
+}
+
+func (u *transferUsecase) resolve(ctx context.Context, actor string, id int64, status string) (*entity.Transfer, error) {
+	if actor == "" {
+		return nil, fmt.Errorf("%w: user is required", domainErrors.ErrInvalidInput)
+	}
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	transfer, err := u.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve transfer: %w", err)
+	}
+
+	allowed := transfer.ToUser
+	if status == entity.TransferStatusCancelled {
+		allowed = transfer.FromUser
+	}
+	if actor != allowed {
+		return nil, domainErrors.PermissionDenied("transfer %d cannot be %s by %s", id, status, actor)
+	}
+	if !transfer.IsPending() {
+		return nil, domainErrors.Conflict("transfer %d is already %s", id, transfer.Status)
+	}
+
+	transfer.Resolve(status, actor)
+	// 譲渡の状態と所有者の変更を同じトランザクションで保存する（アイテムが別のストアにある場合は、譲渡の確定後に所有者を変更する）
+	err = inTransaction(ctx, u.uow, func(ctx context.Context) error {
+		if err := u.transferRepo.Resolve(ctx, transfer); err != nil {
+			return err
+		}
+		if status != entity.TransferStatusAccepted {
+			return nil
+		}
+		return u.moveItem(ctx, transfer)
+	})
+	if err != nil {
+		if domainErrors.IsConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to resolve transfer: %w", err)
+	}
+
+	return transfer, nil
+}
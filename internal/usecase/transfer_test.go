@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockTransferRepository は譲渡リポジトリのモック
+type MockTransferRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransferRepository) Create(ctx context.Context, transfer *entity.Transfer) (*entity.Transfer, error) {
+	args := m.Called(ctx, transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) FindByID(ctx context.Context, id int64) (*entity.Transfer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) FindPendingByRecipient(ctx context.Context, userID string) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) Resolve(ctx context.Context, transfer *entity.Transfer) error {
+	args := m.Called(ctx, transfer)
+	return args.Error(0)
+}
+
+func (m *MockTransferRepository) FindByUser(ctx context.Context, userID string) ([]*entity.Transfer, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Transfer), args.Error(1)
+}
+
+func (m *MockTransferRepository) ReplaceUser(ctx context.Context, userID, pseudonym string) error {
+	args := m.Called(ctx, userID, pseudonym)
+	return args.Error(0)
+}
+
+func ownedItem(id int64, owner string) *entity.Item {
+	item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = id
+	item.OwnerID = owner
+	return item
+}
+
+func TestTransferUsecase_RequestTransfer(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       string
+		input       TransferInput
+		item        *entity.Item
+		history     []*entity.Transfer
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 所有者が譲渡を申請",
+			actor: "alice",
+			input: TransferInput{ToUser: "bob", Note: "誕生日プレゼント"},
+			item:  ownedItem(1, "alice"),
+		},
+		{
+			name:  "正常系: 所有者未設定のアイテム",
+			actor: "alice",
+			input: TransferInput{ToUser: "bob"},
+			item:  ownedItem(1, ""),
+		},
+		{
+			name:        "異常系: 所有者以外は申請できない",
+			actor:       "mallory",
+			input:       TransferInput{ToUser: "bob"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:        "異常系: 自分自身への譲渡",
+			actor:       "alice",
+			input:       TransferInput{ToUser: "alice"},
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 承諾待ちの譲渡がある",
+			actor:       "alice",
+			input:       TransferInput{ToUser: "bob"},
+			item:        ownedItem(1, "alice"),
+			history:     []*entity.Transfer{{ID: 9, ItemID: 1, Status: entity.TransferStatusPending}},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:        "異常系: アイテムが存在しない",
+			actor:       "alice",
+			input:       TransferInput{ToUser: "bob"},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			transferRepo := new(MockTransferRepository)
+			if tt.item != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+				transferRepo.On("FindByItemID", mock.Anything, int64(1)).Return(tt.history, nil).Maybe()
+			} else {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			}
+			if tt.expectedErr == nil {
+				transferRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transfer")).
+					Return(&entity.Transfer{ID: 1, ItemID: 1, FromUser: tt.actor, ToUser: tt.input.ToUser, Status: entity.TransferStatusPending}, nil)
+			}
+			usecase := NewTransferUsecase(itemRepo, transferRepo, nil)
+
+			transfer, err := usecase.RequestTransfer(context.Background(), tt.actor, 1, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, transfer)
+				transferRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.TransferStatusPending, transfer.Status)
+			}
+		})
+	}
+}
+
+func TestTransferUsecase_RequestBulkTransfer(t *testing.T) {
+	t.Run("異常系: 1件でも不正なら何も作成しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		transferRepo := new(MockTransferRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(ownedItem(2, "carol"), nil)
+		transferRepo.On("FindByItemID", mock.Anything, mock.Anything).Return([]*entity.Transfer{}, nil)
+		usecase := NewTransferUsecase(itemRepo, transferRepo, nil)
+
+		_, err := usecase.RequestBulkTransfer(context.Background(), "alice", BulkTransferInput{ItemIDs: []int64{1, 2}, ToUser: "bob"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		transferRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: まとめて申請", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		transferRepo := new(MockTransferRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(ownedItem(2, "alice"), nil)
+		transferRepo.On("FindByItemID", mock.Anything, mock.Anything).Return([]*entity.Transfer{}, nil)
+		transferRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Transfer")).
+			Return(&entity.Transfer{Status: entity.TransferStatusPending}, nil).Times(2)
+		usecase := NewTransferUsecase(itemRepo, transferRepo, nil)
+
+		transfers, err := usecase.RequestBulkTransfer(context.Background(), "alice", BulkTransferInput{ItemIDs: []int64{1, 2}, ToUser: "bob"})
+
+		require.NoError(t, err)
+		assert.Len(t, transfers, 2)
+		transferRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 重複したID", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		transferRepo := new(MockTransferRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		transferRepo.On("FindByItemID", mock.Anything, mock.Anything).Return([]*entity.Transfer{}, nil)
+		usecase := NewTransferUsecase(itemRepo, transferRepo, nil)
+
+		_, err := usecase.RequestBulkTransfer(context.Background(), "alice", BulkTransferInput{ItemIDs: []int64{1, 1}, ToUser: "bob"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestTransferUsecase_Resolve(t *testing.T) {
+	pending := func() *entity.Transfer {
+		return &entity.Transfer{ID: 5, ItemID: 1, FromUser: "alice", ToUser: "bob", Status: entity.TransferStatusPending}
+	}
+
+	tests := []struct {
+		name           string
+		actor          string
+		action         func(TransferUsecase, string) (*entity.Transfer, error)
+		stored         *entity.Transfer
+		expectedStatus string
+		expectedErr    error
+	}{
+		{
+			name:  "正常系: 受取人が承諾",
+			actor: "bob",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.AcceptTransfer(context.Background(), actor, 5)
+			},
+			stored:         pending(),
+			expectedStatus: entity.TransferStatusAccepted,
+		},
+		{
+			name:  "正常系: 受取人が拒否",
+			actor: "bob",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.RejectTransfer(context.Background(), actor, 5)
+			},
+			stored:         pending(),
+			expectedStatus: entity.TransferStatusRejected,
+		},
+		{
+			name:  "正常系: 送り主が取消",
+			actor: "alice",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.CancelTransfer(context.Background(), actor, 5)
+			},
+			stored:         pending(),
+			expectedStatus: entity.TransferStatusCancelled,
+		},
+		{
+			name:  "異常系: 送り主は承諾できない",
+			actor: "alice",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.AcceptTransfer(context.Background(), actor, 5)
+			},
+			stored:      pending(),
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:  "異常系: 確定済みの譲渡",
+			actor: "bob",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.AcceptTransfer(context.Background(), actor, 5)
+			},
+			stored:      &entity.Transfer{ID: 5, ItemID: 1, FromUser: "alice", ToUser: "bob", Status: entity.TransferStatusRejected},
+			expectedErr: domainErrors.ErrConflict,
+		},
+		{
+			name:  "異常系: 譲渡が存在しない",
+			actor: "bob",
+			action: func(u TransferUsecase, actor string) (*entity.Transfer, error) {
+				return u.AcceptTransfer(context.Background(), actor, 5)
+			},
+			expectedErr: domainErrors.ErrTransferNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transferRepo := new(MockTransferRepository)
+			if tt.stored != nil {
+				transferRepo.On("FindByID", mock.Anything, int64(5)).Return(tt.stored, nil)
+			} else {
+				transferRepo.On("FindByID", mock.Anything, int64(5)).Return(nil, domainErrors.ErrTransferNotFound)
+			}
+			if tt.expectedErr == nil {
+				transferRepo.On("Resolve", mock.Anything, tt.stored).Return(nil)
+			}
+			itemRepo := new(MockItemRepository)
+			if tt.expectedStatus == entity.TransferStatusAccepted {
+				// 承諾すると、アイテムリポジトリを通して所有者を受取人に変更する
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, OwnerID: "alice", Version: 2}, nil)
+				itemRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.ID == 1 && item.OwnerID == "bob" && item.Version == 2
+				})).Return(&entity.Item{ID: 1, OwnerID: "bob", Version: 3}, nil)
+			}
+			usecase := NewTransferUsecase(itemRepo, transferRepo, nil)
+
+			transfer, err := tt.action(usecase, tt.actor)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				transferRepo.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, transfer.Status)
+				assert.Equal(t, tt.actor, transfer.ResolvedBy)
+				assert.NotNil(t, transfer.ResolvedAt)
+				itemRepo.AssertExpectations(t)
+				if tt.expectedStatus != entity.TransferStatusAccepted {
+					itemRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				}
+			}
+		})
+	}
+}
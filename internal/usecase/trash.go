@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// DefaultTrashPageSize is the number of items per page of the trash when no page size is given
+const DefaultTrashPageSize = 20
+
+// TrashUsecase lists the deleted items, which stay in the trash until they are purged
+type TrashUsecase interface {
+	// ListTrash returns a page of the items in the trash, most recently deleted first
+	ListTrash(ctx context.Context, page, pageSize int) (*TrashList, error)
+
+	// PurgeItem permanently deletes an item in the trash along with its images and documents
+	PurgeItem(ctx context.Context, id int64) error
+}
+
+// TrashList is a page of the items in the trash
+type TrashList = Page[*entity.TrashedItem]
+
+type trashUsecase struct {
+	itemUsecase ItemUsecase
+	itemRepo    ItemReader
+}
+
+// NewTrashUsecase creates the trash usecase. Items are purged with itemUsecase.PurgeItem, so their images and
+// documents are deleted with them.
+func NewTrashUsecase(itemUsecase ItemUsecase, itemRepo ItemReader) TrashUsecase {
+	return &trashUsecase{
+		itemUsecase: itemUsecase,
+		itemRepo:    itemRepo,
+	}
+}
+
+func (u *trashUsecase) ListTrash(ctx context.Context, page, pageSize int) (*TrashList, error) {
+	return listPage(ReadOnly(ctx), page, pageSize, DefaultTrashPageSize, "trash",
+		func(ctx context.Context, limit, offset int) ([]*entity.TrashedItem, error) {
+			return u.itemRepo.FindTrashed(ctx, TrashQuery{Limit: limit, Offset: offset})
+		}, u.itemRepo.CountTrashed)
+}
+
+func (u *trashUsecase) PurgeItem(ctx context.Context, id int64) error {
+	return u.itemUsecase.PurgeItem(ctx, id)
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func trashedItem(id int64, deletedAt time.Time) *entity.TrashedItem {
+	return &entity.TrashedItem{Item: &entity.Item{ID: id, Name: "ロレックス"}, DeletedAt: deletedAt}
+}
+
+func TestTrashUsecase_ListTrash(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 指定がなければ1ページ目を既定の件数で返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashed", mock.Anything, TrashQuery{Limit: DefaultTrashPageSize}).Return([]*entity.TrashedItem{trashedItem(1, deletedAt)}, nil)
+		itemRepo.On("CountTrashed", mock.Anything).Return(1, nil)
+
+		list, err := NewTrashUsecase(nil, itemRepo).ListTrash(ctx, 0, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Page)
+		assert.Equal(t, DefaultTrashPageSize, list.PageSize)
+		assert.Equal(t, 1, list.Total)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, deletedAt, list.Items[0].DeletedAt)
+	})
+
+	t.Run("正常系: ページの位置から取得し、空なら空の配列", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashed", mock.Anything, TrashQuery{Limit: 10, Offset: 20}).Return(nil, nil)
+		itemRepo.On("CountTrashed", mock.Anything).Return(5, nil)
+
+		list, err := NewTrashUsecase(nil, itemRepo).ListTrash(ctx, 3, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.TrashedItem{}, list.Items)
+		assert.Equal(t, 5, list.Total)
+	})
+
+	t.Run("異常系: 不正なページ", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		usecase := NewTrashUsecase(nil, itemRepo)
+
+		_, err := usecase.ListTrash(ctx, -1, 0)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		_, err = usecase.ListTrash(ctx, 1, entity.MaxPageSize+1)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "FindTrashed", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_PurgeItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: ゴミ箱のアイテムを完全に削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, time.Now()), nil)
+		itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		uow := &fakeUnitOfWork{}
+
+		err := NewItemUsecase(itemRepo, nil, nil, nil, uow).PurgeItem(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, uow.calls)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: ゴミ箱にないアイテムは削除しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+
+		err := NewItemUsecase(itemRepo, nil, nil, nil, nil).PurgeItem(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		itemRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// UndoClaims is what an undo token grants: restoring one item from the trash, as long as it is still there
+// from the same deletion
+type UndoClaims struct {
+	ItemID    int64
+	DeletedAt time.Time
+}
+
+// UndoTokenSigner signs undo tokens so that they need not be stored
+type UndoTokenSigner interface {
+	Sign(claims UndoClaims) string
+	// Verify returns the claims of a token signed by Sign, whether or not its window has passed
+	Verify(token string) (UndoClaims, error)
+}
+
+// UndoDeleteUsecase deletes items with a token that restores them within a short window after the deletion,
+// e.g. for an "undo" button; after the window the item can still be found in the trash
+type UndoDeleteUsecase interface {
+	// DeleteItem moves an item to the trash like ItemUsecase.DeleteItem and returns a token for undoing it;
+	// the token is nil if undoing is disabled
+	DeleteItem(ctx context.Context, id int64, ifMatch *int64) (*UndoToken, error)
+	// UndoDelete restores the item a token was issued for and returns it
+	UndoDelete(ctx context.Context, token string) (*entity.Item, error)
+}
+
+type UndoToken struct {
+	Token     string    `json:"undo_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type undoDeleteUsecase struct {
+	itemUsecase ItemUsecase
+	itemRepo    ItemReader
+	signer      UndoTokenSigner
+	window      time.Duration
+	now         func() time.Time
+}
+
+// NewUndoDeleteUsecase creates the usecase; tokens are valid for window from the deletion, and 0 disables undoing.
+// Items are deleted and restored through itemUsecase, so the changes are recorded like other deletions.
+func NewUndoDeleteUsecase(itemUsecase ItemUsecase, itemRepo ItemReader, signer UndoTokenSigner, window time.Duration) UndoDeleteUsecase {
+	return &undoDeleteUsecase{
+		itemUsecase: itemUsecase,
+		itemRepo:    itemRepo,
+		signer:      signer,
+		window:      window,
+		now:         time.Now,
+	}
+}
+
+func (u *undoDeleteUsecase) DeleteItem(ctx context.Context, id int64, ifMatch *int64) (*UndoToken, error) {
+	if err := u.itemUsecase.DeleteItem(ctx, id, ifMatch); err != nil {
+		return nil, err
+	}
+	if u.window <= 0 {
+		return nil, nil
+	}
+
+	// The token is bound to this deletion, so it cannot restore the item if it is restored and deleted again
+	item, err := u.itemRepo.FindTrashedByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve deleted item: %w", err)
+	}
+	claims := UndoClaims{ItemID: id, DeletedAt: item.DeletedAt.UTC().Truncate(time.Second)}
+	return &UndoToken{
+		Token:     u.signer.Sign(claims),
+		ExpiresAt: claims.DeletedAt.Add(u.window),
+	}, nil
+}
+
+// UndoDelete reports invalid and expired tokens, and tokens whose item is no longer in the trash, as ErrUndoTokenNotFound
+func (u *undoDeleteUsecase) UndoDelete(ctx context.Context, token string) (*entity.Item, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: undo_token is required", domainErrors.ErrInvalidInput)
+	}
+	claims, err := u.signer.Verify(token)
+	if err != nil {
+		return nil, domainErrors.ErrUndoTokenNotFound
+	}
+	expiresAt := claims.DeletedAt.Add(u.window)
+	if !u.now().Before(expiresAt) {
+		return nil, domainErrors.NotFound(domainErrors.ErrUndoTokenNotFound, "expired at %s", expiresAt.UTC().Format(time.RFC3339))
+	}
+
+	if err := u.itemUsecase.RestoreItem(ctx, claims.ItemID, claims.DeletedAt); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.NotFound(domainErrors.ErrUndoTokenNotFound, "item %d is no longer in the trash", claims.ItemID)
+		}
+		return nil, err
+	}
+	return u.itemUsecase.GetItemByID(ctx, claims.ItemID)
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeUndoTokenSigner はトークンと内容の対応を覚えておく署名
+type fakeUndoTokenSigner struct {
+	issued map[string]UndoClaims
+}
+
+func (s *fakeUndoTokenSigner) Sign(claims UndoClaims) string {
+	token := fmt.Sprintf("token-%d-%d", claims.ItemID, claims.DeletedAt.Unix())
+	s.issued[token] = claims
+	return token
+}
+
+func (s *fakeUndoTokenSigner) Verify(token string) (UndoClaims, error) {
+	claims, ok := s.issued[token]
+	if !ok {
+		return UndoClaims{}, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+var undoDeletedAt = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestUndoDeleteUsecase(itemRepo ItemRepository, window time.Duration, now time.Time) (*undoDeleteUsecase, *fakeUndoTokenSigner) {
+	signer := &fakeUndoTokenSigner{issued: make(map[string]UndoClaims)}
+	u := NewUndoDeleteUsecase(NewItemUsecase(itemRepo, nil, nil, nil, nil), itemRepo, signer, window).(*undoDeleteUsecase)
+	u.now = func() time.Time { return now }
+	return u, signer
+}
+
+func TestUndoDeleteUsecase_DeleteItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 削除日時から取り消せる期間のトークンを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 1}, nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, undoDeletedAt), nil)
+		u, signer := newTestUndoDeleteUsecase(itemRepo, 30*time.Second, undoDeletedAt)
+
+		undo, err := u.DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, undo)
+		assert.Equal(t, undoDeletedAt.Add(30*time.Second), undo.ExpiresAt)
+		assert.Equal(t, UndoClaims{ItemID: 1, DeletedAt: undoDeletedAt}, signer.issued[undo.Token])
+	})
+
+	t.Run("正常系: 取り消しが無効ならトークンを返さない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Version: 1}, nil)
+		itemRepo.On("Trash", mock.Anything, int64(1), mock.Anything).Return(nil)
+		u, _ := newTestUndoDeleteUsecase(itemRepo, 0, undoDeletedAt)
+
+		undo, err := u.DeleteItem(ctx, 1, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, undo)
+		itemRepo.AssertNotCalled(t, "FindTrashedByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 削除できなければトークンを返さない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		u, _ := newTestUndoDeleteUsecase(itemRepo, 30*time.Second, undoDeletedAt)
+
+		_, err := u.DeleteItem(ctx, 1, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestUndoDeleteUsecase_UndoDelete(t *testing.T) {
+	ctx := context.Background()
+	window := 30 * time.Second
+
+	t.Run("正常系: 期間内ならゴミ箱から戻す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, undoDeletedAt), nil)
+		itemRepo.On("Restore", mock.Anything, int64(1)).Return(nil)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "ロレックス"}, nil)
+		u, signer := newTestUndoDeleteUsecase(itemRepo, window, undoDeletedAt.Add(10*time.Second))
+		token := signer.Sign(UndoClaims{ItemID: 1, DeletedAt: undoDeletedAt})
+
+		item, err := u.UndoDelete(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス", item.Name)
+		itemRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 期限切れ", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u, signer := newTestUndoDeleteUsecase(itemRepo, window, undoDeletedAt.Add(window))
+		token := signer.Sign(UndoClaims{ItemID: 1, DeletedAt: undoDeletedAt})
+
+		_, err := u.UndoDelete(ctx, token)
+
+		assert.ErrorIs(t, err, domainErrors.ErrUndoTokenNotFound)
+		itemRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 戻した後に再び削除された", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(trashedItem(1, undoDeletedAt.Add(5*time.Second)), nil)
+		u, signer := newTestUndoDeleteUsecase(itemRepo, window, undoDeletedAt.Add(10*time.Second))
+		token := signer.Sign(UndoClaims{ItemID: 1, DeletedAt: undoDeletedAt})
+
+		_, err := u.UndoDelete(ctx, token)
+
+		assert.ErrorIs(t, err, domainErrors.ErrUndoTokenNotFound)
+		itemRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 完全に削除された", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindTrashedByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		u, signer := newTestUndoDeleteUsecase(itemRepo, window, undoDeletedAt)
+		token := signer.Sign(UndoClaims{ItemID: 1, DeletedAt: undoDeletedAt})
+
+		_, err := u.UndoDelete(ctx, token)
+
+		assert.ErrorIs(t, err, domainErrors.ErrUndoTokenNotFound)
+	})
+
+	t.Run("異常系: 不正なトークン", func(t *testing.T) {
+		u, _ := newTestUndoDeleteUsecase(new(MockItemRepository), window, undoDeletedAt)
+
+		_, err := u.UndoDelete(ctx, "forged")
+		assert.ErrorIs(t, err, domainErrors.ErrUndoTokenNotFound)
+		_, err = u.UndoDelete(ctx, "")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
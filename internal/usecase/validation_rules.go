@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// PurchaseDateToday as ValidationRules.MaxPurchaseDate allows purchase dates up to the current date
+const PurchaseDateToday = "today"
+
+// ValidationRules are the business rules on item fields that a deployment tunes in its configuration.
+// They are checked when items are created and patched, after the format and length checks of the entity.
+// The zero value requires a brand and leaves purchase prices and dates unbounded.
+type ValidationRules struct {
+	// BrandOptional allows items without a brand
+	BrandOptional bool
+	// MaxPurchasePrice caps purchase prices in BaseCurrency; 0 leaves them uncapped.
+	// Prices in other currencies are not capped.
+	MaxPurchasePrice int
+	// MinPurchaseDate and MaxPurchaseDate are the earliest and latest purchase dates (YYYY-MM-DD, both inclusive);
+	// empty leaves that side open. MaxPurchaseDate may be PurchaseDateToday.
+	MinPurchaseDate string
+	MaxPurchaseDate string
+}
+
+// itemRuleFields are the fields that rules check, in the order violations are reported
+var itemRuleFields = []string{"brand", "purchase_price", "purchase_date"}
+
+// itemRule is one rule of a field; check returns the violation of item, or "" when the rule holds
+type itemRule struct {
+	field string
+	check func(item *entity.Item, now time.Time) string
+}
+
+// itemRules returns the rules that r turns on
+func (r ValidationRules) itemRules() []itemRule {
+	var rules []itemRule
+	if !r.BrandOptional {
+		rules = append(rules, itemRule{field: "brand", check: func(item *entity.Item, _ time.Time) string {
+			if item.Brand == "" {
+				return "brand is required"
+			}
+			return ""
+		}})
+	}
+	if r.MaxPurchasePrice > 0 {
+		rules = append(rules, itemRule{field: "purchase_price", check: func(item *entity.Item, _ time.Time) string {
+			if item.Price().Currency == BaseCurrency && item.PurchasePrice > r.MaxPurchasePrice {
+				return fmt.Sprintf("purchase_price must be %d or less", r.MaxPurchasePrice)
+			}
+			return ""
+		}})
+	}
+	// Dates in YYYY-MM-DD format compare as strings
+	if r.MinPurchaseDate != "" {
+		rules = append(rules, itemRule{field: "purchase_date", check: func(item *entity.Item, _ time.Time) string {
+			if item.PurchaseDate < r.MinPurchaseDate {
+				return fmt.Sprintf("purchase_date must be on or after %s", r.MinPurchaseDate)
+			}
+			return ""
+		}})
+	}
+	if r.MaxPurchaseDate != "" {
+		rules = append(rules, itemRule{field: "purchase_date", check: func(item *entity.Item, now time.Time) string {
+			latest := r.MaxPurchaseDate
+			if latest == PurchaseDateToday {
+				latest = now.Format("2006-01-02")
+			}
+			if item.PurchaseDate > latest {
+				return fmt.Sprintf("purchase_date must be on or before %s", latest)
+			}
+			return ""
+		}})
+	}
+	return rules
+}
+
+// check returns the violations of the rules on the given fields, at most one per field
+func (r ValidationRules) check(item *entity.Item, now time.Time, fields ...string) []string {
+	var violations []string
+	violated := make(map[string]bool)
+	for _, rule := range r.itemRules() {
+		if violated[rule.field] || !slices.Contains(fields, rule.field) {
+			continue
+		}
+		if violation := rule.check(item, now); violation != "" {
+			violated[rule.field] = true
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
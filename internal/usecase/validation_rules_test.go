@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestValidationRules_Check(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	valid := entity.Item{Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+
+	tests := []struct {
+		name     string
+		rules    ValidationRules
+		modify   func(*entity.Item)
+		fields   []string
+		expected []string
+	}{
+		{
+			name:     "正常系: 既定のルールではブランドが必須",
+			modify:   func(item *entity.Item) { item.Brand = "" },
+			fields:   itemRuleFields,
+			expected: []string{"brand is required"},
+		},
+		{
+			name:   "正常系: ブランドを任意にする",
+			rules:  ValidationRules{BrandOptional: true},
+			modify: func(item *entity.Item) { item.Brand = "" },
+			fields: itemRuleFields,
+		},
+		{
+			name:     "正常系: 購入価格の上限",
+			rules:    ValidationRules{MaxPurchasePrice: 1000000},
+			modify:   func(item *entity.Item) {},
+			fields:   itemRuleFields,
+			expected: []string{"purchase_price must be 1000000 or less"},
+		},
+		{
+			name:   "正常系: ほかの通貨の価格には上限を適用しない",
+			rules:  ValidationRules{MaxPurchasePrice: 1000000},
+			modify: func(item *entity.Item) { item.Currency = "USD" },
+			fields: itemRuleFields,
+		},
+		{
+			name:     "正常系: 購入日の範囲（下限）",
+			rules:    ValidationRules{MinPurchaseDate: "2024-01-01", MaxPurchaseDate: PurchaseDateToday},
+			modify:   func(item *entity.Item) {},
+			fields:   itemRuleFields,
+			expected: []string{"purchase_date must be on or after 2024-01-01"},
+		},
+		{
+			name:     "正常系: 購入日の範囲（今日まで）",
+			rules:    ValidationRules{MinPurchaseDate: "2024-01-01", MaxPurchaseDate: PurchaseDateToday},
+			modify:   func(item *entity.Item) { item.PurchaseDate = "2024-07-01" },
+			fields:   itemRuleFields,
+			expected: []string{"purchase_date must be on or before 2024-06-30"},
+		},
+		{
+			name:   "正常系: 範囲の境界は含む",
+			rules:  ValidationRules{MaxPurchasePrice: 1500000, MinPurchaseDate: "2023-01-15", MaxPurchaseDate: "2023-01-15"},
+			modify: func(item *entity.Item) {},
+			fields: itemRuleFields,
+		},
+		{
+			name:     "正常系: 指定したフィールドのルールだけを確かめる",
+			rules:    ValidationRules{MaxPurchasePrice: 1000000},
+			modify:   func(item *entity.Item) { item.Brand = "" },
+			fields:   []string{"purchase_price"},
+			expected: []string{"purchase_price must be 1000000 or less"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := valid
+			tt.modify(&item)
+
+			assert.Equal(t, tt.expected, tt.rules.check(&item, now, tt.fields...))
+		})
+	}
+}
+
+func TestItemUsecase_ValidationRules(t *testing.T) {
+	rules := ValidationRules{BrandOptional: true, MaxPurchasePrice: 1000000, MinPurchaseDate: "2000-01-01"}
+
+	t.Run("正常系: ブランドのないアイテムを登録する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		u := NewItemUsecaseWithRules(itemRepo, nil, nil, nil, nil, rules)
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "懐中時計", Category: "時計", PurchasePrice: entity.MinorUnits(1), PurchaseDate: "2023-01-15"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: 既定のルールではブランドのないアイテムを登録できない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u := NewItemUsecase(itemRepo, nil, nil, nil, nil)
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "懐中時計", Category: "時計", PurchasePrice: entity.MinorUnits(1), PurchaseDate: "2023-01-15"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "invalid input: brand is required")
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 上限を超える価格・範囲外の購入日では登録できない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		u := NewItemUsecaseWithRules(itemRepo, nil, nil, nil, nil, rules)
+
+		_, err := u.CreateItem(context.Background(), CreateItemInput{Name: "懐中時計", Category: "時計", PurchasePrice: entity.MinorUnits(2000000), PurchaseDate: "1999-12-31"})
+
+		assert.EqualError(t, err, "invalid input: purchase_price must be 1000000 or less, purchase_date must be on or after 2000-01-01")
+	})
+
+	t.Run("正常系: 更新では変更したフィールドのルールだけを確かめる", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		// ルールより前に登録された、上限を超える価格のアイテム
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "懐中時計", Category: "時計", Brand: "SEIKO", PurchasePrice: 2000000, PurchaseDate: "2023-01-15", Version: 1}, nil)
+		itemRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Version: 2}, nil)
+		u := NewItemUsecaseWithRules(itemRepo, nil, nil, nil, nil, rules)
+		name, brand, price, version := "懐中時計（銀）", "", entity.MinorUnits(1500000), int64(1)
+
+		_, err := u.PatchItem(context.Background(), 1, &UpdateItemRequest{Name: &name, Brand: &brand, Version: &version})
+		require.NoError(t, err)
+
+		_, err = u.PatchItem(context.Background(), 1, &UpdateItemRequest{PurchasePrice: &price, Version: &version})
+		assert.EqualError(t, err, "invalid input: purchase_price must be 1000000 or less")
+	})
+}
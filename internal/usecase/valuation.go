@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MarketQuote is the market value of an item according to a price provider
+type MarketQuote struct {
+	Value      int
+	Currency   string
+	Source     string
+	SampleSize int
+	QuotedAt   time.Time
+}
+
+// MarketPriceProvider looks up the market value of items from an external price source
+type MarketPriceProvider interface {
+	// Quote returns the market value of the item. Returns ErrMarketPriceNotFound if the source has no price for it,
+	// and ErrPriceProviderUnavailable (ErrPriceProviderTimeout if it did not answer in time) if it cannot be used.
+	Quote(ctx context.Context, item *entity.Item) (*MarketQuote, error)
+}
+
+type ValuationUsecase interface {
+	// RefreshMarketValue fetches the current market value of an item and records it as a valuation
+	RefreshMarketValue(ctx context.Context, actor string, itemID int64) (*entity.Valuation, error)
+	ListValuations(ctx context.Context, itemID int64) ([]*entity.Valuation, error)
+}
+
+type valuationUsecase struct {
+	itemRepo      ItemReader
+	valuationRepo ValuationRepository
+	provider      MarketPriceProvider
+	now           func() time.Time
+}
+
+func NewValuationUsecase(itemRepo ItemReader, valuationRepo ValuationRepository, provider MarketPriceProvider) ValuationUsecase {
+	return &valuationUsecase{
+		itemRepo:      itemRepo,
+		valuationRepo: valuationRepo,
+		provider:      provider,
+		now:           time.Now,
+	}
+}
+
+// RefreshMarketValue runs outside a transaction so that no row stays locked while the provider is queried.
+// Items without an owner (created before ownership was tracked) may be valued by any user.
+func (u *valuationUsecase) RefreshMarketValue(ctx context.Context, actor string, itemID int64) (*entity.Valuation, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if item.OwnerID != "" && item.OwnerID != actor {
+		return nil, domainErrors.PermissionDenied("item %d is not owned by %s", itemID, actor)
+	}
+
+	quote, err := u.provider.Quote(ctx, item)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsPriceProviderError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrPriceProviderUnavailable, err)
+	}
+
+	valuation := &entity.Valuation{
+		ItemID:     itemID,
+		Value:      quote.Value,
+		Currency:   quote.Currency,
+		Source:     quote.Source,
+		SampleSize: quote.SampleSize,
+		QuotedAt:   quote.QuotedAt,
+		CreatedAt:  u.now(),
+	}
+	if valuation.QuotedAt.IsZero() {
+		valuation.QuotedAt = valuation.CreatedAt
+	}
+
+	created, err := u.valuationRepo.Create(ctx, valuation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create valuation: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *valuationUsecase) ListValuations(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	ctx = ReadOnly(ctx)
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	valuations, err := u.valuationRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+
+	if valuations == nil {
+		valuations = []*entity.Valuation{}
+	}
+
+	return valuations, nil
+}
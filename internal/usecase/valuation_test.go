@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockValuationRepository は評価リポジトリのモック
+type MockValuationRepository struct {
+	mock.Mock
+}
+
+func (m *MockValuationRepository) Create(ctx context.Context, valuation *entity.Valuation) (*entity.Valuation, error) {
+	args := m.Called(ctx, valuation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Valuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Valuation, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Valuation), args.Error(1)
+}
+
+func (m *MockValuationRepository) FindLatestValues(ctx context.Context) (map[int64]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]int), args.Error(1)
+}
+
+// MockMarketPriceProvider は相場の提供元のモック
+type MockMarketPriceProvider struct {
+	mock.Mock
+}
+
+func (m *MockMarketPriceProvider) Quote(ctx context.Context, item *entity.Item) (*MarketQuote, error) {
+	args := m.Called(ctx, item)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MarketQuote), args.Error(1)
+}
+
+var valuationNow = time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestValuationUsecase(itemRepo ItemRepository, valuationRepo ValuationRepository, provider MarketPriceProvider) ValuationUsecase {
+	u := NewValuationUsecase(itemRepo, valuationRepo, provider).(*valuationUsecase)
+	u.now = func() time.Time { return valuationNow }
+	return u
+}
+
+func TestValuationUsecase_RefreshMarketValue(t *testing.T) {
+	quotedAt := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		actor          string
+		item           *entity.Item
+		quote          *MarketQuote
+		providerErr    error
+		expectedErr    error
+		expectedQuoted time.Time
+	}{
+		{
+			name:           "正常系: 所有者が時価を更新",
+			actor:          "alice",
+			item:           ownedItem(1, "alice"),
+			quote:          &MarketQuote{Value: 1250000, Currency: "JPY", Source: "chrono24", SampleSize: 42, QuotedAt: quotedAt},
+			expectedQuoted: quotedAt,
+		},
+		{
+			name:           "正常系: 算出日時がなければ取得日時を使う",
+			actor:          "bob",
+			item:           ownedItem(1, ""),
+			quote:          &MarketQuote{Value: 300000, Currency: "JPY", Source: "chrono24"},
+			expectedQuoted: valuationNow,
+		},
+		{
+			name:        "異常系: 所有者以外は更新できない",
+			actor:       "mallory",
+			item:        ownedItem(1, "alice"),
+			expectedErr: domainErrors.ErrForbidden,
+		},
+		{
+			name:        "異常系: アイテムが存在しない",
+			actor:       "alice",
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:        "異常系: 相場が見つからない",
+			actor:       "alice",
+			item:        ownedItem(1, "alice"),
+			providerErr: domainErrors.ErrMarketPriceNotFound,
+			expectedErr: domainErrors.ErrMarketPriceNotFound,
+		},
+		{
+			name:        "異常系: 提供元がタイムアウト",
+			actor:       "alice",
+			item:        ownedItem(1, "alice"),
+			providerErr: domainErrors.ErrPriceProviderTimeout,
+			expectedErr: domainErrors.ErrPriceProviderTimeout,
+		},
+		{
+			name:        "異常系: 想定外のエラーは提供元の障害として扱う",
+			actor:       "alice",
+			item:        ownedItem(1, "alice"),
+			providerErr: errors.New("unexpected EOF"),
+			expectedErr: domainErrors.ErrPriceProviderUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemRepo := new(MockItemRepository)
+			valuationRepo := new(MockValuationRepository)
+			provider := new(MockMarketPriceProvider)
+			if tt.item != nil {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(tt.item, nil)
+			} else {
+				itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+			}
+			if tt.quote != nil {
+				provider.On("Quote", mock.Anything, tt.item).Return(tt.quote, nil)
+			} else {
+				provider.On("Quote", mock.Anything, tt.item).Return(nil, tt.providerErr)
+			}
+			var stored *entity.Valuation
+			valuationRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Valuation")).
+				Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.Valuation) }).
+				Return(&entity.Valuation{ID: 1, ItemID: 1}, nil)
+
+			valuation, err := newTestValuationUsecase(itemRepo, valuationRepo, provider).RefreshMarketValue(context.Background(), tt.actor, 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, valuation)
+				valuationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), valuation.ID)
+			assert.Equal(t, int64(1), stored.ItemID)
+			assert.Equal(t, tt.quote.Value, stored.Value)
+			assert.Equal(t, tt.quote.Currency, stored.Currency)
+			assert.Equal(t, tt.quote.Source, stored.Source)
+			assert.Equal(t, tt.quote.SampleSize, stored.SampleSize)
+			assert.Equal(t, tt.expectedQuoted, stored.QuotedAt)
+			assert.Equal(t, valuationNow, stored.CreatedAt)
+		})
+	}
+
+	t.Run("異常系: 不正なID", func(t *testing.T) {
+		provider := new(MockMarketPriceProvider)
+
+		valuation, err := newTestValuationUsecase(new(MockItemRepository), new(MockValuationRepository), provider).RefreshMarketValue(context.Background(), "alice", 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, valuation)
+		provider.AssertNotCalled(t, "Quote", mock.Anything, mock.Anything)
+	})
+}
+
+func TestValuationUsecase_ListValuations(t *testing.T) {
+	t.Run("正常系: 評価がなければ空のリスト", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(ownedItem(1, "alice"), nil)
+		valuationRepo := new(MockValuationRepository)
+		valuationRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, nil)
+
+		valuations, err := newTestValuationUsecase(itemRepo, valuationRepo, nil).ListValuations(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.NotNil(t, valuations)
+		assert.Empty(t, valuations)
+	})
+
+	t.Run("異常系: アイテムが存在しない", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		valuationRepo := new(MockValuationRepository)
+
+		valuations, err := newTestValuationUsecase(itemRepo, valuationRepo, nil).ListValuations(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Nil(t, valuations)
+		valuationRepo.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// webhookSecretPrefix marks signing keys so they are recognizable when leaked into logs or code
+const webhookSecretPrefix = "whsec_"
+
+// deliveryLogLimit is the number of latest deliveries returned by ListDeliveries
+const deliveryLogLimit = 100
+
+type WebhookUsecase interface {
+	CreateWebhook(ctx context.Context, input WebhookInput) (*entity.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error)
+}
+
+type WebhookInput struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type webhookUsecase struct {
+	webhookRepo WebhookRepository
+}
+
+func NewWebhookUsecase(webhookRepo WebhookRepository) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+// CreateWebhook registers a webhook with a newly generated signing secret.
+// The returned webhook is the only one carrying the secret; it cannot be retrieved afterwards.
+func (u *webhookUsecase) CreateWebhook(ctx context.Context, input WebhookInput) (*entity.Webhook, error) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := entity.NewWebhook(input.URL, input.Events, secret)
+	if err != nil {
+		return nil, domainErrors.Invalid(err)
+	}
+
+	created, err := u.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *webhookUsecase) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := u.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	if webhooks == nil {
+		webhooks = []*entity.Webhook{}
+	}
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (u *webhookUsecase) GetWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	webhook, err := u.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook; pending deliveries to it are dropped along with its delivery log
+func (u *webhookUsecase) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := u.webhookRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of a webhook, newest first
+func (u *webhookUsecase) ListDeliveries(ctx context.Context, webhookID int64) ([]*entity.WebhookDelivery, error) {
+	if _, err := u.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.webhookRepo.FindDeliveries(ctx, webhookID, deliveryLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+
+	if deliveries == nil {
+		deliveries = []*entity.WebhookDelivery{}
+	}
+
+	return deliveries, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MockWebhookRepository は Webhook リポジトリのモック
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	args := m.Called(ctx, webhook)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDelivery(ctx context.Context, delivery *entity.WebhookDelivery, now, until time.Time) (bool, error) {
+	args := m.Called(ctx, delivery, now, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       WebhookInput
+		expectedErr string
+	}{
+		{
+			name:  "正常系: イベントを指定して登録",
+			input: WebhookInput{URL: "https://example.com/hooks", Events: []string{"item.created", "item.deleted"}},
+		},
+		{
+			name:  "正常系: イベント未指定はすべて購読",
+			input: WebhookInput{URL: "http://hooks.example.com:9000/hooks"},
+		},
+		{
+			name:        "異常系: ループバックのホスト名",
+			input:       WebhookInput{URL: "http://localhost:9000/hooks"},
+			expectedErr: "url must not point to a private network",
+		},
+		{
+			name:        "異常系: クラウドのメタデータ（リンクローカル）",
+			input:       WebhookInput{URL: "http://169.254.169.254/latest/meta-data/"},
+			expectedErr: "url must not point to a private network",
+		},
+		{
+			name:        "異常系: プライベートアドレス",
+			input:       WebhookInput{URL: "https://10.0.0.5/hooks"},
+			expectedErr: "url must not point to a private network",
+		},
+		{
+			name:        "異常系: IPv6のループバック",
+			input:       WebhookInput{URL: "http://[::1]:8080/hooks"},
+			expectedErr: "url must not point to a private network",
+		},
+		{
+			name:        "異常系: URLが空",
+			input:       WebhookInput{URL: " "},
+			expectedErr: "url is required",
+		},
+		{
+			name:        "異常系: http(s)以外のURL",
+			input:       WebhookInput{URL: "ftp://example.com/hooks"},
+			expectedErr: "url must start with http:// or https://",
+		},
+		{
+			name:        "異常系: 未知のイベント",
+			input:       WebhookInput{URL: "https://example.com/hooks", Events: []string{"item.archived"}},
+			expectedErr: "events must be one of item.created, item.updated, item.deleted, item.expiring",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepository)
+			var stored *entity.Webhook
+			if tt.expectedErr == "" {
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Webhook")).
+					Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.Webhook) }).
+					Return(&entity.Webhook{ID: 1}, nil)
+			}
+			usecase := NewWebhookUsecase(repo)
+
+			webhook, err := usecase.CreateWebhook(context.Background(), tt.input)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), webhook.ID)
+			assert.Equal(t, tt.input.URL, stored.URL)
+			assert.True(t, strings.HasPrefix(stored.Secret, webhookSecretPrefix))
+			assert.Len(t, stored.Secret, len(webhookSecretPrefix)+48)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookUsecase_ListWebhooks(t *testing.T) {
+	repo := new(MockWebhookRepository)
+	repo.On("FindAll", mock.Anything).Return([]*entity.Webhook{
+		{ID: 1, URL: "https://example.com/a", Secret: "whsec_a"},
+		{ID: 2, URL: "https://example.com/b", Secret: "whsec_b"},
+	}, nil)
+	usecase := NewWebhookUsecase(repo)
+
+	webhooks, err := usecase.ListWebhooks(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	for _, w := range webhooks {
+		assert.Empty(t, w.Secret, "一覧には署名の鍵を含めない")
+	}
+}
+
+func TestWebhookUsecase_ListDeliveries(t *testing.T) {
+	tests := []struct {
+		name        string
+		webhookErr  error
+		deliveries  []*entity.WebhookDelivery
+		expectedErr error
+		expectedLen int
+	}{
+		{
+			name:        "正常系: 配信ログを返す",
+			deliveries:  []*entity.WebhookDelivery{{ID: 2}, {ID: 1}},
+			expectedLen: 2,
+		},
+		{
+			name:        "正常系: 配信がなければ空の配列",
+			expectedLen: 0,
+		},
+		{
+			name:        "異常系: Webhookが存在しない",
+			webhookErr:  domainErrors.ErrWebhookNotFound,
+			expectedErr: domainErrors.ErrWebhookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockWebhookRepository)
+			if tt.webhookErr != nil {
+				repo.On("FindByID", mock.Anything, int64(1)).Return(nil, tt.webhookErr)
+			} else {
+				repo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Webhook{ID: 1}, nil)
+				repo.On("FindDeliveries", mock.Anything, int64(1), deliveryLogLimit).Return(tt.deliveries, nil)
+			}
+			usecase := NewWebhookUsecase(repo)
+
+			deliveries, err := usecase.ListDeliveries(context.Background(), 1)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, deliveries)
+			assert.Len(t, deliveries, tt.expectedLen)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookUsecase_DeleteWebhook(t *testing.T) {
+	repo := new(MockWebhookRepository)
+	repo.On("Delete", mock.Anything, int64(9)).Return(domainErrors.ErrWebhookNotFound)
+	usecase := NewWebhookUsecase(repo)
+
+	err := usecase.DeleteWebhook(context.Background(), 9)
+
+	assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+}